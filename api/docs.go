@@ -153,6 +153,52 @@ var doc = `{
                 }
             }
         },
+        "/api/v1/changefeeds/precheck": {
+            "post": {
+                "description": "check whether a changefeed can be created without creating it, the sink, filter rules, tables and GC safepoint are checked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Precheck changefeed",
+                "parameters": [
+                    {
+                        "description": "changefeed config",
+                        "name": "changefeed",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangefeedConfig"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangefeedPrecheckReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/changefeeds/{changefeed_id}": {
             "get": {
                 "description": "get detail information of a changefeed",
@@ -197,7 +243,7 @@ var doc = `{
                 }
             },
             "put": {
-                "description": "Update a changefeed",
+                "description": "update a changefeed, a running changefeed is paused, updated and resumed from the checkpoint with the new config, the warnings in the result tell the data skipped because of the update",
                 "consumes": [
                     "application/json"
                 ],
@@ -270,7 +316,10 @@ var doc = `{
                 ],
                 "responses": {
                     "202": {
-                        "description": ""
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangefeedUpdateResult"
+                        }
                     },
                     "400": {
                         "description": "Internal Server Error",
@@ -326,9 +375,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/changefeeds/{changefeed_id}/pause": {
-            "post": {
-                "description": "Pause a changefeed",
+        "/api/v1/changefeeds/{changefeed_id}/checksum": {
+            "get": {
+                "description": "get the result of the last consistency check of a changefeed",
                 "consumes": [
                     "application/json"
                 ],
@@ -338,7 +387,7 @@ var doc = `{
                 "tags": [
                     "changefeed"
                 ],
-                "summary": "Pause a changefeed",
+                "summary": "Get changefeed checksum status",
                 "parameters": [
                     {
                         "type": "string",
@@ -349,8 +398,11 @@ var doc = `{
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": ""
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangefeedChecksumStatus"
+                        }
                     },
                     "400": {
                         "description": "Internal Server Error",
@@ -367,9 +419,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/changefeeds/{changefeed_id}/resume": {
+        "/api/v1/changefeeds/{changefeed_id}/pause": {
             "post": {
-                "description": "Resume a changefeed",
+                "description": "Pause a changefeed",
                 "consumes": [
                     "application/json"
                 ],
@@ -379,12 +431,12 @@ var doc = `{
                 "tags": [
                     "changefeed"
                 ],
-                "summary": "Resume a changefeed",
+                "summary": "Pause a changefeed",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "changefeed_id",
-                        "name": "changefeed-id",
+                        "name": "changefeed_id",
                         "in": "path",
                         "required": true
                     }
@@ -408,9 +460,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/changefeeds/{changefeed_id}/tables/move_table": {
+        "/api/v1/changefeeds/{changefeed_id}/resume": {
             "post": {
-                "description": "move one table to the target capture",
+                "description": "Resume a changefeed, a changefeed quarantined for blocking the GC can only be resumed with an overwrite_checkpoint_ts greater than its quarantined checkpoint ts",
                 "consumes": [
                     "application/json"
                 ],
@@ -420,32 +472,20 @@ var doc = `{
                 "tags": [
                     "changefeed"
                 ],
-                "summary": "move table",
+                "summary": "Resume a changefeed",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "changefeed_id",
-                        "name": "changefeed_id",
+                        "name": "changefeed-id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "table_id",
-                        "name": "table_id",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
-                    {
-                        "description": "capture_id",
-                        "name": "capture_id",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "integer",
+                        "description": "the checkpoint ts a quarantined changefeed is resumed from",
+                        "name": "overwrite_checkpoint_ts",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -467,9 +507,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/changefeeds/{changefeed_id}/tables/rebalance_table": {
-            "post": {
-                "description": "rebalance all tables of a changefeed",
+        "/api/v1/changefeeds/{changefeed_id}/slo": {
+            "get": {
+                "description": "get the replication lag SLO status of a changefeed",
                 "consumes": [
                     "application/json"
                 ],
@@ -479,7 +519,7 @@ var doc = `{
                 "tags": [
                     "changefeed"
                 ],
-                "summary": "rebalance tables",
+                "summary": "Get changefeed SLO status",
                 "parameters": [
                     {
                         "type": "string",
@@ -490,8 +530,11 @@ var doc = `{
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": ""
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangefeedSLOStatus"
+                        }
                     },
                     "400": {
                         "description": "Internal Server Error",
@@ -508,9 +551,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/health": {
+        "/api/v1/changefeeds/{changefeed_id}/syncpoints": {
             "get": {
-                "description": "check if CDC cluster is health",
+                "description": "get the syncpoints recorded in the downstream, which map the upstream ts to the downstream ts\nat which the downstream is consistent with the upstream, the latest syncpoint first",
                 "consumes": [
                     "application/json"
                 ],
@@ -518,12 +561,51 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "common"
+                    "changefeed"
+                ],
+                "summary": "Get changefeed syncpoints",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the minimum upstream ts of the syncpoints",
+                        "name": "start_ts",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the maximum upstream ts of the syncpoints",
+                        "name": "end_ts",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "the maximum number of syncpoints, default 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Check if CDC cluster is health",
                 "responses": {
                     "200": {
-                        "description": ""
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SyncpointRecord"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -534,9 +616,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/log": {
+        "/api/v1/changefeeds/{changefeed_id}/tables/add_tables": {
             "post": {
-                "description": "change TiCDC log level dynamically",
+                "description": "add the tables matched by the matcher to a running changefeed, the added tables start replicating from start_ts, and their existing rows at start_ts are written to the downstream first if bootstrap is true",
                 "consumes": [
                     "application/json"
                 ],
@@ -544,26 +626,58 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "common"
+                    "changefeed"
                 ],
-                "summary": "Change TiCDC log level",
+                "summary": "Add tables to a changefeed",
                 "parameters": [
                     {
-                        "description": "log level",
-                        "name": "log_level",
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "matcher",
+                        "name": "matcher",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "start_ts",
+                        "name": "start_ts",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "bootstrap",
+                        "name": "bootstrap",
+                        "in": "body",
+                        "schema": {
+                            "type": "boolean"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "202": {
                         "description": ""
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/model.HTTPError"
                         }
@@ -571,9 +685,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/owner/resign": {
+        "/api/v1/changefeeds/{changefeed_id}/tables/move_table": {
             "post": {
-                "description": "notify the current owner to resign",
+                "description": "move one table to the target capture",
                 "consumes": [
                     "application/json"
                 ],
@@ -581,9 +695,36 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "owner"
+                    "changefeed"
+                ],
+                "summary": "move table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "table_id",
+                        "name": "table_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "capture_id",
+                        "name": "capture_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 ],
-                "summary": "notify the owner to resign",
                 "responses": {
                     "202": {
                         "description": ""
@@ -603,9 +744,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/processors": {
-            "get": {
-                "description": "list all processors in the TiCDC cluster",
+        "/api/v1/changefeeds/{changefeed_id}/tables/pause_tables": {
+            "post": {
+                "description": "pause the replication of the tables of a running changefeed, the tables are replicated from the paused ts once resumed",
                 "consumes": [
                     "application/json"
                 ],
@@ -613,18 +754,33 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "processor"
+                    "changefeed"
                 ],
-                "summary": "List processors",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Pause tables of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "table_ids",
+                        "name": "table_ids",
+                        "in": "body",
+                        "required": true,
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.ProcessorCommonInfo"
+                                "type": "integer"
                             }
                         }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": ""
                     },
                     "400": {
                         "description": "Internal Server Error",
@@ -641,9 +797,9 @@ var doc = `{
                 }
             }
         },
-        "/api/v1/processors/{changefeed_id}/{capture_id}": {
-            "get": {
-                "description": "get the detail information of a processor",
+        "/api/v1/changefeeds/{changefeed_id}/tables/rebalance_table": {
+            "post": {
+                "description": "rebalance all tables of a changefeed",
                 "consumes": [
                     "application/json"
                 ],
@@ -651,11 +807,401 @@ var doc = `{
                     "application/json"
                 ],
                 "tags": [
-                    "processor"
+                    "changefeed"
+                ],
+                "summary": "rebalance tables",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get processor detail information",
                 "responses": {
-                    "200": {
+                    "202": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/changefeeds/{changefeed_id}/tables/remove_tables": {
+            "post": {
+                "description": "remove the tables matched by the matcher from a running changefeed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Remove tables from a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "matcher",
+                        "name": "matcher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/changefeeds/{changefeed_id}/tables/resume_tables": {
+            "post": {
+                "description": "resume the replication of the paused tables of a running changefeed, the tables are replicated again from the paused ts, and the checkpoint ts of the changefeed goes back to the earliest paused ts until they catch up",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Resume tables of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "table_ids",
+                        "name": "table_ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/changefeeds/{changefeed_id}/tables/rewind_table": {
+            "post": {
+                "description": "rewind the replication position of a table of a running changefeed to an earlier ts, the events of the table after the ts are emitted again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Rewind a table of a changefeed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "table_id",
+                        "name": "table_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "ts",
+                        "name": "ts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/changefeeds/{changefeed_id}/tables/{table_id}/status": {
+            "get": {
+                "description": "get the in-process status of a table pipeline, which is useful to debug stuck tables",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Get table pipeline status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "changefeed_id",
+                        "name": "changefeed_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "table_id",
+                        "name": "table_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TablePipelineStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/health": {
+            "get": {
+                "description": "check if CDC cluster is health",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common"
+                ],
+                "summary": "Check if CDC cluster is health",
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/log": {
+            "post": {
+                "description": "change TiCDC log level dynamically",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "common"
+                ],
+                "summary": "Change TiCDC log level",
+                "parameters": [
+                    {
+                        "description": "log level",
+                        "name": "log_level",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/owner/resign": {
+            "post": {
+                "description": "notify the current owner to resign",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "owner"
+                ],
+                "summary": "notify the owner to resign",
+                "responses": {
+                    "202": {
+                        "description": ""
+                    },
+                    "400": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/processors": {
+            "get": {
+                "description": "list all processors in the TiCDC cluster",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processor"
+                ],
+                "summary": "List processors",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ProcessorCommonInfo"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/processors/{changefeed_id}/{capture_id}": {
+            "get": {
+                "description": "get the detail information of a processor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "processor"
+                ],
+                "summary": "Get processor detail information",
+                "responses": {
+                    "200": {
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/model.ProcessorDetail"
@@ -676,6 +1222,35 @@ var doc = `{
                 }
             }
         },
+        "/api/v1/reconcile": {
+            "get": {
+                "description": "get the status of the declarative changefeed reconciliation, including the drift between the changefeeds and their specs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changefeed"
+                ],
+                "summary": "Get the declarative changefeed reconciliation status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReconcileStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/status": {
             "get": {
                 "description": "get the status of a server(capture)",
@@ -752,6 +1327,12 @@ var doc = `{
                 },
                 "is_owner": {
                     "type": "boolean"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -776,6 +1357,42 @@ var doc = `{
                 }
             }
         },
+        "model.ChangefeedChecksumStatus": {
+            "type": "object",
+            "properties": {
+                "check_time": {
+                    "type": "string"
+                },
+                "checked_tables": {
+                    "type": "integer"
+                },
+                "checked_ts": {
+                    "description": "The upstream ts of the syncpoint checked last time, 0 means no check is finished.",
+                    "type": "integer"
+                },
+                "downstream_ts": {
+                    "description": "The downstream ts of the syncpoint checked last time.",
+                    "type": "integer"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "The error of the last check, the tables are not fully checked if it is not empty.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mismatched_tables": {
+                    "description": "The tables whose checksums mismatch, which are empty if the upstream and\ndownstream are consistent.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ChecksumMismatch"
+                    }
+                }
+            }
+        },
         "model.ChangefeedCommonInfo": {
             "type": "object",
             "properties": {
@@ -799,9 +1416,20 @@ var doc = `{
         "model.ChangefeedConfig": {
             "type": "object",
             "properties": {
+                "backup_meta_path": {
+                    "description": "the path of a BR backupmeta file or the backup directory on the cdc\nserver, the end ts of the backup is used as the start ts",
+                    "type": "string"
+                },
                 "changefeed_id": {
                     "type": "string"
                 },
+                "features": {
+                    "description": "the feature flags, the features not specified are left unchanged",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
                 "filter_rules": {
                     "type": "array",
                     "items": {
@@ -827,12 +1455,24 @@ var doc = `{
                     "type": "integer",
                     "default": 16
                 },
+                "no_key_table_strategy": {
+                    "description": "the strategy of replicating the tables without keys, all-columns or row-id",
+                    "type": "string"
+                },
                 "sink_config": {
                     "$ref": "#/definitions/config.SinkConfig"
                 },
                 "sink_uri": {
                     "type": "string"
                 },
+                "standby_sink_uri": {
+                    "description": "the sink which the changefeed fails over to when the sink is unhealthy",
+                    "type": "string"
+                },
+                "start_time": {
+                    "description": "the start time in the format of \"2006-01-02 15:04:05\" in the timezone,\nwhich is resolved to the start ts",
+                    "type": "string"
+                },
                 "start_ts": {
                     "type": "integer"
                 },
@@ -843,6 +1483,9 @@ var doc = `{
                     "description": "timezone used when checking sink uri",
                     "type": "string",
                     "default": "system"
+                },
+                "upstream": {
+                    "$ref": "#/definitions/model.UpstreamInfo"
                 }
             }
         },
@@ -870,15 +1513,40 @@ var doc = `{
                         "type": "integer"
                     }
                 },
+                "features": {
+                    "description": "the features enabled for the changefeed",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
+                "paused_tables": {
+                    "description": "the paused tables mapped to the ts they are paused at",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "sink_switchovers": {
+                    "description": "the recent failovers of the sink, the latest last",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SinkSwitchover"
+                    }
+                },
                 "sink_uri": {
                     "type": "string"
                 },
                 "sort_engine": {
                     "type": "string"
                 },
+                "standby_sink_uri": {
+                    "description": "the sink which the changefeed fails over to when the sink is unhealthy",
+                    "type": "string"
+                },
                 "start_ts": {
                     "type": "integer"
                 },
@@ -893,6 +1561,177 @@ var doc = `{
                     "items": {
                         "$ref": "#/definitions/model.CaptureTaskStatus"
                     }
+                },
+                "update_history": {
+                    "description": "the recent updates of the config, the latest last",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ChangefeedUpdate"
+                    }
+                },
+                "upstream": {
+                    "$ref": "#/definitions/model.UpstreamInfo"
+                }
+            }
+        },
+        "model.ChangefeedPrecheckReport": {
+            "type": "object",
+            "properties": {
+                "changefeed_id": {
+                    "type": "string"
+                },
+                "eligible_table_count": {
+                    "description": "the number of tables to be replicated, partitions are counted separately",
+                    "type": "integer"
+                },
+                "gc_safe_point": {
+                    "description": "the minimum service GC safepoint of the upstream cluster",
+                    "type": "integer"
+                },
+                "gc_safe_point_margin": {
+                    "description": "the duration in seconds from the GC safepoint to the start ts",
+                    "type": "number"
+                },
+                "ineligible_tables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TableName"
+                    }
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PrecheckItem"
+                    }
+                },
+                "passed": {
+                    "description": "true if all the precheck items are passed",
+                    "type": "boolean"
+                },
+                "region_count": {
+                    "description": "the number of regions of the tables to be replicated",
+                    "type": "integer"
+                },
+                "start_ts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ChangefeedReconcileStatus": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "the action taken by the last reconciliation, such as \"create\", \"pause\", \"update\", \"resume\" and \"remove\"",
+                    "type": "string"
+                },
+                "changefeed_id": {
+                    "type": "string"
+                },
+                "drift_fields": {
+                    "description": "the fields of the changefeed which are different from the spec",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "spec": {
+                    "description": "the file or the etcd key of the spec, it is empty if the changefeed has no spec",
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ChangefeedSLOStatus": {
+            "type": "object",
+            "properties": {
+                "checkpoint_lag": {
+                    "description": "The checkpoint lag in seconds when the SLO is evaluated last time.",
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_checkpoint_lag": {
+                    "description": "The maximum checkpoint lag in seconds, 0 means the SLO is disabled.",
+                    "type": "integer"
+                },
+                "violated": {
+                    "type": "boolean"
+                },
+                "violated_since": {
+                    "description": "The time since when the SLO is violated, it is nil if the SLO is met.",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ChangefeedUpdate": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "checkpoint-ts": {
+                    "type": "integer"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ChangefeedUpdateResult": {
+            "type": "object",
+            "properties": {
+                "changefeed_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "the paths of the changed fields of the changefeed info",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "warnings": {
+                    "description": "the data which is skipped because of the update, such as the changes\nof the tables which are filtered out after the checkpoint ts",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ChecksumMismatch": {
+            "type": "object",
+            "properties": {
+                "chunks": {
+                    "description": "The key ranges of the mismatched chunks",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "schema": {
+                    "type": "string"
+                },
+                "table": {
+                    "type": "string"
                 }
             }
         },
@@ -907,6 +1746,21 @@ var doc = `{
                 }
             }
         },
+        "model.PrecheckItem": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "the reason of the failure, or a warning if the item is passed",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "passed": {
+                    "type": "boolean"
+                }
+            }
+        },
         "model.ProcessorCommonInfo": {
             "type": "object",
             "properties": {
@@ -942,15 +1796,48 @@ var doc = `{
                 }
             }
         },
+        "model.ReconcileStatus": {
+            "type": "object",
+            "properties": {
+                "changefeeds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ChangefeedReconcileStatus"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "the error which fails the whole reconciliation, such as failing to load the specs",
+                    "type": "string"
+                },
+                "last_reconcile_time": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "the directory or the etcd prefix of the changefeed specs",
+                    "type": "string"
+                }
+            }
+        },
         "model.RunningError": {
             "type": "object",
             "properties": {
                 "addr": {
                     "type": "string"
                 },
+                "class": {
+                    "description": "Class is the retryability class of the error, see cerror.ErrorClass.",
+                    "type": "string"
+                },
                 "code": {
                     "type": "string"
                 },
+                "hint": {
+                    "description": "Hint is the remediation hint of the error, it is empty if there is no\nknown remediation.",
+                    "type": "string"
+                },
                 "message": {
                     "type": "string"
                 }
@@ -976,6 +1863,54 @@ var doc = `{
                 }
             }
         },
+        "model.SinkSwitchover": {
+            "type": "object",
+            "properties": {
+                "checkpoint-ts": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SyncpointRecord": {
+            "type": "object",
+            "properties": {
+                "primary_ts": {
+                    "type": "integer"
+                },
+                "secondary_ts": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TableName": {
+            "type": "object",
+            "properties": {
+                "db-name": {
+                    "type": "string"
+                },
+                "is-partition": {
+                    "type": "boolean"
+                },
+                "tbl-id": {
+                    "type": "integer"
+                },
+                "tbl-name": {
+                    "type": "string"
+                }
+            }
+        },
         "model.TableOperation": {
             "type": "object",
             "properties": {
@@ -997,6 +1932,71 @@ var doc = `{
                     "type": "integer"
                 }
             }
+        },
+        "model.TablePipelineStatus": {
+            "type": "object",
+            "properties": {
+                "barrier_ts": {
+                    "description": "The barrier ts which the sink node is not allowed to exceed.",
+                    "type": "integer"
+                },
+                "capture_id": {
+                    "type": "string"
+                },
+                "checkpoint_ts": {
+                    "description": "The checkpoint ts flushed by the sink node.",
+                    "type": "integer"
+                },
+                "flow_controller_consumption": {
+                    "description": "The memory consumption of the flow controller in bytes.",
+                    "type": "integer"
+                },
+                "output_channel_length": {
+                    "description": "The number of messages in the output channel of each pipeline node.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "resolved_ts": {
+                    "description": "The resolved ts received by the sink node.",
+                    "type": "integer"
+                },
+                "sorter_resolved_ts": {
+                    "description": "The resolved ts of the sorter node.",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "The status of the table pipeline, Initializing, Running or Stopped.",
+                    "type": "string"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "table_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UpstreamInfo": {
+            "type": "object",
+            "properties": {
+                "ca-path": {
+                    "type": "string"
+                },
+                "cert-path": {
+                    "type": "string"
+                },
+                "key-path": {
+                    "type": "string"
+                },
+                "pd-addrs": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
         }
     }
 }`
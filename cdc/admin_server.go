@@ -0,0 +1,352 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/proto/adminpb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+const defaultWatchChangefeedInterval = time.Second
+
+// notFoundErrorCodes are the error codes of the OpenAPI which are mapped to
+// codes.NotFound, the other bad request errors are mapped to codes.InvalidArgument.
+var notFoundErrorCodes = map[string]struct{}{
+	"CDC:ErrChangeFeedNotExists":    {},
+	"CDC:ErrCaptureNotExist":        {},
+	"CDC:ErrProcessorTableNotFound": {},
+}
+
+// adminServer implements adminpb.AdminServer. Every RPC is dispatched in
+// process to the corresponding OpenAPI, so the gRPC API always behaves the
+// same as the OpenAPI, including forwarding the requests to the owner.
+type adminServer struct {
+	router http.Handler
+}
+
+func newAdminServer(router http.Handler) *adminServer {
+	return &adminServer{router: router}
+}
+
+// call sends a request to the OpenAPI and decodes the response body into
+// resp if it is not nil. A non-2xx response is converted to a gRPC status.
+func (s *adminServer) call(ctx context.Context, method, path string, body, resp interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return grpcstatus.Error(codes.InvalidArgument, err.Error())
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, reader)
+	if err != nil {
+		return grpcstatus.Error(codes.InvalidArgument, err.Error())
+	}
+	// the request is forwarded with its RequestURI if it must be handled by
+	// the owner, which is only set for the requests from the network.
+	req.RequestURI = path
+	req.Header.Set("Content-Type", "application/json")
+
+	w := &responseRecorder{header: make(http.Header), code: http.StatusOK}
+	s.router.ServeHTTP(w, req)
+	if w.code < http.StatusOK || w.code >= http.StatusMultipleChoices {
+		return httpErrorToStatus(w.code, w.body.Bytes())
+	}
+	if resp == nil || w.body.Len() == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(w.body.Bytes(), resp); err != nil {
+		return grpcstatus.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+func httpErrorToStatus(code int, body []byte) error {
+	var httpErr model.HTTPError
+	if err := json.Unmarshal(body, &httpErr); err != nil || httpErr.Error == "" {
+		httpErr.Error = fmt.Sprintf("%d %s: %s", code, http.StatusText(code), string(body))
+	}
+	msg := httpErr.Error
+	if httpErr.Code != "" {
+		msg = fmt.Sprintf("[%s] %s", httpErr.Code, httpErr.Error)
+	}
+	switch {
+	case code == http.StatusBadRequest:
+		if _, ok := notFoundErrorCodes[httpErr.Code]; ok {
+			return grpcstatus.Error(codes.NotFound, msg)
+		}
+		return grpcstatus.Error(codes.InvalidArgument, msg)
+	case code == http.StatusNotFound:
+		return grpcstatus.Error(codes.Unimplemented, msg)
+	case code == http.StatusGatewayTimeout:
+		return grpcstatus.Error(codes.DeadlineExceeded, msg)
+	default:
+		return grpcstatus.Error(codes.Internal, msg)
+	}
+}
+
+func changefeedPath(id string, suffix string) string {
+	return "/api/v1/changefeeds/" + url.PathEscape(id) + suffix
+}
+
+func (s *adminServer) GetServerStatus(ctx context.Context, _ *adminpb.Empty) (*adminpb.ServerStatus, error) {
+	resp := &adminpb.ServerStatus{}
+	if err := s.call(ctx, http.MethodGet, "/api/v1/status", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *adminServer) ListChangefeeds(ctx context.Context, req *adminpb.ListChangefeedsRequest) (*adminpb.ListChangefeedsResponse, error) {
+	path := "/api/v1/changefeeds"
+	if req.GetState() != "" {
+		path += "?state=" + url.QueryEscape(req.GetState())
+	}
+	resp := &adminpb.ListChangefeedsResponse{}
+	if err := s.call(ctx, http.MethodGet, path, nil, &resp.Changefeeds); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *adminServer) GetChangefeed(ctx context.Context, req *adminpb.ChangefeedRequest) (*adminpb.ChangefeedDetail, error) {
+	// the keys of the upstream info are not valid proto field names
+	var resp struct {
+		adminpb.ChangefeedDetail
+		Upstream *model.UpstreamInfo `json:"upstream"`
+	}
+	if err := s.call(ctx, http.MethodGet, changefeedPath(req.GetChangefeedId(), ""), nil, &resp); err != nil {
+		return nil, err
+	}
+	detail := resp.ChangefeedDetail
+	detail.Upstream = upstreamToPB(resp.Upstream)
+	return &detail, nil
+}
+
+func (s *adminServer) CreateChangefeed(ctx context.Context, req *adminpb.ChangefeedConfig) (*adminpb.Empty, error) {
+	if err := s.call(ctx, http.MethodPost, "/api/v1/changefeeds", changefeedConfigFromPB(req), nil); err != nil {
+		return nil, err
+	}
+	return &adminpb.Empty{}, nil
+}
+
+func (s *adminServer) UpdateChangefeed(ctx context.Context, req *adminpb.ChangefeedConfig) (*adminpb.ChangefeedUpdateResult, error) {
+	resp := &adminpb.ChangefeedUpdateResult{}
+	err := s.call(ctx, http.MethodPut, changefeedPath(req.GetChangefeedId(), ""), changefeedConfigFromPB(req), resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *adminServer) PauseChangefeed(ctx context.Context, req *adminpb.ChangefeedRequest) (*adminpb.Empty, error) {
+	if err := s.call(ctx, http.MethodPost, changefeedPath(req.GetChangefeedId(), "/pause"), nil, nil); err != nil {
+		return nil, err
+	}
+	return &adminpb.Empty{}, nil
+}
+
+func (s *adminServer) ResumeChangefeed(ctx context.Context, req *adminpb.ChangefeedRequest) (*adminpb.Empty, error) {
+	if err := s.call(ctx, http.MethodPost, changefeedPath(req.GetChangefeedId(), "/resume"), nil, nil); err != nil {
+		return nil, err
+	}
+	return &adminpb.Empty{}, nil
+}
+
+func (s *adminServer) RemoveChangefeed(ctx context.Context, req *adminpb.ChangefeedRequest) (*adminpb.Empty, error) {
+	if err := s.call(ctx, http.MethodDelete, changefeedPath(req.GetChangefeedId(), ""), nil, nil); err != nil {
+		return nil, err
+	}
+	return &adminpb.Empty{}, nil
+}
+
+// WatchChangefeed checks the status of the changefeed at the interval and
+// sends it if it is changed, until the stream is closed or the changefeed
+// is not found.
+func (s *adminServer) WatchChangefeed(req *adminpb.WatchChangefeedRequest, stream adminpb.Admin_WatchChangefeedServer) error {
+	interval := defaultWatchChangefeedInterval
+	if req.GetIntervalMs() > 0 {
+		interval = time.Duration(req.GetIntervalMs()) * time.Millisecond
+	}
+	ctx := stream.Context()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *adminpb.ChangefeedCommonInfo
+	for {
+		info := &adminpb.ChangefeedCommonInfo{}
+		if err := s.call(ctx, http.MethodGet, changefeedPath(req.GetChangefeedId(), ""), nil, info); err != nil {
+			return err
+		}
+		if last == nil || !proto.Equal(last, info) {
+			if err := stream.Send(info); err != nil {
+				log.Info("watch changefeed stream closed",
+					zap.String("changefeed", req.GetChangefeedId()), zap.Error(err))
+				return err
+			}
+			last = info
+		}
+		select {
+		case <-ctx.Done():
+			return grpcstatus.Error(codes.Canceled, ctx.Err().Error())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *adminServer) RebalanceTables(ctx context.Context, req *adminpb.ChangefeedRequest) (*adminpb.Empty, error) {
+	path := changefeedPath(req.GetChangefeedId(), "/tables/rebalance_table")
+	if err := s.call(ctx, http.MethodPost, path, nil, nil); err != nil {
+		return nil, err
+	}
+	return &adminpb.Empty{}, nil
+}
+
+func (s *adminServer) MoveTable(ctx context.Context, req *adminpb.MoveTableRequest) (*adminpb.Empty, error) {
+	path := changefeedPath(req.GetChangefeedId(), "/tables/move_table")
+	body := struct {
+		CaptureID string `json:"capture_id"`
+		TableID   int64  `json:"table_id"`
+	}{CaptureID: req.GetCaptureId(), TableID: req.GetTableId()}
+	if err := s.call(ctx, http.MethodPost, path, body, nil); err != nil {
+		return nil, err
+	}
+	return &adminpb.Empty{}, nil
+}
+
+func (s *adminServer) ListProcessors(ctx context.Context, _ *adminpb.Empty) (*adminpb.ListProcessorsResponse, error) {
+	resp := &adminpb.ListProcessorsResponse{}
+	if err := s.call(ctx, http.MethodGet, "/api/v1/processors", nil, &resp.Processors); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *adminServer) GetProcessor(ctx context.Context, req *adminpb.ProcessorRequest) (*adminpb.ProcessorDetail, error) {
+	path := "/api/v1/processors/" + url.PathEscape(req.GetChangefeedId()) + "/" + url.PathEscape(req.GetCaptureId())
+	resp := &adminpb.ProcessorDetail{}
+	if err := s.call(ctx, http.MethodGet, path, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *adminServer) ListCaptures(ctx context.Context, _ *adminpb.Empty) (*adminpb.ListCapturesResponse, error) {
+	resp := &adminpb.ListCapturesResponse{}
+	if err := s.call(ctx, http.MethodGet, "/api/v1/captures", nil, &resp.Captures); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *adminServer) DrainCapture(ctx context.Context, req *adminpb.CaptureRequest) (*adminpb.DrainCaptureStatus, error) {
+	path := "/api/v1/captures/" + url.PathEscape(req.GetCaptureId()) + "/drain"
+	resp := &adminpb.DrainCaptureStatus{}
+	if err := s.call(ctx, http.MethodPost, path, nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *adminServer) ResignOwner(ctx context.Context, _ *adminpb.Empty) (*adminpb.Empty, error) {
+	if err := s.call(ctx, http.MethodPost, "/api/v1/owner/resign", nil, nil); err != nil {
+		return nil, err
+	}
+	return &adminpb.Empty{}, nil
+}
+
+func upstreamToPB(info *model.UpstreamInfo) *adminpb.UpstreamInfo {
+	if info == nil {
+		return nil
+	}
+	return &adminpb.UpstreamInfo{
+		PdAddrs:  info.PDAddrs,
+		CaPath:   info.CAPath,
+		CertPath: info.CertPath,
+		KeyPath:  info.KeyPath,
+	}
+}
+
+func changefeedConfigFromPB(req *adminpb.ChangefeedConfig) *model.ChangefeedConfig {
+	cfg := &model.ChangefeedConfig{
+		ID:                    req.GetChangefeedId(),
+		StartTS:               req.GetStartTs(),
+		TargetTS:              req.GetTargetTs(),
+		SinkURI:               req.GetSinkUri(),
+		StartTime:             req.GetStartTime(),
+		BackupMetaPath:        req.GetBackupMetaPath(),
+		StandbySinkURI:        req.GetStandbySinkUri(),
+		TimeZone:              req.GetTimezone(),
+		ForceReplicate:        req.GetForceReplicate(),
+		IgnoreIneligibleTable: req.GetIgnoreIneligibleTable(),
+		FilterRules:           req.GetFilterRules(),
+		IgnoreTxnStartTs:      req.GetIgnoreTxnStartTs(),
+		MounterWorkerNum:      int(req.GetMounterWorkerNum()),
+		NoKeyTableStrategy:    config.NoKeyTableStrategy(req.GetNoKeyTableStrategy()),
+		Features:              req.GetFeatures(),
+	}
+	if upstream := req.GetUpstream(); upstream != nil {
+		cfg.Upstream = &model.UpstreamInfo{
+			PDAddrs:  upstream.GetPdAddrs(),
+			CAPath:   upstream.GetCaPath(),
+			CertPath: upstream.GetCertPath(),
+			KeyPath:  upstream.GetKeyPath(),
+		}
+	}
+	if sinkCfg := req.GetSinkConfig(); sinkCfg != nil {
+		cfg.SinkConfig = &config.SinkConfig{Protocol: sinkCfg.GetProtocol()}
+		for _, rule := range sinkCfg.GetDispatchers() {
+			cfg.SinkConfig.DispatchRules = append(cfg.SinkConfig.DispatchRules, &config.DispatchRule{
+				Matcher:    rule.GetMatcher(),
+				Dispatcher: rule.GetDispatcher(),
+			})
+		}
+	}
+	return cfg
+}
+
+// responseRecorder records the response of an in-process OpenAPI request.
+type responseRecorder struct {
+	header http.Header
+	code   int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	return r.body.Write(data)
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	r.code = code
+}
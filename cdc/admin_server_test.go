@@ -0,0 +1,165 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pingcap/ticdc/cdc/model"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/proto/adminpb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// mockOpenAPI serves a part of the OpenAPI, the changefeed is stopped after
+// it is got twice.
+type mockOpenAPI struct {
+	mu      sync.Mutex
+	gets    int
+	created *model.ChangefeedConfig
+}
+
+func (m *mockOpenAPI) router() *gin.Engine {
+	router := gin.New()
+	router.Use(errorHandleMiddleware())
+	router.GET("/api/v1/changefeeds/:changefeed_id", func(c *gin.Context) {
+		id := c.Param("changefeed_id")
+		if id != "test" {
+			_ = c.Error(cerror.ErrChangeFeedNotExists.GenWithStackByArgs(id))
+			return
+		}
+		m.mu.Lock()
+		m.gets++
+		state := model.StateNormal
+		if m.gets > 2 {
+			state = model.StateStopped
+		}
+		m.mu.Unlock()
+		c.IndentedJSON(http.StatusOK, model.ChangefeedDetail{
+			ID:            id,
+			FeedState:     state,
+			CheckpointTSO: 429000000000000000,
+			PausedTables:  map[model.TableID]model.Ts{52: 429000000000000001},
+			Upstream:      &model.UpstreamInfo{PDAddrs: []string{"http://127.0.0.1:2379"}},
+		})
+	})
+	router.POST("/api/v1/changefeeds", func(c *gin.Context) {
+		cfg := &model.ChangefeedConfig{}
+		if err := c.BindJSON(cfg); err != nil || cfg.SinkURI == "" {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid sink uri"))
+			return
+		}
+		m.mu.Lock()
+		m.created = cfg
+		m.mu.Unlock()
+		c.Status(http.StatusAccepted)
+	})
+	router.GET("/api/v1/captures", func(c *gin.Context) {
+		c.IndentedJSON(http.StatusOK, []*model.Capture{
+			{ID: "capture-1", IsOwner: true, AdvertiseAddr: "127.0.0.1:8300"},
+		})
+	})
+	return router
+}
+
+func TestAdminServer(t *testing.T) {
+	t.Parallel()
+	api := &mockOpenAPI{}
+	server := newAdminServer(api.router())
+	ctx := context.Background()
+
+	_, err := server.CreateChangefeed(ctx, &adminpb.ChangefeedConfig{ChangefeedId: "test"})
+	require.Equal(t, codes.InvalidArgument, grpcstatus.Code(err))
+	require.Regexp(t, ".*CDC:ErrAPIInvalidParam.*invalid sink uri.*", err)
+
+	_, err = server.CreateChangefeed(ctx, &adminpb.ChangefeedConfig{
+		ChangefeedId: "test",
+		SinkUri:      "blackhole://",
+		Upstream:     &adminpb.UpstreamInfo{PdAddrs: []string{"http://127.0.0.1:2379"}},
+		SinkConfig: &adminpb.SinkConfig{
+			Protocol:    "canal-json",
+			Dispatchers: []*adminpb.DispatchRule{{Matcher: []string{"test.*"}, Dispatcher: "ts"}},
+		},
+		Features: map[string]bool{"checksum": true},
+	})
+	require.Nil(t, err)
+	require.Equal(t, "blackhole://", api.created.SinkURI)
+	require.Equal(t, []string{"http://127.0.0.1:2379"}, api.created.Upstream.PDAddrs)
+	require.Equal(t, "canal-json", api.created.SinkConfig.Protocol)
+	require.Equal(t, "ts", api.created.SinkConfig.DispatchRules[0].Dispatcher)
+	require.Equal(t, map[string]bool{"checksum": true}, api.created.Features)
+
+	_, err = server.GetChangefeed(ctx, &adminpb.ChangefeedRequest{ChangefeedId: "not-exist"})
+	require.Equal(t, codes.NotFound, grpcstatus.Code(err))
+	detail, err := server.GetChangefeed(ctx, &adminpb.ChangefeedRequest{ChangefeedId: "test"})
+	require.Nil(t, err)
+	require.Equal(t, "normal", detail.State)
+	require.Equal(t, uint64(429000000000000000), detail.CheckpointTso)
+	require.NotEmpty(t, detail.CheckpointTime)
+	require.Equal(t, map[int64]uint64{52: 429000000000000001}, detail.PausedTables)
+	require.Equal(t, []string{"http://127.0.0.1:2379"}, detail.Upstream.PdAddrs)
+
+	captures, err := server.ListCaptures(ctx, &adminpb.Empty{})
+	require.Nil(t, err)
+	require.Len(t, captures.Captures, 1)
+	require.Equal(t, "127.0.0.1:8300", captures.Captures[0].Address)
+	require.True(t, captures.Captures[0].IsOwner)
+
+	// the route is not served by the mock
+	_, err = server.ResignOwner(ctx, &adminpb.Empty{})
+	require.Equal(t, codes.Unimplemented, grpcstatus.Code(err))
+}
+
+func TestAdminServerWatchChangefeed(t *testing.T) {
+	t.Parallel()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	grpcServer := grpc.NewServer()
+	adminpb.RegisterAdminServer(grpcServer, newAdminServer((&mockOpenAPI{}).router()))
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	defer grpcServer.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, lis.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+	require.Nil(t, err)
+	defer conn.Close()
+	client := adminpb.NewAdminClient(conn)
+
+	stream, err := client.WatchChangefeed(ctx, &adminpb.WatchChangefeedRequest{ChangefeedId: "test", IntervalMs: 10})
+	require.Nil(t, err)
+	// the unchanged status is sent only once
+	info, err := stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, "normal", info.State)
+	info, err = stream.Recv()
+	require.Nil(t, err)
+	require.Equal(t, "stopped", info.State)
+
+	stream, err = client.WatchChangefeed(ctx, &adminpb.WatchChangefeedRequest{ChangefeedId: "not-exist"})
+	require.Nil(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.NotFound, grpcstatus.Code(err))
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/ticdc/cdc/owner"
 	"github.com/pingcap/ticdc/cdc/processor"
 	"github.com/pingcap/ticdc/cdc/processor/pipeline/system"
+	"github.com/pingcap/ticdc/cdc/upstream"
 	"github.com/pingcap/ticdc/pkg/config"
 	cdcContext "github.com/pingcap/ticdc/pkg/context"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
@@ -43,6 +44,10 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// upstreamReadyTimeout is the maximum duration to wait for an upstream to be
+// ready when serving the http requests.
+const upstreamReadyTimeout = 30 * time.Second
+
 // Capture represents a Capture server, it monitors the changefeed information in etcd and schedules Task on it.
 type Capture struct {
 	captureMu sync.Mutex
@@ -61,17 +66,22 @@ type Capture struct {
 	etcdClient *etcd.CDCEtcdClient
 	grpcPool   kv.GrpcPool
 
+	upstreamManager *upstream.Manager
+
 	tableActorSystem *system.System
 
+	// reconciler is nil if the declarative changefeed reconciliation is disabled
+	reconciler *reconciler
+
 	cancel context.CancelFunc
 
 	newProcessorManager func() *processor.Manager
-	newOwner            func(pd.Client) *owner.Owner
+	newOwner            func(*upstream.Manager) *owner.Owner
 }
 
 // NewCapture returns a new Capture instance
 func NewCapture(pdClient pd.Client, kvStorage tidbkv.Storage, etcdClient *etcd.CDCEtcdClient) *Capture {
-	return &Capture{
+	c := &Capture{
 		pdClient:   pdClient,
 		kvStorage:  kvStorage,
 		etcdClient: etcdClient,
@@ -80,6 +90,10 @@ func NewCapture(pdClient pd.Client, kvStorage tidbkv.Storage, etcdClient *etcd.C
 		newProcessorManager: processor.NewManager,
 		newOwner:            owner.NewOwner,
 	}
+	if conf := config.GetGlobalServerConfig(); conf.Reconcile.IsEnabled() {
+		c.reconciler = newReconciler(c, conf.Reconcile)
+	}
+	return c
 }
 
 func (c *Capture) reset(ctx context.Context) error {
@@ -90,6 +104,7 @@ func (c *Capture) reset(ctx context.Context) error {
 		ID:            uuid.New().String(),
 		AdvertiseAddr: conf.AdvertiseAddr,
 		Version:       version.ReleaseVersion,
+		Labels:        conf.Labels,
 	}
 	c.processorManager = c.newProcessorManager()
 	if c.session != nil {
@@ -103,6 +118,9 @@ func (c *Capture) reset(ctx context.Context) error {
 	}
 	c.session = sess
 	c.election = concurrency.NewElection(sess, etcd.CaptureOwnerKey)
+	if c.upstreamManager != nil {
+		c.upstreamManager.Close()
+	}
 	if c.grpcPool != nil {
 		c.grpcPool.Close()
 	}
@@ -120,6 +138,7 @@ func (c *Capture) reset(ctx context.Context) error {
 		}
 	}
 	c.grpcPool = kv.NewGrpcPoolImpl(ctx, conf.Security)
+	c.upstreamManager = upstream.NewManager(ctx, c.pdClient, c.kvStorage, c.grpcPool)
 	log.Info("init capture", zap.String("capture-id", c.info.ID), zap.String("capture-addr", c.info.AdvertiseAddr))
 	return nil
 }
@@ -170,6 +189,7 @@ func (c *Capture) run(stdCtx context.Context) error {
 		EtcdClient:       c.etcdClient,
 		GrpcPool:         c.grpcPool,
 		TableActorSystem: c.tableActorSystem,
+		UpstreamManager:  c.upstreamManager,
 	})
 	err := c.register(ctx)
 	if err != nil {
@@ -209,6 +229,15 @@ func (c *Capture) run(stdCtx context.Context) error {
 		defer wg.Done()
 		c.grpcPool.RecycleConn(ctx)
 	}()
+	if c.reconciler != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// the reconciler only works when the capture is the owner
+			err := c.reconciler.run(ctx)
+			log.Info("the reconciler routine has exited", zap.Error(err))
+		}()
+	}
 	wg.Wait()
 	if ownerErr != nil {
 		return errors.Annotate(ownerErr, "owner exited with error")
@@ -249,8 +278,20 @@ func (c *Capture) campaignOwner(ctx cdcContext.Context) error {
 			}
 			return errors.Trace(err)
 		}
+		// Keep the global state warm while campaigning, so that the owner can
+		// take it over once the capture is elected.
+		standby := newOwnerStandby()
+		standbyCtx, cancelStandby := cdcContext.WithCancel(ctx)
+		standbyErrCh := make(chan error, 1)
+		go func() {
+			err := c.runEtcdWorker(standbyCtx, standby, orchestrator.NewGlobalState(), ownerFlushInterval)
+			standby.markExited()
+			standbyErrCh <- err
+		}()
 		// Campaign to be an owner, it blocks until it becomes the owner
 		if err := c.campaign(ctx); err != nil {
+			cancelStandby()
+			<-standbyErrCh
 			switch errors.Cause(err) {
 			case context.Canceled:
 				return nil
@@ -264,9 +305,17 @@ func (c *Capture) campaignOwner(ctx cdcContext.Context) error {
 		}
 
 		log.Info("campaign owner successfully", zap.String("capture-id", c.info.ID))
-		owner := c.newOwner(c.pdClient)
+		owner := c.newOwner(c.upstreamManager)
 		c.setOwner(owner)
-		err = c.runEtcdWorker(ctx, owner, orchestrator.NewGlobalState(), ownerFlushInterval)
+		if standby.promote(owner) {
+			err = <-standbyErrCh
+		} else {
+			standbyErr := <-standbyErrCh
+			log.Warn("the etcd worker of the owner standby has exited, load the state from etcd",
+				zap.Error(standbyErr))
+			err = c.runEtcdWorker(ctx, owner, orchestrator.NewGlobalState(), ownerFlushInterval)
+		}
+		cancelStandby()
 		c.setOwner(nil)
 		log.Info("run owner exited", zap.Error(err))
 		// if owner exits, resign the owner key
@@ -368,6 +417,9 @@ func (c *Capture) AsyncClose() {
 	if c.processorManager != nil {
 		c.processorManager.AsyncClose()
 	}
+	if c.upstreamManager != nil {
+		c.upstreamManager.Close()
+	}
 	if c.grpcPool != nil {
 		c.grpcPool.Close()
 	}
@@ -397,6 +449,43 @@ func (c *Capture) WriteDebugInfo(w io.Writer) {
 	}
 }
 
+// QueryTableStatus returns the in-process status of the table pipeline, nil is
+// returned if the table is not replicated by this capture.
+func (c *Capture) QueryTableStatus(changefeedID model.ChangeFeedID, tableID model.TableID) (*model.TablePipelineStatus, error) {
+	c.captureMu.Lock()
+	processorManager := c.processorManager
+	captureID := c.info.ID
+	c.captureMu.Unlock()
+	if processorManager == nil {
+		return nil, nil
+	}
+	status, err := processorManager.QueryTableStatus(changefeedID, tableID)
+	if err != nil || status == nil {
+		return nil, errors.Trace(err)
+	}
+	status.CaptureID = captureID
+	return status, nil
+}
+
+// QueryResourceUsage returns the approximate resource usage of the changefeeds
+// running on the capture.
+func (c *Capture) QueryResourceUsage() (*model.ResourceUsageStatus, error) {
+	c.captureMu.Lock()
+	processorManager := c.processorManager
+	captureID := c.info.ID
+	c.captureMu.Unlock()
+	status := &model.ResourceUsageStatus{}
+	if processorManager != nil {
+		var err error
+		status, err = processorManager.QueryResourceUsage()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	status.CaptureID = captureID
+	return status, nil
+}
+
 // IsOwner returns whether the capture is an owner
 func (c *Capture) IsOwner() bool {
 	c.ownerMu.Lock()
@@ -423,3 +512,29 @@ func (c *Capture) GetOwner(ctx context.Context) (*model.CaptureInfo, error) {
 	}
 	return nil, cerror.ErrOwnerNotFound.FastGenByArgs()
 }
+
+// acquireUpstream acquires the upstream and waits until it is ready, the
+// returned function should be called to release the upstream after use.
+func (c *Capture) acquireUpstream(ctx context.Context, info *model.UpstreamInfo) (*upstream.Upstream, func(), error) {
+	if err := info.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := info.CheckCredential(config.GetGlobalServerConfig().Security); err != nil {
+		return nil, nil, err
+	}
+	c.captureMu.Lock()
+	upstreamManager := c.upstreamManager
+	c.captureMu.Unlock()
+	if upstreamManager == nil {
+		return nil, nil, cerror.ErrInvalidUpstream.GenWithStackByArgs("the capture is not initialized")
+	}
+	up := upstreamManager.Acquire(info)
+	ctx, cancel := context.WithTimeout(ctx, upstreamReadyTimeout)
+	defer cancel()
+	if err := up.WaitReady(ctx); err != nil {
+		upstreamManager.Release(up)
+		return nil, nil, cerror.ErrInvalidUpstream.GenWithStackByArgs(
+			fmt.Sprintf("the upstream %s is unavailable, %s", up.ID, err.Error()))
+	}
+	return up, func() { upstreamManager.Release(up) }, nil
+}
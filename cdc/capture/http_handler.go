@@ -15,15 +15,20 @@ package capture
 
 import (
 	"bufio"
+	"math"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/entry"
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/owner"
+	"github.com/pingcap/ticdc/cdc/sink"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/hotkey"
 	"github.com/pingcap/ticdc/pkg/logutil"
 	"github.com/pingcap/ticdc/pkg/retry"
 	"github.com/pingcap/ticdc/pkg/version"
@@ -39,10 +44,25 @@ const (
 	apiOpVarChangefeedID = "changefeed_id"
 	// apiOpVarCaptureID is the key of capture ID in HTTP API
 	apiOpVarCaptureID = "capture_id"
+	// apiOpVarTableID is the key of table ID in HTTP API
+	apiOpVarTableID = "table_id"
+	// apiOpVarLimit is the key of the limit of the number of items in HTTP API
+	apiOpVarLimit = "limit"
+	// apiOpVarStartTs is the key of the lower bound of a ts range in HTTP API
+	apiOpVarStartTs = "start_ts"
+	// apiOpVarEndTs is the key of the upper bound of a ts range in HTTP API
+	apiOpVarEndTs = "end_ts"
+	// apiOpVarOverwriteCheckpointTs is the key of the checkpoint ts a quarantined
+	// changefeed is resumed from in HTTP API
+	apiOpVarOverwriteCheckpointTs = "overwrite_checkpoint_ts"
 	// forWardFromCapture is a header to be set when a request is forwarded from another capture
 	forWardFromCapture = "TiCDC-ForwardFromCapture"
 	// getOwnerRetryMaxTime is the retry max time to get an owner
 	getOwnerRetryMaxTime = 3
+	// defaultHotKeysLimit is the default number of hot keys of each source
+	defaultHotKeysLimit = 10
+	// defaultSyncpointsLimit is the default number of syncpoints returned
+	defaultSyncpointsLimit = 100
 )
 
 // HTTPHandler is a  HTTPHandler of capture
@@ -109,6 +129,7 @@ func (h *HTTPHandler) ListChangefeed(c *gin.Context) {
 		if cfInfo != nil {
 			resp.FeedState = cfInfo.State
 			resp.RunningError = cfInfo.Error
+			resp.GCQuarantined = cfInfo.GCQuarantine != nil
 		}
 
 		if cfStatus != nil {
@@ -184,11 +205,267 @@ func (h *HTTPHandler) GetChangefeed(c *gin.Context) {
 		Engine:         info.Engine,
 		FeedState:      info.State,
 		TaskStatus:     taskStatus,
+		PausedTables:   info.PausedTables,
+
+		StandbySinkURI:  info.StandbySinkURI,
+		SinkSwitchovers: info.SinkSwitchovers,
+		Features:        info.Config.EnabledFeatures(),
+		Upstream:        info.Upstream,
+		UpdateHistory:   info.UpdateHistory,
+		ErrorRecords:    info.ErrorRecords,
+		GCQuarantine:    info.GCQuarantine,
 	}
 
 	c.IndentedJSON(http.StatusOK, changefeedDetail)
 }
 
+// GetChangefeedSLO get the lag SLO status of a changefeed
+// @Summary Get changefeed SLO status
+// @Description get the replication lag SLO status of a changefeed
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Success 200 {object} model.ChangefeedSLOStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/slo [get]
+func (h *HTTPHandler) GetChangefeedSLO(c *gin.Context) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+
+	ctx := c.Request.Context()
+	changefeedID := c.Param(apiOpVarChangefeedID)
+	if err := model.ValidateChangefeedID(changefeedID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedID))
+		return
+	}
+
+	status, err := h.capture.owner.StatusProvider().GetChangeFeedSLOStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, status)
+}
+
+// GetChangefeedChecksum get the consistency check status of a changefeed
+// @Summary Get changefeed checksum status
+// @Description get the result of the last consistency check of a changefeed
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Success 200 {object} model.ChangefeedChecksumStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/checksum [get]
+func (h *HTTPHandler) GetChangefeedChecksum(c *gin.Context) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+
+	ctx := c.Request.Context()
+	changefeedID := c.Param(apiOpVarChangefeedID)
+	if err := model.ValidateChangefeedID(changefeedID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedID))
+		return
+	}
+
+	status, err := h.capture.owner.StatusProvider().GetChangeFeedChecksumStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, status)
+}
+
+// GetChangefeedDDLBarrier gets the DDL which blocks a changefeed
+// @Summary Get changefeed DDL barrier
+// @Description get the DDL which blocks a changefeed as a barrier, and how long it has been executed downstream
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Success 200 {object} model.ChangefeedDDLBarrier
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/ddl_barrier [get]
+func (h *HTTPHandler) GetChangefeedDDLBarrier(c *gin.Context) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+
+	ctx := c.Request.Context()
+	changefeedID := c.Param(apiOpVarChangefeedID)
+	if err := model.ValidateChangefeedID(changefeedID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedID))
+		return
+	}
+
+	status, err := h.capture.owner.StatusProvider().GetChangeFeedDDLBarrier(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, status)
+}
+
+// SkipDDL skips the DDL which blocks a changefeed
+// @Summary Skip the DDL barrier of a changefeed
+// @Description skip the DDL which blocks a changefeed as a barrier, the DDL is not executed downstream.
+// @Description the DDL being executed is skipped only if it fails, and the changefeed stopped by its error is restarted at once
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param commit_ts body integer true "commit_ts"
+// @Success 202
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/ddl_barrier/skip [post]
+func (h *HTTPHandler) SkipDDL(c *gin.Context) {
+	h.operateDDLBarrier(c, true)
+}
+
+// RetryDDL retries the DDL which blocks a changefeed
+// @Summary Retry the DDL barrier of a changefeed
+// @Description restart the changefeed stopped by the error of executing the DDL barrier at once to execute the DDL again
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param commit_ts body integer true "commit_ts"
+// @Success 202
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/ddl_barrier/retry [post]
+func (h *HTTPHandler) RetryDDL(c *gin.Context) {
+	h.operateDDLBarrier(c, false)
+}
+
+func (h *HTTPHandler) operateDDLBarrier(c *gin.Context, skip bool) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+	statusProvider := h.capture.owner.StatusProvider()
+	ctx := c.Request.Context()
+	changefeedID := c.Param(apiOpVarChangefeedID)
+	if err := model.ValidateChangefeedID(changefeedID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedID))
+		return
+	}
+	info, err := statusProvider.GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	barrier, err := statusProvider.GetChangeFeedDDLBarrier(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var cfg model.DDLBarrierConfig
+	if err = c.BindJSON(&cfg); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.Wrap(err))
+		return
+	}
+	if barrier.CommitTs == 0 || cfg.CommitTs != barrier.CommitTs {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack(
+			"the DDL at commit ts %d is not the barrier of the changefeed", cfg.CommitTs))
+		return
+	}
+	if !skip && (info.Error == nil || (info.State != model.StateError && info.State != model.StateFailed)) {
+		_ = c.Error(cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+			"can only retry the DDL when the changefeed is stopped by an error"))
+		return
+	}
+
+	_ = h.capture.OperateOwnerUnderLock(func(owner *owner.Owner) error {
+		if skip {
+			owner.SkipDDL(changefeedID, cfg.CommitTs)
+		} else {
+			owner.RetryDDL(changefeedID, cfg.CommitTs)
+		}
+		return nil
+	})
+
+	c.Status(http.StatusAccepted)
+}
+
+// GetChangefeedSyncpoints gets the syncpoints of a changefeed
+// @Summary Get changefeed syncpoints
+// @Description get the syncpoints recorded in the downstream, which map the upstream ts to the downstream ts
+// @Description at which the downstream is consistent with the upstream, the latest syncpoint first
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param start_ts  query  integer  false  "the minimum upstream ts of the syncpoints"
+// @Param end_ts  query  integer  false  "the maximum upstream ts of the syncpoints"
+// @Param limit  query  integer  false  "the maximum number of syncpoints, default 100"
+// @Success 200 {array} model.SyncpointRecord
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/syncpoints [get]
+func (h *HTTPHandler) GetChangefeedSyncpoints(c *gin.Context) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+
+	ctx := c.Request.Context()
+	changefeedID := c.Param(apiOpVarChangefeedID)
+	if err := model.ValidateChangefeedID(changefeedID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedID))
+		return
+	}
+	startTs, endTs := uint64(0), uint64(math.MaxUint64)
+	var err error
+	if startTsStr := c.Query(apiOpVarStartTs); startTsStr != "" {
+		if startTs, err = strconv.ParseUint(startTsStr, 10, 64); err != nil {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid start_ts: %s", startTsStr))
+			return
+		}
+	}
+	if endTsStr := c.Query(apiOpVarEndTs); endTsStr != "" {
+		if endTs, err = strconv.ParseUint(endTsStr, 10, 64); err != nil || endTs < startTs {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid end_ts: %s", endTsStr))
+			return
+		}
+	}
+	limit := defaultSyncpointsLimit
+	if limitStr := c.Query(apiOpVarLimit); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid limit: %s", limitStr))
+			return
+		}
+	}
+
+	info, err := h.capture.owner.StatusProvider().GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if !info.SyncPointEnabled {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("the syncpoint of changefeed %s is not enabled", changefeedID))
+		return
+	}
+	store, err := sink.NewSyncpointStore(ctx, changefeedID, info.SinkURI)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer store.Close()
+	records, err := store.QuerySyncpoints(ctx, changefeedID, startTs, endTs, limit)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, records)
+}
+
 // CreateChangefeed creates a changefeed
 // @Summary Create changefeed
 // @Description create a new changefeed
@@ -234,6 +511,36 @@ func (h *HTTPHandler) CreateChangefeed(c *gin.Context) {
 	c.Status(http.StatusAccepted)
 }
 
+// PrecheckChangefeed prechecks the creation of a changefeed
+// @Summary Precheck changefeed
+// @Description check whether a changefeed can be created without creating it, the sink, filter rules, tables and GC safepoint are checked
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed body model.ChangefeedConfig true "changefeed config"
+// @Success 200 {object} model.ChangefeedPrecheckReport
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/precheck [post]
+func (h *HTTPHandler) PrecheckChangefeed(c *gin.Context) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+
+	var changefeedConfig model.ChangefeedConfig
+	if err := c.BindJSON(&changefeedConfig); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.Wrap(err))
+		return
+	}
+
+	report, err := precheckChangefeedConfig(c, changefeedConfig, h.capture)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, report)
+}
+
 // PauseChangefeed pauses a changefeed
 // @Summary Pause a changefeed
 // @Description Pause a changefeed
@@ -279,11 +586,12 @@ func (h *HTTPHandler) PauseChangefeed(c *gin.Context) {
 
 // ResumeChangefeed resumes a changefeed
 // @Summary Resume a changefeed
-// @Description Resume a changefeed
+// @Description Resume a changefeed, a changefeed quarantined for blocking the GC can only be resumed with an overwrite_checkpoint_ts greater than its quarantined checkpoint ts
 // @Tags changefeed
 // @Accept json
 // @Produce json
 // @Param changefeed-id path string true "changefeed_id"
+// @Param overwrite_checkpoint_ts query integer false "the checkpoint ts a quarantined changefeed is resumed from"
 // @Success 202
 // @Failure 500,400 {object} model.HTTPError
 // @Router	/api/v1/changefeeds/{changefeed_id}/resume [post]
@@ -299,16 +607,29 @@ func (h *HTTPHandler) ResumeChangefeed(c *gin.Context) {
 		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedID))
 		return
 	}
+	var overwriteCheckpointTs uint64
+	if tsStr := c.Query(apiOpVarOverwriteCheckpointTs); tsStr != "" {
+		var err error
+		if overwriteCheckpointTs, err = strconv.ParseUint(tsStr, 10, 64); err != nil {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid overwrite_checkpoint_ts: %s", tsStr))
+			return
+		}
+	}
 	// check if the changefeed exists
-	_, err := statusProvider.GetChangeFeedStatus(ctx, changefeedID)
+	info, err := statusProvider.GetChangeFeedInfo(ctx, changefeedID)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
+	if err := info.VerifyResume(changefeedID, overwriteCheckpointTs); err != nil {
+		_ = c.Error(err)
+		return
+	}
 
 	job := model.AdminJob{
 		CfID: changefeedID,
 		Type: model.AdminResume,
+		Opts: &model.AdminJobOption{OverwriteCheckpointTs: overwriteCheckpointTs},
 	}
 
 	_ = h.capture.OperateOwnerUnderLock(func(owner *owner.Owner) error {
@@ -321,7 +642,7 @@ func (h *HTTPHandler) ResumeChangefeed(c *gin.Context) {
 
 // UpdateChangefeed updates a changefeed
 // @Summary Update a changefeed
-// @Description Update a changefeed
+// @Description update a changefeed, a running changefeed is paused, updated and resumed from the checkpoint with the new config, the warnings in the result tell the data skipped because of the update
 // @Tags changefeed
 // @Accept json
 // @Produce json
@@ -332,7 +653,7 @@ func (h *HTTPHandler) ResumeChangefeed(c *gin.Context) {
 // @Param ignore_txn_start_ts body integer false "ignore transaction start ts"
 // @Param mounter_worker_num body integer false "mounter worker nums"
 // @Param sink_config body config.SinkConfig false "sink config"
-// @Success 202
+// @Success 202 {object} model.ChangefeedUpdateResult
 // @Failure 500,400 {object} model.HTTPError
 // @Router /api/v1/changefeeds/{changefeed_id} [put]
 func (h *HTTPHandler) UpdateChangefeed(c *gin.Context) {
@@ -353,8 +674,15 @@ func (h *HTTPHandler) UpdateChangefeed(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
-	if info.State != model.StateStopped {
-		_ = c.Error(cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs("can only update changefeed config when it is stopped"))
+	switch info.State {
+	case model.StateNormal, model.StateError, model.StateStopped, model.StateFailed:
+	default:
+		_ = c.Error(cerror.ErrChangefeedUpdateRefused.GenWithStack("can not update changefeed config when it is %s", info.State))
+		return
+	}
+	status, err := statusProvider.GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
@@ -371,14 +699,37 @@ func (h *HTTPHandler) UpdateChangefeed(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
-
-	err = h.capture.etcdClient.SaveChangeFeedInfo(ctx, newInfo, changefeedID)
+	up, release, err := h.capture.acquireUpstream(ctx, info.Upstream)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer release()
+	checkpointTs := info.GetCheckpointTs(status)
+	warnings, err := entry.VerifyFilterChange(info, newInfo, up.KVStorage, checkpointTs)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	update, err := newInfo.RecordUpdate(info, model.ChangefeedUpdateSourceAPI, checkpointTs, warnings)
 	if err != nil {
 		_ = c.Error(err)
 		return
 	}
+	for _, warning := range warnings {
+		log.Warn("update changefeed config", zap.String("changefeed", changefeedID), zap.String("warning", warning))
+	}
 
-	c.Status(http.StatusAccepted)
+	_ = h.capture.OperateOwnerUnderLock(func(owner *owner.Owner) error {
+		owner.UpdateChangefeed(changefeedID, newInfo)
+		return nil
+	})
+
+	result := &model.ChangefeedUpdateResult{ID: changefeedID, Warnings: warnings}
+	if update != nil {
+		result.Changes = update.Changes
+	}
+	c.IndentedJSON(http.StatusAccepted, result)
 }
 
 // RemoveChangefeed removes a changefeed
@@ -515,6 +866,348 @@ func (h *HTTPHandler) MoveTable(c *gin.Context) {
 	c.Status(http.StatusAccepted)
 }
 
+// AddTables adds tables to a changefeed
+// @Summary Add tables to a changefeed
+// @Description add the tables matched by the matcher to a running changefeed, the added tables start replicating from start_ts, and their existing rows at start_ts are written to the downstream first if bootstrap is true
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param matcher body []string true "matcher"
+// @Param start_ts body integer false "start_ts"
+// @Param bootstrap body boolean false "bootstrap"
+// @Success 202
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/tables/add_tables [post]
+func (h *HTTPHandler) AddTables(c *gin.Context) {
+	h.updateTables(c, false)
+}
+
+// RemoveTables removes tables from a changefeed
+// @Summary Remove tables from a changefeed
+// @Description remove the tables matched by the matcher from a running changefeed
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param matcher body []string true "matcher"
+// @Success 202
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/tables/remove_tables [post]
+func (h *HTTPHandler) RemoveTables(c *gin.Context) {
+	h.updateTables(c, true)
+}
+
+func (h *HTTPHandler) updateTables(c *gin.Context, remove bool) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+	statusProvider := h.capture.owner.StatusProvider()
+	ctx := c.Request.Context()
+	changefeedID := c.Param(apiOpVarChangefeedID)
+	if err := model.ValidateChangefeedID(changefeedID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedID))
+		return
+	}
+	info, err := statusProvider.GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if info.State != model.StateNormal {
+		_ = c.Error(cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs("can only add or remove tables when the changefeed is running"))
+		return
+	}
+	status, err := statusProvider.GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var cfg model.UpdateTablesConfig
+	if err = c.BindJSON(&cfg); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.Wrap(err))
+		return
+	}
+	up, release, err := h.capture.acquireUpstream(ctx, info.Upstream)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer release()
+	if err = verifyUpdateTablesConfig(cfg, info, status, remove, up.KVStorage); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err = h.capture.OperateOwnerUnderLock(func(owner *owner.Owner) error {
+		if remove {
+			return owner.RemoveTables(ctx, changefeedID, &cfg)
+		}
+		return owner.AddTables(ctx, changefeedID, &cfg)
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// PauseTables pauses tables of a changefeed
+// @Summary Pause tables of a changefeed
+// @Description pause the replication of the tables of a running changefeed, the tables are replicated from the paused ts once resumed
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param table_ids body []integer true "table_ids"
+// @Success 202
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/tables/pause_tables [post]
+func (h *HTTPHandler) PauseTables(c *gin.Context) {
+	h.pauseTables(c, false)
+}
+
+// ResumeTables resumes tables of a changefeed
+// @Summary Resume tables of a changefeed
+// @Description resume the replication of the paused tables of a running changefeed, the tables are replicated again from the paused ts, and the checkpoint ts of the changefeed goes back to the earliest paused ts until they catch up
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param table_ids body []integer true "table_ids"
+// @Success 202
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/tables/resume_tables [post]
+func (h *HTTPHandler) ResumeTables(c *gin.Context) {
+	h.pauseTables(c, true)
+}
+
+func (h *HTTPHandler) pauseTables(c *gin.Context, resume bool) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+	statusProvider := h.capture.owner.StatusProvider()
+	ctx := c.Request.Context()
+	changefeedID := c.Param(apiOpVarChangefeedID)
+	if err := model.ValidateChangefeedID(changefeedID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedID))
+		return
+	}
+	info, err := statusProvider.GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if info.State != model.StateNormal {
+		_ = c.Error(cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs("can only pause or resume tables when the changefeed is running"))
+		return
+	}
+	taskStatuses, err := statusProvider.GetAllTaskStatuses(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var cfg model.PauseTablesConfig
+	if err = c.BindJSON(&cfg); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.Wrap(err))
+		return
+	}
+	if err = verifyPauseTablesConfig(cfg, info, taskStatuses, resume); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	_ = h.capture.OperateOwnerUnderLock(func(owner *owner.Owner) error {
+		if resume {
+			owner.ResumeTables(changefeedID, &cfg)
+		} else {
+			owner.PauseTables(changefeedID, &cfg)
+		}
+		return nil
+	})
+
+	c.Status(http.StatusAccepted)
+}
+
+// RewindTable rewinds a table of a changefeed
+// @Summary Rewind a table of a changefeed
+// @Description rewind the replication position of a table of a running changefeed to an earlier ts, the events of the table after the ts are emitted again
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param table_id body integer true "table_id"
+// @Param ts body integer true "ts"
+// @Success 202
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/tables/rewind_table [post]
+func (h *HTTPHandler) RewindTable(c *gin.Context) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+	statusProvider := h.capture.owner.StatusProvider()
+	ctx := c.Request.Context()
+	changefeedID := c.Param(apiOpVarChangefeedID)
+	if err := model.ValidateChangefeedID(changefeedID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedID))
+		return
+	}
+	info, err := statusProvider.GetChangeFeedInfo(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if info.State != model.StateNormal {
+		_ = c.Error(cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs("can only rewind tables when the changefeed is running"))
+		return
+	}
+	status, err := statusProvider.GetChangeFeedStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	taskStatuses, err := statusProvider.GetAllTaskStatuses(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var cfg model.RewindTableConfig
+	if err = c.BindJSON(&cfg); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.Wrap(err))
+		return
+	}
+	up, release, err := h.capture.acquireUpstream(ctx, info.Upstream)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer release()
+	if err = verifyRewindTableConfig(ctx, cfg, status, taskStatuses, up.PDClient, up.KVStorage); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	_ = h.capture.OperateOwnerUnderLock(func(owner *owner.Owner) error {
+		owner.RewindTable(changefeedID, cfg.TableID, cfg.Ts)
+		return nil
+	})
+
+	c.Status(http.StatusAccepted)
+}
+
+// GetTableStatus gets the in-process status of a table pipeline
+// @Summary Get table pipeline status
+// @Description get the in-process status of a table pipeline, which is useful to debug stuck tables
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Param changefeed_id  path  string  true  "changefeed_id"
+// @Param table_id  path  integer  true  "table_id"
+// @Success 200 {object} model.TablePipelineStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/changefeeds/{changefeed_id}/tables/{table_id}/status [get]
+func (h *HTTPHandler) GetTableStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedID := c.Param(apiOpVarChangefeedID)
+	if err := model.ValidateChangefeedID(changefeedID); err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedID))
+		return
+	}
+	tableID, err := strconv.ParseInt(c.Param(apiOpVarTableID), 10, 64)
+	if err != nil {
+		_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid table_id: %s", c.Param(apiOpVarTableID)))
+		return
+	}
+
+	status, err := h.capture.QueryTableStatus(changefeedID, tableID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if status != nil {
+		c.IndentedJSON(http.StatusOK, status)
+		return
+	}
+
+	// the table is not replicated by this capture, forward the request to
+	// the capture which replicates the table.
+	if len(c.GetHeader(forWardFromCapture)) != 0 {
+		_ = c.Error(cerror.ErrProcessorTableNotFound.GenWithStackByArgs())
+		return
+	}
+	taskStatuses, err := h.capture.etcdClient.GetAllTaskStatus(ctx, changefeedID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	for captureID, taskStatus := range taskStatuses {
+		if _, ok := taskStatus.Tables[tableID]; !ok {
+			continue
+		}
+		captureInfo, err := h.capture.etcdClient.GetCaptureInfo(ctx, captureID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		h.forwardToCapture(c, captureInfo.AdvertiseAddr)
+		return
+	}
+	_ = c.Error(cerror.ErrProcessorTableNotFound.GenWithStackByArgs())
+}
+
+// GetHotKeys gets the hot keys recorded by this capture
+// @Summary Get hot keys
+// @Description get the keys which most frequently cause causality conflicts in mysql sinks
+// @Description and the tables dispatched to each partition by mq sinks, recorded by this capture
+// @Tags capture
+// @Accept json
+// @Produce json
+// @Param limit  query  integer  false  "the maximum number of keys of each source, default 10"
+// @Success 200 {object} model.HotKeysStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/hot-keys [get]
+func (h *HTTPHandler) GetHotKeys(c *gin.Context) {
+	limit := defaultHotKeysLimit
+	if limitStr := c.Query(apiOpVarLimit); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			_ = c.Error(cerror.ErrAPIInvalidParam.GenWithStack("invalid limit: %s", limitStr))
+			return
+		}
+	}
+	c.IndentedJSON(http.StatusOK, &model.HotKeysStatus{
+		CaptureID: h.capture.Info().ID,
+		Reports:   hotkey.Reports(limit),
+	})
+}
+
+// GetResourceUsage gets the approximate resource usage of the changefeeds
+// @Summary Get resource usage
+// @Description get the approximate CPU and memory usage of the changefeeds running on this capture,
+// @Description which helps to identify the noisy changefeeds
+// @Tags capture
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.ResourceUsageStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/resource-usage [get]
+func (h *HTTPHandler) GetResourceUsage(c *gin.Context) {
+	status, err := h.capture.QueryResourceUsage()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, status)
+}
+
 // ResignOwner makes the current owner resign
 // @Summary notify the owner to resign
 // @Description notify the current owner to resign
@@ -656,12 +1349,93 @@ func (h *HTTPHandler) ListCapture(c *gin.Context) {
 	for _, c := range captureInfos {
 		isOwner := c.ID == ownerID
 		captures = append(captures,
-			&model.Capture{ID: c.ID, IsOwner: isOwner, AdvertiseAddr: c.AdvertiseAddr})
+			&model.Capture{ID: c.ID, IsOwner: isOwner, AdvertiseAddr: c.AdvertiseAddr, Labels: c.Labels})
 	}
 
 	c.IndentedJSON(http.StatusOK, captures)
 }
 
+// DrainCapture drains a capture
+// @Summary Drain a capture
+// @Description move all the tables of a capture to other captures in batches, and no more tables are dispatched to the capture until it exits.
+// @Description It is used to upgrade captures one by one without stalling the replication.
+// @Tags capture
+// @Accept json
+// @Produce json
+// @Param capture_id  path  string  true  "capture_id"
+// @Success 202 {object} model.DrainCaptureStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/captures/{capture_id}/drain [post]
+func (h *HTTPHandler) DrainCapture(c *gin.Context) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+	ctx := c.Request.Context()
+	captureID := c.Param(apiOpVarCaptureID)
+	statusProvider := h.capture.owner.StatusProvider()
+	if _, err := statusProvider.GetDrainCaptureStatus(ctx, captureID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	_ = h.capture.OperateOwnerUnderLock(func(owner *owner.Owner) error {
+		owner.DrainCapture(captureID)
+		return nil
+	})
+
+	status, err := statusProvider.GetDrainCaptureStatus(ctx, captureID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusAccepted, status)
+}
+
+// GetDrainCaptureStatus gets the status of draining a capture
+// @Summary Get the status of draining a capture
+// @Description get the status of draining a capture, the capture is drained if no table is replicated by it
+// @Tags capture
+// @Accept json
+// @Produce json
+// @Param capture_id  path  string  true  "capture_id"
+// @Success 200 {object} model.DrainCaptureStatus
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v1/captures/{capture_id}/drain [get]
+func (h *HTTPHandler) GetDrainCaptureStatus(c *gin.Context) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+	status, err := h.capture.owner.StatusProvider().GetDrainCaptureStatus(c.Request.Context(), c.Param(apiOpVarCaptureID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.IndentedJSON(http.StatusOK, status)
+}
+
+// GetReconcileStatus gets the status of the declarative changefeed reconciliation
+// @Summary Get the declarative changefeed reconciliation status
+// @Description get the status of the declarative changefeed reconciliation, including the drift between the changefeeds and their specs
+// @Tags changefeed
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.ReconcileStatus
+// @Failure 500 {object} model.HTTPError
+// @Router /api/v1/reconcile [get]
+func (h *HTTPHandler) GetReconcileStatus(c *gin.Context) {
+	if !h.capture.IsOwner() {
+		h.forwardToOwner(c)
+		return
+	}
+	if h.capture.reconciler == nil {
+		c.IndentedJSON(http.StatusOK, &model.ReconcileStatus{Enabled: false})
+		return
+	}
+	c.IndentedJSON(http.StatusOK, h.capture.reconciler.getStatus())
+}
+
 // ServerStatus gets the status of server(capture)
 // @Summary Get server status
 // @Description get the status of a server(capture)
@@ -741,6 +1515,26 @@ func SetLogLevel(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
 
+// ReloadServerConfig reloads the safe items of the TiCDC server config dynamically.
+// @Summary Reload TiCDC server config
+// @Description reload the safe items of TiCDC server config from the config file, such as log-level, gc-ttl and the sorter settings
+// @Tags common
+// @Produce json
+// @Success 200 {array} config.ServerConfigChange
+// @Failure 400,500 {object} model.HTTPError
+// @Router	/api/v1/config/reload [post]
+func ReloadServerConfig(c *gin.Context) {
+	changes, err := config.ReloadGlobalServerConfig("api")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if changes == nil {
+		changes = []config.ServerConfigChange{}
+	}
+	c.IndentedJSON(http.StatusOK, changes)
+}
+
 // forwardToOwner forward an request to owner
 func (h *HTTPHandler) forwardToOwner(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -749,7 +1543,6 @@ func (h *HTTPHandler) forwardToOwner(c *gin.Context) {
 		_ = c.Error(cerror.ErrRequestForwardErr.FastGenByArgs())
 		return
 	}
-	c.Header(forWardFromCapture, h.capture.Info().ID)
 
 	var owner *model.CaptureInfo
 	// get owner
@@ -766,6 +1559,12 @@ func (h *HTTPHandler) forwardToOwner(c *gin.Context) {
 		_ = c.Error(err)
 		return
 	}
+	h.forwardToCapture(c, owner.AdvertiseAddr)
+}
+
+// forwardToCapture forward an request to the capture with the address
+func (h *HTTPHandler) forwardToCapture(c *gin.Context, addr string) {
+	c.Header(forWardFromCapture, h.capture.Info().ID)
 
 	tslConfig, err := config.GetGlobalServerConfig().Security.ToTLSConfigWithVerify()
 	if err != nil {
@@ -775,7 +1574,7 @@ func (h *HTTPHandler) forwardToOwner(c *gin.Context) {
 
 	// init a request
 	req, _ := http.NewRequest(c.Request.Method, c.Request.RequestURI, c.Request.Body)
-	req.URL.Host = owner.AdvertiseAddr
+	req.URL.Host = addr
 	if tslConfig != nil {
 		req.URL.Scheme = "https"
 	} else {
@@ -786,8 +1585,10 @@ func (h *HTTPHandler) forwardToOwner(c *gin.Context) {
 			req.Header.Add(k, vv)
 		}
 	}
+	// mark the request as forwarded, so that it won't be forwarded again
+	req.Header.Set(forWardFromCapture, h.capture.Info().ID)
 
-	// forward to owner
+	// forward to the capture
 	cli := httputil.NewClient(tslConfig)
 	resp, err := cli.Do(req)
 	if err != nil {
@@ -0,0 +1,246 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/entry"
+	"github.com/pingcap/ticdc/cdc/kv"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sink"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/regionspan"
+	"github.com/pingcap/ticdc/pkg/txnutil/gc"
+	"github.com/pingcap/ticdc/pkg/util"
+	tidbkv "github.com/pingcap/tidb/kv"
+	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
+)
+
+// All precheck items of a changefeed creation
+const (
+	precheckItemChangefeedID = "changefeed-id"
+	precheckItemUpstream     = "upstream"
+	precheckItemStartTs      = "start-ts"
+	precheckItemTargetTs     = "target-ts"
+	precheckItemGCSafePoint  = "gc-safepoint"
+	precheckItemFilterRules  = "filter-rules"
+	precheckItemTables       = "tables"
+	precheckItemRegions      = "regions"
+	precheckItemSink         = "sink"
+)
+
+const (
+	// precheckGCSafePointMarginWarning is the default GC life time of TiDB,
+	// the start ts is likely to be GCed before the changefeed is created if
+	// the margin is less than it.
+	precheckGCSafePointMarginWarning = 10 * time.Minute
+	// precheckScanRegionsLimit is the maximum number of regions scanned at a time
+	precheckScanRegionsLimit = 1024
+)
+
+// precheckChangefeedConfig checks whether a changefeed can be created with the
+// ChangefeedConfig, it doesn't create the changefeed or set the GC safepoint.
+// The failed checks are recorded in the report, and an error is returned
+// only if the check can not be done.
+func precheckChangefeedConfig(ctx context.Context, changefeedConfig model.ChangefeedConfig, capture *Capture) (*model.ChangefeedPrecheckReport, error) {
+	report := &model.ChangefeedPrecheckReport{ID: changefeedConfig.ID, Passed: true}
+
+	// check changefeedID
+	var err error
+	if err = model.ValidateChangefeedID(changefeedConfig.ID); err != nil {
+		err = cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s", changefeedConfig.ID)
+	} else {
+		cfStatus, err1 := capture.owner.StatusProvider().GetChangeFeedStatus(ctx, changefeedConfig.ID)
+		if err1 != nil && cerror.ErrChangeFeedNotExists.NotEqual(err1) {
+			return nil, err1
+		}
+		if cfStatus != nil {
+			err = cerror.ErrChangeFeedAlreadyExists.GenWithStackByArgs(changefeedConfig.ID)
+		}
+	}
+	addPrecheckItem(report, precheckItemChangefeedID, err)
+
+	// check upstream, the other items can not be checked without it
+	up, release, err := capture.acquireUpstream(ctx, changefeedConfig.Upstream)
+	addPrecheckItem(report, precheckItemUpstream, err)
+	if err != nil {
+		return report, nil
+	}
+	defer release()
+
+	// check start-ts and target-ts
+	changefeedConfig.StartTS, err = resolveStartTs(ctx, changefeedConfig, up.PDClient)
+	if cerror.ErrPDEtcdAPIError.Equal(err) {
+		return nil, err
+	}
+	addPrecheckItem(report, precheckItemStartTs, err)
+	if err != nil {
+		return report, nil
+	}
+	report.StartTs = changefeedConfig.StartTS
+	err = nil
+	if changefeedConfig.TargetTS > 0 && changefeedConfig.TargetTS <= changefeedConfig.StartTS {
+		err = cerror.ErrTargetTsBeforeStartTs.GenWithStackByArgs(changefeedConfig.TargetTS, changefeedConfig.StartTS)
+	}
+	addPrecheckItem(report, precheckItemTargetTs, err)
+
+	startTsSafe, err := precheckGCSafePoint(ctx, up.PDClient, report)
+	if err != nil {
+		return nil, err
+	}
+
+	replicaConfig := newReplicaConfig(changefeedConfig)
+	f, err := filter.NewFilter(replicaConfig)
+	addPrecheckItem(report, precheckItemFilterRules, err)
+
+	// the schemas can not be read if the start ts is GCed
+	if err == nil && startTsSafe {
+		tableIDs, err := precheckTables(changefeedConfig, replicaConfig, f, up.KVStorage, report)
+		if err != nil {
+			return nil, err
+		}
+		report.RegionCount, err = countTableRegions(ctx, up.PDClient, tableIDs)
+		addPrecheckItem(report, precheckItemRegions, err)
+	}
+
+	// check sink connectivity and codec config
+	tz, err := util.GetTimezone(changefeedConfig.TimeZone)
+	if err != nil {
+		err = cerror.ErrAPIInvalidParam.Wrap(errors.Annotatef(err, "invalid timezone:%s", changefeedConfig.TimeZone))
+	} else if changefeedConfig.SinkURI == "" {
+		err = cerror.ErrSinkURIInvalid.GenWithStackByArgs("sink-uri is empty, can't not create a changefeed without sink-uri")
+	} else {
+		err = sink.Validate(util.PutTimezoneInCtx(ctx, tz), changefeedConfig.SinkURI, replicaConfig, make(map[string]string))
+	}
+	addPrecheckItem(report, precheckItemSink, err)
+	return report, nil
+}
+
+// precheckGCSafePoint checks the margin between the start ts and the GC safepoint,
+// it returns true if the start ts is not GCed.
+func precheckGCSafePoint(ctx context.Context, pdCli pd.Client, report *model.ChangefeedPrecheckReport) (bool, error) {
+	safePoint, err := gc.GetMinServiceGCSafepoint(ctx, pdCli)
+	if err != nil {
+		return false, cerror.ErrPDEtcdAPIError.Wrap(err)
+	}
+	report.GCSafePoint = safePoint
+	margin := oracle.GetTimeFromTS(report.StartTs).Sub(oracle.GetTimeFromTS(safePoint))
+	report.GCSafePointMargin = margin.Seconds()
+	if report.StartTs < safePoint {
+		addPrecheckItem(report, precheckItemGCSafePoint,
+			cerror.ErrStartTsBeforeGC.GenWithStackByArgs(
+				report.StartTs, util.FormatTs(report.StartTs), safePoint, util.FormatTs(safePoint)))
+		return false, nil
+	}
+	if margin < precheckGCSafePointMarginWarning {
+		addPrecheckWarning(report, precheckItemGCSafePoint,
+			fmt.Sprintf("start-ts is only %s later than the GC safepoint, it may be GCed before the changefeed is created", margin))
+		return true, nil
+	}
+	addPrecheckItem(report, precheckItemGCSafePoint, nil)
+	return true, nil
+}
+
+// precheckTables checks the eligibility of the tables matched by the filter
+// rules, and returns the IDs of the physical tables to be replicated.
+func precheckTables(
+	changefeedConfig model.ChangefeedConfig, replicaConfig *config.ReplicaConfig, f *filter.Filter,
+	storage tidbkv.Storage, report *model.ChangefeedPrecheckReport,
+) ([]model.TableID, error) {
+	meta, err := kv.GetSnapshotMeta(storage, changefeedConfig.StartTS)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snap, err := entry.NewSingleSchemaSnapshotFromMeta(meta, changefeedConfig.StartTS, false /* explicitTables */)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var tableIDs []model.TableID
+	for _, tableInfo := range snap.Tables() {
+		if f.ShouldIgnoreTable(tableInfo.TableName.Schema, tableInfo.TableName.Table) {
+			continue
+		}
+		if !tableInfo.IsEligible(replicaConfig.ReplicateNoKeyTables()) {
+			report.IneligibleTables = append(report.IneligibleTables, tableInfo.TableName)
+			continue
+		}
+		if pi := tableInfo.GetPartitionInfo(); pi != nil {
+			for _, partition := range pi.Definitions {
+				tableIDs = append(tableIDs, partition.ID)
+			}
+		} else {
+			tableIDs = append(tableIDs, tableInfo.ID)
+		}
+	}
+	report.EligibleTableCount = len(tableIDs)
+
+	switch {
+	case len(report.IneligibleTables) != 0 && !changefeedConfig.IgnoreIneligibleTable:
+		addPrecheckItem(report, precheckItemTables, cerror.ErrTableIneligible.GenWithStackByArgs(report.IneligibleTables))
+	case len(report.IneligibleTables) != 0:
+		addPrecheckWarning(report, precheckItemTables,
+			fmt.Sprintf("%d ineligible tables will be ignored", len(report.IneligibleTables)))
+	case len(tableIDs) == 0:
+		addPrecheckWarning(report, precheckItemTables, "no table is matched by the filter rules")
+	default:
+		addPrecheckItem(report, precheckItemTables, nil)
+	}
+	return tableIDs, nil
+}
+
+// countTableRegions returns the number of regions of the tables.
+func countTableRegions(ctx context.Context, pdCli pd.Client, tableIDs []model.TableID) (int, error) {
+	count := 0
+	for _, tableID := range tableIDs {
+		span := regionspan.ToComparableSpan(regionspan.GetTableSpan(tableID))
+		start := span.Start
+		for {
+			regions, err := pdCli.ScanRegions(ctx, start, span.End, precheckScanRegionsLimit)
+			if err != nil {
+				return 0, cerror.WrapError(cerror.ErrPDBatchLoadRegions, err)
+			}
+			count += len(regions)
+			if len(regions) < precheckScanRegionsLimit {
+				break
+			}
+			end := regions[len(regions)-1].Meta.GetEndKey()
+			if len(end) == 0 || regionspan.EndCompare(end, span.End) >= 0 {
+				break
+			}
+			start = end
+		}
+	}
+	return count, nil
+}
+
+func addPrecheckItem(report *model.ChangefeedPrecheckReport, name string, err error) {
+	item := &model.PrecheckItem{Name: name, Passed: err == nil}
+	if err != nil {
+		item.Message = err.Error()
+		report.Passed = false
+	}
+	report.Items = append(report.Items, item)
+}
+
+func addPrecheckWarning(report *model.ChangefeedPrecheckReport, name string, message string) {
+	report.Items = append(report.Items, &model.PrecheckItem{Name: name, Passed: true, Message: message})
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/metapb"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/regionspan"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
+)
+
+type mockPDClientForPrecheck struct {
+	pd.Client
+	safePoint uint64
+	// split keys of the regions, sorted
+	splitKeys [][]byte
+}
+
+func (m *mockPDClientForPrecheck) UpdateServiceGCSafePoint(
+	ctx context.Context, serviceID string, ttl int64, safePoint uint64,
+) (uint64, error) {
+	return m.safePoint, nil
+}
+
+func (m *mockPDClientForPrecheck) ScanRegions(ctx context.Context, key, endKey []byte, limit int) ([]*pd.Region, error) {
+	var regions []*pd.Region
+	var start []byte
+	for i := 0; i <= len(m.splitKeys); i++ {
+		var end []byte
+		if i < len(m.splitKeys) {
+			end = m.splitKeys[i]
+		}
+		if regionspan.EndCompare(end, key) > 0 && regionspan.StartCompare(start, endKey) < 0 {
+			regions = append(regions, &pd.Region{Meta: &metapb.Region{StartKey: start, EndKey: end}})
+			if len(regions) == limit {
+				break
+			}
+		}
+		start = end
+	}
+	return regions, nil
+}
+
+func TestPrecheckGCSafePoint(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	pdCli := &mockPDClientForPrecheck{safePoint: oracle.GoTimeToTS(now.Add(-time.Hour))}
+
+	report := &model.ChangefeedPrecheckReport{Passed: true, StartTs: oracle.GoTimeToTS(now)}
+	safe, err := precheckGCSafePoint(ctx, pdCli, report)
+	require.Nil(t, err)
+	require.True(t, safe)
+	require.True(t, report.Passed)
+	require.Equal(t, pdCli.safePoint, report.GCSafePoint)
+	require.InDelta(t, time.Hour.Seconds(), report.GCSafePointMargin, 1)
+	require.Equal(t, []*model.PrecheckItem{{Name: precheckItemGCSafePoint, Passed: true}}, report.Items)
+
+	// the margin is too small
+	report = &model.ChangefeedPrecheckReport{Passed: true, StartTs: oracle.GoTimeToTS(now.Add(-55 * time.Minute))}
+	safe, err = precheckGCSafePoint(ctx, pdCli, report)
+	require.Nil(t, err)
+	require.True(t, safe)
+	require.True(t, report.Passed)
+	require.Regexp(t, "it may be GCed", report.Items[0].Message)
+
+	// the start ts is GCed
+	report = &model.ChangefeedPrecheckReport{Passed: true, StartTs: oracle.GoTimeToTS(now.Add(-2 * time.Hour))}
+	safe, err = precheckGCSafePoint(ctx, pdCli, report)
+	require.Nil(t, err)
+	require.False(t, safe)
+	require.False(t, report.Passed)
+	require.False(t, report.Items[0].Passed)
+	require.Regexp(t, ".*ErrStartTsBeforeGC.*", report.Items[0].Message)
+}
+
+func TestCountTableRegions(t *testing.T) {
+	ctx := context.Background()
+	span1 := regionspan.ToComparableSpan(regionspan.GetTableSpan(1))
+	span2 := regionspan.ToComparableSpan(regionspan.GetTableSpan(2))
+	pdCli := &mockPDClientForPrecheck{}
+	// table 1 and table 2 are in the same region
+	count, err := countTableRegions(ctx, pdCli, []model.TableID{1, 2})
+	require.Nil(t, err)
+	require.Equal(t, 2, count)
+
+	// split table 1 into precheckScanRegionsLimit+1 regions
+	pdCli.splitKeys = append(pdCli.splitKeys, span1.Start)
+	for i := 0; i < precheckScanRegionsLimit; i++ {
+		key := append(append([]byte{}, span1.Start...), byte(i/256), byte(i%256))
+		pdCli.splitKeys = append(pdCli.splitKeys, key)
+	}
+	pdCli.splitKeys = append(pdCli.splitKeys, span2.Start, span2.End)
+	count, err = countTableRegions(ctx, pdCli, []model.TableID{1, 2})
+	require.Nil(t, err)
+	require.Equal(t, precheckScanRegionsLimit+2, count)
+}
@@ -32,6 +32,7 @@ import (
 	tidbkv "github.com/pingcap/tidb/kv"
 	"github.com/r3labs/diff"
 	"github.com/tikv/client-go/v2/oracle"
+	pd "github.com/tikv/pd/client"
 )
 
 // verifyCreateChangefeedConfig verify ChangefeedConfig for create a changefeed
@@ -54,19 +55,23 @@ func verifyCreateChangefeedConfig(ctx context.Context, changefeedConfig model.Ch
 		return nil, cerror.ErrChangeFeedAlreadyExists.GenWithStackByArgs(changefeedConfig.ID)
 	}
 
+	// verify upstream
+	up, release, err := capture.acquireUpstream(ctx, changefeedConfig.Upstream)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	// verify start-ts
-	if changefeedConfig.StartTS == 0 {
-		ts, logical, err := capture.pdClient.GetTS(ctx)
-		if err != nil {
-			return nil, cerror.ErrPDEtcdAPIError.GenWithStackByArgs("fail to get ts from pd client")
-		}
-		changefeedConfig.StartTS = oracle.ComposeTS(ts, logical)
+	changefeedConfig.StartTS, err = resolveStartTs(ctx, changefeedConfig, up.PDClient)
+	if err != nil {
+		return nil, err
 	}
 
 	// Ensure the start ts is valid in the next 1 hour.
 	const ensureTTL = 60 * 60
 	if err := gc.EnsureChangefeedStartTsSafety(
-		ctx, capture.pdClient, changefeedConfig.ID, ensureTTL, changefeedConfig.StartTS); err != nil {
+		ctx, up.PDClient, changefeedConfig.ID, ensureTTL, changefeedConfig.StartTS); err != nil {
 		if !cerror.ErrStartTsBeforeGC.Equal(err) {
 			return nil, cerror.ErrPDEtcdAPIError.Wrap(err)
 		}
@@ -79,19 +84,9 @@ func verifyCreateChangefeedConfig(ctx context.Context, changefeedConfig model.Ch
 	}
 
 	// init replicaConfig
-	replicaConfig := config.GetDefaultReplicaConfig()
-	replicaConfig.ForceReplicate = changefeedConfig.ForceReplicate
-	if changefeedConfig.MounterWorkerNum != 0 {
-		replicaConfig.Mounter.WorkerNum = changefeedConfig.MounterWorkerNum
-	}
-	if changefeedConfig.SinkConfig != nil {
-		replicaConfig.Sink = changefeedConfig.SinkConfig
-	}
-	if len(changefeedConfig.IgnoreTxnStartTs) != 0 {
-		replicaConfig.Filter.IgnoreTxnStartTs = changefeedConfig.IgnoreTxnStartTs
-	}
-	if len(changefeedConfig.FilterRules) != 0 {
-		replicaConfig.Filter.Rules = changefeedConfig.FilterRules
+	replicaConfig := newReplicaConfig(changefeedConfig)
+	if err := replicaConfig.Validate(); err != nil {
+		return nil, err
 	}
 
 	captureInfos, err := capture.owner.StatusProvider().GetCaptures(ctx)
@@ -115,6 +110,8 @@ func verifyCreateChangefeedConfig(ctx context.Context, changefeedConfig model.Ch
 	// init ChangefeedInfo
 	info := &model.ChangeFeedInfo{
 		SinkURI:           changefeedConfig.SinkURI,
+		StandbySinkURI:    changefeedConfig.StandbySinkURI,
+		Upstream:          changefeedConfig.Upstream,
 		Opts:              make(map[string]string),
 		CreateTime:        time.Now(),
 		StartTs:           changefeedConfig.StartTS,
@@ -127,8 +124,8 @@ func verifyCreateChangefeedConfig(ctx context.Context, changefeedConfig model.Ch
 		CreatorVersion:    version.ReleaseVersion,
 	}
 
-	if !replicaConfig.ForceReplicate && !changefeedConfig.IgnoreIneligibleTable {
-		ineligibleTables, _, err := verifyTables(replicaConfig, capture.kvStorage, changefeedConfig.StartTS)
+	if !replicaConfig.ReplicateNoKeyTables() && !changefeedConfig.IgnoreIneligibleTable {
+		ineligibleTables, _, err := verifyTables(replicaConfig, up.KVStorage, changefeedConfig.StartTS)
 		if err != nil {
 			return nil, err
 		}
@@ -145,16 +142,75 @@ func verifyCreateChangefeedConfig(ctx context.Context, changefeedConfig model.Ch
 	if err := sink.Validate(ctx, info.SinkURI, info.Config, info.Opts); err != nil {
 		return nil, err
 	}
+	if info.StandbySinkURI != "" {
+		if err := info.ValidateStandbySinkURI(); err != nil {
+			return nil, err
+		}
+		if err := sink.Validate(ctx, info.StandbySinkURI, info.Config, info.Opts); err != nil {
+			return nil, err
+		}
+	}
 
 	return info, nil
 }
 
+// resolveStartTs resolves the start ts of a new changefeed from the start ts,
+// the start time or the BR backupmeta, the current ts of the upstream is used
+// if none of them is specified.
+func resolveStartTs(ctx context.Context, changefeedConfig model.ChangefeedConfig, pdClient pd.Client) (uint64, error) {
+	tz, err := util.GetTimezone(changefeedConfig.TimeZone)
+	if err != nil {
+		return 0, cerror.ErrAPIInvalidParam.Wrap(errors.Annotatef(err, "invalid timezone:%s", changefeedConfig.TimeZone))
+	}
+	startTs, err := util.ResolveStartTs(
+		changefeedConfig.StartTS, changefeedConfig.StartTime, changefeedConfig.BackupMetaPath, tz)
+	if err != nil {
+		return 0, err
+	}
+	if startTs != 0 {
+		return startTs, nil
+	}
+	ts, logical, err := pdClient.GetTS(ctx)
+	if err != nil {
+		return 0, cerror.ErrPDEtcdAPIError.GenWithStackByArgs("fail to get ts from pd client")
+	}
+	return oracle.ComposeTS(ts, logical), nil
+}
+
+// newReplicaConfig creates the ReplicaConfig of a new changefeed from the ChangefeedConfig
+func newReplicaConfig(changefeedConfig model.ChangefeedConfig) *config.ReplicaConfig {
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.ForceReplicate = changefeedConfig.ForceReplicate
+	replicaConfig.NoKeyTableStrategy = changefeedConfig.NoKeyTableStrategy
+	if changefeedConfig.MounterWorkerNum != 0 {
+		replicaConfig.Mounter.WorkerNum = changefeedConfig.MounterWorkerNum
+	}
+	if changefeedConfig.SinkConfig != nil {
+		replicaConfig.Sink = changefeedConfig.SinkConfig
+	}
+	if len(changefeedConfig.IgnoreTxnStartTs) != 0 {
+		replicaConfig.Filter.IgnoreTxnStartTs = changefeedConfig.IgnoreTxnStartTs
+	}
+	if len(changefeedConfig.FilterRules) != 0 {
+		replicaConfig.Filter.Rules = changefeedConfig.FilterRules
+	}
+	if len(changefeedConfig.Features) != 0 {
+		replicaConfig.Features = changefeedConfig.Features
+	}
+	return replicaConfig
+}
+
 // verifyUpdateChangefeedConfig verify ChangefeedConfig for update a changefeed
 func verifyUpdateChangefeedConfig(ctx context.Context, changefeedConfig model.ChangefeedConfig, oldInfo *model.ChangeFeedInfo) (*model.ChangeFeedInfo, error) {
 	newInfo, err := oldInfo.Clone()
 	if err != nil {
 		return nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(err.Error())
 	}
+	// the upstream can not be updated
+	if changefeedConfig.Upstream != nil && changefeedConfig.Upstream.ID() != oldInfo.Upstream.ID() {
+		return nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs("the upstream of a changefeed can not be updated")
+	}
+
 	// verify target_ts
 	if changefeedConfig.TargetTS != 0 {
 		if changefeedConfig.TargetTS <= newInfo.StartTs {
@@ -184,6 +240,19 @@ func verifyUpdateChangefeedConfig(ctx context.Context, changefeedConfig model.Ch
 		newInfo.Config.Sink = changefeedConfig.SinkConfig
 	}
 
+	// verify features
+	if len(changefeedConfig.Features) != 0 {
+		if newInfo.Config.Features == nil {
+			newInfo.Config.Features = make(map[string]bool, len(changefeedConfig.Features))
+		}
+		for name, enabled := range changefeedConfig.Features {
+			newInfo.Config.Features[name] = enabled
+		}
+		if err := newInfo.Config.Validate(); err != nil {
+			return nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+		}
+	}
+
 	// verify sink_uri
 	if changefeedConfig.SinkURI != "" {
 		newInfo.SinkURI = changefeedConfig.SinkURI
@@ -192,6 +261,17 @@ func verifyUpdateChangefeedConfig(ctx context.Context, changefeedConfig model.Ch
 		}
 	}
 
+	// verify standby_sink_uri
+	if changefeedConfig.StandbySinkURI != "" {
+		newInfo.StandbySinkURI = changefeedConfig.StandbySinkURI
+		if err := sink.Validate(ctx, changefeedConfig.StandbySinkURI, newInfo.Config, newInfo.Opts); err != nil {
+			return nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+		}
+	}
+	if err := newInfo.ValidateStandbySinkURI(); err != nil {
+		return nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByCause(err)
+	}
+
 	if !diff.Changed(oldInfo, newInfo) {
 		return nil, cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs("changefeed config is the same with the old one, do nothing")
 	}
@@ -199,6 +279,136 @@ func verifyUpdateChangefeedConfig(ctx context.Context, changefeedConfig model.Ch
 	return newInfo, nil
 }
 
+// verifyUpdateTablesConfig verify UpdateTablesConfig for add tables to or remove tables from a changefeed
+func verifyUpdateTablesConfig(cfg model.UpdateTablesConfig, info *model.ChangeFeedInfo, status *model.ChangeFeedStatus, remove bool, storage tidbkv.Storage) error {
+	if remove {
+		if cfg.StartTs != 0 {
+			return cerror.ErrAPIInvalidParam.GenWithStack("start_ts can not be specified when removing tables")
+		}
+		if cfg.Bootstrap {
+			return cerror.ErrAPIInvalidParam.GenWithStack("bootstrap can not be specified when removing tables")
+		}
+		_, err := filter.RemoveTablesFromRules(info.Config.Filter, cfg.Matcher)
+		return err
+	}
+	if _, err := filter.AddTablesToRules(info.Config.Filter, cfg.Matcher); err != nil {
+		return err
+	}
+	if cfg.StartTs != 0 && cfg.StartTs < status.CheckpointTs {
+		return cerror.ErrAPIInvalidParam.GenWithStack(
+			"start_ts %d is less than the checkpoint ts %d of the changefeed", cfg.StartTs, status.CheckpointTs)
+	}
+	if cfg.Bootstrap && !info.Config.Backfill.IsEnabled() {
+		return cerror.ErrAPIInvalidParam.GenWithStack(
+			"the added tables can not be bootstrapped since the backfill.upstream-uri of the changefeed is not set")
+	}
+	if info.TableBootstrap != nil {
+		return cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+			"the existing rows of the added tables are being backfilled, please retry later")
+	}
+	if info.Config.ReplicateNoKeyTables() {
+		return nil
+	}
+	// only check the tables matched by the matcher
+	replicaConfig := info.Config.Clone()
+	replicaConfig.Filter.Rules = cfg.Matcher
+	ineligibleTables, _, err := verifyTables(replicaConfig, storage, status.CheckpointTs)
+	if err != nil {
+		return err
+	}
+	if len(ineligibleTables) != 0 {
+		return cerror.ErrTableIneligible.GenWithStackByArgs(ineligibleTables)
+	}
+	return nil
+}
+
+// verifyPauseTablesConfig verifies that the tables to pause are being replicated
+// by the changefeed, and the tables to resume are paused.
+func verifyPauseTablesConfig(cfg model.PauseTablesConfig, info *model.ChangeFeedInfo, taskStatuses map[model.CaptureID]*model.TaskStatus, resume bool) error {
+	if len(cfg.TableIDs) == 0 {
+		return cerror.ErrAPIInvalidParam.GenWithStack("table_ids can not be empty")
+	}
+	for _, tableID := range cfg.TableIDs {
+		_, paused := info.PausedTables[tableID]
+		if resume {
+			if !paused {
+				return cerror.ErrAPIInvalidParam.GenWithStack("table %d is not paused", tableID)
+			}
+			continue
+		}
+		if paused {
+			return cerror.ErrAPIInvalidParam.GenWithStack("table %d is already paused", tableID)
+		}
+		if !isTableReplicated(taskStatuses, tableID) {
+			return cerror.ErrAPIInvalidParam.GenWithStack("table %d is not replicated by the changefeed", tableID)
+		}
+	}
+	return nil
+}
+
+// verifyRewindTableConfig verifies that the table is being replicated by the
+// changefeed, and the events of the table after the ts can be emitted again,
+// that is, the ts is not GCed and no DDL is executed after the ts.
+func verifyRewindTableConfig(
+	ctx context.Context, cfg model.RewindTableConfig, status *model.ChangeFeedStatus,
+	taskStatuses map[model.CaptureID]*model.TaskStatus, pdClient pd.Client, storage tidbkv.Storage,
+) error {
+	if !isTableReplicated(taskStatuses, cfg.TableID) {
+		return cerror.ErrAPIInvalidParam.GenWithStack("table %d is not replicated by the changefeed", cfg.TableID)
+	}
+	if cfg.Ts >= status.CheckpointTs {
+		return cerror.ErrAPIInvalidParam.GenWithStack(
+			"ts %d should be less than the checkpoint ts %d of the changefeed", cfg.Ts, status.CheckpointTs)
+	}
+	// the changefeed reads the snapshot at (checkpointTs - 1), which should not
+	// be less than the GC safepoint after the table is rewound
+	minServiceGCTs, err := gc.GetMinServiceGCSafepoint(ctx, pdClient)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.Ts <= minServiceGCTs {
+		return cerror.ErrAPIInvalidParam.GenWithStack(
+			"ts %d is earlier than or equal to GC safepoint at %d", cfg.Ts, minServiceGCTs)
+	}
+	// the processors only keep the schema snapshots after the checkpoint ts,
+	// so the table can not be rewound across DDLs
+	getSchemaVersion := func(ts uint64) (int64, error) {
+		meta, err := kv.GetSnapshotMeta(storage, ts)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		version, err := meta.GetSchemaVersion()
+		return version, errors.Trace(err)
+	}
+	rewindVersion, err := getSchemaVersion(cfg.Ts)
+	if err != nil {
+		return err
+	}
+	checkpointVersion, err := getSchemaVersion(status.CheckpointTs - 1)
+	if err != nil {
+		return err
+	}
+	if rewindVersion != checkpointVersion {
+		return cerror.ErrAPIInvalidParam.GenWithStack(
+			"some DDLs are executed between ts %d and the checkpoint ts %d, the table can not be rewound across DDLs",
+			cfg.Ts, status.CheckpointTs)
+	}
+	return nil
+}
+
+// isTableReplicated returns whether the table is being replicated by one of the captures
+func isTableReplicated(taskStatuses map[model.CaptureID]*model.TaskStatus, tableID model.TableID) bool {
+	for _, status := range taskStatuses {
+		if _, exist := status.Tables[tableID]; exist {
+			return true
+		}
+		if _, exist := status.Operation[tableID]; exist {
+			return true
+		}
+	}
+	return false
+}
+
 func verifyTables(replicaConfig *config.ReplicaConfig, storage tidbkv.Storage, startTs uint64) (ineligibleTables, eligibleTables []model.TableName, err error) {
 	filter, err := filter.NewFilter(replicaConfig)
 	if err != nil {
@@ -49,3 +49,57 @@ func TestVerifyUpdateChangefeedConfig(t *testing.T) {
 	require.Nil(t, err)
 	require.NotNil(t, newInfo)
 }
+
+func TestVerifyUpdateTablesConfig(t *testing.T) {
+	info := &model.ChangeFeedInfo{Config: config.GetDefaultReplicaConfig()}
+	status := &model.ChangeFeedStatus{CheckpointTs: 100}
+	matcher := []string{"test.t1"}
+
+	err := verifyUpdateTablesConfig(model.UpdateTablesConfig{Matcher: matcher, Bootstrap: true}, info, status, true, nil)
+	require.Regexp(t, ".*bootstrap can not be specified when removing tables.*", err)
+	err = verifyUpdateTablesConfig(model.UpdateTablesConfig{Matcher: matcher, StartTs: 50}, info, status, false, nil)
+	require.Regexp(t, ".*start_ts 50 is less than the checkpoint ts 100.*", err)
+	err = verifyUpdateTablesConfig(model.UpdateTablesConfig{Matcher: matcher, Bootstrap: true}, info, status, false, nil)
+	require.Regexp(t, ".*backfill.upstream-uri of the changefeed is not set.*", err)
+
+	info.Config.Backfill = &config.BackfillConfig{UpstreamURI: "mysql://127.0.0.1:4000/"}
+	info.TableBootstrap = &model.TableBootstrap{Ts: 100, TableIDs: []model.TableID{1}}
+	err = verifyUpdateTablesConfig(model.UpdateTablesConfig{Matcher: matcher, Bootstrap: true}, info, status, false, nil)
+	require.Regexp(t, ".*being backfilled.*", err)
+}
+
+func TestVerifyPauseTablesConfig(t *testing.T) {
+	info := &model.ChangeFeedInfo{PausedTables: map[model.TableID]model.Ts{3: 100}}
+	taskStatuses := map[model.CaptureID]*model.TaskStatus{
+		"capture-1": {Tables: map[model.TableID]*model.TableReplicaInfo{1: {}}},
+		"capture-2": {Operation: map[model.TableID]*model.TableOperation{2: {}}},
+	}
+
+	err := verifyPauseTablesConfig(model.PauseTablesConfig{}, info, taskStatuses, false)
+	require.Regexp(t, ".*table_ids can not be empty.*", err)
+
+	err = verifyPauseTablesConfig(model.PauseTablesConfig{TableIDs: []model.TableID{1, 2}}, info, taskStatuses, false)
+	require.Nil(t, err)
+	err = verifyPauseTablesConfig(model.PauseTablesConfig{TableIDs: []model.TableID{1, 3}}, info, taskStatuses, false)
+	require.Regexp(t, ".*table 3 is already paused.*", err)
+	err = verifyPauseTablesConfig(model.PauseTablesConfig{TableIDs: []model.TableID{4}}, info, taskStatuses, false)
+	require.Regexp(t, ".*table 4 is not replicated by the changefeed.*", err)
+
+	err = verifyPauseTablesConfig(model.PauseTablesConfig{TableIDs: []model.TableID{3}}, info, taskStatuses, true)
+	require.Nil(t, err)
+	err = verifyPauseTablesConfig(model.PauseTablesConfig{TableIDs: []model.TableID{1}}, info, taskStatuses, true)
+	require.Regexp(t, ".*table 1 is not paused.*", err)
+}
+
+func TestVerifyRewindTableConfig(t *testing.T) {
+	ctx := context.Background()
+	status := &model.ChangeFeedStatus{CheckpointTs: 100}
+	taskStatuses := map[model.CaptureID]*model.TaskStatus{
+		"capture-1": {Tables: map[model.TableID]*model.TableReplicaInfo{1: {}}},
+	}
+
+	err := verifyRewindTableConfig(ctx, model.RewindTableConfig{TableID: 2, Ts: 50}, status, taskStatuses, nil, nil)
+	require.Regexp(t, ".*table 2 is not replicated by the changefeed.*", err)
+	err = verifyRewindTableConfig(ctx, model.RewindTableConfig{TableID: 1, Ts: 100}, status, taskStatuses, nil, nil)
+	require.Regexp(t, ".*ts 100 should be less than the checkpoint ts 100.*", err)
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/ticdc/pkg/orchestrator"
+)
+
+// ownerStandby is the reactor of the etcd worker run by a capture while it is
+// campaigning for the owner. Before the capture is elected, it does nothing but
+// keeps the global state warm by watching etcd. Once the capture is elected,
+// the owner is promoted to tick on the same etcd worker, so that it resumes
+// scheduling immediately with the warm state instead of loading all the state
+// from etcd again.
+type ownerStandby struct {
+	mu     sync.Mutex
+	owner  orchestrator.Reactor
+	exited bool
+}
+
+func newOwnerStandby() *ownerStandby {
+	return &ownerStandby{}
+}
+
+// Tick implements the Reactor interface
+func (s *ownerStandby) Tick(ctx context.Context, state orchestrator.ReactorState) (orchestrator.ReactorState, error) {
+	s.mu.Lock()
+	owner := s.owner
+	s.mu.Unlock()
+	if owner == nil {
+		// the standby never patches the state
+		return state, nil
+	}
+	return owner.Tick(ctx, state)
+}
+
+// promote hands over the warm state to the owner, it returns false if the etcd
+// worker of the standby has exited.
+func (s *ownerStandby) promote(owner orchestrator.Reactor) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.exited {
+		return false
+	}
+	s.owner = owner
+	return true
+}
+
+// markExited marks that the etcd worker of the standby has exited.
+func (s *ownerStandby) markExited() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exited = true
+}
@@ -0,0 +1,59 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/orchestrator"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOwnerReactor struct {
+	ticks int
+}
+
+func (r *mockOwnerReactor) Tick(ctx context.Context, state orchestrator.ReactorState) (orchestrator.ReactorState, error) {
+	r.ticks++
+	state.(*orchestrator.GlobalReactorState).Captures["owner"] = &model.CaptureInfo{ID: "owner"}
+	return state, nil
+}
+
+func TestOwnerStandby(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	state := orchestrator.NewGlobalState().(*orchestrator.GlobalReactorState)
+	standby := newOwnerStandby()
+	// the standby never touches the state
+	nextState, err := standby.Tick(ctx, state)
+	require.Nil(t, err)
+	require.Equal(t, state, nextState)
+	require.Empty(t, state.Captures)
+
+	// the owner ticks with the warm state after it is promoted
+	owner := &mockOwnerReactor{}
+	require.True(t, standby.promote(owner))
+	_, err = standby.Tick(ctx, state)
+	require.Nil(t, err)
+	require.Equal(t, 1, owner.ticks)
+	require.Contains(t, state.Captures, "owner")
+
+	// the owner can not be promoted if the etcd worker has exited
+	standby = newOwnerStandby()
+	standby.markExited()
+	require.False(t, standby.promote(owner))
+}
@@ -0,0 +1,433 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/owner"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	"go.etcd.io/etcd/clientv3"
+	"go.uber.org/zap"
+)
+
+const (
+	// optManagedBy is the changefeed option which marks the changefeeds
+	// created by the reconciliation.
+	optManagedBy        = "_managed-by"
+	managedByReconciler = "reconciler"
+
+	reconcileActionCreate = "create"
+	reconcileActionPause  = "pause"
+	reconcileActionUpdate = "update"
+	reconcileActionResume = "resume"
+	reconcileActionRemove = "remove"
+)
+
+// changefeedSpec is a declarative changefeed spec, it is in the format of the
+// create changefeed API.
+type changefeedSpec struct {
+	// source is the file or the etcd key of the spec
+	source string
+	config model.ChangefeedConfig
+	// err is not nil if the spec is invalid
+	err error
+}
+
+// parseChangefeedSpec parses a changefeed spec, the returned spec carries the
+// error if the spec is invalid.
+func parseChangefeedSpec(source string, data []byte) *changefeedSpec {
+	spec := &changefeedSpec{source: source}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// reject unknown fields to find out the typos in the specs
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&spec.config); err != nil {
+		spec.err = cerror.ErrInvalidChangefeedSpec.GenWithStackByArgs(source, err.Error())
+		return spec
+	}
+	if err := model.ValidateChangefeedID(spec.config.ID); err != nil {
+		spec.err = cerror.ErrInvalidChangefeedSpec.GenWithStackByArgs(source, "invalid changefeed_id: "+spec.config.ID)
+		return spec
+	}
+	if spec.config.SinkURI == "" {
+		spec.err = cerror.ErrInvalidChangefeedSpec.GenWithStackByArgs(source, "sink_uri is empty")
+	}
+	return spec
+}
+
+// specLoader loads the declarative changefeed specs.
+type specLoader interface {
+	// load returns all the specs sorted by their sources, the invalid specs
+	// are returned with errors instead of failing the whole loading.
+	load(ctx context.Context) ([]*changefeedSpec, error)
+	// source returns the location of the specs.
+	source() string
+}
+
+// dirSpecLoader loads the specs from the "*.json" files in a directory.
+type dirSpecLoader struct {
+	dir string
+}
+
+func (l *dirSpecLoader) load(ctx context.Context) ([]*changefeedSpec, error) {
+	files, err := filepath.Glob(filepath.Join(l.dir, "*.json"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// the files are returned in lexical order
+	specs := make([]*changefeedSpec, 0, len(files))
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Annotatef(err, "read changefeed spec %s", file)
+		}
+		specs = append(specs, parseChangefeedSpec(file, data))
+	}
+	return specs, nil
+}
+
+func (l *dirSpecLoader) source() string {
+	return l.dir
+}
+
+// etcdSpecLoader loads the specs from the values under an etcd prefix.
+type etcdSpecLoader struct {
+	client *etcd.Client
+	prefix string
+}
+
+func (l *etcdSpecLoader) load(ctx context.Context) ([]*changefeedSpec, error) {
+	resp, err := l.client.Get(ctx, l.prefix, clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPDEtcdAPIError, err)
+	}
+	specs := make([]*changefeedSpec, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		specs = append(specs, parseChangefeedSpec(string(kv.Key), kv.Value))
+	}
+	return specs, nil
+}
+
+func (l *etcdSpecLoader) source() string {
+	return l.prefix
+}
+
+// reconcileOperator applies the actions of the reconciliation.
+type reconcileOperator interface {
+	createChangefeed(ctx context.Context, spec model.ChangefeedConfig) error
+	updateChangefeed(ctx context.Context, id model.ChangeFeedID, info *model.ChangeFeedInfo, spec model.ChangefeedConfig) error
+	enqueueAdminJob(job model.AdminJob) error
+}
+
+// captureReconcileOperator applies the actions through the owner of the capture.
+type captureReconcileOperator struct {
+	capture *Capture
+}
+
+func (o *captureReconcileOperator) createChangefeed(ctx context.Context, spec model.ChangefeedConfig) error {
+	info, err := verifyCreateChangefeedConfig(ctx, spec, o.capture)
+	if err != nil {
+		return err
+	}
+	info.Opts[optManagedBy] = managedByReconciler
+	return o.capture.etcdClient.CreateChangefeedInfo(ctx, info, spec.ID)
+}
+
+func (o *captureReconcileOperator) updateChangefeed(
+	ctx context.Context, id model.ChangeFeedID, info *model.ChangeFeedInfo, spec model.ChangefeedConfig,
+) error {
+	newInfo, err := verifyUpdateChangefeedConfig(ctx, spec, info)
+	if err != nil {
+		return err
+	}
+	return o.capture.etcdClient.SaveChangeFeedInfo(ctx, newInfo, id)
+}
+
+func (o *captureReconcileOperator) enqueueAdminJob(job model.AdminJob) error {
+	return o.capture.OperateOwnerUnderLock(func(owner *owner.Owner) error {
+		owner.EnqueueJob(job)
+		return nil
+	})
+}
+
+// reconciler makes the changefeeds match the declarative specs. The drifted
+// changefeeds are paused, updated and then resumed, because a changefeed can
+// only be updated when it is stopped.
+type reconciler struct {
+	capture  *Capture
+	loader   specLoader
+	operator reconcileOperator
+	prune    bool
+	interval time.Duration
+
+	// paused is the changefeeds paused by the reconciliation to be updated,
+	// they are resumed once they match the specs.
+	paused map[model.ChangeFeedID]struct{}
+
+	statusMu sync.Mutex
+	status   *model.ReconcileStatus
+}
+
+func newReconciler(capture *Capture, cfg *config.ReconcileConfig) *reconciler {
+	var loader specLoader
+	if cfg.SpecDir != "" {
+		loader = &dirSpecLoader{dir: cfg.SpecDir}
+	} else {
+		loader = &etcdSpecLoader{client: capture.etcdClient.Client, prefix: cfg.EtcdPrefix}
+	}
+	return &reconciler{
+		capture:  capture,
+		loader:   loader,
+		operator: &captureReconcileOperator{capture: capture},
+		prune:    cfg.Prune,
+		interval: time.Duration(cfg.Interval),
+		paused:   make(map[model.ChangeFeedID]struct{}),
+		status: &model.ReconcileStatus{
+			Enabled: true,
+			Source:  loader.source(),
+		},
+	}
+}
+
+// run reconciles the changefeeds periodically when the capture is the owner.
+func (r *reconciler) run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if !r.capture.IsOwner() {
+			// the changefeeds paused by the reconciliation are not resumed by
+			// the new owner, they need to be resumed manually.
+			r.paused = make(map[model.ChangeFeedID]struct{})
+			continue
+		}
+		var statusProvider owner.StatusProvider
+		var infos map[model.ChangeFeedID]*model.ChangeFeedInfo
+		specs, err := r.loader.load(ctx)
+		if err == nil {
+			err = r.capture.OperateOwnerUnderLock(func(o *owner.Owner) error {
+				statusProvider = o.StatusProvider()
+				return nil
+			})
+		}
+		if err == nil {
+			infos, err = statusProvider.GetAllChangeFeedInfo(ctx)
+		}
+		if err != nil {
+			// nothing is changed if the specs or the changefeeds are unknown,
+			// to avoid removing changefeeds by mistake.
+			log.Warn("reconcile changefeeds failed", zap.String("source", r.loader.source()), zap.Error(err))
+			r.setStatus(nil, err)
+			continue
+		}
+		r.setStatus(r.reconcile(ctx, specs, infos), nil)
+	}
+}
+
+// reconcile takes one step to make the changefeeds match the specs and
+// returns the reconciliation status of the changefeeds.
+func (r *reconciler) reconcile(
+	ctx context.Context, specs []*changefeedSpec, infos map[model.ChangeFeedID]*model.ChangeFeedInfo,
+) []*model.ChangefeedReconcileStatus {
+	statuses := make([]*model.ChangefeedReconcileStatus, 0, len(specs))
+	declared := make(map[model.ChangeFeedID]struct{}, len(specs))
+	// the changefeeds declared by the invalid specs may be unknown, so
+	// nothing is pruned until all the specs are valid.
+	invalid := false
+	for _, spec := range specs {
+		status := &model.ChangefeedReconcileStatus{
+			ID:   spec.config.ID,
+			Spec: spec.source,
+		}
+		statuses = append(statuses, status)
+		if spec.err == nil {
+			if _, ok := declared[spec.config.ID]; ok {
+				spec.err = cerror.ErrInvalidChangefeedSpec.GenWithStackByArgs(
+					spec.source, "duplicated changefeed_id: "+spec.config.ID)
+			}
+		}
+		if spec.config.ID != "" {
+			declared[spec.config.ID] = struct{}{}
+		}
+		if spec.err != nil {
+			invalid = true
+			status.State = model.ReconcileStateInvalid
+			status.Error = spec.err.Error()
+			continue
+		}
+		r.reconcileChangefeed(ctx, spec, infos[spec.config.ID], status)
+	}
+
+	undeclared := make([]model.ChangeFeedID, 0)
+	for id := range infos {
+		if _, ok := declared[id]; !ok {
+			undeclared = append(undeclared, id)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, id := range undeclared {
+		status := &model.ChangefeedReconcileStatus{ID: id, State: model.ReconcileStateUnmanaged}
+		statuses = append(statuses, status)
+		if infos[id].Opts[optManagedBy] != managedByReconciler {
+			continue
+		}
+		status.State = model.ReconcileStateOrphaned
+		if !r.prune {
+			continue
+		}
+		if invalid {
+			log.Warn("skip removing changefeed whose spec is removed since some specs are invalid",
+				zap.String("changefeed", id))
+			continue
+		}
+		status.Action = reconcileActionRemove
+		if err := r.operator.enqueueAdminJob(model.AdminJob{CfID: id, Type: model.AdminRemove}); err != nil {
+			status.Error = err.Error()
+			continue
+		}
+		delete(r.paused, id)
+		log.Info("remove changefeed whose spec is removed", zap.String("changefeed", id))
+	}
+	return statuses
+}
+
+func (r *reconciler) reconcileChangefeed(
+	ctx context.Context, spec *changefeedSpec, info *model.ChangeFeedInfo, status *model.ChangefeedReconcileStatus,
+) {
+	id := spec.config.ID
+	if info == nil {
+		status.State = model.ReconcileStateMissing
+		status.Action = reconcileActionCreate
+		if err := r.operator.createChangefeed(ctx, spec.config); err != nil {
+			status.Error = err.Error()
+			return
+		}
+		log.Info("create changefeed from spec", zap.String("changefeed", id), zap.String("spec", spec.source))
+		return
+	}
+
+	status.DriftFields = changefeedSpecDrift(spec.config, info)
+	if len(status.DriftFields) == 0 {
+		status.State = model.ReconcileStateInSync
+		if _, ok := r.paused[id]; ok && info.State == model.StateStopped {
+			status.Action = reconcileActionResume
+			if err := r.operator.enqueueAdminJob(model.AdminJob{CfID: id, Type: model.AdminResume}); err != nil {
+				status.Error = err.Error()
+				return
+			}
+			log.Info("resume changefeed updated by spec", zap.String("changefeed", id))
+		}
+		delete(r.paused, id)
+		return
+	}
+
+	status.State = model.ReconcileStateDrifted
+	switch info.State {
+	case model.StateNormal:
+		status.Action = reconcileActionPause
+		if err := r.operator.enqueueAdminJob(model.AdminJob{CfID: id, Type: model.AdminStop}); err != nil {
+			status.Error = err.Error()
+			return
+		}
+		r.paused[id] = struct{}{}
+		log.Info("pause changefeed to apply spec", zap.String("changefeed", id),
+			zap.Strings("drift-fields", status.DriftFields))
+	case model.StateStopped:
+		status.Action = reconcileActionUpdate
+		if err := r.operator.updateChangefeed(ctx, id, info, spec.config); err != nil {
+			status.Error = err.Error()
+			return
+		}
+		log.Info("update changefeed by spec", zap.String("changefeed", id),
+			zap.Strings("drift-fields", status.DriftFields))
+	default:
+		status.Error = "changefeed can not be updated in state " + string(info.State)
+	}
+}
+
+// changefeedSpecDrift returns the fields of the changefeed which are different
+// from the spec. Only the fields which can be updated are checked, and the
+// zero value fields of the spec are ignored, which is the same as the update
+// changefeed API.
+func changefeedSpecDrift(spec model.ChangefeedConfig, info *model.ChangeFeedInfo) []string {
+	var fields []string
+	if spec.SinkURI != info.SinkURI {
+		fields = append(fields, "sink_uri")
+	}
+	if spec.TargetTS != 0 && spec.TargetTS != info.TargetTs {
+		fields = append(fields, "target_ts")
+	}
+	if info.Config == nil {
+		return append(fields, "replica_config")
+	}
+	if len(spec.FilterRules) != 0 && !jsonEqual(spec.FilterRules, info.Config.Filter.Rules) {
+		fields = append(fields, "filter_rules")
+	}
+	if len(spec.IgnoreTxnStartTs) != 0 && !jsonEqual(spec.IgnoreTxnStartTs, info.Config.Filter.IgnoreTxnStartTs) {
+		fields = append(fields, "ignore_txn_start_ts")
+	}
+	if spec.MounterWorkerNum != 0 && spec.MounterWorkerNum != info.Config.Mounter.WorkerNum {
+		fields = append(fields, "mounter_worker_num")
+	}
+	if spec.SinkConfig != nil && !jsonEqual(spec.SinkConfig, info.Config.Sink) {
+		fields = append(fields, "sink_config")
+	}
+	return fields
+}
+
+// jsonEqual compares two values by their json encodings, both the specs and the
+// changefeed infos are decoded from json, so the encodings are comparable.
+func jsonEqual(a, b interface{}) bool {
+	aData, aErr := json.Marshal(a)
+	bData, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aData, bData)
+}
+
+func (r *reconciler) setStatus(changefeeds []*model.ChangefeedReconcileStatus, err error) {
+	r.statusMu.Lock()
+	defer r.statusMu.Unlock()
+	now := model.JSONTime(time.Now())
+	r.status = &model.ReconcileStatus{
+		Enabled:           true,
+		Source:            r.loader.source(),
+		LastReconcileTime: &now,
+		Changefeeds:       changefeeds,
+	}
+	if err != nil {
+		r.status.Error = err.Error()
+	}
+}
+
+// getStatus returns the status of the last reconciliation.
+func (r *reconciler) getStatus() *model.ReconcileStatus {
+	r.statusMu.Lock()
+	defer r.statusMu.Unlock()
+	return r.status
+}
@@ -0,0 +1,202 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+type mockReconcileOperator struct {
+	created []model.ChangeFeedID
+	updated []model.ChangeFeedID
+	jobs    []model.AdminJob
+}
+
+func (o *mockReconcileOperator) createChangefeed(ctx context.Context, spec model.ChangefeedConfig) error {
+	o.created = append(o.created, spec.ID)
+	return nil
+}
+
+func (o *mockReconcileOperator) updateChangefeed(
+	ctx context.Context, id model.ChangeFeedID, info *model.ChangeFeedInfo, spec model.ChangefeedConfig,
+) error {
+	o.updated = append(o.updated, id)
+	return nil
+}
+
+func (o *mockReconcileOperator) enqueueAdminJob(job model.AdminJob) error {
+	o.jobs = append(o.jobs, job)
+	return nil
+}
+
+func TestDirSpecLoader(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{
+		"b.json":    `{"changefeed_id": "cf-b", "sink_uri": "blackhole://"}`,
+		"a.json":    `{"changefeed_id": "cf-a", "sink_uri": "blackhole://", "filter_rules": ["test.*"]}`,
+		"c.json":    `{"changefeed_id": "cf-c", "sink-uri": "blackhole://"}`,
+		"d.json":    `{"changefeed_id": "cf-d"}`,
+		"e.json":    `{"changefeed_id": "cf_e", "sink_uri": "blackhole://"}`,
+		"README.md": `not a spec`,
+	}
+	for name, content := range files {
+		require.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	loader := &dirSpecLoader{dir: dir}
+	specs, err := loader.load(context.Background())
+	require.Nil(t, err)
+	require.Len(t, specs, 5)
+	require.Equal(t, filepath.Join(dir, "a.json"), specs[0].source)
+	require.Nil(t, specs[0].err)
+	require.Equal(t, "cf-a", specs[0].config.ID)
+	require.Equal(t, []string{"test.*"}, specs[0].config.FilterRules)
+	require.Nil(t, specs[1].err)
+	require.Equal(t, "cf-b", specs[1].config.ID)
+	require.Regexp(t, ".*unknown field.*", specs[2].err)
+	require.Regexp(t, ".*sink_uri is empty.*", specs[3].err)
+	require.Regexp(t, ".*invalid changefeed_id.*", specs[4].err)
+}
+
+func TestChangefeedSpecDrift(t *testing.T) {
+	t.Parallel()
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Filter.Rules = []string{"test.*"}
+	info := &model.ChangeFeedInfo{
+		SinkURI:  "blackhole://",
+		TargetTs: 100,
+		Config:   replicaConfig,
+	}
+	spec := model.ChangefeedConfig{ID: "test", SinkURI: "blackhole://"}
+	require.Empty(t, changefeedSpecDrift(spec, info))
+
+	spec.FilterRules = []string{"test.*"}
+	spec.TargetTS = 100
+	spec.SinkConfig = config.GetDefaultReplicaConfig().Sink
+	require.Empty(t, changefeedSpecDrift(spec, info))
+
+	spec.SinkURI = "mysql://127.0.0.1:3306/"
+	spec.FilterRules = []string{"test.*", "test2.*"}
+	spec.TargetTS = 200
+	spec.MounterWorkerNum = replicaConfig.Mounter.WorkerNum + 1
+	spec.SinkConfig = &config.SinkConfig{Protocol: "canal-json"}
+	require.Equal(t, []string{
+		"sink_uri", "target_ts", "filter_rules", "mounter_worker_num", "sink_config",
+	}, changefeedSpecDrift(spec, info))
+}
+
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	operator := &mockReconcileOperator{}
+	r := &reconciler{
+		operator: operator,
+		paused:   make(map[model.ChangeFeedID]struct{}),
+	}
+	newInfo := func(sinkURI string, state model.FeedState, managed bool) *model.ChangeFeedInfo {
+		info := &model.ChangeFeedInfo{
+			SinkURI: sinkURI,
+			State:   state,
+			Config:  config.GetDefaultReplicaConfig(),
+			Opts:    make(map[string]string),
+		}
+		if managed {
+			info.Opts[optManagedBy] = managedByReconciler
+		}
+		return info
+	}
+	newSpec := func(id, sinkURI string) *changefeedSpec {
+		return &changefeedSpec{
+			source: id + ".json",
+			config: model.ChangefeedConfig{ID: id, SinkURI: sinkURI},
+		}
+	}
+	specs := []*changefeedSpec{
+		newSpec("missing", "blackhole://"),
+		newSpec("in-sync", "blackhole://"),
+		newSpec("drifted", "blackhole://new"),
+		newSpec("drifted", "blackhole://dup"),
+		{source: "invalid.json", err: errors.New("invalid spec")},
+	}
+	infos := map[model.ChangeFeedID]*model.ChangeFeedInfo{
+		"in-sync":   newInfo("blackhole://", model.StateNormal, true),
+		"drifted":   newInfo("blackhole://old", model.StateNormal, true),
+		"orphaned":  newInfo("blackhole://", model.StateNormal, true),
+		"unmanaged": newInfo("blackhole://", model.StateNormal, false),
+	}
+
+	// the drifted changefeed is paused first
+	statuses := r.reconcile(ctx, specs, infos)
+	require.Len(t, statuses, 7)
+	require.Equal(t, model.ReconcileStateMissing, statuses[0].State)
+	require.Equal(t, reconcileActionCreate, statuses[0].Action)
+	require.Equal(t, model.ReconcileStateInSync, statuses[1].State)
+	require.Empty(t, statuses[1].Action)
+	require.Equal(t, model.ReconcileStateDrifted, statuses[2].State)
+	require.Equal(t, []string{"sink_uri"}, statuses[2].DriftFields)
+	require.Equal(t, reconcileActionPause, statuses[2].Action)
+	require.Equal(t, model.ReconcileStateInvalid, statuses[3].State)
+	require.Regexp(t, ".*duplicated changefeed_id.*", statuses[3].Error)
+	require.Equal(t, model.ReconcileStateInvalid, statuses[4].State)
+	require.Equal(t, "orphaned", statuses[5].ID)
+	require.Equal(t, model.ReconcileStateOrphaned, statuses[5].State)
+	require.Empty(t, statuses[5].Action)
+	require.Equal(t, "unmanaged", statuses[6].ID)
+	require.Equal(t, model.ReconcileStateUnmanaged, statuses[6].State)
+	require.Equal(t, []model.ChangeFeedID{"missing"}, operator.created)
+	require.Equal(t, []model.AdminJob{{CfID: "drifted", Type: model.AdminStop}}, operator.jobs)
+
+	// the paused changefeed is updated
+	infos["drifted"].State = model.StateStopped
+	statuses = r.reconcile(ctx, specs, infos)
+	require.Equal(t, reconcileActionUpdate, statuses[2].Action)
+	require.Equal(t, []model.ChangeFeedID{"drifted"}, operator.updated)
+
+	// nothing is removed while some specs are invalid
+	r.prune = true
+	operator.jobs = nil
+	infos["drifted"].SinkURI = "blackhole://new"
+	statuses = r.reconcile(ctx, specs, infos)
+	require.Equal(t, model.ReconcileStateInSync, statuses[2].State)
+	require.Equal(t, reconcileActionResume, statuses[2].Action)
+	require.Equal(t, model.ReconcileStateOrphaned, statuses[5].State)
+	require.Empty(t, statuses[5].Action)
+	require.Equal(t, []model.AdminJob{{CfID: "drifted", Type: model.AdminResume}}, operator.jobs)
+	require.Empty(t, r.paused)
+
+	// the orphaned changefeed is removed once all the specs are valid
+	operator.jobs = nil
+	specs = specs[:3]
+	statuses = r.reconcile(ctx, specs, infos)
+	require.Len(t, statuses, 5)
+	require.Equal(t, "orphaned", statuses[3].ID)
+	require.Equal(t, reconcileActionRemove, statuses[3].Action)
+	require.Empty(t, statuses[4].Action)
+	require.Equal(t, []model.AdminJob{{CfID: "orphaned", Type: model.AdminRemove}}, operator.jobs)
+
+	// the changefeed stopped by users is not resumed
+	operator.jobs = nil
+	statuses = r.reconcile(ctx, specs, infos)
+	require.Empty(t, statuses[2].Action)
+	require.Len(t, operator.jobs, 1)
+}
@@ -0,0 +1,90 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/filter"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+)
+
+// SnapshotColumns returns the columns of the table read from a snapshot for
+// MountSnapshotRow, in the order of the values.
+func SnapshotColumns(tableInfo *model.TableInfo) []*timodel.ColumnInfo {
+	cols := make([]*timodel.ColumnInfo, 0, len(tableInfo.RowColumnsOffset))
+	for _, col := range tableInfo.Columns {
+		if model.IsColCDCVisible(col) {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// MountSnapshotRow mounts a row read from a snapshot of the table by TiDB as
+// an insert committed at commitTs, the values are in the same formats as the
+// values mounted from the KV entries. The values are the texts of the
+// SnapshotColumns of the table in the session time zone tz, nil for NULL. It
+// returns nil if the row is ignored by the row filter.
+func MountSnapshotRow(
+	tableInfo *model.TableInfo, physicalTableID model.TableID, partition string,
+	values [][]byte, commitTs model.Ts, tz *time.Location, rowFilter *filter.RowFilter,
+) (*model.RowChangedEvent, error) {
+	sc := &stmtctx.StatementContext{TimeZone: tz}
+	datums := make(map[int64]types.Datum, len(values))
+	var size int64
+	for i, col := range SnapshotColumns(tableInfo) {
+		if values[i] == nil {
+			datums[col.ID] = types.Datum{}
+			continue
+		}
+		d := types.NewBytesDatum(values[i])
+		datum, err := d.ConvertTo(sc, &col.FieldType)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		datums[col.ID] = datum
+		size += int64(len(values[i]))
+	}
+	ignore, err := rowFilter.ShouldIgnoreRow(tableInfo, datums, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if ignore {
+		return nil, nil
+	}
+	cols, err := datum2Column(tableInfo, datums, true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &model.RowChangedEvent{
+		StartTs:          commitTs,
+		CommitTs:         commitTs,
+		TableInfoVersion: tableInfo.TableInfoVersion,
+		Table: &model.TableName{
+			Schema:      tableInfo.TableName.Schema,
+			Table:       tableInfo.TableName.Table,
+			TableID:     physicalTableID,
+			IsPartition: tableInfo.GetPartitionInfo() != nil,
+			Partition:   partition,
+		},
+		Columns:         cols,
+		IndexColumns:    tableInfo.IndexColumnsOffset,
+		ApproximateSize: size,
+	}, nil
+}
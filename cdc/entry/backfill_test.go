@@ -0,0 +1,95 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/filter"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMountSnapshotRow(t *testing.T) {
+	t.Parallel()
+
+	newCol := func(id int64, name string, tp byte) *timodel.ColumnInfo {
+		return &timodel.ColumnInfo{
+			ID:        id,
+			Name:      timodel.NewCIStr(name),
+			Offset:    int(id - 1),
+			State:     timodel.StatePublic,
+			FieldType: *types.NewFieldType(tp),
+		}
+	}
+	id := newCol(1, "id", mysql.TypeLonglong)
+	id.Flag = mysql.PriKeyFlag | mysql.UnsignedFlag | mysql.NotNullFlag
+	name := newCol(2, "name", mysql.TypeVarchar)
+	name.Charset, name.Collate = "utf8mb4", "utf8mb4_bin"
+	state := newCol(3, "state", mysql.TypeEnum)
+	state.Elems = []string{"on", "off"}
+	flags := newCol(4, "flags", mysql.TypeBit)
+	flags.Flen = 8
+	ts := newCol(5, "ts", mysql.TypeTimestamp)
+	price := newCol(6, "price", mysql.TypeNewDecimal)
+	price.Flen, price.Decimal = 10, 2
+	doc := newCol(7, "doc", mysql.TypeJSON)
+	tableInfo := model.WrapTableInfo(1, "test", 10, &timodel.TableInfo{
+		ID:         100,
+		Name:       timodel.NewCIStr("t"),
+		PKIsHandle: true,
+		Columns:    []*timodel.ColumnInfo{id, name, state, flags, ts, price, doc},
+	})
+	require.Len(t, SnapshotColumns(tableInfo), 7)
+
+	values := [][]byte{
+		[]byte("1"), []byte("abc"), []byte("off"), {0x05},
+		[]byte("2021-01-02 03:04:05"), []byte("1.50"), nil,
+	}
+	row, err := MountSnapshotRow(tableInfo, 100, "", values, 400, time.UTC, nil)
+	require.Nil(t, err)
+	require.Equal(t, model.Ts(400), row.CommitTs)
+	require.Equal(t, &model.TableName{Schema: "test", Table: "t", TableID: 100}, row.Table)
+	require.Nil(t, row.PreColumns)
+	require.Equal(t, int64(31), row.ApproximateSize)
+	expected := []interface{}{uint64(1), []byte("abc"), uint64(2), uint64(5), "2021-01-02 03:04:05", "1.50", nil}
+	for i, col := range row.Columns {
+		require.Equal(t, expected[i], col.Value, col.Name)
+	}
+	require.True(t, row.Columns[0].Flag.IsHandleKey())
+	require.Equal(t, "utf8mb4", row.Columns[1].Charset)
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Filter.RowFilters = []*config.RowFilterRule{
+		{Matcher: []string{"test.t"}, Expression: "id > 1"},
+	}
+	rowFilter, err := filter.NewRowFilter(cfg, time.UTC)
+	require.Nil(t, err)
+	row, err = MountSnapshotRow(tableInfo, 100, "", values, 400, time.UTC, rowFilter)
+	require.Nil(t, err)
+	require.Nil(t, row)
+	values[0] = []byte("2")
+	row, err = MountSnapshotRow(tableInfo, 100, "", values, 400, time.UTC, rowFilter)
+	require.Nil(t, err)
+	require.Equal(t, uint64(2), row.Columns[0].Value)
+
+	values[2] = []byte("unknown")
+	_, err = MountSnapshotRow(tableInfo, 100, "", values, 400, time.UTC, nil)
+	require.NotNil(t, err)
+}
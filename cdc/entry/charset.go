@@ -0,0 +1,81 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+// CharsetConverter converts the values of the non-binary string columns to
+// utf8mb4.
+type CharsetConverter struct {
+	replaceInvalid bool
+}
+
+// NewCharsetConverter creates a CharsetConverter, it returns nil if the values
+// are not converted.
+func NewCharsetConverter(cfg *config.ReplicaConfig) *CharsetConverter {
+	if !cfg.Charset.IsForceUTF8MB4() {
+		return nil
+	}
+	return &CharsetConverter{
+		replaceInvalid: cfg.Charset.GetInvalidPolicy() == config.CharsetInvalidPolicyReplace,
+	}
+}
+
+// ConvertRow converts the columns of the row in place, it returns the number
+// of the values whose charset is converted and the number of the values whose
+// invalid bytes are replaced.
+func (c *CharsetConverter) ConvertRow(row *model.RowChangedEvent) (converted, replaced int, err error) {
+	for _, cols := range [][]*model.Column{row.Columns, row.PreColumns} {
+		for _, col := range cols {
+			if col == nil || !isNonBinaryString(col) {
+				continue
+			}
+			// TiDB stores the strings of all the charsets as UTF-8, so only the
+			// invalid bytes need to be handled.
+			if v, ok := col.Value.([]byte); ok && !utf8.Valid(v) {
+				if !c.replaceInvalid {
+					return 0, 0, cerror.ErrInvalidCharsetValue.GenWithStackByArgs(
+						col.Name, row.Table.String(), charset.CharsetUTF8MB4)
+				}
+				col.Value = bytes.ToValidUTF8(v, []byte(string(utf8.RuneError)))
+				replaced++
+			}
+			if col.Charset != charset.CharsetUTF8MB4 {
+				col.Charset = charset.CharsetUTF8MB4
+				col.Collation = charset.CollationUTF8MB4
+				converted++
+			}
+		}
+	}
+	return converted, replaced, nil
+}
+
+func isNonBinaryString(col *model.Column) bool {
+	switch col.Type {
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		return col.Charset != "" && col.Charset != charset.CharsetBin && !col.Flag.IsBinary()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharsetConverter(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	require.Nil(t, NewCharsetConverter(cfg))
+
+	newRow := func() *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			Table: &model.TableName{Schema: "test", Table: "t"},
+			Columns: []*model.Column{
+				{Name: "id", Type: mysql.TypeLong, Value: 1},
+				{Name: "a", Type: mysql.TypeVarchar, Charset: "gbk", Collation: "gbk_bin", Value: []byte("中文")},
+				{Name: "b", Type: mysql.TypeBlob, Charset: "utf8mb4", Collation: "utf8mb4_bin", Value: []byte("a\xffb")},
+				{Name: "c", Type: mysql.TypeVarString, Charset: "binary", Value: []byte("\xff")},
+				nil,
+			},
+		}
+	}
+
+	cfg.Charset = &config.CharsetConfig{ForceUTF8MB4: true}
+	converter := NewCharsetConverter(cfg)
+	_, _, err := converter.ConvertRow(newRow())
+	require.Regexp(t, ".*the value of column b of table test.t is not valid utf8mb4.*", err)
+
+	cfg.Charset.InvalidPolicy = config.CharsetInvalidPolicyReplace
+	converter = NewCharsetConverter(cfg)
+	row := newRow()
+	converted, replaced, err := converter.ConvertRow(row)
+	require.Nil(t, err)
+	require.Equal(t, 1, converted)
+	require.Equal(t, 1, replaced)
+	require.Equal(t, &model.Column{
+		Name: "a", Type: mysql.TypeVarchar, Charset: "utf8mb4", Collation: "utf8mb4_bin", Value: []byte("中文"),
+	}, row.Columns[1])
+	require.Equal(t, []byte("a�b"), row.Columns[2].Value)
+	require.Equal(t, []byte("\xff"), row.Columns[3].Value)
+}
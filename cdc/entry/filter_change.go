@@ -0,0 +1,44 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/kv"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/filter"
+	tidbkv "github.com/pingcap/tidb/kv"
+)
+
+// VerifyFilterChange returns the warnings about the data skipped if the filter
+// config of the changefeed is changed at the checkpoint ts, the tables are read
+// from the snapshot of the storage at the checkpoint ts.
+func VerifyFilterChange(oldInfo, newInfo *model.ChangeFeedInfo, storage tidbkv.Storage, checkpointTs uint64) ([]string, error) {
+	if !filter.IsChanged(oldInfo.Config, newInfo.Config) {
+		return nil, nil
+	}
+	meta, err := kv.GetSnapshotMeta(storage, checkpointTs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snap, err := NewSingleSchemaSnapshotFromMeta(meta, checkpointTs, false /* explicitTables */)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tables := make([]model.TableName, 0, len(snap.Tables()))
+	for _, tableInfo := range snap.Tables() {
+		tables = append(tables, tableInfo.TableName)
+	}
+	return filter.ChangeWarnings(oldInfo.Config, newInfo.Config, tables, checkpointTs)
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestVerifyFilterChange(t *testing.T) {
+	store, err := mockstore.NewMockStore()
+	require.Nil(t, err)
+	defer store.Close() //nolint:errcheck
+	session.SetSchemaLease(0)
+	session.DisableStats4Test()
+	domain, err := session.BootstrapSession(store)
+	require.Nil(t, err)
+	defer domain.Close()
+	domain.SetStatsUpdating(true)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database test1")
+	tk.MustExec("create table test1.t1(id int primary key)")
+	tk.MustExec("create database test2")
+	tk.MustExec("create table test2.t1(id int primary key)")
+	ver, err := store.CurrentVersion(oracle.GlobalTxnScope)
+	require.Nil(t, err)
+
+	oldInfo := &model.ChangeFeedInfo{Config: config.GetDefaultReplicaConfig()}
+	oldInfo.Config.Filter.Rules = []string{"test1.*"}
+	newInfo, err := oldInfo.Clone()
+	require.Nil(t, err)
+	warnings, err := VerifyFilterChange(oldInfo, newInfo, store, ver.Ver)
+	require.Nil(t, err)
+	require.Len(t, warnings, 0)
+
+	newInfo.Config.Filter.Rules = []string{"test2.*"}
+	warnings, err = VerifyFilterChange(oldInfo, newInfo, store, ver.Ver)
+	require.Nil(t, err)
+	require.Len(t, warnings, 2)
+	require.Regexp(t, `tables \[test2.t1\] are replicated from the checkpoint ts .*`, warnings[0])
+	require.Regexp(t, `tables \[test1.t1\] are not replicated any more, .*`, warnings[1])
+}
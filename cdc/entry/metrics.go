@@ -40,6 +40,27 @@ var (
 			Name:      "total_rows_count",
 			Help:      "The total count of rows that are processed by mounter",
 		}, []string{"capture", "changefeed"})
+	charsetConvertedValuesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "mounter",
+			Name:      "charset_converted_values_total",
+			Help:      "The total count of string values converted to utf8mb4, or with invalid bytes replaced",
+		}, []string{"capture", "changefeed", "type"})
+	oversizedRowsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "mounter",
+			Name:      "oversized_rows_total",
+			Help:      "The total count of rows larger than the max row size",
+		}, []string{"capture", "changefeed"})
+	offloadedValuesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "mounter",
+			Name:      "offloaded_values_total",
+			Help:      "The total count of column values offloaded to the external storage",
+		}, []string{"capture", "changefeed"})
 )
 
 // InitMetrics registers all metrics in this file
@@ -47,4 +68,7 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(mounterInputChanSizeGauge)
 	registry.MustRegister(mountDuration)
 	registry.MustRegister(totalRowsCountGauge)
+	registry.MustRegister(charsetConvertedValuesCounter)
+	registry.MustRegister(oversizedRowsCounter)
+	registry.MustRegister(offloadedValuesCounter)
 }
@@ -26,10 +26,12 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/model"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/tidb/kv"
 	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
@@ -75,22 +77,43 @@ type mounterImpl struct {
 	tz               *time.Location
 	workerNum        int
 	enableOldValue   bool
+	// rowFilters are the row filters of the workers, which are nil if there
+	// is no row filter rule
+	rowFilters []*filter.RowFilter
+	// charsetConverter is nil if the string values are not converted
+	charsetConverter *CharsetConverter
+	// offloader is nil if the large values are not offloaded
+	offloader *Offloader
+	// rowSizeGuard is nil if the size of rows is not limited
+	rowSizeGuard *RowSizeGuard
 }
 
-// NewMounter creates a mounter
-func NewMounter(schemaStorage SchemaStorage, workerNum int, enableOldValue bool) Mounter {
+// NewMounter creates a mounter, the rows filtered out by the rowFilter are
+// mounted as nil.
+func NewMounter(
+	schemaStorage SchemaStorage, workerNum int, enableOldValue bool,
+	rowFilter *filter.RowFilter, charsetConverter *CharsetConverter,
+	offloader *Offloader, rowSizeGuard *RowSizeGuard,
+) Mounter {
 	if workerNum <= 0 {
 		workerNum = defaultMounterWorkerNum
 	}
 	chs := make([]chan *model.PolymorphicEvent, workerNum)
+	rowFilters := make([]*filter.RowFilter, workerNum)
 	for i := 0; i < workerNum; i++ {
 		chs[i] = make(chan *model.PolymorphicEvent, defaultOutputChanSize)
+		// the row filter can't be shared by the workers
+		rowFilters[i] = rowFilter.Clone()
 	}
 	return &mounterImpl{
 		schemaStorage:    schemaStorage,
 		rawRowChangedChs: chs,
 		workerNum:        workerNum,
 		enableOldValue:   enableOldValue,
+		rowFilters:       rowFilters,
+		charsetConverter: charsetConverter,
+		offloader:        offloader,
+		rowSizeGuard:     rowSizeGuard,
 	}
 }
 
@@ -117,9 +140,17 @@ func (m *mounterImpl) codecWorker(ctx context.Context, index int) error {
 	changefeedID := util.ChangefeedIDFromCtx(ctx)
 	metricMountDuration := mountDuration.WithLabelValues(captureAddr, changefeedID)
 	metricTotalRows := totalRowsCountGauge.WithLabelValues(captureAddr, changefeedID)
+	metricConvertedValues := charsetConvertedValuesCounter.WithLabelValues(captureAddr, changefeedID, "converted")
+	metricReplacedValues := charsetConvertedValuesCounter.WithLabelValues(captureAddr, changefeedID, "replaced")
+	metricOversizedRows := oversizedRowsCounter.WithLabelValues(captureAddr, changefeedID)
+	metricOffloadedValues := offloadedValuesCounter.WithLabelValues(captureAddr, changefeedID)
 	defer func() {
 		mountDuration.DeleteLabelValues(captureAddr, changefeedID)
 		totalRowsCountGauge.DeleteLabelValues(captureAddr, changefeedID)
+		charsetConvertedValuesCounter.DeleteLabelValues(captureAddr, changefeedID, "converted")
+		charsetConvertedValuesCounter.DeleteLabelValues(captureAddr, changefeedID, "replaced")
+		oversizedRowsCounter.DeleteLabelValues(captureAddr, changefeedID)
+		offloadedValuesCounter.DeleteLabelValues(captureAddr, changefeedID)
 	}()
 
 	for {
@@ -134,10 +165,34 @@ func (m *mounterImpl) codecWorker(ctx context.Context, index int) error {
 			continue
 		}
 		startTime := time.Now()
-		rowEvent, err := m.unmarshalAndMountRowChanged(ctx, pEvent.RawKV)
+		rowEvent, err := m.unmarshalAndMountRowChanged(ctx, pEvent.RawKV, m.rowFilters[index])
 		if err != nil {
 			return errors.Trace(err)
 		}
+		if rowEvent != nil && m.charsetConverter != nil {
+			converted, replaced, err := m.charsetConverter.ConvertRow(rowEvent)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			metricConvertedValues.Add(float64(converted))
+			metricReplacedValues.Add(float64(replaced))
+		}
+		// the values are offloaded before the size of the row is checked,
+		// so the row is not oversized if its large values are offloaded.
+		if rowEvent != nil && m.offloader != nil {
+			offloaded, err := m.offloader.OffloadRow(ctx, rowEvent, pEvent.RawKV.Key)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			metricOffloadedValues.Add(float64(offloaded))
+		}
+		if rowEvent != nil && m.rowSizeGuard != nil && m.rowSizeGuard.IsOversized(rowEvent) {
+			metricOversizedRows.Inc()
+			rowEvent, err = m.rowSizeGuard.Handle(ctx, rowEvent, pEvent.RawKV.Key)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
 		pEvent.Row = rowEvent
 		pEvent.RawKV.Value = nil
 		pEvent.RawKV.OldValue = nil
@@ -170,7 +225,9 @@ func (m *mounterImpl) collectMetrics(ctx context.Context) {
 	}
 }
 
-func (m *mounterImpl) unmarshalAndMountRowChanged(ctx context.Context, raw *model.RawKVEntry) (*model.RowChangedEvent, error) {
+func (m *mounterImpl) unmarshalAndMountRowChanged(
+	ctx context.Context, raw *model.RawKVEntry, rowFilter *filter.RowFilter,
+) (*model.RowChangedEvent, error) {
 	if !bytes.HasPrefix(raw.Key, tablePrefix) {
 		return nil, nil
 	}
@@ -204,7 +261,7 @@ func (m *mounterImpl) unmarshalAndMountRowChanged(ctx context.Context, raw *mode
 			return nil, cerror.ErrSnapshotTableNotFound.GenWithStackByArgs(physicalTableID)
 		}
 		if bytes.HasPrefix(key, recordPrefix) {
-			rowKV, err := m.unmarshalRowKVEntry(tableInfo, raw.Key, raw.Value, raw.OldValue, baseInfo)
+			rowKV, err := m.unmarshalRowKVEntry(tableInfo, raw.Key, raw.Value, raw.OldValue, baseInfo, rowFilter)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
@@ -222,7 +279,9 @@ func (m *mounterImpl) unmarshalAndMountRowChanged(ctx context.Context, raw *mode
 	return row, err
 }
 
-func (m *mounterImpl) unmarshalRowKVEntry(tableInfo *model.TableInfo, rawKey []byte, rawValue []byte, rawOldValue []byte, base baseKVEntry) (*rowKVEntry, error) {
+func (m *mounterImpl) unmarshalRowKVEntry(
+	tableInfo *model.TableInfo, rawKey []byte, rawValue []byte, rawOldValue []byte, base baseKVEntry, rowFilter *filter.RowFilter,
+) (*rowKVEntry, error) {
 	recordID, err := tablecodec.DecodeRowKey(rawKey)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -246,6 +305,15 @@ func (m *mounterImpl) unmarshalRowKVEntry(tableInfo *model.TableInfo, rawKey []b
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// the row filter is evaluated with the full old value, so it must be
+	// called before the old value is trimmed below.
+	ignore, err := rowFilter.ShouldIgnoreRow(tableInfo, row, preRow)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if ignore {
+		return nil, nil
+	}
 
 	if base.Delete && !m.enableOldValue && (tableInfo.PKIsHandle || tableInfo.IsCommonHandle) {
 		handleColIDs, fieldTps, _ := tableInfo.GetRowColInfos()
@@ -327,10 +395,12 @@ func datum2Column(tableInfo *model.TableInfo, datums map[int64]types.Datum, fill
 			continue
 		}
 		cols[tableInfo.RowColumnsOffset[colInfo.ID]] = &model.Column{
-			Name:  colName,
-			Type:  colInfo.Tp,
-			Value: colValue,
-			Flag:  tableInfo.ColumnsFlag[colInfo.ID],
+			Name:      colName,
+			Type:      colInfo.Tp,
+			Charset:   colInfo.Charset,
+			Collation: colInfo.Collate,
+			Value:     colValue,
+			Flag:      tableInfo.ColumnsFlag[colInfo.ID],
 		}
 	}
 	return cols, nil
@@ -388,6 +458,11 @@ func (m *mounterImpl) mountRowKVEntry(tableInfo *model.TableInfo, row *rowKVEntr
 		tableInfoVersion = tableInfo.TableInfoVersion
 	}
 
+	var partition string
+	if pi := tableInfo.GetPartitionInfo(); pi != nil {
+		partition = pi.GetNameByID(row.PhysicalTableID)
+	}
+
 	return &model.RowChangedEvent{
 		StartTs:          row.StartTs,
 		CommitTs:         row.CRTs,
@@ -398,6 +473,7 @@ func (m *mounterImpl) mountRowKVEntry(tableInfo *model.TableInfo, row *rowKVEntr
 			Table:       tableName,
 			TableID:     row.PhysicalTableID,
 			IsPartition: tableInfo.GetPartitionInfo() != nil,
+			Partition:   partition,
 		},
 		Columns:         cols,
 		PreColumns:      preCols,
@@ -465,6 +541,35 @@ func getDefaultOrZeroValue(col *timodel.ColumnInfo) interface{} {
 		d := types.NewDatum(col.GetDefaultValue())
 		return d.GetValue()
 	}
+	return getZeroValue(col)
+}
+
+// GetOriginDefaultValue returns the value of the column in the rows written
+// before the column is added, it's converted to the type of the column and
+// formatted the same as the mounted column values.
+func GetOriginDefaultValue(col *timodel.ColumnInfo) (interface{}, error) {
+	defaultValue := col.GetOriginDefaultValue()
+	if defaultValue == nil {
+		if mysql.HasNotNullFlag(col.Flag) {
+			return getZeroValue(col), nil
+		}
+		return nil, nil
+	}
+	// the origin default value of the timestamp column is stored in UTC.
+	sc := &stmtctx.StatementContext{TimeZone: time.UTC}
+	datum := types.NewDatum(defaultValue)
+	d, err := datum.ConvertTo(sc, &col.FieldType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, _, err := formatColVal(d, col.Tp)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return value, nil
+}
+
+func getZeroValue(col *timodel.ColumnInfo) interface{} {
 	switch col.Tp {
 	case mysql.TypeEnum:
 		// For enum type, if no default value and not null is set,
@@ -24,10 +24,12 @@ import (
 	"github.com/pingcap/ticdc/pkg/regionspan"
 	ticonfig "github.com/pingcap/tidb/config"
 	tidbkv "github.com/pingcap/tidb/kv"
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/store/mockstore"
 	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
@@ -253,7 +255,7 @@ func testMounterDisableOldValue(t *testing.T, tc struct {
 	ver, err := store.CurrentVersion(oracle.GlobalTxnScope)
 	require.Nil(t, err)
 	scheamStorage.AdvanceResolvedTs(ver.Ver)
-	mounter := NewMounter(scheamStorage, 1, false).(*mounterImpl)
+	mounter := NewMounter(scheamStorage, 1, false, nil, nil, nil, nil).(*mounterImpl)
 	mounter.tz = time.Local
 	ctx := context.Background()
 
@@ -261,7 +263,7 @@ func testMounterDisableOldValue(t *testing.T, tc struct {
 		var rows int
 		walkTableSpanInStore(t, store, tableID, func(key []byte, value []byte) {
 			rawKV := f(key, value)
-			row, err := mounter.unmarshalAndMountRowChanged(ctx, rawKV)
+			row, err := mounter.unmarshalAndMountRowChanged(ctx, rawKV, nil)
 			require.Nil(t, err)
 			if row == nil {
 				return
@@ -390,3 +392,34 @@ func walkTableSpanInStore(t *testing.T, store tidbkv.Storage, tableID int64, f f
 		require.Nil(t, err)
 	}
 }
+
+func TestGetOriginDefaultValue(t *testing.T) {
+	newColInfo := func(tp byte, notNull bool, originDefault interface{}) *timodel.ColumnInfo {
+		colInfo := &timodel.ColumnInfo{FieldType: *types.NewFieldType(tp), OriginDefaultValue: originDefault}
+		if notNull {
+			colInfo.Flag = mysql.NotNullFlag
+		}
+		return colInfo
+	}
+
+	// the origin default value is converted to the type of the column.
+	value, err := GetOriginDefaultValue(newColInfo(mysql.TypeLong, true, "10"))
+	require.Nil(t, err)
+	require.Equal(t, int64(10), value)
+	value, err = GetOriginDefaultValue(newColInfo(mysql.TypeDouble, false, "1.5"))
+	require.Nil(t, err)
+	require.Equal(t, float64(1.5), value)
+
+	// the zero value is used if the column is not null and has no default value.
+	value, err = GetOriginDefaultValue(newColInfo(mysql.TypeLong, true, nil))
+	require.Nil(t, err)
+	require.Equal(t, int64(0), value)
+	value, err = GetOriginDefaultValue(newColInfo(mysql.TypeVarchar, true, nil))
+	require.Nil(t, err)
+	require.Equal(t, emptyBytes, value)
+
+	// null is used if the column is nullable and has no default value.
+	value, err = GetOriginDefaultValue(newColInfo(mysql.TypeLong, false, nil))
+	require.Nil(t, err)
+	require.Nil(t, value)
+}
@@ -0,0 +1,172 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+// OpenExternalStorage opens the external storage of the URI, such as
+// "s3://bucket/prefix" or "local:///data".
+func OpenExternalStorage(ctx context.Context, uri string) (storage.ExternalStorage, error) {
+	backend, err := storage.ParseBackend(uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	return storage.New(ctx, backend, &storage.ExternalStorageOptions{
+		SendCredentials: false,
+		SkipCheckPath:   true,
+	})
+}
+
+// OpenOffloadStorage opens the storage of the values offloaded by the
+// changefeed, which is the "{changefeed-id}/" prefix of the offload storage,
+// so the changefeeds sharing a storage never touch the objects of the others.
+func OpenOffloadStorage(ctx context.Context, uri string, changefeedID model.ChangeFeedID) (storage.ExternalStorage, error) {
+	u, err := storage.ParseRawURL(uri)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, changefeedID)
+	return OpenExternalStorage(ctx, u.String())
+}
+
+// Offloader writes the large column values to the external storage, and
+// replaces them with the URIs of the objects.
+type Offloader struct {
+	threshold int64
+	storage   storage.ExternalStorage
+}
+
+// NewOffloader creates an Offloader writing the values under the prefix of the
+// changefeed, it returns nil if the values are not offloaded.
+func NewOffloader(ctx context.Context, changefeedID model.ChangeFeedID, cfg *config.ReplicaConfig) (*Offloader, error) {
+	if !cfg.Offload.IsEnabled() {
+		return nil, nil
+	}
+	s, err := OpenOffloadStorage(ctx, cfg.Offload.Storage, changefeedID)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrOffloadStorage, err)
+	}
+	return &Offloader{threshold: cfg.Offload.Threshold, storage: s}, nil
+}
+
+// OffloadRow offloads the values of the BLOB, TEXT and JSON columns of the row
+// not smaller than the threshold, it returns the number of the offloaded
+// values. The key is the raw key of the row, which names the objects.
+func (o *Offloader) OffloadRow(ctx context.Context, row *model.RowChangedEvent, key []byte) (int, error) {
+	offloaded := 0
+	for _, c := range []struct {
+		cols  []*model.Column
+		isOld bool
+	}{{cols: row.Columns}, {cols: row.PreColumns, isOld: true}} {
+		for _, col := range c.cols {
+			if col == nil || col.Flag.IsOffloaded() || !isOffloadableType(col.Type) {
+				continue
+			}
+			var data []byte
+			switch v := col.Value.(type) {
+			case []byte:
+				data = v
+			case string:
+				data = []byte(v)
+			default:
+				continue
+			}
+			if int64(len(data)) < o.threshold {
+				continue
+			}
+			name := OffloadedObjectName(row, col.Name, key, c.isOld)
+			if err := o.storage.WriteFile(ctx, name, data); err != nil {
+				return offloaded, cerror.WrapError(cerror.ErrOffloadStorage, err)
+			}
+			ref := o.storage.URI() + "/" + name
+			if col.Type == mysql.TypeJSON {
+				col.Value = ref
+			} else {
+				col.Value = []byte(ref)
+			}
+			col.Flag.SetIsOffloaded()
+			row.ApproximateSize -= int64(len(data) - len(ref))
+			offloaded++
+		}
+	}
+	return offloaded, nil
+}
+
+func isOffloadableType(tp byte) bool {
+	switch tp {
+	case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// OffloadedObjectName returns the name of the object of an offloaded value, in
+// the format of "{commitTs}.{schema}.{table}.{column}.{keyHash}[.old]" under
+// the prefix of the changefeed. The name is deterministic, so the object is
+// overwritten if the value is offloaded again after the changefeed restarts.
+func OffloadedObjectName(row *model.RowChangedEvent, column string, key []byte, isOld bool) string {
+	h := fnv.New64a()
+	_, _ = h.Write(key)
+	name := fmt.Sprintf("%d.%s.%s.%s.%x", row.CommitTs, row.Table.Schema, row.Table.Table, column, h.Sum64())
+	if isOld {
+		name += ".old"
+	}
+	return name
+}
+
+// GCOffloadedObjects deletes the offloaded objects of the values committed
+// before the ts, it returns the number of the deleted objects. The storage is
+// opened by OpenOffloadStorage, so only the objects of the changefeed are
+// walked.
+func GCOffloadedObjects(ctx context.Context, s storage.ExternalStorage, beforeTs model.Ts) (int, error) {
+	var names []string
+	err := s.WalkDir(ctx, &storage.WalkOption{}, func(path string, size int64) error {
+		prefix := strings.TrimLeft(path, "/")
+		if i := strings.IndexByte(prefix, '.'); i >= 0 {
+			prefix = prefix[:i]
+		}
+		commitTs, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			// not an offloaded object
+			return nil
+		}
+		if commitTs < beforeTs {
+			names = append(names, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, cerror.WrapError(cerror.ErrOffloadStorage, err)
+	}
+	for i, name := range names {
+		if err := s.DeleteFile(ctx, name); err != nil {
+			return i, cerror.WrapError(cerror.ErrOffloadStorage, err)
+		}
+	}
+	return len(names), nil
+}
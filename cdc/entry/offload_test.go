@@ -0,0 +1,124 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOffloader(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := config.GetDefaultReplicaConfig()
+	offloader, err := NewOffloader(ctx, "test", cfg)
+	require.Nil(t, err)
+	require.Nil(t, offloader)
+
+	dir := t.TempDir()
+	cfg.Offload = &config.OffloadConfig{Threshold: 8, Storage: "local://" + dir}
+	offloader, err = NewOffloader(ctx, "test", cfg)
+	require.Nil(t, err)
+
+	largeValue := strings.Repeat("a", 1000)
+	largeOldValue := strings.Repeat("b", 1000)
+	row := &model.RowChangedEvent{
+		CommitTs: 42,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: 123456789},
+			{Name: "a", Type: mysql.TypeBlob, Value: []byte(largeValue)},
+			{Name: "b", Type: mysql.TypeBlob, Value: []byte("small")},
+			{Name: "c", Type: mysql.TypeJSON, Value: `{"key": "value"}`},
+			{Name: "d", Type: mysql.TypeVarchar, Value: []byte("large varchar")},
+		},
+		PreColumns: []*model.Column{
+			{Name: "a", Type: mysql.TypeBlob, Value: []byte(largeOldValue)},
+		},
+		ApproximateSize: 3000,
+	}
+	offloaded, err := offloader.OffloadRow(ctx, row, []byte("key"))
+	require.Nil(t, err)
+	require.Equal(t, 3, offloaded)
+
+	check := func(col *model.Column, isOld bool, expected string) {
+		name := OffloadedObjectName(row, col.Name, []byte("key"), isOld)
+		require.True(t, strings.HasPrefix(name, "42.test.t."+col.Name+"."))
+		require.True(t, col.Flag.IsOffloaded())
+		data, err := os.ReadFile(filepath.Join(dir, "test", name))
+		require.Nil(t, err)
+		require.Equal(t, expected, string(data))
+	}
+	check(row.Columns[1], false, largeValue)
+	require.Equal(t, []byte(offloader.storage.URI()+"/"+OffloadedObjectName(row, "a", []byte("key"), false)), row.Columns[1].Value)
+	check(row.Columns[3], false, `{"key": "value"}`)
+	require.IsType(t, "", row.Columns[3].Value)
+	check(row.PreColumns[0], true, largeOldValue)
+	require.False(t, row.Columns[0].Flag.IsOffloaded())
+	require.Equal(t, []byte("small"), row.Columns[2].Value)
+	require.False(t, row.Columns[4].Flag.IsOffloaded())
+	ref := func(col *model.Column) int64 {
+		if v, ok := col.Value.(string); ok {
+			return int64(len(v))
+		}
+		return int64(len(col.Value.([]byte)))
+	}
+	require.Equal(t, 3000-(1000-ref(row.Columns[1]))-(16-ref(row.Columns[3]))-(1000-ref(row.PreColumns[0])),
+		row.ApproximateSize)
+
+	// the offloaded values are not offloaded again
+	offloaded, err = offloader.OffloadRow(ctx, row, []byte("key"))
+	require.Nil(t, err)
+	require.Equal(t, 0, offloaded)
+}
+
+func TestGCOffloadedObjects(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := OpenOffloadStorage(ctx, "local://"+dir, "cf-1")
+	require.Nil(t, err)
+	for _, name := range []string{"10.test.t.a.1", "10.test.t.a.1.old", "20.test.t.a.1", "meta"} {
+		require.Nil(t, s.WriteFile(ctx, name, []byte("v")))
+	}
+	// the objects of another changefeed sharing the storage are not touched
+	other, err := OpenOffloadStorage(ctx, "local://"+dir, "cf-2")
+	require.Nil(t, err)
+	require.Nil(t, other.WriteFile(ctx, "10.test.t.a.1", []byte("v")))
+
+	deleted, err := GCOffloadedObjects(ctx, s, 15)
+	require.Nil(t, err)
+	require.Equal(t, 2, deleted)
+	readDir := func(dir string) []string {
+		entries, err := os.ReadDir(dir)
+		require.Nil(t, err)
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		return names
+	}
+	require.ElementsMatch(t, []string{"20.test.t.a.1", "meta"}, readDir(filepath.Join(dir, "cf-1")))
+	require.ElementsMatch(t, []string{"10.test.t.a.1"}, readDir(filepath.Join(dir, "cf-2")))
+}
@@ -0,0 +1,257 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"go.uber.org/zap"
+)
+
+// RowSizeGuard handles the rows larger than the max row size by the oversized
+// row policy of the changefeed.
+type RowSizeGuard struct {
+	maxRowSize int64
+	policy     config.OversizedRowPolicy
+	// deadLetter is only set in the dead-letter policy
+	deadLetter storage.ExternalStorage
+
+	// the rows of the ignored transactions and the rows ignored by the event
+	// filters are dropped by the sink, so they are never handled.
+	ignoreTxnStartTs map[uint64]struct{}
+	eventFilterMu    sync.Mutex
+	eventFilter      *filter.EventTypeFilter
+}
+
+// NewRowSizeGuard creates a RowSizeGuard, it returns nil if the size of rows
+// is not limited.
+func NewRowSizeGuard(ctx context.Context, cfg *config.ReplicaConfig) (*RowSizeGuard, error) {
+	if !cfg.RowSize.IsLimited() {
+		return nil, nil
+	}
+	g := &RowSizeGuard{
+		maxRowSize:       cfg.RowSize.MaxRowSize,
+		policy:           cfg.RowSize.GetPolicy(),
+		ignoreTxnStartTs: make(map[uint64]struct{}),
+	}
+	var err error
+	if cfg.Filter != nil {
+		for _, ts := range cfg.Filter.IgnoreTxnStartTs {
+			g.ignoreTxnStartTs[ts] = struct{}{}
+		}
+		if g.eventFilter, err = filter.NewEventTypeFilter(cfg); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if g.policy == config.OversizedRowPolicyDeadLetter {
+		g.deadLetter, err = OpenExternalStorage(ctx, cfg.RowSize.DeadLetterURI)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrDeadLetterStorage, err)
+		}
+	}
+	return g, nil
+}
+
+// MaxRawEntrySize returns the max size of the raw key-values before they are
+// sorted, the larger ones are rejected so that they never reach the sorter.
+// It returns 0 if the rows can't be rejected before they are mounted, i.e.
+// they may be truncated, written to the dead letter storage, shrunk by the
+// offloaded values or filtered out by the row filters or the event filters.
+func MaxRawEntrySize(cfg *config.ReplicaConfig) int64 {
+	if !cfg.RowSize.IsLimited() || cfg.RowSize.GetPolicy() != config.OversizedRowPolicyFail ||
+		cfg.Offload.IsEnabled() ||
+		(cfg.Filter != nil && (len(cfg.Filter.RowFilters) > 0 || len(cfg.Filter.EventFilters) > 0)) {
+		return 0
+	}
+	return cfg.RowSize.MaxRowSize
+}
+
+// RawEntrySizeChecker rejects the oversized raw key-values before they are
+// sorted, see MaxRawEntrySize.
+type RawEntrySizeChecker struct {
+	maxSize int64
+	// the transactions ignored by the filter are dropped after they are
+	// mounted, so their rows are never rejected.
+	ignoreTxnStartTs map[uint64]struct{}
+}
+
+// NewRawEntrySizeChecker creates a RawEntrySizeChecker, it returns nil if the
+// raw key-values can't be rejected before they are mounted.
+func NewRawEntrySizeChecker(cfg *config.ReplicaConfig) *RawEntrySizeChecker {
+	maxSize := MaxRawEntrySize(cfg)
+	if maxSize == 0 {
+		return nil
+	}
+	c := &RawEntrySizeChecker{maxSize: maxSize, ignoreTxnStartTs: make(map[uint64]struct{})}
+	if cfg.Filter != nil {
+		for _, ts := range cfg.Filter.IgnoreTxnStartTs {
+			c.ignoreTxnStartTs[ts] = struct{}{}
+		}
+	}
+	return c
+}
+
+// Check returns ErrRowTooLarge if the raw key-value is oversized.
+func (c *RawEntrySizeChecker) Check(tableName string, raw *model.RawKVEntry) error {
+	if c == nil || raw.OpType == model.OpTypeResolved || raw.ApproximateSize() <= c.maxSize {
+		return nil
+	}
+	if _, ok := c.ignoreTxnStartTs[raw.StartTs]; ok {
+		return nil
+	}
+	return cerror.ErrRowTooLarge.GenWithStackByArgs(tableName, raw.CRTs, raw.ApproximateSize(), c.maxSize)
+}
+
+// IsOversized returns true if the row is larger than the max row size, and it
+// is not dropped by the filters afterwards.
+func (g *RowSizeGuard) IsOversized(row *model.RowChangedEvent) bool {
+	if row.ApproximateSize <= g.maxRowSize {
+		return false
+	}
+	if _, ok := g.ignoreTxnStartTs[row.StartTs]; ok {
+		return false
+	}
+	if g.eventFilter != nil {
+		g.eventFilterMu.Lock()
+		defer g.eventFilterMu.Unlock()
+		return !g.eventFilter.ShouldIgnoreEvent(row)
+	}
+	return true
+}
+
+// Handle handles the oversized row, it returns the row to be replicated, or
+// nil if the row is written to the dead letter storage. The key is the raw
+// key of the row, which names the dead letter file of the row.
+func (g *RowSizeGuard) Handle(ctx context.Context, row *model.RowChangedEvent, key []byte) (*model.RowChangedEvent, error) {
+	switch g.policy {
+	case config.OversizedRowPolicyTruncate:
+		if !g.truncate(row) {
+			return nil, g.errRowTooLarge(row)
+		}
+		log.Warn("truncate the oversized row",
+			zap.Stringer("table", row.Table), zap.Uint64("commitTs", row.CommitTs))
+		return row, nil
+	case config.OversizedRowPolicyDeadLetter:
+		data, err := json.Marshal(row)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMarshalFailed, err)
+		}
+		h := fnv.New64a()
+		_, _ = h.Write(key)
+		// the name is deterministic, so the row is overwritten if it is
+		// written again after the changefeed restarts.
+		name := fmt.Sprintf("%s.%s.%d.%x.json", row.Table.Schema, row.Table.Table, row.CommitTs, h.Sum64())
+		if err := g.deadLetter.WriteFile(ctx, name, data); err != nil {
+			return nil, cerror.WrapError(cerror.ErrDeadLetterStorage, err)
+		}
+		log.Warn("write the oversized row to the dead letter storage",
+			zap.Stringer("table", row.Table), zap.Uint64("commitTs", row.CommitTs),
+			zap.String("file", name))
+		return nil, nil
+	default:
+		return nil, g.errRowTooLarge(row)
+	}
+}
+
+func (g *RowSizeGuard) errRowTooLarge(row *model.RowChangedEvent) error {
+	return cerror.ErrRowTooLarge.GenWithStackByArgs(
+		row.Table.String(), row.CommitTs, row.ApproximateSize, g.maxRowSize)
+}
+
+// truncate truncates the new values of the BLOB, TEXT and JSON columns from
+// the largest one until the row is not larger than the max row size, and sets
+// TruncatedFlag of the truncated columns. The old values are kept to locate
+// the row downstream. It returns false if the row is still too large.
+func (g *RowSizeGuard) truncate(row *model.RowChangedEvent) bool {
+	var cols []*model.Column
+	for _, col := range row.Columns {
+		if col != nil && truncatableSize(col) > 0 {
+			cols = append(cols, col)
+		}
+	}
+	sort.SliceStable(cols, func(i, j int) bool {
+		return truncatableSize(cols[i]) > truncatableSize(cols[j])
+	})
+
+	excess := row.ApproximateSize - g.maxRowSize
+	for _, col := range cols {
+		if excess <= 0 {
+			break
+		}
+		saved := truncateColumn(col, excess)
+		excess -= saved
+		row.ApproximateSize -= saved
+		col.Flag.SetIsTruncated()
+	}
+	return excess <= 0
+}
+
+// truncatableSize returns the size of the value which can be truncated.
+func truncatableSize(col *model.Column) int64 {
+	switch col.Type {
+	case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		v, _ := col.Value.([]byte)
+		return int64(len(v))
+	case mysql.TypeJSON:
+		v, _ := col.Value.(string)
+		if len(v) <= len(jsonNull) {
+			return 0
+		}
+		return int64(len(v))
+	default:
+		return 0
+	}
+}
+
+const jsonNull = "null"
+
+// truncateColumn truncates at least excess bytes of the value if possible, it
+// returns the number of the truncated bytes.
+func truncateColumn(col *model.Column, excess int64) int64 {
+	size := truncatableSize(col)
+	if col.Type == mysql.TypeJSON {
+		// a truncated JSON document is invalid
+		col.Value = jsonNull
+		return size - int64(len(jsonNull))
+	}
+	v := col.Value.([]byte)
+	n := size - excess
+	if n < 0 {
+		n = 0
+	}
+	// keep the TEXT values valid UTF-8
+	if col.Charset != charset.CharsetBin && !col.Flag.IsBinary() {
+		for n > 0 && !utf8.RuneStart(v[n]) {
+			n--
+		}
+	}
+	// copy the kept bytes to release the original value
+	col.Value = append(make([]byte, 0, n), v[:n]...)
+	return size - n
+}
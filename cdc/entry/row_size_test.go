@@ -0,0 +1,183 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entry
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowSizeGuard(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := config.GetDefaultReplicaConfig()
+	guard, err := NewRowSizeGuard(ctx, cfg)
+	require.Nil(t, err)
+	require.Nil(t, guard)
+
+	newRow := func() *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			CommitTs: 42,
+			Table:    &model.TableName{Schema: "test", Table: "t"},
+			Columns: []*model.Column{
+				{Name: "id", Type: mysql.TypeLong, Value: 1},
+				{Name: "a", Type: mysql.TypeBlob, Charset: "utf8mb4", Value: []byte("中文中文")},
+				{Name: "b", Type: mysql.TypeBlob, Charset: "binary", Value: []byte("xyz")},
+				{Name: "c", Type: mysql.TypeJSON, Value: `{"key": "value"}`},
+			},
+			ApproximateSize: 100,
+		}
+	}
+
+	// fail
+	require.Equal(t, int64(0), MaxRawEntrySize(cfg))
+	cfg.RowSize = &config.RowSizeConfig{MaxRowSize: 90}
+	require.Equal(t, int64(90), MaxRawEntrySize(cfg))
+	guard, err = NewRowSizeGuard(ctx, cfg)
+	require.Nil(t, err)
+	require.False(t, guard.IsOversized(&model.RowChangedEvent{ApproximateSize: 90}))
+	row := newRow()
+	require.True(t, guard.IsOversized(row))
+	_, err = guard.Handle(ctx, row, []byte("key"))
+	require.Regexp(t, ".*the row of table test.t committed at 42 is 100 bytes, which exceeds the max row size 90.*", err)
+
+	// truncate the JSON column first, then the TEXT column on the rune boundary
+	cfg.RowSize = &config.RowSizeConfig{MaxRowSize: 84, Policy: config.OversizedRowPolicyTruncate}
+	require.Equal(t, int64(0), MaxRawEntrySize(cfg))
+	guard, err = NewRowSizeGuard(ctx, cfg)
+	require.Nil(t, err)
+	row, err = guard.Handle(ctx, newRow(), []byte("key"))
+	require.Nil(t, err)
+	require.Len(t, row.Columns, 4)
+	require.Equal(t, "null", row.Columns[3].Value)
+	require.True(t, row.Columns[3].Flag.IsTruncated())
+	require.Equal(t, []byte("中文"), row.Columns[1].Value)
+	require.True(t, row.Columns[1].Flag.IsTruncated())
+	require.Equal(t, []byte("xyz"), row.Columns[2].Value)
+	require.False(t, row.Columns[2].Flag.IsTruncated())
+	require.Equal(t, int64(82), row.ApproximateSize)
+
+	// the old values are kept to locate the row
+	row = newRow()
+	row.PreColumns = row.Columns
+	row.Columns = nil
+	_, err = guard.Handle(ctx, row, []byte("key"))
+	require.Regexp(t, ".*exceeds the max row size 84.*", err)
+	require.Equal(t, []byte("中文中文"), row.PreColumns[1].Value)
+	require.False(t, row.PreColumns[1].Flag.IsTruncated())
+
+	// the row is still too large after all the values are truncated
+	cfg.RowSize.MaxRowSize = 50
+	guard, err = NewRowSizeGuard(ctx, cfg)
+	require.Nil(t, err)
+	_, err = guard.Handle(ctx, newRow(), []byte("key"))
+	require.Regexp(t, ".*exceeds the max row size 50.*", err)
+
+	// dead letter
+	dir := t.TempDir()
+	cfg.RowSize = &config.RowSizeConfig{
+		MaxRowSize: 90, Policy: config.OversizedRowPolicyDeadLetter, DeadLetterURI: "local://" + dir,
+	}
+	guard, err = NewRowSizeGuard(ctx, cfg)
+	require.Nil(t, err)
+	row, err = guard.Handle(ctx, newRow(), []byte("key"))
+	require.Nil(t, err)
+	require.Nil(t, row)
+	files, err := filepath.Glob(filepath.Join(dir, "test.t.42.*.json"))
+	require.Nil(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.Nil(t, err)
+	written := new(model.RowChangedEvent)
+	require.Nil(t, json.Unmarshal(data, written))
+	require.Equal(t, uint64(42), written.CommitTs)
+	require.Len(t, written.Columns, 4)
+}
+
+func TestRawEntrySizeChecker(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	require.Nil(t, NewRawEntrySizeChecker(cfg))
+	cfg.RowSize = &config.RowSizeConfig{MaxRowSize: 10}
+	raw := &model.RawKVEntry{
+		OpType:  model.OpTypePut,
+		Key:     []byte("key"),
+		Value:   []byte("large value"),
+		StartTs: 41,
+		CRTs:    42,
+	}
+	checker := NewRawEntrySizeChecker(cfg)
+	require.NotNil(t, checker)
+	require.Regexp(t, ".*the row of table test.t committed at 42 is 14 bytes, which exceeds the max row size 10.*",
+		checker.Check("test.t", raw))
+	require.Nil(t, checker.Check("test.t", &model.RawKVEntry{OpType: model.OpTypePut, Key: []byte("key")}))
+	require.Nil(t, checker.Check("test.t", &model.RawKVEntry{OpType: model.OpTypeResolved, Value: raw.Value, CRTs: 42}))
+
+	// the oversized rows of the ignored transactions are dropped after they
+	// are mounted, they never fail the changefeed.
+	cfg.Filter.IgnoreTxnStartTs = []uint64{41}
+	checker = NewRawEntrySizeChecker(cfg)
+	require.Nil(t, checker.Check("test.t", raw))
+	raw.StartTs = 40
+	require.NotNil(t, checker.Check("test.t", raw))
+
+	// the event filters are applied to the mounted rows, so the rows can't
+	// be rejected before they are sorted.
+	cfg.Filter.EventFilters = []*config.EventFilterRule{{Matcher: []string{"test.t"}, IgnoreEvent: []string{config.EventTypeInsert}}}
+	require.Equal(t, int64(0), MaxRawEntrySize(cfg))
+	checker = NewRawEntrySizeChecker(cfg)
+	require.Nil(t, checker)
+	require.Nil(t, checker.Check("test.t", raw))
+}
+
+func TestRowSizeGuardIgnoredRows(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.RowSize = &config.RowSizeConfig{MaxRowSize: 10}
+	cfg.Filter.IgnoreTxnStartTs = []uint64{41}
+	cfg.Filter.EventFilters = []*config.EventFilterRule{{Matcher: []string{"test.t"}, IgnoreEvent: []string{config.EventTypeDelete}}}
+	guard, err := NewRowSizeGuard(ctx, cfg)
+	require.Nil(t, err)
+
+	newRow := func(startTs model.Ts, table string) *model.RowChangedEvent {
+		return &model.RowChangedEvent{
+			StartTs:         startTs,
+			CommitTs:        42,
+			Table:           &model.TableName{Schema: "test", Table: table},
+			Columns:         []*model.Column{{Name: "id", Type: mysql.TypeLong, Value: 1}},
+			ApproximateSize: 100,
+		}
+	}
+	require.True(t, guard.IsOversized(newRow(40, "t")))
+
+	// the oversized rows dropped by the filters never fail the changefeed.
+	require.False(t, guard.IsOversized(newRow(41, "t")))
+	deleted := newRow(40, "t")
+	deleted.PreColumns, deleted.Columns = deleted.Columns, nil
+	require.False(t, guard.IsOversized(deleted))
+	deleted.Table.Table = "t2"
+	require.True(t, guard.IsOversized(deleted))
+}
@@ -67,10 +67,12 @@ func (s *SingleSchemaSnapshot) PreTableInfo(job *timodel.Job) (*model.TableInfo,
 	switch job.Type {
 	case timodel.ActionCreateSchema, timodel.ActionModifySchemaCharsetAndCollate, timodel.ActionDropSchema:
 		return nil, nil
-	case timodel.ActionCreateTable, timodel.ActionCreateView, timodel.ActionRecoverTable:
+	case timodel.ActionCreateTable, timodel.ActionCreateView, timodel.ActionRecoverTable,
+		timodel.ActionCreateSequence:
 		// no pre table info
 		return nil, nil
-	case timodel.ActionRenameTable, timodel.ActionDropTable, timodel.ActionDropView, timodel.ActionTruncateTable:
+	case timodel.ActionRenameTable, timodel.ActionDropTable, timodel.ActionDropView, timodel.ActionTruncateTable,
+		timodel.ActionDropSequence:
 		// get the table will be dropped
 		table, ok := s.TableByID(job.TableID)
 		if !ok {
@@ -584,12 +586,13 @@ func (s *schemaSnapshot) handleDDL(job *timodel.Job) error {
 		if err != nil {
 			return errors.Trace(err)
 		}
-	case timodel.ActionCreateTable, timodel.ActionCreateView, timodel.ActionRecoverTable:
+	case timodel.ActionCreateTable, timodel.ActionCreateView, timodel.ActionRecoverTable,
+		timodel.ActionCreateSequence:
 		err := s.createTable(getWrapTableInfo(job))
 		if err != nil {
 			return errors.Trace(err)
 		}
-	case timodel.ActionDropTable, timodel.ActionDropView:
+	case timodel.ActionDropTable, timodel.ActionDropView, timodel.ActionDropSequence:
 		err := s.dropTable(job.TableID)
 		if err != nil {
 			return errors.Trace(err)
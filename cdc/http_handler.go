@@ -43,6 +43,10 @@ const (
 	APIOpVarTableID = "table-id"
 	// APIOpForceRemoveChangefeed is used when remove a changefeed
 	APIOpForceRemoveChangefeed = "force-remove"
+	// APIOpOverwriteCheckpointTs is used when resume a quarantined changefeed
+	APIOpOverwriteCheckpointTs = "overwrite-checkpoint-ts"
+	// APIOpVarChangefeedInfo is the key of the updated changefeed info in HTTP API
+	APIOpVarChangefeedInfo = "cf-info"
 )
 
 type commonResp struct {
@@ -125,6 +129,15 @@ func (s *Server) handleChangefeedAdmin(w http.ResponseWriter, req *http.Request)
 		}
 		opts.ForceRemove = forceRemoveOpt
 	}
+	if tsStr := req.Form.Get(APIOpOverwriteCheckpointTs); tsStr != "" {
+		ts, err := strconv.ParseUint(tsStr, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest,
+				cerror.ErrAPIInvalidParam.GenWithStack("invalid overwrite checkpoint ts: %s", tsStr))
+			return
+		}
+		opts.OverwriteCheckpointTs = ts
+	}
 	job := model.AdminJob{
 		CfID: req.Form.Get(APIOpVarChangefeedID),
 		Type: model.AdminJobType(typ),
@@ -206,6 +219,38 @@ func (s *Server) handleMoveTable(w http.ResponseWriter, req *http.Request) {
 	handleOwnerResp(w, err)
 }
 
+func (s *Server) handleChangefeedUpdate(w http.ResponseWriter, req *http.Request) {
+	if s.capture == nil {
+		// for test only
+		handleOwnerResp(w, concurrency.ErrElectionNotLeader)
+		return
+	}
+
+	err := req.ParseForm()
+	if err != nil {
+		writeInternalServerError(w, err)
+		return
+	}
+	changefeedID := req.Form.Get(APIOpVarChangefeedID)
+	if err := model.ValidateChangefeedID(changefeedID); err != nil {
+		writeError(w, http.StatusBadRequest,
+			cerror.ErrAPIInvalidParam.GenWithStack("invalid changefeed id: %s", changefeedID))
+		return
+	}
+	info := &model.ChangeFeedInfo{}
+	if err := info.Unmarshal([]byte(req.Form.Get(APIOpVarChangefeedInfo))); err != nil {
+		writeError(w, http.StatusBadRequest, cerror.ErrAPIInvalidParam.Wrap(err))
+		return
+	}
+
+	err = s.capture.OperateOwnerUnderLock(func(owner *owner.Owner) error {
+		owner.UpdateChangefeed(changefeedID, info)
+		return nil
+	})
+
+	handleOwnerResp(w, err)
+}
+
 func (s *Server) handleChangefeedQuery(w http.ResponseWriter, req *http.Request) {
 	if s.capture == nil {
 		// for test only
@@ -52,21 +52,44 @@ func newRouter(captureHandler capture.HTTPHandler) *gin.Engine {
 	router.GET("/api/v1/status", captureHandler.ServerStatus)
 	router.GET("/api/v1/health", captureHandler.Health)
 	router.POST("/api/v1/log", capture.SetLogLevel)
+	router.POST("/api/v1/config/reload", capture.ReloadServerConfig)
 
 	// changefeed API
 	changefeedGroup := router.Group("/api/v1/changefeeds")
 	{
 		changefeedGroup.GET("", captureHandler.ListChangefeed)
 		changefeedGroup.GET("/:changefeed_id", captureHandler.GetChangefeed)
+		changefeedGroup.GET("/:changefeed_id/slo", captureHandler.GetChangefeedSLO)
+		changefeedGroup.GET("/:changefeed_id/checksum", captureHandler.GetChangefeedChecksum)
+		changefeedGroup.GET("/:changefeed_id/syncpoints", captureHandler.GetChangefeedSyncpoints)
+		changefeedGroup.GET("/:changefeed_id/ddl_barrier", captureHandler.GetChangefeedDDLBarrier)
+		changefeedGroup.POST("/:changefeed_id/ddl_barrier/skip", captureHandler.SkipDDL)
+		changefeedGroup.POST("/:changefeed_id/ddl_barrier/retry", captureHandler.RetryDDL)
 		changefeedGroup.POST("", captureHandler.CreateChangefeed)
+		changefeedGroup.POST("/precheck", captureHandler.PrecheckChangefeed)
 		changefeedGroup.PUT("/:changefeed_id", captureHandler.UpdateChangefeed)
 		changefeedGroup.POST("/:changefeed_id/pause", captureHandler.PauseChangefeed)
 		changefeedGroup.POST("/:changefeed_id/resume", captureHandler.ResumeChangefeed)
 		changefeedGroup.DELETE("/:changefeed_id", captureHandler.RemoveChangefeed)
 		changefeedGroup.POST("/:changefeed_id/tables/rebalance_table", captureHandler.RebalanceTable)
 		changefeedGroup.POST("/:changefeed_id/tables/move_table", captureHandler.MoveTable)
+		changefeedGroup.POST("/:changefeed_id/tables/add_tables", captureHandler.AddTables)
+		changefeedGroup.POST("/:changefeed_id/tables/remove_tables", captureHandler.RemoveTables)
+		changefeedGroup.POST("/:changefeed_id/tables/pause_tables", captureHandler.PauseTables)
+		changefeedGroup.POST("/:changefeed_id/tables/resume_tables", captureHandler.ResumeTables)
+		changefeedGroup.POST("/:changefeed_id/tables/rewind_table", captureHandler.RewindTable)
+		changefeedGroup.GET("/:changefeed_id/tables/:table_id/status", captureHandler.GetTableStatus)
 	}
 
+	// declarative changefeed reconciliation API
+	router.GET("/api/v1/reconcile", captureHandler.GetReconcileStatus)
+
+	// hot keys API
+	router.GET("/api/v1/hot-keys", captureHandler.GetHotKeys)
+
+	// resource usage API
+	router.GET("/api/v1/resource-usage", captureHandler.GetResourceUsage)
+
 	// owner API
 	ownerGroup := router.Group("/api/v1/owner")
 	{
@@ -84,6 +107,8 @@ func newRouter(captureHandler capture.HTTPHandler) *gin.Engine {
 	captureGroup := router.Group("/api/v1/captures")
 	{
 		captureGroup.GET("", captureHandler.ListCapture)
+		captureGroup.POST("/:capture_id/drain", captureHandler.DrainCapture)
+		captureGroup.GET("/:capture_id/drain", captureHandler.GetDrainCaptureStatus)
 	}
 
 	// pprof debug API
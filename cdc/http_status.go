@@ -18,7 +18,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"os"
 
@@ -30,15 +29,18 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/ticdc/pkg/tcpserver"
 	"github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/ticdc/pkg/version"
+	"github.com/pingcap/ticdc/proto/adminpb"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.etcd.io/etcd/clientv3"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
-func (s *Server) startStatusHTTP() error {
+func (s *Server) startStatusHTTP(ctx context.Context) error {
 	conf := config.GetGlobalServerConfig()
 	router := newRouter(capture.NewHTTPHandler(s.capture))
 
@@ -49,6 +51,7 @@ func (s *Server) startStatusHTTP() error {
 	router.POST("/capture/owner/rebalance_trigger", gin.WrapF(s.handleRebalanceTrigger))
 	router.POST("/capture/owner/move_table", gin.WrapF(s.handleMoveTable))
 	router.POST("/capture/owner/changefeed/query", gin.WrapF(s.handleChangefeedQuery))
+	router.POST("/capture/owner/changefeed/update", gin.WrapF(s.handleChangefeedUpdate))
 	router.POST("/admin/log", gin.WrapF(handleAdminLogLevel))
 
 	if util.FailpointBuild {
@@ -64,29 +67,38 @@ func (s *Server) startStatusHTTP() error {
 		log.Error("status server set tls config failed", zap.Error(err))
 		return errors.Trace(err)
 	}
-	tlsConfig, err := conf.Security.ToTLSConfigWithVerify()
-	if err != nil {
-		log.Error("status server get tls config failed", zap.Error(err))
-		return errors.Trace(err)
-	}
 
-	s.statusServer = &http.Server{Addr: conf.Addr, Handler: router, TLSConfig: tlsConfig}
-
-	ln, err := net.Listen("tcp", conf.Addr)
+	// The TCP server handles the TLS, and serves both the HTTP requests and
+	// the gRPC requests of the admin API on the same address.
+	tcpServer, err := tcpserver.NewTCPServer(conf.Addr, conf.Security)
 	if err != nil {
 		return cerror.WrapError(cerror.ErrServeHTTP, err)
 	}
+	statusServer := &http.Server{Addr: conf.Addr, Handler: router}
+	grpcServer := grpc.NewServer()
+	adminpb.RegisterAdminServer(grpcServer, newAdminServer(router))
+	s.statusServer, s.grpcServer = statusServer, grpcServer
+
 	go func() {
 		log.Info("http server is running", zap.String("addr", conf.Addr))
-		if tlsConfig != nil {
-			err = s.statusServer.ServeTLS(ln, conf.Security.CertPath, conf.Security.KeyPath)
-		} else {
-			err = s.statusServer.Serve(ln)
-		}
-		if err != nil && err != http.ErrServerClosed {
+		err := statusServer.Serve(tcpServer.HTTP1Listener())
+		if err != nil && err != http.ErrServerClosed && ctx.Err() == nil {
 			log.Error("http server error", zap.Error(cerror.WrapError(cerror.ErrServeHTTP, err)))
 		}
 	}()
+	go func() {
+		log.Info("admin grpc server is running", zap.String("addr", conf.Addr))
+		err := grpcServer.Serve(tcpServer.GrpcListener())
+		if err != nil && ctx.Err() == nil {
+			log.Error("admin grpc server error", zap.Error(err))
+		}
+	}()
+	go func() {
+		err := tcpServer.Run(ctx)
+		if err != nil && !cerror.ErrTCPServerClosed.Equal(err) {
+			log.Error("status server error", zap.Error(err))
+		}
+	}()
 	return nil
 }
 
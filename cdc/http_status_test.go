@@ -15,6 +15,7 @@ package cdc
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -59,11 +60,11 @@ func (s *httpStatusSuite) TestHTTPStatus(c *check.C) {
 	config.StoreGlobalServerConfig(conf)
 	server, err := NewServer([]string{"http://127.0.0.1:2379"})
 	c.Assert(err, check.IsNil)
-	err = server.startStatusHTTP()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = server.startStatusHTTP(ctx)
 	c.Assert(err, check.IsNil)
-	defer func() {
-		c.Assert(server.statusServer.Close(), check.IsNil)
-	}()
+	defer server.Close()
 
 	s.waitUntilServerOnline(c)
 
@@ -73,6 +74,7 @@ func (s *httpStatusSuite) TestHTTPStatus(c *check.C) {
 	testHandleRebalance(c)
 	testHandleMoveTable(c)
 	testHandleChangefeedQuery(c)
+	testHandleChangefeedUpdate(c)
 	testHandleFailpoint(c)
 }
 
@@ -116,6 +118,11 @@ func testHandleChangefeedQuery(c *check.C) {
 	testRequestNonOwnerFailed(c, uri)
 }
 
+func testHandleChangefeedUpdate(c *check.C) {
+	uri := fmt.Sprintf("http://%s/capture/owner/changefeed/update", advertiseAddr4Test)
+	testRequestNonOwnerFailed(c, uri)
+}
+
 func testRequestNonOwnerFailed(c *check.C, uri string) {
 	resp, err := http.PostForm(uri, url.Values{})
 	c.Assert(err, check.IsNil)
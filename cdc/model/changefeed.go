@@ -16,15 +16,19 @@ package model
 import (
 	"encoding/json"
 	"math"
+	"net/url"
 	"regexp"
 	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/cyclic/mark"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/r3labs/diff"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
@@ -37,6 +41,10 @@ const (
 	SortInMemory SortEngine = "memory"
 	SortInFile   SortEngine = "file"
 	SortUnified  SortEngine = "unified"
+	// SortLowLatency sorts the events in memory with a strict quota and falls
+	// back to the unified sorter once the quota is exceeded. It can only be
+	// specified per table by the sort engine rules.
+	SortLowLatency SortEngine = "low-latency"
 )
 
 // FeedState represents the running state of a changefeed
@@ -128,6 +136,138 @@ type ChangeFeedInfo struct {
 	SyncPointEnabled  bool          `json:"sync-point-enabled"`
 	SyncPointInterval time.Duration `json:"sync-point-interval"`
 	CreatorVersion    string        `json:"creator-version"`
+	// SyncPointRetention is how long the syncpoints are kept in the
+	// downstream, 0 means the syncpoints are never cleaned up.
+	SyncPointRetention time.Duration `json:"sync-point-retention,omitempty"`
+
+	// PausedTables holds the tables whose replication is paused, mapped to
+	// the ts they are paused at, from which they are replicated once resumed.
+	PausedTables map[TableID]Ts `json:"paused-tables,omitempty"`
+
+	// StandbySinkURI is the sink which the changefeed fails over to when the
+	// sink is unhealthy, it uses the same scheme and protocol as SinkURI.
+	StandbySinkURI string `json:"standby-sink-uri,omitempty"`
+	// SinkSwitchovers records the recent failovers of the sink, the latest last.
+	SinkSwitchovers []*SinkSwitchover `json:"sink-switchovers,omitempty"`
+
+	// Upstream is the upstream cluster which the changefeed replicates from,
+	// nil means the upstream cluster of the server.
+	Upstream *UpstreamInfo `json:"upstream,omitempty"`
+
+	// UpdateHistory records the recent updates of the config, the latest last.
+	UpdateHistory []*ChangefeedUpdate `json:"update-history,omitempty"`
+
+	// ErrorRecords records the recent errors of the changefeed and how they
+	// are handled, the latest last. Unlike Error and ErrorHis, they are kept
+	// when the changefeed is resumed.
+	ErrorRecords []*ChangefeedErrorRecord `json:"error-records,omitempty"`
+
+	// GCQuarantine is not nil if the changefeed is quarantined for blocking
+	// the GC of the upstream longer than its gc ttl, the GC safepoint held by
+	// the changefeed is released until it is resumed.
+	GCQuarantine *GCQuarantine `json:"gc-quarantine,omitempty"`
+
+	// BackfillFinished is true if the existing rows of the tables have been
+	// backfilled, the incremental changes are not replicated until then.
+	BackfillFinished bool `json:"backfill-finished,omitempty"`
+
+	// TableBootstrap is not nil if the existing rows of the tables added to
+	// the running changefeed are being backfilled.
+	TableBootstrap *TableBootstrap `json:"table-bootstrap,omitempty"`
+}
+
+// maxSinkSwitchovers is the maximum number of the recorded sink switchovers.
+const maxSinkSwitchovers = 16
+
+// SinkSwitchover records a failover from the sink to the standby sink. The
+// sinks are recorded by their hosts, because the sink URIs may contain passwords.
+type SinkSwitchover struct {
+	Time         time.Time `json:"time"`
+	From         string    `json:"from"`
+	To           string    `json:"to"`
+	CheckpointTs Ts        `json:"checkpoint-ts"`
+	Reason       string    `json:"reason"`
+}
+
+// maxChangefeedUpdates is the maximum number of the recorded config updates.
+const maxChangefeedUpdates = 16
+
+// The sources of the changefeed updates
+const (
+	ChangefeedUpdateSourceAPI = "api"
+	ChangefeedUpdateSourceCLI = "cli"
+)
+
+// ChangefeedUpdate records an update of the changefeed config. Only the paths
+// of the changed fields are recorded, because the sink URIs may contain passwords.
+type ChangefeedUpdate struct {
+	Time         time.Time `json:"time"`
+	Source       string    `json:"source"`
+	Changes      []string  `json:"changes"`
+	CheckpointTs Ts        `json:"checkpoint-ts"`
+	Warnings     []string  `json:"warnings,omitempty"`
+}
+
+// maxChangefeedErrorRecords is the maximum number of the recorded errors.
+const maxChangefeedErrorRecords = 16
+
+// The actions taken by the owner when the changefeed runs into an error
+const (
+	// ErrorActionRestart means the changefeed is restarted immediately.
+	ErrorActionRestart = "restart"
+	// ErrorActionBackoff means the changefeed is restarted after a backoff,
+	// because the error keeps occurring.
+	ErrorActionBackoff = "backoff"
+	// ErrorActionPause means the changefeed is paused until it is resumed.
+	ErrorActionPause = "pause"
+	// ErrorActionFail means the changefeed is failed.
+	ErrorActionFail = "fail"
+	// ErrorActionQuarantine means the changefeed is paused and the GC
+	// safepoint held by it is released.
+	ErrorActionQuarantine = "quarantine"
+)
+
+// ChangefeedErrorRecord records an error of the changefeed and the action
+// taken by the owner.
+type ChangefeedErrorRecord struct {
+	Time   time.Time     `json:"time"`
+	Error  *RunningError `json:"error"`
+	Action string        `json:"action"`
+}
+
+// GCQuarantine records the quarantine of a changefeed blocking the GC.
+type GCQuarantine struct {
+	Time         time.Time `json:"time"`
+	CheckpointTs Ts        `json:"checkpoint-ts"`
+	// TTL is the gc ttl in seconds exceeded by the changefeed.
+	TTL int64 `json:"ttl"`
+}
+
+// VerifyResume verifies the checkpoint ts the changefeed is resumed from, 0
+// means resuming from the checkpoint ts of the changefeed. A quarantined
+// changefeed has released its GC safepoint, so the data after its checkpoint
+// ts may have been GCed, and it can only be resumed from a greater checkpoint
+// ts, which is checked against the GC safepoint once the changefeed is resumed.
+func (info *ChangeFeedInfo) VerifyResume(id ChangeFeedID, overwriteCheckpointTs Ts) error {
+	if info.GCQuarantine == nil {
+		if overwriteCheckpointTs != 0 {
+			return cerror.ErrAPIInvalidParam.GenWithStack(
+				"the checkpoint-ts can only be overwritten when resuming a quarantined changefeed")
+		}
+		return nil
+	}
+	if overwriteCheckpointTs <= info.GCQuarantine.CheckpointTs {
+		return cerror.ErrChangefeedQuarantined.GenWithStackByArgs(id, info.GCQuarantine.CheckpointTs)
+	}
+	return nil
+}
+
+// TableBootstrap records the tables added to a running changefeed whose
+// existing rows at Ts are written to the sink as inserts, the tables are
+// replicated from Ts once the rows are written.
+type TableBootstrap struct {
+	Ts       Ts        `json:"ts"`
+	TableIDs []TableID `json:"table-ids"`
 }
 
 const changeFeedIDMaxLen = 128
@@ -158,6 +298,9 @@ func (info *ChangeFeedInfo) String() (str string) {
 		return
 	}
 	clone.SinkURI = "***"
+	if clone.StandbySinkURI != "" {
+		clone.StandbySinkURI = "***"
+	}
 	str, err = clone.Marshal()
 	if err != nil {
 		log.Error("failed to marshal changefeed info", zap.Error(err))
@@ -165,6 +308,114 @@ func (info *ChangeFeedInfo) String() (str string) {
 	return
 }
 
+// ValidateStandbySinkURI checks whether the standby sink uses the same scheme
+// and protocol as the sink, so that the changefeed can fail over to it.
+func (info *ChangeFeedInfo) ValidateStandbySinkURI() error {
+	if info.StandbySinkURI == "" {
+		return nil
+	}
+	sinkURI, err := url.Parse(info.SinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	standbyURI, err := url.Parse(info.StandbySinkURI)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+	}
+	if !strings.EqualFold(sinkURI.Scheme, standbyURI.Scheme) {
+		return cerror.ErrSinkURIInvalid.GenWithStack(
+			"the scheme of standby-sink-uri (%s) should be the same as sink-uri (%s)", standbyURI.Scheme, sinkURI.Scheme)
+	}
+	if sinkURI.Query().Get("protocol") != standbyURI.Query().Get("protocol") {
+		return cerror.ErrSinkURIInvalid.GenWithStack(
+			"the protocol of standby-sink-uri should be the same as sink-uri")
+	}
+	if info.SinkURI == info.StandbySinkURI {
+		return cerror.ErrSinkURIInvalid.GenWithStack("standby-sink-uri should be different from sink-uri")
+	}
+	return nil
+}
+
+// SwitchSink fails over to the standby sink, the previous sink becomes the
+// standby sink, so that the changefeed can fail back to it later.
+func (info *ChangeFeedInfo) SwitchSink(checkpointTs Ts, reason string) {
+	sinkHost := func(sinkURI string) string {
+		u, err := url.Parse(sinkURI)
+		if err != nil {
+			return ""
+		}
+		return u.Scheme + "://" + u.Host
+	}
+	info.SinkSwitchovers = append(info.SinkSwitchovers, &SinkSwitchover{
+		Time:         time.Now(),
+		From:         sinkHost(info.SinkURI),
+		To:           sinkHost(info.StandbySinkURI),
+		CheckpointTs: checkpointTs,
+		Reason:       reason,
+	})
+	if len(info.SinkSwitchovers) > maxSinkSwitchovers {
+		info.SinkSwitchovers = info.SinkSwitchovers[len(info.SinkSwitchovers)-maxSinkSwitchovers:]
+	}
+	info.SinkURI, info.StandbySinkURI = info.StandbySinkURI, info.SinkURI
+}
+
+// RecordUpdate records the update from the old changefeed info to this one,
+// the source is where the update comes from, such as the API or the cli, and
+// the warnings are the risks of the update. It returns nil if nothing changed.
+func (info *ChangeFeedInfo) RecordUpdate(oldInfo *ChangeFeedInfo, source string, checkpointTs Ts, warnings []string) (*ChangefeedUpdate, error) {
+	changelog, err := diff.Diff(oldInfo, info)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var changes []string
+	seen := make(map[string]struct{})
+	for _, change := range changelog {
+		path := make([]string, 0, len(change.Path))
+		for _, name := range change.Path {
+			// omit the indexes of slices and the keys of maps
+			if name == "" || unicode.IsLower(rune(name[0])) || unicode.IsDigit(rune(name[0])) {
+				break
+			}
+			path = append(path, name)
+		}
+		if len(path) == 0 || path[0] == "UpdateHistory" || path[0] == "ErrorRecords" {
+			continue
+		}
+		p := strings.Join(path, ".")
+		if _, ok := seen[p]; !ok {
+			seen[p] = struct{}{}
+			changes = append(changes, p)
+		}
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	update := &ChangefeedUpdate{
+		Time:         time.Now(),
+		Source:       source,
+		Changes:      changes,
+		CheckpointTs: checkpointTs,
+		Warnings:     warnings,
+	}
+	info.UpdateHistory = append(info.UpdateHistory, update)
+	if len(info.UpdateHistory) > maxChangefeedUpdates {
+		info.UpdateHistory = info.UpdateHistory[len(info.UpdateHistory)-maxChangefeedUpdates:]
+	}
+	return update, nil
+}
+
+// RecordError records the error and the action taken for it.
+func (info *ChangeFeedInfo) RecordError(err *RunningError, action string, now time.Time) {
+	info.ErrorRecords = append(info.ErrorRecords, &ChangefeedErrorRecord{
+		Time:   now,
+		Error:  err,
+		Action: action,
+	})
+	if len(info.ErrorRecords) > maxChangefeedErrorRecords {
+		info.ErrorRecords = info.ErrorRecords[len(info.ErrorRecords)-maxChangefeedErrorRecords:]
+	}
+}
+
 // GetStartTs returns StartTs if it's  specified or using the CreateTime of changefeed.
 func (info *ChangeFeedInfo) GetStartTs() uint64 {
 	if info.StartTs > 0 {
@@ -182,6 +433,19 @@ func (info *ChangeFeedInfo) GetCheckpointTs(status *ChangeFeedStatus) uint64 {
 	return info.GetStartTs()
 }
 
+// GetMinPausedTs returns the minimum ts the tables are paused at, the GC
+// safepoint and the schema snapshots are kept for it. MaxUint64 is returned if
+// no table is paused.
+func (info *ChangeFeedInfo) GetMinPausedTs() uint64 {
+	minTs := uint64(math.MaxUint64)
+	for _, pausedTs := range info.PausedTables {
+		if pausedTs < minTs {
+			minTs = pausedTs
+		}
+	}
+	return minTs
+}
+
 // GetTargetTs returns TargetTs if it's specified, otherwise MaxUint64 is returned.
 func (info *ChangeFeedInfo) GetTargetTs() uint64 {
 	if info.TargetTs > 0 {
@@ -252,6 +516,9 @@ func (info *ChangeFeedInfo) VerifyAndFix() error {
 	if info.Config.Consistent == nil {
 		info.Config.Consistent = defaultConfig.Consistent
 	}
+	if info.Config.EventTrace == nil {
+		info.Config.EventTrace = defaultConfig.EventTrace
+	}
 	return nil
 }
 
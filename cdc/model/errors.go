@@ -13,9 +13,49 @@
 
 package model
 
+import (
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/errorutil"
+)
+
 // RunningError represents some running error from cdc components, such as processor.
 type RunningError struct {
 	Addr    string `json:"addr"`
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	// Class is the retryability class of the error, see cerror.ErrorClass.
+	Class string `json:"class,omitempty"`
+	// Hint is the remediation hint of the error, it is empty if there is no
+	// known remediation.
+	Hint string `json:"hint,omitempty"`
+}
+
+// NewRunningError creates a RunningError from the error, the code of
+// unknownErr is used if the error doesn't carry an error code.
+func NewRunningError(addr string, err error, unknownErr *errors.Error) *RunningError {
+	code, ok := cerror.RFCCode(err)
+	if !ok {
+		code = unknownErr.RFCCode()
+	}
+	class := cerror.ErrorClassOf(code)
+	if class == cerror.ErrorClassRetryable && errorutil.IsDownstreamSchemaError(err) {
+		class = cerror.ErrorClassDownstreamSchema
+	}
+	return &RunningError{
+		Addr:    addr,
+		Code:    string(code),
+		Message: err.Error(),
+		Class:   string(class),
+		Hint:    cerror.ErrorHint(code),
+	}
+}
+
+// ErrorClass returns the class of the error, it is classified by the code if
+// the error is reported by a server without the classification.
+func (e *RunningError) ErrorClass() cerror.ErrorClass {
+	if e.Class != "" {
+		return cerror.ErrorClass(e.Class)
+	}
+	return cerror.ErrorClassOf(errors.RFCErrorCode(e.Code))
 }
@@ -0,0 +1,41 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRunningError(t *testing.T) {
+	t.Parallel()
+	err := NewRunningError("127.0.0.1:8300", cerror.ErrGCTTLExceeded.GenWithStackByArgs(1, "test"), cerror.ErrOwnerUnknown)
+	require.Equal(t, "127.0.0.1:8300", err.Addr)
+	require.Equal(t, string(cerror.ErrGCTTLExceeded.RFCCode()), err.Code)
+	require.Equal(t, string(cerror.ErrorClassFatal), err.Class)
+	require.NotEmpty(t, err.Hint)
+	require.Contains(t, err.Message, "ErrGCTTLExceeded")
+
+	err = NewRunningError("127.0.0.1:8300", errors.New("unknown error"), cerror.ErrOwnerUnknown)
+	require.Equal(t, string(cerror.ErrOwnerUnknown.RFCCode()), err.Code)
+	require.Equal(t, string(cerror.ErrorClassRetryable), err.Class)
+	require.Empty(t, err.Hint)
+	require.Equal(t, "unknown error", err.Message)
+
+	clone := (&TaskPosition{Error: err}).Clone()
+	require.Equal(t, err, clone.Error)
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/hotkey"
 )
 
 // JSONTime used to wrap time into json format
@@ -62,6 +63,8 @@ type ChangefeedCommonInfo struct {
 	CheckpointTSO  uint64        `json:"checkpoint_tso"`
 	CheckpointTime JSONTime      `json:"checkpoint_time"`
 	RunningError   *RunningError `json:"error"`
+	// whether the changefeed is quarantined for blocking the GC
+	GCQuarantined bool `json:"gc_quarantined,omitempty"`
 }
 
 // MarshalJSON use to marshal ChangefeedCommonInfo
@@ -93,6 +96,24 @@ type ChangefeedDetail struct {
 	ErrorHis       []int64             `json:"error_history"`
 	CreatorVersion string              `json:"creator_version"`
 	TaskStatus     []CaptureTaskStatus `json:"task_status"`
+	// the paused tables mapped to the ts they are paused at
+	PausedTables map[TableID]Ts `json:"paused_tables,omitempty"`
+	// the sink which the changefeed fails over to when the sink is unhealthy
+	StandbySinkURI string `json:"standby_sink_uri,omitempty"`
+	// the recent failovers of the sink, the latest last
+	SinkSwitchovers []*SinkSwitchover `json:"sink_switchovers,omitempty"`
+	// the features enabled for the changefeed
+	Features []string `json:"features,omitempty"`
+	// the upstream cluster, it is omitted if the changefeed replicates from
+	// the upstream cluster of the server
+	Upstream *UpstreamInfo `json:"upstream,omitempty"`
+	// the recent updates of the config, the latest last
+	UpdateHistory []*ChangefeedUpdate `json:"update_history,omitempty"`
+	// the recent errors and how they are handled, the latest last
+	ErrorRecords []*ChangefeedErrorRecord `json:"error_records,omitempty"`
+	// the quarantine of the changefeed for blocking the GC, it is omitted if
+	// the changefeed is not quarantined
+	GCQuarantine *GCQuarantine `json:"gc_quarantine,omitempty"`
 }
 
 // MarshalJSON use to marshal ChangefeedDetail
@@ -109,12 +130,95 @@ func (c ChangefeedDetail) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// ChangefeedSLOStatus holds the replication lag SLO status of a changefeed
+type ChangefeedSLOStatus struct {
+	ID string `json:"id"`
+	// The maximum checkpoint lag in seconds, 0 means the SLO is disabled.
+	MaxCheckpointLag int64 `json:"max_checkpoint_lag"`
+	// The checkpoint lag in seconds when the SLO is evaluated last time.
+	CheckpointLag float64 `json:"checkpoint_lag"`
+	Violated      bool    `json:"violated"`
+	// The time since when the SLO is violated, it is nil if the SLO is met.
+	ViolatedSince *JSONTime `json:"violated_since,omitempty"`
+}
+
+// ChangefeedChecksumStatus holds the result of the last consistency check of a
+// changefeed, which compares the checksums of the upstream and downstream tables
+// at a syncpoint.
+type ChangefeedChecksumStatus struct {
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled"`
+	// The upstream ts of the syncpoint checked last time, 0 means no check is finished.
+	CheckedTs uint64 `json:"checked_ts"`
+	// The downstream ts of the syncpoint checked last time.
+	DownstreamTs  uint64    `json:"downstream_ts"`
+	CheckTime     *JSONTime `json:"check_time,omitempty"`
+	CheckedTables int       `json:"checked_tables"`
+	// The tables whose checksums mismatch, which are empty if the upstream and
+	// downstream are consistent.
+	MismatchedTables []ChecksumMismatch `json:"mismatched_tables,omitempty"`
+	// The error of the last check, the tables are not fully checked if it is not empty.
+	Error string `json:"error,omitempty"`
+}
+
+// ChangefeedDDLBarrier holds the DDL which blocks a changefeed as a barrier, the
+// checkpoint of the changefeed can't advance until the DDL is executed downstream.
+type ChangefeedDDLBarrier struct {
+	ID string `json:"id"`
+	// Blocked is true if the checkpoint reaches the DDL, the other fields of
+	// the DDL are empty if no DDL is the barrier of the changefeed.
+	Blocked  bool   `json:"blocked"`
+	Query    string `json:"query,omitempty"`
+	Type     string `json:"type,omitempty"`
+	CommitTs uint64 `json:"commit_ts,omitempty"`
+	// The time since when the DDL is executed downstream, and how long it has
+	// been executed in seconds, they are empty if the DDL isn't executed yet.
+	ExecutingSince    *JSONTime `json:"executing_since,omitempty"`
+	ExecutingDuration float64   `json:"executing_duration,omitempty"`
+	// The commit ts of the DDL which will be skipped by the request of the user, 0 means none.
+	SkippingTs uint64 `json:"skipping_ts,omitempty"`
+	// The error of the changefeed, which may be caused by executing the DDL.
+	Error *RunningError `json:"error,omitempty"`
+}
+
+// DDLBarrierConfig is used to skip or retry the DDL which blocks a changefeed.
+type DDLBarrierConfig struct {
+	// The commit ts of the DDL, which must be the barrier of the changefeed.
+	CommitTs uint64 `json:"commit_ts"`
+}
+
+// SyncpointRecord maps the upstream ts of a syncpoint to the downstream ts,
+// the downstream read at SecondaryTs is consistent with the upstream read at
+// PrimaryTs.
+type SyncpointRecord struct {
+	PrimaryTs   uint64 `json:"primary_ts"`
+	SecondaryTs uint64 `json:"secondary_ts"`
+}
+
+// ChecksumMismatch is a table whose checksums of the upstream and downstream mismatch
+type ChecksumMismatch struct {
+	Schema string `json:"schema"`
+	Table  string `json:"table"`
+	// The key ranges of the mismatched chunks
+	Chunks []string `json:"chunks"`
+}
+
 // ChangefeedConfig use to create a changefeed
 type ChangefeedConfig struct {
 	ID       string `json:"changefeed_id"`
 	StartTS  uint64 `json:"start_ts"`
 	TargetTS uint64 `json:"target_ts"`
 	SinkURI  string `json:"sink_uri"`
+	// the start time in the format of "2006-01-02 15:04:05" in the timezone,
+	// which is resolved to the start ts
+	StartTime string `json:"start_time"`
+	// the path of a BR backupmeta file or the backup directory on the cdc
+	// server, the end ts of the backup is used as the start ts
+	BackupMetaPath string `json:"backup_meta_path"`
+	// the sink which the changefeed fails over to when the sink is unhealthy
+	StandbySinkURI string `json:"standby_sink_uri"`
+	// the upstream cluster, the upstream cluster of the server is used if it is nil
+	Upstream *UpstreamInfo `json:"upstream"`
 	// timezone used when checking sink uri
 	TimeZone string `json:"timezone" default:"system"`
 	// if true, force to replicate some ineligible tables
@@ -124,6 +228,116 @@ type ChangefeedConfig struct {
 	IgnoreTxnStartTs      []uint64           `json:"ignore_txn_start_ts"`
 	MounterWorkerNum      int                `json:"mounter_worker_num" default:"16"`
 	SinkConfig            *config.SinkConfig `json:"sink_config"`
+	// the strategy of replicating the tables without keys, all-columns or row-id
+	NoKeyTableStrategy config.NoKeyTableStrategy `json:"no_key_table_strategy"`
+	// the feature flags, the features not specified are left unchanged
+	Features map[string]bool `json:"features"`
+}
+
+// ChangefeedUpdateResult is the result of updating a changefeed
+type ChangefeedUpdateResult struct {
+	ID string `json:"changefeed_id"`
+	// the paths of the changed fields of the changefeed info
+	Changes []string `json:"changes"`
+	// the data which is skipped because of the update, such as the changes
+	// of the tables which are filtered out after the checkpoint ts
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ChangefeedPrecheckReport is the report of a changefeed creation precheck
+type ChangefeedPrecheckReport struct {
+	ID string `json:"changefeed_id"`
+	// true if all the precheck items are passed
+	Passed  bool   `json:"passed"`
+	StartTs uint64 `json:"start_ts"`
+	// the minimum service GC safepoint of the upstream cluster
+	GCSafePoint uint64 `json:"gc_safe_point"`
+	// the duration in seconds from the GC safepoint to the start ts
+	GCSafePointMargin float64 `json:"gc_safe_point_margin"`
+	// the number of tables to be replicated, partitions are counted separately
+	EligibleTableCount int         `json:"eligible_table_count"`
+	IneligibleTables   []TableName `json:"ineligible_tables"`
+	// the number of regions of the tables to be replicated
+	RegionCount int             `json:"region_count"`
+	Items       []*PrecheckItem `json:"items"`
+}
+
+// PrecheckItem is the result of a precheck item
+type PrecheckItem struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	// the reason of the failure, or a warning if the item is passed
+	Message string `json:"message,omitempty"`
+}
+
+// ReconcileState is the state of a changefeed in the declarative reconciliation
+type ReconcileState string
+
+const (
+	// ReconcileStateInSync means the changefeed matches its spec
+	ReconcileStateInSync ReconcileState = "in_sync"
+	// ReconcileStateMissing means the changefeed of the spec doesn't exist
+	ReconcileStateMissing ReconcileState = "missing"
+	// ReconcileStateDrifted means the changefeed doesn't match its spec
+	ReconcileStateDrifted ReconcileState = "drifted"
+	// ReconcileStateInvalid means the spec can't be parsed or is invalid
+	ReconcileStateInvalid ReconcileState = "invalid"
+	// ReconcileStateOrphaned means the changefeed is created by the
+	// reconciliation, but its spec is removed
+	ReconcileStateOrphaned ReconcileState = "orphaned"
+	// ReconcileStateUnmanaged means the changefeed is not created by the
+	// reconciliation and has no spec
+	ReconcileStateUnmanaged ReconcileState = "unmanaged"
+)
+
+// ReconcileStatus is the status of the declarative changefeed reconciliation
+type ReconcileStatus struct {
+	Enabled bool `json:"enabled"`
+	// the directory or the etcd prefix of the changefeed specs
+	Source            string    `json:"source"`
+	LastReconcileTime *JSONTime `json:"last_reconcile_time,omitempty"`
+	// the error which fails the whole reconciliation, such as failing to load the specs
+	Error       string                       `json:"error,omitempty"`
+	Changefeeds []*ChangefeedReconcileStatus `json:"changefeeds"`
+}
+
+// ChangefeedReconcileStatus is the reconciliation status of a changefeed
+type ChangefeedReconcileStatus struct {
+	ID string `json:"changefeed_id"`
+	// the file or the etcd key of the spec, it is empty if the changefeed has no spec
+	Spec  string         `json:"spec,omitempty"`
+	State ReconcileState `json:"state"`
+	// the fields of the changefeed which are different from the spec
+	DriftFields []string `json:"drift_fields,omitempty"`
+	// the action taken by the last reconciliation, such as "create", "pause", "update", "resume" and "remove"
+	Action string `json:"action,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// UpdateTablesConfig use to add tables to or remove tables from a running changefeed
+type UpdateTablesConfig struct {
+	// the tables to add or remove, in the syntax of filter rules, such as "db.tbl" or "db.*"
+	Matcher []string `json:"matcher"`
+	// the ts from which the added tables start replicating, 0 means the checkpoint ts of the changefeed.
+	// The data of the added tables before start ts is expected to be loaded into the downstream in advance,
+	// unless Bootstrap is true.
+	StartTs uint64 `json:"start_ts"`
+	// Bootstrap is true if the existing rows of the added tables at start ts are written to the downstream
+	// by the backfill of the changefeed before the tables start replicating.
+	Bootstrap bool `json:"bootstrap"`
+}
+
+// PauseTablesConfig use to pause or resume tables of a running changefeed
+type PauseTablesConfig struct {
+	TableIDs []TableID `json:"table_ids"`
+}
+
+// RewindTableConfig use to rewind the replication position of a table in a running changefeed
+type RewindTableConfig struct {
+	TableID TableID `json:"table_id"`
+	// the ts from which the events of the table are emitted again, it should be
+	// less than the checkpoint ts of the changefeed and greater than the GC safepoint.
+	Ts uint64 `json:"ts"`
 }
 
 // ProcessorCommonInfo holds the common info of a processor
@@ -144,6 +358,65 @@ type ProcessorDetail struct {
 	Error *RunningError `json:"error"`
 }
 
+// TablePipelineStatus holds the in-process status of a table pipeline
+type TablePipelineStatus struct {
+	CaptureID string `json:"capture_id"`
+	TableID   int64  `json:"table_id"`
+	TableName string `json:"table_name"`
+	// The status of the table pipeline, Initializing, Running or Stopped.
+	Status string `json:"status"`
+	// The resolved ts of the sorter node.
+	SorterResolvedTs uint64 `json:"sorter_resolved_ts"`
+	// The resolved ts received by the sink node.
+	ResolvedTs uint64 `json:"resolved_ts"`
+	// The checkpoint ts flushed by the sink node.
+	CheckpointTs uint64 `json:"checkpoint_ts"`
+	// The barrier ts which the sink node is not allowed to exceed.
+	BarrierTs uint64 `json:"barrier_ts"`
+	// The memory consumption of the flow controller in bytes.
+	FlowControllerConsumption uint64 `json:"flow_controller_consumption"`
+	// The number of the row events output by the sorter node.
+	ProcessedEvents uint64 `json:"processed_events"`
+	// The number of messages in the output channel of each pipeline node.
+	OutputChannelLength map[string]int `json:"output_channel_length"`
+}
+
+// ResourceUsageStatus holds the approximate resource usage of the changefeeds
+// running on a capture
+type ResourceUsageStatus struct {
+	CaptureID string `json:"capture_id"`
+	// The CPU usage of the capture process in cores during the last sampling
+	// interval.
+	CPUUsage float64 `json:"cpu_usage"`
+	// The heap memory in use of the capture process in bytes.
+	HeapInUse uint64 `json:"heap_in_use"`
+	// The changefeeds sorted by the CPU usage in descending order.
+	Changefeeds []ChangefeedResourceUsage `json:"changefeeds"`
+}
+
+// ChangefeedResourceUsage holds the approximate resource usage of a changefeed
+// on a capture. The CPU time of the capture process is attributed to the
+// changefeeds in proportion to the events processed by their table pipelines.
+type ChangefeedResourceUsage struct {
+	ChangefeedID ChangeFeedID `json:"changefeed_id"`
+	// The CPU time in seconds attributed to the changefeed since it is
+	// sampled on the capture.
+	CPUTime float64 `json:"cpu_time"`
+	// The CPU usage in cores during the last sampling interval.
+	CPUUsage float64 `json:"cpu_usage"`
+	// The memory consumption of the table pipelines in bytes.
+	MemoryUsage uint64 `json:"memory_usage"`
+	// The number of the row events processed by the table pipelines.
+	ProcessedEvents uint64 `json:"processed_events"`
+}
+
+// HotKeysStatus holds the hot keys recorded by a capture
+type HotKeysStatus struct {
+	CaptureID string `json:"capture_id"`
+	// The hot keys of the causality detection and dispatching of the sinks.
+	Reports []hotkey.Report `json:"reports"`
+}
+
 // CaptureTaskStatus holds TaskStatus of a capture
 type CaptureTaskStatus struct {
 	CaptureID string `json:"capture_id"`
@@ -152,9 +425,20 @@ type CaptureTaskStatus struct {
 	Operation map[TableID]*TableOperation `json:"table_operations"`
 }
 
+// DrainCaptureStatus holds the status of draining a capture
+type DrainCaptureStatus struct {
+	CaptureID string `json:"capture_id"`
+	// Whether the tables of the capture are being moved to other captures.
+	Draining bool `json:"draining"`
+	// The number of tables which are still replicated by the capture,
+	// including the tables being added to or removed from the capture.
+	TableCount int `json:"table_count"`
+}
+
 // Capture holds common information of a capture in cdc
 type Capture struct {
-	ID            string `json:"id"`
-	IsOwner       bool   `json:"is_owner"`
-	AdvertiseAddr string `json:"address"`
+	ID            string            `json:"id"`
+	IsOwner       bool              `json:"is_owner"`
+	AdvertiseAddr string            `json:"address"`
+	Labels        map[string]string `json:"labels,omitempty"`
 }
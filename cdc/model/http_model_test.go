@@ -23,9 +23,8 @@ import (
 
 func TestChangefeedCommonInfoMarshalJSON(t *testing.T) {
 	runningErr := &RunningError{
-		"",
-		string(cerror.ErrProcessorUnknown.RFCCode()),
-		cerror.ErrProcessorUnknown.GetMsg(),
+		Code:    string(cerror.ErrProcessorUnknown.RFCCode()),
+		Message: cerror.ErrProcessorUnknown.GetMsg(),
 	}
 	cfInfo := &ChangefeedCommonInfo{
 		ID:           "test",
@@ -46,9 +45,8 @@ func TestChangefeedCommonInfoMarshalJSON(t *testing.T) {
 
 func TestChangefeedDetailMarshalJSON(t *testing.T) {
 	runningErr := &RunningError{
-		"",
-		string(cerror.ErrProcessorUnknown.RFCCode()),
-		cerror.ErrProcessorUnknown.GetMsg(),
+		Code:    string(cerror.ErrProcessorUnknown.RFCCode()),
+		Message: cerror.ErrProcessorUnknown.GetMsg(),
 	}
 	cfDetail := &ChangefeedDetail{
 		ID:           "test",
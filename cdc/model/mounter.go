@@ -15,6 +15,7 @@ package model
 
 import (
 	"context"
+	"time"
 )
 
 // PolymorphicEvent describes an event can be in multiple states
@@ -26,6 +27,20 @@ type PolymorphicEvent struct {
 	RawKV    *RawKVEntry
 	Row      *RowChangedEvent
 	finished chan struct{}
+
+	// Trace is only set for sampled events, see EventTrace.
+	Trace *EventTrace
+}
+
+// EventTrace records the time at which a sampled event leaves each stage of
+// the table pipeline. It is used to observe the latency of every stage.
+type EventTrace struct {
+	Pulled  time.Time
+	Sorted  time.Time
+	Mounted time.Time
+	Emitted time.Time
+	// Span is true if the stages are recorded as OpenTelemetry spans.
+	Span bool
 }
 
 // NewPolymorphicEvent creates a new PolymorphicEvent with a raw KV
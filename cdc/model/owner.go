@@ -30,6 +30,9 @@ type AdminJobType int
 // AdminJobOption records addition options of an admin job
 type AdminJobOption struct {
 	ForceRemove bool
+	// OverwriteCheckpointTs is the checkpoint ts from which a quarantined
+	// changefeed is resumed, 0 means not overwriting the checkpoint ts.
+	OverwriteCheckpointTs Ts
 }
 
 // AdminJob holds an admin job
@@ -118,6 +121,8 @@ func (tp *TaskPosition) Clone() *TaskPosition {
 			Addr:    tp.Error.Addr,
 			Code:    tp.Error.Code,
 			Message: tp.Error.Message,
+			Class:   tp.Error.Class,
+			Hint:    tp.Error.Hint,
 		}
 	}
 	return ret
@@ -194,6 +199,13 @@ type TaskWorkload map[TableID]WorkloadInfo
 // WorkloadInfo records the workload info of a table
 type WorkloadInfo struct {
 	Workload uint64 `json:"workload"`
+	// EventRate is the number of row changed events emitted to the sink per second
+	EventRate float64 `json:"event-rate,omitempty"`
+	// SorterBacklog is the lag in seconds between the resolved ts of the sorter
+	// and the checkpoint ts of the sink
+	SorterBacklog float64 `json:"sorter-backlog,omitempty"`
+	// SinkFlushLatency is the average duration in seconds of flushing the sink
+	SinkFlushLatency float64 `json:"sink-flush-latency,omitempty"`
 }
 
 // Unmarshal unmarshals into *TaskWorkload from json marshal byte slice
@@ -216,6 +228,9 @@ func (w *TaskWorkload) Marshal() (string, error) {
 type TableReplicaInfo struct {
 	StartTs     Ts      `json:"start-ts"`
 	MarkTableID TableID `json:"mark-table-id"`
+	// NewlyAdded means the table is dispatched at the ts it is added to the
+	// changefeed, rather than moved, rewound or restarted from the checkpoint.
+	NewlyAdded bool `json:"newly-added,omitempty"`
 }
 
 // Clone clones a TableReplicaInfo
@@ -428,6 +443,9 @@ type ChangeFeedStatus struct {
 	ResolvedTs   uint64       `json:"resolved-ts"`
 	CheckpointTs uint64       `json:"checkpoint-ts"`
 	AdminJobType AdminJobType `json:"admin-job-type"`
+	// ResumingTables holds the resumed tables which are still in the paused
+	// tables of the changefeed info, until they are replicated again.
+	ResumingTables []TableID `json:"resuming-tables,omitempty"`
 }
 
 // Marshal returns json encoded string of ChangeFeedStatus, only contains necessary fields stored in storage
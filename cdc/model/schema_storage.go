@@ -199,6 +199,13 @@ func (ti *TableInfo) initColumnsFlag() {
 		if mysql.HasUnsignedFlag(colInfo.Flag) {
 			flag.SetIsUnsigned()
 		}
+		if mysql.HasAutoIncrementFlag(colInfo.Flag) {
+			flag.SetIsAutoIncrement()
+		}
+		// the AUTO_RANDOM column must be the clustered integer primary key.
+		if ti.ContainsAutoRandomBits() && mysql.HasPriKeyFlag(colInfo.Flag) {
+			flag.SetIsAutoRandom()
+		}
 		ti.ColumnsFlag[colInfo.ID] = flag
 	}
 
@@ -39,6 +39,8 @@ const (
 	MqMessageTypeDDL
 	// MqMessageTypeResolved is resolved type of message key
 	MqMessageTypeResolved
+	// MqMessageTypeTableStarted is table started type of message key
+	MqMessageTypeTableStarted
 )
 
 // ColumnFlagType is for encapsulating the flag operations for different flags.
@@ -61,6 +63,16 @@ const (
 	NullableFlag
 	// UnsignedFlag means the column stores an unsigned integer
 	UnsignedFlag
+	// AutoIncrementFlag means the column is an AUTO_INCREMENT column
+	AutoIncrementFlag
+	// AutoRandomFlag means the column is an AUTO_RANDOM column
+	AutoRandomFlag
+	// OffloadedFlag means the value of the column is offloaded to the external
+	// storage, and the value is the URI of the object
+	OffloadedFlag
+	// TruncatedFlag means the value of the column is truncated since the row
+	// is larger than the max row size
+	TruncatedFlag
 )
 
 // SetIsBinary sets BinaryFlag
@@ -183,12 +195,70 @@ func (b *ColumnFlagType) UnsetIsUnsigned() {
 	(*util.Flag)(b).Remove(util.Flag(UnsignedFlag))
 }
 
+// IsAutoIncrement shows whether AutoIncrementFlag is set
+func (b *ColumnFlagType) IsAutoIncrement() bool {
+	return (*util.Flag)(b).HasAll(util.Flag(AutoIncrementFlag))
+}
+
+// SetIsAutoIncrement sets AutoIncrementFlag
+func (b *ColumnFlagType) SetIsAutoIncrement() {
+	(*util.Flag)(b).Add(util.Flag(AutoIncrementFlag))
+}
+
+// UnsetIsAutoIncrement unsets AutoIncrementFlag
+func (b *ColumnFlagType) UnsetIsAutoIncrement() {
+	(*util.Flag)(b).Remove(util.Flag(AutoIncrementFlag))
+}
+
+// IsAutoRandom shows whether AutoRandomFlag is set
+func (b *ColumnFlagType) IsAutoRandom() bool {
+	return (*util.Flag)(b).HasAll(util.Flag(AutoRandomFlag))
+}
+
+// SetIsAutoRandom sets AutoRandomFlag
+func (b *ColumnFlagType) SetIsAutoRandom() {
+	(*util.Flag)(b).Add(util.Flag(AutoRandomFlag))
+}
+
+// UnsetIsAutoRandom unsets AutoRandomFlag
+func (b *ColumnFlagType) UnsetIsAutoRandom() {
+	(*util.Flag)(b).Remove(util.Flag(AutoRandomFlag))
+}
+
+// IsOffloaded shows whether OffloadedFlag is set
+func (b *ColumnFlagType) IsOffloaded() bool {
+	return (*util.Flag)(b).HasAll(util.Flag(OffloadedFlag))
+}
+
+// SetIsOffloaded sets OffloadedFlag
+func (b *ColumnFlagType) SetIsOffloaded() {
+	(*util.Flag)(b).Add(util.Flag(OffloadedFlag))
+}
+
+// UnsetIsOffloaded unsets OffloadedFlag
+func (b *ColumnFlagType) UnsetIsOffloaded() {
+	(*util.Flag)(b).Remove(util.Flag(OffloadedFlag))
+}
+
+// IsTruncated shows whether TruncatedFlag is set
+func (b *ColumnFlagType) IsTruncated() bool {
+	return (*util.Flag)(b).HasAll(util.Flag(TruncatedFlag))
+}
+
+// SetIsTruncated sets TruncatedFlag
+func (b *ColumnFlagType) SetIsTruncated() {
+	(*util.Flag)(b).Add(util.Flag(TruncatedFlag))
+}
+
 // TableName represents name of a table, includes table name and schema name.
 type TableName struct {
 	Schema      string `toml:"db-name" json:"db-name" msg:"db-name"`
 	Table       string `toml:"tbl-name" json:"tbl-name" msg:"tbl-name"`
 	TableID     int64  `toml:"tbl-id" json:"tbl-id" msg:"tbl-id"`
 	IsPartition bool   `toml:"is-partition" json:"is-partition" msg:"is-partition"`
+	// Partition is the name of the partition of the row changed events, it's
+	// empty if the table is not partitioned.
+	Partition string `toml:"-" json:"-" msg:"-"`
 }
 
 // String implements fmt.Stringer interface.
@@ -318,10 +388,12 @@ func (r *RowChangedEvent) HandleKeyColumns() []*Column {
 
 // Column represents a column value in row changed event
 type Column struct {
-	Name  string         `json:"name" msg:"name"`
-	Type  byte           `json:"type" msg:"type"`
-	Flag  ColumnFlagType `json:"flag" msg:"-"`
-	Value interface{}    `json:"value" msg:"value"`
+	Name      string         `json:"name" msg:"name"`
+	Type      byte           `json:"type" msg:"type"`
+	Charset   string         `json:"charset,omitempty" msg:"charset"`
+	Collation string         `json:"collation,omitempty" msg:"collation"`
+	Flag      ColumnFlagType `json:"flag" msg:"-"`
+	Value     interface{}    `json:"value" msg:"value"`
 }
 
 // RedoColumn stores Column change
@@ -378,12 +450,15 @@ func ColumnValueString(c interface{}) string {
 type ColumnInfo struct {
 	Name string `msg:"name"`
 	Type byte   `msg:"type"`
+	// Default is the value taken by existing rows when the column is added.
+	Default interface{} `msg:"-"`
 }
 
 // FromTiColumnInfo populates cdc's ColumnInfo from TiDB's model.ColumnInfo
 func (c *ColumnInfo) FromTiColumnInfo(tiColumnInfo *model.ColumnInfo) {
 	c.Type = tiColumnInfo.Tp
 	c.Name = tiColumnInfo.Name.O
+	c.Default = tiColumnInfo.GetOriginDefaultValue()
 }
 
 // SimpleTableInfo is the simplified table info passed to the sink
@@ -405,6 +480,10 @@ type DDLEvent struct {
 	PreTableInfo *SimpleTableInfo `msg:"pre-table-info"`
 	Query        string           `msg:"query"`
 	Type         model.ActionType `msg:"-"`
+	// PartitionInfo and PrePartitionInfo are the partitions of the table after
+	// and before the DDL, they are nil if the table is not partitioned.
+	PartitionInfo    *model.PartitionInfo `msg:"-"`
+	PrePartitionInfo *model.PartitionInfo `msg:"-"`
 }
 
 // RedoDDLEvent represents DDL event used in redo log persistent
@@ -434,6 +513,7 @@ func (d *DDLEvent) FromJob(job *model.Job, preTableInfo *TableInfo) {
 
 		d.TableInfo.Table = tableName
 		d.TableInfo.TableID = job.TableID
+		d.PartitionInfo = tableInfo.GetPartitionInfo()
 	}
 	d.fillPreTableInfo(preTableInfo)
 }
@@ -446,6 +526,7 @@ func (d *DDLEvent) fillPreTableInfo(preTableInfo *TableInfo) {
 	d.PreTableInfo.Schema = preTableInfo.TableName.Schema
 	d.PreTableInfo.Table = preTableInfo.TableName.Table
 	d.PreTableInfo.TableID = preTableInfo.ID
+	d.PrePartitionInfo = preTableInfo.GetPartitionInfo()
 
 	d.PreTableInfo.ColumnInfo = make([]*ColumnInfo, len(preTableInfo.Columns))
 	for i, colInfo := range preTableInfo.Columns {
@@ -454,7 +535,29 @@ func (d *DDLEvent) fillPreTableInfo(preTableInfo *TableInfo) {
 	}
 }
 
+// AddedColumns returns the columns added by an ADD COLUMN DDL.
+func (d *DDLEvent) AddedColumns() []*ColumnInfo {
+	if d.Type != model.ActionAddColumn && d.Type != model.ActionAddColumns {
+		return nil
+	}
+	if d.TableInfo == nil || d.PreTableInfo == nil {
+		return nil
+	}
+	existing := make(map[string]struct{}, len(d.PreTableInfo.ColumnInfo))
+	for _, col := range d.PreTableInfo.ColumnInfo {
+		existing[col.Name] = struct{}{}
+	}
+	var added []*ColumnInfo
+	for _, col := range d.TableInfo.ColumnInfo {
+		if _, ok := existing[col.Name]; !ok {
+			added = append(added, col)
+		}
+	}
+	return added
+}
+
 // SingleTableTxn represents a transaction which includes many row events in a single table
+//
 //msgp:ignore SingleTableTxn
 type SingleTableTxn struct {
 	// data fields of SingleTableTxn
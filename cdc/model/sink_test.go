@@ -54,6 +54,21 @@ func TestSetFlag(t *testing.T) {
 	require.True(t, flag.IsNullable())
 	flag.UnsetIsNullable()
 	require.False(t, flag.IsNullable())
+
+	flag = ColumnFlagType(0)
+	flag.SetIsAutoIncrement()
+	flag.SetIsAutoRandom()
+	require.True(t, flag.IsAutoIncrement() && flag.IsAutoRandom())
+	flag.UnsetIsAutoIncrement()
+	flag.UnsetIsAutoRandom()
+	require.False(t, flag.IsAutoIncrement() || flag.IsAutoRandom())
+	flag.SetIsOffloaded()
+	require.True(t, flag.IsOffloaded())
+	flag.UnsetIsOffloaded()
+	require.False(t, flag.IsOffloaded())
+	require.False(t, flag.IsTruncated())
+	flag.SetIsTruncated()
+	require.True(t, flag.IsTruncated())
 }
 
 func TestFlagValue(t *testing.T) {
@@ -199,7 +214,7 @@ func TestDDLEventFromJob(t *testing.T) {
 				Name: timodel.CIStr{O: "t1"},
 				Columns: []*timodel.ColumnInfo{
 					{ID: 1, Name: timodel.CIStr{O: "id"}, FieldType: types.FieldType{Flag: mysql.PriKeyFlag}, State: timodel.StatePublic},
-					{ID: 2, Name: timodel.CIStr{O: "a"}, FieldType: types.FieldType{}, State: timodel.StatePublic},
+					{ID: 2, Name: timodel.CIStr{O: "a"}, FieldType: types.FieldType{}, State: timodel.StatePublic, OriginDefaultValue: "10"},
 				},
 			},
 			FinishedTS: 420536581196873729,
@@ -224,8 +239,13 @@ func TestDDLEventFromJob(t *testing.T) {
 	require.Equal(t, uint64(420536581131337731), event.StartTs)
 	require.Equal(t, event.TableInfo.TableID, int64(49))
 	require.Equal(t, 1, len(event.PreTableInfo.ColumnInfo))
+	added := event.AddedColumns()
+	require.Len(t, added, 1)
+	require.Equal(t, "a", added[0].Name)
+	require.Equal(t, "10", added[0].Default)
 
 	event = &DDLEvent{}
 	event.FromJob(job, nil)
 	require.Nil(t, event.PreTableInfo)
+	require.Nil(t, event.AddedColumns())
 }
@@ -0,0 +1,104 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/security"
+)
+
+// DefaultUpstreamID is the ID of the upstream cluster of the server, which is
+// the upstream of the changefeeds not specifying their own.
+const DefaultUpstreamID = "default"
+
+// UpstreamInfo is the upstream TiDB cluster which a changefeed replicates from.
+// Note that the TLS settings of the TiKV client are global in a process, so all
+// the upstreams should share the same credential as the server, see
+// CheckCredential.
+type UpstreamInfo struct {
+	PDAddrs  []string `json:"pd-addrs"`
+	CAPath   string   `json:"ca-path,omitempty"`
+	CertPath string   `json:"cert-path,omitempty"`
+	KeyPath  string   `json:"key-path,omitempty"`
+}
+
+// ID returns the ID of the upstream, the upstreams with the same PD addresses
+// share the same ID. It returns DefaultUpstreamID if the info is nil.
+func (u *UpstreamInfo) ID() string {
+	if u == nil {
+		return DefaultUpstreamID
+	}
+	addrs := make([]string, 0, len(u.PDAddrs))
+	for _, addr := range u.PDAddrs {
+		addrs = append(addrs, strings.TrimRight(addr, "/"))
+	}
+	sort.Strings(addrs)
+	return strings.Join(addrs, ",")
+}
+
+// IsTLSEnabled returns whether the upstream is connected with TLS.
+func (u *UpstreamInfo) IsTLSEnabled() bool {
+	return u != nil && u.CAPath != ""
+}
+
+// Validate checks whether the upstream info is valid.
+func (u *UpstreamInfo) Validate() error {
+	if u == nil {
+		return nil
+	}
+	if len(u.PDAddrs) == 0 {
+		return cerror.ErrInvalidUpstream.GenWithStackByArgs("pd-addrs is empty")
+	}
+	for _, addr := range u.PDAddrs {
+		pdURL, err := url.Parse(addr)
+		if err != nil || (pdURL.Scheme != "http" && pdURL.Scheme != "https") || pdURL.Host == "" {
+			return cerror.ErrInvalidUpstream.GenWithStackByArgs("the PD address " + addr + " should be a valid http or https URL")
+		}
+		if (pdURL.Scheme == "https") != u.IsTLSEnabled() {
+			return cerror.ErrInvalidUpstream.GenWithStackByArgs("the scheme of the PD address " + addr + " mismatches the TLS settings")
+		}
+	}
+	if u.IsTLSEnabled() && (u.CertPath == "" || u.KeyPath == "") {
+		return cerror.ErrInvalidUpstream.GenWithStackByArgs("cert-path and key-path are required if ca-path is set")
+	}
+	return nil
+}
+
+// CheckCredential checks whether the upstream uses the same TLS credential as
+// the server. Creating the kv storage of an upstream overwrites the global TLS
+// settings of the TiKV client, which are used by all the kv storages in the
+// process, so an upstream with a different credential is rejected, as well as
+// a plaintext upstream of a TLS server and vice versa.
+func (u *UpstreamInfo) CheckCredential(server *security.Credential) error {
+	if u == nil {
+		// the default upstream uses the credential of the server
+		return nil
+	}
+	if u.IsTLSEnabled() != server.IsTLSEnabled() {
+		return cerror.ErrInvalidUpstream.GenWithStackByArgs(
+			"TLS should be enabled for the upstream if and only if it is enabled for the server")
+	}
+	if !u.IsTLSEnabled() {
+		return nil
+	}
+	if u.CAPath != server.CAPath || u.CertPath != server.CertPath || u.KeyPath != server.KeyPath {
+		return cerror.ErrInvalidUpstream.GenWithStackByArgs(
+			"ca-path, cert-path and key-path should be the same as the security config of the server")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/security"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamInfoID(t *testing.T) {
+	t.Parallel()
+
+	var info *UpstreamInfo
+	require.Equal(t, DefaultUpstreamID, info.ID())
+
+	info1 := &UpstreamInfo{PDAddrs: []string{"http://pd2:2379/", "http://pd1:2379"}}
+	info2 := &UpstreamInfo{PDAddrs: []string{"http://pd1:2379", "http://pd2:2379"}}
+	require.Equal(t, "http://pd1:2379,http://pd2:2379", info1.ID())
+	require.Equal(t, info1.ID(), info2.ID())
+}
+
+func TestUpstreamInfoValidate(t *testing.T) {
+	t.Parallel()
+
+	var info *UpstreamInfo
+	require.Nil(t, info.Validate())
+	require.False(t, info.IsTLSEnabled())
+
+	testCases := []struct {
+		info   *UpstreamInfo
+		errMsg string
+	}{
+		{
+			info: &UpstreamInfo{PDAddrs: []string{"http://127.0.0.1:2379"}},
+		},
+		{
+			info: &UpstreamInfo{
+				PDAddrs:  []string{"https://127.0.0.1:2379"},
+				CAPath:   "ca.pem",
+				CertPath: "cert.pem",
+				KeyPath:  "key.pem",
+			},
+		},
+		{
+			info:   &UpstreamInfo{},
+			errMsg: "pd-addrs is empty",
+		},
+		{
+			info:   &UpstreamInfo{PDAddrs: []string{"127.0.0.1:2379"}},
+			errMsg: "should be a valid http or https URL",
+		},
+		{
+			info:   &UpstreamInfo{PDAddrs: []string{"https://127.0.0.1:2379"}},
+			errMsg: "mismatches the TLS settings",
+		},
+		{
+			info:   &UpstreamInfo{PDAddrs: []string{"https://127.0.0.1:2379"}, CAPath: "ca.pem"},
+			errMsg: "cert-path and key-path are required",
+		},
+	}
+	for _, tc := range testCases {
+		err := tc.info.Validate()
+		if tc.errMsg == "" {
+			require.Nil(t, err)
+			continue
+		}
+		require.True(t, cerror.ErrInvalidUpstream.Equal(err))
+		require.Regexp(t, tc.errMsg, err)
+	}
+}
+
+func TestUpstreamInfoCheckCredential(t *testing.T) {
+	t.Parallel()
+
+	server := &security.Credential{CAPath: "ca.pem", CertPath: "cert.pem", KeyPath: "key.pem"}
+	var info *UpstreamInfo
+	require.Nil(t, info.CheckCredential(server))
+	info = &UpstreamInfo{PDAddrs: []string{"http://127.0.0.1:2379"}}
+	require.Nil(t, info.CheckCredential(&security.Credential{}))
+	err := info.CheckCredential(server)
+	require.True(t, cerror.ErrInvalidUpstream.Equal(err))
+	require.Regexp(t, "TLS should be enabled for the upstream if and only if", err)
+
+	info = &UpstreamInfo{
+		PDAddrs:  []string{"https://127.0.0.1:2379"},
+		CAPath:   "ca.pem",
+		CertPath: "cert.pem",
+		KeyPath:  "key.pem",
+	}
+	require.Nil(t, info.CheckCredential(server))
+	info.CertPath = "other-cert.pem"
+	err = info.CheckCredential(server)
+	require.True(t, cerror.ErrInvalidUpstream.Equal(err))
+	require.Regexp(t, "should be the same as the security config of the server", err)
+	require.True(t, cerror.ErrInvalidUpstream.Equal(info.CheckCredential(&security.Credential{})))
+}
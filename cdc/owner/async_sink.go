@@ -27,6 +27,7 @@ import (
 	cdcContext "github.com/pingcap/ticdc/pkg/context"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
@@ -58,6 +59,9 @@ type asyncSinkImpl struct {
 	checkpointTs model.Ts
 
 	lastSyncPoint model.Ts
+	// syncPointRetention is how long the syncpoints are kept in the
+	// downstream, 0 means the syncpoints are never cleaned up.
+	syncPointRetention time.Duration
 
 	ddlCh         chan *model.DDLEvent
 	ddlFinishedTs model.Ts
@@ -95,6 +99,7 @@ func newAsyncSink(ctx cdcContext.Context) (AsyncSink, error) {
 		if err := asyncSink.syncpointStore.CreateSynctable(ctx); err != nil {
 			return nil, errors.Trace(err)
 		}
+		asyncSink.syncPointRetention = changefeedInfo.SyncPointRetention
 	}
 	asyncSink.wg.Add(1)
 	go asyncSink.run(ctx)
@@ -178,7 +183,20 @@ func (s *asyncSinkImpl) SinkSyncpoint(ctx cdcContext.Context, checkpointTs uint6
 	}
 	s.lastSyncPoint = checkpointTs
 	// TODO implement async sink syncpoint
-	return s.syncpointStore.SinkSyncpoint(ctx, ctx.ChangefeedVars().ID, checkpointTs)
+	if err := s.syncpointStore.SinkSyncpoint(ctx, ctx.ChangefeedVars().ID, checkpointTs); err != nil {
+		return errors.Trace(err)
+	}
+	if s.syncPointRetention <= 0 {
+		return nil
+	}
+	// the syncpoints out of the retention are cleaned up after each syncpoint
+	// is recorded, a failed cleanup is retried with the next syncpoint
+	beforeTs := oracle.GoTimeToTS(oracle.GetTimeFromTS(checkpointTs).Add(-s.syncPointRetention))
+	if err := s.syncpointStore.CleanupSyncpoints(ctx, ctx.ChangefeedVars().ID, beforeTs); err != nil {
+		log.Warn("failed to clean up the outdated syncpoints",
+			zap.String("changefeed", ctx.ChangefeedVars().ID), zap.Uint64("beforeTs", beforeTs), zap.Error(err))
+	}
+	return nil
 }
 
 func (s *asyncSinkImpl) Close(ctx context.Context) (err error) {
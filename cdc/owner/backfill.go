@@ -0,0 +1,412 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/entry"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sink"
+	"github.com/pingcap/ticdc/pkg/config"
+	cdcContext "github.com/pingcap/ticdc/pkg/context"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/quotes"
+	"github.com/pingcap/ticdc/pkg/util"
+	"go.uber.org/zap"
+)
+
+// backfillFlushInterval is the interval between the checks whether the
+// backfilled rows are flushed by the sink.
+const backfillFlushInterval = 100 * time.Millisecond
+
+// backfillTable is a physical table to be backfilled.
+type backfillTable struct {
+	info       *model.TableInfo
+	physicalID model.TableID
+	// partition is the name of the partition, empty if the table is not
+	// partitioned.
+	partition string
+	// keyColumns are the columns of the primary key or a not null unique key,
+	// which split the rows into batches. The table is read by a single query
+	// if it has no such key.
+	keyColumns []string
+}
+
+// backfiller writes the existing rows of the tables at the snapshot ts to the
+// sink as inserts committed at the snapshot ts in the background. The rows
+// are read by a TiDB server of the upstream, and the snapshot ts is the
+// checkpoint of the new changefeed or the start ts of the tables added to the
+// changefeed, so the incremental changes replicated after the backfill start
+// right after the rows. The rows are written again
+// if the backfill is interrupted, which is idempotent for the MySQL sink in
+// the safe mode.
+type backfiller struct {
+	id         model.ChangeFeedID
+	config     *config.BackfillConfig
+	snapshotTs model.Ts
+	tables     []*backfillTable
+	tz         *time.Location
+	// flushPerBatch is true if each batch is flushed by a distinct resolved ts
+	// after the snapshot ts, it only suits the MySQL sink, which buffers the
+	// rows until they are flushed and never sends the resolved ts downstream.
+	// The other sinks send the rows once they are emitted and are flushed at
+	// the snapshot ts once all the rows are emitted.
+	flushPerBatch bool
+	flushTs       model.Ts
+
+	rowFilter        *filter.RowFilter
+	charsetConverter *entry.CharsetConverter
+	offloader        *entry.Offloader
+	rowSizeGuard     *entry.RowSizeGuard
+
+	openDB  func(ctx context.Context, uri string) (*sql.DB, error)
+	newSink func(ctx context.Context, errCh chan error) (sink.Sink, error)
+	errCh   chan error
+
+	finished int32
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// newBackfiller creates a backfiller of the tables at the snapshot ts, the
+// rows are written to the sink of the changefeed.
+func newBackfiller(
+	ctx cdcContext.Context, info *model.ChangeFeedInfo, snapshotTs model.Ts, tables []*backfillTable,
+) (*backfiller, error) {
+	id := ctx.ChangefeedVars().ID
+	tz := util.TimezoneFromCtx(ctx)
+	b := &backfiller{
+		id:               id,
+		config:           info.Config.Backfill,
+		snapshotTs:       snapshotTs,
+		tables:           tables,
+		tz:               tz,
+		flushPerBatch:    isMySQLSinkURI(info.SinkURI),
+		flushTs:          snapshotTs,
+		charsetConverter: entry.NewCharsetConverter(info.Config),
+		errCh:            make(chan error, defaultErrChSize),
+		cancel:           func() {},
+	}
+	var err error
+	if b.rowFilter, err = filter.NewRowFilter(info.Config, tz); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if b.offloader, err = entry.NewOffloader(ctx, id, info.Config); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if b.rowSizeGuard, err = entry.NewRowSizeGuard(ctx, info.Config); err != nil {
+		return nil, errors.Trace(err)
+	}
+	b.openDB = func(ctx context.Context, uri string) (*sql.DB, error) {
+		return openMySQLDB(ctx, "backfill"+id, uri, fmt.Sprintf(`"%s"`, tz.String()))
+	}
+	sinkFilter, err := filter.NewFilter(info.Config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	opts := make(map[string]string, len(info.Opts)+2)
+	for k, v := range info.Opts {
+		opts[k] = v
+	}
+	opts[sink.OptChangefeedID] = id
+	opts[sink.OptCaptureAddr] = ctx.GlobalVars().CaptureInfo.AdvertiseAddr
+	b.newSink = func(ctx context.Context, errCh chan error) (sink.Sink, error) {
+		return sink.New(ctx, id, info.SinkURI, sinkFilter, info.Config, opts, errCh)
+	}
+	return b, nil
+}
+
+func isMySQLSinkURI(sinkURI string) bool {
+	u, err := url.Parse(sinkURI)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "mysql", "tidb", "mysql+ssl", "tidb+ssl":
+		return true
+	default:
+		return false
+	}
+}
+
+// run starts the background goroutine backfilling the tables, the error is
+// thrown to the context.
+func (b *backfiller) run(ctx cdcContext.Context) {
+	stdCtx, cancel := context.WithCancel(ctx)
+	b.cancel = cancel
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		log.Info("start to backfill the tables", zap.String("changefeed", b.id),
+			zap.Uint64("snapshotTs", b.snapshotTs), zap.Int("tables", len(b.tables)))
+		if err := b.backfill(stdCtx); err != nil {
+			if errors.Cause(err) != context.Canceled {
+				ctx.Throw(err)
+			}
+			return
+		}
+		log.Info("backfill the tables finished", zap.String("changefeed", b.id),
+			zap.Uint64("snapshotTs", b.snapshotTs))
+		atomic.StoreInt32(&b.finished, 1)
+	}()
+}
+
+// isFinished returns true if all the rows have been written to the sink.
+func (b *backfiller) isFinished() bool {
+	return atomic.LoadInt32(&b.finished) == 1
+}
+
+// close stops backfilling.
+func (b *backfiller) close() {
+	if b == nil {
+		return
+	}
+	b.cancel()
+	b.wg.Wait()
+}
+
+func (b *backfiller) backfill(ctx context.Context) error {
+	db, err := b.openDB(ctx, b.config.UpstreamURI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer db.Close() //nolint:errcheck
+	conn, err := snapshotConn(ctx, db, b.snapshotTs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closeSnapshotConn(conn)
+	s, err := b.newSink(ctx, b.errCh)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err := s.Close(context.Background()); err != nil {
+			log.Warn("close the backfill sink failed", zap.String("changefeed", b.id), zap.Error(err))
+		}
+	}()
+	for _, table := range b.tables {
+		count, err := b.backfillTable(ctx, conn, s, table)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		log.Info("backfill the table finished", zap.String("changefeed", b.id),
+			zap.Stringer("table", table.info.TableName), zap.String("partition", table.partition),
+			zap.Int("rows", count))
+	}
+	if b.flushPerBatch {
+		return nil
+	}
+	return b.flush(ctx, s, b.snapshotTs)
+}
+
+// backfillTable writes the rows of the table by batches, it returns the
+// number of the rows read.
+func (b *backfiller) backfillTable(
+	ctx context.Context, conn *sql.Conn, s sink.Sink, table *backfillTable,
+) (int, error) {
+	cols := entry.SnapshotColumns(table.info)
+	names := make([]string, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, col.Name.O)
+	}
+	keyOffsets := columnOffsets(names, table.keyColumns)
+	from := quotes.QuoteSchema(table.info.TableName.Schema, table.info.TableName.Table)
+	if table.partition != "" {
+		from += fmt.Sprintf(" PARTITION (%s)", quotes.QuoteName(table.partition))
+	}
+	batchSize := b.config.GetBatchSize()
+	total := 0
+	var lower []interface{}
+	for {
+		query := fmt.Sprintf("SELECT %s FROM %s", buildColumnList(names), from)
+		var args []interface{}
+		if keyOffsets != nil {
+			var where string
+			where, args = chunkRange(table.keyColumns, lower, nil)
+			query += fmt.Sprintf(" WHERE %s ORDER BY %s LIMIT %d", where, buildColumnList(table.keyColumns), batchSize)
+		}
+		count, last, err := b.backfillRows(ctx, conn, s, table, keyOffsets, query, args, len(names))
+		if err != nil {
+			return total, errors.Trace(err)
+		}
+		total += count
+		if keyOffsets == nil || count < batchSize {
+			return total, nil
+		}
+		lower = make([]interface{}, 0, len(keyOffsets))
+		for _, offset := range keyOffsets {
+			lower = append(lower, string(last[offset]))
+		}
+	}
+}
+
+// backfillRows writes the rows of the query by batches, it returns the number
+// of the rows read and the values of the last row.
+func (b *backfiller) backfillRows(
+	ctx context.Context, conn *sql.Conn, s sink.Sink, table *backfillTable,
+	keyOffsets []int, query string, args []interface{}, columnNum int,
+) (int, [][]byte, error) {
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close() //nolint:errcheck
+	batchSize := b.config.GetBatchSize()
+	batch := make([]*model.RowChangedEvent, 0, batchSize)
+	count := 0
+	var values [][]byte
+	for rows.Next() {
+		values = make([][]byte, columnNum)
+		dest := make([]interface{}, columnNum)
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return count, nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		key := backfillRowKey(table.physicalID, values, keyOffsets, count)
+		count++
+		row, err := b.mountRow(ctx, table, values, key)
+		if err != nil {
+			return count, nil, errors.Trace(err)
+		}
+		if row == nil {
+			continue
+		}
+		batch = append(batch, row)
+		if len(batch) >= batchSize {
+			if err := b.emit(ctx, s, batch); err != nil {
+				return count, nil, errors.Trace(err)
+			}
+			batch = make([]*model.RowChangedEvent, 0, batchSize)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return count, nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return count, values, b.emit(ctx, s, batch)
+}
+
+// mountRow mounts the row in the same way as the mounter of the processors,
+// it returns nil if the row is ignored.
+func (b *backfiller) mountRow(
+	ctx context.Context, table *backfillTable, values [][]byte, key []byte,
+) (*model.RowChangedEvent, error) {
+	row, err := entry.MountSnapshotRow(
+		table.info, table.physicalID, table.partition, values, b.snapshotTs, b.tz, b.rowFilter)
+	if err != nil || row == nil {
+		return nil, errors.Trace(err)
+	}
+	if b.charsetConverter != nil {
+		if _, _, err := b.charsetConverter.ConvertRow(row); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if b.offloader != nil {
+		if _, err := b.offloader.OffloadRow(ctx, row, key); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if b.rowSizeGuard != nil && b.rowSizeGuard.IsOversized(row) {
+		return b.rowSizeGuard.Handle(ctx, row, key)
+	}
+	return row, nil
+}
+
+// emit writes the rows to the sink, they are flushed if each batch is flushed.
+func (b *backfiller) emit(ctx context.Context, s sink.Sink, rows []*model.RowChangedEvent) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if err := s.EmitRowChangedEvents(ctx, rows...); err != nil {
+		return errors.Trace(err)
+	}
+	if !b.flushPerBatch {
+		return nil
+	}
+	b.flushTs++
+	return b.flush(ctx, s, b.flushTs)
+}
+
+// flush flushes the rows emitted to the sink and waits for them to be written.
+func (b *backfiller) flush(ctx context.Context, s sink.Sink, resolvedTs model.Ts) error {
+	ticker := time.NewTicker(backfillFlushInterval)
+	defer ticker.Stop()
+	for {
+		checkpointTs, err := s.FlushRowChangedEvents(ctx, resolvedTs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if checkpointTs >= resolvedTs {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case err := <-b.errCh:
+			return errors.Trace(err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// backfillRowKey returns the key identifying the row, which names the objects
+// written by the offloader and the row size guard. It consists of the values
+// of the key columns, or the ordinal of the row if the table has no key.
+func backfillRowKey(physicalID model.TableID, values [][]byte, keyOffsets []int, ordinal int) []byte {
+	key := []byte(fmt.Sprintf("%d", physicalID))
+	if keyOffsets == nil {
+		return append(key, fmt.Sprintf("_%d", ordinal)...)
+	}
+	for _, offset := range keyOffsets {
+		key = append(key, '_')
+		key = append(key, values[offset]...)
+	}
+	return key
+}
+
+// columnOffsets returns the offsets of the columns in the names, it returns
+// nil if any column is absent.
+func columnOffsets(names []string, columns []string) []int {
+	if len(columns) == 0 {
+		return nil
+	}
+	offsets := make([]int, 0, len(columns))
+	for _, col := range columns {
+		offset := -1
+		for i, name := range names {
+			if name == col {
+				offset = i
+				break
+			}
+		}
+		if offset < 0 {
+			return nil
+		}
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
@@ -0,0 +1,170 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"sync"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sink"
+	"github.com/pingcap/ticdc/pkg/config"
+	cdcContext "github.com/pingcap/ticdc/pkg/context"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+)
+
+var _ = check.Suite(&backfillSuite{})
+
+type backfillSuite struct{}
+
+// mockBackfillSink records the emitted rows and the flushed resolved ts.
+type mockBackfillSink struct {
+	sink.Sink
+	mu        sync.Mutex
+	rows      []*model.RowChangedEvent
+	flushedTs []model.Ts
+}
+
+func (m *mockBackfillSink) EmitRowChangedEvents(ctx context.Context, rows ...*model.RowChangedEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rows = append(m.rows, rows...)
+	return nil
+}
+
+func (m *mockBackfillSink) FlushRowChangedEvents(ctx context.Context, resolvedTs uint64) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.flushedTs = append(m.flushedTs, resolvedTs)
+	return resolvedTs, nil
+}
+
+func (m *mockBackfillSink) Close(ctx context.Context) error {
+	return nil
+}
+
+func newBackfillTestTable(withKey bool) *backfillTable {
+	id := &timodel.ColumnInfo{
+		ID: 1, Name: timodel.NewCIStr("id"), Offset: 0, State: timodel.StatePublic,
+		FieldType: *types.NewFieldType(mysql.TypeLonglong),
+	}
+	name := &timodel.ColumnInfo{
+		ID: 2, Name: timodel.NewCIStr("name"), Offset: 1, State: timodel.StatePublic,
+		FieldType: *types.NewFieldType(mysql.TypeVarchar),
+	}
+	table := &backfillTable{physicalID: 100}
+	if withKey {
+		id.Flag = mysql.PriKeyFlag | mysql.NotNullFlag
+		table.keyColumns = []string{"id"}
+	}
+	table.info = model.WrapTableInfo(1, "test", 10, &timodel.TableInfo{
+		ID:         100,
+		Name:       timodel.NewCIStr("t"),
+		PKIsHandle: withKey,
+		Columns:    []*timodel.ColumnInfo{id, name},
+	})
+	return table
+}
+
+func (s *backfillSuite) newBackfiller(c *check.C, sinkURI string, table *backfillTable) (*backfiller, *mockBackfillSink, sqlmock.Sqlmock) {
+	info := &model.ChangeFeedInfo{SinkURI: sinkURI, StartTs: 100, Config: config.GetDefaultReplicaConfig()}
+	info.Config.Backfill = &config.BackfillConfig{UpstreamURI: "mysql://127.0.0.1:4000/", BatchSize: 2}
+	ctx := cdcContext.NewBackendContext4Test(true)
+	b, err := newBackfiller(ctx, info, 100, []*backfillTable{table})
+	c.Assert(err, check.IsNil)
+	db, mock, err := sqlmock.New()
+	c.Assert(err, check.IsNil)
+	b.openDB = func(ctx context.Context, uri string) (*sql.DB, error) {
+		return db, nil
+	}
+	mockSink := &mockBackfillSink{}
+	b.newSink = func(ctx context.Context, errCh chan error) (sink.Sink, error) {
+		return mockSink, nil
+	}
+	return b, mockSink, mock
+}
+
+func (s *backfillSuite) TestBackfillByKey(c *check.C) {
+	defer testleak.AfterTest(c)()
+	b, mockSink, mock := s.newBackfiller(c, "mysql://127.0.0.1:3306/", newBackfillTestTable(true))
+	c.Assert(b.flushPerBatch, check.IsTrue)
+	mock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = '100'")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name` FROM `test`.`t` WHERE TRUE ORDER BY `id` LIMIT 2")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("1", "a").AddRow("2", nil))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name` FROM `test`.`t` WHERE (`id`) > (?) ORDER BY `id` LIMIT 2")).
+		WithArgs("2").WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("3", "c"))
+	mock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = ''")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	c.Assert(b.backfill(context.Background()), check.IsNil)
+	c.Assert(mock.ExpectationsWereMet(), check.IsNil)
+	// each batch is flushed by a distinct resolved ts for the MySQL sink
+	c.Assert(mockSink.flushedTs, check.DeepEquals, []model.Ts{101, 102})
+	c.Assert(mockSink.rows, check.HasLen, 3)
+	for i, row := range mockSink.rows {
+		c.Assert(row.CommitTs, check.Equals, model.Ts(100))
+		c.Assert(row.Table.TableID, check.Equals, model.TableID(100))
+		c.Assert(row.PreColumns, check.IsNil)
+		c.Assert(row.Columns[0].Value, check.Equals, int64(i+1))
+	}
+	c.Assert(mockSink.rows[1].Columns[1].Value, check.IsNil)
+	c.Assert(mockSink.rows[2].Columns[1].Value, check.DeepEquals, []byte("c"))
+}
+
+func (s *backfillSuite) TestBackfillWithoutKey(c *check.C) {
+	defer testleak.AfterTest(c)()
+	table := newBackfillTestTable(false)
+	table.partition = "p0"
+	b, mockSink, mock := s.newBackfiller(c, "kafka://127.0.0.1:9092/test", table)
+	c.Assert(b.flushPerBatch, check.IsFalse)
+	mock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = '100'")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`, `name` FROM `test`.`t` PARTITION (`p0`)")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("1", "a").AddRow("2", "b").AddRow("3", "c"))
+	mock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = ''")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	c.Assert(b.backfill(context.Background()), check.IsNil)
+	c.Assert(mock.ExpectationsWereMet(), check.IsNil)
+	// the rows are flushed at the snapshot ts once all of them are emitted
+	c.Assert(mockSink.flushedTs, check.DeepEquals, []model.Ts{100})
+	c.Assert(mockSink.rows, check.HasLen, 3)
+	c.Assert(mockSink.rows[0].Table.Partition, check.Equals, "p0")
+}
+
+func (s *backfillSuite) TestRunBackfill(c *check.C) {
+	defer testleak.AfterTest(c)()
+	b, _, _ := s.newBackfiller(c, "mysql://127.0.0.1:3306/", newBackfillTestTable(true))
+	b.openDB = func(ctx context.Context, uri string) (*sql.DB, error) {
+		return nil, errors.New("connection refused")
+	}
+	errCh := make(chan error, 1)
+	ctx := cdcContext.WithErrorHandler(cdcContext.NewBackendContext4Test(true), func(err error) error {
+		errCh <- err
+		return nil
+	})
+	b.run(ctx)
+	c.Assert(<-errCh, check.ErrorMatches, ".*connection refused.*")
+	b.close()
+	c.Assert(b.isFinished(), check.IsFalse)
+
+	// a nil backfiller is closed
+	var disabled *backfiller
+	disabled.close()
+}
@@ -23,8 +23,10 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/redo"
+	"github.com/pingcap/ticdc/pkg/config"
 	cdcContext "github.com/pingcap/ticdc/pkg/context"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/orchestrator"
 	"github.com/pingcap/ticdc/pkg/txnutil/gc"
 	"github.com/pingcap/ticdc/pkg/util"
@@ -44,6 +46,19 @@ type changefeed struct {
 	feedStateManager *feedStateManager
 	gcManager        gc.Manager
 	redoManager      redo.LogManager
+	// slo is nil if the lag SLO of the changefeed is not enabled
+	slo *sloChecker
+	// checksum is nil if the consistency check of the changefeed is not enabled
+	checksum *checksumChecker
+	// offloadGC is nil if the large values are not offloaded
+	offloadGC *offloadGC
+	// backfill is not nil if the existing rows are being backfilled, the
+	// tables are not scheduled until it's finished.
+	backfill *backfiller
+	// sinkFailover probes the sink if the changefeed has a standby sink
+	sinkFailover *sinkFailover
+	// notifier sends the notifications about the state transitions
+	notifier *notifier
 
 	schema      *schemaWrap4Owner
 	sink        AsyncSink
@@ -56,6 +71,19 @@ type changefeed struct {
 	// ddlEventCache is not nil when the changefeed is executing a DDL event asynchronously
 	// After the DDL event has been executed, ddlEventCache will be set to nil.
 	ddlEventCache *model.DDLEvent
+	// ddlExecStartTime is when the DDL event in ddlEventCache is started to be executed
+	ddlExecStartTime time.Time
+	// asyncDDLEvent is the DDL event being executed asynchronously according to the async-ddl
+	// policy, the rows after it are replicated while it's executed, but the checkpoint ts is
+	// held at its commit ts until it's finished.
+	asyncDDLEvent *model.DDLEvent
+	// barrierDDL is the DDL job which is the barrier of the changefeed, it's kept when
+	// the changefeed is stopped to show the DDL which may cause the error.
+	barrierDDL        *timodel.Job
+	barrierDDLBlocked bool
+	// skipDDLTs is the commit ts of the DDL which is skipped by the request of the user,
+	// the DDL is handled in the schema but isn't executed downstream. 0 means none.
+	skipDDLTs model.Ts
 
 	errCh  chan error
 	cancel context.CancelFunc
@@ -81,6 +109,8 @@ func newChangefeed(id model.ChangeFeedID, gcManager gc.Manager) *changefeed {
 		barriers:         newBarriers(),
 		feedStateManager: new(feedStateManager),
 		gcManager:        gcManager,
+		sinkFailover:     newSinkFailover(id),
+		notifier:         newNotifier(id),
 
 		errCh:  make(chan error, defaultErrChSize),
 		cancel: func() {},
@@ -110,17 +140,7 @@ func (c *changefeed) Tick(ctx cdcContext.Context, state *orchestrator.Changefeed
 	state.CheckCaptureAlive(ctx.GlobalVars().CaptureInfo.ID)
 	if err := c.tick(ctx, state, captures); err != nil {
 		log.Error("an error occurred in Owner", zap.String("changefeedID", c.state.ID), zap.Error(err))
-		var code string
-		if rfcCode, ok := cerror.RFCCode(err); ok {
-			code = string(rfcCode)
-		} else {
-			code = string(cerror.ErrOwnerUnknown.RFCCode())
-		}
-		c.feedStateManager.handleError(&model.RunningError{
-			Addr:    util.CaptureAddrFromCtx(ctx),
-			Code:    code,
-			Message: err.Error(),
-		})
+		c.feedStateManager.handleError(model.NewRunningError(util.CaptureAddrFromCtx(ctx), err, cerror.ErrOwnerUnknown))
 		c.releaseResources(ctx)
 	}
 }
@@ -131,9 +151,20 @@ func (c *changefeed) checkStaleCheckpointTs(ctx cdcContext.Context, checkpointTs
 		failpoint.Inject("InjectChangefeedFastFailError", func() error {
 			return cerror.ErrGCTTLExceeded.FastGen("InjectChangefeedFastFailError")
 		})
-		if err := c.gcManager.CheckStaleCheckpointTs(ctx, c.id, checkpointTs); err != nil {
-			return errors.Trace(err)
+		// the quarantined changefeed has released its GC safepoint
+		if c.state.Info.GCQuarantine != nil {
+			return nil
+		}
+		gcConfig := c.state.Info.Config.GC
+		err := c.gcManager.CheckStaleCheckpointTs(ctx, c.id, checkpointTs, gcConfig.GetTTL())
+		if err == nil {
+			return nil
 		}
+		if cerror.ErrGCTTLExceeded.Equal(err) && gcConfig.GetPolicy() == config.GCPolicyQuarantine {
+			c.feedStateManager.QuarantineGC(model.NewRunningError(ctx.GlobalVars().CaptureInfo.AdvertiseAddr, err, cerror.ErrGCTTLExceeded), checkpointTs, gcConfig.GetTTL())
+			return nil
+		}
+		return errors.Trace(err)
 	}
 	return nil
 }
@@ -141,6 +172,7 @@ func (c *changefeed) checkStaleCheckpointTs(ctx cdcContext.Context, checkpointTs
 func (c *changefeed) tick(ctx cdcContext.Context, state *orchestrator.ChangefeedReactorState, captures map[model.CaptureID]*model.CaptureInfo) error {
 	c.state = state
 	c.feedStateManager.Tick(state)
+	c.notifier.observeState(c.state.Info, c.state.Status, time.Now())
 
 	checkpointTs := c.state.Info.GetCheckpointTs(c.state.Status)
 	// check stale checkPointTs must be called before `feedStateManager.ShouldRunning()`
@@ -148,6 +180,7 @@ func (c *changefeed) tick(ctx cdcContext.Context, state *orchestrator.Changefeed
 	if err := c.checkStaleCheckpointTs(ctx, checkpointTs); err != nil {
 		return errors.Trace(err)
 	}
+	c.checkSinkFailover(checkpointTs)
 
 	if !c.feedStateManager.ShouldRunning() {
 		c.isRemoved = c.feedStateManager.ShouldRemoved()
@@ -158,6 +191,14 @@ func (c *changefeed) tick(ctx cdcContext.Context, state *orchestrator.Changefeed
 	if !c.preflightCheck(captures) {
 		return nil
 	}
+	// The filter rules are updated by adding or removing tables, reinitialize
+	// the changefeed to apply the new rules if no DDL is being executed.
+	if c.initialized && c.ddlEventCache == nil && c.asyncDDLEvent == nil &&
+		c.schema.filter.IsRulesChanged(c.state.Info.Config) {
+		log.Info("filter rules of changefeed changed, reinitialize the changefeed",
+			zap.String("changefeed", c.state.ID), zap.Strings("rules", c.state.Info.Config.Filter.Rules))
+		c.releaseResources(ctx)
+	}
 	if err := c.initialize(ctx); err != nil {
 		return errors.Trace(err)
 	}
@@ -168,7 +209,16 @@ func (c *changefeed) tick(ctx cdcContext.Context, state *orchestrator.Changefeed
 	default:
 	}
 
+	if c.backfill != nil {
+		if !c.backfill.isFinished() {
+			// the checkpoint is not sent downstream before the rows committed
+			// at it are backfilled.
+			return nil
+		}
+		c.finishBackfill()
+	}
 	c.sink.EmitCheckpointTs(ctx, checkpointTs)
+	c.offloadGC.trigger(checkpointTs)
 	barrierTs, err := c.handleBarrier(ctx)
 	if err != nil {
 		return errors.Trace(err)
@@ -179,16 +229,31 @@ func (c *changefeed) tick(ctx cdcContext.Context, state *orchestrator.Changefeed
 		// So we return here.
 		return nil
 	}
-	shouldUpdateState, err := c.scheduler.Tick(c.state, c.schema.AllPhysicalTables(), captures)
+	shouldUpdateState, err := c.scheduler.Tick(c.state, c.replicatingTables(), captures)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	if shouldUpdateState {
 		c.updateStatus(barrierTs)
 	}
+	c.updatePausedTables()
 	return nil
 }
 
+// checkSinkFailover fails over the changefeed to the standby sink if the sink
+// has been unhealthy beyond the threshold and the standby sink is healthy.
+func (c *changefeed) checkSinkFailover(checkpointTs model.Ts) {
+	if c.state.Info == nil {
+		return
+	}
+	if state := c.state.Info.State; state != model.StateNormal && state != model.StateError {
+		return
+	}
+	if failover, reason := c.sinkFailover.tick(c.state.Info); failover {
+		c.feedStateManager.FailoverSink(checkpointTs, reason)
+	}
+}
+
 func (c *changefeed) initialize(ctx cdcContext.Context) error {
 	if c.initialized {
 		return nil
@@ -209,7 +274,8 @@ LOOP:
 		zap.Stringer("info", c.state.Info),
 		zap.Uint64("checkpoint ts", checkpointTs))
 	failpoint.Inject("NewChangefeedNoRetryError", func() {
-		failpoint.Return(cerror.ErrStartTsBeforeGC.GenWithStackByArgs(checkpointTs-300, checkpointTs))
+		failpoint.Return(cerror.ErrStartTsBeforeGC.GenWithStackByArgs(
+			checkpointTs-300, util.FormatTs(checkpointTs-300), checkpointTs, util.FormatTs(checkpointTs)))
 	})
 	failpoint.Inject("NewChangefeedRetryError", func() {
 		failpoint.Return(errors.New("failpoint injected retriable error"))
@@ -227,7 +293,7 @@ LOOP:
 		// See more gc doc.
 		ensureTTL := int64(10 * 60)
 		err := gc.EnsureChangefeedStartTsSafety(
-			ctx, ctx.GlobalVars().PDClient, c.state.ID, ensureTTL, checkpointTs)
+			ctx, cdcContext.GetUpstream(ctx).PDClient, c.state.ID, ensureTTL, checkpointTs)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -240,17 +306,25 @@ LOOP:
 	// the DDL barrier to the correct start point.
 	c.barriers.Update(ddlJobBarrier, checkpointTs-1)
 	c.barriers.Update(finishBarrier, c.state.Info.GetTargetTs())
+	c.restoreResumingTables()
 	var err error
 	// Note that (checkpointTs == ddl.FinishedTs) DOES NOT imply that the DDL has been completed executed.
 	// So we need to process all DDLs from the range [checkpointTs, ...), but since the semantics of start-ts requires
 	// the lower bound of an open interval, i.e. (startTs, ...), we pass checkpointTs-1 as the start-ts to initialize
 	// the schema cache.
-	c.schema, err = newSchemaWrap4Owner(ctx.GlobalVars().KVStorage, checkpointTs-1, c.state.Info.Config)
+	c.schema, err = newSchemaWrap4Owner(cdcContext.GetUpstream(ctx).KVStorage, checkpointTs-1, c.state.Info.Config)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	cancelCtx, cancel := cdcContext.WithCancel(ctx)
 	c.cancel = cancel
+	// the sinks and the backfill render the TIMESTAMP values in the time zone
+	// of the changefeed.
+	tzCtx, err := util.PutChangefeedTimezoneInCtx(cancelCtx, c.state.Info.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cancelCtx = cdcContext.WithStd(cancelCtx, tzCtx)
 	c.sink, err = c.newSink(cancelCtx)
 	if err != nil {
 		return errors.Trace(err)
@@ -281,10 +355,104 @@ LOOP:
 	// init metrics
 	c.metricsChangefeedCheckpointTsGauge = changefeedCheckpointTsGauge.WithLabelValues(c.id)
 	c.metricsChangefeedCheckpointTsLagGauge = changefeedCheckpointTsLagGauge.WithLabelValues(c.id)
+	if c.state.Info.Config.SLO.IsEnabled() {
+		c.slo = newSLOChecker(c.id, c.state.Info.Config.SLO, c.slo)
+	} else {
+		c.slo = nil
+	}
+	switch {
+	case !c.state.Info.Config.Checksum.IsEnabled():
+		c.checksum = nil
+	case !c.state.Info.SyncPointEnabled:
+		log.Warn("the consistency check is skipped since the syncpoint is not enabled",
+			zap.String("changefeed", c.id))
+		c.checksum = nil
+	default:
+		c.checksum = newChecksumChecker(c.id, c.state.Info.Config.Checksum, c.state.Info.SinkURI, c.checksum)
+		c.checksum.run(cancelCtx)
+	}
+	if c.state.Info.Config.Offload.IsEnabled() {
+		c.offloadGC = newOffloadGC(c.id, c.state.Info.Config.Offload)
+		c.offloadGC.run(cancelCtx)
+	} else {
+		c.offloadGC = nil
+	}
+	if c.state.Info.Config.Backfill.IsEnabled() {
+		if !c.state.Info.BackfillFinished {
+			err = c.startBackfill(cancelCtx, checkpointTs)
+		} else if c.state.Info.TableBootstrap != nil {
+			err = c.startTableBootstrap(cancelCtx, c.state.Info.TableBootstrap)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	c.initialized = true
 	return nil
 }
 
+// startBackfill starts to backfill the existing rows of the tables at the
+// checkpoint, which must be the start ts of the changefeed.
+func (c *changefeed) startBackfill(ctx cdcContext.Context, checkpointTs model.Ts) error {
+	if checkpointTs != c.state.Info.StartTs {
+		// the tables have been scheduled, and the incremental changes have
+		// been replicated.
+		log.Warn("skip the backfill since the changefeed has been replicated from the start ts",
+			zap.String("changefeed", c.id), zap.Uint64("startTs", c.state.Info.StartTs),
+			zap.Uint64("checkpointTs", checkpointTs))
+		c.finishBackfill()
+		return nil
+	}
+	var err error
+	c.backfill, err = newBackfiller(ctx, c.state.Info, checkpointTs, c.schema.backfillTables())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.backfill.run(ctx)
+	return nil
+}
+
+// startTableBootstrap starts to backfill the existing rows of the tables added
+// to the changefeed at the ts they are replicated from.
+func (c *changefeed) startTableBootstrap(ctx cdcContext.Context, bootstrap *model.TableBootstrap) error {
+	tableIDs := make(map[model.TableID]struct{}, len(bootstrap.TableIDs))
+	for _, tableID := range bootstrap.TableIDs {
+		tableIDs[tableID] = struct{}{}
+	}
+	var tables []*backfillTable
+	for _, table := range c.schema.backfillTables() {
+		if _, ok := tableIDs[table.physicalID]; ok {
+			tables = append(tables, table)
+		}
+	}
+	// the start ts of the added tables is lost if the owner is changed
+	c.scheduler.AddTables(bootstrap.TableIDs, bootstrap.Ts)
+	var err error
+	c.backfill, err = newBackfiller(ctx, c.state.Info, bootstrap.Ts, tables)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.backfill.run(ctx)
+	return nil
+}
+
+// finishBackfill records that the existing rows are backfilled, so that they
+// are not backfilled again after the changefeed is restarted.
+func (c *changefeed) finishBackfill() {
+	c.backfill.close()
+	c.backfill = nil
+	c.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil || (info.BackfillFinished && info.TableBootstrap == nil) {
+			return info, false, nil
+		}
+		// the added tables are bootstrapped only after the existing rows of
+		// the changefeed have been backfilled.
+		info.BackfillFinished = true
+		info.TableBootstrap = nil
+		return info, true, nil
+	})
+}
+
 func (c *changefeed) releaseResources(ctx context.Context) {
 	if !c.initialized {
 		return
@@ -295,12 +463,24 @@ func (c *changefeed) releaseResources(ctx context.Context) {
 	c.cancel = func() {}
 	c.ddlPuller.Close()
 	c.schema = nil
+	// the DDL event is built again with the new schema after the changefeed is restarted,
+	// and the DDL being executed asynchronously is executed again.
+	c.ddlEventCache = nil
+	c.asyncDDLEvent = nil
 	if c.isRemoved && c.redoManager.Enabled() {
 		err := c.redoManager.Cleanup(ctx)
 		if err != nil {
 			log.Error("cleanup redo logs failed", zap.String("changefeed", c.id), zap.Error(err))
 		}
 	}
+	c.backfill.close()
+	c.backfill = nil
+	c.offloadGC.close()
+	if c.isRemoved {
+		if err := c.offloadGC.cleanup(ctx); err != nil {
+			log.Error("cleanup offloaded values failed", zap.String("changefeed", c.id), zap.Error(err))
+		}
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 	// We don't need to wait sink Close, pass a canceled context is ok
@@ -312,9 +492,50 @@ func (c *changefeed) releaseResources(ctx context.Context) {
 	changefeedCheckpointTsLagGauge.DeleteLabelValues(c.id)
 	c.metricsChangefeedCheckpointTsGauge = nil
 	c.metricsChangefeedCheckpointTsLagGauge = nil
+	c.slo.releaseMetrics()
+	c.checksum.close()
 	c.initialized = false
 }
 
+// ddlBarrierStatus returns the DDL which blocks the changefeed as a barrier.
+func (c *changefeed) ddlBarrierStatus() *model.ChangefeedDDLBarrier {
+	status := &model.ChangefeedDDLBarrier{ID: c.id, SkippingTs: c.skipDDLTs}
+	if c.state != nil && c.state.Info != nil && c.state.Info.State != model.StateNormal {
+		status.Error = c.state.Info.Error
+	}
+	job := c.barrierDDL
+	if job == nil || job.BinlogInfo == nil {
+		return status
+	}
+	status.Blocked = c.barrierDDLBlocked
+	status.Query = job.Query
+	status.Type = job.Type.String()
+	status.CommitTs = job.BinlogInfo.FinishedTS
+	if c.ddlEventCache != nil && c.ddlEventCache.CommitTs == status.CommitTs {
+		since := model.JSONTime(c.ddlExecStartTime)
+		status.ExecutingSince = &since
+		status.ExecutingDuration = time.Since(c.ddlExecStartTime).Seconds()
+	}
+	return status
+}
+
+// operateDDLBarrier skips or retries the DDL which blocks the changefeed, the changefeed
+// stopped by the error of executing the DDL is restarted at once.
+func (c *changefeed) operateDDLBarrier(commitTs model.Ts, skip bool) {
+	if skip {
+		c.skipDDLTs = commitTs
+	}
+	log.Warn("operate the DDL barrier of the changefeed", zap.String("changefeed", c.id),
+		zap.Uint64("commitTs", commitTs), zap.Bool("skip", skip))
+	if c.state == nil || c.state.Info == nil || c.state.Info.Error == nil {
+		return
+	}
+	switch c.state.Info.State {
+	case model.StateError, model.StateFailed:
+		c.feedStateManager.PushAdminJob(&model.AdminJob{CfID: c.id, Type: model.AdminResume})
+	}
+}
+
 // preflightCheck makes sure that the metadata in Etcd is complete enough to run the tick.
 // If the metadata is not complete, such as when the ChangeFeedStatus is nil,
 // this function will reconstruct the lost metadata and skip this tick.
@@ -378,6 +599,10 @@ func (c *changefeed) preflightCheck(captures map[model.CaptureID]*model.CaptureI
 func (c *changefeed) handleBarrier(ctx cdcContext.Context) (uint64, error) {
 	barrierTp, barrierTs := c.barriers.Min()
 	blocked := (barrierTs == c.state.Status.CheckpointTs) && (barrierTs == c.state.Status.ResolvedTs)
+	c.barrierDDL, c.barrierDDLBlocked = nil, false
+	if err := c.checkAsyncDDL(ctx); err != nil {
+		return 0, errors.Trace(err)
+	}
 	switch barrierTp {
 	case ddlJobBarrier:
 		ddlResolvedTs, ddlJob := c.ddlPuller.FrontDDL()
@@ -388,6 +613,7 @@ func (c *changefeed) handleBarrier(ctx cdcContext.Context) (uint64, error) {
 			c.barriers.Update(ddlJobBarrier, ddlResolvedTs)
 			return barrierTs, nil
 		}
+		c.barrierDDL, c.barrierDDLBlocked = ddlJob, blocked
 		if !blocked {
 			return barrierTs, nil
 		}
@@ -396,7 +622,18 @@ func (c *changefeed) handleBarrier(ctx cdcContext.Context) (uint64, error) {
 			return 0, errors.Trace(err)
 		}
 		if !done {
-			return barrierTs, nil
+			if c.ddlEventCache == nil || !c.state.Info.Config.DDL.IsAsync(ddlJob.Type) {
+				return barrierTs, nil
+			}
+			// the rows after the DDL are replicated while it's executed downstream, and the DDLs
+			// after it are blocked by the checkpoint ts held by it until it's finished.
+			log.Info("execute the DDL asynchronously", zap.String("changefeed", c.id),
+				zap.Uint64("commitTs", c.ddlEventCache.CommitTs), zap.String("query", c.ddlEventCache.Query))
+			c.asyncDDLEvent, c.ddlEventCache = c.ddlEventCache, nil
+		}
+		c.barrierDDL, c.barrierDDLBlocked = nil, false
+		if c.skipDDLTs != 0 && c.skipDDLTs <= barrierTs {
+			c.skipDDLTs = 0
 		}
 		c.ddlPuller.PopFrontDDL()
 		newDDLResolvedTs, _ := c.ddlPuller.FrontDDL()
@@ -410,6 +647,15 @@ func (c *changefeed) handleBarrier(ctx cdcContext.Context) (uint64, error) {
 		if err := c.sink.SinkSyncpoint(ctx, barrierTs); err != nil {
 			return 0, errors.Trace(err)
 		}
+		if c.checksum != nil {
+			tables, err := c.schema.checksumTables(c.state.Info.Config.Checksum)
+			if err != nil {
+				log.Warn("skip the consistency check since the tables can not be listed",
+					zap.String("changefeed", c.id), zap.Uint64("syncpoint", barrierTs), zap.Error(err))
+			} else {
+				c.checksum.trigger(&checksumTask{primaryTs: barrierTs, tables: tables})
+			}
+		}
 		c.barriers.Update(syncPointBarrier, nextSyncPointTs)
 
 	case finishBarrier:
@@ -441,8 +687,18 @@ func (c *changefeed) asyncExecDDL(ctx cdcContext.Context, job *timodel.Job) (don
 		if err != nil {
 			return false, errors.Trace(err)
 		}
+		if ddlEvent == nil {
+			// the DDL is skipped according to the DDL policies
+			return true, nil
+		}
+		if ddlEvent.CommitTs == c.skipDDLTs {
+			log.Warn("the DDL is skipped by the user", zap.String("changefeed", c.id),
+				zap.Uint64("commitTs", ddlEvent.CommitTs), zap.String("query", ddlEvent.Query))
+			return true, nil
+		}
 		ddlEvent.Query = binloginfo.AddSpecialComment(ddlEvent.Query)
 		c.ddlEventCache = ddlEvent
+		c.ddlExecStartTime = time.Now()
 		if c.redoManager.Enabled() {
 			err = c.redoManager.EmitDDLEvent(ctx, ddlEvent)
 			if err != nil {
@@ -464,6 +720,23 @@ func (c *changefeed) asyncExecDDL(ctx cdcContext.Context, job *timodel.Job) (don
 	return done, nil
 }
 
+// checkAsyncDDL checks whether the DDL being executed asynchronously is finished.
+func (c *changefeed) checkAsyncDDL(ctx cdcContext.Context) error {
+	if c.asyncDDLEvent == nil {
+		return nil
+	}
+	done, err := c.sink.EmitDDLEvent(ctx, c.asyncDDLEvent)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if done {
+		log.Info("the DDL executed asynchronously is finished", zap.String("changefeed", c.id),
+			zap.Uint64("commitTs", c.asyncDDLEvent.CommitTs), zap.String("query", c.asyncDDLEvent.Query))
+		c.asyncDDLEvent = nil
+	}
+	return nil
+}
+
 func (c *changefeed) updateStatus(barrierTs model.Ts) {
 	resolvedTs := barrierTs
 	for _, position := range c.state.TaskPositions {
@@ -484,6 +757,18 @@ func (c *changefeed) updateStatus(barrierTs model.Ts) {
 			checkpointTs = position.CheckPointTs
 		}
 	}
+	// the checkpoint ts is held by the DDL being executed asynchronously, whose commit ts
+	// is reached by the checkpoint ts before it's executed.
+	if c.asyncDDLEvent != nil && checkpointTs > c.asyncDDLEvent.CommitTs {
+		checkpointTs = c.asyncDDLEvent.CommitTs
+	}
+	// the checkpoint ts goes back to the paused ts of the resumed tables, which
+	// are replicated again from it.
+	for _, tableID := range c.state.Status.ResumingTables {
+		if pausedTs, exist := c.state.Info.PausedTables[tableID]; exist && checkpointTs > pausedTs {
+			checkpointTs = pausedTs
+		}
+	}
 	c.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
 		changed := false
 		if status.ResolvedTs != resolvedTs {
@@ -501,7 +786,260 @@ func (c *changefeed) updateStatus(barrierTs model.Ts) {
 	c.metricsChangefeedCheckpointTsGauge.Set(float64(phyTs))
 	// It is more accurate to get tso from PD, but in most cases since we have
 	// deployed NTP service, a little bias is acceptable here.
-	c.metricsChangefeedCheckpointTsLagGauge.Set(float64(oracle.GetPhysical(time.Now())-phyTs) / 1e3)
+	now := time.Now()
+	lag := time.Duration(oracle.GetPhysical(now)-phyTs) * time.Millisecond
+	c.metricsChangefeedCheckpointTsLagGauge.Set(lag.Seconds())
+	c.slo.check(lag, now)
+	c.notifier.observeLag(lag, now)
+}
+
+// updateTables adds the tables to or removes the tables from the changefeed by
+// updating the filter rules, which will be applied in the next ticks. If the
+// added tables are bootstrapped, their existing rows at the start ts are
+// backfilled after the changefeed is reinitialized with the new rules.
+func (c *changefeed) updateTables(cfg *model.UpdateTablesConfig, remove bool) error {
+	if !c.initialized {
+		return cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+			"the changefeed is not initialized, please retry later")
+	}
+	if c.backfill != nil || c.state.Info.TableBootstrap != nil {
+		return cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+			"the existing rows of the tables are being backfilled, please retry later")
+	}
+	var rules []string
+	var err error
+	if remove {
+		rules, err = filter.RemoveTablesFromRules(c.state.Info.Config.Filter, cfg.Matcher)
+	} else {
+		rules, err = filter.AddTablesToRules(c.state.Info.Config.Filter, cfg.Matcher)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var bootstrap *model.TableBootstrap
+	if !remove && (cfg.StartTs != 0 || cfg.Bootstrap) {
+		startTs := cfg.StartTs
+		if startTs == 0 {
+			startTs = c.state.Status.CheckpointTs
+		}
+		replicaConfig := c.state.Info.Config.Clone()
+		replicaConfig.Filter.Rules = rules
+		tables, err := c.schema.AllPhysicalTablesWithConfig(replicaConfig)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if cfg.Bootstrap {
+			// only the tables not replicated yet are bootstrapped
+			if addedTables := diffTables(tables, c.schema.AllPhysicalTables()); len(addedTables) > 0 {
+				bootstrap = &model.TableBootstrap{Ts: startTs, TableIDs: addedTables}
+			}
+		}
+		c.scheduler.AddTables(tables, startTs)
+	}
+	log.Info("update tables of changefeed", zap.String("changefeed", c.id),
+		zap.Strings("matcher", cfg.Matcher), zap.Bool("remove", remove),
+		zap.Uint64("startTs", cfg.StartTs), zap.Bool("bootstrap", bootstrap != nil),
+		zap.Strings("rules", rules))
+	c.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil {
+			return nil, false, nil
+		}
+		info.Config.Filter.Rules = rules
+		info.TableBootstrap = bootstrap
+		return info, true, nil
+	})
+	return nil
+}
+
+// diffTables returns the tables which are not in the excluded tables.
+func diffTables(tables, excluded []model.TableID) []model.TableID {
+	excludedSet := make(map[model.TableID]struct{}, len(excluded))
+	for _, tableID := range excluded {
+		excludedSet[tableID] = struct{}{}
+	}
+	var ret []model.TableID
+	for _, tableID := range tables {
+		if _, ok := excludedSet[tableID]; !ok {
+			ret = append(ret, tableID)
+		}
+	}
+	return ret
+}
+
+// pauseTables pauses or resumes the replication of the tables. The paused tables
+// are removed from the processors in the next ticks, and the ts they are paused
+// at is recorded. The resumed tables are added again from the recorded ts, the
+// checkpoint ts of the changefeed goes back to it until they catch up, so the
+// other tables are replicated again from it too if the changefeed restarts in
+// the meantime.
+func (c *changefeed) pauseTables(tableIDs []model.TableID, resume bool) {
+	if !c.initialized {
+		log.Warn("changefeed is not running, skip pausing or resuming tables",
+			zap.String("changefeed", c.id), zap.Int64s("tables", tableIDs))
+		return
+	}
+	checkpointTs := c.state.Status.CheckpointTs
+	log.Info("pause or resume tables of changefeed", zap.String("changefeed", c.id),
+		zap.Int64s("tables", tableIDs), zap.Bool("resume", resume), zap.Uint64("checkpointTs", checkpointTs))
+	if resume {
+		// the tables are kept paused until they are added again, which holds
+		// the GC safepoint and the schema snapshots at the paused ts. The
+		// resuming tables are recorded in the status, so that they are resumed
+		// by the new owner if the owner changes in the meantime.
+		resumingTables := c.resumingTables()
+		var resumed []model.TableID
+		for _, tableID := range tableIDs {
+			pausedTs, exist := c.state.Info.PausedTables[tableID]
+			if _, resuming := resumingTables[tableID]; !exist || resuming {
+				continue
+			}
+			resumed = append(resumed, tableID)
+			c.scheduler.ResumeTable(tableID, pausedTs)
+		}
+		if len(resumed) == 0 {
+			return
+		}
+		log.Warn("the checkpoint ts of the changefeed goes back to the paused ts of the resumed tables",
+			zap.String("changefeed", c.id), zap.Int64s("tables", resumed),
+			zap.Uint64("minPausedTs", c.state.Info.GetMinPausedTs()))
+		c.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+			if status == nil {
+				return nil, false, nil
+			}
+			status.ResumingTables = append(status.ResumingTables, resumed...)
+			return status, true, nil
+		})
+		return
+	}
+	c.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil {
+			return nil, false, nil
+		}
+		changed := false
+		for _, tableID := range tableIDs {
+			if _, paused := info.PausedTables[tableID]; paused {
+				continue
+			}
+			if info.PausedTables == nil {
+				info.PausedTables = make(map[model.TableID]model.Ts)
+			}
+			info.PausedTables[tableID] = checkpointTs
+			changed = true
+		}
+		return info, changed, nil
+	})
+}
+
+// replicatingTables returns the tables which should be replicated, that is,
+// all the physical tables except the paused ones which are not resumed.
+func (c *changefeed) replicatingTables() []model.TableID {
+	allTables := c.schema.AllPhysicalTables()
+	if len(c.state.Info.PausedTables) == 0 {
+		return allTables
+	}
+	resumingTables := c.resumingTables()
+	tables := make([]model.TableID, 0, len(allTables))
+	for _, tableID := range allTables {
+		_, paused := c.state.Info.PausedTables[tableID]
+		_, resuming := resumingTables[tableID]
+		if !paused || resuming {
+			tables = append(tables, tableID)
+		}
+	}
+	return tables
+}
+
+// updatePausedTables removes the tables from the paused tables once they are
+// resumed and the checkpoint ts of the changefeed goes back to the paused ts,
+// or they are no longer replicated by the changefeed, e.g. dropped.
+func (c *changefeed) updatePausedTables() {
+	if len(c.state.Info.PausedTables) == 0 {
+		if len(c.state.Status.ResumingTables) != 0 {
+			c.removeResumingTables(c.state.Status.ResumingTables)
+		}
+		return
+	}
+	allTables := make(map[model.TableID]struct{})
+	for _, tableID := range c.schema.AllPhysicalTables() {
+		allTables[tableID] = struct{}{}
+	}
+	resumingTables := c.resumingTables()
+	var removed []model.TableID
+	for tableID, pausedTs := range c.state.Info.PausedTables {
+		if _, exist := allTables[tableID]; !exist {
+			removed = append(removed, tableID)
+			continue
+		}
+		if _, resuming := resumingTables[tableID]; resuming &&
+			c.state.Status.CheckpointTs <= pausedTs && c.isTableDispatched(tableID) {
+			removed = append(removed, tableID)
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+	log.Info("tables are no longer paused", zap.String("changefeed", c.id), zap.Int64s("tables", removed))
+	c.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil {
+			return nil, false, nil
+		}
+		for _, tableID := range removed {
+			delete(info.PausedTables, tableID)
+		}
+		if len(info.PausedTables) == 0 {
+			info.PausedTables = nil
+		}
+		return info, true, nil
+	})
+	c.removeResumingTables(removed)
+}
+
+// removeResumingTables removes the tables from the resuming tables recorded in
+// the changefeed status.
+func (c *changefeed) removeResumingTables(tableIDs []model.TableID) {
+	c.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		if status == nil || len(status.ResumingTables) == 0 {
+			return status, false, nil
+		}
+		tables := diffTables(status.ResumingTables, tableIDs)
+		changed := len(tables) != len(status.ResumingTables)
+		status.ResumingTables = tables
+		return status, changed, nil
+	})
+}
+
+// resumingTables returns the resumed tables recorded in the changefeed status.
+func (c *changefeed) resumingTables() map[model.TableID]struct{} {
+	tables := make(map[model.TableID]struct{}, len(c.state.Status.ResumingTables))
+	for _, tableID := range c.state.Status.ResumingTables {
+		tables[tableID] = struct{}{}
+	}
+	return tables
+}
+
+// restoreResumingTables resumes the tables recorded in the changefeed status
+// which are not dispatched yet, e.g. when the owner changed after they are
+// resumed.
+func (c *changefeed) restoreResumingTables() {
+	for _, tableID := range c.state.Status.ResumingTables {
+		pausedTs, exist := c.state.Info.PausedTables[tableID]
+		if !exist || c.isTableDispatched(tableID) {
+			continue
+		}
+		log.Info("restore the resuming table", zap.String("changefeed", c.id),
+			zap.Int64("tableID", tableID), zap.Uint64("pausedTs", pausedTs))
+		c.scheduler.ResumeTable(tableID, pausedTs)
+	}
+}
+
+// isTableDispatched returns whether the table is dispatched to one of the captures
+func (c *changefeed) isTableDispatched(tableID model.TableID) bool {
+	for _, status := range c.state.TaskStatuses {
+		if _, exist := status.Tables[tableID]; exist {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *changefeed) Close(ctx context.Context) {
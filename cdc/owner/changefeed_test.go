@@ -292,6 +292,323 @@ func (s *changefeedSuite) TestExecDDL(c *check.C) {
 	c.Assert(state.TaskStatuses[ctx.GlobalVars().CaptureInfo.ID].Tables, check.HasKey, job.TableID)
 }
 
+func (s *changefeedSuite) TestExecDDLAsync(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	helper := entry.NewSchemaTestHelper(c)
+	defer helper.Close()
+	helper.DDL2Job("create database test0")
+	job := helper.DDL2Job("create table test0.table0(id int primary key, v int)")
+	startTs := job.BinlogInfo.FinishedTS + 1000
+
+	ctx := cdcContext.NewContext(context.Background(), &cdcContext.GlobalVars{
+		KVStorage: helper.Storage(),
+		CaptureInfo: &model.CaptureInfo{
+			ID:            "capture-id-test",
+			AdvertiseAddr: "127.0.0.1:0000",
+			Version:       version.ReleaseVersion,
+		},
+	})
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.DDL = &config.DDLConfig{AsyncDDL: config.AsyncDDLPolicyIndex}
+	ctx = cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
+		ID: "changefeed-id-test",
+		Info: &model.ChangeFeedInfo{
+			StartTs: startTs,
+			Config:  replicaConfig,
+		},
+	})
+
+	cf, state, captures, tester := createChangefeed4Test(ctx, c)
+	defer cf.Close(ctx)
+	tickThreeTime := func() {
+		for i := 0; i < 3; i++ {
+			cf.Tick(ctx, state, captures)
+			tester.MustApplyPatches()
+		}
+	}
+	// finishOperations mocks the processor to finish all the table operations
+	finishOperations := func() {
+		state.PatchTaskStatus(ctx.GlobalVars().CaptureInfo.ID, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
+			for _, operation := range status.Operation {
+				operation.Status = model.OperFinished
+			}
+			return status, true, nil
+		})
+		tester.MustApplyPatches()
+	}
+	tickThreeTime()
+	mockDDLPuller := cf.ddlPuller.(*mockDDLPuller)
+	mockAsyncSink := cf.sink.(*mockAsyncSink)
+	mockDDLPuller.resolvedTs = startTs + 1000
+	tickThreeTime()
+	finishOperations()
+	tickThreeTime()
+	c.Assert(state.Status.CheckpointTs, check.Equals, mockDDLPuller.resolvedTs)
+
+	// the rows after the DDL of index are replicated while it's executed
+	job = helper.DDL2Job("alter table test0.table0 add index idx_v(v)")
+	mockDDLPuller.resolvedTs += 1000
+	job.BinlogInfo.FinishedTS = mockDDLPuller.resolvedTs
+	mockDDLPuller.ddlQueue = append(mockDDLPuller.ddlQueue, job)
+	tickThreeTime()
+	c.Assert(cf.asyncDDLEvent, check.NotNil)
+	c.Assert(mockAsyncSink.ddlExecuting.Query, check.Equals, "alter table test0.table0 add index idx_v(v)")
+	mockDDLPuller.resolvedTs += 1000
+	tickThreeTime()
+	c.Assert(state.Status.ResolvedTs, check.Equals, mockDDLPuller.resolvedTs)
+	// the checkpoint ts is held until the DDL is finished
+	c.Assert(state.Status.CheckpointTs, check.Equals, job.BinlogInfo.FinishedTS)
+
+	mockAsyncSink.ddlDone = true
+	tickThreeTime()
+	c.Assert(cf.asyncDDLEvent, check.IsNil)
+	c.Assert(state.Status.CheckpointTs, check.Equals, mockDDLPuller.resolvedTs)
+
+	// the other DDLs are executed synchronously
+	job = helper.DDL2Job("alter table test0.table0 add column v2 int")
+	mockDDLPuller.resolvedTs += 1000
+	job.BinlogInfo.FinishedTS = mockDDLPuller.resolvedTs
+	mockDDLPuller.ddlQueue = append(mockDDLPuller.ddlQueue, job)
+	tickThreeTime()
+	c.Assert(cf.asyncDDLEvent, check.IsNil)
+	c.Assert(mockAsyncSink.ddlExecuting.Query, check.Equals, "alter table test0.table0 add column v2 int")
+	mockDDLPuller.resolvedTs += 1000
+	tickThreeTime()
+	c.Assert(state.Status.ResolvedTs, check.Equals, job.BinlogInfo.FinishedTS)
+}
+
+func (s *changefeedSuite) TestDDLBarrier(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	helper := entry.NewSchemaTestHelper(c)
+	defer helper.Close()
+	job := helper.DDL2Job("create database test0")
+	startTs := job.BinlogInfo.FinishedTS + 1000
+
+	ctx := cdcContext.NewContext(context.Background(), &cdcContext.GlobalVars{
+		KVStorage: helper.Storage(),
+		CaptureInfo: &model.CaptureInfo{
+			ID:            "capture-id-test",
+			AdvertiseAddr: "127.0.0.1:0000",
+			Version:       version.ReleaseVersion,
+		},
+	})
+	ctx = cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
+		ID: "changefeed-id-test",
+		Info: &model.ChangeFeedInfo{
+			StartTs: startTs,
+			Config:  config.GetDefaultReplicaConfig(),
+		},
+	})
+
+	cf, state, captures, tester := createChangefeed4Test(ctx, c)
+	defer cf.Close(ctx)
+	tickThreeTime := func() {
+		for i := 0; i < 3; i++ {
+			cf.Tick(ctx, state, captures)
+			tester.MustApplyPatches()
+		}
+	}
+	tickThreeTime()
+	status := cf.ddlBarrierStatus()
+	c.Assert(status.Blocked, check.IsFalse)
+	c.Assert(status.CommitTs, check.Equals, uint64(0))
+
+	// the DDL being executed is the barrier
+	mockDDLPuller := cf.ddlPuller.(*mockDDLPuller)
+	mockAsyncSink := cf.sink.(*mockAsyncSink)
+	job = helper.DDL2Job("create database test1")
+	mockDDLPuller.resolvedTs = startTs + 1000
+	job.BinlogInfo.FinishedTS = mockDDLPuller.resolvedTs
+	mockDDLPuller.ddlQueue = append(mockDDLPuller.ddlQueue, job)
+	tickThreeTime()
+	c.Assert(state.Status.CheckpointTs, check.Equals, mockDDLPuller.resolvedTs)
+	status = cf.ddlBarrierStatus()
+	c.Assert(status.ID, check.Equals, "changefeed-id-test")
+	c.Assert(status.Blocked, check.IsTrue)
+	c.Assert(status.Query, check.Equals, "create database test1")
+	c.Assert(status.Type, check.Equals, "create schema")
+	c.Assert(status.CommitTs, check.Equals, mockDDLPuller.resolvedTs)
+	c.Assert(status.ExecutingSince, check.NotNil)
+	c.Assert(status.Error, check.IsNil)
+
+	// the skipped DDL isn't executed downstream
+	mockAsyncSink.ddlDone = true
+	job = helper.DDL2Job("create database test2")
+	mockDDLPuller.resolvedTs += 1000
+	job.BinlogInfo.FinishedTS = mockDDLPuller.resolvedTs
+	mockDDLPuller.ddlQueue = append(mockDDLPuller.ddlQueue, job)
+	cf.operateDDLBarrier(job.BinlogInfo.FinishedTS, true)
+	c.Assert(cf.ddlBarrierStatus().SkippingTs, check.Equals, job.BinlogInfo.FinishedTS)
+	mockDDLPuller.resolvedTs += 1000
+	tickThreeTime()
+	tickThreeTime()
+	c.Assert(state.Status.CheckpointTs, check.Equals, mockDDLPuller.resolvedTs)
+	c.Assert(mockAsyncSink.ddlExecuting.Query, check.Equals, "create database test1")
+	status = cf.ddlBarrierStatus()
+	c.Assert(status.CommitTs, check.Equals, uint64(0))
+	c.Assert(status.SkippingTs, check.Equals, uint64(0))
+}
+
+func (s *changefeedSuite) TestPauseTables(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	helper := entry.NewSchemaTestHelper(c)
+	defer helper.Close()
+	helper.DDL2Job("create database test0")
+	job := helper.DDL2Job("create table test0.table0(id int primary key)")
+	tableID := job.TableID
+	startTs := job.BinlogInfo.FinishedTS + 1000
+
+	ctx := cdcContext.NewContext(context.Background(), &cdcContext.GlobalVars{
+		KVStorage: helper.Storage(),
+		CaptureInfo: &model.CaptureInfo{
+			ID:            "capture-id-test",
+			AdvertiseAddr: "127.0.0.1:0000",
+			Version:       version.ReleaseVersion,
+		},
+	})
+	ctx = cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
+		ID: "changefeed-id-test",
+		Info: &model.ChangeFeedInfo{
+			StartTs: startTs,
+			Config:  config.GetDefaultReplicaConfig(),
+		},
+	})
+	captureID := ctx.GlobalVars().CaptureInfo.ID
+
+	cf, state, captures, tester := createChangefeed4Test(ctx, c)
+	defer func() {
+		cf.Close(ctx)
+	}()
+	tickThreeTime := func() {
+		for i := 0; i < 3; i++ {
+			cf.Tick(ctx, state, captures)
+			tester.MustApplyPatches()
+		}
+	}
+	// finishOperations mocks the processor to finish all the table operations
+	finishOperations := func() {
+		state.PatchTaskStatus(captureID, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
+			for _, operation := range status.Operation {
+				operation.Status = model.OperFinished
+			}
+			return status, true, nil
+		})
+		tester.MustApplyPatches()
+	}
+	// pre check and initialize
+	tickThreeTime()
+	mockDDLPuller := cf.ddlPuller.(*mockDDLPuller)
+	mockDDLPuller.resolvedTs = startTs + 1000
+	tickThreeTime()
+	c.Assert(state.TaskStatuses[captureID].Tables, check.HasKey, tableID)
+	finishOperations()
+	tickThreeTime()
+	c.Assert(state.Status.CheckpointTs, check.Equals, mockDDLPuller.resolvedTs)
+
+	// pause the table, the table is removed and the checkpoint ts is not held
+	pausedTs := state.Status.CheckpointTs
+	cf.pauseTables([]model.TableID{tableID}, false)
+	tester.MustApplyPatches()
+	c.Assert(state.Info.PausedTables, check.DeepEquals, map[model.TableID]model.Ts{tableID: pausedTs})
+	c.Assert(state.Info.GetMinPausedTs(), check.Equals, pausedTs)
+	tickThreeTime()
+	finishOperations()
+	mockDDLPuller.resolvedTs += 1000
+	tickThreeTime()
+	c.Assert(state.TaskStatuses[captureID].Tables, check.HasLen, 0)
+	c.Assert(state.TaskStatuses[captureID].Operation, check.HasLen, 0)
+	c.Assert(state.Status.ResolvedTs, check.Equals, mockDDLPuller.resolvedTs)
+	c.Assert(state.Status.CheckpointTs, check.Equals, mockDDLPuller.resolvedTs)
+
+	// resume the table, the resuming table is recorded in the status, so that
+	// it's resumed by the new owner if the owner changes before it's added
+	cf.pauseTables([]model.TableID{tableID}, true)
+	tester.MustApplyPatches()
+	c.Assert(state.Status.ResumingTables, check.DeepEquals, []model.TableID{tableID})
+	cf.Close(ctx)
+	cf = newChangefeed4Test(ctx.ChangefeedVars().ID, gc.NewManager(ctx.GlobalVars().PDClient),
+		func(ctx cdcContext.Context, startTs uint64) (DDLPuller, error) {
+			return mockDDLPuller, nil
+		}, cf.newSink)
+
+	// the table is added from the paused ts, and the checkpoint ts goes back
+	// to it before the table is no longer paused
+	c.Assert(state.Info.PausedTables, check.HasKey, tableID)
+	tickThreeTime()
+	tickThreeTime()
+	c.Assert(state.TaskStatuses[captureID].Tables[tableID].StartTs, check.Equals, pausedTs)
+	c.Assert(state.Status.CheckpointTs <= pausedTs, check.IsTrue)
+	c.Assert(state.Info.PausedTables, check.IsNil)
+	c.Assert(state.Status.ResumingTables, check.HasLen, 0)
+	finishOperations()
+	tickThreeTime()
+	c.Assert(state.Status.CheckpointTs, check.Equals, mockDDLPuller.resolvedTs)
+}
+
+func (s *changefeedSuite) TestUpdateTables(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	helper := entry.NewSchemaTestHelper(c)
+	defer helper.Close()
+	helper.DDL2Job("create database test0")
+	helper.DDL2Job("create table test0.table0(id int primary key)")
+	job := helper.DDL2Job("create table test0.table1(id int primary key)")
+	tableID := job.TableID
+	startTs := job.BinlogInfo.FinishedTS + 1000
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Filter.Rules = []string{"test0.table0"}
+	replicaConfig.Backfill = &config.BackfillConfig{UpstreamURI: "mysql://127.0.0.1:4000/"}
+	ctx := cdcContext.NewContext(context.Background(), &cdcContext.GlobalVars{
+		KVStorage: helper.Storage(),
+		CaptureInfo: &model.CaptureInfo{
+			ID:            "capture-id-test",
+			AdvertiseAddr: "127.0.0.1:0000",
+			Version:       version.ReleaseVersion,
+		},
+	})
+	ctx = cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
+		ID: "changefeed-id-test",
+		Info: &model.ChangeFeedInfo{
+			StartTs:          startTs,
+			Config:           replicaConfig,
+			BackfillFinished: true,
+		},
+	})
+
+	cf, state, captures, tester := createChangefeed4Test(ctx, c)
+	defer cf.Close(ctx)
+	cfg := &model.UpdateTablesConfig{Matcher: []string{"test0.table1"}, Bootstrap: true}
+	// the tables can't be updated before the changefeed is initialized
+	err := cf.updateTables(cfg, false)
+	c.Assert(err, check.ErrorMatches, ".*the changefeed is not initialized.*")
+
+	// pre check and initialize
+	for i := 0; i < 3; i++ {
+		cf.Tick(ctx, state, captures)
+		tester.MustApplyPatches()
+	}
+	c.Assert(cf.initialized, check.IsTrue)
+	c.Assert(cf.schema.AllPhysicalTables(), check.HasLen, 1)
+
+	// only the added table is bootstrapped from the checkpoint ts
+	err = cf.updateTables(cfg, false)
+	c.Assert(err, check.IsNil)
+	tester.MustApplyPatches()
+	c.Assert(state.Info.Config.Filter.Rules, check.DeepEquals, []string{"test0.table0", "test0.table1"})
+	c.Assert(state.Info.TableBootstrap, check.DeepEquals, &model.TableBootstrap{
+		Ts: state.Status.CheckpointTs, TableIDs: []model.TableID{tableID},
+	})
+	c.Assert(cf.scheduler.addTableStartTs, check.HasKey, tableID)
+
+	// the tables can't be updated until the bootstrap is finished
+	err = cf.updateTables(&model.UpdateTablesConfig{Matcher: []string{"test0.table0"}}, true)
+	c.Assert(err, check.ErrorMatches, ".*being backfilled.*")
+}
+
 func (s *changefeedSuite) TestSyncPoint(c *check.C) {
 	defer testleak.AfterTest(c)()
 	ctx := cdcContext.NewBackendContext4Test(true)
@@ -0,0 +1,464 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sink"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/cyclic/mark"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/quotes"
+	"github.com/pingcap/ticdc/pkg/security"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// Results of consistency checks
+const (
+	checksumResultConsistent   = "consistent"
+	checksumResultInconsistent = "inconsistent"
+	checksumResultError        = "error"
+)
+
+// checksumTable is a table to be checked by the consistency checker.
+type checksumTable struct {
+	schema  string
+	table   string
+	columns []string
+	// keyColumns are the columns of the primary key or a not null unique key,
+	// which split the rows into chunks. The table is checked as a single chunk
+	// if it has no such key.
+	keyColumns []string
+}
+
+// checksumTask is a syncpoint to be checked.
+type checksumTask struct {
+	primaryTs model.Ts
+	tables    []*checksumTable
+}
+
+// checksumChecker compares the checksums of the upstream and downstream tables
+// at the syncpoints of a changefeed in the background. The upstream is read by
+// a TiDB server of the upstream at the upstream ts of a syncpoint, and the
+// downstream is read at the downstream ts recorded in the syncpoint table. The
+// result of the last check is exposed by metrics and the owner status provider.
+type checksumChecker struct {
+	id            model.ChangeFeedID
+	config        *config.ChecksumConfig
+	downstreamURI string
+	openDB        func(ctx context.Context, uri string) (*sql.DB, error)
+
+	taskCh chan *checksumTask
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	statusMu sync.Mutex
+	status   model.ChangefeedChecksumStatus
+
+	metricsMismatchedTablesGauge prometheus.Gauge
+	metricsCheckedTsGauge        prometheus.Gauge
+}
+
+// newChecksumChecker creates a checksumChecker, the status of the previous
+// checker is inherited so that the result of the last check is kept after the
+// changefeed is restarted.
+func newChecksumChecker(
+	id model.ChangeFeedID, cfg *config.ChecksumConfig, downstreamURI string, prev *checksumChecker,
+) *checksumChecker {
+	c := &checksumChecker{
+		id:            id,
+		config:        cfg,
+		downstreamURI: downstreamURI,
+		taskCh:        make(chan *checksumTask, 1),
+		cancel:        func() {},
+		status: model.ChangefeedChecksumStatus{
+			ID:      id,
+			Enabled: true,
+		},
+		metricsMismatchedTablesGauge: changefeedChecksumMismatchedTablesGauge.WithLabelValues(id),
+		metricsCheckedTsGauge:        changefeedChecksumCheckedTsGauge.WithLabelValues(id),
+	}
+	// the time zone of the sessions is UTC so that the upstream and
+	// downstream format the timestamps in the same way.
+	c.openDB = func(ctx context.Context, uri string) (*sql.DB, error) {
+		return openMySQLDB(ctx, "checksum"+id, uri, `"+00:00"`)
+	}
+	if prev != nil {
+		c.status = *prev.getStatus()
+	}
+	return c
+}
+
+// run starts the background goroutine checking the syncpoints.
+func (c *checksumChecker) run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.runChecks(ctx)
+	}()
+}
+
+// trigger sends a syncpoint to be checked, the syncpoint is skipped if the
+// previous one is still being checked.
+func (c *checksumChecker) trigger(task *checksumTask) {
+	if c == nil {
+		return
+	}
+	select {
+	case c.taskCh <- task:
+	default:
+		log.Info("skip the consistency check since the previous one is not finished",
+			zap.String("changefeed", c.id), zap.Uint64("syncpoint", task.primaryTs))
+	}
+}
+
+// close stops checking and releases the metrics.
+func (c *checksumChecker) close() {
+	if c == nil {
+		return
+	}
+	c.cancel()
+	c.wg.Wait()
+	changefeedChecksumMismatchedTablesGauge.DeleteLabelValues(c.id)
+	changefeedChecksumCheckedTsGauge.DeleteLabelValues(c.id)
+	for _, result := range []string{checksumResultConsistent, checksumResultInconsistent, checksumResultError} {
+		changefeedChecksumCheckCounter.DeleteLabelValues(c.id, result)
+	}
+}
+
+// getStatus returns a copy of the status of the last check.
+func (c *checksumChecker) getStatus() *model.ChangefeedChecksumStatus {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	status := c.status
+	return &status
+}
+
+func (c *checksumChecker) runChecks(ctx context.Context) {
+	var upstreamDB, downstreamDB *sql.DB
+	defer func() {
+		for _, db := range []*sql.DB{upstreamDB, downstreamDB} {
+			if db != nil {
+				_ = db.Close()
+			}
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-c.taskCh:
+			var err error
+			if upstreamDB == nil {
+				upstreamDB, err = c.openDB(ctx, c.config.UpstreamURI)
+			}
+			if err == nil && downstreamDB == nil {
+				downstreamDB, err = c.openDB(ctx, c.downstreamURI)
+			}
+			status := &model.ChangefeedChecksumStatus{ID: c.id, Enabled: true, CheckedTs: task.primaryTs}
+			if err == nil {
+				err = c.check(ctx, upstreamDB, downstreamDB, task, status)
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			c.updateStatus(status, err)
+		}
+	}
+}
+
+func (c *checksumChecker) updateStatus(status *model.ChangefeedChecksumStatus, err error) {
+	now := model.JSONTime(time.Now())
+	status.CheckTime = &now
+	result := checksumResultConsistent
+	switch {
+	case err != nil:
+		result = checksumResultError
+		status.Error = err.Error()
+		log.Warn("the consistency check of changefeed failed",
+			zap.String("changefeed", c.id), zap.Uint64("syncpoint", status.CheckedTs), zap.Error(err))
+	case len(status.MismatchedTables) != 0:
+		result = checksumResultInconsistent
+		log.Warn("the upstream and downstream of changefeed are inconsistent",
+			zap.String("changefeed", c.id), zap.Uint64("syncpoint", status.CheckedTs),
+			zap.Reflect("mismatchedTables", status.MismatchedTables))
+	default:
+		log.Info("the upstream and downstream of changefeed are consistent",
+			zap.String("changefeed", c.id), zap.Uint64("syncpoint", status.CheckedTs),
+			zap.Int("checkedTables", status.CheckedTables))
+	}
+	changefeedChecksumCheckCounter.WithLabelValues(c.id, result).Inc()
+	if err == nil {
+		c.metricsMismatchedTablesGauge.Set(float64(len(status.MismatchedTables)))
+		c.metricsCheckedTsGauge.Set(float64(status.CheckedTs))
+	}
+	c.statusMu.Lock()
+	c.status = *status
+	c.statusMu.Unlock()
+}
+
+// check compares the checksums of the tables at the syncpoint.
+func (c *checksumChecker) check(
+	ctx context.Context, upstreamDB, downstreamDB *sql.DB, task *checksumTask, status *model.ChangefeedChecksumStatus,
+) error {
+	var secondaryTs string
+	err := downstreamDB.QueryRowContext(ctx,
+		"SELECT secondary_ts FROM "+quotes.QuoteSchema(mark.SchemaName, sink.SyncpointTableName)+
+			" WHERE cf = ? AND primary_ts = ?", c.id, strconv.FormatUint(task.primaryTs, 10)).Scan(&secondaryTs)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	status.DownstreamTs, err = strconv.ParseUint(secondaryTs, 10, 64)
+	if err != nil {
+		return errors.Annotatef(err, "invalid secondary ts %s of syncpoint %d", secondaryTs, task.primaryTs)
+	}
+	upstreamConn, err := snapshotConn(ctx, upstreamDB, task.primaryTs)
+	if err != nil {
+		return err
+	}
+	defer closeSnapshotConn(upstreamConn)
+	downstreamConn, err := snapshotConn(ctx, downstreamDB, status.DownstreamTs)
+	if err != nil {
+		return err
+	}
+	defer closeSnapshotConn(downstreamConn)
+
+	for _, table := range task.tables {
+		chunks, err := c.checkTable(ctx, upstreamConn, downstreamConn, table)
+		if err != nil {
+			return errors.Annotatef(err, "check table %s", quotes.QuoteSchema(table.schema, table.table))
+		}
+		status.CheckedTables++
+		if len(chunks) != 0 {
+			status.MismatchedTables = append(status.MismatchedTables, model.ChecksumMismatch{
+				Schema: table.schema,
+				Table:  table.table,
+				Chunks: chunks,
+			})
+		}
+	}
+	return nil
+}
+
+// checkTable compares the checksums of the table chunk by chunk, and returns the
+// key ranges of the mismatched chunks. The chunks are split by the upstream.
+func (c *checksumChecker) checkTable(
+	ctx context.Context, upstreamConn, downstreamConn *sql.Conn, table *checksumTable,
+) ([]string, error) {
+	var mismatched []string
+	var lower []interface{}
+	for {
+		var upper []interface{}
+		if len(table.keyColumns) != 0 {
+			var err error
+			upper, err = chunkUpperBound(ctx, upstreamConn, table, lower, c.config.GetChunkSize())
+			if err != nil {
+				return nil, err
+			}
+		}
+		where, args := chunkRange(table.keyColumns, lower, upper)
+		upstreamCount, upstreamChecksum, err := chunkChecksum(ctx, upstreamConn, table, where, args)
+		if err != nil {
+			return nil, err
+		}
+		downstreamCount, downstreamChecksum, err := chunkChecksum(ctx, downstreamConn, table, where, args)
+		if err != nil {
+			return nil, err
+		}
+		if upstreamCount != downstreamCount || upstreamChecksum != downstreamChecksum {
+			mismatched = append(mismatched, describeChunk(lower, upper))
+		}
+		if upper == nil {
+			return mismatched, nil
+		}
+		lower = upper
+	}
+}
+
+// snapshotConn returns a connection reading the snapshot at ts.
+func snapshotConn(ctx context.Context, db *sql.DB, ts uint64) (*sql.Conn, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLConnectionError, err)
+	}
+	_, err = conn.ExecContext(ctx, fmt.Sprintf("SET @@tidb_snapshot = '%d'", ts))
+	if err != nil {
+		_ = conn.Close()
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return conn, nil
+}
+
+// closeSnapshotConn resets the snapshot before returning the connection to the pool.
+func closeSnapshotConn(conn *sql.Conn) {
+	if _, err := conn.ExecContext(context.Background(), "SET @@tidb_snapshot = ''"); err != nil {
+		// discard the connection instead of reusing it with the snapshot
+		_ = conn.Raw(func(driverConn interface{}) error { return driver.ErrBadConn })
+	}
+	_ = conn.Close()
+}
+
+// chunkUpperBound returns the key of the last row of the chunk after lower, it
+// returns nil if the chunk is the last one.
+func chunkUpperBound(
+	ctx context.Context, conn *sql.Conn, table *checksumTable, lower []interface{}, chunkSize int,
+) ([]interface{}, error) {
+	keys := buildColumnList(table.keyColumns)
+	where, args := chunkRange(table.keyColumns, lower, nil)
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s LIMIT 1 OFFSET %d",
+		keys, quotes.QuoteSchema(table.schema, table.table), where, keys, chunkSize-1)
+	values := make([]sql.NullString, len(table.keyColumns))
+	dest := make([]interface{}, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	err := conn.QueryRowContext(ctx, query, args...).Scan(dest...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	upper := make([]interface{}, len(values))
+	for i, v := range values {
+		upper[i] = v.String
+	}
+	return upper, nil
+}
+
+// chunkRange returns the condition of the rows in the key range (lower, upper],
+// a nil bound means unbounded.
+func chunkRange(keyColumns []string, lower, upper []interface{}) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(keyColumns)), ",")
+	if lower != nil {
+		conds = append(conds, fmt.Sprintf("(%s) > (%s)", buildColumnList(keyColumns), placeholders))
+		args = append(args, lower...)
+	}
+	if upper != nil {
+		conds = append(conds, fmt.Sprintf("(%s) <= (%s)", buildColumnList(keyColumns), placeholders))
+		args = append(args, upper...)
+	}
+	if len(conds) == 0 {
+		return "TRUE", nil
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// chunkChecksum returns the number of rows and the checksum of the chunk, the
+// checksum is computed in the same way as sync-diff-inspector.
+func chunkChecksum(
+	ctx context.Context, conn *sql.Conn, table *checksumTable, where string, args []interface{},
+) (count uint64, checksum uint64, err error) {
+	columns := make([]string, 0, len(table.columns))
+	isNull := make([]string, 0, len(table.columns))
+	for _, col := range table.columns {
+		columns = append(columns, quotes.QuoteName(col))
+		isNull = append(isNull, fmt.Sprintf("ISNULL(%s)", quotes.QuoteName(col)))
+	}
+	query := fmt.Sprintf(
+		"SELECT COUNT(*), BIT_XOR(CAST(CRC32(CONCAT_WS(',', %s, CONCAT(%s))) AS UNSIGNED)) FROM %s WHERE %s",
+		strings.Join(columns, ", "), strings.Join(isNull, ", "), quotes.QuoteSchema(table.schema, table.table), where)
+	err = conn.QueryRowContext(ctx, query, args...).Scan(&count, &checksum)
+	if err != nil {
+		return 0, 0, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	return count, checksum, nil
+}
+
+// describeChunk returns the key range of the chunk in the form of (lower, upper].
+func describeChunk(lower, upper []interface{}) string {
+	format := func(bound []interface{}, unbounded string) string {
+		if bound == nil {
+			return unbounded
+		}
+		values := make([]string, 0, len(bound))
+		for _, v := range bound {
+			values = append(values, fmt.Sprintf("%v", v))
+		}
+		return "(" + strings.Join(values, ", ") + ")"
+	}
+	return fmt.Sprintf("(%s, %s]", format(lower, "-inf"), format(upper, "+inf"))
+}
+
+func buildColumnList(columns []string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, col := range columns {
+		quoted = append(quoted, quotes.QuoteName(col))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// openMySQLDB opens a connection pool of the MySQL URI, the name identifies
+// the TLS config of the pool and the sessions use the time zone.
+func openMySQLDB(ctx context.Context, name string, uri string, timeZone string) (*sql.DB, error) {
+	mysqlURI, err := url.Parse(uri)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	var tlsParam string
+	if mysqlURI.Query().Get("ssl-ca") != "" {
+		credential := security.Credential{
+			CAPath:   mysqlURI.Query().Get("ssl-ca"),
+			CertPath: mysqlURI.Query().Get("ssl-cert"),
+			KeyPath:  mysqlURI.Query().Get("ssl-key"),
+		}
+		tlsCfg, err := credential.ToTLSConfig()
+		if err != nil {
+			return nil, cerror.ErrMySQLConnectionError.Wrap(err).GenWithStack("fail to open MySQL connection")
+		}
+		tlsName := "cdc_mysql_tls" + name + mysqlURI.Host
+		err = dmysql.RegisterTLSConfig(tlsName, tlsCfg)
+		if err != nil {
+			return nil, cerror.ErrMySQLConnectionError.Wrap(err).GenWithStack("fail to open MySQL connection")
+		}
+		tlsParam = "?tls=" + tlsName
+	}
+	username := mysqlURI.User.Username()
+	password, _ := mysqlURI.User.Password()
+	port := mysqlURI.Port()
+	if username == "" {
+		username = "root"
+	}
+	if port == "" {
+		port = "4000"
+	}
+	dsn, err := dmysql.ParseDSN(fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", username, password, mysqlURI.Hostname(), port, tlsParam))
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	if dsn.Params == nil {
+		dsn.Params = make(map[string]string, 1)
+	}
+	dsn.Params["time_zone"] = timeZone
+	dsn.InterpolateParams = true
+	return sink.GetDBConnImpl(ctx, dsn.FormatDSN())
+}
@@ -0,0 +1,152 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+var _ = check.Suite(&checksumSuite{})
+
+type checksumSuite struct{}
+
+func (s *checksumSuite) TestChunkRange(c *check.C) {
+	defer testleak.AfterTest(c)()
+	where, args := chunkRange([]string{"a", "b"}, nil, nil)
+	c.Assert(where, check.Equals, "TRUE")
+	c.Assert(args, check.HasLen, 0)
+	where, args = chunkRange([]string{"a", "b"}, []interface{}{"1", "x"}, nil)
+	c.Assert(where, check.Equals, "(`a`, `b`) > (?,?)")
+	c.Assert(args, check.DeepEquals, []interface{}{"1", "x"})
+	where, args = chunkRange([]string{"a"}, []interface{}{"1"}, []interface{}{"5"})
+	c.Assert(where, check.Equals, "(`a`) > (?) AND (`a`) <= (?)")
+	c.Assert(args, check.DeepEquals, []interface{}{"1", "5"})
+
+	c.Assert(describeChunk(nil, nil), check.Equals, "(-inf, +inf]")
+	c.Assert(describeChunk([]interface{}{"1", "x"}, nil), check.Equals, "((1, x), +inf]")
+	c.Assert(describeChunk(nil, []interface{}{"5"}), check.Equals, "(-inf, (5)]")
+}
+
+func (s *checksumSuite) TestCheck(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := context.Background()
+	upstreamDB, upstreamMock, err := sqlmock.New()
+	c.Assert(err, check.IsNil)
+	defer upstreamDB.Close() //nolint:errcheck
+	downstreamDB, downstreamMock, err := sqlmock.New()
+	c.Assert(err, check.IsNil)
+	defer downstreamDB.Close() //nolint:errcheck
+
+	checker := newChecksumChecker("test-changefeed", &config.ChecksumConfig{ChunkSize: 2}, "mysql://127.0.0.1:3306/", nil)
+	defer checker.close()
+	task := &checksumTask{
+		primaryTs: 100,
+		tables: []*checksumTable{
+			{schema: "test", table: "t1", columns: []string{"id", "v"}, keyColumns: []string{"id"}},
+			{schema: "test", table: "t2", columns: []string{"v"}},
+		},
+	}
+
+	downstreamMock.ExpectQuery(regexp.QuoteMeta("SELECT secondary_ts FROM `tidb_cdc`.`syncpoint_v1` WHERE cf = ? AND primary_ts = ?")).
+		WithArgs("test-changefeed", "100").
+		WillReturnRows(sqlmock.NewRows([]string{"secondary_ts"}).AddRow("200"))
+	upstreamMock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = '100'")).WillReturnResult(sqlmock.NewResult(0, 0))
+	downstreamMock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = '200'")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// the first chunk of t1 is consistent
+	upstreamMock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `test`.`t1` WHERE TRUE ORDER BY `id` LIMIT 1 OFFSET 1")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("2"))
+	checksumQuery := "SELECT COUNT(*), BIT_XOR(CAST(CRC32(CONCAT_WS(',', `id`, `v`, CONCAT(ISNULL(`id`), ISNULL(`v`)))) AS UNSIGNED)) FROM `test`.`t1` WHERE "
+	upstreamMock.ExpectQuery(regexp.QuoteMeta(checksumQuery + "(`id`) <= (?)")).WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(2, 100))
+	downstreamMock.ExpectQuery(regexp.QuoteMeta(checksumQuery + "(`id`) <= (?)")).WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(2, 100))
+	// the last chunk of t1 is inconsistent
+	upstreamMock.ExpectQuery(regexp.QuoteMeta("SELECT `id` FROM `test`.`t1` WHERE (`id`) > (?) ORDER BY `id` LIMIT 1 OFFSET 1")).
+		WithArgs("2").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	upstreamMock.ExpectQuery(regexp.QuoteMeta(checksumQuery + "(`id`) > (?)")).WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(1, 7))
+	downstreamMock.ExpectQuery(regexp.QuoteMeta(checksumQuery + "(`id`) > (?)")).WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(1, 8))
+	// t2 has no key and is checked as a single chunk
+	checksumQuery = "SELECT COUNT(*), BIT_XOR(CAST(CRC32(CONCAT_WS(',', `v`, CONCAT(ISNULL(`v`)))) AS UNSIGNED)) FROM `test`.`t2` WHERE TRUE"
+	upstreamMock.ExpectQuery(regexp.QuoteMeta(checksumQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(3, 9))
+	downstreamMock.ExpectQuery(regexp.QuoteMeta(checksumQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "checksum"}).AddRow(3, 9))
+
+	downstreamMock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = ''")).WillReturnResult(sqlmock.NewResult(0, 0))
+	upstreamMock.ExpectExec(regexp.QuoteMeta("SET @@tidb_snapshot = ''")).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	status := &model.ChangefeedChecksumStatus{ID: "test-changefeed", Enabled: true, CheckedTs: task.primaryTs}
+	err = checker.check(ctx, upstreamDB, downstreamDB, task, status)
+	c.Assert(err, check.IsNil)
+	c.Assert(status.DownstreamTs, check.Equals, uint64(200))
+	c.Assert(status.CheckedTables, check.Equals, 2)
+	c.Assert(status.MismatchedTables, check.DeepEquals, []model.ChecksumMismatch{
+		{Schema: "test", Table: "t1", Chunks: []string{"((2), +inf]"}},
+	})
+	c.Assert(upstreamMock.ExpectationsWereMet(), check.IsNil)
+	c.Assert(downstreamMock.ExpectationsWereMet(), check.IsNil)
+
+	checker.updateStatus(status, nil)
+	status = checker.getStatus()
+	c.Assert(status.Enabled, check.IsTrue)
+	c.Assert(status.CheckedTs, check.Equals, uint64(100))
+	c.Assert(status.CheckTime, check.NotNil)
+	c.Assert(status.MismatchedTables, check.HasLen, 1)
+}
+
+func (s *checksumSuite) TestRunChecks(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	checker := newChecksumChecker("test-changefeed", &config.ChecksumConfig{UpstreamURI: "mysql://127.0.0.1:4000/"}, "mysql://127.0.0.1:3306/", nil)
+	checker.openDB = func(ctx context.Context, uri string) (*sql.DB, error) {
+		return nil, errors.New("connection refused")
+	}
+	checker.run(ctx)
+	checker.trigger(&checksumTask{primaryTs: 100})
+	for i := 0; ; i++ {
+		status := checker.getStatus()
+		if status.CheckedTs != 0 {
+			c.Assert(status.CheckedTs, check.Equals, uint64(100))
+			c.Assert(status.Error, check.Equals, "connection refused")
+			break
+		}
+		c.Assert(i, check.Less, 100)
+		time.Sleep(50 * time.Millisecond)
+	}
+	checker.close()
+
+	// the status is inherited by the next checker
+	next := newChecksumChecker("test-changefeed", checker.config, checker.downstreamURI, checker)
+	defer next.close()
+	c.Assert(next.getStatus().CheckedTs, check.Equals, uint64(100))
+
+	// a nil checker is not enabled
+	var disabled *checksumChecker
+	disabled.trigger(&checksumTask{primaryTs: 100})
+	disabled.close()
+}
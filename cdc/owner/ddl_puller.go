@@ -57,16 +57,17 @@ type ddlPullerImpl struct {
 }
 
 func newDDLPuller(ctx cdcContext.Context, startTs uint64) (DDLPuller, error) {
-	pdCli := ctx.GlobalVars().PDClient
+	up := cdcContext.GetUpstream(ctx)
+	pdCli := up.PDClient
 	f, err := filter.NewFilter(ctx.ChangefeedVars().Info.Config)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	var plr puller.Puller
-	kvStorage := ctx.GlobalVars().KVStorage
+	kvStorage := up.KVStorage
 	// kvStorage can be nil only in the test
 	if kvStorage != nil {
-		plr = puller.NewPuller(ctx, pdCli, ctx.GlobalVars().GrpcPool, kvStorage, startTs,
+		plr = puller.NewPuller(ctx, pdCli, up.GrpcPool, kvStorage, startTs,
 			[]regionspan.Span{regionspan.GetDDLSpan(), regionspan.GetAddIndexDDLSpan()}, false)
 	}
 
@@ -16,7 +16,6 @@ package owner
 import (
 	"time"
 
-	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/model"
 	cerrors "github.com/pingcap/ticdc/pkg/errors"
@@ -24,6 +23,17 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// errorBackoffInitInterval is the backoff before a changefeed is restarted
+	// when the retryable errors reach model.ErrorHistoryThreshold for the
+	// first time, it is doubled each time until errorBackoffMaxInterval.
+	errorBackoffInitInterval = 10 * time.Second
+	errorBackoffMaxInterval  = 10 * time.Minute
+	// errorBackoffResetInterval is how long a changefeed runs without errors
+	// before the backoff is reset to errorBackoffInitInterval.
+	errorBackoffResetInterval = 30 * time.Minute
+)
+
 // feedStateManager manages the ReactorState of a changefeed
 // when an error or an admin job occurs, the feedStateManager is responsible for controlling the ReactorState
 type feedStateManager struct {
@@ -35,6 +45,16 @@ type feedStateManager struct {
 	shouldBeRemoved bool
 
 	adminJobQueue []*model.AdminJob
+	// updatedInfo is the changefeed info updated by the user, which is
+	// applied in the next tick
+	updatedInfo *model.ChangeFeedInfo
+
+	// backoffInterval is the backoff of the next time the retryable errors
+	// reach the threshold, and backoffUntil is when the changefeed in the
+	// error state is restarted.
+	backoffInterval time.Duration
+	backoffUntil    time.Time
+	lastErrorTime   time.Time
 }
 
 func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) {
@@ -52,6 +72,10 @@ func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) {
 		// skip to the next tick until all the admin jobs is handled
 		return
 	}
+	if m.handleUpdatedInfo() {
+		// the changefeed is restarted with the updated info in the next tick
+		return
+	}
 	switch m.state.Info.State {
 	case model.StateRemoved:
 		m.shouldBeRunning = false
@@ -60,6 +84,11 @@ func (m *feedStateManager) Tick(state *orchestrator.ChangefeedReactorState) {
 	case model.StateStopped, model.StateFailed, model.StateFinished:
 		m.shouldBeRunning = false
 		return
+	case model.StateError:
+		if m.backingOff(time.Now()) {
+			m.shouldBeRunning = false
+		}
+		return
 	}
 	errs := m.errorsReportedByProcessors()
 	m.handleError(errs...)
@@ -85,6 +114,94 @@ func (m *feedStateManager) MarkFinished() {
 	})
 }
 
+// FailoverSink switches the changefeed to the standby sink and records the
+// switchover. The changefeed is stopped in this tick and restarted from the
+// checkpoint with the new sink in the next tick.
+func (m *feedStateManager) FailoverSink(checkpointTs model.Ts, reason string) {
+	m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil || info.StandbySinkURI == "" {
+			return info, false, nil
+		}
+		info.SwitchSink(checkpointTs, reason)
+		info.Error = nil
+		info.ErrorHis = nil
+		return info, true, nil
+	})
+	m.patchState(model.StateNormal)
+	m.shouldBeRunning = false
+	m.cleanUpInfos()
+	log.Warn("the sink of changefeed is unhealthy, fail over to the standby sink",
+		zap.String("changefeedID", m.state.ID), zap.Uint64("checkpointTs", checkpointTs), zap.String("reason", reason))
+}
+
+// QuarantineGC pauses the changefeed which blocks the GC longer than the gc
+// ttl and records the quarantine, so that the owner releases the GC safepoint
+// held by the changefeed. The quarantine is lifted when the changefeed is
+// resumed from an overwrite checkpoint ts greater than the quarantined one.
+func (m *feedStateManager) QuarantineGC(err *model.RunningError, checkpointTs model.Ts, ttl int64) {
+	now := time.Now()
+	m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil {
+			return nil, false, nil
+		}
+		info.Error = err
+		info.GCQuarantine = &model.GCQuarantine{
+			Time:         now,
+			CheckpointTs: checkpointTs,
+			TTL:          ttl,
+		}
+		info.RecordError(err, model.ErrorActionQuarantine, now)
+		return info, true, nil
+	})
+	m.shouldBeRunning = false
+	m.patchState(model.StateStopped)
+	m.cleanUpInfos()
+	log.Warn("the changefeed blocks the GC longer than the gc ttl, quarantine it and release its GC safepoint",
+		zap.String("changefeedID", m.state.ID), zap.Uint64("checkpointTs", checkpointTs), zap.Int64("ttl", ttl))
+}
+
+// UpdateInfo replaces the changefeed info with the info updated by the user in
+// the next tick. A running changefeed is stopped in that tick and restarted
+// from the checkpoint in the following tick, so that the sink and the filter
+// are recreated with the updated config.
+func (m *feedStateManager) UpdateInfo(info *model.ChangeFeedInfo) {
+	m.updatedInfo = info
+}
+
+func (m *feedStateManager) handleUpdatedInfo() (restarting bool) {
+	updatedInfo := m.updatedInfo
+	if updatedInfo == nil {
+		return false
+	}
+	m.updatedInfo = nil
+	m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil {
+			return nil, false, nil
+		}
+		// keep the states maintained by the owner
+		updatedInfo.State = info.State
+		updatedInfo.AdminJobType = info.AdminJobType
+		updatedInfo.Error = info.Error
+		updatedInfo.ErrorHis = info.ErrorHis
+		updatedInfo.PausedTables = info.PausedTables
+		updatedInfo.SinkSwitchovers = info.SinkSwitchovers
+		updatedInfo.ErrorRecords = info.ErrorRecords
+		updatedInfo.GCQuarantine = info.GCQuarantine
+		updatedInfo.BackfillFinished = info.BackfillFinished
+		return updatedInfo, true, nil
+	})
+	switch m.state.Info.State {
+	case model.StateNormal, model.StateError:
+	default:
+		log.Info("the config of changefeed is updated", zap.String("changefeedID", m.state.ID))
+		return false
+	}
+	m.shouldBeRunning = false
+	log.Info("the config of changefeed is updated, restart the changefeed with the new config",
+		zap.String("changefeedID", m.state.ID))
+	return true
+}
+
 func (m *feedStateManager) PushAdminJob(job *model.AdminJob) {
 	switch job.Type {
 	case model.AdminStop, model.AdminResume, model.AdminRemove:
@@ -144,18 +261,42 @@ func (m *feedStateManager) handleAdminJob() (jobsPending bool) {
 				zap.String("changefeedState", string(m.state.Info.State)), zap.Any("job", job))
 			return
 		}
+		var overwriteCheckpointTs model.Ts
+		if job.Opts != nil {
+			overwriteCheckpointTs = job.Opts.OverwriteCheckpointTs
+		}
+		if err := m.state.Info.VerifyResume(m.state.ID, overwriteCheckpointTs); err != nil {
+			log.Warn("can not resume the changefeed", zap.String("changefeedID", m.state.ID),
+				zap.Any("job", job), zap.Error(err))
+			return
+		}
 		m.shouldBeRunning = true
 		jobsPending = true
 		m.patchState(model.StateNormal)
+		m.backoffInterval = 0
+		m.backoffUntil = time.Time{}
 		// remove error history to make sure the changefeed can running in next tick
 		m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
-			if info.Error != nil || len(info.ErrorHis) != 0 {
+			if info.Error != nil || len(info.ErrorHis) != 0 || info.GCQuarantine != nil {
 				info.Error = nil
 				info.ErrorHis = nil
+				info.GCQuarantine = nil
 				return info, true, nil
 			}
 			return info, false, nil
 		})
+		if overwriteCheckpointTs != 0 {
+			m.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+				if status == nil {
+					status = new(model.ChangeFeedStatus)
+				}
+				status.CheckpointTs = overwriteCheckpointTs
+				status.ResolvedTs = overwriteCheckpointTs
+				return status, true, nil
+			})
+			log.Info("the checkpoint ts of the quarantined changefeed is overwritten",
+				zap.String("changefeedID", m.state.ID), zap.Uint64("checkpointTs", overwriteCheckpointTs))
+		}
 	case model.AdminFinish:
 		switch m.state.Info.State {
 		case model.StateNormal:
@@ -270,15 +411,28 @@ func (m *feedStateManager) errorsReportedByProcessors() []*model.RunningError {
 	return result
 }
 
+// handleError handles the errors by their classes. The changefeed is failed
+// by a fatal error, and paused by a config or downstream schema error, which
+// can't be fixed by retrying. The changefeed is restarted after the retryable
+// errors, with an exponential backoff if they keep occurring.
 func (m *feedStateManager) handleError(errs ...*model.RunningError) {
+	now := time.Now()
+	if len(errs) > 0 {
+		m.lastErrorTime = now
+		for _, err := range errs {
+			changefeedErrorCounter.WithLabelValues(m.state.ID, string(err.ErrorClass())).Inc()
+		}
+	}
+
 	// if there are a fastFail error in errs, we can just fastFail the changefeed
 	// and no need to patch other error to the changefeed info
 	for _, err := range errs {
-		if cerrors.ChangefeedFastFailErrorCode(errors.RFCErrorCode(err.Code)) {
+		if err.ErrorClass() == cerrors.ErrorClassFatal {
 			m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
 				info.Error = err
-				info.ErrorHis = append(info.ErrorHis, time.Now().UnixNano()/1e6)
+				info.ErrorHis = append(info.ErrorHis, now.UnixNano()/1e6)
 				info.CleanUpOutdatedErrorHistory()
+				info.RecordError(err, model.ErrorActionFail, now)
 				return info, true, nil
 			})
 			m.shouldBeRunning = false
@@ -287,19 +441,89 @@ func (m *feedStateManager) handleError(errs ...*model.RunningError) {
 		}
 	}
 
+	// the config and downstream schema errors are fixed by the users, the
+	// changefeed is paused with the error as the reason
+	for _, err := range errs {
+		switch err.ErrorClass() {
+		case cerrors.ErrorClassConfig, cerrors.ErrorClassDownstreamSchema:
+			m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+				info.Error = err
+				info.ErrorHis = append(info.ErrorHis, now.UnixNano()/1e6)
+				info.CleanUpOutdatedErrorHistory()
+				info.RecordError(err, model.ErrorActionPause, now)
+				return info, true, nil
+			})
+			log.Warn("the changefeed is paused by an error which can't be fixed by retrying",
+				zap.String("changefeedID", m.state.ID), zap.String("class", string(err.ErrorClass())),
+				zap.String("code", err.Code), zap.String("message", err.Message))
+			m.shouldBeRunning = false
+			m.patchState(model.StateStopped)
+			return
+		}
+	}
+
 	m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
 		for _, err := range errs {
 			info.Error = err
-			info.ErrorHis = append(info.ErrorHis, time.Now().UnixNano()/1e6)
+			info.ErrorHis = append(info.ErrorHis, now.UnixNano()/1e6)
+			info.RecordError(err, model.ErrorActionRestart, now)
 		}
 		changed := info.CleanUpOutdatedErrorHistory()
 		return info, changed || len(errs) > 0, nil
 	})
 
-	// if the number of errors has reached the error threshold, stop the changefeed
+	// if the number of errors has reached the error threshold, stop the
+	// changefeed and restart it after a backoff
 	if m.state.Info.ErrorsReachedThreshold() {
+		backoff := m.nextBackoff(now)
+		m.backoffUntil = now.Add(backoff)
+		m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+			if n := len(info.ErrorRecords); n > 0 {
+				info.ErrorRecords[n-1].Action = model.ErrorActionBackoff
+			}
+			return info, true, nil
+		})
+		log.Warn("the changefeed keeps running into errors, restart it after a backoff",
+			zap.String("changefeedID", m.state.ID), zap.Duration("backoff", backoff))
 		m.shouldBeRunning = false
 		m.patchState(model.StateError)
 		return
 	}
 }
+
+// nextBackoff returns the backoff before the changefeed is restarted, and
+// doubles the backoff for the next time.
+func (m *feedStateManager) nextBackoff(now time.Time) time.Duration {
+	if m.backoffInterval == 0 || now.Sub(m.lastErrorTime) > errorBackoffResetInterval {
+		m.backoffInterval = errorBackoffInitInterval
+	}
+	backoff := m.backoffInterval
+	m.backoffInterval *= 2
+	if m.backoffInterval > errorBackoffMaxInterval {
+		m.backoffInterval = errorBackoffMaxInterval
+	}
+	return backoff
+}
+
+// backingOff returns whether the changefeed in the error state should keep
+// waiting before it is restarted. The error history is cleared when the
+// backoff is over, so that the changefeed is not stopped by the old errors.
+func (m *feedStateManager) backingOff(now time.Time) bool {
+	if m.backoffUntil.IsZero() {
+		// the owner is changed while the changefeed is backing off
+		m.backoffUntil = now.Add(m.nextBackoff(now))
+	}
+	if now.Before(m.backoffUntil) {
+		return true
+	}
+	m.backoffUntil = time.Time{}
+	m.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		if info == nil || len(info.ErrorHis) == 0 {
+			return info, false, nil
+		}
+		info.ErrorHis = nil
+		return info, true, nil
+	})
+	log.Info("the backoff of changefeed is over, restart the changefeed", zap.String("changefeedID", m.state.ID))
+	return false
+}
@@ -14,6 +14,8 @@
 package owner
 
 import (
+	"time"
+
 	"github.com/pingcap/check"
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/pkg/config"
@@ -172,6 +174,180 @@ func (s *feedStateManagerSuite) TestCleanUpInfos(c *check.C) {
 	c.Assert(state.Workloads, check.Not(check.HasKey), ctx.GlobalVars().CaptureInfo.ID)
 }
 
+func (s *feedStateManagerSuite) TestFailoverSink(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := new(feedStateManager)
+	state := orchestrator.NewChangefeedReactorState(ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(c, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		c.Assert(info, check.IsNil)
+		return &model.ChangeFeedInfo{
+			SinkURI:        "kafka://127.0.0.1:9092/topic",
+			StandbySinkURI: "kafka://127.0.0.2:9092/topic",
+			Config:         &config.ReplicaConfig{},
+			State:          model.StateError,
+			Error:          &model.RunningError{Message: "fake error"},
+			ErrorHis:       []int64{time.Now().UnixNano() / 1e6},
+		}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		c.Assert(status, check.IsNil)
+		return &model.ChangeFeedStatus{AdminJobType: model.AdminStop}, true, nil
+	})
+	state.PatchTaskStatus(ctx.GlobalVars().CaptureInfo.ID, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
+		return &model.TaskStatus{}, true, nil
+	})
+	state.PatchTaskPosition(ctx.GlobalVars().CaptureInfo.ID, func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+		return &model.TaskPosition{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.state = state
+
+	manager.FailoverSink(100, "fake error")
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsFalse)
+	c.Assert(state.Info.SinkURI, check.Equals, "kafka://127.0.0.2:9092/topic")
+	c.Assert(state.Info.StandbySinkURI, check.Equals, "kafka://127.0.0.1:9092/topic")
+	c.Assert(state.Info.SinkSwitchovers, check.HasLen, 1)
+	c.Assert(state.Info.SinkSwitchovers[0].CheckpointTs, check.Equals, model.Ts(100))
+	c.Assert(state.Info.State, check.Equals, model.StateNormal)
+	c.Assert(state.Info.Error, check.IsNil)
+	c.Assert(state.Info.ErrorHis, check.HasLen, 0)
+	c.Assert(state.Status.AdminJobType, check.Equals, model.AdminNone)
+	c.Assert(state.TaskStatuses, check.Not(check.HasKey), ctx.GlobalVars().CaptureInfo.ID)
+	c.Assert(state.TaskPositions, check.Not(check.HasKey), ctx.GlobalVars().CaptureInfo.ID)
+
+	// the changefeed is restarted in the next tick
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsTrue)
+}
+
+func (s *feedStateManagerSuite) TestQuarantineGC(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := new(feedStateManager)
+	state := orchestrator.NewChangefeedReactorState(ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(c, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		c.Assert(info, check.IsNil)
+		return &model.ChangeFeedInfo{SinkURI: "123", Config: &config.ReplicaConfig{}}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		c.Assert(status, check.IsNil)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsTrue)
+
+	manager.QuarantineGC(&model.RunningError{Code: "CDC:ErrGCTTLExceeded", Message: "gc ttl exceeded"}, 100, 60)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsFalse)
+	c.Assert(state.Info.State, check.Equals, model.StateStopped)
+	c.Assert(state.Info.AdminJobType, check.Equals, model.AdminStop)
+	c.Assert(state.Info.Error.Code, check.Equals, "CDC:ErrGCTTLExceeded")
+	c.Assert(state.Info.GCQuarantine.CheckpointTs, check.Equals, model.Ts(100))
+	c.Assert(state.Info.GCQuarantine.TTL, check.Equals, int64(60))
+	c.Assert(state.Info.ErrorRecords, check.HasLen, 1)
+	c.Assert(state.Info.ErrorRecords[0].Action, check.Equals, model.ErrorActionQuarantine)
+
+	// the quarantined changefeed keeps stopped
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsFalse)
+
+	// the quarantined changefeed can't be resumed from its checkpoint ts
+	for _, ts := range []model.Ts{0, 100} {
+		manager.PushAdminJob(&model.AdminJob{
+			CfID: ctx.ChangefeedVars().ID,
+			Type: model.AdminResume,
+			Opts: &model.AdminJobOption{OverwriteCheckpointTs: ts},
+		})
+		manager.Tick(state)
+		tester.MustApplyPatches()
+		c.Assert(manager.ShouldRunning(), check.IsFalse)
+		c.Assert(state.Info.State, check.Equals, model.StateStopped)
+		c.Assert(state.Info.GCQuarantine, check.NotNil)
+	}
+
+	// the quarantine is lifted when the changefeed is resumed from a new checkpoint ts
+	manager.PushAdminJob(&model.AdminJob{
+		CfID: ctx.ChangefeedVars().ID,
+		Type: model.AdminResume,
+		Opts: &model.AdminJobOption{OverwriteCheckpointTs: 200},
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsTrue)
+	c.Assert(state.Info.State, check.Equals, model.StateNormal)
+	c.Assert(state.Info.Error, check.IsNil)
+	c.Assert(state.Info.GCQuarantine, check.IsNil)
+	c.Assert(state.Status.CheckpointTs, check.Equals, model.Ts(200))
+	c.Assert(state.Status.ResolvedTs, check.Equals, model.Ts(200))
+}
+
+func (s *feedStateManagerSuite) TestUpdateInfo(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := cdcContext.NewBackendContext4Test(true)
+	manager := new(feedStateManager)
+	state := orchestrator.NewChangefeedReactorState(ctx.ChangefeedVars().ID)
+	tester := orchestrator.NewReactorStateTester(c, state, nil)
+	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		c.Assert(info, check.IsNil)
+		return &model.ChangeFeedInfo{
+			SinkURI:      "kafka://127.0.0.1:9092/topic",
+			Config:       &config.ReplicaConfig{},
+			PausedTables: map[model.TableID]model.Ts{1: 100},
+		}, true, nil
+	})
+	state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		c.Assert(status, check.IsNil)
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	state.PatchTaskStatus(ctx.GlobalVars().CaptureInfo.ID, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
+		return &model.TaskStatus{}, true, nil
+	})
+	tester.MustApplyPatches()
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsTrue)
+
+	// the running changefeed is stopped and the sink is swapped
+	manager.UpdateInfo(&model.ChangeFeedInfo{
+		SinkURI: "kafka://127.0.0.2:9092/topic",
+		Config:  &config.ReplicaConfig{},
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsFalse)
+	c.Assert(state.Info.SinkURI, check.Equals, "kafka://127.0.0.2:9092/topic")
+	c.Assert(state.Info.State, check.Equals, model.StateNormal)
+	c.Assert(state.Info.PausedTables, check.DeepEquals, map[model.TableID]model.Ts{1: 100})
+	c.Assert(state.TaskStatuses, check.Not(check.HasKey), ctx.GlobalVars().CaptureInfo.ID)
+
+	// the changefeed is resumed with the new config in the next tick
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsTrue)
+
+	// the stopped changefeed is kept stopped
+	manager.PushAdminJob(&model.AdminJob{CfID: ctx.ChangefeedVars().ID, Type: model.AdminStop})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	manager.UpdateInfo(&model.ChangeFeedInfo{
+		SinkURI: "kafka://127.0.0.3:9092/topic",
+		Config:  &config.ReplicaConfig{},
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsFalse)
+	c.Assert(state.Info.SinkURI, check.Equals, "kafka://127.0.0.3:9092/topic")
+	c.Assert(state.Info.State, check.Equals, model.StateStopped)
+}
+
 func (s *feedStateManagerSuite) TestHandleError(c *check.C) {
 	defer testleak.AfterTest(c)()
 	ctx := cdcContext.NewBackendContext4Test(true)
@@ -224,6 +400,109 @@ func (s *feedStateManagerSuite) TestHandleError(c *check.C) {
 	c.Assert(state.Info.State, check.Equals, model.StateError)
 	c.Assert(state.Info.AdminJobType, check.Equals, model.AdminStop)
 	c.Assert(state.Status.AdminJobType, check.Equals, model.AdminStop)
+	records := state.Info.ErrorRecords
+	c.Assert(records, check.HasLen, model.ErrorHistoryThreshold+1)
+	c.Assert(records[0].Action, check.Equals, model.ErrorActionRestart)
+	c.Assert(records[len(records)-1].Action, check.Equals, model.ErrorActionBackoff)
+
+	// the changefeed keeps in the error state during the backoff
+	c.Assert(manager.backoffUntil.After(time.Now()), check.IsTrue)
+	c.Assert(manager.backoffInterval, check.Equals, 2*errorBackoffInitInterval)
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsFalse)
+	c.Assert(state.Info.State, check.Equals, model.StateError)
+
+	// the changefeed is restarted when the backoff is over
+	manager.backoffUntil = time.Now().Add(-time.Second)
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsTrue)
+	c.Assert(state.Info.State, check.Equals, model.StateNormal)
+	c.Assert(state.Info.ErrorHis, check.HasLen, 0)
+	c.Assert(state.Info.ErrorRecords, check.HasLen, model.ErrorHistoryThreshold+1)
+}
+
+func (s *feedStateManagerSuite) TestHandleErrorByClass(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := cdcContext.NewBackendContext4Test(true)
+	newState := func() (*orchestrator.ChangefeedReactorState, *orchestrator.ReactorStateTester) {
+		state := orchestrator.NewChangefeedReactorState(ctx.ChangefeedVars().ID)
+		tester := orchestrator.NewReactorStateTester(c, state, nil)
+		state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+			return &model.ChangeFeedInfo{SinkURI: "123", Config: &config.ReplicaConfig{}}, true, nil
+		})
+		state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+			return &model.ChangeFeedStatus{}, true, nil
+		})
+		tester.MustApplyPatches()
+		return state, tester
+	}
+	reportError := func(state *orchestrator.ChangefeedReactorState, tester *orchestrator.ReactorStateTester, err *model.RunningError) {
+		state.PatchTaskPosition(ctx.GlobalVars().CaptureInfo.ID, func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
+			return &model.TaskPosition{Error: err}, true, nil
+		})
+		tester.MustApplyPatches()
+	}
+
+	// the changefeed is paused by a downstream schema error
+	manager := new(feedStateManager)
+	state, tester := newState()
+	reportError(state, tester, &model.RunningError{
+		Code:    "CDC:ErrMySQLTxnError",
+		Message: "Error 1146: Table 'test.t' doesn't exist",
+		Class:   "downstream-schema",
+	})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsFalse)
+	c.Assert(state.Info.State, check.Equals, model.StateStopped)
+	c.Assert(state.Info.Error.Code, check.Equals, "CDC:ErrMySQLTxnError")
+	c.Assert(state.Info.ErrorRecords, check.HasLen, 1)
+	c.Assert(state.Info.ErrorRecords[0].Action, check.Equals, model.ErrorActionPause)
+
+	// the error is cleared after resuming, but the record is kept
+	manager.PushAdminJob(&model.AdminJob{CfID: ctx.ChangefeedVars().ID, Type: model.AdminResume})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsTrue)
+	c.Assert(state.Info.State, check.Equals, model.StateNormal)
+	c.Assert(state.Info.Error, check.IsNil)
+	c.Assert(state.Info.ErrorRecords, check.HasLen, 1)
+
+	// the config error is classified by the code if the class is missing
+	manager = new(feedStateManager)
+	state, tester = newState()
+	reportError(state, tester, &model.RunningError{Code: "CDC:ErrSinkURIInvalid", Message: "invalid sink uri"})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(state.Info.State, check.Equals, model.StateStopped)
+
+	// the changefeed is failed by a fatal error
+	manager = new(feedStateManager)
+	state, tester = newState()
+	reportError(state, tester, &model.RunningError{Code: "CDC:ErrGCTTLExceeded", Message: "gc ttl exceeded"})
+	manager.Tick(state)
+	tester.MustApplyPatches()
+	c.Assert(manager.ShouldRunning(), check.IsFalse)
+	c.Assert(state.Info.State, check.Equals, model.StateFailed)
+	c.Assert(state.Info.ErrorRecords[0].Action, check.Equals, model.ErrorActionFail)
+}
+
+func (s *feedStateManagerSuite) TestErrorBackoff(c *check.C) {
+	defer testleak.AfterTest(c)()
+	now := time.Now()
+	manager := &feedStateManager{lastErrorTime: now}
+	c.Assert(manager.nextBackoff(now), check.Equals, errorBackoffInitInterval)
+	c.Assert(manager.nextBackoff(now), check.Equals, 2*errorBackoffInitInterval)
+	for i := 0; i < 10; i++ {
+		manager.nextBackoff(now)
+	}
+	c.Assert(manager.nextBackoff(now), check.Equals, errorBackoffMaxInterval)
+
+	// the backoff is reset if the changefeed runs without errors for a while
+	manager.lastErrorTime = now.Add(-errorBackoffResetInterval - time.Second)
+	c.Assert(manager.nextBackoff(now), check.Equals, errorBackoffInitInterval)
 }
 
 func (s *feedStateManagerSuite) TestChangefeedStatusNotExist(c *check.C) {
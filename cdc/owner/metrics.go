@@ -51,6 +51,48 @@ var (
 			Name:      "status",
 			Help:      "The status of changefeeds",
 		}, []string{"changefeed"})
+	changefeedSLOViolatedGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "slo_violated",
+			Help:      "Whether the replication lag SLO of changefeeds is violated, 1 for violated",
+		}, []string{"changefeed"})
+	changefeedSLOViolationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "slo_violation_count",
+			Help:      "The counter of replication lag SLO violations of changefeeds",
+		}, []string{"changefeed"})
+	changefeedChecksumMismatchedTablesGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "checksum_mismatched_tables",
+			Help:      "The number of the tables whose checksums mismatch in the last consistency check of changefeeds",
+		}, []string{"changefeed"})
+	changefeedChecksumCheckedTsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "checksum_checked_ts",
+			Help:      "The syncpoint ts of the last finished consistency check of changefeeds",
+		}, []string{"changefeed"})
+	changefeedChecksumCheckCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "checksum_check_count",
+			Help:      "The counter of consistency checks of changefeeds by result",
+		}, []string{"changefeed", "result"})
+	changefeedErrorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "owner",
+			Name:      "changefeed_error_count",
+			Help:      "The counter of errors of changefeeds by class",
+		}, []string{"changefeed", "class"})
 )
 
 const (
@@ -67,4 +109,10 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(ownershipCounter)
 	registry.MustRegister(ownerMaintainTableNumGauge)
 	registry.MustRegister(changefeedStatusGauge)
+	registry.MustRegister(changefeedSLOViolatedGauge)
+	registry.MustRegister(changefeedSLOViolationCounter)
+	registry.MustRegister(changefeedChecksumMismatchedTablesGauge)
+	registry.MustRegister(changefeedChecksumCheckedTsGauge)
+	registry.MustRegister(changefeedChecksumCheckCounter)
+	registry.MustRegister(changefeedErrorCounter)
 }
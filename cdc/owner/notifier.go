@@ -0,0 +1,226 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/retry"
+	"go.uber.org/zap"
+)
+
+const (
+	// notificationTimeout is the timeout of sending a notification to a
+	// target, including the retries.
+	notificationTimeout        = 30 * time.Second
+	notificationRetryBaseDelay = 500
+	notificationRetryMaxDelay  = 5000
+)
+
+// changefeedNotification is the payload of a notification, it is also the
+// data of the payload templates.
+type changefeedNotification struct {
+	Event        string             `json:"event"`
+	Time         model.JSONTime     `json:"time"`
+	ID           model.ChangeFeedID `json:"changefeed_id"`
+	State        model.FeedState    `json:"state"`
+	CheckpointTs model.Ts           `json:"checkpoint_ts"`
+	// CheckpointLag is the checkpoint lag in seconds
+	CheckpointLag float64             `json:"checkpoint_lag"`
+	Error         *model.RunningError `json:"error,omitempty"`
+}
+
+// notifier observes the state and the checkpoint lag of a changefeed, and
+// sends the notifications to the targets when the state transitions or the
+// lag crosses the threshold. The notifications are sent asynchronously, so
+// they may arrive out of order if they are retried.
+type notifier struct {
+	id     model.ChangeFeedID
+	config *config.NotificationConfig
+
+	// state is empty before the changefeed is observed for the first time,
+	// the state of an existing changefeed is not notified when the owner
+	// starts.
+	state         model.FeedState
+	checkpointTs  model.Ts
+	checkpointLag time.Duration
+	lagging       bool
+
+	send func(target *config.NotificationTarget, maxRetries int, n *changefeedNotification)
+}
+
+func newNotifier(id model.ChangeFeedID) *notifier {
+	n := &notifier{id: id}
+	n.send = n.sendAsync
+	return n
+}
+
+// observeState notifies the state transition of the changefeed.
+func (n *notifier) observeState(info *model.ChangeFeedInfo, status *model.ChangeFeedStatus, now time.Time) {
+	if info == nil {
+		return
+	}
+	n.config = info.Config.Notification
+	n.checkpointTs = info.GetCheckpointTs(status)
+	prev := n.state
+	n.state = info.State
+	if prev == "" || prev == info.State {
+		return
+	}
+	var event string
+	switch info.State {
+	case model.StateError, model.StateFailed:
+		event = config.NotificationEventError
+	case model.StateStopped:
+		event = config.NotificationEventPaused
+	case model.StateNormal:
+		event = config.NotificationEventResumed
+	case model.StateFinished:
+		event = config.NotificationEventFinished
+	default:
+		return
+	}
+	n.notify(event, info.Error, now)
+}
+
+// observeLag notifies that the checkpoint lag of the changefeed crosses the
+// lag threshold.
+func (n *notifier) observeLag(lag time.Duration, now time.Time) {
+	n.checkpointLag = lag
+	if n.config == nil || n.config.LagThreshold == 0 {
+		n.lagging = false
+		return
+	}
+	lagging := lag > time.Duration(n.config.LagThreshold)*time.Second
+	switch {
+	case lagging && !n.lagging:
+		n.notify(config.NotificationEventLagExceeded, nil, now)
+	case !lagging && n.lagging:
+		n.notify(config.NotificationEventLagRecovered, nil, now)
+	}
+	n.lagging = lagging
+}
+
+func (n *notifier) notify(event string, runningErr *model.RunningError, now time.Time) {
+	if !n.config.IsEventEnabled(event) {
+		return
+	}
+	log.Info("notify the changefeed event",
+		zap.String("changefeed", n.id), zap.String("event", event), zap.String("state", string(n.state)))
+	notification := &changefeedNotification{
+		Event:         event,
+		Time:          model.JSONTime(now),
+		ID:            n.id,
+		State:         n.state,
+		CheckpointTs:  n.checkpointTs,
+		CheckpointLag: n.checkpointLag.Seconds(),
+		Error:         runningErr,
+	}
+	for _, target := range n.config.Targets {
+		n.send(target, n.config.GetMaxRetries(), notification)
+	}
+}
+
+// sendAsync renders the payload and sends it to the target in background
+// with retries.
+func (n *notifier) sendAsync(target *config.NotificationTarget, maxRetries int, notification *changefeedNotification) {
+	payload, err := renderNotification(target, notification)
+	if err != nil {
+		log.Warn("render the changefeed notification failed",
+			zap.String("changefeed", n.id), zap.String("uri", target.URI), zap.Error(err))
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+		defer cancel()
+		err := retry.Do(ctx, func() error {
+			return sendNotification(ctx, target.URI, n.id, payload)
+		}, retry.WithBackoffBaseDelay(notificationRetryBaseDelay),
+			retry.WithBackoffMaxDelay(notificationRetryMaxDelay),
+			retry.WithMaxTries(int64(maxRetries)+1))
+		if err != nil {
+			log.Warn("send the changefeed notification failed",
+				zap.String("changefeed", n.id), zap.String("uri", target.URI),
+				zap.String("event", notification.Event), zap.Error(err))
+		}
+	}()
+}
+
+// renderNotification renders the payload by the template of the target, the
+// notification is encoded in JSON if the target has no template.
+func renderNotification(target *config.NotificationTarget, notification *changefeedNotification) ([]byte, error) {
+	tmpl, err := target.ParseTemplate()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tmpl == nil {
+		data, err := json.Marshal(notification)
+		return data, errors.Trace(err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, notification); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// sendNotification sends the payload to a webhook by a POST request or to a
+// Kafka topic with the changefeed ID as the key.
+func sendNotification(ctx context.Context, uri string, id model.ChangeFeedID, payload []byte) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if strings.ToLower(u.Scheme) == "kafka" {
+		cfg := sarama.NewConfig()
+		cfg.Producer.Return.Successes = true
+		cfg.Producer.Retry.Max = 0
+		producer, err := sarama.NewSyncProducer(strings.Split(u.Host, ","), cfg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer producer.Close()
+		_, _, err = producer.SendMessage(&sarama.ProducerMessage{
+			Topic: strings.Trim(u.Path, "/"),
+			Key:   sarama.StringEncoder(id),
+			Value: sarama.ByteEncoder(payload),
+		})
+		return errors.Trace(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("the webhook responded with the status %d", resp.StatusCode)
+	}
+	return nil
+}
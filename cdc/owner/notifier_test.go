@@ -0,0 +1,160 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+var _ = check.Suite(&notifierSuite{})
+
+type notifierSuite struct{}
+
+func (s *notifierSuite) TestObserveState(c *check.C) {
+	defer testleak.AfterTest(c)()
+	n := newNotifier("test-changefeed")
+	var notifications []*changefeedNotification
+	n.send = func(target *config.NotificationTarget, maxRetries int, notification *changefeedNotification) {
+		c.Assert(target.URI, check.Equals, "http://127.0.0.1:8080/notify")
+		c.Assert(maxRetries, check.Equals, 3)
+		notifications = append(notifications, notification)
+	}
+	info := &model.ChangeFeedInfo{
+		State:  model.StateNormal,
+		Config: config.GetDefaultReplicaConfig(),
+	}
+	info.Config.Notification = &config.NotificationConfig{
+		Events:  []string{config.NotificationEventError, config.NotificationEventPaused, config.NotificationEventResumed},
+		Targets: []*config.NotificationTarget{{URI: "http://127.0.0.1:8080/notify"}},
+	}
+	status := &model.ChangeFeedStatus{CheckpointTs: 200}
+	now := time.Now()
+
+	// the initial state is not notified
+	n.observeState(info, status, now)
+	c.Assert(notifications, check.HasLen, 0)
+	n.observeState(info, status, now)
+	c.Assert(notifications, check.HasLen, 0)
+
+	info.State = model.StateError
+	info.Error = &model.RunningError{Code: "CDC:ErrSinkURIInvalid", Message: "sink failed"}
+	n.observeState(info, status, now)
+	c.Assert(notifications, check.HasLen, 1)
+	c.Assert(notifications[0].Event, check.Equals, config.NotificationEventError)
+	c.Assert(notifications[0].State, check.Equals, model.StateError)
+	c.Assert(notifications[0].CheckpointTs, check.Equals, model.Ts(200))
+	c.Assert(notifications[0].Error.Message, check.Equals, "sink failed")
+
+	info.State = model.StateStopped
+	info.Error = nil
+	n.observeState(info, status, now)
+	info.State = model.StateNormal
+	n.observeState(info, status, now)
+	c.Assert(notifications, check.HasLen, 3)
+	c.Assert(notifications[1].Event, check.Equals, config.NotificationEventPaused)
+	c.Assert(notifications[2].Event, check.Equals, config.NotificationEventResumed)
+
+	// the finished event is not enabled
+	info.State = model.StateFinished
+	n.observeState(info, status, now)
+	c.Assert(notifications, check.HasLen, 3)
+
+	// nothing is notified without targets
+	info.Config.Notification = nil
+	info.State = model.StateNormal
+	n.observeState(info, status, now)
+	c.Assert(notifications, check.HasLen, 3)
+}
+
+func (s *notifierSuite) TestObserveLag(c *check.C) {
+	defer testleak.AfterTest(c)()
+	n := newNotifier("test-changefeed")
+	var notifications []*changefeedNotification
+	n.send = func(_ *config.NotificationTarget, _ int, notification *changefeedNotification) {
+		notifications = append(notifications, notification)
+	}
+	info := &model.ChangeFeedInfo{State: model.StateNormal, Config: config.GetDefaultReplicaConfig()}
+	info.Config.Notification = &config.NotificationConfig{
+		Targets: []*config.NotificationTarget{{URI: "http://127.0.0.1:8080/notify"}},
+	}
+	n.observeState(info, nil, time.Now())
+
+	// the lag events are disabled without the threshold
+	n.observeLag(time.Hour, time.Now())
+	c.Assert(notifications, check.HasLen, 0)
+
+	info.Config.Notification.LagThreshold = 30
+	n.observeState(info, nil, time.Now())
+	n.observeLag(10*time.Second, time.Now())
+	c.Assert(notifications, check.HasLen, 0)
+	n.observeLag(40*time.Second, time.Now())
+	n.observeLag(50*time.Second, time.Now())
+	c.Assert(notifications, check.HasLen, 1)
+	c.Assert(notifications[0].Event, check.Equals, config.NotificationEventLagExceeded)
+	c.Assert(notifications[0].CheckpointLag, check.Equals, float64(40))
+	n.observeLag(20*time.Second, time.Now())
+	c.Assert(notifications, check.HasLen, 2)
+	c.Assert(notifications[1].Event, check.Equals, config.NotificationEventLagRecovered)
+}
+
+func (s *notifierSuite) TestWebhook(c *check.C) {
+	defer testleak.AfterTest(c)()
+	var requests int32
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first request fails, and the notification is retried
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		c.Assert(err, check.IsNil)
+		received <- string(body)
+	}))
+	defer server.Close()
+
+	n := newNotifier("test-changefeed")
+	n.config = &config.NotificationConfig{
+		Targets: []*config.NotificationTarget{{
+			URI:      server.URL,
+			Template: `{"text": {{json (printf "changefeed %s is %s" .ID .Event)}}}`,
+		}},
+	}
+	n.state = model.StateStopped
+	n.notify(config.NotificationEventPaused, nil, time.Now())
+	select {
+	case body := <-received:
+		c.Assert(body, check.Equals, `{"text": "changefeed test-changefeed is paused"}`)
+	case <-time.After(10 * time.Second):
+		c.Fatal("webhook is not notified")
+	}
+	c.Assert(atomic.LoadInt32(&requests), check.Equals, int32(2))
+
+	payload, err := renderNotification(&config.NotificationTarget{URI: server.URL}, &changefeedNotification{
+		Event: config.NotificationEventFinished,
+		ID:    "test-changefeed",
+		State: model.StateFinished,
+	})
+	c.Assert(err, check.IsNil)
+	c.Assert(string(payload), check.Matches, `\{"event":"finished","time":".*","changefeed_id":"test-changefeed","state":"finished",.*\}`)
+}
@@ -0,0 +1,127 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/entry"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+// offloadGCInterval is the min interval between two GCs of the offloaded values.
+const offloadGCInterval = time.Minute
+
+// offloadGC deletes the values offloaded by a changefeed in the background,
+// once the checkpoint of the changefeed has passed them longer than the gc ttl.
+type offloadGC struct {
+	id          model.ChangeFeedID
+	config      *config.OffloadConfig
+	openStorage func(ctx context.Context, uri string, changefeedID model.ChangeFeedID) (storage.ExternalStorage, error)
+
+	tsCh        chan model.Ts
+	lastTrigger time.Time
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+func newOffloadGC(id model.ChangeFeedID, cfg *config.OffloadConfig) *offloadGC {
+	return &offloadGC{
+		id:          id,
+		config:      cfg,
+		openStorage: entry.OpenOffloadStorage,
+		tsCh:        make(chan model.Ts, 1),
+		cancel:      func() {},
+	}
+}
+
+// run starts the background goroutine deleting the offloaded values.
+func (g *offloadGC) run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	g.cancel = cancel
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		var s storage.ExternalStorage
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case checkpointTs := <-g.tsCh:
+				var err error
+				if s == nil {
+					if s, err = g.openStorage(ctx, g.config.Storage, g.id); err != nil {
+						log.Warn("open the offload storage failed", zap.String("changefeed", g.id), zap.Error(err))
+						continue
+					}
+				}
+				ttl := time.Duration(g.config.GetGCTTL()) * time.Second
+				beforeTs := oracle.GoTimeToTS(oracle.GetTimeFromTS(checkpointTs).Add(-ttl))
+				deleted, err := entry.GCOffloadedObjects(ctx, s, beforeTs)
+				if err != nil {
+					log.Warn("delete the offloaded values failed", zap.String("changefeed", g.id), zap.Error(err))
+				}
+				if deleted > 0 {
+					log.Info("delete the offloaded values", zap.String("changefeed", g.id),
+						zap.Uint64("beforeTs", beforeTs), zap.Int("count", deleted))
+				}
+			}
+		}
+	}()
+}
+
+// trigger sends the checkpoint of the changefeed to delete the values passed
+// by it, it is skipped if the last GC is triggered within offloadGCInterval
+// or is not finished.
+func (g *offloadGC) trigger(checkpointTs model.Ts) {
+	if g == nil || time.Since(g.lastTrigger) < offloadGCInterval {
+		return
+	}
+	select {
+	case g.tsCh <- checkpointTs:
+		g.lastTrigger = time.Now()
+	default:
+	}
+}
+
+// close stops deleting the offloaded values.
+func (g *offloadGC) close() {
+	if g == nil {
+		return
+	}
+	g.cancel()
+	g.wg.Wait()
+}
+
+// cleanup deletes all the values offloaded by the removed changefeed.
+func (g *offloadGC) cleanup(ctx context.Context) error {
+	if g == nil {
+		return nil
+	}
+	s, err := g.openStorage(ctx, g.config.Storage, g.id)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrOffloadStorage, err)
+	}
+	_, err = entry.GCOffloadedObjects(ctx, s, math.MaxUint64)
+	return err
+}
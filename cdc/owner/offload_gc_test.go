@@ -0,0 +1,78 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+var _ = check.Suite(&offloadGCSuite{})
+
+type offloadGCSuite struct{}
+
+func (s *offloadGCSuite) TestOffloadGC(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := c.MkDir()
+	now := time.Now()
+	oldName := fmt.Sprintf("%d.test.t.a.1", oracle.GoTimeToTS(now.Add(-2*time.Hour)))
+	newName := fmt.Sprintf("%d.test.t.a.1", oracle.GoTimeToTS(now.Add(-time.Minute)))
+	// the objects of the changefeed are under its prefix, the objects of
+	// another changefeed sharing the storage are never deleted.
+	for _, name := range []string{"test/" + oldName, "test/" + newName, "other/" + oldName} {
+		c.Assert(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755), check.IsNil)
+		c.Assert(os.WriteFile(filepath.Join(dir, name), []byte("v"), 0o644), check.IsNil)
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+	oldName, newName = "test/"+oldName, "test/"+newName
+
+	var nilGC *offloadGC
+	nilGC.trigger(oracle.GoTimeToTS(now))
+	nilGC.close()
+	c.Assert(nilGC.cleanup(ctx), check.IsNil)
+
+	gc := newOffloadGC("test", &config.OffloadConfig{Threshold: 1, Storage: "local://" + dir, GCTTL: 3600})
+	gc.run(ctx)
+	gc.trigger(oracle.GoTimeToTS(now))
+	for i := 0; exists(oldName) && i < 100; i++ {
+		time.Sleep(50 * time.Millisecond)
+	}
+	c.Assert(exists(oldName), check.IsFalse)
+	c.Assert(exists(newName), check.IsTrue)
+
+	// the GC is not triggered again within the interval
+	lastTrigger := gc.lastTrigger
+	gc.trigger(oracle.GoTimeToTS(now.Add(2 * time.Hour)))
+	c.Assert(gc.lastTrigger, check.Equals, lastTrigger)
+	gc.close()
+
+	c.Assert(gc.cleanup(ctx), check.IsNil)
+	c.Assert(exists(newName), check.IsFalse)
+	c.Assert(exists("other/"+strings.TrimPrefix(oldName, "test/")), check.IsTrue)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/upstream"
 	cdcContext "github.com/pingcap/ticdc/pkg/context"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/orchestrator"
@@ -44,16 +45,24 @@ const (
 	ownerJobTypeAdminJob
 	ownerJobTypeDebugInfo
 	ownerJobTypeQuery
+	ownerJobTypeUpdateTables
+	ownerJobTypePauseTables
+	ownerJobTypeRewindTable
+	ownerJobTypeDrainCapture
+	ownerJobTypeUpdateChangefeed
+	ownerJobTypeOperateDDLBarrier
 )
 
 type ownerJob struct {
 	tp           ownerJobType
 	changefeedID model.ChangeFeedID
 
-	// for ManualSchedule only
+	// for ManualSchedule and DrainCapture only
 	targetCaptureID model.CaptureID
-	// for ManualSchedule only
+	// for ManualSchedule and RewindTable only
 	tableID model.TableID
+	// for RewindTable only
+	rewindTs model.Ts
 
 	// for Admin Job only
 	adminJob *model.AdminJob
@@ -61,9 +70,28 @@ type ownerJob struct {
 	// for debug info only
 	debugInfoWriter io.Writer
 
+	// for UpdateTables only
+	updateTablesConfig *model.UpdateTablesConfig
+	// for UpdateTables only
+	removeTables bool
+
+	// for PauseTables only
+	pauseTablesConfig *model.PauseTablesConfig
+	// for PauseTables only
+	resumeTables bool
+
+	// for UpdateChangefeed only
+	changefeedInfo *model.ChangeFeedInfo
+
+	// for OperateDDLBarrier only
+	ddlCommitTs model.Ts
+	skipDDL     bool
+
 	// for status provider
 	query *ownerQuery
 
+	// err is the error of handling the job, only for UpdateTables
+	err  error
 	done chan struct{}
 }
 
@@ -72,8 +100,18 @@ type ownerJob struct {
 type Owner struct {
 	changefeeds map[model.ChangeFeedID]*changefeed
 	captures    map[model.CaptureID]*model.CaptureInfo
+	// drainingCaptures are the captures whose tables are being moved to other captures,
+	// the draining flags are persisted in etcd to survive the owner switching
+	drainingCaptures map[model.CaptureID]struct{}
 
-	gcManager gc.Manager
+	// gcManager is the GC manager of the default upstream, and gcManagers are
+	// the GC managers of the other upstreams, keyed by the upstream ID.
+	gcManager  gc.Manager
+	gcManagers map[string]gc.Manager
+
+	upstreamManager *upstream.Manager
+	// upstreams are the upstreams held by the changefeeds
+	upstreams map[model.ChangeFeedID]*upstream.Upstream
 
 	ownerJobQueueMu sync.Mutex
 	ownerJobQueue   []*ownerJob
@@ -86,12 +124,16 @@ type Owner struct {
 }
 
 // NewOwner creates a new Owner
-func NewOwner(pdClient pd.Client) *Owner {
+func NewOwner(upstreamManager *upstream.Manager) *Owner {
 	return &Owner{
-		changefeeds:   make(map[model.ChangeFeedID]*changefeed),
-		gcManager:     gc.NewManager(pdClient),
-		lastTickTime:  time.Now(),
-		newChangefeed: newChangefeed,
+		changefeeds:      make(map[model.ChangeFeedID]*changefeed),
+		drainingCaptures: make(map[model.CaptureID]struct{}),
+		gcManager:        gc.NewManager(upstreamManager.Default().PDClient),
+		gcManagers:       make(map[string]gc.Manager),
+		upstreamManager:  upstreamManager,
+		upstreams:        make(map[model.ChangeFeedID]*upstream.Upstream),
+		lastTickTime:     time.Now(),
+		newChangefeed:    newChangefeed,
 	}
 }
 
@@ -101,7 +143,7 @@ func NewOwner4Test(
 	newSink func(ctx cdcContext.Context) (AsyncSink, error),
 	pdClient pd.Client,
 ) *Owner {
-	o := NewOwner(pdClient)
+	o := NewOwner(upstream.NewManager4Test(pdClient))
 	o.newChangefeed = func(id model.ChangeFeedID, gcManager gc.Manager) *changefeed {
 		return newChangefeed4Test(id, gcManager, newDDLPuller, newSink)
 	}
@@ -114,6 +156,12 @@ func (o *Owner) Tick(stdCtx context.Context, rawState orchestrator.ReactorState)
 		failpoint.Return(nil, errors.New("owner run with injected error"))
 	})
 	failpoint.Inject("sleep-in-owner-tick", nil)
+	defer func() {
+		if err != nil {
+			// the owner exits, release the upstreams held by the changefeeds
+			o.releaseUpstreams()
+		}
+	}()
 	ctx := stdCtx.(cdcContext.Context)
 	state := rawState.(*orchestrator.GlobalReactorState)
 	o.captures = state.Captures
@@ -133,7 +181,16 @@ func (o *Owner) Tick(stdCtx context.Context, rawState orchestrator.ReactorState)
 		return nil, errors.Trace(err)
 	}
 
-	o.handleJobs()
+	o.drainingCaptures = make(map[model.CaptureID]struct{}, len(state.DrainingCaptures))
+	for captureID := range state.DrainingCaptures {
+		if _, exist := state.Captures[captureID]; !exist {
+			// the drained capture has exited
+			state.PatchDrainingCapture(captureID, false)
+			continue
+		}
+		o.drainingCaptures[captureID] = struct{}{}
+	}
+	o.handleJobs(state)
 	for changefeedID, changefeedState := range state.Changefeeds {
 		if changefeedState.Info == nil {
 			o.cleanUpChangefeed(changefeedState)
@@ -142,15 +199,22 @@ func (o *Owner) Tick(stdCtx context.Context, rawState orchestrator.ReactorState)
 			}
 			continue
 		}
+		up := o.acquireUpstream(changefeedID, changefeedState.Info)
+		if !up.IsReady() {
+			// the changefeed starts after the clients of its upstream are created
+			continue
+		}
 		ctx = cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
-			ID:   changefeedID,
-			Info: changefeedState.Info,
+			ID:       changefeedID,
+			Info:     changefeedState.Info,
+			Upstream: up,
 		})
 		cfReactor, exist := o.changefeeds[changefeedID]
 		if !exist {
-			cfReactor = o.newChangefeed(changefeedID, o.gcManager)
+			cfReactor = o.newChangefeed(changefeedID, o.gcManagerOf(up))
 			o.changefeeds[changefeedID] = cfReactor
 		}
+		cfReactor.scheduler.drainingCaptures = o.drainingCaptures
 		cfReactor.Tick(ctx, changefeedState, state.Captures)
 	}
 	if len(o.changefeeds) != len(state.Changefeeds) {
@@ -162,6 +226,11 @@ func (o *Owner) Tick(stdCtx context.Context, rawState orchestrator.ReactorState)
 			delete(o.changefeeds, changefeedID)
 		}
 	}
+	for changefeedID := range o.upstreams {
+		if _, exist := state.Changefeeds[changefeedID]; !exist {
+			o.releaseUpstream(changefeedID)
+		}
+	}
 	if atomic.LoadInt32(&o.closed) != 0 {
 		for _, cfReactor := range o.changefeeds {
 			cfReactor.Close(stdCtx)
@@ -201,6 +270,106 @@ func (o *Owner) ManualSchedule(cfID model.ChangeFeedID, toCapture model.CaptureI
 	})
 }
 
+// AddTables adds the tables matched by the matcher to a running changefeed,
+// the added tables start replicating from the specified start ts.
+func (o *Owner) AddTables(ctx context.Context, cfID model.ChangeFeedID, cfg *model.UpdateTablesConfig) error {
+	return o.pushOwnerJobAndWait(ctx, &ownerJob{
+		tp:                 ownerJobTypeUpdateTables,
+		changefeedID:       cfID,
+		updateTablesConfig: cfg,
+		done:               make(chan struct{}),
+	})
+}
+
+// RemoveTables removes the tables matched by the matcher from a running changefeed
+func (o *Owner) RemoveTables(ctx context.Context, cfID model.ChangeFeedID, cfg *model.UpdateTablesConfig) error {
+	return o.pushOwnerJobAndWait(ctx, &ownerJob{
+		tp:                 ownerJobTypeUpdateTables,
+		changefeedID:       cfID,
+		updateTablesConfig: cfg,
+		removeTables:       true,
+		done:               make(chan struct{}),
+	})
+}
+
+// PauseTables pauses the replication of the tables of a running changefeed,
+// the GC safepoint is held at the paused ts until the tables are resumed.
+func (o *Owner) PauseTables(cfID model.ChangeFeedID, cfg *model.PauseTablesConfig) {
+	o.pushOwnerJob(&ownerJob{
+		tp:                ownerJobTypePauseTables,
+		changefeedID:      cfID,
+		pauseTablesConfig: cfg,
+		done:              make(chan struct{}),
+	})
+}
+
+// ResumeTables resumes the replication of the paused tables of a running changefeed
+func (o *Owner) ResumeTables(cfID model.ChangeFeedID, cfg *model.PauseTablesConfig) {
+	o.pushOwnerJob(&ownerJob{
+		tp:                ownerJobTypePauseTables,
+		changefeedID:      cfID,
+		pauseTablesConfig: cfg,
+		resumeTables:      true,
+		done:              make(chan struct{}),
+	})
+}
+
+// RewindTable rewinds the replication position of a table to the specified ts,
+// the events of the table after the ts are emitted again.
+func (o *Owner) RewindTable(cfID model.ChangeFeedID, tableID model.TableID, ts model.Ts) {
+	o.pushOwnerJob(&ownerJob{
+		tp:           ownerJobTypeRewindTable,
+		changefeedID: cfID,
+		tableID:      tableID,
+		rewindTs:     ts,
+		done:         make(chan struct{}),
+	})
+}
+
+// UpdateChangefeed updates the config of a changefeed to the given info, a
+// running changefeed is restarted from the checkpoint with the new config.
+func (o *Owner) UpdateChangefeed(cfID model.ChangeFeedID, info *model.ChangeFeedInfo) {
+	o.pushOwnerJob(&ownerJob{
+		tp:             ownerJobTypeUpdateChangefeed,
+		changefeedID:   cfID,
+		changefeedInfo: info,
+		done:           make(chan struct{}),
+	})
+}
+
+// SkipDDL skips the DDL which blocks the changefeed as a barrier, the DDL isn't
+// executed downstream if it isn't being executed, or it fails to be executed.
+func (o *Owner) SkipDDL(cfID model.ChangeFeedID, commitTs model.Ts) {
+	o.pushOwnerJob(&ownerJob{
+		tp:           ownerJobTypeOperateDDLBarrier,
+		changefeedID: cfID,
+		ddlCommitTs:  commitTs,
+		skipDDL:      true,
+		done:         make(chan struct{}),
+	})
+}
+
+// RetryDDL retries the DDL which blocks the changefeed as a barrier at once, if
+// the changefeed is stopped by the error of executing the DDL.
+func (o *Owner) RetryDDL(cfID model.ChangeFeedID, commitTs model.Ts) {
+	o.pushOwnerJob(&ownerJob{
+		tp:           ownerJobTypeOperateDDLBarrier,
+		changefeedID: cfID,
+		ddlCommitTs:  commitTs,
+		done:         make(chan struct{}),
+	})
+}
+
+// DrainCapture moves all the tables of the capture to other captures, and no
+// more tables are dispatched to the capture until it exits.
+func (o *Owner) DrainCapture(captureID model.CaptureID) {
+	o.pushOwnerJob(&ownerJob{
+		tp:              ownerJobTypeDrainCapture,
+		targetCaptureID: captureID,
+		done:            make(chan struct{}),
+	})
+}
+
 // WriteDebugInfo writes debug info into the specified http writer
 func (o *Owner) WriteDebugInfo(w io.Writer) {
 	timeout := time.Second * 3
@@ -282,13 +451,15 @@ func (o *Owner) clusterVersionConsistent(captures map[model.CaptureID]*model.Cap
 	return true
 }
 
-func (o *Owner) handleJobs() {
+func (o *Owner) handleJobs(state *orchestrator.GlobalReactorState) {
 	jobs := o.takeOwnerJobs()
 	for _, job := range jobs {
 		changefeedID := job.changefeedID
 		cfReactor, exist := o.changefeeds[changefeedID]
-		if !exist && job.tp != ownerJobTypeQuery {
+		if !exist && job.tp != ownerJobTypeQuery && job.tp != ownerJobTypeDrainCapture {
 			log.Warn("changefeed not found when handle a job", zap.Reflect("job", job))
+			job.err = cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedID)
+			close(job.done)
 			continue
 		}
 		switch job.tp {
@@ -298,6 +469,20 @@ func (o *Owner) handleJobs() {
 			cfReactor.scheduler.MoveTable(job.tableID, job.targetCaptureID)
 		case ownerJobTypeRebalance:
 			cfReactor.scheduler.Rebalance()
+		case ownerJobTypeUpdateTables:
+			job.err = cfReactor.updateTables(job.updateTablesConfig, job.removeTables)
+		case ownerJobTypePauseTables:
+			cfReactor.pauseTables(job.pauseTablesConfig.TableIDs, job.resumeTables)
+		case ownerJobTypeRewindTable:
+			cfReactor.scheduler.RewindTable(job.tableID, job.rewindTs)
+		case ownerJobTypeUpdateChangefeed:
+			cfReactor.feedStateManager.UpdateInfo(job.changefeedInfo)
+		case ownerJobTypeOperateDDLBarrier:
+			cfReactor.operateDDLBarrier(job.ddlCommitTs, job.skipDDL)
+		case ownerJobTypeDrainCapture:
+			log.Info("start draining capture", zap.String("capture", job.targetCaptureID))
+			state.PatchDrainingCapture(job.targetCaptureID, true)
+			o.drainingCaptures[job.targetCaptureID] = struct{}{}
 		case ownerJobTypeQuery:
 			o.handleQueries(job.query)
 		case ownerJobTypeDebugInfo:
@@ -396,6 +581,61 @@ func (o *Owner) handleQueries(query *ownerQuery) {
 			})
 		}
 		query.data = ret
+	case ownerQueryChangeFeedSLOStatus:
+		cfReactor, ok := o.changefeeds[query.changeFeedID]
+		if !ok {
+			query.err = cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.changeFeedID)
+			return
+		}
+		if cfReactor.slo == nil {
+			// the SLO of the changefeed is not enabled
+			query.data = &model.ChangefeedSLOStatus{ID: query.changeFeedID}
+			return
+		}
+		query.data = cfReactor.slo.getStatus()
+	case ownerQueryChangeFeedChecksumStatus:
+		cfReactor, ok := o.changefeeds[query.changeFeedID]
+		if !ok {
+			query.err = cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.changeFeedID)
+			return
+		}
+		if cfReactor.checksum == nil {
+			// the consistency check of the changefeed is not enabled
+			query.data = &model.ChangefeedChecksumStatus{ID: query.changeFeedID}
+			return
+		}
+		query.data = cfReactor.checksum.getStatus()
+	case ownerQueryChangeFeedDDLBarrier:
+		cfReactor, ok := o.changefeeds[query.changeFeedID]
+		if !ok {
+			query.err = cerror.ErrChangeFeedNotExists.GenWithStackByArgs(query.changeFeedID)
+			return
+		}
+		query.data = cfReactor.ddlBarrierStatus()
+	case ownerQueryDrainCaptureStatus:
+		if _, exist := o.captures[query.captureID]; !exist {
+			query.err = cerror.ErrCaptureNotExist.GenWithStackByArgs(query.captureID)
+			return
+		}
+		_, draining := o.drainingCaptures[query.captureID]
+		ret := &model.DrainCaptureStatus{CaptureID: query.captureID, Draining: draining}
+		for _, cfReactor := range o.changefeeds {
+			if cfReactor.state == nil {
+				continue
+			}
+			taskStatus, exist := cfReactor.state.TaskStatuses[query.captureID]
+			if !exist {
+				continue
+			}
+			ret.TableCount += len(taskStatus.Tables)
+			for tableID, operation := range taskStatus.Operation {
+				if _, exist := taskStatus.Tables[tableID]; !exist && operation.Delete {
+					// the table is being removed from the capture
+					ret.TableCount++
+				}
+			}
+		}
+		query.data = ret
 	}
 }
 
@@ -414,11 +654,30 @@ func (o *Owner) pushOwnerJob(job *ownerJob) {
 	o.ownerJobQueue = append(o.ownerJobQueue, job)
 }
 
+// pushOwnerJobAndWait pushes the job and waits for it to be handled, and
+// returns the error of handling it.
+func (o *Owner) pushOwnerJobAndWait(ctx context.Context, job *ownerJob) error {
+	o.pushOwnerJob(job)
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-job.done:
+	}
+	return errors.Trace(job.err)
+}
+
 func (o *Owner) updateGCSafepoint(
 	ctx context.Context, state *orchestrator.GlobalReactorState,
 ) error {
-	forceUpdate := false
-	minCheckpointTs := uint64(math.MaxUint64)
+	type upstreamGCState struct {
+		gcManager       gc.Manager
+		minCheckpointTs uint64
+		forceUpdate     bool
+	}
+	// the GC safepoint of the default upstream is always updated
+	gcStates := map[string]*upstreamGCState{
+		model.DefaultUpstreamID: {gcManager: o.gcManager, minCheckpointTs: math.MaxUint64},
+	}
 	for changefeedID, changefeefState := range state.Changefeeds {
 		if changefeefState.Info == nil {
 			continue
@@ -428,22 +687,96 @@ func (o *Owner) updateGCSafepoint(
 		default:
 			continue
 		}
+		// the GC safepoint held by a quarantined changefeed is released
+		if changefeefState.Info.GCQuarantine != nil {
+			continue
+		}
+		upstreamID := changefeefState.Info.Upstream.ID()
+		gcState, ok := gcStates[upstreamID]
+		if !ok {
+			up, ok := o.upstreams[changefeedID]
+			if !ok || !up.IsReady() {
+				// the GC safepoint is updated once the upstream is ready,
+				// before the changefeed starts.
+				continue
+			}
+			gcState = &upstreamGCState{gcManager: o.gcManagerOf(up), minCheckpointTs: math.MaxUint64}
+			gcStates[upstreamID] = gcState
+		}
 		checkpointTs := changefeefState.Info.GetCheckpointTs(changefeefState.Status)
-		if minCheckpointTs > checkpointTs {
-			minCheckpointTs = checkpointTs
+		// the paused tables are replicated from their paused ts once resumed
+		if pausedTs := changefeefState.Info.GetMinPausedTs(); checkpointTs > pausedTs {
+			checkpointTs = pausedTs
+		}
+		if gcState.minCheckpointTs > checkpointTs {
+			gcState.minCheckpointTs = checkpointTs
 		}
 		// Force update when adding a new changefeed.
 		_, exist := o.changefeeds[changefeedID]
 		if !exist {
-			forceUpdate = true
+			gcState.forceUpdate = true
+		}
+	}
+	for upstreamID := range o.gcManagers {
+		if _, ok := gcStates[upstreamID]; !ok {
+			delete(o.gcManagers, upstreamID)
+		}
+	}
+	for upstreamID, gcState := range gcStates {
+		// When the changefeed starts up, CDC will do a snapshot read at
+		// (checkpointTs - 1) from TiKV, so (checkpointTs - 1) should be an upper
+		// bound for the GC safepoint.
+		gcSafepointUpperBound := gcState.minCheckpointTs - 1
+		err := gcState.gcManager.TryUpdateGCSafePoint(ctx, gcSafepointUpperBound, gcState.forceUpdate)
+		if err == nil {
+			continue
 		}
+		if upstreamID == model.DefaultUpstreamID {
+			return errors.Trace(err)
+		}
+		// The owner keeps running for the other upstreams, and the changefeeds
+		// of the upstream fail once their snapshots are lost.
+		log.Warn("failed to update the GC safepoint of the upstream",
+			zap.String("upstream", upstreamID), zap.Error(err))
+	}
+	return nil
+}
+
+// gcManagerOf returns the GC manager of the upstream, the upstream must be ready.
+func (o *Owner) gcManagerOf(up *upstream.Upstream) gc.Manager {
+	if up.ID == model.DefaultUpstreamID {
+		return o.gcManager
+	}
+	gcManager, ok := o.gcManagers[up.ID]
+	if !ok {
+		gcManager = gc.NewManager(up.PDClient)
+		o.gcManagers[up.ID] = gcManager
+	}
+	return gcManager
+}
+
+// acquireUpstream returns the upstream held by the changefeed, and acquires it
+// if the changefeed doesn't hold one.
+func (o *Owner) acquireUpstream(changefeedID model.ChangeFeedID, info *model.ChangeFeedInfo) *upstream.Upstream {
+	if up, ok := o.upstreams[changefeedID]; ok {
+		return up
+	}
+	up := o.upstreamManager.Acquire(info.Upstream)
+	o.upstreams[changefeedID] = up
+	return up
+}
+
+func (o *Owner) releaseUpstream(changefeedID model.ChangeFeedID) {
+	if up, ok := o.upstreams[changefeedID]; ok {
+		o.upstreamManager.Release(up)
+		delete(o.upstreams, changefeedID)
+	}
+}
+
+func (o *Owner) releaseUpstreams() {
+	for changefeedID := range o.upstreams {
+		o.releaseUpstream(changefeedID)
 	}
-	// When the changefeed starts up, CDC will do a snapshot read at
-	// (checkpointTs - 1) from TiKV, so (checkpointTs - 1) should be an upper
-	// bound for the GC safepoint.
-	gcSafepointUpperBound := minCheckpointTs - 1
-	err := o.gcManager.TryUpdateGCSafePoint(ctx, gcSafepointUpperBound, forceUpdate)
-	return errors.Trace(err)
 }
 
 // StatusProvider returns a StatusProvider
@@ -18,10 +18,12 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/pingcap/check"
 	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/upstream"
 	"github.com/pingcap/ticdc/pkg/config"
 	cdcContext "github.com/pingcap/ticdc/pkg/context"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
@@ -41,7 +43,7 @@ type mockManager struct {
 }
 
 func (m *mockManager) CheckStaleCheckpointTs(
-	ctx context.Context, changefeedID model.ChangeFeedID, checkpointTs model.Ts,
+	ctx context.Context, changefeedID model.ChangeFeedID, checkpointTs model.Ts, gcTTL int64,
 ) error {
 	return cerror.ErrGCTTLExceeded.GenWithStackByArgs()
 }
@@ -126,7 +128,7 @@ func (s *ownerSuite) TestCreateRemoveChangefeed(c *check.C) {
 	// this will make changefeed always meet ErrGCTTLExceeded
 	mockedManager := &mockManager{Manager: owner.gcManager}
 	owner.gcManager = mockedManager
-	err = owner.gcManager.CheckStaleCheckpointTs(ctx, changefeedID, 0)
+	err = owner.gcManager.CheckStaleCheckpointTs(ctx, changefeedID, 0, 0)
 	c.Assert(err, check.NotNil)
 
 	// this tick create remove changefeed patches
@@ -221,6 +223,37 @@ func (s *ownerSuite) TestCheckClusterVersion(c *check.C) {
 	c.Assert(owner.changefeeds, check.HasKey, changefeedID)
 }
 
+func (s *ownerSuite) TestDrainCapture(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := cdcContext.NewBackendContext4Test(false)
+	owner, state, tester := createOwner4Test(ctx, c)
+	captureID := "6bbc01c8-0605-4f86-a0f9-b3119109b225"
+	captureKey := etcd.CDCKey{Tp: etcd.CDCKeyTypeCapture, CaptureID: captureID}
+	tester.MustUpdate(captureKey.String(), []byte(`{"id":"6bbc01c8-0605-4f86-a0f9-b3119109b225","address":"127.0.0.1:8300","version":"`+ctx.GlobalVars().CaptureInfo.Version+`"}`))
+
+	owner.DrainCapture(captureID)
+	_, err := owner.Tick(ctx, state)
+	c.Assert(err, check.IsNil)
+	c.Assert(owner.drainingCaptures, check.HasKey, captureID)
+	tester.MustApplyPatches()
+	c.Assert(state.DrainingCaptures, check.HasKey, captureID)
+
+	// the draining capture is recovered by the new owner
+	newOwner := NewOwner4Test(nil, nil, ctx.GlobalVars().PDClient)
+	_, err = newOwner.Tick(ctx, state)
+	c.Assert(err, check.IsNil)
+	tester.MustApplyPatches()
+	c.Assert(newOwner.drainingCaptures, check.HasKey, captureID)
+
+	// the draining flag is removed after the capture exits
+	tester.MustUpdate(captureKey.String(), nil)
+	_, err = newOwner.Tick(ctx, state)
+	c.Assert(err, check.IsNil)
+	tester.MustApplyPatches()
+	c.Assert(newOwner.drainingCaptures, check.HasLen, 0)
+	c.Assert(state.DrainingCaptures, check.HasLen, 0)
+}
+
 func (s *ownerSuite) TestAdminJob(c *check.C) {
 	defer testleak.AfterTest(c)()
 	ctx := cdcContext.NewBackendContext4Test(false)
@@ -231,6 +264,7 @@ func (s *ownerSuite) TestAdminJob(c *check.C) {
 	})
 	owner.TriggerRebalance("test-changefeed2")
 	owner.ManualSchedule("test-changefeed3", "test-caputre1", 10)
+	owner.DrainCapture("test-capture2")
 	var buf bytes.Buffer
 	owner.WriteDebugInfo(&buf)
 
@@ -257,6 +291,9 @@ func (s *ownerSuite) TestAdminJob(c *check.C) {
 			changefeedID:    "test-changefeed3",
 			targetCaptureID: "test-caputre1",
 			tableID:         10,
+		}, {
+			tp:              ownerJobTypeDrainCapture,
+			targetCaptureID: "test-capture2",
 		}, {
 			tp:              ownerJobTypeDebugInfo,
 			debugInfoWriter: &buf,
@@ -268,7 +305,7 @@ func (s *ownerSuite) TestAdminJob(c *check.C) {
 func (s *ownerSuite) TestUpdateGCSafePoint(c *check.C) {
 	defer testleak.AfterTest(c)()
 	mockPDClient := &gc.MockPDClient{}
-	o := NewOwner(mockPDClient)
+	o := NewOwner(upstream.NewManager4Test(mockPDClient))
 	o.gcManager = gc.NewManager(mockPDClient)
 	ctx := cdcContext.NewBackendContext4Test(true)
 	state := orchestrator.NewGlobalState().(*orchestrator.GlobalReactorState)
@@ -361,4 +398,87 @@ func (s *ownerSuite) TestUpdateGCSafePoint(c *check.C) {
 		c.Fatal("timeout")
 	case <-ch:
 	}
+
+	// quarantine changefeed-test1, the GC safepoint held by it is released.
+	state.Changefeeds[changefeedID1].PatchInfo(
+		func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+			info.State = model.StateStopped
+			info.GCQuarantine = &model.GCQuarantine{CheckpointTs: 20, TTL: 60}
+			return info, true, nil
+		})
+	tester.MustApplyPatches()
+	mockPDClient.UpdateServiceGCSafePointFunc =
+		func(ctx context.Context, serviceID string, ttl int64, safePoint uint64) (uint64, error) {
+			c.Assert(safePoint, check.Equals, uint64(29))
+			ch <- struct{}{}
+			return 0, nil
+		}
+	err = o.updateGCSafepoint(ctx, state)
+	c.Assert(err, check.IsNil)
+	select {
+	case <-time.After(5 * time.Second):
+		c.Fatal("timeout")
+	case <-ch:
+	}
+}
+
+func (s *ownerSuite) TestUpdateGCSafePointOfUpstreams(c *check.C) {
+	defer testleak.AfterTest(c)()
+	mockPDClient := &gc.MockPDClient{}
+	o := NewOwner(upstream.NewManager4Test(mockPDClient))
+	defer o.releaseUpstreams()
+	ctx := cdcContext.NewBackendContext4Test(true)
+	state := orchestrator.NewGlobalState().(*orchestrator.GlobalReactorState)
+	tester := orchestrator.NewReactorStateTester(c, state, nil)
+
+	changefeedID1 := "changefeed-test1"
+	changefeedID2 := "changefeed-test2"
+	tester.MustUpdate(
+		fmt.Sprintf("/tidb/cdc/changefeed/info/%s", changefeedID1),
+		[]byte(`{"config":{"cyclic-replication":{}},"state":"normal"}`))
+	tester.MustUpdate(
+		fmt.Sprintf("/tidb/cdc/changefeed/info/%s", changefeedID2),
+		[]byte(`{"config":{"cyclic-replication":{}},"state":"normal","upstream":{"pd-addrs":["http://pd2:2379"]}}`))
+	tester.MustApplyPatches()
+	state.Changefeeds[changefeedID1].PatchStatus(
+		func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+			return &model.ChangeFeedStatus{CheckpointTs: 20}, true, nil
+		})
+	state.Changefeeds[changefeedID2].PatchStatus(
+		func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+			return &model.ChangeFeedStatus{CheckpointTs: 10}, true, nil
+		})
+	tester.MustApplyPatches()
+
+	// the upstream of changefeed-test2 is not acquired, only the GC safepoint
+	// of the default upstream is updated.
+	safePointCh := make(chan uint64, 2)
+	mockPDClient.UpdateServiceGCSafePointFunc =
+		func(ctx context.Context, serviceID string, ttl int64, safePoint uint64) (uint64, error) {
+			safePointCh <- safePoint
+			return 0, nil
+		}
+	err := o.updateGCSafepoint(ctx, state)
+	c.Assert(err, check.IsNil)
+	c.Assert(<-safePointCh, check.Equals, uint64(19))
+	c.Assert(safePointCh, check.HasLen, 0)
+
+	// the GC safepoint of each upstream is bounded by its own changefeeds.
+	up := o.acquireUpstream(changefeedID2, state.Changefeeds[changefeedID2].Info)
+	c.Assert(up.WaitReady(ctx), check.IsNil)
+	err = o.updateGCSafepoint(ctx, state)
+	c.Assert(err, check.IsNil)
+	safePoints := []uint64{<-safePointCh, <-safePointCh}
+	sort.Slice(safePoints, func(i, j int) bool { return safePoints[i] < safePoints[j] })
+	c.Assert(safePoints, check.DeepEquals, []uint64{9, 19})
+	c.Assert(o.gcManagers, check.HasLen, 1)
+
+	// the GC manager of the upstream is removed with its changefeeds.
+	o.releaseUpstream(changefeedID2)
+	tester.MustUpdate(fmt.Sprintf("/tidb/cdc/changefeed/info/%s", changefeedID2), nil)
+	tester.MustApplyPatches()
+	err = o.updateGCSafepoint(ctx, state)
+	c.Assert(err, check.IsNil)
+	c.Assert(<-safePointCh, check.Equals, uint64(19))
+	c.Assert(o.gcManagers, check.HasLen, 0)
 }
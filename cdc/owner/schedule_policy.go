@@ -0,0 +1,191 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+)
+
+// schedulePolicy decides how the tables of a changefeed are balanced among
+// captures. The scheduler dispatches every table to the capture with the
+// minimum load, and removes the victims found by the policy from their
+// captures when rebalancing, which are dispatched again in the next tick.
+type schedulePolicy interface {
+	// tableLoad returns the load of a table. The load of a table without a
+	// known workload is 1, which is also about the average load of tables.
+	tableLoad(tableID model.TableID) float64
+	// findVictims returns the tables to be removed from each capture to
+	// balance the load among the captures.
+	findVictims(captureTables map[model.CaptureID][]model.TableID) map[model.CaptureID][]model.TableID
+}
+
+// newSchedulePolicy creates the schedule policy of the given type, the
+// workloads are the latest workloads reported by the processors.
+func newSchedulePolicy(tp string, workloads map[model.CaptureID]model.TaskWorkload) schedulePolicy {
+	if tp == config.SchedulerTypeLoad {
+		return newLoadPolicy(workloads)
+	}
+	return tableNumberPolicy{}
+}
+
+// tableNumberPolicy balances the number of tables among captures.
+type tableNumberPolicy struct{}
+
+func (tableNumberPolicy) tableLoad(model.TableID) float64 {
+	return 1
+}
+
+func (tableNumberPolicy) findVictims(captureTables map[model.CaptureID][]model.TableID) map[model.CaptureID][]model.TableID {
+	totalTableNum := 0
+	for _, tables := range captureTables {
+		totalTableNum += len(tables)
+	}
+	upperLimitPerCapture := int(math.Ceil(float64(totalTableNum) / float64(len(captureTables))))
+	victims := make(map[model.CaptureID][]model.TableID)
+	for captureID, tables := range captureTables {
+		tableNum2Remove := len(tables) - upperLimitPerCapture
+		if tableNum2Remove <= 0 {
+			continue
+		}
+		victims[captureID] = tables[:tableNum2Remove]
+	}
+	return victims
+}
+
+const (
+	// loadTolerance is the ratio by which the load of a capture is allowed to
+	// exceed the average load before its tables are moved to other captures.
+	loadTolerance = 0.2
+	// minTableLoad prevents idle tables from being treated as free, so that
+	// the number of tables is still roughly balanced when there is no traffic.
+	minTableLoad = 0.1
+)
+
+// loadPolicy balances the load of tables among captures. The load of a table
+// is the average of its event rate, sorter backlog and sink flush latency, each
+// of which is normalized by the mean value of all the tables.
+type loadPolicy struct {
+	loads map[model.TableID]float64
+}
+
+func newLoadPolicy(workloads map[model.CaptureID]model.TaskWorkload) *loadPolicy {
+	var (
+		tableNum                              int
+		totalRate, totalBacklog, totalLatency float64
+		meanRate, meanBacklog, meanLatency    float64
+	)
+	for _, taskWorkload := range workloads {
+		for _, workload := range taskWorkload {
+			tableNum++
+			totalRate += workload.EventRate
+			totalBacklog += workload.SorterBacklog
+			totalLatency += workload.SinkFlushLatency
+		}
+	}
+	if tableNum != 0 {
+		meanRate = totalRate / float64(tableNum)
+		meanBacklog = totalBacklog / float64(tableNum)
+		meanLatency = totalLatency / float64(tableNum)
+	}
+	normalize := func(v, mean float64) float64 {
+		if mean == 0 {
+			return 1
+		}
+		return v / mean
+	}
+
+	loads := make(map[model.TableID]float64, tableNum)
+	for _, taskWorkload := range workloads {
+		for tableID, workload := range taskWorkload {
+			load := (normalize(workload.EventRate, meanRate) +
+				normalize(workload.SorterBacklog, meanBacklog) +
+				normalize(workload.SinkFlushLatency, meanLatency)) / 3
+			loads[tableID] = math.Max(load, minTableLoad)
+		}
+	}
+	return &loadPolicy{loads: loads}
+}
+
+func (p *loadPolicy) tableLoad(tableID model.TableID) float64 {
+	if load, exist := p.loads[tableID]; exist {
+		return load
+	}
+	return 1
+}
+
+func (p *loadPolicy) findVictims(captureTables map[model.CaptureID][]model.TableID) map[model.CaptureID][]model.TableID {
+	captureLoads := make(map[model.CaptureID]float64, len(captureTables))
+	totalLoad := 0.0
+	for captureID, tables := range captureTables {
+		for _, tableID := range tables {
+			captureLoads[captureID] += p.tableLoad(tableID)
+		}
+		totalLoad += captureLoads[captureID]
+	}
+	avgLoad := totalLoad / float64(len(captureTables))
+	upperLimit := avgLoad * (1 + loadTolerance)
+
+	captureIDs := make([]model.CaptureID, 0, len(captureTables))
+	for captureID := range captureTables {
+		captureIDs = append(captureIDs, captureID)
+	}
+	sort.Strings(captureIDs)
+	minLoadCapture := func() model.CaptureID {
+		minCapture := captureIDs[0]
+		for _, captureID := range captureIDs[1:] {
+			if captureLoads[captureID] < captureLoads[minCapture] {
+				minCapture = captureID
+			}
+		}
+		return minCapture
+	}
+
+	victims := make(map[model.CaptureID][]model.TableID)
+	for _, captureID := range captureIDs {
+		if captureLoads[captureID] <= upperLimit {
+			continue
+		}
+		// try to move the heaviest tables first to move as few tables as possible
+		tables := append([]model.TableID(nil), captureTables[captureID]...)
+		sort.Slice(tables, func(i, j int) bool {
+			li, lj := p.tableLoad(tables[i]), p.tableLoad(tables[j])
+			if li != lj {
+				return li > lj
+			}
+			return tables[i] < tables[j]
+		})
+		for _, tableID := range tables {
+			if captureLoads[captureID] <= upperLimit {
+				break
+			}
+			// the victim is expected to be dispatched to the capture with the
+			// minimum load, skip the table if the move doesn't reduce the load
+			// of the busier one of the two captures, which avoids moving tables
+			// back and forth
+			target := minLoadCapture()
+			tableLoad := p.tableLoad(tableID)
+			if captureLoads[target]+tableLoad >= captureLoads[captureID] {
+				continue
+			}
+			victims[captureID] = append(victims[captureID], tableID)
+			captureLoads[captureID] -= tableLoad
+			captureLoads[target] += tableLoad
+		}
+	}
+	return victims
+}
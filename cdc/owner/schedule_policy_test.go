@@ -0,0 +1,83 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableNumberPolicy(t *testing.T) {
+	t.Parallel()
+	policy := newSchedulePolicy(config.SchedulerTypeTableNumber, nil)
+	require.Equal(t, 1.0, policy.tableLoad(1))
+	victims := policy.findVictims(map[model.CaptureID][]model.TableID{
+		"capture-1": {1, 2, 3, 4, 5},
+		"capture-2": {6},
+		"capture-3": {},
+	})
+	require.Equal(t, map[model.CaptureID][]model.TableID{"capture-1": {1, 2, 3}}, victims)
+}
+
+func TestLoadPolicy(t *testing.T) {
+	t.Parallel()
+	workloads := map[model.CaptureID]model.TaskWorkload{
+		"capture-1": {
+			1: {Workload: 1, EventRate: 400, SorterBacklog: 4, SinkFlushLatency: 0.4},
+			2: {Workload: 1, EventRate: 100, SorterBacklog: 1, SinkFlushLatency: 0.1},
+			3: {Workload: 1, EventRate: 100, SorterBacklog: 1, SinkFlushLatency: 0.1},
+		},
+		"capture-2": {
+			4: {Workload: 1},
+			5: {Workload: 1},
+		},
+	}
+	policy := newSchedulePolicy(config.SchedulerTypeLoad, workloads)
+	require.InDelta(t, 3.3333, policy.tableLoad(1), 0.001)
+	require.InDelta(t, 0.8333, policy.tableLoad(2), 0.001)
+	require.Equal(t, minTableLoad, policy.tableLoad(4))
+	// the load of an unknown table is 1
+	require.Equal(t, 1.0, policy.tableLoad(6))
+
+	// the heavy table is moved to the new capture
+	victims := policy.findVictims(map[model.CaptureID][]model.TableID{
+		"capture-1": {1, 2, 3},
+		"capture-2": {4, 5},
+		"capture-3": {},
+	})
+	require.Equal(t, map[model.CaptureID][]model.TableID{"capture-1": {1}}, victims)
+
+	// the light tables are moved to the capture with the heavy table
+	victims = policy.findVictims(map[model.CaptureID][]model.TableID{
+		"capture-1": {2, 3},
+		"capture-2": {1, 4, 5},
+	})
+	require.Equal(t, map[model.CaptureID][]model.TableID{"capture-2": {4, 5}}, victims)
+
+	// moving the heavy table doesn't make the load more balanced
+	victims = policy.findVictims(map[model.CaptureID][]model.TableID{
+		"capture-1": {1},
+		"capture-2": {2, 3, 4, 5},
+	})
+	require.Empty(t, victims)
+
+	// all the tables are equal without any traffic
+	policy = newSchedulePolicy(config.SchedulerTypeLoad, map[model.CaptureID]model.TaskWorkload{
+		"capture-1": {1: {Workload: 1}, 2: {Workload: 1}},
+	})
+	require.Equal(t, 1.0, policy.tableLoad(1))
+}
@@ -15,11 +15,14 @@ package owner
 
 import (
 	"math"
+	"sort"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/orchestrator"
 	"go.uber.org/zap"
@@ -41,6 +44,11 @@ type schedulerJob struct {
 	TargetCapture model.CaptureID
 }
 
+// drainBatchSize is the maximum number of tables of a changefeed being moved
+// at the same time when draining captures, which bounds the replication stall
+// caused by draining.
+const drainBatchSize = 16
+
 type moveTableJob struct {
 	tableID model.TableID
 	target  model.CaptureID
@@ -51,15 +59,38 @@ type scheduler struct {
 	currentTables []model.TableID
 	captures      map[model.CaptureID]*model.CaptureInfo
 
-	moveTableTargets      map[model.TableID]model.CaptureID
-	moveTableJobQueue     []*moveTableJob
+	moveTableTargets  map[model.TableID]model.CaptureID
+	moveTableJobQueue []*moveTableJob
+	// addTableStartTs holds the start ts of the tables added by AddTables,
+	// which is used instead of the checkpoint ts when the table is dispatched.
+	addTableStartTs map[model.TableID]model.Ts
+	// rewindTableTs holds the ts to which the tables are rewound by RewindTable
+	// or resumed by ResumeTable, which is used as the start ts when the table is
+	// dispatched again.
+	rewindTableTs map[model.TableID]model.Ts
+	// knownTables are the current tables seen in the last tick, it is nil
+	// before the first tick.
+	knownTables map[model.TableID]struct{}
+	// tableAddTs holds the ts at which the tables are added to the changefeed,
+	// a table dispatched at this ts is replicated for the first time.
+	tableAddTs            map[model.TableID]model.Ts
 	needRebalanceNextTick bool
 	lastTickCaptureCount  int
+	lastRebalanceTime     time.Time
+
+	// policy is recreated in every tick by the latest workloads
+	policy schedulePolicy
+	// drainingCaptures are the captures whose tables are being moved to other
+	// captures, no table is dispatched to them. It is set by the owner.
+	drainingCaptures map[model.CaptureID]struct{}
 }
 
 func newScheduler() *scheduler {
 	return &scheduler{
 		moveTableTargets: make(map[model.TableID]model.CaptureID),
+		addTableStartTs:  make(map[model.TableID]model.Ts),
+		rewindTableTs:    make(map[model.TableID]model.Ts),
+		tableAddTs:       make(map[model.TableID]model.Ts),
 	}
 }
 
@@ -70,8 +101,10 @@ func (s *scheduler) Tick(state *orchestrator.ChangefeedReactorState, currentTabl
 	s.state = state
 	s.currentTables = currentTables
 	s.captures = captures
+	s.policy = newSchedulePolicy(s.schedulerConfig().Tp, state.Workloads)
 
 	s.cleanUpFinishedOperations()
+	s.trackAddedTables()
 	pendingJob, err := s.syncTablesWithCurrentTables()
 	if err != nil {
 		return false, errors.Trace(err)
@@ -85,6 +118,7 @@ func (s *scheduler) Tick(state *orchestrator.ChangefeedReactorState, currentTabl
 	// only if the pending job list is empty and no table is being rebalanced or moved,
 	// can the global resolved ts and checkpoint ts be updated
 	shouldUpdateState = len(pendingJob) == 0
+	shouldUpdateState = s.drainCaptures() && shouldUpdateState
 	shouldUpdateState = s.rebalance() && shouldUpdateState
 	shouldUpdateStateInMoveTable, err := s.handleMoveTableJob()
 	if err != nil {
@@ -102,6 +136,61 @@ func (s *scheduler) MoveTable(tableID model.TableID, target model.CaptureID) {
 	})
 }
 
+// AddTables specifies the start ts of the tables which are going to be added,
+// the start ts takes effect only if it is greater than the checkpoint ts.
+func (s *scheduler) AddTables(tableIDs []model.TableID, startTs model.Ts) {
+	for _, tableID := range tableIDs {
+		s.addTableStartTs[tableID] = startTs
+	}
+}
+
+// RewindTable removes the table from the capture replicating it, and then
+// dispatches the table to the same capture again with the specified ts as the
+// start ts, which is allowed to be less than the checkpoint ts.
+func (s *scheduler) RewindTable(tableID model.TableID, ts model.Ts) {
+	s.rewindTableTs[tableID] = ts
+	// a move table job without a target moves the table to the same capture
+	s.moveTableJobQueue = append(s.moveTableJobQueue, &moveTableJob{
+		tableID: tableID,
+	})
+}
+
+// ResumeTable specifies the start ts of a paused table, which is used when the
+// table is added again and is allowed to be less than the checkpoint ts.
+func (s *scheduler) ResumeTable(tableID model.TableID, ts model.Ts) {
+	s.rewindTableTs[tableID] = ts
+}
+
+// trackAddedTables records the ts at which the tables appearing in the current
+// tables are added to the changefeed. The tables in the first tick are added
+// only if the changefeed has not advanced since it was created, otherwise they
+// are being restarted, e.g. after the owner changed.
+func (s *scheduler) trackAddedTables() {
+	checkpointTs := s.state.Status.CheckpointTs
+	firstTick := s.knownTables == nil
+	knownTables := make(map[model.TableID]struct{}, len(s.currentTables))
+	for _, tableID := range s.currentTables {
+		knownTables[tableID] = struct{}{}
+		if _, exist := s.knownTables[tableID]; exist {
+			continue
+		}
+		addTs := checkpointTs
+		if startTs, exist := s.addTableStartTs[tableID]; exist && startTs > addTs {
+			addTs = startTs
+		} else if firstTick && (s.state.Info == nil || checkpointTs != s.state.Info.StartTs) {
+			continue
+		}
+		s.tableAddTs[tableID] = addTs
+	}
+	s.knownTables = knownTables
+	for tableID, addTs := range s.tableAddTs {
+		if _, exist := knownTables[tableID]; !exist || addTs < checkpointTs {
+			// the table can't be dispatched at the add ts any more
+			delete(s.tableAddTs, tableID)
+		}
+	}
+}
+
 // handleMoveTableJob handles the move table job add be MoveTable function
 func (s *scheduler) handleMoveTableJob() (shouldUpdateState bool, err error) {
 	shouldUpdateState = true
@@ -117,7 +206,19 @@ func (s *scheduler) handleMoveTableJob() (shouldUpdateState bool, err error) {
 		if !exist {
 			return
 		}
-		s.moveTableTargets[job.tableID] = job.target
+		if job.target == "" {
+			s.moveTableTargets[job.tableID] = source
+		} else {
+			if target, exist := s.captures[job.target]; exist && !s.placementConfig().Match(target.Labels) {
+				log.Warn("the target capture doesn't satisfy the placement rules, skip moving the table",
+					zap.String("changefeed", s.state.ID),
+					zap.Int64("table-id", job.tableID),
+					zap.String("target", job.target),
+					zap.Strings("constraints", s.placementConfig().Constraints))
+				continue
+			}
+			s.moveTableTargets[job.tableID] = job.target
+		}
 		job := job
 		shouldUpdateState = false
 		// for all move table job, here just remove the table from the source capture.
@@ -143,6 +244,20 @@ func (s *scheduler) Rebalance() {
 	s.needRebalanceNextTick = true
 }
 
+func (s *scheduler) schedulerConfig() *config.SchedulerConfig {
+	if s.state.Info != nil && s.state.Info.Config != nil && s.state.Info.Config.Scheduler != nil {
+		return s.state.Info.Config.Scheduler
+	}
+	return config.GetDefaultReplicaConfig().Scheduler
+}
+
+func (s *scheduler) placementConfig() *config.PlacementConfig {
+	if s.state.Info != nil && s.state.Info.Config != nil {
+		return s.state.Info.Config.Placement
+	}
+	return nil
+}
+
 func (s *scheduler) table2CaptureIndex() (map[model.TableID]model.CaptureID, error) {
 	table2CaptureIndex := make(map[model.TableID]model.CaptureID)
 	for captureID, taskStatus := range s.state.TaskStatuses {
@@ -163,19 +278,19 @@ func (s *scheduler) table2CaptureIndex() (map[model.TableID]model.CaptureID, err
 }
 
 // dispatchToTargetCaptures sets the TargetCapture of scheduler jobs
-// If the TargetCapture of a job is not set, it chooses a capture with the minimum workload(minimum number of tables)
-// and sets the TargetCapture to the capture.
+// If the TargetCapture of a job is not set, it chooses a capture with the minimum workload
+// calculated by the schedule policy and sets the TargetCapture to the capture.
 func (s *scheduler) dispatchToTargetCaptures(pendingJobs []*schedulerJob) {
-	workloads := make(map[model.CaptureID]uint64)
+	workloads := make(map[model.CaptureID]float64)
 
-	for captureID := range s.captures {
+	for captureID := range s.schedulableCaptures() {
 		workloads[captureID] = 0
 		taskWorkload := s.state.Workloads[captureID]
 		if taskWorkload == nil {
 			continue
 		}
-		for _, workload := range taskWorkload {
-			workloads[captureID] += workload.Workload
+		for tableID := range taskWorkload {
+			workloads[captureID] += s.policy.tableLoad(tableID)
 		}
 	}
 
@@ -189,11 +304,14 @@ func (s *scheduler) dispatchToTargetCaptures(pendingJobs []*schedulerJob) {
 			delete(s.moveTableTargets, pendingJob.TableID)
 			continue
 		}
+		if _, exist := workloads[pendingJob.TargetCapture]; !exist {
+			continue
+		}
 		switch pendingJob.Tp {
 		case schedulerJobTypeAddTable:
-			workloads[pendingJob.TargetCapture] += 1
+			workloads[pendingJob.TargetCapture] += s.policy.tableLoad(pendingJob.TableID)
 		case schedulerJobTypeRemoveTable:
-			workloads[pendingJob.TargetCapture] -= 1
+			workloads[pendingJob.TargetCapture] -= s.policy.tableLoad(pendingJob.TableID)
 		default:
 			log.Panic("Unreachable, please report a bug",
 				zap.String("changefeed", s.state.ID), zap.Any("job", pendingJob))
@@ -202,7 +320,7 @@ func (s *scheduler) dispatchToTargetCaptures(pendingJobs []*schedulerJob) {
 
 	getMinWorkloadCapture := func() model.CaptureID {
 		minCapture := ""
-		minWorkLoad := uint64(math.MaxUint64)
+		minWorkLoad := math.MaxFloat64
 		for captureID, workload := range workloads {
 			if workload < minWorkLoad {
 				minCapture = captureID
@@ -220,9 +338,17 @@ func (s *scheduler) dispatchToTargetCaptures(pendingJobs []*schedulerJob) {
 		if pendingJob.TargetCapture != "" {
 			continue
 		}
+		if len(workloads) == 0 {
+			// the job is left undispatched, and it is skipped by handleJobs
+			log.Warn("no capture satisfies the placement rules, the table can't be dispatched",
+				zap.String("changefeed", s.state.ID),
+				zap.Int64("table-id", pendingJob.TableID),
+				zap.Strings("constraints", s.placementConfig().Constraints))
+			continue
+		}
 		minCapture := getMinWorkloadCapture()
 		pendingJob.TargetCapture = minCapture
-		workloads[minCapture] += 1
+		workloads[minCapture] += s.policy.tableLoad(pendingJob.TableID)
 	}
 }
 
@@ -238,13 +364,22 @@ func (s *scheduler) syncTablesWithCurrentTables() ([]*schedulerJob, error) {
 	for _, tableID := range s.currentTables {
 		if _, exist := allTableListeningNow[tableID]; exist {
 			delete(allTableListeningNow, tableID)
+			delete(s.addTableStartTs, tableID)
 			continue
 		}
+		boundaryTs := globalCheckpointTs
+		if startTs, exist := s.addTableStartTs[tableID]; exist && startTs > boundaryTs {
+			boundaryTs = startTs
+		}
+		if rewindTs, exist := s.rewindTableTs[tableID]; exist {
+			boundaryTs = rewindTs
+			delete(s.rewindTableTs, tableID)
+		}
 		// For each table which should be listened but is not, add an adding-table job to the pending job list
 		pendingJob = append(pendingJob, &schedulerJob{
 			Tp:         schedulerJobTypeAddTable,
 			TableID:    tableID,
-			BoundaryTs: globalCheckpointTs,
+			BoundaryTs: boundaryTs,
 		})
 	}
 	// The remaining tables are the tables which should be not listened
@@ -267,6 +402,9 @@ func (s *scheduler) syncTablesWithCurrentTables() ([]*schedulerJob, error) {
 
 func (s *scheduler) handleJobs(jobs []*schedulerJob) {
 	for _, job := range jobs {
+		if job.TargetCapture == "" {
+			continue
+		}
 		job := job
 		s.state.PatchTaskStatus(job.TargetCapture, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
 			switch job.Tp {
@@ -276,9 +414,11 @@ func (s *scheduler) handleJobs(jobs []*schedulerJob) {
 					log.Warn("task status of the capture is not found, may be the capture is already down. specify a new capture and redo the job", zap.Any("job", job))
 					return status, false, nil
 				}
+				addTs, added := s.tableAddTs[job.TableID]
 				status.AddTable(job.TableID, &model.TableReplicaInfo{
 					StartTs:     job.BoundaryTs,
 					MarkTableID: 0, // mark table ID will be set in processors
+					NewlyAdded:  added && addTs == job.BoundaryTs,
 				}, job.BoundaryTs)
 			case schedulerJobTypeRemoveTable:
 				failpoint.Inject("OwnerRemoveTableError", func() {
@@ -305,6 +445,11 @@ func (s *scheduler) cleanUpFinishedOperations() {
 			changed := false
 			for tableID, operation := range status.Operation {
 				if operation.Status == model.OperFinished {
+					if !operation.Delete {
+						// the table has been added, it is not newly added any more
+						// when it is dispatched again
+						delete(s.tableAddTs, tableID)
+					}
 					delete(status.Operation, tableID)
 					changed = true
 				}
@@ -319,8 +464,8 @@ func (s *scheduler) rebalance() (shouldUpdateState bool) {
 		// if no table is rebalanced, we can update the resolved ts and checkpoint ts
 		return true
 	}
-	// we only support rebalance by table number for now
-	return s.rebalanceByTableNum()
+	s.lastRebalanceTime = time.Now()
+	return s.rebalanceByPolicy()
 }
 
 func (s *scheduler) shouldRebalance() bool {
@@ -333,37 +478,142 @@ func (s *scheduler) shouldRebalance() bool {
 		// or some captures offline
 		return true
 	}
-	// TODO periodic trigger rebalance
+	pollingTime := s.schedulerConfig().PollingTime
+	if pollingTime > 0 && time.Since(s.lastRebalanceTime) >= time.Duration(pollingTime)*time.Minute {
+		return true
+	}
 	return false
 }
 
-// rebalanceByTableNum removes tables from captures replicating an above-average number of tables.
-// the removed table will be dispatched again by syncTablesWithCurrentTables function
-func (s *scheduler) rebalanceByTableNum() (shouldUpdateState bool) {
-	totalTableNum := len(s.currentTables)
-	captureNum := len(s.captures)
-	upperLimitPerCapture := int(math.Ceil(float64(totalTableNum) / float64(captureNum)))
-	shouldUpdateState = true
+// placedCaptures returns the captures satisfying the placement rules of the
+// changefeed, which are all the captures if no placement rule is specified.
+func (s *scheduler) placedCaptures() map[model.CaptureID]*model.CaptureInfo {
+	placement := s.placementConfig()
+	if !placement.IsEnabled() {
+		return s.captures
+	}
+	captures := make(map[model.CaptureID]*model.CaptureInfo, len(s.captures))
+	for captureID, info := range s.captures {
+		if placement.Match(info.Labels) {
+			captures[captureID] = info
+		}
+	}
+	return captures
+}
 
-	log.Info("Start rebalancing",
-		zap.String("changefeed", s.state.ID),
-		zap.Int("table-num", totalTableNum),
-		zap.Int("capture-num", captureNum),
-		zap.Int("target-limit", upperLimitPerCapture))
+// schedulableCaptures returns the captures which tables can be dispatched to,
+// which are the placed captures except the draining ones. If all the placed
+// captures are draining, all of them are returned, because the tables have
+// nowhere else to go.
+func (s *scheduler) schedulableCaptures() map[model.CaptureID]*model.CaptureInfo {
+	placed := s.placedCaptures()
+	if len(s.drainingCaptures) == 0 {
+		return placed
+	}
+	captures := make(map[model.CaptureID]*model.CaptureInfo, len(placed))
+	for captureID, info := range placed {
+		if _, draining := s.drainingCaptures[captureID]; !draining {
+			captures[captureID] = info
+		}
+	}
+	if len(captures) == 0 {
+		return placed
+	}
+	return captures
+}
 
-	for captureID, taskStatus := range s.state.TaskStatuses {
-		tableNum2Remove := len(taskStatus.Tables) - upperLimitPerCapture
-		if tableNum2Remove <= 0 {
+// drainCaptures removes the tables from the draining captures, the removed tables
+// will be dispatched to other captures by syncTablesWithCurrentTables function.
+// At most drainBatchSize tables are being moved at the same time.
+func (s *scheduler) drainCaptures() (shouldUpdateState bool) {
+	shouldUpdateState = true
+	if len(s.drainingCaptures) == 0 || len(s.schedulableCaptures()) == len(s.placedCaptures()) {
+		// no capture is draining, or the tables have nowhere else to go
+		return
+	}
+	inflight := 0
+	for _, taskStatus := range s.state.TaskStatuses {
+		inflight += len(taskStatus.Operation)
+	}
+	captureIDs := make([]model.CaptureID, 0, len(s.drainingCaptures))
+	for captureID := range s.drainingCaptures {
+		captureIDs = append(captureIDs, captureID)
+	}
+	sort.Strings(captureIDs)
+	for _, captureID := range captureIDs {
+		taskStatus, exist := s.state.TaskStatuses[captureID]
+		if !exist {
 			continue
 		}
-
-		// here we pick `tableNum2Remove` tables to delete,
-		// and then the removed tables will be dispatched by `syncTablesWithCurrentTables` function in the next tick
+		tableIDs := make([]model.TableID, 0, len(taskStatus.Tables))
 		for tableID := range taskStatus.Tables {
+			if taskStatus.Operation[tableID] == nil {
+				tableIDs = append(tableIDs, tableID)
+			}
+		}
+		sort.Slice(tableIDs, func(i, j int) bool { return tableIDs[i] < tableIDs[j] })
+		for _, tableID := range tableIDs {
+			if inflight >= drainBatchSize {
+				return
+			}
+			inflight++
+			shouldUpdateState = false
 			tableID := tableID
-			if tableNum2Remove <= 0 {
-				break
+			captureID := captureID
+			s.state.PatchTaskStatus(captureID, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
+				if status == nil {
+					// the capture may be down, just skip remove this table
+					return status, false, nil
+				}
+				if status.Operation != nil && status.Operation[tableID] != nil {
+					return status, false, nil
+				}
+				status.RemoveTable(tableID, s.state.Status.CheckpointTs, false)
+				log.Info("Drain: Move table",
+					zap.Int64("table-id", tableID),
+					zap.String("capture", captureID),
+					zap.String("changefeed-id", s.state.ID))
+				return status, true, nil
+			})
+		}
+	}
+	return
+}
+
+// rebalanceByPolicy removes the victims found by the schedule policy from the captures.
+// the removed table will be dispatched again by syncTablesWithCurrentTables function
+func (s *scheduler) rebalanceByPolicy() (shouldUpdateState bool) {
+	shouldUpdateState = true
+	captures := s.schedulableCaptures()
+	if len(captures) == 0 {
+		return
+	}
+	captureTables := make(map[model.CaptureID][]model.TableID, len(captures))
+	for captureID := range captures {
+		var tables []model.TableID
+		if taskStatus, exist := s.state.TaskStatuses[captureID]; exist {
+			for tableID := range taskStatus.Tables {
+				tables = append(tables, tableID)
 			}
+		}
+		sort.Slice(tables, func(i, j int) bool { return tables[i] < tables[j] })
+		captureTables[captureID] = tables
+	}
+	victims := s.policy.findVictims(captureTables)
+
+	log.Info("Start rebalancing",
+		zap.String("changefeed", s.state.ID),
+		zap.String("policy", s.schedulerConfig().Tp),
+		zap.Int("table-num", len(s.currentTables)),
+		zap.Int("capture-num", len(s.captures)),
+		zap.Any("victims", victims))
+
+	for captureID, tableIDs := range victims {
+		captureID := captureID
+		// here we remove the victims from the capture,
+		// and then the removed tables will be dispatched by `syncTablesWithCurrentTables` function in the next tick
+		for _, tableID := range tableIDs {
+			tableID := tableID
 			shouldUpdateState = false
 			s.state.PatchTaskStatus(captureID, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
 				if status == nil {
@@ -381,7 +631,6 @@ func (s *scheduler) rebalanceByTableNum() (shouldUpdateState bool) {
 					zap.String("changefeed-id", s.state.ID))
 				return status, true, nil
 			})
-			tableNum2Remove--
 		}
 	}
 	return
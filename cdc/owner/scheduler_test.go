@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/check"
 	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/orchestrator"
 	"github.com/pingcap/ticdc/pkg/util/testleak"
 )
@@ -115,7 +116,7 @@ func (s *schedulerSuite) TestScheduleOneCapture(c *check.C) {
 	c.Assert(shouldUpdateState, check.IsFalse)
 	s.tester.MustApplyPatches()
 	c.Assert(s.state.TaskStatuses[captureID].Tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
-		3: {StartTs: 0}, 4: {StartTs: 0}, 5: {StartTs: 0},
+		3: {StartTs: 0}, 4: {StartTs: 0}, 5: {StartTs: 0, NewlyAdded: true},
 	})
 	c.Assert(s.state.TaskStatuses[captureID].Operation, check.DeepEquals, map[model.TableID]*model.TableOperation{
 		1: {Delete: true, BoundaryTs: 0, Status: model.OperDispatched},
@@ -134,7 +135,7 @@ func (s *schedulerSuite) TestScheduleOneCapture(c *check.C) {
 	c.Assert(shouldUpdateState, check.IsFalse)
 	s.tester.MustApplyPatches()
 	c.Assert(s.state.TaskStatuses[captureID].Tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
-		4: {StartTs: 0}, 5: {StartTs: 0},
+		4: {StartTs: 0}, 5: {StartTs: 0, NewlyAdded: true},
 	})
 	c.Assert(s.state.TaskStatuses[captureID].Operation, check.DeepEquals, map[model.TableID]*model.TableOperation{
 		1: {Delete: true, BoundaryTs: 0, Status: model.OperDispatched},
@@ -152,7 +153,7 @@ func (s *schedulerSuite) TestScheduleOneCapture(c *check.C) {
 	c.Assert(shouldUpdateState, check.IsTrue)
 	s.tester.MustApplyPatches()
 	c.Assert(s.state.TaskStatuses[captureID].Tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
-		4: {StartTs: 0}, 5: {StartTs: 0},
+		4: {StartTs: 0}, 5: {StartTs: 0, NewlyAdded: true},
 	})
 	c.Assert(s.state.TaskStatuses[captureID].Operation, check.DeepEquals, map[model.TableID]*model.TableOperation{})
 
@@ -163,7 +164,7 @@ func (s *schedulerSuite) TestScheduleOneCapture(c *check.C) {
 	c.Assert(shouldUpdateState, check.IsFalse)
 	s.tester.MustApplyPatches()
 	c.Assert(s.state.TaskStatuses[captureID].Tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
-		4: {StartTs: 0}, 5: {StartTs: 0},
+		4: {StartTs: 0}, 5: {StartTs: 0, NewlyAdded: true},
 	})
 	c.Assert(s.state.TaskStatuses[captureID].Operation, check.DeepEquals, map[model.TableID]*model.TableOperation{})
 
@@ -172,7 +173,7 @@ func (s *schedulerSuite) TestScheduleOneCapture(c *check.C) {
 	c.Assert(shouldUpdateState, check.IsFalse)
 	s.tester.MustApplyPatches()
 	c.Assert(s.state.TaskStatuses[captureID].Tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
-		3: {StartTs: 0}, 4: {StartTs: 0}, 5: {StartTs: 0},
+		3: {StartTs: 0}, 4: {StartTs: 0}, 5: {StartTs: 0, NewlyAdded: true},
 	})
 	c.Assert(s.state.TaskStatuses[captureID].Operation, check.DeepEquals, map[model.TableID]*model.TableOperation{
 		3: {Delete: false, BoundaryTs: 0, Status: model.OperDispatched},
@@ -216,7 +217,7 @@ func (s *schedulerSuite) TestScheduleMoveTable(c *check.C) {
 	})
 	c.Assert(s.state.TaskStatuses[captureID1].Operation, check.DeepEquals, map[model.TableID]*model.TableOperation{})
 	c.Assert(s.state.TaskStatuses[captureID2].Tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
-		2: {StartTs: 0},
+		2: {StartTs: 0, NewlyAdded: true},
 	})
 	c.Assert(s.state.TaskStatuses[captureID2].Operation, check.DeepEquals, map[model.TableID]*model.TableOperation{
 		2: {Delete: false, BoundaryTs: 0, Status: model.OperDispatched},
@@ -338,3 +339,274 @@ func (s *schedulerSuite) TestScheduleRebalance(c *check.C) {
 	}
 	c.Assert(tableIDs, check.DeepEquals, map[model.TableID]struct{}{1: {}, 2: {}, 3: {}, 4: {}, 5: {}, 6: {}})
 }
+
+func (s *schedulerSuite) TestScheduleAddTables(c *check.C) {
+	defer testleak.AfterTest(c)()
+	s.reset(c)
+	captureID := "test-capture-1"
+	s.addCapture(captureID)
+	s.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		status.CheckpointTs = 100
+		return status, true, nil
+	})
+	s.tester.MustApplyPatches()
+
+	// table 1 is replicated already, table 3 starts from a ts before the checkpoint ts
+	shouldUpdateState, err := s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsFalse)
+	s.tester.MustApplyPatches()
+	s.scheduler.AddTables([]model.TableID{1, 2}, 200)
+	s.scheduler.AddTables([]model.TableID{3}, 50)
+	shouldUpdateState, err = s.scheduler.Tick(s.state, []model.TableID{1, 2, 3}, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsFalse)
+	s.tester.MustApplyPatches()
+	c.Assert(s.state.TaskStatuses[captureID].Tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
+		1: {StartTs: 100}, 2: {StartTs: 200, NewlyAdded: true}, 3: {StartTs: 100, NewlyAdded: true},
+	})
+	c.Assert(s.state.TaskStatuses[captureID].Operation[2], check.DeepEquals,
+		&model.TableOperation{Delete: false, BoundaryTs: 200, Status: model.OperDispatched})
+
+	// the start ts is dropped once the table is dispatched
+	shouldUpdateState, err = s.scheduler.Tick(s.state, []model.TableID{1, 2, 3}, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsTrue)
+	c.Assert(s.scheduler.addTableStartTs, check.HasLen, 0)
+}
+
+func (s *schedulerSuite) TestNewlyAddedTables(c *check.C) {
+	defer testleak.AfterTest(c)()
+	s.reset(c)
+	captureID1 := "test-capture-1"
+	captureID2 := "test-capture-2"
+	s.addCapture(captureID1)
+	s.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		return &model.ChangeFeedInfo{StartTs: 100, Config: config.GetDefaultReplicaConfig()}, true, nil
+	})
+	s.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		status.CheckpointTs = 100
+		return status, true, nil
+	})
+	s.tester.MustApplyPatches()
+
+	// the tables are added when the changefeed is created
+	_, err := s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+	c.Assert(err, check.IsNil)
+	s.tester.MustApplyPatches()
+	c.Assert(s.state.TaskStatuses[captureID1].Tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
+		1: {StartTs: 100, NewlyAdded: true},
+	})
+	s.finishTableOperation(captureID1, 1)
+	_, err = s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+	c.Assert(err, check.IsNil)
+	s.tester.MustApplyPatches()
+
+	// the moved table is not newly added, even if the checkpoint ts is not changed
+	s.addCapture(captureID2)
+	s.scheduler.MoveTable(1, captureID2)
+	_, err = s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+	c.Assert(err, check.IsNil)
+	s.tester.MustApplyPatches()
+	s.finishTableOperation(captureID1, 1)
+	for i := 0; i < 2; i++ {
+		_, err = s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+		c.Assert(err, check.IsNil)
+		s.tester.MustApplyPatches()
+	}
+	c.Assert(s.state.TaskStatuses[captureID2].Tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
+		1: {StartTs: 100},
+	})
+
+	// the tables are restarted by a new owner after the changefeed advanced,
+	// and the table created by a DDL is added at the checkpoint ts
+	s.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		status.CheckpointTs = 200
+		return status, true, nil
+	})
+	s.state.PatchTaskStatus(captureID2, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
+		return &model.TaskStatus{}, true, nil
+	})
+	s.tester.MustApplyPatches()
+	s.scheduler = newScheduler()
+	_, err = s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+	c.Assert(err, check.IsNil)
+	s.tester.MustApplyPatches()
+	_, err = s.scheduler.Tick(s.state, []model.TableID{1, 2}, s.captures)
+	c.Assert(err, check.IsNil)
+	s.tester.MustApplyPatches()
+	tables := make(map[model.TableID]*model.TableReplicaInfo)
+	for _, status := range s.state.TaskStatuses {
+		for tableID, replicaInfo := range status.Tables {
+			tables[tableID] = replicaInfo
+		}
+	}
+	c.Assert(tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
+		1: {StartTs: 200}, 2: {StartTs: 200, NewlyAdded: true},
+	})
+}
+
+func (s *schedulerSuite) TestRewindTable(c *check.C) {
+	defer testleak.AfterTest(c)()
+	s.reset(c)
+	captureID1 := "test-capture-1"
+	captureID2 := "test-capture-2"
+	s.addCapture(captureID1)
+	s.state.PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		status.CheckpointTs = 100
+		return status, true, nil
+	})
+	s.tester.MustApplyPatches()
+
+	shouldUpdateState, err := s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsFalse)
+	s.tester.MustApplyPatches()
+	s.finishTableOperation(captureID1, 1)
+	s.addCapture(captureID2)
+	shouldUpdateState, err = s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsTrue)
+	s.tester.MustApplyPatches()
+
+	// the table is removed first
+	s.scheduler.RewindTable(1, 50)
+	shouldUpdateState, err = s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsFalse)
+	s.tester.MustApplyPatches()
+	c.Assert(s.state.TaskStatuses[captureID1].Tables, check.HasLen, 0)
+	c.Assert(s.state.TaskStatuses[captureID1].Operation[1], check.DeepEquals,
+		&model.TableOperation{Delete: true, BoundaryTs: 100, Status: model.OperDispatched})
+	s.finishTableOperation(captureID1, 1)
+	shouldUpdateState, err = s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsTrue)
+	s.tester.MustApplyPatches()
+
+	// the table is added to the same capture with the rewound ts
+	shouldUpdateState, err = s.scheduler.Tick(s.state, []model.TableID{1}, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsFalse)
+	s.tester.MustApplyPatches()
+	c.Assert(s.state.TaskStatuses[captureID1].Tables, check.DeepEquals, map[model.TableID]*model.TableReplicaInfo{
+		1: {StartTs: 50},
+	})
+	c.Assert(s.state.TaskStatuses[captureID1].Operation[1], check.DeepEquals,
+		&model.TableOperation{Delete: false, BoundaryTs: 50, Status: model.OperDispatched})
+	c.Assert(s.state.TaskStatuses[captureID2].Tables, check.HasLen, 0)
+	c.Assert(s.scheduler.rewindTableTs, check.HasLen, 0)
+}
+
+func (s *schedulerSuite) TestDrainCapture(c *check.C) {
+	defer testleak.AfterTest(c)()
+	s.reset(c)
+	captureID1 := "test-capture-1"
+	captureID2 := "test-capture-2"
+	s.addCapture(captureID1)
+	s.addCapture(captureID2)
+	s.state.PatchTaskStatus(captureID1, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
+		status.Tables = make(map[model.TableID]*model.TableReplicaInfo)
+		status.Tables[1] = &model.TableReplicaInfo{StartTs: 1}
+		status.Tables[2] = &model.TableReplicaInfo{StartTs: 1}
+		return status, true, nil
+	})
+	s.state.PatchTaskStatus(captureID2, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
+		status.Tables = make(map[model.TableID]*model.TableReplicaInfo)
+		status.Tables[3] = &model.TableReplicaInfo{StartTs: 1}
+		status.Tables[4] = &model.TableReplicaInfo{StartTs: 1}
+		return status, true, nil
+	})
+	s.tester.MustApplyPatches()
+	currentTables := []model.TableID{1, 2, 3, 4, 5}
+	s.scheduler.lastTickCaptureCount = len(s.captures)
+	s.scheduler.drainingCaptures = map[model.CaptureID]struct{}{captureID1: {}}
+
+	// the new table is dispatched to capture 2 and the tables of capture 1 are removed
+	shouldUpdateState, err := s.scheduler.Tick(s.state, currentTables, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsFalse)
+	s.tester.MustApplyPatches()
+	c.Assert(s.state.TaskStatuses[captureID1].Tables, check.HasLen, 0)
+	c.Assert(s.state.TaskStatuses[captureID1].Operation, check.DeepEquals, map[model.TableID]*model.TableOperation{
+		1: {Delete: true, BoundaryTs: 0, Status: model.OperDispatched},
+		2: {Delete: true, BoundaryTs: 0, Status: model.OperDispatched},
+	})
+	c.Assert(s.state.TaskStatuses[captureID2].Tables, check.HasLen, 3)
+	c.Assert(s.state.TaskStatuses[captureID2].Tables[5], check.NotNil)
+
+	s.finishTableOperation(captureID1, 1, 2)
+	s.finishTableOperation(captureID2, 5)
+	// clean finished operations
+	shouldUpdateState, err = s.scheduler.Tick(s.state, currentTables, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsTrue)
+	s.tester.MustApplyPatches()
+
+	// the removed tables are dispatched to capture 2
+	shouldUpdateState, err = s.scheduler.Tick(s.state, currentTables, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsFalse)
+	s.tester.MustApplyPatches()
+	c.Assert(s.state.TaskStatuses[captureID1].Tables, check.HasLen, 0)
+	c.Assert(s.state.TaskStatuses[captureID2].Tables, check.HasLen, 5)
+
+	// the tables stay on the draining capture if all the captures are draining
+	s.scheduler.drainingCaptures[captureID2] = struct{}{}
+	s.finishTableOperation(captureID2, 1, 2)
+	shouldUpdateState, err = s.scheduler.Tick(s.state, currentTables, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsTrue)
+	s.tester.MustApplyPatches()
+	c.Assert(s.state.TaskStatuses[captureID2].Tables, check.HasLen, 5)
+}
+
+func (s *schedulerSuite) TestPlacement(c *check.C) {
+	defer testleak.AfterTest(c)()
+	s.reset(c)
+	captureID1 := "test-capture-1"
+	captureID2 := "test-capture-2"
+	s.addCapture(captureID1)
+	s.addCapture(captureID2)
+	s.captures[captureID1].Labels = map[string]string{"ssd": "true", "zone": "z1"}
+	s.captures[captureID2].Labels = map[string]string{"zone": "z2"}
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Placement = &config.PlacementConfig{Constraints: []string{"ssd=true"}}
+	s.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		return &model.ChangeFeedInfo{SinkURI: "blackhole://", Config: replicaConfig}, true, nil
+	})
+	s.tester.MustApplyPatches()
+	s.scheduler.lastTickCaptureCount = len(s.captures)
+
+	// all the tables are dispatched to the capture satisfying the constraints
+	currentTables := []model.TableID{1, 2, 3}
+	shouldUpdateState, err := s.scheduler.Tick(s.state, currentTables, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsFalse)
+	s.tester.MustApplyPatches()
+	c.Assert(s.state.TaskStatuses[captureID1].Tables, check.HasLen, 3)
+	c.Assert(s.state.TaskStatuses[captureID2].Tables, check.HasLen, 0)
+	s.finishTableOperation(captureID1, 1, 2, 3)
+
+	// the table is not moved to the capture violating the constraints
+	s.scheduler.MoveTable(1, captureID2)
+	shouldUpdateState, err = s.scheduler.Tick(s.state, currentTables, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsTrue)
+	s.tester.MustApplyPatches()
+	c.Assert(s.state.TaskStatuses[captureID1].Tables, check.HasLen, 3)
+
+	// the new table is not dispatched if no capture satisfies the constraints
+	s.state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		info.Config.Placement.Constraints = []string{"zone!=z1", "zone!=z2"}
+		return info, true, nil
+	})
+	s.tester.MustApplyPatches()
+	currentTables = append(currentTables, 4)
+	shouldUpdateState, err = s.scheduler.Tick(s.state, currentTables, s.captures)
+	c.Assert(err, check.IsNil)
+	c.Assert(shouldUpdateState, check.IsFalse)
+	s.tester.MustApplyPatches()
+	c.Assert(s.state.TaskStatuses[captureID1].Tables, check.HasLen, 3)
+	c.Assert(s.state.TaskStatuses[captureID2].Tables, check.HasLen, 0)
+}
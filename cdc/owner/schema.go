@@ -14,6 +14,10 @@
 package owner
 
 import (
+	"bytes"
+	"sort"
+	"strings"
+
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/entry"
@@ -21,10 +25,16 @@ import (
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/cyclic/mark"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
+	tfilter "github.com/pingcap/tidb-tools/pkg/table-filter"
+	"github.com/pingcap/tidb/executor"
 	tidbkv "github.com/pingcap/tidb/kv"
 	timeta "github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
 	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/mock"
 	"go.uber.org/zap"
 )
 
@@ -46,7 +56,7 @@ func newSchemaWrap4Owner(kvStorage tidbkv.Storage, startTs model.Ts, config *con
 			return nil, errors.Trace(err)
 		}
 	}
-	schemaSnap, err := entry.NewSingleSchemaSnapshotFromMeta(meta, startTs, config.ForceReplicate)
+	schemaSnap, err := entry.NewSingleSchemaSnapshotFromMeta(meta, startTs, config.ReplicateNoKeyTables())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -85,6 +95,21 @@ func (s *schemaWrap4Owner) AllPhysicalTables() []model.TableID {
 	return s.allPhysicalTablesCache
 }
 
+// AllPhysicalTablesWithConfig returns the table IDs of all tables and partition
+// tables which would be replicated with the given config.
+func (s *schemaWrap4Owner) AllPhysicalTablesWithConfig(config *config.ReplicaConfig) ([]model.TableID, error) {
+	f, err := filter.NewFilter(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	schema := &schemaWrap4Owner{
+		schemaSnapshot: s.schemaSnapshot,
+		filter:         f,
+		config:         config,
+	}
+	return schema.AllPhysicalTables(), nil
+}
+
 func (s *schemaWrap4Owner) HandleDDL(job *timodel.Job) error {
 	if job.BinlogInfo.FinishedTS <= s.ddlHandledTs {
 		return nil
@@ -102,6 +127,8 @@ func (s *schemaWrap4Owner) IsIneligibleTableID(tableID model.TableID) bool {
 	return s.schemaSnapshot.IsIneligibleTableID(tableID)
 }
 
+// BuildDDLEvent builds the DDL event of a DDL job, it returns nil if the DDL
+// job should be skipped according to the DDL policies of the changefeed.
 func (s *schemaWrap4Owner) BuildDDLEvent(job *timodel.Job) (*model.DDLEvent, error) {
 	ddlEvent := new(model.DDLEvent)
 	preTableInfo, err := s.schemaSnapshot.PreTableInfo(job)
@@ -113,9 +140,87 @@ func (s *schemaWrap4Owner) BuildDDLEvent(job *timodel.Job) (*model.DDLEvent, err
 		return nil, errors.Trace(err)
 	}
 	ddlEvent.FromJob(job, preTableInfo)
+	return s.applyDDLPolicy(job, preTableInfo, ddlEvent)
+}
+
+// applyDDLPolicy applies the allow list and the deny list of DDL types, and
+// the DDL policies to the DDLs of temporary tables, `CREATE TABLE ... LIKE`
+// and `CREATE TABLE ... SELECT`.
+func (s *schemaWrap4Owner) applyDDLPolicy(
+	job *timodel.Job, preTableInfo *model.TableInfo, ddlEvent *model.DDLEvent,
+) (*model.DDLEvent, error) {
+	if s.config.DDL.IsDenied(job.Type) {
+		log.Warn("skip the DDL according to the allow list and the deny list",
+			zap.String("query", job.Query), zap.Stringer("job", job))
+		return nil, nil
+	}
+	tableInfo := job.BinlogInfo.TableInfo
+	if tableInfo == nil && preTableInfo != nil {
+		tableInfo = preTableInfo.TableInfo
+	}
+	if tableInfo != nil && tableInfo.TempTableType != timodel.TempTableNone {
+		policy := s.config.DDL.TemporaryTablePolicy()
+		if policy == config.DDLPolicySkip {
+			log.Warn("skip the DDL of temporary table",
+				zap.String("query", job.Query), zap.Stringer("job", job))
+			return nil, nil
+		}
+		return ddlEvent, nil
+	}
+	if job.Type != timodel.ActionCreateTable || tableInfo == nil {
+		return ddlEvent, nil
+	}
+
+	stmt, err := parser.New().ParseOneStmt(job.Query, "", "")
+	if err != nil {
+		log.Warn("failed to parse the DDL, replicate it as is",
+			zap.String("query", job.Query), zap.Error(err))
+		return ddlEvent, nil
+	}
+	createStmt, ok := stmt.(*ast.CreateTableStmt)
+	if !ok {
+		return ddlEvent, nil
+	}
+	var policy config.DDLPolicy
+	switch {
+	case createStmt.ReferTable != nil:
+		policy = s.config.DDL.CreateTableLikePolicy()
+	case createStmt.Select != nil:
+		policy = s.config.DDL.CreateTableAsSelectPolicy()
+	default:
+		return ddlEvent, nil
+	}
+	switch policy {
+	case config.DDLPolicySkip:
+		log.Warn("skip the DDL according to the DDL policy",
+			zap.String("query", job.Query), zap.Stringer("job", job))
+		return nil, nil
+	case config.DDLPolicyRewrite:
+		query, err := showCreateTable(tableInfo, createStmt.IfNotExists)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		log.Info("rewrite the DDL according to the DDL policy",
+			zap.String("query", job.Query), zap.String("rewritten", query))
+		ddlEvent.Query = query
+	}
 	return ddlEvent, nil
 }
 
+// showCreateTable returns the plain CREATE TABLE statement of a table.
+func showCreateTable(tableInfo *timodel.TableInfo, ifNotExists bool) (string, error) {
+	var buf bytes.Buffer
+	err := executor.ConstructResultOfShowCreateTable(mock.NewContext(), tableInfo, nil, &buf)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	query := buf.String()
+	if ifNotExists {
+		query = strings.Replace(query, "CREATE TABLE ", "CREATE TABLE IF NOT EXISTS ", 1)
+	}
+	return query, nil
+}
+
 func (s *schemaWrap4Owner) SinkTableInfos() []*model.SimpleTableInfo {
 	var sinkTableInfos []*model.SimpleTableInfo
 	for tableID := range s.schemaSnapshot.CloneTables() {
@@ -146,6 +251,117 @@ func (s *schemaWrap4Owner) SinkTableInfos() []*model.SimpleTableInfo {
 	return sinkTableInfos
 }
 
+// checksumTables returns the replicated tables to be checked by the consistency
+// checker, sorted by name.
+func (s *schemaWrap4Owner) checksumTables(cfg *config.ChecksumConfig) ([]*checksumTable, error) {
+	matcher := cfg.Tables
+	if len(matcher) == 0 {
+		matcher = []string{"*.*"}
+	}
+	tableFilter, err := s.parseChecksumMatcher(matcher)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ruleFilters := make([]tfilter.Filter, 0, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		f, err := s.parseChecksumMatcher(rule.Matcher)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ruleFilters = append(ruleFilters, f)
+	}
+
+	var tables []*checksumTable
+	for tableID := range s.schemaSnapshot.CloneTables() {
+		tblInfo, ok := s.schemaSnapshot.TableByID(tableID)
+		if !ok {
+			log.Panic("table not found for table ID", zap.Int64("tid", tableID))
+		}
+		if s.shouldIgnoreTable(tblInfo) {
+			continue
+		}
+		schemaName, tableName := tblInfo.TableName.Schema, tblInfo.TableName.Table
+		if !tableFilter.MatchTable(schemaName, tableName) {
+			continue
+		}
+		table := &checksumTable{schema: schemaName, table: tableName}
+		for i, f := range ruleFilters {
+			if f.MatchTable(schemaName, tableName) {
+				table.columns = cfg.Rules[i].Columns
+				break
+			}
+		}
+		if table.columns == nil {
+			for _, col := range tblInfo.Columns {
+				if model.IsColCDCVisible(col) {
+					table.columns = append(table.columns, col.Name.O)
+				}
+			}
+		}
+		if keys := tblInfo.GetUniqueKeys(); len(keys) > 0 {
+			table.keyColumns = keys[0]
+		}
+		tables = append(tables, table)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		if tables[i].schema != tables[j].schema {
+			return tables[i].schema < tables[j].schema
+		}
+		return tables[i].table < tables[j].table
+	})
+	return tables, nil
+}
+
+// backfillTables returns the physical tables to be backfilled, which are the
+// partitions of the partitioned tables.
+func (s *schemaWrap4Owner) backfillTables() []*backfillTable {
+	var tables []*backfillTable
+	for tableID := range s.schemaSnapshot.CloneTables() {
+		tblInfo, ok := s.schemaSnapshot.TableByID(tableID)
+		if !ok {
+			log.Panic("table not found for table ID", zap.Int64("tid", tableID))
+		}
+		if s.shouldIgnoreTable(tblInfo) {
+			continue
+		}
+		var keyColumns []string
+		if keys := tblInfo.GetUniqueKeys(); len(keys) > 0 {
+			keyColumns = keys[0]
+		}
+		if pi := tblInfo.GetPartitionInfo(); pi != nil {
+			for _, def := range pi.Definitions {
+				tables = append(tables, &backfillTable{
+					info: tblInfo, physicalID: def.ID, partition: def.Name.O, keyColumns: keyColumns,
+				})
+			}
+			continue
+		}
+		tables = append(tables, &backfillTable{info: tblInfo, physicalID: tableID, keyColumns: keyColumns})
+	}
+	sort.SliceStable(tables, func(i, j int) bool {
+		a, b := tables[i].info.TableName, tables[j].info.TableName
+		if a.Schema != b.Schema {
+			return a.Schema < b.Schema
+		}
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		return tables[i].physicalID < tables[j].physicalID
+	})
+	return tables
+}
+
+func (s *schemaWrap4Owner) parseChecksumMatcher(matcher []string) (tfilter.Filter, error) {
+	f, err := tfilter.Parse(matcher)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+	}
+	if !s.config.CaseSensitive {
+		f = tfilter.CaseInsensitive(f)
+	}
+	return f, nil
+}
+
 func (s *schemaWrap4Owner) shouldIgnoreTable(tableInfo *model.TableInfo) bool {
 	schemaName := tableInfo.TableName.Schema
 	tableName := tableInfo.TableName.Table
@@ -156,7 +372,15 @@ func (s *schemaWrap4Owner) shouldIgnoreTable(tableInfo *model.TableInfo) bool {
 		// skip the mark table if cyclic is enabled
 		return true
 	}
-	if !tableInfo.IsEligible(s.config.ForceReplicate) {
+	if tableInfo.TempTableType != timodel.TempTableNone {
+		// the data of temporary tables is not stored in TiKV
+		return true
+	}
+	if tableInfo.IsSequence() {
+		// a sequence has no rows, its DDLs are replicated by the auto-id config
+		return true
+	}
+	if !tableInfo.IsEligible(s.config.ReplicateNoKeyTables()) {
 		log.Warn("skip ineligible table", zap.Int64("tid", tableInfo.ID), zap.Stringer("table", tableInfo.TableName))
 		return true
 	}
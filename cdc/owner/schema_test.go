@@ -135,7 +135,7 @@ func (s *schemaSuite) TestBuildDDLEvent(c *check.C) {
 			Schema:     "test",
 			Table:      "t1",
 			TableID:    job.TableID,
-			ColumnInfo: []*model.ColumnInfo{{Name: "id", Type: mysql.TypeLong}, {Name: "c1", Type: mysql.TypeString}},
+			ColumnInfo: []*model.ColumnInfo{{Name: "id", Type: mysql.TypeLong}, {Name: "c1", Type: mysql.TypeString, Default: ""}},
 		},
 		PreTableInfo: &model.SimpleTableInfo{
 			Schema:     "test",
@@ -146,6 +146,73 @@ func (s *schemaSuite) TestBuildDDLEvent(c *check.C) {
 	})
 }
 
+func (s *schemaSuite) TestBuildDDLEventWithDDLPolicy(c *check.C) {
+	defer testleak.AfterTest(c)()
+	helper := entry.NewSchemaTestHelper(c)
+	defer helper.Close()
+	ver, err := helper.Storage().CurrentVersion(oracle.GlobalTxnScope)
+	c.Assert(err, check.IsNil)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	schema, err := newSchemaWrap4Owner(helper.Storage(), ver.Ver, replicaConfig)
+	c.Assert(err, check.IsNil)
+	job := helper.DDL2Job("create table test.t1(id int primary key, name varchar(16))")
+	c.Assert(schema.HandleDDL(job), check.IsNil)
+
+	// CREATE TABLE ... LIKE is rewritten by default
+	job = helper.DDL2Job("create table if not exists test.t2 like test.t1")
+	event, err := schema.BuildDDLEvent(job)
+	c.Assert(err, check.IsNil)
+	c.Assert(event.Query, check.Equals, "CREATE TABLE IF NOT EXISTS `t2` (\n"+
+		"  `id` int(11) NOT NULL,\n"+
+		"  `name` varchar(16) DEFAULT NULL,\n"+
+		"  PRIMARY KEY (`id`) /*T![clustered_index] NONCLUSTERED */\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin")
+	c.Assert(event.TableInfo.Table, check.Equals, "t2")
+	replicaConfig.DDL = &config.DDLConfig{CreateTableLike: config.DDLPolicyReplicate}
+	event, err = schema.BuildDDLEvent(job)
+	c.Assert(err, check.IsNil)
+	c.Assert(event.Query, check.Equals, "create table if not exists test.t2 like test.t1")
+	replicaConfig.DDL.CreateTableLike = config.DDLPolicySkip
+	event, err = schema.BuildDDLEvent(job)
+	c.Assert(err, check.IsNil)
+	c.Assert(event, check.IsNil)
+	c.Assert(schema.HandleDDL(job), check.IsNil)
+
+	// the DDLs of global temporary tables are skipped by default
+	job = helper.DDL2Job("create global temporary table test.t3(id int primary key) on commit delete rows")
+	event, err = schema.BuildDDLEvent(job)
+	c.Assert(err, check.IsNil)
+	c.Assert(event, check.IsNil)
+	c.Assert(schema.HandleDDL(job), check.IsNil)
+	c.Assert(schema.AllPhysicalTables(), check.HasLen, 2)
+	job = helper.DDL2Job("drop table test.t3")
+	event, err = schema.BuildDDLEvent(job)
+	c.Assert(err, check.IsNil)
+	c.Assert(event, check.IsNil)
+	replicaConfig.DDL.TemporaryTable = config.DDLPolicyReplicate
+	event, err = schema.BuildDDLEvent(job)
+	c.Assert(err, check.IsNil)
+	c.Assert(event.Query, check.Equals, "drop table test.t3")
+	c.Assert(schema.HandleDDL(job), check.IsNil)
+
+	// the denied DDLs are skipped but still change the schema
+	replicaConfig.DDL.DenyList = []string{"truncate table"}
+	job = helper.DDL2Job("truncate table test.t1")
+	event, err = schema.BuildDDLEvent(job)
+	c.Assert(err, check.IsNil)
+	c.Assert(event, check.IsNil)
+	c.Assert(schema.HandleDDL(job), check.IsNil)
+	c.Assert(schema.AllPhysicalTables(), check.HasLen, 2)
+	job = helper.DDL2Job("alter table test.t1 add column c1 int")
+	event, err = schema.BuildDDLEvent(job)
+	c.Assert(err, check.IsNil)
+	c.Assert(event.Query, check.Equals, "alter table test.t1 add column c1 int")
+	replicaConfig.DDL.AllowList = []string{"create table"}
+	event, err = schema.BuildDDLEvent(job)
+	c.Assert(err, check.IsNil)
+	c.Assert(event, check.IsNil)
+}
+
 func (s *schemaSuite) TestSinkTableInfos(c *check.C) {
 	defer testleak.AfterTest(c)()
 	helper := entry.NewSchemaTestHelper(c)
@@ -0,0 +1,151 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sink"
+	"github.com/pingcap/ticdc/pkg/config"
+	"go.uber.org/zap"
+)
+
+const sinkProbeTimeout = 30 * time.Second
+
+type sinkProbeFunc func(ctx context.Context, sinkURI string, cfg *config.ReplicaConfig, opts map[string]string) error
+
+// sinkFailover probes the health of the sink of a changefeed with a standby
+// sink, and decides when the changefeed fails over to the standby sink. The
+// probes run in the background, so that the owner is never blocked by them.
+type sinkFailover struct {
+	changefeedID model.ChangeFeedID
+	probe        sinkProbeFunc
+	now          func() time.Time
+
+	mu            sync.Mutex
+	probing       bool
+	lastProbeTime time.Time
+	// sinkURI is the probed sink, the probe results are reset when it changes
+	sinkURI        string
+	unhealthySince time.Time
+	lastErr        error
+	// ready is set if the sink has been unhealthy beyond the threshold and
+	// the standby sink is healthy
+	ready bool
+}
+
+func newSinkFailover(id model.ChangeFeedID) *sinkFailover {
+	return &sinkFailover{
+		changefeedID: id,
+		probe:        sink.Validate,
+		now:          time.Now,
+	}
+}
+
+// tick starts probing the sinks if it is time to, and returns whether the
+// changefeed should fail over to the standby sink and the reason.
+func (f *sinkFailover) tick(info *model.ChangeFeedInfo) (failover bool, reason string) {
+	if info.StandbySinkURI == "" {
+		return false, ""
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.sinkURI != info.SinkURI {
+		f.sinkURI = info.SinkURI
+		f.unhealthySince = time.Time{}
+		f.lastErr = nil
+		f.ready = false
+	}
+	if f.ready {
+		f.ready = false
+		return true, f.lastErr.Error()
+	}
+	var failoverConfig *config.FailoverConfig
+	if info.Config != nil && info.Config.Sink != nil {
+		failoverConfig = info.Config.Sink.Failover
+	}
+	now := f.now()
+	if f.probing || now.Sub(f.lastProbeTime) < failoverConfig.GetProbeInterval() {
+		return false, ""
+	}
+	f.probing = true
+	f.lastProbeTime = now
+	opts := make(map[string]string, len(info.Opts))
+	for k, v := range info.Opts {
+		opts[k] = v
+	}
+	go f.probeSinks(info.SinkURI, info.StandbySinkURI, info.Config.Clone(), opts, failoverConfig.GetThreshold())
+	return false, ""
+}
+
+// probeSinks probes the sink, and probes the standby sink if the sink has
+// been unhealthy beyond the threshold.
+func (f *sinkFailover) probeSinks(
+	sinkURI, standbySinkURI string, cfg *config.ReplicaConfig, opts map[string]string, threshold time.Duration,
+) {
+	err := f.probeSink(sinkURI, cfg, opts)
+
+	f.mu.Lock()
+	if f.sinkURI != sinkURI {
+		f.probing = false
+		f.mu.Unlock()
+		return
+	}
+	if err == nil {
+		if !f.unhealthySince.IsZero() {
+			log.Info("the sink of changefeed is recovered", zap.String("changefeed", f.changefeedID),
+				zap.Duration("unhealthyDuration", f.now().Sub(f.unhealthySince)))
+		}
+		f.unhealthySince = time.Time{}
+		f.lastErr = nil
+		f.probing = false
+		f.mu.Unlock()
+		return
+	}
+	if f.unhealthySince.IsZero() {
+		f.unhealthySince = f.now()
+	}
+	f.lastErr = err
+	unhealthyDuration := f.now().Sub(f.unhealthySince)
+	log.Warn("the sink of changefeed is unhealthy", zap.String("changefeed", f.changefeedID),
+		zap.Duration("unhealthyDuration", unhealthyDuration), zap.Duration("threshold", threshold), zap.Error(err))
+	if unhealthyDuration < threshold {
+		f.probing = false
+		f.mu.Unlock()
+		return
+	}
+	f.mu.Unlock()
+
+	standbyErr := f.probeSink(standbySinkURI, cfg, opts)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.probing = false
+	if standbyErr != nil {
+		log.Warn("the standby sink of changefeed is unhealthy, can not fail over to it",
+			zap.String("changefeed", f.changefeedID), zap.Error(standbyErr))
+		return
+	}
+	f.ready = f.sinkURI == sinkURI
+}
+
+func (f *sinkFailover) probeSink(sinkURI string, cfg *config.ReplicaConfig, opts map[string]string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sinkProbeTimeout)
+	defer cancel()
+	return f.probe(ctx, sinkURI, cfg, opts)
+}
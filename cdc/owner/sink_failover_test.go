@@ -0,0 +1,120 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+var _ = check.Suite(&sinkFailoverSuite{})
+
+type sinkFailoverSuite struct{}
+
+func (s *sinkFailoverSuite) TestFailover(c *check.C) {
+	defer testleak.AfterTest(c)()
+	const (
+		sinkURI        = "kafka://127.0.0.1:9092/topic"
+		standbySinkURI = "kafka://127.0.0.2:9092/topic"
+	)
+	var (
+		mu        sync.Mutex
+		unhealthy = map[string]bool{sinkURI: true, standbySinkURI: true}
+	)
+	failover := newSinkFailover("test-changefeed")
+	failover.probe = func(ctx context.Context, sinkURI string, cfg *config.ReplicaConfig, opts map[string]string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if unhealthy[sinkURI] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	now := time.Now()
+	failover.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+	// tick waits for the probes started by it to finish
+	tick := func(info *model.ChangeFeedInfo) (bool, string) {
+		ok, reason := failover.tick(info)
+		for {
+			failover.mu.Lock()
+			probing := failover.probing
+			failover.mu.Unlock()
+			if !probing {
+				return ok, reason
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	info := &model.ChangeFeedInfo{
+		SinkURI: sinkURI,
+		Config: &config.ReplicaConfig{Sink: &config.SinkConfig{
+			Failover: &config.FailoverConfig{Threshold: 60, ProbeInterval: 10},
+		}},
+	}
+	// no standby sink, never probed
+	ok, _ := tick(info)
+	c.Assert(ok, check.IsFalse)
+	c.Assert(failover.lastProbeTime.IsZero(), check.IsTrue)
+
+	info.StandbySinkURI = standbySinkURI
+	ok, _ = tick(info)
+	c.Assert(ok, check.IsFalse)
+	// unhealthy within the threshold
+	advance(30 * time.Second)
+	ok, _ = tick(info)
+	c.Assert(ok, check.IsFalse)
+	// unhealthy beyond the threshold, but the standby sink is unhealthy too
+	advance(30 * time.Second)
+	ok, _ = tick(info)
+	c.Assert(ok, check.IsFalse)
+	ok, _ = tick(info)
+	c.Assert(ok, check.IsFalse)
+
+	// the standby sink is recovered
+	mu.Lock()
+	unhealthy[standbySinkURI] = false
+	mu.Unlock()
+	advance(10 * time.Second)
+	ok, _ = tick(info)
+	c.Assert(ok, check.IsFalse)
+	ok, reason := tick(info)
+	c.Assert(ok, check.IsTrue)
+	c.Assert(reason, check.Equals, "connection refused")
+
+	// the probe results are reset after the sink is switched
+	info.SwitchSink(100, reason)
+	advance(10 * time.Second)
+	ok, _ = tick(info)
+	c.Assert(ok, check.IsFalse)
+	ok, _ = tick(info)
+	c.Assert(ok, check.IsFalse)
+	c.Assert(failover.unhealthySince.IsZero(), check.IsTrue)
+}
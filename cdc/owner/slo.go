@@ -0,0 +1,156 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const sloWebhookTimeout = 5 * time.Second
+
+// Events of SLO notifications
+const (
+	sloEventViolated  = "violated"
+	sloEventRecovered = "recovered"
+)
+
+// sloNotification is the payload posted to the SLO webhook.
+type sloNotification struct {
+	Event string         `json:"event"`
+	Time  model.JSONTime `json:"time"`
+	*model.ChangefeedSLOStatus
+}
+
+// sloChecker evaluates the checkpoint lag of a changefeed against the SLO,
+// the violation state is exposed by metrics and the owner status provider.
+type sloChecker struct {
+	config *config.SLOConfig
+	status model.ChangefeedSLOStatus
+	notify func(n *sloNotification)
+
+	metricsViolatedGauge    prometheus.Gauge
+	metricsViolationCounter prometheus.Counter
+}
+
+// newSLOChecker creates a sloChecker, the violation state of the previous
+// checker is inherited so that a restarted changefeed doesn't notify again.
+func newSLOChecker(id model.ChangeFeedID, cfg *config.SLOConfig, prev *sloChecker) *sloChecker {
+	s := &sloChecker{
+		config: cfg,
+		status: model.ChangefeedSLOStatus{
+			ID:               id,
+			MaxCheckpointLag: cfg.MaxCheckpointLag,
+		},
+		metricsViolatedGauge:    changefeedSLOViolatedGauge.WithLabelValues(id),
+		metricsViolationCounter: changefeedSLOViolationCounter.WithLabelValues(id),
+	}
+	if prev != nil {
+		s.status.CheckpointLag = prev.status.CheckpointLag
+		s.status.Violated = prev.status.Violated
+		s.status.ViolatedSince = prev.status.ViolatedSince
+	}
+	s.notify = s.postWebhook
+	return s
+}
+
+// check evaluates the checkpoint lag against the SLO.
+func (s *sloChecker) check(lag time.Duration, now time.Time) {
+	if s == nil {
+		return
+	}
+	s.status.CheckpointLag = lag.Seconds()
+	violated := lag > time.Duration(s.config.MaxCheckpointLag)*time.Second
+	switch {
+	case violated && !s.status.Violated:
+		since := model.JSONTime(now)
+		s.status.Violated = true
+		s.status.ViolatedSince = &since
+		s.metricsViolationCounter.Inc()
+		log.Warn("the replication lag SLO of changefeed is violated",
+			zap.String("changefeed", s.status.ID),
+			zap.Duration("checkpointLag", lag),
+			zap.Int64("maxCheckpointLag", s.config.MaxCheckpointLag))
+		s.notify(&sloNotification{Event: sloEventViolated, Time: model.JSONTime(now), ChangefeedSLOStatus: s.getStatus()})
+	case !violated && s.status.Violated:
+		s.status.Violated = false
+		s.status.ViolatedSince = nil
+		log.Info("the replication lag SLO of changefeed is recovered",
+			zap.String("changefeed", s.status.ID),
+			zap.Duration("checkpointLag", lag))
+		s.notify(&sloNotification{Event: sloEventRecovered, Time: model.JSONTime(now), ChangefeedSLOStatus: s.getStatus()})
+	}
+	if s.status.Violated {
+		s.metricsViolatedGauge.Set(1)
+	} else {
+		s.metricsViolatedGauge.Set(0)
+	}
+}
+
+// getStatus returns a copy of the SLO status.
+func (s *sloChecker) getStatus() *model.ChangefeedSLOStatus {
+	status := s.status
+	return &status
+}
+
+// postWebhook posts the notification to the webhook asynchronously.
+func (s *sloChecker) postWebhook(n *sloNotification) {
+	if s.config.WebhookURL == "" {
+		return
+	}
+	data, err := json.Marshal(n)
+	if err != nil {
+		log.Warn("marshal SLO notification failed", zap.String("changefeed", n.ID), zap.Error(err))
+		return
+	}
+	url := s.config.WebhookURL
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), sloWebhookTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+		if err != nil {
+			log.Warn("create SLO webhook request failed", zap.String("changefeed", n.ID), zap.Error(err))
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			log.Warn("post SLO webhook failed", zap.String("changefeed", n.ID), zap.Error(err))
+			return
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode/100 != 2 {
+			log.Warn("SLO webhook responded with an unexpected status",
+				zap.String("changefeed", n.ID), zap.Int("status", resp.StatusCode))
+		}
+	}()
+}
+
+// releaseMetrics removes the metrics of the changefeed.
+func (s *sloChecker) releaseMetrics() {
+	if s == nil {
+		return
+	}
+	changefeedSLOViolatedGauge.DeleteLabelValues(s.status.ID)
+	changefeedSLOViolationCounter.DeleteLabelValues(s.status.ID)
+}
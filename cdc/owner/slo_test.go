@@ -0,0 +1,104 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+var _ = check.Suite(&sloSuite{})
+
+type sloSuite struct{}
+
+func (s *sloSuite) TestCheck(c *check.C) {
+	defer testleak.AfterTest(c)()
+	checker := newSLOChecker("test-changefeed", &config.SLOConfig{MaxCheckpointLag: 30}, nil)
+	defer checker.releaseMetrics()
+	var notifications []*sloNotification
+	checker.notify = func(n *sloNotification) {
+		notifications = append(notifications, n)
+	}
+
+	now := time.Now()
+	checker.check(10*time.Second, now)
+	c.Assert(checker.getStatus().Violated, check.IsFalse)
+	c.Assert(notifications, check.HasLen, 0)
+
+	checker.check(40*time.Second, now.Add(time.Second))
+	status := checker.getStatus()
+	c.Assert(status.Violated, check.IsTrue)
+	c.Assert(status.CheckpointLag, check.Equals, float64(40))
+	c.Assert(time.Time(*status.ViolatedSince).Equal(now.Add(time.Second)), check.IsTrue)
+	c.Assert(notifications, check.HasLen, 1)
+	c.Assert(notifications[0].Event, check.Equals, sloEventViolated)
+
+	// keep violating, no more notification
+	checker.check(50*time.Second, now.Add(2*time.Second))
+	c.Assert(checker.getStatus().ViolatedSince, check.DeepEquals, status.ViolatedSince)
+	c.Assert(notifications, check.HasLen, 1)
+
+	// the violation state is inherited by the new checker
+	checker = newSLOChecker("test-changefeed", &config.SLOConfig{MaxCheckpointLag: 30}, checker)
+	checker.notify = func(n *sloNotification) {
+		notifications = append(notifications, n)
+	}
+	checker.check(50*time.Second, now.Add(3*time.Second))
+	c.Assert(notifications, check.HasLen, 1)
+
+	checker.check(20*time.Second, now.Add(4*time.Second))
+	status = checker.getStatus()
+	c.Assert(status.Violated, check.IsFalse)
+	c.Assert(status.ViolatedSince, check.IsNil)
+	c.Assert(notifications, check.HasLen, 2)
+	c.Assert(notifications[1].Event, check.Equals, sloEventRecovered)
+
+	// nil checker is a no-op
+	var nilChecker *sloChecker
+	nilChecker.check(time.Hour, now)
+	nilChecker.releaseMetrics()
+}
+
+func (s *sloSuite) TestWebhook(c *check.C) {
+	defer testleak.AfterTest(c)()
+	received := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		c.Assert(err, check.IsNil)
+		var payload map[string]interface{}
+		c.Assert(json.Unmarshal(body, &payload), check.IsNil)
+		received <- payload
+	}))
+	defer server.Close()
+
+	checker := newSLOChecker("test-changefeed", &config.SLOConfig{MaxCheckpointLag: 30, WebhookURL: server.URL}, nil)
+	defer checker.releaseMetrics()
+	checker.check(time.Minute, time.Now())
+	select {
+	case payload := <-received:
+		c.Assert(payload["event"], check.Equals, sloEventViolated)
+		c.Assert(payload["id"], check.Equals, "test-changefeed")
+		c.Assert(payload["violated"], check.Equals, true)
+		c.Assert(payload["max_checkpoint_lag"], check.Equals, float64(30))
+	case <-time.After(10 * time.Second):
+		c.Fatal("webhook is not notified")
+	}
+}
@@ -47,6 +47,19 @@ type StatusProvider interface {
 
 	// GetCaptures returns the information about all captures.
 	GetCaptures(ctx context.Context) ([]*model.CaptureInfo, error)
+
+	// GetChangeFeedSLOStatus returns the lag SLO status of a changefeed.
+	GetChangeFeedSLOStatus(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangefeedSLOStatus, error)
+
+	// GetChangeFeedChecksumStatus returns the result of the last consistency
+	// check of a changefeed.
+	GetChangeFeedChecksumStatus(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangefeedChecksumStatus, error)
+
+	// GetChangeFeedDDLBarrier returns the DDL which blocks a changefeed as a barrier.
+	GetChangeFeedDDLBarrier(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangefeedDDLBarrier, error)
+
+	// GetDrainCaptureStatus returns the status of draining a capture.
+	GetDrainCaptureStatus(ctx context.Context, captureID model.CaptureID) (*model.DrainCaptureStatus, error)
 }
 
 type ownerQueryType int32
@@ -58,11 +71,16 @@ const (
 	ownerQueryTaskPositions
 	ownerQueryProcessors
 	ownerQueryCaptures
+	ownerQueryChangeFeedSLOStatus
+	ownerQueryDrainCaptureStatus
+	ownerQueryChangeFeedChecksumStatus
+	ownerQueryChangeFeedDDLBarrier
 )
 
 type ownerQuery struct {
 	tp           ownerQueryType
 	changeFeedID model.ChangeFeedID
+	captureID    model.CaptureID
 
 	data interface{}
 	err  error
@@ -158,6 +176,50 @@ func (p *ownerStatusProvider) GetCaptures(ctx context.Context) ([]*model.Capture
 	return query.data.([]*model.CaptureInfo), nil
 }
 
+func (p *ownerStatusProvider) GetChangeFeedSLOStatus(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangefeedSLOStatus, error) {
+	query := &ownerQuery{
+		tp:           ownerQueryChangeFeedSLOStatus,
+		changeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.data.(*model.ChangefeedSLOStatus), nil
+}
+
+func (p *ownerStatusProvider) GetChangeFeedChecksumStatus(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangefeedChecksumStatus, error) {
+	query := &ownerQuery{
+		tp:           ownerQueryChangeFeedChecksumStatus,
+		changeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.data.(*model.ChangefeedChecksumStatus), nil
+}
+
+func (p *ownerStatusProvider) GetChangeFeedDDLBarrier(ctx context.Context, changefeedID model.ChangeFeedID) (*model.ChangefeedDDLBarrier, error) {
+	query := &ownerQuery{
+		tp:           ownerQueryChangeFeedDDLBarrier,
+		changeFeedID: changefeedID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.data.(*model.ChangefeedDDLBarrier), nil
+}
+
+func (p *ownerStatusProvider) GetDrainCaptureStatus(ctx context.Context, captureID model.CaptureID) (*model.DrainCaptureStatus, error) {
+	query := &ownerQuery{
+		tp:        ownerQueryDrainCaptureStatus,
+		captureID: captureID,
+	}
+	if err := p.sendQueryToOwner(ctx, query); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return query.data.(*model.DrainCaptureStatus), nil
+}
+
 func (p *ownerStatusProvider) sendQueryToOwner(ctx context.Context, query *ownerQuery) error {
 	doneCh := make(chan struct{})
 	job := &ownerJob{
@@ -17,12 +17,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"runtime"
 	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/upstream"
 	cdcContext "github.com/pingcap/ticdc/pkg/context"
 	cerrors "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/orchestrator"
@@ -35,6 +37,8 @@ const (
 	commandTpUnknow commandTp = iota //nolint:varcheck,deadcode
 	commandTpClose
 	commandTpWriteDebugInfo
+	commandTpQueryTableStatus
+	commandTpQueryResourceUsage
 )
 
 type command struct {
@@ -46,9 +50,15 @@ type command struct {
 // Manager is a manager of processor, which maintains the state and behavior of processors
 type Manager struct {
 	processors map[model.ChangeFeedID]*processor
+	// upstreams are the upstreams held by the processors, which are acquired
+	// from the upstreamManager in the GlobalVars.
+	upstreams       map[model.ChangeFeedID]*upstream.Upstream
+	upstreamManager *upstream.Manager
 
 	commandQueue chan *command
 
+	usageSampler *resourceUsageSampler
+
 	newProcessor func(cdcContext.Context) *processor
 }
 
@@ -56,7 +66,9 @@ type Manager struct {
 func NewManager() *Manager {
 	return &Manager{
 		processors:   make(map[model.ChangeFeedID]*processor),
+		upstreams:    make(map[model.ChangeFeedID]*upstream.Upstream),
 		commandQueue: make(chan *command, 4),
+		usageSampler: newResourceUsageSampler(),
 		newProcessor: newProcessor,
 	}
 }
@@ -78,10 +90,6 @@ func (m *Manager) Tick(stdCtx context.Context, state orchestrator.ReactorState)
 			m.closeProcessor(changefeedID)
 			continue
 		}
-		ctx := cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
-			ID:   changefeedID,
-			Info: changefeedState.Info,
-		})
 		processor, exist := m.processors[changefeedID]
 		if !exist {
 			if changefeedState.Status.AdminJobType.IsStopState() || changefeedState.TaskStatuses[captureID].AdminJobType.IsStopState() {
@@ -92,6 +100,17 @@ func (m *Manager) Tick(stdCtx context.Context, state orchestrator.ReactorState)
 			if taskStatus == nil || (len(taskStatus.Tables) == 0 && len(taskStatus.Operation) == 0) {
 				continue
 			}
+			// the processor should start after the clients of its upstream are created
+			if !m.acquireUpstream(ctx, changefeedID, changefeedState.Info) {
+				continue
+			}
+		}
+		ctx := cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
+			ID:       changefeedID,
+			Info:     changefeedState.Info,
+			Upstream: m.upstreams[changefeedID],
+		})
+		if !exist {
 			failpoint.Inject("processorManagerHandleNewChangefeedDelay", nil)
 			processor = m.newProcessor(ctx)
 			m.processors[changefeedID] = processor
@@ -112,9 +131,52 @@ func (m *Manager) Tick(stdCtx context.Context, state orchestrator.ReactorState)
 			}
 		}
 	}
+	for changefeedID := range m.upstreams {
+		if _, exist := globalState.Changefeeds[changefeedID]; !exist {
+			m.closeProcessor(changefeedID)
+		}
+	}
+	m.sampleResourceUsage(time.Now())
 	return state, nil
 }
 
+// sampleResourceUsage samples the resource usage of the changefeeds every
+// resourceUsageSampleInterval.
+func (m *Manager) sampleResourceUsage(now time.Time) {
+	if !m.usageSampler.due(now) {
+		return
+	}
+	cpuTime, err := processCPUTime()
+	if err != nil {
+		log.Warn("failed to get the cpu time of the process", zap.Error(err))
+		return
+	}
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	usages := make(map[model.ChangeFeedID]pipelineUsage, len(m.processors))
+	for changefeedID, processor := range m.processors {
+		usages[changefeedID] = processor.pipelineUsage()
+	}
+	m.usageSampler.sample(now, cpuTime, memStats.HeapInuse, usages)
+}
+
+// acquireUpstream acquires the upstream of the changefeed if it doesn't hold
+// one, and returns whether the upstream is ready.
+func (m *Manager) acquireUpstream(ctx cdcContext.Context, changefeedID model.ChangeFeedID, info *model.ChangeFeedInfo) bool {
+	upstreamManager := ctx.GlobalVars().UpstreamManager
+	if upstreamManager == nil {
+		// only in the test, the clients in the GlobalVars are used
+		return true
+	}
+	m.upstreamManager = upstreamManager
+	up, exist := m.upstreams[changefeedID]
+	if !exist {
+		up = upstreamManager.Acquire(info.Upstream)
+		m.upstreams[changefeedID] = up
+	}
+	return up.IsReady()
+}
+
 func (m *Manager) closeProcessor(changefeedID model.ChangeFeedID) {
 	if processor, exist := m.processors[changefeedID]; exist {
 		err := processor.Close()
@@ -123,6 +185,10 @@ func (m *Manager) closeProcessor(changefeedID model.ChangeFeedID) {
 		}
 		delete(m.processors, changefeedID)
 	}
+	if up, exist := m.upstreams[changefeedID]; exist {
+		m.upstreamManager.Release(up)
+		delete(m.upstreams, changefeedID)
+	}
 }
 
 // AsyncClose sends a close signal to Manager and closing all processors
@@ -142,6 +208,47 @@ func (m *Manager) WriteDebugInfo(w io.Writer) {
 	}
 }
 
+type resourceUsageQuery struct {
+	status *model.ResourceUsageStatus
+}
+
+type tableStatusQuery struct {
+	changefeedID model.ChangeFeedID
+	tableID      model.TableID
+	status       *model.TablePipelineStatus
+}
+
+// QueryTableStatus returns the in-process status of the table pipeline, nil is
+// returned if the table is not replicated by this capture.
+func (m *Manager) QueryTableStatus(changefeedID model.ChangeFeedID, tableID model.TableID) (*model.TablePipelineStatus, error) {
+	timeout := time.Second * 3
+	query := &tableStatusQuery{changefeedID: changefeedID, tableID: tableID}
+	done := m.sendCommand(commandTpQueryTableStatus, query)
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		return nil, cerrors.ErrProcessorQueryTimeout.GenWithStackByArgs()
+	}
+	return query.status, nil
+}
+
+// QueryResourceUsage returns the approximate resource usage of the
+// changefeeds, which is sampled every resourceUsageSampleInterval.
+func (m *Manager) QueryResourceUsage() (*model.ResourceUsageStatus, error) {
+	timeout := time.Second * 3
+	query := &resourceUsageQuery{}
+	done := m.sendCommand(commandTpQueryResourceUsage, query)
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		return nil, cerrors.ErrProcessorQueryTimeout.GenWithStackByArgs()
+	}
+	if query.status == nil {
+		return nil, cerrors.ErrProcessorQueryTimeout.GenWithStackByArgs()
+	}
+	return query.status, nil
+}
+
 func (m *Manager) sendCommand(tp commandTp, payload interface{}) chan struct{} {
 	timeout := time.Second * 3
 	cmd := &command{tp: tp, payload: payload, done: make(chan struct{})}
@@ -171,6 +278,14 @@ func (m *Manager) handleCommand() error {
 	case commandTpWriteDebugInfo:
 		w := cmd.payload.(io.Writer)
 		m.writeDebugInfo(w)
+	case commandTpQueryTableStatus:
+		query := cmd.payload.(*tableStatusQuery)
+		if processor, exist := m.processors[query.changefeedID]; exist {
+			query.status = processor.tableSnapshot(query.tableID)
+		}
+	case commandTpQueryResourceUsage:
+		query := cmd.payload.(*resourceUsageQuery)
+		query.status = m.usageSampler.snapshot()
 	default:
 		log.Warn("Unknown command in processor manager", zap.Any("command", cmd))
 	}
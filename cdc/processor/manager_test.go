@@ -179,6 +179,119 @@ func (s *managerSuite) TestDebugInfo(c *check.C) {
 	<-done
 }
 
+func (s *managerSuite) TestQueryTableStatus(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := cdcContext.NewBackendContext4Test(false)
+	s.resetSuit(ctx, c)
+
+	s.state.Changefeeds["test-changefeed"] = orchestrator.NewChangefeedReactorState("test-changefeed")
+	s.state.Changefeeds["test-changefeed"].PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		return &model.ChangeFeedInfo{
+			SinkURI:    "blackhole://",
+			CreateTime: time.Now(),
+			StartTs:    0,
+			TargetTs:   math.MaxUint64,
+			Config:     config.GetDefaultReplicaConfig(),
+		}, true, nil
+	})
+	s.state.Changefeeds["test-changefeed"].PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	s.state.Changefeeds["test-changefeed"].PatchTaskStatus(ctx.GlobalVars().CaptureInfo.ID, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
+		return &model.TaskStatus{
+			Tables: map[int64]*model.TableReplicaInfo{1: {StartTs: 10}},
+		}, true, nil
+	})
+	s.tester.MustApplyPatches()
+	// the tables are added after the processor is initialized
+	for i := 0; i < 2; i++ {
+		_, err := s.manager.Tick(ctx, s.state)
+		c.Assert(err, check.IsNil)
+		s.tester.MustApplyPatches()
+	}
+	c.Assert(s.manager.processors, check.HasLen, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			_, err := s.manager.Tick(ctx, s.state)
+			if err != nil {
+				c.Assert(cerrors.ErrReactorFinished.Equal(errors.Cause(err)), check.IsTrue)
+				return
+			}
+			s.tester.MustApplyPatches()
+		}
+	}()
+
+	status, err := s.manager.QueryTableStatus("test-changefeed", 1)
+	c.Assert(err, check.IsNil)
+	c.Assert(status.TableID, check.Equals, int64(1))
+	c.Assert(status.TableName, check.Equals, "`test`.`table1`")
+	c.Assert(status.Status, check.Equals, tablepipeline.TableStatusRunning.String())
+	c.Assert(status.CheckpointTs, check.Equals, uint64(10))
+	// the table is not replicated by this capture
+	status, err = s.manager.QueryTableStatus("test-changefeed", 2)
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.IsNil)
+	status, err = s.manager.QueryTableStatus("unknown-changefeed", 1)
+	c.Assert(err, check.IsNil)
+	c.Assert(status, check.IsNil)
+	s.manager.AsyncClose()
+	<-done
+}
+
+func (s *managerSuite) TestQueryResourceUsage(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := cdcContext.NewBackendContext4Test(false)
+	s.resetSuit(ctx, c)
+
+	s.state.Changefeeds["test-changefeed"] = orchestrator.NewChangefeedReactorState("test-changefeed")
+	s.state.Changefeeds["test-changefeed"].PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
+		return &model.ChangeFeedInfo{
+			SinkURI:    "blackhole://",
+			CreateTime: time.Now(),
+			StartTs:    0,
+			TargetTs:   math.MaxUint64,
+			Config:     config.GetDefaultReplicaConfig(),
+		}, true, nil
+	})
+	s.state.Changefeeds["test-changefeed"].PatchStatus(func(status *model.ChangeFeedStatus) (*model.ChangeFeedStatus, bool, error) {
+		return &model.ChangeFeedStatus{}, true, nil
+	})
+	s.state.Changefeeds["test-changefeed"].PatchTaskStatus(ctx.GlobalVars().CaptureInfo.ID, func(status *model.TaskStatus) (*model.TaskStatus, bool, error) {
+		return &model.TaskStatus{
+			Tables: map[int64]*model.TableReplicaInfo{1: {StartTs: 10}},
+		}, true, nil
+	})
+	s.tester.MustApplyPatches()
+	for i := 0; i < 2; i++ {
+		_, err := s.manager.Tick(ctx, s.state)
+		c.Assert(err, check.IsNil)
+		s.tester.MustApplyPatches()
+	}
+	c.Assert(s.manager.processors, check.HasLen, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			_, err := s.manager.Tick(ctx, s.state)
+			if err != nil {
+				c.Assert(cerrors.ErrReactorFinished.Equal(errors.Cause(err)), check.IsTrue)
+				return
+			}
+			s.tester.MustApplyPatches()
+		}
+	}()
+
+	status, err := s.manager.QueryResourceUsage()
+	c.Assert(err, check.IsNil)
+	c.Assert(status.HeapInUse, check.Greater, uint64(0))
+	c.Assert(status.Changefeeds, check.HasLen, 1)
+	c.Assert(status.Changefeeds[0].ChangefeedID, check.Equals, "test-changefeed")
+	s.manager.AsyncClose()
+	<-done
+}
+
 func (s *managerSuite) TestClose(c *check.C) {
 	defer testleak.AfterTest(c)()
 	ctx := cdcContext.NewBackendContext4Test(false)
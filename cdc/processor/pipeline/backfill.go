@@ -0,0 +1,235 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"container/list"
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/entry"
+	"github.com/pingcap/ticdc/cdc/model"
+)
+
+// tableInfoGetter returns the table info of the given table at the given ts.
+type tableInfoGetter func(ctx context.Context, ts model.Ts, tableID model.TableID) (*model.TableInfo, error)
+
+func newSchemaStorageTableInfoGetter(schemaStorage entry.SchemaStorage) tableInfoGetter {
+	return func(ctx context.Context, ts model.Ts, tableID model.TableID) (*model.TableInfo, error) {
+		snap, err := schemaStorage.GetSnapshot(ctx, ts)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tableInfo, ok := snap.PhysicalTableByID(tableID)
+		if !ok {
+			return nil, errors.Errorf("table %d not found at ts %d", tableID, ts)
+		}
+		return tableInfo, nil
+	}
+}
+
+// addColumnBackfiller remembers the latest after-image of the recently
+// changed rows of a table. Once a row with newly added columns is seen, it
+// generates update events which fill the default values of the new columns
+// into the remembered rows, so that downstream consumers see the new columns
+// for the recently changed keys without waiting for them to be updated.
+//
+// Only rows within the window are backfilled, rows which are not changed
+// recently are left to the downstream.
+type addColumnBackfiller struct {
+	window         int
+	enableOldValue bool
+	getTableInfo   tableInfoGetter
+
+	// rows is ordered from the most recently changed row to the oldest one.
+	rows *list.List
+	keys map[string]*list.Element
+
+	tableInfoVersion uint64
+	columns          map[string]struct{}
+}
+
+type backfillRow struct {
+	key     string
+	columns []*model.Column
+}
+
+func newAddColumnBackfiller(window int, enableOldValue bool, getTableInfo tableInfoGetter) *addColumnBackfiller {
+	return &addColumnBackfiller{
+		window:         window,
+		enableOldValue: enableOldValue,
+		getTableInfo:   getTableInfo,
+		rows:           list.New(),
+		keys:           make(map[string]*list.Element),
+	}
+}
+
+// backfill returns the backfilling events which should be emitted before the
+// given event, and remembers the given event.
+func (b *addColumnBackfiller) backfill(ctx context.Context, event *model.PolymorphicEvent) ([]*model.PolymorphicEvent, error) {
+	if b == nil {
+		return nil, nil
+	}
+	row := event.Row
+	var events []*model.PolymorphicEvent
+	if !row.IsDelete() {
+		var err error
+		events, err = b.checkColumns(ctx, row)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	b.remember(row)
+	return events, nil
+}
+
+// checkColumns generates the backfilling events if the row contains columns
+// which are never seen before.
+func (b *addColumnBackfiller) checkColumns(ctx context.Context, row *model.RowChangedEvent) ([]*model.PolymorphicEvent, error) {
+	if row.TableInfoVersion == b.tableInfoVersion && b.columns != nil {
+		return nil, nil
+	}
+	columns := make(map[string]struct{}, len(row.Columns))
+	added := false
+	for _, col := range row.Columns {
+		if col == nil {
+			continue
+		}
+		columns[col.Name] = struct{}{}
+		if _, ok := b.columns[col.Name]; !ok {
+			added = true
+		}
+	}
+	isFirstRow := b.columns == nil
+	b.tableInfoVersion = row.TableInfoVersion
+	b.columns = columns
+	if isFirstRow || !added || b.rows.Len() == 0 {
+		return nil, nil
+	}
+
+	tableInfo, err := b.getTableInfo(ctx, row.CommitTs, row.Table.TableID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defaults := make(map[string]interface{}, len(tableInfo.Columns))
+	for _, colInfo := range tableInfo.Columns {
+		if !model.IsColCDCVisible(colInfo) {
+			continue
+		}
+		// the existing rows take the origin default value of the added
+		// columns, it's the same as the default of the DDL event.
+		defaults[colInfo.Name.O], err = entry.GetOriginDefaultValue(colInfo)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	events := make([]*model.PolymorphicEvent, 0, b.rows.Len())
+	for e := b.rows.Back(); e != nil; e = e.Prev() {
+		cached := e.Value.(*backfillRow)
+		cachedValues := make(map[string]*model.Column, len(cached.columns))
+		for _, col := range cached.columns {
+			cachedValues[col.Name] = col
+		}
+		cols := make([]*model.Column, len(row.Columns))
+		for i, col := range row.Columns {
+			if col == nil {
+				continue
+			}
+			value := defaults[col.Name]
+			if cachedCol, ok := cachedValues[col.Name]; ok {
+				value = cachedCol.Value
+			}
+			cols[i] = &model.Column{
+				Name:  col.Name,
+				Type:  col.Type,
+				Flag:  col.Flag,
+				Value: value,
+			}
+		}
+		filled := &model.RowChangedEvent{
+			StartTs:          row.StartTs,
+			CommitTs:         row.CommitTs,
+			Table:            row.Table,
+			TableInfoVersion: row.TableInfoVersion,
+			IndexColumns:     row.IndexColumns,
+			Columns:          cols,
+		}
+		if b.enableOldValue {
+			filled.PreColumns = cached.columns
+		}
+		event := model.NewPolymorphicEvent(&model.RawKVEntry{
+			OpType:  model.OpTypePut,
+			StartTs: row.StartTs,
+			CRTs:    row.CommitTs,
+		})
+		event.Row = filled
+		events = append(events, event)
+		cached.columns = cols
+	}
+	return events, nil
+}
+
+// remember records the after-image of the row, or forgets the row if it is
+// deleted.
+func (b *addColumnBackfiller) remember(row *model.RowChangedEvent) {
+	handleCols := row.HandleKeyColumns()
+	if len(handleCols) == 0 {
+		return
+	}
+	// The handle key may be changed by an update, forget the old one.
+	if len(row.PreColumns) != 0 {
+		b.forget(handleKey(preHandleKeyColumns(row)))
+	}
+	key := handleKey(handleCols)
+	if row.IsDelete() {
+		b.forget(key)
+		return
+	}
+	if e, ok := b.keys[key]; ok {
+		e.Value.(*backfillRow).columns = row.Columns
+		b.rows.MoveToFront(e)
+		return
+	}
+	b.keys[key] = b.rows.PushFront(&backfillRow{key: key, columns: row.Columns})
+	for b.rows.Len() > b.window {
+		b.forget(b.rows.Back().Value.(*backfillRow).key)
+	}
+}
+
+func (b *addColumnBackfiller) forget(key string) {
+	if e, ok := b.keys[key]; ok {
+		b.rows.Remove(e)
+		delete(b.keys, key)
+	}
+}
+
+func preHandleKeyColumns(row *model.RowChangedEvent) []*model.Column {
+	cols := make([]*model.Column, 0)
+	for _, col := range row.PreColumns {
+		if col != nil && col.Flag.IsHandleKey() {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+func handleKey(cols []*model.Column) string {
+	values := make([]string, 0, len(cols))
+	for _, col := range cols {
+		values = append(values, model.ColumnValueString(col.Value))
+	}
+	return strings.Join(values, "\x00")
+}
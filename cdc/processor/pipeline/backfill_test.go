@@ -0,0 +1,121 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+)
+
+type backfillSuite struct{}
+
+var _ = check.Suite(&backfillSuite{})
+
+func newBackfillTestRow(commitTs model.Ts, version uint64, id int64, extra ...*model.Column) *model.PolymorphicEvent {
+	cols := []*model.Column{{
+		Name:  "id",
+		Type:  mysql.TypeLong,
+		Flag:  model.HandleKeyFlag | model.PrimaryKeyFlag,
+		Value: id,
+	}}
+	cols = append(cols, extra...)
+	event := model.NewPolymorphicEvent(&model.RawKVEntry{OpType: model.OpTypePut, CRTs: commitTs})
+	event.Row = &model.RowChangedEvent{
+		CommitTs:         commitTs,
+		Table:            &model.TableName{Schema: "test", Table: "t", TableID: 1},
+		TableInfoVersion: version,
+		Columns:          cols,
+	}
+	return event
+}
+
+func (s *backfillSuite) TestAddColumnBackfiller(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := context.Background()
+	addedColInfo := &timodel.ColumnInfo{
+		Name:               timodel.NewCIStr("c"),
+		State:              timodel.StatePublic,
+		FieldType:          *types.NewFieldType(mysql.TypeLong),
+		DefaultValue:       "10",
+		OriginDefaultValue: "10",
+	}
+	addedColInfo.Flag = mysql.NotNullFlag
+	// a not null column without default value takes the zero value.
+	noDefaultColInfo := &timodel.ColumnInfo{
+		Name:      timodel.NewCIStr("d"),
+		State:     timodel.StatePublic,
+		FieldType: *types.NewFieldType(mysql.TypeLong),
+	}
+	noDefaultColInfo.Flag = mysql.NotNullFlag
+	tableInfo := &model.TableInfo{TableInfo: &timodel.TableInfo{
+		Columns: []*timodel.ColumnInfo{
+			{Name: timodel.NewCIStr("id"), State: timodel.StatePublic, FieldType: *types.NewFieldType(mysql.TypeLong)},
+			addedColInfo,
+			noDefaultColInfo,
+		},
+	}}
+	getTableInfo := func(ctx context.Context, ts model.Ts, tableID model.TableID) (*model.TableInfo, error) {
+		c.Assert(ts, check.Equals, model.Ts(5))
+		c.Assert(tableID, check.Equals, model.TableID(1))
+		return tableInfo, nil
+	}
+
+	var nilBackfiller *addColumnBackfiller
+	events, err := nilBackfiller.backfill(ctx, newBackfillTestRow(1, 1, 1))
+	c.Assert(err, check.IsNil)
+	c.Assert(events, check.HasLen, 0)
+
+	backfiller := newAddColumnBackfiller(2, true, getTableInfo)
+	for id := int64(1); id <= 3; id++ {
+		events, err = backfiller.backfill(ctx, newBackfillTestRow(model.Ts(id), 1, id))
+		c.Assert(err, check.IsNil)
+		c.Assert(events, check.HasLen, 0)
+	}
+	// Only the two most recently changed rows are remembered.
+	c.Assert(backfiller.rows.Len(), check.Equals, 2)
+
+	// Deleted rows are forgotten.
+	deleted := newBackfillTestRow(4, 1, 3)
+	deleted.Row.PreColumns, deleted.Row.Columns = deleted.Row.Columns, nil
+	events, err = backfiller.backfill(ctx, deleted)
+	c.Assert(err, check.IsNil)
+	c.Assert(events, check.HasLen, 0)
+	c.Assert(backfiller.rows.Len(), check.Equals, 1)
+
+	added := &model.Column{Name: "c", Type: mysql.TypeLong, Value: int64(20)}
+	addedNoDefault := &model.Column{Name: "d", Type: mysql.TypeLong, Value: int64(30)}
+	events, err = backfiller.backfill(ctx, newBackfillTestRow(5, 2, 4, added, addedNoDefault))
+	c.Assert(err, check.IsNil)
+	c.Assert(events, check.HasLen, 1)
+	row := events[0].Row
+	c.Assert(row.CommitTs, check.Equals, model.Ts(5))
+	c.Assert(row.Columns, check.HasLen, 3)
+	c.Assert(row.Columns[0].Value, check.Equals, int64(2))
+	c.Assert(row.Columns[1].Name, check.Equals, "c")
+	c.Assert(row.Columns[1].Value, check.Equals, int64(10))
+	c.Assert(row.Columns[2].Name, check.Equals, "d")
+	c.Assert(row.Columns[2].Value, check.Equals, int64(0))
+	c.Assert(row.PreColumns, check.HasLen, 1)
+
+	// The columns are not changed, nothing to backfill.
+	events, err = backfiller.backfill(ctx, newBackfillTestRow(6, 3, 5, added, addedNoDefault))
+	c.Assert(err, check.IsNil)
+	c.Assert(events, check.HasLen, 0)
+}
@@ -40,6 +40,21 @@ var (
 			Help:      "estimated memory consumption for a table after the sorter",
 			Buckets:   prometheus.ExponentialBuckets(1*1024*1024 /* mb */, 2, 10),
 		}, []string{"changefeed", "capture"})
+	eventStageLatencyHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "processor",
+			Name:      "event_stage_latency",
+			Help:      "latency of each table pipeline stage for sampled events",
+			Buckets:   prometheus.ExponentialBuckets(0.001 /* 1 ms */, 2, 18),
+		}, []string{"changefeed", "capture", "stage"})
+	filteredEventCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "processor",
+			Name:      "filtered_event_count",
+			Help:      "the number of the DML events dropped by the event filters",
+		}, []string{"changefeed", "capture", "type"})
 )
 
 // InitMetrics registers all metrics used in processor
@@ -47,4 +62,6 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(tableResolvedTsGauge)
 	registry.MustRegister(txnCounter)
 	registry.MustRegister(tableMemoryHistogram)
+	registry.MustRegister(eventStageLatencyHistogram)
+	registry.MustRegister(filteredEventCounter)
 }
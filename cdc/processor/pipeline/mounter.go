@@ -99,6 +99,9 @@ func (n *mounterNode) Init(ctx pipeline.NodeContext) error {
 								ctx.Throw(err)
 								return nil
 							}
+							if event.Trace != nil {
+								event.Trace.Mounted = time.Now()
+							}
 						}
 
 						ctx.SendToNextNode(msg)
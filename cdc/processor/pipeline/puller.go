@@ -17,6 +17,7 @@ import (
 	"context"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/entry"
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/puller"
 	cdcContext "github.com/pingcap/ticdc/pkg/context"
@@ -65,12 +66,19 @@ func (n *pullerNode) Init(ctx pipeline.NodeContext) error {
 	ctxC = util.PutChangefeedIDInCtx(ctxC, ctx.ChangefeedVars().ID)
 	// NOTICE: always pull the old value internally
 	// See also: https://github.com/pingcap/ticdc/issues/2301.
-	plr := puller.NewPuller(ctxC, ctx.GlobalVars().PDClient, ctx.GlobalVars().GrpcPool, ctx.GlobalVars().KVStorage,
+	up := cdcContext.GetUpstream(ctx)
+	plr := puller.NewPuller(ctxC, up.PDClient, up.GrpcPool, up.KVStorage,
 		n.replicaInfo.StartTs, n.tableSpan(ctx), true)
 	n.wg.Go(func() error {
 		ctx.Throw(errors.Trace(plr.Run(ctxC)))
 		return nil
 	})
+	var sampler *eventSampler
+	if traceRate, spanRate := eventSampleRates(ctx.ChangefeedVars().Info.Config); traceRate > 0 || spanRate > 0 {
+		sampler = newEventSampler(traceRate, spanRate)
+	}
+	// the oversized rows are rejected before they are sorted if possible
+	sizeChecker := entry.NewRawEntrySizeChecker(ctx.ChangefeedVars().Info.Config)
 	n.wg.Go(func() error {
 		for {
 			select {
@@ -82,8 +90,12 @@ func (n *pullerNode) Init(ctx pipeline.NodeContext) error {
 				}
 				if rawKV.OpType == model.OpTypeResolved {
 					metricTableResolvedTsGauge.Set(float64(oracle.ExtractPhysical(rawKV.CRTs)))
+				} else if err := sizeChecker.Check(n.tableName, rawKV); err != nil {
+					ctx.Throw(err)
+					return nil
 				}
 				pEvent := model.NewPolymorphicEvent(rawKV)
+				sampler.trace(pEvent)
 				ctx.SendToNextNode(pipeline.PolymorphicEventMessage(pEvent))
 			}
 		}
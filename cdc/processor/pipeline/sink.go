@@ -23,7 +23,10 @@ import (
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/sink"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/pipeline"
+	"github.com/pingcap/ticdc/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -67,6 +70,9 @@ type sinkNode struct {
 	sink   sink.Sink
 	status TableStatus
 
+	tableID   model.TableID
+	tableName string
+
 	resolvedTs   model.Ts
 	checkpointTs model.Ts
 	targetTs     model.Ts
@@ -76,6 +82,16 @@ type sinkNode struct {
 	rowBuffer   []*model.RowChangedEvent
 
 	flowController tableFlowController
+	tracer         *eventTracer
+	backfiller     *addColumnBackfiller
+	// eventFilter is nil if there is no event filter rule
+	eventFilter *filter.EventTypeFilter
+
+	// the following counters are accessed atomically, they are sampled by
+	// tablePipelineImpl to calculate the workload of the table
+	emittedRows   uint64
+	flushCount    uint64
+	flushDuration int64 // in nanoseconds
 }
 
 func newSinkNode(sink sink.Sink, startTs model.Ts, targetTs model.Ts, flowController tableFlowController) *sinkNode {
@@ -94,9 +110,24 @@ func newSinkNode(sink sink.Sink, startTs model.Ts, targetTs model.Ts, flowContro
 func (n *sinkNode) ResolvedTs() model.Ts   { return atomic.LoadUint64(&n.resolvedTs) }
 func (n *sinkNode) CheckpointTs() model.Ts { return atomic.LoadUint64(&n.checkpointTs) }
 func (n *sinkNode) Status() TableStatus    { return n.status.Load() }
+func (n *sinkNode) BarrierTs() model.Ts    { return atomic.LoadUint64(&n.barrierTs) }
+
+// sinkStats returns the number of rows emitted to the sink, and the number and
+// the total duration of the flushes of the sink.
+func (n *sinkNode) sinkStats() (emittedRows uint64, flushCount uint64, flushDuration time.Duration) {
+	return atomic.LoadUint64(&n.emittedRows), atomic.LoadUint64(&n.flushCount),
+		time.Duration(atomic.LoadInt64(&n.flushDuration))
+}
 
 func (n *sinkNode) Init(ctx pipeline.NodeContext) error {
-	// do nothing
+	if traceRate, spanRate := eventSampleRates(ctx.ChangefeedVars().Info.Config); traceRate > 0 || spanRate > 0 {
+		n.tracer = newEventTracer(ctx.ChangefeedVars().ID, ctx.GlobalVars().CaptureInfo.AdvertiseAddr, n.tableID, n.tableName)
+	}
+	eventFilter, err := filter.NewEventTypeFilter(ctx.ChangefeedVars().Info.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	n.eventFilter = eventFilter
 	return nil
 }
 
@@ -133,25 +164,42 @@ func (n *sinkNode) flushSink(ctx pipeline.NodeContext, resolvedTs model.Ts) (err
 	if resolvedTs <= n.checkpointTs {
 		return nil
 	}
+	spanCtx, span := tracing.Tracer().Start(ctx, "sink.flush", trace.WithAttributes(
+		tracing.ChangefeedKey.String(ctx.ChangefeedVars().ID),
+		tracing.TableIDKey.Int64(n.tableID),
+		tracing.TableNameKey.String(n.tableName),
+		tracing.ResolvedTsKey.Int64(int64(resolvedTs))))
+	defer span.End()
 	if err := n.emitRow2Sink(ctx); err != nil {
 		return errors.Trace(err)
 	}
-	checkpointTs, err := n.sink.FlushRowChangedEvents(ctx, resolvedTs)
+	flushStart := time.Now()
+	checkpointTs, err := n.sink.FlushRowChangedEvents(spanCtx, resolvedTs)
+	atomic.AddInt64(&n.flushDuration, int64(time.Since(flushStart)))
+	atomic.AddUint64(&n.flushCount, 1)
 	if err != nil {
+		span.RecordError(err)
 		return errors.Trace(err)
 	}
 	if checkpointTs <= n.checkpointTs {
 		return nil
 	}
 	atomic.StoreUint64(&n.checkpointTs, checkpointTs)
+	n.tracer.flushed(checkpointTs)
 
 	n.flowController.Release(checkpointTs)
 	return nil
 }
 
 func (n *sinkNode) emitEvent(ctx pipeline.NodeContext, event *model.PolymorphicEvent) error {
-	if event == nil || event.Row == nil {
-		log.Warn("skip emit nil event", zap.Any("event", event))
+	if event == nil {
+		log.Warn("skip emit nil event")
+		return nil
+	}
+	if event.Row == nil {
+		// the row is skipped by the mounter, such as the rows filtered out by
+		// the row filter
+		log.Debug("skip emit the event without row", zap.Any("event", event))
 		return nil
 	}
 
@@ -165,8 +213,21 @@ func (n *sinkNode) emitEvent(ctx pipeline.NodeContext, event *model.PolymorphicE
 		return nil
 	}
 
+	if n.eventFilter.ShouldIgnoreEvent(event.Row) {
+		filteredEventCounter.WithLabelValues(ctx.ChangefeedVars().ID,
+			ctx.GlobalVars().CaptureInfo.AdvertiseAddr, filter.EventTypeOf(event.Row)).Inc()
+		return nil
+	}
+
+	n.tracer.emitted(event)
 	config := ctx.ChangefeedVars().Info.Config
 
+	backfillEvents, err := n.backfiller.backfill(ctx, event)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	n.eventBuffer = append(n.eventBuffer, backfillEvents...)
+
 	// This indicates that it is an update event,
 	// and after enable old value internally by default(but disable in the configuration).
 	// We need to handle the update event to be compatible with the old format.
@@ -295,6 +356,7 @@ func (n *sinkNode) emitRow2Sink(ctx pipeline.NodeContext) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	atomic.AddUint64(&n.emittedRows, uint64(len(n.rowBuffer)))
 	n.clearBuffers()
 	return nil
 }
@@ -333,7 +395,7 @@ func (n *sinkNode) Receive(ctx pipeline.NodeContext) error {
 			return n.stop(ctx)
 		}
 	case pipeline.MessageTypeBarrier:
-		n.barrierTs = msg.BarrierTs
+		atomic.StoreUint64(&n.barrierTs, msg.BarrierTs)
 		if err := n.flushSink(ctx, n.resolvedTs); err != nil {
 			return errors.Trace(err)
 		}
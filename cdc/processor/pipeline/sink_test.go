@@ -388,6 +388,42 @@ func (s *outputSuite) TestIgnoreEmptyRowChangeEvent(c *check.C) {
 	c.Assert(node.eventBuffer, check.HasLen, 0)
 }
 
+func (s *outputSuite) TestIgnoreFilteredEventTypes(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := cdcContext.NewContext(context.Background(), &cdcContext.GlobalVars{
+		CaptureInfo: &model.CaptureInfo{AdvertiseAddr: "127.0.0.1:8300"},
+	})
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Filter.EventFilters = []*config.EventFilterRule{
+		{Matcher: []string{"audit.*"}, IgnoreEvent: []string{config.EventTypeDelete}},
+	}
+	ctx = cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
+		ID: "changefeed-id-test-ignore-filtered-event-types",
+		Info: &model.ChangeFeedInfo{
+			StartTs: oracle.GoTimeToTS(time.Now()),
+			Config:  cfg,
+		},
+	})
+	sink := &mockSink{}
+	node := newSinkNode(sink, 0, 10, &mockFlowController{})
+	c.Assert(node.Init(pipeline.MockNodeContext4Test(ctx, pipeline.Message{}, nil)), check.IsNil)
+
+	columns := []*model.Column{{Name: "id", Flag: model.HandleKeyFlag, Value: 1}}
+	for _, row := range []*model.RowChangedEvent{
+		{CommitTs: 1, Table: &model.TableName{Schema: "audit", Table: "log"}, Columns: columns},
+		{CommitTs: 1, Table: &model.TableName{Schema: "audit", Table: "log"}, PreColumns: columns},
+		{CommitTs: 1, Table: &model.TableName{Schema: "test", Table: "t"}, PreColumns: columns},
+	} {
+		c.Assert(node.Receive(pipeline.MockNodeContext4Test(ctx,
+			pipeline.PolymorphicEventMessage(&model.PolymorphicEvent{CRTs: 1, RawKV: &model.RawKVEntry{OpType: model.OpTypePut}, Row: row}), nil)), check.IsNil)
+	}
+	// the delete event of the audit table is ignored
+	c.Assert(node.eventBuffer, check.HasLen, 2)
+	c.Assert(node.eventBuffer[0].Row.Table.Table, check.Equals, "log")
+	c.Assert(node.eventBuffer[0].Row.IsDelete(), check.IsFalse)
+	c.Assert(node.eventBuffer[1].Row.Table.Table, check.Equals, "t")
+}
+
 func (s *outputSuite) TestSplitUpdateEventWhenEnableOldValue(c *check.C) {
 	defer testleak.AfterTest(c)()
 	ctx := cdcContext.NewContext(context.Background(), &cdcContext.GlobalVars{})
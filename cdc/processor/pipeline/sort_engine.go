@@ -0,0 +1,75 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	filter "github.com/pingcap/tidb-tools/pkg/table-filter"
+	"go.uber.org/zap"
+)
+
+// SortEngineSelector selects the sort engine of a table by the sort engine
+// rules of the changefeed.
+type SortEngineSelector struct {
+	rules []struct {
+		engine model.SortEngine
+		filter.Filter
+	}
+	defaultEngine model.SortEngine
+}
+
+// NewSortEngineSelector creates a SortEngineSelector, the tables not matched
+// by any rule use the defaultEngine.
+func NewSortEngineSelector(cfg *config.ReplicaConfig, defaultEngine model.SortEngine) (*SortEngineSelector, error) {
+	s := &SortEngineSelector{defaultEngine: defaultEngine}
+	if cfg.SortEngine == nil {
+		return s, nil
+	}
+	for _, rule := range cfg.SortEngine.Rules {
+		engine := rule.Engine
+		if engine == model.SortLowLatency && !cfg.IsFeatureEnabled(config.FeatureLowLatencySortEngine) {
+			log.Warn("the low-latency sort engine is disabled by the feature flag, use the unified sort engine",
+				zap.Strings("matcher", rule.Matcher))
+			engine = model.SortUnified
+		}
+		f, err := filter.Parse(rule.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+		}
+		if !cfg.CaseSensitive {
+			f = filter.CaseInsensitive(f)
+		}
+		s.rules = append(s.rules, struct {
+			engine model.SortEngine
+			filter.Filter
+		}{engine: engine, Filter: f})
+	}
+	return s, nil
+}
+
+// Select returns the sort engine of the table, the first matched rule wins.
+func (s *SortEngineSelector) Select(tableName *model.TableName) model.SortEngine {
+	if tableName == nil {
+		return s.defaultEngine
+	}
+	for _, rule := range s.rules {
+		if rule.MatchTable(tableName.Schema, tableName.Table) {
+			return rule.engine
+		}
+	}
+	return s.defaultEngine
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+type sortEngineSuite struct{}
+
+var _ = check.Suite(&sortEngineSuite{})
+
+func (s *sortEngineSuite) TestSortEngineSelector(c *check.C) {
+	defer testleak.AfterTest(c)()
+	cfg := config.GetDefaultReplicaConfig()
+	selector, err := NewSortEngineSelector(cfg, model.SortUnified)
+	c.Assert(err, check.IsNil)
+	c.Assert(selector.Select(&model.TableName{Schema: "test", Table: "t1"}), check.Equals, model.SortUnified)
+	c.Assert(selector.Select(nil), check.Equals, model.SortUnified)
+
+	cfg.SortEngine = &config.SortEngineConfig{Rules: []*config.SortEngineRule{
+		{Matcher: []string{"test.hot*"}, Engine: model.SortInMemory},
+		{Matcher: []string{"test.small*"}, Engine: model.SortLowLatency},
+		{Matcher: []string{"test.*"}, Engine: model.SortUnified},
+	}}
+	selector, err = NewSortEngineSelector(cfg, model.SortInMemory)
+	c.Assert(err, check.IsNil)
+	// the low-latency sort engine is disabled by the feature flag by default
+	c.Assert(selector.Select(&model.TableName{Schema: "test", Table: "small1"}), check.Equals, model.SortUnified)
+
+	cfg.Features = map[string]bool{config.FeatureLowLatencySortEngine: true}
+	selector, err = NewSortEngineSelector(cfg, model.SortInMemory)
+	c.Assert(err, check.IsNil)
+	c.Assert(selector.Select(&model.TableName{Schema: "test", Table: "hot1"}), check.Equals, model.SortInMemory)
+	c.Assert(selector.Select(&model.TableName{Schema: "TEST", Table: "HOT1"}), check.Equals, model.SortInMemory)
+	c.Assert(selector.Select(&model.TableName{Schema: "test", Table: "huge"}), check.Equals, model.SortUnified)
+	c.Assert(selector.Select(&model.TableName{Schema: "test", Table: "small1"}), check.Equals, model.SortLowLatency)
+	c.Assert(selector.Select(&model.TableName{Schema: "other", Table: "t1"}), check.Equals, model.SortInMemory)
+
+	cfg.SortEngine.Rules[0].Matcher = []string{"test.t["}
+	_, err = NewSortEngineSelector(cfg, model.SortInMemory)
+	c.Assert(err, check.ErrorMatches, ".*ErrFilterRuleInvalid.*")
+}
@@ -42,6 +42,10 @@ type sorterNode struct {
 	tableID   model.TableID
 	tableName string // quoted schema and table, used in metircs only
 
+	// sortEngine is the sort engine of the table, the sort engine of the
+	// changefeed is used if it is empty.
+	sortEngine model.SortEngine
+
 	// for per-table flow control
 	flowController tableFlowController
 
@@ -52,6 +56,9 @@ type sorterNode struct {
 
 	// The latest resolved ts that sorter has received.
 	resolvedTs model.Ts
+
+	// The number of the row events output by the sorter.
+	processedEvents uint64
 }
 
 func newSorterNode(
@@ -70,22 +77,36 @@ func newSorterNode(
 func (n *sorterNode) Init(ctx pipeline.NodeContext) error {
 	stdCtx, cancel := context.WithCancel(ctx)
 	n.cancel = cancel
+	newUnifiedSorter := func() (sorter.EventSorter, error) {
+		sortDir := ctx.ChangefeedVars().Info.SortDir
+		err := unified.CheckDir(sortDir)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		s, err := unified.NewUnifiedSorter(sortDir, ctx.ChangefeedVars().ID, n.tableName, n.tableID, ctx.GlobalVars().CaptureInfo.AdvertiseAddr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return s, nil
+	}
 	var sorter sorter.EventSorter
-	sortEngine := ctx.ChangefeedVars().Info.Engine
+	sortEngine := n.sortEngine
+	if sortEngine == "" {
+		sortEngine = ctx.ChangefeedVars().Info.Engine
+	}
 	switch sortEngine {
 	case model.SortInMemory:
 		sorter = memory.NewEntrySorter()
+	case model.SortLowLatency:
+		quota := ctx.ChangefeedVars().Info.Config.SortEngine.GetLowLatencyMemoryQuota()
+		sorter = memory.NewFallbackSorter(quota, newUnifiedSorter)
 	case model.SortUnified, model.SortInFile /* `file` becomes an alias of `unified` for backward compatibility */ :
 		if sortEngine == model.SortInFile {
 			log.Warn("File sorter is obsolete and replaced by unified sorter. Please revise your changefeed settings",
 				zap.String("changefeed-id", ctx.ChangefeedVars().ID), zap.String("table-name", n.tableName))
 		}
-		sortDir := ctx.ChangefeedVars().Info.SortDir
-		err := unified.CheckDir(sortDir)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		sorter, err = unified.NewUnifiedSorter(sortDir, ctx.ChangefeedVars().ID, n.tableName, n.tableID, ctx.GlobalVars().CaptureInfo.AdvertiseAddr)
+		var err error
+		sorter, err = newUnifiedSorter()
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -131,6 +152,9 @@ func (n *sorterNode) Init(ctx pipeline.NodeContext) error {
 					log.Panic("unexpected empty msg", zap.Reflect("msg", msg))
 				}
 				if msg.RawKV.OpType != model.OpTypeResolved {
+					if msg.Trace != nil {
+						msg.Trace.Sorted = time.Now()
+					}
 					size := uint64(msg.RawKV.ApproximateSize())
 					commitTs := msg.CRTs
 					// We interpolate a resolved-ts if none has been sent for some time.
@@ -169,6 +193,7 @@ func (n *sorterNode) Init(ctx pipeline.NodeContext) error {
 						return nil
 					}
 					lastCRTs = commitTs
+					atomic.AddUint64(&n.processedEvents, 1)
 
 					// DESIGN NOTE: We send the messages to the mounter in this separate goroutine to prevent
 					// blocking the whole pipeline.
@@ -228,3 +253,8 @@ func (n *sorterNode) Destroy(ctx pipeline.NodeContext) error {
 func (n *sorterNode) ResolvedTs() model.Ts {
 	return atomic.LoadUint64(&n.resolvedTs)
 }
+
+// ProcessedEvents returns the number of the row events output by the sorter.
+func (n *sorterNode) ProcessedEvents() uint64 {
+	return atomic.LoadUint64(&n.processedEvents)
+}
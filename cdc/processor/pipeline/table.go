@@ -15,6 +15,7 @@ package pipeline
 
 import (
 	"context"
+	"net/url"
 	"time"
 
 	"github.com/pingcap/log"
@@ -54,6 +55,8 @@ type TablePipeline interface {
 	Workload() model.WorkloadInfo
 	// Status returns the status of this table pipeline
 	Status() TableStatus
+	// Snapshot returns the in-process status of this table pipeline for debugging
+	Snapshot() *model.TablePipelineStatus
 	// Cancel stops this table pipeline immediately and destroy all resources created by this table pipeline
 	Cancel()
 	// Wait waits for table pipeline destroyed
@@ -71,6 +74,8 @@ type tablePipelineImpl struct {
 	sinkNode   *sinkNode
 	cancel     context.CancelFunc
 
+	workloadSampler workloadSampler
+
 	replConfig *serverConfig.ReplicaConfig
 }
 
@@ -126,13 +131,11 @@ func (t *tablePipelineImpl) AsyncStop(targetTs model.Ts) bool {
 	return true
 }
 
-var workload = model.WorkloadInfo{Workload: 1}
-
 // Workload returns the workload of this table
 func (t *tablePipelineImpl) Workload() model.WorkloadInfo {
-	// TODO(leoppro) calculate the workload of this table
-	// We temporarily set the value to constant 1
-	return workload
+	emittedRows, flushCount, flushDuration := t.sinkNode.sinkStats()
+	return t.workloadSampler.sample(time.Now(), emittedRows, flushCount, flushDuration,
+		t.sorterNode.ResolvedTs(), t.sinkNode.CheckpointTs())
 }
 
 // Status returns the status of this table pipeline
@@ -150,6 +153,22 @@ func (t *tablePipelineImpl) Name() string {
 	return t.tableName
 }
 
+// Snapshot returns the in-process status of this table pipeline for debugging
+func (t *tablePipelineImpl) Snapshot() *model.TablePipelineStatus {
+	return &model.TablePipelineStatus{
+		TableID:                   t.tableID,
+		TableName:                 t.tableName,
+		Status:                    t.sinkNode.Status().String(),
+		SorterResolvedTs:          t.sorterNode.ResolvedTs(),
+		ResolvedTs:                t.sinkNode.ResolvedTs(),
+		CheckpointTs:              t.sinkNode.CheckpointTs(),
+		BarrierTs:                 t.sinkNode.BarrierTs(),
+		FlowControllerConsumption: t.sorterNode.flowController.GetConsumption(),
+		ProcessedEvents:           t.sorterNode.ProcessedEvents(),
+		OutputChannelLength:       t.p.OutputChannelLength(),
+	}
+}
+
 // Cancel stops this table pipeline immediately and destroy all resources created by this table pipeline
 func (t *tablePipelineImpl) Cancel() {
 	t.cancel()
@@ -172,8 +191,10 @@ const defaultRunnersSize = 5
 // TODO(leoppro): implement a mock kvclient to test the table pipeline
 func NewTablePipeline(ctx cdcContext.Context,
 	mounter entry.Mounter,
+	schemaStorage entry.SchemaStorage,
 	tableID model.TableID,
 	tableName string,
+	sortEngine model.SortEngine,
 	replicaInfo *model.TableReplicaInfo,
 	sink sink.Sink,
 	targetTs model.Ts) TablePipeline {
@@ -202,7 +223,16 @@ func NewTablePipeline(ctx cdcContext.Context,
 
 	p := pipeline.NewPipeline(ctx, 500*time.Millisecond, runnerSize, defaultOutputChannelSize)
 	sorterNode := newSorterNode(tableName, tableID, replicaInfo.StartTs, flowController, mounter)
+	sorterNode.sortEngine = sortEngine
 	sinkNode := newSinkNode(sink, replicaInfo.StartTs, targetTs, flowController)
+	sinkNode.tableID = tableID
+	sinkNode.tableName = tableName
+	// only the consumers of the MQ sinks materialize the full rows, the
+	// downstream databases fill the default values of the added columns.
+	if config.Sink.AddColumn.IsBackfillEnabled() && isMQSink(ctx.ChangefeedVars().Info.SinkURI) {
+		sinkNode.backfiller = newAddColumnBackfiller(config.Sink.AddColumn.BackfillWindow,
+			config.EnableOldValue, newSchemaStorageTableInfoGetter(schemaStorage))
+	}
 
 	p.AppendNode(ctx, "puller", newPullerNode(tableID, replicaInfo, tableName))
 	p.AppendNode(ctx, "sorter", sorterNode)
@@ -217,3 +247,11 @@ func NewTablePipeline(ctx cdcContext.Context,
 	tablePipeline.sinkNode = sinkNode
 	return tablePipeline
 }
+
+func isMQSink(sinkURI string) bool {
+	u, err := url.Parse(sinkURI)
+	if err != nil {
+		return false
+	}
+	return sink.IsMQScheme(u.Scheme)
+}
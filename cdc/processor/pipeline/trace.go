@@ -0,0 +1,181 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Stages observed by eventStageLatencyHistogram.
+const (
+	traceStageSort  = "sort"
+	traceStageMount = "mount"
+	traceStageEmit  = "emit"
+	traceStageFlush = "flush"
+	traceStageTotal = "total"
+)
+
+// eventSampleRates returns the fraction of the events tracked by the event
+// trace of the changefeed, and the fraction of the events recorded as spans by
+// the OpenTelemetry tracing of the server.
+func eventSampleRates(cfg *config.ReplicaConfig) (traceRate, spanRate float64) {
+	if cfg.EventTrace.IsEnabled() {
+		traceRate = cfg.EventTrace.SampleRate
+	}
+	if tracingCfg := config.GetGlobalServerConfig().Tracing; tracingCfg.IsEnabled() {
+		spanRate = tracingCfg.SampleRatio
+	}
+	return
+}
+
+// eventSampler decides which row changed events carry an EventTrace.
+type eventSampler struct {
+	rate     float64
+	spanRate float64
+	rand     *rand.Rand
+}
+
+func newEventSampler(rate, spanRate float64) *eventSampler {
+	return &eventSampler{
+		rate:     rate,
+		spanRate: spanRate,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// trace attaches an EventTrace to the event if it is sampled by either rate,
+// the trace is recorded as spans if it is sampled by the span rate.
+func (s *eventSampler) trace(event *model.PolymorphicEvent) {
+	if s == nil || event.RawKV.OpType == model.OpTypeResolved {
+		return
+	}
+	rate := s.rate
+	if s.spanRate > rate {
+		rate = s.spanRate
+	}
+	if rate <= 0 {
+		return
+	}
+	r := 0.0
+	if rate < 1 || s.spanRate < 1 {
+		r = s.rand.Float64()
+	}
+	if r >= rate {
+		return
+	}
+	event.Trace = &model.EventTrace{Pulled: time.Now(), Span: r < s.spanRate}
+}
+
+// eventTracer collects the traces of emitted events and observes the stage
+// latencies once the events are flushed to the sink. The stages of the events
+// sampled by the OpenTelemetry tracing are also recorded as spans.
+//
+// NOTE: events spilled to disk by the unified sorter lose their trace, so
+// the histograms only cover events sorted in memory.
+type eventTracer struct {
+	pending    []pendingTrace
+	attributes []attribute.KeyValue
+
+	sortHistogram  prometheus.Observer
+	mountHistogram prometheus.Observer
+	emitHistogram  prometheus.Observer
+	flushHistogram prometheus.Observer
+	totalHistogram prometheus.Observer
+}
+
+type pendingTrace struct {
+	commitTs model.Ts
+	trace    *model.EventTrace
+}
+
+func newEventTracer(changefeedID model.ChangeFeedID, captureAddr string, tableID model.TableID, tableName string) *eventTracer {
+	return &eventTracer{
+		attributes: []attribute.KeyValue{
+			tracing.ChangefeedKey.String(changefeedID),
+			tracing.TableIDKey.Int64(tableID),
+			tracing.TableNameKey.String(tableName),
+		},
+		sortHistogram:  eventStageLatencyHistogram.WithLabelValues(changefeedID, captureAddr, traceStageSort),
+		mountHistogram: eventStageLatencyHistogram.WithLabelValues(changefeedID, captureAddr, traceStageMount),
+		emitHistogram:  eventStageLatencyHistogram.WithLabelValues(changefeedID, captureAddr, traceStageEmit),
+		flushHistogram: eventStageLatencyHistogram.WithLabelValues(changefeedID, captureAddr, traceStageFlush),
+		totalHistogram: eventStageLatencyHistogram.WithLabelValues(changefeedID, captureAddr, traceStageTotal),
+	}
+}
+
+// emitted records that the event has been handed to the sink.
+func (t *eventTracer) emitted(event *model.PolymorphicEvent) {
+	if t == nil || event.Trace == nil {
+		return
+	}
+	event.Trace.Emitted = time.Now()
+	t.pending = append(t.pending, pendingTrace{commitTs: event.CRTs, trace: event.Trace})
+}
+
+// flushed observes the latencies of the pending traces whose events have
+// been flushed, i.e. their commit ts is not larger than the checkpoint ts.
+func (t *eventTracer) flushed(checkpointTs model.Ts) {
+	if t == nil || len(t.pending) == 0 {
+		return
+	}
+	now := time.Now()
+	i := 0
+	for ; i < len(t.pending) && t.pending[i].commitTs <= checkpointTs; i++ {
+		trace := t.pending[i].trace
+		t.sortHistogram.Observe(trace.Sorted.Sub(trace.Pulled).Seconds())
+		t.mountHistogram.Observe(trace.Mounted.Sub(trace.Sorted).Seconds())
+		t.emitHistogram.Observe(trace.Emitted.Sub(trace.Mounted).Seconds())
+		t.flushHistogram.Observe(now.Sub(trace.Emitted).Seconds())
+		t.totalHistogram.Observe(now.Sub(trace.Pulled).Seconds())
+		if trace.Span {
+			t.recordSpans(t.pending[i].commitTs, trace, now)
+		}
+	}
+	t.pending = append(t.pending[:0], t.pending[i:]...)
+}
+
+// recordSpans records the stages of a flushed event as spans.
+func (t *eventTracer) recordSpans(commitTs model.Ts, event *model.EventTrace, flushed time.Time) {
+	tracer := tracing.Tracer()
+	ctx, span := tracer.Start(context.Background(), tracing.EventSpanName,
+		trace.WithTimestamp(event.Pulled),
+		trace.WithAttributes(t.attributes...),
+		trace.WithAttributes(tracing.CommitTsKey.Int64(int64(commitTs))))
+	if !span.IsRecording() {
+		return
+	}
+	stages := []struct {
+		name       string
+		start, end time.Time
+	}{
+		{traceStageSort, event.Pulled, event.Sorted},
+		{traceStageMount, event.Sorted, event.Mounted},
+		{traceStageEmit, event.Mounted, event.Emitted},
+		{traceStageFlush, event.Emitted, flushed},
+	}
+	for _, stage := range stages {
+		_, stageSpan := tracer.Start(ctx, stage.name, trace.WithTimestamp(stage.start))
+		stageSpan.End(trace.WithTimestamp(stage.end))
+	}
+	span.End(trace.WithTimestamp(flushed))
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+type traceSuite struct{}
+
+var _ = check.Suite(&traceSuite{})
+
+func (s *traceSuite) TestEventSampler(c *check.C) {
+	defer testleak.AfterTest(c)()
+	var nilSampler *eventSampler
+	event := model.NewPolymorphicEvent(&model.RawKVEntry{OpType: model.OpTypePut, CRTs: 1})
+	nilSampler.trace(event)
+	c.Assert(event.Trace, check.IsNil)
+
+	newEventSampler(0, 0).trace(event)
+	c.Assert(event.Trace, check.IsNil)
+
+	resolved := model.NewResolvedPolymorphicEvent(0, 2)
+	newEventSampler(1, 1).trace(resolved)
+	c.Assert(resolved.Trace, check.IsNil)
+
+	newEventSampler(1, 0).trace(event)
+	c.Assert(event.Trace, check.NotNil)
+	c.Assert(event.Trace.Pulled.IsZero(), check.IsFalse)
+	c.Assert(event.Trace.Span, check.IsFalse)
+
+	// the events sampled by the OpenTelemetry tracing alone are recorded as spans.
+	event.Trace = nil
+	newEventSampler(0, 1).trace(event)
+	c.Assert(event.Trace, check.NotNil)
+	c.Assert(event.Trace.Span, check.IsTrue)
+}
+
+func (s *traceSuite) TestEventSampleRates(c *check.C) {
+	defer testleak.AfterTest(c)()
+	cfg := config.GetDefaultReplicaConfig()
+	traceRate, spanRate := eventSampleRates(cfg)
+	c.Assert(traceRate, check.Equals, float64(0))
+	c.Assert(spanRate, check.Equals, float64(0))
+
+	oldServerCfg := config.GetGlobalServerConfig()
+	defer config.StoreGlobalServerConfig(oldServerCfg)
+	serverCfg := oldServerCfg.Clone()
+	serverCfg.Tracing = &config.TracingConfig{
+		Exporter:    config.TracingExporterOTLP,
+		Endpoint:    "http://127.0.0.1:4318/v1/traces",
+		SampleRatio: 0.5,
+	}
+	config.StoreGlobalServerConfig(serverCfg)
+	traceRate, spanRate = eventSampleRates(cfg)
+	c.Assert(traceRate, check.Equals, float64(0))
+	c.Assert(spanRate, check.Equals, 0.5)
+
+	cfg.EventTrace = &config.EventTraceConfig{SampleRate: 0.1}
+	traceRate, spanRate = eventSampleRates(cfg)
+	c.Assert(traceRate, check.Equals, 0.1)
+	c.Assert(spanRate, check.Equals, 0.5)
+}
+
+func (s *traceSuite) TestEventTracer(c *check.C) {
+	defer testleak.AfterTest(c)()
+	tracer := newEventTracer("changefeed-trace-test", "127.0.0.1:8300", 1, "`test`.`t`")
+	sampler := newEventSampler(1, 0)
+	for _, ts := range []model.Ts{1, 2, 3} {
+		event := model.NewPolymorphicEvent(&model.RawKVEntry{OpType: model.OpTypePut, CRTs: ts})
+		sampler.trace(event)
+		tracer.emitted(event)
+	}
+	// events without a trace are ignored.
+	tracer.emitted(model.NewPolymorphicEvent(&model.RawKVEntry{OpType: model.OpTypePut, CRTs: 4}))
+	c.Assert(tracer.pending, check.HasLen, 3)
+
+	tracer.flushed(2)
+	c.Assert(tracer.pending, check.HasLen, 1)
+	c.Assert(tracer.pending[0].commitTs, check.Equals, model.Ts(3))
+	tracer.flushed(10)
+	c.Assert(tracer.pending, check.HasLen, 0)
+
+	var nilTracer *eventTracer
+	nilTracer.flushed(10)
+}
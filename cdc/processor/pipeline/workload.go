@@ -0,0 +1,80 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"math"
+	"time"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// workloadSampleInterval is the minimum interval between two samples of the
+// workload, which limits how often the workload reported to etcd changes.
+const workloadSampleInterval = 10 * time.Second
+
+// workloadSampler calculates the workload of a table from the counters of the
+// sink node. It is not thread-safe.
+type workloadSampler struct {
+	lastSampleTime    time.Time
+	lastEmittedRows   uint64
+	lastFlushCount    uint64
+	lastFlushDuration time.Duration
+
+	workload model.WorkloadInfo
+}
+
+// sample returns the workload of the table, which is updated at most once per
+// workloadSampleInterval.
+func (s *workloadSampler) sample(
+	now time.Time, emittedRows, flushCount uint64, flushDuration time.Duration,
+	sorterResolvedTs, checkpointTs model.Ts,
+) model.WorkloadInfo {
+	if s.lastSampleTime.IsZero() {
+		s.lastSampleTime = now
+		s.lastEmittedRows = emittedRows
+		s.lastFlushCount = flushCount
+		s.lastFlushDuration = flushDuration
+		s.workload = model.WorkloadInfo{Workload: 1}
+		return s.workload
+	}
+	elapsed := now.Sub(s.lastSampleTime)
+	if elapsed < workloadSampleInterval {
+		return s.workload
+	}
+
+	workload := model.WorkloadInfo{Workload: 1}
+	workload.EventRate = round(float64(emittedRows-s.lastEmittedRows) / elapsed.Seconds())
+	if flushCount > s.lastFlushCount {
+		latency := (flushDuration - s.lastFlushDuration) / time.Duration(flushCount-s.lastFlushCount)
+		workload.SinkFlushLatency = round(latency.Seconds())
+	}
+	if sorterResolvedTs > checkpointTs {
+		backlog := oracle.ExtractPhysical(sorterResolvedTs) - oracle.ExtractPhysical(checkpointTs)
+		workload.SorterBacklog = round(float64(backlog) / 1000)
+	}
+
+	s.lastSampleTime = now
+	s.lastEmittedRows = emittedRows
+	s.lastFlushCount = flushCount
+	s.lastFlushDuration = flushDuration
+	s.workload = workload
+	return s.workload
+}
+
+// round rounds a metric to 3 decimal places to keep the reported workload short.
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"time"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+type workloadSuite struct{}
+
+var _ = check.Suite(&workloadSuite{})
+
+func (s *workloadSuite) TestWorkloadSampler(c *check.C) {
+	defer testleak.AfterTest(c)()
+	var sampler workloadSampler
+	now := time.Now()
+	checkpointTs := oracle.ComposeTS(oracle.GetPhysical(now), 0)
+	resolvedTs := oracle.ComposeTS(oracle.GetPhysical(now.Add(3*time.Second)), 0)
+
+	c.Assert(sampler.sample(now, 100, 1, time.Second, resolvedTs, checkpointTs),
+		check.Equals, model.WorkloadInfo{Workload: 1})
+	// the workload is not updated within the sample interval
+	c.Assert(sampler.sample(now.Add(time.Second), 200, 2, 2*time.Second, resolvedTs, checkpointTs),
+		check.Equals, model.WorkloadInfo{Workload: 1})
+
+	now = now.Add(workloadSampleInterval)
+	c.Assert(sampler.sample(now, 1100, 5, 3*time.Second, resolvedTs, checkpointTs),
+		check.Equals, model.WorkloadInfo{
+			Workload:         1,
+			EventRate:        100,
+			SorterBacklog:    3,
+			SinkFlushLatency: 0.5,
+		})
+
+	// no flush and no backlog
+	now = now.Add(2 * workloadSampleInterval)
+	c.Assert(sampler.sample(now, 1100, 5, 3*time.Second, checkpointTs, checkpointTs),
+		check.Equals, model.WorkloadInfo{Workload: 1})
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/ticdc/cdc/redo"
 	"github.com/pingcap/ticdc/cdc/sink"
 	"github.com/pingcap/ticdc/cdc/sorter/memory"
+	"github.com/pingcap/ticdc/pkg/config"
 	cdcContext "github.com/pingcap/ticdc/pkg/context"
 	"github.com/pingcap/ticdc/pkg/cyclic/mark"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
@@ -61,11 +62,13 @@ type processor struct {
 	schemaStorage entry.SchemaStorage
 	lastSchemaTs  model.Ts
 
-	filter        *filter.Filter
-	mounter       entry.Mounter
-	sinkManager   *sink.Manager
-	redoManager   redo.LogManager
-	lastRedoFlush time.Time
+	filter             *filter.Filter
+	mounter            entry.Mounter
+	sortEngineSelector *tablepipeline.SortEngineSelector
+	sinkManager        *sink.Manager
+	sinkURI            string
+	redoManager        redo.LogManager
+	lastRedoFlush      time.Time
 
 	initialized bool
 	errCh       chan error
@@ -116,8 +119,9 @@ func (p *processor) Tick(ctx cdcContext.Context, state *orchestrator.ChangefeedR
 	p.changefeed = state
 	state.CheckCaptureAlive(ctx.GlobalVars().CaptureInfo.ID)
 	ctx = cdcContext.WithChangefeedVars(ctx, &cdcContext.ChangefeedVars{
-		ID:   state.ID,
-		Info: state.Info,
+		ID:       state.ID,
+		Info:     state.Info,
+		Upstream: cdcContext.GetUpstream(ctx),
 	})
 	_, err := p.tick(ctx, state)
 	if err == nil {
@@ -130,21 +134,12 @@ func (p *processor) Tick(ctx cdcContext.Context, state *orchestrator.ChangefeedR
 	}
 	p.metricProcessorErrorCounter.Inc()
 	// record error information in etcd
-	var code string
-	if rfcCode, ok := cerror.RFCCode(err); ok {
-		code = string(rfcCode)
-	} else {
-		code = string(cerror.ErrProcessorUnknown.RFCCode())
-	}
+	runningErr := model.NewRunningError(ctx.GlobalVars().CaptureInfo.AdvertiseAddr, err, cerror.ErrProcessorUnknown)
 	state.PatchTaskPosition(p.captureInfo.ID, func(position *model.TaskPosition) (*model.TaskPosition, bool, error) {
 		if position == nil {
 			position = &model.TaskPosition{}
 		}
-		position.Error = &model.RunningError{
-			Addr:    ctx.GlobalVars().CaptureInfo.AdvertiseAddr,
-			Code:    code,
-			Message: err.Error(),
-		}
+		position.Error = runningErr
 		return position, true, nil
 	})
 	log.Error("run processor failed",
@@ -159,6 +154,13 @@ func (p *processor) tick(ctx cdcContext.Context, state *orchestrator.ChangefeedR
 	if !p.checkChangefeedNormal() {
 		return nil, cerror.ErrAdminStopProcessor.GenWithStackByArgs()
 	}
+	// the changefeed fails over to the standby sink, exit the processor and
+	// the new processor replicates the tables to the new sink
+	if p.initialized && p.sinkURI != p.changefeed.Info.SinkURI {
+		log.Info("the sink of changefeed is switched, restart the processor",
+			cdcContext.ZapFieldCapture(ctx), cdcContext.ZapFieldChangefeed(ctx))
+		return nil, cerror.ErrReactorFinished.GenWithStackByArgs()
+	}
 	if skip := p.checkPosition(); skip {
 		return p.changefeed, nil
 	}
@@ -168,6 +170,9 @@ func (p *processor) tick(ctx cdcContext.Context, state *orchestrator.ChangefeedR
 	if err := p.lazyInit(ctx); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := p.updateFilter(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if err := p.handleTableOperation(ctx); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -262,8 +267,32 @@ func (p *processor) lazyInitImpl(ctx cdcContext.Context) error {
 
 	stdCtx := util.PutChangefeedIDInCtx(ctx, p.changefeed.ID)
 	stdCtx = util.PutCaptureAddrInCtx(stdCtx, p.captureInfo.AdvertiseAddr)
+	// the mounter and the sink render the TIMESTAMP values in the time zone
+	// of the changefeed.
+	stdCtx, err = util.PutChangefeedTimezoneInCtx(stdCtx, p.changefeed.Info.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
 
-	p.mounter = entry.NewMounter(p.schemaStorage, p.changefeed.Info.Config.Mounter.WorkerNum, p.changefeed.Info.Config.EnableOldValue)
+	rowFilter, err := filter.NewRowFilter(p.changefeed.Info.Config, util.TimezoneFromCtx(stdCtx))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	offloader, err := entry.NewOffloader(stdCtx, p.changefeedID, p.changefeed.Info.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rowSizeGuard, err := entry.NewRowSizeGuard(stdCtx, p.changefeed.Info.Config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p.mounter = entry.NewMounter(p.schemaStorage, p.changefeed.Info.Config.Mounter.WorkerNum,
+		p.changefeed.Info.Config.EnableOldValue, rowFilter, entry.NewCharsetConverter(p.changefeed.Info.Config),
+		offloader, rowSizeGuard)
+	p.sortEngineSelector, err = tablepipeline.NewSortEngineSelector(p.changefeed.Info.Config, p.changefeed.Info.Engine)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	p.wg.Add(1)
 	go func() {
 		defer p.wg.Done()
@@ -285,13 +314,18 @@ func (p *processor) lazyInitImpl(ctx cdcContext.Context) error {
 	}
 	opts[sink.OptChangefeedID] = p.changefeed.ID
 	opts[sink.OptCaptureAddr] = ctx.GlobalVars().CaptureInfo.AdvertiseAddr
+	if pdClient := cdcContext.GetUpstream(ctx).PDClient; pdClient != nil {
+		opts[sink.OptClusterID] = strconv.FormatUint(pdClient.GetClusterID(ctx), 10)
+	}
 	s, err := sink.New(stdCtx, p.changefeed.ID, p.changefeed.Info.SinkURI, p.filter, p.changefeed.Info.Config, opts, errCh)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	p.sinkURI = p.changefeed.Info.SinkURI
 	checkpointTs := p.changefeed.Info.GetCheckpointTs(p.changefeed.Status)
 	captureAddr := ctx.GlobalVars().CaptureInfo.AdvertiseAddr
-	p.sinkManager = sink.NewManager(stdCtx, s, errCh, checkpointTs, captureAddr, p.changefeedID)
+	p.sinkManager = sink.NewManager(stdCtx, s, errCh, checkpointTs, captureAddr, p.changefeedID,
+		p.changefeed.Info.Config.IsFeatureEnabled(config.FeatureTableSinkMetrics))
 	redoManagerOpts := &redo.ManagerOptions{EnableBgRunner: true, ErrCh: errCh}
 	p.redoManager, err = redo.NewManager(stdCtx, p.changefeed.Info.Config.Consistent, redoManagerOpts)
 	if err != nil {
@@ -302,6 +336,18 @@ func (p *processor) lazyInitImpl(ctx cdcContext.Context) error {
 	return nil
 }
 
+// updateFilter applies the filter rules updated by adding or removing tables
+// of the changefeed, the filter is shared by the sink and the schema storage.
+func (p *processor) updateFilter() error {
+	if !p.filter.IsRulesChanged(p.changefeed.Info.Config) {
+		return nil
+	}
+	log.Info("filter rules of changefeed changed",
+		zap.String("changefeed", p.changefeed.ID),
+		zap.Strings("rules", p.changefeed.Info.Config.Filter.Rules))
+	return p.filter.UpdateRules(p.changefeed.Info.Config)
+}
+
 // handleErrorCh listen the error channel and throw the error if it is not expected.
 func (p *processor) handleErrorCh(ctx cdcContext.Context) error {
 	var err error
@@ -442,22 +488,26 @@ func (p *processor) handleTableOperation(ctx cdcContext.Context) error {
 }
 
 func (p *processor) createAndDriveSchemaStorage(ctx cdcContext.Context) (entry.SchemaStorage, error) {
-	kvStorage := ctx.GlobalVars().KVStorage
+	up := cdcContext.GetUpstream(ctx)
+	kvStorage := up.KVStorage
 	ddlspans := []regionspan.Span{regionspan.GetDDLSpan(), regionspan.GetAddIndexDDLSpan()}
 	checkpointTs := p.changefeed.Info.GetCheckpointTs(p.changefeed.Status)
+	if pausedTs := p.changefeed.Info.GetMinPausedTs(); checkpointTs > pausedTs {
+		checkpointTs = pausedTs
+	}
 	stdCtx := util.PutTableInfoInCtx(ctx, -1, puller.DDLPullerTableName)
 	stdCtx = util.PutChangefeedIDInCtx(stdCtx, ctx.ChangefeedVars().ID)
 	ddlPuller := puller.NewPuller(
 		stdCtx,
-		ctx.GlobalVars().PDClient,
-		ctx.GlobalVars().GrpcPool,
-		ctx.GlobalVars().KVStorage,
+		up.PDClient,
+		up.GrpcPool,
+		up.KVStorage,
 		checkpointTs, ddlspans, false)
 	meta, err := kv.GetSnapshotMeta(kvStorage, checkpointTs)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	schemaStorage, err := entry.NewSchemaStorage(meta, checkpointTs, p.filter, p.changefeed.Info.Config.ForceReplicate)
+	schemaStorage, err := entry.NewSchemaStorage(meta, checkpointTs, p.filter, p.changefeed.Info.Config.ReplicateNoKeyTables())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -711,19 +761,27 @@ func (p *processor) createTablePipelineImpl(ctx cdcContext.Context, tableID mode
 		replicaInfo.MarkTableID = markTableID
 	}
 	var tableNameStr string
+	var sinkTableName *model.TableName
 	if tableName == nil {
 		log.Warn("failed to get table name for metric")
 		tableNameStr = strconv.Itoa(int(tableID))
 	} else {
 		tableNameStr = tableName.QuoteString()
+		// the same as the table name of the row changed events
+		sinkTableName = &model.TableName{Schema: tableName.Schema, Table: tableName.Table, TableID: tableID}
+		if tableInfo, ok := p.schemaStorage.GetLastSnapshot().PhysicalTableByID(tableID); ok {
+			sinkTableName.IsPartition = tableInfo.GetPartitionInfo() != nil
+		}
 	}
 
-	sink := p.sinkManager.CreateTableSink(tableID, replicaInfo.StartTs, p.redoManager)
+	sink := p.sinkManager.CreateTableSink(tableID, sinkTableName, replicaInfo.StartTs, replicaInfo.NewlyAdded, p.redoManager)
 	table := tablepipeline.NewTablePipeline(
 		ctx,
 		p.mounter,
+		p.schemaStorage,
 		tableID,
 		tableNameStr,
+		p.sortEngineSelector.Select(tableName),
 		replicaInfo,
 		sink,
 		p.changefeed.Info.GetTargetTs(),
@@ -774,9 +832,16 @@ func (p *processor) doGCSchemaStorage(ctx cdcContext.Context) {
 		return
 	}
 
+	gcTs := p.changefeed.Status.CheckpointTs
+	// the paused tables are replicated from their paused ts once resumed
+	if p.changefeed.Info != nil {
+		if pausedTs := p.changefeed.Info.GetMinPausedTs(); gcTs > pausedTs {
+			gcTs = pausedTs
+		}
+	}
 	// Please refer to `unmarshalAndMountRowChanged` in cdc/entry/mounter.go
 	// for why we need -1.
-	lastSchemaTs := p.schemaStorage.DoGC(p.changefeed.Status.CheckpointTs - 1)
+	lastSchemaTs := p.schemaStorage.DoGC(gcTs - 1)
 	if p.lastSchemaTs == lastSchemaTs {
 		return
 	}
@@ -830,6 +895,28 @@ func (p *processor) Close() error {
 	return nil
 }
 
+// tableSnapshot returns the in-process status of the table pipeline, nil is
+// returned if the table is not replicated by this processor.
+func (p *processor) tableSnapshot(tableID model.TableID) *model.TablePipelineStatus {
+	table, ok := p.tables[tableID]
+	if !ok {
+		return nil
+	}
+	return table.Snapshot()
+}
+
+// pipelineUsage returns the resource usage accounted by the table pipelines.
+func (p *processor) pipelineUsage() pipelineUsage {
+	var usage pipelineUsage
+	for _, table := range p.tables {
+		if status := table.Snapshot(); status != nil {
+			usage.memory += status.FlowControllerConsumption
+			usage.events += status.ProcessedEvents
+		}
+	}
+	return usage
+}
+
 // WriteDebugInfo write the debug info to Writer
 func (p *processor) WriteDebugInfo(w io.Writer) {
 	fmt.Fprintf(w, "%+v\n", *p.changefeed)
@@ -28,9 +28,11 @@ import (
 	"github.com/pingcap/ticdc/cdc/model"
 	tablepipeline "github.com/pingcap/ticdc/cdc/processor/pipeline"
 	"github.com/pingcap/ticdc/cdc/redo"
+	"github.com/pingcap/ticdc/pkg/config"
 	cdcContext "github.com/pingcap/ticdc/pkg/context"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/orchestrator"
 	"github.com/pingcap/ticdc/pkg/util/testleak"
 )
@@ -51,6 +53,9 @@ func newProcessor4Test(
 	p.redoManager = redo.NewDisabledManager()
 	p.createTablePipeline = createTablePipeline
 	p.schemaStorage = &mockSchemaStorage{c: c}
+	var err error
+	p.filter, err = filter.NewFilter(config.GetDefaultReplicaConfig())
+	c.Assert(err, check.IsNil)
 	return p
 }
 
@@ -117,6 +122,17 @@ func (m *mockTablePipeline) Status() tablepipeline.TableStatus {
 	return m.status
 }
 
+func (m *mockTablePipeline) Snapshot() *model.TablePipelineStatus {
+	return &model.TablePipelineStatus{
+		TableID:      m.tableID,
+		TableName:    m.name,
+		Status:       m.status.String(),
+		ResolvedTs:   m.resolvedTs,
+		CheckpointTs: m.checkpointTs,
+		BarrierTs:    m.barrierTs,
+	}
+}
+
 func (m *mockTablePipeline) Cancel() {
 	if m.canceled {
 		log.Panic("cancel a canceled table pipeline")
@@ -524,6 +540,8 @@ func (s *processorSuite) TestProcessorError(c *check.C) {
 			Addr:    "127.0.0.1:0000",
 			Code:    "CDC:ErrSinkURIInvalid",
 			Message: "[CDC:ErrSinkURIInvalid]sink uri invalid",
+			Class:   "config",
+			Hint:    "check the format and the parameters of the sink-uri",
 		},
 	})
 
@@ -649,6 +667,8 @@ func (s *processorSuite) TestProcessorClose(c *check.C) {
 		Addr:    "127.0.0.1:0000",
 		Code:    "CDC:ErrSinkURIInvalid",
 		Message: "[CDC:ErrSinkURIInvalid]sink uri invalid",
+		Class:   "config",
+		Hint:    "check the format and the parameters of the sink-uri",
 	})
 	c.Assert(p.tables[1].(*mockTablePipeline).canceled, check.IsTrue)
 	c.Assert(p.tables[2].(*mockTablePipeline).canceled, check.IsTrue)
@@ -0,0 +1,147 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/model"
+)
+
+// resourceUsageSampleInterval is the interval of sampling the resource usage
+// of the changefeeds.
+const resourceUsageSampleInterval = 10 * time.Second
+
+// pipelineUsage is the resource usage accounted by the table pipelines of a
+// changefeed.
+type pipelineUsage struct {
+	// memory is the size of the events buffered in the table pipelines
+	memory uint64
+	// events is the number of the events processed by the table pipelines
+	events uint64
+}
+
+// resourceUsageSampler attributes the CPU time of the capture process to the
+// changefeeds in proportion to the events processed by their table pipelines
+// during each sampling interval, and evenly if no event is processed. The
+// attribution is approximate, since the CPU time of the capture process also
+// includes the work shared by the changefeeds, such as the kv client.
+type resourceUsageSampler struct {
+	lastSampleTime time.Time
+	lastCPUTime    time.Duration
+	lastEvents     map[model.ChangeFeedID]uint64
+	cpuTimes       map[model.ChangeFeedID]time.Duration
+
+	status *model.ResourceUsageStatus
+}
+
+func newResourceUsageSampler() *resourceUsageSampler {
+	return &resourceUsageSampler{
+		lastEvents: make(map[model.ChangeFeedID]uint64),
+		cpuTimes:   make(map[model.ChangeFeedID]time.Duration),
+		status:     &model.ResourceUsageStatus{},
+	}
+}
+
+// due returns whether the resource usage should be sampled.
+func (s *resourceUsageSampler) due(now time.Time) bool {
+	return now.Sub(s.lastSampleTime) >= resourceUsageSampleInterval
+}
+
+// sample updates the resource usage with the CPU time and the heap in use of
+// the capture process, and the usage of the table pipelines of the
+// changefeeds. The CPU usage is unknown until the second sample.
+func (s *resourceUsageSampler) sample(
+	now time.Time, cpuTime time.Duration, heapInUse uint64, usages map[model.ChangeFeedID]pipelineUsage,
+) {
+	var interval, cpuDelta time.Duration
+	if !s.lastSampleTime.IsZero() {
+		interval = now.Sub(s.lastSampleTime)
+		cpuDelta = cpuTime - s.lastCPUTime
+	}
+	s.lastSampleTime = now
+	s.lastCPUTime = cpuTime
+
+	eventDeltas := make(map[model.ChangeFeedID]uint64, len(usages))
+	var totalEvents uint64
+	for changefeedID, usage := range usages {
+		delta := usage.events
+		// the number of the events decreases after the tables are removed or
+		// the processor is restarted
+		if last := s.lastEvents[changefeedID]; usage.events >= last {
+			delta = usage.events - last
+		}
+		eventDeltas[changefeedID] = delta
+		totalEvents += delta
+	}
+
+	status := &model.ResourceUsageStatus{
+		HeapInUse:   heapInUse,
+		Changefeeds: make([]model.ChangefeedResourceUsage, 0, len(usages)),
+	}
+	if interval > 0 {
+		status.CPUUsage = cpuDelta.Seconds() / interval.Seconds()
+	}
+	lastEvents := make(map[model.ChangeFeedID]uint64, len(usages))
+	cpuTimes := make(map[model.ChangeFeedID]time.Duration, len(usages))
+	for changefeedID, usage := range usages {
+		var cpuShare time.Duration
+		if totalEvents > 0 {
+			cpuShare = time.Duration(float64(cpuDelta) * float64(eventDeltas[changefeedID]) / float64(totalEvents))
+		} else {
+			cpuShare = cpuDelta / time.Duration(len(usages))
+		}
+		lastEvents[changefeedID] = usage.events
+		cpuTimes[changefeedID] = s.cpuTimes[changefeedID] + cpuShare
+		changefeedUsage := model.ChangefeedResourceUsage{
+			ChangefeedID:    changefeedID,
+			CPUTime:         cpuTimes[changefeedID].Seconds(),
+			MemoryUsage:     usage.memory,
+			ProcessedEvents: usage.events,
+		}
+		if interval > 0 {
+			changefeedUsage.CPUUsage = cpuShare.Seconds() / interval.Seconds()
+		}
+		status.Changefeeds = append(status.Changefeeds, changefeedUsage)
+	}
+	sort.Slice(status.Changefeeds, func(i, j int) bool {
+		if status.Changefeeds[i].CPUUsage != status.Changefeeds[j].CPUUsage {
+			return status.Changefeeds[i].CPUUsage > status.Changefeeds[j].CPUUsage
+		}
+		return status.Changefeeds[i].ChangefeedID < status.Changefeeds[j].ChangefeedID
+	})
+	// the changefeeds removed from the capture are forgotten
+	s.lastEvents = lastEvents
+	s.cpuTimes = cpuTimes
+	s.status = status
+}
+
+// snapshot returns a copy of the latest resource usage.
+func (s *resourceUsageSampler) snapshot() *model.ResourceUsageStatus {
+	status := *s.status
+	status.Changefeeds = append([]model.ChangefeedResourceUsage(nil), s.status.Changefeeds...)
+	return &status
+}
+
+// processCPUTime returns the user and system CPU time of the process.
+func processCPUTime() (time.Duration, error) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processor
+
+import (
+	"time"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+type resourceUsageSuite struct{}
+
+var _ = check.Suite(&resourceUsageSuite{})
+
+func (s *resourceUsageSuite) TestSample(c *check.C) {
+	defer testleak.AfterTest(c)()
+	sampler := newResourceUsageSampler()
+	now := time.Now()
+	c.Assert(sampler.due(now), check.IsTrue)
+	c.Assert(sampler.snapshot().Changefeeds, check.HasLen, 0)
+
+	sampler.sample(now, 10*time.Second, 1024, map[model.ChangeFeedID]pipelineUsage{
+		"cf1": {memory: 100, events: 1000},
+		"cf2": {memory: 200, events: 1000},
+	})
+	c.Assert(sampler.due(now.Add(time.Second)), check.IsFalse)
+	status := sampler.snapshot()
+	c.Assert(status.HeapInUse, check.Equals, uint64(1024))
+	// the cpu usage is unknown until the second sample
+	c.Assert(status.CPUUsage, check.Equals, float64(0))
+	c.Assert(status.Changefeeds, check.DeepEquals, []model.ChangefeedResourceUsage{
+		{ChangefeedID: "cf1", MemoryUsage: 100, ProcessedEvents: 1000},
+		{ChangefeedID: "cf2", MemoryUsage: 200, ProcessedEvents: 1000},
+	})
+
+	// cf2 processes three times the events of cf1
+	now = now.Add(resourceUsageSampleInterval)
+	c.Assert(sampler.due(now), check.IsTrue)
+	sampler.sample(now, 18*time.Second, 2048, map[model.ChangeFeedID]pipelineUsage{
+		"cf1": {memory: 100, events: 1100},
+		"cf2": {memory: 300, events: 1300},
+	})
+	status = sampler.snapshot()
+	c.Assert(status.CPUUsage, check.Equals, 0.8)
+	c.Assert(status.Changefeeds, check.DeepEquals, []model.ChangefeedResourceUsage{
+		{ChangefeedID: "cf2", CPUTime: 6, CPUUsage: 0.6, MemoryUsage: 300, ProcessedEvents: 1300},
+		{ChangefeedID: "cf1", CPUTime: 2, CPUUsage: 0.2, MemoryUsage: 100, ProcessedEvents: 1100},
+	})
+
+	// the cpu time is evenly attributed if no event is processed, and the
+	// removed changefeed is forgotten
+	now = now.Add(resourceUsageSampleInterval)
+	sampler.sample(now, 20*time.Second, 2048, map[model.ChangeFeedID]pipelineUsage{
+		"cf1": {memory: 0, events: 1100},
+		"cf3": {memory: 0, events: 0},
+	})
+	status = sampler.snapshot()
+	c.Assert(status.Changefeeds, check.DeepEquals, []model.ChangefeedResourceUsage{
+		{ChangefeedID: "cf1", CPUTime: 3, CPUUsage: 0.1, ProcessedEvents: 1100},
+		{ChangefeedID: "cf3", CPUTime: 1, CPUUsage: 0.1},
+	})
+
+	// the number of the events decreases after the processor is restarted
+	now = now.Add(resourceUsageSampleInterval)
+	sampler.sample(now, 22*time.Second, 2048, map[model.ChangeFeedID]pipelineUsage{
+		"cf1": {memory: 0, events: 100},
+		"cf3": {memory: 0, events: 100},
+	})
+	status = sampler.snapshot()
+	c.Assert(status.Changefeeds[0].CPUTime, check.Equals, float64(4))
+	c.Assert(status.Changefeeds[1].CPUTime, check.Equals, float64(2))
+
+	// the snapshot is not affected by the later samples
+	status.CaptureID = "capture-1"
+	c.Assert(sampler.snapshot().CaptureID, check.Equals, "")
+}
+
+func (s *resourceUsageSuite) TestProcessCPUTime(c *check.C) {
+	defer testleak.AfterTest(c)()
+	cpuTime, err := processCPUTime()
+	c.Assert(err, check.IsNil)
+	c.Assert(cpuTime, check.Greater, time.Duration(0))
+}
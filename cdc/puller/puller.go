@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/ticdc/cdc/kv"
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/puller/frontier"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/regionspan"
 	"github.com/pingcap/ticdc/pkg/txnutil"
 	"github.com/pingcap/ticdc/pkg/util"
@@ -176,6 +177,7 @@ func (p *pullerImpl) Run(ctx context.Context) error {
 
 		start := time.Now()
 		initialized := false
+		governor := memquota.GlobalGovernor()
 		for {
 			var e model.RegionFeedEvent
 			select {
@@ -184,6 +186,14 @@ func (p *pullerImpl) Run(ctx context.Context) error {
 				return errors.Trace(ctx.Err())
 			}
 			if e.Val != nil {
+				// Stop outputting the row events while the memory limit of the
+				// server is reached, so that the backpressure is propagated to
+				// the kv client buffers. The resolved events are never blocked,
+				// the memory held by the sorter is released only after the
+				// resolved ts is advanced.
+				if err := governor.WaitAvailable(ctx); err != nil {
+					return errors.Trace(err)
+				}
 				metricTxnCollectCounterKv.Inc()
 				if err := output(e.Val); err != nil {
 					return errors.Trace(err)
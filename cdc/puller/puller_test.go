@@ -18,12 +18,15 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"testing"
+	"time"
 
 	"github.com/pingcap/check"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/ticdc/cdc/kv"
 	"github.com/pingcap/ticdc/cdc/model"
 	cerrors "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/regionspan"
 	"github.com/pingcap/ticdc/pkg/retry"
 	"github.com/pingcap/ticdc/pkg/security"
@@ -35,6 +38,8 @@ import (
 	pd "github.com/tikv/pd/client"
 )
 
+func Test(t *testing.T) { check.TestingT(t) }
+
 type pullerSuite struct{}
 
 var _ = check.Suite(&pullerSuite{})
@@ -222,3 +227,50 @@ func (s *pullerSuite) TestPullerRawKV(c *check.C) {
 	cancel()
 	wg.Wait()
 }
+
+func (s *pullerSuite) TestPullerMemoryLimitReached(c *check.C) {
+	defer testleak.AfterTest(c)()
+	governor := memquota.GlobalGovernor()
+	governor.SetLimit(1024)
+	governor.ForceAcquire(memquota.ComponentSorter, 1024)
+	defer governor.SetLimit(0)
+	spans := []regionspan.Span{
+		{Start: []byte("t_a"), End: []byte("t_e")},
+	}
+	checkpointTs := uint64(996)
+	plr, cancel, wg, store := s.newPullerForTest(c, spans, checkpointTs)
+
+	// the resolved events are never blocked
+	plr.cli.Returns(model.RegionFeedEvent{
+		Resolved: &model.ResolvedSpan{
+			Span:       regionspan.ToComparableSpan(spans[0]),
+			ResolvedTs: uint64(1000),
+		},
+	})
+	ev := <-plr.Output()
+	c.Assert(ev.OpType, check.Equals, model.OpTypeResolved)
+	c.Assert(ev.CRTs, check.Equals, uint64(1000))
+
+	// the row events are blocked until the memory is released
+	plr.cli.Returns(model.RegionFeedEvent{
+		Val: &model.RawKVEntry{
+			OpType: model.OpTypePut,
+			Key:    []byte("t_b"),
+			Value:  []byte("test-value"),
+			CRTs:   uint64(1002),
+		},
+	})
+	select {
+	case ev := <-plr.Output():
+		c.Fatalf("unexpected event %v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+	governor.Release(memquota.ComponentSorter, 1024)
+	ev = <-plr.Output()
+	c.Assert(ev.OpType, check.Equals, model.OpTypePut)
+	c.Assert(ev.Key, check.DeepEquals, []byte("t_b"))
+
+	store.Close()
+	cancel()
+	wg.Wait()
+}
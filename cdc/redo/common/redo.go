@@ -21,6 +21,12 @@ const (
 	MinSectorSize = 512
 )
 
+// FileHeaderMark is the most significant byte of the length field of the
+// header frame at the beginning of a log file, the header records the name of
+// the compression codec of the records in the file. The length field of a
+// record never has the byte, whose most significant byte is 0 or 0x80|padBytes.
+const FileHeaderMark = 0x7f
+
 const (
 	// TmpEXT is the file ext of log file before safely wrote to disk
 	TmpEXT = ".tmp"
@@ -55,4 +61,7 @@ type LogMeta struct {
 	CheckPointTs   uint64           `msg:"checkPointTs"`
 	ResolvedTs     uint64           `msg:"resolvedTs"`
 	ResolvedTsList map[int64]uint64 `msg:"-"`
+	// Compression is the compression of the records in the log files, see
+	// pkg/compression for the format. Empty means no compression.
+	Compression string `msg:"compression"`
 }
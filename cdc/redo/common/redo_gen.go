@@ -36,6 +36,12 @@ func (z *LogMeta) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "ResolvedTs")
 				return
 			}
+		case "compression":
+			z.Compression, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Compression")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -49,9 +55,9 @@ func (z *LogMeta) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z LogMeta) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
+	// map header, size 3
 	// write "checkPointTs"
-	err = en.Append(0x82, 0xac, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x73)
+	err = en.Append(0x83, 0xac, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x73)
 	if err != nil {
 		return
 	}
@@ -70,19 +76,32 @@ func (z LogMeta) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "ResolvedTs")
 		return
 	}
+	// write "compression"
+	err = en.Append(0xab, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Compression)
+	if err != nil {
+		err = msgp.WrapError(err, "Compression")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z LogMeta) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 3
 	// string "checkPointTs"
-	o = append(o, 0x82, 0xac, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x73)
+	o = append(o, 0x83, 0xac, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x54, 0x73)
 	o = msgp.AppendUint64(o, z.CheckPointTs)
 	// string "resolvedTs"
 	o = append(o, 0xaa, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x54, 0x73)
 	o = msgp.AppendUint64(o, z.ResolvedTs)
+	// string "compression"
+	o = append(o, 0xab, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Compression)
 	return
 }
 
@@ -116,6 +135,12 @@ func (z *LogMeta) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "ResolvedTs")
 				return
 			}
+		case "compression":
+			z.Compression, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Compression")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -130,6 +155,6 @@ func (z *LogMeta) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z LogMeta) Msgsize() (s int) {
-	s = 1 + 13 + msgp.Uint64Size + 11 + msgp.Uint64Size
+	s = 1 + 13 + msgp.Uint64Size + 11 + msgp.Uint64Size + 12 + msgp.StringPrefixSize + len(z.Compression)
 	return
 }
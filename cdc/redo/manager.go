@@ -87,6 +87,40 @@ func IsS3StorageEnabled(storage string) bool {
 	return consistentStorage(storage) == consistentStorageS3
 }
 
+// ValidateConsistentConfig checks whether the consistent level and the storage
+// of the redo log are valid, the storage is only checked if the redo log is enabled.
+func ValidateConsistentConfig(cfg *config.ConsistentConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if !IsValidConsistentLevel(cfg.Level) {
+		return cerror.ErrConsistentLevel.GenWithStackByArgs(cfg.Level)
+	}
+	if !IsConsistentEnabled(cfg.Level) {
+		return nil
+	}
+	uri, err := storage.ParseRawURL(cfg.Storage)
+	if err != nil {
+		return cerror.ErrConsistentStorage.Wrap(err).GenWithStackByArgs(cfg.Storage)
+	}
+	if !IsValidConsistentStorage(uri.Scheme) {
+		return cerror.ErrConsistentStorage.GenWithStackByArgs(cfg.Storage)
+	}
+	switch consistentStorage(uri.Scheme) {
+	case consistentStorageLocal, consistentStorageNFS:
+		// the redo logs are stored in the path directly
+		if uri.Path == "" {
+			return cerror.ErrConsistentStorage.GenWithStackByArgs(cfg.Storage)
+		}
+	case consistentStorageS3:
+		// the host is the bucket of S3
+		if uri.Host == "" {
+			return cerror.ErrConsistentStorage.GenWithStackByArgs(cfg.Storage)
+		}
+	}
+	return nil
+}
+
 // LogManager defines an interface that is used to manage redo log
 type LogManager interface {
 	// Enabled returns whether the log manager is enabled
@@ -144,6 +178,9 @@ func NewManager(ctx context.Context, cfg *config.ConsistentConfig, opts *Manager
 	if cfg == nil || consistentLevelType(cfg.Level) == consistentLevelNone {
 		return &ManagerImpl{enabled: false}, nil
 	}
+	if !IsValidConsistentLevel(cfg.Level) {
+		return nil, cerror.ErrConsistentLevel.GenWithStackByArgs(cfg.Level)
+	}
 	uri, err := storage.ParseRawURL(cfg.Storage)
 	if err != nil {
 		return nil, err
@@ -177,6 +214,7 @@ func NewManager(ctx context.Context, cfg *config.ConsistentConfig, opts *Manager
 			MaxLogSize:        cfg.MaxLogSize,
 			FlushIntervalInMs: cfg.FlushIntervalInMs,
 			S3Storage:         m.storageType == consistentStorageS3,
+			Compression:       cfg.Compression,
 		}
 		if writerCfg.S3Storage {
 			writerCfg.S3URI = *uri
@@ -79,6 +79,49 @@ func TestConsistentConfig(t *testing.T) {
 	}
 }
 
+func TestValidateConsistentConfig(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		cfg    *config.ConsistentConfig
+		errMsg string
+	}{
+		{cfg: nil},
+		{cfg: &config.ConsistentConfig{Level: "none", Storage: "invalid://"}},
+		{cfg: &config.ConsistentConfig{Level: "eventual", Storage: "blackhole://"}},
+		{cfg: &config.ConsistentConfig{Level: "eventual", Storage: "local:///tmp/redo"}},
+		{cfg: &config.ConsistentConfig{Level: "eventual", Storage: "nfs:///mnt/redo"}},
+		{cfg: &config.ConsistentConfig{Level: "eventual", Storage: "s3://bucket/prefix"}},
+		{
+			cfg:    &config.ConsistentConfig{Level: "normal", Storage: "local:///tmp/redo"},
+			errMsg: ".*ErrConsistentLevel.*",
+		},
+		{
+			cfg:    &config.ConsistentConfig{Level: "eventual", Storage: "hdfs://namenode/redo"},
+			errMsg: ".*ErrConsistentStorage.*",
+		},
+		{
+			cfg:    &config.ConsistentConfig{Level: "eventual", Storage: "local://"},
+			errMsg: ".*ErrConsistentStorage.*",
+		},
+		{
+			cfg:    &config.ConsistentConfig{Level: "eventual", Storage: "s3:///prefix"},
+			errMsg: ".*ErrConsistentStorage.*",
+		},
+	}
+	for _, tc := range testCases {
+		err := ValidateConsistentConfig(tc.cfg)
+		if tc.errMsg == "" {
+			require.Nil(t, err)
+		} else {
+			require.Regexp(t, tc.errMsg, err)
+		}
+	}
+
+	_, err := NewManager(context.Background(),
+		&config.ConsistentConfig{Level: "normal", Storage: "blackhole://"}, &ManagerOptions{})
+	require.Regexp(t, ".*ErrConsistentLevel.*", err)
+}
+
 // TestLogManagerInProcessor tests how redo log manager is used in processor,
 // where the redo log manager needs to handle DMLs and redo log meta data
 func TestLogManagerInProcessor(t *testing.T) {
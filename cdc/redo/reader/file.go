@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/redo/common"
 	"github.com/pingcap/ticdc/cdc/redo/writer"
+	"github.com/pingcap/ticdc/pkg/compression"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"go.uber.org/multierr"
@@ -43,6 +44,10 @@ const (
 	// frameSizeBytes is frame size in bytes, including record size and padding size.
 	frameSizeBytes = 8
 
+	// maxFileHeaderBytes is the maximum size of the codec name in the header
+	// of a log file, a larger size means the file is corrupted.
+	maxFileHeaderBytes = 256
+
 	// defaultWorkerNum is the num of workers used to sort the log file to sorted file,
 	// will load the file to memory first then write the sorted file to disk
 	// the memory used is defaultWorkerNum * defaultMaxLogSize (64 * megabyte) total
@@ -72,6 +77,12 @@ type reader struct {
 	br       *bufio.Reader
 	fileName string
 	closer   io.Closer
+	// codec decompresses the records, it's the codec recorded in the header
+	// of the file, the files without the header are not compressed, whose
+	// codec is nil.
+	codec compression.Codec
+	// headerRead is true if the header of the file has been read
+	headerRead bool
 	// lastValidOff file offset following the last valid decoded record
 	lastValidOff int64
 }
@@ -95,7 +106,6 @@ func newReader(ctx context.Context, cfg *readerConfig) ([]fileReader, error) {
 	if cfg.workerNums == 0 {
 		cfg.workerNums = defaultWorkerNum
 	}
-
 	rr, err := openSelectedFiles(ctx, cfg.dir, cfg.fileType, cfg.startTs, cfg.workerNums)
 	if err != nil {
 		return nil, err
@@ -164,7 +174,9 @@ func downLoadToLocal(ctx context.Context, dir string, s3storage storage.External
 	return eg.Wait()
 }
 
-func openSelectedFiles(ctx context.Context, dir, fixedType string, startTs uint64, workerNum int) ([]io.ReadCloser, error) {
+func openSelectedFiles(
+	ctx context.Context, dir, fixedType string, startTs uint64, workerNum int,
+) ([]io.ReadCloser, error) {
 	files, err := ioutil.ReadDir(dir)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrRedoFileOp, errors.Annotatef(err, "can't read log file directory: %s", dir))
@@ -274,7 +286,9 @@ func writFile(ctx context.Context, dir, name string, h logHeap) error {
 	return w.Close()
 }
 
-func createSortedFiles(ctx context.Context, dir string, names []string, workerNum int) ([]io.ReadCloser, error) {
+func createSortedFiles(
+	ctx context.Context, dir string, names []string, workerNum int,
+) ([]io.ReadCloser, error) {
 	logFiles := []io.ReadCloser{}
 	errCh := make(chan error)
 	retCh := make(chan io.ReadCloser)
@@ -313,7 +327,9 @@ func createSortedFiles(ctx context.Context, dir string, names []string, workerNu
 	return logFiles, nil
 }
 
-func createSortedFile(ctx context.Context, dir string, name string, errCh chan error, retCh chan io.ReadCloser) {
+func createSortedFile(
+	ctx context.Context, dir string, name string, errCh chan error, retCh chan io.ReadCloser,
+) {
 	path := filepath.Join(dir, name)
 	file, err := openReadFile(path)
 	if err != nil {
@@ -372,6 +388,13 @@ func (r *reader) Read(redoLog *model.RedoLog) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if !r.headerRead {
+		r.headerRead = true
+		if err := r.readHeader(); err != nil {
+			return err
+		}
+	}
+
 	lenField, err := readInt64(r.br)
 	if err != nil {
 		if err == io.EOF {
@@ -393,7 +416,18 @@ func (r *reader) Read(redoLog *model.RedoLog) error {
 		return cerror.WrapError(cerror.ErrRedoFileOp, err)
 	}
 
-	_, err = redoLog.UnmarshalMsg(data[:recBytes])
+	rec := data[:recBytes]
+	if r.codec != nil {
+		rec, err = r.codec.Decompress(nil, rec)
+		if err != nil {
+			if r.isTornEntry(data) {
+				return io.EOF
+			}
+			return cerror.WrapError(cerror.ErrRedoFileOp, err)
+		}
+	}
+
+	_, err = redoLog.UnmarshalMsg(rec)
 	if err != nil {
 		if r.isTornEntry(data) {
 			// just return io.EOF, since if torn write it is the last redoLog entry
@@ -407,6 +441,43 @@ func (r *reader) Read(redoLog *model.RedoLog) error {
 	return nil
 }
 
+// readHeader reads the header frame at the beginning of the file if any, and
+// uses the codec recorded in it to decompress the records.
+func (r *reader) readHeader() error {
+	buf, err := r.br.Peek(frameSizeBytes)
+	if err != nil {
+		// the empty or torn file is handled by reading the records
+		return nil
+	}
+	lenField := binary.LittleEndian.Uint64(buf)
+	if lenField>>56 != common.FileHeaderMark {
+		return nil
+	}
+	nameBytes := int64(lenField & ^(uint64(0xff) << 56))
+	if nameBytes > maxFileHeaderBytes {
+		return cerror.ErrRedoFileOp.GenWithStack("invalid header of redo log file %s", r.fileName)
+	}
+	padBytes := (8 - nameBytes%8) % 8
+	data := make([]byte, frameSizeBytes+nameBytes+padBytes)
+	_, err = io.ReadFull(r.br, data)
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			log.Warn("read redo log header have unexpected io error",
+				zap.String("fileName", r.fileName),
+				zap.Error(err))
+			return io.EOF
+		}
+		return cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	codec, err := compression.New(string(data[frameSizeBytes : frameSizeBytes+nameBytes]))
+	if err != nil {
+		return cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	r.codec = codec
+	r.lastValidOff += int64(len(data))
+	return nil
+}
+
 func readInt64(r io.Reader) (int64, error) {
 	var n int64
 	err := binary.Read(r, binary.LittleEndian, &n)
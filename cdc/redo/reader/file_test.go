@@ -15,6 +15,8 @@ package reader
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -26,6 +28,7 @@ import (
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/redo/common"
 	"github.com/pingcap/ticdc/cdc/redo/writer"
+	"github.com/pingcap/ticdc/pkg/compression"
 	"github.com/pingcap/ticdc/pkg/leakutil"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
@@ -97,6 +100,86 @@ func TestReaderRead(t *testing.T) {
 	time.Sleep(1001 * time.Millisecond)
 }
 
+func TestReaderReadCompressed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "redo-reader-compressed")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the files are written by the captures with different compressions
+	for captureID, spec := range map[string]string{"cp1": "lz4", "cp2": "gzip:6", "cp3": compression.None} {
+		cfg := &writer.FileWriterConfig{
+			MaxLogSize:   100000,
+			Dir:          dir,
+			ChangeFeedID: "test-cf",
+			CaptureID:    captureID,
+			FileType:     common.DefaultRowLogFileType,
+			CreateTime:   time.Date(2000, 1, 1, 1, 1, 1, 1, &time.Location{}),
+			Compression:  spec,
+		}
+		w, err := writer.NewWriter(ctx, cfg)
+		require.Nil(t, err)
+		for ts := uint64(20); ts > 10; ts-- {
+			log := &model.RedoLog{
+				RedoRow: &model.RedoRowChangedEvent{Row: &model.RowChangedEvent{CommitTs: ts}},
+			}
+			data, err := log.MarshalMsg(nil)
+			require.Nil(t, err)
+			w.AdvanceTs(ts)
+			_, err = w.Write(data)
+			require.Nil(t, err)
+		}
+		require.Nil(t, w.Close())
+	}
+
+	// the records are decompressed by the codec recorded in each file, and
+	// are sorted into the files without compression
+	r, err := newReader(ctx, &readerConfig{
+		dir:      dir,
+		startTs:  1,
+		endTs:    20,
+		fileType: common.DefaultRowLogFileType,
+	})
+	require.Nil(t, err)
+	require.Equal(t, 3, len(r))
+	for i := range r {
+		defer r[i].Close() //nolint:errcheck
+		for ts := uint64(11); ts <= 20; ts++ {
+			log := &model.RedoLog{}
+			require.Nil(t, r[i].Read(log))
+			require.Equal(t, ts, log.RedoRow.Row.CommitTs)
+		}
+		require.Equal(t, io.EOF, r[i].Read(&model.RedoLog{}))
+	}
+	time.Sleep(1001 * time.Millisecond)
+}
+
+func TestReaderReadWithoutHeader(t *testing.T) {
+	log := &model.RedoLog{
+		RedoRow: &model.RedoRowChangedEvent{Row: &model.RowChangedEvent{CommitTs: 10}},
+	}
+	data, err := log.MarshalMsg(nil)
+	require.Nil(t, err)
+
+	// the file written without the header is not compressed, which is the
+	// format of the old versions
+	buf := &bytes.Buffer{}
+	padBytes := (8 - len(data)%8) % 8
+	lenField := uint64(len(data))
+	if padBytes != 0 {
+		lenField |= uint64(0x80|padBytes) << 56
+	}
+	require.Nil(t, binary.Write(buf, binary.LittleEndian, lenField))
+	buf.Write(data)
+	buf.Write(make([]byte, padBytes))
+	r := &reader{br: bufio.NewReader(buf)}
+	log = &model.RedoLog{}
+	require.Nil(t, r.Read(log))
+	require.Equal(t, uint64(10), log.RedoRow.Row.CommitTs)
+	require.Equal(t, io.EOF, r.Read(&model.RedoLog{}))
+}
+
 func TestReaderOpenSelectedFiles(t *testing.T) {
 	dir, err := ioutil.TempDir("", "redo-openSelectedFiles")
 	require.Nil(t, err)
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/redo/common"
+	"github.com/pingcap/ticdc/pkg/compression"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/uber-go/atomic"
@@ -84,6 +85,9 @@ type FileWriterConfig struct {
 	FlushIntervalInMs int64
 	S3Storage         bool
 	S3URI             url.URL
+	// Compression is the compression of the records, see pkg/compression for
+	// the format. Empty means no compression.
+	Compression string
 }
 
 // Option define the writerOptions
@@ -118,7 +122,10 @@ type Writer struct {
 	file          *os.File
 	bw            *pioutil.PageWriter
 	uint64buf     []byte
-	storage       storage.ExternalStorage
+	// codec compresses the records, nil means no compression. Its name is
+	// recorded in the header of each log file if it's not nil.
+	codec   compression.Codec
+	storage storage.ExternalStorage
 	sync.RWMutex
 }
 
@@ -135,9 +142,16 @@ func NewWriter(ctx context.Context, cfg *FileWriterConfig, opts ...Option) (*Wri
 	if cfg.MaxLogSize == 0 {
 		cfg.MaxLogSize = defaultMaxLogSize
 	}
+	codec, err := compression.New(cfg.Compression)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrRedoConfigInvalid, err)
+	}
+	if codec.Name() == compression.None {
+		// the records without compression are written without the header
+		codec = nil
+	}
 	var s3storage storage.ExternalStorage
 	if cfg.S3Storage {
-		var err error
 		s3storage, err = common.InitS3storage(ctx, cfg.S3URI)
 		if err != nil {
 			return nil, err
@@ -152,6 +166,7 @@ func NewWriter(ctx context.Context, cfg *FileWriterConfig, opts ...Option) (*Wri
 		cfg:       cfg,
 		op:        op,
 		uint64buf: make([]byte, 8),
+		codec:     codec,
 		storage:   s3storage,
 	}
 
@@ -191,6 +206,13 @@ func (w *Writer) Write(rawData []byte) (int, error) {
 	w.Lock()
 	defer w.Unlock()
 
+	if w.codec != nil {
+		var err error
+		rawData, err = w.codec.Compress(nil, rawData)
+		if err != nil {
+			return 0, cerror.WrapError(cerror.ErrRedoFileOp, err)
+		}
+	}
 	writeLen := int64(len(rawData))
 	if writeLen > w.cfg.MaxLogSize {
 		return 0, cerror.ErrFileSizeExceed.GenWithStackByArgs(writeLen, w.cfg.MaxLogSize)
@@ -350,7 +372,28 @@ func (w *Writer) openNew() error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return w.writeHeader()
+}
+
+// writeHeader writes the header frame recording the compression codec of the
+// records, so that each file is decompressed by its own codec. The header is
+// written only if the records are compressed, the files without compression
+// keep the format which the readers of old versions can read.
+func (w *Writer) writeHeader() error {
+	if w.codec == nil {
+		return nil
+	}
+	name := []byte(w.codec.Name())
+	// the header is padded to 8 bytes alignment like the records, but the
+	// padding size isn't stored since it's not a record.
+	lenField := uint64(common.FileHeaderMark)<<56 | uint64(len(name))
+	if err := w.writeUint64(lenField, w.uint64buf); err != nil {
+		return cerror.WrapError(cerror.ErrRedoFileOp, err)
+	}
+	// the header isn't counted in the size of the file like the length fields
+	padBytes := (8 - (len(name) % 8)) % 8
+	_, err := w.bw.Write(append(name, make([]byte, padBytes)...))
+	return cerror.WrapError(cerror.ErrRedoFileOp, err)
 }
 
 func (w *Writer) openOrNew(writeLen int) error {
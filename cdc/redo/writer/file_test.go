@@ -15,6 +15,7 @@ package writer
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"io/ioutil"
 	"net/url"
@@ -26,6 +27,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/ticdc/cdc/redo/common"
+	"github.com/pingcap/ticdc/pkg/compression"
 	"github.com/pingcap/ticdc/pkg/leakutil"
 	mockstorage "github.com/pingcap/tidb/br/pkg/mock/storage"
 	"github.com/stretchr/testify/require"
@@ -201,6 +203,40 @@ func TestWriterGC(t *testing.T) {
 	time.Sleep(time.Duration(100) * time.Millisecond)
 }
 
+func TestWriterWriteHeader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "redo-writer-header")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	for captureID, spec := range map[string]string{"cp1": compression.None, "cp2": "lz4"} {
+		w, err := NewWriter(context.Background(), &FileWriterConfig{
+			MaxLogSize:   10,
+			Dir:          dir,
+			ChangeFeedID: "test-cf",
+			CaptureID:    captureID,
+			FileType:     common.DefaultRowLogFileType,
+			CreateTime:   time.Date(2000, 1, 1, 1, 1, 1, 1, &time.Location{}),
+			Compression:  spec,
+		})
+		require.Nil(t, err)
+		w.AdvanceTs(1)
+		_, err = w.Write([]byte("test"))
+		require.Nil(t, err)
+		require.Nil(t, w.Close())
+
+		fileName := fmt.Sprintf("%s_%s_%d_%s_%d%s", captureID, w.cfg.ChangeFeedID, w.cfg.CreateTime.Unix(), w.cfg.FileType, 1, common.LogEXT)
+		data, err := ioutil.ReadFile(filepath.Join(dir, fileName))
+		require.Nil(t, err)
+		lenField := binary.LittleEndian.Uint64(data)
+		if spec == compression.None {
+			// the file without compression starts with the first record
+			require.Equal(t, uint64(0x80|4)<<56|4, lenField)
+		} else {
+			require.Equal(t, uint64(common.FileHeaderMark), lenField>>56)
+		}
+	}
+}
+
 func TestAdvanceTs(t *testing.T) {
 	w := &Writer{}
 	w.AdvanceTs(111)
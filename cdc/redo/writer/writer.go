@@ -92,6 +92,10 @@ type LogWriterConfig struct {
 	S3Storage         bool
 	// S3URI should be like S3URI="s3://logbucket/test-changefeed?endpoint=http://$S3_ENDPOINT/"
 	S3URI url.URL
+	// Compression is the compression of the records, it is recorded in the
+	// header of each compressed log file so that the reader knows how to
+	// decompress the records.
+	Compression string
 }
 
 // LogWriter implement the RedoLogWriter interface
@@ -132,6 +136,7 @@ func NewLogWriter(ctx context.Context, cfg *LogWriterConfig) (*LogWriter, error)
 		FlushIntervalInMs: cfg.FlushIntervalInMs,
 		S3Storage:         cfg.S3Storage,
 		S3URI:             cfg.S3URI,
+		Compression:       cfg.Compression,
 	}
 	ddlCfg := &FileWriterConfig{
 		Dir:               cfg.Dir,
@@ -143,6 +148,7 @@ func NewLogWriter(ctx context.Context, cfg *LogWriterConfig) (*LogWriter, error)
 		FlushIntervalInMs: cfg.FlushIntervalInMs,
 		S3Storage:         cfg.S3Storage,
 		S3URI:             cfg.S3URI,
+		Compression:       cfg.Compression,
 	}
 	logWriter = &LogWriter{
 		cfg: cfg,
@@ -537,6 +543,7 @@ func (l *LogWriter) flushLogMeta(checkPointTs, resolvedTs uint64) error {
 	if resolvedTs != 0 {
 		l.meta.ResolvedTs = resolvedTs
 	}
+	l.meta.Compression = l.cfg.Compression
 	data, err := l.meta.MarshalMsg(nil)
 	if err != nil {
 		return cerror.WrapError(cerror.ErrMarshalFailed, err)
@@ -595,5 +602,5 @@ func (l *LogWriter) filePath() string {
 }
 
 func (cfg LogWriterConfig) String() string {
-	return fmt.Sprintf("%s:%s:%s:%d:%d:%s:%t", cfg.ChangeFeedID, cfg.CaptureID, cfg.Dir, cfg.MaxLogSize, cfg.FlushIntervalInMs, cfg.S3URI.String(), cfg.S3Storage)
+	return fmt.Sprintf("%s:%s:%s:%d:%d:%s:%t:%s", cfg.ChangeFeedID, cfg.CaptureID, cfg.Dir, cfg.MaxLogSize, cfg.FlushIntervalInMs, cfg.S3URI.String(), cfg.S3Storage, cfg.Compression)
 }
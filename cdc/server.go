@@ -31,6 +31,8 @@ import (
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/etcd"
 	"github.com/pingcap/ticdc/pkg/httputil"
+	"github.com/pingcap/ticdc/pkg/memquota"
+	"github.com/pingcap/ticdc/pkg/tracing"
 	"github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/ticdc/pkg/version"
 	tidbkv "github.com/pingcap/tidb/kv"
@@ -55,6 +57,7 @@ const (
 type Server struct {
 	capture      *capture.Capture
 	statusServer *http.Server
+	grpcServer   *grpc.Server
 	pdClient     pd.Client
 	etcdClient   *etcd.CDCEtcdClient
 	kvStorage    tidbkv.Storage
@@ -78,6 +81,17 @@ func NewServer(pdEndpoints []string) (*Server, error) {
 // Run runs the server.
 func (s *Server) Run(ctx context.Context) error {
 	conf := config.GetGlobalServerConfig()
+	memquota.GlobalGovernor().SetLimit(conf.MemoryLimit)
+
+	shutdownTracing, err := tracing.Setup(conf.Tracing, conf.AdvertiseAddr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Warn("shutdown tracing failed", zap.Error(err))
+		}
+	}()
 
 	grpcTLSOption, err := conf.Security.ToGRPCDialOption()
 	if err != nil {
@@ -168,7 +182,7 @@ func (s *Server) Run(ctx context.Context) error {
 
 	s.capture = capture.NewCapture(s.pdClient, s.kvStorage, s.etcdClient)
 
-	err = s.startStatusHTTP()
+	err = s.startStatusHTTP(ctx)
 	if err != nil {
 		return err
 	}
@@ -248,6 +262,10 @@ func (s *Server) Close() {
 	if s.capture != nil {
 		s.capture.AsyncClose()
 	}
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+		s.grpcServer = nil
+	}
 	if s.statusServer != nil {
 		err := s.statusServer.Close()
 		if err != nil {
@@ -15,6 +15,8 @@ package sink
 
 import (
 	"encoding/binary"
+	"fmt"
+	"strings"
 
 	"github.com/pingcap/log"
 	"go.uber.org/zap"
@@ -70,6 +72,29 @@ func (c *causality) detectConflict(keys [][]byte) (bool, int) {
 	return firstIdx != -1, firstIdx
 }
 
+// conflictKeys returns the keys which are related to the existing relations.
+func (c *causality) conflictKeys(keys [][]byte) [][]byte {
+	var conflicts [][]byte
+	for _, key := range keys {
+		if _, ok := c.relations[string(key)]; ok {
+			conflicts = append(conflicts, key)
+		}
+	}
+	return conflicts
+}
+
+// describeKey returns the readable form of a key generated by genRowKeys,
+// which is used in the hot keys report.
+func describeKey(key []byte, table *model.TableName) string {
+	if len(key) <= 16 {
+		// the key is a table key
+		return table.QuoteString()
+	}
+	iIdx := binary.BigEndian.Uint64(key[len(key)-16 : len(key)-8])
+	values := strings.Split(string(key[:len(key)-17]), "\x00")
+	return fmt.Sprintf("%s index %d (%s)", table.QuoteString(), iIdx, strings.Join(values, ", "))
+}
+
 func genTxnKeys(txn *model.SingleTableTxn) [][]byte {
 	if len(txn.Rows) == 0 {
 		return nil
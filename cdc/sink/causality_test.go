@@ -68,10 +68,38 @@ func (s *testCausalitySuite) TestCausality(c *check.C) {
 		c.Assert(conflict, check.Equals, cas.conflict, comment)
 		c.Assert(idx, check.Equals, cas.idx, comment)
 	}
+	c.Assert(ca.conflictKeys([][]byte{[]byte("a"), []byte("d"), []byte("c")}), check.DeepEquals,
+		[][]byte{[]byte("a"), []byte("c")})
 	ca.reset()
 	c.Assert(len(ca.relations), check.Equals, 0)
 }
 
+func (s *testCausalitySuite) TestDescribeKey(c *check.C) {
+	defer testleak.AfterTest(c)()
+	table := &model.TableName{Schema: "test", Table: "t", TableID: 47}
+	row := &model.RowChangedEvent{
+		Table: table,
+		Columns: []*model.Column{
+			{Name: "a", Type: mysql.TypeLong, Value: 1},
+			{Name: "b", Type: mysql.TypeVarchar, Value: "x"},
+		},
+		IndexColumns: [][]int{{0}, {0, 1}},
+	}
+	var descriptions []string
+	for _, key := range genRowKeys(row) {
+		descriptions = append(descriptions, describeKey(key, table))
+	}
+	c.Assert(descriptions, check.DeepEquals, []string{
+		"`test`.`t` index 0 (1)",
+		"`test`.`t` index 1 (1, x)",
+	})
+
+	row.IndexColumns = nil
+	keys := genRowKeys(row)
+	c.Assert(keys, check.HasLen, 1)
+	c.Assert(describeKey(keys[0], table), check.Equals, "`test`.`t`")
+}
+
 func (s *testCausalitySuite) TestGenKeys(c *check.C) {
 	defer testleak.AfterTest(c)()
 	testCases := []struct {
@@ -138,6 +138,12 @@ func (a *AvroEventBatchEncoder) EncodeCheckpointEvent(ts uint64) (*MQMessage, er
 	return nil, nil
 }
 
+// EncodeTableStartedEvent is not supported by avro, the MQ sink refuses to
+// emit table started events for it.
+func (a *AvroEventBatchEncoder) EncodeTableStartedEvent(table *model.TableName, ts uint64) (*MQMessage, error) {
+	return nil, cerror.ErrSinkInvalidConfig.GenWithStack("the table started event is not supported by avro")
+}
+
 // EncodeDDLEvent is no-op now
 func (a *AvroEventBatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*MQMessage, error) {
 	return nil, nil
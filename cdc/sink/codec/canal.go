@@ -329,6 +329,13 @@ func (d *CanalEventBatchEncoder) EncodeCheckpointEvent(ts uint64) (*MQMessage, e
 	return nil, nil
 }
 
+// EncodeTableStartedEvent implements the EventBatchEncoder interface
+func (d *CanalEventBatchEncoder) EncodeTableStartedEvent(table *model.TableName, ts uint64) (*MQMessage, error) {
+	// For canal now, there is no such a corresponding type to TableStartedEvent so far.
+	// Therefore the MQ sink refuses to emit table started events for it.
+	return nil, cerror.ErrSinkInvalidConfig.GenWithStack("the table started event is not supported by canal")
+}
+
 // AppendRowChangedEvent implements the EventBatchEncoder interface
 func (d *CanalEventBatchEncoder) AppendRowChangedEvent(e *model.RowChangedEvent) (EncoderResult, error) {
 	entry, err := d.entryBuilder.FromRowEvent(e)
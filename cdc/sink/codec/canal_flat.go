@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/ticdc/cdc/model"
 	cerrors "github.com/pingcap/ticdc/pkg/errors"
 	canal "github.com/pingcap/ticdc/proto/canal"
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/types"
 	"go.uber.org/zap"
 )
@@ -36,8 +37,12 @@ type CanalFlatEventBatchEncoder struct {
 	unresolvedBuf []canalFlatMessageInterface
 	resolvedBuf   []canalFlatMessageInterface
 	// When it is true, canal-json would generate TiDB extension information
-	// which, at the moment, only includes `tidbWaterMarkType` and `_tidb` fields.
+	// which includes `tidbWaterMarkType` and `_tidb` fields. The `_tidb` field
+	// carries the commit ts of the events, the watermark ts of the watermark
+	// messages and the table schema of the DDL events.
 	enableTiDBExtension bool
+	// identity is attached to the `_tidb` field, it requires enableTiDBExtension.
+	identity *identity
 }
 
 const tidbWaterMarkType = "TIDB_WATERMARK"
@@ -145,6 +150,66 @@ func (c *canalFlatMessage) getMySQLType() map[string]string {
 type tidbExtension struct {
 	CommitTs    uint64 `json:"commit-ts"`
 	WatermarkTs uint64 `json:"watermark-ts"`
+
+	// The type of the DDL and the schema of the table after the DDL, only set
+	// for the DDL events.
+	DDLType     timodel.ActionType    `json:"ddl-type,omitempty"`
+	TableSchema *canalFlatTableSchema `json:"table-schema,omitempty"`
+
+	// The upstream identity, only set if it is required.
+	ClusterID    uint64 `json:"cluster-id,omitempty"`
+	ChangefeedID string `json:"changefeed-id,omitempty"`
+	CaptureAddr  string `json:"capture-addr,omitempty"`
+}
+
+// canalFlatTableSchema is the schema of a table carried by the DDL events, so
+// that the consumers can track the schema of the tables without querying the
+// upstream.
+type canalFlatTableSchema struct {
+	TableID int64                   `json:"table-id"`
+	Columns []canalFlatColumnSchema `json:"columns"`
+}
+
+type canalFlatColumnSchema struct {
+	Name      string `json:"name"`
+	MySQLType string `json:"mysql-type"`
+}
+
+func newCanalFlatTableSchema(tableInfo *model.SimpleTableInfo) *canalFlatTableSchema {
+	if tableInfo == nil || len(tableInfo.ColumnInfo) == 0 {
+		return nil
+	}
+	schema := &canalFlatTableSchema{
+		TableID: tableInfo.TableID,
+		Columns: make([]canalFlatColumnSchema, 0, len(tableInfo.ColumnInfo)),
+	}
+	for _, col := range tableInfo.ColumnInfo {
+		schema.Columns = append(schema.Columns, canalFlatColumnSchema{
+			Name:      col.Name,
+			MySQLType: types.TypeStr(col.Type),
+		})
+	}
+	return schema
+}
+
+func (s *canalFlatTableSchema) toColumnInfo() []*model.ColumnInfo {
+	columns := make([]*model.ColumnInfo, 0, len(s.Columns))
+	for _, col := range s.Columns {
+		columns = append(columns, &model.ColumnInfo{
+			Name: col.Name,
+			Type: types.StrToType(col.MySQLType),
+		})
+	}
+	return columns
+}
+
+func (e *tidbExtension) setIdentity(id *identity) *tidbExtension {
+	if id != nil {
+		e.ClusterID = id.ClusterID
+		e.ChangefeedID = id.ChangefeedID
+		e.CaptureAddr = id.CaptureAddr
+	}
+	return e
 }
 
 type canalFlatMessageWithTiDBExtension struct {
@@ -275,7 +340,7 @@ func (c *CanalFlatEventBatchEncoder) newFlatMessageForDML(e *model.RowChangedEve
 
 	return &canalFlatMessageWithTiDBExtension{
 		canalFlatMessage: flatMessage,
-		Extensions:       &tidbExtension{CommitTs: e.CommitTs},
+		Extensions:       (&tidbExtension{CommitTs: e.CommitTs}).setIdentity(c.identity),
 	}, nil
 }
 
@@ -299,7 +364,11 @@ func (c *CanalFlatEventBatchEncoder) newFlatMessageForDDL(e *model.DDLEvent) can
 
 	return &canalFlatMessageWithTiDBExtension{
 		canalFlatMessage: flatMessage,
-		Extensions:       &tidbExtension{CommitTs: e.CommitTs},
+		Extensions: (&tidbExtension{
+			CommitTs:    e.CommitTs,
+			DDLType:     e.Type,
+			TableSchema: newCanalFlatTableSchema(e.TableInfo),
+		}).setIdentity(c.identity),
 	}
 }
 
@@ -312,7 +381,7 @@ func (c *CanalFlatEventBatchEncoder) newFlatMessage4CheckpointEvent(ts uint64) *
 			ExecutionTime: convertToCanalTs(ts),
 			BuildTime:     time.Now().UnixNano() / int64(time.Millisecond), // converts to milliseconds
 		},
-		Extensions: &tidbExtension{WatermarkTs: ts},
+		Extensions: (&tidbExtension{WatermarkTs: ts}).setIdentity(c.identity),
 	}
 }
 
@@ -330,6 +399,12 @@ func (c *CanalFlatEventBatchEncoder) EncodeCheckpointEvent(ts uint64) (*MQMessag
 	return newResolvedMQMessage(ProtocolCanalJSON, nil, value, ts), nil
 }
 
+// EncodeTableStartedEvent is not supported by canal-json, the MQ sink refuses
+// to emit table started events for it.
+func (c *CanalFlatEventBatchEncoder) EncodeTableStartedEvent(table *model.TableName, ts uint64) (*MQMessage, error) {
+	return nil, cerrors.ErrSinkInvalidConfig.GenWithStack("the table started event is not supported by canal-json")
+}
+
 // AppendRowChangedEvent implements the interface EventBatchEncoder
 func (c *CanalFlatEventBatchEncoder) AppendRowChangedEvent(e *model.RowChangedEvent) (EncoderResult, error) {
 	message, err := c.newFlatMessageForDML(e)
@@ -409,6 +484,14 @@ func (c *CanalFlatEventBatchEncoder) SetParams(params map[string]string) error {
 		}
 		c.enableTiDBExtension = a
 	}
+	var err error
+	c.identity, err = newIdentity(params)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.identity != nil && !c.enableTiDBExtension {
+		return cerrors.ErrSinkInvalidConfig.GenWithStack("the upstream identity of canal-json requires enable-tidb-extension")
+	}
 	return nil
 }
 
@@ -499,6 +582,11 @@ func (b *CanalFlatEventBatchDecoder) NextResolvedEvent() (uint64, error) {
 	return message.Extensions.WatermarkTs, nil
 }
 
+// NextTableStartedEvent implements the EventBatchDecoder interface
+func (b *CanalFlatEventBatchDecoder) NextTableStartedEvent() (*model.TableName, uint64, error) {
+	return nil, 0, cerrors.ErrCanalDecodeFailed.GenWithStack("not found table started event message")
+}
+
 func canalFlatMessage2RowChangedEvent(flatMessage canalFlatMessageInterface) (*model.RowChangedEvent, error) {
 	result := new(model.RowChangedEvent)
 	result.CommitTs = flatMessage.getCommitTs()
@@ -558,5 +646,14 @@ func canalFlatMessage2DDLEvent(flatDDL canalFlatMessageInterface) *model.DDLEven
 	// we lost DDL type from canal flat json format, only got the DDL SQL.
 	result.Query = flatDDL.getQuery()
 
+	// the DDL type and the table schema are restored from the TiDB extension
+	if msg, ok := flatDDL.(*canalFlatMessageWithTiDBExtension); ok && msg.Extensions != nil {
+		result.Type = msg.Extensions.DDLType
+		if schema := msg.Extensions.TableSchema; schema != nil {
+			result.TableInfo.TableID = schema.TableID
+			result.TableInfo.ColumnInfo = schema.toColumnInfo()
+		}
+	}
+
 	return result
 }
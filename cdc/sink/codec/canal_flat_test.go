@@ -205,6 +205,59 @@ func (s *canalFlatSuite) TestNewCanalFlatMessageFromDDL(c *check.C) {
 
 	c.Assert(withExtension.Extensions, check.NotNil)
 	c.Assert(withExtension.Extensions.CommitTs, check.Equals, testCaseDdl.CommitTs)
+	c.Assert(withExtension.Extensions.DDLType, check.Equals, mm.ActionCreateTable)
+	c.Assert(withExtension.Extensions.TableSchema, check.IsNil)
+}
+
+func (s *canalFlatSuite) TestDDLTableSchema(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ddl := &model.DDLEvent{
+		CommitTs: 417318403368288260,
+		TableInfo: &model.SimpleTableInfo{
+			Schema:  "cdc",
+			Table:   "person",
+			TableID: 100,
+			ColumnInfo: []*model.ColumnInfo{
+				{Name: "id", Type: mysql.TypeLong},
+				{Name: "name", Type: mysql.TypeVarchar},
+				{Name: "comment", Type: mysql.TypeBlob},
+			},
+		},
+		Query: "alter table person add column comment text",
+		Type:  mm.ActionAddColumn,
+	}
+	encoder := &CanalFlatEventBatchEncoder{builder: NewCanalEntryBuilder(), enableTiDBExtension: true}
+	result, err := encoder.EncodeDDLEvent(ddl)
+	c.Assert(err, check.IsNil)
+
+	var msg map[string]interface{}
+	c.Assert(json.Unmarshal(result.Value, &msg), check.IsNil)
+	c.Assert(msg["_tidb"], check.DeepEquals, map[string]interface{}{
+		"commit-ts":    float64(417318403368288260),
+		"watermark-ts": float64(0),
+		"ddl-type":     float64(mm.ActionAddColumn),
+		"table-schema": map[string]interface{}{
+			"table-id": float64(100),
+			"columns": []interface{}{
+				map[string]interface{}{"name": "id", "mysql-type": "int"},
+				map[string]interface{}{"name": "name", "mysql-type": "varchar"},
+				map[string]interface{}{"name": "comment", "mysql-type": "text"},
+			},
+		},
+	})
+
+	rawBytes, err := json.Marshal(result)
+	c.Assert(err, check.IsNil)
+	decoder := NewCanalFlatEventBatchDecoder(rawBytes, true)
+	ty, hasNext, err := decoder.HasNext()
+	c.Assert(err, check.IsNil)
+	c.Assert(hasNext, check.IsTrue)
+	c.Assert(ty, check.Equals, model.MqMessageTypeDDL)
+	consumed, err := decoder.NextDDLEvent()
+	c.Assert(err, check.IsNil)
+	c.Assert(consumed.CommitTs, check.Equals, ddl.CommitTs)
+	c.Assert(consumed.Type, check.Equals, ddl.Type)
+	c.Assert(consumed.TableInfo, check.DeepEquals, ddl.TableInfo)
 }
 
 func (s *canalFlatSuite) TestNewCanalFlatEventBatchDecoder4DDLMessage(c *check.C) {
@@ -337,6 +390,42 @@ func (s *canalFlatSuite) TestEncodeCheckpointEvent(c *check.C) {
 	}
 }
 
+func (s *canalFlatSuite) TestIdentity(c *check.C) {
+	defer testleak.AfterTest(c)()
+	params := map[string]string{
+		OptIdentityClusterID:    "6982447767308788395",
+		OptIdentityChangefeedID: "test-changefeed",
+		OptIdentityCaptureAddr:  "127.0.0.1:8300",
+	}
+	encoder := NewCanalFlatEventBatchEncoder()
+	err := encoder.SetParams(params)
+	c.Assert(err, check.ErrorMatches, ".*requires enable-tidb-extension.*")
+
+	params["enable-tidb-extension"] = "true"
+	err = encoder.SetParams(params)
+	c.Assert(err, check.IsNil)
+
+	msg, err := encoder.EncodeCheckpointEvent(2333)
+	c.Assert(err, check.IsNil)
+	var checkpoint struct {
+		Extensions *tidbExtension `json:"_tidb"`
+	}
+	c.Assert(json.Unmarshal(msg.Value, &checkpoint), check.IsNil)
+	c.Assert(checkpoint.Extensions, check.DeepEquals, &tidbExtension{
+		WatermarkTs:  2333,
+		ClusterID:    6982447767308788395,
+		ChangefeedID: "test-changefeed",
+		CaptureAddr:  "127.0.0.1:8300",
+	})
+
+	message, err := encoder.(*CanalFlatEventBatchEncoder).newFlatMessageForDML(testCaseUpdate)
+	c.Assert(err, check.IsNil)
+	row, ok := message.(*canalFlatMessageWithTiDBExtension)
+	c.Assert(ok, check.IsTrue)
+	c.Assert(row.Extensions.CommitTs, check.Equals, testCaseUpdate.CommitTs)
+	c.Assert(row.Extensions.ChangefeedID, check.Equals, "test-changefeed")
+}
+
 var testCaseUpdate = &model.RowChangedEvent{
 	CommitTs: 417318403368288260,
 	Table: &model.TableName{
@@ -41,6 +41,12 @@ func (e *CraftEventBatchEncoder) EncodeCheckpointEvent(ts uint64) (*MQMessage, e
 	return newResolvedMQMessage(ProtocolCraft, nil, craft.NewResolvedEventEncoder(e.allocator, ts).Encode(), ts), nil
 }
 
+// EncodeTableStartedEvent is not supported by craft, the MQ sink refuses to
+// emit table started events for it.
+func (e *CraftEventBatchEncoder) EncodeTableStartedEvent(table *model.TableName, ts uint64) (*MQMessage, error) {
+	return nil, cerror.ErrSinkInvalidConfig.GenWithStack("the table started event is not supported by craft")
+}
+
 func (e *CraftEventBatchEncoder) flush() {
 	headers := e.rowChangedBuffer.GetHeaders()
 	ts := headers.GetTs(0)
@@ -189,6 +195,11 @@ func (b *CraftEventBatchDecoder) NextResolvedEvent() (uint64, error) {
 	return ts, nil
 }
 
+// NextTableStartedEvent implements the EventBatchDecoder interface
+func (b *CraftEventBatchDecoder) NextTableStartedEvent() (*model.TableName, uint64, error) {
+	return nil, 0, cerror.ErrCraftCodecInvalidData.GenWithStack("not found table started event message")
+}
+
 // NextRowChangedEvent implements the EventBatchDecoder interface
 func (b *CraftEventBatchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
 	ty, hasNext, err := b.HasNext()
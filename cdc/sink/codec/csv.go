@@ -0,0 +1,228 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// Options of the csv protocol
+const (
+	OptCSVDelimiter       = "csv-delimiter"
+	OptCSVQuote           = "csv-quote"
+	OptCSVNullString      = "csv-null"
+	OptCSVIncludeCommitTs = "csv-include-commit-ts"
+)
+
+// The operation types of the rows in the first field
+const (
+	csvOperationInsert = "I"
+	csvOperationUpdate = "U"
+	csvOperationDelete = "D"
+)
+
+// csvTerminator terminates the rows as RFC 4180 specifies.
+const csvTerminator = "\r\n"
+
+type csvEventBatchEncoderBuilder struct {
+	opts map[string]string
+}
+
+// Build a `CSVEventBatchEncoder`
+func (b *csvEventBatchEncoderBuilder) Build(ctx context.Context) (EventBatchEncoder, error) {
+	encoder := NewCSVEventBatchEncoder()
+	if err := encoder.SetParams(b.opts); err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
+	}
+
+	return encoder, nil
+}
+
+func newCSVEventBatchEncoderBuilder(opts map[string]string) EncoderBuilder {
+	return &csvEventBatchEncoderBuilder{opts: opts}
+}
+
+// CSVEventBatchEncoder encodes each row changed event into a csv line. The
+// fields of a line are the operation type (I, U or D), the table name, the
+// schema name, the commit ts if it is included, and the values of the columns
+// in the order of the table schema. An update event carries the new values,
+// and a delete event carries the old values. The DDL and the checkpoint
+// events are not encoded, since there is no way to represent them in csv.
+type CSVEventBatchEncoder struct {
+	delimiter       string
+	quote           string
+	nullString      string
+	includeCommitTs bool
+
+	messages []*MQMessage
+	size     int
+}
+
+// NewCSVEventBatchEncoder creates a new CSVEventBatchEncoder.
+func NewCSVEventBatchEncoder() EventBatchEncoder {
+	return &CSVEventBatchEncoder{
+		delimiter:  config.DefaultCSVDelimiter,
+		quote:      config.DefaultCSVQuote,
+		nullString: config.DefaultCSVNullString,
+	}
+}
+
+// SetParams implements the EventBatchEncoder interface
+func (d *CSVEventBatchEncoder) SetParams(params map[string]string) error {
+	cfg := &config.CSVConfig{
+		Delimiter:  params[OptCSVDelimiter],
+		Quote:      params[OptCSVQuote],
+		NullString: params[OptCSVNullString],
+	}
+	if err := cfg.Validate(); err != nil {
+		return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+	}
+	d.delimiter = cfg.GetDelimiter()
+	d.quote = cfg.GetQuote()
+	d.nullString = cfg.GetNullString()
+	if s, ok := params[OptCSVIncludeCommitTs]; ok {
+		includeCommitTs, err := strconv.ParseBool(s)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		d.includeCommitTs = includeCommitTs
+	}
+	return nil
+}
+
+// EncodeCheckpointEvent is no-op for csv
+func (d *CSVEventBatchEncoder) EncodeCheckpointEvent(ts uint64) (*MQMessage, error) {
+	return nil, nil
+}
+
+// EncodeTableStartedEvent is not supported by csv, the MQ sink refuses to
+// emit table started events for it.
+func (d *CSVEventBatchEncoder) EncodeTableStartedEvent(table *model.TableName, ts uint64) (*MQMessage, error) {
+	return nil, cerror.ErrSinkInvalidConfig.GenWithStack("the table started event is not supported by csv")
+}
+
+// AppendRowChangedEvent implements the EventBatchEncoder interface
+func (d *CSVEventBatchEncoder) AppendRowChangedEvent(e *model.RowChangedEvent) (EncoderResult, error) {
+	value := d.encodeRow(e)
+	d.messages = append(d.messages,
+		NewMQMessage(ProtocolCSV, nil, value, e.CommitTs, model.MqMessageTypeRow, &e.Table.Schema, &e.Table.Table))
+	d.size += len(value)
+	return EncoderNoOperation, nil
+}
+
+// AppendResolvedEvent is no-op for csv
+func (d *CSVEventBatchEncoder) AppendResolvedEvent(ts uint64) (EncoderResult, error) {
+	return EncoderNoOperation, nil
+}
+
+// EncodeDDLEvent is no-op for csv
+func (d *CSVEventBatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*MQMessage, error) {
+	return nil, nil
+}
+
+// Build implements the EventBatchEncoder interface, each line is built into
+// a message.
+func (d *CSVEventBatchEncoder) Build() []*MQMessage {
+	if len(d.messages) == 0 {
+		return nil
+	}
+	ret := d.messages
+	d.Reset()
+	return ret
+}
+
+// MixedBuild implements the EventBatchEncoder interface, it concatenates the
+// lines, which is used by the storage sinks to write them into a file.
+func (d *CSVEventBatchEncoder) MixedBuild(withVersion bool) []byte {
+	var buf bytes.Buffer
+	buf.Grow(d.size)
+	for _, msg := range d.messages {
+		buf.Write(msg.Value)
+	}
+	d.Reset()
+	return buf.Bytes()
+}
+
+// Size implements the EventBatchEncoder interface
+func (d *CSVEventBatchEncoder) Size() int {
+	return d.size
+}
+
+// Reset implements the EventBatchEncoder interface
+func (d *CSVEventBatchEncoder) Reset() {
+	d.messages = nil
+	d.size = 0
+}
+
+func (d *CSVEventBatchEncoder) encodeRow(e *model.RowChangedEvent) []byte {
+	var (
+		op      string
+		columns []*model.Column
+	)
+	switch {
+	case e.IsDelete():
+		op, columns = csvOperationDelete, e.PreColumns
+	case len(e.PreColumns) != 0:
+		op, columns = csvOperationUpdate, e.Columns
+	default:
+		op, columns = csvOperationInsert, e.Columns
+	}
+
+	fields := make([]string, 0, len(columns)+4)
+	fields = append(fields, d.quoteString(op), d.quoteString(e.Table.Table), d.quoteString(e.Table.Schema))
+	if d.includeCommitTs {
+		fields = append(fields, strconv.FormatUint(e.CommitTs, 10))
+	}
+	for _, col := range columns {
+		if col == nil {
+			continue
+		}
+		fields = append(fields, d.formatColumn(col))
+	}
+	return []byte(strings.Join(fields, d.delimiter) + csvTerminator)
+}
+
+func (d *CSVEventBatchEncoder) formatColumn(col *model.Column) string {
+	switch v := col.Value.(type) {
+	case nil:
+		return d.nullString
+	case []byte:
+		if col.Flag.IsBinary() {
+			return d.quoteString(base64.StdEncoding.EncodeToString(v))
+		}
+		return d.quoteString(string(v))
+	case string:
+		return d.quoteString(v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// quoteString quotes the string and escapes the quotes in it by doubling them.
+func (d *CSVEventBatchEncoder) quoteString(s string) string {
+	return d.quote + strings.ReplaceAll(s, d.quote, d.quote+d.quote) + d.quote
+}
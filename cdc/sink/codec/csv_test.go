@@ -0,0 +1,126 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"context"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+type csvSuite struct{}
+
+var _ = check.Suite(&csvSuite{})
+
+func (s *csvSuite) TestCSVEventBatchCodec(c *check.C) {
+	defer testleak.AfterTest(c)()
+	encoder := NewCSVEventBatchEncoder()
+	c.Assert(encoder.SetParams(map[string]string{}), check.IsNil)
+
+	table := &model.TableName{Schema: "test", Table: "t"}
+	insert := &model.RowChangedEvent{
+		CommitTs: 417318403368288260,
+		Table:    table,
+		Columns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: int64(1)},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte(`say "hi", bob`)},
+			nil,
+			{Name: "score", Type: mysql.TypeDouble, Value: 1.5},
+			{Name: "blob", Type: mysql.TypeBlob, Flag: model.BinaryFlag, Value: []byte{0x1, 0x2}},
+			{Name: "comment", Type: mysql.TypeVarchar, Value: nil},
+		},
+	}
+	update := &model.RowChangedEvent{
+		CommitTs:   417318403368288261,
+		Table:      table,
+		PreColumns: []*model.Column{{Name: "id", Type: mysql.TypeLong, Value: int64(1)}},
+		Columns:    []*model.Column{{Name: "id", Type: mysql.TypeLong, Value: int64(2)}},
+	}
+	del := &model.RowChangedEvent{
+		CommitTs:   417318403368288262,
+		Table:      table,
+		PreColumns: []*model.Column{{Name: "id", Type: mysql.TypeLong, Value: int64(2)}},
+	}
+	for _, row := range []*model.RowChangedEvent{insert, update, del} {
+		result, err := encoder.AppendRowChangedEvent(row)
+		c.Assert(err, check.IsNil)
+		c.Assert(result, check.Equals, EncoderNoOperation)
+	}
+	size := encoder.Size()
+	messages := encoder.Build()
+	c.Assert(messages, check.HasLen, 3)
+	c.Assert(string(messages[0].Value), check.Equals,
+		"\"I\",\"t\",\"test\",1,\"say \"\"hi\"\", bob\",1.5,\"AQI=\",\\N\r\n")
+	c.Assert(string(messages[1].Value), check.Equals, "\"U\",\"t\",\"test\",2\r\n")
+	c.Assert(string(messages[2].Value), check.Equals, "\"D\",\"t\",\"test\",2\r\n")
+	c.Assert(*messages[0].Schema, check.Equals, "test")
+	c.Assert(*messages[0].Table, check.Equals, "t")
+	c.Assert(messages[0].Ts, check.Equals, insert.CommitTs)
+	c.Assert(len(messages[0].Value)+len(messages[1].Value)+len(messages[2].Value), check.Equals, size)
+	c.Assert(encoder.Size(), check.Equals, 0)
+	c.Assert(encoder.Build(), check.IsNil)
+
+	// DDL and checkpoint events are not encoded
+	msg, err := encoder.EncodeDDLEvent(&model.DDLEvent{CommitTs: 1, Query: "create table t(id int)"})
+	c.Assert(err, check.IsNil)
+	c.Assert(msg, check.IsNil)
+	msg, err = encoder.EncodeCheckpointEvent(1)
+	c.Assert(err, check.IsNil)
+	c.Assert(msg, check.IsNil)
+}
+
+func (s *csvSuite) TestCSVParams(c *check.C) {
+	defer testleak.AfterTest(c)()
+	builder, err := NewEventBatchEncoderBuilder(ProtocolCSV, nil, map[string]string{
+		OptCSVDelimiter:       "|",
+		OptCSVQuote:           "'",
+		OptCSVNullString:      "NULL",
+		OptCSVIncludeCommitTs: "true",
+	})
+	c.Assert(err, check.IsNil)
+	encoder, err := builder.Build(context.Background())
+	c.Assert(err, check.IsNil)
+
+	table := &model.TableName{Schema: "test", Table: "t"}
+	for _, row := range []*model.RowChangedEvent{{
+		CommitTs: 100,
+		Table:    table,
+		Columns: []*model.Column{
+			{Name: "name", Type: mysql.TypeVarchar, Value: "it's"},
+			{Name: "comment", Type: mysql.TypeVarchar, Value: nil},
+		},
+	}, {
+		CommitTs: 101,
+		Table:    table,
+		Columns:  []*model.Column{{Name: "id", Type: mysql.TypeLong, Value: int64(1)}},
+	}} {
+		_, err = encoder.AppendRowChangedEvent(row)
+		c.Assert(err, check.IsNil)
+	}
+	c.Assert(string(encoder.MixedBuild(false)), check.Equals,
+		"'I'|'t'|'test'|100|'it''s'|NULL\r\n'I'|'t'|'test'|101|1\r\n")
+	c.Assert(encoder.Size(), check.Equals, 0)
+
+	c.Assert(NewCSVEventBatchEncoder().SetParams(map[string]string{OptCSVDelimiter: "\""}),
+		check.ErrorMatches, ".*sink.csv.delimiter and sink.csv.quote should be different.*")
+	c.Assert(NewCSVEventBatchEncoder().SetParams(map[string]string{OptCSVIncludeCommitTs: "yes"}),
+		check.NotNil)
+
+	var protocol Protocol
+	protocol.FromString("csv")
+	c.Assert(protocol, check.Equals, ProtocolCSV)
+}
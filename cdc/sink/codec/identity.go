@@ -0,0 +1,56 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codec
+
+import (
+	"strconv"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// Options of the upstream identity attached to the messages, the identity is
+// not attached if all of them are empty.
+const (
+	OptIdentityClusterID    = "identity-cluster-id"
+	OptIdentityChangefeedID = "identity-changefeed-id"
+	OptIdentityCaptureAddr  = "identity-capture-addr"
+)
+
+// identity is the upstream identity of the events. It allows the consumers
+// which aggregate several TiDB clusters into one topic to attribute the events.
+type identity struct {
+	// ClusterID is the cluster ID of the upstream PD
+	ClusterID    uint64
+	ChangefeedID string
+	CaptureAddr  string
+}
+
+// newIdentity returns nil if no identity option is set.
+func newIdentity(params map[string]string) (*identity, error) {
+	id := &identity{
+		ChangefeedID: params[OptIdentityChangefeedID],
+		CaptureAddr:  params[OptIdentityCaptureAddr],
+	}
+	if s := params[OptIdentityClusterID]; s != "" {
+		clusterID, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrSinkInvalidConfig, err)
+		}
+		id.ClusterID = clusterID
+	}
+	if *id == (identity{}) {
+		return nil, nil
+	}
+	return id, nil
+}
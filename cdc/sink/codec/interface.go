@@ -38,6 +38,10 @@ type EventBatchEncoder interface {
 	AppendResolvedEvent(ts uint64) (EncoderResult, error)
 	// EncodeDDLEvent appends a DDL event into the batch
 	EncodeDDLEvent(e *model.DDLEvent) (*MQMessage, error)
+	// EncodeTableStartedEvent encodes a table started event.
+	// This event will be broadcast to all partitions to signal that all the events
+	// of the table with a commit ts greater than ts are sent after it.
+	EncodeTableStartedEvent(table *model.TableName, ts uint64) (*MQMessage, error)
 	// Build builds the batch and returns the bytes of key and value.
 	Build() []*MQMessage
 	// MixedBuild builds the batch and returns the bytes of mixed keys and values.
@@ -91,6 +95,10 @@ func newResolvedMQMessage(proto Protocol, key, value []byte, ts uint64) *MQMessa
 	return NewMQMessage(proto, key, value, ts, model.MqMessageTypeResolved, nil, nil)
 }
 
+func newTableStartedMQMessage(proto Protocol, key, value []byte, table *model.TableName, ts uint64) *MQMessage {
+	return NewMQMessage(proto, key, value, ts, model.MqMessageTypeTableStarted, &table.Schema, &table.Table)
+}
+
 // NewMQMessage should be used when creating a MQMessage struct.
 // It copies the input byte slices to avoid any surprises in asynchronous MQ writes.
 func NewMQMessage(proto Protocol, key []byte, value []byte, ts uint64, ty model.MqMessageType, schema, table *string) *MQMessage {
@@ -131,6 +139,8 @@ type EventBatchDecoder interface {
 	NextRowChangedEvent() (*model.RowChangedEvent, error)
 	// NextDDLEvent returns the next DDL event if exists
 	NextDDLEvent() (*model.DDLEvent, error)
+	// NextTableStartedEvent returns the table and the start ts of the next table started event if exists
+	NextTableStartedEvent() (*model.TableName, uint64, error)
 }
 
 // EncoderResult indicates an action request by the encoder to the mqSink
@@ -154,6 +164,7 @@ const (
 	ProtocolMaxwell
 	ProtocolCanalJSON
 	ProtocolCraft
+	ProtocolCSV
 )
 
 // FromString converts the protocol from string to Protocol enum type
@@ -171,6 +182,8 @@ func (p *Protocol) FromString(protocol string) {
 		*p = ProtocolCanalJSON
 	case "craft":
 		*p = ProtocolCraft
+	case "csv":
+		*p = ProtocolCSV
 	default:
 		*p = ProtocolDefault
 		log.Warn("can't support codec protocol, using default protocol", zap.String("protocol", protocol))
@@ -196,6 +209,8 @@ func NewEventBatchEncoderBuilder(p Protocol, credential *security.Credential, op
 		return newCanalFlatEventBatchEncoderBuilder(opts), nil
 	case ProtocolCraft:
 		return newCraftEventBatchEncoderBuilder(opts), nil
+	case ProtocolCSV:
+		return newCSVEventBatchEncoderBuilder(opts), nil
 	default:
 		log.Warn("unknown codec protocol value of EventBatchEncoder, use open-protocol as the default", zap.Int("protocol_value", int(p)))
 		return newJSONEventBatchEncoderBuilder(opts), nil
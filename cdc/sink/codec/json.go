@@ -40,6 +40,9 @@ const (
 	DefaultMaxMessageBytes int = 1 * 1024 * 1024 // 1M
 	// DefaultMaxBatchSize sets the default value for max-batch-size
 	DefaultMaxBatchSize int = 16
+	// OptAddColumnDefaultValue is the option which attaches the default
+	// values of added columns to the DDL messages of ADD COLUMN DDLs
+	OptAddColumnDefaultValue = "add-column-default-value"
 )
 
 type column struct {
@@ -50,6 +53,9 @@ type column struct {
 	WhereHandle *bool                `json:"h,omitempty"`
 	Flag        model.ColumnFlagType `json:"f"`
 	Value       interface{}          `json:"v"`
+	// Charset and Collation are only set for the string columns
+	Charset   string `json:"cs,omitempty"`
+	Collation string `json:"co,omitempty"`
 }
 
 func NewColumn(value interface{}, tp byte) *column {
@@ -62,6 +68,12 @@ func NewColumn(value interface{}, tp byte) *column {
 func (c *column) FromSinkColumn(col *model.Column) {
 	c.Type = col.Type
 	c.Flag = col.Flag
+	switch col.Type {
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		c.Charset = col.Charset
+		c.Collation = col.Collation
+	}
 	if c.Flag.IsHandleKey() {
 		whereHandle := true
 		c.WhereHandle = &whereHandle
@@ -96,6 +108,8 @@ func (c *column) ToSinkColumn(name string) *model.Column {
 	col.Type = c.Type
 	col.Flag = c.Flag
 	col.Name = name
+	col.Charset = c.Charset
+	col.Collation = c.Collation
 	col.Value = c.Value
 	if c.Value == nil {
 		return col
@@ -174,6 +188,20 @@ type mqMessageKey struct {
 	RowID     int64               `json:"rid,omitempty"`
 	Partition *int64              `json:"ptn,omitempty"`
 	Type      model.MqMessageType `json:"t"`
+
+	// The upstream identity, only set if it is required.
+	ClusterID    uint64 `json:"cid,omitempty"`
+	ChangefeedID string `json:"cf,omitempty"`
+	CaptureAddr  string `json:"ca,omitempty"`
+}
+
+func (m *mqMessageKey) setIdentity(id *identity) {
+	if id == nil {
+		return
+	}
+	m.ClusterID = id.ClusterID
+	m.ChangefeedID = id.ChangefeedID
+	m.CaptureAddr = id.CaptureAddr
 }
 
 func (m *mqMessageKey) Encode() ([]byte, error) {
@@ -218,6 +246,15 @@ func (m *mqMessageRow) Decode(data []byte) error {
 type mqMessageDDL struct {
 	Query string             `json:"q"`
 	Type  timodel.ActionType `json:"t"`
+	// AddedColumns is only set for ADD COLUMN DDLs if the default values of
+	// added columns are required.
+	AddedColumns []*mqMessageAddedColumn `json:"ac,omitempty"`
+}
+
+type mqMessageAddedColumn struct {
+	Name    string      `json:"n"`
+	Type    byte        `json:"t"`
+	Default interface{} `json:"d"`
 }
 
 func (m *mqMessageDDL) Encode() ([]byte, error) {
@@ -236,6 +273,31 @@ func newResolvedMessage(ts uint64) *mqMessageKey {
 	}
 }
 
+func newTableStartedMessage(table *model.TableName, ts uint64) *mqMessageKey {
+	key := &mqMessageKey{
+		Ts:     ts,
+		Schema: table.Schema,
+		Table:  table.Table,
+		Type:   model.MqMessageTypeTableStarted,
+	}
+	if table.IsPartition {
+		key.Partition = &table.TableID
+	}
+	return key
+}
+
+func mqMessageToTableStartedEvent(key *mqMessageKey) (*model.TableName, uint64) {
+	table := &model.TableName{
+		Schema: key.Schema,
+		Table:  key.Table,
+	}
+	if key.Partition != nil {
+		table.TableID = *key.Partition
+		table.IsPartition = true
+	}
+	return table, key.Ts
+}
+
 func rowEventToMqMessage(e *model.RowChangedEvent) (*mqMessageKey, *mqMessageRow) {
 	var partition *int64
 	if e.Table.IsPartition {
@@ -338,6 +400,13 @@ func mqMessageToDDLEvent(key *mqMessageKey, value *mqMessageDDL) *model.DDLEvent
 	e.TableInfo.Schema = key.Schema
 	e.Type = value.Type
 	e.Query = value.Query
+	for _, col := range value.AddedColumns {
+		e.TableInfo.ColumnInfo = append(e.TableInfo.ColumnInfo, &model.ColumnInfo{
+			Name:    col.Name,
+			Type:    col.Type,
+			Default: col.Default,
+		})
+	}
 	return e
 }
 
@@ -351,8 +420,10 @@ type JSONEventBatchEncoder struct {
 	messageBuf   []*MQMessage
 	curBatchSize int
 	// configs
-	maxMessageSize int
-	maxBatchSize   int
+	maxMessageSize        int
+	maxBatchSize          int
+	addColumnDefaultValue bool
+	identity              *identity
 }
 
 // GetMaxMessageSize is only for unit testing.
@@ -378,6 +449,7 @@ func (d *JSONEventBatchEncoder) AppendResolvedEvent(ts uint64) (EncoderResult, e
 // EncodeCheckpointEvent implements the EventBatchEncoder interface
 func (d *JSONEventBatchEncoder) EncodeCheckpointEvent(ts uint64) (*MQMessage, error) {
 	keyMsg := newResolvedMessage(ts)
+	keyMsg.setIdentity(d.identity)
 	key, err := keyMsg.Encode()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -409,9 +481,45 @@ func (d *JSONEventBatchEncoder) EncodeCheckpointEvent(ts uint64) (*MQMessage, er
 	return ret, nil
 }
 
+// EncodeTableStartedEvent implements the EventBatchEncoder interface
+func (d *JSONEventBatchEncoder) EncodeTableStartedEvent(table *model.TableName, ts uint64) (*MQMessage, error) {
+	keyMsg := newTableStartedMessage(table, ts)
+	keyMsg.setIdentity(d.identity)
+	key, err := keyMsg.Encode()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var keyLenByte [8]byte
+	binary.BigEndian.PutUint64(keyLenByte[:], uint64(len(key)))
+	var valueLenByte [8]byte
+	binary.BigEndian.PutUint64(valueLenByte[:], 0)
+
+	if d.supportMixedBuild {
+		d.keyBuf.Write(keyLenByte[:])
+		d.keyBuf.Write(key)
+		d.valueBuf.Write(valueLenByte[:])
+		return nil, nil
+	}
+
+	keyBuf := new(bytes.Buffer)
+	var versionByte [8]byte
+	binary.BigEndian.PutUint64(versionByte[:], BatchVersion1)
+	keyBuf.Write(versionByte[:])
+	keyBuf.Write(keyLenByte[:])
+	keyBuf.Write(key)
+
+	valueBuf := new(bytes.Buffer)
+	valueBuf.Write(valueLenByte[:])
+
+	ret := newTableStartedMQMessage(ProtocolDefault, keyBuf.Bytes(), valueBuf.Bytes(), table, ts)
+	return ret, nil
+}
+
 // AppendRowChangedEvent implements the EventBatchEncoder interface
 func (d *JSONEventBatchEncoder) AppendRowChangedEvent(e *model.RowChangedEvent) (EncoderResult, error) {
 	keyMsg, valueMsg := rowEventToMqMessage(e)
+	keyMsg.setIdentity(d.identity)
 	key, err := keyMsg.Encode()
 	if err != nil {
 		return EncoderNoOperation, errors.Trace(err)
@@ -475,6 +583,16 @@ func (d *JSONEventBatchEncoder) AppendRowChangedEvent(e *model.RowChangedEvent)
 // EncodeDDLEvent implements the EventBatchEncoder interface
 func (d *JSONEventBatchEncoder) EncodeDDLEvent(e *model.DDLEvent) (*MQMessage, error) {
 	keyMsg, valueMsg := ddlEventtoMqMessage(e)
+	keyMsg.setIdentity(d.identity)
+	if d.addColumnDefaultValue {
+		for _, col := range e.AddedColumns() {
+			valueMsg.AddedColumns = append(valueMsg.AddedColumns, &mqMessageAddedColumn{
+				Name:    col.Name,
+				Type:    col.Type,
+				Default: col.Default,
+			})
+		}
+	}
 	key, err := keyMsg.Encode()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -606,6 +724,18 @@ func (d *JSONEventBatchEncoder) SetParams(params map[string]string) error {
 		return cerror.ErrSinkInvalidConfig.Wrap(errors.Errorf("invalid max-batch-size %d", d.maxBatchSize))
 	}
 
+	if s, ok := params[OptAddColumnDefaultValue]; ok {
+		d.addColumnDefaultValue, err = strconv.ParseBool(s)
+		if err != nil {
+			return cerror.ErrSinkInvalidConfig.Wrap(err)
+		}
+	}
+
+	d.identity, err = newIdentity(params)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	return nil
 }
 
@@ -721,6 +851,24 @@ func (b *JSONEventBatchMixedDecoder) NextDDLEvent() (*model.DDLEvent, error) {
 	return ddlEvent, nil
 }
 
+// NextTableStartedEvent implements the EventBatchDecoder interface
+func (b *JSONEventBatchMixedDecoder) NextTableStartedEvent() (*model.TableName, uint64, error) {
+	if b.nextKey == nil {
+		if err := b.decodeNextKey(); err != nil {
+			return nil, 0, err
+		}
+	}
+	b.mixedBytes = b.mixedBytes[b.nextKeyLen+8:]
+	if b.nextKey.Type != model.MqMessageTypeTableStarted {
+		return nil, 0, cerror.ErrJSONCodecInvalidData.GenWithStack("not found table started event message")
+	}
+	valueLen := binary.BigEndian.Uint64(b.mixedBytes[:8])
+	b.mixedBytes = b.mixedBytes[valueLen+8:]
+	table, ts := mqMessageToTableStartedEvent(b.nextKey)
+	b.nextKey = nil
+	return table, ts, nil
+}
+
 func (b *JSONEventBatchMixedDecoder) hasNext() bool {
 	return len(b.mixedBytes) > 0
 }
@@ -822,6 +970,24 @@ func (b *JSONEventBatchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
 	return ddlEvent, nil
 }
 
+// NextTableStartedEvent implements the EventBatchDecoder interface
+func (b *JSONEventBatchDecoder) NextTableStartedEvent() (*model.TableName, uint64, error) {
+	if b.nextKey == nil {
+		if err := b.decodeNextKey(); err != nil {
+			return nil, 0, err
+		}
+	}
+	b.keyBytes = b.keyBytes[b.nextKeyLen+8:]
+	if b.nextKey.Type != model.MqMessageTypeTableStarted {
+		return nil, 0, cerror.ErrJSONCodecInvalidData.GenWithStack("not found table started event message")
+	}
+	valueLen := binary.BigEndian.Uint64(b.valueBytes[:8])
+	b.valueBytes = b.valueBytes[valueLen+8:]
+	table, ts := mqMessageToTableStartedEvent(b.nextKey)
+	b.nextKey = nil
+	return table, ts, nil
+}
+
 func (b *JSONEventBatchDecoder) hasNext() bool {
 	return len(b.keyBytes) > 0 && len(b.valueBytes) > 0
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/check"
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/pkg/util/testleak"
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 )
 
@@ -330,6 +331,144 @@ func (s *batchSuite) TestDefaultEventBatchCodec(c *check.C) {
 	}, NewJSONEventBatchDecoder)
 }
 
+func (s *batchSuite) TestAddColumnDefaultValue(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ddl := &model.DDLEvent{
+		CommitTs: 1,
+		TableInfo: &model.SimpleTableInfo{
+			Schema: "a", Table: "b",
+			ColumnInfo: []*model.ColumnInfo{
+				{Name: "id", Type: mysql.TypeLong},
+				{Name: "c", Type: mysql.TypeVarchar, Default: "default"},
+			},
+		},
+		PreTableInfo: &model.SimpleTableInfo{
+			Schema: "a", Table: "b",
+			ColumnInfo: []*model.ColumnInfo{{Name: "id", Type: mysql.TypeLong}},
+		},
+		Query: "alter table a.b add column c varchar(10) default 'default'",
+		Type:  timodel.ActionAddColumn,
+	}
+
+	encoder := NewJSONEventBatchEncoder()
+	err := encoder.SetParams(map[string]string{OptAddColumnDefaultValue: "true"})
+	c.Assert(err, check.IsNil)
+	msg, err := encoder.EncodeDDLEvent(ddl)
+	c.Assert(err, check.IsNil)
+	decoder, err := NewJSONEventBatchDecoder(msg.Key, msg.Value)
+	c.Assert(err, check.IsNil)
+	_, hasNext, err := decoder.HasNext()
+	c.Assert(err, check.IsNil)
+	c.Assert(hasNext, check.IsTrue)
+	decoded, err := decoder.NextDDLEvent()
+	c.Assert(err, check.IsNil)
+	c.Assert(decoded.TableInfo.ColumnInfo, check.DeepEquals, []*model.ColumnInfo{
+		{Name: "c", Type: mysql.TypeVarchar, Default: "default"},
+	})
+
+	// the added columns are not attached by default.
+	encoder = NewJSONEventBatchEncoder()
+	err = encoder.SetParams(map[string]string{})
+	c.Assert(err, check.IsNil)
+	msg, err = encoder.EncodeDDLEvent(ddl)
+	c.Assert(err, check.IsNil)
+	decoder, err = NewJSONEventBatchDecoder(msg.Key, msg.Value)
+	c.Assert(err, check.IsNil)
+	_, _, err = decoder.HasNext()
+	c.Assert(err, check.IsNil)
+	decoded, err = decoder.NextDDLEvent()
+	c.Assert(err, check.IsNil)
+	c.Assert(decoded.TableInfo.ColumnInfo, check.IsNil)
+
+	err = encoder.SetParams(map[string]string{OptAddColumnDefaultValue: "invalid"})
+	c.Assert(err, check.ErrorMatches, ".*invalid syntax.*")
+}
+
+func (s *batchSuite) TestIdentity(c *check.C) {
+	defer testleak.AfterTest(c)()
+	decodeKey := func(msg *MQMessage) *mqMessageKey {
+		// skip the version and the length of the key
+		key := new(mqMessageKey)
+		c.Assert(key.Decode(msg.Key[16:]), check.IsNil)
+		return key
+	}
+	encoder := NewJSONEventBatchEncoder()
+	err := encoder.SetParams(map[string]string{
+		OptIdentityClusterID:    "6982447767308788395",
+		OptIdentityChangefeedID: "test-changefeed",
+		OptIdentityCaptureAddr:  "127.0.0.1:8300",
+	})
+	c.Assert(err, check.IsNil)
+
+	msg, err := encoder.EncodeCheckpointEvent(1)
+	c.Assert(err, check.IsNil)
+	key := decodeKey(msg)
+	c.Assert(key.ClusterID, check.Equals, uint64(6982447767308788395))
+	c.Assert(key.ChangefeedID, check.Equals, "test-changefeed")
+	c.Assert(key.CaptureAddr, check.Equals, "127.0.0.1:8300")
+
+	_, err = encoder.AppendRowChangedEvent(&model.RowChangedEvent{
+		CommitTs: 2,
+		Table:    &model.TableName{Schema: "a", Table: "b"},
+		Columns:  []*model.Column{{Name: "col1", Type: mysql.TypeLong, Value: 1}},
+	})
+	c.Assert(err, check.IsNil)
+	msgs := encoder.Build()
+	c.Assert(msgs, check.HasLen, 1)
+	key = decodeKey(msgs[0])
+	c.Assert(key.ChangefeedID, check.Equals, "test-changefeed")
+
+	// the identity is not attached by default
+	encoder = NewJSONEventBatchEncoder()
+	err = encoder.SetParams(map[string]string{OptIdentityClusterID: ""})
+	c.Assert(err, check.IsNil)
+	msg, err = encoder.EncodeDDLEvent(&model.DDLEvent{
+		CommitTs:  3,
+		TableInfo: &model.SimpleTableInfo{Schema: "a", Table: "b"},
+		Query:     "create table a.b(id int primary key)",
+		Type:      timodel.ActionCreateTable,
+	})
+	c.Assert(err, check.IsNil)
+	c.Assert(string(msg.Key[16:]), check.Equals, `{"ts":3,"scm":"a","tbl":"b","t":2}`)
+
+	err = encoder.SetParams(map[string]string{OptIdentityClusterID: "invalid"})
+	c.Assert(err, check.ErrorMatches, ".*invalid syntax.*")
+}
+
+func (s *batchSuite) TestTableStartedEvent(c *check.C) {
+	defer testleak.AfterTest(c)()
+	tables := []*model.TableName{
+		{Schema: "a", Table: "b", TableID: 10},
+		{Schema: "a", Table: "c", TableID: 12, IsPartition: true},
+	}
+	for _, table := range tables {
+		encoder := NewJSONEventBatchEncoder()
+		msg, err := encoder.EncodeTableStartedEvent(table, 100)
+		c.Assert(err, check.IsNil)
+		c.Assert(msg.Type, check.Equals, model.MqMessageTypeTableStarted)
+		c.Assert(msg.Ts, check.Equals, uint64(100))
+
+		decoder, err := NewJSONEventBatchDecoder(msg.Key, msg.Value)
+		c.Assert(err, check.IsNil)
+		tp, hasNext, err := decoder.HasNext()
+		c.Assert(err, check.IsNil)
+		c.Assert(hasNext, check.IsTrue)
+		c.Assert(tp, check.Equals, model.MqMessageTypeTableStarted)
+		decodedTable, ts, err := decoder.NextTableStartedEvent()
+		c.Assert(err, check.IsNil)
+		c.Assert(ts, check.Equals, uint64(100))
+		c.Assert(decodedTable.Schema, check.Equals, table.Schema)
+		c.Assert(decodedTable.Table, check.Equals, table.Table)
+		c.Assert(decodedTable.IsPartition, check.Equals, table.IsPartition)
+		if table.IsPartition {
+			c.Assert(decodedTable.TableID, check.Equals, table.TableID)
+		}
+		_, hasNext, err = decoder.HasNext()
+		c.Assert(err, check.IsNil)
+		c.Assert(hasNext, check.IsFalse)
+	}
+}
+
 var _ = check.Suite(&columnSuite{})
 
 type columnSuite struct{}
@@ -362,9 +501,11 @@ func (s *columnSuite) TestFormatCol(c *check.C) {
 func (s *columnSuite) TestNonBinaryStringCol(c *check.C) {
 	defer testleak.AfterTest(c)()
 	col := &model.Column{
-		Name:  "test",
-		Type:  mysql.TypeString,
-		Value: "value",
+		Name:      "test",
+		Type:      mysql.TypeString,
+		Charset:   "gbk",
+		Collation: "gbk_bin",
+		Value:     "value",
 	}
 	jsonCol := column{}
 	jsonCol.FromSinkColumn(col)
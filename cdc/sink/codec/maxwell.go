@@ -18,12 +18,17 @@ import (
 	"context"
 	"encoding/binary"
 	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/ticdc/cdc/model"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	model2 "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/tikv/client-go/v2/oracle"
 	"github.com/tikv/pd/pkg/tsoutil"
 )
 
@@ -52,17 +57,32 @@ type MaxwellEventBatchEncoder struct {
 	batchSize int
 }
 
+// The types of the maxwell messages carrying rows
+const (
+	maxwellTypeInsert            = "insert"
+	maxwellTypeUpdate            = "update"
+	maxwellTypeDelete            = "delete"
+	maxwellTypeBootstrapStart    = "bootstrap-start"
+	maxwellTypeBootstrapInsert   = "bootstrap-insert"
+	maxwellTypeBootstrapComplete = "bootstrap-complete"
+)
+
+// maxwellMessage is a row message of maxwell, the xid is the start ts of the
+// transaction, and the position is the commit ts of the transaction, since ts
+// only has the precision of seconds.
 type maxwellMessage struct {
-	Database string                 `json:"database"`
-	Table    string                 `json:"table"`
-	Type     string                 `json:"type"`
-	Ts       int64                  `json:"ts"`
-	Xid      int                    `json:"xid,omitempty"`
-	Xoffset  int                    `json:"xoffset,omitempty"`
-	Position string                 `json:"position,omitempty"`
-	Gtid     string                 `json:"gtid,omitempty"`
-	Data     map[string]interface{} `json:"data,omitempty"`
-	Old      map[string]interface{} `json:"old,omitempty"`
+	Database          string                 `json:"database"`
+	Table             string                 `json:"table"`
+	Type              string                 `json:"type"`
+	Ts                int64                  `json:"ts"`
+	Xid               uint64                 `json:"xid,omitempty"`
+	Xoffset           int                    `json:"xoffset,omitempty"`
+	Position          string                 `json:"position,omitempty"`
+	Gtid              string                 `json:"gtid,omitempty"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+	Old               map[string]interface{} `json:"old,omitempty"`
+	PrimaryKey        []interface{}          `json:"primary_key,omitempty"`
+	PrimaryKeyColumns []string               `json:"primary_key_columns,omitempty"`
 }
 
 // Encode encodes the message to bytes
@@ -84,6 +104,33 @@ func (d *MaxwellEventBatchEncoder) EncodeCheckpointEvent(ts uint64) (*MQMessage,
 	return nil, nil
 }
 
+// EncodeTableStartedEvent implements the EventBatchEncoder interface, a table
+// started event is encoded as an empty bootstrap of the table, that is a
+// bootstrap-start message followed by a bootstrap-complete message.
+func (d *MaxwellEventBatchEncoder) EncodeTableStartedEvent(table *model.TableName, ts uint64) (*MQMessage, error) {
+	physicalTime, _ := tsoutil.ParseTS(ts)
+	keyBuf := new(bytes.Buffer)
+	var versionByte [8]byte
+	binary.BigEndian.PutUint64(versionByte[:], BatchVersion1)
+	keyBuf.Write(versionByte[:])
+	valueBuf := new(bytes.Buffer)
+	for _, tp := range []string{maxwellTypeBootstrapStart, maxwellTypeBootstrapComplete} {
+		msg := &maxwellMessage{
+			Database: table.Schema,
+			Table:    table.Table,
+			Type:     tp,
+			Ts:       physicalTime.Unix(),
+			Position: strconv.FormatUint(ts, 10),
+		}
+		value, err := msg.Encode()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		valueBuf.Write(value)
+	}
+	return newTableStartedMQMessage(ProtocolMaxwell, keyBuf.Bytes(), valueBuf.Bytes(), table, ts), nil
+}
+
 // AppendResolvedEvent implements the EventBatchEncoder interface
 func (d *MaxwellEventBatchEncoder) AppendResolvedEvent(ts uint64) (EncoderResult, error) {
 	return EncoderNoOperation, nil
@@ -111,67 +158,52 @@ func rowEventToMaxwellMessage(e *model.RowChangedEvent) (*mqMessageKey, *maxwell
 
 	physicalTime, _ := tsoutil.ParseTS(e.CommitTs)
 	value.Ts = physicalTime.Unix()
+	value.Xid = e.StartTs
+	value.Position = strconv.FormatUint(e.CommitTs, 10)
+	columns := e.Columns
 	if e.IsDelete() {
-		value.Type = "delete"
+		value.Type = maxwellTypeDelete
+		columns = e.PreColumns
+	} else if e.PreColumns == nil {
+		value.Type = maxwellTypeInsert
+	} else {
+		value.Type = maxwellTypeUpdate
+	}
+	for _, v := range columns {
+		if v == nil {
+			continue
+		}
+		value.Data[v.Name] = maxwellColumnValue(v)
+		if v.Flag.IsPrimaryKey() {
+			value.PrimaryKey = append(value.PrimaryKey, value.Data[v.Name])
+			value.PrimaryKeyColumns = append(value.PrimaryKeyColumns, v.Name)
+		}
+	}
+	// the old values of the updated columns
+	if value.Type == maxwellTypeUpdate {
 		for _, v := range e.PreColumns {
-			switch v.Type {
-			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
-				if v.Value == nil {
-					value.Old[v.Name] = nil
-				} else if v.Flag.IsBinary() {
-					value.Old[v.Name] = v.Value
-				} else {
-					value.Old[v.Name] = string(v.Value.([]byte))
-				}
-			default:
-				value.Old[v.Name] = v.Value
+			if v == nil {
+				continue
 			}
-		}
-	} else {
-		for _, v := range e.Columns {
-			switch v.Type {
-			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
-				if v.Value == nil {
-					value.Data[v.Name] = nil
-				} else if v.Flag.IsBinary() {
-					value.Data[v.Name] = v.Value
-				} else {
-					value.Data[v.Name] = string(v.Value.([]byte))
-				}
-			default:
-				value.Data[v.Name] = v.Value
+			old := maxwellColumnValue(v)
+			if !reflect.DeepEqual(value.Data[v.Name], old) {
+				value.Old[v.Name] = old
 			}
 		}
-		if e.PreColumns == nil {
-			value.Type = "insert"
-		} else {
-			value.Type = "update"
-			for _, v := range e.PreColumns {
-				switch v.Type {
-				case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
-					if v.Value == nil {
-						if value.Data[v.Name] != nil {
-							value.Old[v.Name] = nil
-						}
-					} else if v.Flag.IsBinary() {
-						if value.Data[v.Name] != v.Value {
-							value.Old[v.Name] = v.Value
-						}
-					} else {
-						if value.Data[v.Name] != string(v.Value.([]byte)) {
-							value.Old[v.Name] = string(v.Value.([]byte))
-						}
-					}
-				default:
-					if value.Data[v.Name] != v.Value {
-						value.Old[v.Name] = v.Value
-					}
-				}
-			}
+	}
+	return key, value
+}
 
+func maxwellColumnValue(v *model.Column) interface{} {
+	switch v.Type {
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if v.Value == nil || v.Flag.IsBinary() {
+			return v.Value
 		}
+		return string(v.Value.([]byte))
+	default:
+		return v.Value
 	}
-	return key, value
 }
 
 // AppendRowChangedEvent implements the EventBatchEncoder interface
@@ -259,13 +291,8 @@ func ddlEventtoMaxwellMessage(e *model.DDLEvent) (*mqMessageKey, *DdlMaxwellMess
 	value.Def.Database = e.TableInfo.Schema
 	value.Def.Table = e.TableInfo.Table
 	for _, v := range e.TableInfo.ColumnInfo {
-		maxwellcolumntype, err := columnToMaxwellType(v.Type)
-		if err != nil {
-			value.Old.Columns = append(value.Old.Columns, &Column{
-				Name: v.Name,
-				Type: err.Error(),
-			})
-		}
+		// the type of the columns unsupported by maxwell is left empty
+		maxwellcolumntype, _ := columnToMaxwellType(v.Type)
 		value.Def.Columns = append(value.Def.Columns, &Column{
 			Name: v.Name,
 			Type: maxwellcolumntype,
@@ -387,3 +414,255 @@ func columnToMaxwellType(columnType byte) (string, error) {
 		return "", cerror.ErrMaxwellInvalidData.GenWithStack("unsupported column type - %v", columnType)
 	}
 }
+
+// maxwellTypeToDDL converts the maxwell type of a DDL message back to the DDL
+// type, it returns the first DDL type converted to the maxwell type.
+func maxwellTypeToDDL(tp string) model2.ActionType {
+	for ddlType := model2.ActionType(1); ddlType < math.MaxUint8; ddlType++ {
+		if ddlToMaxwellType(ddlType) == tp {
+			return ddlType
+		}
+	}
+	return model2.ActionNone
+}
+
+var maxwellColumnTypes = map[string]byte{
+	"int":      mysql.TypeLong,
+	"bigint":   mysql.TypeLonglong,
+	"string":   mysql.TypeVarchar,
+	"date":     mysql.TypeDate,
+	"datetime": mysql.TypeDatetime,
+	"time":     mysql.TypeDuration,
+	"year":     mysql.TypeYear,
+	"enum":     mysql.TypeEnum,
+	"set":      mysql.TypeSet,
+	"bit":      mysql.TypeBit,
+	"json":     mysql.TypeJSON,
+	"float":    mysql.TypeDouble,
+	"decimal":  mysql.TypeNewDecimal,
+}
+
+// MaxwellEventBatchDecoder decodes the messages encoded by the
+// MaxwellEventBatchEncoder. The types of the columns are not carried by the
+// maxwell row messages, so the values of the decoded columns are strings or
+// numbers.
+type MaxwellEventBatchDecoder struct {
+	decoder *json.Decoder
+
+	// the type and the raw data of the next message
+	nextType model.MqMessageType
+	next     json.RawMessage
+}
+
+// NewMaxwellEventBatchDecoder creates a new MaxwellEventBatchDecoder. The key
+// is not needed, since the type of the messages is carried by the values.
+func NewMaxwellEventBatchDecoder(key []byte, value []byte) (EventBatchDecoder, error) {
+	return &MaxwellEventBatchDecoder{
+		decoder: json.NewDecoder(bytes.NewReader(value)),
+	}, nil
+}
+
+// HasNext implements the EventBatchDecoder interface
+func (b *MaxwellEventBatchDecoder) HasNext() (model.MqMessageType, bool, error) {
+	if b.next != nil {
+		return b.nextType, true, nil
+	}
+	for b.decoder.More() {
+		var raw json.RawMessage
+		if err := b.decoder.Decode(&raw); err != nil {
+			return model.MqMessageTypeUnknown, false, cerror.WrapError(cerror.ErrMaxwellDecodeFailed, err)
+		}
+		msg := &struct {
+			Type string `json:"type"`
+		}{}
+		if err := json.Unmarshal(raw, msg); err != nil {
+			return model.MqMessageTypeUnknown, false, cerror.WrapError(cerror.ErrMaxwellDecodeFailed, err)
+		}
+		switch msg.Type {
+		case maxwellTypeInsert, maxwellTypeUpdate, maxwellTypeDelete:
+			b.nextType = model.MqMessageTypeRow
+		case maxwellTypeBootstrapStart:
+			b.nextType = model.MqMessageTypeTableStarted
+		case maxwellTypeBootstrapInsert, maxwellTypeBootstrapComplete:
+			// the bootstrap of a table started event is empty
+			continue
+		default:
+			b.nextType = model.MqMessageTypeDDL
+		}
+		b.next = raw
+		return b.nextType, true, nil
+	}
+	return model.MqMessageTypeUnknown, false, nil
+}
+
+func (b *MaxwellEventBatchDecoder) decodeNext(tp model.MqMessageType, event string, v interface{}) error {
+	if b.next == nil || b.nextType != tp {
+		return cerror.ErrMaxwellDecodeFailed.GenWithStack("not found %s message", event)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b.next))
+	decoder.UseNumber()
+	b.next = nil
+	return cerror.WrapError(cerror.ErrMaxwellDecodeFailed, decoder.Decode(v))
+}
+
+// NextResolvedEvent implements the EventBatchDecoder interface, maxwell does
+// not carry resolved events.
+func (b *MaxwellEventBatchDecoder) NextResolvedEvent() (uint64, error) {
+	return 0, cerror.ErrMaxwellDecodeFailed.GenWithStack("not found resolved event message")
+}
+
+// NextRowChangedEvent implements the EventBatchDecoder interface
+func (b *MaxwellEventBatchDecoder) NextRowChangedEvent() (*model.RowChangedEvent, error) {
+	msg := &maxwellMessage{}
+	if err := b.decodeNext(model.MqMessageTypeRow, "row changed event", msg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	commitTs, err := maxwellCommitTs(msg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	row := &model.RowChangedEvent{
+		StartTs:  msg.Xid,
+		CommitTs: commitTs,
+		Table:    &model.TableName{Schema: msg.Database, Table: msg.Table},
+	}
+	columns, err := maxwellDataToColumns(msg.Data, msg.PrimaryKeyColumns)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch msg.Type {
+	case maxwellTypeInsert:
+		row.Columns = columns
+	case maxwellTypeDelete:
+		row.PreColumns = columns
+	default:
+		row.Columns = columns
+		row.PreColumns = make([]*model.Column, 0, len(columns))
+		for _, col := range columns {
+			preCol := *col
+			if old, ok := msg.Old[col.Name]; ok {
+				if preCol.Value, err = maxwellDecodeValue(old); err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+			row.PreColumns = append(row.PreColumns, &preCol)
+		}
+	}
+	return row, nil
+}
+
+// NextDDLEvent implements the EventBatchDecoder interface
+func (b *MaxwellEventBatchDecoder) NextDDLEvent() (*model.DDLEvent, error) {
+	msg := &DdlMaxwellMessage{}
+	if err := b.decodeNext(model.MqMessageTypeDDL, "ddl event", msg); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ddl := &model.DDLEvent{
+		CommitTs: msg.Ts,
+		Query:    msg.SQL,
+		Type:     maxwellTypeToDDL(msg.Type),
+		TableInfo: &model.SimpleTableInfo{
+			Schema:     msg.Database,
+			Table:      msg.Table,
+			ColumnInfo: maxwellColumnsToColumnInfos(msg.Def.Columns),
+		},
+	}
+	if msg.Old.Database != "" || msg.Old.Table != "" || len(msg.Old.Columns) != 0 {
+		ddl.PreTableInfo = &model.SimpleTableInfo{
+			Schema:     msg.Old.Database,
+			Table:      msg.Old.Table,
+			ColumnInfo: maxwellColumnsToColumnInfos(msg.Old.Columns),
+		}
+	}
+	return ddl, nil
+}
+
+// NextTableStartedEvent implements the EventBatchDecoder interface
+func (b *MaxwellEventBatchDecoder) NextTableStartedEvent() (*model.TableName, uint64, error) {
+	msg := &maxwellMessage{}
+	if err := b.decodeNext(model.MqMessageTypeTableStarted, "table started event", msg); err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	ts, err := maxwellCommitTs(msg)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	return &model.TableName{Schema: msg.Database, Table: msg.Table}, ts, nil
+}
+
+// maxwellCommitTs returns the commit ts carried by the position, the messages
+// without the position fall back to the ts in seconds.
+func maxwellCommitTs(msg *maxwellMessage) (uint64, error) {
+	if msg.Position == "" {
+		return oracle.ComposeTS(msg.Ts*1000, 0), nil
+	}
+	ts, err := strconv.ParseUint(msg.Position, 10, 64)
+	if err != nil {
+		return 0, cerror.ErrMaxwellInvalidData.GenWithStack("invalid position %s", msg.Position)
+	}
+	return ts, nil
+}
+
+// maxwellDataToColumns converts the data of a row message to columns, the
+// primary key columns come first in their order, and the other columns are
+// sorted by their names.
+func maxwellDataToColumns(data map[string]interface{}, primaryKeyColumns []string) ([]*model.Column, error) {
+	columns := make([]*model.Column, 0, len(data))
+	isPrimaryKey := make(map[string]bool, len(primaryKeyColumns))
+	for _, name := range primaryKeyColumns {
+		if _, ok := data[name]; !ok {
+			return nil, cerror.ErrMaxwellInvalidData.GenWithStack("primary key column %s not found", name)
+		}
+		isPrimaryKey[name] = true
+		columns = append(columns, &model.Column{
+			Name: name,
+			Flag: model.PrimaryKeyFlag | model.HandleKeyFlag,
+		})
+	}
+	names := make([]string, 0, len(data))
+	for name := range data {
+		if !isPrimaryKey[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		columns = append(columns, &model.Column{Name: name})
+	}
+	for _, col := range columns {
+		value, err := maxwellDecodeValue(data[col.Name])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		col.Value = value
+	}
+	return columns, nil
+}
+
+// maxwellDecodeValue converts the numbers decoded from the messages to the
+// integers if possible.
+func maxwellDecodeValue(value interface{}) (interface{}, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value, nil
+	}
+	if i, err := number.Int64(); err == nil {
+		return i, nil
+	}
+	if u, err := strconv.ParseUint(number.String(), 10, 64); err == nil {
+		return u, nil
+	}
+	f, err := number.Float64()
+	if err != nil {
+		return nil, cerror.ErrMaxwellInvalidData.GenWithStack("invalid number %s", number)
+	}
+	return f, nil
+}
+
+func maxwellColumnsToColumnInfos(columns []*Column) []*model.ColumnInfo {
+	infos := make([]*model.ColumnInfo, 0, len(columns))
+	for _, col := range columns {
+		infos = append(infos, &model.ColumnInfo{Name: col.Name, Type: maxwellColumnTypes[col.Type]})
+	}
+	return infos
+}
@@ -14,9 +14,13 @@
 package codec
 
 import (
+	"encoding/json"
+
 	"github.com/pingcap/check"
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/pkg/util/testleak"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
 )
 
 type maxwellbatchSuite struct {
@@ -96,3 +100,176 @@ func (s *maxwellcolumnSuite) TestMaxwellFormatCol(c *check.C) {
 	c.Assert(err, check.IsNil)
 	c.Assert(rowEncode, check.NotNil)
 }
+
+func (s *maxwellcolumnSuite) TestMaxwellRowMessage(c *check.C) {
+	defer testleak.AfterTest(c)()
+	table := &model.TableName{Schema: "test", Table: "t"}
+	columns := []*model.Column{
+		{Name: "id", Type: mysql.TypeLong, Flag: model.PrimaryKeyFlag | model.HandleKeyFlag, Value: int64(1)},
+		{Name: "name", Type: mysql.TypeVarchar, Value: []byte("alice")},
+		{Name: "comment", Type: mysql.TypeVarchar, Value: nil},
+	}
+	_, msg := rowEventToMaxwellMessage(&model.RowChangedEvent{
+		StartTs:    417318403368288259,
+		CommitTs:   417318403368288260,
+		Table:      table,
+		PreColumns: columns,
+	})
+	c.Assert(msg.Type, check.Equals, "delete")
+	c.Assert(msg.Xid, check.Equals, uint64(417318403368288259))
+	c.Assert(msg.Position, check.Equals, "417318403368288260")
+	c.Assert(msg.Data, check.DeepEquals, map[string]interface{}{"id": int64(1), "name": "alice", "comment": nil})
+	c.Assert(msg.Old, check.HasLen, 0)
+	c.Assert(msg.PrimaryKey, check.DeepEquals, []interface{}{int64(1)})
+	c.Assert(msg.PrimaryKeyColumns, check.DeepEquals, []string{"id"})
+
+	_, msg = rowEventToMaxwellMessage(&model.RowChangedEvent{
+		CommitTs:   417318403368288260,
+		Table:      table,
+		PreColumns: columns,
+		Columns: []*model.Column{
+			columns[0],
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte("bob")},
+			{Name: "comment", Type: mysql.TypeVarchar, Value: []byte("new")},
+		},
+	})
+	c.Assert(msg.Type, check.Equals, "update")
+	c.Assert(msg.Data, check.DeepEquals, map[string]interface{}{"id": int64(1), "name": "bob", "comment": "new"})
+	c.Assert(msg.Old, check.DeepEquals, map[string]interface{}{"name": "alice", "comment": nil})
+
+	// the binary values are compared without panicking
+	_, msg = rowEventToMaxwellMessage(&model.RowChangedEvent{
+		CommitTs:   417318403368288260,
+		Table:      table,
+		PreColumns: []*model.Column{{Name: "b", Type: mysql.TypeBlob, Flag: model.BinaryFlag, Value: []byte{1}}},
+		Columns:    []*model.Column{{Name: "b", Type: mysql.TypeBlob, Flag: model.BinaryFlag, Value: []byte{1}}},
+	})
+	c.Assert(msg.Old, check.HasLen, 0)
+}
+
+func (s *maxwellcolumnSuite) TestMaxwellRoundTrip(c *check.C) {
+	defer testleak.AfterTest(c)()
+	table := &model.TableName{Schema: "test", Table: "t"}
+	rows := []*model.RowChangedEvent{{
+		StartTs:  417318403368288259,
+		CommitTs: 417318403368288260,
+		Table:    table,
+		Columns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Flag: model.PrimaryKeyFlag | model.HandleKeyFlag, Value: int64(1)},
+			{Name: "name", Type: mysql.TypeVarchar, Value: []byte("alice")},
+			{Name: "score", Type: mysql.TypeDouble, Value: 1.5},
+			{Name: "data", Type: mysql.TypeBlob, Flag: model.BinaryFlag, Value: []byte{1, 2}},
+			{Name: "comment", Type: mysql.TypeVarchar, Value: nil},
+		},
+	}, {
+		StartTs:    417318403368288261,
+		CommitTs:   417318403368288262,
+		Table:      table,
+		PreColumns: []*model.Column{{Name: "id", Type: mysql.TypeLong, Flag: model.PrimaryKeyFlag, Value: int64(1)}, {Name: "name", Type: mysql.TypeVarchar, Value: []byte("alice")}},
+		Columns:    []*model.Column{{Name: "id", Type: mysql.TypeLong, Flag: model.PrimaryKeyFlag, Value: int64(1)}, {Name: "name", Type: mysql.TypeVarchar, Value: []byte("bob")}},
+	}, {
+		StartTs:    417318403368288263,
+		CommitTs:   417318403368288264,
+		Table:      table,
+		PreColumns: []*model.Column{{Name: "id", Type: mysql.TypeLonglong, Value: uint64(18446744073709551615)}},
+	}}
+	for _, row := range rows {
+		encoder := NewMaxwellEventBatchEncoder()
+		_, err := encoder.AppendRowChangedEvent(row)
+		c.Assert(err, check.IsNil)
+		messages := encoder.Build()
+		c.Assert(messages, check.HasLen, 1)
+
+		decoder, err := NewMaxwellEventBatchDecoder(messages[0].Key, messages[0].Value)
+		c.Assert(err, check.IsNil)
+		tp, hasNext, err := decoder.HasNext()
+		c.Assert(err, check.IsNil)
+		c.Assert(hasNext, check.IsTrue)
+		c.Assert(tp, check.Equals, model.MqMessageTypeRow)
+		decoded, err := decoder.NextRowChangedEvent()
+		c.Assert(err, check.IsNil)
+		c.Assert(decoded.StartTs, check.Equals, row.StartTs)
+		c.Assert(decoded.CommitTs, check.Equals, row.CommitTs)
+		c.Assert(decoded.Table, check.DeepEquals, table)
+		c.Assert(decoded.IsDelete(), check.Equals, row.IsDelete())
+		_, hasNext, err = decoder.HasNext()
+		c.Assert(err, check.IsNil)
+		c.Assert(hasNext, check.IsFalse)
+
+		// the decoded row is encoded into the same message
+		encoder = NewMaxwellEventBatchEncoder()
+		_, err = encoder.AppendRowChangedEvent(decoded)
+		c.Assert(err, check.IsNil)
+		c.Assert(string(encoder.Build()[0].Value), check.Equals, string(messages[0].Value))
+	}
+
+	encoder := NewMaxwellEventBatchEncoder()
+	ddl := &model.DDLEvent{
+		CommitTs: 417318403368288265,
+		TableInfo: &model.SimpleTableInfo{
+			Schema:     "test",
+			Table:      "t",
+			ColumnInfo: []*model.ColumnInfo{{Name: "id", Type: mysql.TypeLong}, {Name: "name", Type: mysql.TypeVarchar}},
+		},
+		PreTableInfo: &model.SimpleTableInfo{
+			Schema:     "test",
+			Table:      "t",
+			ColumnInfo: []*model.ColumnInfo{{Name: "id", Type: mysql.TypeLong}},
+		},
+		Query: "alter table t add column name varchar(255)",
+		Type:  timodel.ActionAddColumn,
+	}
+	message, err := encoder.EncodeDDLEvent(ddl)
+	c.Assert(err, check.IsNil)
+	decoder, err := NewMaxwellEventBatchDecoder(message.Key, message.Value)
+	c.Assert(err, check.IsNil)
+	tp, hasNext, err := decoder.HasNext()
+	c.Assert(err, check.IsNil)
+	c.Assert(hasNext, check.IsTrue)
+	c.Assert(tp, check.Equals, model.MqMessageTypeDDL)
+	_, err = decoder.NextRowChangedEvent()
+	c.Assert(err, check.NotNil)
+	decodedDDL, err := decoder.NextDDLEvent()
+	c.Assert(err, check.IsNil)
+	c.Assert(decodedDDL, check.DeepEquals, ddl)
+
+	message, err = encoder.EncodeTableStartedEvent(table, 417318403368288266)
+	c.Assert(err, check.IsNil)
+	c.Assert(message.Type, check.Equals, model.MqMessageTypeTableStarted)
+	decoder, err = NewMaxwellEventBatchDecoder(message.Key, message.Value)
+	c.Assert(err, check.IsNil)
+	tp, hasNext, err = decoder.HasNext()
+	c.Assert(err, check.IsNil)
+	c.Assert(hasNext, check.IsTrue)
+	c.Assert(tp, check.Equals, model.MqMessageTypeTableStarted)
+	decodedTable, ts, err := decoder.NextTableStartedEvent()
+	c.Assert(err, check.IsNil)
+	c.Assert(decodedTable, check.DeepEquals, table)
+	c.Assert(ts, check.Equals, uint64(417318403368288266))
+	// the bootstrap-complete message is skipped
+	_, hasNext, err = decoder.HasNext()
+	c.Assert(err, check.IsNil)
+	c.Assert(hasNext, check.IsFalse)
+}
+
+func (s *maxwellcolumnSuite) TestMaxwellDecodeLegacyMessage(c *check.C) {
+	defer testleak.AfterTest(c)()
+	// the messages without the position fall back to the ts in seconds
+	value, err := json.Marshal(&maxwellMessage{
+		Database: "test",
+		Table:    "t",
+		Type:     "insert",
+		Ts:       1590000000,
+		Data:     map[string]interface{}{"id": 1},
+	})
+	c.Assert(err, check.IsNil)
+	decoder, err := NewMaxwellEventBatchDecoder(nil, value)
+	c.Assert(err, check.IsNil)
+	_, hasNext, err := decoder.HasNext()
+	c.Assert(err, check.IsNil)
+	c.Assert(hasNext, check.IsTrue)
+	row, err := decoder.NextRowChangedEvent()
+	c.Assert(err, check.IsNil)
+	c.Assert(row.CommitTs>>18, check.Equals, uint64(1590000000000))
+	c.Assert(row.Columns, check.DeepEquals, []*model.Column{{Name: "id", Value: int64(1)}})
+}
@@ -14,6 +14,7 @@
 package common
 
 import (
+	"context"
 	"log"
 	"sync"
 	"sync/atomic"
@@ -21,6 +22,7 @@ import (
 	"github.com/edwingeng/deque"
 	"github.com/pingcap/errors"
 	cerrors "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"go.uber.org/zap"
 )
 
@@ -141,9 +143,16 @@ func (c *TableMemoryQuota) GetConsumption() uint64 {
 // TableFlowController provides a convenient interface to control the memory consumption of a per table event stream
 type TableFlowController struct {
 	memoryQuota *TableMemoryQuota
+	// governor accounts the memory consumption of the table against the
+	// server-level memory limit, ctx is cancelled by Abort to interrupt
+	// the blocking acquirements from the governor.
+	governor *memquota.Governor
+	ctx      context.Context
+	cancel   context.CancelFunc
 
-	mu    sync.Mutex
-	queue deque.Deque
+	mu      sync.Mutex
+	queue   deque.Deque
+	aborted bool
 
 	lastCommitTs uint64
 }
@@ -155,8 +164,16 @@ type commitTsSizeEntry struct {
 
 // NewTableFlowController creates a new TableFlowController
 func NewTableFlowController(quota uint64) *TableFlowController {
+	return newTableFlowController(quota, memquota.GlobalGovernor())
+}
+
+func newTableFlowController(quota uint64, governor *memquota.Governor) *TableFlowController {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &TableFlowController{
 		memoryQuota: NewTableMemoryQuota(quota),
+		governor:    governor,
+		ctx:         ctx,
+		cancel:      cancel,
 		queue:       deque.NewDeque(),
 	}
 }
@@ -178,6 +195,14 @@ func (c *TableFlowController) Consume(commitTs uint64, size uint64, blockCallBac
 		if err != nil {
 			return errors.Trace(err)
 		}
+		err = c.governor.Acquire(c.ctx, memquota.ComponentSink, size, blockCallBack)
+		if err != nil {
+			c.memoryQuota.Release(size)
+			if c.ctx.Err() != nil {
+				return cerrors.ErrFlowControllerAborted.GenWithStackByArgs()
+			}
+			return errors.Trace(err)
+		}
 	} else {
 		// Here commitTs == lastCommitTs, which means that we are not crossing
 		// a transaction boundary. In this situation, we use `ForceConsume` because
@@ -188,10 +213,18 @@ func (c *TableFlowController) Consume(commitTs uint64, size uint64, blockCallBac
 		if err != nil {
 			return errors.Trace(err)
 		}
+		c.governor.ForceAcquire(memquota.ComponentSink, size)
 	}
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if c.aborted {
+		// The queue has been drained by Abort, release the memory here so
+		// that it is not leaked in the governor.
+		c.memoryQuota.Release(size)
+		c.governor.Release(memquota.ComponentSink, size)
+		return cerrors.ErrFlowControllerAborted.GenWithStackByArgs()
+	}
 	c.queue.PushBack(&commitTsSizeEntry{
 		CommitTs: commitTs,
 		Size:     size,
@@ -216,11 +249,24 @@ func (c *TableFlowController) Release(resolvedTs uint64) {
 	c.mu.Unlock()
 
 	c.memoryQuota.Release(nBytesToRelease)
+	c.governor.Release(memquota.ComponentSink, nBytesToRelease)
 }
 
-// Abort interrupts any ongoing Consume call
+// Abort interrupts any ongoing Consume call, and returns the memory consumed
+// by the table to the governor, as the events will never be released.
 func (c *TableFlowController) Abort() {
 	c.memoryQuota.Abort()
+	c.cancel()
+
+	var nBytesToRelease uint64
+	c.mu.Lock()
+	c.aborted = true
+	for c.queue.Len() > 0 {
+		nBytesToRelease += c.queue.PopFront().(*commitTsSizeEntry).Size
+	}
+	c.mu.Unlock()
+
+	c.governor.Release(memquota.ComponentSink, nBytesToRelease)
 }
 
 // GetConsumption returns the current memory consumption
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/util/testleak"
 	"golang.org/x/sync/errgroup"
 )
@@ -598,3 +599,44 @@ func BenchmarkTableFlowController(B *testing.B) {
 		return nil
 	})
 }
+
+func (s *flowControlSuite) TestFlowControlGovernor(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	governor := memquota.NewGovernor(1000)
+	controller := newTableFlowController(1024, governor)
+	callBacker := &mockCallBacker{}
+
+	// the sink is able to exceed its share while the total limit is not reached
+	err := controller.Consume(1, 500, callBacker.cb)
+	c.Assert(err, check.IsNil)
+	err = controller.Consume(1, 300, callBacker.cb)
+	c.Assert(err, check.IsNil)
+	c.Assert(governor.Used(memquota.ComponentSink), check.Equals, uint64(800))
+
+	// the sorter takes the rest of the limit, the sink is blocked by the governor
+	governor.ForceAcquire(memquota.ComponentSorter, 300)
+	consumed := make(chan error, 1)
+	go func() {
+		consumed <- controller.Consume(2, 100, callBacker.cb)
+	}()
+	select {
+	case <-consumed:
+		c.Fatal("the consumption should be blocked by the governor")
+	case <-time.After(100 * time.Millisecond):
+	}
+	controller.Release(1)
+	c.Assert(<-consumed, check.IsNil)
+	c.Assert(callBacker.timesCalled, check.Equals, 1)
+	c.Assert(governor.Used(memquota.ComponentSink), check.Equals, uint64(100))
+
+	// the memory consumed by an aborted table is returned to the governor
+	governor.ForceAcquire(memquota.ComponentSorter, 1000)
+	go func() {
+		consumed <- controller.Consume(3, 500, callBacker.cb)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	controller.Abort()
+	c.Assert(<-consumed, check.ErrorMatches, ".*ErrFlowControllerAborted.*")
+	c.Assert(governor.Used(memquota.ComponentSink), check.Equals, uint64(0))
+}
@@ -0,0 +1,203 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	tfilter "github.com/pingcap/tidb-tools/pkg/table-filter"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"go.uber.org/zap"
+)
+
+type ddlRewriteRule struct {
+	filter       tfilter.Filter
+	targetSchema string
+	ifNotExists  bool
+}
+
+// ddlRewriter rewrites the DDL statements according to the DDL rewrite rules
+// of the changefeed before they are executed, and routes the rows of the
+// tables to the renamed schemas.
+type ddlRewriter struct {
+	rules []*ddlRewriteRule
+	// targetSchemas caches the downstream schemas of the tables.
+	targetSchemas sync.Map
+}
+
+// newDDLRewriter creates a ddlRewriter, it returns nil if there is no DDL
+// rewrite rule in the configuration.
+func newDDLRewriter(cfg *config.ReplicaConfig) (*ddlRewriter, error) {
+	if cfg.DDL == nil || len(cfg.DDL.Rewrites) == 0 {
+		return nil, nil
+	}
+	rules := make([]*ddlRewriteRule, 0, len(cfg.DDL.Rewrites))
+	for _, ruleCfg := range cfg.DDL.Rewrites {
+		f, err := tfilter.Parse(ruleCfg.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+		}
+		if !cfg.CaseSensitive {
+			f = tfilter.CaseInsensitive(f)
+		}
+		rules = append(rules, &ddlRewriteRule{
+			filter:       f,
+			targetSchema: ruleCfg.TargetSchema,
+			ifNotExists:  ruleCfg.IfNotExists,
+		})
+	}
+	return &ddlRewriter{rules: rules}, nil
+}
+
+func (r *ddlRewriter) matchRule(ddl *model.DDLEvent) *ddlRewriteRule {
+	schema, table := ddl.TableInfo.Schema, ddl.TableInfo.Table
+	for _, rule := range r.rules {
+		switch ddl.Type {
+		case timodel.ActionCreateSchema, timodel.ActionDropSchema,
+			timodel.ActionModifySchemaCharsetAndCollate:
+			if rule.filter.MatchSchema(schema) {
+				return rule
+			}
+		default:
+			if rule.filter.MatchTable(schema, table) {
+				return rule
+			}
+		}
+	}
+	return nil
+}
+
+// targetSchema returns the downstream schema of the row changed events of the
+// table, which is renamed in the same way as the DDLs of the table.
+func (r *ddlRewriter) targetSchema(table *model.TableName) string {
+	if r == nil {
+		return table.Schema
+	}
+	key := model.TableName{Schema: table.Schema, Table: table.Table}
+	if target, ok := r.targetSchemas.Load(key); ok {
+		return target.(string)
+	}
+	target := table.Schema
+	for _, rule := range r.rules {
+		if rule.filter.MatchTable(table.Schema, table.Table) {
+			if rule.targetSchema != "" {
+				target = rule.targetSchema
+			}
+			break
+		}
+	}
+	r.targetSchemas.Store(key, target)
+	return target
+}
+
+// rewrite returns the rewritten DDL event, the original event is returned if
+// the DDL is matched by no rule. The original event is never modified, so the
+// DDL can be rewritten again if the execution is retried.
+func (r *ddlRewriter) rewrite(ddl *model.DDLEvent) (*model.DDLEvent, error) {
+	if r == nil || ddl.TableInfo == nil {
+		return ddl, nil
+	}
+	rule := r.matchRule(ddl)
+	if rule == nil {
+		return ddl, nil
+	}
+	stmt, err := parser.New().ParseOneStmt(ddl.Query, "", "")
+	if err != nil {
+		log.Warn("failed to parse the DDL, execute it as is",
+			zap.String("query", ddl.Query), zap.Error(err))
+		return ddl, nil
+	}
+
+	if rule.ifNotExists {
+		addIfNotExists(stmt)
+	}
+	schema := ddl.TableInfo.Schema
+	if rule.targetSchema != "" {
+		renameSchema(stmt, schema, rule.targetSchema)
+		schema = rule.targetSchema
+	}
+
+	query, err := restoreStmt(stmt)
+	if err != nil {
+		return nil, err
+	}
+	rewritten := *ddl
+	rewritten.Query = query
+	tableInfo := *ddl.TableInfo
+	tableInfo.Schema = schema
+	rewritten.TableInfo = &tableInfo
+	log.Info("rewrite the DDL according to the DDL rewrite rules",
+		zap.String("query", ddl.Query), zap.String("rewritten", rewritten.Query))
+	return &rewritten, nil
+}
+
+// addIfNotExists adds IF NOT EXISTS to the CREATE statements and IF EXISTS to
+// the DROP statements of tables and databases, which are supported by MySQL.
+func addIfNotExists(stmt ast.StmtNode) {
+	switch v := stmt.(type) {
+	case *ast.CreateDatabaseStmt:
+		v.IfNotExists = true
+	case *ast.DropDatabaseStmt:
+		v.IfExists = true
+	case *ast.CreateTableStmt:
+		v.IfNotExists = true
+	case *ast.DropTableStmt:
+		v.IfExists = true
+	}
+}
+
+// renameSchema renames the schema of the DDL from the original schema to the
+// target schema, the table names without a schema are qualified with the
+// target schema, and the table names of other schemas are not changed.
+func renameSchema(stmt ast.StmtNode, origin, target string) {
+	rename := func(name string) string {
+		if name == "" || strings.EqualFold(name, origin) {
+			return target
+		}
+		return name
+	}
+	switch v := stmt.(type) {
+	case *ast.CreateDatabaseStmt:
+		v.Name = rename(v.Name)
+	case *ast.DropDatabaseStmt:
+		v.Name = rename(v.Name)
+	case *ast.AlterDatabaseStmt:
+		v.Name = rename(v.Name)
+	default:
+		stmt.Accept(&schemaRenameVisitor{rename: rename})
+	}
+}
+
+type schemaRenameVisitor struct {
+	rename func(name string) string
+}
+
+func (v *schemaRenameVisitor) Enter(in ast.Node) (ast.Node, bool) {
+	if t, ok := in.(*ast.TableName); ok {
+		t.Schema = timodel.NewCIStr(v.rename(t.Schema.O))
+		return in, true
+	}
+	return in, false
+}
+
+func (v *schemaRenameVisitor) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
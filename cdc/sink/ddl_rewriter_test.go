@@ -0,0 +1,142 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+	timodel "github.com/pingcap/tidb/parser/model"
+)
+
+type ddlRewriterSuite struct{}
+
+var _ = check.Suite(&ddlRewriterSuite{})
+
+func (s *ddlRewriterSuite) TestRewriteDDL(c *check.C) {
+	defer testleak.AfterTest(c)()
+	cfg := config.GetDefaultReplicaConfig()
+	r, err := newDDLRewriter(cfg)
+	c.Assert(err, check.IsNil)
+	c.Assert(r, check.IsNil)
+	// a nil rewriter rewrites nothing
+	ddl := &model.DDLEvent{
+		Query:     "create table t1(id int primary key)",
+		Type:      timodel.ActionCreateTable,
+		TableInfo: &model.SimpleTableInfo{Schema: "test", Table: "t1"},
+	}
+	rewritten, err := r.rewrite(ddl)
+	c.Assert(err, check.IsNil)
+	c.Assert(rewritten, check.Equals, ddl)
+
+	cfg.DDL = &config.DDLConfig{Rewrites: []*config.DDLRewriteRule{
+		{Matcher: []string{"test.t1"}, IfNotExists: true},
+		{Matcher: []string{"test.*"}, TargetSchema: "test_bak", IfNotExists: true},
+	}}
+	r, err = newDDLRewriter(cfg)
+	c.Assert(err, check.IsNil)
+
+	cases := []struct {
+		query  string
+		tp     timodel.ActionType
+		table  string
+		expect string
+		schema string
+	}{{
+		query:  "create table t1(id int primary key)",
+		tp:     timodel.ActionCreateTable,
+		table:  "t1",
+		expect: "CREATE TABLE IF NOT EXISTS `t1` (`id` INT PRIMARY KEY)",
+		schema: "test",
+	}, {
+		query:  "create table test.t2(id int primary key, a bigint /*T![auto_rand] AUTO_RANDOM(5) */)",
+		tp:     timodel.ActionCreateTable,
+		table:  "t2",
+		expect: "CREATE TABLE IF NOT EXISTS `test_bak`.`t2` (`id` INT PRIMARY KEY,`a` BIGINT /*T![auto_rand] AUTO_RANDOM(5) */)",
+		schema: "test_bak",
+	}, {
+		query:  "drop table t2, other.t3",
+		tp:     timodel.ActionDropTable,
+		table:  "t2",
+		expect: "DROP TABLE IF EXISTS `test_bak`.`t2`, `other`.`t3`",
+		schema: "test_bak",
+	}, {
+		query:  "alter table t2 add column c1 int",
+		tp:     timodel.ActionAddColumn,
+		table:  "t2",
+		expect: "ALTER TABLE `test_bak`.`t2` ADD COLUMN `c1` INT",
+		schema: "test_bak",
+	}, {
+		// the DDLs of schemas use the first rule matching the schema
+		query:  "create database test",
+		tp:     timodel.ActionCreateSchema,
+		expect: "CREATE DATABASE IF NOT EXISTS `test`",
+		schema: "test",
+	}, {
+		// the DDL which can't be parsed is executed as is
+		query:  "create table t2(",
+		tp:     timodel.ActionCreateTable,
+		table:  "t2",
+		expect: "create table t2(",
+		schema: "test",
+	}}
+	for _, cs := range cases {
+		ddl := &model.DDLEvent{
+			Query:     cs.query,
+			Type:      cs.tp,
+			TableInfo: &model.SimpleTableInfo{Schema: "test", Table: cs.table},
+		}
+		rewritten, err := r.rewrite(ddl)
+		c.Assert(err, check.IsNil)
+		c.Assert(rewritten.Query, check.Equals, cs.expect)
+		c.Assert(rewritten.TableInfo.Schema, check.Equals, cs.schema)
+		// the original event is not modified
+		c.Assert(ddl.Query, check.Equals, cs.query)
+		c.Assert(ddl.TableInfo.Schema, check.Equals, "test")
+	}
+
+	// the DDLs matched by no rule are not rewritten
+	ddl = &model.DDLEvent{
+		Query:     "create table t1(id int primary key)",
+		Type:      timodel.ActionCreateTable,
+		TableInfo: &model.SimpleTableInfo{Schema: "other", Table: "t1"},
+	}
+	rewritten, err = r.rewrite(ddl)
+	c.Assert(err, check.IsNil)
+	c.Assert(rewritten, check.Equals, ddl)
+}
+
+func (s *ddlRewriterSuite) TestTargetSchema(c *check.C) {
+	defer testleak.AfterTest(c)()
+	var r *ddlRewriter
+	c.Assert(r.targetSchema(&model.TableName{Schema: "test", Table: "t1"}), check.Equals, "test")
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.DDL = &config.DDLConfig{Rewrites: []*config.DDLRewriteRule{
+		{Matcher: []string{"test.t1"}, IfNotExists: true},
+		{Matcher: []string{"test.*"}, TargetSchema: "test_bak"},
+	}}
+	r, err := newDDLRewriter(cfg)
+	c.Assert(err, check.IsNil)
+	// the rows are routed by the first matched rule like the DDLs
+	for i := 0; i < 2; i++ {
+		c.Assert(r.targetSchema(&model.TableName{Schema: "test", Table: "t1"}), check.Equals, "test")
+		c.Assert(r.targetSchema(&model.TableName{Schema: "test", Table: "t2"}), check.Equals, "test_bak")
+		c.Assert(r.targetSchema(&model.TableName{Schema: "other", Table: "t2"}), check.Equals, "other")
+	}
+
+	sink := &mysqlSink{ddlRewriter: r}
+	c.Assert(sink.quoteTable(&model.TableName{Schema: "test", Table: "t2"}), check.Equals, "`test_bak`.`t2`")
+}
@@ -32,6 +32,15 @@ const (
 	defaultMetricInterval = time.Second * 15
 )
 
+// tableStartedEmitter is implemented by the sinks which inform the downstream
+// that a table starts being replicated.
+type tableStartedEmitter interface {
+	// EmitTableStarted is called before any row changed event of the table is
+	// emitted, all the events of the table with a commit ts greater than startTs
+	// are emitted after it.
+	EmitTableStarted(ctx context.Context, table *model.TableName, startTs model.Ts) error
+}
+
 // Manager manages table sinks, maintains the relationship between table sinks and backendSink.
 type Manager struct {
 	backendSink  Sink
@@ -44,30 +53,42 @@ type Manager struct {
 
 	drawbackChan chan drawbackMsg
 
+	// tableStartedEmitter is the original backend sink if it implements tableStartedEmitter
+	tableStartedEmitter tableStartedEmitter
+
 	captureAddr               string
 	changefeedID              model.ChangeFeedID
 	metricsTableSinkTotalRows prometheus.Counter
+	tableMetrics              *tableMetrics
 }
 
-// NewManager creates a new Sink manager
+// NewManager creates a new Sink manager, the metrics labelled by table are
+// reported if enableTableMetrics is true.
 func NewManager(
 	ctx context.Context, backendSink Sink, errCh chan error, checkpointTs model.Ts,
-	captureAddr string, changefeedID model.ChangeFeedID,
+	captureAddr string, changefeedID model.ChangeFeedID, enableTableMetrics bool,
 ) *Manager {
 	drawbackChan := make(chan drawbackMsg, 16)
+	emitter, _ := backendSink.(tableStartedEmitter)
 	return &Manager{
 		backendSink:               newBufferSink(ctx, backendSink, errCh, checkpointTs, drawbackChan),
+		tableStartedEmitter:       emitter,
 		checkpointTs:              checkpointTs,
 		tableSinks:                make(map[model.TableID]*tableSink),
 		drawbackChan:              drawbackChan,
 		captureAddr:               captureAddr,
 		changefeedID:              changefeedID,
 		metricsTableSinkTotalRows: tableSinkTotalRowsCountCounter.WithLabelValues(captureAddr, changefeedID),
+		tableMetrics:              newTableMetrics(enableTableMetrics, captureAddr, changefeedID),
 	}
 }
 
-// CreateTableSink creates a table sink
-func (m *Manager) CreateTableSink(tableID model.TableID, checkpointTs model.Ts, redoManager redo.LogManager) Sink {
+// CreateTableSink creates a table sink, the tableName can be nil. If newlyAdded
+// is true, the downstream is informed that the table starts being replicated,
+// which is not repeated when the table is moved or restarted.
+func (m *Manager) CreateTableSink(
+	tableID model.TableID, tableName *model.TableName, checkpointTs model.Ts, newlyAdded bool, redoManager redo.LogManager,
+) Sink {
 	m.tableSinksMu.Lock()
 	defer m.tableSinksMu.Unlock()
 	if _, exist := m.tableSinks[tableID]; exist {
@@ -75,8 +96,11 @@ func (m *Manager) CreateTableSink(tableID model.TableID, checkpointTs model.Ts,
 	}
 	sink := &tableSink{
 		tableID:     tableID,
+		tableName:   tableName,
 		manager:     m,
 		buffer:      make([]*model.RowChangedEvent, 0, 128),
+		startTs:     checkpointTs,
+		started:     !newlyAdded,
 		emittedTs:   checkpointTs,
 		redoManager: redoManager,
 	}
@@ -87,9 +111,24 @@ func (m *Manager) CreateTableSink(tableID model.TableID, checkpointTs model.Ts,
 // Close closes the Sink manager and backend Sink, this method can be reentrantly called
 func (m *Manager) Close(ctx context.Context) error {
 	tableSinkTotalRowsCountCounter.DeleteLabelValues(m.captureAddr, m.changefeedID)
+	m.tableMetrics.close()
 	return m.backendSink.Close(ctx)
 }
 
+// emitTableStarted informs the downstream that the table starts being replicated
+// from startTs, it must be called before any row of the table is emitted.
+func (m *Manager) emitTableStarted(ctx context.Context, table *model.TableName, startTs model.Ts) error {
+	if m.tableStartedEmitter == nil {
+		return nil
+	}
+	if table == nil {
+		log.Warn("table name is unknown, skip emitting table started event",
+			zap.String("changefeed", m.changefeedID), zap.Uint64("startTs", startTs))
+		return nil
+	}
+	return m.tableStartedEmitter.EmitTableStarted(ctx, table, startTs)
+}
+
 func (m *Manager) getMinEmittedTs() model.Ts {
 	m.tableSinksMu.Lock()
 	defer m.tableSinksMu.Unlock()
@@ -129,6 +168,9 @@ func (m *Manager) flushBackendSink(ctx context.Context) (model.Ts, error) {
 
 func (m *Manager) destroyTableSink(ctx context.Context, tableID model.TableID) error {
 	m.tableSinksMu.Lock()
+	if sink, ok := m.tableSinks[tableID]; ok {
+		m.tableMetrics.remove(sink.tableName)
+	}
 	delete(m.tableSinks, tableID)
 	m.tableSinksMu.Unlock()
 	callback := make(chan struct{})
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/redo"
 	"github.com/pingcap/ticdc/pkg/util/testleak"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 type managerSuite struct{}
@@ -92,7 +93,7 @@ func (s *managerSuite) TestManagerRandom(c *check.C) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	errCh := make(chan error, 16)
-	manager := NewManager(ctx, &checkSink{C: c}, errCh, 0, "", "")
+	manager := NewManager(ctx, &checkSink{C: c}, errCh, 0, "", "", false)
 	defer manager.Close(ctx)
 	goroutineNum := 10
 	rowNum := 100
@@ -103,7 +104,7 @@ func (s *managerSuite) TestManagerRandom(c *check.C) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			tableSinks[i] = manager.CreateTableSink(model.TableID(i), 0, redo.NewDisabledManager())
+			tableSinks[i] = manager.CreateTableSink(model.TableID(i), nil, 0, false, redo.NewDisabledManager())
 		}()
 	}
 	wg.Wait()
@@ -147,7 +148,7 @@ func (s *managerSuite) TestManagerAddRemoveTable(c *check.C) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	errCh := make(chan error, 16)
-	manager := NewManager(ctx, &checkSink{C: c}, errCh, 0, "", "")
+	manager := NewManager(ctx, &checkSink{C: c}, errCh, 0, "", "", false)
 	defer manager.Close(ctx)
 	goroutineNum := 200
 	var wg sync.WaitGroup
@@ -196,7 +197,7 @@ func (s *managerSuite) TestManagerAddRemoveTable(c *check.C) {
 		for i := 0; i < goroutineNum; i++ {
 			if i%4 != 3 {
 				// add table
-				table := manager.CreateTableSink(model.TableID(i), maxResolvedTs, redoManager)
+				table := manager.CreateTableSink(model.TableID(i), nil, maxResolvedTs, false, redoManager)
 				ctx, cancel := context.WithCancel(ctx)
 				tableCancels = append(tableCancels, cancel)
 				tableSinks = append(tableSinks, table)
@@ -234,11 +235,11 @@ func (s *managerSuite) TestManagerDestroyTableSink(c *check.C) {
 	defer cancel()
 
 	errCh := make(chan error, 16)
-	manager := NewManager(ctx, &checkSink{C: c}, errCh, 0, "", "")
+	manager := NewManager(ctx, &checkSink{C: c}, errCh, 0, "", "", false)
 	defer manager.Close(ctx)
 
 	tableID := int64(49)
-	tableSink := manager.CreateTableSink(tableID, 100, redo.NewDisabledManager())
+	tableSink := manager.CreateTableSink(tableID, nil, 100, false, redo.NewDisabledManager())
 	err := tableSink.EmitRowChangedEvents(ctx, &model.RowChangedEvent{
 		Table:    &model.TableName{TableID: tableID},
 		CommitTs: uint64(110),
@@ -250,12 +251,73 @@ func (s *managerSuite) TestManagerDestroyTableSink(c *check.C) {
 	c.Assert(err, check.IsNil)
 }
 
+func (s *managerSuite) TestManagerTableMetrics(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 16)
+	changefeedID := "test-changefeed-table-metrics"
+	manager := NewManager(ctx, &checkSink{C: c}, errCh, 0, "", changefeedID, true)
+	defer manager.Close(ctx)
+
+	tableID := int64(49)
+	tableName := &model.TableName{Schema: "test", Table: "t", TableID: tableID}
+	tableSink := manager.CreateTableSink(tableID, tableName, 100, false, redo.NewDisabledManager())
+	for _, commitTs := range []uint64{110, 120, 130} {
+		err := tableSink.EmitRowChangedEvents(ctx, &model.RowChangedEvent{Table: tableName, CommitTs: commitTs})
+		c.Assert(err, check.IsNil)
+	}
+	pendingRows := tablePendingRowsGauge.WithLabelValues("", changefeedID, tableName.QuoteString())
+	c.Assert(testutil.ToFloat64(pendingRows), check.Equals, float64(3))
+	_, err := tableSink.FlushRowChangedEvents(ctx, 120)
+	c.Assert(err, check.IsNil)
+	c.Assert(testutil.ToFloat64(pendingRows), check.Equals, float64(1))
+
+	err = manager.destroyTableSink(ctx, tableID)
+	c.Assert(err, check.IsNil)
+	c.Assert(testutil.CollectAndCount(tablePendingRowsGauge), check.Equals, 0)
+}
+
+type tableStartedSink struct {
+	checkSink
+	started []model.Ts
+}
+
+func (s *tableStartedSink) EmitTableStarted(ctx context.Context, table *model.TableName, startTs model.Ts) error {
+	s.started = append(s.started, startTs)
+	return nil
+}
+
+func (s *managerSuite) TestManagerEmitTableStarted(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 16)
+	backendSink := &tableStartedSink{checkSink: checkSink{C: c}}
+	manager := NewManager(ctx, backendSink, errCh, 0, "", "", false)
+	defer manager.Close(ctx)
+
+	// only the newly added table informs the downstream
+	tableName := &model.TableName{Schema: "test", Table: "t", TableID: 1}
+	for i, newlyAdded := range []bool{true, false} {
+		tableID := model.TableID(i + 1)
+		tableSink := manager.CreateTableSink(tableID, tableName, 100, newlyAdded, redo.NewDisabledManager())
+		_, err := tableSink.FlushRowChangedEvents(ctx, 110)
+		c.Assert(err, check.IsNil)
+		_, err = tableSink.FlushRowChangedEvents(ctx, 120)
+		c.Assert(err, check.IsNil)
+	}
+	c.Assert(backendSink.started, check.DeepEquals, []model.Ts{100})
+}
+
 // Run the benchmark
 // go test -benchmem -run='^$' -bench '^(BenchmarkManagerFlushing)$' github.com/pingcap/ticdc/cdc/sink
 func BenchmarkManagerFlushing(b *testing.B) {
 	ctx, cancel := context.WithCancel(context.Background())
 	errCh := make(chan error, 16)
-	manager := NewManager(ctx, &checkSink{}, errCh, 0, "", "")
+	manager := NewManager(ctx, &checkSink{}, errCh, 0, "", "", false)
 
 	// Init table sinks.
 	goroutineNum := 2000
@@ -267,7 +329,7 @@ func BenchmarkManagerFlushing(b *testing.B) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			tableSinks[i] = manager.CreateTableSink(model.TableID(i), 0, redo.NewDisabledManager())
+			tableSinks[i] = manager.CreateTableSink(model.TableID(i), nil, 0, false, redo.NewDisabledManager())
 		}()
 	}
 	wg.Wait()
@@ -366,9 +428,9 @@ func (s *managerSuite) TestManagerError(c *check.C) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	errCh := make(chan error, 16)
-	manager := NewManager(ctx, &errorSink{C: c}, errCh, 0, "", "")
+	manager := NewManager(ctx, &errorSink{C: c}, errCh, 0, "", "", false)
 	defer manager.Close(ctx)
-	sink := manager.CreateTableSink(1, 0, redo.NewDisabledManager())
+	sink := manager.CreateTableSink(1, nil, 0, false, redo.NewDisabledManager())
 	err := sink.EmitRowChangedEvents(ctx, &model.RowChangedEvent{
 		CommitTs: 1,
 		Table:    &model.TableName{TableID: 1},
@@ -109,6 +109,61 @@ var (
 			Name:      "buffer_sink_total_rows_count",
 			Help:      "The total count of rows that are processed by buffer sink",
 		}, []string{"capture", "changefeed"})
+
+	mqSendDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "mq_send_duration",
+			Help:      "Bucketed histogram of time (s) of writing a message to the MQ producer, including waiting for the acks of the synchronous writes.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001 /* 0.1 ms */, 2, 20),
+		}, []string{"capture", "changefeed"})
+	mqSentBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "mq_sent_bytes",
+			Help:      "total bytes of the messages written to the MQ producer",
+		}, []string{"capture", "changefeed"})
+	mqEncodeErrorCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "mq_encode_error",
+			Help:      "total count of errors of encoding the events to MQ messages",
+		}, []string{"capture", "changefeed"})
+
+	// the metrics labelled by table are only reported if the
+	// table-sink-metrics feature is enabled.
+	tableFlushDurationHistogram = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "table_flush_duration",
+			Help:      "Bucketed histogram of flush time (s) of the rows of a table, including the retries.",
+			Buckets:   prometheus.ExponentialBuckets(0.002 /* 2 ms */, 2, 18),
+		}, []string{"capture", "changefeed", "table"})
+	tableRetryCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "table_retry_count",
+			Help:      "total count of retries of flushing the rows of a table",
+		}, []string{"capture", "changefeed", "table"})
+	tableConflictCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "table_conflict_count",
+			Help:      "total count of duplicate key conflicts of flushing the rows of a table",
+		}, []string{"capture", "changefeed", "table"})
+	tablePendingRowsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "sink",
+			Name:      "table_pending_rows_count",
+			Help:      "The count of rows of a table buffered in table sink",
+		}, []string{"capture", "changefeed", "table"})
 )
 
 // InitMetrics registers all metrics in this file
@@ -125,4 +180,11 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(bufferChanSizeGauge)
 	registry.MustRegister(tableSinkTotalRowsCountCounter)
 	registry.MustRegister(bufferSinkTotalRowsCountCounter)
+	registry.MustRegister(mqSendDurationHistogram)
+	registry.MustRegister(mqSentBytesCounter)
+	registry.MustRegister(mqEncodeErrorCounter)
+	registry.MustRegister(tableFlushDurationHistogram)
+	registry.MustRegister(tableRetryCounter)
+	registry.MustRegister(tableConflictCounter)
+	registry.MustRegister(tablePendingRowsGauge)
 }
@@ -16,6 +16,7 @@ package sink
 import (
 	"context"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -31,8 +32,10 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/hotkey"
 	"github.com/pingcap/ticdc/pkg/notify"
 	"github.com/pingcap/ticdc/pkg/security"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -55,6 +58,8 @@ type mqSink struct {
 	filter         *filter.Filter
 	protocol       codec.Protocol
 
+	emitTableStarted bool
+
 	partitionNum        int32
 	partitionInput      []chan mqEvent
 	partitionResolvedTs []uint64
@@ -64,6 +69,17 @@ type mqSink struct {
 	resolvedReceiver *notify.Receiver
 
 	statistics *Statistics
+
+	captureAddr        string
+	changefeedID       model.ChangeFeedID
+	metricSendDuration prometheus.Observer
+	metricSentBytes    prometheus.Counter
+	metricEncodeErrors prometheus.Counter
+
+	// hotKeys records the tables dispatched to each partition, which helps
+	// to find the tables causing partition skew
+	hotKeys           *hotkey.Tracker
+	unregisterHotKeys func()
 }
 
 func newMqSink(
@@ -77,6 +93,33 @@ func newMqSink(
 		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, errors.New("Canal requires old value to be enabled"))
 	}
 
+	if config.Sink.AddColumn.IsEmitDefaultValueEnabled() {
+		opts[codec.OptAddColumnDefaultValue] = "true"
+	}
+
+	if config.Sink.EmitIdentity {
+		if protocol != codec.ProtocolDefault && protocol != codec.ProtocolCanalJSON {
+			return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+				"emit-identity is not supported by protocol %s", config.Sink.Protocol)
+		}
+		opts[codec.OptIdentityClusterID] = opts[OptClusterID]
+		opts[codec.OptIdentityChangefeedID] = opts[OptChangefeedID]
+		opts[codec.OptIdentityCaptureAddr] = opts[OptCaptureAddr]
+	}
+
+	if protocol == codec.ProtocolCSV {
+		csvConfig := config.Sink.CSV
+		opts[codec.OptCSVDelimiter] = csvConfig.GetDelimiter()
+		opts[codec.OptCSVQuote] = csvConfig.GetQuote()
+		opts[codec.OptCSVNullString] = csvConfig.GetNullString()
+		opts[codec.OptCSVIncludeCommitTs] = strconv.FormatBool(csvConfig != nil && csvConfig.IncludeCommitTs)
+	}
+
+	if config.Sink.EmitTableStarted && protocol != codec.ProtocolDefault && protocol != codec.ProtocolMaxwell {
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"emit-table-started is not supported by protocol %s", config.Sink.Protocol)
+	}
+
 	encoderBuilder, err := codec.NewEventBatchEncoderBuilder(protocol, credential, opts)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrKafkaInvalidConfig, err)
@@ -103,6 +146,8 @@ func newMqSink(
 		return nil, errors.Trace(err)
 	}
 
+	captureAddr, changefeedID := opts[OptCaptureAddr], opts[OptChangefeedID]
+
 	s := &mqSink{
 		mqProducer:     mqProducer,
 		dispatcher:     d,
@@ -110,6 +155,8 @@ func newMqSink(
 		filter:         filter,
 		protocol:       protocol,
 
+		emitTableStarted: config.Sink.EmitTableStarted,
+
 		partitionNum:        partitionNum,
 		partitionInput:      partitionInput,
 		partitionResolvedTs: make([]uint64, partitionNum),
@@ -117,7 +164,16 @@ func newMqSink(
 		resolvedReceiver:    resolvedReceiver,
 
 		statistics: NewStatistics(ctx, "MQ", opts),
+
+		captureAddr:        captureAddr,
+		changefeedID:       changefeedID,
+		metricSendDuration: mqSendDurationHistogram.WithLabelValues(captureAddr, changefeedID),
+		metricSentBytes:    mqSentBytesCounter.WithLabelValues(captureAddr, changefeedID),
+		metricEncodeErrors: mqEncodeErrorCounter.WithLabelValues(captureAddr, changefeedID),
+
+		hotKeys: hotkey.NewTracker(hotkey.DefaultCapacity),
 	}
+	s.unregisterHotKeys = hotkey.Register(hotKeySource(opts[OptChangefeedID], "dispatcher"), s.hotKeys)
 
 	go func() {
 		if err := s.run(ctx); err != nil && errors.Cause(err) != context.Canceled {
@@ -141,6 +197,7 @@ func (k *mqSink) EmitRowChangedEvents(ctx context.Context, rows ...*model.RowCha
 			continue
 		}
 		partition := k.dispatcher.Dispatch(row)
+		k.hotKeys.Observe(row.Table.QuoteString() + " -> partition " + strconv.Itoa(int(partition)))
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -202,6 +259,7 @@ func (k *mqSink) EmitCheckpointTs(ctx context.Context, ts uint64) error {
 	}
 	msg, err := encoder.EncodeCheckpointEvent(ts)
 	if err != nil {
+		k.metricEncodeErrors.Inc()
 		return errors.Trace(err)
 	}
 	if msg == nil {
@@ -227,6 +285,7 @@ func (k *mqSink) EmitDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
 	}
 	msg, err := encoder.EncodeDDLEvent(ddl)
 	if err != nil {
+		k.metricEncodeErrors.Inc()
 		return errors.Trace(err)
 	}
 
@@ -249,6 +308,26 @@ func (k *mqSink) EmitDDLEvent(ctx context.Context, ddl *model.DDLEvent) error {
 	return errors.Trace(err)
 }
 
+// EmitTableStarted broadcasts a table started event to all partitions synchronously,
+// so that it is sent before any row changed event of the table.
+func (k *mqSink) EmitTableStarted(ctx context.Context, table *model.TableName, startTs uint64) error {
+	if !k.emitTableStarted {
+		return nil
+	}
+	encoder, err := k.encoderBuilder.Build(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	msg, err := encoder.EncodeTableStartedEvent(table, startTs)
+	if err != nil {
+		k.metricEncodeErrors.Inc()
+		return errors.Trace(err)
+	}
+	log.Debug("emit table started event", zap.Stringer("table", table), zap.Uint64("start-ts", startTs))
+	err = k.writeToProducer(ctx, msg, codec.EncoderNeedSyncWrite, -1)
+	return errors.Trace(err)
+}
+
 // Initialize registers Avro schemas for all tables
 func (k *mqSink) Initialize(ctx context.Context, tableInfo []*model.SimpleTableInfo) error {
 	// No longer need it for now
@@ -256,6 +335,12 @@ func (k *mqSink) Initialize(ctx context.Context, tableInfo []*model.SimpleTableI
 }
 
 func (k *mqSink) Close(ctx context.Context) error {
+	if k.unregisterHotKeys != nil {
+		k.unregisterHotKeys()
+	}
+	mqSendDurationHistogram.DeleteLabelValues(k.captureAddr, k.changefeedID)
+	mqSentBytesCounter.DeleteLabelValues(k.captureAddr, k.changefeedID)
+	mqEncodeErrorCounter.DeleteLabelValues(k.captureAddr, k.changefeedID)
 	err := k.mqProducer.Close()
 	return errors.Trace(err)
 }
@@ -330,6 +415,7 @@ func (k *mqSink) runWorker(ctx context.Context, partition int32) error {
 			if e.resolvedTs != 0 {
 				op, err := encoder.AppendResolvedEvent(e.resolvedTs)
 				if err != nil {
+					k.metricEncodeErrors.Inc()
 					return errors.Trace(err)
 				}
 
@@ -344,6 +430,7 @@ func (k *mqSink) runWorker(ctx context.Context, partition int32) error {
 		}
 		op, err := encoder.AppendRowChangedEvent(e.row)
 		if err != nil {
+			k.metricEncodeErrors.Inc()
 			return errors.Trace(err)
 		}
 
@@ -360,6 +447,13 @@ func (k *mqSink) runWorker(ctx context.Context, partition int32) error {
 }
 
 func (k *mqSink) writeToProducer(ctx context.Context, message *codec.MQMessage, op codec.EncoderResult, partition int32) error {
+	if op != codec.EncoderNoOperation {
+		startTime := time.Now()
+		defer func() {
+			k.metricSendDuration.Observe(time.Since(startTime).Seconds())
+		}()
+		k.metricSentBytes.Add(float64(message.Length()))
+	}
 	switch op {
 	case codec.EncoderNeedAsyncWrite:
 		if partition >= 0 {
@@ -29,6 +29,7 @@ import (
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/util/testleak"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 type mqSinkSuite struct{}
@@ -233,3 +234,67 @@ func (s mqSinkSuite) TestPulsarSinkEncoderConfig(c *check.C) {
 	c.Assert(encoder.(*codec.JSONEventBatchEncoder).GetMaxBatchSize(), check.Equals, 1)
 	c.Assert(encoder.(*codec.JSONEventBatchEncoder).GetMaxMessageSize(), check.Equals, 4194304)
 }
+
+type discardProducer struct {
+	partitionNum int32
+}
+
+func (p *discardProducer) AsyncSendMessage(ctx context.Context, message *codec.MQMessage, partition int32) error {
+	return nil
+}
+
+func (p *discardProducer) SyncBroadcastMessage(ctx context.Context, message *codec.MQMessage) error {
+	return nil
+}
+
+func (p *discardProducer) Flush(ctx context.Context) error {
+	return nil
+}
+
+func (p *discardProducer) GetPartitionNum() int32 {
+	return p.partitionNum
+}
+
+func (p *discardProducer) Close() error {
+	return nil
+}
+
+func (s mqSinkSuite) TestMQSinkMetrics(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	fr, err := filter.NewFilter(replicaConfig)
+	c.Assert(err, check.IsNil)
+	changefeedID := "test-changefeed-mq-metrics"
+	opts := map[string]string{OptChangefeedID: changefeedID}
+	sink, err := newMqSink(ctx, nil, &discardProducer{partitionNum: 1}, fr, replicaConfig, opts, make(chan error, 1))
+	c.Assert(err, check.IsNil)
+
+	err = sink.EmitCheckpointTs(ctx, 120)
+	c.Assert(err, check.IsNil)
+	sentBytes := testutil.ToFloat64(mqSentBytesCounter.WithLabelValues("", changefeedID))
+	c.Assert(sentBytes, check.Greater, float64(0))
+	c.Assert(testutil.CollectAndCount(mqSendDurationHistogram), check.Equals, 1)
+
+	err = sink.Close(ctx)
+	c.Assert(err, check.IsNil)
+	c.Assert(testutil.CollectAndCount(mqSendDurationHistogram), check.Equals, 0)
+}
+
+func (s mqSinkSuite) TestMQSinkEmitTableStartedProtocol(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.EmitTableStarted = true
+	fr, err := filter.NewFilter(replicaConfig)
+	c.Assert(err, check.IsNil)
+	for _, protocol := range []string{"canal", "canal-json", "avro", "craft", "csv"} {
+		replicaConfig.Sink.Protocol = protocol
+		_, err := newMqSink(ctx, nil, &discardProducer{partitionNum: 1}, fr, replicaConfig, map[string]string{}, make(chan error, 1))
+		c.Assert(err, check.ErrorMatches, ".*emit-table-started is not supported by protocol "+protocol+".*")
+	}
+}
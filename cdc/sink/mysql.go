@@ -36,9 +36,11 @@ import (
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/errorutil"
 	tifilter "github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/hotkey"
 	"github.com/pingcap/ticdc/pkg/notify"
 	"github.com/pingcap/ticdc/pkg/quotes"
 	"github.com/pingcap/ticdc/pkg/retry"
+	"github.com/pingcap/tidb/parser/charset"
 	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/prometheus/client_golang/prometheus"
@@ -55,8 +57,10 @@ type mysqlSink struct {
 	db     *sql.DB
 	params *sinkParams
 
-	filter *tifilter.Filter
-	cyclic *cyclic.Cyclic
+	filter          *tifilter.Filter
+	cyclic          *cyclic.Cyclic
+	ddlRewriter     *ddlRewriter
+	partitionRouter *partitionRouter
 
 	txnCache      *common.UnresolvedTxnCache
 	workers       []*mysqlSinkWorker
@@ -73,9 +77,18 @@ type mysqlSink struct {
 	// metrics used by mysql sink only
 	metricConflictDetectDurationHis prometheus.Observer
 	metricBucketSizeCounters        []prometheus.Counter
-
-	forceReplicate bool
-	cancel         func()
+	tableMetrics                    *tableMetrics
+
+	// hotKeys records the keys causing conflicts in causality detection
+	hotKeys           *hotkey.Tracker
+	unregisterHotKeys func()
+
+	noKeyTableStrategy config.NoKeyTableStrategy
+	// the values of the auto id columns are generated by the downstream
+	// instead of written as is if they are regenerated.
+	regenerateAutoIncrement bool
+	regenerateAutoRandom    bool
+	cancel                  func()
 }
 
 var _ Sink = &mysqlSink{}
@@ -89,6 +102,10 @@ func newMySQLSink(
 	replicaConfig *config.ReplicaConfig,
 	opts map[string]string,
 ) (Sink, error) {
+	if replicaConfig.Offload.IsEnabled() {
+		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig,
+			errors.New("offloading the large column values is not supported by the MySQL sink"))
+	}
 	opts[OptChangefeedID] = changefeedID
 	params, err := parseSinkURIToParams(ctx, sinkURI, opts)
 	if err != nil {
@@ -96,6 +113,7 @@ func newMySQLSink(
 	}
 
 	params.enableOldValue = replicaConfig.EnableOldValue
+	params.writeRowID = replicaConfig.GetNoKeyTableStrategy() == config.NoKeyTableStrategyRowID
 
 	// dsn format of the driver:
 	// [username[:password]@][protocol[(address)]]/dbname[?param1=value1&...&paramN=valueN]
@@ -109,7 +127,7 @@ func newMySQLSink(
 		port = "4000"
 	}
 
-	dsnStr := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", username, password, sinkURI.Hostname(), port, params.tls)
+	dsnStr := fmt.Sprintf("%s:%s@%s(%s:%s)/%s", username, password, params.network, sinkURI.Hostname(), port, params.tls)
 	dsn, err := dmysql.ParseDSN(dsnStr)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
@@ -135,6 +153,25 @@ func newMySQLSink(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// the sessions use the time zone of the changefeed by default, but it may
+	// be overridden by the time-zone parameter of the sink URI.
+	tz, err := replicaConfig.LoadTimeZone()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tz != nil {
+		if err := checkSessionTimeZone(ctx, testDB, tz); err != nil {
+			return nil, err
+		}
+	}
+	ddlRewriter, err := newDDLRewriter(replicaConfig)
+	if err != nil {
+		return nil, err
+	}
+	partitionRouter, err := newPartitionRouter(replicaConfig)
+	if err != nil {
+		return nil, err
+	}
 	db, err := GetDBConnImpl(ctx, dsnStr)
 	if err != nil {
 		return nil, err
@@ -152,18 +189,25 @@ func newMySQLSink(
 		metricBucketSizeCounters[i] = bucketSizeCounter.WithLabelValues(
 			params.captureAddr, params.changefeedID, strconv.Itoa(i))
 	}
+	tableMetrics := newTableMetrics(replicaConfig.IsFeatureEnabled(config.FeatureTableSinkMetrics),
+		params.captureAddr, params.changefeedID)
 	ctx, cancel := context.WithCancel(ctx)
 
 	sink := &mysqlSink{
 		db:                              db,
 		params:                          params,
 		filter:                          filter,
+		ddlRewriter:                     ddlRewriter,
+		partitionRouter:                 partitionRouter,
 		txnCache:                        common.NewUnresolvedTxnCache(),
 		statistics:                      NewStatistics(ctx, "mysql", opts),
 		metricConflictDetectDurationHis: metricConflictDetectDurationHis,
 		metricBucketSizeCounters:        metricBucketSizeCounters,
+		tableMetrics:                    tableMetrics,
 		errCh:                           make(chan error, 1),
-		forceReplicate:                  replicaConfig.ForceReplicate,
+		noKeyTableStrategy:              replicaConfig.GetNoKeyTableStrategy(),
+		regenerateAutoIncrement:         replicaConfig.AutoID.AutoIncrementMode() == config.AutoIDModeRegenerate,
+		regenerateAutoRandom:            replicaConfig.AutoID.AutoRandomMode() == config.AutoIDModeRegenerate,
 		cancel:                          cancel,
 	}
 
@@ -196,6 +240,9 @@ func newMySQLSink(
 	}
 	go sink.flushRowChangedEvents(ctx, receiver)
 
+	sink.hotKeys = hotkey.NewTracker(hotkey.DefaultCapacity)
+	sink.unregisterHotKeys = hotkey.Register(hotKeySource(params.changefeedID, "causality"), sink.hotKeys)
+
 	return sink, nil
 }
 
@@ -287,7 +334,28 @@ func (s *mysqlSink) EmitDDLEvent(ctx context.Context, ddl *model.DDLEvent) error
 		return cerror.ErrDDLEventIgnored.GenWithStackByArgs()
 	}
 	s.statistics.AddDDLCount()
-	err := s.execDDLWithMaxRetries(ctx, ddl)
+	// the DDL of a partitioned table may be converted to several statements,
+	// which are rewritten by the DDL rewrite rules respectively.
+	ddls, err := s.partitionRouter.rewrite(ddl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(ddls) == 0 {
+		log.Info("DDL event changes nothing of the downstream tables, skip it",
+			zap.String("query", ddl.Query), zap.Uint64("commitTs", ddl.CommitTs))
+		return nil
+	}
+	queries := make([]string, 0, len(ddls))
+	for _, d := range ddls {
+		rewritten, err := s.ddlRewriter.rewrite(d)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		queries = append(queries, rewritten.Query)
+		// the schema may be renamed by the rewrite rules.
+		ddl = rewritten
+	}
+	err = s.execDDLWithMaxRetries(ctx, ddl, queries)
 	return errors.Trace(err)
 }
 
@@ -296,21 +364,23 @@ func (s *mysqlSink) Initialize(ctx context.Context, tableInfo []*model.SimpleTab
 	return nil
 }
 
-func (s *mysqlSink) execDDLWithMaxRetries(ctx context.Context, ddl *model.DDLEvent) error {
+// execDDLWithMaxRetries executes the queries of the DDL in a transaction,
+// the queries are the DDL query or the statements converted from it.
+func (s *mysqlSink) execDDLWithMaxRetries(ctx context.Context, ddl *model.DDLEvent, queries []string) error {
 	return retry.Do(ctx, func() error {
-		err := s.execDDL(ctx, ddl)
+		err := s.execDDL(ctx, ddl, queries)
 		if errorutil.IsIgnorableMySQLDDLError(err) {
-			log.Info("execute DDL failed, but error can be ignored", zap.String("query", ddl.Query), zap.Error(err))
+			log.Info("execute DDL failed, but error can be ignored", zap.Strings("queries", queries), zap.Error(err))
 			return nil
 		}
 		if err != nil {
-			log.Warn("execute DDL with error, retry later", zap.String("query", ddl.Query), zap.Error(err))
+			log.Warn("execute DDL with error, retry later", zap.Strings("queries", queries), zap.Error(err))
 		}
 		return err
 	}, retry.WithBackoffBaseDelay(backoffBaseDelayInMs), retry.WithBackoffMaxDelay(backoffMaxDelayInMs), retry.WithMaxTries(defaultDDLMaxRetryTime), retry.WithIsRetryableErr(cerror.IsRetryableError))
 }
 
-func (s *mysqlSink) execDDL(ctx context.Context, ddl *model.DDLEvent) error {
+func (s *mysqlSink) execDDL(ctx context.Context, ddl *model.DDLEvent, queries []string) error {
 	shouldSwitchDB := needSwitchDB(ddl)
 
 	failpoint.Inject("MySQLSinkExecDDLDelay", func() {
@@ -337,11 +407,13 @@ func (s *mysqlSink) execDDL(ctx context.Context, ddl *model.DDLEvent) error {
 			}
 		}
 
-		if _, err = tx.ExecContext(ctx, ddl.Query); err != nil {
-			if rbErr := tx.Rollback(); rbErr != nil {
-				log.Error("Failed to rollback", zap.String("sql", ddl.Query), zap.Error(err))
+		for _, query := range queries {
+			if _, err = tx.ExecContext(ctx, query); err != nil {
+				if rbErr := tx.Rollback(); rbErr != nil {
+					log.Error("Failed to rollback", zap.String("sql", query), zap.Error(err))
+				}
+				return err
 			}
-			return err
 		}
 
 		return tx.Commit()
@@ -350,7 +422,7 @@ func (s *mysqlSink) execDDL(ctx context.Context, ddl *model.DDLEvent) error {
 		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
 	}
 
-	log.Info("Exec DDL succeeded", zap.String("sql", ddl.Query))
+	log.Info("Exec DDL succeeded", zap.Strings("sql", queries))
 	return nil
 }
 
@@ -454,6 +526,9 @@ func (s *mysqlSink) dispatchAndExecTxns(ctx context.Context, txnsGroup map[model
 				sendFn(txn, keys, idx)
 				return
 			}
+			for _, key := range causality.conflictKeys(keys) {
+				s.hotKeys.Observe(describeKey(key, txn.Table))
+			}
 			s.notifyAndWaitExec(ctx)
 			causality.reset()
 		}
@@ -471,8 +546,12 @@ func (s *mysqlSink) dispatchAndExecTxns(ctx context.Context, txnsGroup map[model
 }
 
 func (s *mysqlSink) Close(ctx context.Context) error {
+	if s.unregisterHotKeys != nil {
+		s.unregisterHotKeys()
+	}
 	s.execWaitNotifier.Close()
 	s.resolvedNotifier.Close()
+	s.tableMetrics.close()
 	err := s.db.Close()
 	s.cancel()
 	return cerror.WrapError(cerror.ErrMySQLConnectionError, err)
@@ -550,7 +629,13 @@ func (s *mysqlSink) execDMLWithMaxRetries(ctx context.Context, dmls *preparedDML
 			zap.Any("values", dmls.values))
 	}
 
-	return retry.Do(ctx, func() error {
+	startTime := time.Now()
+	attempts := 0
+	err := retry.Do(ctx, func() error {
+		if attempts > 0 {
+			s.tableMetrics.incRetry(dmls.tables)
+		}
+		attempts++
 		failpoint.Inject("MySQLSinkTxnRandomError", func() {
 			failpoint.Return(logDMLTxnErr(errors.Trace(dmysql.ErrInvalidConn)))
 		})
@@ -591,6 +676,9 @@ func (s *mysqlSink) execDMLWithMaxRetries(ctx context.Context, dmls *preparedDML
 			return dmls.rowCount, nil
 		})
 		if err != nil {
+			if errCode, ok := getSQLErrCode(err); ok && errCode == mysql.ErrDupEntry {
+				s.tableMetrics.incConflict(dmls.tables)
+			}
 			return errors.Trace(err)
 		}
 		log.Debug("Exec Rows succeeded",
@@ -599,6 +687,11 @@ func (s *mysqlSink) execDMLWithMaxRetries(ctx context.Context, dmls *preparedDML
 			zap.Int("bucket", bucket))
 		return nil
 	}, retry.WithBackoffBaseDelay(backoffBaseDelayInMs), retry.WithBackoffMaxDelay(backoffMaxDelayInMs), retry.WithMaxTries(defaultDMLMaxRetryTime), retry.WithIsRetryableErr(isRetryableDMLError))
+	if err != nil {
+		return err
+	}
+	s.tableMetrics.observeFlush(dmls.tables, time.Since(startTime))
+	return nil
 }
 
 type preparedDMLs struct {
@@ -606,6 +699,9 @@ type preparedDMLs struct {
 	values   [][]interface{}
 	markSQL  string
 	rowCount int
+	// tables is the quoted names of the tables of the rows, it is only set
+	// if the metrics labelled by table are enabled.
+	tables []string
 }
 
 // prepareDMLs converts model.RowChangedEvent list to query string list and args list
@@ -615,6 +711,8 @@ func (s *mysqlSink) prepareDMLs(rows []*model.RowChangedEvent, replicaID uint64,
 	replaces := make(map[string][][]interface{})
 	rowCount := 0
 	translateToInsert := s.params.enableOldValue && !s.params.safeMode
+	// the rows without keys are identified by all the columns
+	forceReplicate := s.noKeyTableStrategy == config.NoKeyTableStrategyAllColumns
 
 	// flush cached batch replace or insert, to keep the sequence of DMLs
 	flushCacheDMLs := func() {
@@ -629,13 +727,23 @@ func (s *mysqlSink) prepareDMLs(rows []*model.RowChangedEvent, replicaID uint64,
 	for _, row := range rows {
 		var query string
 		var args []interface{}
-		quoteTable := quotes.QuoteSchema(row.Table.Schema, row.Table.Table)
+		quoteTable := s.quoteTable(row.Table)
+		if s.noKeyTableStrategy == config.NoKeyTableStrategyRowID {
+			row = withRowIDKey(row)
+		}
+		regenerated := false
+		if s.regenerateAutoIncrement || s.regenerateAutoRandom {
+			row, regenerated = withoutAutoIDValues(row, s.regenerateAutoIncrement, s.regenerateAutoRandom)
+		}
 
 		// If the old value is enabled, is not in safe mode and is an update event, then translate to UPDATE.
+		// The updates of the rows with regenerated auto id columns are always translated to UPDATE, since
+		// DELETE + REPLACE without the ids inserts the rows with new ids, or duplicates the rows if the
+		// deletes match nothing.
 		// NOTICE: Only update events with the old value feature enabled will have both columns and preColumns.
-		if translateToInsert && len(row.PreColumns) != 0 && len(row.Columns) != 0 {
+		if (translateToInsert || regenerated) && len(row.PreColumns) != 0 && len(row.Columns) != 0 {
 			flushCacheDMLs()
-			query, args = prepareUpdate(quoteTable, row.PreColumns, row.Columns, s.forceReplicate)
+			query, args = prepareUpdate(quoteTable, row.PreColumns, row.Columns, forceReplicate)
 			if query != "" {
 				sqls = append(sqls, query)
 				values = append(values, args)
@@ -652,7 +760,7 @@ func (s *mysqlSink) prepareDMLs(rows []*model.RowChangedEvent, replicaID uint64,
 		// It will be translated directly into a DELETE SQL.
 		if len(row.PreColumns) != 0 {
 			flushCacheDMLs()
-			query, args = prepareDelete(quoteTable, row.PreColumns, s.forceReplicate)
+			query, args = prepareDelete(quoteTable, row.PreColumns, forceReplicate)
 			if query != "" {
 				sqls = append(sqls, query)
 				values = append(values, args)
@@ -706,9 +814,19 @@ func (s *mysqlSink) prepareDMLs(rows []*model.RowChangedEvent, replicaID uint64,
 		// we do not count mark table rows in rowCount.
 	}
 	dmls.rowCount = rowCount
+	if s.tableMetrics != nil {
+		dmls.tables = tablesOf(rows)
+	}
 	return dmls
 }
 
+// quoteTable returns the quoted downstream table of the row changed events of
+// the table, the partitions may be routed to other tables, and the schema may
+// be renamed by the DDL rewrite rules.
+func (s *mysqlSink) quoteTable(table *model.TableName) string {
+	return quotes.QuoteSchema(s.ddlRewriter.targetSchema(table), s.partitionRouter.targetTable(table))
+}
+
 func (s *mysqlSink) execDMLs(ctx context.Context, rows []*model.RowChangedEvent, replicaID uint64, bucket int) error {
 	failpoint.Inject("SinkFlushDMLPanic", func() {
 		time.Sleep(time.Second)
@@ -743,7 +861,7 @@ func prepareReplace(
 			continue
 		}
 		columnNames = append(columnNames, col.Name)
-		args = append(args, col.Value)
+		args = appendQueryArgs(args, col)
 	}
 	if len(args) == 0 {
 		return "", nil
@@ -807,11 +925,12 @@ func prepareUpdate(quoteTable string, preCols, cols []*model.Column, forceReplic
 	columnNames := make([]string, 0, len(cols))
 	args := make([]interface{}, 0, len(cols)+len(preCols))
 	for _, col := range cols {
-		if col == nil || col.Flag.IsGeneratedColumn() {
+		// the _tidb_rowid of a row is never changed
+		if col == nil || col.Flag.IsGeneratedColumn() || col.Name == timodel.ExtraHandleName.O {
 			continue
 		}
 		columnNames = append(columnNames, col.Name)
-		args = append(args, col.Value)
+		args = appendQueryArgs(args, col)
 	}
 	if len(args) == 0 {
 		return "", nil
@@ -877,7 +996,7 @@ func whereSlice(cols []*model.Column, forceReplicate bool) (colNames []string, a
 			continue
 		}
 		colNames = append(colNames, col.Name)
-		args = append(args, col.Value)
+		args = appendQueryArgs(args, col)
 	}
 	// if no explicit row id but force replicate, use all key-values in where condition
 	if len(colNames) == 0 && forceReplicate {
@@ -885,12 +1004,80 @@ func whereSlice(cols []*model.Column, forceReplicate bool) (colNames []string, a
 		args = make([]interface{}, 0, len(cols))
 		for _, col := range cols {
 			colNames = append(colNames, col.Name)
-			args = append(args, col.Value)
+			args = appendQueryArgs(args, col)
 		}
 	}
 	return
 }
 
+// withRowIDKey returns the row with the _tidb_rowid column as its handle key
+// if the row has no handle key column. The original row is not modified.
+func withRowIDKey(row *model.RowChangedEvent) *model.RowChangedEvent {
+	for _, cols := range [][]*model.Column{row.Columns, row.PreColumns} {
+		for _, col := range cols {
+			if col != nil && col.Flag.IsHandleKey() {
+				return row
+			}
+		}
+	}
+	appendRowID := func(cols []*model.Column) []*model.Column {
+		if len(cols) == 0 {
+			return cols
+		}
+		ret := make([]*model.Column, 0, len(cols)+1)
+		ret = append(ret, cols...)
+		return append(ret, &model.Column{
+			Name:  timodel.ExtraHandleName.O,
+			Type:  mysql.TypeLonglong,
+			Value: row.RowID,
+			Flag:  model.HandleKeyFlag,
+		})
+	}
+	clone := *row
+	clone.Columns = appendRowID(row.Columns)
+	clone.PreColumns = appendRowID(row.PreColumns)
+	return &clone
+}
+
+// appendQueryArgs appends the value of the column to the args. The values of
+// the non-binary string columns are passed as strings, which are converted to
+// the charset of the downstream column by the downstream, instead of written
+// as the raw bytes.
+func appendQueryArgs(args []interface{}, col *model.Column) []interface{} {
+	if v, ok := col.Value.([]byte); ok && col.Charset != "" && col.Charset != charset.CharsetBin {
+		return append(args, string(v))
+	}
+	return append(args, col.Value)
+}
+
+// withoutAutoIDValues returns the row without the values of the regenerated
+// auto id columns, so that they are generated by the downstream, and whether
+// any column is omitted. The old values are kept to locate the row. The
+// original row is not modified.
+func withoutAutoIDValues(row *model.RowChangedEvent, autoIncrement, autoRandom bool) (*model.RowChangedEvent, bool) {
+	regenerated := func(col *model.Column) bool {
+		return col != nil && (autoIncrement && col.Flag.IsAutoIncrement() ||
+			autoRandom && col.Flag.IsAutoRandom())
+	}
+	var cols []*model.Column
+	for i, col := range row.Columns {
+		if !regenerated(col) {
+			continue
+		}
+		if cols == nil {
+			cols = make([]*model.Column, len(row.Columns))
+			copy(cols, row.Columns)
+		}
+		cols[i] = nil
+	}
+	if cols == nil {
+		return row, false
+	}
+	clone := *row
+	clone.Columns = cols
+	return &clone, true
+}
+
 func getSQLErrCode(err error) (errors.ErrCode, bool) {
 	mysqlErr, ok := errors.Cause(err).(*dmysql.MySQLError)
 	if !ok {
@@ -17,6 +17,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net"
 	"net/url"
 	"strconv"
 	"strings"
@@ -46,6 +47,7 @@ const (
 	defaultWriteTimeout        = "2m"
 	defaultDialTimeout         = "2m"
 	defaultSafeMode            = true
+	defaultNetwork             = "tcp"
 )
 
 var defaultParams = &sinkParams{
@@ -58,6 +60,7 @@ var defaultParams = &sinkParams{
 	writeTimeout:        defaultWriteTimeout,
 	dialTimeout:         defaultDialTimeout,
 	safeMode:            defaultSafeMode,
+	network:             defaultNetwork,
 }
 
 var validSchemes = map[string]bool{
@@ -82,6 +85,12 @@ type sinkParams struct {
 	safeMode            bool
 	timezone            string
 	tls                 string
+	// the network of the DSN, it is registered with a custom dialer if
+	// socket options are specified
+	network string
+	// writeRowID writes the _tidb_rowid of the tables without keys, which is
+	// only supported by TiDB
+	writeRowID bool
 }
 
 func (s *sinkParams) Clone() *sinkParams {
@@ -205,6 +214,26 @@ func parseSinkURIToParams(ctx context.Context, sinkURI *url.URL, opts map[string
 		params.dialTimeout = s
 	}
 
+	socketOptions, err := util.ParseSocketOptions(sinkURI.Query())
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+	}
+	if !socketOptions.IsDefault() {
+		// the custom dialer replaces the one of the driver, so the dial
+		// timeout is applied here.
+		dialTimeout, err := time.ParseDuration(params.dialTimeout)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLInvalidConfig, err)
+		}
+		name := "cdc_mysql_tcp" + params.changefeedID
+		dmysql.RegisterDialContext(name, func(ctx context.Context, addr string) (net.Conn, error) {
+			ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+			defer cancel()
+			return socketOptions.DialContext(ctx, defaultNetwork, addr)
+		})
+		params.network = name
+	}
+
 	return params, nil
 }
 
@@ -236,6 +265,18 @@ func generateDSNByParams(
 		dsnCfg.Params["allow_auto_random_explicit_insert"] = autoRandom
 	}
 
+	if params.writeRowID {
+		writeRowID, err := checkTiDBVariable(ctx, testDB, "tidb_opt_write_row_id", "1")
+		if err != nil {
+			return "", err
+		}
+		if writeRowID == "" {
+			return "", cerror.ErrMySQLInvalidConfig.GenWithStack(
+				"the row-id strategy of the tables without keys is only supported by TiDB")
+		}
+		dsnCfg.Params["tidb_opt_write_row_id"] = writeRowID
+	}
+
 	txnMode, err := checkTiDBVariable(ctx, testDB, "tidb_txn_mode", params.tidbTxnMode)
 	if err != nil {
 		return "", err
@@ -267,3 +308,26 @@ func checkTiDBVariable(ctx context.Context, db *sql.DB, variableName, defaultVal
 	// session variable not exists, return "" to ignore it
 	return "", nil
 }
+
+// checkSessionTimeZone checks the sessions of the downstream have the same UTC
+// offset as the time zone of the changefeed, which renders the TIMESTAMP
+// values, otherwise the values are shifted silently when they are written.
+func checkSessionTimeZone(ctx context.Context, db *sql.DB, tz *time.Location) error {
+	var name string
+	var offset int
+	err := db.QueryRowContext(ctx, "SELECT @@SESSION.time_zone, TIMESTAMPDIFF(SECOND, UTC_TIMESTAMP(), NOW())").
+		Scan(&name, &offset)
+	if err != nil {
+		return cerror.ErrMySQLQueryError.Wrap(err).GenWithStack("fail to query the time zone of the session")
+	}
+	_, expected := time.Now().In(tz).Zone()
+	if offset != expected {
+		return cerror.ErrMySQLTimeZoneMismatch.GenWithStackByArgs(name, offset, tz.String(), expected)
+	}
+	if name != tz.String() {
+		log.Warn("the time zone of the downstream sessions differs from the changefeed, "+
+			"the TIMESTAMP values may be shifted once their daylight saving times differ",
+			zap.String("sessionTimeZone", name), zap.String("changefeedTimeZone", tz.String()))
+	}
+	return nil
+}
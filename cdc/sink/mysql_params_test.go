@@ -17,7 +17,9 @@ import (
 	"context"
 	"database/sql"
 	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	dmysql "github.com/go-sql-driver/mysql"
@@ -42,6 +44,7 @@ func (s MySQLSinkSuite) TestSinkParamsClone(c *check.C) {
 		writeTimeout:        defaultWriteTimeout,
 		dialTimeout:         defaultDialTimeout,
 		safeMode:            defaultSafeMode,
+		network:             defaultNetwork,
 	})
 	c.Assert(param2, check.DeepEquals, &sinkParams{
 		changefeedID:        "123",
@@ -54,6 +57,7 @@ func (s MySQLSinkSuite) TestSinkParamsClone(c *check.C) {
 		writeTimeout:        defaultWriteTimeout,
 		dialTimeout:         defaultDialTimeout,
 		safeMode:            defaultSafeMode,
+		network:             defaultNetwork,
 	})
 }
 
@@ -119,9 +123,39 @@ func (s MySQLSinkSuite) TestGenerateDSNByParams(c *check.C) {
 		}
 	}
 
+	testWriteRowIDParam := func() {
+		db, mock, err := sqlmock.New()
+		c.Assert(err, check.IsNil)
+		defer db.Close()
+		columns := []string{"Variable_name", "Value"}
+		mock.ExpectQuery("show session variables like 'allow_auto_random_explicit_insert';").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("allow_auto_random_explicit_insert", "0"))
+		mock.ExpectQuery("show session variables like 'tidb_opt_write_row_id';").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("tidb_opt_write_row_id", "0"))
+		mock.ExpectQuery("show session variables like 'tidb_txn_mode';").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("tidb_txn_mode", "pessimistic"))
+		// the variable doesn't exist in MySQL
+		mock.ExpectQuery("show session variables like 'allow_auto_random_explicit_insert';").
+			WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectQuery("show session variables like 'tidb_opt_write_row_id';").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		dsn, err := dmysql.ParseDSN("root:123456@tcp(127.0.0.1:4000)/")
+		c.Assert(err, check.IsNil)
+		params := defaultParams.Clone()
+		params.writeRowID = true
+		dsnStr, err := generateDSNByParams(context.TODO(), dsn, params, db)
+		c.Assert(err, check.IsNil)
+		c.Assert(strings.Contains(dsnStr, "tidb_opt_write_row_id=1"), check.IsTrue)
+		_, err = generateDSNByParams(context.TODO(), dsn, params, db)
+		c.Assert(err, check.ErrorMatches, ".*only supported by TiDB.*")
+		c.Assert(mock.ExpectationsWereMet(), check.IsNil)
+	}
+
 	testDefaultParams()
 	testTimezoneParam()
 	testTimeoutParams()
+	testWriteRowIDParam()
 }
 
 func (s MySQLSinkSuite) TestParseSinkURIToParams(c *check.C) {
@@ -150,6 +184,31 @@ func (s MySQLSinkSuite) TestParseSinkURIToParams(c *check.C) {
 	c.Assert(params, check.DeepEquals, expected)
 }
 
+func (s MySQLSinkSuite) TestParseSinkURISocketOptions(c *check.C) {
+	defer testleak.AfterTest(c)()
+	opts := map[string]string{OptChangefeedID: "cf-id"}
+	uri, err := url.Parse("mysql://127.0.0.1:3306/?worker-count=64")
+	c.Assert(err, check.IsNil)
+	params, err := parseSinkURIToParams(context.TODO(), uri, opts)
+	c.Assert(err, check.IsNil)
+	c.Assert(params.network, check.Equals, defaultNetwork)
+
+	uri, err = url.Parse("mysql://127.0.0.1:3306/?send-buffer-size=4194304&tcp-nodelay=false&keepalive-interval=10s")
+	c.Assert(err, check.IsNil)
+	params, err = parseSinkURIToParams(context.TODO(), uri, opts)
+	c.Assert(err, check.IsNil)
+	c.Assert(params.network, check.Equals, "cdc_mysql_tcpcf-id")
+	// the custom network is accepted by the driver
+	_, err = dmysql.ParseDSN("root:@cdc_mysql_tcpcf-id(127.0.0.1:3306)/")
+	c.Assert(err, check.IsNil)
+
+	// the dial timeout is applied by the custom dialer
+	uri, err = url.Parse("mysql://127.0.0.1:3306/?tcp-nodelay=false&timeout=abc")
+	c.Assert(err, check.IsNil)
+	_, err = parseSinkURIToParams(context.TODO(), uri, opts)
+	c.Assert(err, check.ErrorMatches, ".*invalid duration.*")
+}
+
 func (s MySQLSinkSuite) TestParseSinkURITimezone(c *check.C) {
 	defer testleak.AfterTest(c)()
 	uris := []string{
@@ -184,6 +243,8 @@ func (s MySQLSinkSuite) TestParseSinkURIBadQueryString(c *check.C) {
 		"mysql://127.0.0.1:3306/?batch-replace-enable=not-bool",
 		"mysql://127.0.0.1:3306/?batch-replace-enable=true&batch-replace-size=not-number",
 		"mysql://127.0.0.1:3306/?safe-mode=not-bool",
+		"mysql://127.0.0.1:3306/?send-buffer-size=not-number",
+		"mysql://127.0.0.1:3306/?tcp-nodelay=not-bool",
 	}
 	ctx := context.TODO()
 	opts := map[string]string{OptChangefeedID: "changefeed-01"}
@@ -224,3 +285,28 @@ func (s MySQLSinkSuite) TestCheckTiDBVariable(c *check.C) {
 	_, err = checkTiDBVariable(context.TODO(), db, "version", "5.7.25-TiDB-v4.0.0")
 	c.Assert(err, check.ErrorMatches, ".*"+sql.ErrConnDone.Error())
 }
+
+func (s MySQLSinkSuite) TestCheckSessionTimeZone(c *check.C) {
+	defer testleak.AfterTest(c)()
+	db, mock, err := sqlmock.New()
+	c.Assert(err, check.IsNil)
+	defer db.Close() //nolint:errcheck
+	query := regexp.QuoteMeta("SELECT @@SESSION.time_zone, TIMESTAMPDIFF(SECOND, UTC_TIMESTAMP(), NOW())")
+	columns := []string{"time_zone", "offset"}
+
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(columns).AddRow("UTC", 0))
+	c.Assert(checkSessionTimeZone(context.TODO(), db, time.UTC), check.IsNil)
+
+	// the same offset by another name only logs a warning
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(columns).AddRow("+00:00", 0))
+	c.Assert(checkSessionTimeZone(context.TODO(), db, time.UTC), check.IsNil)
+
+	mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(columns).AddRow("SYSTEM", 28800))
+	err = checkSessionTimeZone(context.TODO(), db, time.UTC)
+	c.Assert(err, check.ErrorMatches, ".*the time zone SYSTEM of the downstream sessions is 28800 seconds from UTC.*")
+
+	mock.ExpectQuery(query).WillReturnError(sql.ErrConnDone)
+	err = checkSessionTimeZone(context.TODO(), db, time.UTC)
+	c.Assert(err, check.ErrorMatches, ".*fail to query the time zone of the session.*")
+	c.Assert(mock.ExpectationsWereMet(), check.IsNil)
+}
@@ -18,11 +18,13 @@ import (
 	"database/sql"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 
 	dmysql "github.com/go-sql-driver/mysql"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/pkg/cyclic/mark"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/security"
@@ -31,7 +33,7 @@ import (
 )
 
 // SyncpointTableName is the name of table where all syncpoint maps sit
-const syncpointTableName string = "syncpoint_v1"
+const SyncpointTableName string = "syncpoint_v1"
 
 type mysqlSyncpointStore struct {
 	db *sql.DB
@@ -156,7 +158,7 @@ func (s *mysqlSyncpointStore) CreateSynctable(ctx context.Context) error {
 		}
 		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
 	}
-	_, err = tx.Exec("CREATE TABLE  IF NOT EXISTS " + syncpointTableName + " (cf varchar(255),primary_ts varchar(18),secondary_ts varchar(18),PRIMARY KEY ( `cf`, `primary_ts` ) )")
+	_, err = tx.Exec("CREATE TABLE  IF NOT EXISTS " + SyncpointTableName + " (cf varchar(255),primary_ts varchar(18),secondary_ts varchar(18),PRIMARY KEY ( `cf`, `primary_ts` ) )")
 	if err != nil {
 		err2 := tx.Rollback()
 		if err2 != nil {
@@ -185,7 +187,7 @@ func (s *mysqlSyncpointStore) SinkSyncpoint(ctx context.Context, id string, chec
 		}
 		return cerror.WrapError(cerror.ErrMySQLTxnError, err)
 	}
-	_, err = tx.Exec("insert ignore into "+mark.SchemaName+"."+syncpointTableName+"(cf, primary_ts, secondary_ts) VALUES (?,?,?)", id, checkpointTs, secondaryTs)
+	_, err = tx.Exec("insert ignore into "+mark.SchemaName+"."+SyncpointTableName+"(cf, primary_ts, secondary_ts) VALUES (?,?,?)", id, checkpointTs, secondaryTs)
 	if err != nil {
 		err2 := tx.Rollback()
 		if err2 != nil {
@@ -197,6 +199,41 @@ func (s *mysqlSyncpointStore) SinkSyncpoint(ctx context.Context, id string, chec
 	return cerror.WrapError(cerror.ErrMySQLTxnError, err)
 }
 
+func (s *mysqlSyncpointStore) CleanupSyncpoints(ctx context.Context, id string, beforeTs uint64) error {
+	// primary_ts is a varchar column, cast it to compare the ts numerically
+	_, err := s.db.ExecContext(ctx, "DELETE FROM "+mark.SchemaName+"."+SyncpointTableName+
+		" WHERE cf = ? AND CAST(primary_ts AS UNSIGNED) < ?", id, beforeTs)
+	return cerror.WrapError(cerror.ErrMySQLTxnError, err)
+}
+
+func (s *mysqlSyncpointStore) QuerySyncpoints(
+	ctx context.Context, id string, startTs, endTs uint64, limit int,
+) ([]model.SyncpointRecord, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT primary_ts, secondary_ts FROM "+mark.SchemaName+"."+SyncpointTableName+
+		" WHERE cf = ? AND CAST(primary_ts AS UNSIGNED) BETWEEN ? AND ?"+
+		" ORDER BY CAST(primary_ts AS UNSIGNED) DESC LIMIT ?", id, startTs, endTs, limit)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+	}
+	defer rows.Close()
+	records := make([]model.SyncpointRecord, 0)
+	for rows.Next() {
+		var primaryTs, secondaryTs string
+		if err := rows.Scan(&primaryTs, &secondaryTs); err != nil {
+			return nil, cerror.WrapError(cerror.ErrMySQLQueryError, err)
+		}
+		var record model.SyncpointRecord
+		if record.PrimaryTs, err = strconv.ParseUint(primaryTs, 10, 64); err != nil {
+			return nil, errors.Annotatef(err, "invalid primary ts %s", primaryTs)
+		}
+		if record.SecondaryTs, err = strconv.ParseUint(secondaryTs, 10, 64); err != nil {
+			return nil, errors.Annotatef(err, "invalid secondary ts %s", secondaryTs)
+		}
+		records = append(records, record)
+	}
+	return records, cerror.WrapError(cerror.ErrMySQLQueryError, rows.Err())
+}
+
 func (s *mysqlSyncpointStore) Close() error {
 	err := s.db.Close()
 	return cerror.WrapError(cerror.ErrMySQLConnectionError, err)
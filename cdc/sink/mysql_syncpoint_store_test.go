@@ -0,0 +1,73 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+type syncpointStoreSuite struct{}
+
+var _ = check.Suite(&syncpointStoreSuite{})
+
+func (s *syncpointStoreSuite) TestCleanupSyncpoints(c *check.C) {
+	defer testleak.AfterTest(c)()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	c.Assert(err, check.IsNil)
+	mock.ExpectExec("DELETE FROM tidb_cdc.syncpoint_v1 WHERE cf = ? AND CAST(primary_ts AS UNSIGNED) < ?").
+		WithArgs("test-cf", 100).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectClose()
+	store := &mysqlSyncpointStore{db: db}
+	c.Assert(store.CleanupSyncpoints(context.Background(), "test-cf", 100), check.IsNil)
+	c.Assert(store.Close(), check.IsNil)
+	c.Assert(mock.ExpectationsWereMet(), check.IsNil)
+}
+
+func (s *syncpointStoreSuite) TestQuerySyncpoints(c *check.C) {
+	defer testleak.AfterTest(c)()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	c.Assert(err, check.IsNil)
+	query := "SELECT primary_ts, secondary_ts FROM tidb_cdc.syncpoint_v1 " +
+		"WHERE cf = ? AND CAST(primary_ts AS UNSIGNED) BETWEEN ? AND ? " +
+		"ORDER BY CAST(primary_ts AS UNSIGNED) DESC LIMIT ?"
+	mock.ExpectQuery(query).
+		WithArgs("test-cf", 100, 300, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"primary_ts", "secondary_ts"}).
+			AddRow("300", "310").
+			AddRow("200", "210"))
+	mock.ExpectQuery(query).
+		WithArgs("test-cf", 0, 300, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"primary_ts", "secondary_ts"}).
+			AddRow("100", "invalid"))
+	mock.ExpectClose()
+	store := &mysqlSyncpointStore{db: db}
+
+	records, err := store.QuerySyncpoints(context.Background(), "test-cf", 100, 300, 10)
+	c.Assert(err, check.IsNil)
+	c.Assert(records, check.DeepEquals, []model.SyncpointRecord{
+		{PrimaryTs: 300, SecondaryTs: 310},
+		{PrimaryTs: 200, SecondaryTs: 210},
+	})
+
+	_, err = store.QuerySyncpoints(context.Background(), "test-cf", 0, 300, 10)
+	c.Assert(err, check.ErrorMatches, ".*invalid secondary ts invalid.*")
+	c.Assert(store.Close(), check.IsNil)
+	c.Assert(mock.ExpectationsWereMet(), check.IsNil)
+}
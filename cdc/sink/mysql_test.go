@@ -39,6 +39,7 @@ import (
 	"github.com/pingcap/tidb/infoschema"
 	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 type MySQLSinkSuite struct{}
@@ -103,6 +104,136 @@ func (s MySQLSinkSuite) TestPrepareDML(c *check.C) {
 	}
 }
 
+func (s MySQLSinkSuite) TestPrepareDMLWithRowIDKey(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ms := newMySQLSink4Test(ctx, c)
+	ms.params.enableOldValue = true
+	ms.params.safeMode = false
+	ms.params.batchReplaceEnabled = true
+	ms.noKeyTableStrategy = config.NoKeyTableStrategyRowID
+
+	cols := func(a int) []*model.Column {
+		return []*model.Column{{Name: "a", Type: mysql.TypeLong, Value: a}}
+	}
+	rows := []*model.RowChangedEvent{
+		{Table: &model.TableName{Schema: "test", Table: "t"}, RowID: 7, Columns: cols(1)},
+		{Table: &model.TableName{Schema: "test", Table: "t"}, RowID: 7, PreColumns: cols(1), Columns: cols(2)},
+		{Table: &model.TableName{Schema: "test", Table: "t"}, RowID: 7, PreColumns: cols(2)},
+	}
+	dmls := ms.prepareDMLs(rows, 0, 0)
+	c.Assert(dmls.sqls, check.DeepEquals, []string{
+		"INSERT INTO `test`.`t`(`a`,`_tidb_rowid`) VALUES (?,?)",
+		"UPDATE `test`.`t` SET `a`=? WHERE `_tidb_rowid`=? LIMIT 1;",
+		"DELETE FROM `test`.`t` WHERE `_tidb_rowid` = ? LIMIT 1;",
+	})
+	c.Assert(dmls.values, check.DeepEquals, [][]interface{}{
+		{1, int64(7)}, {2, int64(7)}, {int64(7)},
+	})
+	// the original rows are not modified
+	c.Assert(rows[1].PreColumns, check.HasLen, 1)
+	c.Assert(rows[1].Columns, check.HasLen, 1)
+
+	// the rows with keys are not changed
+	row := &model.RowChangedEvent{
+		Table: &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: 1, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag},
+		},
+	}
+	c.Assert(withRowIDKey(row), check.Equals, row)
+}
+
+func (s MySQLSinkSuite) TestNewMySQLSinkWithOffload(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Offload = &config.OffloadConfig{Threshold: 1024, Storage: "s3://bucket/prefix"}
+	sinkURI, err := url.Parse("mysql://127.0.0.1:4000")
+	c.Assert(err, check.IsNil)
+	_, err = newMySQLSink(ctx, "test", sinkURI, nil, cfg, map[string]string{})
+	c.Assert(err, check.ErrorMatches, ".*offloading the large column values is not supported by the MySQL sink.*")
+}
+
+func (s MySQLSinkSuite) TestPrepareDMLWithCharset(c *check.C) {
+	defer testleak.AfterTest(c)()
+	cols := []*model.Column{
+		{Name: "a", Type: mysql.TypeVarchar, Charset: "gbk", Value: []byte("中文"), Flag: model.HandleKeyFlag},
+		{Name: "b", Type: mysql.TypeVarString, Charset: "binary", Value: []byte("\xff")},
+		{Name: "c", Type: mysql.TypeVarchar, Value: []byte("c")},
+	}
+	query, args := prepareReplace("`test`.`t`", cols, true, false)
+	c.Assert(query, check.Equals, "REPLACE INTO `test`.`t`(`a`,`b`,`c`) VALUES (?,?,?);")
+	c.Assert(args, check.DeepEquals, []interface{}{"中文", []byte("\xff"), []byte("c")})
+	query, args = prepareDelete("`test`.`t`", cols, false)
+	c.Assert(query, check.Equals, "DELETE FROM `test`.`t` WHERE `a` = ? LIMIT 1;")
+	c.Assert(args, check.DeepEquals, []interface{}{"中文"})
+}
+
+func (s MySQLSinkSuite) TestPrepareDMLWithRegeneratedAutoID(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ms := newMySQLSink4Test(ctx, c)
+	ms.params.enableOldValue = true
+	ms.params.safeMode = false
+	ms.params.batchReplaceEnabled = true
+	ms.regenerateAutoIncrement = true
+
+	cols := func(id, uk, a int) []*model.Column {
+		return []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: id, Flag: model.AutoIncrementFlag},
+			{Name: "uk", Type: mysql.TypeLong, Value: uk, Flag: model.HandleKeyFlag | model.UniqueKeyFlag},
+			{Name: "a", Type: mysql.TypeLong, Value: a},
+		}
+	}
+	rows := []*model.RowChangedEvent{
+		{Table: &model.TableName{Schema: "test", Table: "t"}, Columns: cols(1, 10, 1)},
+		{Table: &model.TableName{Schema: "test", Table: "t"}, PreColumns: cols(1, 10, 1), Columns: cols(1, 10, 2)},
+		{Table: &model.TableName{Schema: "test", Table: "t"}, PreColumns: cols(1, 10, 2)},
+	}
+	dmls := ms.prepareDMLs(rows, 0, 0)
+	c.Assert(dmls.sqls, check.DeepEquals, []string{
+		"INSERT INTO `test`.`t`(`uk`,`a`) VALUES (?,?)",
+		"UPDATE `test`.`t` SET `uk`=?,`a`=? WHERE `uk`=? LIMIT 1;",
+		"DELETE FROM `test`.`t` WHERE `uk` = ? LIMIT 1;",
+	})
+	c.Assert(dmls.values, check.DeepEquals, [][]interface{}{
+		{10, 1}, {10, 2, 10}, {10},
+	})
+	// the original rows are not modified
+	c.Assert(rows[1].Columns[0], check.NotNil)
+
+	// the auto random columns are replicated as is unless regenerated
+	row := &model.RowChangedEvent{
+		Table: &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{Name: "id", Type: mysql.TypeLong, Value: 1, Flag: model.HandleKeyFlag | model.AutoRandomFlag},
+		},
+	}
+	kept, regenerated := withoutAutoIDValues(row, true, false)
+	c.Assert(kept, check.Equals, row)
+	c.Assert(regenerated, check.IsFalse)
+	kept, regenerated = withoutAutoIDValues(row, false, true)
+	c.Assert(kept.Columns, check.DeepEquals, []*model.Column{nil})
+	c.Assert(regenerated, check.IsTrue)
+
+	// the updates are not translated to DELETE + REPLACE in the safe mode,
+	// which would insert the rows with new ids
+	ms.params.safeMode = true
+	dmls = ms.prepareDMLs(rows, 0, 0)
+	c.Assert(dmls.sqls, check.DeepEquals, []string{
+		"REPLACE INTO `test`.`t`(`uk`,`a`) VALUES (?,?)",
+		"UPDATE `test`.`t` SET `uk`=?,`a`=? WHERE `uk`=? LIMIT 1;",
+		"DELETE FROM `test`.`t` WHERE `uk` = ? LIMIT 1;",
+	})
+	c.Assert(dmls.values, check.DeepEquals, [][]interface{}{
+		{10, 1}, {10, 2, 10}, {10},
+	})
+}
+
 func (s MySQLSinkSuite) TestPrepareUpdate(c *check.C) {
 	defer testleak.AfterTest(c)()
 	testCases := []struct {
@@ -911,6 +1042,75 @@ func (s MySQLSinkSuite) TestExecDMLRollbackErrRetryable(c *check.C) {
 	c.Assert(err, check.IsNil)
 }
 
+func (s MySQLSinkSuite) TestExecDMLTableMetrics(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	rows := []*model.RowChangedEvent{
+		{
+			Table: &model.TableName{Schema: "s1", Table: "t1", TableID: 1},
+			Columns: []*model.Column{
+				{Name: "a", Type: mysql.TypeLong, Flag: model.HandleKeyFlag | model.PrimaryKeyFlag, Value: 1},
+			},
+		},
+	}
+
+	dbIndex := 0
+	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		defer func() {
+			dbIndex++
+		}()
+		if dbIndex == 0 {
+			// test db
+			db, err := mockTestDB()
+			c.Assert(err, check.IsNil)
+			return db, nil
+		}
+		// normal db
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		c.Assert(err, check.IsNil)
+		mock.ExpectBegin()
+		mock.ExpectExec("REPLACE INTO `s1`.`t1`(`a`) VALUES (?)").
+			WithArgs(1).
+			WillReturnError(&dmysql.MySQLError{Number: mysql.ErrDupEntry})
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		mock.ExpectExec("REPLACE INTO `s1`.`t1`(`a`) VALUES (?)").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectClose()
+		return db, nil
+	}
+	backupGetDBConn := GetDBConnImpl
+	GetDBConnImpl = mockGetDBConn
+	defer func() {
+		GetDBConnImpl = backupGetDBConn
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changefeed := "test-changefeed-table-metrics"
+	sinkURI, err := url.Parse("mysql://127.0.0.1:4000/?time-zone=UTC&worker-count=1")
+	c.Assert(err, check.IsNil)
+	rc := config.GetDefaultReplicaConfig()
+	rc.Features = map[string]bool{config.FeatureTableSinkMetrics: true}
+	f, err := filter.NewFilter(rc)
+	c.Assert(err, check.IsNil)
+	sink, err := newMySQLSink(ctx, changefeed, sinkURI, f, rc, map[string]string{})
+	c.Assert(err, check.IsNil)
+
+	err = sink.(*mysqlSink).execDMLs(ctx, rows, 1 /* replicaID */, 1 /* bucket */)
+	c.Assert(err, check.IsNil)
+	table := "`s1`.`t1`"
+	c.Assert(testutil.ToFloat64(tableRetryCounter.WithLabelValues("", changefeed, table)), check.Equals, float64(1))
+	c.Assert(testutil.ToFloat64(tableConflictCounter.WithLabelValues("", changefeed, table)), check.Equals, float64(1))
+	c.Assert(testutil.CollectAndCount(tableFlushDurationHistogram), check.Equals, 1)
+
+	err = sink.Close(ctx)
+	c.Assert(err, check.IsNil)
+	c.Assert(testutil.CollectAndCount(tableFlushDurationHistogram), check.Equals, 0)
+}
+
 func (s MySQLSinkSuite) TestNewMySQLSinkExecDDL(c *check.C) {
 	defer testleak.AfterTest(c)()
 
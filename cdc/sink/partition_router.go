@@ -0,0 +1,458 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/quotes"
+	tfilter "github.com/pingcap/tidb-tools/pkg/table-filter"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"go.uber.org/zap"
+)
+
+type partitionRouteRule struct {
+	filter tfilter.Filter
+	*config.PartitionRouteRule
+}
+
+// partitionRouter writes the partitions of the partitioned tables to the
+// downstream non-partitioned tables in the merge partition mode, and converts
+// the DDLs of the partitioned tables to the statements of the downstream
+// tables.
+type partitionRouter struct {
+	rules []*partitionRouteRule
+	// targets caches the names of the downstream tables of the partitions.
+	targets sync.Map
+}
+
+// newPartitionRouter creates a partitionRouter, it returns nil if the
+// partitions are not merged.
+func newPartitionRouter(cfg *config.ReplicaConfig) (*partitionRouter, error) {
+	if !cfg.Partition.IsMergeMode() {
+		return nil, nil
+	}
+	rules := make([]*partitionRouteRule, 0, len(cfg.Partition.Routes))
+	for _, ruleCfg := range cfg.Partition.Routes {
+		f, err := tfilter.Parse(ruleCfg.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+		}
+		if !cfg.CaseSensitive {
+			f = tfilter.CaseInsensitive(f)
+		}
+		rules = append(rules, &partitionRouteRule{filter: f, PartitionRouteRule: ruleCfg})
+	}
+	return &partitionRouter{rules: rules}, nil
+}
+
+func (r *partitionRouter) matchRule(schema, table string) *partitionRouteRule {
+	for _, rule := range r.rules {
+		if rule.filter.MatchTable(schema, table) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// quoteTable returns the quoted downstream table of the row changed events
+// of the table.
+func (r *partitionRouter) quoteTable(table *model.TableName) string {
+	return quotes.QuoteSchema(table.Schema, r.targetTable(table))
+}
+
+// targetTable returns the name of the downstream table of the row changed
+// events of the table.
+func (r *partitionRouter) targetTable(table *model.TableName) string {
+	if r == nil || table.Partition == "" {
+		return table.Table
+	}
+	if target, ok := r.targets.Load(*table); ok {
+		return target.(string)
+	}
+	target := table.Table
+	if rule := r.matchRule(table.Schema, table.Table); rule != nil {
+		target = rule.Target(table.Schema, table.Table, table.Partition)
+	}
+	r.targets.Store(*table, target)
+	return target
+}
+
+// rewrite converts the DDL of a partitioned table to the DDLs or the DMLs of
+// the downstream tables, which are executed in order. The DDL is returned as
+// is if it's not the DDL of a partitioned table, and nothing is returned if
+// it changes nothing of the downstream tables.
+func (r *partitionRouter) rewrite(ddl *model.DDLEvent) ([]*model.DDLEvent, error) {
+	pi := ddl.PartitionInfo
+	if pi == nil {
+		pi = ddl.PrePartitionInfo
+	}
+	if r == nil || ddl.TableInfo == nil || pi == nil {
+		return []*model.DDLEvent{ddl}, nil
+	}
+	rule := r.matchRule(ddl.TableInfo.Schema, ddl.TableInfo.Table)
+
+	var queries []string
+	var err error
+	switch ddl.Type {
+	case timodel.ActionAddTablePartition:
+		queries, err = r.addPartitions(ddl, rule)
+	case timodel.ActionDropTablePartition, timodel.ActionTruncateTablePartition:
+		queries, err = r.removePartitions(ddl, rule)
+	case timodel.ActionExchangeTablePartition:
+		queries, err = r.exchangePartition(ddl, rule)
+	case timodel.ActionAlterTablePartitionAttributes, timodel.ActionAlterTablePartitionPolicy:
+		// the attributes of the partitions are meaningless to the downstream tables.
+	default:
+		queries, err = r.rewriteTableDDL(ddl, rule, pi)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ddls := make([]*model.DDLEvent, 0, len(queries))
+	for _, query := range queries {
+		rewritten := *ddl
+		rewritten.Query = query
+		ddls = append(ddls, &rewritten)
+	}
+	log.Info("rewrite the DDL of partitioned table according to the partition mode",
+		zap.String("query", ddl.Query), zap.Strings("rewritten", queries))
+	return ddls, nil
+}
+
+// rewriteTableDDL removes the partitions from CREATE TABLE, and executes the
+// DDL on the table of each partition if the partitions are routed.
+func (r *partitionRouter) rewriteTableDDL(
+	ddl *model.DDLEvent, rule *partitionRouteRule, pi *timodel.PartitionInfo,
+) ([]string, error) {
+	stmt, err := parser.New().ParseOneStmt(ddl.Query, "", "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	create, isCreate := stmt.(*ast.CreateTableStmt)
+	if isCreate {
+		create.Partition = nil
+	}
+	if rule == nil {
+		if !isCreate {
+			return []string{ddl.Query}, nil
+		}
+		query, err := restoreStmt(stmt)
+		if err != nil {
+			return nil, err
+		}
+		return []string{query}, nil
+	}
+
+	// the names of the table before and after the DDL, e.g. RENAME TABLE, are
+	// both replaced by the names of the tables of the partitions.
+	names := []*model.SimpleTableInfo{ddl.TableInfo}
+	if ddl.PreTableInfo != nil {
+		names = append(names, ddl.PreTableInfo)
+	}
+	queries := make([]string, 0, len(pi.Definitions))
+	for _, def := range pi.Definitions {
+		v := &tableRenameVisitor{defaultSchema: ddl.TableInfo.Schema, rename: func(schema, table string) string {
+			for _, name := range names {
+				if strings.EqualFold(schema, name.Schema) && strings.EqualFold(table, name.Table) {
+					return rule.Target(schema, table, def.Name.L)
+				}
+			}
+			return table
+		}}
+		stmt.Accept(v)
+		query, err := restoreStmt(stmt)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+		// restore the names for the next partition.
+		v.restore()
+	}
+	return queries, nil
+}
+
+// addPartitions creates the tables of the added partitions like the table of
+// an existing partition if the partitions are routed.
+func (r *partitionRouter) addPartitions(ddl *model.DDLEvent, rule *partitionRouteRule) ([]string, error) {
+	if rule == nil {
+		return nil, nil
+	}
+	if ddl.PrePartitionInfo == nil || len(ddl.PrePartitionInfo.Definitions) == 0 || ddl.PartitionInfo == nil {
+		return nil, cerror.ErrPartitionDDLUnsupported.GenWithStackByArgs(ddl.Query, "the partitions before the DDL are unknown")
+	}
+	schema, table := ddl.TableInfo.Schema, ddl.TableInfo.Table
+	like := quotes.QuoteSchema(schema, rule.Target(schema, table, ddl.PrePartitionInfo.Definitions[0].Name.L))
+	var queries []string
+	for _, def := range diffPartitions(ddl.PartitionInfo, ddl.PrePartitionInfo) {
+		queries = append(queries, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s LIKE %s",
+			quotes.QuoteSchema(schema, rule.Target(schema, table, def.Name.L)), like))
+	}
+	return queries, nil
+}
+
+// removePartitions deletes the rows of the dropped or truncated partitions,
+// or drops or truncates the tables of them if the partitions are routed.
+func (r *partitionRouter) removePartitions(ddl *model.DDLEvent, rule *partitionRouteRule) ([]string, error) {
+	if ddl.PrePartitionInfo == nil {
+		return nil, cerror.ErrPartitionDDLUnsupported.GenWithStackByArgs(ddl.Query, "the partitions before the DDL are unknown")
+	}
+	schema, table := ddl.TableInfo.Schema, ddl.TableInfo.Table
+	// the IDs of the truncated partitions are changed, so they are removed
+	// partitions as the dropped ones.
+	removed := diffPartitions(ddl.PrePartitionInfo, ddl.PartitionInfo)
+	queries := make([]string, 0, len(removed))
+	for _, def := range removed {
+		if rule != nil {
+			target := quotes.QuoteSchema(schema, rule.Target(schema, table, def.Name.L))
+			if ddl.Type == timodel.ActionDropTablePartition {
+				queries = append(queries, "DROP TABLE IF EXISTS "+target)
+			} else {
+				queries = append(queries, "TRUNCATE TABLE "+target)
+			}
+			continue
+		}
+		cond, err := partitionCondition(ddl.PrePartitionInfo, &def)
+		if err != nil {
+			return nil, cerror.ErrPartitionDDLUnsupported.GenWithStackByArgs(ddl.Query, err.Error())
+		}
+		queries = append(queries, fmt.Sprintf("DELETE FROM %s WHERE %s", quotes.QuoteSchema(schema, table), cond))
+	}
+	return queries, nil
+}
+
+// exchangePartition swaps the tables by RENAME TABLE if the partitions are
+// routed. The rows of a merged partition and the table can't be swapped
+// atomically, a retry after a partial swap loses the rows, so EXCHANGE
+// PARTITION is refused when the partitions are merged.
+func (r *partitionRouter) exchangePartition(ddl *model.DDLEvent, rule *partitionRouteRule) ([]string, error) {
+	stmt, err := parser.New().ParseOneStmt(ddl.Query, "", "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	alter, ok := stmt.(*ast.AlterTableStmt)
+	if !ok || len(alter.Specs) != 1 || alter.Specs[0].Tp != ast.AlterTableExchangePartition ||
+		len(alter.Specs[0].PartitionNames) != 1 {
+		return nil, cerror.ErrPartitionDDLUnsupported.GenWithStackByArgs(ddl.Query, "unexpected statement")
+	}
+	pi := ddl.PrePartitionInfo
+	if pi == nil {
+		pi = ddl.PartitionInfo
+	}
+	var def *timodel.PartitionDefinition
+	for i := range pi.Definitions {
+		if pi.Definitions[i].Name.L == alter.Specs[0].PartitionNames[0].L {
+			def = &pi.Definitions[i]
+		}
+	}
+	if def == nil {
+		return nil, cerror.ErrPartitionDDLUnsupported.GenWithStackByArgs(ddl.Query, "the partition is unknown")
+	}
+
+	qualify := func(name *ast.TableName) (string, string) {
+		if name.Schema.O == "" {
+			return ddl.TableInfo.Schema, name.Name.O
+		}
+		return name.Schema.O, name.Name.O
+	}
+	schema, table := qualify(alter.Table)
+	ntSchema, ntTable := qualify(alter.Specs[0].NewTable)
+	nt := quotes.QuoteSchema(ntSchema, ntTable)
+	if rule != nil {
+		target := rule.Target(schema, table, def.Name.L)
+		staging := quotes.QuoteSchema(schema, "_cdc_exchange_"+target)
+		return []string{fmt.Sprintf("RENAME TABLE %s TO %s, %s TO %s, %s TO %s",
+			quotes.QuoteSchema(schema, target), staging, nt, quotes.QuoteSchema(schema, target), staging, nt)}, nil
+	}
+
+	return nil, cerror.ErrPartitionDDLUnsupported.GenWithStackByArgs(ddl.Query,
+		"the rows of the partition and the table can't be swapped atomically when the partitions are merged, "+
+			"route the partitions to separate tables instead")
+}
+
+// diffPartitions returns the partitions in a but not in b by their IDs.
+func diffPartitions(a, b *timodel.PartitionInfo) []timodel.PartitionDefinition {
+	ids := make(map[int64]struct{})
+	if b != nil {
+		for _, def := range b.Definitions {
+			ids[def.ID] = struct{}{}
+		}
+	}
+	var diff []timodel.PartitionDefinition
+	for _, def := range a.Definitions {
+		if _, ok := ids[def.ID]; !ok {
+			diff = append(diff, def)
+		}
+	}
+	return diff
+}
+
+// partitionCondition returns the WHERE condition of the rows in the partition.
+func partitionCondition(pi *timodel.PartitionInfo, def *timodel.PartitionDefinition) (string, error) {
+	expr := pi.Expr
+	if len(pi.Columns) > 0 {
+		cols := make([]string, 0, len(pi.Columns))
+		for _, col := range pi.Columns {
+			cols = append(cols, quotes.QuoteName(col.O))
+		}
+		expr = strings.Join(cols, ", ")
+		if len(cols) > 1 {
+			expr = "(" + expr + ")"
+		}
+	}
+	if expr == "" {
+		return "", errors.New("the partition expression is unknown")
+	}
+	multiColumns := len(pi.Columns) > 1
+
+	idx := -1
+	for i := range pi.Definitions {
+		if pi.Definitions[i].ID == def.ID {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return "", errors.Errorf("partition %s is unknown", def.Name.O)
+	}
+
+	switch pi.Type {
+	case timodel.PartitionTypeRange:
+		var conds []string
+		bound := func(values []string) (string, bool, error) {
+			for _, v := range values {
+				if strings.EqualFold(v, "MAXVALUE") {
+					if multiColumns {
+						return "", false, errors.New("MAXVALUE of multiple columns is not supported")
+					}
+					return "", false, nil
+				}
+			}
+			if multiColumns {
+				return "(" + strings.Join(values, ", ") + ")", true, nil
+			}
+			return values[0], true, nil
+		}
+		if idx > 0 {
+			lower, ok, err := bound(pi.Definitions[idx-1].LessThan)
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				conds = append(conds, fmt.Sprintf("%s >= %s", expr, lower))
+			}
+		}
+		upper, ok, err := bound(def.LessThan)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			cond := fmt.Sprintf("%s < %s", expr, upper)
+			// NULL is less than any value, so it's in the first partition.
+			if idx == 0 && !multiColumns {
+				cond = fmt.Sprintf("(%s OR %s IS NULL)", cond, expr)
+			}
+			conds = append(conds, cond)
+		}
+		if len(conds) == 0 {
+			return "1 = 1", nil
+		}
+		return strings.Join(conds, " AND "), nil
+	case timodel.PartitionTypeList:
+		var values, conds []string
+		for _, in := range def.InValues {
+			if len(in) == 1 && strings.EqualFold(in[0], "NULL") {
+				conds = append(conds, expr+" IS NULL")
+				continue
+			}
+			if multiColumns {
+				values = append(values, "("+strings.Join(in, ", ")+")")
+			} else {
+				values = append(values, strings.Join(in, ", "))
+			}
+		}
+		if len(values) > 0 {
+			conds = append(conds, fmt.Sprintf("%s IN (%s)", expr, strings.Join(values, ", ")))
+		}
+		if len(conds) == 0 {
+			return "", errors.Errorf("partition %s has no value", def.Name.O)
+		}
+		return "(" + strings.Join(conds, " OR ") + ")", nil
+	case timodel.PartitionTypeHash:
+		// TiDB locates the rows by the absolute value of the modulo, and the
+		// rows of NULL are in the first partition.
+		cond := fmt.Sprintf("ABS(MOD(%s, %d)) = %d", expr, len(pi.Definitions), idx)
+		if idx == 0 {
+			cond = fmt.Sprintf("(%s OR %s IS NULL)", cond, expr)
+		}
+		return cond, nil
+	}
+	return "", errors.Errorf("%s partition is not supported", pi.Type)
+}
+
+func restoreStmt(stmt ast.StmtNode) (string, error) {
+	var sb strings.Builder
+	flags := format.DefaultRestoreFlags | format.RestoreTiDBSpecialComment
+	if err := stmt.Restore(format.NewRestoreCtx(flags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	return sb.String(), nil
+}
+
+// tableRenameVisitor renames the tables in the statement, the renamed tables
+// can be restored to the original names.
+type tableRenameVisitor struct {
+	defaultSchema string
+	rename        func(schema, table string) string
+
+	renamed map[*ast.TableName]timodel.CIStr
+}
+
+func (v *tableRenameVisitor) Enter(in ast.Node) (ast.Node, bool) {
+	if t, ok := in.(*ast.TableName); ok {
+		schema := t.Schema.O
+		if schema == "" {
+			schema = v.defaultSchema
+		}
+		if name := v.rename(schema, t.Name.O); name != t.Name.O {
+			if v.renamed == nil {
+				v.renamed = make(map[*ast.TableName]timodel.CIStr)
+			}
+			v.renamed[t] = t.Name
+			t.Name = timodel.NewCIStr(name)
+		}
+		return in, true
+	}
+	return in, false
+}
+
+func (v *tableRenameVisitor) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (v *tableRenameVisitor) restore() {
+	for t, name := range v.renamed {
+		t.Name = name
+	}
+}
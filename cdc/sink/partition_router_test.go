@@ -0,0 +1,259 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"fmt"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+	timodel "github.com/pingcap/tidb/parser/model"
+)
+
+type partitionRouterSuite struct{}
+
+var _ = check.Suite(&partitionRouterSuite{})
+
+func newRangePartitions(ids ...int64) *timodel.PartitionInfo {
+	pi := &timodel.PartitionInfo{Type: timodel.PartitionTypeRange, Expr: "`id`", Enable: true}
+	bounds := map[int64]string{1: "10", 2: "20", 3: "MAXVALUE"}
+	for _, id := range ids {
+		pi.Definitions = append(pi.Definitions, timodel.PartitionDefinition{
+			ID:       id,
+			Name:     timodel.NewCIStr(fmt.Sprintf("p%d", id)),
+			LessThan: []string{bounds[id]},
+		})
+	}
+	return pi
+}
+
+func (s *partitionRouterSuite) TestQuoteTable(c *check.C) {
+	defer testleak.AfterTest(c)()
+	cfg := config.GetDefaultReplicaConfig()
+	r, err := newPartitionRouter(cfg)
+	c.Assert(err, check.IsNil)
+	c.Assert(r, check.IsNil)
+	table := &model.TableName{Schema: "test", Table: "t1", TableID: 1, IsPartition: true, Partition: "p1"}
+	c.Assert(r.quoteTable(table), check.Equals, "`test`.`t1`")
+
+	cfg.Partition = &config.PartitionConfig{
+		Mode:   config.PartitionModeMerge,
+		Routes: []*config.PartitionRouteRule{{Matcher: []string{"test.routed*"}, TargetTable: "{table}_{partition}"}},
+	}
+	r, err = newPartitionRouter(cfg)
+	c.Assert(err, check.IsNil)
+	c.Assert(r.quoteTable(table), check.Equals, "`test`.`t1`")
+	table = &model.TableName{Schema: "test", Table: "routed", TableID: 1, IsPartition: true, Partition: "p1"}
+	c.Assert(r.quoteTable(table), check.Equals, "`test`.`routed_p1`")
+	c.Assert(r.quoteTable(table), check.Equals, "`test`.`routed_p1`")
+	table = &model.TableName{Schema: "test", Table: "routed", TableID: 4}
+	c.Assert(r.quoteTable(table), check.Equals, "`test`.`routed`")
+}
+
+func (s *partitionRouterSuite) TestRewritePartitionDDL(c *check.C) {
+	defer testleak.AfterTest(c)()
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Partition = &config.PartitionConfig{
+		Mode:   config.PartitionModeMerge,
+		Routes: []*config.PartitionRouteRule{{Matcher: []string{"test.routed*"}, TargetTable: "{table}_{partition}"}},
+	}
+	r, err := newPartitionRouter(cfg)
+	c.Assert(err, check.IsNil)
+
+	cases := []struct {
+		query  string
+		tp     timodel.ActionType
+		table  string
+		pre    *timodel.PartitionInfo
+		post   *timodel.PartitionInfo
+		expect []string
+	}{{
+		query:  "create table t1(id int primary key)",
+		tp:     timodel.ActionCreateTable,
+		table:  "t1",
+		expect: []string{"create table t1(id int primary key)"},
+	}, {
+		query: "create table t1(id int primary key) partition by range(id) " +
+			"(partition p1 values less than (10), partition p2 values less than (20))",
+		tp:     timodel.ActionCreateTable,
+		table:  "t1",
+		post:   newRangePartitions(1, 2),
+		expect: []string{"CREATE TABLE `t1` (`id` INT PRIMARY KEY)"},
+	}, {
+		query: "create table routed(id int primary key) partition by range(id) " +
+			"(partition p1 values less than (10), partition p2 values less than (20))",
+		tp:    timodel.ActionCreateTable,
+		table: "routed",
+		post:  newRangePartitions(1, 2),
+		expect: []string{
+			"CREATE TABLE `routed_p1` (`id` INT PRIMARY KEY)",
+			"CREATE TABLE `routed_p2` (`id` INT PRIMARY KEY)",
+		},
+	}, {
+		query: "alter table test.routed add column c1 int",
+		tp:    timodel.ActionAddColumn,
+		table: "routed",
+		pre:   newRangePartitions(1, 2),
+		post:  newRangePartitions(1, 2),
+		expect: []string{
+			"ALTER TABLE `test`.`routed_p1` ADD COLUMN `c1` INT",
+			"ALTER TABLE `test`.`routed_p2` ADD COLUMN `c1` INT",
+		},
+	}, {
+		query:  "alter table t1 add column c1 int",
+		tp:     timodel.ActionAddColumn,
+		table:  "t1",
+		pre:    newRangePartitions(1, 2),
+		post:   newRangePartitions(1, 2),
+		expect: []string{"alter table t1 add column c1 int"},
+	}, {
+		query:  "alter table t1 add partition (partition p3 values less than (maxvalue))",
+		tp:     timodel.ActionAddTablePartition,
+		table:  "t1",
+		pre:    newRangePartitions(1, 2),
+		post:   newRangePartitions(1, 2, 3),
+		expect: nil,
+	}, {
+		query:  "alter table routed add partition (partition p3 values less than (maxvalue))",
+		tp:     timodel.ActionAddTablePartition,
+		table:  "routed",
+		pre:    newRangePartitions(1, 2),
+		post:   newRangePartitions(1, 2, 3),
+		expect: []string{"CREATE TABLE IF NOT EXISTS `test`.`routed_p3` LIKE `test`.`routed_p1`"},
+	}, {
+		query:  "alter table t1 drop partition p2",
+		tp:     timodel.ActionDropTablePartition,
+		table:  "t1",
+		pre:    newRangePartitions(1, 2, 3),
+		post:   newRangePartitions(1, 3),
+		expect: []string{"DELETE FROM `test`.`t1` WHERE `id` >= 10 AND `id` < 20"},
+	}, {
+		query:  "alter table routed drop partition p2",
+		tp:     timodel.ActionDropTablePartition,
+		table:  "routed",
+		pre:    newRangePartitions(1, 2, 3),
+		post:   newRangePartitions(1, 3),
+		expect: []string{"DROP TABLE IF EXISTS `test`.`routed_p2`"},
+	}, {
+		query: "alter table t1 truncate partition p1, p2",
+		tp:    timodel.ActionTruncateTablePartition,
+		table: "t1",
+		pre:   newRangePartitions(1, 2),
+		post:  newRangePartitions(),
+		expect: []string{
+			"DELETE FROM `test`.`t1` WHERE (`id` < 10 OR `id` IS NULL)",
+			"DELETE FROM `test`.`t1` WHERE `id` >= 10 AND `id` < 20",
+		},
+	}, {
+		query:  "alter table routed truncate partition p1",
+		tp:     timodel.ActionTruncateTablePartition,
+		table:  "routed",
+		pre:    newRangePartitions(1, 2),
+		post:   newRangePartitions(2),
+		expect: []string{"TRUNCATE TABLE `test`.`routed_p1`"},
+	}, {
+		query: "alter table routed exchange partition p2 with table other.nt",
+		tp:    timodel.ActionExchangeTablePartition,
+		table: "routed",
+		pre:   newRangePartitions(1, 2),
+		post:  newRangePartitions(1, 2),
+		expect: []string{"RENAME TABLE `test`.`routed_p2` TO `test`.`_cdc_exchange_routed_p2`, " +
+			"`other`.`nt` TO `test`.`routed_p2`, `test`.`_cdc_exchange_routed_p2` TO `other`.`nt`"},
+	}}
+	for _, tc := range cases {
+		ddl := &model.DDLEvent{
+			Query:            tc.query,
+			Type:             tc.tp,
+			TableInfo:        &model.SimpleTableInfo{Schema: "test", Table: tc.table},
+			PartitionInfo:    tc.post,
+			PrePartitionInfo: tc.pre,
+		}
+		ddls, err := r.rewrite(ddl)
+		c.Assert(err, check.IsNil)
+		var queries []string
+		for _, d := range ddls {
+			queries = append(queries, d.Query)
+		}
+		c.Assert(queries, check.DeepEquals, tc.expect, check.Commentf("query: %s", tc.query))
+	}
+
+	// the rows of key partitions can't be deleted by a condition
+	pi := newRangePartitions(1, 2)
+	pi.Type = timodel.PartitionTypeKey
+	_, err = r.rewrite(&model.DDLEvent{
+		Query:            "alter table t1 truncate partition p1",
+		Type:             timodel.ActionTruncateTablePartition,
+		TableInfo:        &model.SimpleTableInfo{Schema: "test", Table: "t1"},
+		PrePartitionInfo: pi,
+	})
+	c.Assert(cerror.ErrPartitionDDLUnsupported.Equal(err), check.IsTrue)
+
+	// the partition and the table can't be swapped atomically if the partitions are merged
+	_, err = r.rewrite(&model.DDLEvent{
+		Query:            "alter table t1 exchange partition p2 with table nt",
+		Type:             timodel.ActionExchangeTablePartition,
+		TableInfo:        &model.SimpleTableInfo{Schema: "test", Table: "t1"},
+		PartitionInfo:    newRangePartitions(1, 2),
+		PrePartitionInfo: newRangePartitions(1, 2),
+	})
+	c.Assert(cerror.ErrPartitionDDLUnsupported.Equal(err), check.IsTrue)
+}
+
+func (s *partitionRouterSuite) TestPartitionCondition(c *check.C) {
+	defer testleak.AfterTest(c)()
+	pi := newRangePartitions(1, 2, 3)
+	cond, err := partitionCondition(pi, &pi.Definitions[2])
+	c.Assert(err, check.IsNil)
+	c.Assert(cond, check.Equals, "`id` >= 20")
+
+	pi = &timodel.PartitionInfo{
+		Type:    timodel.PartitionTypeList,
+		Columns: []timodel.CIStr{timodel.NewCIStr("a"), timodel.NewCIStr("b")},
+		Definitions: []timodel.PartitionDefinition{
+			{ID: 1, Name: timodel.NewCIStr("p0"), InValues: [][]string{{"1", "'x'"}, {"2", "'y'"}}},
+		},
+	}
+	cond, err = partitionCondition(pi, &pi.Definitions[0])
+	c.Assert(err, check.IsNil)
+	c.Assert(cond, check.Equals, "((`a`, `b`) IN ((1, 'x'), (2, 'y')))")
+
+	pi = &timodel.PartitionInfo{
+		Type: timodel.PartitionTypeList,
+		Expr: "`a`",
+		Definitions: []timodel.PartitionDefinition{
+			{ID: 1, Name: timodel.NewCIStr("p0"), InValues: [][]string{{"1"}, {"NULL"}, {"3"}}},
+		},
+	}
+	cond, err = partitionCondition(pi, &pi.Definitions[0])
+	c.Assert(err, check.IsNil)
+	c.Assert(cond, check.Equals, "(`a` IS NULL OR `a` IN (1, 3))")
+
+	pi = &timodel.PartitionInfo{
+		Type: timodel.PartitionTypeHash,
+		Expr: "YEAR(`d`)",
+		Definitions: []timodel.PartitionDefinition{
+			{ID: 1, Name: timodel.NewCIStr("p0")},
+			{ID: 2, Name: timodel.NewCIStr("p1")},
+		},
+	}
+	cond, err = partitionCondition(pi, &pi.Definitions[0])
+	c.Assert(err, check.IsNil)
+	c.Assert(cond, check.Equals, "(ABS(MOD(YEAR(`d`), 2)) = 0 OR YEAR(`d`) IS NULL)")
+	cond, err = partitionCondition(pi, &pi.Definitions[1])
+	c.Assert(err, check.IsNil)
+	c.Assert(cond, check.Equals, "ABS(MOD(YEAR(`d`), 2)) = 1")
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/sink/codec"
+	"github.com/pingcap/ticdc/pkg/compression"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/notify"
@@ -616,21 +617,27 @@ func newSaramaConfig(ctx context.Context, c *Config) (*sarama.Config, error) {
 	config.Producer.Retry.Max = 600
 	config.Producer.Retry.Backoff = 500 * time.Millisecond
 
-	switch strings.ToLower(strings.TrimSpace(c.Compression)) {
-	case "none":
-		config.Producer.Compression = sarama.CompressionNone
-	case "gzip":
+	// the compression is in the format of "name[:level]", see pkg/compression
+	compressionCodec, err := compression.New(c.Compression)
+	if err != nil {
+		log.Warn("Unsupported compression algorithm", zap.String("compression", c.Compression), zap.Error(err))
+		compressionCodec, err = compression.New(compression.None)
+	}
+	switch compressionCodec.Name() {
+	case compression.Gzip:
 		config.Producer.Compression = sarama.CompressionGZIP
-	case "snappy":
+	case compression.Snappy:
 		config.Producer.Compression = sarama.CompressionSnappy
-	case "lz4":
+	case compression.LZ4:
 		config.Producer.Compression = sarama.CompressionLZ4
-	case "zstd":
+	case compression.ZSTD:
 		config.Producer.Compression = sarama.CompressionZSTD
 	default:
-		log.Warn("Unsupported compression algorithm", zap.String("compression", c.Compression))
 		config.Producer.Compression = sarama.CompressionNone
 	}
+	if compressionCodec.Level() != compression.DefaultLevel {
+		config.Producer.CompressionLevel = compressionCodec.Level()
+	}
 
 	// Time out in one minute(120 * 500ms).
 	config.Admin.Retry.Max = 120
@@ -301,20 +301,26 @@ func (s *kafkaSuite) TestNewSaramaConfig(c *check.C) {
 	compressionCases := []struct {
 		algorithm string
 		expected  sarama.CompressionCodec
+		level     int
 	}{
-		{"none", sarama.CompressionNone},
-		{"gzip", sarama.CompressionGZIP},
-		{"snappy", sarama.CompressionSnappy},
-		{"lz4", sarama.CompressionLZ4},
-		{"zstd", sarama.CompressionZSTD},
-		{"others", sarama.CompressionNone},
+		{"none", sarama.CompressionNone, sarama.CompressionLevelDefault},
+		{"gzip", sarama.CompressionGZIP, sarama.CompressionLevelDefault},
+		{"gzip:9", sarama.CompressionGZIP, 9},
+		{"snappy", sarama.CompressionSnappy, sarama.CompressionLevelDefault},
+		{"lz4", sarama.CompressionLZ4, sarama.CompressionLevelDefault},
+		{"zstd", sarama.CompressionZSTD, sarama.CompressionLevelDefault},
+		{"zstd:3", sarama.CompressionZSTD, 3},
+		{"zstd:100", sarama.CompressionNone, sarama.CompressionLevelDefault},
+		{"others", sarama.CompressionNone, sarama.CompressionLevelDefault},
 	}
 	for _, cc := range compressionCases {
 		config.Compression = cc.algorithm
 		cfg, err := newSaramaConfigImpl(ctx, config)
 		c.Assert(err, check.IsNil)
 		c.Assert(cfg.Producer.Compression, check.Equals, cc.expected)
+		c.Assert(cfg.Producer.CompressionLevel, check.Equals, cc.level)
 	}
+	config.Compression = "none"
 
 	config.Credential = &security.Credential{
 		CAPath: "/invalid/ca/path",
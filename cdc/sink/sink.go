@@ -23,12 +23,14 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/util"
 )
 
 // Sink options keys
 const (
 	OptChangefeedID = "_changefeed_id"
 	OptCaptureAddr  = "_capture_addr"
+	OptClusterID    = "_cluster_id"
 )
 
 // Sink is an abstraction for anything that a changefeed may emit into.
@@ -113,12 +115,27 @@ func New(ctx context.Context, changefeedID model.ChangeFeedID, sinkURIStr string
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
 	}
+	// the codecs and the sessions of the MySQL sink use the time zone of the
+	// changefeed, which renders the TIMESTAMP values in the events.
+	ctx, err = util.PutChangefeedTimezoneInCtx(ctx, config)
+	if err != nil {
+		return nil, err
+	}
 	if newSink, ok := sinkIniterMap[strings.ToLower(sinkURI.Scheme)]; ok {
 		return newSink(ctx, changefeedID, sinkURI, filter, config, opts, errCh)
 	}
 	return nil, cerror.ErrSinkURIInvalid.GenWithStack("the sink scheme (%s) is not supported", sinkURI.Scheme)
 }
 
+// IsMQScheme returns whether the scheme of the sink-uri is one of the MQ sinks.
+func IsMQScheme(scheme string) bool {
+	switch strings.ToLower(scheme) {
+	case "kafka", "kafka+ssl", "pulsar", "pulsar+ssl":
+		return true
+	}
+	return false
+}
+
 // Validate sink if given valid parameters.
 func Validate(ctx context.Context, sinkURI string, cfg *config.ReplicaConfig, opts map[string]string) error {
 	sinkFilter, err := filter.NewFilter(cfg)
@@ -144,3 +161,8 @@ func Validate(ctx context.Context, sinkURI string, cfg *config.ReplicaConfig, op
 	}
 	return nil
 }
+
+// hotKeySource returns the source of the hot keys of a component of a changefeed.
+func hotKeySource(changefeedID model.ChangeFeedID, component string) string {
+	return "cdc/" + changefeedID + "/" + component
+}
@@ -42,3 +42,12 @@ func TestValidateSink(t *testing.T) {
 	err = Validate(ctx, sinkURI, replicateConfig, opts)
 	require.Nil(t, err)
 }
+
+func TestIsMQScheme(t *testing.T) {
+	for _, scheme := range []string{"kafka", "Kafka+SSL", "pulsar", "pulsar+ssl"} {
+		require.True(t, IsMQScheme(scheme))
+	}
+	for _, scheme := range []string{"mysql", "tidb", "blackhole", "s3", "local"} {
+		require.False(t, IsMQScheme(scheme))
+	}
+}
@@ -30,6 +30,14 @@ type SyncpointStore interface {
 	// SinkSyncpoint record the syncpoint(a map with ts) in downstream db
 	SinkSyncpoint(ctx context.Context, id string, checkpointTs uint64) error
 
+	// CleanupSyncpoints removes the syncpoints of the changefeed whose
+	// upstream ts is less than beforeTs
+	CleanupSyncpoints(ctx context.Context, id string, beforeTs uint64) error
+
+	// QuerySyncpoints returns at most limit syncpoints of the changefeed whose
+	// upstream ts is in [startTs, endTs], the latest first
+	QuerySyncpoints(ctx context.Context, id string, startTs, endTs uint64, limit int) ([]model.SyncpointRecord, error)
+
 	// Close closes the SyncpointSink
 	Close() error
 }
@@ -0,0 +1,141 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingcap/ticdc/cdc/model"
+)
+
+// tableMetrics reports the sink metrics labelled by table, so that the hot
+// or problematic tables can be identified. A nil *tableMetrics is valid and
+// reports nothing, which is the case unless the table-sink-metrics feature
+// is enabled.
+type tableMetrics struct {
+	captureAddr  string
+	changefeedID model.ChangeFeedID
+
+	mu sync.Mutex
+	// tables records the labels reported, which are deleted when closed
+	tables map[string]struct{}
+}
+
+func newTableMetrics(enabled bool, captureAddr string, changefeedID model.ChangeFeedID) *tableMetrics {
+	if !enabled {
+		return nil
+	}
+	return &tableMetrics{
+		captureAddr:  captureAddr,
+		changefeedID: changefeedID,
+		tables:       make(map[string]struct{}),
+	}
+}
+
+// tablesOf returns the quoted names of the distinct tables of the rows.
+func tablesOf(rows []*model.RowChangedEvent) []string {
+	var tables []string
+	seen := make(map[model.TableName]struct{})
+	for _, row := range rows {
+		if row.Table == nil {
+			continue
+		}
+		if _, ok := seen[*row.Table]; ok {
+			continue
+		}
+		seen[*row.Table] = struct{}{}
+		tables = append(tables, row.Table.QuoteString())
+	}
+	return tables
+}
+
+func (m *tableMetrics) observeFlush(tables []string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	for _, table := range m.track(tables...) {
+		tableFlushDurationHistogram.WithLabelValues(m.captureAddr, m.changefeedID, table).Observe(duration.Seconds())
+	}
+}
+
+func (m *tableMetrics) incRetry(tables []string) {
+	if m == nil {
+		return
+	}
+	for _, table := range m.track(tables...) {
+		tableRetryCounter.WithLabelValues(m.captureAddr, m.changefeedID, table).Inc()
+	}
+}
+
+func (m *tableMetrics) incConflict(tables []string) {
+	if m == nil {
+		return
+	}
+	for _, table := range m.track(tables...) {
+		tableConflictCounter.WithLabelValues(m.captureAddr, m.changefeedID, table).Inc()
+	}
+}
+
+func (m *tableMetrics) setPendingRows(table *model.TableName, count int) {
+	if m == nil || table == nil {
+		return
+	}
+	name := table.QuoteString()
+	m.track(name)
+	tablePendingRowsGauge.WithLabelValues(m.captureAddr, m.changefeedID, name).Set(float64(count))
+}
+
+// remove deletes the metrics of the table, it is called after the table is
+// removed from the processor.
+func (m *tableMetrics) remove(table *model.TableName) {
+	if m == nil || table == nil {
+		return
+	}
+	name := table.QuoteString()
+	m.mu.Lock()
+	delete(m.tables, name)
+	m.mu.Unlock()
+	m.deleteLabelValues(name)
+}
+
+// close deletes the metrics of all the tables reported.
+func (m *tableMetrics) close() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	tables := m.tables
+	m.tables = make(map[string]struct{})
+	m.mu.Unlock()
+	for table := range tables {
+		m.deleteLabelValues(table)
+	}
+}
+
+func (m *tableMetrics) track(tables ...string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, table := range tables {
+		m.tables[table] = struct{}{}
+	}
+	return tables
+}
+
+func (m *tableMetrics) deleteLabelValues(table string) {
+	tableFlushDurationHistogram.DeleteLabelValues(m.captureAddr, m.changefeedID, table)
+	tableRetryCounter.DeleteLabelValues(m.captureAddr, m.changefeedID, table)
+	tableConflictCounter.DeleteLabelValues(m.captureAddr, m.changefeedID, table)
+	tablePendingRowsGauge.DeleteLabelValues(m.captureAddr, m.changefeedID, table)
+}
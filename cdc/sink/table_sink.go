@@ -24,9 +24,15 @@ import (
 )
 
 type tableSink struct {
-	tableID model.TableID
-	manager *Manager
-	buffer  []*model.RowChangedEvent
+	tableID   model.TableID
+	tableName *model.TableName
+	manager   *Manager
+	buffer    []*model.RowChangedEvent
+	// startTs is the checkpoint ts when the table sink is created
+	startTs model.Ts
+	// started means the table started event is emitted to backendSink, or
+	// it needn't be emitted since the table is not newly added
+	started bool
 	// emittedTs means all of events which of commitTs less than or equal to emittedTs is sent to backendSink
 	emittedTs   model.Ts
 	redoManager redo.LogManager
@@ -40,6 +46,7 @@ func (t *tableSink) Initialize(ctx context.Context, tableInfo []*model.SimpleTab
 func (t *tableSink) EmitRowChangedEvents(ctx context.Context, rows ...*model.RowChangedEvent) error {
 	t.buffer = append(t.buffer, rows...)
 	t.manager.metricsTableSinkTotalRows.Add(float64(len(rows)))
+	t.manager.tableMetrics.setPendingRows(t.tableName, len(t.buffer))
 	if t.redoManager.Enabled() {
 		return t.redoManager.EmitRowChangedEvents(ctx, t.tableID, rows...)
 	}
@@ -55,6 +62,14 @@ func (t *tableSink) EmitDDLEvent(ctx context.Context, ddl *model.DDLEvent) error
 // is required to be no more than global resolvedTs, table barrierTs and table
 // redo log watermarkTs.
 func (t *tableSink) FlushRowChangedEvents(ctx context.Context, resolvedTs uint64) (uint64, error) {
+	if !t.started {
+		// the table started event is emitted in the first flush instead of the
+		// creation of the table sink, to avoid blocking the processor.
+		if err := t.manager.emitTableStarted(ctx, t.tableName, t.startTs); err != nil {
+			return t.manager.getCheckpointTs(), errors.Trace(err)
+		}
+		t.started = true
+	}
 	i := sort.Search(len(t.buffer), func(i int) bool {
 		return t.buffer[i].CommitTs > resolvedTs
 	})
@@ -68,6 +83,7 @@ func (t *tableSink) FlushRowChangedEvents(ctx context.Context, resolvedTs uint64
 	}
 	resolvedRows := t.buffer[:i]
 	t.buffer = append(make([]*model.RowChangedEvent, 0, len(t.buffer[i:])), t.buffer[i:]...)
+	t.manager.tableMetrics.setPendingRows(t.tableName, len(t.buffer))
 
 	err := t.manager.backendSink.EmitRowChangedEvents(ctx, resolvedRows...)
 	if err != nil {
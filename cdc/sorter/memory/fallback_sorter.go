@@ -0,0 +1,234 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sorter"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/util"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const fallbackSorterOutputChSize = 1024
+
+// FallbackSorter sorts the events in memory and outputs them as soon as they
+// are resolved, which makes it suitable for the small tables that require low
+// latency. The events buffered in memory are limited by a strict quota, once
+// the quota is exceeded, the sorter falls back to another sorter, usually the
+// unified sorter, for the rest of its life.
+type FallbackSorter struct {
+	quota       uint64
+	newFallback func() (sorter.EventSorter, error)
+
+	lock       sync.Mutex
+	unsorted   []*model.PolymorphicEvent
+	size       uint64
+	resolvedTs model.Ts
+	// overflowed is set once the size of the buffered events exceeds the quota
+	overflowed bool
+	// fallback is not nil after the sorter falls back, all the events are
+	// added to it since then.
+	fallback sorter.EventSorter
+	closed   int32
+
+	notifyCh chan struct{}
+	outputCh chan *model.PolymorphicEvent
+}
+
+// NewFallbackSorter creates a new FallbackSorter, the quota is the maximum
+// size in bytes of the buffered events.
+func NewFallbackSorter(quota uint64, newFallback func() (sorter.EventSorter, error)) *FallbackSorter {
+	return &FallbackSorter{
+		quota:       quota,
+		newFallback: newFallback,
+		notifyCh:    make(chan struct{}, 1),
+		outputCh:    make(chan *model.PolymorphicEvent, fallbackSorterOutputChSize),
+	}
+}
+
+// Run runs FallbackSorter
+func (s *FallbackSorter) Run(ctx context.Context) error {
+	errg, ctx := errgroup.WithContext(ctx)
+	errg.Go(func() error {
+		defer func() {
+			atomic.StoreInt32(&s.closed, 1)
+			close(s.outputCh)
+		}()
+		var (
+			lastResolvedTs model.Ts
+			fallback       sorter.EventSorter
+			err            error
+		)
+		for fallback == nil {
+			select {
+			case <-ctx.Done():
+				return errors.Trace(ctx.Err())
+			case <-s.notifyCh:
+			}
+			lastResolvedTs, err = s.flush(ctx, lastResolvedTs)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			fallback, err = s.tryFallback(ctx, errg, lastResolvedTs)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return errors.Trace(ctx.Err())
+			case entry, ok := <-fallback.Output():
+				if !ok {
+					return nil
+				}
+				s.output(ctx, entry)
+			}
+		}
+	})
+	return errg.Wait()
+}
+
+// flush outputs the events resolved since the last flush, and returns the
+// resolved ts which has been output.
+func (s *FallbackSorter) flush(ctx context.Context, lastResolvedTs model.Ts) (model.Ts, error) {
+	s.lock.Lock()
+	toSort := s.unsorted
+	s.unsorted = nil
+	resolvedTs := s.resolvedTs
+	s.lock.Unlock()
+
+	sort.Slice(toSort, func(i, j int) bool {
+		return eventLess(toSort[i], toSort[j])
+	})
+	idx := sort.Search(len(toSort), func(i int) bool {
+		return toSort[i].CRTs > resolvedTs
+	})
+	var outputSize uint64
+	for _, entry := range toSort[:idx] {
+		outputSize += uint64(entry.RawKV.ApproximateSize())
+		s.output(ctx, entry)
+	}
+	if resolvedTs > lastResolvedTs {
+		// regionID = 0 means the event is produced by TiCDC
+		s.output(ctx, model.NewResolvedPolymorphicEvent(0, resolvedTs))
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.unsorted = append(toSort[idx:], s.unsorted...)
+	s.size -= outputSize
+	return resolvedTs, ctx.Err()
+}
+
+// tryFallback falls back to another sorter if the quota is exceeded, the
+// buffered events are moved to the new sorter.
+func (s *FallbackSorter) tryFallback(
+	ctx context.Context, errg *errgroup.Group, lastResolvedTs model.Ts,
+) (sorter.EventSorter, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.overflowed {
+		return nil, nil
+	}
+	fallback, err := s.newFallback()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	errg.Go(func() error {
+		return fallback.Run(ctx)
+	})
+	changefeedID := util.ChangefeedIDFromCtx(ctx)
+	_, tableName := util.TableIDFromCtx(ctx)
+	log.Warn("the events of the table exceed the memory quota, fall back to another sorter",
+		zap.String("changefeed", changefeedID), zap.String("table", tableName),
+		zap.Uint64("quota", s.quota), zap.Uint64("size", s.size))
+	fallbackSorterFallbackCounter.WithLabelValues(util.CaptureAddrFromCtx(ctx), changefeedID, tableName).Inc()
+
+	// The lock is held until the buffered events are moved, so that the
+	// events added later are always added to the new sorter after them.
+	for _, entry := range s.unsorted {
+		fallback.AddEntry(ctx, entry)
+	}
+	if s.resolvedTs > lastResolvedTs {
+		fallback.AddEntry(ctx, model.NewResolvedPolymorphicEvent(0, s.resolvedTs))
+	}
+	s.unsorted = nil
+	s.size = 0
+	s.fallback = fallback
+	return fallback, nil
+}
+
+func (s *FallbackSorter) output(ctx context.Context, entry *model.PolymorphicEvent) {
+	select {
+	case <-ctx.Done():
+	case s.outputCh <- entry:
+	}
+}
+
+func (s *FallbackSorter) notify() {
+	select {
+	case s.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// AddEntry adds an RawKVEntry to the sorter
+func (s *FallbackSorter) AddEntry(ctx context.Context, entry *model.PolymorphicEvent) {
+	if atomic.LoadInt32(&s.closed) != 0 {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.fallback != nil {
+		s.fallback.AddEntry(ctx, entry)
+		return
+	}
+	if entry.RawKV.OpType == model.OpTypeResolved {
+		if entry.CRTs > s.resolvedTs {
+			s.resolvedTs = entry.CRTs
+		}
+		s.notify()
+		return
+	}
+	s.unsorted = append(s.unsorted, entry)
+	s.size += uint64(entry.RawKV.ApproximateSize())
+	if s.size > s.quota {
+		s.overflowed = true
+		s.notify()
+	}
+}
+
+// TryAddEntry implements the EventSorter interface
+func (s *FallbackSorter) TryAddEntry(ctx context.Context, entry *model.PolymorphicEvent) (bool, error) {
+	if atomic.LoadInt32(&s.closed) != 0 {
+		return false, cerror.ErrSorterClosed.GenWithStackByArgs()
+	}
+	s.AddEntry(ctx, entry)
+	return true, nil
+}
+
+// Output returns the sorted raw kv output channel
+func (s *FallbackSorter) Output() <-chan *model.PolymorphicEvent {
+	return s.outputCh
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sorter"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+type fallbackSorterSuite struct{}
+
+var _ = check.Suite(&fallbackSorterSuite{})
+
+func (s *fallbackSorterSuite) TestFallbackSorter(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	es := NewFallbackSorter(10, func() (sorter.EventSorter, error) {
+		return NewEntrySorter(), nil
+	})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := es.Run(ctx)
+		c.Assert(errors.Cause(err), check.Equals, context.Canceled)
+	}()
+	addEntry := func(crts uint64, opType model.OpType, value string) {
+		es.AddEntry(ctx, model.NewPolymorphicEvent(&model.RawKVEntry{
+			CRTs: crts, OpType: opType, Value: []byte(value),
+		}))
+	}
+	expectOutput := func(expect []*model.RawKVEntry) {
+		for _, e := range expect {
+			entry := <-es.Output()
+			c.Assert(entry.CRTs, check.Equals, e.CRTs)
+			c.Assert(entry.RawKV.OpType, check.Equals, e.OpType)
+		}
+	}
+
+	// within the quota, the events are sorted in memory
+	addEntry(3, model.OpTypePut, "a")
+	addEntry(2, model.OpTypePut, "b")
+	addEntry(2, model.OpTypeDelete, "c")
+	addEntry(5, model.OpTypePut, "d")
+	addEntry(3, model.OpTypeResolved, "")
+	expectOutput([]*model.RawKVEntry{
+		{CRTs: 2, OpType: model.OpTypeDelete},
+		{CRTs: 2, OpType: model.OpTypePut},
+		{CRTs: 3, OpType: model.OpTypePut},
+		{CRTs: 3, OpType: model.OpTypeResolved},
+	})
+	addEntry(4, model.OpTypePut, "e")
+	addEntry(6, model.OpTypeResolved, "")
+	expectOutput([]*model.RawKVEntry{
+		{CRTs: 4, OpType: model.OpTypePut},
+		{CRTs: 5, OpType: model.OpTypePut},
+		{CRTs: 6, OpType: model.OpTypeResolved},
+	})
+	es.lock.Lock()
+	c.Assert(es.fallback, check.IsNil)
+	es.lock.Unlock()
+
+	// exceed the quota, the sorter falls back and the buffered events are
+	// moved to the fallback sorter
+	addEntry(8, model.OpTypePut, "0123456789")
+	addEntry(7, model.OpTypePut, "f")
+	addEntry(9, model.OpTypePut, "g")
+	addEntry(8, model.OpTypeResolved, "")
+	expectOutput([]*model.RawKVEntry{
+		{CRTs: 7, OpType: model.OpTypePut},
+		{CRTs: 8, OpType: model.OpTypePut},
+		{CRTs: 8, OpType: model.OpTypeResolved},
+	})
+	addEntry(10, model.OpTypeResolved, "")
+	expectOutput([]*model.RawKVEntry{
+		{CRTs: 9, OpType: model.OpTypePut},
+		{CRTs: 10, OpType: model.OpTypeResolved},
+	})
+	es.lock.Lock()
+	c.Assert(es.fallback, check.NotNil)
+	es.lock.Unlock()
+
+	cancel()
+	wg.Wait()
+}
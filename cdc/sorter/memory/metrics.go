@@ -61,6 +61,13 @@ var (
 			Help:      "Bucketed histogram of processing time (s) of merge in entry sorter.",
 			Buckets:   prometheus.ExponentialBuckets(0.000001, 10, 10),
 		}, []string{"capture", "changefeed", "table"})
+	fallbackSorterFallbackCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "puller",
+			Name:      "fallback_sorter_fallback_count",
+			Help:      "The number of times the fallback sorter falls back due to exceeding the memory quota",
+		}, []string{"capture", "changefeed", "table"})
 )
 
 // InitMetrics registers all metrics in this file
@@ -70,4 +77,5 @@ func InitMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(entrySorterUnsortedSizeGauge)
 	registry.MustRegister(entrySorterSortDuration)
 	registry.MustRegister(entrySorterMergeDuration)
+	registry.MustRegister(fallbackSorterFallbackCounter)
 }
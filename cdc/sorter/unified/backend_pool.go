@@ -29,9 +29,11 @@ import (
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/sorter"
 	sorterencoding "github.com/pingcap/ticdc/cdc/sorter/encoding"
+	"github.com/pingcap/ticdc/pkg/compression"
 	"github.com/pingcap/ticdc/pkg/config"
 	cerrors "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/filelock"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"github.com/pingcap/ticdc/pkg/util"
 	"github.com/pingcap/tidb/util/memory"
 	"go.uber.org/zap"
@@ -41,6 +43,14 @@ const (
 	backgroundJobInterval      = time.Second * 15
 	sortDirLockFileName        = "ticdc_lock"
 	sortDirDataFileMagicPrefix = "sort"
+
+	// diskFullCheckInterval is the interval of checking whether the disk
+	// space of sort-dir is freed when the disk is full.
+	diskFullCheckInterval = time.Second
+	// maxDiskFullInMemoryFlushSize is the size above which a flush is not
+	// kept in memory when the disk is full, so that the memory fallback
+	// serves the tables with few changes instead of a single large one.
+	maxDiskFullInMemoryFlushSize = 4 * 1024 * 1024 // 4MB
 )
 
 var (
@@ -53,9 +63,18 @@ type backEndPool struct {
 	onDiskDataSize    int64
 	fileNameCounter   uint64
 	memPressure       int32
-	cache             [256]unsafe.Pointer
-	dir               string
-	filePrefix        string
+	// diskFullSince is the time in unix nanoseconds since which the disk of
+	// sort-dir is full, 0 if the disk is not full.
+	diskFullSince int64
+	// diskFullMemoryUsage is the size of the flushes kept in memory because
+	// the disk of sort-dir is full.
+	diskFullMemoryUsage int64
+	cache               [256]unsafe.Pointer
+	dir                 string
+	filePrefix          string
+	// segments stores the data of the file backEnds in the segments shared
+	// by the tables, it is nil if the sorter uses a file per backEnd.
+	segments *segmentManager
 
 	// to prevent `dir` from being accidentally used by another TiCDC server process.
 	fileLock *filelock.FileLock
@@ -75,6 +94,9 @@ func newBackEndPool(dir string, captureAddr string) (*backEndPool, error) {
 		cancelCh:          make(chan struct{}),
 		filePrefix:        fmt.Sprintf("%s/%s-%d-", dir, sortDirDataFileMagicPrefix, os.Getpid()),
 	}
+	if segmentSize := config.GetGlobalServerConfig().Sorter.SegmentSize; segmentSize != 0 {
+		ret.segments = newSegmentManager(ret.filePrefix, int64(segmentSize))
+	}
 
 	err := ret.lockSortDir()
 	if err != nil {
@@ -118,6 +140,10 @@ func newBackEndPool(dir string, captureAddr string) (*backEndPool, error) {
 			metricSorterOnDiskDataSizeGauge.Set(float64(atomic.LoadInt64(&ret.onDiskDataSize)))
 			metricSorterOpenFileCountGauge.Set(float64(atomic.LoadInt64(&openFDCount)))
 
+			if ret.segments != nil {
+				ret.segments.compact()
+			}
+
 			// update memPressure
 			usedMemory, err := memory.MemUsed()
 			if err != nil || totalMemory == 0 {
@@ -162,15 +188,35 @@ func newBackEndPool(dir string, captureAddr string) (*backEndPool, error) {
 	return ret, nil
 }
 
-func (p *backEndPool) alloc(ctx context.Context) (backEnd, error) {
+// alloc allocates a backEnd for a flush of about size bytes. The canceller,
+// if not nil, stops waiting for the disk space when the disk is full.
+func (p *backEndPool) alloc(ctx context.Context, size int64, canceller *asyncCanceller) (backEnd, error) {
 	sorterConfig := config.GetGlobalServerConfig().Sorter
 	if p.sorterMemoryUsage() < int64(sorterConfig.MaxMemoryConsumption) &&
-		p.memoryPressure() < int32(sorterConfig.MaxMemoryPressure) {
+		p.memoryPressure() < int32(sorterConfig.MaxMemoryPressure) &&
+		memquota.GlobalGovernor().Available(memquota.ComponentSorter) {
 
 		ret := newMemoryBackEnd()
 		return ret, nil
 	}
 
+	ret, err := p.allocFromCache()
+	if ret != nil || err != nil {
+		return ret, err
+	}
+
+	if err := util.CheckDataDirSatisfied(); err != nil {
+		ret, err := p.allocOnDiskFull(ctx, size, canceller, err)
+		if ret != nil || err != nil {
+			return ret, err
+		}
+	}
+
+	return p.allocOnDisk(ctx)
+}
+
+// allocFromCache returns a cached fileBackEnd, or nil if there is none.
+func (p *backEndPool) allocFromCache() (backEnd, error) {
 	p.cancelRWLock.RLock()
 	defer p.cancelRWLock.RUnlock()
 
@@ -185,6 +231,26 @@ func (p *backEndPool) alloc(ctx context.Context) (backEnd, error) {
 			return (*fileBackEnd)(ret), nil
 		}
 	}
+	return nil, nil
+}
+
+// allocOnDisk creates a backEnd which stores the data in the sort-dir.
+func (p *backEndPool) allocOnDisk(ctx context.Context) (backEnd, error) {
+	p.cancelRWLock.RLock()
+	defer p.cancelRWLock.RUnlock()
+
+	if p.isTerminating {
+		return nil, cerrors.ErrUnifiedSorterBackendTerminating.GenWithStackByArgs()
+	}
+
+	codec, err := compression.New(config.GetGlobalServerConfig().Sorter.FileCompression)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if p.segments != nil {
+		return newSegmentBackEnd(p.segments, &sorterencoding.MsgPackGenSerde{}, codec), nil
+	}
 
 	fname := fmt.Sprintf("%s%d.tmp", p.filePrefix, atomic.AddUint64(&p.fileNameCounter, 1))
 	tableID, tableName := util.TableIDFromCtx(ctx)
@@ -193,11 +259,7 @@ func (p *backEndPool) alloc(ctx context.Context) (backEnd, error) {
 		zap.Int64("table-id", tableID),
 		zap.String("table-name", tableName))
 
-	if err := util.CheckDataDirSatisfied(); err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	ret, err := newFileBackEnd(fname, &sorterencoding.MsgPackGenSerde{})
+	ret, err := newFileBackEnd(fname, &sorterencoding.MsgPackGenSerde{}, codec)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -205,6 +267,97 @@ func (p *backEndPool) alloc(ctx context.Context) (backEnd, error) {
 	return ret, nil
 }
 
+// allocOnDiskFull is called when the disk of sort-dir is found full, with the
+// cause being the error reporting it. The spill files no longer needed are
+// freed at once, and a small flush is kept in memory if the
+// disk-full-memory-quota allows. Otherwise it waits for the disk space to be
+// freed, which blocks the heap sorter and in turn pauses the puller and the
+// incremental scans of the table by the backpressure. A nil backEnd is
+// returned once the disk space is freed, and ErrUnifiedSorterDiskFull is
+// returned if the disk stays full for disk-full-timeout.
+func (p *backEndPool) allocOnDiskFull(
+	ctx context.Context, size int64, canceller *asyncCanceller, cause error,
+) (backEnd, error) {
+	sorterConfig := config.GetGlobalServerConfig().Sorter
+	timeout := time.Duration(sorterConfig.DiskFullTimeout)
+	since := p.markDiskFull(cause)
+	ticker := time.NewTicker(diskFullCheckInterval)
+	defer ticker.Stop()
+	for {
+		p.freeSpillFiles()
+		if size <= maxDiskFullInMemoryFlushSize &&
+			atomic.LoadInt64(&p.diskFullMemoryUsage)+size <= int64(sorterConfig.DiskFullMemoryQuota) {
+			return newDiskFullMemoryBackEnd(), nil
+		}
+		if time.Since(since) >= timeout {
+			return nil, cerrors.ErrUnifiedSorterDiskFull.GenWithStackByArgs(timeout, cause.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Trace(ctx.Err())
+		case <-ticker.C:
+		}
+		if canceller != nil && canceller.IsCanceled() {
+			return nil, cerrors.ErrAsyncIOCancelled.GenWithStackByArgs()
+		}
+
+		err := util.CheckDataDirSatisfied()
+		if err == nil {
+			return nil, nil
+		}
+		cause = err
+	}
+}
+
+// markDiskFull marks the disk of sort-dir full, and returns the time since
+// which the disk has been full.
+func (p *backEndPool) markDiskFull(cause error) time.Time {
+	now := time.Now()
+	if atomic.CompareAndSwapInt64(&p.diskFullSince, 0, now.UnixNano()) {
+		log.Warn("Unified Sorter: the disk of sort-dir is full, waiting for the disk space to be freed",
+			zap.String("sort-dir", p.dir),
+			zap.Duration("timeout", time.Duration(config.GetGlobalServerConfig().Sorter.DiskFullTimeout)),
+			zap.Error(cause))
+		return now
+	}
+	return time.Unix(0, atomic.LoadInt64(&p.diskFullSince))
+}
+
+// clearDiskFull is called once a flush is written to the disk successfully.
+func (p *backEndPool) clearDiskFull() {
+	if since := atomic.SwapInt64(&p.diskFullSince, 0); since != 0 {
+		log.Info("Unified Sorter: the disk space of sort-dir is freed",
+			zap.String("sort-dir", p.dir),
+			zap.Duration("duration", time.Since(time.Unix(0, since))))
+	}
+}
+
+// freeSpillFiles removes the cached files and compacts the segments, so that
+// the disk space is freed as soon as possible.
+func (p *backEndPool) freeSpillFiles() {
+	p.cancelRWLock.RLock()
+	defer p.cancelRWLock.RUnlock()
+
+	if p.isTerminating {
+		return
+	}
+
+	for i := range p.cache {
+		innerPtr := atomic.SwapPointer(&p.cache[i], nil)
+		if innerPtr == nil {
+			continue
+		}
+		backEnd := (*fileBackEnd)(innerPtr)
+		if err := backEnd.free(); err != nil {
+			log.Warn("Cannot remove temporary file for sorting", zap.String("file", backEnd.fileName), zap.Error(err))
+		}
+	}
+	if p.segments != nil {
+		p.segments.compact()
+	}
+}
+
 func (p *backEndPool) dealloc(backEnd backEnd) error {
 	switch b := backEnd.(type) {
 	case *memoryBackEnd:
@@ -244,6 +397,9 @@ func (p *backEndPool) dealloc(backEnd backEnd) error {
 		}
 
 		return nil
+	case *segmentBackEnd:
+		// the segments are shared, so there is nothing to cache
+		return errors.Trace(b.free())
 	default:
 		log.Panic("backEndPool: unexpected backEnd type to be deallocated", zap.Reflect("type", reflect.TypeOf(backEnd)))
 	}
@@ -282,6 +438,9 @@ func (p *backEndPool) terminate() {
 		}
 		_ = backend.free()
 	}
+	if p.segments != nil {
+		p.segments.close()
+	}
 
 	if p.filePrefix == "" {
 		// This should not happen. But to prevent accidents in production, we add this anyway.
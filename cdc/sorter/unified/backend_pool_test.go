@@ -49,6 +49,7 @@ func (s *backendPoolSuite) TestBasicFunction(c *check.C) {
 	conf.Sorter.SortDir = sortDir
 	conf.Sorter.MaxMemoryPressure = 90                         // 90%
 	conf.Sorter.MaxMemoryConsumption = 16 * 1024 * 1024 * 1024 // 16G
+	conf.Sorter.SegmentSize = 0                                // use a file per backEnd
 	config.StoreGlobalServerConfig(conf)
 
 	err = failpoint.Enable("github.com/pingcap/ticdc/cdc/sorter/unified/memoryPressureInjectPoint", "return(100)")
@@ -62,7 +63,7 @@ func (s *backendPoolSuite) TestBasicFunction(c *check.C) {
 	c.Assert(backEndPool, check.NotNil)
 	defer backEndPool.terminate()
 
-	backEnd, err := backEndPool.alloc(ctx)
+	backEnd, err := backEndPool.alloc(ctx, 0, nil)
 	c.Assert(err, check.IsNil)
 	c.Assert(backEnd, check.FitsTypeOf, &fileBackEnd{})
 	fileName := backEnd.(*fileBackEnd).fileName
@@ -73,7 +74,7 @@ func (s *backendPoolSuite) TestBasicFunction(c *check.C) {
 	err = failpoint.Enable("github.com/pingcap/ticdc/cdc/sorter/unified/memoryUsageInjectPoint", "return(34359738368)")
 	c.Assert(err, check.IsNil)
 
-	backEnd1, err := backEndPool.alloc(ctx)
+	backEnd1, err := backEndPool.alloc(ctx, 0, nil)
 	c.Assert(err, check.IsNil)
 	c.Assert(backEnd1, check.FitsTypeOf, &fileBackEnd{})
 	fileName1 := backEnd1.(*fileBackEnd).fileName
@@ -85,7 +86,7 @@ func (s *backendPoolSuite) TestBasicFunction(c *check.C) {
 	err = failpoint.Enable("github.com/pingcap/ticdc/cdc/sorter/unified/memoryUsageInjectPoint", "return(0)")
 	c.Assert(err, check.IsNil)
 
-	backEnd2, err := backEndPool.alloc(ctx)
+	backEnd2, err := backEndPool.alloc(ctx, 0, nil)
 	c.Assert(err, check.IsNil)
 	c.Assert(backEnd2, check.FitsTypeOf, &memoryBackEnd{})
 
@@ -124,13 +125,14 @@ func (s *backendPoolSuite) TestDirectoryBadPermission(c *check.C) {
 	conf.DataDir = dataDir
 	conf.Sorter.SortDir = sortDir
 	conf.Sorter.MaxMemoryPressure = 0 // force using files
+	conf.Sorter.SegmentSize = 0       // use a file per backEnd
 
 	backEndPool, err := newBackEndPool(sortDir, "")
 	c.Assert(err, check.IsNil)
 	c.Assert(backEndPool, check.NotNil)
 	defer backEndPool.terminate()
 
-	backEnd, err := backEndPool.alloc(context.Background())
+	backEnd, err := backEndPool.alloc(context.Background(), 0, nil)
 	c.Assert(err, check.IsNil)
 	defer backEnd.free() //nolint:errcheck
 
@@ -159,6 +161,7 @@ func (s *backendPoolSuite) TestCleanUpSelf(c *check.C) {
 	conf.Sorter.SortDir = sorterDir
 	conf.Sorter.MaxMemoryPressure = 90                         // 90%
 	conf.Sorter.MaxMemoryConsumption = 16 * 1024 * 1024 * 1024 // 16G
+	conf.Sorter.SegmentSize = 0                                // use a file per backEnd
 	config.StoreGlobalServerConfig(conf)
 
 	err = failpoint.Enable("github.com/pingcap/ticdc/cdc/sorter/unified/memoryPressureInjectPoint", "return(100)")
@@ -174,7 +177,7 @@ func (s *backendPoolSuite) TestCleanUpSelf(c *check.C) {
 
 	var fileNames []string
 	for i := 0; i < 20; i++ {
-		backEnd, err := backEndPool.alloc(ctx)
+		backEnd, err := backEndPool.alloc(ctx, 0, nil)
 		c.Assert(err, check.IsNil)
 		c.Assert(backEnd, check.FitsTypeOf, &fileBackEnd{})
 
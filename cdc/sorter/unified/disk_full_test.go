@@ -0,0 +1,146 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unified
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerrors "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+type diskFullSuite struct{}
+
+var _ = check.SerialSuites(&diskFullSuite{})
+
+// newDiskFullPool creates a backEndPool whose data-dir does not exist, so
+// that the disk is reported full until the data-dir is created.
+func newDiskFullPool(c *check.C, timeout time.Duration) (*backEndPool, string) {
+	sortDir := c.MkDir()
+	dataDir := filepath.Join(c.MkDir(), "data")
+
+	conf := config.GetDefaultServerConfig()
+	conf.DataDir = dataDir
+	conf.Sorter.SortDir = sortDir
+	conf.Sorter.MaxMemoryPressure = 0 // force using files
+	conf.Sorter.SegmentSize = 0       // use a file per backEnd
+	conf.Sorter.DiskFullTimeout = config.TomlDuration(timeout)
+	conf.Sorter.DiskFullMemoryQuota = 1024
+	config.StoreGlobalServerConfig(conf)
+
+	backEndPool, err := newBackEndPool(sortDir, "")
+	c.Assert(err, check.IsNil)
+	pool = backEndPool
+	return backEndPool, dataDir
+}
+
+func (s *diskFullSuite) TestMemoryFallback(c *check.C) {
+	defer testleak.AfterTest(c)()
+	defer config.StoreGlobalServerConfig(config.GetGlobalServerConfig())
+
+	backEndPool, _ := newDiskFullPool(c, 0)
+	defer func() { pool = nil }()
+	defer backEndPool.terminate()
+	ctx := context.Background()
+
+	backEnd, err := backEndPool.alloc(ctx, 100, nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(backEnd, check.FitsTypeOf, &memoryBackEnd{})
+	c.Assert(backEnd.(*memoryBackEnd).onDiskFull, check.IsTrue)
+	c.Assert(backEndPool.diskFullSince, check.Not(check.Equals), int64(0))
+
+	w, err := backEnd.writer()
+	c.Assert(err, check.IsNil)
+	c.Assert(w.writeNext(model.NewPolymorphicEvent(generateMockRawKV(1))), check.IsNil)
+	c.Assert(w.flushAndClose(), check.IsNil)
+	usage := backEndPool.diskFullMemoryUsage
+	c.Assert(usage, check.Greater, int64(0))
+
+	// the flushes exceeding the disk-full-memory-quota wait for the disk
+	// space until the disk-full-timeout
+	_, err = backEndPool.alloc(ctx, 1024-usage+1, nil)
+	c.Assert(cerrors.ErrUnifiedSorterDiskFull.Equal(err), check.IsTrue, check.Commentf("%s", err))
+	backEnd1, err := backEndPool.alloc(ctx, 1024-usage, nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(backEnd1, check.FitsTypeOf, &memoryBackEnd{})
+
+	c.Assert(backEndPool.dealloc(backEnd), check.IsNil)
+	c.Assert(backEndPool.dealloc(backEnd1), check.IsNil)
+	c.Assert(backEndPool.diskFullMemoryUsage, check.Equals, int64(0))
+
+	// the large flushes are never kept in memory
+	_, err = backEndPool.alloc(ctx, maxDiskFullInMemoryFlushSize+1, nil)
+	c.Assert(cerrors.ErrUnifiedSorterDiskFull.Equal(err), check.IsTrue)
+}
+
+func (s *diskFullSuite) TestWaitForDiskSpace(c *check.C) {
+	defer testleak.AfterTest(c)()
+	defer config.StoreGlobalServerConfig(config.GetGlobalServerConfig())
+
+	backEndPool, dataDir := newDiskFullPool(c, time.Minute)
+	defer func() { pool = nil }()
+	defer backEndPool.terminate()
+
+	time.AfterFunc(500*time.Millisecond, func() {
+		_ = os.MkdirAll(dataDir, 0o755)
+	})
+	backEnd, err := backEndPool.alloc(context.Background(), 2048, nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(backEnd, check.FitsTypeOf, &fileBackEnd{})
+	c.Assert(backEndPool.dealloc(backEnd), check.IsNil)
+
+	c.Assert(backEndPool.diskFullSince, check.Not(check.Equals), int64(0))
+	backEndPool.clearDiskFull()
+	c.Assert(backEndPool.diskFullSince, check.Equals, int64(0))
+}
+
+func (s *diskFullSuite) TestWaitCanceled(c *check.C) {
+	defer testleak.AfterTest(c)()
+	defer config.StoreGlobalServerConfig(config.GetGlobalServerConfig())
+
+	backEndPool, _ := newDiskFullPool(c, time.Minute)
+	defer func() { pool = nil }()
+	defer backEndPool.terminate()
+
+	canceller := &asyncCanceller{}
+	canceller.Cancel()
+	_, err := backEndPool.alloc(context.Background(), 2048, canceller)
+	c.Assert(cerrors.ErrAsyncIOCancelled.Equal(err), check.IsTrue)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = backEndPool.alloc(ctx, 2048, nil)
+	c.Assert(errors.Cause(err), check.Equals, context.Canceled)
+}
+
+func (s *diskFullSuite) TestIsNoSpaceError(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	err := errors.Trace(wrapIOError(&os.PathError{Op: "write", Path: "sort-1", Err: syscall.ENOSPC}))
+	c.Assert(isNoSpaceError(err), check.IsTrue)
+	c.Assert(cerrors.ErrUnifiedSorterIOError.Equal(err), check.IsTrue)
+
+	err = errors.Trace(wrapIOError(&os.PathError{Op: "write", Path: "sort-1", Err: syscall.EACCES}))
+	c.Assert(isNoSpaceError(err), check.IsFalse)
+	c.Assert(cerrors.ErrUnifiedSorterIOError.Equal(err), check.IsTrue)
+	c.Assert(isNoSpaceError(errors.New("no space left on device")), check.IsFalse)
+}
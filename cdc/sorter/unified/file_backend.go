@@ -16,15 +16,18 @@ package unified
 import (
 	"bufio"
 	"encoding/binary"
+	stderrors "errors"
 	"io"
 	"os"
 	"sync/atomic"
+	"syscall"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/sorter/encoding"
+	"github.com/pingcap/ticdc/pkg/compression"
 	cerrors "github.com/pingcap/ticdc/pkg/errors"
 	"go.uber.org/zap"
 )
@@ -41,11 +44,15 @@ var openFDCount int64
 type fileBackEnd struct {
 	fileName string
 	serde    encoding.SerializerDeserializer
+	// codec compresses every serialized event written to the file
+	codec    compression.Codec
 	borrowed int32
 	size     int64
 }
 
-func newFileBackEnd(fileName string, serde encoding.SerializerDeserializer) (*fileBackEnd, error) {
+func newFileBackEnd(
+	fileName string, serde encoding.SerializerDeserializer, codec compression.Codec,
+) (*fileBackEnd, error) {
 	f, err := os.Create(fileName)
 	if err != nil {
 		return nil, errors.Trace(wrapIOError(err))
@@ -60,6 +67,7 @@ func newFileBackEnd(fileName string, serde encoding.SerializerDeserializer) (*fi
 	return &fileBackEnd{
 		fileName: fileName,
 		serde:    serde,
+		codec:    codec,
 		borrowed: 0,
 	}, nil
 }
@@ -250,6 +258,11 @@ func (r *fileBackEndReader) readNext() (*model.PolymorphicEvent, error) {
 		return nil, errors.Errorf("fileSorterBackEnd: expected %d bytes, actually read %d bytes", size, n)
 	}
 
+	rawBytesBuf, err = r.backEnd.codec.Decompress(nil, rawBytesBuf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	event := new(model.PolymorphicEvent)
 	_, err = r.backEnd.serde.Unmarshal(event, rawBytesBuf)
 	if err != nil {
@@ -352,11 +365,16 @@ func (w *fileBackEndWriter) writeNext(event *model.PolymorphicEvent) error {
 		return errors.Trace(wrapIOError(err))
 	}
 
-	size := len(rawBytesBuf)
-	if size == 0 {
+	if len(rawBytesBuf) == 0 {
 		log.Panic("fileSorterBackEnd: serialized to empty byte array. Bug?")
 	}
 
+	rawBytesBuf, err = w.backEnd.codec.Compress(nil, rawBytesBuf)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	size := len(rawBytesBuf)
+
 	err = binary.Write(w.writer, binary.LittleEndian, uint32(blockMagic))
 	if err != nil {
 		return errors.Trace(wrapIOError(err))
@@ -442,8 +460,34 @@ func wrapIOError(err error) error {
 	switch cause.(type) {
 	case *os.PathError:
 		// We don't generate stack in this helper function to avoid confusion.
-		return cerrors.ErrUnifiedSorterIOError.FastGenByArgs(err.Error())
+		ioErr := cerrors.ErrUnifiedSorterIOError.FastGenByArgs(err.Error())
+		if isNoSpaceError(cause) {
+			return &noSpaceError{error: ioErr, cause: cause}
+		}
+		return ioErr
 	default:
 		return err
 	}
 }
+
+// noSpaceError is an ErrUnifiedSorterIOError caused by the disk being full.
+// Its Cause is the ErrUnifiedSorterIOError so that the error code is kept,
+// while the original error is unwrapped by the standard library to be
+// recognized by isNoSpaceError.
+type noSpaceError struct {
+	error
+	cause error
+}
+
+func (e *noSpaceError) Cause() error {
+	return e.error
+}
+
+func (e *noSpaceError) Unwrap() error {
+	return e.cause
+}
+
+// isNoSpaceError returns whether the error is caused by the disk being full.
+func isNoSpaceError(err error) bool {
+	return stderrors.Is(err, syscall.ENOSPC)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/check"
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/sorter/encoding"
+	"github.com/pingcap/ticdc/pkg/compression"
 	cerrors "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/util/testleak"
 )
@@ -47,9 +48,12 @@ func (s *fileBackendSuite) TestWrapIOError(c *check.C) {
 func (s *fileBackendSuite) TestNoSpace(c *check.C) {
 	defer testleak.AfterTest(c)()
 
+	codec, err := compression.New(compression.None)
+	c.Assert(err, check.IsNil)
 	fb := &fileBackEnd{
 		fileName: "/dev/full",
 		serde:    &encoding.MsgPackGenSerde{},
+		codec:    codec,
 	}
 	w, err := fb.writer()
 	c.Assert(err, check.IsNil)
@@ -63,3 +67,35 @@ func (s *fileBackendSuite) TestNoSpace(c *check.C) {
 	c.Assert(err, check.ErrorMatches, ".*review the settings.*no space.*")
 	c.Assert(cerrors.ErrUnifiedSorterIOError.Equal(err), check.IsTrue)
 }
+
+func (s *fileBackendSuite) TestCompression(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	codec, err := compression.New(compression.LZ4)
+	c.Assert(err, check.IsNil)
+	fb, err := newFileBackEnd(c.MkDir()+"/compressed.tmp", &encoding.MsgPackGenSerde{}, codec)
+	c.Assert(err, check.IsNil)
+	pool = &backEndPool{}
+	defer func() { pool = nil }()
+
+	w, err := fb.writer()
+	c.Assert(err, check.IsNil)
+	for i := 0; i < 100; i++ {
+		err = w.writeNext(model.NewPolymorphicEvent(generateMockRawKV(uint64(i))))
+		c.Assert(err, check.IsNil)
+	}
+	c.Assert(w.flushAndClose(), check.IsNil)
+
+	r, err := fb.reader()
+	c.Assert(err, check.IsNil)
+	for i := 0; i < 100; i++ {
+		event, err := r.readNext()
+		c.Assert(err, check.IsNil)
+		c.Assert(event.CRTs, check.Equals, uint64(i))
+	}
+	event, err := r.readNext()
+	c.Assert(err, check.IsNil)
+	c.Assert(event, check.IsNil)
+	c.Assert(r.resetAndClose(), check.IsNil)
+	c.Assert(fb.free(), check.IsNil)
+}
@@ -64,6 +64,16 @@ func (t *flushTask) markDeallocated() {
 	t.isDeallocated = true
 }
 
+// setBackEnd replaces the backEnd of a task, whose previous backEnd has been
+// deallocated.
+func (t *flushTask) setBackEnd(backEnd backEnd) {
+	t.deallocLock.Lock()
+	defer t.deallocLock.Unlock()
+
+	t.backend = backEnd
+	t.isDeallocated = false
+}
+
 func (t *flushTask) GetBackEnd() backEnd {
 	t.deallocLock.RLock()
 	defer t.deallocLock.RUnlock()
@@ -122,6 +132,7 @@ func (h *heapSorter) flush(ctx context.Context, maxResolvedTs uint64) error {
 	}
 
 	isEmptyFlush := h.heap.Len() == 0
+	sizeEstimate := h.internalState.heapSizeBytesEstimate
 	var finishCh chan error
 	if !isEmptyFlush {
 		failpoint.Inject("InjectErrorBackEndAlloc", func() {
@@ -129,7 +140,7 @@ func (h *heapSorter) flush(ctx context.Context, maxResolvedTs uint64) error {
 		})
 
 		var err error
-		backEnd, err = pool.alloc(ctx)
+		backEnd, err = pool.alloc(ctx, sizeEstimate, h.canceller)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -194,20 +205,8 @@ func (h *heapSorter) flush(ctx context.Context, maxResolvedTs uint64) error {
 				return
 			}
 
-			writer, err := backEnd.writer()
-			if err != nil {
-				if backEndFinal != nil {
-					_ = task.dealloc()
-				}
-				task.finished <- errors.Trace(err)
-				return
-			}
-
 			defer func() {
 				// handle errors (or aborts) gracefully to prevent resource leaking (especially FD's)
-				if writer != nil {
-					_ = writer.flushAndClose()
-				}
 				if backEndFinal != nil {
 					_ = task.dealloc()
 				}
@@ -219,37 +218,38 @@ func (h *heapSorter) flush(ctx context.Context, maxResolvedTs uint64) error {
 				failpoint.Return()
 			})
 
-			counter := 0
+			// the events are kept until they are written, so that they can be
+			// written again to another backEnd if the disk is full.
+			events := make([]*model.PolymorphicEvent, 0, oldHeap.Len())
 			for oldHeap.Len() > 0 {
 				failpoint.Inject("asyncFlushInProcessDelay", func() {
 					log.Debug("asyncFlushInProcessDelay")
 				})
 				// no need to check for cancellation so frequently.
-				if counter%10000 == 0 && h.canceller.IsCanceled() {
+				if len(events)%10000 == 0 && h.canceller.IsCanceled() {
 					task.finished <- cerrors.ErrAsyncIOCancelled.GenWithStackByArgs()
 					return
 				}
-				counter++
+				events = append(events, heap.Pop(&oldHeap).(*sortItem).entry)
+			}
 
-				event := heap.Pop(&oldHeap).(*sortItem).entry
-				err := writer.writeNext(event)
+			dataSize, err := writeEvents(backEnd, events)
+			for err != nil && isNoSpaceError(err) {
+				backEnd, err = h.reallocOnDiskFull(ctx, task, sizeEstimate, err)
 				if err != nil {
-					task.finished <- errors.Trace(err)
-					return
+					break
 				}
+				dataSize, err = writeEvents(backEnd, events)
 			}
-
-			dataSize := writer.dataSize()
-			atomic.StoreInt64(&task.dataSize, int64(dataSize))
-			eventCount := writer.writtenCount()
-
-			writer1 := writer
-			writer = nil
-			err = writer1.flushAndClose()
 			if err != nil {
 				task.finished <- errors.Trace(err)
 				return
 			}
+			if _, ok := backEnd.(*memoryBackEnd); !ok {
+				pool.clearDiskFull()
+			}
+			atomic.StoreInt64(&task.dataSize, int64(dataSize))
+			eventCount := len(events)
 
 			backEndFinal = nil
 
@@ -280,6 +280,50 @@ func (h *heapSorter) flush(ctx context.Context, maxResolvedTs uint64) error {
 	return nil
 }
 
+// writeEvents writes the events to the backEnd, and returns the size of the
+// data written.
+func writeEvents(backEnd backEnd, events []*model.PolymorphicEvent) (uint64, error) {
+	writer, err := backEnd.writer()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	for _, event := range events {
+		if err := writer.writeNext(event); err != nil {
+			_ = writer.flushAndClose()
+			return 0, errors.Trace(err)
+		}
+	}
+	dataSize := writer.dataSize()
+	return dataSize, errors.Trace(writer.flushAndClose())
+}
+
+// reallocOnDiskFull replaces the backEnd of the task, which fails to be
+// written because the disk of sort-dir is full.
+func (h *heapSorter) reallocOnDiskFull(ctx context.Context, task *flushTask, size int64, cause error) (backEnd, error) {
+	tableID, tableName := util.TableIDFromCtx(ctx)
+	log.Warn("Unified Sorter: failed to flush because the disk is full, retrying",
+		zap.Int64("table-id", tableID),
+		zap.String("table-name", tableName),
+		zap.Int("heap-id", task.heapSorterID),
+		zap.Error(cause))
+
+	if err := task.dealloc(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	backEnd, err := pool.allocOnDiskFull(ctx, size, h.canceller, cause)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if backEnd == nil {
+		backEnd, err = pool.allocOnDisk(ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	task.setBackEnd(backEnd)
+	return backEnd, nil
+}
+
 var (
 	heapSorterPool   workerpool.WorkerPool
 	heapSorterIOPool workerpool.AsyncPool
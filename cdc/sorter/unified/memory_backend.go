@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/memquota"
 	"go.uber.org/zap"
 )
 
@@ -26,12 +27,31 @@ type memoryBackEnd struct {
 	events        []*model.PolymorphicEvent
 	estimatedSize int64
 	borrowed      int32
+	// onDiskFull is true if the backEnd is allocated because the disk of
+	// sort-dir is full, its size is accounted in the disk-full-memory-quota.
+	onDiskFull bool
 }
 
 func newMemoryBackEnd() *memoryBackEnd {
 	return &memoryBackEnd{}
 }
 
+func newDiskFullMemoryBackEnd() *memoryBackEnd {
+	return &memoryBackEnd{onDiskFull: true}
+}
+
+// release releases the memory accounted for the backEnd.
+func (m *memoryBackEnd) release() {
+	if pool != nil {
+		atomic.AddInt64(&pool.memoryUseEstimate, -m.estimatedSize)
+		if m.onDiskFull {
+			atomic.AddInt64(&pool.diskFullMemoryUsage, -m.estimatedSize)
+		}
+		memquota.GlobalGovernor().Release(memquota.ComponentSorter, uint64(m.estimatedSize))
+	}
+	m.estimatedSize = 0
+}
+
 func (m *memoryBackEnd) reader() (backEndReader, error) {
 	failpoint.Inject("sorterDebug", func() {
 		if atomic.SwapInt32(&m.borrowed, 1) != 0 {
@@ -62,10 +82,7 @@ func (m *memoryBackEnd) free() error {
 		}
 	})
 
-	if pool != nil {
-		atomic.AddInt64(&pool.memoryUseEstimate, -m.estimatedSize)
-	}
-
+	m.release()
 	return nil
 }
 
@@ -92,11 +109,7 @@ func (r *memoryBackEndReader) resetAndClose() error {
 		atomic.StoreInt32(&r.backEnd.borrowed, 0)
 	})
 
-	if pool != nil {
-		atomic.AddInt64(&pool.memoryUseEstimate, -r.backEnd.estimatedSize)
-	}
-	r.backEnd.estimatedSize = 0
-
+	r.backEnd.release()
 	return nil
 }
 
@@ -140,6 +153,12 @@ func (w *memoryBackEndWriter) flushAndClose() error {
 	w.backEnd.estimatedSize = w.bytesWritten
 	if pool != nil {
 		atomic.AddInt64(&pool.memoryUseEstimate, w.bytesWritten)
+		if w.backEnd.onDiskFull {
+			atomic.AddInt64(&pool.diskFullMemoryUsage, w.bytesWritten)
+		}
+		// The events have been buffered, so the sorter is never blocked here.
+		// The governor throttles the sorter by spilling to files in alloc.
+		memquota.GlobalGovernor().ForceAcquire(memquota.ComponentSorter, uint64(w.bytesWritten))
 	}
 
 	return nil
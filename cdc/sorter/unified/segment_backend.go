@@ -0,0 +1,564 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unified
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sorter/encoding"
+	"github.com/pingcap/ticdc/pkg/compression"
+	"go.uber.org/zap"
+)
+
+const (
+	// segmentWriteBufferSize is the size of the data buffered by a writer
+	// before it is appended to the segments.
+	segmentWriteBufferSize = 64 * 1024 // 64KB
+	// segmentCompactionRatio is the ratio of the live data in a sealed
+	// segment, below which the segment is compacted.
+	segmentCompactionRatio = 0.5
+	// maxCompactedSegments is the maximum number of the segments compacted
+	// in a round of the background job.
+	maxCompactedSegments = 4
+)
+
+// segment is a file shared by the backEnds. The data is only appended to the
+// active segment, which is sealed once it is full. A segment is removed once
+// no extent refers to it, and a sealed segment with little live data is
+// compacted by moving its live extents to the active segment.
+type segment struct {
+	id       uint64
+	fileName string
+	f        *os.File
+
+	// the fields below are protected by segmentManager.mu
+	allocated int64
+	live      int64
+	sealed    bool
+	// owners records the live bytes of every backEnd in the segment
+	owners map[*segmentBackEnd]int64
+}
+
+// extent is a range of a segment.
+type extent struct {
+	seg    *segment
+	offset int64
+	length int64
+}
+
+// segmentManager manages the segments in the sort dir, the number of the
+// files opened is bounded by the size of the data on disk instead of the
+// number of the tables.
+type segmentManager struct {
+	filePrefix  string
+	segmentSize int64
+
+	mu       sync.Mutex
+	nextID   uint64
+	active   *segment
+	segments map[uint64]*segment
+	closed   bool
+}
+
+func newSegmentManager(filePrefix string, segmentSize int64) *segmentManager {
+	return &segmentManager{
+		filePrefix:  filePrefix,
+		segmentSize: segmentSize,
+		segments:    make(map[uint64]*segment),
+	}
+}
+
+// append writes the data to the active segments on behalf of the owner, and
+// returns the extents storing the data.
+func (m *segmentManager) append(owner *segmentBackEnd, data []byte) ([]extent, error) {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil, errors.New("segmentManager: closed")
+	}
+	var extents []extent
+	for remaining := int64(len(data)); remaining > 0; {
+		if m.active == nil || m.active.allocated >= m.segmentSize {
+			if err := m.rollLocked(); err != nil {
+				m.mu.Unlock()
+				m.release(owner, extents)
+				return nil, errors.Trace(err)
+			}
+		}
+		seg := m.active
+		length := m.segmentSize - seg.allocated
+		if length > remaining {
+			length = remaining
+		}
+		extents = append(extents, extent{seg: seg, offset: seg.allocated, length: length})
+		seg.allocated += length
+		seg.live += length
+		seg.owners[owner] += length
+		remaining -= length
+	}
+	m.mu.Unlock()
+
+	// the extents are written outside the lock, and the segments can't be
+	// removed since the extents are live.
+	pos := int64(0)
+	for _, ext := range extents {
+		_, err := ext.seg.f.WriteAt(data[pos:pos+ext.length], ext.offset)
+		if err != nil {
+			m.release(owner, extents)
+			return nil, errors.Trace(wrapIOError(err))
+		}
+		pos += ext.length
+	}
+	return extents, nil
+}
+
+// rollLocked seals the active segment and creates a new one.
+func (m *segmentManager) rollLocked() error {
+	if m.active != nil {
+		m.active.sealed = true
+		if m.active.live == 0 {
+			m.removeLocked(m.active)
+		}
+		m.active = nil
+	}
+	m.nextID++
+	fileName := fmt.Sprintf("%ssegment-%d.tmp", m.filePrefix, m.nextID)
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o644)
+	if err != nil {
+		return wrapIOError(err)
+	}
+	atomic.AddInt64(&openFDCount, 1)
+	log.Debug("Unified Sorter: segment created", zap.String("filename", fileName))
+
+	seg := &segment{
+		id:       m.nextID,
+		fileName: fileName,
+		f:        f,
+		owners:   make(map[*segmentBackEnd]int64),
+	}
+	m.segments[seg.id] = seg
+	m.active = seg
+	return nil
+}
+
+// release drops the references of the owner to the extents, the sealed
+// segments without any live data are removed.
+func (m *segmentManager) release(owner *segmentBackEnd, extents []extent) {
+	if len(extents) == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, ext := range extents {
+		seg := ext.seg
+		seg.live -= ext.length
+		seg.owners[owner] -= ext.length
+		if seg.owners[owner] <= 0 {
+			delete(seg.owners, owner)
+		}
+		if seg.live == 0 && seg.sealed {
+			m.removeLocked(seg)
+		}
+	}
+}
+
+func (m *segmentManager) removeLocked(seg *segment) {
+	if _, ok := m.segments[seg.id]; !ok {
+		return
+	}
+	delete(m.segments, seg.id)
+	if err := seg.f.Close(); err != nil {
+		log.Warn("Unified Sorter: failed to close segment", zap.String("filename", seg.fileName), zap.Error(err))
+	}
+	atomic.AddInt64(&openFDCount, -1)
+	if err := os.Remove(seg.fileName); err != nil {
+		log.Warn("Unified Sorter: failed to remove segment", zap.String("filename", seg.fileName), zap.Error(err))
+	}
+	log.Debug("Unified Sorter: segment removed", zap.String("filename", seg.fileName))
+}
+
+// compact moves the live extents of the sealed segments with little live
+// data to the active segment, so that the disk space of the segments can be
+// reclaimed. The extents of the backEnds being read or written are skipped.
+func (m *segmentManager) compact() {
+	type candidate struct {
+		seg    *segment
+		owners []*segmentBackEnd
+	}
+	var candidates []candidate
+	m.mu.Lock()
+	for _, seg := range m.segments {
+		if !seg.sealed || float64(seg.live) >= float64(seg.allocated)*segmentCompactionRatio {
+			continue
+		}
+		owners := make([]*segmentBackEnd, 0, len(seg.owners))
+		for owner := range seg.owners {
+			owners = append(owners, owner)
+		}
+		candidates = append(candidates, candidate{seg: seg, owners: owners})
+		if len(candidates) >= maxCompactedSegments {
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	for _, c := range candidates {
+		for _, owner := range c.owners {
+			if err := owner.relocate(c.seg); err != nil {
+				log.Warn("Unified Sorter: failed to compact segment",
+					zap.String("filename", c.seg.fileName), zap.Error(err))
+				return
+			}
+		}
+	}
+}
+
+// count returns the number of the segments.
+func (m *segmentManager) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.segments)
+}
+
+// close removes all the segments, no more data can be appended after it.
+func (m *segmentManager) close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	for _, seg := range m.segments {
+		m.removeLocked(seg)
+	}
+	m.active = nil
+}
+
+// segmentBackEnd stores the events in the extents of the shared segments
+// instead of a dedicated file.
+type segmentBackEnd struct {
+	manager *segmentManager
+	serde   encoding.SerializerDeserializer
+	// codec compresses every serialized event written to the segments
+	codec compression.Codec
+
+	mu        sync.Mutex
+	extents   []extent
+	numEvents uint64
+	// borrowed is true if the backEnd is being read or written, the extents
+	// of a borrowed backEnd are not relocated by compaction.
+	borrowed bool
+	size     int64
+}
+
+func newSegmentBackEnd(
+	manager *segmentManager, serde encoding.SerializerDeserializer, codec compression.Codec,
+) *segmentBackEnd {
+	return &segmentBackEnd{
+		manager: manager,
+		serde:   serde,
+		codec:   codec,
+	}
+}
+
+func (s *segmentBackEnd) borrow() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.borrowed {
+		failpoint.Inject("sorterDebug", func() {
+			log.Panic("segmentBackEnd: already borrowed")
+		})
+	}
+	s.borrowed = true
+}
+
+func (s *segmentBackEnd) giveBack() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.borrowed = false
+}
+
+func (s *segmentBackEnd) reader() (backEndReader, error) {
+	s.borrow()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	readers := make([]io.Reader, 0, len(s.extents))
+	for _, ext := range s.extents {
+		readers = append(readers, io.NewSectionReader(ext.seg.f, ext.offset, ext.length))
+	}
+	return &segmentBackEndReader{
+		backEnd:     s,
+		reader:      bufio.NewReaderSize(io.MultiReader(readers...), fileBufferSize),
+		totalEvents: s.numEvents,
+	}, nil
+}
+
+func (s *segmentBackEnd) writer() (backEndWriter, error) {
+	s.borrow()
+	// the data written before is dropped, the same as truncating the file
+	s.reset()
+	return &segmentBackEndWriter{
+		backEnd: s,
+		buf:     make([]byte, 0, segmentWriteBufferSize),
+	}, nil
+}
+
+func (s *segmentBackEnd) free() error {
+	failpoint.Inject("sorterDebug", func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.borrowed {
+			log.Panic("segmentBackEnd: trying to free borrowed backEnd")
+		}
+	})
+	s.reset()
+	return nil
+}
+
+// reset releases the extents of the backEnd.
+func (s *segmentBackEnd) reset() {
+	s.mu.Lock()
+	extents := s.extents
+	s.extents = nil
+	s.numEvents = 0
+	s.mu.Unlock()
+	s.manager.release(s, extents)
+	s.cleanStats()
+}
+
+func (s *segmentBackEnd) cleanStats() {
+	if pool != nil {
+		atomic.AddInt64(&pool.onDiskDataSize, -atomic.SwapInt64(&s.size, 0))
+	}
+}
+
+// appendExtents appends the extents to the backEnd, the adjacent extents
+// are merged.
+func (s *segmentBackEnd) appendExtents(extents []extent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ext := range extents {
+		if n := len(s.extents); n > 0 {
+			last := &s.extents[n-1]
+			if last.seg == ext.seg && last.offset+last.length == ext.offset {
+				last.length += ext.length
+				continue
+			}
+		}
+		s.extents = append(s.extents, ext)
+	}
+}
+
+// relocate moves the extents of the backEnd in the segment to the active
+// segment, it does nothing if the backEnd is borrowed.
+func (s *segmentBackEnd) relocate(seg *segment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.borrowed {
+		return nil
+	}
+	// the extents are replaced only if all of them are copied successfully
+	var moved, appended, relocated []extent
+	for _, ext := range s.extents {
+		if ext.seg != seg {
+			relocated = append(relocated, ext)
+			continue
+		}
+		buf := make([]byte, ext.length)
+		if _, err := ext.seg.f.ReadAt(buf, ext.offset); err != nil {
+			s.manager.release(s, appended)
+			return errors.Trace(wrapIOError(err))
+		}
+		extents, err := s.manager.append(s, buf)
+		if err != nil {
+			s.manager.release(s, appended)
+			return errors.Trace(err)
+		}
+		appended = append(appended, extents...)
+		relocated = append(relocated, extents...)
+		moved = append(moved, ext)
+	}
+	s.extents = relocated
+	s.manager.release(s, moved)
+	return nil
+}
+
+type segmentBackEndReader struct {
+	backEnd *segmentBackEnd
+	reader  *bufio.Reader
+	isEOF   bool
+	closed  bool
+
+	// to prevent truncation-like corruption
+	totalEvents uint64
+	readEvents  uint64
+}
+
+func (r *segmentBackEndReader) readNext() (*model.PolymorphicEvent, error) {
+	if r.isEOF {
+		// guaranteed EOF idempotency
+		return nil, nil
+	}
+
+	var m uint32
+	err := binary.Read(r.reader, binary.LittleEndian, &m)
+	if err != nil {
+		if err == io.EOF {
+			r.isEOF = true
+			if r.totalEvents != r.readEvents {
+				log.Panic("unexpected EOF",
+					zap.Uint64("expected-num-events", r.totalEvents),
+					zap.Uint64("actual-num-events", r.readEvents))
+			}
+			return nil, nil
+		}
+		return nil, errors.Trace(wrapIOError(err))
+	}
+	if m != blockMagic {
+		log.Panic("segmentBackEnd: wrong blockMagic. Damaged file or bug?", zap.Uint32("actual", m))
+	}
+
+	var size uint32
+	err = binary.Read(r.reader, binary.LittleEndian, &size)
+	if err != nil {
+		return nil, errors.Trace(wrapIOError(err))
+	}
+
+	// Note, do not hold the buffer in reader to avoid hogging memory.
+	rawBytesBuf := make([]byte, size)
+	_, err = io.ReadFull(r.reader, rawBytesBuf)
+	if err != nil {
+		return nil, errors.Trace(wrapIOError(err))
+	}
+
+	rawBytesBuf, err = r.backEnd.codec.Decompress(nil, rawBytesBuf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	event := new(model.PolymorphicEvent)
+	_, err = r.backEnd.serde.Unmarshal(event, rawBytesBuf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r.readEvents++
+	return event, nil
+}
+
+func (r *segmentBackEndReader) resetAndClose() error {
+	if r.closed {
+		failpoint.Inject("sorterDebug", func() {
+			log.Panic("Double closing of segmentBackEndReader")
+		})
+		log.Warn("Double closing of segmentBackEndReader")
+		return nil
+	}
+	r.closed = true
+	r.backEnd.reset()
+	r.backEnd.giveBack()
+	return nil
+}
+
+type segmentBackEndWriter struct {
+	backEnd *segmentBackEnd
+	buf     []byte
+	closed  bool
+
+	bytesWritten  int64
+	eventsWritten int64
+}
+
+func (w *segmentBackEndWriter) writeNext(event *model.PolymorphicEvent) error {
+	var err error
+	// Note, do not hold the buffer in writer to avoid hogging memory.
+	var rawBytesBuf []byte
+	rawBytesBuf, err = w.backEnd.serde.Marshal(event, rawBytesBuf)
+	if err != nil {
+		return errors.Trace(wrapIOError(err))
+	}
+
+	if len(rawBytesBuf) == 0 {
+		log.Panic("segmentBackEnd: serialized to empty byte array. Bug?")
+	}
+
+	rawBytesBuf, err = w.backEnd.codec.Compress(nil, rawBytesBuf)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	size := len(rawBytesBuf)
+
+	var header [8]byte
+	binary.LittleEndian.PutUint32(header[:4], blockMagic)
+	binary.LittleEndian.PutUint32(header[4:], uint32(size))
+	w.buf = append(w.buf, header[:]...)
+	w.buf = append(w.buf, rawBytesBuf...)
+	if len(w.buf) >= segmentWriteBufferSize {
+		if err := w.flush(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	w.eventsWritten++
+	w.bytesWritten += int64(size)
+	return nil
+}
+
+func (w *segmentBackEndWriter) flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	extents, err := w.backEnd.manager.append(w.backEnd, w.buf)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.backEnd.appendExtents(extents)
+	w.buf = w.buf[:0]
+	return nil
+}
+
+func (w *segmentBackEndWriter) writtenCount() int {
+	return int(w.eventsWritten)
+}
+
+func (w *segmentBackEndWriter) dataSize() uint64 {
+	return uint64(w.bytesWritten)
+}
+
+func (w *segmentBackEndWriter) flushAndClose() error {
+	if w.closed {
+		log.Panic("Double closing of segmentBackEndWriter")
+	}
+	w.closed = true
+	defer w.backEnd.giveBack()
+
+	err := w.flush()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.buf = nil
+
+	w.backEnd.mu.Lock()
+	w.backEnd.numEvents = uint64(w.eventsWritten)
+	w.backEnd.mu.Unlock()
+	atomic.StoreInt64(&w.backEnd.size, w.bytesWritten)
+	atomic.AddInt64(&pool.onDiskDataSize, w.bytesWritten)
+	return nil
+}
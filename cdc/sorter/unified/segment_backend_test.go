@@ -0,0 +1,195 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unified
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sorter/encoding"
+	"github.com/pingcap/ticdc/pkg/compression"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+type segmentBackendSuite struct{}
+
+var _ = check.SerialSuites(&segmentBackendSuite{})
+
+func newTestSegmentBackEnds(c *check.C, manager *segmentManager, n int) []*segmentBackEnd {
+	codec, err := compression.New(compression.None)
+	c.Assert(err, check.IsNil)
+	backEnds := make([]*segmentBackEnd, n)
+	for i := range backEnds {
+		backEnds[i] = newSegmentBackEnd(manager, &encoding.MsgPackGenSerde{}, codec)
+	}
+	return backEnds
+}
+
+// writeInterleaved writes the events to the backEnds in turn, so that the
+// extents of the backEnds are interleaved in the segments.
+func writeInterleaved(c *check.C, backEnds []*segmentBackEnd, numEvents int) {
+	writers := make([]backEndWriter, len(backEnds))
+	for i, b := range backEnds {
+		w, err := b.writer()
+		c.Assert(err, check.IsNil)
+		writers[i] = w
+	}
+	for ts := 1; ts <= numEvents; ts++ {
+		for _, w := range writers {
+			err := w.writeNext(model.NewPolymorphicEvent(generateMockRawKV(uint64(ts))))
+			c.Assert(err, check.IsNil)
+			// flush every event to interleave the extents
+			c.Assert(w.(*segmentBackEndWriter).flush(), check.IsNil)
+		}
+	}
+	for _, w := range writers {
+		c.Assert(w.writtenCount(), check.Equals, numEvents)
+		c.Assert(w.flushAndClose(), check.IsNil)
+	}
+}
+
+func readAndCheck(c *check.C, b *segmentBackEnd, numEvents int) {
+	r, err := b.reader()
+	c.Assert(err, check.IsNil)
+	for ts := 1; ts <= numEvents; ts++ {
+		event, err := r.readNext()
+		c.Assert(err, check.IsNil)
+		c.Assert(event.CRTs, check.Equals, uint64(ts))
+	}
+	event, err := r.readNext()
+	c.Assert(err, check.IsNil)
+	c.Assert(event, check.IsNil)
+	c.Assert(r.resetAndClose(), check.IsNil)
+}
+
+func segmentFiles(c *check.C, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*segment-*"))
+	c.Assert(err, check.IsNil)
+	return files
+}
+
+func (s *segmentBackendSuite) TestReadWrite(c *check.C) {
+	defer testleak.AfterTest(c)()
+	pool = &backEndPool{}
+	defer func() { pool = nil }()
+
+	dir := c.MkDir()
+	manager := newSegmentManager(dir+"/sort-1-", 4096)
+	defer manager.close()
+	backEnds := newTestSegmentBackEnds(c, manager, 2)
+	writeInterleaved(c, backEnds, 200)
+	c.Assert(manager.count(), check.Greater, 1)
+	c.Assert(segmentFiles(c, dir), check.HasLen, manager.count())
+
+	for _, b := range backEnds {
+		readAndCheck(c, b, 200)
+	}
+	// the sealed segments are removed once the data is read, and only the
+	// active segment is left.
+	c.Assert(manager.count(), check.Equals, 1)
+	c.Assert(segmentFiles(c, dir), check.HasLen, 1)
+	c.Assert(pool.onDiskDataSize, check.Equals, int64(0))
+
+	// the backEnd can be written again
+	writeInterleaved(c, backEnds[:1], 10)
+	readAndCheck(c, backEnds[0], 10)
+	c.Assert(backEnds[1].free(), check.IsNil)
+}
+
+func (s *segmentBackendSuite) TestRewriteDropsData(c *check.C) {
+	defer testleak.AfterTest(c)()
+	pool = &backEndPool{}
+	defer func() { pool = nil }()
+
+	manager := newSegmentManager(c.MkDir()+"/sort-1-", 4096)
+	defer manager.close()
+	backEnds := newTestSegmentBackEnds(c, manager, 1)
+	writeInterleaved(c, backEnds, 100)
+	writeInterleaved(c, backEnds, 10)
+	readAndCheck(c, backEnds[0], 10)
+	c.Assert(manager.count(), check.Equals, 1)
+}
+
+func (s *segmentBackendSuite) TestCompaction(c *check.C) {
+	defer testleak.AfterTest(c)()
+	pool = &backEndPool{}
+	defer func() { pool = nil }()
+
+	manager := newSegmentManager(c.MkDir()+"/sort-1-", 4096)
+	defer manager.close()
+	backEnds := newTestSegmentBackEnds(c, manager, 3)
+	writeInterleaved(c, backEnds, 50)
+	numSegments := manager.count()
+	c.Assert(numSegments, check.Greater, 2)
+
+	// only a third of the data is live after two of the backEnds are freed
+	c.Assert(backEnds[0].free(), check.IsNil)
+	c.Assert(backEnds[1].free(), check.IsNil)
+	c.Assert(manager.count(), check.Equals, numSegments)
+
+	// the extents of a borrowed backEnd are not moved
+	r, err := backEnds[2].reader()
+	c.Assert(err, check.IsNil)
+	manager.compact()
+	c.Assert(manager.count(), check.Equals, numSegments)
+	c.Assert(r.resetAndClose(), check.IsNil)
+
+	writeInterleaved(c, backEnds, 50)
+	c.Assert(backEnds[0].free(), check.IsNil)
+	c.Assert(backEnds[1].free(), check.IsNil)
+	numSegments = manager.count()
+	for i := 0; i < numSegments; i++ {
+		manager.compact()
+	}
+	c.Assert(manager.count(), check.Less, numSegments)
+	readAndCheck(c, backEnds[2], 50)
+}
+
+func (s *segmentBackendSuite) TestBackEndPool(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	dataDir := c.MkDir()
+	sortDir := filepath.Join(dataDir, config.DefaultSortDir)
+	err := os.MkdirAll(sortDir, 0o755)
+	c.Assert(err, check.IsNil)
+
+	conf := config.GetDefaultServerConfig()
+	conf.DataDir = dataDir
+	conf.Sorter.SortDir = sortDir
+	conf.Sorter.MaxMemoryPressure = 0 // force using files
+	conf.Sorter.SegmentSize = 1024 * 1024
+	config.StoreGlobalServerConfig(conf)
+
+	backEndPool, err := newBackEndPool(sortDir, "")
+	c.Assert(err, check.IsNil)
+	pool = backEndPool
+	defer func() { pool = nil }()
+
+	backEnd, err := backEndPool.alloc(context.Background(), 0, nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(backEnd, check.FitsTypeOf, &segmentBackEnd{})
+	w, err := backEnd.writer()
+	c.Assert(err, check.IsNil)
+	c.Assert(w.writeNext(model.NewPolymorphicEvent(generateMockRawKV(1))), check.IsNil)
+	c.Assert(w.flushAndClose(), check.IsNil)
+	c.Assert(segmentFiles(c, sortDir), check.HasLen, 1)
+	c.Assert(backEndPool.dealloc(backEnd), check.IsNil)
+
+	backEndPool.terminate()
+	c.Assert(segmentFiles(c, sortDir), check.HasLen, 0)
+}
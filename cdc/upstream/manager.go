@@ -0,0 +1,162 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/kv"
+	"github.com/pingcap/ticdc/cdc/model"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	tidbkv "github.com/pingcap/tidb/kv"
+	pd "github.com/tikv/pd/client"
+	"go.uber.org/zap"
+)
+
+const initRetryInterval = 5 * time.Second
+
+// Manager manages the upstreams of the changefeeds in a server. The upstreams
+// are shared by the changefeeds replicating from the same cluster, they are
+// created on the first acquisition and closed after the last release.
+type Manager struct {
+	ctx             context.Context
+	defaultUpstream *Upstream
+
+	mu        sync.Mutex
+	upstreams map[string]*Upstream
+	closed    bool
+
+	initUpstream func(ctx context.Context, up *Upstream, defaultUpstream *Upstream) error
+}
+
+// NewManager creates a new Manager, the clients of the server are used by the
+// default upstream, and they are not closed by the Manager.
+func NewManager(
+	ctx context.Context, pdClient pd.Client, kvStorage tidbkv.Storage, grpcPool kv.GrpcPool,
+) *Manager {
+	return &Manager{
+		ctx:             ctx,
+		defaultUpstream: newReadyUpstream(model.DefaultUpstreamID, pdClient, kvStorage, grpcPool),
+		upstreams:       make(map[string]*Upstream),
+		initUpstream:    initUpstream,
+	}
+}
+
+// NewManager4Test creates a new Manager for test, all the upstreams share the
+// given PD client and become ready immediately.
+func NewManager4Test(pdClient pd.Client) *Manager {
+	m := NewManager(context.Background(), pdClient, nil, nil)
+	m.initUpstream = func(ctx context.Context, up *Upstream, defaultUpstream *Upstream) error {
+		up.PDClient = defaultUpstream.PDClient
+		up.shared = true
+		return nil
+	}
+	return m
+}
+
+// Default returns the default upstream.
+func (m *Manager) Default() *Upstream {
+	return m.defaultUpstream
+}
+
+// Acquire returns the upstream of the info and holds a reference to it, the
+// upstream should be released by Release after use. A new upstream is created
+// in the background, and it is not ready until its clients are created.
+func (m *Manager) Acquire(info *model.UpstreamInfo) *Upstream {
+	id := info.ID()
+	if id == model.DefaultUpstreamID {
+		return m.defaultUpstream
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if up, ok := m.upstreams[id]; ok {
+		up.refs++
+		return up
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	up := &Upstream{
+		ID:      id,
+		info:    info,
+		refs:    1,
+		readyCh: make(chan struct{}),
+		cancel:  cancel,
+	}
+	if m.closed {
+		// the upstream never becomes ready
+		cancel()
+		return up
+	}
+	m.upstreams[id] = up
+	up.wg.Add(1)
+	go func() {
+		defer up.wg.Done()
+		m.runInit(ctx, up)
+	}()
+	return up
+}
+
+func (m *Manager) runInit(ctx context.Context, up *Upstream) {
+	for {
+		err := m.initUpstream(ctx, up, m.defaultUpstream)
+		if err == nil {
+			log.Info("upstream is ready", zap.String("upstream", up.ID), zap.Bool("shared", up.shared))
+			close(up.readyCh)
+			return
+		}
+		if cerror.ErrInvalidUpstream.Equal(err) {
+			// the upstream never becomes ready, retrying doesn't help.
+			log.Error("invalid upstream", zap.String("upstream", up.ID), zap.Error(err))
+			return
+		}
+		log.Warn("failed to init upstream, retry later", zap.String("upstream", up.ID), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(initRetryInterval):
+		}
+	}
+}
+
+// Release releases a reference to the upstream, the upstream is closed in the
+// background once it is not referenced.
+func (m *Manager) Release(up *Upstream) {
+	if up == nil || up == m.defaultUpstream {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	up.refs--
+	if up.refs > 0 {
+		return
+	}
+	if m.upstreams[up.ID] == up {
+		delete(m.upstreams, up.ID)
+	}
+	go up.close()
+}
+
+// Close closes all the upstreams except the default one.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	upstreams := m.upstreams
+	m.upstreams = make(map[string]*Upstream)
+	m.closed = true
+	m.mu.Unlock()
+	for _, up := range upstreams {
+		up.close()
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerDefaultUpstream(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager4Test(nil)
+	defer m.Close()
+	up := m.Acquire(nil)
+	require.Equal(t, m.Default(), up)
+	require.Equal(t, model.DefaultUpstreamID, up.ID)
+	require.True(t, up.IsReady())
+	m.Release(up)
+	require.True(t, up.IsReady())
+}
+
+func TestManagerAcquireRelease(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager4Test(nil)
+	defer m.Close()
+	initCh := make(chan struct{})
+	m.initUpstream = func(ctx context.Context, up *Upstream, defaultUpstream *Upstream) error {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-initCh:
+		}
+		up.shared = true
+		return nil
+	}
+
+	info := &model.UpstreamInfo{PDAddrs: []string{"http://pd1:2379"}}
+	up1 := m.Acquire(info)
+	require.False(t, up1.IsReady())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	require.Regexp(t, "context deadline exceeded", up1.WaitReady(ctx))
+	cancel()
+
+	// the upstreams with the same PD addresses are shared
+	up2 := m.Acquire(&model.UpstreamInfo{PDAddrs: []string{"http://pd1:2379/"}})
+	require.Equal(t, up1, up2)
+
+	close(initCh)
+	require.Nil(t, up1.WaitReady(context.Background()))
+
+	m.Release(up1)
+	m.mu.Lock()
+	require.Len(t, m.upstreams, 1)
+	m.mu.Unlock()
+	m.Release(up2)
+	m.mu.Lock()
+	require.Len(t, m.upstreams, 0)
+	m.mu.Unlock()
+
+	// a released upstream is created again on the next acquisition
+	up3 := m.Acquire(info)
+	require.NotEqual(t, up1, up3)
+	require.Nil(t, up3.WaitReady(context.Background()))
+	m.Release(up3)
+}
+
+func TestManagerClose(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager4Test(nil)
+	m.initUpstream = func(ctx context.Context, up *Upstream, defaultUpstream *Upstream) error {
+		return errors.New("unavailable")
+	}
+	up := m.Acquire(&model.UpstreamInfo{PDAddrs: []string{"http://pd1:2379"}})
+	// the retrying initialization is canceled by closing the manager
+	m.Close()
+	require.False(t, up.IsReady())
+
+	// the upstreams acquired after closing never become ready
+	up = m.Acquire(&model.UpstreamInfo{PDAddrs: []string{"http://pd2:2379"}})
+	require.False(t, up.IsReady())
+	m.Release(up)
+}
+
+func TestManagerInvalidCredential(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager4Test(nil)
+	defer m.Close()
+	initCh := make(chan struct{}, 2)
+	m.initUpstream = func(ctx context.Context, up *Upstream, defaultUpstream *Upstream) error {
+		initCh <- struct{}{}
+		return initUpstream(ctx, up, defaultUpstream)
+	}
+	// the server has no TLS credential, the upstream with TLS is rejected
+	// before creating any client, and the initialization is not retried.
+	up := m.Acquire(&model.UpstreamInfo{
+		PDAddrs:  []string{"https://pd1:2379"},
+		CAPath:   "ca.pem",
+		CertPath: "cert.pem",
+		KeyPath:  "key.pem",
+	})
+	<-initCh
+	up.wg.Wait()
+	require.False(t, up.IsReady())
+	require.Len(t, initCh, 0)
+	m.Release(up)
+}
@@ -0,0 +1,188 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstream
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/kv"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/security"
+	"github.com/pingcap/ticdc/pkg/version"
+	tidbkv "github.com/pingcap/tidb/kv"
+	pd "github.com/tikv/pd/client"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+)
+
+// Upstream holds the clients of an upstream cluster. The clients are only
+// available after the upstream is ready.
+type Upstream struct {
+	ID        string
+	PDClient  pd.Client
+	KVStorage tidbkv.Storage
+	GrpcPool  kv.GrpcPool
+
+	info *model.UpstreamInfo
+	// refs is the number of the holders of the upstream, guarded by the lock
+	// of the Manager.
+	refs int
+	// shared is true if the clients are shared with the default upstream,
+	// which means the upstream info points to the cluster of the server.
+	shared bool
+
+	readyCh   chan struct{}
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+func newReadyUpstream(id string, pdClient pd.Client, kvStorage tidbkv.Storage, grpcPool kv.GrpcPool) *Upstream {
+	up := &Upstream{
+		ID:        id,
+		PDClient:  pdClient,
+		KVStorage: kvStorage,
+		GrpcPool:  grpcPool,
+		readyCh:   make(chan struct{}),
+		cancel:    func() {},
+	}
+	close(up.readyCh)
+	return up
+}
+
+// IsReady returns whether the clients of the upstream are available.
+func (up *Upstream) IsReady() bool {
+	select {
+	case <-up.readyCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// WaitReady waits until the upstream is ready or the context is done.
+func (up *Upstream) WaitReady(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-up.readyCh:
+		return nil
+	}
+}
+
+func (up *Upstream) close() {
+	up.closeOnce.Do(func() {
+		up.cancel()
+		up.wg.Wait()
+		if !up.IsReady() || up.shared {
+			return
+		}
+		up.GrpcPool.Close()
+		if err := up.KVStorage.Close(); err != nil {
+			log.Warn("failed to close the kv storage of the upstream", zap.String("upstream", up.ID), zap.Error(err))
+		}
+		up.PDClient.Close()
+		log.Info("upstream closed", zap.String("upstream", up.ID))
+	})
+}
+
+func credentialOf(info *model.UpstreamInfo) *security.Credential {
+	return &security.Credential{
+		CAPath:   info.CAPath,
+		CertPath: info.CertPath,
+		KeyPath:  info.KeyPath,
+	}
+}
+
+// NewPDClient creates a PD client of the upstream cluster.
+func NewPDClient(ctx context.Context, pdAddrs []string, credential *security.Credential) (pd.Client, error) {
+	grpcTLSOption, err := credential.ToGRPCDialOption()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pdClient, err := pd.NewClientWithContext(
+		ctx, pdAddrs, credential.PDSecurityOption(),
+		pd.WithGRPCDialOptions(
+			grpcTLSOption,
+			grpc.WithBlock(),
+			grpc.WithConnectParams(grpc.ConnectParams{
+				Backoff: backoff.Config{
+					BaseDelay:  time.Second,
+					Multiplier: 1.1,
+					Jitter:     0.1,
+					MaxDelay:   3 * time.Second,
+				},
+				MinConnectTimeout: 3 * time.Second,
+			}),
+		))
+	if err != nil {
+		return nil, errors.Annotatef(err, "fail to open PD client, pd=\"%s\"", strings.Join(pdAddrs, ","))
+	}
+	return pdClient, nil
+}
+
+// initUpstream creates the clients of the upstream.
+func initUpstream(ctx context.Context, up *Upstream, defaultUpstream *Upstream) error {
+	// the kv storage can't be created with a different credential, see
+	// UpstreamInfo.CheckCredential.
+	if err := up.info.CheckCredential(config.GetGlobalServerConfig().Security); err != nil {
+		return errors.Trace(err)
+	}
+	credential := credentialOf(up.info)
+	pdClient, err := NewPDClient(ctx, up.info.PDAddrs, credential)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if defaultUpstream.PDClient != nil &&
+		pdClient.GetClusterID(ctx) == defaultUpstream.PDClient.GetClusterID(ctx) {
+		// The kv storages of the same cluster are cached and shared in a
+		// process, so the clients of the default upstream are reused.
+		pdClient.Close()
+		up.PDClient = defaultUpstream.PDClient
+		up.KVStorage = defaultUpstream.KVStorage
+		up.GrpcPool = defaultUpstream.GrpcPool
+		up.shared = true
+		return nil
+	}
+	// To not block replicating, we need to warn instead of error when TiKV
+	// is incompatible.
+	errorTiKVIncompatible := false
+	err = version.CheckClusterVersion(ctx, pdClient, up.info.PDAddrs, credential, errorTiKVIncompatible)
+	if err != nil {
+		pdClient.Close()
+		return errors.Trace(err)
+	}
+	kvStorage, err := kv.CreateTiStore(strings.Join(up.info.PDAddrs, ","), credential)
+	if err != nil {
+		pdClient.Close()
+		return errors.Trace(err)
+	}
+	grpcPool := kv.NewGrpcPoolImpl(ctx, credential)
+	up.wg.Add(1)
+	go func() {
+		defer up.wg.Done()
+		grpcPool.RecycleConn(ctx)
+	}()
+	up.PDClient = pdClient
+	up.KVStorage = kvStorage
+	up.GrpcPool = grpcPool
+	return nil
+}
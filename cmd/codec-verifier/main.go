@@ -0,0 +1,276 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// codec-verifier consumes the messages sent by TiCDC to a Kafka topic, and
+// verifies that each message is decoded and encoded back into the same bytes.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sink/codec"
+	"github.com/pingcap/ticdc/pkg/logutil"
+	"github.com/pingcap/ticdc/pkg/security"
+	"go.uber.org/zap"
+)
+
+var (
+	kafkaAddrs    []string
+	kafkaTopic    string
+	kafkaVersion  = "2.4.0"
+	protocol      = codec.ProtocolMaxwell
+	logPath       string
+	logLevel      string
+	ca, cert, key string
+)
+
+func init() {
+	var upstreamURIStr string
+
+	flag.StringVar(&upstreamURIStr, "upstream-uri", "", "Kafka uri, the protocol is specified by the protocol parameter")
+	flag.StringVar(&logPath, "log-file", "cdc_codec_verifier.log", "log file path")
+	flag.StringVar(&logLevel, "log-level", "info", "log level")
+	flag.StringVar(&ca, "ca", "", "CA certificate path for Kafka SSL connection")
+	flag.StringVar(&cert, "cert", "", "Certificate path for Kafka SSL connection")
+	flag.StringVar(&key, "key", "", "Private key path for Kafka SSL connection")
+	flag.Parse()
+
+	err := logutil.InitLogger(&logutil.Config{
+		Level: logLevel,
+		File:  logPath,
+	})
+	if err != nil {
+		log.Fatal("init logger failed", zap.Error(err))
+	}
+
+	upstreamURI, err := url.Parse(upstreamURIStr)
+	if err != nil {
+		log.Fatal("invalid upstream-uri", zap.Error(err))
+	}
+	if strings.ToLower(upstreamURI.Scheme) != "kafka" {
+		log.Fatal("invalid upstream-uri scheme, the scheme of upstream-uri must be `kafka`", zap.String("upstream-uri", upstreamURIStr))
+	}
+	if s := upstreamURI.Query().Get("version"); s != "" {
+		kafkaVersion = s
+	}
+	if s := upstreamURI.Query().Get("protocol"); s != "" {
+		protocol.FromString(s)
+	}
+	kafkaTopic = strings.TrimFunc(upstreamURI.Path, func(r rune) bool {
+		return r == '/'
+	})
+	kafkaAddrs = strings.Split(upstreamURI.Host, ",")
+}
+
+func newSaramaConfig() (*sarama.Config, error) {
+	config := sarama.NewConfig()
+
+	version, err := sarama.ParseKafkaVersion(kafkaVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	config.ClientID = "ticdc_codec_verifier"
+	config.Version = version
+
+	config.Metadata.Retry.Max = 10000
+	config.Metadata.Retry.Backoff = 500 * time.Millisecond
+	config.Consumer.Retry.Backoff = 500 * time.Millisecond
+
+	if len(ca) != 0 {
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config, err = (&security.Credential{
+			CAPath:   ca,
+			CertPath: cert,
+			KeyPath:  key,
+		}).ToTLSConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	return config, err
+}
+
+func newDecoder(key, value []byte) (codec.EventBatchDecoder, error) {
+	switch protocol {
+	case codec.ProtocolMaxwell:
+		return codec.NewMaxwellEventBatchDecoder(key, value)
+	default:
+		return nil, errors.Errorf("protocol %d is not supported by the verifier", protocol)
+	}
+}
+
+// verifyMessage decodes the events in the message, and encodes them into the
+// value again, which should be the same as the value of the message.
+func verifyMessage(ctx context.Context, encoderBuilder codec.EncoderBuilder, key, value []byte) error {
+	decoder, err := newDecoder(key, value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	encoder, err := encoderBuilder.Build(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var encoded []byte
+	for {
+		tp, hasNext, err := decoder.HasNext()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !hasNext {
+			break
+		}
+		var msg *codec.MQMessage
+		switch tp {
+		case model.MqMessageTypeRow:
+			row, err := decoder.NextRowChangedEvent()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if _, err := encoder.AppendRowChangedEvent(row); err != nil {
+				return errors.Trace(err)
+			}
+		case model.MqMessageTypeDDL:
+			ddl, err := decoder.NextDDLEvent()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if msg, err = encoder.EncodeDDLEvent(ddl); err != nil {
+				return errors.Trace(err)
+			}
+		case model.MqMessageTypeResolved:
+			ts, err := decoder.NextResolvedEvent()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if msg, err = encoder.EncodeCheckpointEvent(ts); err != nil {
+				return errors.Trace(err)
+			}
+		case model.MqMessageTypeTableStarted:
+			table, ts, err := decoder.NextTableStartedEvent()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if msg, err = encoder.EncodeTableStartedEvent(table, ts); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if msg != nil {
+			encoded = append(encoded, msg.Value...)
+		}
+	}
+	for _, msg := range encoder.Build() {
+		encoded = append(encoded, msg.Value...)
+	}
+	if !bytes.Equal(encoded, value) {
+		return errors.Errorf("the message is encoded into %s", encoded)
+	}
+	return nil
+}
+
+// verifyPartition verifies the messages in the partition which are sent
+// before the verifier starts.
+func verifyPartition(ctx context.Context, client sarama.Client, consumer sarama.Consumer,
+	encoderBuilder codec.EncoderBuilder, partition int32,
+) (verified, mismatched int, err error) {
+	oldest, err := client.GetOffset(kafkaTopic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	newest, err := client.GetOffset(kafkaTopic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	if oldest >= newest {
+		return 0, 0, nil
+	}
+	pc, err := consumer.ConsumePartition(kafkaTopic, partition, oldest)
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	defer pc.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return verified, mismatched, errors.Trace(ctx.Err())
+		case err := <-pc.Errors():
+			return verified, mismatched, errors.Trace(err)
+		case message := <-pc.Messages():
+			verified++
+			if err := verifyMessage(ctx, encoderBuilder, message.Key, message.Value); err != nil {
+				mismatched++
+				log.Warn("message verification failed",
+					zap.Int32("partition", partition),
+					zap.Int64("offset", message.Offset),
+					zap.ByteString("value", message.Value),
+					zap.Error(err))
+			}
+			if message.Offset >= newest-1 {
+				return verified, mismatched, nil
+			}
+		}
+	}
+}
+
+func main() {
+	log.Info("Starting a new TiCDC codec verifier", zap.String("topic", kafkaTopic))
+
+	config, err := newSaramaConfig()
+	if err != nil {
+		log.Fatal("Error creating sarama config", zap.Error(err))
+	}
+	encoderBuilder, err := codec.NewEventBatchEncoderBuilder(protocol, nil, map[string]string{})
+	if err != nil {
+		log.Fatal("Error creating encoder builder", zap.Error(err))
+	}
+	client, err := sarama.NewClient(kafkaAddrs, config)
+	if err != nil {
+		log.Fatal("Error creating kafka client", zap.Error(err))
+	}
+	defer client.Close()
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		log.Fatal("Error creating kafka consumer", zap.Error(err))
+	}
+	defer consumer.Close()
+	partitions, err := client.Partitions(kafkaTopic)
+	if err != nil {
+		log.Fatal("Error getting partitions", zap.String("topic", kafkaTopic), zap.Error(err))
+	}
+
+	ctx := context.Background()
+	var totalVerified, totalMismatched int
+	for _, partition := range partitions {
+		verified, mismatched, err := verifyPartition(ctx, client, consumer, encoderBuilder, partition)
+		if err != nil {
+			log.Fatal("Error verifying partition", zap.Int32("partition", partition), zap.Error(err))
+		}
+		totalVerified += verified
+		totalMismatched += mismatched
+	}
+	if totalMismatched != 0 {
+		log.Fatal("codec verification failed",
+			zap.Int("verified", totalVerified), zap.Int("mismatched", totalMismatched))
+	}
+	log.Info("codec verification succeeded", zap.Int("verified", totalVerified))
+}
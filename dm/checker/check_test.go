@@ -48,6 +48,7 @@ func ignoreExcept(itemMap map[string]struct{}) []string {
 		config.TableSchemaChecking,
 		config.ShardTableSchemaChecking,
 		config.ShardAutoIncrementIDChecking,
+		config.TableCompatibilityChecking,
 	}
 	ignoreCheckingItems := make([]string, 0, len(items)-len(itemMap))
 	for _, i := range items {
@@ -78,6 +78,10 @@ type Checker struct {
 	}
 	errCnt  int64
 	warnCnt int64
+
+	// crossCheck enables the checks which cross-check the task config with the
+	// live source and target databases, used by `check-task --connect`.
+	crossCheck bool
 }
 
 // NewChecker returns a checker.
@@ -120,6 +124,7 @@ func (c *Checker) Init(ctx context.Context) (err error) {
 	_, checkingShardID := c.checkingItems[config.ShardAutoIncrementIDChecking]
 	_, checkingShard := c.checkingItems[config.ShardTableSchemaChecking]
 	_, checkSchema := c.checkingItems[config.TableSchemaChecking]
+	_, checkCompatibility := c.checkingItems[config.TableCompatibilityChecking]
 
 	for _, instance := range c.instances {
 		bw, err := filter.New(instance.cfg.CaseSensitive, instance.cfg.BAList)
@@ -183,8 +188,17 @@ func (c *Checker) Init(ctx context.Context) (err error) {
 		if _, ok := c.checkingItems[config.ReplicationPrivilegeChecking]; ok {
 			c.checkList = append(c.checkList, check.NewSourceReplicationPrivilegeChecker(instance.sourceDB.DB, instance.sourceDBinfo))
 		}
+		if c.crossCheck {
+			if _, ok := c.checkingItems[config.ServerIDChecking]; ok && instance.cfg.ServerID != 0 {
+				c.checkList = append(c.checkList, newSourceServerIDChecker(instance.sourceDB.DB, instance.sourceDBinfo, instance.cfg.ServerID))
+			}
+			// all sub-tasks share the same target database
+			if _, ok := c.checkingItems[config.VersionChecking]; ok && instance == c.instances[0] {
+				c.checkList = append(c.checkList, newTargetTiDBVersionChecker(instance.targetDB.DB, instance.targetDBInfo))
+			}
+		}
 
-		if !checkingShard && !checkSchema {
+		if !checkingShard && !checkSchema && !checkCompatibility {
 			continue
 		}
 
@@ -221,6 +235,9 @@ func (c *Checker) Init(ctx context.Context) (err error) {
 		if checkSchema {
 			c.checkList = append(c.checkList, check.NewTablesChecker(instance.sourceDB.DB, instance.sourceDBinfo, checkTables))
 		}
+		if checkCompatibility {
+			c.checkList = append(c.checkList, newTableCompatibilityChecker(instance.sourceDB.DB, instance.sourceDBinfo, instance.targetDB.DB, checkTables))
+		}
 	}
 
 	if checkingShard {
@@ -19,6 +19,8 @@ import (
 	"github.com/pingcap/ticdc/dm/dm/config"
 	"github.com/pingcap/ticdc/dm/dm/pb"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
+
+	"github.com/pingcap/tidb-tools/pkg/check"
 )
 
 var (
@@ -27,31 +29,44 @@ var (
 
 	// CheckSyncConfigFunc holds the CheckSyncConfig function.
 	CheckSyncConfigFunc func(ctx context.Context, cfgs []*config.SubTaskConfig, errCnt, warnCnt int64) error
+
+	// CheckTaskReportFunc holds the CheckTaskReport function.
+	CheckTaskReportFunc func(ctx context.Context, cfgs []*config.SubTaskConfig) (*check.Results, error)
 )
 
 func init() {
 	CheckSyncConfigFunc = CheckSyncConfig
+	CheckTaskReportFunc = CheckTaskReport
 }
 
-// CheckSyncConfig checks synchronization configuration.
-func CheckSyncConfig(ctx context.Context, cfgs []*config.SubTaskConfig, errCnt, warnCnt int64) error {
-	if len(cfgs) == 0 {
-		return nil
-	}
-
+// checkingItemsOfTask returns the checking items of the sub-tasks.
+func checkingItemsOfTask(cfgs []*config.SubTaskConfig) map[string]string {
 	// all `IgnoreCheckingItems` and `Mode` of sub-task are same, so we take first one
-	// for ModeFull we don't need replication privilege; for ModeIncrement we don't need dump privilege
+	// for ModeFull and ModeDump we don't need replication privilege; for ModeIncrement we don't need dump privilege;
+	// for ModeLoad we don't need any privilege of the source; for ModeLoadSync we don't need dump privilege
 	ignoreCheckingItems := cfgs[0].IgnoreCheckingItems
 	// we directly append ignore checking items here which may cause duplicate in ignoreCheckingItems
 	// but in config.FilterCheckingItems we only use this to delete map's keys so it is tolerable to append directly here
 	switch cfgs[0].Mode {
-	case config.ModeFull:
+	case config.ModeFull, config.ModeDump:
 		ignoreCheckingItems = append(ignoreCheckingItems, config.ReplicationPrivilegeChecking,
 			config.BinlogEnableChecking, config.BinlogFormatChecking, config.BinlogRowImageChecking, config.ServerIDChecking)
-	case config.ModeIncrement:
+	case config.ModeLoad:
+		ignoreCheckingItems = append(ignoreCheckingItems, config.DumpPrivilegeChecking, config.ReplicationPrivilegeChecking,
+			config.BinlogEnableChecking, config.BinlogFormatChecking, config.BinlogRowImageChecking, config.ServerIDChecking)
+	case config.ModeIncrement, config.ModeLoadSync:
 		ignoreCheckingItems = append(ignoreCheckingItems, config.DumpPrivilegeChecking)
 	}
-	checkingItems := config.FilterCheckingItems(ignoreCheckingItems)
+	return config.FilterCheckingItems(ignoreCheckingItems)
+}
+
+// CheckSyncConfig checks synchronization configuration.
+func CheckSyncConfig(ctx context.Context, cfgs []*config.SubTaskConfig, errCnt, warnCnt int64) error {
+	if len(cfgs) == 0 {
+		return nil
+	}
+
+	checkingItems := checkingItemsOfTask(cfgs)
 	if len(checkingItems) == 0 {
 		return nil
 	}
@@ -75,3 +90,29 @@ func CheckSyncConfig(ctx context.Context, cfgs []*config.SubTaskConfig, errCnt,
 
 	return nil
 }
+
+// CheckTaskReport connects to the source and target databases of the sub-tasks and
+// returns the results of all checking items, including the passed ones. Besides the
+// checks of CheckSyncConfig, it cross-checks the server-id of DM with the live sources
+// and the compatibility of the target TiDB.
+func CheckTaskReport(ctx context.Context, cfgs []*config.SubTaskConfig) (*check.Results, error) {
+	if len(cfgs) == 0 {
+		return &check.Results{Summary: &check.ResultSummary{Passed: true}}, nil
+	}
+
+	c := NewChecker(cfgs, checkingItemsOfTask(cfgs), 0, 0)
+	c.crossCheck = true
+	if err := c.Init(ctx); err != nil {
+		return nil, terror.Annotate(err, "fail to initial checker")
+	}
+	defer c.Close()
+
+	cctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	result, err := check.Do(cctx, c.checkList)
+	if err != nil {
+		return nil, terror.ErrTaskCheckSyncConfigError.Generate(ErrorMsgHeader, err.Error(), "")
+	}
+	c.updateInstruction(result)
+	return result, nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/tidb-tools/pkg/check"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+)
+
+// unsupportedColumnTypes are the column types of MySQL which TiDB doesn't support.
+var unsupportedColumnTypes = map[string]struct{}{
+	"geometry":           {},
+	"point":              {},
+	"linestring":         {},
+	"polygon":            {},
+	"multipoint":         {},
+	"multilinestring":    {},
+	"multipolygon":       {},
+	"geometrycollection": {},
+	"geomcollection":     {},
+}
+
+// tableCompatibilityChecker checks the features of the source tables which are not
+// compatible with the target TiDB, including unsupported column types, charsets and
+// collations, generated columns, triggers and views, and reports a warning for each
+// table. foreign keys are checked by the table structure checker of `table_schema`.
+type tableCompatibilityChecker struct {
+	db       *sql.DB
+	dbinfo   *dbutil.DBConfig
+	targetDB *sql.DB
+	tables   map[string][]string // schema => [table1, table2, ...]
+}
+
+func newTableCompatibilityChecker(db *sql.DB, dbinfo *dbutil.DBConfig, targetDB *sql.DB, tables map[string][]string) check.Checker {
+	return &tableCompatibilityChecker{db: db, dbinfo: dbinfo, targetDB: targetDB, tables: tables}
+}
+
+// Check implements the Checker interface.
+func (c *tableCompatibilityChecker) Check(ctx context.Context) *check.Result {
+	result := &check.Result{
+		Name:  c.Name(),
+		Desc:  "check whether the features of the source tables are compatible with the target TiDB",
+		State: check.StateFailure,
+		Extra: fmt.Sprintf("address of db instance - %s:%d", c.dbinfo.Host, c.dbinfo.Port),
+	}
+
+	charsets, collations, err := getCollations(ctx, c.targetDB)
+	if err != nil {
+		result.Errors = append(result.Errors, check.NewError(err.Error()))
+		return result
+	}
+
+	schemas := make([]string, 0, len(c.tables))
+	for schema := range c.tables {
+		schemas = append(schemas, schema)
+	}
+	sort.Strings(schemas)
+
+	warn := func(name, msg, instruction string) {
+		result.Errors = append(result.Errors, &check.Error{
+			Severity:    check.StateWarning,
+			ShortErr:    fmt.Sprintf("table %s %s", name, msg),
+			Instruction: instruction,
+		})
+	}
+	for _, schema := range schemas {
+		tables := make(map[string]struct{}, len(c.tables[schema]))
+		for _, table := range c.tables[schema] {
+			tables[table] = struct{}{}
+		}
+
+		// the default collation of the tables
+		rows, err := c.db.QueryContext(ctx, "SELECT TABLE_NAME, IFNULL(TABLE_COLLATION, '') FROM information_schema.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE'", schema)
+		if err != nil {
+			result.Errors = append(result.Errors, check.NewError(err.Error()))
+			return result
+		}
+		err = forEachRow(rows, func(values []string) {
+			table, collation := values[0], values[1]
+			if _, ok := tables[table]; !ok || collation == "" {
+				return
+			}
+			if _, ok := collations[strings.ToLower(collation)]; !ok {
+				warn(dbutil.TableName(schema, table), fmt.Sprintf("uses collation %s which is not supported by the target", collation),
+					"please change the collation of the table, or create the table in the target manually")
+			}
+		})
+		if err != nil {
+			result.Errors = append(result.Errors, check.NewError(err.Error()))
+			return result
+		}
+
+		// column types, charsets, collations and generated columns
+		rows, err = c.db.QueryContext(ctx, "SELECT TABLE_NAME, COLUMN_NAME, DATA_TYPE, IFNULL(CHARACTER_SET_NAME, ''), IFNULL(COLLATION_NAME, ''), EXTRA FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? ORDER BY TABLE_NAME, ORDINAL_POSITION", schema)
+		if err != nil {
+			result.Errors = append(result.Errors, check.NewError(err.Error()))
+			return result
+		}
+		err = forEachRow(rows, func(values []string) {
+			table, column, tp, cs, collation, extra := values[0], values[1], values[2], values[3], values[4], strings.ToUpper(values[5])
+			if _, ok := tables[table]; !ok {
+				return
+			}
+			name := dbutil.TableName(schema, table)
+			if _, ok := unsupportedColumnTypes[strings.ToLower(tp)]; ok {
+				warn(name, fmt.Sprintf("column %s of type %s is not supported by TiDB", column, tp),
+					"please change the type of the column, or filter out the table")
+			}
+			if _, ok := charsets[strings.ToLower(cs)]; cs != "" && !ok {
+				warn(name, fmt.Sprintf("column %s uses charset %s which is not supported by the target", column, cs),
+					"please convert the charset of the column, or create the table in the target manually")
+			} else if _, ok = collations[strings.ToLower(collation)]; collation != "" && !ok {
+				warn(name, fmt.Sprintf("column %s uses collation %s which is not supported by the target", column, collation),
+					"please change the collation of the column, or create the table in the target manually")
+			}
+			if strings.Contains(extra, "GENERATED") {
+				warn(name, fmt.Sprintf("column %s is a %s column, whose value is computed by the target instead of migrated", column, strings.ToLower(extra)),
+					"please make sure the expression of the generated column is supported by TiDB")
+			}
+		})
+		if err != nil {
+			result.Errors = append(result.Errors, check.NewError(err.Error()))
+			return result
+		}
+
+		rows, err = c.db.QueryContext(ctx, "SELECT EVENT_OBJECT_TABLE, TRIGGER_NAME FROM information_schema.TRIGGERS WHERE TRIGGER_SCHEMA = ?", schema)
+		if err != nil {
+			result.Errors = append(result.Errors, check.NewError(err.Error()))
+			return result
+		}
+		err = forEachRow(rows, func(values []string) {
+			if _, ok := tables[values[0]]; ok {
+				warn(dbutil.TableName(schema, values[0]), fmt.Sprintf("has trigger %s which is not supported by TiDB and won't be migrated", values[1]),
+					"please implement the logic of the trigger in the application")
+			}
+		})
+		if err != nil {
+			result.Errors = append(result.Errors, check.NewError(err.Error()))
+			return result
+		}
+
+		rows, err = c.db.QueryContext(ctx, "SELECT TABLE_NAME FROM information_schema.VIEWS WHERE TABLE_SCHEMA = ?", schema)
+		if err != nil {
+			result.Errors = append(result.Errors, check.NewError(err.Error()))
+			return result
+		}
+		err = forEachRow(rows, func(values []string) {
+			warn(dbutil.TableName(schema, values[0]), "is a view which won't be migrated",
+				"please create the view in the target manually after the data is migrated")
+		})
+		if err != nil {
+			result.Errors = append(result.Errors, check.NewError(err.Error()))
+			return result
+		}
+	}
+
+	result.State = check.StateSuccess
+	if len(result.Errors) > 0 {
+		result.State = check.StateWarning
+	}
+	return result
+}
+
+// Name implements the Checker interface.
+func (c *tableCompatibilityChecker) Name() string {
+	return "table_compatibility"
+}
+
+// getCollations returns the supported charsets and collations of the database, in lower case.
+func getCollations(ctx context.Context, db *sql.DB) (map[string]struct{}, map[string]struct{}, error) {
+	rows, err := db.QueryContext(ctx, "SELECT COLLATION_NAME, CHARACTER_SET_NAME FROM information_schema.COLLATIONS")
+	if err != nil {
+		return nil, nil, err
+	}
+	charsets := make(map[string]struct{})
+	collations := make(map[string]struct{})
+	err = forEachRow(rows, func(values []string) {
+		collations[strings.ToLower(values[0])] = struct{}{}
+		charsets[strings.ToLower(values[1])] = struct{}{}
+	})
+	return charsets, collations, err
+}
+
+// forEachRow scans every row of rows as strings and calls fn with them, rows is closed after that.
+func forEachRow(rows *sql.Rows, fn func(values []string)) error {
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	values := make([]string, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return err
+		}
+		fn(values)
+	}
+	return rows.Err()
+}
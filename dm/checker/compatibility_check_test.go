@@ -0,0 +1,93 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	tc "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/check"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+)
+
+func (s *testCheckerSuite) TestTableCompatibilityChecker(c *tc.C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, tc.IsNil)
+	targetDB, targetMock, err := sqlmock.New()
+	c.Assert(err, tc.IsNil)
+	checker := newTableCompatibilityChecker(db, &dbutil.DBConfig{}, targetDB, map[string][]string{"db": {"t1", "t2"}})
+	ctx := context.Background()
+
+	mockCollations := func() {
+		targetMock.ExpectQuery("SELECT COLLATION_NAME, CHARACTER_SET_NAME FROM information_schema.COLLATIONS").
+			WillReturnRows(sqlmock.NewRows([]string{"COLLATION_NAME", "CHARACTER_SET_NAME"}).
+				AddRow("utf8mb4_bin", "utf8mb4").AddRow("utf8mb4_general_ci", "utf8mb4").AddRow("binary", "binary"))
+	}
+	mockTables := func(t1Collation string) {
+		mock.ExpectQuery("SELECT TABLE_NAME, .* FROM information_schema.TABLES").WithArgs("db").
+			WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME", "TABLE_COLLATION"}).
+				AddRow("t1", t1Collation).AddRow("t2", "utf8mb4_bin").AddRow("t3", "latin1_swedish_ci"))
+	}
+	columns := []string{"TABLE_NAME", "COLUMN_NAME", "DATA_TYPE", "CHARACTER_SET_NAME", "COLLATION_NAME", "EXTRA"}
+
+	// all compatible
+	mockCollations()
+	mockTables("utf8mb4_general_ci")
+	mock.ExpectQuery("SELECT TABLE_NAME, COLUMN_NAME, .* FROM information_schema.COLUMNS").WithArgs("db").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("t1", "id", "int", "", "", "").
+			AddRow("t1", "name", "varchar", "utf8mb4", "utf8mb4_bin", "").
+			AddRow("t3", "g", "geometry", "", "", ""))
+	mock.ExpectQuery("SELECT EVENT_OBJECT_TABLE, TRIGGER_NAME FROM information_schema.TRIGGERS").WithArgs("db").
+		WillReturnRows(sqlmock.NewRows([]string{"EVENT_OBJECT_TABLE", "TRIGGER_NAME"}).AddRow("t3", "trg"))
+	mock.ExpectQuery("SELECT TABLE_NAME FROM information_schema.VIEWS").WithArgs("db").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME"}))
+	result := checker.Check(ctx)
+	c.Assert(result.State, tc.Equals, check.StateSuccess)
+	c.Assert(result.Errors, tc.HasLen, 0)
+
+	// incompatible features
+	mockCollations()
+	mockTables("utf8mb4_0900_ai_ci")
+	mock.ExpectQuery("SELECT TABLE_NAME, COLUMN_NAME, .* FROM information_schema.COLUMNS").WithArgs("db").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("t1", "id", "int", "", "", "").
+			AddRow("t1", "g", "point", "", "", "").
+			AddRow("t2", "name", "varchar", "latin1", "latin1_swedish_ci", "").
+			AddRow("t2", "c", "int", "", "", "VIRTUAL GENERATED"))
+	mock.ExpectQuery("SELECT EVENT_OBJECT_TABLE, TRIGGER_NAME FROM information_schema.TRIGGERS").WithArgs("db").
+		WillReturnRows(sqlmock.NewRows([]string{"EVENT_OBJECT_TABLE", "TRIGGER_NAME"}).AddRow("t1", "trg"))
+	mock.ExpectQuery("SELECT TABLE_NAME FROM information_schema.VIEWS").WithArgs("db").
+		WillReturnRows(sqlmock.NewRows([]string{"TABLE_NAME"}).AddRow("v1"))
+	result = checker.Check(ctx)
+	c.Assert(result.State, tc.Equals, check.StateWarning)
+	c.Assert(result.Errors, tc.HasLen, 6)
+	expected := []string{
+		"table `db`.`t1` uses collation utf8mb4_0900_ai_ci which is not supported by the target",
+		"table `db`.`t1` column g of type point is not supported by TiDB",
+		"table `db`.`t2` column name uses charset latin1 which is not supported by the target",
+		"table `db`.`t2` column c is a virtual generated column, whose value is computed by the target instead of migrated",
+		"table `db`.`t1` has trigger trg which is not supported by TiDB and won't be migrated",
+		"table `db`.`v1` is a view which won't be migrated",
+	}
+	for i, e := range result.Errors {
+		c.Assert(e.Severity, tc.Equals, check.StateWarning)
+		c.Assert(e.ShortErr, tc.Equals, expected[i])
+		c.Assert(e.Instruction, tc.Not(tc.Equals), "")
+	}
+
+	c.Assert(mock.ExpectationsWereMet(), tc.IsNil)
+	c.Assert(targetMock.ExpectationsWereMet(), tc.IsNil)
+}
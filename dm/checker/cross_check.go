@@ -0,0 +1,139 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/coreos/go-semver/semver"
+	"github.com/pingcap/tidb-tools/pkg/check"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+
+	"github.com/pingcap/ticdc/dm/pkg/utils"
+)
+
+// minTiDBVersion is the minimal version of the downstream TiDB that DM is tested with.
+var minTiDBVersion = semver.New("4.0.0")
+
+// sourceServerIDChecker checks whether the server-id used by DM to pull the binlog
+// conflicts with the server_id of the upstream database or its replicas.
+type sourceServerIDChecker struct {
+	db       *sql.DB
+	dbinfo   *dbutil.DBConfig
+	serverID uint32
+}
+
+func newSourceServerIDChecker(db *sql.DB, dbinfo *dbutil.DBConfig, serverID uint32) check.Checker {
+	return &sourceServerIDChecker{db: db, dbinfo: dbinfo, serverID: serverID}
+}
+
+// Check implements the Checker interface.
+func (c *sourceServerIDChecker) Check(ctx context.Context) *check.Result {
+	result := &check.Result{
+		Name:  c.Name(),
+		Desc:  "check whether the server-id of DM is unique in the replication topology of the source",
+		State: check.StateFailure,
+		Extra: fmt.Sprintf("address of db instance - %s:%d", c.dbinfo.Host, c.dbinfo.Port),
+	}
+
+	serverID, err := utils.GetServerID(ctx, c.db)
+	if err != nil {
+		result.Errors = append(result.Errors, check.NewError(err.Error()))
+		return result
+	}
+	if serverID == c.serverID {
+		result.Errors = append(result.Errors, check.NewError("server-id %d of DM is the same as the server_id of the source", c.serverID))
+		result.Instruction = "please set a different server-id in the source config"
+		return result
+	}
+
+	slaveServerIDs, err := utils.GetSlaveServerID(ctx, c.db)
+	if err != nil {
+		result.Errors = append(result.Errors, check.NewError(err.Error()))
+		return result
+	}
+	if _, ok := slaveServerIDs[c.serverID]; ok {
+		// the replica may be the relay log or the syncer of DM itself
+		result.State = check.StateWarning
+		result.Errors = append(result.Errors, &check.Error{
+			Severity: check.StateWarning,
+			ShortErr: fmt.Sprintf("server-id %d of DM is used by a replica connected to the source", c.serverID),
+		})
+		result.Instruction = "please make sure the replica is DM itself, or set a different server-id in the source config"
+		return result
+	}
+
+	result.State = check.StateSuccess
+	return result
+}
+
+// Name implements the Checker interface.
+func (c *sourceServerIDChecker) Name() string {
+	return "source_server_id_unique"
+}
+
+// targetTiDBVersionChecker checks whether the target database is a TiDB which DM supports.
+type targetTiDBVersionChecker struct {
+	db     *sql.DB
+	dbinfo *dbutil.DBConfig
+}
+
+func newTargetTiDBVersionChecker(db *sql.DB, dbinfo *dbutil.DBConfig) check.Checker {
+	return &targetTiDBVersionChecker{db: db, dbinfo: dbinfo}
+}
+
+// Check implements the Checker interface.
+func (c *targetTiDBVersionChecker) Check(ctx context.Context) *check.Result {
+	result := &check.Result{
+		Name:  c.Name(),
+		Desc:  "check whether the target database is a compatible TiDB",
+		State: check.StateFailure,
+		Extra: fmt.Sprintf("address of db instance - %s:%d", c.dbinfo.Host, c.dbinfo.Port),
+	}
+
+	value, err := dbutil.ShowVersion(ctx, c.db)
+	if err != nil {
+		result.Errors = append(result.Errors, check.NewError(err.Error()))
+		return result
+	}
+
+	result.State = check.StateWarning
+	version, err := utils.ExtractTiDBVersion(value)
+	if err != nil {
+		result.Errors = append(result.Errors, &check.Error{
+			Severity: check.StateWarning,
+			ShortErr: fmt.Sprintf("target database version %s is not TiDB", value),
+		})
+		result.Instruction = "DM is only tested with TiDB as the target database"
+		return result
+	}
+	if version.LessThan(*minTiDBVersion) {
+		result.Errors = append(result.Errors, &check.Error{
+			Severity: check.StateWarning,
+			ShortErr: fmt.Sprintf("TiDB version required at least %v but got %v", minTiDBVersion, version),
+		})
+		result.Instruction = "please upgrade the target TiDB"
+		return result
+	}
+
+	result.State = check.StateSuccess
+	return result
+}
+
+// Name implements the Checker interface.
+func (c *targetTiDBVersionChecker) Name() string {
+	return "target_tidb_version"
+}
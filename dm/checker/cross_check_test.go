@@ -0,0 +1,113 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/tidb-tools/pkg/check"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/pkg/conn"
+
+	tc "github.com/pingcap/check"
+)
+
+func mockSlaveHosts(mock sqlmock.Sqlmock, serverIDs ...string) {
+	rows := sqlmock.NewRows([]string{"Server_id", "Host", "Port", "Master_id", "Slave_UUID"})
+	for _, serverID := range serverIDs {
+		rows.AddRow(serverID, "replica", "3306", "1", "14cb6624-7f93-11e0-b2c0-c80aa9429562")
+	}
+	mock.ExpectQuery("SHOW SLAVE HOSTS").WillReturnRows(rows)
+}
+
+func (s *testCheckerSuite) TestSourceServerIDChecker(c *tc.C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, tc.IsNil)
+	checker := newSourceServerIDChecker(db, &dbutil.DBConfig{}, 429)
+	ctx := context.Background()
+
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'server_id'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("server_id", "1"))
+	mockSlaveHosts(mock, "2")
+	result := checker.Check(ctx)
+	c.Assert(result.State, tc.Equals, check.StateSuccess)
+
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'server_id'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("server_id", "429"))
+	result = checker.Check(ctx)
+	c.Assert(result.State, tc.Equals, check.StateFailure)
+	c.Assert(result.Errors[0].ShortErr, tc.Matches, "server-id 429 of DM is the same as the server_id of the source")
+
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'server_id'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("server_id", "1"))
+	mockSlaveHosts(mock, "2", "429")
+	result = checker.Check(ctx)
+	c.Assert(result.State, tc.Equals, check.StateWarning)
+	c.Assert(result.Errors[0].Severity, tc.Equals, check.StateWarning)
+	c.Assert(mock.ExpectationsWereMet(), tc.IsNil)
+}
+
+func (s *testCheckerSuite) TestTargetTiDBVersionChecker(c *tc.C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, tc.IsNil)
+	checker := newTargetTiDBVersionChecker(db, &dbutil.DBConfig{})
+	ctx := context.Background()
+
+	cases := []struct {
+		version string
+		state   check.State
+	}{
+		{"5.7.25-TiDB-v5.2.1", check.StateSuccess},
+		{"5.7.25-TiDB-v4.0.0-beta.2-1204-gd3b4c5a8e", check.StateWarning},
+		{"5.7.25-TiDB-v3.0.20", check.StateWarning},
+		{"5.7.26-log", check.StateWarning},
+	}
+	for _, cs := range cases {
+		mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'version'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+			AddRow("version", cs.version))
+		result := checker.Check(ctx)
+		c.Assert(result.State, tc.Equals, cs.state, tc.Commentf("%s", cs.version))
+	}
+	c.Assert(mock.ExpectationsWereMet(), tc.IsNil)
+}
+
+func (s *testCheckerSuite) TestCheckTaskReport(c *tc.C) {
+	result, err := CheckTaskReport(context.Background(), nil)
+	c.Assert(err, tc.IsNil)
+	c.Assert(result.Summary.Passed, tc.IsTrue)
+
+	cfgs := []*config.SubTaskConfig{
+		{
+			ServerID:            429,
+			IgnoreCheckingItems: ignoreExcept(map[string]struct{}{config.ServerIDChecking: {}}),
+		},
+	}
+	mock := conn.InitMockDB(c)
+	mock.MatchExpectationsInOrder(false)
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'server_id'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("server_id", "1"))
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'server_id'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("server_id", "1"))
+	mockSlaveHosts(mock, "429")
+	result, err = CheckTaskReport(context.Background(), cfgs)
+	c.Assert(err, tc.IsNil)
+	// the passed items are reported too
+	c.Assert(result.Results, tc.HasLen, 2)
+	c.Assert(result.Summary.Passed, tc.IsTrue)
+	c.Assert(result.Summary.Successful, tc.Equals, int64(1))
+	c.Assert(result.Summary.Warning, tc.Equals, int64(1))
+}
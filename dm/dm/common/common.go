@@ -22,6 +22,10 @@ import (
 	"github.com/pingcap/ticdc/dm/pkg/terror"
 )
 
+// WorkerNameMetadataKey is the gRPC metadata key of the name of the DM-worker which a request is sent to, it routes
+// the request when a DM-worker process serves multiple sources as multiple DM-workers.
+const WorkerNameMetadataKey = "dm-worker-name"
+
 var (
 	useOfClosedErrMsg = "use of closed network connection"
 	// ClusterVersionKey is used to store the version of the cluster.
@@ -35,6 +39,12 @@ var (
 	// LoadTaskKeyAdapter is used to store the worker which in load stage for the source of the subtask.
 	// k/v: Encode(task, source-id) -> worker-name.
 	LoadTaskKeyAdapter KeyAdapter = keyHexEncoderDecoder("/dm-master/load-task/")
+	// ErrorOperatorKeyAdapter is used to store the `handle-error` operators of the subtask.
+	// k/v: Encode(task, source-id, binlog-pos) -> error operator.
+	ErrorOperatorKeyAdapter KeyAdapter = keyHexEncoderDecoder("/dm-master/error-operator/")
+	// SyncerCheckpointKeyAdapter is used to store the checkpoints of the syncer when `checkpoint-storage` is `etcd`.
+	// k/v: Encode(task, checkpoint-id, upstream-schema-name, upstream-table-name) -> checkpoint.
+	SyncerCheckpointKeyAdapter KeyAdapter = keyHexEncoderDecoder("/dm-worker/syncer-checkpoint/")
 	// UpstreamConfigKeyAdapter stores all config of which MySQL-task has not stopped.
 	// k/v: Encode(source-id) -> config.
 	UpstreamConfigKeyAdapter KeyAdapter = keyHexEncoderDecoder("/dm-master/v2/upstream/config/")
@@ -100,9 +110,9 @@ func keyAdapterKeysLen(s KeyAdapter) int {
 		ShardDDLPessimismInfoKeyAdapter, ShardDDLPessimismOperationKeyAdapter,
 		ShardDDLOptimismSourceTablesKeyAdapter, LoadTaskKeyAdapter:
 		return 2
-	case ShardDDLOptimismInitSchemaKeyAdapter:
+	case ShardDDLOptimismInitSchemaKeyAdapter, ErrorOperatorKeyAdapter:
 		return 3
-	case ShardDDLOptimismInfoKeyAdapter, ShardDDLOptimismOperationKeyAdapter:
+	case ShardDDLOptimismInfoKeyAdapter, ShardDDLOptimismOperationKeyAdapter, SyncerCheckpointKeyAdapter:
 		return 4
 	case ShardDDLOptimismDroppedColumnsKeyAdapter:
 		return 5
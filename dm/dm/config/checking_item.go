@@ -34,6 +34,7 @@ const (
 	TableSchemaChecking          = "table_schema"
 	ShardTableSchemaChecking     = "schema_of_shard_tables"
 	ShardAutoIncrementIDChecking = "auto_increment_ID"
+	TableCompatibilityChecking   = "table_compatibility"
 )
 
 // AllCheckingItems contains all checking items.
@@ -49,6 +50,7 @@ var AllCheckingItems = map[string]string{
 	TableSchemaChecking:          "table schema compatibility checking item",
 	ShardTableSchemaChecking:     "consistent schema of shard tables checking item",
 	ShardAutoIncrementIDChecking: "conflict auto increment ID of shard tables checking item",
+	TableCompatibilityChecking:   "column types, charsets, generated columns, triggers and views compatibility checking item",
 }
 
 // MaxSourceIDLength is the max length for dm-worker source id.
@@ -0,0 +1,52 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+// ColumnTransform represents a transform that computes the value of a column from the row by a SQL expression,
+// like `CONCAT(first_name, ' ', last_name)`, `SUBSTRING(code, 1, 4)`, `id | (3 << 48)` to embed the shard ID
+// or `CONVERT_TZ(created_at, '+08:00', '+00:00')` to shift the timezone.
+// the expression is evaluated on the source row after the column mapping, and its result replaces the value of
+// the column, which must exist in both the source table and the target table.
+// it's applied to the rows of both the full data and the incremental data.
+type ColumnTransform struct {
+	Schema string `yaml:"schema" toml:"schema" json:"schema"`
+	Table  string `yaml:"table" toml:"table" json:"table"`
+	Column string `yaml:"column" toml:"column" json:"column"`
+	Expr   string `yaml:"expr" toml:"expr" json:"expr"`
+}
+
+// adjust verifies the column transform.
+func (t *ColumnTransform) adjust(name string) error {
+	if t == nil {
+		return terror.ErrConfigInvalidColumnTransform.Generate(name, "empty rule")
+	}
+	switch {
+	case t.Schema == "":
+		return terror.ErrConfigInvalidColumnTransform.Generate(name, "empty schema")
+	case t.Table == "":
+		return terror.ErrConfigInvalidColumnTransform.Generate(name, "empty table")
+	case t.Column == "":
+		return terror.ErrConfigInvalidColumnTransform.Generate(name, "empty column")
+	case t.Expr == "":
+		return terror.ErrConfigInvalidColumnTransform.Generate(name, "empty expr")
+	}
+	if err := checkValidExpr(t.Expr); err != nil {
+		return terror.ErrConfigInvalidColumnTransform.Generate(name, "wrong grammar of expr "+t.Expr+": "+err.Error())
+	}
+	return nil
+}
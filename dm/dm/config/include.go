@@ -0,0 +1,216 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+// includesKey is the key of the task config which lists the files of the shared blocks.
+const includesKey = "includes"
+
+// includableBlocks are the blocks of the task config which can be shared by the included files,
+// mapping to the names referred by a mysql instance.
+var includableBlocks = map[string]func(inst *MySQLInstance) []string{
+	"routes":            func(inst *MySQLInstance) []string { return inst.RouteRules },
+	"filters":           func(inst *MySQLInstance) []string { return inst.FilterRules },
+	"column-mappings":   func(inst *MySQLInstance) []string { return inst.ColumnMappingRules },
+	"expression-filter": func(inst *MySQLInstance) []string { return inst.ExpressionFilters },
+	"column-transforms": func(inst *MySQLInstance) []string { return inst.ColumnTransformRules },
+	"block-allow-list":  func(inst *MySQLInstance) []string { return []string{inst.BAListName, inst.BWListName} },
+	"black-white-list":  func(inst *MySQLInstance) []string { return []string{inst.BAListName, inst.BWListName} },
+	"mydumpers":         func(inst *MySQLInstance) []string { return []string{inst.MydumperConfigName} },
+	"loaders":           func(inst *MySQLInstance) []string { return []string{inst.LoaderConfigName} },
+	"syncers":           func(inst *MySQLInstance) []string { return []string{inst.SyncerConfigName} },
+	"validators":        func(inst *MySQLInstance) []string { return []string{inst.ValidatorConfigName} },
+}
+
+// MergeIncludes merges the shared blocks of the files listed in `includes` into the task
+// config, and returns the task config without `includes`. The relative paths of the
+// included files are relative to baseDir. The merge rules are:
+//   - the included files can only contain the includable blocks, and can't include other files.
+//   - a rule (or a mydumper/loader/syncer config) can't be defined by more than one included file.
+//   - a rule defined by both an included file and the task config is merged field by field,
+//     the fields of the task config override the fields of the included file.
+//   - a rule only defined by the included files is dropped if no mysql instance refers it.
+func MergeIncludes(data []byte, baseDir string) ([]byte, error) {
+	var task yaml.MapSlice
+	if err := yaml.Unmarshal(data, &task); err != nil {
+		// leave the error to the decoding of the task config
+		// nolint:nilerr
+		return data, nil
+	}
+	idx := -1
+	for i, item := range task {
+		if item.Key == includesKey {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return data, nil
+	}
+
+	includes, ok := task[idx].Value.([]interface{})
+	if !ok && task[idx].Value != nil {
+		return nil, terror.ErrConfigIncludeInvalid.Delegate(fmt.Errorf("%s should be a list of files", includesKey), task[idx].Value)
+	}
+	task = append(task[:idx:idx], task[idx+1:]...)
+
+	var (
+		included  yaml.MapSlice
+		definedIn = make(map[string]string)
+	)
+	for _, item := range includes {
+		include, ok := item.(string)
+		if !ok {
+			return nil, terror.ErrConfigIncludeInvalid.Delegate(fmt.Errorf("%s should be a list of files", includesKey), item)
+		}
+		blocks, err := readIncludedBlocks(include, baseDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, block := range blocks {
+			rules, _ := block.Value.(yaml.MapSlice)
+			for _, rule := range rules {
+				name := fmt.Sprintf("%v %v", block.Key, rule.Key)
+				if file, ok := definedIn[name]; ok {
+					return nil, terror.ErrConfigIncludeInvalid.Delegate(fmt.Errorf("%s is also defined in %s", name, file), include)
+				}
+				definedIn[name] = include
+			}
+			included = mergeMapSlice(included, yaml.MapSlice{block})
+		}
+	}
+
+	merged, err := yaml.Marshal(mergeMapSlice(dropUnreferenced(included, task), task))
+	if err != nil {
+		return nil, terror.ErrConfigYamlTransform.Delegate(err, "encode task config failed")
+	}
+	return merged, nil
+}
+
+// dropUnreferenced drops the included rules which are neither referred by the mysql instances
+// nor defined by the task config, so a task can include the files shared with other tasks.
+func dropUnreferenced(included, task yaml.MapSlice) yaml.MapSlice {
+	var refs struct {
+		MySQLInstances []*MySQLInstance `yaml:"mysql-instances"`
+	}
+	bs, err := yaml.Marshal(task)
+	if err == nil {
+		err = yaml.Unmarshal(bs, &refs)
+	}
+	if err != nil {
+		// leave the error to the decoding of the task config
+		return included
+	}
+
+	kept := make(yaml.MapSlice, 0, len(included))
+	for _, block := range included {
+		referred := make(map[interface{}]struct{})
+		for _, inst := range refs.MySQLInstances {
+			if inst == nil {
+				continue
+			}
+			for _, name := range includableBlocks[block.Key.(string)](inst) {
+				referred[name] = struct{}{}
+			}
+		}
+		for _, item := range task {
+			if item.Key != block.Key {
+				continue
+			}
+			rules, _ := item.Value.(yaml.MapSlice)
+			for _, rule := range rules {
+				referred[rule.Key] = struct{}{}
+			}
+		}
+
+		rules, _ := block.Value.(yaml.MapSlice)
+		keptRules := make(yaml.MapSlice, 0, len(rules))
+		for _, rule := range rules {
+			if _, ok := referred[rule.Key]; ok {
+				keptRules = append(keptRules, rule)
+			}
+		}
+		if len(keptRules) > 0 {
+			kept = append(kept, yaml.MapItem{Key: block.Key, Value: keptRules})
+		}
+	}
+	return kept
+}
+
+// readIncludedBlocks reads the shared blocks of an included file.
+func readIncludedBlocks(include, baseDir string) (yaml.MapSlice, error) {
+	path := include
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, terror.ErrConfigIncludeInvalid.Delegate(err, include)
+	}
+
+	var blocks yaml.MapSlice
+	if err = yaml.Unmarshal(bs, &blocks); err != nil {
+		return nil, terror.ErrConfigIncludeInvalid.Delegate(err, include)
+	}
+	for _, block := range blocks {
+		key, _ := block.Key.(string)
+		if _, ok := includableBlocks[key]; !ok {
+			return nil, terror.ErrConfigIncludeInvalid.Delegate(fmt.Errorf("%v can't be included", block.Key), include)
+		}
+		if _, ok := block.Value.(yaml.MapSlice); !ok && block.Value != nil {
+			return nil, terror.ErrConfigIncludeInvalid.Delegate(fmt.Errorf("%v should be a map", block.Key), include)
+		}
+	}
+	return blocks, nil
+}
+
+// mergeMapSlice merges override into base recursively, the maps are merged by keys and the
+// other values of override replace the values of base. The order of the keys is kept.
+func mergeMapSlice(base, override yaml.MapSlice) yaml.MapSlice {
+	merged := make(yaml.MapSlice, len(base), len(base)+len(override))
+	copy(merged, base)
+	for _, item := range override {
+		found := false
+		for i := range merged {
+			if merged[i].Key != item.Key {
+				continue
+			}
+			found = true
+			baseValue, ok1 := merged[i].Value.(yaml.MapSlice)
+			overrideValue, ok2 := item.Value.(yaml.MapSlice)
+			switch {
+			case ok1 && ok2:
+				merged[i].Value = mergeMapSlice(baseValue, overrideValue)
+			case ok1 && item.Value == nil:
+				// an empty block of the task config keeps the included rules
+			default:
+				merged[i].Value = item.Value
+			}
+			break
+		}
+		if !found {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
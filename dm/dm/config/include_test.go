@@ -0,0 +1,155 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+const (
+	sharedRules = `
+routes:
+  route-rule-1:
+    schema-pattern: "test_*"
+    target-schema: "test"
+  route-rule-unused:
+    schema-pattern: "unused_*"
+    target-schema: "unused"
+
+filters:
+  filter-rule-1:
+    schema-pattern: "test_*"
+    events: ["truncate table", "drop table"]
+    action: Ignore
+`
+
+	sharedUnits = `
+mydumpers:
+  global:
+    threads: 4
+    chunk-filesize: 64
+    extra-args: "--consistency none"
+
+syncers:
+  global:
+    worker-count: 16
+    batch: 100
+`
+
+	taskWithIncludes = `---
+name: test
+task-mode: all
+includes: ["shared-rules.yaml", "shared-units.yaml"]
+
+target-database:
+  host: "127.0.0.1"
+  port: 4000
+  user: "root"
+  password: ""
+
+syncers:
+  global:
+    batch: 200
+
+mysql-instances:
+  - source-id: "mysql-replica-01"
+    route-rules: ["route-rule-1"]
+    filter-rules: ["filter-rule-1"]
+    mydumper-config-name: "global"
+    syncer-config-name: "global"
+`
+)
+
+func writeIncludes(c *C, files map[string]string) string {
+	dir := c.MkDir()
+	for name, content := range files {
+		c.Assert(os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644), IsNil)
+	}
+	return dir
+}
+
+func (t *testConfig) TestTaskConfigIncludes(c *C) {
+	dir := writeIncludes(c, map[string]string{
+		"shared-rules.yaml": sharedRules,
+		"shared-units.yaml": sharedUnits,
+		"task.yaml":         taskWithIncludes,
+	})
+
+	cfg := NewTaskConfig()
+	c.Assert(cfg.DecodeFile(filepath.Join(dir, "task.yaml")), IsNil)
+	c.Assert(cfg.Routes, HasKey, "route-rule-1")
+	// the included rules not referred by the task are dropped
+	c.Assert(cfg.Routes, Not(HasKey), "route-rule-unused")
+	c.Assert(cfg.Filters, HasKey, "filter-rule-1")
+	c.Assert(cfg.Mydumpers["global"].Threads, Equals, 4)
+	// the task config overrides the included config field by field
+	c.Assert(cfg.Syncers["global"].WorkerCount, Equals, 16)
+	c.Assert(cfg.Syncers["global"].Batch, Equals, 200)
+	c.Assert(cfg.MySQLInstances[0].Syncer.Batch, Equals, 200)
+
+	// the merged config has no `includes`
+	merged, err := MergeIncludes([]byte(taskWithIncludes), dir)
+	c.Assert(err, IsNil)
+	c.Assert(string(merged), Not(Matches), "(.|\n)*includes(.|\n)*")
+	cfg2 := NewTaskConfig()
+	c.Assert(cfg2.RawDecode(string(merged)), IsNil)
+	c.Assert(cfg2.Routes, DeepEquals, cfg.Routes)
+
+	// the config without `includes` is kept as is
+	merged, err = MergeIncludes([]byte(correctTaskConfig), dir)
+	c.Assert(err, IsNil)
+	c.Assert(string(merged), Equals, correctTaskConfig)
+}
+
+func (t *testConfig) TestTaskConfigIncludesInvalid(c *C) {
+	cases := []struct {
+		files map[string]string
+		err   string
+	}{
+		{
+			map[string]string{"shared-units.yaml": sharedUnits},
+			".*shared-rules.yaml.*no such file or directory.*",
+		},
+		{
+			map[string]string{"shared-rules.yaml": sharedRules, "shared-units.yaml": sharedUnits + "\nname: other\n"},
+			".*name can't be included.*",
+		},
+		{
+			map[string]string{"shared-rules.yaml": sharedRules, "shared-units.yaml": sharedUnits + "\nincludes: [\"a.yaml\"]\n"},
+			".*includes can't be included.*",
+		},
+		{
+			map[string]string{"shared-rules.yaml": sharedRules, "shared-units.yaml": sharedUnits + sharedRules},
+			".*routes route-rule-1 is also defined in shared-rules.yaml.*",
+		},
+		{
+			map[string]string{"shared-rules.yaml": sharedRules, "shared-units.yaml": "loaders: [\"global\"]\n"},
+			".*loaders should be a map.*",
+		},
+	}
+	for _, cs := range cases {
+		dir := writeIncludes(c, cs.files)
+		_, err := MergeIncludes([]byte(taskWithIncludes), dir)
+		c.Assert(terror.ErrConfigIncludeInvalid.Equal(err), IsTrue, Commentf("%v", err))
+		c.Assert(err, ErrorMatches, cs.err)
+	}
+
+	_, err := MergeIncludes([]byte("name: test\nincludes: shared.yaml\n"), c.MkDir())
+	c.Assert(terror.ErrConfigIncludeInvalid.Equal(err), IsTrue)
+}
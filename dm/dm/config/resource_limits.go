@@ -0,0 +1,84 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"github.com/docker/go-units"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/ticdc/dm/pkg/log"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+// ResourceLimits limits the resources used by each subtask of the task in the DM-worker,
+// so one aggressive task can't starve the other tasks sharing the DM-worker. 0 or empty means no limit.
+type ResourceLimits struct {
+	// the max connections to the downstream to load and replicate the data, which caps `pool-size` of the loader,
+	// the region concurrency of TiDB Lightning and `worker-count` of the syncer.
+	// a few extra connections are still used for the checkpoints and DDLs.
+	MaxDownstreamConnections int `yaml:"max-downstream-connections" toml:"max-downstream-connections" json:"max-downstream-connections"`
+	// the max memory like `512MiB` to buffer the SQL statements of the dumped files by the loader,
+	// which limits the length of the job queue of each loader worker.
+	MaxLoaderMemory string `yaml:"max-loader-memory" toml:"max-loader-memory" json:"max-loader-memory"`
+	// the max `worker-count` of the syncer.
+	MaxSyncerWorkerCount int `yaml:"max-syncer-worker-count" toml:"max-syncer-worker-count" json:"max-syncer-worker-count"`
+}
+
+// adjust verifies the resource limits.
+func (r *ResourceLimits) adjust() error {
+	if r.MaxDownstreamConnections < 0 {
+		return terror.ErrConfigInvalidResourceLimits.Generate("negative max-downstream-connections")
+	}
+	if r.MaxSyncerWorkerCount < 0 {
+		return terror.ErrConfigInvalidResourceLimits.Generate("negative max-syncer-worker-count")
+	}
+	if r.MaxLoaderMemory != "" {
+		if _, err := units.RAMInBytes(r.MaxLoaderMemory); err != nil {
+			return terror.ErrConfigInvalidResourceLimits.Generate("invalid max-loader-memory " + r.MaxLoaderMemory)
+		}
+	}
+	return nil
+}
+
+// LoaderMemoryBytes returns the max memory of the loader in bytes, 0 if not limited.
+func (r *ResourceLimits) LoaderMemoryBytes() int64 {
+	if r.MaxLoaderMemory == "" {
+		return 0
+	}
+	size, err := units.RAMInBytes(r.MaxLoaderMemory)
+	if err != nil || size < 0 {
+		return 0
+	}
+	return size
+}
+
+// ApplyResourceLimits caps the concurrency of the units by the resource limits of the task,
+// it should be called by the DM-worker before creating the units of the subtask.
+func (c *SubTaskConfig) ApplyResourceLimits() {
+	limits := c.ResourceLimits
+	maxWorkerCount := limits.MaxSyncerWorkerCount
+	if limits.MaxDownstreamConnections > 0 && (maxWorkerCount == 0 || limits.MaxDownstreamConnections < maxWorkerCount) {
+		maxWorkerCount = limits.MaxDownstreamConnections
+	}
+	if maxWorkerCount > 0 && c.WorkerCount > maxWorkerCount {
+		log.L().Warn("worker-count of the syncer is limited by the resource limits of the task",
+			zap.String("task", c.Name), zap.Int("worker-count", c.WorkerCount), zap.Int("limit", maxWorkerCount))
+		c.WorkerCount = maxWorkerCount
+	}
+	if limits.MaxDownstreamConnections > 0 && c.PoolSize > limits.MaxDownstreamConnections {
+		log.L().Warn("pool-size of the loader is limited by the resource limits of the task",
+			zap.String("task", c.Name), zap.Int("pool-size", c.PoolSize), zap.Int("limit", limits.MaxDownstreamConnections))
+		c.PoolSize = limits.MaxDownstreamConnections
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+const (
+	secretRefPrefix = "secret://"
+
+	// secretRefFile reads the secret from a file, like `secret://file/etc/dm/password`.
+	secretRefFile = "file"
+	// secretRefVault reads the secret from a key of a HashiCorp Vault secret, like
+	// `secret://vault/secret/data/dm#password`. The address and the token of Vault
+	// are read from the environment variables `VAULT_ADDR` and `VAULT_TOKEN`.
+	secretRefVault = "vault"
+
+	vaultAddrEnv     = "VAULT_ADDR"
+	vaultTokenEnv    = "VAULT_TOKEN"
+	vaultTimeout     = 10 * time.Second
+	vaultTokenHeader = "X-Vault-Token"
+)
+
+// envPlaceholder matches the `${ENV_VAR}` placeholders, the `$ENV_VAR` form is not
+// expanded since `$` is common in passwords.
+var envPlaceholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces the `${ENV_VAR}` placeholders in the task config with the values
+// of the environment variables, an unset environment variable is an error.
+func expandEnv(data []byte) ([]byte, error) {
+	var err error
+	expanded := envPlaceholder.ReplaceAllFunc(data, func(placeholder []byte) []byte {
+		name := string(envPlaceholder.FindSubmatch(placeholder)[1])
+		value, ok := os.LookupEnv(name)
+		if !ok && err == nil {
+			err = terror.ErrConfigEnvNotSet.Generate(name)
+		}
+		return []byte(value)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expanded, nil
+}
+
+// resolveSecrets replaces the `secret://` references of the hosts and the passwords in
+// the task config with the secrets.
+func (c *TaskConfig) resolveSecrets() error {
+	if c.TargetDB == nil {
+		return nil
+	}
+	for _, field := range []*string{&c.TargetDB.Host, &c.TargetDB.User, &c.TargetDB.Password} {
+		if !strings.HasPrefix(*field, secretRefPrefix) {
+			continue
+		}
+		secret, err := resolveSecret(*field)
+		if err != nil {
+			return terror.ErrConfigSecretRefInvalid.Delegate(err, *field)
+		}
+		*field = secret
+	}
+	return nil
+}
+
+// resolveSecret reads the secret referenced by a `secret://` reference.
+func resolveSecret(ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	switch u.Host {
+	case secretRefFile:
+		data, err := os.ReadFile(u.Path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case secretRefVault:
+		return readVaultSecret(u.Path, u.Fragment)
+	default:
+		return "", fmt.Errorf("unknown secret store %s, only %s and %s are supported", u.Host, secretRefFile, secretRefVault)
+	}
+}
+
+// readVaultSecret reads a key of a secret from the HTTP API of Vault, both the KV
+// secrets engine version 1 and 2 are supported.
+func readVaultSecret(path, key string) (string, error) {
+	if key == "" {
+		return "", fmt.Errorf("the key of the vault secret should be specified after #")
+	}
+	addr := os.Getenv(vaultAddrEnv)
+	if addr == "" {
+		return "", fmt.Errorf("environment variable %s is not set", vaultAddrEnv)
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(addr, "/")+"/v1"+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(vaultTokenHeader, os.Getenv(vaultTokenEnv))
+	client := &http.Client{Timeout: vaultTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault responds %s", resp.Status)
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", err
+	}
+	data := secret.Data
+	// the KV secrets engine version 2 nests the secret in data.data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+	value, ok := data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("key %s is not found in the vault secret", key)
+	}
+	return value, nil
+}
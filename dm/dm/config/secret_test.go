@@ -0,0 +1,131 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+// taskConfigWithTargetDB replaces the target database of correctTaskConfig.
+func taskConfigWithTargetDB(host, user, password string) string {
+	return strings.Replace(correctTaskConfig, `  host: "127.0.0.1"
+  port: 4000
+  user: "root"
+  password: ""`, `  host: "`+host+`"
+  port: 4000
+  user: "`+user+`"
+  password: "`+password+`"`, 1)
+}
+
+func (t *testConfig) TestExpandEnv(c *C) {
+	c.Assert(os.Setenv("DM_TEST_TIDB_HOST", "tidb.local"), IsNil)
+	c.Assert(os.Setenv("DM_TEST_TIDB_PASSWORD", "pa$$word"), IsNil)
+	defer os.Unsetenv("DM_TEST_TIDB_HOST")
+	defer os.Unsetenv("DM_TEST_TIDB_PASSWORD")
+
+	cfg := NewTaskConfig()
+	c.Assert(cfg.Decode(taskConfigWithTargetDB("${DM_TEST_TIDB_HOST}", "root", "${DM_TEST_TIDB_PASSWORD}")), IsNil)
+	c.Assert(cfg.TargetDB.Host, Equals, "tidb.local")
+	c.Assert(cfg.TargetDB.Password, Equals, "pa$$word")
+
+	// `$ENV_VAR` is kept as is
+	cfg = NewTaskConfig()
+	c.Assert(cfg.Decode(taskConfigWithTargetDB("127.0.0.1", "root", "$DM_TEST_TIDB_PASSWORD")), IsNil)
+	c.Assert(cfg.TargetDB.Password, Equals, "$DM_TEST_TIDB_PASSWORD")
+
+	cfg = NewTaskConfig()
+	err := cfg.Decode(taskConfigWithTargetDB("127.0.0.1", "root", "${DM_TEST_NOT_SET}"))
+	c.Assert(terror.ErrConfigEnvNotSet.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*DM_TEST_NOT_SET.*")
+
+	// DecodeFile expands the placeholders too
+	fpath := filepath.Join(c.MkDir(), "task.yaml")
+	c.Assert(os.WriteFile(fpath, []byte(taskConfigWithTargetDB("${DM_TEST_TIDB_HOST}", "root", "")), 0o644), IsNil)
+	cfg = NewTaskConfig()
+	c.Assert(cfg.DecodeFile(fpath), IsNil)
+	c.Assert(cfg.TargetDB.Host, Equals, "tidb.local")
+}
+
+func (t *testConfig) TestFileSecret(c *C) {
+	dir := c.MkDir()
+	passwordFile := filepath.Join(dir, "password")
+	c.Assert(os.WriteFile(passwordFile, []byte("123456\n"), 0o600), IsNil)
+
+	cfg := NewTaskConfig()
+	c.Assert(cfg.Decode(taskConfigWithTargetDB("127.0.0.1", "root", "secret://file"+passwordFile)), IsNil)
+	c.Assert(cfg.TargetDB.Password, Equals, "123456")
+
+	cfg = NewTaskConfig()
+	err := cfg.Decode(taskConfigWithTargetDB("127.0.0.1", "root", "secret://file"+filepath.Join(dir, "not-exist")))
+	c.Assert(terror.ErrConfigSecretRefInvalid.Equal(err), IsTrue)
+
+	cfg = NewTaskConfig()
+	err = cfg.Decode(taskConfigWithTargetDB("127.0.0.1", "root", "secret://kms/dm#password"))
+	c.Assert(terror.ErrConfigSecretRefInvalid.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*unknown secret store kms.*")
+}
+
+func (t *testConfig) TestVaultSecret(c *C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(vaultTokenHeader) != "root-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/dm":
+			// KV version 2
+			_, _ = w.Write([]byte(`{"data": {"data": {"host": "tidb.local", "password": "123456"}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/dm":
+			// KV version 1
+			_, _ = w.Write([]byte(`{"data": {"user": "dm"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c.Assert(os.Setenv(vaultAddrEnv, server.URL), IsNil)
+	c.Assert(os.Setenv(vaultTokenEnv, "root-token"), IsNil)
+	defer os.Unsetenv(vaultAddrEnv)
+	defer os.Unsetenv(vaultTokenEnv)
+
+	cfg := NewTaskConfig()
+	c.Assert(cfg.Decode(taskConfigWithTargetDB(
+		"secret://vault/secret/data/dm#host", "secret://vault/kv/dm#user", "secret://vault/secret/data/dm#password")), IsNil)
+	c.Assert(cfg.TargetDB.Host, Equals, "tidb.local")
+	c.Assert(cfg.TargetDB.User, Equals, "dm")
+	c.Assert(cfg.TargetDB.Password, Equals, "123456")
+
+	for _, ref := range []string{
+		"secret://vault/secret/data/dm#user", // key not found
+		"secret://vault/secret/data/dm",      // no key
+		"secret://vault/secret/data/tidb#password",
+	} {
+		cfg = NewTaskConfig()
+		err := cfg.Decode(taskConfigWithTargetDB("127.0.0.1", "root", ref))
+		c.Assert(terror.ErrConfigSecretRefInvalid.Equal(err), IsTrue, Commentf("%s", ref))
+	}
+
+	c.Assert(os.Setenv(vaultTokenEnv, "bad-token"), IsNil)
+	cfg = NewTaskConfig()
+	err := cfg.Decode(taskConfigWithTargetDB("127.0.0.1", "root", "secret://vault/secret/data/dm#password"))
+	c.Assert(err, ErrorMatches, ".*403 Forbidden.*")
+}
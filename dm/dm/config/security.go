@@ -14,13 +14,29 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"os"
 	"path"
 
+	"github.com/pingcap/errors"
+	toolutils "github.com/pingcap/tidb-tools/pkg/utils"
+
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 	"github.com/pingcap/ticdc/dm/pkg/utils"
 )
 
+// the verify modes of the TLS connections to the databases, like `--ssl-mode` of the MySQL client.
+const (
+	// SSLVerifyModeRequired encrypts the connection without verifying the certificate of the server.
+	SSLVerifyModeRequired = "required"
+	// SSLVerifyModeCA verifies the certificate of the server by the CA, but not the host name.
+	SSLVerifyModeCA = "verify-ca"
+	// SSLVerifyModeIdentity verifies the certificate of the server by the CA and the host name.
+	SSLVerifyModeIdentity = "verify-identity"
+)
+
 // Security config.
 type Security struct {
 	SSLCA         string   `toml:"ssl-ca" json:"ssl-ca" yaml:"ssl-ca"`
@@ -30,6 +46,8 @@ type Security struct {
 	SSLCABytes    []byte   `toml:"ssl-ca-bytes" json:"-" yaml:"ssl-ca-bytes"`
 	SSLKEYBytes   []byte   `toml:"ssl-key-bytes" json:"-" yaml:"ssl-key-bytes"`
 	SSLCertBytes  []byte   `toml:"ssl-cert-bytes" json:"-" yaml:"ssl-cert-bytes"`
+	// SSLVerifyMode is only used by the connections to the databases, empty keeps the behavior before it's added.
+	SSLVerifyMode string `toml:"ssl-verify-mode,omitempty" json:"ssl-verify-mode,omitempty" yaml:"ssl-verify-mode,omitempty"`
 }
 
 // used for parse string slice in flag.
@@ -44,6 +62,92 @@ func (i *strArray) Set(value string) error {
 	return nil
 }
 
+// AdjustDBTLS validates the TLS config of a database.
+func (s *Security) AdjustDBTLS() error {
+	if s == nil || s.SSLVerifyMode == "" {
+		return nil
+	}
+	switch s.SSLVerifyMode {
+	case SSLVerifyModeRequired:
+	case SSLVerifyModeCA, SSLVerifyModeIdentity:
+		if s.SSLCA == "" && len(s.SSLCABytes) == 0 {
+			return terror.ErrConfigInvalidDBTLS.Generate(fmt.Sprintf("`ssl-ca` is required by `ssl-verify-mode` %s", s.SSLVerifyMode))
+		}
+	default:
+		return terror.ErrConfigInvalidDBTLS.Generate(fmt.Sprintf("unsupported `ssl-verify-mode` %s", s.SSLVerifyMode))
+	}
+	hasCert := s.SSLCert != "" || len(s.SSLCertBytes) > 0
+	hasKey := s.SSLKey != "" || len(s.SSLKEYBytes) > 0
+	if hasCert != hasKey {
+		return terror.ErrConfigInvalidDBTLS.Generate("`ssl-cert` and `ssl-key` should be set together")
+	}
+	return nil
+}
+
+// ToTLSConfig builds the TLS config to connect to the database on the host, LoadTLSContent should be called before.
+// if the verify mode is not set, the certificate of the server is verified by the CA, and `ssl-ca`, `ssl-cert`
+// and `ssl-key` are all required.
+func (s *Security) ToTLSConfig(host string) (*tls.Config, error) {
+	if s.SSLVerifyMode == "" {
+		return toolutils.ToTLSConfigWithVerifyByRawbytes(s.SSLCABytes, s.SSLCertBytes, s.SSLKEYBytes, s.CertAllowedCN)
+	}
+
+	tlsCfg := &tls.Config{}
+	if len(s.SSLCertBytes) > 0 || len(s.SSLKEYBytes) > 0 {
+		cert, err := tls.X509KeyPair(s.SSLCertBytes, s.SSLKEYBytes)
+		if err != nil {
+			return nil, errors.Annotate(err, "could not load client key pair")
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	var roots *x509.CertPool
+	if len(s.SSLCABytes) > 0 {
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(s.SSLCABytes) {
+			return nil, errors.New("failed to append ca certs")
+		}
+	}
+
+	mode := s.SSLVerifyMode
+	switch mode {
+	case SSLVerifyModeIdentity:
+		tlsCfg.RootCAs = roots
+		tlsCfg.ServerName = host
+	case SSLVerifyModeCA, SSLVerifyModeRequired:
+		// the certificate chain is verified in VerifyConnection without the host name for `verify-ca`.
+		tlsCfg.InsecureSkipVerify = true
+	default:
+		return nil, errors.Errorf("unsupported ssl-verify-mode %s", mode)
+	}
+
+	allowedCN := make(map[string]struct{}, len(s.CertAllowedCN))
+	for _, cn := range s.CertAllowedCN {
+		allowedCN[cn] = struct{}{}
+	}
+	tlsCfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		if mode == SSLVerifyModeCA {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("no certificate of the server")
+			}
+			opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			if _, err := cs.PeerCertificates[0].Verify(opts); err != nil {
+				return err
+			}
+		}
+		if len(allowedCN) > 0 && len(cs.PeerCertificates) > 0 {
+			cn := cs.PeerCertificates[0].Subject.CommonName
+			if _, ok := allowedCN[cn]; !ok {
+				return errors.Errorf("common name %s of the server certificate is not allowed", cn)
+			}
+		}
+		return nil
+	}
+	return tlsCfg, nil
+}
+
 // LoadTLSContent load all tls config from file.
 func (s *Security) LoadTLSContent() error {
 	if len(s.SSLCABytes) > 0 {
@@ -15,12 +15,15 @@ package config
 
 import (
 	"bytes"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"os"
 	"path"
 	"reflect"
 
 	. "github.com/pingcap/check"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 	"github.com/pingcap/ticdc/dm/pkg/utils"
 )
 
@@ -149,9 +152,10 @@ func (t *testTLSConfig) TestClone(c *C) {
 		SSLCABytes:    nil,
 		SSLKEYBytes:   []byte("e"),
 		SSLCertBytes:  []byte("f"),
+		SSLVerifyMode: SSLVerifyModeCA,
 	}
 	// When add new fields, also update this value
-	c.Assert(reflect.Indirect(reflect.ValueOf(s)).NumField(), Equals, 7)
+	c.Assert(reflect.Indirect(reflect.ValueOf(s)).NumField(), Equals, 8)
 	clone := s.Clone()
 	c.Assert(clone, DeepEquals, s)
 	clone.CertAllowedCN[0] = "g"
@@ -188,3 +192,114 @@ func (t *testTLSConfig) TestLoadDumpTLSContent(c *C) {
 	c.Assert(utils.IsFileExists(s.SSLCert), Equals, true)
 	c.Assert(utils.IsFileExists(s.SSLKey), Equals, true)
 }
+
+func (t *testTLSConfig) TestAdjustDBTLS(c *C) {
+	var s *Security
+	c.Assert(s.AdjustDBTLS(), IsNil)
+	s = &Security{}
+	c.Assert(s.AdjustDBTLS(), IsNil)
+
+	s.SSLVerifyMode = SSLVerifyModeRequired
+	c.Assert(s.AdjustDBTLS(), IsNil)
+	s.SSLVerifyMode = "verify-full"
+	c.Assert(terror.ErrConfigInvalidDBTLS.Equal(s.AdjustDBTLS()), IsTrue)
+	for _, mode := range []string{SSLVerifyModeCA, SSLVerifyModeIdentity} {
+		s.SSLVerifyMode = mode
+		c.Assert(terror.ErrConfigInvalidDBTLS.Equal(s.AdjustDBTLS()), IsTrue)
+	}
+	s.SSLCA = caFilePath
+	c.Assert(s.AdjustDBTLS(), IsNil)
+	s.SSLCert = certFilePath
+	c.Assert(terror.ErrConfigInvalidDBTLS.Equal(s.AdjustDBTLS()), IsTrue)
+	s.SSLKEYBytes = []byte(keyFileContent)
+	c.Assert(s.AdjustDBTLS(), IsNil)
+
+	taskStr := `---
+name: test
+task-mode: all
+target-database:
+  host: "127.0.0.1"
+  port: 4000
+  user: "root"
+  password: ""
+  security:
+    ssl-verify-mode: "verify-ca"
+block-allow-list:
+  instance:
+    do-dbs: ["dm_benchmark"]
+mysql-instances:
+  - source-id: "mysql-replica-01-tls"
+    block-allow-list: "instance"
+    security:
+      ssl-verify-mode: "required"
+`
+	task := NewTaskConfig()
+	err := task.RawDecode(taskStr)
+	c.Assert(err, IsNil)
+	c.Assert(terror.ErrConfigInvalidDBTLS.Equal(task.adjust()), IsTrue)
+	task.TargetDB.Security.SSLVerifyMode = SSLVerifyModeRequired
+	c.Assert(task.adjust(), IsNil)
+
+	// the TLS config of the source in the task overrides the one of the source config
+	sourceSecurity := &Security{SSLCA: "source-ca.pem"}
+	stCfgs, err := TaskConfigToSubTaskConfigs(task, map[string]DBConfig{"mysql-replica-01-tls": {Security: sourceSecurity}})
+	c.Assert(err, IsNil)
+	c.Assert(stCfgs, HasLen, 1)
+	c.Assert(stCfgs[0].From.Security, DeepEquals, task.MySQLInstances[0].Security)
+	c.Assert(stCfgs[0].From.Security, Not(Equals), task.MySQLInstances[0].Security)
+	c.Assert(stCfgs[0].To.Security.SSLVerifyMode, Equals, SSLVerifyModeRequired)
+
+	task.MySQLInstances[0].Security.SSLVerifyMode = "preferred"
+	c.Assert(terror.ErrConfigInvalidDBTLS.Equal(task.adjust()), IsTrue)
+}
+
+func (t *testTLSConfig) TestToTLSConfig(c *C) {
+	confDir := "../../tests/tls/conf"
+	serverCert, err := tls.LoadX509KeyPair(path.Join(confDir, "dm.pem"), path.Join(confDir, "dm.key"))
+	c.Assert(err, IsNil)
+
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{serverCert}})
+	c.Assert(err, IsNil)
+	defer l.Close()
+	go func() {
+		for {
+			conn, err2 := l.Accept()
+			if err2 != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = conn.(*tls.Conn).Handshake()
+			}()
+		}
+	}()
+
+	// handshake with a server whose certificate is issued to `localhost` and `127.0.0.1` with the CN `dm`.
+	handshake := func(s *Security, host string) error {
+		c.Assert(s.LoadTLSContent(), IsNil)
+		tlsCfg, err2 := s.ToTLSConfig(host)
+		c.Assert(err2, IsNil)
+		conn, err2 := net.Dial("tcp", l.Addr().String())
+		c.Assert(err2, IsNil)
+		defer conn.Close()
+		return tls.Client(conn, tlsCfg).Handshake()
+	}
+
+	ca := path.Join(confDir, "ca.pem")
+	c.Assert(handshake(&Security{SSLCA: ca, SSLVerifyMode: SSLVerifyModeIdentity}, "localhost"), IsNil)
+	c.Assert(handshake(&Security{SSLCA: ca, SSLVerifyMode: SSLVerifyModeIdentity}, "example.com"), NotNil)
+	c.Assert(handshake(&Security{SSLCA: ca, SSLVerifyMode: SSLVerifyModeCA}, "example.com"), IsNil)
+	c.Assert(handshake(&Security{SSLCA: ca, SSLVerifyMode: SSLVerifyModeCA, CertAllowedCN: []string{"dm"}}, "example.com"), IsNil)
+	c.Assert(handshake(&Security{SSLCA: ca, SSLVerifyMode: SSLVerifyModeCA, CertAllowedCN: []string{"other"}}, "example.com"), NotNil)
+	c.Assert(handshake(&Security{SSLVerifyMode: SSLVerifyModeRequired}, "example.com"), IsNil)
+
+	// the client certificate and key are loaded
+	tlsCfg, err := (&Security{SSLCertBytes: []byte(certFileContent), SSLKEYBytes: []byte(keyFileContent), SSLVerifyMode: SSLVerifyModeRequired}).ToTLSConfig("localhost")
+	c.Assert(err, NotNil)
+	c.Assert(tlsCfg, IsNil)
+	s := &Security{SSLCert: path.Join(confDir, "dm.pem"), SSLKey: path.Join(confDir, "dm.key"), SSLVerifyMode: SSLVerifyModeRequired}
+	c.Assert(s.LoadTLSContent(), IsNil)
+	tlsCfg, err = s.ToTLSConfig("localhost")
+	c.Assert(err, IsNil)
+	c.Assert(tlsCfg.Certificates, HasLen, 1)
+}
@@ -58,8 +58,13 @@ type PurgeConfig struct {
 	Interval    int64 `yaml:"interval" toml:"interval" json:"interval"`             // check whether need to purge at this @Interval (seconds)
 	Expires     int64 `yaml:"expires" toml:"expires" json:"expires"`                // if file's modified time is older than @Expires (hours), then it can be purged
 	RemainSpace int64 `yaml:"remain-space" toml:"remain-space" json:"remain-space"` // if remain space in @RelayBaseDir less than @RemainSpace (GB), then it can be purged
+	// the algorithm used to compress the inactive relay log files at @Interval before they're purged, empty means no compression
+	Compression string `yaml:"compression,omitempty" toml:"compression,omitempty" json:"compression,omitempty"`
 }
 
+// RelayCompressionZstd compresses the inactive relay log files with zstd.
+const RelayCompressionZstd = "zstd"
+
 // SourceConfig is the configuration for source.
 type SourceConfig struct {
 	EnableGTID  bool   `yaml:"enable-gtid" toml:"enable-gtid" json:"enable-gtid"`
@@ -214,8 +219,20 @@ func (c *SourceConfig) Verify() error {
 		}
 	}
 
+	if err = c.From.Security.AdjustDBTLS(); err != nil {
+		return err
+	}
+
 	c.DecryptPassword()
 
+	for _, rule := range c.Filters {
+		if rule == nil {
+			continue
+		}
+		if err = validateBinlogEventRule(rule); err != nil {
+			return terror.ErrConfigBinlogEventFilter.Delegate(err)
+		}
+	}
 	_, err = bf.NewBinlogEvent(c.CaseSensitive, c.Filters)
 	if err != nil {
 		return terror.ErrConfigBinlogEventFilter.Delegate(err)
@@ -225,6 +242,10 @@ func (c *SourceConfig) Verify() error {
 		return terror.ErrConfigCheckerMaxTooSmall.Generate(c.Checker.BackoffMax.Duration, c.Checker.BackoffMin.Duration)
 	}
 
+	if c.Purge.Compression != "" && c.Purge.Compression != RelayCompressionZstd {
+		return terror.ErrConfigInvalidRelayCompression.Generate(c.Purge.Compression)
+	}
+
 	return nil
 }
 
@@ -280,7 +301,8 @@ func (c *SourceConfig) Adjust(ctx context.Context, db *sql.DB) (err error) {
 	}
 
 	if len(c.RelayDir) == 0 {
-		c.RelayDir = defaultRelayDir
+		// use a directory per source, so the relay logs of the sources bound to the same DM-worker don't mix
+		c.RelayDir = filepath.Join(defaultRelayDir, c.SourceID)
 	}
 	if filepath.IsAbs(c.RelayDir) {
 		log.L().Warn("using an absolute relay path, relay log can't work when starting multiple relay worker")
@@ -317,7 +339,16 @@ func (c *SourceConfig) AdjustFlavor(ctx context.Context, db *sql.DB) (err error)
 	return terror.WithScope(err, terror.ScopeUpstream)
 }
 
-// AdjustServerID adjust server id from DB.
+type reservedServerIDsKey struct{}
+
+// WithReservedServerIDs returns a context which excludes the server-ids from the allocation of
+// AdjustServerID, they are usually used by the other sources of the cluster.
+func WithReservedServerIDs(ctx context.Context, serverIDs map[uint32]struct{}) context.Context {
+	return context.WithValue(ctx, reservedServerIDsKey{}, serverIDs)
+}
+
+// AdjustServerID allocates a random server-id which is neither used by the upstream and its replicas
+// nor reserved by WithReservedServerIDs if the server-id is not set.
 func (c *SourceConfig) AdjustServerID(ctx context.Context, db *sql.DB) error {
 	if c.ServerID != 0 {
 		return nil
@@ -331,6 +362,7 @@ func (c *SourceConfig) AdjustServerID(ctx context.Context, db *sql.DB) error {
 		return terror.WithScope(err, terror.ScopeUpstream)
 	}
 
+	reserved, _ := ctx.Value(reservedServerIDsKey{}).(map[uint32]struct{})
 	rand.Seed(time.Now().UnixNano())
 	for i := 0; i < 5; i++ {
 		randomValue := uint32(rand.Intn(100000))
@@ -338,6 +370,9 @@ func (c *SourceConfig) AdjustServerID(ctx context.Context, db *sql.DB) error {
 		if _, ok := serverIDs[randomServerID]; ok {
 			continue
 		}
+		if _, ok := reserved[randomServerID]; ok {
+			continue
+		}
 
 		c.ServerID = randomServerID
 		return nil
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"time"
@@ -224,6 +225,22 @@ func (t *testConfig) TestConfigVerify(c *C) {
 			},
 			"",
 		},
+		{
+			func() *SourceConfig {
+				cfg := newConfig()
+				cfg.Purge.Compression = RelayCompressionZstd
+				return cfg
+			},
+			"",
+		},
+		{
+			func() *SourceConfig {
+				cfg := newConfig()
+				cfg.Purge.Compression = "gzip"
+				return cfg
+			},
+			".*invalid compression gzip of the relay log.*",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -307,6 +324,32 @@ func (t *testConfig) TestAdjustServerID(c *C) {
 	cfg.ServerID = 0
 	c.Assert(cfg.AdjustServerID(context.Background(), nil), IsNil)
 	c.Assert(cfg.ServerID, Not(Equals), 0)
+
+	// the server-ids reserved by other sources are not allocated
+	reserved := make(map[uint32]struct{})
+	for i := uint32(0); i < 100000; i++ {
+		reserved[defaultBaseServerID+i] = struct{}{}
+	}
+	cfg.ServerID = 0
+	ctx := WithReservedServerIDs(context.Background(), reserved)
+	c.Assert(cfg.AdjustServerID(ctx, nil), ErrorMatches, ".*can't find a random available server ID.*")
+}
+
+func (t *testConfig) TestAdjustRelayDir(c *C) {
+	cfg, err := LoadFromFile(sourceSampleFile)
+	c.Assert(err, IsNil)
+	cfg.RelayDir = ""
+	cfg.Flavor = mysql.MySQLFlavor
+	cfg.ServerID = 429
+	cfg.EnableGTID = false
+
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	mock.ExpectQuery("SELECT @@lower_case_table_names;").
+		WillReturnRows(sqlmock.NewRows([]string{"@@lower_case_table_names"}).AddRow(utils.LCTableNamesSensitive))
+	c.Assert(cfg.Adjust(context.Background(), db), IsNil)
+	c.Assert(cfg.RelayDir, Equals, filepath.Join(defaultRelayDir, cfg.SourceID))
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
 }
 
 func getMockServerIDs(ctx context.Context, db *sql.DB) (map[uint32]struct{}, error) {
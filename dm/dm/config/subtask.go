@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
@@ -40,6 +41,13 @@ const (
 	ModeAll       = "all"
 	ModeFull      = "full"
 	ModeIncrement = "incremental"
+	// ModeDump only dumps the data of the sources into the dump directory.
+	ModeDump = "dump"
+	// ModeLoad only loads an existing dump directory into the target.
+	ModeLoad = "load"
+	// ModeLoadSync loads an existing dump directory into the target, and then replicates the binlog
+	// from the position or GTID in the metadata of the dump, like the `all` mode without dumping.
+	ModeLoadSync = "load-sync"
 
 	DefaultShadowTableRules = "^_(.+)_(?:new|gho)$"
 	DefaultTrashTableRules  = "^_(.+)_(?:ghc|del|old)$"
@@ -261,6 +269,7 @@ type SubTaskConfig struct {
 	FilterRules        []*bf.BinlogEventRule `toml:"filter-rules" json:"filter-rules"`
 	ColumnMappingRules []*column.Rule        `toml:"mapping-rule" json:"mapping-rule"`
 	ExprFilter         []*ExpressionFilter   `yaml:"expression-filter" toml:"expression-filter" json:"expression-filter"`
+	ColumnTransforms   []*ColumnTransform    `yaml:"column-transforms" toml:"column-transforms" json:"column-transforms"`
 
 	// black-white-list is deprecated, use block-allow-list instead
 	BWList *filter.Rules `toml:"black-white-list" json:"black-white-list"`
@@ -270,6 +279,8 @@ type SubTaskConfig struct {
 	LoaderConfig   // Loader configuration
 	SyncerConfig   // Syncer configuration
 
+	ValidatorCfg ValidatorConfig `yaml:"validator" toml:"validator" json:"validator"`
+
 	// compatible with standalone dm unit
 	LogLevel  string `toml:"log-level" json:"log-level"`
 	LogFile   string `toml:"log-file" json:"log-file"`
@@ -283,6 +294,8 @@ type SubTaskConfig struct {
 
 	CleanDumpFile bool `toml:"clean-dump-file" json:"clean-dump-file"`
 
+	ResourceLimits ResourceLimits `toml:"resource-limits" json:"resource-limits"`
+
 	// deprecated, will auto discover SQL mode
 	EnableANSIQuotes bool `toml:"ansi-quotes" json:"ansi-quotes"`
 
@@ -420,7 +433,8 @@ func (c *SubTaskConfig) Adjust(verifyDecryptPassword bool) error {
 	}
 
 	dirSuffix := "." + c.Name
-	if !strings.HasSuffix(c.LoaderConfig.Dir, dirSuffix) { // check to support multiple times calling
+	// for ModeLoad and ModeLoadSync the dump directory is given by the user, which may be dumped by another task or outside of DM
+	if !c.IsDumpGivenByUser() && !strings.HasSuffix(c.LoaderConfig.Dir, dirSuffix) { // check to support multiple times calling
 		// if not ends with the task name, we append the task name to the tail
 		c.LoaderConfig.Dir += dirSuffix
 	}
@@ -431,6 +445,19 @@ func (c *SubTaskConfig) Adjust(verifyDecryptPassword bool) error {
 	if c.SyncerConfig.CheckpointFlushInterval == 0 {
 		c.SyncerConfig.CheckpointFlushInterval = defaultCheckpointFlushInterval
 	}
+	if err := c.adjustCheckpointStorage(); err != nil {
+		return err
+	}
+	if err := c.adjustHeartbeat(); err != nil {
+		return err
+	}
+	if err := c.adjustSafeModeDuration(); err != nil {
+		return err
+	}
+	// the subtasks created by the older versions have no validator config.
+	if err := c.ValidatorCfg.Adjust(); err != nil {
+		return err
+	}
 
 	c.From.AdjustWithTimeZone(c.Timezone)
 	c.To.AdjustWithTimeZone(c.Timezone)
@@ -461,9 +488,33 @@ func (c *SubTaskConfig) Adjust(verifyDecryptPassword bool) error {
 		return terror.ErrConfigInvalidChunkFileSize.Generate(c.MydumperConfig.ChunkFilesize)
 	}
 
+	switch c.LoaderConfig.ImportMode {
+	case "":
+	case ImportModeSQL:
+		c.TiDB.Backend = ""
+	case ImportModeLogical:
+		c.TiDB.Backend = lcfg.BackendTiDB
+	case ImportModePhysical:
+		c.TiDB.Backend = lcfg.BackendLocal
+	default:
+		return terror.ErrConfigInvalidImportMode.Generate(c.LoaderConfig.ImportMode)
+	}
 	if c.TiDB.Backend != "" && c.TiDB.Backend != lcfg.BackendLocal && c.TiDB.Backend != lcfg.BackendTiDB {
 		return terror.ErrLoadBackendNotSupport.Generate(c.TiDB.Backend)
 	}
+	if err := c.ResourceLimits.adjust(); err != nil {
+		return err
+	}
+
+	for i, transform := range c.ColumnTransforms {
+		if err := transform.adjust(fmt.Sprintf("column-transforms[%d]", i)); err != nil {
+			return err
+		}
+		// TiDB Lightning imports the dumped files as is.
+		if c.NeedUseLightning() {
+			return terror.ErrConfigInvalidColumnTransform.Generate(fmt.Sprintf("column-transforms[%d]", i), "not supported by the import-mode "+c.LoaderConfig.ImportMode)
+		}
+	}
 	if _, err := bf.NewBinlogEvent(c.CaseSensitive, c.FilterRules); err != nil {
 		return terror.ErrConfigBinlogEventFilter.Delegate(err)
 	}
@@ -509,6 +560,61 @@ func (c *SubTaskConfig) Parse(arguments []string, verifyDecryptPassword bool) er
 	return c.Adjust(verifyDecryptPassword)
 }
 
+// adjustCheckpointStorage checks the storage of the syncer checkpoints.
+func (c *SubTaskConfig) adjustCheckpointStorage() error {
+	switch c.CheckpointStorage {
+	case "", CheckpointStorageDownstream:
+		return nil
+	case CheckpointStorageMySQL:
+		if c.CheckpointDB == nil {
+			return terror.ErrConfigInvalidCheckpointStorage.Generate(c.CheckpointStorage, "`checkpoint-db` is not set")
+		}
+		// the syncer config may be shared by the subtasks of the task
+		c.CheckpointDB = c.CheckpointDB.Clone()
+		c.CheckpointDB.AdjustWithTimeZone(c.Timezone)
+	case CheckpointStorageEtcd:
+	default:
+		return terror.ErrConfigInvalidCheckpointStorage.Generate(c.CheckpointStorage, "not supported")
+	}
+	// the sharding meta of the pessimistic mode is flushed with the checkpoints in the downstream.
+	if c.ShardMode == ShardPessimistic {
+		return terror.ErrConfigInvalidCheckpointStorage.Generate(c.CheckpointStorage, "only `downstream` is supported in the pessimistic shard mode")
+	}
+	return nil
+}
+
+// adjustHeartbeat checks the heartbeat used to measure the replication lag.
+func (c *SubTaskConfig) adjustHeartbeat() error {
+	if c.HeartbeatInterval < 0 {
+		return terror.ErrConfigInvalidHeartbeat.Generate(fmt.Sprintf("`heartbeat-interval` %d is negative", c.HeartbeatInterval))
+	}
+	if c.HeartbeatTable == "" {
+		return nil
+	}
+	if c.HeartbeatInterval > 0 {
+		return terror.ErrConfigInvalidHeartbeat.Generate("`heartbeat-interval` and `heartbeat-table` can't be set at the same time")
+	}
+	if _, _, ok := c.HeartbeatSourceTable(); !ok {
+		return terror.ErrConfigInvalidHeartbeat.Generate(fmt.Sprintf("`heartbeat-table` %s is not in `schema.table` format", c.HeartbeatTable))
+	}
+	return nil
+}
+
+// adjustSafeModeDuration checks the duration to engage safe-mode automatically.
+func (c *SubTaskConfig) adjustSafeModeDuration() error {
+	if c.SafeModeDuration == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(c.SafeModeDuration)
+	if err != nil {
+		return terror.ErrConfigInvalidSafeModeDuration.Generate(c.SafeModeDuration, err.Error())
+	}
+	if duration < 0 {
+		return terror.ErrConfigInvalidSafeModeDuration.Generate(c.SafeModeDuration, "the duration is negative")
+	}
+	return nil
+}
+
 // DecryptPassword tries to decrypt db password in config.
 func (c *SubTaskConfig) DecryptPassword() (*SubTaskConfig, error) {
 	clone, err := c.Clone()
@@ -528,6 +634,9 @@ func (c *SubTaskConfig) DecryptPassword() (*SubTaskConfig, error) {
 	}
 	clone.From.Password = pswdFrom
 	clone.To.Password = pswdTo
+	if clone.CheckpointDB != nil && len(clone.CheckpointDB.Password) > 0 {
+		clone.CheckpointDB.Password = utils.DecryptOrPlaintext(clone.CheckpointDB.Password)
+	}
 
 	return clone, nil
 }
@@ -548,7 +657,13 @@ func (c *SubTaskConfig) Clone() (*SubTaskConfig, error) {
 	return clone, nil
 }
 
+// IsDumpGivenByUser returns whether the dump directory is given by the user instead of dumped by the task,
+// the dump files are never cleaned since they may be reused or shared with other tasks.
+func (c *SubTaskConfig) IsDumpGivenByUser() bool {
+	return c.Mode == ModeLoad || c.Mode == ModeLoadSync
+}
+
 // NeedUseLightning returns whether need to use lightning loader.
 func (c *SubTaskConfig) NeedUseLightning() bool {
-	return (c.Mode == ModeAll || c.Mode == ModeFull) && c.TiDB.Backend != ""
+	return (c.Mode == ModeAll || c.Mode == ModeFull || c.Mode == ModeLoad || c.Mode == ModeLoadSync) && c.TiDB.Backend != ""
 }
@@ -18,6 +18,8 @@ import (
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb-tools/pkg/filter"
+
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 )
 
 func (t *testConfig) TestSubTask(c *C) {
@@ -135,6 +137,80 @@ func (t *testConfig) TestSubTaskAdjustFail(c *C) {
 			},
 			"\\[.*\\], Message: online scheme rtc not supported.*",
 		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.ImportMode = "fast"
+				return cfg
+			},
+			"\\[.*\\], Message: invalid import-mode fast of the loader.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.CheckpointStorage = "redis"
+				return cfg
+			},
+			"\\[.*\\], Message: invalid checkpoint-storage redis of the syncer: not supported.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.CheckpointStorage = CheckpointStorageMySQL
+				return cfg
+			},
+			"\\[.*\\], Message: invalid checkpoint-storage mysql of the syncer: `checkpoint-db` is not set.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.CheckpointStorage = CheckpointStorageEtcd
+				cfg.ShardMode = ShardPessimistic
+				return cfg
+			},
+			"\\[.*\\], Message: invalid checkpoint-storage etcd of the syncer: only `downstream` is supported in the pessimistic shard mode.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.HeartbeatInterval = -1
+				return cfg
+			},
+			"\\[.*\\], Message: invalid heartbeat config of the syncer: `heartbeat-interval` -1 is negative.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.HeartbeatInterval = 1
+				cfg.HeartbeatTable = "percona.heartbeat"
+				return cfg
+			},
+			"\\[.*\\], Message: invalid heartbeat config of the syncer: `heartbeat-interval` and `heartbeat-table` can't be set at the same time.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.HeartbeatTable = "heartbeat"
+				return cfg
+			},
+			"\\[.*\\], Message: invalid heartbeat config of the syncer: `heartbeat-table` heartbeat is not in `schema.table` format.*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.SafeModeDuration = "60"
+				return cfg
+			},
+			"\\[.*\\], Message: invalid safe-mode-duration 60: .*",
+		},
+		{
+			func() *SubTaskConfig {
+				cfg := newSubTaskConfig()
+				cfg.SafeModeDuration = "-1s"
+				return cfg
+			},
+			"\\[.*\\], Message: invalid safe-mode-duration -1s: the duration is negative.*",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -144,6 +220,93 @@ func (t *testConfig) TestSubTaskAdjustFail(c *C) {
 	}
 }
 
+func (t *testConfig) TestSubTaskImportMode(c *C) {
+	cfg := &SubTaskConfig{
+		Name:     "test-task",
+		SourceID: "mysql-instance-01",
+		TiDB:     TiDBExtraConfig{Backend: "tidb"},
+	}
+	c.Assert(cfg.Adjust(false), IsNil)
+	// keep the backend of the task if import-mode is not set
+	c.Assert(cfg.TiDB.Backend, Equals, "tidb")
+	c.Assert(cfg.NeedUseLightning(), IsFalse)
+	cfg.Mode = ModeAll
+	c.Assert(cfg.NeedUseLightning(), IsTrue)
+
+	cases := []struct {
+		importMode string
+		backend    string
+	}{
+		{ImportModeSQL, ""},
+		{ImportModeLogical, "tidb"},
+		{ImportModePhysical, "local"},
+	}
+	for _, cs := range cases {
+		cfg.ImportMode = cs.importMode
+		c.Assert(cfg.Adjust(false), IsNil)
+		c.Assert(cfg.TiDB.Backend, Equals, cs.backend)
+		c.Assert(cfg.NeedUseLightning(), Equals, cs.backend != "")
+	}
+}
+
+func (t *testConfig) TestSubTaskDumpGivenByUser(c *C) {
+	for _, cs := range []struct {
+		mode        string
+		givenByUser bool
+	}{
+		{ModeAll, false},
+		{ModeFull, false},
+		{ModeLoad, true},
+		{ModeLoadSync, true},
+	} {
+		cfg := &SubTaskConfig{
+			Name:         "test-task",
+			SourceID:     "mysql-instance-01",
+			Mode:         cs.mode,
+			LoaderConfig: LoaderConfig{Dir: "/data/nightly"},
+		}
+		c.Assert(cfg.Adjust(false), IsNil)
+		c.Assert(cfg.IsDumpGivenByUser(), Equals, cs.givenByUser)
+		// the dump directory given by the user is used as it is
+		if cs.givenByUser {
+			c.Assert(cfg.Dir, Equals, "/data/nightly")
+		} else {
+			c.Assert(cfg.Dir, Equals, "/data/nightly.test-task")
+		}
+	}
+}
+
+func (t *testConfig) TestSubTaskCheckpointStorage(c *C) {
+	cfg := &SubTaskConfig{
+		Name:     "test-task",
+		SourceID: "mysql-instance-01",
+		To: DBConfig{
+			Host: "127.0.0.1",
+			Port: 4306,
+			User: "root",
+		},
+		Timezone: "+08:00",
+	}
+	c.Assert(cfg.Adjust(false), IsNil)
+	c.Assert(cfg.CheckpointStorage, Equals, "")
+
+	cfg.CheckpointStorage = CheckpointStorageMySQL
+	cfg.CheckpointDB = &DBConfig{
+		Host:     "127.0.0.1",
+		Port:     3306,
+		User:     "root",
+		Password: "Up8156jArvIPymkVC+5LxkAT6rek",
+	}
+	c.Assert(cfg.Adjust(false), IsNil)
+	// the checkpoint DB is adjusted like the downstream
+	c.Assert(cfg.CheckpointDB.Session["time_zone"], Equals, "+08:00")
+	c.Assert(cfg.CheckpointDB.Password, Equals, "1234")
+
+	cfg.CheckpointStorage = CheckpointStorageEtcd
+	cfg.ShardMode = ShardOptimistic
+	c.Assert(cfg.Adjust(false), IsNil)
+}
+
 func (t *testConfig) TestSubTaskBlockAllowList(c *C) {
 	filterRules1 := &filter.Rules{
 		DoDBs: []string{"s1"},
@@ -206,3 +369,45 @@ func (t *testConfig) TestDBConfigClone(c *C) {
 	c.Assert(a, DeepEquals, b)
 	c.Assert(a.Security, Not(Equals), b.Security)
 }
+
+func (t *testConfig) TestSubTaskResourceLimits(c *C) {
+	cfg := &SubTaskConfig{
+		Name:     "test-task",
+		SourceID: "mysql-instance-01",
+		LoaderConfig: LoaderConfig{
+			PoolSize: 16,
+		},
+		SyncerConfig: SyncerConfig{
+			WorkerCount: 16,
+		},
+	}
+	c.Assert(cfg.Adjust(false), IsNil)
+	c.Assert(cfg.ResourceLimits.LoaderMemoryBytes(), Equals, int64(0))
+	// no limits
+	cfg.ApplyResourceLimits()
+	c.Assert(cfg.PoolSize, Equals, 16)
+	c.Assert(cfg.WorkerCount, Equals, 16)
+
+	invalids := []ResourceLimits{
+		{MaxDownstreamConnections: -1},
+		{MaxSyncerWorkerCount: -1},
+		{MaxLoaderMemory: "512 apples"},
+	}
+	for _, limits := range invalids {
+		cfg.ResourceLimits = limits
+		c.Assert(terror.ErrConfigInvalidResourceLimits.Equal(cfg.Adjust(false)), IsTrue)
+	}
+
+	cfg.ResourceLimits = ResourceLimits{MaxDownstreamConnections: 8, MaxLoaderMemory: "512MiB", MaxSyncerWorkerCount: 12}
+	c.Assert(cfg.Adjust(false), IsNil)
+	c.Assert(cfg.ResourceLimits.LoaderMemoryBytes(), Equals, int64(512*1024*1024))
+	cfg.ApplyResourceLimits()
+	c.Assert(cfg.PoolSize, Equals, 8)
+	c.Assert(cfg.WorkerCount, Equals, 8)
+
+	cfg.WorkerCount = 16
+	cfg.ResourceLimits = ResourceLimits{MaxDownstreamConnections: 32, MaxSyncerWorkerCount: 12}
+	cfg.ApplyResourceLimits()
+	c.Assert(cfg.PoolSize, Equals, 8)
+	c.Assert(cfg.WorkerCount, Equals, 12)
+}
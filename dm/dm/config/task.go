@@ -18,8 +18,10 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/coreos/go-semver/semver"
 	"github.com/dustin/go-humanize"
@@ -31,6 +33,7 @@ import (
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v2"
 
+	"github.com/pingcap/ticdc/dm/pkg/gtid"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
 	"github.com/pingcap/ticdc/dm/pkg/utils"
@@ -50,6 +53,13 @@ const (
 	tidbTxnOptimistic = "optimistic"
 )
 
+// storage of the syncer checkpoints.
+const (
+	CheckpointStorageDownstream = "downstream"
+	CheckpointStorageMySQL      = "mysql"
+	CheckpointStorageEtcd       = "etcd"
+)
+
 // default config item values.
 var (
 	// TaskConfig.
@@ -71,6 +81,11 @@ var (
 	defaultBatch                   = 100
 	defaultQueueSize               = 1024 // do not give too large default value to avoid OOM
 	defaultCheckpointFlushInterval = 30   // in seconds
+	// ValidatorConfig.
+	defaultValidatorSampleRate    = 10
+	defaultValidatorCheckInterval = "5s"
+	defaultValidatorRowErrorDelay = "30s"
+	defaultValidatorBatchSize     = 100
 
 	// TargetDBConfig.
 	defaultSessionCfg = []struct {
@@ -84,7 +99,7 @@ var (
 
 // Meta represents binlog's meta pos
 // NOTE: refine to put these config structs into pkgs
-// NOTE: now, syncer does not support GTID mode and which is supported by relay.
+// NOTE: syncer starts from `binlog-gtid` if GTID is enabled for the source, otherwise from `binlog-name` and `binlog-pos`.
 type Meta struct {
 	BinLogName string `toml:"binlog-name" yaml:"binlog-name"`
 	BinLogPos  uint32 `toml:"binlog-pos" yaml:"binlog-pos"`
@@ -97,6 +112,12 @@ func (m *Meta) Verify() error {
 	if m != nil && len(m.BinLogName) == 0 && len(m.BinLogGTID) == 0 {
 		return terror.ErrConfigMetaInvalid.Generate()
 	}
+	// the flavor is unknown until bound to a source, so both MySQL and MariaDB GTID sets are accepted.
+	if m != nil && len(m.BinLogGTID) > 0 {
+		if _, err := gtid.ParserGTID("", m.BinLogGTID); err != nil {
+			return terror.ErrConfigMetaInvalid.Delegate(err)
+		}
+	}
 
 	return nil
 }
@@ -104,12 +125,13 @@ func (m *Meta) Verify() error {
 // MySQLInstance represents a sync config of a MySQL instance.
 type MySQLInstance struct {
 	// it represents a MySQL/MariaDB instance or a replica group
-	SourceID           string   `yaml:"source-id"`
-	Meta               *Meta    `yaml:"meta"`
-	FilterRules        []string `yaml:"filter-rules"`
-	ColumnMappingRules []string `yaml:"column-mapping-rules"`
-	RouteRules         []string `yaml:"route-rules"`
-	ExpressionFilters  []string `yaml:"expression-filters"`
+	SourceID             string   `yaml:"source-id"`
+	Meta                 *Meta    `yaml:"meta"`
+	FilterRules          []string `yaml:"filter-rules"`
+	ColumnMappingRules   []string `yaml:"column-mapping-rules"`
+	RouteRules           []string `yaml:"route-rules"`
+	ExpressionFilters    []string `yaml:"expression-filters"`
+	ColumnTransformRules []string `yaml:"column-transform-rules"`
 
 	// black-white-list is deprecated, use block-allow-list instead
 	BWListName string `yaml:"black-white-list"`
@@ -129,6 +151,12 @@ type MySQLInstance struct {
 	Syncer           *SyncerConfig `yaml:"syncer"`
 	// SyncerThread is alias for WorkerCount in SyncerConfig, and its priority is higher than WorkerCount
 	SyncerThread int `yaml:"syncer-thread"`
+
+	ValidatorConfigName string           `yaml:"validator-config-name"`
+	Validator           *ValidatorConfig `yaml:"validator"`
+
+	// Security overrides the TLS config of the source config to connect to the source in the task
+	Security *Security `yaml:"security"`
 }
 
 // VerifyAndAdjust does verification on configs, and adjust some configs.
@@ -145,6 +173,10 @@ func (m *MySQLInstance) VerifyAndAdjust() error {
 		return terror.Annotatef(err, "source %s", m.SourceID)
 	}
 
+	if err := m.Security.AdjustDBTLS(); err != nil {
+		return terror.Annotatef(err, "source %s", m.SourceID)
+	}
+
 	if len(m.MydumperConfigName) > 0 && m.Mydumper != nil {
 		return terror.ErrConfigMydumperCfgConflict.Generate()
 	}
@@ -154,6 +186,9 @@ func (m *MySQLInstance) VerifyAndAdjust() error {
 	if len(m.SyncerConfigName) > 0 && m.Syncer != nil {
 		return terror.ErrConfigSyncerCfgConflict.Generate()
 	}
+	if len(m.ValidatorConfigName) > 0 && m.Validator != nil {
+		return terror.ErrConfigValidatorCfgConflict.Generate()
+	}
 
 	if len(m.BAListName) == 0 && len(m.BWListName) != 0 {
 		m.BAListName = m.BWListName
@@ -164,7 +199,7 @@ func (m *MySQLInstance) VerifyAndAdjust() error {
 
 // MydumperConfig represents mydumper process unit's specific config.
 type MydumperConfig struct {
-	MydumperPath  string `yaml:"mydumper-path" toml:"mydumper-path" json:"mydumper-path"`    // mydumper binary path
+	MydumperPath  string `yaml:"mydumper-path" toml:"mydumper-path" json:"mydumper-path"`    // deprecated, the dump unit uses the embedded dumpling
 	Threads       int    `yaml:"threads" toml:"threads" json:"threads"`                      // -t, --threads
 	ChunkFilesize string `yaml:"chunk-filesize" toml:"chunk-filesize" json:"chunk-filesize"` // -F, --chunk-filesize
 	StatementSize uint64 `yaml:"statement-size" toml:"statement-size" json:"statement-size"` // -S, --statement-size
@@ -200,11 +235,26 @@ func (m *MydumperConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	return nil
 }
 
+// import modes of the load unit.
+const (
+	// ImportModeSQL replays the dumped SQL files to the target DB.
+	ImportModeSQL = "sql"
+	// ImportModeLogical imports the dumped files by TiDB Lightning with the tidb backend.
+	ImportModeLogical = "logical"
+	// ImportModePhysical imports the dumped files by TiDB Lightning with the local backend,
+	// which encodes the data to KV pairs and ingests them into TiKV directly.
+	ImportModePhysical = "physical"
+)
+
 // LoaderConfig represents loader process unit's specific config.
 type LoaderConfig struct {
 	PoolSize int    `yaml:"pool-size" toml:"pool-size" json:"pool-size"`
 	Dir      string `yaml:"dir" toml:"dir" json:"dir"`
 	SQLMode  string `yaml:"-" toml:"-" json:"-"` // wrote by dump unit
+	// ImportMode overrides `tidb.backend` of the task if set, see ImportModeSQL, ImportModeLogical and ImportModePhysical.
+	ImportMode string `yaml:"import-mode,omitempty" toml:"import-mode" json:"import-mode"`
+	// SortingDirPhysical is the directory to sort the KV pairs for ImportModePhysical, default to Dir.
+	SortingDirPhysical string `yaml:"sorting-dir-physical,omitempty" toml:"sorting-dir-physical" json:"sorting-dir-physical"`
 }
 
 // DefaultLoaderConfig return default loader config for task.
@@ -239,6 +289,20 @@ type SyncerConfig struct {
 	// TODO: add this two new config items for openapi.
 	Compact      bool `yaml:"compact" toml:"compact" json:"compact"`
 	MultipleRows bool `yaml:"multiple-rows" toml:"multiple-rows" json:"multiple-rows"`
+	// batch the UPDATEs of the same table changing the primary/unique keys as a multiple rows DELETE and a
+	// multiple rows INSERT, which implies `multiple-rows`.
+	BatchDML bool `yaml:"batch-dml,omitempty" toml:"batch-dml" json:"batch-dml"`
+
+	// the storage of the checkpoints, `downstream` (default), `mysql` or `etcd`.
+	CheckpointStorage string `yaml:"checkpoint-storage,omitempty" toml:"checkpoint-storage" json:"checkpoint-storage"`
+	// the external MySQL to store the checkpoints when `checkpoint-storage` is `mysql`.
+	CheckpointDB *DBConfig `yaml:"checkpoint-db,omitempty" toml:"checkpoint-db" json:"checkpoint-db"`
+
+	// the interval in seconds to write heartbeats into the source to measure the replication lag, 0 disables it.
+	HeartbeatInterval int `yaml:"heartbeat-interval,omitempty" toml:"heartbeat-interval" json:"heartbeat-interval"`
+	// an existing heartbeat table of the source in `schema.table` format, like the one of pt-heartbeat,
+	// which is read to measure the replication lag instead of the heartbeats written by DM.
+	HeartbeatTable string `yaml:"heartbeat-table,omitempty" toml:"heartbeat-table" json:"heartbeat-table"`
 
 	// deprecated
 	MaxRetry int `yaml:"max-retry" toml:"max-retry" json:"max-retry"`
@@ -249,8 +313,19 @@ type SyncerConfig struct {
 	// deprecated
 	DisableCausality bool `yaml:"disable-detect" toml:"disable-detect" json:"disable-detect"`
 	SafeMode         bool `yaml:"safe-mode" toml:"safe-mode" json:"safe-mode"`
+	// the duration like `60s` to engage safe-mode automatically after the syncer restarts or its checkpoint regresses,
+	// empty means twice of `checkpoint-flush-interval` and `0s` disables it.
+	SafeModeDuration string `yaml:"safe-mode-duration,omitempty" toml:"safe-mode-duration" json:"safe-mode-duration"`
+	// the max count of the prepared statements cached in each DML connection to the downstream, 0 disables it.
+	// the statements of a table are prepared again after the DDLs of the table. it doesn't work with `multiple-rows`.
+	PreparedStmtCacheSize int `yaml:"prepared-stmt-cache-size,omitempty" toml:"prepared-stmt-cache-size" json:"prepared-stmt-cache-size"`
 	// deprecated, use `ansi-quotes` in top level config instead
 	EnableANSIQuotes bool `yaml:"enable-ansi-quotes" toml:"enable-ansi-quotes" json:"enable-ansi-quotes"`
+
+	// the online DDL tool (gh-ost or pt) used on the source, which enables online DDL for the
+	// mysql instances referring this syncer config even if `online-ddl` of the task is false.
+	// it's only used in the task config, the subtask config has its own `online-ddl-scheme`.
+	OnlineDDLScheme string `yaml:"online-ddl-scheme,omitempty" toml:"-" json:"-"`
 }
 
 // DefaultSyncerConfig return default syncer config for task.
@@ -276,6 +351,110 @@ func (m *SyncerConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// HeartbeatSourceTable returns the schema and table name of `heartbeat-table`.
+func (m *SyncerConfig) HeartbeatSourceTable() (schema, table string, ok bool) {
+	parts := strings.Split(m.HeartbeatTable, ".")
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	schema, table = strings.Trim(parts[0], "`"), strings.Trim(parts[1], "`")
+	return schema, table, schema != "" && table != ""
+}
+
+// validation modes of the validator.
+const (
+	// ValidationNone disables the validator.
+	ValidationNone = "none"
+	// ValidationFull validates all the replicated rows.
+	ValidationFull = "full"
+	// ValidationSample validates the replicated rows of the keys sampled by `sample-rate`.
+	ValidationSample = "sample"
+)
+
+// ValidatorConfig represents validator's specific config, the validator checks the rows replicated by the
+// sync unit exist with the same values in the downstream.
+type ValidatorConfig struct {
+	Mode string `yaml:"mode" toml:"mode" json:"mode"`
+	// the percentage of the keys whose rows are validated in ValidationSample mode.
+	SampleRate int `yaml:"sample-rate" toml:"sample-rate" json:"sample-rate"`
+	// the duration like `5s` between validating the pending rows, a changed row is validated after it at least.
+	CheckInterval string `yaml:"check-interval" toml:"check-interval" json:"check-interval"`
+	// the duration like `30s` a mismatched row keeps being validated again before it's reported as a row error,
+	// which should be longer than the replication lag.
+	RowErrorDelay string `yaml:"row-error-delay" toml:"row-error-delay" json:"row-error-delay"`
+	// the max count of the rows of a table selected from the downstream in one query.
+	BatchSize int `yaml:"batch-size" toml:"batch-size" json:"batch-size"`
+}
+
+// DefaultValidatorConfig return default validator config for task.
+func DefaultValidatorConfig() ValidatorConfig {
+	return ValidatorConfig{
+		Mode:          ValidationNone,
+		SampleRate:    defaultValidatorSampleRate,
+		CheckInterval: defaultValidatorCheckInterval,
+		RowErrorDelay: defaultValidatorRowErrorDelay,
+		BatchSize:     defaultValidatorBatchSize,
+	}
+}
+
+// alias to avoid infinite recursion for UnmarshalYAML.
+type rawValidatorConfig ValidatorConfig
+
+// UnmarshalYAML implements Unmarshaler.UnmarshalYAML.
+func (m *ValidatorConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	raw := rawValidatorConfig(DefaultValidatorConfig())
+	if err := unmarshal(&raw); err != nil {
+		return terror.ErrConfigYamlTransform.Delegate(err, "unmarshal validator config")
+	}
+	*m = ValidatorConfig(raw) // raw used only internal, so no deep copy
+	return nil
+}
+
+// Adjust verifies the validator config, and fills the empty items with the defaults.
+func (m *ValidatorConfig) Adjust() error {
+	defaultCfg := DefaultValidatorConfig()
+	if m.Mode == "" {
+		m.Mode = defaultCfg.Mode
+	}
+	if m.SampleRate == 0 {
+		m.SampleRate = defaultCfg.SampleRate
+	}
+	if m.CheckInterval == "" {
+		m.CheckInterval = defaultCfg.CheckInterval
+	}
+	if m.RowErrorDelay == "" {
+		m.RowErrorDelay = defaultCfg.RowErrorDelay
+	}
+	if m.BatchSize == 0 {
+		m.BatchSize = defaultCfg.BatchSize
+	}
+
+	switch m.Mode {
+	case ValidationNone, ValidationFull, ValidationSample:
+	default:
+		return terror.ErrConfigInvalidValidator.Generate(fmt.Sprintf("unknown mode %s", m.Mode))
+	}
+	if m.SampleRate < 0 || m.SampleRate > 100 {
+		return terror.ErrConfigInvalidValidator.Generate(fmt.Sprintf("sample-rate %d is out of range", m.SampleRate))
+	}
+	if m.BatchSize < 0 {
+		return terror.ErrConfigInvalidValidator.Generate(fmt.Sprintf("negative batch-size %d", m.BatchSize))
+	}
+	for name, value := range map[string]string{"check-interval": m.CheckInterval, "row-error-delay": m.RowErrorDelay} {
+		if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+			return terror.ErrConfigInvalidValidator.Generate(fmt.Sprintf("%s %s is not a positive duration", name, value))
+		}
+	}
+	return nil
+}
+
+// Durations returns the parsed check-interval and row-error-delay of an adjusted config.
+func (m *ValidatorConfig) Durations() (checkInterval, rowErrorDelay time.Duration) {
+	checkInterval, _ = time.ParseDuration(m.CheckInterval)
+	rowErrorDelay, _ = time.ParseDuration(m.RowErrorDelay)
+	return checkInterval, rowErrorDelay
+}
+
 // TaskConfig is the configuration for Task.
 type TaskConfig struct {
 	*flag.FlagSet `yaml:"-" toml:"-" json:"-"`
@@ -313,10 +492,11 @@ type TaskConfig struct {
 	// deprecated
 	OnlineDDLScheme string `yaml:"online-ddl-scheme" toml:"online-ddl-scheme" json:"online-ddl-scheme"`
 
-	Routes         map[string]*router.TableRule   `yaml:"routes" toml:"routes" json:"routes"`
-	Filters        map[string]*bf.BinlogEventRule `yaml:"filters" toml:"filters" json:"filters"`
-	ColumnMappings map[string]*column.Rule        `yaml:"column-mappings" toml:"column-mappings" json:"column-mappings"`
-	ExprFilter     map[string]*ExpressionFilter   `yaml:"expression-filter" toml:"expression-filter" json:"expression-filter"`
+	Routes           map[string]*router.TableRule   `yaml:"routes" toml:"routes" json:"routes"`
+	Filters          map[string]*bf.BinlogEventRule `yaml:"filters" toml:"filters" json:"filters"`
+	ColumnMappings   map[string]*column.Rule        `yaml:"column-mappings" toml:"column-mappings" json:"column-mappings"`
+	ExprFilter       map[string]*ExpressionFilter   `yaml:"expression-filter" toml:"expression-filter" json:"expression-filter"`
+	ColumnTransforms map[string]*ColumnTransform    `yaml:"column-transforms" toml:"column-transforms" json:"column-transforms"`
 
 	// black-white-list is deprecated, use block-allow-list instead
 	BWList map[string]*filter.Rules `yaml:"black-white-list" toml:"black-white-list" json:"black-white-list"`
@@ -326,6 +506,8 @@ type TaskConfig struct {
 	Loaders   map[string]*LoaderConfig   `yaml:"loaders" toml:"loaders" json:"loaders"`
 	Syncers   map[string]*SyncerConfig   `yaml:"syncers" toml:"syncers" json:"syncers"`
 
+	Validators map[string]*ValidatorConfig `yaml:"validators" toml:"validators" json:"validators"`
+
 	CleanDumpFile bool `yaml:"clean-dump-file" toml:"clean-dump-file" json:"clean-dump-file"`
 	// deprecated
 	EnableANSIQuotes bool `yaml:"ansi-quotes" toml:"ansi-quotes" json:"ansi-quotes"`
@@ -335,6 +517,8 @@ type TaskConfig struct {
 
 	// extra config when target db is TiDB
 	TiDB *TiDBExtraConfig `yaml:"tidb" toml:"tidb" json:"tidb"`
+
+	ResourceLimits *ResourceLimits `yaml:"resource-limits" toml:"resource-limits" json:"resource-limits"`
 }
 
 // NewTaskConfig creates a TaskConfig.
@@ -351,11 +535,13 @@ func NewTaskConfig() *TaskConfig {
 		Filters:                 make(map[string]*bf.BinlogEventRule),
 		ColumnMappings:          make(map[string]*column.Rule),
 		ExprFilter:              make(map[string]*ExpressionFilter),
+		ColumnTransforms:        make(map[string]*ColumnTransform),
 		BWList:                  make(map[string]*filter.Rules),
 		BAList:                  make(map[string]*filter.Rules),
 		Mydumpers:               make(map[string]*MydumperConfig),
 		Loaders:                 make(map[string]*LoaderConfig),
 		Syncers:                 make(map[string]*SyncerConfig),
+		Validators:              make(map[string]*ValidatorConfig),
 		CleanDumpFile:           true,
 	}
 	cfg.FlagSet = flag.NewFlagSet("task", flag.ContinueOnError)
@@ -381,27 +567,52 @@ func (c *TaskConfig) JSON() string {
 	return string(cfg)
 }
 
-// DecodeFile loads and decodes config from file.
+// DecodeFile loads and decodes config from file, the placeholders and the references are expanded like Decode.
+// The relative paths of `includes` are relative to the directory of the file.
 func (c *TaskConfig) DecodeFile(fpath string) error {
 	bs, err := os.ReadFile(fpath)
 	if err != nil {
 		return terror.ErrConfigReadCfgFromFile.Delegate(err, fpath)
 	}
+	bs, err = MergeIncludes(bs, filepath.Dir(fpath))
+	if err != nil {
+		return err
+	}
+	bs, err = expandEnv(bs)
+	if err != nil {
+		return err
+	}
 
 	err = yaml.UnmarshalStrict(bs, c)
 	if err != nil {
 		return terror.ErrConfigYamlTransform.Delegate(err)
 	}
+	if err = c.resolveSecrets(); err != nil {
+		return err
+	}
 
 	return c.adjust()
 }
 
 // Decode loads config from file data.
+// The `${ENV_VAR}` placeholders and the `secret://` references of the target database are expanded,
+// and the files listed in `includes` are merged, their relative paths are relative to the working directory.
 func (c *TaskConfig) Decode(data string) error {
-	err := yaml.UnmarshalStrict([]byte(data), c)
+	bs, err := MergeIncludes([]byte(data), "")
+	if err != nil {
+		return err
+	}
+	bs, err = expandEnv(bs)
+	if err != nil {
+		return err
+	}
+	err = yaml.UnmarshalStrict(bs, c)
 	if err != nil {
 		return terror.ErrConfigYamlTransform.Delegate(err, "decode task config failed")
 	}
+	if err = c.resolveSecrets(); err != nil {
+		return err
+	}
 
 	return c.adjust()
 }
@@ -412,7 +623,7 @@ func (c *TaskConfig) RawDecode(data string) error {
 }
 
 // find unused items in config.
-var configRefPrefixes = []string{"RouteRules", "FilterRules", "ColumnMappingRules", "Mydumper", "Loader", "Syncer", "ExprFilter"}
+var configRefPrefixes = []string{"RouteRules", "FilterRules", "ColumnMappingRules", "Mydumper", "Loader", "Syncer", "ExprFilter", "ColumnTransform", "Validator"}
 
 const (
 	routeRulesIdx = iota
@@ -422,6 +633,8 @@ const (
 	loaderIdx
 	syncerIdx
 	exprFilterIdx
+	columnTransformIdx
+	validatorIdx
 )
 
 // adjust adjusts and verifies config.
@@ -429,7 +642,9 @@ func (c *TaskConfig) adjust() error {
 	if len(c.Name) == 0 {
 		return terror.ErrConfigNeedUniqueTaskName.Generate()
 	}
-	if c.TaskMode != ModeFull && c.TaskMode != ModeIncrement && c.TaskMode != ModeAll {
+	switch c.TaskMode {
+	case ModeFull, ModeIncrement, ModeAll, ModeDump, ModeLoad, ModeLoadSync:
+	default:
 		return terror.ErrConfigInvalidTaskMode.Generate()
 	}
 
@@ -455,6 +670,9 @@ func (c *TaskConfig) adjust() error {
 	if c.TargetDB == nil {
 		return terror.ErrConfigNeedTargetDB.Generate()
 	}
+	if err := c.TargetDB.Security.AdjustDBTLS(); err != nil {
+		return terror.Annotate(err, "target-database")
+	}
 
 	if len(c.MySQLInstances) == 0 {
 		return terror.ErrConfigMySQLInstsAtLeastOne.Generate()
@@ -498,6 +716,27 @@ func (c *TaskConfig) adjust() error {
 		}
 	}
 
+	if c.ResourceLimits != nil {
+		if err := c.ResourceLimits.adjust(); err != nil {
+			return err
+		}
+	}
+
+	for name, transform := range c.ColumnTransforms {
+		if err := transform.adjust(name); err != nil {
+			return err
+		}
+	}
+
+	for name, rule := range c.Filters {
+		if rule == nil {
+			continue
+		}
+		if err := validateBinlogEventRule(rule); err != nil {
+			return terror.ErrConfigInvalidFilterRule.Generate(name, err)
+		}
+	}
+
 	instanceIDs := make(map[string]int) // source-id -> instance-index
 	globalConfigReferCount := map[string]int{}
 	duplicateErrorStrings := make([]string, 0)
@@ -511,9 +750,9 @@ func (c *TaskConfig) adjust() error {
 		instanceIDs[inst.SourceID] = i
 
 		switch c.TaskMode {
-		case ModeFull, ModeAll:
+		case ModeFull, ModeAll, ModeDump, ModeLoad, ModeLoadSync:
 			if inst.Meta != nil {
-				log.L().Warn("metadata will not be used. for Full mode, incremental sync will never occur; for All mode, the meta dumped by MyDumper will be used", zap.Int("mysql instance", i), zap.String("task mode", c.TaskMode))
+				log.L().Warn("metadata will not be used. for Full/Dump/Load mode, incremental sync will never occur; for All/Load-Sync mode, the meta of the dump will be used", zap.Int("mysql instance", i), zap.String("task mode", c.TaskMode))
 			}
 		case ModeIncrement:
 			if inst.Meta == nil {
@@ -575,11 +814,6 @@ func (c *TaskConfig) adjust() error {
 			inst.Mydumper.Threads = inst.MydumperThread
 		}
 
-		if (c.TaskMode == ModeFull || c.TaskMode == ModeAll) && len(inst.Mydumper.MydumperPath) == 0 {
-			// only verify if set, whether is valid can only be verify when we run it
-			return terror.ErrConfigMydumperPathNotValid.Generate(i)
-		}
-
 		if len(inst.LoaderConfigName) > 0 {
 			rule, ok := c.Loaders[inst.LoaderConfigName]
 			if !ok {
@@ -599,6 +833,11 @@ func (c *TaskConfig) adjust() error {
 		if inst.LoaderThread != 0 {
 			inst.Loader.PoolSize = inst.LoaderThread
 		}
+		switch inst.Loader.ImportMode {
+		case "", ImportModeSQL, ImportModeLogical, ImportModePhysical:
+		default:
+			return terror.ErrConfigInvalidImportMode.Generate(inst.Loader.ImportMode)
+		}
 
 		if len(inst.SyncerConfigName) > 0 {
 			rule, ok := c.Syncers[inst.SyncerConfigName]
@@ -627,6 +866,26 @@ func (c *TaskConfig) adjust() error {
 		if inst.Syncer.DisableCausality {
 			log.L().Warn("`disable-causality` is no longer take effect")
 		}
+		if inst.Syncer.OnlineDDLScheme != "" && inst.Syncer.OnlineDDLScheme != PT && inst.Syncer.OnlineDDLScheme != GHOST {
+			return terror.ErrConfigOnlineSchemeNotSupport.Generate(inst.Syncer.OnlineDDLScheme)
+		}
+
+		if len(inst.ValidatorConfigName) > 0 {
+			rule, ok := c.Validators[inst.ValidatorConfigName]
+			if !ok {
+				return terror.ErrConfigValidatorCfgNotFound.Generate(i, inst.ValidatorConfigName)
+			}
+			globalConfigReferCount[configRefPrefixes[validatorIdx]+inst.ValidatorConfigName]++
+			inst.Validator = new(ValidatorConfig)
+			*inst.Validator = *rule // ref validator config
+		}
+		if inst.Validator == nil {
+			defaultCfg := DefaultValidatorConfig()
+			inst.Validator = &defaultCfg
+		}
+		if err := inst.Validator.Adjust(); err != nil {
+			return terror.Annotatef(err, "mysql-instance: %d", i)
+		}
 
 		for _, name := range inst.ExpressionFilters {
 			if _, ok := c.ExprFilter[name]; !ok {
@@ -635,6 +894,13 @@ func (c *TaskConfig) adjust() error {
 			globalConfigReferCount[configRefPrefixes[exprFilterIdx]+name]++
 		}
 
+		for _, name := range inst.ColumnTransformRules {
+			if _, ok := c.ColumnTransforms[name]; !ok {
+				return terror.ErrConfigColumnTransformNotFound.Generate(i, name)
+			}
+			globalConfigReferCount[configRefPrefixes[columnTransformIdx]+name]++
+		}
+
 		if dupeRules := checkDuplicateString(inst.RouteRules); len(dupeRules) > 0 {
 			duplicateErrorStrings = append(duplicateErrorStrings, fmt.Sprintf("mysql-instance(%d)'s route-rules: %s", i, strings.Join(dupeRules, ", ")))
 		}
@@ -647,6 +913,9 @@ func (c *TaskConfig) adjust() error {
 		if dupeRules := checkDuplicateString(inst.ExpressionFilters); len(dupeRules) > 0 {
 			duplicateErrorStrings = append(duplicateErrorStrings, fmt.Sprintf("mysql-instance(%d)'s expression-filters: %s", i, strings.Join(dupeRules, ", ")))
 		}
+		if dupeRules := checkDuplicateString(inst.ColumnTransformRules); len(dupeRules) > 0 {
+			duplicateErrorStrings = append(duplicateErrorStrings, fmt.Sprintf("mysql-instance(%d)'s column-transform-rules: %s", i, strings.Join(dupeRules, ", ")))
+		}
 	}
 	if len(duplicateErrorStrings) > 0 {
 		return terror.ErrConfigDuplicateCfgItem.Generate(strings.Join(duplicateErrorStrings, "\n"))
@@ -683,11 +952,21 @@ func (c *TaskConfig) adjust() error {
 			unusedConfigs = append(unusedConfigs, syncer)
 		}
 	}
+	for validator := range c.Validators {
+		if globalConfigReferCount[configRefPrefixes[validatorIdx]+validator] == 0 {
+			unusedConfigs = append(unusedConfigs, validator)
+		}
+	}
 	for exprFilter := range c.ExprFilter {
 		if globalConfigReferCount[configRefPrefixes[exprFilterIdx]+exprFilter] == 0 {
 			unusedConfigs = append(unusedConfigs, exprFilter)
 		}
 	}
+	for transform := range c.ColumnTransforms {
+		if globalConfigReferCount[configRefPrefixes[columnTransformIdx]+transform] == 0 {
+			unusedConfigs = append(unusedConfigs, transform)
+		}
+	}
 
 	if len(unusedConfigs) != 0 {
 		sort.Strings(unusedConfigs)
@@ -788,6 +1067,31 @@ func checkValidExpr(expr string) error {
 	return err
 }
 
+// binlogEventTypes are the event types which can be used in the `events` of the binlog event filter rules.
+var binlogEventTypes = []bf.EventType{
+	bf.AllEvent, bf.AllDDL, bf.AllDML, bf.NoneEvent, bf.NoneDDL, bf.NoneDML,
+	bf.InsertEvent, bf.UpdateEvent, bf.DeleteEvent,
+	bf.CreateDatabase, bf.DropDatabase, bf.CreateTable, bf.DropTable, bf.TruncateTable, bf.RenameTable,
+	bf.CreateIndex, bf.DropIndex, bf.CreateView, bf.DropView, bf.AlertTable,
+}
+
+// validateBinlogEventRule checks the event types, SQL patterns and action of the binlog event filter rule.
+func validateBinlogEventRule(rule *bf.BinlogEventRule) error {
+	for _, event := range rule.Events {
+		valid := false
+		for _, tp := range binlogEventTypes {
+			if strings.EqualFold(string(event), string(tp)) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("event type %s is not supported", event)
+		}
+	}
+	return rule.Valid()
+}
+
 // YamlForDowngrade returns YAML format represents of config for downgrade.
 func (c *TaskConfig) YamlForDowngrade() (string, error) {
 	t := NewTaskConfigForDowngrade(c)
@@ -824,7 +1128,10 @@ type MySQLInstanceForDowngrade struct {
 	Syncer             *SyncerConfig   `yaml:"syncer"`
 	SyncerThread       int             `yaml:"syncer-thread"`
 	// new config item
-	ExpressionFilters []string `yaml:"expression-filters,omitempty"`
+	ExpressionFilters    []string         `yaml:"expression-filters,omitempty"`
+	ColumnTransformRules []string         `yaml:"column-transform-rules,omitempty"`
+	ValidatorConfigName  string           `yaml:"validator-config-name,omitempty"`
+	Validator            *ValidatorConfig `yaml:"validator,omitempty"`
 }
 
 // NewMySQLInstancesForDowngrade creates []* MySQLInstanceForDowngrade.
@@ -832,23 +1139,26 @@ func NewMySQLInstancesForDowngrade(mysqlInstances []*MySQLInstance) []*MySQLInst
 	mysqlInstancesForDowngrade := make([]*MySQLInstanceForDowngrade, 0, len(mysqlInstances))
 	for _, m := range mysqlInstances {
 		newMySQLInstance := &MySQLInstanceForDowngrade{
-			SourceID:           m.SourceID,
-			Meta:               m.Meta,
-			FilterRules:        m.FilterRules,
-			ColumnMappingRules: m.ColumnMappingRules,
-			RouteRules:         m.RouteRules,
-			BWListName:         m.BWListName,
-			BAListName:         m.BAListName,
-			MydumperConfigName: m.MydumperConfigName,
-			Mydumper:           m.Mydumper,
-			MydumperThread:     m.MydumperThread,
-			LoaderConfigName:   m.LoaderConfigName,
-			Loader:             m.Loader,
-			LoaderThread:       m.LoaderThread,
-			SyncerConfigName:   m.SyncerConfigName,
-			Syncer:             m.Syncer,
-			SyncerThread:       m.SyncerThread,
-			ExpressionFilters:  m.ExpressionFilters,
+			SourceID:             m.SourceID,
+			Meta:                 m.Meta,
+			FilterRules:          m.FilterRules,
+			ColumnMappingRules:   m.ColumnMappingRules,
+			RouteRules:           m.RouteRules,
+			BWListName:           m.BWListName,
+			BAListName:           m.BAListName,
+			MydumperConfigName:   m.MydumperConfigName,
+			Mydumper:             m.Mydumper,
+			MydumperThread:       m.MydumperThread,
+			LoaderConfigName:     m.LoaderConfigName,
+			Loader:               m.Loader,
+			LoaderThread:         m.LoaderThread,
+			SyncerConfigName:     m.SyncerConfigName,
+			Syncer:               m.Syncer,
+			SyncerThread:         m.SyncerThread,
+			ExpressionFilters:    m.ExpressionFilters,
+			ColumnTransformRules: m.ColumnTransformRules,
+			ValidatorConfigName:  m.ValidatorConfigName,
+			Validator:            m.Validator,
 		}
 		mysqlInstancesForDowngrade = append(mysqlInstancesForDowngrade, newMySQLInstance)
 	}
@@ -871,8 +1181,17 @@ type SyncerConfigForDowngrade struct {
 	SafeMode                bool   `yaml:"safe-mode"`
 	EnableANSIQuotes        bool   `yaml:"enable-ansi-quotes"`
 
-	Compact      bool `yaml:"compact,omitempty"`
-	MultipleRows bool `yaml:"multipleRows,omitempty"`
+	Compact           bool      `yaml:"compact,omitempty"`
+	MultipleRows      bool      `yaml:"multipleRows,omitempty"`
+	BatchDML          bool      `yaml:"batch-dml,omitempty"`
+	OnlineDDLScheme   string    `yaml:"online-ddl-scheme,omitempty"`
+	CheckpointStorage string    `yaml:"checkpoint-storage,omitempty"`
+	CheckpointDB      *DBConfig `yaml:"checkpoint-db,omitempty"`
+	HeartbeatInterval int       `yaml:"heartbeat-interval,omitempty"`
+	HeartbeatTable    string    `yaml:"heartbeat-table,omitempty"`
+	SafeModeDuration  string    `yaml:"safe-mode-duration,omitempty"`
+
+	PreparedStmtCacheSize int `yaml:"prepared-stmt-cache-size,omitempty"`
 }
 
 // NewSyncerConfigsForDowngrade converts SyncerConfig to SyncerConfigForDowngrade.
@@ -893,6 +1212,14 @@ func NewSyncerConfigsForDowngrade(syncerConfigs map[string]*SyncerConfig) map[st
 			EnableANSIQuotes:        syncerConfig.EnableANSIQuotes,
 			Compact:                 syncerConfig.Compact,
 			MultipleRows:            syncerConfig.MultipleRows,
+			BatchDML:                syncerConfig.BatchDML,
+			OnlineDDLScheme:         syncerConfig.OnlineDDLScheme,
+			CheckpointStorage:       syncerConfig.CheckpointStorage,
+			CheckpointDB:            syncerConfig.CheckpointDB,
+			HeartbeatInterval:       syncerConfig.HeartbeatInterval,
+			HeartbeatTable:          syncerConfig.HeartbeatTable,
+			SafeModeDuration:        syncerConfig.SafeModeDuration,
+			PreparedStmtCacheSize:   syncerConfig.PreparedStmtCacheSize,
 		}
 		syncerConfigsForDowngrade[configName] = newSyncerConfig
 	}
@@ -930,9 +1257,12 @@ type TaskConfigForDowngrade struct {
 	// new config item
 	MySQLInstances   []*MySQLInstanceForDowngrade `yaml:"mysql-instances"`
 	ExprFilter       map[string]*ExpressionFilter `yaml:"expression-filter,omitempty"`
+	ColumnTransforms map[string]*ColumnTransform  `yaml:"column-transforms,omitempty"`
+	ResourceLimits   *ResourceLimits              `yaml:"resource-limits,omitempty"`
 	OnlineDDL        bool                         `yaml:"online-ddl,omitempty"`
 	ShadowTableRules []string                     `yaml:"shadow-table-rules,omitempty"`
 	TrashTableRules  []string                     `yaml:"trash-table-rules,omitempty"`
+	Validators       map[string]*ValidatorConfig  `yaml:"validators,omitempty"`
 }
 
 // NewTaskConfigForDowngrade create new TaskConfigForDowngrade.
@@ -964,9 +1294,12 @@ func NewTaskConfigForDowngrade(taskConfig *TaskConfig) *TaskConfigForDowngrade {
 		RemoveMeta:              taskConfig.RemoveMeta,
 		MySQLInstances:          NewMySQLInstancesForDowngrade(taskConfig.MySQLInstances),
 		ExprFilter:              taskConfig.ExprFilter,
+		ColumnTransforms:        taskConfig.ColumnTransforms,
+		ResourceLimits:          taskConfig.ResourceLimits,
 		OnlineDDL:               taskConfig.OnlineDDL,
 		ShadowTableRules:        taskConfig.ShadowTableRules,
 		TrashTableRules:         taskConfig.TrashTableRules,
+		Validators:              taskConfig.Validators,
 	}
 }
 
@@ -980,6 +1313,11 @@ func (c *TaskConfigForDowngrade) omitDefaultVals() {
 	if len(c.TrashTableRules) == 1 && c.TrashTableRules[0] == DefaultTrashTableRules {
 		c.TrashTableRules = nil
 	}
+	for _, inst := range c.MySQLInstances {
+		if inst.Validator != nil && *inst.Validator == DefaultValidatorConfig() {
+			inst.Validator = nil
+		}
+	}
 }
 
 // Yaml returns YAML format representation of config.
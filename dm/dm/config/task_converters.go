@@ -58,6 +58,9 @@ func TaskConfigToSubTaskConfigs(c *TaskConfig, sources map[string]DBConfig) ([]*
 			return nil, terror.ErrConfigMySQLInstNotFound
 		}
 		cfg.From = *fromClone
+		if inst.Security != nil {
+			cfg.From.Security = inst.Security.Clone()
+		}
 		toClone := c.TargetDB.Clone()
 		if toClone == nil {
 			return nil, terror.ErrConfigNeedTargetDB
@@ -86,13 +89,24 @@ func TaskConfigToSubTaskConfigs(c *TaskConfig, sources map[string]DBConfig) ([]*
 			cfg.ExprFilter[j] = c.ExprFilter[name]
 		}
 
+		for _, name := range inst.ColumnTransformRules {
+			cfg.ColumnTransforms = append(cfg.ColumnTransforms, c.ColumnTransforms[name])
+		}
+
 		cfg.BAList = c.BAList[inst.BAListName]
 
 		cfg.MydumperConfig = *inst.Mydumper
 		cfg.LoaderConfig = *inst.Loader
 		cfg.SyncerConfig = *inst.Syncer
+		cfg.ValidatorCfg = *inst.Validator
+		if inst.Syncer.OnlineDDLScheme != "" {
+			cfg.OnlineDDL = true
+		}
 
 		cfg.CleanDumpFile = c.CleanDumpFile
+		if c.ResourceLimits != nil {
+			cfg.ResourceLimits = *c.ResourceLimits
+		}
 
 		if err := cfg.Adjust(true); err != nil {
 			return nil, terror.Annotatef(err, "source %s", inst.SourceID)
@@ -292,6 +306,10 @@ func SubTaskConfigsToTaskConfig(stCfgs ...*SubTaskConfig) *TaskConfig {
 	c.OnlineDDL = stCfg0.OnlineDDL
 	c.OnlineDDLScheme = stCfg0.OnlineDDLScheme
 	c.CleanDumpFile = stCfg0.CleanDumpFile
+	if stCfg0.ResourceLimits != (ResourceLimits{}) {
+		limits := stCfg0.ResourceLimits
+		c.ResourceLimits = &limits
+	}
 	c.MySQLInstances = make([]*MySQLInstance, 0, len(stCfgs))
 	c.BAList = make(map[string]*filter.Rules)
 	c.Routes = make(map[string]*router.TableRule)
@@ -301,6 +319,8 @@ func SubTaskConfigsToTaskConfig(stCfgs ...*SubTaskConfig) *TaskConfig {
 	c.Loaders = make(map[string]*LoaderConfig)
 	c.Syncers = make(map[string]*SyncerConfig)
 	c.ExprFilter = make(map[string]*ExpressionFilter)
+	c.ColumnTransforms = make(map[string]*ColumnTransform)
+	c.Validators = make(map[string]*ValidatorConfig)
 
 	baListMap := make(map[string]string, len(stCfgs))
 	routeMap := make(map[string]string, len(stCfgs))
@@ -310,8 +330,10 @@ func SubTaskConfigsToTaskConfig(stCfgs ...*SubTaskConfig) *TaskConfig {
 	syncMap := make(map[string]string, len(stCfgs))
 	cmMap := make(map[string]string, len(stCfgs))
 	exprFilterMap := make(map[string]string, len(stCfgs))
-	var baListIdx, routeIdx, filterIdx, dumpIdx, loadIdx, syncIdx, cmIdx, efIdx int
-	var baListName, routeName, filterName, dumpName, loadName, syncName, cmName, efName string
+	ctMap := make(map[string]string, len(stCfgs))
+	validatorMap := make(map[string]string, len(stCfgs))
+	var baListIdx, routeIdx, filterIdx, dumpIdx, loadIdx, syncIdx, cmIdx, efIdx, ctIdx, validatorIdx int
+	var baListName, routeName, filterName, dumpName, loadName, syncName, cmName, efName, ctName, validatorName string
 
 	// NOTE:
 	// - we choose to ref global configs for instances now.
@@ -346,6 +368,13 @@ func SubTaskConfigsToTaskConfig(stCfgs ...*SubTaskConfig) *TaskConfig {
 		syncName, syncIdx = getGenerateName(stCfg.SyncerConfig, syncIdx, "sync", syncMap)
 		c.Syncers[syncName] = &stCfg.SyncerConfig
 
+		// only the enabled validators are listed to keep the task config short.
+		validatorName = ""
+		if stCfg.ValidatorCfg.Mode != "" && stCfg.ValidatorCfg.Mode != ValidationNone {
+			validatorName, validatorIdx = getGenerateName(stCfg.ValidatorCfg, validatorIdx, "validator", validatorMap)
+			c.Validators[validatorName] = &stCfg.ValidatorCfg
+		}
+
 		exprFilterNames := make([]string, 0, len(stCfg.ExprFilter))
 		for _, f := range stCfg.ExprFilter {
 			efName, efIdx = getGenerateName(f, efIdx, "expr-filter", exprFilterMap)
@@ -360,17 +389,26 @@ func SubTaskConfigsToTaskConfig(stCfgs ...*SubTaskConfig) *TaskConfig {
 			c.ColumnMappings[cmName] = rule
 		}
 
+		ctNames := make([]string, 0, len(stCfg.ColumnTransforms))
+		for _, t := range stCfg.ColumnTransforms {
+			ctName, ctIdx = getGenerateName(t, ctIdx, "ct", ctMap)
+			ctNames = append(ctNames, ctName)
+			c.ColumnTransforms[ctName] = t
+		}
+
 		c.MySQLInstances = append(c.MySQLInstances, &MySQLInstance{
-			SourceID:           stCfg.SourceID,
-			Meta:               stCfg.Meta,
-			FilterRules:        filterNames,
-			ColumnMappingRules: cmNames,
-			RouteRules:         routeNames,
-			BAListName:         baListName,
-			MydumperConfigName: dumpName,
-			LoaderConfigName:   loadName,
-			SyncerConfigName:   syncName,
-			ExpressionFilters:  exprFilterNames,
+			SourceID:             stCfg.SourceID,
+			Meta:                 stCfg.Meta,
+			FilterRules:          filterNames,
+			ColumnMappingRules:   cmNames,
+			RouteRules:           routeNames,
+			BAListName:           baListName,
+			MydumperConfigName:   dumpName,
+			LoaderConfigName:     loadName,
+			SyncerConfigName:     syncName,
+			ValidatorConfigName:  validatorName,
+			ExpressionFilters:    exprFilterNames,
+			ColumnTransformRules: ctNames,
 		})
 	}
 	return c
@@ -0,0 +1,225 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
+	"gopkg.in/yaml.v2"
+
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// schemaDefaults are the default values of the blocks referred by name in the task config,
+// which are used as the defaults of the JSON schema.
+var schemaDefaults = map[reflect.Type]interface{}{
+	reflect.TypeOf(MydumperConfig{}):  DefaultMydumperConfig(),
+	reflect.TypeOf(LoaderConfig{}):    DefaultLoaderConfig(),
+	reflect.TypeOf(SyncerConfig{}):    DefaultSyncerConfig(),
+	reflect.TypeOf(ValidatorConfig{}): DefaultValidatorConfig(),
+}
+
+// schemaEnums are the allowed values of the string types in the task config.
+var schemaEnums = map[reflect.Type]interface{}{
+	reflect.TypeOf(bf.EventType("")):  binlogEventTypes,
+	reflect.TypeOf(bf.ActionType("")): []bf.ActionType{bf.Do, bf.Ignore},
+}
+
+// TaskConfigJSONSchema returns the JSON schema of the task config, which follows the
+// yaml tags of TaskConfig and its nested configs.
+func TaskConfigJSONSchema() ([]byte, error) {
+	g := &schemaGenerator{definitions: make(map[string]interface{})}
+	schema := g.structSchema(reflect.TypeOf(TaskConfig{}), reflect.ValueOf(NewTaskConfig()).Elem())
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = "DM task config"
+	properties := schema["properties"].(map[string]interface{})
+	properties[includesKey] = map[string]interface{}{
+		"type":  "array",
+		"items": map[string]interface{}{"type": "string"},
+	}
+	schema["definitions"] = g.definitions
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+type schemaGenerator struct {
+	definitions map[string]interface{}
+}
+
+// typeSchema returns the schema of a type, the structs are referred from the definitions.
+func (g *schemaGenerator) typeSchema(t reflect.Type) map[string]interface{} {
+	if enum, ok := schemaEnums[t]; ok {
+		return map[string]interface{}{"type": "string", "enum": enum}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.typeSchema(t.Elem())
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := g.definitions[name]; !ok {
+			// placeholder for the recursive types
+			g.definitions[name] = nil
+			defaults := reflect.New(t).Elem()
+			if d, ok := schemaDefaults[t]; ok {
+				defaults = reflect.ValueOf(d)
+			}
+			g.definitions[name] = g.structSchema(t, defaults)
+		}
+		return map[string]interface{}{"$ref": "#/definitions/" + name}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema returns the schema of a struct, the non-zero fields of defaults are used as the default values.
+func (g *schemaGenerator) structSchema(t reflect.Type, defaults reflect.Value) map[string]interface{} {
+	properties := make(map[string]interface{})
+	g.addProperties(properties, t, defaults)
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func (g *schemaGenerator) addProperties(properties map[string]interface{}, t reflect.Type, defaults reflect.Value) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("yaml")
+		if tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+		if strings.Contains(opts, "inline") {
+			g.addProperties(properties, field.Type, defaults.Field(i))
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		schema := g.typeSchema(field.Type)
+		if value := defaults.Field(i); !value.IsZero() && (value.Kind() != reflect.Map || value.Len() > 0) {
+			if _, ok := schema["$ref"]; !ok {
+				schema["default"] = value.Interface()
+			}
+		}
+		properties[name] = schema
+	}
+}
+
+// ValidationError is an error of the task config reported by ValidateTaskConfigFile.
+type ValidationError struct {
+	// Line is the line number of the error in the task config file, 0 if unknown.
+	Line int `json:"line,omitempty"`
+	// Code is the error code of DM, 0 for the errors of the YAML format.
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+var yamlLineError = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// yamlValidationErrors splits a YAML decoding error into the errors of each line.
+func yamlValidationErrors(err error) []*ValidationError {
+	var messages []string
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		messages = typeErr.Errors
+	} else {
+		messages = []string{err.Error()}
+	}
+	errs := make([]*ValidationError, 0, len(messages))
+	for _, msg := range messages {
+		vErr := &ValidationError{Message: msg}
+		if matches := yamlLineError.FindStringSubmatch(msg); matches != nil {
+			vErr.Line, _ = strconv.Atoi(matches[1])
+			vErr.Message = matches[2]
+		}
+		errs = append(errs, vErr)
+	}
+	return errs
+}
+
+// ValidateTaskConfigFile validates the task config file like DecodeFile without resolving the
+// `secret://` references, and returns the errors found. The type errors of the fields are reported
+// with the line numbers of the file.
+func ValidateTaskConfigFile(fpath string) []*ValidationError {
+	bs, err := os.ReadFile(fpath)
+	if err != nil {
+		return []*ValidationError{{Message: err.Error()}}
+	}
+	expanded, err := expandEnv(bs)
+	if err != nil {
+		return []*ValidationError{validationErrorOf(err)}
+	}
+
+	// check the file itself before merging the included files to keep the line numbers
+	var raw struct {
+		TaskConfig `yaml:",inline"`
+		Includes   []string `yaml:"includes"`
+	}
+	if err = yaml.UnmarshalStrict(expanded, &raw); err != nil {
+		return yamlValidationErrors(err)
+	}
+
+	merged, err := MergeIncludes(bs, filepath.Dir(fpath))
+	if err == nil {
+		merged, err = expandEnv(merged)
+	}
+	if err != nil {
+		return []*ValidationError{validationErrorOf(err)}
+	}
+	cfg := NewTaskConfig()
+	if err = yaml.UnmarshalStrict(merged, cfg); err != nil {
+		return yamlValidationErrors(err)
+	}
+	if err = cfg.adjust(); err != nil {
+		return []*ValidationError{validationErrorOf(err)}
+	}
+	return nil
+}
+
+func validationErrorOf(err error) *ValidationError {
+	vErr := &ValidationError{Message: err.Error()}
+	if tErr, ok := err.(*terror.Error); ok {
+		vErr.Code = int(tErr.Code())
+		vErr.Message = tErr.Message()
+	}
+	return vErr
+}
@@ -0,0 +1,113 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+func (t *testConfig) TestTaskConfigJSONSchema(c *C) {
+	content, err := TaskConfigJSONSchema()
+	c.Assert(err, IsNil)
+
+	var schema struct {
+		Schema      string                            `json:"$schema"`
+		Properties  map[string]map[string]interface{} `json:"properties"`
+		Definitions map[string]struct {
+			Properties map[string]map[string]interface{} `json:"properties"`
+		} `json:"definitions"`
+	}
+	c.Assert(json.Unmarshal(content, &schema), IsNil)
+	c.Assert(schema.Schema, Equals, jsonSchemaDraft)
+
+	c.Assert(schema.Properties["name"]["type"], Equals, "string")
+	c.Assert(schema.Properties["task-mode"]["type"], Equals, "string")
+	c.Assert(schema.Properties["includes"]["type"], Equals, "array")
+	c.Assert(schema.Properties["mysql-instances"]["items"], DeepEquals, map[string]interface{}{"$ref": "#/definitions/config.MySQLInstance"})
+	c.Assert(schema.Properties["mydumpers"]["additionalProperties"], DeepEquals, map[string]interface{}{"$ref": "#/definitions/config.MydumperConfig"})
+	c.Assert(schema.Properties["meta-schema"]["default"], Equals, defaultMetaSchema)
+	// the fields skipped by yaml are not in the schema
+	c.Assert(schema.Properties, Not(HasKey), "FlagSet")
+
+	// the nested configs have the defaults
+	c.Assert(schema.Definitions["config.MydumperConfig"].Properties["threads"]["default"], Equals, float64(defaultThreads))
+	c.Assert(schema.Definitions["config.LoaderConfig"].Properties["pool-size"]["default"], Equals, float64(defaultPoolSize))
+	c.Assert(schema.Definitions["config.SyncerConfig"].Properties["worker-count"]["default"], Equals, float64(defaultWorkerCount))
+	c.Assert(schema.Definitions["config.SyncerConfig"].Properties["safe-mode"]["type"], Equals, "boolean")
+	c.Assert(schema.Definitions["config.MySQLInstance"].Properties["source-id"]["type"], Equals, "string")
+	c.Assert(schema.Definitions["config.DBConfig"].Properties["port"]["type"], Equals, "integer")
+	c.Assert(schema.Definitions["config.DBConfig"].Properties, Not(HasKey), "RawDBCfg")
+
+	// the event types and actions of the binlog event filter rules are enumerated
+	rule := schema.Definitions["binlog-filter.BinlogEventRule"].Properties
+	c.Assert(rule["events"]["items"].(map[string]interface{})["enum"], HasLen, len(binlogEventTypes))
+	c.Assert(rule["action"]["enum"], DeepEquals, []interface{}{"Do", "Ignore"})
+}
+
+func (t *testConfig) TestValidateTaskConfigFile(c *C) {
+	dir := writeIncludes(c, map[string]string{
+		"shared-rules.yaml": sharedRules,
+		"shared-units.yaml": sharedUnits,
+		"task.yaml":         taskWithIncludes,
+		"correct.yaml":      correctTaskConfig,
+		"type-error.yaml": `---
+name: test
+task-mode: all
+is-sharding: "yes"
+target-database:
+  host: "127.0.0.1"
+  port: "port"
+  unknown-field: 1
+`,
+		"syntax-error.yaml": "name: test\ntask-mode: [all\n",
+		"adjust-error.yaml": `---
+name: test
+task-mode: all-in-one
+target-database:
+  host: "127.0.0.1"
+  port: 4000
+mysql-instances:
+  - source-id: "mysql-replica-01"
+`,
+	})
+
+	c.Assert(ValidateTaskConfigFile(filepath.Join(dir, "correct.yaml")), HasLen, 0)
+	c.Assert(ValidateTaskConfigFile(filepath.Join(dir, "task.yaml")), HasLen, 0)
+
+	errs := ValidateTaskConfigFile(filepath.Join(dir, "type-error.yaml"))
+	c.Assert(errs, HasLen, 3)
+	c.Assert(errs[0].Line, Equals, 4)
+	c.Assert(errs[0].Message, Matches, ".*cannot unmarshal !!str `yes` into bool.*")
+	c.Assert(errs[1].Line, Equals, 7)
+	c.Assert(errs[2].Line, Equals, 8)
+	c.Assert(errs[2].Message, Matches, ".*field unknown-field not found.*")
+
+	errs = ValidateTaskConfigFile(filepath.Join(dir, "syntax-error.yaml"))
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Line, Greater, 0)
+
+	errs = ValidateTaskConfigFile(filepath.Join(dir, "adjust-error.yaml"))
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Line, Equals, 0)
+	c.Assert(errs[0].Code, Equals, int(terror.ErrConfigInvalidTaskMode.Code()))
+
+	errs = ValidateTaskConfigFile(filepath.Join(dir, "not-exist.yaml"))
+	c.Assert(errs, HasLen, 1)
+	c.Assert(errs[0].Message, Matches, ".*no such file or directory.*")
+}
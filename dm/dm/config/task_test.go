@@ -19,6 +19,7 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	. "github.com/pingcap/check"
 	bf "github.com/pingcap/tidb-tools/pkg/binlog-filter"
@@ -136,6 +137,72 @@ mysql-instances:
     syncer-config-name: "global2"
 `
 
+func (t *testConfig) TestTaskModeDumpAndLoad(c *C) {
+	sources := map[string]DBConfig{
+		"mysql-replica-01": {Host: "127.0.0.1", Port: 3306, User: "root"},
+		"mysql-replica-02": {Host: "127.0.0.1", Port: 3307, User: "root"},
+	}
+
+	cfg := NewTaskConfig()
+	c.Assert(cfg.Decode(strings.Replace(correctTaskConfig, "task-mode: all", "task-mode: dump", 1)), IsNil)
+	c.Assert(cfg.TaskMode, Equals, ModeDump)
+	stCfgs, err := TaskConfigToSubTaskConfigs(cfg, sources)
+	c.Assert(err, IsNil)
+	// the dump directory has the suffix of the task name like the other modes
+	c.Assert(stCfgs[0].LoaderConfig.Dir, Equals, "./dumped_data1.test")
+
+	cfg = NewTaskConfig()
+	c.Assert(cfg.Decode(strings.Replace(correctTaskConfig, "task-mode: all", "task-mode: load", 1)), IsNil)
+	c.Assert(cfg.TaskMode, Equals, ModeLoad)
+	stCfgs, err = TaskConfigToSubTaskConfigs(cfg, sources)
+	c.Assert(err, IsNil)
+	// the dump directory to load is used as is
+	c.Assert(stCfgs[0].LoaderConfig.Dir, Equals, "./dumped_data1")
+	c.Assert(stCfgs[1].LoaderConfig.Dir, Equals, "./dumped_data2")
+
+	cfg = NewTaskConfig()
+	c.Assert(cfg.Decode(strings.Replace(correctTaskConfig, "task-mode: all", "task-mode: load-sync", 1)), IsNil)
+	c.Assert(cfg.TaskMode, Equals, ModeLoadSync)
+	stCfgs, err = TaskConfigToSubTaskConfigs(cfg, sources)
+	c.Assert(err, IsNil)
+	// the existing dump directory is used as is
+	c.Assert(stCfgs[0].LoaderConfig.Dir, Equals, "./dumped_data1")
+	c.Assert(stCfgs[1].LoaderConfig.Dir, Equals, "./dumped_data2")
+}
+
+func (t *testConfig) TestSyncerOnlineDDLScheme(c *C) {
+	sources := map[string]DBConfig{
+		"mysql-replica-01": {Host: "127.0.0.1", Port: 3306, User: "root"},
+		"mysql-replica-02": {Host: "127.0.0.1", Port: 3307, User: "root"},
+	}
+	taskConfig := strings.Replace(correctTaskConfig, "online-ddl: true", "online-ddl: false", 1)
+	taskConfig = strings.Replace(taskConfig, `  global2:
+    worker-count: 32`, `  global2:
+    online-ddl-scheme: "gh-ost"
+    worker-count: 32`, 1)
+
+	cfg := NewTaskConfig()
+	c.Assert(cfg.Decode(taskConfig), IsNil)
+	c.Assert(cfg.OnlineDDL, IsFalse)
+	c.Assert(cfg.MySQLInstances[1].Syncer.OnlineDDLScheme, Equals, GHOST)
+	stCfgs, err := TaskConfigToSubTaskConfigs(cfg, sources)
+	c.Assert(err, IsNil)
+	// only the source referring the syncer config enables online DDL
+	c.Assert(stCfgs[0].OnlineDDL, IsFalse)
+	c.Assert(stCfgs[1].OnlineDDL, IsTrue)
+
+	// the subtask config keeps online DDL after encoded
+	content, err := stCfgs[1].Toml()
+	c.Assert(err, IsNil)
+	stCfg := NewSubTaskConfig()
+	c.Assert(stCfg.Decode(content, true), IsNil)
+	c.Assert(stCfg.OnlineDDL, IsTrue)
+
+	cfg = NewTaskConfig()
+	err = cfg.Decode(strings.Replace(taskConfig, `online-ddl-scheme: "gh-ost"`, `online-ddl-scheme: "osc"`, 1))
+	c.Assert(terror.ErrConfigOnlineSchemeNotSupport.Equal(err), IsTrue)
+}
+
 func (t *testConfig) TestUnusedTaskConfig(c *C) {
 	taskConfig := NewTaskConfig()
 	err := taskConfig.Decode(correctTaskConfig)
@@ -702,6 +769,13 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 				EnableGTID:              true,
 				SafeMode:                true,
 			},
+			ValidatorCfg: ValidatorConfig{
+				Mode:          ValidationSample,
+				SampleRate:    20,
+				CheckInterval: "10s",
+				RowErrorDelay: "1m",
+				BatchSize:     50,
+			},
 			CleanDumpFile:    true,
 			EnableANSIQuotes: true,
 		}
@@ -719,6 +793,7 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 	stCfg2.BAList = &baList2
 	stCfg2.RouteRules = []*router.TableRule{&routeRule4, &routeRule1, &routeRule2}
 	stCfg2.ExprFilter = []*ExpressionFilter{&exprFilter1}
+	stCfg2.ValidatorCfg = DefaultValidatorConfig()
 
 	cfg := SubTaskConfigsToTaskConfig(stCfg1, stCfg2)
 
@@ -736,22 +811,23 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 		TargetDB:                &stCfg1.To,
 		MySQLInstances: []*MySQLInstance{
 			{
-				SourceID:           source1,
-				Meta:               stCfg1.Meta,
-				FilterRules:        []string{"filter-01", "filter-02"},
-				ColumnMappingRules: []string{},
-				RouteRules:         []string{"route-01", "route-02", "route-03"},
-				BWListName:         "",
-				BAListName:         "balist-01",
-				MydumperConfigName: "dump-01",
-				Mydumper:           nil,
-				MydumperThread:     0,
-				LoaderConfigName:   "load-01",
-				Loader:             nil,
-				LoaderThread:       0,
-				SyncerConfigName:   "sync-01",
-				Syncer:             nil,
-				SyncerThread:       0,
+				SourceID:            source1,
+				Meta:                stCfg1.Meta,
+				FilterRules:         []string{"filter-01", "filter-02"},
+				ColumnMappingRules:  []string{},
+				RouteRules:          []string{"route-01", "route-02", "route-03"},
+				BWListName:          "",
+				BAListName:          "balist-01",
+				MydumperConfigName:  "dump-01",
+				Mydumper:            nil,
+				MydumperThread:      0,
+				LoaderConfigName:    "load-01",
+				Loader:              nil,
+				LoaderThread:        0,
+				SyncerConfigName:    "sync-01",
+				Syncer:              nil,
+				SyncerThread:        0,
+				ValidatorConfigName: "validator-01",
 			},
 			{
 				SourceID:           source2,
@@ -802,6 +878,9 @@ func (t *testConfig) TestGenAndFromSubTaskConfigs(c *C) {
 		ExprFilter: map[string]*ExpressionFilter{
 			"expr-filter-01": &exprFilter1,
 		},
+		Validators: map[string]*ValidatorConfig{
+			"validator-01": &stCfg1.ValidatorCfg,
+		},
 		CleanDumpFile: stCfg1.CleanDumpFile,
 	}
 
@@ -861,6 +940,19 @@ func (t *testConfig) TestMetaVerify(c *C) {
 	}
 	c.Assert(m.Verify(), IsNil)
 
+	// only MySQL `binlog-gtid`.
+	m = &Meta{
+		BinLogGTID: "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14,406a3f61-690d-11e7-87c5-6c92bf46f384:1-94321383",
+	}
+	c.Assert(m.Verify(), IsNil)
+
+	// invalid `binlog-gtid`.
+	m = &Meta{
+		BinLogName: "mysql-bin.000123",
+		BinLogGTID: "3ccc475b-2343-11e7-be21-6c0b84d59f30:a-14",
+	}
+	c.Assert(terror.ErrConfigMetaInvalid.Equal(m.Verify()), IsTrue)
+
 	// all
 	m = &Meta{
 		BinLogName: "mysql-bin.000123",
@@ -1015,6 +1107,48 @@ func (t *testConfig) TestExclusiveAndWrongExprFilterFields(c *C) {
 	c.Assert(terror.ErrConfigExprFilterWrongGrammar.Equal(err), IsTrue)
 }
 
+func (t *testConfig) TestInvalidFilterRule(c *C) {
+	cfg := NewTaskConfig()
+	c.Assert(cfg.RawDecode(correctTaskConfig), IsNil)
+	c.Assert(cfg.adjust(), IsNil)
+	// the event types are case insensitive
+	c.Assert(cfg.Filters["filter-rule-1"].Events, DeepEquals, []bf.EventType{bf.TruncateTable, bf.DropTable})
+
+	cases := []struct {
+		rule *bf.BinlogEventRule
+		msg  string
+	}{
+		{
+			&bf.BinlogEventRule{SchemaPattern: "test_*", Events: []bf.EventType{"Truncate Table"}, Action: bf.Ignore},
+			"",
+		},
+		{
+			&bf.BinlogEventRule{SchemaPattern: "test_*", Events: []bf.EventType{"truncate"}, Action: bf.Ignore},
+			".*invalid filter rule filter-rule-1: event type truncate is not supported.*",
+		},
+		{
+			&bf.BinlogEventRule{SchemaPattern: "test_*", SQLPattern: []string{"^DROP\\s+(PROCEDURE"}, Action: bf.Ignore},
+			".*invalid filter rule filter-rule-1: compile regular expression.*",
+		},
+		{
+			&bf.BinlogEventRule{SchemaPattern: "test_*", Events: []bf.EventType{bf.AllDML}},
+			".*invalid filter rule filter-rule-1: action of binlog event rule.*should not be empty.*",
+		},
+	}
+	for _, cs := range cases {
+		cfg = NewTaskConfig()
+		c.Assert(cfg.RawDecode(correctTaskConfig), IsNil)
+		cfg.Filters["filter-rule-1"] = cs.rule
+		err := cfg.adjust()
+		if cs.msg == "" {
+			c.Assert(err, IsNil)
+		} else {
+			c.Assert(terror.ErrConfigInvalidFilterRule.Equal(err), IsTrue)
+			c.Assert(err, ErrorMatches, cs.msg)
+		}
+	}
+}
+
 func (t *testConfig) TestTaskConfigForDowngrade(c *C) {
 	cfg := NewTaskConfig()
 	err := cfg.Decode(correctTaskConfig)
@@ -1104,3 +1238,135 @@ func cloneValues(dest, src reflect.Value) {
 		}
 	}
 }
+
+func (t *testConfig) TestColumnTransforms(c *C) {
+	newConfig := func(transform *ColumnTransform) *TaskConfig {
+		cfg := NewTaskConfig()
+		cfg.Name = "test"
+		cfg.TaskMode = "all"
+		cfg.TargetDB = &DBConfig{}
+		cfg.MySQLInstances = append(cfg.MySQLInstances, &MySQLInstance{SourceID: "source1"})
+		cfg.ColumnTransforms["transform"] = transform
+		cfg.MySQLInstances[0].ColumnTransformRules = []string{"transform"}
+		return cfg
+	}
+	c.Assert(newConfig(&ColumnTransform{Schema: "db", Table: "tbl", Column: "a", Expr: "CONCAT(a, '_', b)"}).adjust(), IsNil)
+
+	cases := []struct {
+		transform *ColumnTransform
+		msg       string
+	}{
+		{nil, ".*invalid column transform transform: empty rule.*"},
+		{&ColumnTransform{Table: "tbl", Column: "a", Expr: "a"}, ".*invalid column transform transform: empty schema.*"},
+		{&ColumnTransform{Schema: "db", Column: "a", Expr: "a"}, ".*invalid column transform transform: empty table.*"},
+		{&ColumnTransform{Schema: "db", Table: "tbl", Expr: "a"}, ".*invalid column transform transform: empty column.*"},
+		{&ColumnTransform{Schema: "db", Table: "tbl", Column: "a"}, ".*invalid column transform transform: empty expr.*"},
+		{&ColumnTransform{Schema: "db", Table: "tbl", Column: "a", Expr: "CONCAT(a,"}, ".*invalid column transform transform: wrong grammar of expr.*"},
+	}
+	for _, cs := range cases {
+		err := newConfig(cs.transform).adjust()
+		c.Assert(terror.ErrConfigInvalidColumnTransform.Equal(err), IsTrue)
+		c.Assert(err, ErrorMatches, cs.msg)
+	}
+
+	transform := &ColumnTransform{Schema: "db", Table: "tbl", Column: "a", Expr: "a + 1"}
+	cfg := newConfig(transform)
+	cfg.MySQLInstances[0].ColumnTransformRules = []string{"unknown"}
+	c.Assert(terror.ErrConfigColumnTransformNotFound.Equal(cfg.adjust()), IsTrue)
+	cfg = newConfig(transform)
+	cfg.MySQLInstances[0].ColumnTransformRules = []string{"transform", "transform"}
+	c.Assert(terror.ErrConfigDuplicateCfgItem.Equal(cfg.adjust()), IsTrue)
+	cfg = newConfig(transform)
+	cfg.MySQLInstances[0].ColumnTransformRules = nil
+	c.Assert(terror.ErrConfigGlobalConfigsUnused.Equal(cfg.adjust()), IsTrue)
+
+	// converted to the subtask configs and back
+	cfg = newConfig(transform)
+	c.Assert(cfg.adjust(), IsNil)
+	stCfgs, err := TaskConfigToSubTaskConfigs(cfg, map[string]DBConfig{"source1": {}})
+	c.Assert(err, IsNil)
+	c.Assert(stCfgs[0].ColumnTransforms, DeepEquals, []*ColumnTransform{transform})
+	cfg2 := SubTaskConfigsToTaskConfig(stCfgs...)
+	c.Assert(cfg2.MySQLInstances[0].ColumnTransformRules, DeepEquals, []string{"ct-01"})
+	c.Assert(cfg2.ColumnTransforms["ct-01"], DeepEquals, transform)
+
+	// TiDB Lightning doesn't support column transforms
+	stCfgs[0].ImportMode = ImportModeLogical
+	c.Assert(terror.ErrConfigInvalidColumnTransform.Equal(stCfgs[0].Adjust(false)), IsTrue)
+}
+
+func (t *testConfig) TestTaskResourceLimits(c *C) {
+	cfg := NewTaskConfig()
+	c.Assert(cfg.RawDecode(correctTaskConfig), IsNil)
+	cfg.ResourceLimits = &ResourceLimits{MaxDownstreamConnections: -1}
+	c.Assert(terror.ErrConfigInvalidResourceLimits.Equal(cfg.adjust()), IsTrue)
+
+	cfg = NewTaskConfig()
+	c.Assert(cfg.RawDecode(correctTaskConfig), IsNil)
+	cfg.ResourceLimits = &ResourceLimits{MaxDownstreamConnections: 8, MaxLoaderMemory: "1GiB"}
+	c.Assert(cfg.adjust(), IsNil)
+	stCfgs, err := TaskConfigToSubTaskConfigs(cfg, map[string]DBConfig{"mysql-replica-01": {}, "mysql-replica-02": {}})
+	c.Assert(err, IsNil)
+	for _, stCfg := range stCfgs {
+		c.Assert(stCfg.ResourceLimits, Equals, *cfg.ResourceLimits)
+	}
+	c.Assert(SubTaskConfigsToTaskConfig(stCfgs...).ResourceLimits, DeepEquals, cfg.ResourceLimits)
+}
+
+func (t *testConfig) TestValidatorConfig(c *C) {
+	taskConfig := strings.Replace(correctTaskConfig, `
+mysql-instances:`, `
+validators:
+  sample:
+    mode: sample
+    sample-rate: 50
+
+mysql-instances:`, 1)
+	taskConfig = strings.Replace(taskConfig, `    syncer-config-name: "global2"`, `    syncer-config-name: "global2"
+    validator-config-name: "sample"`, 1)
+
+	cfg := NewTaskConfig()
+	c.Assert(cfg.Decode(taskConfig), IsNil)
+	defaultCfg := DefaultValidatorConfig()
+	c.Assert(*cfg.MySQLInstances[0].Validator, Equals, defaultCfg)
+	c.Assert(*cfg.MySQLInstances[1].Validator, Equals, ValidatorConfig{
+		Mode:          ValidationSample,
+		SampleRate:    50,
+		CheckInterval: defaultCfg.CheckInterval,
+		RowErrorDelay: defaultCfg.RowErrorDelay,
+		BatchSize:     defaultCfg.BatchSize,
+	})
+	stCfgs, err := TaskConfigToSubTaskConfigs(cfg, map[string]DBConfig{"mysql-replica-01": {}, "mysql-replica-02": {}})
+	c.Assert(err, IsNil)
+	c.Assert(stCfgs[0].ValidatorCfg, Equals, defaultCfg)
+	c.Assert(stCfgs[1].ValidatorCfg, Equals, *cfg.MySQLInstances[1].Validator)
+	checkInterval, rowErrorDelay := stCfgs[1].ValidatorCfg.Durations()
+	c.Assert(checkInterval, Equals, 5*time.Second)
+	c.Assert(rowErrorDelay, Equals, 30*time.Second)
+
+	// only the enabled validators are exported
+	exported := SubTaskConfigsToTaskConfig(stCfgs...)
+	c.Assert(exported.Validators, HasLen, 1)
+	c.Assert(exported.MySQLInstances[0].ValidatorConfigName, Equals, "")
+	c.Assert(exported.MySQLInstances[1].ValidatorConfigName, Not(Equals), "")
+
+	cfg = NewTaskConfig()
+	err = cfg.Decode(strings.Replace(taskConfig, `validator-config-name: "sample"`, `validator-config-name: "full"`, 1))
+	c.Assert(terror.ErrConfigValidatorCfgNotFound.Equal(err), IsTrue)
+
+	cfg = NewTaskConfig()
+	err = cfg.Decode(strings.Replace(taskConfig, `validator-config-name: "sample"`, `validator-config-name: "sample"
+    validator:
+      mode: full`, 1))
+	c.Assert(terror.ErrConfigValidatorCfgConflict.Equal(err), IsTrue)
+
+	cfg = NewTaskConfig()
+	err = cfg.Decode(strings.Replace(taskConfig, `    validator-config-name: "sample"`, "", 1))
+	c.Assert(terror.ErrConfigGlobalConfigsUnused.Equal(err), IsTrue)
+
+	for _, invalid := range []string{"mode: fast", "sample-rate: 101", "check-interval: 0s", "row-error-delay: 1"} {
+		cfg = NewTaskConfig()
+		err = cfg.Decode(strings.Replace(taskConfig, "mode: sample\n    sample-rate: 50", invalid, 1))
+		c.Assert(terror.ErrConfigInvalidValidator.Equal(err), IsTrue, Commentf(invalid))
+	}
+}
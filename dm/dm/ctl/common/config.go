@@ -36,6 +36,8 @@ const (
 	EncryptCmdName = "encrypt"
 	// DecryptCmdName is special command.
 	DecryptCmdName = "decrypt"
+	// ValidateCmdName is special command.
+	ValidateCmdName = "validate"
 
 	// Master specifies member master type.
 	Master = "master"
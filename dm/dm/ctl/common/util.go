@@ -328,15 +328,8 @@ func GetTaskNameFromArgOrFile(arg string) string {
 	if !(strings.HasSuffix(arg, ".yaml") || strings.HasSuffix(arg, ".yml")) {
 		return arg
 	}
-	var (
-		content []byte
-		err     error
-	)
-	if content, err = GetFileContent(arg); err != nil {
-		return arg
-	}
 	cfg := config.NewTaskConfig()
-	if err := cfg.Decode(string(content)); err != nil {
+	if err := cfg.DecodeFile(arg); err != nil {
 		return arg
 	}
 	return cfg.Name
@@ -14,11 +14,13 @@
 package ctl
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 
+	"github.com/pingcap/ticdc/dm/dm/config"
 	"github.com/pingcap/ticdc/dm/dm/ctl/common"
 	"github.com/pingcap/ticdc/dm/dm/ctl/master"
 	"github.com/pingcap/ticdc/dm/pkg/log"
@@ -27,6 +29,7 @@ import (
 	"github.com/chzyer/readline"
 	"github.com/pingcap/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -80,8 +83,10 @@ func NewRootCmd() *cobra.Command {
 		master.NewShardDDLLockCmd(),
 		master.NewSourceTableSchemaCmd(),
 		master.NewConfigCmd(),
+		master.NewWatchCmd(),
 		newDecryptCmd(),
 		newEncryptCmd(),
+		newValidateCmd(),
 	)
 	// copied from (*cobra.Command).InitDefaultHelpCmd
 	helpCmd := &cobra.Command{
@@ -127,6 +132,7 @@ func loop() error {
 		HistoryFile:     "/tmp/dmctlreadline.tmp",
 		InterruptPrompt: "^C",
 		EOFPrompt:       "^D",
+		AutoComplete:    newCompleter(NewRootCmd()),
 	})
 	if err != nil {
 		return err
@@ -169,6 +175,31 @@ func loop() error {
 	return l.Close()
 }
 
+// newCompleter completes the commands, sub commands and flags of dmctl in the interactive mode.
+func newCompleter(rootCmd *cobra.Command) *readline.PrefixCompleter {
+	items := commandCompleteItems(rootCmd)
+	items = append(items, readline.PcItem("exit"))
+	return readline.NewPrefixCompleter(items...)
+}
+
+func commandCompleteItems(cmd *cobra.Command) []readline.PrefixCompleterInterface {
+	var items []readline.PrefixCompleterInterface
+	for _, sub := range cmd.Commands() {
+		if sub.Hidden {
+			continue
+		}
+		items = append(items, readline.PcItem(sub.Name(), commandCompleteItems(sub)...))
+	}
+	addFlag := func(flag *pflag.Flag) {
+		if !flag.Hidden {
+			items = append(items, readline.PcItem("--"+flag.Name))
+		}
+	}
+	cmd.LocalFlags().VisitAll(addFlag)
+	cmd.InheritedFlags().VisitAll(addFlag)
+	return items
+}
+
 // MainStart starts running a command.
 func MainStart(args []string) {
 	rootCmd := NewRootCmd()
@@ -209,7 +240,7 @@ func MainStart(args []string) {
 			os.Exit(0)
 		}
 
-		if cmd.Name() == common.DecryptCmdName || cmd.Name() == common.EncryptCmdName {
+		if cmd.Name() == common.DecryptCmdName || cmd.Name() == common.EncryptCmdName || cmd.Name() == common.ValidateCmdName {
 			return nil
 		}
 
@@ -272,3 +303,61 @@ func newDecryptCmd() *cobra.Command {
 		},
 	}
 }
+
+func newValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [--format text|json] [--schema] [config-file]",
+		Short: "Validates a task config file offline, or prints the JSON schema of the task config",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, err := cmd.Flags().GetBool("schema")
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if schema {
+				content, err2 := config.TaskConfigJSONSchema()
+				if err2 != nil {
+					return errors.Trace(err2)
+				}
+				fmt.Println(string(content))
+				return nil
+			}
+			if len(args) != 1 {
+				return cmd.Help()
+			}
+			format, err := cmd.Flags().GetString("format")
+			if err != nil {
+				return errors.Trace(err)
+			}
+
+			validationErrs := config.ValidateTaskConfigFile(args[0])
+			switch format {
+			case "json":
+				content, err2 := json.MarshalIndent(map[string]interface{}{
+					"valid":  len(validationErrs) == 0,
+					"errors": validationErrs,
+				}, "", "\t")
+				if err2 != nil {
+					return errors.Trace(err2)
+				}
+				fmt.Println(string(content))
+			case "text":
+				for _, vErr := range validationErrs {
+					if vErr.Line > 0 {
+						fmt.Printf("%s:%d: %s\n", args[0], vErr.Line, vErr.Message)
+					} else {
+						fmt.Printf("%s: %s\n", args[0], vErr.Message)
+					}
+				}
+			default:
+				return errors.Errorf("unknown format %s, should be text or json", format)
+			}
+			if len(validationErrs) > 0 {
+				return errors.Errorf("task config %s is invalid", args[0])
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String("format", "text", "output format of the errors, text or json")
+	cmd.Flags().Bool("schema", false, "print the JSON schema of the task config")
+	return cmd
+}
@@ -17,23 +17,29 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"github.com/pingcap/ticdc/dm/checker"
+	"github.com/pingcap/ticdc/dm/dm/config"
 	"github.com/pingcap/ticdc/dm/dm/ctl/common"
 	"github.com/pingcap/ticdc/dm/dm/pb"
 )
 
+// checkReportSummary is contained in the report of `check-task --connect`.
+const checkReportSummary = `"summary"`
+
 // NewCheckTaskCmd creates a CheckTask command.
 func NewCheckTaskCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "check-task <config-file> [--error count] [--warn count]",
+		Use:   "check-task <config-file> [--error count] [--warn count] [--connect]",
 		Short: "Checks the configuration file of the task",
 		RunE:  checkTaskFunc,
 	}
 	cmd.Flags().Int64P("error", "e", common.DefaultErrorCnt, "max count of errors to display")
 	cmd.Flags().Int64P("warn", "w", common.DefaultWarnCnt, "max count of warns to display")
+	cmd.Flags().Bool("connect", false, "connect to the sources and the target to report the result of every checking item")
 	return cmd
 }
 
@@ -48,6 +54,11 @@ func checkTaskFunc(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	// the included files are merged here since DM-master can't access them
+	content, err = config.MergeIncludes(content, filepath.Dir(cmd.Flags().Arg(0)))
+	if err != nil {
+		return err
+	}
 
 	errCnt, err := cmd.Flags().GetInt64("error")
 	if err != nil {
@@ -57,6 +68,10 @@ func checkTaskFunc(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	connect, err := cmd.Flags().GetBool("connect")
+	if err != nil {
+		return err
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -70,6 +85,7 @@ func checkTaskFunc(cmd *cobra.Command, _ []string) error {
 			Task:    string(content),
 			ErrCnt:  errCnt,
 			WarnCnt: warnCnt,
+			Connect: connect,
 		},
 		&resp,
 	)
@@ -78,7 +94,12 @@ func checkTaskFunc(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	if !common.PrettyPrintResponseWithCheckTask(resp, checker.ErrorMsgHeader) {
+	// the report of `--connect` is a JSON object, print it as is
+	subStr := checker.ErrorMsgHeader
+	if connect {
+		subStr = checkReportSummary
+	}
+	if !common.PrettyPrintResponseWithCheckTask(resp, subStr) {
 		common.PrettyPrintResponse(resp)
 	}
 	return nil
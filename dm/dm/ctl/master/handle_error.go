@@ -29,11 +29,11 @@ import (
 func NewHandleErrorCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:    "handle-error <task-name | task-file> [-s source ...] [-b binlog-pos] <skip/replace/revert> [replace-sql1;replace-sql2;]",
-		Short:  "`skip`/`replace`/`revert` the current error event or a specific binlog position (binlog-pos) event",
+		Short:  "`skip`/`replace`/`revert` the current error event or a specific binlog position (binlog-pos) or GTID event",
 		Hidden: true,
 		RunE:   handleErrorFunc,
 	}
-	cmd.Flags().StringP("binlog-pos", "b", "", "position used to match binlog event if matched the handler-error operation will be applied. The format like \"mysql-bin|000001.000003:3270\"")
+	cmd.Flags().StringP("binlog-pos", "b", "", "position or GTID used to match binlog event if matched the handler-error operation will be applied. The format like \"mysql-bin|000001.000003:3270\" or \"3ccc475b-2343-11e7-be21-6c0b84d59f30:15\"")
 	return cmd
 }
 
@@ -93,7 +93,7 @@ func sendHandleErrorRequest(cmd *cobra.Command, op pb.ErrorOp, taskName string,
 		return err
 	}
 	if len(binlogPos) != 0 {
-		_, err = binlog.VerifyBinlogPos(binlogPos)
+		_, _, err = binlog.VerifyBinlogPosOrGTID("", binlogPos)
 		if err != nil {
 			return err
 		}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -51,6 +52,11 @@ func startTaskFunc(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	// the included files are merged here since DM-master can't access them
+	content, err = config.MergeIncludes(content, filepath.Dir(cmd.Flags().Arg(0)))
+	if err != nil {
+		return err
+	}
 
 	// If task's target db is configured with tls certificate related content
 	// the contents of the certificate need to be read and transferred to the dm-master
@@ -0,0 +1,244 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chzyer/readline"
+	"github.com/spf13/cobra"
+
+	"github.com/pingcap/ticdc/dm/dm/ctl/common"
+	"github.com/pingcap/ticdc/dm/dm/pb"
+)
+
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorBlue   = "\033[34m"
+
+	clearScreen = "\033[H\033[2J"
+
+	watchMsgMaxLen = 80
+)
+
+// NewWatchCmd creates a Watch command.
+func NewWatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch <command>",
+		Short: "Refreshes the output of a command continuously until Ctrl+C is pressed",
+	}
+	cmd.PersistentFlags().Duration("interval", 2*time.Second, "the interval to refresh the output")
+	cmd.PersistentFlags().Int("count", 0, "exit after refreshing the output count times, 0 means no limit")
+	cmd.AddCommand(newWatchQueryStatusCmd())
+	return cmd
+}
+
+func newWatchQueryStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "query-status [-s source ...] <task-name | task-file> [--interval 2s] [--count 0]",
+		Short: "Refreshes the stage, unit and replication lag of the subtasks of a task",
+		RunE:  watchQueryStatusFunc,
+	}
+}
+
+func watchQueryStatusFunc(cmd *cobra.Command, _ []string) error {
+	if len(cmd.Flags().Args()) != 1 {
+		cmd.SetOut(os.Stdout)
+		common.PrintCmdUsage(cmd)
+		return errors.New("please check output to see error")
+	}
+	taskName := common.GetTaskNameFromArgOrFile(cmd.Flags().Arg(0))
+
+	sources, err := common.GetSourceArgs(cmd)
+	if err != nil {
+		return err
+	}
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		common.PrintLinesf("error in parse `--interval`")
+		return err
+	}
+	if interval <= 0 {
+		return errors.New("`--interval` should be positive")
+	}
+	count, err := cmd.Flags().GetInt("count")
+	if err != nil {
+		common.PrintLinesf("error in parse `--count`")
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	colored := readline.DefaultIsTerminal()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for i := 0; count <= 0 || i < count; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+
+		resp := &pb.QueryStatusListResponse{}
+		reqCtx, cancel := context.WithTimeout(ctx, common.GlobalConfig().RPCTimeout)
+		err = common.SendRequest(
+			reqCtx,
+			"QueryStatus",
+			&pb.QueryStatusListRequest{
+				Name:    taskName,
+				Sources: sources,
+			},
+			&resp,
+		)
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if colored {
+			fmt.Print(clearScreen)
+		}
+		fmt.Printf("Every %s: query-status %s\t%s\n\n", interval, taskName, time.Now().Format("2006-01-02 15:04:05"))
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Print(formatWatchStatus(resp, colored))
+	}
+	return nil
+}
+
+// formatWatchStatus formats the status of the subtasks as a table of one line for each subtask.
+func formatWatchStatus(resp *pb.QueryStatusListResponse, colored bool) string {
+	header := []string{"SOURCE", "TASK", "STAGE", "UNIT", "LAG", "TPS", "SYNCED", "MESSAGE"}
+	const stageCol = 2
+
+	var rows [][]string
+	if !resp.Result {
+		rows = append(rows, []string{"-", "-", stageError, "-", "-", "-", "-", truncateWatchMsg(resp.Msg)})
+	}
+	sourceStatuses := append([]*pb.QueryStatusResponse(nil), resp.Sources...)
+	sort.Slice(sourceStatuses, func(i, j int) bool {
+		return sourceStatuses[i].GetSourceStatus().GetSource() < sourceStatuses[j].GetSourceStatus().GetSource()
+	})
+	for _, source := range sourceStatuses {
+		sourceID := source.GetSourceStatus().GetSource()
+		if !source.Result {
+			rows = append(rows, []string{sourceID, "-", stageError, "-", "-", "-", "-", truncateWatchMsg(source.Msg)})
+			continue
+		}
+		for _, subTask := range source.SubTaskStatus {
+			rows = append(rows, formatWatchSubTask(sourceID, source.GetSourceStatus().GetRelayStatus(), subTask))
+		}
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	var b strings.Builder
+	writeRow := func(row []string, isHeader bool) {
+		var line strings.Builder
+		for i, cell := range row {
+			if i == len(row)-1 {
+				line.WriteString(cell)
+				break
+			}
+			padded := cell + strings.Repeat(" ", widths[i]-len(cell)+2)
+			if colored && !isHeader && i == stageCol {
+				padded = stageColor(cell) + padded + colorReset
+			}
+			line.WriteString(padded)
+		}
+		b.WriteString(strings.TrimRight(line.String(), " "))
+		b.WriteString("\n")
+	}
+	writeRow(header, true)
+	for _, row := range rows {
+		writeRow(row, false)
+	}
+	return b.String()
+}
+
+func formatWatchSubTask(sourceID string, relayStatus *pb.RelayStatus, subTask *pb.SubTaskStatus) []string {
+	stage := subTask.Stage.String()
+	lag, tps, synced, msg := "-", "-", "-", ""
+	switch {
+	case errorOccurred(subTask.Result):
+		stage = stageError
+		msg = truncateWatchMsg(subTask.Result.Errors[0].Message)
+	case subTask.Unit == pb.UnitType_Sync && subTask.Stage == pb.Stage_Running && relayStatus != nil &&
+		(relayStatus.Stage == pb.Stage_Paused || relayStatus.Stage == pb.Stage_Stopped):
+		stage = stageError
+		msg = "relay status is " + getRelayStage(relayStatus)
+	case subTask.UnresolvedDDLLockID != "":
+		msg = "blocked by the DDL lock " + subTask.UnresolvedDDLLockID
+	}
+
+	if sync := subTask.GetSync(); sync != nil {
+		lag = strconv.FormatInt(sync.SecondsBehindMaster, 10) + "s"
+		tps = strconv.FormatInt(sync.RecentTps, 10)
+		synced = strconv.FormatBool(sync.Synced)
+	} else if msg == "" {
+		if load := subTask.GetLoad(); load != nil && load.Progress != "" {
+			msg = "loaded " + load.Progress
+		} else if dump := subTask.GetDump(); dump != nil && dump.Progress != "" {
+			msg = "dumped " + dump.Progress
+		}
+	}
+	return []string{sourceID, subTask.Name, stage, subTask.Unit.String(), lag, tps, synced, msg}
+}
+
+func stageColor(stage string) string {
+	switch stage {
+	case stageError:
+		return colorRed
+	case pb.Stage_Running.String():
+		return colorGreen
+	case pb.Stage_Paused.String(), pb.Stage_Pausing.String():
+		return colorYellow
+	default:
+		return colorBlue
+	}
+}
+
+// truncateWatchMsg keeps the first line of the message to not break the table.
+func truncateWatchMsg(msg string) string {
+	if i := strings.IndexByte(msg, '\n'); i >= 0 {
+		msg = msg[:i]
+	}
+	if len(msg) > watchMsgMaxLen {
+		msg = msg[:watchMsgMaxLen] + "..."
+	}
+	return msg
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pingcap/check"
+
+	"github.com/pingcap/ticdc/dm/dm/pb"
+)
+
+func (t *testCtlMaster) TestFormatWatchStatus(c *check.C) {
+	resp := &pb.QueryStatusListResponse{
+		Result: true,
+		Sources: []*pb.QueryStatusResponse{
+			{
+				Result:       true,
+				SourceStatus: &pb.SourceStatus{Source: "mysql-replica-02"},
+				SubTaskStatus: []*pb.SubTaskStatus{{
+					Name:  "test",
+					Stage: pb.Stage_Running,
+					Unit:  pb.UnitType_Load,
+					Status: &pb.SubTaskStatus_Load{Load: &pb.LoadStatus{
+						Progress: "50.00 %",
+					}},
+				}},
+			},
+			{
+				Result:       true,
+				SourceStatus: &pb.SourceStatus{Source: "mysql-replica-01"},
+				SubTaskStatus: []*pb.SubTaskStatus{{
+					Name:  "test",
+					Stage: pb.Stage_Running,
+					Unit:  pb.UnitType_Sync,
+					Status: &pb.SubTaskStatus_Sync{Sync: &pb.SyncStatus{
+						SecondsBehindMaster: 12,
+						RecentTps:           100,
+						Synced:              false,
+					}},
+				}},
+			},
+			{
+				Result:       true,
+				SourceStatus: &pb.SourceStatus{Source: "mysql-replica-03"},
+				SubTaskStatus: []*pb.SubTaskStatus{{
+					Name:  "test",
+					Stage: pb.Stage_Paused,
+					Unit:  pb.UnitType_Sync,
+					Result: &pb.ProcessResult{Errors: []*pb.ProcessError{{
+						Message: "execute DDL failed\nstack trace",
+					}}},
+					Status: &pb.SubTaskStatus_Sync{Sync: &pb.SyncStatus{}},
+				}},
+			},
+			{
+				Result:       false,
+				Msg:          "source not bound",
+				SourceStatus: &pb.SourceStatus{Source: "mysql-replica-04"},
+			},
+		},
+	}
+
+	lines := strings.Split(strings.TrimSuffix(formatWatchStatus(resp, false), "\n"), "\n")
+	c.Assert(lines, check.HasLen, 5)
+	c.Assert(strings.Fields(lines[0]), check.DeepEquals, []string{"SOURCE", "TASK", "STAGE", "UNIT", "LAG", "TPS", "SYNCED", "MESSAGE"})
+	// sorted by the sources
+	c.Assert(strings.Fields(lines[1]), check.DeepEquals, []string{"mysql-replica-01", "test", "Running", "Sync", "12s", "100", "false"})
+	c.Assert(strings.Fields(lines[2]), check.DeepEquals, []string{"mysql-replica-02", "test", "Running", "Load", "-", "-", "-", "loaded", "50.00", "%"})
+	c.Assert(strings.Fields(lines[3]), check.DeepEquals, []string{"mysql-replica-03", "test", "Error", "Sync", "0s", "0", "false", "execute", "DDL", "failed"})
+	c.Assert(strings.Fields(lines[4]), check.DeepEquals, []string{"mysql-replica-04", "-", "Error", "-", "-", "-", "-", "source", "not", "bound"})
+	// the columns are aligned
+	for _, line := range lines[1:4] {
+		c.Assert(strings.Index(line, "test"), check.Equals, strings.Index(lines[0], "TASK"))
+	}
+
+	// the stages are colored, and the columns are still aligned
+	lines = strings.Split(formatWatchStatus(resp, true), "\n")
+	c.Assert(strings.Contains(lines[0], "\033"), check.IsFalse)
+	c.Assert(lines[1], check.Matches, ".*"+regexp.QuoteMeta(colorGreen)+"Running +"+regexp.QuoteMeta(colorReset)+"Sync.*")
+	c.Assert(lines[3], check.Matches, ".*"+regexp.QuoteMeta(colorRed)+"Error +"+regexp.QuoteMeta(colorReset)+"Sync.*")
+	c.Assert(strings.Index(strings.ReplaceAll(strings.ReplaceAll(lines[1], colorGreen, ""), colorReset, ""), "Sync"),
+		check.Equals, strings.Index(lines[0], "UNIT"))
+
+	c.Assert(truncateWatchMsg(strings.Repeat("a", 100)), check.Equals, strings.Repeat("a", watchMsgMaxLen)+"...")
+}
@@ -168,6 +168,7 @@ func (s *Server) collectSourceConfigFilesV1Import(tctx *tcontext.Context) (map[s
 	}
 
 	cfgs := make(map[string]*config.SourceConfig)
+	serverIDs := make(map[uint32]struct{})
 	for _, f := range files {
 		if f.IsDir() {
 			continue // ignore sub directories.
@@ -179,7 +180,7 @@ func (s *Server) collectSourceConfigFilesV1Import(tctx *tcontext.Context) (map[s
 			return nil, err
 		}
 
-		cfgs2, err := parseAndAdjustSourceConfig(tctx.Ctx, []string{string(content)})
+		cfgs2, err := parseAndAdjustSourceConfig(tctx.Ctx, []string{string(content)}, serverIDs)
 		if err != nil {
 			// abort importing if any invalid source config files exist.
 			return nil, err
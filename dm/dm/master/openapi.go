@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/ticdc/dm/dm/master/workerrpc"
 	"github.com/pingcap/ticdc/dm/dm/pb"
 	"github.com/pingcap/ticdc/dm/openapi"
+	"github.com/pingcap/ticdc/dm/pkg/binlog"
 	"github.com/pingcap/ticdc/dm/pkg/conn"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
@@ -167,7 +168,8 @@ func (s *Server) DMAPICreateSource(ctx echo.Context) error {
 		return err
 	}
 	cfg := modelToSourceCfg(createSourceReq)
-	if err := checkAndAdjustSourceConfigFunc(ctx.Request().Context(), cfg); err != nil {
+	newCtx := config.WithReservedServerIDs(ctx.Request().Context(), s.usedServerIDs())
+	if err := checkAndAdjustSourceConfigFunc(newCtx, cfg); err != nil {
 		return err
 	}
 	if err := s.scheduler.AddSourceCfg(cfg); err != nil {
@@ -425,6 +427,9 @@ func (s *Server) DMAPIStartTask(ctx echo.Context) error {
 	} else {
 		needStartSubTaskList = subTaskConfigList
 	}
+	if err = s.checkServerIDConflict(newCtx, needStartSubTaskList); err != nil {
+		return err
+	}
 	// end all pre-check, start to create task
 	var (
 		latched = false
@@ -453,6 +458,49 @@ func (s *Server) DMAPIStartTask(ctx echo.Context) error {
 	return ctx.JSON(http.StatusCreated, task)
 }
 
+// DMAPIStartTaskWithYaml url is:(POST /api/v1/tasks/yaml).
+func (s *Server) DMAPIStartTaskWithYaml(ctx echo.Context) error {
+	var req openapi.CreateTaskWithYamlRequest
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+	// decode the task config first, so the invalid config error is returned with its error code
+	cfg := config.NewTaskConfig()
+	if err := cfg.Decode(req.TaskYaml); err != nil {
+		return terror.WithClass(err, terror.ClassDMMaster)
+	}
+	newCtx := ctx.Request().Context()
+	resp := openapi.CreateTaskWithYamlResponse{TaskName: cfg.Name}
+	if req.CheckOnly != nil && *req.CheckOnly {
+		if _, _, err := s.generateSubTask(newCtx, req.TaskYaml, common.DefaultErrorCnt, common.DefaultWarnCnt); err != nil {
+			return err
+		}
+		return ctx.JSON(http.StatusOK, resp)
+	}
+
+	startReq := &pb.StartTaskRequest{Task: req.TaskYaml}
+	if req.RemoveMeta != nil {
+		startReq.RemoveMeta = *req.RemoveMeta
+	}
+	if req.SourceNameList != nil {
+		startReq.Sources = *req.SourceNameList
+	}
+	startResp, err := s.StartTask(newCtx, startReq)
+	if err != nil {
+		return err
+	}
+	if !startResp.Result {
+		msg := startResp.Msg
+		for _, sourceResp := range startResp.Sources {
+			if !sourceResp.Result {
+				msg += fmt.Sprintf("source %s: %s; ", sourceResp.Source, sourceResp.Msg)
+			}
+		}
+		return terror.ErrOpenAPICommonError.New(msg)
+	}
+	return ctx.JSON(http.StatusCreated, resp)
+}
+
 // DMAPIDeleteTask url is:(DELETE /api/v1/tasks).
 func (s *Server) DMAPIDeleteTask(ctx echo.Context, taskName string, params openapi.DMAPIDeleteTaskParams) error {
 	var sourceList []string
@@ -587,6 +635,112 @@ func (s *Server) DMAPIResumeTask(ctx echo.Context, taskName string) error {
 	return s.scheduler.UpdateExpectSubTaskStage(pb.Stage_Running, taskName, sourceName...)
 }
 
+// DMAPIHandleTaskError handle the error binlog event of the task url is: (POST /api/v1/tasks/{task-name}/handle-error).
+func (s *Server) DMAPIHandleTaskError(ctx echo.Context, taskName string) error {
+	var req openapi.HandleTaskErrorRequest
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+	handleErrReq := &pb.HandleErrorRequest{Task: taskName}
+	switch req.Op {
+	case openapi.HandleTaskErrorRequestOpSkip:
+		handleErrReq.Op = pb.ErrorOp_Skip
+	case openapi.HandleTaskErrorRequestOpReplace:
+		handleErrReq.Op = pb.ErrorOp_Replace
+	case openapi.HandleTaskErrorRequestOpRevert:
+		handleErrReq.Op = pb.ErrorOp_Revert
+	default:
+		return terror.ErrOpenAPICommonError.New(fmt.Sprintf("invalid operation '%s', please use `skip`, `replace` or `revert`", req.Op))
+	}
+	if req.BinlogPos != nil {
+		handleErrReq.BinlogPos = *req.BinlogPos
+	}
+	if req.Sqls != nil {
+		handleErrReq.Sqls = *req.Sqls
+	}
+	if req.SourceNameList != nil {
+		handleErrReq.Sources = *req.SourceNameList
+	}
+	if handleErrReq.Op == pb.ErrorOp_Replace && len(handleErrReq.Sqls) == 0 {
+		return terror.ErrOpenAPICommonError.New("must specify the sqls for replace operation")
+	}
+	if len(handleErrReq.BinlogPos) > 0 {
+		if _, _, err := binlog.VerifyBinlogPosOrGTID("", handleErrReq.BinlogPos); err != nil {
+			return err
+		}
+	}
+
+	resp, err := s.HandleError(ctx.Request().Context(), handleErrReq)
+	if err != nil {
+		return err
+	}
+	if !resp.Result {
+		return terror.ErrOpenAPICommonError.New(resp.Msg)
+	}
+	for _, sourceResp := range resp.Sources {
+		if !sourceResp.Result {
+			return terror.ErrOpenAPICommonError.New(fmt.Sprintf("source %s: %s", sourceResp.Source, sourceResp.Msg))
+		}
+	}
+	return nil
+}
+
+// DMAPIGetTaskDDLLockList get the unresolved shard DDL locks of the task url is: (GET /api/v1/tasks/{task-name}/ddl-locks).
+func (s *Server) DMAPIGetTaskDDLLockList(ctx echo.Context, taskName string, params openapi.DMAPIGetTaskDDLLockListParams) error {
+	req := &pb.ShowDDLLocksRequest{Task: taskName}
+	if params.SourceNameList != nil {
+		req.Sources = *params.SourceNameList
+	}
+	resp, err := s.ShowDDLLocks(ctx.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+	if !resp.Result {
+		return terror.ErrOpenAPICommonError.New(resp.Msg)
+	}
+	lockList := make([]openapi.DDLLock, 0, len(resp.Locks))
+	for _, lock := range resp.Locks {
+		lockList = append(lockList, openapi.DDLLock{
+			LockId:             lock.ID,
+			TaskName:           lock.Task,
+			Mode:               lock.Mode,
+			Owner:              lock.Owner,
+			Ddls:               lock.DDLs,
+			SyncedSourceList:   lock.Synced,
+			UnsyncedSourceList: lock.Unsynced,
+		})
+	}
+	return ctx.JSON(http.StatusOK, openapi.GetTaskDDLLockListResponse{Total: len(lockList), Data: lockList})
+}
+
+// DMAPIUnlockTaskDDLLock force to unlock the shard DDL lock of the task url is: (POST /api/v1/tasks/{task-name}/ddl-locks/unlock).
+func (s *Server) DMAPIUnlockTaskDDLLock(ctx echo.Context, taskName string) error {
+	var req openapi.UnlockTaskDDLLockRequest
+	if err := ctx.Bind(&req); err != nil {
+		return err
+	}
+	// the lock ID is given explicitly, make sure it's not a lock of another task.
+	if lockTask := utils.ExtractTaskFromLockID(req.LockId); lockTask != taskName {
+		return terror.ErrOpenAPICommonError.New(fmt.Sprintf("lock %s does not belong to task %s", req.LockId, taskName))
+	}
+	unlockReq := &pb.UnlockDDLLockRequest{ID: req.LockId}
+	if req.Owner != nil {
+		unlockReq.ReplaceOwner = *req.Owner
+	}
+	if req.ForceRemove != nil {
+		unlockReq.ForceRemove = *req.ForceRemove
+	}
+
+	resp, err := s.UnlockDDLLock(ctx.Request().Context(), unlockReq)
+	if err != nil {
+		return err
+	}
+	if !resp.Result {
+		return terror.ErrOpenAPICommonError.New(resp.Msg)
+	}
+	return nil
+}
+
 // DMAPIGetSchemaListByTaskAndSource get task source schema list url is: (GET /api/v1/tasks/{task-name}/sources/{source-name}/schemas).
 func (s *Server) DMAPIGetSchemaListByTaskAndSource(ctx echo.Context, taskName string, sourceName string) error {
 	worker := s.scheduler.GetWorkerBySource(sourceName)
@@ -531,6 +531,30 @@ func (t *openAPISuite) TestTaskAPI(c *check.C) {
 	c.Assert(err, check.IsNil)
 	c.Assert(resultTaskStatusWithStatus, check.DeepEquals, resultTaskStatus)
 
+	// handle the error by the GTID
+	handleErrorURL := fmt.Sprintf("%s/%s/handle-error", taskURL, task.Name)
+	gtid := "3ccc475b-2343-11e7-be21-6c0b84d59f30:15"
+	sqls := []string{"ALTER TABLE db1.t1 ADD COLUMN c4 int"}
+	mockHandleError(mockWorkerClient,
+		&pb.HandleWorkerErrorRequest{Op: pb.ErrorOp_Replace, Task: task.Name, BinlogPos: gtid, Sqls: sqls},
+		&pb.CommonWorkerResponse{Result: true})
+	handleErrorReq := openapi.HandleTaskErrorRequest{Op: openapi.HandleTaskErrorRequestOpReplace, BinlogPos: &gtid, Sqls: &sqls}
+	result = testutil.NewRequest().Post(handleErrorURL).WithJsonBody(handleErrorReq).Go(t.testT, s.echo)
+	c.Assert(result.Code(), check.Equals, http.StatusOK)
+
+	// the failure of the worker is returned
+	mockHandleError(mockWorkerClient,
+		&pb.HandleWorkerErrorRequest{Op: pb.ErrorOp_Skip, Task: task.Name},
+		&pb.CommonWorkerResponse{Result: false, Msg: "source 'mysql-replica-01' has no error"})
+	handleErrorReq = openapi.HandleTaskErrorRequest{Op: openapi.HandleTaskErrorRequestOpSkip}
+	result = testutil.NewRequest().Post(handleErrorURL).WithJsonBody(handleErrorReq).Go(t.testT, s.echo)
+	c.Assert(result.Code(), check.Equals, http.StatusBadRequest)
+
+	// invalid request
+	handleErrorReq = openapi.HandleTaskErrorRequest{Op: openapi.HandleTaskErrorRequestOpReplace}
+	result = testutil.NewRequest().Post(handleErrorURL).WithJsonBody(handleErrorReq).Go(t.testT, s.echo)
+	c.Assert(result.Code(), check.Equals, http.StatusBadRequest)
+
 	// stop task
 	result = testutil.NewRequest().Delete(fmt.Sprintf("%s/%s", taskURL, task.Name)).Go(t.testT, s.echo)
 	c.Assert(result.Code(), check.Equals, http.StatusNoContent)
@@ -547,6 +571,70 @@ func (t *openAPISuite) TestTaskAPI(c *check.C) {
 	c.Assert(resultTaskList2.Total, check.Equals, 0)
 }
 
+func (t *openAPISuite) TestTaskDDLLockAPI(c *check.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := setupServer(ctx, c)
+	defer func() {
+		cancel()
+		s.Close()
+	}()
+
+	taskName := "test"
+	lockListURL := fmt.Sprintf("/api/v1/tasks/%s/ddl-locks", taskName)
+	unlockURL := lockListURL + "/unlock"
+
+	// no lock exists
+	result := testutil.NewRequest().Get(lockListURL).Go(t.testT, s.echo)
+	c.Assert(result.Code(), check.Equals, http.StatusOK)
+	var resultLockList openapi.GetTaskDDLLockListResponse
+	c.Assert(result.UnmarshalBodyToObject(&resultLockList), check.IsNil)
+	c.Assert(resultLockList.Total, check.Equals, 0)
+	c.Assert(resultLockList.Data, check.HasLen, 0)
+
+	// the lock of another task can't be unlocked
+	owner := source1Name
+	req := openapi.UnlockTaskDDLLockRequest{LockId: "another-task-`db`.`tbl`", Owner: &owner}
+	result = testutil.NewRequest().Post(unlockURL).WithJsonBody(req).Go(t.testT, s.echo)
+	c.Assert(result.Code(), check.Equals, http.StatusBadRequest)
+	var errResp openapi.ErrorWithMessage
+	c.Assert(result.UnmarshalBodyToObject(&errResp), check.IsNil)
+	c.Assert(errResp.ErrorMsg, check.Matches, ".*does not belong to task test.*")
+
+	// the lock not found
+	req.LockId = taskName + "-`db`.`tbl`"
+	result = testutil.NewRequest().Post(unlockURL).WithJsonBody(req).Go(t.testT, s.echo)
+	c.Assert(result.Code(), check.Equals, http.StatusBadRequest)
+	c.Assert(result.UnmarshalBodyToObject(&errResp), check.IsNil)
+	c.Assert(errResp.ErrorMsg, check.Matches, ".*lock with ID .* not found.*")
+}
+
+func (t *openAPISuite) TestStartTaskWithYamlAPI(c *check.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := setupServer(ctx, c)
+	defer func() {
+		cancel()
+		s.Close()
+	}()
+
+	taskURL := "/api/v1/tasks/yaml"
+	checkOnly := true
+
+	// invalid YAML
+	req := openapi.CreateTaskWithYamlRequest{TaskYaml: "name: [", CheckOnly: &checkOnly}
+	result := testutil.NewRequest().Post(taskURL).WithJsonBody(req).Go(t.testT, s.echo)
+	c.Assert(result.Code(), check.Equals, http.StatusBadRequest)
+	var errResp openapi.ErrorWithMessage
+	c.Assert(result.UnmarshalBodyToObject(&errResp), check.IsNil)
+	c.Assert(errResp.ErrorCode, check.Equals, int(terror.ErrConfigYamlTransform.Code()))
+
+	// the validation error of the task config is returned with its error code
+	req.TaskYaml = "task-mode: all"
+	result = testutil.NewRequest().Post(taskURL).WithJsonBody(req).Go(t.testT, s.echo)
+	c.Assert(result.Code(), check.Equals, http.StatusBadRequest)
+	c.Assert(result.UnmarshalBodyToObject(&errResp), check.IsNil)
+	c.Assert(errResp.ErrorCode, check.Equals, int(terror.ErrConfigNeedUniqueTaskName.Code()))
+}
+
 func (t *openAPISuite) TestClusterAPI(c *check.C) {
 	ctx1, cancel1 := context.WithCancel(context.Background())
 	s1 := setupServer(ctx1, c)
@@ -679,6 +767,10 @@ func mockRelayQueryStatus(
 	).Return(queryResp, nil).MaxTimes(maxRetryNum)
 }
 
+func mockHandleError(mockWorkerClient *pbmock.MockWorkerClient, req *pb.HandleWorkerErrorRequest, resp *pb.CommonWorkerResponse) {
+	mockWorkerClient.EXPECT().HandleError(gomock.Any(), req).Return(resp, nil)
+}
+
 func mockTaskQueryStatus(
 	mockWorkerClient *pbmock.MockWorkerClient, taskName, sourceName, workerName string) {
 	queryResp := &pb.QueryStatusResponse{
@@ -52,6 +52,10 @@ import (
 // - a source unbound from another worker:
 //   - trigger by unbound: `a worker from Bound to Offline`.
 //   - TODO(csuzhangxc): design a strategy to ensure the old worker already shutdown its work.
+// - a source bound to a worker of a busier DM-worker process:
+//   - trigger by rebalance: `a worker from Offline to Free` but no unbound source for it.
+// NOTE: a DM-worker process may serve multiple sources as multiple workers with the same address, the free workers
+// of the least loaded DM-worker process are preferred when binding sources.
 // Cases trigger a source-to-worker unbound try.
 // - a worker from Bound to Offline:
 //   - lost keep-alive.
@@ -1772,7 +1776,13 @@ func (s *Scheduler) handleWorkerOnline(ev ha.WorkerEvent, toLock bool) error {
 	}
 
 	// 4. try to bound an unbounded source.
-	_, err := s.tryBoundForWorker(w)
+	bounded, err := s.tryBoundForWorker(w)
+	if err != nil || bounded {
+		return err
+	}
+
+	// 5. try to take over a source from a busier DM-worker process.
+	_, err = s.tryRebalanceForWorker(w)
 	return err
 }
 
@@ -1982,17 +1992,24 @@ func (s *Scheduler) tryBoundForSource(source string) (bool, error) {
 		}
 	}
 
-	// and then a random Free worker.
+	// and then a Free worker of the least loaded DM-worker process.
 	if worker == nil {
+		minLoad := 0
 		for _, w := range s.workers {
-			if w.Stage() == WorkerFree {
-				worker = w
-				s.logger.Info("found free worker when source bound",
-					zap.String("worker", w.BaseInfo().Name),
-					zap.String("source", source))
-				break
+			if w.Stage() != WorkerFree {
+				continue
+			}
+			load := s.processLoad(w.BaseInfo().Addr)
+			if worker == nil || load < minLoad || (load == minLoad && w.BaseInfo().Name < worker.BaseInfo().Name) {
+				worker, minLoad = w, load
 			}
 		}
+		if worker != nil {
+			s.logger.Info("found free worker when source bound",
+				zap.String("worker", worker.BaseInfo().Name),
+				zap.String("source", source),
+				zap.Int("load", minLoad))
+		}
 	}
 
 	if worker == nil {
@@ -2008,6 +2025,64 @@ func (s *Scheduler) tryBoundForSource(source string) (bool, error) {
 	return true, nil
 }
 
+// tryRebalanceForWorker tries to move a source to the given Free worker from the most loaded DM-worker process,
+// if that process serves at least two more sources than the process of the given worker. The sources pulling
+// relay log or having subtasks in load stage are never moved, because their relay log or dump files are local.
+func (s *Scheduler) tryRebalanceForWorker(w *Worker) (bool, error) {
+	if w.Stage() != WorkerFree {
+		return false, nil
+	}
+	var (
+		from    *Worker
+		source  string
+		maxLoad = s.processLoad(w.BaseInfo().Addr) + 1
+	)
+	for sourceID, bw := range s.bounds {
+		if bw.RelaySourceID() != "" || s.hasLoadTaskBySource(sourceID) {
+			continue
+		}
+		if cfg, ok := s.sourceCfgs[sourceID]; !ok || cfg.EnableRelay {
+			continue
+		}
+		load := s.processLoad(bw.BaseInfo().Addr)
+		if load > maxLoad || (load == maxLoad && from != nil && sourceID < source) {
+			from, source, maxLoad = bw, sourceID, load
+		}
+	}
+	if from == nil {
+		return false, nil
+	}
+
+	s.logger.Info("rebalance the source to a less loaded DM-worker",
+		zap.String("source", source),
+		zap.String("old worker", from.BaseInfo().Name),
+		zap.String("new worker", w.BaseInfo().Name),
+		zap.Int("old load", maxLoad))
+	err := s.transferWorkerAndSource(from.BaseInfo().Name, source, w.BaseInfo().Name, "")
+	return err == nil, err
+}
+
+// processLoad returns the number of the sources bound to the workers served by the DM-worker process of the address.
+func (s *Scheduler) processLoad(addr string) int {
+	load := 0
+	for _, w := range s.bounds {
+		if w.BaseInfo().Addr == addr {
+			load++
+		}
+	}
+	return load
+}
+
+// hasLoadTaskBySource returns whether any subtask of the source is in load stage.
+func (s *Scheduler) hasLoadTaskBySource(source string) bool {
+	for _, sources := range s.loadTasks {
+		if _, ok := sources[source]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // boundSourceToWorker bounds the source and worker together.
 // we should check the bound relationship of the source and the stage of the worker in the caller.
 func (s *Scheduler) boundSourceToWorker(source string, w *Worker) error {
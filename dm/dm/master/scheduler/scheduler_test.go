@@ -1711,3 +1711,77 @@ func (t *testScheduler) TestWorkerHasDiffRelayAndBound(c *C) {
 	_, ok = s.unbounds[sourceID1]
 	c.Assert(ok, IsTrue)
 }
+
+func (t *testScheduler) TestRebalanceByProcessLoad(c *C) {
+	defer clearTestInfoOperation(c)
+
+	var (
+		logger    = log.L()
+		s         = NewScheduler(&logger, config.Security{})
+		sourceID1 = "mysql-replica-1"
+		sourceID2 = "mysql-replica-2"
+		sourceID3 = "mysql-replica-3"
+		sourceID4 = "mysql-replica-4"
+		addrA     = "127.0.0.1:8262"
+		addrB     = "127.0.0.1:18262"
+	)
+
+	// two DM-worker processes, each of them serves three sources at most.
+	workerA := []*Worker{
+		{baseInfo: ha.WorkerInfo{Name: "dm-worker-a", Addr: addrA}},
+		{baseInfo: ha.WorkerInfo{Name: "dm-worker-a#1", Addr: addrA}},
+		{baseInfo: ha.WorkerInfo{Name: "dm-worker-a#2", Addr: addrA}},
+	}
+	workerB := []*Worker{
+		{baseInfo: ha.WorkerInfo{Name: "dm-worker-b", Addr: addrB}},
+		{baseInfo: ha.WorkerInfo{Name: "dm-worker-b#1", Addr: addrB}},
+		{baseInfo: ha.WorkerInfo{Name: "dm-worker-b#2", Addr: addrB}},
+	}
+	s.started = true
+	s.etcdCli = etcdTestCli
+	for _, w := range append(workerA, workerB...) {
+		s.workers[w.BaseInfo().Name] = w
+	}
+	for _, sourceID := range []string{sourceID1, sourceID2, sourceID3, sourceID4} {
+		s.sourceCfgs[sourceID] = &config.SourceConfig{}
+	}
+
+	// all sources are bound to the process A.
+	for i, sourceID := range []string{sourceID1, sourceID2, sourceID3} {
+		workerA[i].ToFree()
+		c.Assert(s.boundSourceToWorker(sourceID, workerA[i]), IsNil)
+	}
+	c.Assert(s.processLoad(addrA), Equals, 3)
+	c.Assert(s.processLoad(addrB), Equals, 0)
+
+	// the first worker of the process B takes over a source when it comes online.
+	c.Assert(s.handleWorkerOnline(ha.WorkerEvent{WorkerName: workerB[0].BaseInfo().Name}, true), IsNil)
+	c.Assert(s.bounds[sourceID1], DeepEquals, workerB[0])
+	c.Assert(workerA[0].Stage(), Equals, WorkerFree)
+	c.Assert(s.processLoad(addrA), Equals, 2)
+	c.Assert(s.processLoad(addrB), Equals, 1)
+
+	// the second one doesn't, because the processes are balanced enough.
+	c.Assert(s.handleWorkerOnline(ha.WorkerEvent{WorkerName: workerB[1].BaseInfo().Name}, true), IsNil)
+	c.Assert(workerB[1].Stage(), Equals, WorkerFree)
+	c.Assert(s.processLoad(addrA), Equals, 2)
+	c.Assert(s.processLoad(addrB), Equals, 1)
+
+	// a new source is bound to the free worker of the least loaded process.
+	s.unbounds[sourceID4] = struct{}{}
+	bounded, err := s.tryBoundForSource(sourceID4)
+	c.Assert(err, IsNil)
+	c.Assert(bounded, IsTrue)
+	c.Assert(s.bounds[sourceID4], DeepEquals, workerB[1])
+
+	// the sources pulling relay log are never moved.
+	c.Assert(s.TransferSource(sourceID1, workerA[0].BaseInfo().Name), IsNil)
+	c.Assert(s.processLoad(addrA), Equals, 3)
+	c.Assert(s.processLoad(addrB), Equals, 1)
+	for _, sourceID := range []string{sourceID1, sourceID2, sourceID3} {
+		s.sourceCfgs[sourceID].EnableRelay = true
+	}
+	c.Assert(s.handleWorkerOnline(ha.WorkerEvent{WorkerName: workerB[2].BaseInfo().Name}, true), IsNil)
+	c.Assert(workerB[2].Stage(), Equals, WorkerFree)
+	c.Assert(s.processLoad(addrA), Equals, 3)
+}
@@ -19,7 +19,9 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
 
+	"github.com/pingcap/ticdc/dm/dm/common"
 	"github.com/pingcap/ticdc/dm/dm/config"
 	"github.com/pingcap/ticdc/dm/dm/master/metrics"
 	"github.com/pingcap/ticdc/dm/dm/master/workerrpc"
@@ -237,7 +239,9 @@ func (w *Worker) RelaySourceID() string {
 }
 
 // SendRequest sends request to the DM-worker instance.
+// the name of the worker is attached to the request, because a DM-worker process may serve multiple workers.
 func (w *Worker) SendRequest(ctx context.Context, req *workerrpc.Request, d time.Duration) (*workerrpc.Response, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, common.WorkerNameMetadataKey, w.baseInfo.Name)
 	return w.cli.SendRequest(ctx, req, d)
 }
 
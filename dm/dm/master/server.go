@@ -15,6 +15,7 @@ package master
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -159,13 +160,13 @@ func (s *Server) Start(ctx context.Context) (err error) {
 		return
 	}
 
-	tls, err := toolutils.NewTLS(s.cfg.SSLCA, s.cfg.SSLCert, s.cfg.SSLKey, s.cfg.AdvertiseAddr, s.cfg.CertAllowedCN)
+	tls, err := utils.NewTLS(s.cfg.SSLCA, s.cfg.SSLCert, s.cfg.SSLKey, s.cfg.AdvertiseAddr, s.cfg.CertAllowedCN)
 	if err != nil {
 		return terror.ErrMasterTLSConfigNotValid.Delegate(err)
 	}
 
 	// tls2 is used for grpc client in grpc gateway
-	tls2, err := toolutils.NewTLS(s.cfg.SSLCA, s.cfg.SSLCert, s.cfg.SSLKey, s.cfg.AdvertiseAddr, s.cfg.CertAllowedCN)
+	tls2, err := utils.NewTLS(s.cfg.SSLCA, s.cfg.SSLCert, s.cfg.SSLKey, s.cfg.AdvertiseAddr, s.cfg.CertAllowedCN)
 	if err != nil {
 		return terror.ErrMasterTLSConfigNotValid.Delegate(err)
 	}
@@ -460,6 +461,12 @@ func (s *Server) StartTask(ctx context.Context, req *pb.StartTaskRequest) (*pb.S
 			sources = append(sources, stCfg.SourceID)
 		}
 
+		if err = s.checkServerIDConflict(ctx, subtaskCfgPointersToInstances(stCfgs...)); err != nil {
+			resp.Msg = err.Error()
+			// nolint:nilerr
+			return resp, nil
+		}
+
 		var (
 			latched = false
 			release scheduler.ReleaseFunc
@@ -1097,6 +1104,10 @@ func (s *Server) CheckTask(ctx context.Context, req *pb.CheckTaskRequest) (*pb.C
 		return resp2, err2
 	}
 
+	if req.Connect {
+		return s.checkTaskWithConnect(ctx, req.Task), nil
+	}
+
 	_, _, err := s.generateSubTask(ctx, req.Task, req.ErrCnt, req.WarnCnt)
 	if err != nil {
 		// nolint:nilerr
@@ -1112,21 +1123,65 @@ func (s *Server) CheckTask(ctx context.Context, req *pb.CheckTaskRequest) (*pb.C
 	}, nil
 }
 
-func parseAndAdjustSourceConfig(ctx context.Context, contents []string) ([]*config.SourceConfig, error) {
+// checkTaskWithConnect connects to the sources and the target of the task and returns
+// the report of all checking items in Msg.
+func (s *Server) checkTaskWithConnect(ctx context.Context, task string) *pb.CheckTaskResponse {
+	_, stCfgs, err := s.generateSubTaskConfigs(ctx, task)
+	if err != nil {
+		return &pb.CheckTaskResponse{
+			Result: false,
+			Msg:    err.Error(),
+		}
+	}
+
+	report, err := checker.CheckTaskReportFunc(ctx, stCfgs)
+	if err != nil {
+		return &pb.CheckTaskResponse{
+			Result: false,
+			Msg:    terror.WithClass(err, terror.ClassDMMaster).Error(),
+		}
+	}
+	rawReport, err := json.MarshalIndent(report, "\t", "\t")
+	if err != nil {
+		return &pb.CheckTaskResponse{
+			Result: false,
+			Msg:    err.Error(),
+		}
+	}
+	return &pb.CheckTaskResponse{
+		Result: report.Summary.Passed,
+		Msg:    string(rawReport),
+	}
+}
+
+// parseAndAdjustSourceConfig parses and adjusts the source configs, the server-ids allocated for the
+// sources are neither in usedServerIDs nor allocated for other sources in contents.
+func parseAndAdjustSourceConfig(ctx context.Context, contents []string, usedServerIDs map[uint32]struct{}) ([]*config.SourceConfig, error) {
 	cfgs := make([]*config.SourceConfig, len(contents))
 	for i, content := range contents {
 		cfg, err := config.ParseYaml(content)
 		if err != nil {
 			return cfgs, err
 		}
-		if err := checkAndAdjustSourceConfigFunc(ctx, cfg); err != nil {
+		if err := checkAndAdjustSourceConfigFunc(config.WithReservedServerIDs(ctx, usedServerIDs), cfg); err != nil {
 			return cfgs, err
 		}
+		usedServerIDs[cfg.ServerID] = struct{}{}
 		cfgs[i] = cfg
 	}
 	return cfgs, nil
 }
 
+// usedServerIDs returns the server-ids of the sources in the cluster, which are persisted
+// with the source configs.
+func (s *Server) usedServerIDs() map[uint32]struct{} {
+	serverIDs := make(map[uint32]struct{})
+	for _, cfg := range s.scheduler.GetSourceCfgs() {
+		serverIDs[cfg.ServerID] = struct{}{}
+	}
+	return serverIDs
+}
+
 func checkAndAdjustSourceConfig(ctx context.Context, cfg *config.SourceConfig) error {
 	dbConfig := cfg.GenerateDBConfig()
 	fromDB, err := conn.DefaultDBProvider.Apply(dbConfig)
@@ -1206,7 +1261,7 @@ func (s *Server) OperateSource(ctx context.Context, req *pb.OperateSourceRequest
 	)
 	switch req.Op {
 	case pb.SourceOp_StartSource, pb.SourceOp_UpdateSource:
-		cfgs, err = parseAndAdjustSourceConfig(ctx, req.Config)
+		cfgs, err = parseAndAdjustSourceConfig(ctx, req.Config, s.usedServerIDs())
 	default:
 		// don't check the upstream connections, because upstream may be inaccessible
 		cfgs, err = parseSourceConfig(req.Config)
@@ -1357,6 +1412,21 @@ func (s *Server) OperateLeader(ctx context.Context, req *pb.OperateLeaderRequest
 }
 
 func (s *Server) generateSubTask(ctx context.Context, task string, errCnt, warnCnt int64) (*config.TaskConfig, []*config.SubTaskConfig, error) {
+	cfg, stCfgs, err := s.generateSubTaskConfigs(ctx, task)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = checker.CheckSyncConfigFunc(ctx, stCfgs, errCnt, warnCnt)
+	if err != nil {
+		return nil, nil, terror.WithClass(err, terror.ClassDMMaster)
+	}
+
+	return cfg, stCfgs, nil
+}
+
+// generateSubTaskConfigs decodes the task config and generates the sub-task configs without checking them.
+func (s *Server) generateSubTaskConfigs(ctx context.Context, task string) (*config.TaskConfig, []*config.SubTaskConfig, error) {
 	cfg := config.NewTaskConfig()
 	err := cfg.Decode(task)
 	if err != nil {
@@ -1375,11 +1445,6 @@ func (s *Server) generateSubTask(ctx context.Context, task string, errCnt, warnC
 		return nil, nil, terror.WithClass(err, terror.ClassDMMaster)
 	}
 
-	err = checker.CheckSyncConfigFunc(ctx, stCfgs, errCnt, warnCnt)
-	if err != nil {
-		return nil, nil, terror.WithClass(err, terror.ClassDMMaster)
-	}
-
 	return cfg, stCfgs, nil
 }
 
@@ -1989,7 +2054,7 @@ func (s *Server) createMasterClientByName(ctx context.Context, name string) (pb.
 	if len(clientURLs) == 0 {
 		return nil, nil, errors.New("master not found")
 	}
-	tls, err := toolutils.NewTLS(s.cfg.SSLCA, s.cfg.SSLCert, s.cfg.SSLKey, s.cfg.AdvertiseAddr, s.cfg.CertAllowedCN)
+	tls, err := utils.NewTLS(s.cfg.SSLCA, s.cfg.SSLCert, s.cfg.SSLKey, s.cfg.AdvertiseAddr, s.cfg.CertAllowedCN)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,131 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/pkg/conn"
+	"github.com/pingcap/ticdc/dm/pkg/log"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+	"github.com/pingcap/ticdc/dm/pkg/utils"
+)
+
+var getUpstreamServerIDsFunc = getUpstreamServerIDs
+
+// getUpstreamServerIDs returns the server_id of the upstream of the source, and the server_ids of its replicas.
+func getUpstreamServerIDs(ctx context.Context, cfg *config.SourceConfig) (uint32, map[uint32]struct{}, error) {
+	fromDB, err := conn.DefaultDBProvider.Apply(cfg.GenerateDBConfig())
+	if err != nil {
+		return 0, nil, err
+	}
+	defer fromDB.Close()
+
+	serverID, err := utils.GetServerID(ctx, fromDB.DB)
+	if err != nil {
+		return 0, nil, err
+	}
+	replicaIDs, err := utils.GetSlaveServerID(ctx, fromDB.DB)
+	if err != nil {
+		return 0, nil, err
+	}
+	return serverID, replicaIDs, nil
+}
+
+// serverIDRegistry records the sources and tasks using each server-id.
+type serverIDRegistry map[uint32]map[string]string // server-id -> source -> task
+
+func (r serverIDRegistry) register(serverID uint32, source, task string) {
+	sources, ok := r[serverID]
+	if !ok {
+		sources = make(map[string]string)
+		r[serverID] = sources
+	}
+	if _, ok := sources[source]; !ok {
+		sources[source] = task
+	}
+}
+
+// conflict returns the first other source using the server-id, and the task using it.
+func (r serverIDRegistry) conflict(serverID uint32, source string) (string, string, bool) {
+	others := make([]string, 0, len(r[serverID]))
+	for other := range r[serverID] {
+		if other != source {
+			others = append(others, other)
+		}
+	}
+	if len(others) == 0 {
+		return "", "", false
+	}
+	sort.Strings(others)
+	return others[0], r[serverID][others[0]], true
+}
+
+// checkServerIDConflict checks the server-ids of the sources of the subtasks to start are not used by the
+// sources of the other running tasks, the upstream itself and its replicas.
+func (s *Server) checkServerIDConflict(ctx context.Context, stCfgs []config.SubTaskConfig) error {
+	registry := make(serverIDRegistry)
+	for task, subTaskCfgs := range s.scheduler.GetSubTaskCfgs() {
+		for source := range subTaskCfgs {
+			if sourceCfg := s.scheduler.GetSourceCfgByID(source); sourceCfg != nil && sourceCfg.ServerID != 0 {
+				registry.register(sourceCfg.ServerID, source, task)
+			}
+		}
+	}
+
+	for _, stCfg := range stCfgs {
+		sourceCfg := s.scheduler.GetSourceCfgByID(stCfg.SourceID)
+		// the server-id not allocated yet is allocated by the DM-worker.
+		if sourceCfg == nil || sourceCfg.ServerID == 0 {
+			continue
+		}
+		serverID := sourceCfg.ServerID
+		if other, task, ok := registry.conflict(serverID, stCfg.SourceID); ok {
+			return terror.ErrMasterServerIDConflict.Generate(serverID, stCfg.SourceID, fmt.Sprintf("source %s of task %s", other, task))
+		}
+		registry.register(serverID, stCfg.SourceID, stCfg.Name)
+
+		upstreamID, replicaIDs, err := getUpstreamServerIDsFunc(ctx, sourceCfg)
+		if err != nil {
+			// the connection problems are reported by the precheck or the subtask.
+			log.L().Warn("fail to get the server_ids of the upstream, skip checking them",
+				zap.String("source", stCfg.SourceID), log.ShortError(err))
+			continue
+		}
+		if upstreamID == serverID {
+			return terror.ErrMasterServerIDConflict.Generate(serverID, stCfg.SourceID,
+				fmt.Sprintf("the server_id of the upstream %s:%d", sourceCfg.From.Host, sourceCfg.From.Port))
+		}
+		// the relay and the syncers of the running tasks of the source are listed as the replicas too.
+		if _, ok := replicaIDs[serverID]; ok && !s.isSourceReplicating(stCfg.SourceID) {
+			return terror.ErrMasterServerIDConflict.Generate(serverID, stCfg.SourceID,
+				fmt.Sprintf("the server_id of a replica of the upstream %s:%d", sourceCfg.From.Host, sourceCfg.From.Port))
+		}
+	}
+	return nil
+}
+
+// isSourceReplicating returns whether the relay or the subtasks of the source are running.
+func (s *Server) isSourceReplicating(source string) bool {
+	if len(s.scheduler.GetTaskNameListBySourceName(source)) > 0 {
+		return true
+	}
+	relayWorkers, err := s.scheduler.GetRelayWorkers(source)
+	return err == nil && len(relayWorkers) > 0
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package master
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pingcap/check"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/dm/master/scheduler"
+	"github.com/pingcap/ticdc/dm/pkg/log"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+func (t *testMaster) TestServerIDRegistry(c *check.C) {
+	registry := make(serverIDRegistry)
+	registry.register(101, "source-2", "task-1")
+	registry.register(101, "source-1", "task-2")
+	registry.register(101, "source-1", "task-1")
+	registry.register(102, "source-3", "task-1")
+
+	_, _, ok := registry.conflict(102, "source-3")
+	c.Assert(ok, check.IsFalse)
+	_, _, ok = registry.conflict(103, "source-3")
+	c.Assert(ok, check.IsFalse)
+	source, task, ok := registry.conflict(101, "source-3")
+	c.Assert(ok, check.IsTrue)
+	c.Assert(source, check.Equals, "source-1")
+	c.Assert(task, check.Equals, "task-2")
+	source, task, ok = registry.conflict(101, "source-1")
+	c.Assert(ok, check.IsTrue)
+	c.Assert(source, check.Equals, "source-2")
+	c.Assert(task, check.Equals, "task-1")
+}
+
+func (t *testMaster) TestCheckServerIDConflict(c *check.C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := testDefaultMasterServer(c)
+	logger := log.L()
+	server.scheduler = scheduler.NewScheduler(&logger, config.Security{})
+	c.Assert(server.scheduler.Start(ctx, t.etcdTestCli), check.IsNil)
+	defer server.scheduler.Close()
+	for source, serverID := range map[string]uint32{"source-1": 101, "source-2": 102, "source-3": 101, "source-4": 0} {
+		cfg := config.NewSourceConfig()
+		cfg.SourceID = source
+		cfg.ServerID = serverID
+		cfg.From.Host = "127.0.0.1"
+		cfg.From.Port = 3306
+		c.Assert(server.scheduler.AddSourceCfg(cfg), check.IsNil)
+	}
+
+	upstreamID, replicaIDs := uint32(1), map[uint32]struct{}{}
+	var upstreamErr error
+	getUpstreamServerIDsFunc = func(context.Context, *config.SourceConfig) (uint32, map[uint32]struct{}, error) {
+		return upstreamID, replicaIDs, upstreamErr
+	}
+	defer func() {
+		getUpstreamServerIDsFunc = getUpstreamServerIDs
+	}()
+
+	subTasks := func(sources ...string) []config.SubTaskConfig {
+		cfgs := make([]config.SubTaskConfig, 0, len(sources))
+		for _, source := range sources {
+			cfgs = append(cfgs, config.SubTaskConfig{Name: "test", SourceID: source})
+		}
+		return cfgs
+	}
+
+	c.Assert(server.checkServerIDConflict(ctx, subTasks("source-1", "source-2", "source-4")), check.IsNil)
+	// the sources of a task use the same server-id
+	err := server.checkServerIDConflict(ctx, subTasks("source-1", "source-3"))
+	c.Assert(terror.ErrMasterServerIDConflict.Equal(err), check.IsTrue)
+	c.Assert(err, check.ErrorMatches, ".*server-id 101 of source source-3 conflicts with source source-1 of task test.*")
+
+	// the upstream uses the same server-id
+	upstreamID = 102
+	err = server.checkServerIDConflict(ctx, subTasks("source-1", "source-2"))
+	c.Assert(terror.ErrMasterServerIDConflict.Equal(err), check.IsTrue)
+	c.Assert(err, check.ErrorMatches, ".*server-id 102 of source source-2 conflicts with the server_id of the upstream 127.0.0.1:3306.*")
+
+	// a replica of the upstream uses the same server-id
+	upstreamID = 1
+	replicaIDs[101] = struct{}{}
+	err = server.checkServerIDConflict(ctx, subTasks("source-1"))
+	c.Assert(terror.ErrMasterServerIDConflict.Equal(err), check.IsTrue)
+	c.Assert(err, check.ErrorMatches, ".*server-id 101 of source source-1 conflicts with the server_id of a replica of the upstream.*")
+
+	// the upstream is not checked if it can't be connected
+	upstreamErr = errors.New("connection refused")
+	c.Assert(server.checkServerIDConflict(ctx, subTasks("source-1")), check.IsNil)
+}
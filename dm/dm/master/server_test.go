@@ -16,6 +16,7 @@ package master
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -32,6 +33,7 @@ import (
 	"github.com/pingcap/check"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	tcheck "github.com/pingcap/tidb-tools/pkg/check"
 	toolutils "github.com/pingcap/tidb-tools/pkg/utils"
 	tiddl "github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/parser"
@@ -794,6 +796,50 @@ func (t *testMaster) TestCheckTask(c *check.C) {
 	c.Assert(err, check.IsNil)
 	c.Assert(resp.Result, check.IsTrue)
 	t.clearSchedulerEnv(c, cancel, &wg)
+
+	// check with connect reports all checking items
+	bakCheckTaskReportFunc := checker.CheckTaskReportFunc
+	defer func() {
+		checker.CheckTaskReportFunc = bakCheckTaskReportFunc
+	}()
+	var checkedCfgs []*config.SubTaskConfig
+	checker.CheckTaskReportFunc = func(_ context.Context, cfgs []*config.SubTaskConfig) (*tcheck.Results, error) {
+		checkedCfgs = cfgs
+		return &tcheck.Results{
+			Results: []*tcheck.Result{{Name: "mysql_server_id", State: tcheck.StateSuccess}},
+			Summary: &tcheck.ResultSummary{Passed: true, Total: 1, Successful: 1},
+		}, nil
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	server.scheduler, _ = t.testMockScheduler(ctx, &wg, c, sources, workers, "", t.workerClients)
+	mock = conn.InitVersionDB(c)
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'version'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("version", "5.7.25-TiDB-v4.0.2"))
+	resp, err = server.CheckTask(context.Background(), &pb.CheckTaskRequest{
+		Task:    taskConfig,
+		Connect: true,
+	})
+	c.Assert(err, check.IsNil)
+	c.Assert(resp.Result, check.IsTrue)
+	c.Assert(checkedCfgs, check.HasLen, len(sources))
+	var report tcheck.Results
+	c.Assert(json.Unmarshal([]byte(resp.Msg), &report), check.IsNil)
+	c.Assert(report.Results[0].Name, check.Equals, "mysql_server_id")
+
+	checker.CheckTaskReportFunc = func(_ context.Context, _ []*config.SubTaskConfig) (*tcheck.Results, error) {
+		return nil, errors.New("connect to source failed")
+	}
+	mock = conn.InitVersionDB(c)
+	mock.ExpectQuery("SHOW GLOBAL VARIABLES LIKE 'version'").WillReturnRows(sqlmock.NewRows([]string{"Variable_name", "Value"}).
+		AddRow("version", "5.7.25-TiDB-v4.0.2"))
+	resp, err = server.CheckTask(context.Background(), &pb.CheckTaskRequest{
+		Task:    taskConfig,
+		Connect: true,
+	})
+	c.Assert(err, check.IsNil)
+	c.Assert(resp.Result, check.IsFalse)
+	c.Assert(resp.Msg, check.Matches, ".*connect to source failed.*")
+	t.clearSchedulerEnv(c, cancel, &wg)
 }
 
 func (t *testMaster) TestStartTask(c *check.C) {
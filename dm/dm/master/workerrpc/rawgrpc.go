@@ -17,7 +17,6 @@ import (
 	"context"
 	"time"
 
-	toolutils "github.com/pingcap/tidb-tools/pkg/utils"
 	"go.uber.org/atomic"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
@@ -25,6 +24,7 @@ import (
 	"github.com/pingcap/ticdc/dm/dm/config"
 	"github.com/pingcap/ticdc/dm/dm/pb"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
+	"github.com/pingcap/ticdc/dm/pkg/utils"
 )
 
 // GRPCClient stores raw grpc connection and worker client.
@@ -44,7 +44,7 @@ func NewGRPCClientWrap(conn *grpc.ClientConn, client pb.WorkerClient) (*GRPCClie
 
 // NewGRPCClient initializes a new grpc client from worker address.
 func NewGRPCClient(addr string, securityCfg config.Security) (*GRPCClient, error) {
-	tls, err := toolutils.NewTLS(securityCfg.SSLCA, securityCfg.SSLCert, securityCfg.SSLKey, addr, securityCfg.CertAllowedCN)
+	tls, err := utils.NewTLS(securityCfg.SSLCA, securityCfg.SSLCert, securityCfg.SSLKey, addr, securityCfg.CertAllowedCN)
 	if err != nil {
 		return nil, terror.ErrMasterGRPCCreateConn.Delegate(err)
 	}
@@ -1229,6 +1229,7 @@ type CheckTaskRequest struct {
 	Task    string `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	ErrCnt  int64  `protobuf:"varint,2,opt,name=errCnt,proto3" json:"errCnt,omitempty"`
 	WarnCnt int64  `protobuf:"varint,3,opt,name=warnCnt,proto3" json:"warnCnt,omitempty"`
+	Connect bool   `protobuf:"varint,4,opt,name=connect,proto3" json:"connect,omitempty"`
 }
 
 func (m *CheckTaskRequest) Reset()         { *m = CheckTaskRequest{} }
@@ -1285,6 +1286,13 @@ func (m *CheckTaskRequest) GetWarnCnt() int64 {
 	return 0
 }
 
+func (m *CheckTaskRequest) GetConnect() bool {
+	if m != nil {
+		return m.Connect
+	}
+	return false
+}
+
 type CheckTaskResponse struct {
 	Result bool   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
 	Msg    string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
@@ -3163,135 +3171,135 @@ func init() {
 func init() { proto.RegisterFile("dmmaster.proto", fileDescriptor_f9bef11f2a341f03) }
 
 var fileDescriptor_f9bef11f2a341f03 = []byte{
-	// 2040 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0x5f, 0x6f, 0x1b, 0xc7,
-	0x11, 0xe7, 0x91, 0x34, 0x45, 0x0d, 0x25, 0x86, 0x5e, 0x51, 0xd4, 0xf9, 0x2c, 0xd3, 0xca, 0x36,
-	0x09, 0x04, 0xa1, 0xb0, 0x60, 0xb5, 0x4f, 0x01, 0x52, 0x34, 0x26, 0x1d, 0x47, 0xa8, 0x5c, 0xa5,
-	0x27, 0x3b, 0x4d, 0x50, 0xa0, 0xc8, 0x91, 0x5c, 0x52, 0x07, 0x1d, 0xef, 0xce, 0x77, 0x47, 0xa9,
-	0x82, 0x91, 0x97, 0x7e, 0x80, 0xfe, 0x41, 0x1f, 0xf2, 0xd8, 0x87, 0x7e, 0x93, 0x3e, 0xf5, 0x31,
-	0x40, 0x81, 0xa2, 0x8f, 0x85, 0xdd, 0x0f, 0x52, 0xec, 0xec, 0xde, 0xdd, 0xde, 0x1f, 0xaa, 0xa5,
-	0x81, 0xea, 0xed, 0x66, 0x66, 0x39, 0xf3, 0x9b, 0x3f, 0x3b, 0x3b, 0xbb, 0x84, 0xf6, 0x64, 0x3e,
-	0xb7, 0xc2, 0x88, 0x05, 0x8f, 0xfc, 0xc0, 0x8b, 0x3c, 0x52, 0xf5, 0x47, 0x46, 0x7b, 0x32, 0xbf,
-	0xf2, 0x82, 0x8b, 0x98, 0x67, 0xec, 0xce, 0x3c, 0x6f, 0xe6, 0xb0, 0x43, 0xcb, 0xb7, 0x0f, 0x2d,
-	0xd7, 0xf5, 0x22, 0x2b, 0xb2, 0x3d, 0x37, 0x14, 0x52, 0xfa, 0x0d, 0x74, 0xce, 0x22, 0x2b, 0x88,
-	0x5e, 0x58, 0xe1, 0x85, 0xc9, 0x5e, 0x2d, 0x58, 0x18, 0x11, 0x02, 0xf5, 0xc8, 0x0a, 0x2f, 0x74,
-	0x6d, 0x4f, 0xdb, 0x5f, 0x37, 0xf1, 0x9b, 0xe8, 0xb0, 0x16, 0x7a, 0x8b, 0x60, 0xcc, 0x42, 0xbd,
-	0xba, 0x57, 0xdb, 0x5f, 0x37, 0x63, 0x92, 0xf4, 0x01, 0x02, 0x36, 0xf7, 0x2e, 0xd9, 0x73, 0x16,
-	0x59, 0x7a, 0x6d, 0x4f, 0xdb, 0x6f, 0x9a, 0x0a, 0x87, 0xbe, 0x82, 0xbb, 0x8a, 0x85, 0xd0, 0xf7,
-	0xdc, 0x90, 0x91, 0x1e, 0x34, 0x02, 0x16, 0x2e, 0x9c, 0x08, 0x8d, 0x34, 0x4d, 0x49, 0x91, 0x0e,
-	0xd4, 0xe6, 0xe1, 0x4c, 0xaf, 0xa2, 0x65, 0xfe, 0x49, 0x8e, 0x52, 0xc3, 0xb5, 0xbd, 0xda, 0x7e,
-	0xeb, 0x48, 0x7f, 0xe4, 0x8f, 0x1e, 0x0d, 0xbc, 0xf9, 0xdc, 0x73, 0x7f, 0x89, 0x7e, 0xc6, 0x4a,
-	0x13, 0x48, 0xf4, 0xd7, 0x40, 0x4e, 0x7d, 0x16, 0x58, 0x11, 0x53, 0xdd, 0x32, 0xa0, 0xea, 0xf9,
-	0x68, 0xaf, 0x7d, 0x04, 0x5c, 0x09, 0x17, 0x9e, 0xfa, 0x66, 0xd5, 0xf3, 0xb9, 0xcb, 0xae, 0x35,
-	0x67, 0xd2, 0x30, 0x7e, 0xab, 0x2e, 0xd7, 0x32, 0x2e, 0xd3, 0xdf, 0x6b, 0xb0, 0x95, 0x31, 0x20,
-	0xbd, 0xba, 0xc9, 0x42, 0xea, 0x71, 0xb5, 0xcc, 0xe3, 0x5a, 0xa9, 0xc7, 0xf5, 0xff, 0xd5, 0xe3,
-	0x4f, 0xe1, 0xee, 0x4b, 0x7f, 0x92, 0x73, 0x78, 0xa5, 0x3c, 0xd2, 0x00, 0x88, 0xaa, 0xe2, 0x56,
-	0x12, 0xf5, 0x19, 0xf4, 0x7e, 0xb1, 0x60, 0xc1, 0xf5, 0x59, 0x64, 0x45, 0x8b, 0xf0, 0xc4, 0x0e,
-	0x23, 0x05, 0x3b, 0x26, 0x44, 0x2b, 0x4f, 0x48, 0x0e, 0xfb, 0x25, 0xec, 0x14, 0xf4, 0xac, 0xec,
-	0xc0, 0xe3, 0xbc, 0x03, 0x3b, 0xdc, 0x01, 0x45, 0x6f, 0x11, 0xff, 0x00, 0xb6, 0xce, 0xce, 0xbd,
-	0xab, 0xe1, 0xf0, 0xe4, 0xc4, 0x1b, 0x5f, 0x84, 0xef, 0x16, 0xf8, 0x3f, 0x6b, 0xb0, 0x26, 0x35,
-	0x90, 0x36, 0x54, 0x8f, 0x87, 0xf2, 0x77, 0xd5, 0xe3, 0x61, 0xa2, 0xa9, 0xaa, 0x68, 0x22, 0x50,
-	0x9f, 0x7b, 0x13, 0x26, 0x4b, 0x06, 0xbf, 0x49, 0x17, 0xee, 0x78, 0x57, 0x2e, 0x0b, 0xf4, 0x3a,
-	0x32, 0x05, 0xc1, 0x57, 0x0e, 0x87, 0x27, 0xa1, 0x7e, 0x07, 0x0d, 0xe2, 0x37, 0x8f, 0x47, 0x78,
-	0xed, 0x8e, 0xd9, 0x44, 0x6f, 0x20, 0x57, 0x52, 0xc4, 0x80, 0xe6, 0xc2, 0x95, 0x92, 0x35, 0x94,
-	0x24, 0x34, 0x1d, 0x43, 0x37, 0xeb, 0xe6, 0xca, 0xb1, 0x7d, 0x1f, 0xee, 0x38, 0xfc, 0xa7, 0x32,
-	0xb2, 0x2d, 0x1e, 0x59, 0xa9, 0xce, 0x14, 0x12, 0xea, 0x40, 0xf7, 0xa5, 0xcb, 0x3f, 0x63, 0xbe,
-	0x0c, 0x66, 0x3e, 0x24, 0x14, 0x36, 0x02, 0xe6, 0x3b, 0xd6, 0x98, 0x9d, 0xa2, 0xc7, 0xc2, 0x4a,
-	0x86, 0x47, 0xf6, 0xa0, 0x35, 0xf5, 0x82, 0x31, 0x33, 0xb1, 0x0d, 0xc9, 0xa6, 0xa4, 0xb2, 0xe8,
-	0xa7, 0xb0, 0x9d, 0xb3, 0xb6, 0xaa, 0x4f, 0xd4, 0x84, 0x7b, 0xb2, 0x09, 0xc4, 0xe5, 0xed, 0x58,
-	0xd7, 0x31, 0xea, 0xfb, 0x4a, 0x2b, 0x40, 0x6f, 0x51, 0x2a, 0x7b, 0xc1, 0xf2, 0x5a, 0xf8, 0x4e,
-	0x03, 0xa3, 0x4c, 0xa9, 0x04, 0x77, 0xa3, 0xd6, 0xff, 0x6f, 0x87, 0xf9, 0x4e, 0x83, 0x9d, 0x2f,
-	0x16, 0xc1, 0xac, 0xcc, 0x59, 0xc5, 0x1f, 0x2d, 0x7b, 0x38, 0x18, 0xd0, 0xb4, 0x5d, 0x6b, 0x1c,
-	0xd9, 0x97, 0x4c, 0xa2, 0x4a, 0x68, 0xac, 0x6d, 0x7b, 0x2e, 0xb2, 0x53, 0x33, 0xf1, 0x9b, 0xaf,
-	0x9f, 0xda, 0x0e, 0xc3, 0xad, 0x2f, 0x4a, 0x39, 0xa1, 0xb1, 0x72, 0x17, 0xa3, 0xa1, 0x1d, 0xe8,
-	0x77, 0x50, 0x22, 0x29, 0xfa, 0x1b, 0xd0, 0x8b, 0xc0, 0x6e, 0xa5, 0x7d, 0x7d, 0x05, 0x9d, 0xc1,
-	0x39, 0x1b, 0x5f, 0xfc, 0xb7, 0xa6, 0xdb, 0x83, 0x06, 0x0b, 0x82, 0x81, 0x2b, 0x32, 0x53, 0x33,
-	0x25, 0xc5, 0xe3, 0x76, 0x65, 0x05, 0x2e, 0x17, 0x88, 0x20, 0xc4, 0x24, 0xfd, 0x04, 0xee, 0x2a,
-	0x9a, 0x57, 0x2e, 0xcd, 0x73, 0xe8, 0xca, 0x2a, 0x3a, 0x43, 0xa8, 0x31, 0xb8, 0x5d, 0xa5, 0x7e,
-	0x36, 0xb8, 0x7f, 0x42, 0x9c, 0x16, 0xd0, 0xd8, 0x73, 0xa7, 0xf6, 0x4c, 0x56, 0xa5, 0xa4, 0x78,
-	0x52, 0x84, 0xc7, 0xc7, 0x43, 0x79, 0x12, 0x26, 0x34, 0x5d, 0xc0, 0x76, 0xce, 0xd2, 0xad, 0x44,
-	0xfe, 0x29, 0x6c, 0x9b, 0x6c, 0x66, 0xf3, 0xd1, 0x27, 0x5e, 0x72, 0xe3, 0xb9, 0x61, 0x4d, 0x26,
-	0x01, 0x0b, 0x43, 0x69, 0x36, 0x26, 0xe9, 0x13, 0xe8, 0xe5, 0xd5, 0xac, 0x1c, 0xeb, 0x9f, 0x40,
-	0xf7, 0x74, 0x3a, 0x75, 0x6c, 0x97, 0x3d, 0x67, 0xf3, 0x51, 0x06, 0x49, 0x74, 0xed, 0x27, 0x48,
-	0xf8, 0x77, 0xd9, 0x98, 0xc1, 0x3b, 0x51, 0xee, 0xf7, 0x2b, 0x43, 0xf8, 0x71, 0x92, 0xee, 0x13,
-	0x66, 0x4d, 0x52, 0x08, 0x85, 0x74, 0x0b, 0xb1, 0x48, 0x37, 0x1a, 0xce, 0xfe, 0x6a, 0x65, 0xc3,
-	0xbf, 0xd3, 0x00, 0x9e, 0xe3, 0x00, 0x7a, 0xec, 0x4e, 0xbd, 0xd2, 0xe0, 0x1b, 0xd0, 0x9c, 0xa3,
-	0x5f, 0xc7, 0x43, 0xfc, 0x65, 0xdd, 0x4c, 0x68, 0x7e, 0x6a, 0x59, 0x8e, 0x9d, 0x34, 0x68, 0x41,
-	0xf0, 0x5f, 0xf8, 0x8c, 0x05, 0x2f, 0xcd, 0x13, 0xd1, 0x9e, 0xd6, 0xcd, 0x84, 0xe6, 0xc3, 0xe6,
-	0xd8, 0xb1, 0x99, 0x1b, 0xa1, 0x54, 0x9c, 0x6b, 0x0a, 0x87, 0x8e, 0x00, 0x44, 0x22, 0x97, 0xe2,
-	0x21, 0x50, 0xe7, 0xd9, 0x8f, 0x53, 0xc0, 0xbf, 0x39, 0x8e, 0x30, 0xb2, 0x66, 0xf1, 0x91, 0x2a,
-	0x08, 0xec, 0x37, 0x58, 0x6e, 0xb2, 0x13, 0x49, 0x8a, 0x9e, 0x40, 0x87, 0x4f, 0x18, 0x22, 0x68,
-	0x22, 0x67, 0x71, 0x68, 0xb4, 0xb4, 0xaa, 0xcb, 0x26, 0xca, 0xd8, 0x76, 0x2d, 0xb5, 0x4d, 0x7f,
-	0x2e, 0xb4, 0x89, 0x28, 0x2e, 0xd5, 0xb6, 0x0f, 0x6b, 0x62, 0xd0, 0x17, 0x27, 0x46, 0xeb, 0xa8,
-	0xcd, 0xd3, 0x99, 0x86, 0xde, 0x8c, 0xc5, 0xb1, 0x3e, 0x11, 0x85, 0x9b, 0xf4, 0x89, 0x4b, 0x42,
-	0x46, 0x5f, 0x1a, 0x3a, 0x33, 0x16, 0xd3, 0xbf, 0x68, 0xb0, 0x26, 0xd4, 0x84, 0xe4, 0x11, 0x34,
-	0x1c, 0xf4, 0x1a, 0x55, 0xb5, 0x8e, 0xba, 0x58, 0x53, 0xb9, 0x58, 0x7c, 0x5e, 0x31, 0xe5, 0x2a,
-	0xbe, 0x5e, 0xc0, 0xc2, 0x28, 0x28, 0xeb, 0x55, 0x6f, 0xf9, 0x7a, 0xb1, 0x8a, 0xaf, 0x17, 0x66,
-	0x31, 0x42, 0xca, 0x7a, 0xd5, 0x1b, 0xbe, 0x5e, 0xac, 0x7a, 0xd2, 0x84, 0x86, 0xa8, 0x25, 0x7e,
-	0xc9, 0x40, 0xbd, 0x99, 0x1d, 0xd8, 0xcb, 0xc0, 0x6d, 0x26, 0xb0, 0x7a, 0x19, 0x58, 0xcd, 0xc4,
-	0x7c, 0x2f, 0x63, 0xbe, 0x19, 0x9b, 0xe1, 0xe5, 0xc1, 0xd3, 0x17, 0x57, 0xa3, 0x20, 0x28, 0x03,
-	0xa2, 0x9a, 0x5c, 0xb9, 0xed, 0x7d, 0x08, 0x6b, 0x02, 0x7c, 0x66, 0x28, 0x92, 0xa1, 0x36, 0x63,
-	0x19, 0xfd, 0x87, 0x96, 0xf6, 0xf2, 0xf1, 0x39, 0x9b, 0x5b, 0xcb, 0x7b, 0x39, 0x8a, 0xd3, 0x0b,
-	0x4d, 0x61, 0x70, 0x5c, 0x7a, 0xa1, 0xe1, 0x5b, 0x6e, 0x62, 0x45, 0xd6, 0xc8, 0x0a, 0x93, 0x63,
-	0x37, 0xa6, 0xb9, 0xf7, 0x91, 0x35, 0x72, 0x98, 0x3c, 0x75, 0x05, 0x81, 0x9b, 0x03, 0xed, 0xe9,
-	0x0d, 0xb9, 0x39, 0x90, 0xe2, 0xab, 0xa7, 0xce, 0x22, 0x3c, 0xd7, 0xd7, 0xc4, 0x96, 0x46, 0x82,
-	0xa3, 0xe1, 0xa3, 0xa4, 0xde, 0x44, 0x26, 0x7e, 0xab, 0x27, 0x87, 0xf4, 0xeb, 0x56, 0x4e, 0x8e,
-	0x03, 0xe8, 0x3e, 0x63, 0xd1, 0xd9, 0x62, 0xc4, 0x8f, 0xd6, 0xc1, 0x74, 0x76, 0xc3, 0xc1, 0x41,
-	0x5f, 0xc2, 0x76, 0x6e, 0xed, 0xca, 0x10, 0x09, 0xd4, 0xc7, 0xd3, 0x59, 0x1c, 0x70, 0xfc, 0xa6,
-	0x43, 0xd8, 0x7c, 0xc6, 0x22, 0xc5, 0xf6, 0x43, 0xe5, 0xa8, 0x90, 0x83, 0xdd, 0x60, 0x3a, 0x7b,
-	0x71, 0xed, 0xb3, 0x1b, 0xce, 0x8d, 0x13, 0x68, 0xc7, 0x5a, 0x56, 0x46, 0xd5, 0x81, 0xda, 0x78,
-	0x9a, 0x8c, 0x84, 0xe3, 0xe9, 0x8c, 0x6e, 0xc3, 0xd6, 0x33, 0x26, 0xf7, 0x65, 0x8a, 0x8c, 0xee,
-	0x63, 0xb4, 0x14, 0xb6, 0x34, 0x25, 0x15, 0x68, 0xa9, 0x82, 0x3f, 0x6a, 0x40, 0x3e, 0xb7, 0xdc,
-	0x89, 0xc3, 0x9e, 0x06, 0x81, 0x17, 0x2c, 0x9d, 0x83, 0x51, 0xfa, 0x4e, 0x45, 0xba, 0x0b, 0xeb,
-	0x23, 0xdb, 0x75, 0xbc, 0xd9, 0x17, 0x5e, 0x28, 0xab, 0x34, 0x65, 0x60, 0x89, 0xbd, 0x72, 0x92,
-	0xbb, 0x0e, 0xff, 0xa6, 0x21, 0x6c, 0x65, 0x20, 0xdd, 0x4a, 0x81, 0x3d, 0x83, 0xed, 0x17, 0x81,
-	0xe5, 0x86, 0x53, 0x16, 0x64, 0x87, 0xaf, 0xf4, 0x3c, 0xd1, 0xd4, 0xf3, 0x44, 0x69, 0x3b, 0xc2,
-	0xb2, 0xa4, 0xf8, 0x70, 0x92, 0x57, 0xb4, 0xf2, 0x01, 0x3d, 0x49, 0x1e, 0x2a, 0x32, 0x03, 0xfb,
-	0x03, 0x25, 0x2b, 0x9b, 0xca, 0x3d, 0xe2, 0xcb, 0xa3, 0x78, 0x10, 0x94, 0x48, 0xab, 0x4b, 0x90,
-	0x8a, 0xd4, 0xc4, 0x48, 0x7f, 0x9a, 0xb4, 0xa8, 0x77, 0x9c, 0xbe, 0x0f, 0x46, 0xd0, 0x8c, 0x47,
-	0x51, 0xb2, 0x05, 0xef, 0x1d, 0xbb, 0x97, 0x96, 0x63, 0x4f, 0x62, 0x56, 0xa7, 0x42, 0xde, 0x83,
-	0x16, 0xbe, 0x22, 0x09, 0x56, 0x47, 0x23, 0x1d, 0xd8, 0x10, 0xcf, 0x15, 0x92, 0x53, 0x25, 0x6d,
-	0x80, 0xb3, 0xc8, 0xf3, 0x25, 0x5d, 0x43, 0xfa, 0xdc, 0xbb, 0x92, 0x74, 0xfd, 0xe0, 0x67, 0xd0,
-	0x8c, 0xe7, 0x1f, 0xc5, 0x46, 0xcc, 0xea, 0x54, 0xc8, 0x5d, 0xd8, 0x7c, 0x7a, 0x69, 0x8f, 0xa3,
-	0x84, 0xa5, 0x91, 0x1d, 0xd8, 0x1a, 0x58, 0xee, 0x98, 0x39, 0x59, 0x41, 0xf5, 0xe0, 0x2b, 0x58,
-	0x93, 0x5b, 0x94, 0x43, 0x93, 0xba, 0x38, 0xd9, 0xa9, 0x90, 0x0d, 0x68, 0xf2, 0x86, 0x81, 0x94,
-	0xc6, 0x61, 0x88, 0xfd, 0x83, 0x34, 0xc2, 0x14, 0xa5, 0x83, 0xb4, 0x80, 0x89, 0x10, 0x91, 0xae,
-	0x1f, 0x0c, 0x61, 0x3d, 0xc9, 0x06, 0xe9, 0x42, 0x47, 0xea, 0x4e, 0x78, 0x9d, 0x0a, 0xf7, 0x1d,
-	0x83, 0x81, 0xbc, 0x2f, 0x8f, 0x3a, 0x9a, 0x08, 0x8f, 0xe7, 0xc7, 0x8c, 0xea, 0xd1, 0x5f, 0xdb,
-	0xd0, 0x10, 0x66, 0xc9, 0xd7, 0xb0, 0x9e, 0x3c, 0xc0, 0x11, 0x3c, 0x52, 0xf3, 0x2f, 0x7e, 0xc6,
-	0x76, 0x8e, 0x2b, 0xf2, 0x47, 0x1f, 0xfe, 0xf6, 0xef, 0xff, 0xfe, 0x53, 0xf5, 0x1e, 0xed, 0x1e,
-	0x5a, 0xbe, 0x1d, 0x1e, 0x5e, 0x3e, 0xb6, 0x1c, 0xff, 0xdc, 0x7a, 0x7c, 0xc8, 0x37, 0x6a, 0xf8,
-	0xb1, 0x76, 0x40, 0xa6, 0xd0, 0x52, 0xde, 0xc1, 0x48, 0x8f, 0xab, 0x29, 0xbe, 0xbc, 0x19, 0x3b,
-	0x05, 0xbe, 0x34, 0xf0, 0x11, 0x1a, 0xd8, 0x33, 0xee, 0x97, 0x19, 0x38, 0x7c, 0xcd, 0xfb, 0xdc,
-	0xb7, 0xdc, 0xce, 0x27, 0x00, 0xe9, 0xdb, 0x14, 0x41, 0xb4, 0x85, 0xe7, 0x2e, 0xa3, 0x97, 0x67,
-	0x4b, 0x23, 0x15, 0xe2, 0x40, 0x4b, 0x79, 0xc6, 0x21, 0x46, 0xee, 0x5d, 0x47, 0x79, 0x77, 0x32,
-	0xee, 0x97, 0xca, 0xa4, 0xa6, 0x0f, 0x10, 0x6e, 0x9f, 0xec, 0xe6, 0xe0, 0x86, 0xb8, 0x54, 0xe2,
-	0x25, 0x03, 0xd8, 0x50, 0x5f, 0x4b, 0x08, 0x7a, 0x5f, 0xf2, 0x4c, 0x64, 0xe8, 0x45, 0x41, 0x02,
-	0xf9, 0x33, 0xd8, 0xcc, 0xbc, 0x4f, 0x10, 0x5c, 0x5c, 0xf6, 0x40, 0x62, 0xdc, 0x2b, 0x91, 0x24,
-	0x7a, 0xbe, 0x86, 0x5e, 0xf1, 0x3d, 0x01, 0xa3, 0xf8, 0x40, 0x49, 0x4a, 0xf1, 0x4e, 0x6f, 0xf4,
-	0x97, 0x89, 0x13, 0xd5, 0xa7, 0xd0, 0xc9, 0xdf, 0xbb, 0x09, 0x86, 0x6f, 0xc9, 0x33, 0x81, 0xb1,
-	0x5b, 0x2e, 0x4c, 0x14, 0x7e, 0x0c, 0xeb, 0xc9, 0xa5, 0x57, 0x14, 0x6a, 0xfe, 0x76, 0x2d, 0x0a,
-	0xb5, 0x70, 0x33, 0xa6, 0x15, 0x32, 0x83, 0xcd, 0xcc, 0x3d, 0x54, 0xc4, 0xab, 0xec, 0x12, 0x2c,
-	0xe2, 0x55, 0x7a, 0x69, 0xa5, 0xef, 0x63, 0x82, 0xef, 0x1b, 0xbd, 0x7c, 0x82, 0x45, 0x6f, 0xe7,
-	0xa5, 0x78, 0x0c, 0xed, 0xec, 0x95, 0x91, 0xdc, 0x13, 0x0d, 0xb4, 0xe4, 0x36, 0x6a, 0x18, 0x65,
-	0xa2, 0x04, 0x73, 0x00, 0x9b, 0x99, 0x9b, 0x9f, 0xc4, 0x5c, 0x72, 0x99, 0x94, 0x98, 0xcb, 0xae,
-	0x89, 0xf4, 0x87, 0x88, 0xf9, 0xa3, 0x83, 0x0f, 0x72, 0x98, 0xe5, 0x00, 0x79, 0xf8, 0x9a, 0x4f,
-	0x10, 0xdf, 0xc6, 0xc5, 0x79, 0x91, 0xc4, 0x49, 0x34, 0xb3, 0x4c, 0x9c, 0x32, 0xb7, 0xc7, 0x4c,
-	0x9c, 0xb2, 0x37, 0x44, 0xfa, 0x21, 0xda, 0x7c, 0x68, 0x18, 0x39, 0x9b, 0x62, 0xc0, 0x3e, 0x7c,
-	0xed, 0xf9, 0xb8, 0x6d, 0x7f, 0x05, 0x90, 0x8e, 0xc8, 0x62, 0xdb, 0x16, 0xa6, 0x74, 0xb1, 0x6d,
-	0x8b, 0x93, 0x34, 0xed, 0xa3, 0x0d, 0x9d, 0xf4, 0xca, 0xfd, 0x22, 0xd3, 0x34, 0xe3, 0x62, 0xf4,
-	0xcc, 0x64, 0x5c, 0x1d, 0x95, 0xb3, 0x19, 0xcf, 0x0c, 0x9b, 0x74, 0x0f, 0xad, 0x18, 0xc6, 0x76,
-	0x3e, 0xe3, 0xb8, 0x8c, 0x3b, 0xe1, 0xe0, 0xb4, 0x96, 0x0e, 0x81, 0xc2, 0x4e, 0xd9, 0x0c, 0x29,
-	0xec, 0x94, 0x4e, 0x8c, 0x71, 0xa7, 0x23, 0xfd, 0xbc, 0x9d, 0xc5, 0x48, 0x6d, 0x76, 0xe4, 0x05,
-	0x34, 0xc4, 0x54, 0x47, 0xee, 0x4a, 0x65, 0x8a, 0x7e, 0xa2, 0xb2, 0xa4, 0xe2, 0x1f, 0xa0, 0xe2,
-	0x07, 0xe4, 0xa6, 0x16, 0x4a, 0xbe, 0x81, 0x96, 0x32, 0x08, 0x89, 0x3e, 0x5d, 0x1c, 0xd6, 0x44,
-	0x9f, 0x2e, 0x99, 0x98, 0x96, 0x46, 0x89, 0xf1, 0x55, 0xb8, 0x2d, 0x06, 0xb0, 0xa1, 0x0e, 0x8a,
-	0xa2, 0xe9, 0x95, 0x4c, 0x94, 0x86, 0x5e, 0x14, 0x24, 0x1b, 0xe2, 0x18, 0xda, 0xd9, 0x89, 0x47,
-	0xec, 0xad, 0xd2, 0x71, 0x4a, 0xec, 0xad, 0xf2, 0x01, 0x89, 0x56, 0x38, 0x1e, 0x75, 0x24, 0x21,
-	0xea, 0x11, 0x94, 0x69, 0x4a, 0x7a, 0x51, 0x10, 0x2b, 0x79, 0xa2, 0xff, 0xed, 0x4d, 0x5f, 0xfb,
-	0xfe, 0x4d, 0x5f, 0xfb, 0xd7, 0x9b, 0xbe, 0xf6, 0x87, 0xb7, 0xfd, 0xca, 0xf7, 0x6f, 0xfb, 0x95,
-	0x7f, 0xbe, 0xed, 0x57, 0x46, 0x0d, 0xfc, 0xf7, 0xec, 0x47, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff,
-	0x8d, 0x0a, 0x25, 0x8c, 0x81, 0x1b, 0x00, 0x00,
+	// 2039 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x59, 0x4f, 0x6f, 0xe3, 0xc6,
+	0x15, 0x17, 0x25, 0xad, 0x2c, 0x3f, 0xd9, 0x8a, 0x3c, 0x96, 0x64, 0x6a, 0xd6, 0xab, 0x75, 0xd8,
+	0x24, 0x30, 0x8c, 0x62, 0x8d, 0x75, 0x7b, 0x0a, 0x90, 0xa2, 0x59, 0x69, 0xb3, 0x31, 0xea, 0xad,
+	0x53, 0xda, 0x4e, 0x1b, 0x14, 0x28, 0x42, 0x51, 0x23, 0x59, 0x30, 0x45, 0x72, 0x49, 0xca, 0xae,
+	0xb1, 0xc8, 0xa5, 0x1f, 0xa0, 0x7f, 0xd0, 0x43, 0x8e, 0x3d, 0xf4, 0x9b, 0xf4, 0xd4, 0x63, 0x80,
+	0x02, 0x45, 0x8f, 0xc5, 0x6e, 0x3f, 0x48, 0x31, 0x6f, 0x86, 0xd4, 0x90, 0xa2, 0x9c, 0x6a, 0x81,
+	0xfa, 0xc6, 0xf7, 0xde, 0xe8, 0xfd, 0xde, 0xbf, 0x79, 0xf3, 0x66, 0x04, 0xf5, 0xe1, 0x74, 0x6a,
+	0x85, 0x11, 0x0b, 0x9e, 0xf8, 0x81, 0x17, 0x79, 0xa4, 0xe8, 0x0f, 0x68, 0x7d, 0x38, 0xbd, 0xf1,
+	0x82, 0xab, 0x98, 0x47, 0x77, 0xc7, 0x9e, 0x37, 0x76, 0xd8, 0xa1, 0xe5, 0x4f, 0x0e, 0x2d, 0xd7,
+	0xf5, 0x22, 0x2b, 0x9a, 0x78, 0x6e, 0x28, 0xa4, 0xc6, 0xd7, 0xd0, 0x38, 0x8b, 0xac, 0x20, 0x3a,
+	0xb7, 0xc2, 0x2b, 0x93, 0xbd, 0x9a, 0xb1, 0x30, 0x22, 0x04, 0xca, 0x91, 0x15, 0x5e, 0xe9, 0xda,
+	0x9e, 0xb6, 0xbf, 0x6e, 0xe2, 0x37, 0xd1, 0x61, 0x2d, 0xf4, 0x66, 0x81, 0xcd, 0x42, 0xbd, 0xb8,
+	0x57, 0xda, 0x5f, 0x37, 0x63, 0x92, 0x74, 0x01, 0x02, 0x36, 0xf5, 0xae, 0xd9, 0x4b, 0x16, 0x59,
+	0x7a, 0x69, 0x4f, 0xdb, 0xaf, 0x9a, 0x0a, 0xc7, 0x78, 0x05, 0x5b, 0x0a, 0x42, 0xe8, 0x7b, 0x6e,
+	0xc8, 0x48, 0x1b, 0x2a, 0x01, 0x0b, 0x67, 0x4e, 0x84, 0x20, 0x55, 0x53, 0x52, 0xa4, 0x01, 0xa5,
+	0x69, 0x38, 0xd6, 0x8b, 0x88, 0xcc, 0x3f, 0xc9, 0xd1, 0x1c, 0xb8, 0xb4, 0x57, 0xda, 0xaf, 0x1d,
+	0xe9, 0x4f, 0xfc, 0xc1, 0x93, 0x9e, 0x37, 0x9d, 0x7a, 0xee, 0x2f, 0xd1, 0xcf, 0x58, 0x69, 0x62,
+	0x92, 0xf1, 0x1b, 0x20, 0xa7, 0x3e, 0x0b, 0xac, 0x88, 0xa9, 0x6e, 0x51, 0x28, 0x7a, 0x3e, 0xe2,
+	0xd5, 0x8f, 0x80, 0x2b, 0xe1, 0xc2, 0x53, 0xdf, 0x2c, 0x7a, 0x3e, 0x77, 0xd9, 0xb5, 0xa6, 0x4c,
+	0x02, 0xe3, 0x37, 0xd1, 0xd3, 0xc8, 0x73, 0x97, 0x8d, 0x3f, 0x68, 0xb0, 0x9d, 0x02, 0x90, 0x5e,
+	0xdd, 0x85, 0x30, 0xf7, 0xb8, 0x98, 0xe7, 0x71, 0x29, 0xd7, 0xe3, 0xf2, 0xff, 0xea, 0xf1, 0xa7,
+	0xb0, 0x75, 0xe1, 0x0f, 0x33, 0x0e, 0xaf, 0x94, 0x47, 0x23, 0x00, 0xa2, 0xaa, 0xb8, 0x97, 0x44,
+	0x7d, 0x06, 0xed, 0x5f, 0xcc, 0x58, 0x70, 0x7b, 0x16, 0x59, 0xd1, 0x2c, 0x3c, 0x99, 0x84, 0x91,
+	0x62, 0x3b, 0x26, 0x44, 0xcb, 0x4f, 0x48, 0xc6, 0xf6, 0x6b, 0xd8, 0x59, 0xd0, 0xb3, 0xb2, 0x03,
+	0x4f, 0xb3, 0x0e, 0xec, 0x70, 0x07, 0x14, 0xbd, 0x8b, 0xf6, 0xf7, 0x60, 0xfb, 0xec, 0xd2, 0xbb,
+	0xe9, 0xf7, 0x4f, 0x4e, 0x3c, 0xfb, 0x2a, 0x7c, 0xb7, 0xc0, 0xff, 0x45, 0x83, 0x35, 0xa9, 0x81,
+	0xd4, 0xa1, 0x78, 0xdc, 0x97, 0xbf, 0x2b, 0x1e, 0xf7, 0x13, 0x4d, 0x45, 0x45, 0x13, 0x81, 0xf2,
+	0xd4, 0x1b, 0x32, 0x59, 0x32, 0xf8, 0x4d, 0x9a, 0xf0, 0xc0, 0xbb, 0x71, 0x59, 0xa0, 0x97, 0x91,
+	0x29, 0x08, 0xbe, 0xb2, 0xdf, 0x3f, 0x09, 0xf5, 0x07, 0x08, 0x88, 0xdf, 0x3c, 0x1e, 0xe1, 0xad,
+	0x6b, 0xb3, 0xa1, 0x5e, 0x41, 0xae, 0xa4, 0x08, 0x85, 0xea, 0xcc, 0x95, 0x92, 0x35, 0x94, 0x24,
+	0xb4, 0x61, 0x43, 0x33, 0xed, 0xe6, 0xca, 0xb1, 0x7d, 0x1f, 0x1e, 0x38, 0xfc, 0xa7, 0x32, 0xb2,
+	0x35, 0x1e, 0x59, 0xa9, 0xce, 0x14, 0x12, 0xc3, 0x81, 0xe6, 0x85, 0xcb, 0x3f, 0x63, 0xbe, 0x0c,
+	0x66, 0x36, 0x24, 0x06, 0x6c, 0x04, 0xcc, 0x77, 0x2c, 0x9b, 0x9d, 0xa2, 0xc7, 0x02, 0x25, 0xc5,
+	0x23, 0x7b, 0x50, 0x1b, 0x79, 0x81, 0xcd, 0x4c, 0x6c, 0x43, 0xb2, 0x29, 0xa9, 0x2c, 0xe3, 0x53,
+	0x68, 0x65, 0xd0, 0x56, 0xf5, 0xc9, 0x30, 0xa1, 0x23, 0x9b, 0x40, 0x5c, 0xde, 0x8e, 0x75, 0x1b,
+	0x5b, 0xfd, 0x50, 0x69, 0x05, 0xe8, 0x2d, 0x4a, 0x65, 0x2f, 0x58, 0x5e, 0x0b, 0xdf, 0x6a, 0x40,
+	0xf3, 0x94, 0x4a, 0xe3, 0xee, 0xd4, 0xfa, 0xff, 0xed, 0x30, 0xdf, 0x6a, 0xb0, 0xf3, 0xc5, 0x2c,
+	0x18, 0xe7, 0x39, 0xab, 0xf8, 0xa3, 0xa5, 0x0f, 0x07, 0x0a, 0xd5, 0x89, 0x6b, 0xd9, 0xd1, 0xe4,
+	0x9a, 0x49, 0xab, 0x12, 0x1a, 0x6b, 0x7b, 0x32, 0x15, 0xd9, 0x29, 0x99, 0xf8, 0xcd, 0xd7, 0x8f,
+	0x26, 0x0e, 0xc3, 0xad, 0x2f, 0x4a, 0x39, 0xa1, 0xb1, 0x72, 0x67, 0x83, 0xfe, 0x24, 0xd0, 0x1f,
+	0xa0, 0x44, 0x52, 0xc6, 0x6f, 0x41, 0x5f, 0x34, 0xec, 0x5e, 0xda, 0x57, 0x00, 0x8d, 0xde, 0x25,
+	0xb3, 0xaf, 0xbe, 0xaf, 0xe9, 0xb6, 0xa1, 0xc2, 0x82, 0xa0, 0xe7, 0x8a, 0xcc, 0x94, 0x4c, 0x49,
+	0xf1, 0xb8, 0xdd, 0x58, 0x81, 0xcb, 0x05, 0x22, 0x08, 0x31, 0xc9, 0x25, 0xb6, 0xe7, 0xba, 0xcc,
+	0x8e, 0x30, 0x0c, 0x55, 0x33, 0x26, 0x8d, 0x4f, 0x60, 0x4b, 0xc1, 0x5c, 0xb9, 0x68, 0x2f, 0xa1,
+	0x29, 0xeb, 0xeb, 0x0c, 0x9d, 0x88, 0xcd, 0xde, 0x55, 0x2a, 0x6b, 0x83, 0x7b, 0x2e, 0xc4, 0xf3,
+	0xd2, 0xb2, 0x3d, 0x77, 0x34, 0x19, 0xcb, 0x7a, 0x95, 0x14, 0x4f, 0x97, 0x88, 0xc5, 0x71, 0x5f,
+	0x9e, 0x91, 0x09, 0x6d, 0xcc, 0xa0, 0x95, 0x41, 0xba, 0x97, 0x9c, 0x3c, 0x87, 0x96, 0xc9, 0xc6,
+	0x93, 0x30, 0x62, 0x41, 0xbc, 0xe4, 0xce, 0x13, 0xc5, 0x1a, 0x0e, 0x03, 0x16, 0x86, 0x12, 0x36,
+	0x26, 0x8d, 0x67, 0xd0, 0xce, 0xaa, 0x59, 0x39, 0xd6, 0x3f, 0x81, 0xe6, 0xe9, 0x68, 0xe4, 0x4c,
+	0x5c, 0xf6, 0x92, 0x4d, 0x07, 0x29, 0x4b, 0xa2, 0x5b, 0x3f, 0xb1, 0x84, 0x7f, 0xe7, 0x0d, 0x20,
+	0xbc, 0x47, 0x65, 0x7e, 0xbf, 0xb2, 0x09, 0x3f, 0x4e, 0xd2, 0x7d, 0xc2, 0xac, 0x21, 0x0b, 0x96,
+	0xa6, 0x5b, 0x88, 0x45, 0xba, 0x11, 0x38, 0xfd, 0xab, 0x95, 0x81, 0x7f, 0xaf, 0x01, 0xbc, 0xc4,
+	0xd1, 0xf4, 0xd8, 0x1d, 0x79, 0xb9, 0xc1, 0xa7, 0x50, 0x9d, 0xa2, 0x5f, 0xc7, 0x7d, 0xfc, 0x65,
+	0xd9, 0x4c, 0x68, 0x7e, 0x9e, 0x59, 0xce, 0x24, 0x69, 0xdd, 0x82, 0xe0, 0xbf, 0xf0, 0x19, 0x0b,
+	0x2e, 0xcc, 0x13, 0xd1, 0xb8, 0xd6, 0xcd, 0x84, 0xe6, 0x63, 0xa8, 0xed, 0x4c, 0x98, 0x1b, 0x5d,
+	0x98, 0xc9, 0x89, 0xa7, 0x70, 0x8c, 0x01, 0x80, 0x48, 0xe4, 0x52, 0x7b, 0x08, 0x94, 0x79, 0xf6,
+	0xe3, 0x14, 0xf0, 0x6f, 0x6e, 0x47, 0x18, 0x59, 0xe3, 0xf8, 0xb0, 0x15, 0x04, 0x76, 0x22, 0x2c,
+	0x37, 0xd9, 0xa3, 0x24, 0x65, 0x9c, 0x40, 0x83, 0xcf, 0x1e, 0x22, 0x68, 0x22, 0x67, 0x71, 0x68,
+	0xb4, 0x79, 0x55, 0xe7, 0xcd, 0x9a, 0x31, 0x76, 0x69, 0x8e, 0x6d, 0xfc, 0x5c, 0x68, 0x13, 0x51,
+	0x5c, 0xaa, 0x6d, 0x1f, 0xd6, 0xc4, 0x15, 0x40, 0x9c, 0x25, 0xb5, 0xa3, 0x3a, 0x4f, 0xe7, 0x3c,
+	0xf4, 0x66, 0x2c, 0x8e, 0xf5, 0x89, 0x28, 0xdc, 0xa5, 0x4f, 0x5c, 0x1f, 0x52, 0xfa, 0xe6, 0xa1,
+	0x33, 0x63, 0xb1, 0xf1, 0x57, 0x0d, 0xd6, 0x84, 0x9a, 0x90, 0x3c, 0x81, 0x8a, 0x83, 0x5e, 0xa3,
+	0xaa, 0xda, 0x51, 0x13, 0x6b, 0x2a, 0x13, 0x8b, 0xcf, 0x0b, 0xa6, 0x5c, 0xc5, 0xd7, 0x0b, 0xb3,
+	0xf4, 0x62, 0x7a, 0xbd, 0xea, 0x2d, 0x5f, 0x2f, 0x56, 0xf1, 0xf5, 0x02, 0x56, 0x2f, 0xa5, 0xd7,
+	0xab, 0xde, 0xf0, 0xf5, 0x62, 0xd5, 0xb3, 0x2a, 0x54, 0x44, 0x2d, 0xf1, 0xeb, 0x07, 0xea, 0x4d,
+	0xed, 0xc0, 0x76, 0xca, 0xdc, 0x6a, 0x62, 0x56, 0x3b, 0x65, 0x56, 0x35, 0x81, 0x6f, 0xa7, 0xe0,
+	0xab, 0x31, 0x0c, 0x2f, 0x0f, 0x9e, 0xbe, 0xb8, 0x1a, 0x05, 0x61, 0x30, 0x20, 0x2a, 0xe4, 0xca,
+	0x6d, 0xef, 0x43, 0x58, 0x13, 0xc6, 0xa7, 0xc6, 0x25, 0x19, 0x6a, 0x33, 0x96, 0x19, 0xff, 0xd4,
+	0xe6, 0xbd, 0xdc, 0xbe, 0x64, 0x53, 0x6b, 0x79, 0x2f, 0x47, 0xf1, 0xfc, 0xaa, 0xb3, 0x30, 0x52,
+	0x2e, 0xbd, 0xea, 0xf0, 0x2d, 0x37, 0xb4, 0x22, 0x6b, 0x60, 0x85, 0xc9, 0x81, 0x1c, 0xd3, 0xdc,
+	0xfb, 0xc8, 0x1a, 0x38, 0x4c, 0x9e, 0xc7, 0x82, 0xc0, 0xcd, 0x81, 0x78, 0x7a, 0x45, 0x6e, 0x0e,
+	0xa4, 0xf8, 0xea, 0x91, 0x33, 0x0b, 0x2f, 0xf5, 0x35, 0xb1, 0xa5, 0x91, 0xe0, 0xd6, 0xf0, 0x21,
+	0x53, 0xaf, 0x22, 0x13, 0xbf, 0xd5, 0x93, 0x43, 0xfa, 0x75, 0x2f, 0x27, 0xc7, 0x01, 0x34, 0x5f,
+	0xb0, 0xe8, 0x6c, 0x36, 0xe0, 0x47, 0x6b, 0x6f, 0x34, 0xbe, 0xe3, 0xe0, 0x30, 0x2e, 0xa0, 0x95,
+	0x59, 0xbb, 0xb2, 0x89, 0x04, 0xca, 0xf6, 0x68, 0x1c, 0x07, 0x1c, 0xbf, 0x8d, 0x3e, 0x6c, 0xbe,
+	0x60, 0x91, 0x82, 0xfd, 0x58, 0x39, 0x2a, 0xe4, 0xc8, 0xd7, 0x1b, 0x8d, 0xcf, 0x6f, 0x7d, 0x76,
+	0xc7, 0xb9, 0x71, 0x02, 0xf5, 0x58, 0xcb, 0xca, 0x56, 0x35, 0xa0, 0x64, 0x8f, 0x92, 0x61, 0xd1,
+	0x1e, 0x8d, 0x8d, 0x16, 0x6c, 0xbf, 0x60, 0x72, 0x5f, 0xce, 0x2d, 0x33, 0xf6, 0xa1, 0x99, 0x66,
+	0x4b, 0x28, 0xa9, 0x40, 0x9b, 0x2b, 0xf8, 0x93, 0x06, 0xe4, 0x73, 0xcb, 0x1d, 0x3a, 0xec, 0x79,
+	0x10, 0x78, 0xc1, 0xd2, 0x09, 0x19, 0xa5, 0xef, 0x54, 0xa4, 0xbb, 0xb0, 0x3e, 0x98, 0xb8, 0x8e,
+	0x37, 0xfe, 0xc2, 0x0b, 0x65, 0x95, 0xce, 0x19, 0x58, 0x62, 0xaf, 0x9c, 0xe4, 0x16, 0xc4, 0xbf,
+	0x8d, 0x10, 0xb6, 0x53, 0x26, 0xdd, 0x4b, 0x81, 0xbd, 0x80, 0xd6, 0x79, 0x60, 0xb9, 0xe1, 0x88,
+	0x05, 0xe9, 0xe1, 0x6b, 0x7e, 0x9e, 0x68, 0xea, 0x79, 0xa2, 0xb4, 0x1d, 0x81, 0x2c, 0x29, 0x3e,
+	0x9c, 0x64, 0x15, 0xad, 0x7c, 0x40, 0x0f, 0x93, 0x27, 0x8c, 0xd4, 0x28, 0xff, 0x48, 0xc9, 0xca,
+	0xa6, 0x72, 0xc3, 0xf8, 0xf2, 0x28, 0x1e, 0x04, 0xa5, 0xa5, 0xc5, 0x25, 0x96, 0x8a, 0xd4, 0xc4,
+	0x96, 0xfe, 0x34, 0x69, 0x51, 0xef, 0x38, 0x97, 0x1f, 0x0c, 0xa0, 0x1a, 0x8f, 0xa2, 0x64, 0x1b,
+	0xde, 0x3b, 0x76, 0xaf, 0x2d, 0x67, 0x32, 0x8c, 0x59, 0x8d, 0x02, 0x79, 0x0f, 0x6a, 0xf8, 0xbe,
+	0x24, 0x58, 0x0d, 0x8d, 0x34, 0x60, 0x43, 0x3c, 0x64, 0x48, 0x4e, 0x91, 0xd4, 0x01, 0xce, 0x22,
+	0xcf, 0x97, 0x74, 0x09, 0xe9, 0x4b, 0xef, 0x46, 0xd2, 0xe5, 0x83, 0x9f, 0x41, 0x35, 0x9e, 0x7f,
+	0x14, 0x8c, 0x98, 0xd5, 0x28, 0x90, 0x2d, 0xd8, 0x7c, 0x7e, 0x3d, 0xb1, 0xa3, 0x84, 0xa5, 0x91,
+	0x1d, 0xd8, 0xee, 0x59, 0xae, 0xcd, 0x9c, 0xb4, 0xa0, 0x78, 0xf0, 0x2b, 0x58, 0x93, 0x5b, 0x94,
+	0x9b, 0x26, 0x75, 0x71, 0xb2, 0x51, 0x20, 0x1b, 0x50, 0xe5, 0x0d, 0x03, 0x29, 0x8d, 0x9b, 0x21,
+	0xf6, 0x0f, 0xd2, 0x68, 0xa6, 0x28, 0x1d, 0xa4, 0x85, 0x99, 0x68, 0x22, 0xd2, 0xe5, 0x83, 0x3e,
+	0xac, 0x27, 0xd9, 0x20, 0x4d, 0x68, 0x48, 0xdd, 0x09, 0xaf, 0x51, 0xe0, 0xbe, 0x63, 0x30, 0x90,
+	0xf7, 0xe5, 0x51, 0x43, 0x13, 0xe1, 0xf1, 0xfc, 0x98, 0x51, 0x3c, 0xfa, 0x5b, 0x1d, 0x2a, 0x02,
+	0x96, 0x7c, 0x05, 0xeb, 0xc9, 0xd3, 0x1c, 0xc1, 0x23, 0x35, 0xfb, 0x16, 0x48, 0x5b, 0x19, 0xae,
+	0xc8, 0x9f, 0xf1, 0xf8, 0x77, 0xff, 0xf8, 0xcf, 0x9f, 0x8b, 0x1d, 0xa3, 0xc9, 0x9f, 0x15, 0xc3,
+	0xc3, 0xeb, 0xa7, 0x96, 0xe3, 0x5f, 0x5a, 0x4f, 0x0f, 0xf9, 0x46, 0x0d, 0x3f, 0xd6, 0x0e, 0xc8,
+	0x08, 0x6a, 0xca, 0x0b, 0x19, 0x69, 0x73, 0x35, 0x8b, 0x6f, 0x72, 0x74, 0x67, 0x81, 0x2f, 0x01,
+	0x3e, 0x42, 0x80, 0x3d, 0xfa, 0x30, 0x0f, 0xe0, 0xf0, 0x35, 0xef, 0x73, 0xdf, 0x70, 0x9c, 0x4f,
+	0x00, 0xe6, 0xaf, 0x56, 0x04, 0xad, 0x5d, 0x78, 0x08, 0xa3, 0xed, 0x2c, 0x5b, 0x82, 0x14, 0x88,
+	0x03, 0x35, 0xe5, 0x81, 0x87, 0xd0, 0xcc, 0x8b, 0x8f, 0xf2, 0x22, 0x45, 0x1f, 0xe6, 0xca, 0xa4,
+	0xa6, 0x0f, 0xd0, 0xdc, 0x2e, 0xd9, 0xcd, 0x98, 0x1b, 0xe2, 0x52, 0x69, 0x2f, 0xe9, 0xc1, 0x86,
+	0xfa, 0x8e, 0x42, 0xd0, 0xfb, 0x9c, 0x07, 0x24, 0xaa, 0x2f, 0x0a, 0x12, 0x93, 0x3f, 0x83, 0xcd,
+	0xd4, 0xcb, 0x05, 0xc1, 0xc5, 0x79, 0x4f, 0x27, 0xb4, 0x93, 0x23, 0x49, 0xf4, 0x7c, 0x05, 0xed,
+	0xc5, 0x97, 0x06, 0x8c, 0xe2, 0x23, 0x25, 0x29, 0x8b, 0xb7, 0x7d, 0xda, 0x5d, 0x26, 0x4e, 0x54,
+	0x9f, 0x42, 0x23, 0x7b, 0x23, 0x27, 0x18, 0xbe, 0x25, 0x0f, 0x08, 0x74, 0x37, 0x5f, 0x98, 0x28,
+	0xfc, 0x18, 0xd6, 0x93, 0x4b, 0xaf, 0x28, 0xd4, 0xec, 0xbd, 0x9b, 0xb6, 0x32, 0xdc, 0xe4, 0xb7,
+	0x63, 0xd8, 0x4c, 0xdd, 0x43, 0x45, 0xbc, 0xf2, 0x2e, 0xc1, 0xb4, 0x93, 0x23, 0x91, 0x7a, 0xde,
+	0xc7, 0x04, 0x3f, 0xa4, 0xed, 0x6c, 0x82, 0x71, 0x19, 0x96, 0xfc, 0x31, 0xd4, 0xd3, 0x57, 0x46,
+	0xd2, 0x11, 0x0d, 0x34, 0xe7, 0x36, 0x4a, 0x69, 0x9e, 0x28, 0xb1, 0x39, 0x80, 0xcd, 0xd4, 0xcd,
+	0x4f, 0xda, 0x9c, 0x73, 0x99, 0xa4, 0x9d, 0x1c, 0x89, 0xd4, 0xf3, 0x43, 0xb4, 0xf9, 0xa3, 0x83,
+	0x0f, 0x32, 0x36, 0xcb, 0x01, 0xf2, 0xf0, 0x35, 0x9f, 0x20, 0xbe, 0x89, 0x8b, 0xf3, 0x2a, 0x89,
+	0x93, 0x68, 0x66, 0xa9, 0x38, 0xa5, 0x6e, 0x8f, 0xb4, 0x93, 0x23, 0x91, 0x98, 0x1f, 0x22, 0xe6,
+	0x63, 0x4a, 0x33, 0x98, 0x62, 0xc0, 0x3e, 0x7c, 0xed, 0xf9, 0xb8, 0x6d, 0x7f, 0x0d, 0x30, 0x1f,
+	0x91, 0xc5, 0xb6, 0x5d, 0x98, 0xd2, 0x69, 0x3b, 0xcb, 0x96, 0x18, 0x5d, 0xc4, 0xd0, 0x49, 0x3b,
+	0xdf, 0x2f, 0x32, 0x82, 0xcd, 0xd4, 0xfc, 0x98, 0xce, 0xb8, 0x3a, 0x2a, 0xd3, 0x4e, 0x8e, 0x44,
+	0xa2, 0xec, 0x21, 0x0a, 0xa5, 0xad, 0x6c, 0xc6, 0x71, 0x19, 0x77, 0xc2, 0x81, 0xcd, 0xd4, 0x10,
+	0x28, 0x70, 0xf2, 0x66, 0x48, 0xda, 0xc9, 0x91, 0xa4, 0x3b, 0x1d, 0xe9, 0x66, 0x71, 0x66, 0x03,
+	0xb5, 0xd9, 0x91, 0x73, 0xa8, 0x88, 0xa9, 0x8e, 0x6c, 0x49, 0x65, 0x8a, 0x7e, 0xa2, 0xb2, 0xa4,
+	0xe2, 0x1f, 0xa0, 0xe2, 0x47, 0xe4, 0xae, 0x16, 0x4a, 0xbe, 0x86, 0x9a, 0x32, 0x08, 0x89, 0x3e,
+	0xbd, 0x38, 0xac, 0xd1, 0x9d, 0x05, 0xfe, 0xf7, 0x44, 0x89, 0xf1, 0x55, 0xb8, 0x2d, 0x7a, 0xb0,
+	0xa1, 0x0e, 0x8a, 0xa2, 0xe9, 0xe5, 0x4c, 0x94, 0x54, 0x5f, 0x14, 0x24, 0x1b, 0xe2, 0x18, 0xea,
+	0xe9, 0x89, 0x47, 0xec, 0xad, 0xdc, 0x71, 0x8a, 0xd2, 0x3c, 0x51, 0xa2, 0xaa, 0x07, 0x1b, 0xea,
+	0x48, 0x42, 0xd4, 0x23, 0x28, 0xd5, 0x94, 0xf4, 0x45, 0x41, 0xac, 0xe4, 0x99, 0xfe, 0xf7, 0x37,
+	0x5d, 0xed, 0xbb, 0x37, 0x5d, 0xed, 0xdf, 0x6f, 0xba, 0xda, 0x1f, 0xdf, 0x76, 0x0b, 0xdf, 0xbd,
+	0xed, 0x16, 0xfe, 0xf5, 0xb6, 0x5b, 0x18, 0x54, 0xf0, 0x7f, 0xb5, 0x1f, 0xfd, 0x77, 0x00, 0x81,
+	0x69, 0x17, 0xb9, 0x9b, 0x1b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -5001,6 +5009,16 @@ func (m *CheckTaskRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Connect {
+		i--
+		if m.Connect {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.WarnCnt != 0 {
 		i = encodeVarintDmmaster(dAtA, i, uint64(m.WarnCnt))
 		i--
@@ -6882,6 +6900,9 @@ func (m *CheckTaskRequest) Size() (n int) {
 	if m.WarnCnt != 0 {
 		n += 1 + sovDmmaster(uint64(m.WarnCnt))
 	}
+	if m.Connect {
+		n += 2
+	}
 	return n
 }
 
@@ -10030,6 +10051,26 @@ func (m *CheckTaskRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connect", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmmaster
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Connect = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmmaster(dAtA[iNdEx:])
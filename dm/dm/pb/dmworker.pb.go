@@ -5,6 +5,7 @@ package pb
 
 import (
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
 	grpc "google.golang.org/grpc"
@@ -569,8 +570,12 @@ func (m *CheckStatus) GetDetail() []byte {
 }
 
 // DumpStatus represents status for dump unit
-// add fields later
 type DumpStatus struct {
+	CompletedTables   float64 `protobuf:"fixed64,1,opt,name=completedTables,proto3" json:"completedTables,omitempty"`
+	FinishedBytes     float64 `protobuf:"fixed64,2,opt,name=finishedBytes,proto3" json:"finishedBytes,omitempty"`
+	FinishedRows      float64 `protobuf:"fixed64,3,opt,name=finishedRows,proto3" json:"finishedRows,omitempty"`
+	EstimateTotalRows float64 `protobuf:"fixed64,4,opt,name=estimateTotalRows,proto3" json:"estimateTotalRows,omitempty"`
+	Progress          string  `protobuf:"bytes,5,opt,name=progress,proto3" json:"progress,omitempty"`
 }
 
 func (m *DumpStatus) Reset()         { *m = DumpStatus{} }
@@ -606,6 +611,41 @@ func (m *DumpStatus) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DumpStatus proto.InternalMessageInfo
 
+func (m *DumpStatus) GetCompletedTables() float64 {
+	if m != nil {
+		return m.CompletedTables
+	}
+	return 0
+}
+
+func (m *DumpStatus) GetFinishedBytes() float64 {
+	if m != nil {
+		return m.FinishedBytes
+	}
+	return 0
+}
+
+func (m *DumpStatus) GetFinishedRows() float64 {
+	if m != nil {
+		return m.FinishedRows
+	}
+	return 0
+}
+
+func (m *DumpStatus) GetEstimateTotalRows() float64 {
+	if m != nil {
+		return m.EstimateTotalRows
+	}
+	return 0
+}
+
+func (m *DumpStatus) GetProgress() string {
+	if m != nil {
+		return m.Progress
+	}
+	return ""
+}
+
 // LoadStatus represents status for load unit
 type LoadStatus struct {
 	FinishedBytes  int64  `protobuf:"varint,1,opt,name=finishedBytes,proto3" json:"finishedBytes,omitempty"`
@@ -767,18 +807,19 @@ func (m *ShardingGroup) GetUnsynced() []string {
 
 // SyncStatus represents status for sync unit
 type SyncStatus struct {
-	TotalEvents         int64            `protobuf:"varint,1,opt,name=totalEvents,proto3" json:"totalEvents,omitempty"`
-	TotalTps            int64            `protobuf:"varint,2,opt,name=totalTps,proto3" json:"totalTps,omitempty"`
-	RecentTps           int64            `protobuf:"varint,3,opt,name=recentTps,proto3" json:"recentTps,omitempty"`
-	MasterBinlog        string           `protobuf:"bytes,4,opt,name=masterBinlog,proto3" json:"masterBinlog,omitempty"`
-	MasterBinlogGtid    string           `protobuf:"bytes,5,opt,name=masterBinlogGtid,proto3" json:"masterBinlogGtid,omitempty"`
-	SyncerBinlog        string           `protobuf:"bytes,6,opt,name=syncerBinlog,proto3" json:"syncerBinlog,omitempty"`
-	SyncerBinlogGtid    string           `protobuf:"bytes,7,opt,name=syncerBinlogGtid,proto3" json:"syncerBinlogGtid,omitempty"`
-	BlockingDDLs        []string         `protobuf:"bytes,8,rep,name=blockingDDLs,proto3" json:"blockingDDLs,omitempty"`
-	UnresolvedGroups    []*ShardingGroup `protobuf:"bytes,9,rep,name=unresolvedGroups,proto3" json:"unresolvedGroups,omitempty"`
-	Synced              bool             `protobuf:"varint,10,opt,name=synced,proto3" json:"synced,omitempty"`
-	BinlogType          string           `protobuf:"bytes,11,opt,name=binlogType,proto3" json:"binlogType,omitempty"`
-	SecondsBehindMaster int64            `protobuf:"varint,12,opt,name=secondsBehindMaster,proto3" json:"secondsBehindMaster,omitempty"`
+	TotalEvents         int64             `protobuf:"varint,1,opt,name=totalEvents,proto3" json:"totalEvents,omitempty"`
+	TotalTps            int64             `protobuf:"varint,2,opt,name=totalTps,proto3" json:"totalTps,omitempty"`
+	RecentTps           int64             `protobuf:"varint,3,opt,name=recentTps,proto3" json:"recentTps,omitempty"`
+	MasterBinlog        string            `protobuf:"bytes,4,opt,name=masterBinlog,proto3" json:"masterBinlog,omitempty"`
+	MasterBinlogGtid    string            `protobuf:"bytes,5,opt,name=masterBinlogGtid,proto3" json:"masterBinlogGtid,omitempty"`
+	SyncerBinlog        string            `protobuf:"bytes,6,opt,name=syncerBinlog,proto3" json:"syncerBinlog,omitempty"`
+	SyncerBinlogGtid    string            `protobuf:"bytes,7,opt,name=syncerBinlogGtid,proto3" json:"syncerBinlogGtid,omitempty"`
+	BlockingDDLs        []string          `protobuf:"bytes,8,rep,name=blockingDDLs,proto3" json:"blockingDDLs,omitempty"`
+	UnresolvedGroups    []*ShardingGroup  `protobuf:"bytes,9,rep,name=unresolvedGroups,proto3" json:"unresolvedGroups,omitempty"`
+	Synced              bool              `protobuf:"varint,10,opt,name=synced,proto3" json:"synced,omitempty"`
+	BinlogType          string            `protobuf:"bytes,11,opt,name=binlogType,proto3" json:"binlogType,omitempty"`
+	SecondsBehindMaster int64             `protobuf:"varint,12,opt,name=secondsBehindMaster,proto3" json:"secondsBehindMaster,omitempty"`
+	Validation          *ValidationStatus `protobuf:"bytes,13,opt,name=validation,proto3" json:"validation,omitempty"`
 }
 
 func (m *SyncStatus) Reset()         { *m = SyncStatus{} }
@@ -898,6 +939,183 @@ func (m *SyncStatus) GetSecondsBehindMaster() int64 {
 	return 0
 }
 
+func (m *SyncStatus) GetValidation() *ValidationStatus {
+	if m != nil {
+		return m.Validation
+	}
+	return nil
+}
+
+// ValidationStatus represents status for the validator of the sync unit
+type ValidationStatus struct {
+	Mode          string             `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	ValidatedRows int64              `protobuf:"varint,2,opt,name=validatedRows,proto3" json:"validatedRows,omitempty"`
+	PendingRows   int64              `protobuf:"varint,3,opt,name=pendingRows,proto3" json:"pendingRows,omitempty"`
+	ErrorRows     int64              `protobuf:"varint,4,opt,name=errorRows,proto3" json:"errorRows,omitempty"`
+	SkippedRows   int64              `protobuf:"varint,5,opt,name=skippedRows,proto3" json:"skippedRows,omitempty"`
+	Errors        []*ValidationError `protobuf:"bytes,6,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (m *ValidationStatus) Reset()         { *m = ValidationStatus{} }
+func (m *ValidationStatus) String() string { return proto.CompactTextString(m) }
+func (*ValidationStatus) ProtoMessage()    {}
+func (*ValidationStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{8}
+}
+func (m *ValidationStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidationStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidationStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidationStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidationStatus.Merge(m, src)
+}
+func (m *ValidationStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidationStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidationStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidationStatus proto.InternalMessageInfo
+
+func (m *ValidationStatus) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+func (m *ValidationStatus) GetValidatedRows() int64 {
+	if m != nil {
+		return m.ValidatedRows
+	}
+	return 0
+}
+
+func (m *ValidationStatus) GetPendingRows() int64 {
+	if m != nil {
+		return m.PendingRows
+	}
+	return 0
+}
+
+func (m *ValidationStatus) GetErrorRows() int64 {
+	if m != nil {
+		return m.ErrorRows
+	}
+	return 0
+}
+
+func (m *ValidationStatus) GetSkippedRows() int64 {
+	if m != nil {
+		return m.SkippedRows
+	}
+	return 0
+}
+
+func (m *ValidationStatus) GetErrors() []*ValidationError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+// ValidationError represents a row mismatched with the downstream
+type ValidationError struct {
+	Table      string `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Key        string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	ErrType    string `protobuf:"bytes,3,opt,name=errType,proto3" json:"errType,omitempty"`
+	Upstream   string `protobuf:"bytes,4,opt,name=upstream,proto3" json:"upstream,omitempty"`
+	Downstream string `protobuf:"bytes,5,opt,name=downstream,proto3" json:"downstream,omitempty"`
+	Time       string `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (m *ValidationError) Reset()         { *m = ValidationError{} }
+func (m *ValidationError) String() string { return proto.CompactTextString(m) }
+func (*ValidationError) ProtoMessage()    {}
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_51a1b9e17fd67b10, []int{9}
+}
+func (m *ValidationError) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ValidationError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ValidationError.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ValidationError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValidationError.Merge(m, src)
+}
+func (m *ValidationError) XXX_Size() int {
+	return m.Size()
+}
+func (m *ValidationError) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValidationError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValidationError proto.InternalMessageInfo
+
+func (m *ValidationError) GetTable() string {
+	if m != nil {
+		return m.Table
+	}
+	return ""
+}
+
+func (m *ValidationError) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *ValidationError) GetErrType() string {
+	if m != nil {
+		return m.ErrType
+	}
+	return ""
+}
+
+func (m *ValidationError) GetUpstream() string {
+	if m != nil {
+		return m.Upstream
+	}
+	return ""
+}
+
+func (m *ValidationError) GetDownstream() string {
+	if m != nil {
+		return m.Downstream
+	}
+	return ""
+}
+
+func (m *ValidationError) GetTime() string {
+	if m != nil {
+		return m.Time
+	}
+	return ""
+}
+
 // SourceStatus represents status for source runing on dm-worker
 type SourceStatus struct {
 	Source      string         `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
@@ -910,7 +1128,7 @@ func (m *SourceStatus) Reset()         { *m = SourceStatus{} }
 func (m *SourceStatus) String() string { return proto.CompactTextString(m) }
 func (*SourceStatus) ProtoMessage()    {}
 func (*SourceStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{8}
+	return fileDescriptor_51a1b9e17fd67b10, []int{10}
 }
 func (m *SourceStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -983,7 +1201,7 @@ func (m *RelayStatus) Reset()         { *m = RelayStatus{} }
 func (m *RelayStatus) String() string { return proto.CompactTextString(m) }
 func (*RelayStatus) ProtoMessage()    {}
 func (*RelayStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{9}
+	return fileDescriptor_51a1b9e17fd67b10, []int{11}
 }
 func (m *RelayStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1097,7 +1315,7 @@ func (m *SubTaskStatus) Reset()         { *m = SubTaskStatus{} }
 func (m *SubTaskStatus) String() string { return proto.CompactTextString(m) }
 func (*SubTaskStatus) ProtoMessage()    {}
 func (*SubTaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{10}
+	return fileDescriptor_51a1b9e17fd67b10, []int{12}
 }
 func (m *SubTaskStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1251,7 +1469,7 @@ func (m *SubTaskStatusList) Reset()         { *m = SubTaskStatusList{} }
 func (m *SubTaskStatusList) String() string { return proto.CompactTextString(m) }
 func (*SubTaskStatusList) ProtoMessage()    {}
 func (*SubTaskStatusList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{11}
+	return fileDescriptor_51a1b9e17fd67b10, []int{13}
 }
 func (m *SubTaskStatusList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1297,7 +1515,7 @@ func (m *CheckError) Reset()         { *m = CheckError{} }
 func (m *CheckError) String() string { return proto.CompactTextString(m) }
 func (*CheckError) ProtoMessage()    {}
 func (*CheckError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{12}
+	return fileDescriptor_51a1b9e17fd67b10, []int{14}
 }
 func (m *CheckError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1343,7 +1561,7 @@ func (m *DumpError) Reset()         { *m = DumpError{} }
 func (m *DumpError) String() string { return proto.CompactTextString(m) }
 func (*DumpError) ProtoMessage()    {}
 func (*DumpError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{13}
+	return fileDescriptor_51a1b9e17fd67b10, []int{15}
 }
 func (m *DumpError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1388,7 +1606,7 @@ func (m *LoadError) Reset()         { *m = LoadError{} }
 func (m *LoadError) String() string { return proto.CompactTextString(m) }
 func (*LoadError) ProtoMessage()    {}
 func (*LoadError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{14}
+	return fileDescriptor_51a1b9e17fd67b10, []int{16}
 }
 func (m *LoadError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1435,7 +1653,7 @@ func (m *SyncSQLError) Reset()         { *m = SyncSQLError{} }
 func (m *SyncSQLError) String() string { return proto.CompactTextString(m) }
 func (*SyncSQLError) ProtoMessage()    {}
 func (*SyncSQLError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{15}
+	return fileDescriptor_51a1b9e17fd67b10, []int{17}
 }
 func (m *SyncSQLError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1494,7 +1712,7 @@ func (m *SyncError) Reset()         { *m = SyncError{} }
 func (m *SyncError) String() string { return proto.CompactTextString(m) }
 func (*SyncError) ProtoMessage()    {}
 func (*SyncError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{16}
+	return fileDescriptor_51a1b9e17fd67b10, []int{18}
 }
 func (m *SyncError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1542,7 +1760,7 @@ func (m *SourceError) Reset()         { *m = SourceError{} }
 func (m *SourceError) String() string { return proto.CompactTextString(m) }
 func (*SourceError) ProtoMessage()    {}
 func (*SourceError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{17}
+	return fileDescriptor_51a1b9e17fd67b10, []int{19}
 }
 func (m *SourceError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1608,7 +1826,7 @@ func (m *RelayError) Reset()         { *m = RelayError{} }
 func (m *RelayError) String() string { return proto.CompactTextString(m) }
 func (*RelayError) ProtoMessage()    {}
 func (*RelayError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{18}
+	return fileDescriptor_51a1b9e17fd67b10, []int{20}
 }
 func (m *RelayError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1667,7 +1885,7 @@ func (m *SubTaskError) Reset()         { *m = SubTaskError{} }
 func (m *SubTaskError) String() string { return proto.CompactTextString(m) }
 func (*SubTaskError) ProtoMessage()    {}
 func (*SubTaskError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{19}
+	return fileDescriptor_51a1b9e17fd67b10, []int{21}
 }
 func (m *SubTaskError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1807,7 +2025,7 @@ func (m *SubTaskErrorList) Reset()         { *m = SubTaskErrorList{} }
 func (m *SubTaskErrorList) String() string { return proto.CompactTextString(m) }
 func (*SubTaskErrorList) ProtoMessage()    {}
 func (*SubTaskErrorList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{20}
+	return fileDescriptor_51a1b9e17fd67b10, []int{22}
 }
 func (m *SubTaskErrorList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1857,7 +2075,7 @@ func (m *ProcessResult) Reset()         { *m = ProcessResult{} }
 func (m *ProcessResult) String() string { return proto.CompactTextString(m) }
 func (*ProcessResult) ProtoMessage()    {}
 func (*ProcessResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{21}
+	return fileDescriptor_51a1b9e17fd67b10, []int{23}
 }
 func (m *ProcessResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1924,7 +2142,7 @@ func (m *ProcessError) Reset()         { *m = ProcessError{} }
 func (m *ProcessError) String() string { return proto.CompactTextString(m) }
 func (*ProcessError) ProtoMessage()    {}
 func (*ProcessError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{22}
+	return fileDescriptor_51a1b9e17fd67b10, []int{24}
 }
 func (m *ProcessError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2018,7 +2236,7 @@ func (m *PurgeRelayRequest) Reset()         { *m = PurgeRelayRequest{} }
 func (m *PurgeRelayRequest) String() string { return proto.CompactTextString(m) }
 func (*PurgeRelayRequest) ProtoMessage()    {}
 func (*PurgeRelayRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{23}
+	return fileDescriptor_51a1b9e17fd67b10, []int{25}
 }
 func (m *PurgeRelayRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2090,7 +2308,7 @@ func (m *OperateWorkerSchemaRequest) Reset()         { *m = OperateWorkerSchemaR
 func (m *OperateWorkerSchemaRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateWorkerSchemaRequest) ProtoMessage()    {}
 func (*OperateWorkerSchemaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{24}
+	return fileDescriptor_51a1b9e17fd67b10, []int{26}
 }
 func (m *OperateWorkerSchemaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2187,7 +2405,7 @@ func (m *V1SubTaskMeta) Reset()         { *m = V1SubTaskMeta{} }
 func (m *V1SubTaskMeta) String() string { return proto.CompactTextString(m) }
 func (*V1SubTaskMeta) ProtoMessage()    {}
 func (*V1SubTaskMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{25}
+	return fileDescriptor_51a1b9e17fd67b10, []int{27}
 }
 func (m *V1SubTaskMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2252,7 +2470,7 @@ func (m *OperateV1MetaRequest) Reset()         { *m = OperateV1MetaRequest{} }
 func (m *OperateV1MetaRequest) String() string { return proto.CompactTextString(m) }
 func (*OperateV1MetaRequest) ProtoMessage()    {}
 func (*OperateV1MetaRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{26}
+	return fileDescriptor_51a1b9e17fd67b10, []int{28}
 }
 func (m *OperateV1MetaRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2298,7 +2516,7 @@ func (m *OperateV1MetaResponse) Reset()         { *m = OperateV1MetaResponse{} }
 func (m *OperateV1MetaResponse) String() string { return proto.CompactTextString(m) }
 func (*OperateV1MetaResponse) ProtoMessage()    {}
 func (*OperateV1MetaResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{27}
+	return fileDescriptor_51a1b9e17fd67b10, []int{29}
 }
 func (m *OperateV1MetaResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2359,7 +2577,7 @@ func (m *HandleWorkerErrorRequest) Reset()         { *m = HandleWorkerErrorReque
 func (m *HandleWorkerErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*HandleWorkerErrorRequest) ProtoMessage()    {}
 func (*HandleWorkerErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{28}
+	return fileDescriptor_51a1b9e17fd67b10, []int{30}
 }
 func (m *HandleWorkerErrorRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2423,7 +2641,7 @@ func (m *GetWorkerCfgRequest) Reset()         { *m = GetWorkerCfgRequest{} }
 func (m *GetWorkerCfgRequest) String() string { return proto.CompactTextString(m) }
 func (*GetWorkerCfgRequest) ProtoMessage()    {}
 func (*GetWorkerCfgRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{29}
+	return fileDescriptor_51a1b9e17fd67b10, []int{31}
 }
 func (m *GetWorkerCfgRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2460,7 +2678,7 @@ func (m *GetWorkerCfgResponse) Reset()         { *m = GetWorkerCfgResponse{} }
 func (m *GetWorkerCfgResponse) String() string { return proto.CompactTextString(m) }
 func (*GetWorkerCfgResponse) ProtoMessage()    {}
 func (*GetWorkerCfgResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_51a1b9e17fd67b10, []int{30}
+	return fileDescriptor_51a1b9e17fd67b10, []int{32}
 }
 func (m *GetWorkerCfgResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2512,6 +2730,8 @@ func init() {
 	proto.RegisterType((*LoadStatus)(nil), "pb.LoadStatus")
 	proto.RegisterType((*ShardingGroup)(nil), "pb.ShardingGroup")
 	proto.RegisterType((*SyncStatus)(nil), "pb.SyncStatus")
+	proto.RegisterType((*ValidationStatus)(nil), "pb.ValidationStatus")
+	proto.RegisterType((*ValidationError)(nil), "pb.ValidationError")
 	proto.RegisterType((*SourceStatus)(nil), "pb.SourceStatus")
 	proto.RegisterType((*RelayStatus)(nil), "pb.RelayStatus")
 	proto.RegisterType((*SubTaskStatus)(nil), "pb.SubTaskStatus")
@@ -2541,134 +2761,147 @@ func init() {
 func init() { proto.RegisterFile("dmworker.proto", fileDescriptor_51a1b9e17fd67b10) }
 
 var fileDescriptor_51a1b9e17fd67b10 = []byte{
-	// 2022 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4f, 0x6f, 0x1c, 0x49,
-	0x15, 0x9f, 0x9e, 0x9e, 0x19, 0xcf, 0xbc, 0x19, 0x3b, 0x9d, 0xb2, 0xb3, 0x0c, 0x26, 0x0c, 0x56,
-	0x67, 0x15, 0x8c, 0x0f, 0xd6, 0xc6, 0x2c, 0x5a, 0xb4, 0x12, 0x10, 0x6c, 0x67, 0x9d, 0x05, 0x07,
-	0x27, 0x6d, 0x67, 0x39, 0xa2, 0x9a, 0xee, 0xf2, 0xb8, 0xe5, 0x9e, 0xee, 0x4e, 0x57, 0xb7, 0xad,
-	0x39, 0x20, 0x3e, 0x02, 0x5c, 0x38, 0x20, 0x71, 0xe5, 0xba, 0x47, 0x3e, 0x02, 0x70, 0x8c, 0x90,
-	0x90, 0x10, 0x27, 0x94, 0x7c, 0x0d, 0x0e, 0xe8, 0xbd, 0xaa, 0xee, 0xae, 0xb1, 0x67, 0x12, 0x72,
-	0xe0, 0xd6, 0xef, 0x4f, 0xbd, 0xf7, 0xea, 0x57, 0xef, 0x4f, 0x55, 0xc3, 0x5a, 0x30, 0xbd, 0x4e,
-	0xb2, 0x4b, 0x91, 0xed, 0xa6, 0x59, 0x92, 0x27, 0xac, 0x99, 0x8e, 0xdd, 0x6d, 0x60, 0x2f, 0x0a,
-	0x91, 0xcd, 0x4e, 0x73, 0x9e, 0x17, 0xd2, 0x13, 0xaf, 0x0a, 0x21, 0x73, 0xc6, 0xa0, 0x15, 0xf3,
-	0xa9, 0x18, 0x5a, 0x5b, 0xd6, 0x76, 0xcf, 0xa3, 0x6f, 0x37, 0x85, 0x8d, 0x83, 0x64, 0x3a, 0x4d,
-	0xe2, 0x5f, 0x92, 0x0d, 0x4f, 0xc8, 0x34, 0x89, 0xa5, 0x60, 0x1f, 0x41, 0x27, 0x13, 0xb2, 0x88,
-	0x72, 0xd2, 0xee, 0x7a, 0x9a, 0x62, 0x0e, 0xd8, 0x53, 0x39, 0x19, 0x36, 0xc9, 0x04, 0x7e, 0xa2,
-	0xa6, 0x4c, 0x8a, 0xcc, 0x17, 0x43, 0x9b, 0x98, 0x9a, 0x42, 0xbe, 0x8a, 0x6b, 0xd8, 0x52, 0x7c,
-	0x45, 0xb9, 0x5f, 0x5b, 0xb0, 0x3e, 0x17, 0xdc, 0x07, 0x7b, 0xfc, 0x14, 0x06, 0xca, 0x87, 0xb2,
-	0x40, 0x7e, 0xfb, 0x7b, 0xce, 0x6e, 0x3a, 0xde, 0x3d, 0x35, 0xf8, 0xde, 0x9c, 0x16, 0xfb, 0x0c,
-	0x56, 0x65, 0x31, 0x3e, 0xe3, 0xf2, 0x52, 0x2f, 0x6b, 0x6d, 0xd9, 0xdb, 0xfd, 0xbd, 0xbb, 0xb4,
-	0xcc, 0x14, 0x78, 0xf3, 0x7a, 0xee, 0x9f, 0x2c, 0xe8, 0x1f, 0x5c, 0x08, 0x5f, 0xd3, 0x18, 0x68,
-	0xca, 0xa5, 0x14, 0x41, 0x19, 0xa8, 0xa2, 0xd8, 0x06, 0xb4, 0xf3, 0x24, 0xe7, 0x11, 0x85, 0xda,
-	0xf6, 0x14, 0xc1, 0x46, 0x00, 0xb2, 0xf0, 0x7d, 0x21, 0xe5, 0x79, 0x11, 0x51, 0xa8, 0x6d, 0xcf,
-	0xe0, 0xa0, 0xb5, 0x73, 0x1e, 0x46, 0x22, 0x20, 0x98, 0xda, 0x9e, 0xa6, 0xd8, 0x10, 0x56, 0xae,
-	0x79, 0x16, 0x87, 0xf1, 0x64, 0xd8, 0x26, 0x41, 0x49, 0xe2, 0x8a, 0x40, 0xe4, 0x3c, 0x8c, 0x86,
-	0x9d, 0x2d, 0x6b, 0x7b, 0xe0, 0x69, 0xca, 0x1d, 0x00, 0x1c, 0x16, 0xd3, 0x54, 0x47, 0xfd, 0x67,
-	0x0b, 0xe0, 0x38, 0xe1, 0x81, 0x0e, 0xfa, 0x63, 0x58, 0x3d, 0x0f, 0xe3, 0x50, 0x5e, 0x88, 0x60,
-	0x7f, 0x96, 0x0b, 0x49, 0xb1, 0xdb, 0xde, 0x3c, 0x13, 0x83, 0xa5, 0xa8, 0x95, 0x4a, 0x93, 0x54,
-	0x0c, 0x0e, 0xdb, 0x84, 0x6e, 0x9a, 0x25, 0x93, 0x4c, 0x48, 0xa9, 0x4f, 0xbb, 0xa2, 0x71, 0xed,
-	0x54, 0xe4, 0x7c, 0x3f, 0x8c, 0xa3, 0x64, 0xa2, 0xcf, 0xdc, 0xe0, 0xb0, 0x87, 0xb0, 0x56, 0x53,
-	0x47, 0x67, 0x5f, 0x1e, 0xd2, 0xbe, 0x7a, 0xde, 0x0d, 0xae, 0xfb, 0x7b, 0x0b, 0x56, 0x4f, 0x2f,
-	0x78, 0x16, 0x84, 0xf1, 0xe4, 0x28, 0x4b, 0x8a, 0x14, 0x37, 0x9c, 0xf3, 0x6c, 0x22, 0x72, 0x9d,
-	0xb9, 0x9a, 0xc2, 0x7c, 0x3e, 0x3c, 0x3c, 0xc6, 0x38, 0x6d, 0xcc, 0x67, 0xfc, 0x56, 0xfb, 0xcc,
-	0x64, 0x7e, 0x9c, 0xf8, 0x3c, 0x0f, 0x93, 0x58, 0x87, 0x39, 0xcf, 0xa4, 0x9c, 0x9d, 0xc5, 0x3e,
-	0x81, 0x6e, 0x53, 0xce, 0x12, 0x85, 0xfb, 0x2b, 0x62, 0x2d, 0x69, 0x93, 0xa4, 0xa2, 0xdd, 0x7f,
-	0xd8, 0x00, 0xa7, 0xb3, 0xd8, 0xd7, 0x80, 0x6e, 0x41, 0x9f, 0x80, 0x79, 0x72, 0x25, 0xe2, 0xbc,
-	0x84, 0xd3, 0x64, 0xa1, 0x31, 0x22, 0xcf, 0xd2, 0x12, 0xca, 0x8a, 0x66, 0xf7, 0xa1, 0x97, 0x09,
-	0x5f, 0xc4, 0x39, 0x0a, 0x6d, 0x12, 0xd6, 0x0c, 0xe6, 0xc2, 0x60, 0xca, 0x65, 0x2e, 0xb2, 0x39,
-	0x30, 0xe7, 0x78, 0x6c, 0x07, 0x1c, 0x93, 0x3e, 0xca, 0xc3, 0x40, 0x03, 0x7a, 0x8b, 0x8f, 0xf6,
-	0x68, 0x13, 0xa5, 0xbd, 0x8e, 0xb2, 0x67, 0xf2, 0xd0, 0x9e, 0x49, 0x93, 0xbd, 0x15, 0x65, 0xef,
-	0x26, 0x1f, 0xed, 0x8d, 0xa3, 0xc4, 0xbf, 0x0c, 0xe3, 0x09, 0x1d, 0x40, 0x97, 0xa0, 0x9a, 0xe3,
-	0xb1, 0x1f, 0x81, 0x53, 0xc4, 0x99, 0x90, 0x49, 0x74, 0x25, 0x02, 0x3a, 0x47, 0x39, 0xec, 0x19,
-	0x15, 0x67, 0x9e, 0xb0, 0x77, 0x4b, 0xd5, 0x38, 0x21, 0x50, 0x45, 0xa6, 0x4f, 0x68, 0x04, 0x30,
-	0xa6, 0x40, 0xce, 0x66, 0xa9, 0x18, 0xf6, 0x55, 0x96, 0xd5, 0x1c, 0xf6, 0x09, 0xac, 0x4b, 0xe1,
-	0x27, 0x71, 0x20, 0xf7, 0xc5, 0x45, 0x18, 0x07, 0xcf, 0x08, 0x8b, 0xe1, 0x80, 0x20, 0x5e, 0x24,
-	0x72, 0xff, 0x68, 0xc1, 0xc0, 0x6c, 0x1b, 0x46, 0x43, 0xb3, 0x96, 0x34, 0xb4, 0xa6, 0xd9, 0xd0,
-	0xd8, 0xf7, 0xaa, 0xc6, 0xa5, 0x1a, 0x11, 0xed, 0xef, 0x79, 0x96, 0x60, 0x85, 0x7b, 0x24, 0xa8,
-	0x7a, 0xd9, 0x23, 0xe8, 0x67, 0x22, 0xe2, 0xb3, 0xaa, 0x03, 0xa1, 0xfe, 0x1d, 0xd4, 0xf7, 0x6a,
-	0xb6, 0x67, 0xea, 0xb8, 0x7f, 0x6d, 0x42, 0xdf, 0x10, 0xde, 0xca, 0x0d, 0xeb, 0x7f, 0xcc, 0x8d,
-	0xe6, 0x92, 0xdc, 0xd8, 0x2a, 0x43, 0x2a, 0xc6, 0x87, 0x61, 0xa6, 0xcb, 0xc5, 0x64, 0x55, 0x1a,
-	0x73, 0xc9, 0x68, 0xb2, 0xd8, 0x36, 0xdc, 0x31, 0x48, 0x23, 0x15, 0x6f, 0xb2, 0xd9, 0x2e, 0x30,
-	0x62, 0x1d, 0xf0, 0xdc, 0xbf, 0x78, 0x99, 0xea, 0xd3, 0xe9, 0xd0, 0x11, 0x2f, 0x90, 0xb0, 0xef,
-	0x40, 0x5b, 0xe6, 0x7c, 0x22, 0x28, 0x15, 0xd7, 0xf6, 0x7a, 0x94, 0x3a, 0xc8, 0xf0, 0x14, 0xdf,
-	0x00, 0xbf, 0xfb, 0x1e, 0xf0, 0xdd, 0xff, 0x34, 0x61, 0x75, 0xae, 0xd1, 0x2f, 0x1a, 0x88, 0xb5,
-	0xc7, 0xe6, 0x12, 0x8f, 0x5b, 0xd0, 0x2a, 0xe2, 0x50, 0x1d, 0xf6, 0xda, 0xde, 0x00, 0xe5, 0x2f,
-	0xe3, 0x30, 0xc7, 0xec, 0xf3, 0x48, 0x62, 0xc4, 0xd4, 0x7a, 0x5f, 0x42, 0x7c, 0x02, 0xeb, 0x75,
-	0xea, 0x1f, 0x1e, 0x1e, 0x1f, 0x27, 0xfe, 0x65, 0xd5, 0x19, 0x17, 0x89, 0x18, 0x53, 0xe3, 0x90,
-	0x4a, 0xf8, 0x69, 0x43, 0x0d, 0xc4, 0xef, 0x42, 0xdb, 0xc7, 0x01, 0x45, 0x28, 0xe9, 0x84, 0x32,
-	0x26, 0xd6, 0xd3, 0x86, 0xa7, 0xe4, 0xec, 0x63, 0x68, 0x05, 0xc5, 0x34, 0xd5, 0x58, 0xad, 0xa1,
-	0x5e, 0x3d, 0x32, 0x9e, 0x36, 0x3c, 0x92, 0xa2, 0x56, 0x94, 0xf0, 0x60, 0xd8, 0xab, 0xb5, 0xea,
-	0x49, 0x82, 0x5a, 0x28, 0x45, 0x2d, 0xac, 0x49, 0xaa, 0x4f, 0xad, 0x55, 0xb7, 0x47, 0xd4, 0x42,
-	0xe9, 0x7e, 0x17, 0x3a, 0x52, 0x25, 0xf2, 0x8f, 0xe1, 0xee, 0x1c, 0xfa, 0xc7, 0xa1, 0x24, 0xa8,
-	0x94, 0x78, 0x68, 0x2d, 0x9b, 0xc6, 0xe5, 0xfa, 0x11, 0x00, 0xed, 0xe9, 0x49, 0x96, 0x25, 0x59,
-	0x79, 0x2b, 0xb0, 0xaa, 0x5b, 0x81, 0xfb, 0x6d, 0xe8, 0xe1, 0x5e, 0xde, 0x21, 0xc6, 0x4d, 0x2c,
-	0x13, 0xa7, 0x30, 0xa0, 0xe8, 0x5f, 0x1c, 0x2f, 0xd1, 0x60, 0x7b, 0xb0, 0xa1, 0x46, 0xb3, 0x4a,
-	0xe7, 0xe7, 0x89, 0x0c, 0x69, 0xc0, 0xa8, 0xc2, 0x5a, 0x28, 0xc3, 0x11, 0x20, 0xd0, 0xdc, 0xe9,
-	0x8b, 0xe3, 0x72, 0x5e, 0x96, 0xb4, 0xfb, 0x03, 0xe8, 0xa1, 0x47, 0xe5, 0x6e, 0x1b, 0x3a, 0x24,
-	0x28, 0x71, 0x70, 0x2a, 0x38, 0x75, 0x40, 0x9e, 0x96, 0xbb, 0xbf, 0xb5, 0xa0, 0xaf, 0xda, 0x95,
-	0x5a, 0xf9, 0xa1, 0xdd, 0x6a, 0x6b, 0x6e, 0x79, 0x59, 0xef, 0xa6, 0xc5, 0x5d, 0x00, 0x6a, 0x38,
-	0x4a, 0xa1, 0x55, 0x1f, 0x6f, 0xcd, 0xf5, 0x0c, 0x0d, 0x3c, 0x98, 0x9a, 0x5a, 0x00, 0xed, 0x1f,
-	0x9a, 0x30, 0xd0, 0x47, 0xaa, 0x54, 0xfe, 0x4f, 0x65, 0xa7, 0x2b, 0xa3, 0x65, 0x56, 0xc6, 0xc3,
-	0xb2, 0x32, 0xda, 0xf5, 0x36, 0xea, 0x2c, 0xaa, 0x0b, 0xe3, 0x81, 0x2e, 0x8c, 0x0e, 0xa9, 0xad,
-	0x96, 0x85, 0x51, 0x6a, 0xa9, 0xba, 0x78, 0xa0, 0xeb, 0x62, 0xa5, 0x56, 0xaa, 0x52, 0xaa, 0x2a,
-	0x8b, 0x07, 0xba, 0x2c, 0xba, 0xb5, 0x52, 0x75, 0xcc, 0x55, 0x55, 0xac, 0x40, 0x9b, 0x8e, 0xd3,
-	0xfd, 0x1c, 0x1c, 0x13, 0x1a, 0xaa, 0x89, 0x87, 0x5a, 0x38, 0x97, 0x0a, 0x86, 0x92, 0xa7, 0xd7,
-	0xbe, 0x82, 0xd5, 0xb9, 0xa6, 0x82, 0xb3, 0x31, 0x94, 0x07, 0x3c, 0xf6, 0x45, 0x54, 0x5d, 0x4e,
-	0x0d, 0x8e, 0x91, 0x64, 0xcd, 0xda, 0xb2, 0x36, 0x31, 0x97, 0x64, 0xc6, 0x15, 0xd3, 0x9e, 0xbb,
-	0x62, 0xfe, 0xdd, 0x82, 0x81, 0xb9, 0x00, 0x6f, 0xa9, 0x4f, 0xb2, 0xec, 0x20, 0x09, 0xd4, 0x69,
-	0xb6, 0xbd, 0x92, 0xc4, 0xd4, 0xc7, 0xcf, 0x88, 0x4b, 0xa9, 0x33, 0xb0, 0xa2, 0xb5, 0xec, 0xd4,
-	0x4f, 0xd2, 0xf2, 0xd1, 0x50, 0xd1, 0x5a, 0x76, 0x2c, 0xae, 0x44, 0xa4, 0x47, 0x4d, 0x45, 0xa3,
-	0xb7, 0x67, 0x42, 0x4a, 0x4c, 0x13, 0xd5, 0x21, 0x4b, 0x12, 0x57, 0x79, 0xfc, 0xfa, 0x80, 0x17,
-	0x52, 0xe8, 0xdb, 0x4d, 0x45, 0x23, 0x2c, 0xf8, 0xb8, 0xe1, 0x59, 0x52, 0xc4, 0xe5, 0x9d, 0xc6,
-	0xe0, 0xb8, 0xd7, 0x70, 0xf7, 0x79, 0x91, 0x4d, 0x04, 0x25, 0x71, 0xf9, 0x56, 0xda, 0x84, 0x6e,
-	0x18, 0x73, 0x3f, 0x0f, 0xaf, 0x84, 0x46, 0xb2, 0xa2, 0x31, 0x7f, 0xf3, 0x70, 0x2a, 0xf4, 0xa5,
-	0x8e, 0xbe, 0x51, 0xff, 0x3c, 0x8c, 0x04, 0xe5, 0xb5, 0xde, 0x52, 0x49, 0x53, 0x89, 0xaa, 0xe9,
-	0xaa, 0x5f, 0x42, 0x8a, 0x72, 0xff, 0x65, 0xc1, 0xe6, 0x49, 0x2a, 0x32, 0x9e, 0x0b, 0xf5, 0xfa,
-	0x3a, 0xf5, 0x2f, 0xc4, 0x94, 0x97, 0x21, 0xdc, 0x87, 0x66, 0x92, 0x92, 0x73, 0x9d, 0xef, 0x4a,
-	0x7c, 0x92, 0x7a, 0xcd, 0x24, 0xa5, 0x20, 0xb8, 0xbc, 0xd4, 0xd8, 0xd2, 0xf7, 0xd2, 0xa7, 0xd8,
-	0x26, 0x74, 0x03, 0x9e, 0xf3, 0x31, 0x97, 0xa2, 0xc4, 0xb4, 0xa4, 0xe9, 0xd5, 0xc2, 0xc7, 0x51,
-	0x89, 0xa8, 0x22, 0xc8, 0x12, 0x79, 0xd3, 0x68, 0x6a, 0x0a, 0xb5, 0xcf, 0xa3, 0x42, 0x5e, 0x10,
-	0x8c, 0x5d, 0x4f, 0x11, 0x18, 0x4b, 0x95, 0xf3, 0x5d, 0x95, 0xe2, 0x6e, 0x0e, 0xab, 0x5f, 0x3d,
-	0xd2, 0x69, 0xfb, 0x4c, 0xe4, 0x9c, 0x6d, 0x1a, 0xdb, 0x01, 0xdc, 0x0e, 0x4a, 0xf4, 0x66, 0xde,
-	0x5b, 0xfd, 0x65, 0xcb, 0xb0, 0x8d, 0x96, 0x51, 0x22, 0xd0, 0xa2, 0x14, 0xa5, 0x6f, 0xf7, 0x53,
-	0xd8, 0xd0, 0x88, 0x7e, 0xf5, 0x08, 0xbd, 0x2e, 0xc5, 0x52, 0x89, 0x95, 0x7b, 0xf7, 0x2f, 0x16,
-	0xdc, 0xbb, 0xb1, 0xec, 0x83, 0x1f, 0xa5, 0x9f, 0x41, 0x0b, 0x1f, 0x32, 0x43, 0x9b, 0x4a, 0xeb,
-	0x01, 0xfa, 0x58, 0x68, 0x72, 0x17, 0x89, 0x27, 0x71, 0x9e, 0xcd, 0x3c, 0x5a, 0xb0, 0xf9, 0x33,
-	0xe8, 0x55, 0x2c, 0xb4, 0x7b, 0x29, 0x66, 0x65, 0xf7, 0xbc, 0x14, 0x33, 0x9c, 0xed, 0x57, 0x3c,
-	0x2a, 0x14, 0x34, 0x7a, 0x40, 0xce, 0x01, 0xeb, 0x29, 0xf9, 0xe7, 0xcd, 0x1f, 0x5a, 0xee, 0xaf,
-	0x61, 0xf8, 0x94, 0xc7, 0x41, 0xa4, 0xf3, 0x49, 0x15, 0xb5, 0x86, 0xe0, 0x5b, 0x06, 0x04, 0x7d,
-	0xb4, 0x42, 0xd2, 0x77, 0x64, 0xd3, 0x7d, 0xe8, 0x8d, 0xcb, 0x71, 0xa6, 0x81, 0xaf, 0x19, 0x74,
-	0xe6, 0xaf, 0x22, 0xa9, 0x1f, 0x50, 0xf4, 0xed, 0xde, 0x83, 0xf5, 0x23, 0x91, 0x2b, 0xdf, 0x07,
-	0xe7, 0x13, 0xed, 0xd9, 0xdd, 0x86, 0x8d, 0x79, 0xb6, 0x06, 0xd7, 0x01, 0xdb, 0x3f, 0xaf, 0x46,
-	0x85, 0x7f, 0x3e, 0xd9, 0xf9, 0x15, 0x74, 0x54, 0x56, 0xb0, 0x55, 0xe8, 0x7d, 0x19, 0x5f, 0xf1,
-	0x28, 0x0c, 0x4e, 0x52, 0xa7, 0xc1, 0xba, 0xd0, 0x3a, 0xcd, 0x93, 0xd4, 0xb1, 0x58, 0x0f, 0xda,
-	0xcf, 0xb1, 0xac, 0x9d, 0x26, 0x03, 0xe8, 0x60, 0xe7, 0x9b, 0x0a, 0xc7, 0x46, 0xf6, 0x69, 0xce,
-	0xb3, 0xdc, 0x69, 0x21, 0xfb, 0x65, 0x1a, 0xf0, 0x5c, 0x38, 0x6d, 0xb6, 0x06, 0xf0, 0xd3, 0x22,
-	0x4f, 0xb4, 0x5a, 0x67, 0xe7, 0x37, 0xa4, 0x36, 0x41, 0xdf, 0x03, 0x6d, 0x9f, 0x68, 0xa7, 0xc1,
-	0x56, 0xc0, 0xfe, 0x85, 0xb8, 0x76, 0x2c, 0xd6, 0x87, 0x15, 0xaf, 0x88, 0xf1, 0xa9, 0xad, 0x7c,
-	0x90, 0xbb, 0xc0, 0xb1, 0x51, 0x80, 0x41, 0xa4, 0x22, 0x70, 0x5a, 0x6c, 0x00, 0xdd, 0x2f, 0xf4,
-	0xdb, 0xd9, 0x69, 0xa3, 0x08, 0xd5, 0x70, 0x4d, 0x07, 0x45, 0xe4, 0x10, 0xa9, 0x15, 0xa4, 0x68,
-	0x15, 0x52, 0xdd, 0x9d, 0x13, 0xe8, 0x96, 0x63, 0x8b, 0xdd, 0x81, 0xbe, 0x8e, 0x01, 0x59, 0x4e,
-	0x03, 0x37, 0x41, 0xc3, 0xc9, 0xb1, 0x70, 0xc3, 0x38, 0x80, 0x9c, 0x26, 0x7e, 0xe1, 0x94, 0x71,
-	0x6c, 0x02, 0x61, 0x16, 0xfb, 0x4e, 0x0b, 0x15, 0xa9, 0x5b, 0x39, 0xc1, 0xce, 0x33, 0x58, 0xa1,
-	0xcf, 0x13, 0x3c, 0xc4, 0x35, 0x6d, 0x4f, 0x73, 0x9c, 0x06, 0xe2, 0x88, 0xde, 0x95, 0xb6, 0x85,
-	0x78, 0xd0, 0x76, 0x14, 0xdd, 0xc4, 0x10, 0x14, 0x36, 0x8a, 0x61, 0xef, 0xc4, 0xd0, 0x2d, 0xdb,
-	0x0c, 0x5b, 0x87, 0x3b, 0x25, 0x46, 0x9a, 0xa5, 0x0c, 0x1e, 0x89, 0x5c, 0x31, 0x1c, 0x8b, 0xec,
-	0x57, 0x64, 0x13, 0x61, 0xf5, 0xc4, 0x34, 0xb9, 0x12, 0x9a, 0x63, 0xa3, 0x47, 0x9c, 0x6a, 0x9a,
-	0x6e, 0xe1, 0x02, 0xa4, 0xcf, 0xb0, 0xcd, 0x38, 0xed, 0x9d, 0xc7, 0xd0, 0x2d, 0x4b, 0xd1, 0xf0,
-	0x57, 0xb2, 0x2a, 0x7f, 0x8a, 0xe1, 0x58, 0xb5, 0x03, 0xcd, 0x69, 0xee, 0x3c, 0xa6, 0x11, 0x84,
-	0x99, 0x6c, 0x00, 0xa0, 0x39, 0x3a, 0x73, 0x2e, 0xc3, 0x54, 0x9f, 0xab, 0x48, 0x23, 0xee, 0x57,
-	0xb9, 0x73, 0x25, 0xb2, 0xdc, 0xb1, 0xf7, 0xbe, 0xb6, 0xa1, 0xa3, 0xb2, 0x93, 0x3d, 0x86, 0xbe,
-	0xf1, 0x6f, 0x8a, 0x7d, 0x84, 0x75, 0x72, 0xfb, 0x4f, 0xda, 0xe6, 0x37, 0x6e, 0xf1, 0x55, 0x4a,
-	0xbb, 0x0d, 0xf6, 0x13, 0x80, 0x7a, 0x9a, 0xb0, 0x7b, 0x34, 0x62, 0x6f, 0x4e, 0x97, 0xcd, 0x21,
-	0xdd, 0x43, 0x16, 0xfc, 0x77, 0x73, 0x1b, 0xec, 0xe7, 0xb0, 0xaa, 0x1b, 0x87, 0xc2, 0x8c, 0x8d,
-	0x8c, 0x5e, 0xb2, 0x60, 0x4e, 0xbc, 0xd3, 0xd8, 0x17, 0x95, 0x31, 0x85, 0x17, 0x1b, 0x2e, 0x68,
-	0x4c, 0xca, 0xcc, 0x37, 0x97, 0xb6, 0x2c, 0xb7, 0xc1, 0x8e, 0xa0, 0xaf, 0x1a, 0x8b, 0x1a, 0xfb,
-	0xf7, 0x51, 0x77, 0x59, 0xa7, 0x79, 0x67, 0x40, 0x07, 0x30, 0x30, 0x7b, 0x01, 0x23, 0x24, 0x17,
-	0x34, 0x0d, 0x65, 0x64, 0x51, 0xdb, 0x70, 0x1b, 0xfb, 0xc3, 0xbf, 0xbd, 0x19, 0x59, 0xaf, 0xdf,
-	0x8c, 0xac, 0x7f, 0xbf, 0x19, 0x59, 0xbf, 0x7b, 0x3b, 0x6a, 0xbc, 0x7e, 0x3b, 0x6a, 0xfc, 0xf3,
-	0xed, 0xa8, 0x31, 0xee, 0xd0, 0x3f, 0xd0, 0xef, 0xff, 0x37, 0x00, 0x00, 0xff, 0xff, 0x89, 0xaa,
-	0x52, 0x17, 0x15, 0x15, 0x00, 0x00,
+	// 2231 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4f, 0x73, 0xdc, 0x4a,
+	0x11, 0x5f, 0xad, 0x76, 0xd7, 0xbb, 0xbd, 0x6b, 0x47, 0x19, 0x3b, 0x0f, 0x61, 0x82, 0x71, 0x29,
+	0xaf, 0xf2, 0x8c, 0xa1, 0x5c, 0x2f, 0x26, 0xd4, 0xa3, 0x5e, 0x15, 0x10, 0x62, 0xe7, 0x39, 0x0f,
+	0x36, 0x24, 0x91, 0x9d, 0x70, 0xa4, 0xb4, 0xd2, 0x78, 0xad, 0xb2, 0x56, 0x52, 0x34, 0x92, 0x5d,
+	0x7b, 0xa0, 0xf8, 0x00, 0x1c, 0xe0, 0xc2, 0x81, 0x2a, 0x6e, 0x14, 0xd7, 0x77, 0xe4, 0x23, 0x00,
+	0xc5, 0xe9, 0x15, 0x27, 0x0a, 0x2e, 0x54, 0xf2, 0x35, 0x38, 0x50, 0xdd, 0x33, 0x92, 0x46, 0xf6,
+	0x6e, 0x42, 0x0e, 0xdc, 0xa6, 0x7f, 0xdd, 0xd3, 0xdd, 0xd3, 0xd3, 0x7f, 0x46, 0x82, 0xb5, 0x60,
+	0x76, 0x99, 0x64, 0xe7, 0x3c, 0xdb, 0x4b, 0xb3, 0x24, 0x4f, 0x58, 0x3b, 0x9d, 0x38, 0x3b, 0xc0,
+	0x9e, 0x17, 0x3c, 0x9b, 0x1f, 0xe7, 0x5e, 0x5e, 0x08, 0x97, 0xbf, 0x2a, 0xb8, 0xc8, 0x19, 0x83,
+	0x4e, 0xec, 0xcd, 0xb8, 0x6d, 0x6c, 0x1b, 0x3b, 0x03, 0x97, 0xd6, 0x4e, 0x0a, 0x1b, 0x07, 0xc9,
+	0x6c, 0x96, 0xc4, 0x3f, 0x23, 0x1d, 0x2e, 0x17, 0x69, 0x12, 0x0b, 0xce, 0x3e, 0x80, 0x5e, 0xc6,
+	0x45, 0x11, 0xe5, 0x24, 0xdd, 0x77, 0x15, 0xc5, 0x2c, 0x30, 0x67, 0x62, 0x6a, 0xb7, 0x49, 0x05,
+	0x2e, 0x51, 0x52, 0x24, 0x45, 0xe6, 0x73, 0xdb, 0x24, 0x50, 0x51, 0x88, 0x4b, 0xbf, 0xec, 0x8e,
+	0xc4, 0x25, 0xe5, 0x7c, 0x61, 0xc0, 0x7a, 0xc3, 0xb9, 0xf7, 0xb6, 0x78, 0x1f, 0x46, 0xd2, 0x86,
+	0xd4, 0x40, 0x76, 0x87, 0xfb, 0xd6, 0x5e, 0x3a, 0xd9, 0x3b, 0xd6, 0x70, 0xb7, 0x21, 0xc5, 0x3e,
+	0x81, 0x55, 0x51, 0x4c, 0x4e, 0x3c, 0x71, 0xae, 0xb6, 0x75, 0xb6, 0xcd, 0x9d, 0xe1, 0xfe, 0x4d,
+	0xda, 0xa6, 0x33, 0xdc, 0xa6, 0x9c, 0xf3, 0x47, 0x03, 0x86, 0x07, 0x67, 0xdc, 0x57, 0x34, 0x3a,
+	0x9a, 0x7a, 0x42, 0xf0, 0xa0, 0x74, 0x54, 0x52, 0x6c, 0x03, 0xba, 0x79, 0x92, 0x7b, 0x11, 0xb9,
+	0xda, 0x75, 0x25, 0xc1, 0xb6, 0x00, 0x44, 0xe1, 0xfb, 0x5c, 0x88, 0xd3, 0x22, 0x22, 0x57, 0xbb,
+	0xae, 0x86, 0xa0, 0xb6, 0x53, 0x2f, 0x8c, 0x78, 0x40, 0x61, 0xea, 0xba, 0x8a, 0x62, 0x36, 0xac,
+	0x5c, 0x7a, 0x59, 0x1c, 0xc6, 0x53, 0xbb, 0x4b, 0x8c, 0x92, 0xc4, 0x1d, 0x01, 0xcf, 0xbd, 0x30,
+	0xb2, 0x7b, 0xdb, 0xc6, 0xce, 0xc8, 0x55, 0x94, 0xf3, 0x37, 0x03, 0xe0, 0xb0, 0x98, 0xa5, 0xca,
+	0xcd, 0x1d, 0xb8, 0xe1, 0x27, 0xb3, 0x34, 0xe2, 0x39, 0x0f, 0x4e, 0xbc, 0x49, 0xc4, 0x05, 0xf9,
+	0x6b, 0xb8, 0x57, 0x61, 0xf6, 0x21, 0xac, 0x9e, 0x86, 0x71, 0x28, 0xce, 0x78, 0xf0, 0x70, 0x9e,
+	0x73, 0x41, 0x07, 0x30, 0xdc, 0x26, 0xc8, 0x1c, 0x18, 0x95, 0x80, 0x9b, 0x5c, 0xca, 0xa8, 0x1b,
+	0x6e, 0x03, 0x63, 0xdf, 0x86, 0x9b, 0x5c, 0xe4, 0xe1, 0xcc, 0xcb, 0xf9, 0x09, 0x9e, 0x9e, 0x04,
+	0x3b, 0x24, 0x78, 0x9d, 0xc1, 0x36, 0xa1, 0x9f, 0x66, 0xc9, 0x34, 0xe3, 0x42, 0xd0, 0x19, 0x07,
+	0x6e, 0x45, 0x3b, 0x7f, 0x32, 0x00, 0xc6, 0x89, 0x17, 0xa8, 0xc3, 0x5c, 0x73, 0x11, 0x8f, 0x62,
+	0x5e, 0x75, 0x71, 0x0b, 0x80, 0x82, 0x5e, 0x9f, 0xc2, 0x74, 0x35, 0xa4, 0x61, 0xd0, 0x6c, 0x1a,
+	0xc4, 0xbd, 0x33, 0x9e, 0x7b, 0x0f, 0xc3, 0x38, 0x4a, 0xa6, 0x2a, 0x65, 0x35, 0x84, 0xdd, 0x85,
+	0xb5, 0x9a, 0x3a, 0x3a, 0xf9, 0xfc, 0x50, 0xb9, 0x7c, 0x05, 0x75, 0x7e, 0x6b, 0xc0, 0xea, 0xf1,
+	0x99, 0x97, 0x05, 0x61, 0x3c, 0x3d, 0xca, 0x92, 0x22, 0xc5, 0xfb, 0xca, 0xbd, 0x6c, 0xca, 0x73,
+	0x55, 0x78, 0x8a, 0xc2, 0x72, 0x3c, 0x3c, 0x1c, 0xa3, 0x9f, 0x26, 0x96, 0x23, 0xae, 0xe5, 0x39,
+	0x33, 0x91, 0x8f, 0x13, 0xdf, 0xcb, 0xc3, 0x24, 0x56, 0x6e, 0x36, 0x41, 0x2a, 0xb9, 0x79, 0xec,
+	0x53, 0xce, 0x98, 0x54, 0x72, 0x44, 0xe1, 0xf9, 0x8a, 0x58, 0x71, 0xba, 0xc4, 0xa9, 0x68, 0xe7,
+	0x57, 0x1d, 0x80, 0xe3, 0x79, 0xec, 0xab, 0x80, 0x6e, 0xc3, 0x90, 0x02, 0xf3, 0xe8, 0x82, 0xc7,
+	0x79, 0x19, 0x4e, 0x1d, 0x42, 0x65, 0x44, 0x9e, 0xa4, 0x65, 0x28, 0x2b, 0x9a, 0xdd, 0x86, 0x41,
+	0xc6, 0x7d, 0x1e, 0xe7, 0xc8, 0x34, 0x89, 0x59, 0x03, 0x98, 0x29, 0x33, 0x4f, 0xe4, 0x3c, 0x6b,
+	0x04, 0xb3, 0x81, 0xb1, 0x5d, 0xb0, 0x74, 0xfa, 0x28, 0x0f, 0x03, 0x15, 0xd0, 0x6b, 0x38, 0xea,
+	0xa3, 0x43, 0x94, 0xfa, 0x7a, 0x52, 0x9f, 0x8e, 0xa1, 0x3e, 0x9d, 0x26, 0x7d, 0x2b, 0x52, 0xdf,
+	0x55, 0x1c, 0xf5, 0x4d, 0xa2, 0xc4, 0x3f, 0x0f, 0xe3, 0x29, 0x5d, 0x40, 0x9f, 0x42, 0xd5, 0xc0,
+	0xd8, 0xf7, 0xc1, 0x2a, 0xe2, 0x8c, 0x8b, 0x24, 0xba, 0xe0, 0x01, 0xdd, 0xa3, 0xb0, 0x07, 0x5a,
+	0xc3, 0xd0, 0x6f, 0xd8, 0xbd, 0x26, 0xaa, 0xdd, 0x10, 0xc8, 0x1e, 0x21, 0x29, 0xcc, 0xb2, 0x09,
+	0x39, 0x72, 0x32, 0x4f, 0xb9, 0x3d, 0x94, 0x59, 0x56, 0x23, 0xec, 0x63, 0x58, 0x17, 0xdc, 0x4f,
+	0xe2, 0x40, 0x3c, 0xe4, 0x67, 0x61, 0x1c, 0x3c, 0xa1, 0x58, 0xd8, 0x23, 0x0a, 0xf1, 0x22, 0x16,
+	0xbb, 0x0f, 0x70, 0xe1, 0x45, 0x61, 0x20, 0xd3, 0x65, 0x95, 0x5a, 0xe1, 0x06, 0xba, 0xf8, 0xb2,
+	0x42, 0x55, 0x5b, 0xd3, 0xe4, 0x9c, 0x7f, 0x19, 0x60, 0x5d, 0x15, 0xc0, 0x84, 0x9c, 0x25, 0x41,
+	0x35, 0x1f, 0x70, 0x8d, 0x09, 0xa9, 0xb6, 0xa9, 0xb2, 0x97, 0xa9, 0xd0, 0x04, 0x31, 0x9b, 0x52,
+	0x1e, 0x63, 0x40, 0xaa, 0xd6, 0x60, 0xba, 0x3a, 0x84, 0x19, 0xc3, 0xb3, 0x2c, 0xc9, 0xaa, 0x8e,
+	0x60, 0xba, 0x35, 0x80, 0xfb, 0xc5, 0x79, 0x98, 0xa6, 0xca, 0x46, 0x57, 0xee, 0xd7, 0x20, 0xf6,
+	0x2d, 0xe8, 0x91, 0xb8, 0xb0, 0x7b, 0x74, 0x0b, 0xeb, 0xcd, 0x23, 0x3e, 0x22, 0x55, 0x4a, 0xc4,
+	0xf9, 0x83, 0x01, 0x37, 0xae, 0xf0, 0xa8, 0x3b, 0x63, 0xbb, 0x53, 0xa7, 0x93, 0x04, 0x0e, 0x97,
+	0x73, 0x3e, 0x2f, 0x87, 0xcb, 0x39, 0x9f, 0x63, 0xdf, 0xe5, 0x59, 0x46, 0xd7, 0x23, 0x6b, 0xaf,
+	0x24, 0xa9, 0xba, 0x52, 0x91, 0x67, 0xdc, 0x9b, 0xa9, 0x94, 0xae, 0x68, 0xbc, 0xd7, 0x20, 0xb9,
+	0x8c, 0x15, 0x57, 0x26, 0xb2, 0x86, 0x60, 0x68, 0xf3, 0x70, 0xc6, 0x55, 0xea, 0xd2, 0xda, 0xf9,
+	0xbd, 0x01, 0x23, 0x7d, 0x5e, 0x69, 0x93, 0xd4, 0x58, 0x32, 0x49, 0xdb, 0xfa, 0x24, 0x65, 0xdf,
+	0xac, 0x26, 0xa6, 0x9c, 0x80, 0x94, 0x99, 0xcf, 0xb2, 0x04, 0x47, 0x8b, 0x4b, 0x8c, 0x6a, 0x88,
+	0xde, 0x83, 0x61, 0xc6, 0x23, 0x6f, 0x5e, 0x8d, 0x3e, 0x94, 0xbf, 0x81, 0xf2, 0x6e, 0x0d, 0xbb,
+	0xba, 0x8c, 0xf3, 0x97, 0x36, 0x0c, 0x35, 0xe6, 0xb5, 0xaa, 0x36, 0xfe, 0xc7, 0xaa, 0x6e, 0x2f,
+	0xa9, 0xea, 0xed, 0xd2, 0xa5, 0x62, 0x72, 0x18, 0x66, 0x2a, 0xd8, 0x3a, 0x54, 0x49, 0x34, 0xda,
+	0x88, 0x0e, 0xe1, 0x8c, 0xd3, 0x48, 0xad, 0x89, 0x5c, 0x85, 0xd9, 0x1e, 0x30, 0x82, 0x0e, 0xbc,
+	0xdc, 0x3f, 0x7b, 0x91, 0xaa, 0xba, 0xea, 0x51, 0x71, 0x2e, 0xe0, 0xb0, 0x6f, 0x40, 0x57, 0xe4,
+	0xde, 0x94, 0x53, 0x13, 0x59, 0xdb, 0x1f, 0x50, 0xd1, 0x23, 0xe0, 0x4a, 0x5c, 0x0b, 0x7e, 0xff,
+	0x1d, 0xc1, 0x77, 0xfe, 0xd3, 0x86, 0xd5, 0xc6, 0x0b, 0x63, 0xd1, 0x4b, 0xac, 0xb6, 0xd8, 0x5e,
+	0x62, 0x71, 0x1b, 0x3a, 0x45, 0x1c, 0xca, 0xcb, 0x5e, 0xdb, 0x1f, 0x21, 0xff, 0x45, 0x1c, 0xe6,
+	0x98, 0x9b, 0x2e, 0x71, 0x34, 0x9f, 0x3a, 0xef, 0x4a, 0x88, 0x8f, 0x61, 0xbd, 0x6e, 0x5a, 0x87,
+	0x87, 0xe3, 0x71, 0xe2, 0x9f, 0x57, 0x33, 0x6d, 0x11, 0x8b, 0x31, 0xf9, 0x0e, 0xa3, 0x0c, 0x7e,
+	0xdc, 0x92, 0x2f, 0xb1, 0x8f, 0xa0, 0xeb, 0xe3, 0xcb, 0xc8, 0x5e, 0xa9, 0x13, 0x4a, 0x7b, 0x2a,
+	0x3d, 0x6e, 0xb9, 0x92, 0xcf, 0x3e, 0x84, 0x4e, 0x50, 0xcc, 0x52, 0x15, 0xab, 0x35, 0x94, 0xab,
+	0x9f, 0x2a, 0x8f, 0x5b, 0x2e, 0x71, 0x51, 0x2a, 0x4a, 0xbc, 0xc0, 0x1e, 0xd4, 0x52, 0xf5, 0x1b,
+	0x00, 0xa5, 0x90, 0x8b, 0x52, 0xd8, 0x4d, 0x6d, 0xa8, 0xa5, 0xea, 0xc1, 0x86, 0x52, 0xc8, 0x7d,
+	0xd8, 0x87, 0x9e, 0x90, 0x89, 0xfc, 0x03, 0xb8, 0xd9, 0x88, 0xfe, 0x38, 0x14, 0x14, 0x2a, 0xc9,
+	0xb6, 0x8d, 0x65, 0xcf, 0xc0, 0x72, 0xff, 0x16, 0x00, 0x9d, 0x49, 0xf6, 0x11, 0xf5, 0x1c, 0x35,
+	0xaa, 0xe7, 0xa8, 0xf3, 0x75, 0x18, 0xe0, 0x59, 0xde, 0xc2, 0xc6, 0x43, 0x2c, 0x63, 0xa7, 0x30,
+	0x22, 0xef, 0x9f, 0x8f, 0x97, 0x48, 0xb0, 0x7d, 0xd8, 0x90, 0x6f, 0x42, 0x99, 0xce, 0xcf, 0x12,
+	0x11, 0x52, 0xaf, 0x97, 0x85, 0xb5, 0x90, 0x87, 0xbd, 0x8a, 0x7a, 0xe1, 0xf1, 0xf3, 0x71, 0xf9,
+	0xd2, 0x29, 0x69, 0xe7, 0xbb, 0x30, 0x40, 0x8b, 0xd2, 0xdc, 0x4e, 0xd5, 0x57, 0x65, 0x1c, 0xac,
+	0x2a, 0x9c, 0xcf, 0xc7, 0xcd, 0xa6, 0xfa, 0x6b, 0x03, 0x86, 0xb2, 0x5d, 0xc9, 0x9d, 0xef, 0xdb,
+	0xad, 0xb6, 0x1b, 0xdb, 0xcb, 0x7a, 0xd7, 0x35, 0xee, 0x01, 0x50, 0xc3, 0x91, 0x02, 0x9d, 0xfa,
+	0x7a, 0x6b, 0xd4, 0xd5, 0x24, 0xf0, 0x62, 0x6a, 0x6a, 0x41, 0x68, 0x7f, 0xd7, 0x86, 0x91, 0xba,
+	0x52, 0x29, 0xf2, 0x7f, 0x2a, 0x3b, 0x55, 0x19, 0x1d, 0xbd, 0x32, 0xee, 0x96, 0x95, 0xd1, 0xad,
+	0x8f, 0x51, 0x67, 0x51, 0x5d, 0x18, 0x77, 0x54, 0x61, 0xf4, 0x48, 0x6c, 0xb5, 0x2c, 0x8c, 0x52,
+	0x8a, 0x98, 0x28, 0x44, 0x75, 0xb1, 0x52, 0x0b, 0x55, 0x29, 0x55, 0x95, 0xc5, 0x1d, 0x55, 0x16,
+	0xfd, 0x5a, 0xa8, 0xba, 0xe6, 0xaa, 0x2a, 0x56, 0xa0, 0x4b, 0xd7, 0xe9, 0x7c, 0x0a, 0x96, 0x1e,
+	0x1a, 0xaa, 0x89, 0xbb, 0x8a, 0xd9, 0x48, 0x05, 0x4d, 0xc8, 0x55, 0x7b, 0x5f, 0xc1, 0x6a, 0xa3,
+	0xa9, 0xe0, 0xf4, 0x0b, 0xc5, 0x81, 0x17, 0xfb, 0x3c, 0xaa, 0xbe, 0x8a, 0x34, 0x44, 0x4b, 0xb2,
+	0x76, 0xad, 0x59, 0xa9, 0x68, 0x24, 0x99, 0xf6, 0x6d, 0x63, 0x36, 0xbe, 0x6d, 0xfe, 0x6e, 0xc0,
+	0x48, 0xdf, 0x80, 0x63, 0xfa, 0x51, 0x96, 0x1d, 0x94, 0xcf, 0x95, 0xae, 0x5b, 0x92, 0x98, 0xfa,
+	0xb8, 0x8c, 0x3c, 0x21, 0x54, 0x06, 0x56, 0xb4, 0xe2, 0x1d, 0xfb, 0x49, 0x35, 0xdd, 0x2b, 0x5a,
+	0xf1, 0xc6, 0xfc, 0x82, 0x47, 0xe5, 0x78, 0x2f, 0x69, 0xb4, 0xf6, 0x84, 0x0b, 0x81, 0x69, 0x22,
+	0x3b, 0x64, 0x49, 0xe2, 0x2e, 0xd7, 0xbb, 0x3c, 0xf0, 0x0a, 0x51, 0x0e, 0xf7, 0x8a, 0xc6, 0xb0,
+	0xe0, 0x57, 0xb5, 0x97, 0x25, 0x45, 0x5c, 0xbe, 0x46, 0x35, 0xc4, 0xb9, 0x84, 0x9b, 0xcf, 0x8a,
+	0x6c, 0xca, 0x29, 0x89, 0xcb, 0x8f, 0xf4, 0x4d, 0xe8, 0x87, 0xb1, 0xe7, 0xe7, 0xe1, 0x05, 0x57,
+	0x91, 0xac, 0xe8, 0xea, 0x15, 0x21, 0xdf, 0x60, 0xb4, 0x46, 0xf9, 0xd3, 0x30, 0xe2, 0x94, 0xd7,
+	0xea, 0x48, 0x25, 0x4d, 0x25, 0x2a, 0xa7, 0xab, 0xfa, 0x04, 0x97, 0x94, 0xf3, 0x4f, 0x03, 0x36,
+	0x9f, 0xa6, 0x3c, 0xf3, 0x72, 0x2e, 0x3f, 0xfb, 0x8f, 0xfd, 0x33, 0x3e, 0xf3, 0x4a, 0x17, 0x6e,
+	0x43, 0x3b, 0x49, 0x6d, 0xa3, 0xce, 0x77, 0xc9, 0x7e, 0x9a, 0xba, 0xed, 0x24, 0x25, 0x27, 0x3c,
+	0x71, 0xae, 0x62, 0x4b, 0xeb, 0xa5, 0xff, 0x00, 0x36, 0xa1, 0x1f, 0x78, 0xb9, 0x37, 0xf1, 0x04,
+	0x2f, 0x63, 0x5a, 0xd2, 0xf5, 0x83, 0xac, 0xab, 0x3f, 0xc8, 0x50, 0x13, 0x59, 0x53, 0xd1, 0x54,
+	0x14, 0x4a, 0x9f, 0x46, 0x85, 0x38, 0xa3, 0x30, 0xf6, 0x5d, 0x49, 0xa0, 0x2f, 0x55, 0xce, 0xf7,
+	0x65, 0x8a, 0x3b, 0x39, 0xac, 0xbe, 0xbc, 0xa7, 0xd2, 0xf6, 0x09, 0xcf, 0x3d, 0xb6, 0xa9, 0x1d,
+	0x07, 0xf0, 0x38, 0xc8, 0x51, 0x87, 0x79, 0x67, 0xf5, 0x97, 0x2d, 0xc3, 0xd4, 0x5a, 0x46, 0x19,
+	0x81, 0x0e, 0xa5, 0x28, 0xad, 0x9d, 0xfb, 0xb0, 0xa1, 0x22, 0xfa, 0xf2, 0x1e, 0x5a, 0x5d, 0x1a,
+	0x4b, 0xc9, 0x96, 0xe6, 0x9d, 0x3f, 0x1b, 0x70, 0xeb, 0xca, 0xb6, 0xf7, 0xfe, 0x1b, 0xf2, 0x09,
+	0x74, 0xf0, 0x13, 0xd4, 0x36, 0xa9, 0xb4, 0xee, 0xa0, 0x8d, 0x85, 0x2a, 0xf7, 0x90, 0x78, 0x14,
+	0xe7, 0xd9, 0xdc, 0xa5, 0x0d, 0x9b, 0x3f, 0x86, 0x41, 0x05, 0x95, 0x0f, 0x61, 0xa3, 0x7e, 0x08,
+	0x7f, 0x04, 0xdd, 0x0b, 0x2f, 0x2a, 0x64, 0x68, 0xd4, 0x80, 0x6c, 0x04, 0xd6, 0x95, 0xfc, 0x4f,
+	0xdb, 0xdf, 0x33, 0x9c, 0x5f, 0x80, 0xfd, 0xd8, 0x8b, 0x83, 0x48, 0xe5, 0x93, 0x2c, 0x6a, 0x15,
+	0x82, 0xaf, 0x69, 0x21, 0x18, 0xa2, 0x16, 0xe2, 0xbe, 0x25, 0x9b, 0x6e, 0xc3, 0x60, 0x52, 0x8e,
+	0x33, 0x15, 0xf8, 0x1a, 0xa0, 0x3b, 0x7f, 0x15, 0x09, 0xf5, 0xe9, 0x4b, 0x6b, 0xe7, 0x16, 0xac,
+	0x1f, 0xf1, 0x5c, 0xda, 0x3e, 0x38, 0x9d, 0x2a, 0xcb, 0xce, 0x0e, 0x6c, 0x34, 0x61, 0x15, 0x5c,
+	0x0b, 0x4c, 0xff, 0xb4, 0x1a, 0x15, 0xfe, 0xe9, 0x74, 0xf7, 0xe7, 0xd0, 0x93, 0x59, 0xc1, 0x56,
+	0x61, 0xf0, 0x79, 0x4c, 0x5f, 0x37, 0x4f, 0x53, 0xab, 0xc5, 0xfa, 0xd0, 0x39, 0xce, 0x93, 0xd4,
+	0x32, 0xd8, 0x00, 0xba, 0xcf, 0xb0, 0xac, 0xad, 0x36, 0x03, 0xe8, 0x61, 0xe7, 0x9b, 0x71, 0xcb,
+	0x44, 0xf8, 0x38, 0xf7, 0xb2, 0xdc, 0xea, 0x20, 0xfc, 0x22, 0xc5, 0x8f, 0x22, 0xab, 0xcb, 0xd6,
+	0x00, 0x7e, 0x54, 0xe4, 0x89, 0x12, 0xeb, 0xed, 0xfe, 0x92, 0xc4, 0xa6, 0x68, 0x7b, 0xa4, 0xf4,
+	0x13, 0x6d, 0xb5, 0xd8, 0x0a, 0x98, 0x3f, 0xe5, 0x97, 0x96, 0xc1, 0x86, 0xb0, 0xe2, 0x16, 0x31,
+	0xfe, 0xe3, 0x91, 0x36, 0xc8, 0x5c, 0x60, 0x99, 0xc8, 0x40, 0x27, 0x52, 0x1e, 0x58, 0x1d, 0x36,
+	0x82, 0xfe, 0x67, 0xea, 0xaf, 0x87, 0xd5, 0x45, 0x16, 0x8a, 0xe1, 0x9e, 0x1e, 0xb2, 0xc8, 0x20,
+	0x52, 0x2b, 0x48, 0xd1, 0x2e, 0xa4, 0xfa, 0xbb, 0x4f, 0xa1, 0x5f, 0x8e, 0x2d, 0x76, 0x03, 0x86,
+	0xca, 0x07, 0x84, 0xac, 0x16, 0x1e, 0x82, 0x86, 0x93, 0x65, 0xe0, 0x81, 0x71, 0x00, 0x59, 0x6d,
+	0x5c, 0xe1, 0x94, 0xb1, 0x4c, 0x0a, 0xc2, 0x3c, 0xf6, 0xad, 0x0e, 0x0a, 0x52, 0xb7, 0xb2, 0x82,
+	0xdd, 0x27, 0xb0, 0x42, 0xcb, 0xa7, 0x78, 0x89, 0x6b, 0x4a, 0x9f, 0x42, 0xac, 0x16, 0xc6, 0x11,
+	0xad, 0x4b, 0x69, 0x03, 0xe3, 0x41, 0xc7, 0x91, 0x74, 0x1b, 0x5d, 0x90, 0xb1, 0x91, 0x80, 0xb9,
+	0x1b, 0x43, 0xbf, 0x6c, 0x33, 0x6c, 0x1d, 0x6e, 0x94, 0x31, 0x52, 0x90, 0x54, 0x78, 0xc4, 0x73,
+	0x09, 0x58, 0x06, 0xe9, 0xaf, 0xc8, 0x36, 0x86, 0xd5, 0xe5, 0xb3, 0xe4, 0x82, 0x2b, 0xc4, 0x44,
+	0x8b, 0x38, 0xd5, 0x14, 0xdd, 0xc1, 0x0d, 0x48, 0xd3, 0x3f, 0x2f, 0xab, 0xbb, 0xfb, 0x00, 0xfa,
+	0x65, 0x29, 0x6a, 0xf6, 0x4a, 0xa8, 0xb2, 0x27, 0x01, 0xcb, 0xa8, 0x0d, 0x28, 0xa4, 0xbd, 0xfb,
+	0x00, 0x56, 0x54, 0x26, 0x6b, 0x01, 0x50, 0x88, 0xca, 0x9c, 0xf3, 0x30, 0x55, 0xf7, 0xca, 0xd3,
+	0xc8, 0xf3, 0xab, 0xdc, 0xb9, 0xe0, 0x59, 0x6e, 0x99, 0xfb, 0x5f, 0x98, 0xd0, 0x93, 0xd9, 0xc9,
+	0x1e, 0xc0, 0x50, 0xfb, 0x29, 0xca, 0x3e, 0xc0, 0x3a, 0xb9, 0xfe, 0x0b, 0x77, 0xf3, 0x2b, 0xd7,
+	0x70, 0x99, 0xd2, 0x4e, 0x8b, 0xfd, 0x10, 0xa0, 0x9e, 0x26, 0xec, 0x16, 0x8d, 0xd8, 0xab, 0xd3,
+	0x65, 0xd3, 0xa6, 0x77, 0xc8, 0x82, 0x1f, 0xbe, 0x4e, 0x8b, 0xfd, 0x04, 0x56, 0x55, 0xe3, 0x90,
+	0x31, 0x63, 0x5b, 0x5a, 0x2f, 0x59, 0x30, 0x27, 0xde, 0xaa, 0xec, 0xb3, 0x4a, 0x99, 0x8c, 0x17,
+	0xb3, 0x17, 0x34, 0x26, 0xa9, 0xe6, 0xab, 0x4b, 0x5b, 0x96, 0xd3, 0x62, 0x47, 0x30, 0x94, 0x8d,
+	0x45, 0x8e, 0xfd, 0xdb, 0x28, 0xbb, 0xac, 0xd3, 0xbc, 0xd5, 0xa1, 0x03, 0x18, 0xe9, 0xbd, 0x80,
+	0x51, 0x24, 0x17, 0x34, 0x8d, 0x4d, 0xfb, 0x3a, 0xa3, 0x54, 0xf2, 0xd0, 0xfe, 0xeb, 0xeb, 0x2d,
+	0xe3, 0xcb, 0xd7, 0x5b, 0xc6, 0xbf, 0x5f, 0x6f, 0x19, 0xbf, 0x79, 0xb3, 0xd5, 0xfa, 0xf2, 0xcd,
+	0x56, 0xeb, 0x1f, 0x6f, 0xb6, 0x5a, 0x93, 0x1e, 0xfd, 0x7c, 0xff, 0xce, 0x7f, 0x07, 0x00, 0xda,
+	0x5e, 0x05, 0xc7, 0x8e, 0x17, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3169,6 +3402,37 @@ func (m *DumpStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Progress) > 0 {
+		i -= len(m.Progress)
+		copy(dAtA[i:], m.Progress)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Progress)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.EstimateTotalRows != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.EstimateTotalRows))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.FinishedRows != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.FinishedRows))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.FinishedBytes != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.FinishedBytes))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.CompletedTables != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.CompletedTables))))
+		i--
+		dAtA[i] = 0x9
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -3310,6 +3574,18 @@ func (m *SyncStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Validation != nil {
+		{
+			size, err := m.Validation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDmworker(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
 	if m.SecondsBehindMaster != 0 {
 		i = encodeVarintDmworker(dAtA, i, uint64(m.SecondsBehindMaster))
 		i--
@@ -3401,7 +3677,7 @@ func (m *SyncStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *SourceStatus) Marshal() (dAtA []byte, err error) {
+func (m *ValidationStatus) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3411,51 +3687,180 @@ func (m *SourceStatus) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *SourceStatus) MarshalTo(dAtA []byte) (int, error) {
+func (m *ValidationStatus) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *SourceStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ValidationStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.RelayStatus != nil {
-		{
-			size, err := m.RelayStatus.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if len(m.Errors) > 0 {
+		for iNdEx := len(m.Errors) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Errors[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDmworker(dAtA, i, uint64(size))
 			}
-			i -= size
-			i = encodeVarintDmworker(dAtA, i, uint64(size))
+			i--
+			dAtA[i] = 0x32
 		}
+	}
+	if m.SkippedRows != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.SkippedRows))
 		i--
-		dAtA[i] = 0x22
+		dAtA[i] = 0x28
 	}
-	if m.Result != nil {
-		{
-			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintDmworker(dAtA, i, uint64(size))
-		}
+	if m.ErrorRows != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.ErrorRows))
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x20
 	}
-	if len(m.Worker) > 0 {
-		i -= len(m.Worker)
-		copy(dAtA[i:], m.Worker)
-		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Worker)))
+	if m.PendingRows != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.PendingRows))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x18
 	}
-	if len(m.Source) > 0 {
-		i -= len(m.Source)
-		copy(dAtA[i:], m.Source)
-		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Source)))
+	if m.ValidatedRows != 0 {
+		i = encodeVarintDmworker(dAtA, i, uint64(m.ValidatedRows))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Mode) > 0 {
+		i -= len(m.Mode)
+		copy(dAtA[i:], m.Mode)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Mode)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ValidationError) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ValidationError) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ValidationError) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Time) > 0 {
+		i -= len(m.Time)
+		copy(dAtA[i:], m.Time)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Time)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.Downstream) > 0 {
+		i -= len(m.Downstream)
+		copy(dAtA[i:], m.Downstream)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Downstream)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Upstream) > 0 {
+		i -= len(m.Upstream)
+		copy(dAtA[i:], m.Upstream)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Upstream)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ErrType) > 0 {
+		i -= len(m.ErrType)
+		copy(dAtA[i:], m.ErrType)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.ErrType)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Table) > 0 {
+		i -= len(m.Table)
+		copy(dAtA[i:], m.Table)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Table)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SourceStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SourceStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SourceStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RelayStatus != nil {
+		{
+			size, err := m.RelayStatus.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDmworker(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Result != nil {
+		{
+			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDmworker(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Worker) > 0 {
+		i -= len(m.Worker)
+		copy(dAtA[i:], m.Worker)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Worker)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintDmworker(dAtA, i, uint64(len(m.Source)))
 		i--
 		dAtA[i] = 0xa
 	}
@@ -4803,6 +5208,22 @@ func (m *DumpStatus) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if m.CompletedTables != 0 {
+		n += 9
+	}
+	if m.FinishedBytes != 0 {
+		n += 9
+	}
+	if m.FinishedRows != 0 {
+		n += 9
+	}
+	if m.EstimateTotalRows != 0 {
+		n += 9
+	}
+	l = len(m.Progress)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
 	return n
 }
 
@@ -4921,6 +5342,74 @@ func (m *SyncStatus) Size() (n int) {
 	if m.SecondsBehindMaster != 0 {
 		n += 1 + sovDmworker(uint64(m.SecondsBehindMaster))
 	}
+	if m.Validation != nil {
+		l = m.Validation.Size()
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	return n
+}
+
+func (m *ValidationStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Mode)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	if m.ValidatedRows != 0 {
+		n += 1 + sovDmworker(uint64(m.ValidatedRows))
+	}
+	if m.PendingRows != 0 {
+		n += 1 + sovDmworker(uint64(m.PendingRows))
+	}
+	if m.ErrorRows != 0 {
+		n += 1 + sovDmworker(uint64(m.ErrorRows))
+	}
+	if m.SkippedRows != 0 {
+		n += 1 + sovDmworker(uint64(m.SkippedRows))
+	}
+	if len(m.Errors) > 0 {
+		for _, e := range m.Errors {
+			l = e.Size()
+			n += 1 + l + sovDmworker(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ValidationError) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Table)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.ErrType)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.Upstream)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.Downstream)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
+	l = len(m.Time)
+	if l > 0 {
+		n += 1 + l + sovDmworker(uint64(l))
+	}
 	return n
 }
 
@@ -6165,6 +6654,82 @@ func (m *DumpStatus) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: DumpStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompletedTables", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.CompletedTables = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinishedBytes", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.FinishedBytes = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinishedRows", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.FinishedRows = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EstimateTotalRows", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.EstimateTotalRows = float64(math.Float64frombits(v))
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Progress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Progress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -6931,6 +7496,476 @@ func (m *SyncStatus) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Validation == nil {
+				m.Validation = &ValidationStatus{}
+			}
+			if err := m.Validation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidationStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidationStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidationStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mode", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mode = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatedRows", wireType)
+			}
+			m.ValidatedRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValidatedRows |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PendingRows", wireType)
+			}
+			m.PendingRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PendingRows |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorRows", wireType)
+			}
+			m.ErrorRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ErrorRows |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SkippedRows", wireType)
+			}
+			m.SkippedRows = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SkippedRows |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Errors = append(m.Errors, &ValidationError{})
+			if err := m.Errors[len(m.Errors)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDmworker(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ValidationError) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDmworker
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ValidationError: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ValidationError: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Table", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Table = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Upstream", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Upstream = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Downstream", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Downstream = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDmworker
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDmworker
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Time = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDmworker(dAtA[iNdEx:])
@@ -150,9 +150,9 @@ func (d *DMTaskConfig) Verify() error {
 	switch d.TaskMode {
 	case "":
 		return errors.New("task mode should not be empty")
-	case config.ModeAll, config.ModeFull, config.ModeIncrement:
+	case config.ModeAll, config.ModeFull, config.ModeIncrement, config.ModeDump, config.ModeLoad, config.ModeLoadSync:
 	default:
-		return errors.New("task mode should be 'all', 'full' or 'incremental'")
+		return errors.New("task mode should be 'all', 'full', 'incremental', 'dump', 'load' or 'load-sync'")
 	}
 
 	for _, mysqlInstance := range d.MySQLInstances {
@@ -38,6 +38,7 @@ var SampleConfigFile string
 var (
 	defaultKeepAliveTTL      = int64(60)      // 1 minute
 	defaultRelayKeepAliveTTL = int64(60 * 30) // 30 minutes
+	defaultMaxSources        = 1
 )
 
 func init() {
@@ -71,6 +72,7 @@ func NewConfig() *Config {
 	fs.StringVar(&cfg.Name, "name", "", "human-readable name for DM-worker member")
 	fs.Int64Var(&cfg.KeepAliveTTL, "keepalive-ttl", defaultKeepAliveTTL, "dm-worker's TTL for keepalive with etcd (in seconds)")
 	fs.Int64Var(&cfg.RelayKeepAliveTTL, "relay-keepalive-ttl", defaultRelayKeepAliveTTL, "dm-worker's TTL for keepalive with etcd when handle relay enabled sources (in seconds)")
+	fs.IntVar(&cfg.MaxSources, "max-sources", defaultMaxSources, "the max number of upstream sources served by the dm-worker concurrently")
 
 	fs.StringVar(&cfg.SSLCA, "ssl-ca", "", "path of file that contains list of trusted SSL CAs for connection")
 	fs.StringVar(&cfg.SSLCert, "ssl-cert", "", "path of file that contains X509 certificate in PEM format for connection")
@@ -99,6 +101,10 @@ type Config struct {
 	KeepAliveTTL      int64 `toml:"keepalive-ttl" json:"keepalive-ttl"`
 	RelayKeepAliveTTL int64 `toml:"relay-keepalive-ttl" json:"relay-keepalive-ttl"`
 
+	// MaxSources is the max number of upstream sources served concurrently. the process registers itself as
+	// MaxSources DM-workers named by `name`, `name#1`, ..., each of which is bound to at most one source.
+	MaxSources int `toml:"max-sources" json:"max-sources"`
+
 	// tls config
 	config.Security
 
@@ -206,9 +212,22 @@ func (c *Config) adjust() error {
 		c.Join = utils.WrapSchemes(c.Join, c.SSLCA != "")
 	}
 
+	if c.MaxSources < 1 {
+		return terror.ErrWorkerInvalidFlag.Generatef("max-sources should be positive, got %d", c.MaxSources)
+	}
+
 	return nil
 }
 
+// slotNames returns the names of the DM-workers served by the process, the first one is the name of the process.
+func (c *Config) slotNames() []string {
+	names := []string{c.Name}
+	for i := 1; i < c.MaxSources; i++ {
+		names = append(names, fmt.Sprintf("%s#%d", c.Name, i))
+	}
+	return names
+}
+
 // configFromFile loads config from file.
 func (c *Config) configFromFile(path string) error {
 	metaData, err := toml.DecodeFile(path, c)
@@ -64,3 +64,18 @@ func (t *testConfigSuite) TestPrintSampleConfig(c *check.C) {
 	})
 	c.Assert(strings.TrimSpace(out), check.Equals, strings.TrimSpace(string(buf)))
 }
+
+func (t *testConfigSuite) TestMaxSources(c *check.C) {
+	cfg := NewConfig()
+	c.Assert(cfg.configFromFile(defaultConfigFile), check.IsNil)
+	c.Assert(cfg.adjust(), check.IsNil)
+	c.Assert(cfg.MaxSources, check.Equals, 1)
+	c.Assert(cfg.slotNames(), check.DeepEquals, []string{cfg.Name})
+
+	cfg.MaxSources = 3
+	c.Assert(cfg.adjust(), check.IsNil)
+	c.Assert(cfg.slotNames(), check.DeepEquals, []string{cfg.Name, cfg.Name + "#1", cfg.Name + "#2"})
+
+	cfg.MaxSources = 0
+	c.Assert(terror.ErrWorkerInvalidFlag.Equal(cfg.adjust()), check.IsTrue)
+}
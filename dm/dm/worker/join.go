@@ -39,7 +39,7 @@ func GetJoinURLs(addrs string) []string {
 // JoinMaster let dm-worker join the cluster with the specified master endpoints.
 func (s *Server) JoinMaster(endpoints []string) error {
 	// TODO: grpc proxy
-	tls, err := toolutils.NewTLS(s.cfg.SSLCA, s.cfg.SSLCert, s.cfg.SSLKey, s.cfg.AdvertiseAddr, s.cfg.CertAllowedCN)
+	tls, err := utils.NewTLS(s.cfg.SSLCA, s.cfg.SSLCert, s.cfg.SSLKey, s.cfg.AdvertiseAddr, s.cfg.CertAllowedCN)
 	if err != nil {
 		return terror.ErrWorkerTLSConfigNotValid.Delegate(err)
 	}
@@ -48,11 +48,21 @@ func (s *Server) JoinMaster(endpoints []string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	req := &pb.RegisterWorkerRequest{
-		Name:    s.cfg.Name,
-		Address: s.cfg.AdvertiseAddr,
+	var errorStr string
+	for _, name := range s.cfg.slotNames() {
+		req := &pb.RegisterWorkerRequest{
+			Name:    name,
+			Address: s.cfg.AdvertiseAddr,
+		}
+		if errorStr = s.registerWorker(ctx, tls, endpoints, req); errorStr != "" {
+			return terror.ErrWorkerFailConnectMaster.Generate(endpoints, errorStr)
+		}
 	}
+	return nil
+}
 
+// registerWorker registers the DM-worker to one of the DM-master endpoints, returns the last error message if failed.
+func (s *Server) registerWorker(ctx context.Context, tls *toolutils.TLS, endpoints []string, req *pb.RegisterWorkerRequest) string {
 	var errorStr string
 	for _, endpoint := range endpoints {
 		ctx1, cancel1 := context.WithTimeout(ctx, 3*time.Second)
@@ -82,9 +92,9 @@ func (s *Server) JoinMaster(endpoints []string) error {
 			errorStr = resp.Msg
 			continue
 		}
-		return nil
+		return ""
 	}
-	return terror.ErrWorkerFailConnectMaster.Generate(endpoints, errorStr)
+	return errorStr
 }
 
 // KeepAlive attempts to keep the lease of the server alive forever.
@@ -101,7 +111,7 @@ func (s *Server) KeepAlive() {
 		})
 
 		{
-			err1 := ha.KeepAlive(s.kaCtx, s.etcdClient, s.cfg.Name, s.cfg.KeepAliveTTL)
+			err1 := ha.KeepAliveWithUpdateCh(s.kaCtx, s.etcdClient, s.cfg.Name, s.cfg.KeepAliveTTL, s.kaUpdateCh)
 			log.L().Warn("keepalive with master goroutine paused", zap.Error(err1))
 		}
 
@@ -125,6 +135,6 @@ func (s *Server) KeepAlive() {
 
 // UpdateKeepAliveTTL updates keepalive key with new lease TTL in place, to avoid watcher observe a DELETE event.
 func (s *Server) UpdateKeepAliveTTL(newTTL int64) {
-	ha.KeepAliveUpdateCh <- newTTL
+	s.kaUpdateCh <- newTTL
 	log.L().Debug("received update keepalive TTL request, should be updated soon", zap.Int64("new ttl", newTTL))
 }
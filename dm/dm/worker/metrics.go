@@ -15,9 +15,11 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"net/http/pprof"
+	"strconv"
 	"time"
 
 	cpu "github.com/pingcap/tidb-tools/pkg/utils"
@@ -25,13 +27,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/pingcap/ticdc/dm/dm/common"
+	"github.com/pingcap/ticdc/dm/dm/pb"
 	"github.com/pingcap/ticdc/dm/dumpling"
 	"github.com/pingcap/ticdc/dm/loader"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/metricsproxy"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 	"github.com/pingcap/ticdc/dm/pkg/utils"
 	"github.com/pingcap/ticdc/dm/relay"
 	syncer "github.com/pingcap/ticdc/dm/syncer/metrics"
+	"github.com/pingcap/ticdc/pkg/hotkey"
 )
 
 const (
@@ -78,6 +83,88 @@ func (h *statusHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// defaultHotKeysLimit is the default number of hot keys of each source.
+const defaultHotKeysLimit = 10
+
+// hotKeysHandler reports the keys which most frequently cause causality conflicts in the syncers.
+type hotKeysHandler struct{}
+
+func (h *hotKeysHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	limit := defaultHotKeysLimit
+	if limitStr := req.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			http.Error(w, "invalid limit: "+limitStr, http.StatusBadRequest)
+			return
+		}
+	}
+	data, err := json.Marshal(hotkey.Reports(limit))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil && !common.IsErrNetClosing(err) {
+		log.L().Error("fail to write hot keys response", log.ShortError(err))
+	}
+}
+
+// relayPurgeDryRunHandler reports the relay log files which would be purged, without purging them.
+// the query args are the same as `purge-relay`, and the purge strategies used in the background are checked if no args specified.
+type relayPurgeDryRunHandler struct {
+	s *Server
+}
+
+func (h *relayPurgeDryRunHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+	purgeReq := &pb.PurgeRelayRequest{
+		Filename: query.Get("filename"),
+		SubDir:   query.Get("sub-dir"),
+	}
+	if inactiveStr := query.Get("inactive"); inactiveStr != "" {
+		inactive, err := strconv.ParseBool(inactiveStr)
+		if err != nil {
+			http.Error(w, "invalid inactive: "+inactiveStr, http.StatusBadRequest)
+			return
+		}
+		purgeReq.Inactive = inactive
+	}
+	if timeStr := query.Get("time"); timeStr != "" {
+		t, err := strconv.ParseInt(timeStr, 10, 64)
+		if err != nil || t <= 0 {
+			http.Error(w, "invalid time: "+timeStr, http.StatusBadRequest)
+			return
+		}
+		purgeReq.Time = t
+	}
+
+	sw := h.s.getWorker(true)
+	if sw == nil {
+		http.Error(w, terror.ErrWorkerNoStart.Generate().Error(), http.StatusBadRequest)
+		return
+	}
+	files, err := sw.DryRunPurgeRelay(purgeReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if files == nil {
+		files = []string{}
+	}
+	data, err := json.Marshal(files)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil && !common.IsErrNetClosing(err) {
+		log.L().Error("fail to write relay purge dry-run response", log.ShortError(err))
+	}
+}
+
 // Note: handle error inside the function with returning it.
 func (s *Server) collectMetrics() {
 	// CPU usage metric
@@ -119,9 +206,11 @@ func RegistryMetrics() {
 }
 
 // InitStatus initializes the HTTP status server.
-func InitStatus(lis net.Listener) {
+func InitStatus(lis net.Listener, s *Server) {
 	mux := http.NewServeMux()
 	mux.Handle("/status", &statusHandler{})
+	mux.Handle("/hot-keys", &hotKeysHandler{})
+	mux.Handle("/relay/purge/dry-run", &relayPurgeDryRunHandler{s: s})
 	mux.Handle("/metrics", promhttp.Handler())
 
 	mux.HandleFunc("/debug/pprof/", pprof.Index)
@@ -34,12 +34,12 @@ import (
 	"github.com/pingcap/ticdc/dm/syncer"
 
 	"github.com/pingcap/errors"
-	toolutils "github.com/pingcap/tidb-tools/pkg/utils"
 	"github.com/soheilhy/cmux"
 	"go.etcd.io/etcd/clientv3"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 var (
@@ -67,8 +67,13 @@ type Server struct {
 
 	kaCtx    context.Context
 	kaCancel context.CancelFunc
+	// kaUpdateCh notifies the keepalive TTL changing of this server.
+	kaUpdateCh chan int64
 
 	cfg *Config
+	// slots are the other DM-workers served by this process when max-sources is greater than 1, each of them
+	// is bound to at most one source. they share the listener and the etcd client of this server.
+	slots []*Server
 
 	rootLis    net.Listener
 	svr        *grpc.Server
@@ -82,7 +87,20 @@ type Server struct {
 // NewServer creates a new Server.
 func NewServer(cfg *Config) *Server {
 	s := Server{
-		cfg: cfg,
+		cfg:        cfg,
+		kaUpdateCh: ha.KeepAliveUpdateCh,
+	}
+	s.closed.Store(true) // not start yet
+	return &s
+}
+
+// newSlotServer creates a Server serving another DM-worker of the process with the name.
+func newSlotServer(cfg *Config, name string) *Server {
+	slotCfg := cfg.Clone()
+	slotCfg.Name = name
+	s := Server{
+		cfg:        slotCfg,
+		kaUpdateCh: make(chan int64, 10),
 	}
 	s.closed.Store(true) // not start yet
 	return &s
@@ -94,7 +112,7 @@ func (s *Server) Start() error {
 	log.L().Info("starting dm-worker server")
 	RegistryMetrics()
 	s.ctx, s.cancel = context.WithCancel(context.Background())
-	tls, err := toolutils.NewTLS(s.cfg.SSLCA, s.cfg.SSLCert, s.cfg.SSLKey, s.cfg.AdvertiseAddr, s.cfg.CertAllowedCN)
+	tls, err := utils.NewTLS(s.cfg.SSLCA, s.cfg.SSLCert, s.cfg.SSLKey, s.cfg.AdvertiseAddr, s.cfg.CertAllowedCN)
 	if err != nil {
 		return terror.ErrWorkerTLSConfigNotValid.Delegate(err)
 	}
@@ -116,8 +134,6 @@ func (s *Server) Start() error {
 		return err
 	}
 
-	s.setWorker(nil, true)
-
 	s.wg.Add(1)
 	go func() {
 		s.runBackgroundJob(s.ctx)
@@ -130,54 +146,22 @@ func (s *Server) Start() error {
 		s.wg.Done()
 	}()
 
-	s.startKeepAlive()
-
-	relaySource, revRelay, err := ha.GetRelayConfig(s.etcdClient, s.cfg.Name)
-	if err != nil {
-		return err
-	}
-	if relaySource != nil {
-		log.L().Warn("worker has been assigned relay before keepalive", zap.String("relay source", relaySource.SourceID))
-		if err2 := s.enableRelay(relaySource, true); err2 != nil {
-			return err2
-		}
-	}
-
-	s.wg.Add(1)
-	go func(ctx context.Context) {
-		defer s.wg.Done()
-		// TODO: handle fatal error from observeRelayConfig
-		//nolint:errcheck
-		s.observeRelayConfig(ctx, revRelay)
-	}(s.ctx)
-
-	bound, sourceCfg, revBound, err := ha.GetSourceBoundConfig(s.etcdClient, s.cfg.Name)
-	if err != nil {
+	if err = s.startHandleSources(); err != nil {
 		return err
 	}
-	if !bound.IsEmpty() {
-		log.L().Warn("worker has been assigned source before keepalive", zap.Stringer("bound", bound), zap.Bool("is deleted", bound.IsDeleted))
-		if err2 := s.enableHandleSubtasks(sourceCfg, true); err2 != nil {
-			return err2
+	for _, name := range s.cfg.slotNames()[1:] {
+		slot := newSlotServer(s.cfg, name)
+		slot.ctx, slot.cancel = context.WithCancel(s.ctx)
+		slot.etcdClient = s.etcdClient
+		s.slots = append(s.slots, slot)
+		if err = slot.startHandleSources(); err != nil {
+			return err
 		}
-		log.L().Info("started to handle mysql source", zap.String("sourceCfg", sourceCfg.String()))
+		slot.closed.Store(false)
 	}
 
-	s.wg.Add(1)
-	go func(ctx context.Context) {
-		defer s.wg.Done()
-		for {
-			err1 := s.observeSourceBound(ctx, revBound)
-			if err1 == nil {
-				return
-			}
-			s.restartKeepAlive()
-		}
-	}(s.ctx)
-
 	// create a cmux
 	m := cmux.New(s.rootLis)
-
 	m.SetReadTimeout(cmuxReadTimeout) // set a timeout, ref: https://github.com/pingcap/tidb-binlog/pull/352
 
 	// match connections in order: first gRPC, then HTTP
@@ -215,7 +199,7 @@ func (s *Server) Start() error {
 	httpExitCh := make(chan struct{}, 1)
 	s.wg.Add(1)
 	go func() {
-		InitStatus(httpL) // serve status
+		InitStatus(httpL, s) // serve status
 		httpExitCh <- struct{}{}
 	}()
 	go func(ctx context.Context) {
@@ -241,6 +225,58 @@ func (s *Server) Start() error {
 	return terror.ErrWorkerStartService.Delegate(err)
 }
 
+// startHandleSources starts to keep alive with DM-master, and handles the relay and the source bound to the
+// DM-worker named by the server.
+func (s *Server) startHandleSources() error {
+	s.setWorker(nil, true)
+
+	s.startKeepAlive()
+
+	relaySource, revRelay, err := ha.GetRelayConfig(s.etcdClient, s.cfg.Name)
+	if err != nil {
+		return err
+	}
+	if relaySource != nil {
+		log.L().Warn("worker has been assigned relay before keepalive", zap.String("relay source", relaySource.SourceID))
+		if err2 := s.enableRelay(relaySource, true); err2 != nil {
+			return err2
+		}
+	}
+
+	s.wg.Add(1)
+	go func(ctx context.Context) {
+		defer s.wg.Done()
+		// TODO: handle fatal error from observeRelayConfig
+		//nolint:errcheck
+		s.observeRelayConfig(ctx, revRelay)
+	}(s.ctx)
+
+	bound, sourceCfg, revBound, err := ha.GetSourceBoundConfig(s.etcdClient, s.cfg.Name)
+	if err != nil {
+		return err
+	}
+	if !bound.IsEmpty() {
+		log.L().Warn("worker has been assigned source before keepalive", zap.Stringer("bound", bound), zap.Bool("is deleted", bound.IsDeleted))
+		if err2 := s.enableHandleSubtasks(sourceCfg, true); err2 != nil {
+			return err2
+		}
+		log.L().Info("started to handle mysql source", zap.String("sourceCfg", sourceCfg.String()))
+	}
+
+	s.wg.Add(1)
+	go func(ctx context.Context) {
+		defer s.wg.Done()
+		for {
+			err1 := s.observeSourceBound(ctx, revBound)
+			if err1 == nil {
+				return
+			}
+			s.restartKeepAlive()
+		}
+	}(s.ctx)
+	return nil
+}
+
 // worker keepalive with master
 // If worker loses connect from master, it would stop all task and try to connect master again.
 func (s *Server) startKeepAlive() {
@@ -500,10 +536,30 @@ func (s *Server) doClose() {
 
 // Close close the RPC server, this function can be called multiple times.
 func (s *Server) Close() {
+	for _, slot := range s.slots {
+		slot.Close()
+	}
 	s.stopKeepAlive()
 	s.doClose()
 }
 
+// slotOf returns the server of the DM-worker which the request is sent to, DM-master carries the name of the
+// DM-worker in the metadata of the request.
+func (s *Server) slotOf(ctx context.Context) *Server {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return s
+	}
+	for _, name := range md.Get(common.WorkerNameMetadataKey) {
+		for _, slot := range s.slots {
+			if slot.cfg.Name == name {
+				return slot
+			}
+		}
+	}
+	return s
+}
+
 // if needLock is false, we should make sure Server has been locked in caller.
 func (s *Server) getWorker(needLock bool) *SourceWorker {
 	if needLock {
@@ -776,6 +832,9 @@ func (s *Server) disableRelay(source string) error {
 
 // QueryStatus implements WorkerServer.QueryStatus.
 func (s *Server) QueryStatus(ctx context.Context, req *pb.QueryStatusRequest) (*pb.QueryStatusResponse, error) {
+	if slot := s.slotOf(ctx); slot != s {
+		return slot.QueryStatus(ctx, req)
+	}
 	log.L().Info("", zap.String("request", "QueryStatus"), zap.Stringer("payload", req))
 
 	sourceStatus := s.getSourceStatus(true)
@@ -806,6 +865,9 @@ func (s *Server) QueryStatus(ctx context.Context, req *pb.QueryStatusRequest) (*
 
 // PurgeRelay implements WorkerServer.PurgeRelay.
 func (s *Server) PurgeRelay(ctx context.Context, req *pb.PurgeRelayRequest) (*pb.CommonWorkerResponse, error) {
+	if slot := s.slotOf(ctx); slot != s {
+		return slot.PurgeRelay(ctx, req)
+	}
 	log.L().Info("", zap.String("request", "PurgeRelay"), zap.Stringer("payload", req))
 	w := s.getWorker(true)
 	if w == nil {
@@ -822,6 +884,9 @@ func (s *Server) PurgeRelay(ctx context.Context, req *pb.PurgeRelayRequest) (*pb
 
 // OperateSchema operates schema for an upstream table.
 func (s *Server) OperateSchema(ctx context.Context, req *pb.OperateWorkerSchemaRequest) (*pb.CommonWorkerResponse, error) {
+	if slot := s.slotOf(ctx); slot != s {
+		return slot.OperateSchema(ctx, req)
+	}
 	log.L().Info("", zap.String("request", "OperateSchema"), zap.Stringer("payload", req))
 
 	w := s.getWorker(true)
@@ -891,9 +956,9 @@ func makeCommonWorkerResponse(reqErr error) *pb.CommonWorkerResponse {
 
 // all subTask in subTaskCfgs should have same source
 // this function return the min location in all subtasks, used for relay's location.
-func getMinLocInAllSubTasks(ctx context.Context, subTaskCfgs map[string]config.SubTaskConfig) (minLoc *binlog.Location, err error) {
+func getMinLocInAllSubTasks(ctx context.Context, cli *clientv3.Client, subTaskCfgs map[string]config.SubTaskConfig) (minLoc *binlog.Location, err error) {
 	for _, subTaskCfg := range subTaskCfgs {
-		loc, err := getMinLocForSubTaskFunc(ctx, subTaskCfg)
+		loc, err := getMinLocForSubTaskFunc(ctx, cli, subTaskCfg)
 		if err != nil {
 			return nil, err
 		}
@@ -912,8 +977,8 @@ func getMinLocInAllSubTasks(ctx context.Context, subTaskCfgs map[string]config.S
 	return minLoc, nil
 }
 
-func getMinLocForSubTask(ctx context.Context, subTaskCfg config.SubTaskConfig) (minLoc *binlog.Location, err error) {
-	if subTaskCfg.Mode == config.ModeFull {
+func getMinLocForSubTask(ctx context.Context, cli *clientv3.Client, subTaskCfg config.SubTaskConfig) (minLoc *binlog.Location, err error) {
+	if subTaskCfg.Mode != config.ModeAll && subTaskCfg.Mode != config.ModeIncrement && subTaskCfg.Mode != config.ModeLoadSync {
 		return nil, nil
 	}
 	subTaskCfg2, err := subTaskCfg.DecryptPassword()
@@ -922,7 +987,7 @@ func getMinLocForSubTask(ctx context.Context, subTaskCfg config.SubTaskConfig) (
 	}
 
 	tctx := tcontext.NewContext(ctx, log.L())
-	checkpoint := syncer.NewRemoteCheckPoint(tctx, subTaskCfg2, subTaskCfg2.SourceID)
+	checkpoint := syncer.NewRemoteCheckPoint(tctx, subTaskCfg2, cli, subTaskCfg2.SourceID)
 	err = checkpoint.Init(tctx)
 	if err != nil {
 		return nil, errors.Annotate(err, "get min position from checkpoint")
@@ -940,6 +1005,9 @@ func getMinLocForSubTask(ctx context.Context, subTaskCfg config.SubTaskConfig) (
 
 // HandleError handle error.
 func (s *Server) HandleError(ctx context.Context, req *pb.HandleWorkerErrorRequest) (*pb.CommonWorkerResponse, error) {
+	if slot := s.slotOf(ctx); slot != s {
+		return slot.HandleError(ctx, req)
+	}
 	log.L().Info("", zap.String("request", "HandleError"), zap.Stringer("payload", req))
 
 	w := s.getWorker(true)
@@ -959,6 +1027,9 @@ func (s *Server) HandleError(ctx context.Context, req *pb.HandleWorkerErrorReque
 
 // GetWorkerCfg get worker config.
 func (s *Server) GetWorkerCfg(ctx context.Context, req *pb.GetWorkerCfgRequest) (*pb.GetWorkerCfgResponse, error) {
+	if slot := s.slotOf(ctx); slot != s {
+		return slot.GetWorkerCfg(ctx, req)
+	}
 	log.L().Info("", zap.String("request", "GetWorkerCfg"), zap.Stringer("payload", req))
 	var err error
 	resp := &pb.GetWorkerCfgResponse{}
@@ -563,7 +563,7 @@ func (t *testServer) TestGetMinLocInAllSubTasks(c *C) {
 		"test3": {Name: "test3"},
 		"test1": {Name: "test1"},
 	}
-	minLoc, err := getMinLocInAllSubTasks(context.Background(), subTaskCfg)
+	minLoc, err := getMinLocInAllSubTasks(context.Background(), nil, subTaskCfg)
 	c.Assert(err, IsNil)
 	c.Assert(minLoc.Position.Name, Equals, "mysql-binlog.00001")
 	c.Assert(minLoc.Position.Pos, Equals, uint32(12))
@@ -573,13 +573,13 @@ func (t *testServer) TestGetMinLocInAllSubTasks(c *C) {
 		subTaskCfg[k] = cfg
 	}
 
-	minLoc, err = getMinLocInAllSubTasks(context.Background(), subTaskCfg)
+	minLoc, err = getMinLocInAllSubTasks(context.Background(), nil, subTaskCfg)
 	c.Assert(err, IsNil)
 	c.Assert(minLoc.Position.Name, Equals, "mysql-binlog.00001")
 	c.Assert(minLoc.Position.Pos, Equals, uint32(123))
 }
 
-func getFakeLocForSubTask(ctx context.Context, subTaskCfg config.SubTaskConfig) (minLoc *binlog.Location, err error) {
+func getFakeLocForSubTask(ctx context.Context, cli *clientv3.Client, subTaskCfg config.SubTaskConfig) (minLoc *binlog.Location, err error) {
 	gset1, _ := gtid.ParserGTID(mysql.MySQLFlavor, "ba8f633f-1f15-11eb-b1c7-0242ac110001:1-30")
 	gset2, _ := gtid.ParserGTID(mysql.MySQLFlavor, "ba8f633f-1f15-11eb-b1c7-0242ac110001:1-50")
 	gset3, _ := gtid.ParserGTID(mysql.MySQLFlavor, "ba8f633f-1f15-11eb-b1c7-0242ac110001:1-50,ba8f633f-1f15-11eb-b1c7-0242ac110002:1")
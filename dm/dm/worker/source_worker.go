@@ -303,7 +303,7 @@ func (w *SourceWorker) EnableRelay() (err error) {
 
 	dctx, dcancel := context.WithTimeout(w.etcdClient.Ctx(), time.Duration(len(subTaskCfgs)*3)*time.Second)
 	defer dcancel()
-	minLoc, err1 := getMinLocInAllSubTasks(dctx, subTaskCfgs)
+	minLoc, err1 := getMinLocInAllSubTasks(dctx, w.etcdClient, subTaskCfgs)
 	if err1 != nil {
 		w.l.Error("meet error when EnableRelay", zap.Error(err1))
 	}
@@ -915,7 +915,7 @@ func (w *SourceWorker) PurgeRelay(ctx context.Context, req *pb.PurgeRelayRequest
 			return err
 		}
 		for _, subTaskCfg := range subTaskCfgs {
-			loc, err2 := getMinLocForSubTaskFunc(ctx, subTaskCfg)
+			loc, err2 := getMinLocForSubTaskFunc(ctx, w.etcdClient, subTaskCfg)
 			if err2 != nil {
 				return err2
 			}
@@ -931,6 +931,19 @@ func (w *SourceWorker) PurgeRelay(ctx context.Context, req *pb.PurgeRelayRequest
 	return w.relayPurger.Do(ctx, req)
 }
 
+// DryRunPurgeRelay returns the relay log files which would be purged by the request, without purging them.
+func (w *SourceWorker) DryRunPurgeRelay(req *pb.PurgeRelayRequest) ([]string, error) {
+	if w.closed.Load() {
+		return nil, terror.ErrWorkerAlreadyClosed.Generate()
+	}
+
+	if !w.relayEnabled.Load() {
+		w.l.Warn("enable-relay is false, ignore dry-run purge relay")
+		return nil, nil
+	}
+	return w.relayPurger.DryRun(req)
+}
+
 // ForbidPurge implements PurgeInterceptor.ForbidPurge.
 func (w *SourceWorker) ForbidPurge() (bool, string) {
 	if w.closed.Load() {
@@ -968,7 +981,12 @@ func (w *SourceWorker) OperateSchema(ctx context.Context, req *pb.OperateWorkerS
 
 // copyConfigFromSource copies config items from source config and worker's relayEnabled to sub task.
 func copyConfigFromSource(cfg *config.SubTaskConfig, sourceCfg *config.SourceConfig, enableRelay bool) error {
+	// the TLS config of the source may be overridden in the task config.
+	security := cfg.From.Security
 	cfg.From = sourceCfg.From
+	if security != nil {
+		cfg.From.Security = security
+	}
 
 	cfg.Flavor = sourceCfg.Flavor
 	cfg.ServerID = sourceCfg.ServerID
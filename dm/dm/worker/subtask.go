@@ -77,6 +77,22 @@ func createRealUnits(cfg *config.SubTaskConfig, etcdClient *clientv3.Client, wor
 		}
 	case config.ModeIncrement:
 		us = append(us, syncer.NewSyncer(cfg, etcdClient, relay))
+	case config.ModeDump:
+		us = append(us, dumpling.NewDumpling(cfg))
+	case config.ModeLoad:
+		if cfg.NeedUseLightning() {
+			us = append(us, loader.NewLightning(cfg, etcdClient, workerName))
+		} else {
+			us = append(us, loader.NewLoader(cfg, etcdClient, workerName))
+		}
+	case config.ModeLoadSync:
+		// the dump is created outside of the task, like a nightly dump
+		if cfg.NeedUseLightning() {
+			us = append(us, loader.NewLightning(cfg, etcdClient, workerName))
+		} else {
+			us = append(us, loader.NewLoader(cfg, etcdClient, workerName))
+		}
+		us = append(us, syncer.NewSyncer(cfg, etcdClient, relay))
 	default:
 		log.L().Error("unsupported task mode", zap.String("subtask", cfg.Name), zap.String("task mode", cfg.Mode))
 	}
@@ -146,6 +162,7 @@ func (st *SubTask) initUnits(relay relay.Process) error {
 			return terror.Annotatef(err, "fail to dump tls cert data for lightning, subtask %s ", st.cfg.Name)
 		}
 	}
+	st.cfg.ApplyResourceLimits()
 	st.units = createUnits(st.cfg, st.etcdClient, st.workerName, relay)
 	if len(st.units) < 1 {
 		return terror.ErrWorkerNoAvailUnits.Generate(st.cfg.Name, st.cfg.Mode)
@@ -73,6 +73,26 @@ func (t *testSubTask) TestCreateUnits(c *C) {
 	c.Assert(ok, IsTrue)
 	_, ok = unitsAll[2].(*syncer.Syncer)
 	c.Assert(ok, IsTrue)
+
+	cfg.Mode = config.ModeDump
+	unitsDump := createUnits(cfg, nil, worker, nil)
+	c.Assert(unitsDump, HasLen, 1)
+	_, ok = unitsDump[0].(*dumpling.Dumpling)
+	c.Assert(ok, IsTrue)
+
+	cfg.Mode = config.ModeLoad
+	unitsLoad := createUnits(cfg, nil, worker, nil)
+	c.Assert(unitsLoad, HasLen, 1)
+	_, ok = unitsLoad[0].(*loader.Loader)
+	c.Assert(ok, IsTrue)
+
+	cfg.Mode = config.ModeLoadSync
+	unitsLoadSync := createUnits(cfg, nil, worker, nil)
+	c.Assert(unitsLoadSync, HasLen, 2)
+	_, ok = unitsLoadSync[0].(*loader.Loader)
+	c.Assert(ok, IsTrue)
+	_, ok = unitsLoadSync[1].(*syncer.Syncer)
+	c.Assert(ok, IsTrue)
 }
 
 type MockUnit struct {
@@ -26,6 +26,9 @@ import (
 
 // OperateV1Meta implements WorkerServer.OperateV1Meta.
 func (s *Server) OperateV1Meta(ctx context.Context, req *pb.OperateV1MetaRequest) (*pb.OperateV1MetaResponse, error) {
+	if slot := s.slotOf(ctx); slot != s {
+		return slot.OperateV1Meta(ctx, req)
+	}
 	log.L().Info("", zap.String("request", "OperateV1Meta"), zap.Stringer("payload", req))
 
 	switch req.Op {
@@ -46,6 +46,11 @@ type Dumpling struct {
 
 	dumpConfig *export.Config
 	closed     atomic.Bool
+	finish     atomic.Bool
+
+	// used to estimate the remaining time of the dump process
+	lastStatusTime   atomic.Time
+	lastFinishedRows atomic.Float64
 }
 
 // NewDumpling creates a new Dumpling.
@@ -109,6 +114,11 @@ func (m *Dumpling) Process(ctx context.Context, pr chan pb.ProcessResult) {
 		}
 		return
 	}
+	// reset the progress of the previous dumping
+	m.finish.Store(false)
+	m.lastStatusTime.Store(time.Now())
+	m.lastFinishedRows.Store(0)
+	export.RemoveLabelValuesWithTaskInMetrics(m.dumpConfig.Labels)
 
 	failpoint.Inject("dumpUnitProcessCancel", func() {
 		m.logger.Info("mock dump unit cancel", zap.String("failpoint", "dumpUnitProcessCancel"))
@@ -140,6 +150,7 @@ func (m *Dumpling) Process(ctx context.Context, pr chan pb.ProcessResult) {
 	}
 
 	if len(errs) == 0 {
+		m.finish.Store(!isCanceled)
 		m.logger.Info("dump data finished", zap.Duration("cost time", time.Since(begin)))
 	} else {
 		m.logger.Error("dump data exits with error", zap.Duration("cost time", time.Since(begin)),
@@ -195,8 +206,42 @@ func (m *Dumpling) Update(context.Context, *config.SubTaskConfig) error {
 
 // Status implements Unit.Status.
 func (m *Dumpling) Status(_ *binlog.SourceStatus) interface{} {
-	// NOTE: try to add some status, like dumped file count
-	return &pb.DumpStatus{}
+	s := readDumpStatus(m.cfg.Name, m.cfg.SourceID)
+	s.Progress = percent(s.FinishedRows, s.EstimateTotalRows, m.finish.Load())
+	go m.printStatus(s)
+	return s
+}
+
+// printStatus updates the progress and the estimated remaining time of the dump process.
+func (m *Dumpling) printStatus(s *pb.DumpStatus) {
+	finish := m.finish.Load()
+	progressGauge.WithLabelValues(m.cfg.Name, m.cfg.SourceID).Set(progress(s.FinishedRows, s.EstimateTotalRows, finish))
+
+	intervalSecond := time.Since(m.lastStatusTime.Load()).Seconds()
+	if intervalSecond <= 0 {
+		return
+	}
+	lastFinished := m.lastFinishedRows.Swap(s.FinishedRows)
+	m.lastStatusTime.Store(time.Now())
+
+	var remainingSeconds float64
+	speed := (s.FinishedRows - lastFinished) / intervalSecond
+	switch {
+	case finish:
+	case speed > 0 && s.EstimateTotalRows > s.FinishedRows:
+		remainingSeconds = (s.EstimateTotalRows - s.FinishedRows) / speed
+	default:
+		// no progress during this interval, keep the previous estimation
+		return
+	}
+	remainingTimeGauge.WithLabelValues(m.cfg.Name, m.cfg.SourceID).Set(remainingSeconds)
+
+	m.logger.Info("progress status of dump",
+		zap.Float64("finished_rows", s.FinishedRows),
+		zap.Float64("estimate_total_rows", s.EstimateTotalRows),
+		zap.Float64("rows/second", speed),
+		zap.String("progress", s.Progress),
+		zap.Float64("estimate time to finish", remainingSeconds))
 }
 
 // Type implements Unit.Type.
@@ -266,6 +311,16 @@ func (m *Dumpling) constructArgs(ctx context.Context) (*export.Config, error) {
 	}
 
 	if db.Security != nil {
+		// dumpling always verifies the certificate and the host name of the source by the CA.
+		switch db.Security.SSLVerifyMode {
+		case config.SSLVerifyModeRequired:
+			if db.Security.SSLCA == "" && len(db.Security.SSLCABytes) == 0 {
+				return nil, terror.ErrConfigInvalidDBTLS.Generate("`ssl-verify-mode` required without `ssl-ca` is not supported by the dump unit")
+			}
+			fallthrough
+		case config.SSLVerifyModeCA:
+			m.logger.Warn("the dump unit verifies the host name of the source as `verify-identity`", zap.String("ssl-verify-mode", db.Security.SSLVerifyMode))
+		}
 		dumpConfig.Security.CAPath = db.Security.SSLCA
 		dumpConfig.Security.CertPath = db.Security.SSLCert
 		dumpConfig.Security.KeyPath = db.Security.SSLKey
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb-tools/pkg/filter"
 	"github.com/pingcap/tidb/dumpling/export"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/pingcap/ticdc/dm/dm/config"
 	"github.com/pingcap/ticdc/dm/dm/pb"
@@ -124,3 +125,39 @@ func (d *testDumplingSuite) TestDefaultConfig(c *C) {
 	c.Assert(dumpling.dumpConfig.StatementSize, Not(Equals), export.UnspecifiedSize)
 	c.Assert(dumpling.dumpConfig.Rows, Not(Equals), export.UnspecifiedSize)
 }
+
+func (d *testDumplingSuite) TestDumpStatus(c *C) {
+	origin := metricsRegistry
+	defer func() {
+		metricsRegistry = origin
+	}()
+	metricsRegistry = nil
+	dumpling := NewDumpling(d.cfg)
+	c.Assert(dumpling.Status(nil), DeepEquals, &pb.DumpStatus{Progress: "0.00 %"})
+
+	// mock the metrics of dumpling
+	metricsRegistry = prometheus.NewRegistry()
+	labelNames := []string{"task", "source_id"}
+	finishedRows := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "dumpling_dump_finished_rows"}, labelNames)
+	totalRows := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "dumpling_dump_estimate_total_rows"}, labelNames)
+	finishedTables := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "dumpling_dump_finished_tables"}, labelNames)
+	metricsRegistry.MustRegister(finishedRows, totalRows, finishedTables)
+	labels := prometheus.Labels{"task": d.cfg.Name, "source_id": d.cfg.SourceID}
+	finishedRows.With(labels).Set(25)
+	totalRows.With(labels).Add(100)
+	finishedTables.With(labels).Add(1)
+	// the metrics of other tasks are not counted
+	finishedRows.With(prometheus.Labels{"task": "other", "source_id": d.cfg.SourceID}).Set(100)
+
+	c.Assert(dumpling.Status(nil), DeepEquals, &pb.DumpStatus{
+		CompletedTables:   1,
+		FinishedRows:      25,
+		EstimateTotalRows: 100,
+		Progress:          "25.00 %",
+	})
+
+	// the estimated rows may be zero for the empty tables
+	totalRows.Reset()
+	dumpling.finish.Store(true)
+	c.Assert(dumpling.Status(nil).(*pb.DumpStatus).Progress, Equals, "100.00 %")
+}
@@ -17,8 +17,11 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/dumpling/export"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"go.uber.org/zap"
 
+	"github.com/pingcap/ticdc/dm/dm/pb"
+	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/metricsproxy"
 )
 
@@ -31,16 +34,94 @@ var dumplingExitWithErrorCounter = metricsproxy.NewCounterVec(
 		Help:      "counter for dumpling exit with error",
 	}, []string{"task", "source_id"})
 
+var (
+	progressGauge = metricsproxy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "dumpling",
+			Name:      "progress",
+			Help:      "the processing progress of dumpling in percentage",
+		}, []string{"task", "source_id"})
+
+	remainingTimeGauge = metricsproxy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "dumpling",
+			Name:      "remaining_time",
+			Help:      "the remaining time in second to finish dump process",
+		}, []string{"task", "source_id"})
+)
+
+// metricsRegistry is the registry of the dumpling metrics, which are read to report the status of the dump unit.
+var metricsRegistry *prometheus.Registry
+
 // RegisterMetrics registers metrics and saves the given registry for later use.
 func RegisterMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(dumplingExitWithErrorCounter)
+	registry.MustRegister(progressGauge)
+	registry.MustRegister(remainingTimeGauge)
 	export.InitMetricsVector(prometheus.Labels{"task": "", "source_id": ""})
 	export.RegisterMetrics(registry)
+	metricsRegistry = registry
+}
+
+// readDumpStatus reads the progress of the dump unit from the dumpling metrics of the task and source.
+func readDumpStatus(task, source string) *pb.DumpStatus {
+	s := &pb.DumpStatus{}
+	if metricsRegistry == nil {
+		return s
+	}
+	mfs, err := metricsRegistry.Gather()
+	if err != nil {
+		log.L().Warn("fail to gather dumpling metrics", zap.Error(err))
+		return s
+	}
+
+	for _, mf := range mfs {
+		var value *float64
+		switch mf.GetName() {
+		case "dumpling_dump_finished_tables":
+			value = &s.CompletedTables
+		case "dumpling_dump_finished_size":
+			value = &s.FinishedBytes
+		case "dumpling_dump_finished_rows":
+			value = &s.FinishedRows
+		case "dumpling_dump_estimate_total_rows":
+			value = &s.EstimateTotalRows
+		default:
+			continue
+		}
+		for _, metric := range mf.GetMetric() {
+			if !matchLabels(metric, task, source) {
+				continue
+			}
+			if metric.GetCounter() != nil {
+				*value = metric.GetCounter().GetValue()
+			} else {
+				*value = metric.GetGauge().GetValue()
+			}
+		}
+	}
+	return s
+}
+
+func matchLabels(metric *dto.Metric, task, source string) bool {
+	matched := 0
+	for _, label := range metric.GetLabel() {
+		switch {
+		case label.GetName() == "task" && label.GetValue() == task,
+			label.GetName() == "source_id" && label.GetValue() == source:
+			matched++
+		}
+	}
+	return matched == 2
 }
 
 func (m *Dumpling) removeLabelValuesWithTaskInMetrics(task, source string) {
 	labels := prometheus.Labels{"task": task, "source_id": source}
 	dumplingExitWithErrorCounter.DeleteAllAboutLabels(labels)
+	progressGauge.DeleteAllAboutLabels(labels)
+	remainingTimeGauge.DeleteAllAboutLabels(labels)
 	failpoint.Inject("SkipRemovingDumplingMetrics", func(_ failpoint.Value) {
 		m.logger.Info("", zap.String("failpoint", "SkipRemovingDumplingMetrics"))
 		failpoint.Return()
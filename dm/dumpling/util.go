@@ -37,6 +37,27 @@ func ParseArgLikeBash(args []string) []string {
 	return result
 }
 
+// percent calculates percentage of a/b.
+func percent(a, b float64, finish bool) string {
+	if b == 0 {
+		if finish {
+			return "100.00 %"
+		}
+		return "0.00 %"
+	}
+	return fmt.Sprintf("%.2f %%", a/b*100)
+}
+
+func progress(a, b float64, finish bool) float64 {
+	if b == 0 {
+		if finish {
+			return 1
+		}
+		return 0
+	}
+	return a / b
+}
+
 // trimOutQuotes trims a pair of single quotes or a pair of double quotes from arg.
 func trimOutQuotes(arg string) string {
 	argLen := len(arg)
@@ -125,3 +125,10 @@ func (d *testDumplingSuite) TestParseArgsWontOverwrite(c *C) {
 
 	c.Assert(exportCfg.Consistency, Equals, "lock")
 }
+
+func (d *testDumplingSuite) TestProgress(c *C) {
+	c.Assert(progress(0, 0, false), Equals, float64(0))
+	c.Assert(progress(0, 0, true), Equals, float64(1))
+	c.Assert(progress(50, 200, false), Equals, 0.25)
+	c.Assert(percent(50, 200, false), Equals, "25.00 %")
+}
@@ -82,6 +82,10 @@ type CheckPoint interface {
 
 	// AllFinished returns `true` when all restoring job are finished
 	AllFinished() bool
+
+	// FetchOffset fetches the offset of the data file recorded in DB, it's used to check
+	// whether a job has been applied when the result of its transaction is unknown
+	FetchOffset(tctx *tcontext.Context, filename string) (int64, error)
 }
 
 // RemoteCheckPoint implements CheckPoint by saving status in remote database system, mostly in TiDB.
@@ -452,6 +456,29 @@ func (cp *RemoteCheckPoint) Count(tctx *tcontext.Context) (int, error) {
 	return count, nil
 }
 
+// FetchOffset implements CheckPoint.FetchOffset.
+func (cp *RemoteCheckPoint) FetchOffset(tctx *tcontext.Context, filename string) (int64, error) {
+	query := fmt.Sprintf("SELECT `offset` FROM %s WHERE `id` = ? AND `filename` = ?", cp.tableName)
+	cp.connMutex.Lock()
+	rows, err := cp.conn.querySQL(tctx, query, cp.id, filename)
+	cp.connMutex.Unlock()
+	if err != nil {
+		return 0, terror.WithScope(err, terror.ScopeDownstream)
+	}
+	defer rows.Close()
+	var offset int64
+	if !rows.Next() {
+		if rows.Err() != nil {
+			return 0, terror.WithScope(terror.DBErrorAdapt(rows.Err(), terror.ErrDBDriverError), terror.ScopeDownstream)
+		}
+		return 0, terror.ErrLoadTaskCheckPointNotMatch.Generatef("file=%s not in checkpoint", filename)
+	}
+	if err = rows.Scan(&offset); err != nil {
+		return 0, terror.WithScope(terror.DBErrorAdapt(err, terror.ErrDBDriverError), terror.ScopeDownstream)
+	}
+	return offset, nil
+}
+
 func (cp *RemoteCheckPoint) String() string {
 	cp.restoringFiles.RLock()
 	defer cp.restoringFiles.RUnlock()
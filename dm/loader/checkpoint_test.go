@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/ticdc/dm/pkg/conn"
 	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
 	"github.com/pingcap/ticdc/dm/pkg/cputil"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 )
 
 var _ = Suite(&testCheckPointSuite{})
@@ -37,6 +38,7 @@ var (
 	countCheckPointSQL  = ""
 	flushCheckPointSQL  = ""
 	deleteCheckPointSQL = ""
+	fetchOffsetSQL      = ""
 )
 
 type testCheckPointSuite struct {
@@ -76,6 +78,7 @@ func (t *testCheckPointSuite) SetUpSuite(c *C) {
 	countCheckPointSQL = fmt.Sprintf("SELECT COUNT.* FROM `%s`.`%s` WHERE `id` = ?", t.cfg.MetaSchema, cputil.LoaderCheckpoint(t.cfg.Name))
 	flushCheckPointSQL = fmt.Sprintf("INSERT INTO `%s`.`%s` .* VALUES.*", t.cfg.MetaSchema, cputil.LoaderCheckpoint(t.cfg.Name))
 	deleteCheckPointSQL = fmt.Sprintf("DELETE FROM `%s`.`%s` WHERE `id` = .*", t.cfg.MetaSchema, cputil.LoaderCheckpoint(t.cfg.Name))
+	fetchOffsetSQL = fmt.Sprintf("SELECT `offset` FROM `%s`.`%s` WHERE `id` = \\? AND `filename` = \\?", t.cfg.MetaSchema, cputil.LoaderCheckpoint(t.cfg.Name))
 }
 
 func (t *testCheckPointSuite) TearDownSuite(c *C) {
@@ -200,6 +203,15 @@ func (t *testCheckPointSuite) TestForDB(c *C) {
 	c.Assert(info, HasLen, 1)
 	c.Assert(info[cases[0].filename], DeepEquals, []int64{cases[0].endPos, cases[0].endPos})
 
+	// fetch offset from DB
+	mock.ExpectQuery(fetchOffsetSQL).WithArgs(id, cases[0].filename).WillReturnRows(sqlmock.NewRows([]string{"offset"}).AddRow(cases[0].endPos))
+	offset, err := cp.FetchOffset(tctx, cases[0].filename)
+	c.Assert(err, IsNil)
+	c.Assert(offset, Equals, cases[0].endPos)
+	mock.ExpectQuery(fetchOffsetSQL).WithArgs(id, "db1.tbl4.sql").WillReturnRows(sqlmock.NewRows([]string{"offset"}))
+	_, err = cp.FetchOffset(tctx, "db1.tbl4.sql")
+	c.Assert(terror.ErrLoadTaskCheckPointNotMatch.Equal(err), IsTrue)
+
 	infos = cp.GetAllRestoringFileInfo()
 	c.Assert(len(infos), Equals, len(cases))
 	for _, cs := range cases {
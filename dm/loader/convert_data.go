@@ -19,9 +19,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 	"unsafe"
 
+	"github.com/pingcap/ticdc/dm/dm/config"
 	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
 	parserpkg "github.com/pingcap/ticdc/dm/pkg/parser"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
@@ -30,7 +33,10 @@ import (
 	"github.com/pingcap/errors"
 	cm "github.com/pingcap/tidb-tools/pkg/column-mapping"
 	router "github.com/pingcap/tidb-tools/pkg/table-router"
+	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
 )
 
 func bytes2str(bs []byte) string {
@@ -181,7 +187,114 @@ func parseRowValues(str []byte, table *tableInfo, columnMapping *cm.Mapping) ([]
 		}
 	}
 
-	return row, nil
+	return transformRow(row, table)
+}
+
+// columnTransform is a column transform of the loader. the expression is restored as a template whose columns
+// are replaced by the values of the row, so it's evaluated by the target DB in the same way as the syncer.
+type columnTransform struct {
+	offset   int      // offset of the column in the row
+	segments []string // the template split by the columns
+	refs     []int    // offsets of the columns between the segments
+}
+
+var columnPlaceholderRegexp = regexp.MustCompile("`__dm_column_([0-9]+)__`")
+
+// genColumnTransforms generates the column transforms of the table.
+func genColumnTransforms(table *tableInfo, transforms []*config.ColumnTransform) error {
+	tableID := tableName(table.sourceSchema, table.sourceTable)
+	offsetOf := func(column string) int {
+		for i, name := range table.columnNameList {
+			if strings.EqualFold(name, column) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	table.columnTransforms = table.columnTransforms[:0]
+	for _, t := range transforms {
+		if t.Schema != table.sourceSchema || t.Table != table.sourceTable {
+			continue
+		}
+		offset := offsetOf(t.Column)
+		if offset < 0 {
+			return terror.ErrLoadUnitGenColumnTransform.Generate(t.Expr, t.Column, tableID, "column not found")
+		}
+		stmt, err := parser.New().ParseOneStmt("SELECT "+t.Expr, "", "")
+		if err != nil {
+			return terror.ErrLoadUnitGenColumnTransform.Generate(t.Expr, t.Column, tableID, err.Error())
+		}
+		expr := stmt.(*ast.SelectStmt).Fields.Fields[0].Expr
+		visitor := &columnPlaceholderVisitor{offsetOf: offsetOf}
+		expr.Accept(visitor)
+		if visitor.err != nil {
+			return terror.ErrLoadUnitGenColumnTransform.Generate(t.Expr, t.Column, tableID, visitor.err.Error())
+		}
+
+		var sb strings.Builder
+		if err = expr.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return terror.ErrLoadUnitGenColumnTransform.Generate(t.Expr, t.Column, tableID, err.Error())
+		}
+		template := sb.String()
+		transform := &columnTransform{offset: offset}
+		last := 0
+		for _, loc := range columnPlaceholderRegexp.FindAllStringSubmatchIndex(template, -1) {
+			ref, _ := strconv.Atoi(template[loc[2]:loc[3]])
+			transform.segments = append(transform.segments, template[last:loc[0]])
+			transform.refs = append(transform.refs, ref)
+			last = loc[1]
+		}
+		transform.segments = append(transform.segments, template[last:])
+		table.columnTransforms = append(table.columnTransforms, transform)
+	}
+	return nil
+}
+
+// columnPlaceholderVisitor replaces the columns of the expression by the placeholders of their offsets.
+type columnPlaceholderVisitor struct {
+	offsetOf func(column string) int
+	err      error
+}
+
+func (v *columnPlaceholderVisitor) Enter(in ast.Node) (ast.Node, bool) {
+	if c, ok := in.(*ast.ColumnNameExpr); ok {
+		offset := v.offsetOf(c.Name.Name.O)
+		if offset < 0 {
+			v.err = fmt.Errorf("unknown column %s", c.Name.Name.O)
+		} else {
+			c.Name = &ast.ColumnName{Name: model.NewCIStr(fmt.Sprintf("__dm_column_%d__", offset))}
+		}
+		return in, true
+	}
+	return in, false
+}
+
+func (v *columnPlaceholderVisitor) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// transformRow replaces the values of the columns of the row by the expressions of the column transforms.
+func transformRow(row []string, table *tableInfo) ([]string, error) {
+	if len(table.columnTransforms) == 0 {
+		return row, nil
+	}
+	if len(row) != len(table.columnNameList) {
+		return nil, terror.ErrLoadUnitDoColumnMapping.Generate(row, table)
+	}
+
+	newRow := append([]string(nil), row...)
+	for _, t := range table.columnTransforms {
+		var sb strings.Builder
+		for i, segment := range t.segments {
+			sb.WriteString(segment)
+			if i < len(t.refs) {
+				sb.WriteString(row[t.refs[i]])
+			}
+		}
+		newRow[t.offset] = sb.String()
+	}
+	return newRow, nil
 }
 
 // exportStatement returns schema structure in sqlFile.
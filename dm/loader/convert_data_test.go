@@ -17,7 +17,9 @@ import (
 	cm "github.com/pingcap/tidb-tools/pkg/column-mapping"
 	router "github.com/pingcap/tidb-tools/pkg/table-router"
 
+	"github.com/pingcap/ticdc/dm/dm/config"
 	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 
 	. "github.com/pingcap/check"
 )
@@ -229,3 +231,39 @@ func (t *testConvertDataSuite) TestParseRowValues(c *C) {
 	c.Assert(err, ErrorMatches, ".*mapping row data \\[585520728116297738\\] for table.*")
 	c.Assert(values, IsNil)
 }
+
+func (t *testConvertDataSuite) TestReassembleWithColumnTransforms(c *C) {
+	table := &tableInfo{
+		sourceSchema:   "test2",
+		sourceTable:    "t3",
+		targetSchema:   "test",
+		targetTable:    "t",
+		columnNameList: []string{"id", "first_name", "last_name", "created_at"},
+		insertHeadStmt: "INSERT INTO t VALUES",
+	}
+	transforms := []*config.ColumnTransform{
+		{Schema: "test2", Table: "t3", Column: "id", Expr: "id | (3 << 48)"},
+		{Schema: "test2", Table: "t3", Column: "FIRST_NAME", Expr: "CONCAT(First_Name, ' ', `t3`.`last_name`)"},
+		{Schema: "test2", Table: "t3", Column: "created_at", Expr: "CONVERT_TZ(created_at, '+08:00', '+00:00')"},
+		{Schema: "test2", Table: "t4", Column: "id", Expr: "id + 1"},
+	}
+	c.Assert(genColumnTransforms(table, transforms), IsNil)
+	c.Assert(table.columnTransforms, HasLen, 3)
+
+	sql := `INSERT INTO t1 VALUES
+(1,'John','Smith','2021-11-18 08:00:00'),
+(2,NULL,'Do\'e',NULL);
+`
+	expected := "INSERT INTO t VALUES" +
+		"(1|(3<<48),CONCAT('John', _UTF8MB4' ', 'Smith'),'Smith',CONVERT_TZ('2021-11-18 08:00:00', _UTF8MB4'+08:00', _UTF8MB4'+00:00'))," +
+		"(2|(3<<48),CONCAT(NULL, _UTF8MB4' ', 'Do\\'e'),'Do\\'e',CONVERT_TZ(NULL, _UTF8MB4'+08:00', _UTF8MB4'+00:00'));"
+	query, err := reassemble([]byte(sql), table, nil)
+	c.Assert(err, IsNil)
+	c.Assert(query, Equals, expected)
+
+	// unknown columns
+	transforms[0].Expr = "id + unknown"
+	c.Assert(terror.ErrLoadUnitGenColumnTransform.Equal(genColumnTransforms(table, transforms)), IsTrue)
+	transforms[0].Expr, transforms[0].Column = "id", "unknown"
+	c.Assert(terror.ErrLoadUnitGenColumnTransform.Equal(genColumnTransforms(table, transforms)), IsTrue)
+}
@@ -111,6 +111,13 @@ func (conn *DBConn) querySQL(ctx *tcontext.Context, query string, args ...interf
 }
 
 func (conn *DBConn) executeSQL(ctx *tcontext.Context, queries []string, args ...[]interface{}) error {
+	return conn.executeSQLWithCheck(ctx, queries, nil, args...)
+}
+
+// executeSQLWithCheck executes the queries like executeSQL, and calls `applied` before each retry
+// to check whether the queries have been applied by the last try whose result is unknown, e.g.
+// the connection is broken during committing. The retry is skipped if `applied` returns true.
+func (conn *DBConn) executeSQLWithCheck(ctx *tcontext.Context, queries []string, applied func(*tcontext.Context) (bool, error), args ...[]interface{}) error {
 	if len(queries) == 0 {
 		return nil
 	}
@@ -147,10 +154,24 @@ func (conn *DBConn) executeSQL(ctx *tcontext.Context, queries []string, args ...
 		},
 	}
 
+	retried := false
 	_, _, err := conn.baseConn.ApplyRetryStrategy(
 		ctx,
 		params,
 		func(ctx *tcontext.Context) (interface{}, error) {
+			if retried && applied != nil {
+				ok, err := applied(ctx)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					ctx.L().Info("statements have been applied before retry, skip them",
+						zap.String("queries", utils.TruncateInterface(queries, -1)))
+					return nil, nil
+				}
+			}
+			retried = true
+
 			startTime := time.Now()
 			_, err := conn.baseConn.ExecuteSQL(ctx, stmtHistogram, conn.name, queries, args...)
 			failpoint.Inject("LoadExecCreateTableFailed", func(val failpoint.Value) {
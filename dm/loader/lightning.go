@@ -102,6 +102,9 @@ func makeGlobalConfig(cfg *config.SubTaskConfig) *lcfg.GlobalConfig {
 	lightningCfg.PostRestore.Checksum = lcfg.OpLevelOff
 	if cfg.TiDB.Backend == lcfg.BackendLocal {
 		lightningCfg.TikvImporter.SortedKVDir = cfg.Dir
+		if cfg.SortingDirPhysical != "" {
+			lightningCfg.TikvImporter.SortedKVDir = cfg.SortingDirPhysical
+		}
 	}
 	lightningCfg.Mydumper.SourceDir = cfg.Dir
 	return lightningCfg
@@ -207,7 +210,15 @@ func (l *LightningLoader) restore(ctx context.Context) error {
 		if err = cfg.LoadFromGlobal(l.lightningConfig); err != nil {
 			return err
 		}
+		// TiDB Lightning verifies the certificate and the host name of TiDB if the CA is set,
+		// so `verify-ca` is the same as `verify-identity` for it.
+		if l.cfg.To.Security != nil && l.cfg.To.Security.SSLVerifyMode == config.SSLVerifyModeRequired {
+			cfg.TiDB.TLS = "skip-verify"
+		}
 		cfg.Routes = l.cfg.RouteRules
+		if limit := l.cfg.ResourceLimits.MaxDownstreamConnections; limit > 0 && cfg.App.RegionConcurrency > limit {
+			cfg.App.RegionConcurrency = limit
+		}
 		cfg.Checkpoint.Driver = lcfg.CheckpointDriverMySQL
 		cfg.Checkpoint.Schema = config.TiDBLightningCheckpointPrefix + dbutil.TableName(l.workerName, l.cfg.Name)
 		cfg.Checkpoint.KeepAfterSuccess = lcfg.CheckpointOrigin
@@ -250,7 +261,7 @@ func (l *LightningLoader) restore(ctx context.Context) error {
 	} else {
 		l.finish.Store(true)
 	}
-	if err == nil && l.finish.Load() && l.cfg.Mode == config.ModeFull {
+	if err == nil && l.finish.Load() && needDelLoadTask(l.cfg) {
 		if err = delLoadTask(l.cli, l.cfg, l.workerName); err != nil {
 			return err
 		}
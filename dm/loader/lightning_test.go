@@ -0,0 +1,48 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	. "github.com/pingcap/check"
+	lcfg "github.com/pingcap/tidb/br/pkg/lightning/config"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+)
+
+func (*testLoaderSuite) TestMakeGlobalConfig(c *C) {
+	cfg := &config.SubTaskConfig{
+		Name:     "test-task",
+		SourceID: "mysql-instance-01",
+		LoaderConfig: config.LoaderConfig{
+			Dir:        "./dumped_data",
+			ImportMode: config.ImportModePhysical,
+		},
+	}
+	c.Assert(cfg.Adjust(false), IsNil)
+	lightningCfg := makeGlobalConfig(cfg)
+	c.Assert(lightningCfg.TikvImporter.Backend, Equals, lcfg.BackendLocal)
+	c.Assert(lightningCfg.TikvImporter.SortedKVDir, Equals, cfg.Dir)
+	c.Assert(lightningCfg.Mydumper.SourceDir, Equals, cfg.Dir)
+
+	cfg.SortingDirPhysical = "/tmp/sorting"
+	lightningCfg = makeGlobalConfig(cfg)
+	c.Assert(lightningCfg.TikvImporter.SortedKVDir, Equals, "/tmp/sorting")
+	c.Assert(lightningCfg.Mydumper.SourceDir, Equals, cfg.Dir)
+
+	cfg.ImportMode = config.ImportModeLogical
+	c.Assert(cfg.Adjust(false), IsNil)
+	lightningCfg = makeGlobalConfig(cfg)
+	c.Assert(lightningCfg.TikvImporter.Backend, Equals, lcfg.BackendTiDB)
+	c.Assert(lightningCfg.TikvImporter.SortedKVDir, Equals, "")
+}
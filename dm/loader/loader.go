@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
@@ -43,6 +44,7 @@ import (
 	cm "github.com/pingcap/tidb-tools/pkg/column-mapping"
 	"github.com/pingcap/tidb-tools/pkg/filter"
 	router "github.com/pingcap/tidb-tools/pkg/table-router"
+	"github.com/pingcap/tidb/dumpling/export"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -50,6 +52,8 @@ import (
 const (
 	jobCount            = 1000
 	uninitializedOffset = -1
+	// the size of the content before the resumed offset to check whether it's the end of a statement.
+	resumeOffsetCheckSize = 64
 )
 
 // FilePosSet represents a set in mathematics.
@@ -103,7 +107,7 @@ func NewWorker(loader *Loader, id int) *Worker {
 		cfg:        loader.cfg,
 		checkPoint: loader.checkPoint,
 		conn:       loader.toDBConns[id],
-		jobQueue:   make(chan *dataJob, jobCount),
+		jobQueue:   make(chan *dataJob, jobQueueSize(loader.cfg)),
 		loader:     loader,
 		logger:     loader.logger.WithFields(zap.Int("worker ID", id)),
 	}
@@ -117,6 +121,26 @@ func NewWorker(loader *Loader, id int) *Worker {
 	return w
 }
 
+// jobQueueSize returns the length of the job queue of each worker, which is limited by `max-loader-memory` of the task
+// assuming that each job holds an INSERT statement of `statement-size`.
+func jobQueueSize(cfg *config.SubTaskConfig) int {
+	limit := cfg.ResourceLimits.LoaderMemoryBytes()
+	if limit == 0 || cfg.PoolSize <= 0 {
+		return jobCount
+	}
+	stmtSize := int64(cfg.MydumperConfig.StatementSize)
+	if stmtSize <= 0 {
+		stmtSize = export.DefaultStatementSize
+	}
+	size := limit / int64(cfg.PoolSize) / stmtSize
+	if size < 1 {
+		return 1
+	} else if size > jobCount {
+		return jobCount
+	}
+	return int(size)
+}
+
 // Close closes worker.
 func (w *Worker) Close() {
 	// simulate the case that doesn't wait all doJob goroutine exit
@@ -194,7 +218,12 @@ func (w *Worker) run(ctx context.Context, fileJobQueue chan *fileJob, runFatalCh
 			})
 
 			startTime := time.Now()
-			err := w.conn.executeSQL(ctctx, sqls)
+			// the checkpoint is updated in the same transaction, so the job has been applied
+			// if the checkpoint in DB reaches its offset
+			err := w.conn.executeSQLWithCheck(ctctx, sqls, func(tctx *tcontext.Context) (bool, error) {
+				offset, err2 := w.checkPoint.FetchOffset(tctx, job.file)
+				return offset >= job.offset, err2
+			})
 			failpoint.Inject("executeSQLError", func(_ failpoint.Value) {
 				w.logger.Info("", zap.String("failpoint", "executeSQLError"))
 				err = errors.New("inject failpoint executeSQLError")
@@ -322,6 +351,10 @@ func (w *Worker) dispatchSQL(ctx context.Context, file string, offset int64, tab
 			w.logger.Error("fail to initial checkpoint", zap.String("data file", file), zap.Int64("offset", offset), log.ShortError(err2))
 			return err2
 		}
+	} else if offset > 0 {
+		if err = w.checkResumeOffset(f, baseFile, offset, table); err != nil {
+			return err
+		}
 	}
 
 	cur, err = f.Seek(offset, io.SeekStart)
@@ -362,8 +395,8 @@ func (w *Worker) dispatchSQL(ctx context.Context, file string, offset int64, tab
 				continue
 			}
 
-			if w.loader.columnMapping != nil {
-				// column mapping and route table
+			if w.loader.columnMapping != nil || len(table.columnTransforms) > 0 {
+				// column mapping, column transforms and route table
 				query, err = reassemble(data, table, w.loader.columnMapping)
 				if err != nil {
 					return terror.Annotatef(err, "file %s", file)
@@ -400,6 +433,38 @@ func (w *Worker) dispatchSQL(ctx context.Context, file string, offset int64, tab
 	return nil
 }
 
+// checkResumeOffset checks the offset of a partially restored data file, so the data file
+// is resumed from the end of the statement applied last time rather than a wrong position.
+func (w *Worker) checkResumeOffset(f *os.File, baseFile string, offset int64, table *tableInfo) error {
+	finfo, err := f.Stat()
+	if err != nil {
+		return terror.ErrLoadUnitDispatchSQLFromFile.Delegate(err)
+	}
+	if posSet, ok := w.checkPoint.GetRestoringFileInfo(table.sourceSchema, table.sourceTable)[baseFile]; ok && posSet[1] != finfo.Size() {
+		return terror.ErrLoadCheckPointInvalidOffset.Generate(offset, baseFile,
+			fmt.Sprintf("file size %d is different from %d recorded in checkpoint", finfo.Size(), posSet[1]))
+	}
+	if offset > finfo.Size() {
+		return terror.ErrLoadCheckPointInvalidOffset.Generate(offset, baseFile, "offset exceeds the file size")
+	}
+
+	// a statement ends with `;` and a line break, maybe with spaces between them
+	tail := make([]byte, resumeOffsetCheckSize)
+	start := offset - int64(len(tail))
+	if start < 0 {
+		start = 0
+	}
+	n, err := f.ReadAt(tail[:offset-start], start)
+	if err != nil {
+		return terror.ErrLoadUnitDispatchSQLFromFile.Delegate(err)
+	}
+	tail = tail[:n]
+	if !bytes.HasSuffix(tail, []byte("\n")) || !bytes.HasSuffix(bytes.TrimSpace(tail), []byte(";")) {
+		return terror.ErrLoadCheckPointInvalidOffset.Generate(offset, baseFile, "offset is not at the end of a statement")
+	}
+	return nil
+}
+
 type tableInfo struct {
 	sourceSchema   string
 	sourceTable    string
@@ -407,6 +472,8 @@ type tableInfo struct {
 	targetTable    string
 	columnNameList []string
 	insertHeadStmt string
+
+	columnTransforms []*columnTransform
 }
 
 // Loader can load your mydumper data into TiDB database.
@@ -754,7 +821,7 @@ func (l *Loader) Restore(ctx context.Context) error {
 		l.finish.Store(true)
 		l.logger.Info("all data files have been finished", zap.Duration("cost time", time.Since(begin)))
 		if l.checkPoint.AllFinished() {
-			if l.cfg.Mode == config.ModeFull {
+			if needDelLoadTask(l.cfg) {
 				if err = delLoadTask(l.cli, l.cfg, l.workerName); err != nil {
 					return err
 				}
@@ -1422,11 +1489,16 @@ tblSchemaLoop:
 		for table := range l.db2Tables[db] {
 			schemaFile := l.cfg.Dir + "/" + db + "." + table + "-schema.sql" // cache friendly
 			if _, ok := l.tableInfos[tableName(db, table)]; !ok {
-				l.tableInfos[tableName(db, table)], err = parseTable(tctx, l.tableRouter, db, table, schemaFile, l.cfg.LoaderConfig.SQLMode)
+				var ti *tableInfo
+				ti, err = parseTable(tctx, l.tableRouter, db, table, schemaFile, l.cfg.LoaderConfig.SQLMode)
+				if err == nil {
+					err = genColumnTransforms(ti, l.cfg.ColumnTransforms)
+				}
 				if err != nil {
 					err = terror.Annotatef(err, "parse table %s/%s", db, table)
 					break tblSchemaLoop
 				}
+				l.tableInfos[tableName(db, table)] = ti
 			}
 			if l.checkPoint.IsTableFinished(db, table) {
 				l.logger.Info("table has finished, skip it.", zap.String("schema", db), zap.String("table", table))
@@ -15,9 +15,14 @@ package loader
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/errors"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 )
 
 var _ = Suite(&testLoaderSuite{})
@@ -80,3 +85,56 @@ func (*testLoaderSuite) TestJobQueue(c *C) {
 		c.Assert(err, Equals, testcase.exceptedErr)
 	}
 }
+
+func (*testLoaderSuite) TestCheckResumeOffset(c *C) {
+	content := "INSERT INTO `t` VALUES\n(1),\n(2);\nINSERT INTO `t` VALUES\n(3);  \r\n"
+	filename := "db1.tbl1.0.sql"
+	path := filepath.Join(c.MkDir(), filename)
+	c.Assert(os.WriteFile(path, []byte(content), 0o644), IsNil)
+	f, err := os.Open(path)
+	c.Assert(err, IsNil)
+	defer f.Close()
+
+	cp := &RemoteCheckPoint{}
+	cp.restoringFiles.pos = map[string]map[string]FilePosSet{
+		"db1": {"tbl1": {filename: {0, int64(len(content))}}},
+	}
+	w := &Worker{checkPoint: cp}
+	table := &tableInfo{sourceSchema: "db1", sourceTable: "tbl1"}
+
+	// the ends of the statements
+	first := int64(len("INSERT INTO `t` VALUES\n(1),\n(2);\n"))
+	c.Assert(w.checkResumeOffset(f, filename, first, table), IsNil)
+	c.Assert(w.checkResumeOffset(f, filename, int64(len(content)), table), IsNil)
+
+	// in the middle of a statement
+	for _, offset := range []int64{1, first - 1, first + 5, int64(len(content) + 1)} {
+		err = w.checkResumeOffset(f, filename, offset, table)
+		c.Assert(terror.ErrLoadCheckPointInvalidOffset.Equal(err), IsTrue, Commentf("offset %d", offset))
+	}
+
+	// the data file is changed after the checkpoint initialized
+	cp.restoringFiles.pos["db1"]["tbl1"][filename][1] = int64(len(content) - 1)
+	err = w.checkResumeOffset(f, filename, first, table)
+	c.Assert(terror.ErrLoadCheckPointInvalidOffset.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*file size .* is different from .* recorded in checkpoint.*")
+}
+
+func (*testLoaderSuite) TestJobQueueSize(c *C) {
+	cfg := &config.SubTaskConfig{
+		MydumperConfig: config.MydumperConfig{StatementSize: 1024 * 1024},
+		LoaderConfig:   config.LoaderConfig{PoolSize: 16},
+	}
+	c.Assert(jobQueueSize(cfg), Equals, jobCount)
+
+	cfg.ResourceLimits.MaxLoaderMemory = "256MiB"
+	c.Assert(jobQueueSize(cfg), Equals, 16)
+	cfg.ResourceLimits.MaxLoaderMemory = "1MiB"
+	c.Assert(jobQueueSize(cfg), Equals, 1)
+	cfg.ResourceLimits.MaxLoaderMemory = "1TiB"
+	c.Assert(jobQueueSize(cfg), Equals, jobCount)
+	// the default statement size of dumpling is used if not set
+	cfg.MydumperConfig.StatementSize = 0
+	cfg.ResourceLimits.MaxLoaderMemory = "160MB"
+	c.Assert(jobQueueSize(cfg), Equals, 10)
+}
@@ -53,7 +53,7 @@ func (l *Loader) printStatus() {
 	for db, tables := range l.dbTableDataFinishedSize {
 		for table, size := range tables {
 			curFinished := size.Load()
-			lastFinished := l.dbTableDataFinishedSize[db][table].Load()
+			lastFinished := l.dbTableDataLastFinishedSize[db][table].Load()
 			speed := float64(curFinished-lastFinished) / intervalSecond
 			l.dbTableDataLastFinishedSize[db][table].Store(curFinished)
 			if speed > 0 {
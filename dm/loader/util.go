@@ -141,8 +141,21 @@ func getMydumpMetadata(cli *clientv3.Client, cfg *config.SubTaskConfig, workerNa
 	return loc.Position.String(), loc.GTIDSetStr(), nil
 }
 
+// needDelLoadTask returns whether the load task should be deleted when finish restoring data. the
+// tasks without sync unit never need the dump files again, and their load tasks pin the source to the worker.
+// NOTE: only the dump files given by the user are kept in load-mode, see cleanDumpFiles.
+func needDelLoadTask(cfg *config.SubTaskConfig) bool {
+	return cfg.Mode == config.ModeFull || cfg.Mode == config.ModeLoad
+}
+
 // cleanDumpFiles is called when finish restoring data, to clean useless files.
 func cleanDumpFiles(cfg *config.SubTaskConfig) {
+	if cfg.IsDumpGivenByUser() {
+		// in load-mode and load-sync-mode the dump directory is given by the
+		// user, which may be reused or shared with other tasks
+		log.L().Info("skip cleaning dump files given by the user", zap.String("data folder", cfg.Dir))
+		return
+	}
 	log.L().Info("clean dump files")
 	if cfg.Mode == config.ModeFull {
 		// in full-mode all files won't be need in the future
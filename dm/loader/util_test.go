@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	. "github.com/pingcap/check"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
 )
 
 func TestClient(t *testing.T) {
@@ -124,3 +126,45 @@ func (t *testUtilSuite) TestGetDBAndTableFromFilename(c *C) {
 		}
 	}
 }
+
+func (t *testUtilSuite) TestCleanDumpFiles(c *C) {
+	prepare := func() string {
+		dir := c.MkDir()
+		for _, name := range []string{"metadata", "db-schema-create.sql", "db.t-schema.sql", "db.t.0.sql"} {
+			c.Assert(os.WriteFile(path.Join(dir, name), []byte("data"), 0o644), IsNil)
+		}
+		return dir
+	}
+	exists := func(dir, name string) bool {
+		_, err := os.Stat(path.Join(dir, name))
+		return err == nil
+	}
+
+	// the dump directory given by the user is never cleaned
+	for _, mode := range []string{config.ModeLoad, config.ModeLoadSync} {
+		dir := prepare()
+		cleanDumpFiles(&config.SubTaskConfig{Mode: mode, LoaderConfig: config.LoaderConfig{Dir: dir}})
+		c.Assert(exists(dir, "db.t.0.sql"), IsTrue)
+		c.Assert(exists(dir, "metadata"), IsTrue)
+	}
+
+	dir := prepare()
+	cleanDumpFiles(&config.SubTaskConfig{Mode: config.ModeAll, LoaderConfig: config.LoaderConfig{Dir: dir}})
+	c.Assert(exists(dir, "db.t.0.sql"), IsFalse)
+	c.Assert(exists(dir, "db.t-schema.sql"), IsTrue)
+	c.Assert(exists(dir, "metadata"), IsTrue)
+
+	dir = prepare()
+	cleanDumpFiles(&config.SubTaskConfig{Mode: config.ModeFull, LoaderConfig: config.LoaderConfig{Dir: dir}})
+	_, err := os.Stat(dir)
+	c.Assert(os.IsNotExist(err), IsTrue)
+}
+
+func (t *testUtilSuite) TestNeedDelLoadTask(c *C) {
+	for _, mode := range []string{config.ModeFull, config.ModeLoad} {
+		c.Assert(needDelLoadTask(&config.SubTaskConfig{Mode: mode}), IsTrue)
+	}
+	for _, mode := range []string{config.ModeAll, config.ModeIncrement, config.ModeLoadSync} {
+		c.Assert(needDelLoadTask(&config.SubTaskConfig{Mode: mode}), IsFalse)
+	}
+}
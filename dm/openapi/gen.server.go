@@ -77,9 +77,21 @@ type ServerInterface interface {
 	// create and start task
 	// (POST /api/v1/tasks)
 	DMAPIStartTask(ctx echo.Context) error
+	// check or create and start task with the task config in YAML format
+	// (POST /api/v1/tasks/yaml)
+	DMAPIStartTaskWithYaml(ctx echo.Context) error
 	// delete and stop task
 	// (DELETE /api/v1/tasks/{task-name})
 	DMAPIDeleteTask(ctx echo.Context, taskName string, params DMAPIDeleteTaskParams) error
+	// get the unresolved shard DDL locks of the task
+	// (GET /api/v1/tasks/{task-name}/ddl-locks)
+	DMAPIGetTaskDDLLockList(ctx echo.Context, taskName string, params DMAPIGetTaskDDLLockListParams) error
+	// force to unlock the shard DDL lock of the task in the pessimistic shard mode
+	// (POST /api/v1/tasks/{task-name}/ddl-locks/unlock)
+	DMAPIUnlockTaskDDLLock(ctx echo.Context, taskName string) error
+	// skip, replace or revert the error binlog event of the task
+	// (POST /api/v1/tasks/{task-name}/handle-error)
+	DMAPIHandleTaskError(ctx echo.Context, taskName string) error
 	// pause task
 	// (POST /api/v1/tasks/{task-name}/pause)
 	DMAPIPauseTask(ctx echo.Context, taskName string) error
@@ -385,6 +397,15 @@ func (w *ServerInterfaceWrapper) DMAPIStartTask(ctx echo.Context) error {
 	return err
 }
 
+// DMAPIStartTaskWithYaml converts echo context to params.
+func (w *ServerInterfaceWrapper) DMAPIStartTaskWithYaml(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DMAPIStartTaskWithYaml(ctx)
+	return err
+}
+
 // DMAPIDeleteTask converts echo context to params.
 func (w *ServerInterfaceWrapper) DMAPIDeleteTask(ctx echo.Context) error {
 	var err error
@@ -410,6 +431,63 @@ func (w *ServerInterfaceWrapper) DMAPIDeleteTask(ctx echo.Context) error {
 	return err
 }
 
+// DMAPIGetTaskDDLLockList converts echo context to params.
+func (w *ServerInterfaceWrapper) DMAPIGetTaskDDLLockList(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "task-name" -------------
+	var taskName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "task-name", runtime.ParamLocationPath, ctx.Param("task-name"), &taskName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter task-name: %s", err))
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DMAPIGetTaskDDLLockListParams
+	// ------------- Optional query parameter "source_name_list" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source_name_list", ctx.QueryParams(), &params.SourceNameList)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter source_name_list: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DMAPIGetTaskDDLLockList(ctx, taskName, params)
+	return err
+}
+
+// DMAPIUnlockTaskDDLLock converts echo context to params.
+func (w *ServerInterfaceWrapper) DMAPIUnlockTaskDDLLock(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "task-name" -------------
+	var taskName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "task-name", runtime.ParamLocationPath, ctx.Param("task-name"), &taskName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter task-name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DMAPIUnlockTaskDDLLock(ctx, taskName)
+	return err
+}
+
+// DMAPIHandleTaskError converts echo context to params.
+func (w *ServerInterfaceWrapper) DMAPIHandleTaskError(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "task-name" -------------
+	var taskName string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "task-name", runtime.ParamLocationPath, ctx.Param("task-name"), &taskName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter task-name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshalled arguments
+	err = w.Handler.DMAPIHandleTaskError(ctx, taskName)
+	return err
+}
+
 // DMAPIPauseTask converts echo context to params.
 func (w *ServerInterfaceWrapper) DMAPIPauseTask(ctx echo.Context) error {
 	var err error
@@ -689,7 +767,11 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.POST(baseURL+"/api/v1/sources/:source-name/transfer", wrapper.DMAPITransferSource)
 	router.GET(baseURL+"/api/v1/tasks", wrapper.DMAPIGetTaskList)
 	router.POST(baseURL+"/api/v1/tasks", wrapper.DMAPIStartTask)
+	router.POST(baseURL+"/api/v1/tasks/yaml", wrapper.DMAPIStartTaskWithYaml)
 	router.DELETE(baseURL+"/api/v1/tasks/:task-name", wrapper.DMAPIDeleteTask)
+	router.GET(baseURL+"/api/v1/tasks/:task-name/ddl-locks", wrapper.DMAPIGetTaskDDLLockList)
+	router.POST(baseURL+"/api/v1/tasks/:task-name/ddl-locks/unlock", wrapper.DMAPIUnlockTaskDDLLock)
+	router.POST(baseURL+"/api/v1/tasks/:task-name/handle-error", wrapper.DMAPIHandleTaskError)
 	router.POST(baseURL+"/api/v1/tasks/:task-name/pause", wrapper.DMAPIPauseTask)
 	router.POST(baseURL+"/api/v1/tasks/:task-name/resume", wrapper.DMAPIResumeTask)
 	router.GET(baseURL+"/api/v1/tasks/:task-name/sources/:source-name/schemas", wrapper.DMAPIGetSchemaListByTaskAndSource)
@@ -703,85 +785,97 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+w9bXPbNpp/Bae7D21HsiTbcRLf7AfHdrO5s52MrU5vp5NTIBCSsAYBGgDtajP+7zt4",
-	"IQmSIEX5JY1a74etQwJ4HjzvLwD1tYd4nHCGmZK9w689iZY4hubPY5pKhcU51P+vHySCJ1gogs1rGEXm",
-	"aYQlEiRRhLPeoXmKpQR8DtQSA5QKgZkCsVkEMB7hXr+Hf4dxQnHvsDfefb0z2hntjA/f7B6Me/2eWiX6",
-	"uVSCsEXvvt+DlNziOhzOKGEYSAVV6qAR6cD4EJRIcb7qjHOKIdPLUgwjHMCfSH8lswc3tMOiDMYG1WJ/",
-	"dpnAxu77PYFvUiJw1Dv8zc7MNptj17dE/pzP5rN/YqQ0KMecX7m4/gOZM+Mpi6aSpwLhabb7MkwzBNgh",
-	"QA/JmXVncK+DjVfyhg5GbQAVXDSD0i/XAjFjQxDqPLRLdOehJn0Z0xChgkwVGCo8gfL6Et+kWKo6YwWO",
-	"+S2exlhBS4A5TKnqHc4hlbhfIcjdEqullmIO7Dyg54EIKjiDEgPCQMTvmFQCwzh/3AuJtof6lBKL2X8J",
-	"PO8d9v5zWJiQobMfwysz/gLG+EyPvu/3FJTX62bprdfo6m/ZLRMi3gmmWGEL9xLLhDOJ6/TT07vvQuNT",
-	"7OE+APVUCC5+JWp5jqUMSqWGDvXfAOuxvX4FI/N0irSA1uaadwBZ4XWwCVN4gYUGbqfGctE0M3ZIrRPd",
-	"YqG+j0+IzO+xKjkGTZpmcmuZ0v8lCsdyHbXLDqegNhQCrsy/uYLUcLFCisp27Li+hd6+CWtAn34TzjA/",
-	"8yastD8h9nbBb4P2lfHdT4q4XfK50ddW4Qlpbo3e86P8tPROZ8Wa3wL7CZxRfKVEilQqWgy8RXCKjCud",
-	"yhtadubHl6dHk1MwOXp3dgq+qPEX8MMXEn0BhKkfxuMfwcXHCbj45ewMHP0y+Tj9cHF8eXp+ejHpf7r8",
-	"cH50+Q/wv6f/sDN+BMOfJv/xG7LqjqMpYRH+/TM4PvvlanJ6eXoCfhr+CE4v3n+4OP3bB8b4yTtwcvrz",
-	"0S9nE3D896PLq9PJ31I1fxPP9sHxx7Ozo8lp9u/pjLBQaOK2Vo9QolkwWFKaZIHh5vn6eMabnq3lUTXE",
-	"qjMOIycRNYdUROqUwwikjKiaK5wTRuQSR9PZSrknXMRQWfE52A86QR0XaIpRvvDEraCC9366UCQKDkoE",
-	"X+jQOPjSiOgGOFXoWNlVeT0PdHkrAcRDJP9oogsc0pA8gqwkAcgEI4oDG5lgYHgLhJtQYwpN5bIUa9r0",
-	"p7zqr4IoLE1aYcVUAzBJxhKj64QTpoDUT6ACJ+cAQWblgCgA5zr7EFgqKBRhCzPNhHnBQPSGThFnCrPA",
-	"3uQNBSuegjvIlLfDUuQfsADgCxoXJiDTUm0G+uAL2m1+tRd+9Qi9/++g4q8Yqm/2lySCGc15okhMpCII",
-	"yCUUkSajlh9tVcEdUUubDTnWcEZXIJU4AndLzAB0oSngCKVC6rSgac2TkzMQl8LRnDUVqff5FBLcT6kI",
-	"RcsCU7gClC8A0sumCUg4JWgFEGdzskhtKF0Pon9PiHBuLBPTUVVGzSAbiitiE9EcXK9f12uWUqpVo5Lw",
-	"e7ZH/ylurZ/L4e4djGqgJ0udbdnBWjATLAiPCIKUrqyKAGKT8oIARAK7ragP3OLgFtIUHwIDQvNJYsRZ",
-	"JB+GvcAxJGwqE4hwaQfjV1X8zwkjcRqDucAYREReAzPL4PD+3UPAh5KpS7339Q7EZ1pZDGwlw/MG5SXS",
-	"xKW6dgCYE6rZYnG3YlXYiR9sHWJG2M5I/2/cB+O3r9/+GFLQEtzcy5SBv598OMkqLxkiJYD4LRzP0e7u",
-	"AKPRm8F4jN8OZrsQDUa7+7sQjcej0WjvcDx4/Wb/bQgHQ5V2FCzhsrKPRujpEUBQoeU0TaZxXjdsLEqY",
-	"sSBNrIXKueN5xLr9t1AiElhZUzYiAiPFxUqbNoHrKiUV1xbK3/fOUKYzs2TI9oZrTRkRrVSWlrtMGdOT",
-	"18VXZWENCpG/3RCHm4ieoR0yvFfGB+RVjbqeWR9hSnWmRtIvMoL1IWclC7jCKBVErepgjGdyZUEpadm+",
-	"9w3f5gTTCNwRSsEMgyWJIsysx1pglUcK/kKlRcBc8NgMMZZ3rq1c3S6VDQjCQk0hpfwOR1PE6mgf8zjm",
-	"DFy4QubV1RnQc8icIGjjuZxYa4kjJZ0i2BzNeAtbU5WN9KUtKLN6Yb2TxqV/9pbT+/h0eg6sGRz+36vR",
-	"W/d3dWvroV7jVTPQ4wKe5koiyK3e2jVeZZYYeMDXwKuGG2VaBmhQRzCoHS7SeS94mgRy5IjmhcPujJ4T",
-	"IdWUcmS9TGiKDvFwtNmyCooFVsGhKdt8wVr6Z1bvF3uubSRH2wMYJKqtKtVNjX1eC+aYSTYLH9axxp1K",
-	"bJyaCbBSbTWMqZTWEIR8rlux7mWWPGgarZVxbZRwrySkGQmU8o6LqHHFfEB5yb39VwfB9bhoxs689NbZ",
-	"2xsdhKK/JAvA28o8NkrX8ukZ8ta6UDau3DNoxNaVF7p1f6yzrSvgYwqDqQwFKQ47/bKGoeBcrbdH3t6d",
-	"ODm+OZCeVPRLEt+sQC0+22uvNftsO2rQzXH7ZGuClwc/oY7G+raE9eWSx1gttTe/EzwUNmVBjsyRaeO3",
-	"n0M8TgYFTihBsEEWbVewYWGd7rnOowsU6QrY9qQri+Smr9pnHIw3lC0fkaDsKCiUoUqHkpCpwQDoQuam",
-	"klCHVCMv5oTSHpDHvA9NPhqyy4ZsqIH7GYo6+Cg60+1oVpLC3e645CmL82a9naF9YUE8JpVZi4EnI536",
-	"nrZ7Vep8+gJYWy4sdzzpLnY8WSt1f8gmSm2OWjhIOYw62iWvNu6dMqhwHcrrzBrVnV93S/aAJHaRS7+r",
-	"vHr+Lg2nszb667j9qxVDxfZN9T+8ff0KGEg+DqYC2g/FuQJLTm9xNDVhKkfX04YSf6vBzo6JBOkXPufR",
-	"bIUzert9BuWqIEdLoUvvGqRpoFPiDJtdN7DZmaYEYQtNlRAIv557tyRomVeFiATZ5I2S2VrprWORLGAt",
-	"EWZqqpKuDSBXA53O8JKwyKs7dZmbZ0mBToN+17qj0ojmHdl+D77Nzth1wMtO6U4DTw8WOnNt47kdUGE7",
-	"FBikbJCt4rO+Va1L6fLalNInhL/JEtf73SpjZfYEmVHVgxCdvBzWV6omsQops2m8Pbag1tSUrWvaxB2k",
-	"qhvPJjMxJ1TTT6QUu8OBRM+C9FNp9LpDCu8IO+OLn81il3qtUA0fsyVkCE/tAc1p1o5fQrbAa7uIXjJv",
-	"UyIg00RnTWDO7WFMd+4ziihIaLogrMu5TNNJtZiUQ7AoHrhjZZXiZP1UnMFAR1xZa62xcVAs2ni4sNnt",
-	"+wIhr8O5GmfTKDW5iQqstuR3mn5LyCJb45tTghSOzE5MrpnGWhn5LRZ3gtjuqDmZ9jnk4rWCT+Pg6TTN",
-	"jzu4MpV8zrUdgAprl+JBSbCUrovY6/eKlmIYmHWptorbRSJtlnpsx+cnHmKyEFDhXN6r1NZy5cYAM6bf",
-	"/aCO0fVzO7miA5W63AbbmJgJJ1DBd1Di7LBkA9UzzF0bNiP0PKVUb4QhgWPM7KEaSM1BjUKooBnUKb4p",
-	"UFij1BWBrO4/yJUqr8NmNWByQpVshY1S6oUlgCrr7lF8i2nNJJIF4wJbJxSoT+jHWfiZC0XLmBJpQRTT",
-	"Lhbc4eAOJ9WPMCRQKSxMZmRNdzMyTcMLvP7/RPBkPVb3DRz4OaXUybvWs0ASUeq58DnQkpjrl5aieoUI",
-	"cSaJVJihQGfImBOmBKcgszCEuXDFNHtsY5wLbdTm5nxsvhqAUqZCy2qZN6niIRLo5cK9RG3pdVIUEVE3",
-	"zTvDDP7UGdXaynbAVC0FhlH5XMJ+1dsYgtkJmn6IMxeVBUM9EjeuPD4ILm1nrF26SQI+MCQ2kwDPCDUI",
-	"gMAJnc6gQuWTReP6yQl/LR2pLQVn5F85KLMGwL9jlJpHWh9uUsgUMaDCxx4S2pF81Y08mIbN0WHu/Ftj",
-	"w6ZQIBQbFk6xXrCopCoFiNHeHI12D/YGu2/Q68F4jF8P4MGrvcEBGs3e7Eev3s73RofjwevR/nh/d68/",
-	"erX/ej/aQ97wN3uvdge7o71otrt/EEV70eF4MH49Ct7iKJflvFsZ5oU7b9EyM+FlCu0Hc7vnKf22FGOb",
-	"vFgpTGlAZSAwhdqitR900gqdu1LkeLwuvqja8HsbJ2y8TtUSlEO2RiJXd9Q52PIkeV1q6ePRxIZa7NZ8",
-	"QMgGiYr792P8kFF2TLUq1ti8NAtkkhfQdv26m7bL1r5qR4ny86KGtLUP7giNEBRRlo+VE57Z4KdHVixr",
-	"TaqmSqayde5wUN8BVxXEtbXB4giUwQ5JV9GJb8ojn5IZEccSMK7y5DjbsaywZfxACnYEoGYdzOM64gVJ",
-	"36LCpUypheBF4t5O8W3s9G/W6H9I6/6ZuuLtffAQ0ytNnLbCfUsA1dxarRvVAmJj48p1qCTIlFpx1+6V",
-	"bV2rdW2HB7SC25u/9yYR0bkhpCccBdLHk3PwMcHs6NMHcPLxWPNE0N5hb6lUIg+Hw4gjuZMQtkAw2UE8",
-	"Hv5rOVQkmg20cg2sQyScDaXVbhNXzLkpghNldlIDcIuFtLB3d8Y7I1PPSjCDCekd9va0ZhmRUEuD7RAm",
-	"ZHg7HrprNkNbhTWvnMHNr1d+iAy4o08fQlcUTYnZ3hkys3dHI5eIZmfVYGIrGHo//5T2mFZhjtsUp/VK",
-	"pGFCRY1ShLRVue/39p8QjdpV1ADoOSQUR0aMZBrHUKx6h5qSwBHYvwue6ZOCC6llzQ3pfdazGxgz/Gr/",
-	"MP773sobxbZMGeDUx/mcEoYt2S5srSmBAsbYcvm3WvHLQy+LoPRzLTC9rL7a83Do+fpi68MFNbvc0/9c",
-	"E5z9gGH8zjjKLV0rN/s7MTKzYx01rLg/+200LHBfd8s0zPsiwUYa5hgz/Oqcw0Ya5pxaBw3z0WvWMA+H",
-	"v7aGlb8v0crIKN7JkAtq1nusTjj6n6uPFw2qVEZLr5WfCK+LW8QRMOAKrCKOKhi5mKAFnb9Pzs86oaMH",
-	"rkFnqWx5vAkdG16uNz3Frfd1wqwhu6DVXDHJDywakb5JsVh5Mk3UcpqPCMhwuL0YkN8nNXyBO/4BIfVv",
-	"QdDsuFOFBdUhBSuyrMtkHLKJ9PbzJFfZyUUXBb/j0erJ9pt9f6C+QQcNzDS4+xrJx98Ahe/NBtnb2IDh",
-	"O5+3IbbWlWz41Su0rHcj/sdV1iod5TNzrzFl5CYtX9Bp9ijluk8nj9J4Uvy+X6u8cXtemSe2jg+pdKcO",
-	"s1OVJo1zvYqQdTArPNIu7D+ZzAQ/drMFImuFDMDHCuwwgam0FU5jfFqs1ic90hxE3QLB/dzF1X5vTDW8",
-	"8I4mz1NmT/Zmh3Yey2yBZRp34/alGfrC7mdkt+XGc/Lb+whhh0DQXmjtEg4+A3Ob79Q8a1xYucS7JSlw",
-	"dm3INjOaYtCu4jH8av8oQpgOwmJ6gN+frPRbGj4N4Iu9dwQf7Ac9q5SWD8Zul5DaftjDZVRBoTp5rOJ+",
-	"1rY4rGdI+2p31O7LnQ6N7P02Okt3jPk5nWV+jaSLr8xvbH4/gtZ62OabFFcqX8bbEkPlf8LX/w7yU4gU",
-	"TzraLnfH769suirXHP8slisi8rlNlxKQybn7fnWzlE3csK0pPz2TqNVPJvxZZC0ThDz44gDab43Z/soa",
-	"6bJ1u3UeMPts6zM3Kmtfhw0QwdQgqfsw9vfjUHKsCnLbz2239wVM9Dax98+eQ+7rn0X/I/sD7hvl29Id",
-	"gPaT+ELln9Qsc7aqRsOv5rT5Jm0Bx/qNrLJ/5y1gjnMcOhrjphPygcQ68KmUQLm/9r17H+6jvq2ynf3u",
-	"rHBvpMl1UTYTJluy71Ks/57l6fNz9j39Usn99nYCHiAbtqbcqbb/Ih3bKh2ucfAA8XhkmyBvELxbaek5",
-	"YtHDUonvwWm9NC7+qMi4tXvxaCnesJuR9zFeRPqlv7K1uhRssjyxKul5M4o3zGj8n1J40anvTKf6zZfX",
-	"mkieSUBnmjf8RMo2J291zZOeiNcqPuv9z4uGvGjIt+7YtfwW1NY6wFY1TNImNcx/++dFFTcG/ldRxKcv",
-	"Rqz9xak/S1Oq+HmsDfS1PWrtdlTD++LsX6mqvlEF7Bt4mS09FWKkNZOeqnSazwGI20yayre9VzzdiXgM",
-	"CTN3vXuayG6Bxl9uaL9eHnH0yDvlw5uUoOuBPU5nW1oDmf9IaUmseiFj675l/E2QdOjlbwfK/bKnp34B",
-	"JLPLgvm47MH95/t/BwAA//+0gXDln30AAA==",
+	"H4sIAAAAAAAC/+w971PjuJL/ii53H3a3EpIA84tX7wMD7Cx3wExBpva2trig2Eqihy0ZSYbNm8r/fqUf",
+	"tmVbchwgs2SX9+EtE8vdrVZ3q39J/tYJaJxQgojgnYNvHR7MUQzVn0dRygVi51D+v/whYTRBTGCkHsMw",
+	"VL+GiAcMJwJT0jlQvyLOAZ0CMUcgSBlDRIBYAQGEhqjT7aA/YJxEqHPQGe6+2xnsDHaGB+933w473Y5Y",
+	"JPJ3Lhgms86y24ERvkd1PJREmCDABRSpwYa5QWNjECxFOdQJpRGCRIKNEAyRg37MbUhqDmZoC6AExorU",
+	"Yn4ajGNiy26HobsUMxR2Dn7Xb2aTzanraiZf52/Tyb9QICQqszi/Unb7Jy7OhKYkHHOasgCNs9mXcaoh",
+	"QA8Bcki+WA+K9jraeMHvot6gCaGAMz8q+XAlEjXWhaG+hhpE+zWUrC9T6mKUc1EZggKNIL+9RHcp4qK+",
+	"sAzF9B6NYySgZsAUppHoHExhxFG3wpCHORJzKcUU6PeAfA+EUMAJ5AhgAkL6QLhgCMb5zx2XaFukjyOs",
+	"Kfsvhqadg85/9gsT0jf2o3+lxl/AGJ3J0ctuR0B+u+otOfUaX+0pGzDNzPsVi/lvMI68TAzmKLgdUxIt",
+	"VvNQjgLqBaUzEj0IKJniGYAkBCFVPycMqTHdYtADjiJAqAATZaaYQKGTs9u9ouMFjKO6KtpswgT8dnh+",
+	"BqaUxVBoDnFpCCCvsXSKIwRSjkIwWYAwDkS0Uu0KKtpKBU8o4aguFgqS24oVhsvQi0mV9naEenX/+Pjs",
+	"jAa3ddQpYYjT6B6FgM8hC8Hx8RmI5NBuhf4wjHj9/ePjMw4m8gXNVbWl6dexQDEvG7vDs9HJJRgdfjw7",
+	"ATfh5GbnRkyiG3B4fAyOPp99Pb8AN8HuDTi9GLmMp/kBMgYX8t8SzxiHdaJOjzNuGlIKCiSbesNegdyF",
+	"KJb2vAZV80c+80JPEOc4xlzgwAWXPhCXV6B+zjYxH2j9uOfct/iCBCjfADJ1q1CvHnLwwwMWc4UiTYwi",
+	"CziJEP8RPMxxMAdzeI8AQwHCUijkQLnG9nquXJgGUZePgNnLanBS8vwzkTayNhuwQGKNGVVULZM7e6JG",
+	"aLI17mp1cS6NZ55OtUUREkjbxhaWpZWlldaqsLNLB9YTxiiTBu0cce70hSR2KP8GSI6t2Qr16zhwqpF6",
+	"BgLNLYMbE4FmiEnk+tWYz3xvxoaoVQaxANS16XGx+RMSpXBEssbPbrnvyf/m0tPE7RJcp65QASNLBnNW",
+	"VO27GtfV2Jsnod3255+EhrvpSWhpf0bqNcDvQ/aVihiflXANctPkS6tgnIRnZL2B+D2If0aqdZyweZKf",
+	"V1jSSQHze1A/knvtlWBpIFLWsDtpAseBcpXH/C4qu4RHlyeHo5PMJxTDG/DDDQ5vACbih+HwR3DxeQQu",
+	"vp6dgcOvo8/j04ujy5Pzk4tR98vl6fnh5W/gf05+02/8CPo/jf7j90DbKhSOMQnRH9fg6Ozr1ejk8uQY",
+	"/NT/EZxcfDq9OPnnKSH0+CM4Pvn58OvZCBz9cnh5dTL6Zyqm7+PJvnRGzw5HJ9m/xxNMnH6Xnlo9qA8n",
+	"Tj9NuSeO4er3YQsXP389g2Vx1bVUv0ASRio+UXu6FbJWUjeB/EPGfXP1hnKU9HY7wSSiM4DuERF2jFLb",
+	"8/XAcUIdUYIBklCOFSLKwKdR4aWXcOREdEtpJAc1mJuRIcBT5ejxBAV4ilUsXE35TDDZGcj/DQ/2dt8N",
+	"nP55Uied3+KkCxhKIii9cwYYukdMeDgk8ZI0lqslX+x0O+ZN9Zd8sXPtQPz0aJnfuaIz+auO5jX5VWZ3",
+	"gUpAqJB4SnVCMp9q5uO1CuWGMpwaVkK5/UKNM02TqvyP1cFdRfJp4hTvMwpDY/DqU89ztxGFIUgJFjWR",
+	"nWKC+RyF48lCmF9UFkFbx7f7Tgc1RgKONROdoYP1fDwTOHQOShidMcQ9wYe0wGvQVGFWZVZleBbq8lQc",
+	"hLtY/llJBXJtACtti5YopKM0wMwLtUWJUj4v5ap0QrwM9VeGBdIZHq0UEoH8l0qUJRQTAbj8BQpwfA4C",
+	"SLQcYAHgVCCpxipthsnMNmmORNZdNA4oEVK5XfoFFjQFD1AbLjPDTrd5gwM3wdCtGl2dAPE92nM/esK2",
+	"9g9fPqE+2a9JCDOe00SYPIdOG0k2SvmRTgMwcTnm2dIURuZhjgiAJmwENAhSxrNslwumzEbFpVAxX5qK",
+	"1Nvr5BLcLylzRbIMRXABpEEMJNg0AQmNcLAwabc0N4GVAPePBDPES2I6qMqoGgS19GOd4cvRdbp1vSZp",
+	"FEnVqJSALNsj/2T3MCrh3Xs7qKEezRHIBkvBTBDDNMQBjPJ8M54ac58xAHOgpxV2gQEO7mGUogOgUMh1",
+	"4iigJOSPo56hGGIy5gkMUGkGwzdV+s8xwXEagylDCISY3wL1lqLh08fHoHclOi7l3FdvIPailcVA17as",
+	"3aAMIs9L6QE6/4yJpl2LVWEnfqi6KV0w/PDuw4/OBKWNN99lyshtBysjpIQQfYDDabC720PB4H1vOEQf",
+	"epNdGPQGu/u7MBgOB4PB3sGw9+79/gcXDYorzSRoxmUenCTo+QkIoAjm4zQZx3kl2VvUUGNBmmgLla+O",
+	"tSO6CigSS4gdkCVnQ8xQIChbSNPGUF2luKCs4o/u9Hk6USBdttddfcyYqKWyBO4yJUS+vCp8KAurU4js",
+	"6bpW2Mf0jGyX4b1Se0Duq9b1TD3XxVuTH3W4m76IqhLkXqEgZVgs6mjUzmRy7JxHZfuuQ40pRlGoK2sT",
+	"BOY4DBHRO9YMidxTsAGVgIApo7EaoizvVHv9VbtUqRUiJsYwiugDCscBqZN9ROOYEnBhKkRXV2dAvoOn",
+	"OIDan2ufluc8GgfQ781YgLWpykba0uaUWQlYzsQL+mcLnJzHl5NzU7Hr/++bwQfzd3Vqq7HeooUf6VGB",
+	"T1dR8b2c2i1aZJYYWMhX4Ku6G2VeOnhQJ9CpHcbT+cRomjhSQGGUB4TtF3qKGRfjiAZ6lzn45nbxULge",
+	"WAHZDAnn0KyisQ7AWnZDQe8Wc65NJCfbQuhkqtJOX+Wo7swRlUsp9rCWNfKUI53GkA5WKq2GMpVcGwLX",
+	"nmsg1neZOfVXuoBprHF3z7g0I4GcP1AWeiHmA8og9/bfvHXCo8xPnXpowdnbG7x1eX9J5oA3JTa0ly7l",
+	"0zLkjZmQbFw5i+KlVj1s2w+kN9u6Aj4laZ9yxLzUyYc1ChmlYrU9suZuxMmsm0FpSUW3JPF+BWrYswtm",
+	"NuzZTSXr2sZts82HL3d+XNXG1SVDvZdzGiMxl7v5A6MutylzcnhOTNN62zHE02RQJt5wAD2yqPvEPIBl",
+	"uKcHZN52tAC6Yc2kRXLTV+086w3XlC2bEKfsCMiE4kqLlJDKwQBoXGZfSqhFqJEnc1xhD8h93scGH57o",
+	"0hMNeVY/I1E6H3bLTxOZlaBwtz0techidrPOTl8/0CieEsqspMCSkVb5bF1ZLnUl2AJYA+eWO5q0Fzua",
+	"rJS6P2USpSpezR2UKcyWdsnKjVt9p2s046xhyR4RxM5QpZhk7XepO5zV3l/L6V8tSFBMX2X/3dOXj4DC",
+	"ZNMgMbk7lLJ2ubFyU4sOtPUMdtY47OSfu/PXb4Uzfpt5OuWqYEdDokvOGqSpo1JiDJuG65is6gDEZDZ2",
+	"dwmW8rm6OSuTBWzaB/WStw9Gaqm3lkkyh7UMEBFjkbQtAJkc6HiC5piEVt6pzbt5lOSoNMhnjTMqjfDP",
+	"SNd7VIGv7Zz0K+15YOnBTEauTWuuB1SWHTIEUtLLoNhL36jWpXB5ZUhpM8KeZGnVu+0yY+XlcS5GVQ9c",
+	"fLJiWFupfGLlUmZVeHtqQs3Xc1DXtJFpra8bT5+ZmOJI8o+lETLHRVTZH0ZfSqNX9eB8xOSMzn5WwC4l",
+	"LFcOH5E5JAEa6yM746zbZA7JDK2sIlrBvA6JAE+ThDKRV8M1WBCGEUiidIZJm5M6qpKqKSmR0Anjnjlo",
+	"UElO1rvqFQVcUJaV1ryFgwKo97iJf9uvdka7oFAyDlMVmwgHtDl9sBpHZEY0woFAoZqJ1Q9B7xF7YFhX",
+	"RxmjzN0JIRV87G7AluvxABcSW0CptANQqH5eC0u5A7soKTa1XZgO+xYSqaPUIz0+b+iJ8YxBgXJ5r3Jb",
+	"ypUZA9SYbvs+NKXr5/rlig5U8nJrTGOkXjiGAn6EHOUd226uZ5TH5pCTYfQ0jSI5ERIwFCOie8ZgpPqQ",
+	"CqGCUdTWvylIWKHUFYGszt+5KtW1dptVh8lxZbIFUkopAXMARVbdi9A9imomEc8IZUhvQnVo6ufM/cyF",
+	"omFMibUgjKM2FtzQYHrv6i0MCRQCMRUZadPtJ8Y3vKDr/44ZTVp1+DhX4Oc0ioy8Sz2rU1CuudApkJKY",
+	"65eUonqGKKCEYy4QCRyVIWVOiGA0ApmFwcS4K6rYowvjlAnTJWVBA5DzlElZLa9NKqiLBRKcu5bIBWUy",
+	"KAoxq5vmnX6Gf2yMag2yHjAWc4ZgWO5L2K/uNoph+gXJv4AS45U5XT0ceyEP3zpB47gVaJ8EnJKArScB",
+	"lhHyCIDMrY0nsmpZnkC9c8KGJT21OaME/ztHpWAA9AcKUvWT1Ie7FBKBFSp320MStWRfdSKP5qHfO8w3",
+	"/0bf0OcKuHzDYlOsJywqoUqBYrA3DQa7b/d6u++DdzL99q4H377Z670NBpP3++GbD9O9gUy/DfaH+7t7",
+	"3cGb/Xf74V5gDX+/92a3tzvYCye7+2/DcC88GPaG7r7OSlquoEI/MP0WDW+aptb8xX1nbLeZ1G9DMta3",
+	"i5XcFA8pPYYiKC1ac6OTVOh8Kw3MGq/yL6o2fKn9hLXhVC1B2WXzMrk6o9bOliXJq0JLmw7fMtR8N3+D",
+	"kHYSBbXP19ouI28ZalWssXqoAGSS59B2+bidtvPGumpLibLjIk/Y2pVNGGEAWZjFY+WAZ9L76YkZy1qR",
+	"ypfJFDrP7XbqW9AqnLQ2FlgMgzLcLukqKvG+OPI5FyOkiOv2ehMcZzPmlWUZPpKDLRGISQvzuIp5TtY3",
+	"qHApUmpgeBG4N3N8Gyv96xX6H1O631BVvLkO7lr0r0Rm8KzTcC2qSVMqqRQUpOplXW8tHaZvPDijXh/r",
+	"CxccNjwDrgfkh8PVAY6siVefIJ9CrI97aPcU2Ye3HXfUtDs9X8xr/XP0nvPuZlkr51L0HCqYqzPpAixA",
+	"nHLhP6bepv/Ac6DbJRCVql5TJafBo/bX2uu7bIHRK3umZMlBZuUFNfV/3lTGXFWHekRvQHM3wFJFpkKq",
+	"b3RMA9f9Eefgc4LI4ZdTcPz5SCopizoHnbkQCT/o90Ma8J0Ek1kAk52Axv1/z/sCh5OetLY97SFhSvpc",
+	"m3vlaE6pRCOwiJALwT1iXOPe3RnuDPS5L0RggjsHnT1papWNEHNFbR8muH8/7JtjhX2dllePzA6cn5M6",
+	"DRW6wy+nrvPkquagz0iqt3cHA5OZyJoXYaJTWnI+/+K6b6/Yn5ssaeP5dbUIFQVMgwBxVRndf0YyavcG",
+	"OFBLG4VCJUY8jWPIFp0DyUlgGGxfF5Xpk4AzLmXNDOlcy7c9C9P/pv9QDt1Sy1uEBPKs1OfpVGYYNdsu",
+	"dPIxgQzGSK/y77VsqEVe5lLL36XAdLKEe8eioWPriy4YFNxsc5XXdU1w9h0m9YWtKNV8rVz+1WohMzvW",
+	"UsOKyw6+j4Y5LlfYMg2zLi1bS8PMwvS/mc1hLQ0zm1oLDbPJ82uYRcPfW8PKV9A1LmQY72TEOTXrExLH",
+	"NPjvq88XHlUqkyVh5UcE6uIW0gAodAVVIQ0qFBmfoIGcX0bnZ63IkQNXkDMXcdREjrlZaKXpKa4oWSXM",
+	"ErOGqs8c5R2sSqTvUsQWlkxjMR/nIxwy7K43L6/d7Hkuw+e4kMUhpPaxmAhz5xJUhxRLkYXhKgTlPtbr",
+	"69Y0PUbrERcfabh4tvka4I4JGmxgItEtaywffgcSXpoN0rdPAIIe7LV1LWtdyfrfrMzb6m3EvglrpdJF",
+	"dKIOuqYE36XlE1v+HaWcCGy1o3iPDiy77jBe9Z2qCgyMuGlDzdpsVRhnilcu66AgPNEu7D+bzDhvJtsC",
+	"kdVCBuBTBbafwJTrlLcyPg1W64scqTqTt0Bwr9tstS9tUdVaWL3q05To5FzWxfXUxWaIp3G71b5UQ1+X",
+	"e4PLrVdjk+tt3VPewhHUJ5zbuIMbWNyGJOcm/cLKqe4tCYEN/zUsrw/aVjz63/QfhQvTQlhUUfjlyUq3",
+	"oQLoQV/MvSX6cPK9pbTcKb1dQqoLpI+XUQGZaLVjFQf2tmXD2kDYVzu0uFwuq8Qut3GzNH3tm9ws83NF",
+	"bfbK/AjvyxG0xu6r75JcqdwEuiWGyr6e0f5UynOIFE1a2i5z6PPvbLoq517/KpYrxHzTpkswSPgUsRVS",
+	"NjLDtib9tCFRq3cm/FVkLRMEUPSmQH35nK6vrJAunbdbtQNm11RvuFBZuw3bwQRJsEnTv6QNJaeqYLf+",
+	"Ik9zXUB5b3LaGyoK1L+c9GfWB8xnjLalOgD1V7OYyO9YLa9sVY362Td/2ix49tmdjS989atP6wvAYKNU",
+	"+VVd3/aZQM7lCnWfVRTXo+TFCajiDGXAKakg/7yM/3NTLaT5m/zPWkUuY8jW8jHsI70O5yKnoaVr4TsA",
+	"5EgTOW6CchSvahec23ifdHXUdnZvZGUoEpoiYDvTaAlTPwyjnmxcbed3WN/2+FvJ1jq36G861vd9Y2WL",
+	"wn3v5+J4pcX9UZLcN43mzZt/rUX/pQr0hqIw7xGFv0owtt55iuz6eOvmB1B8qm9NUdS3WPT0/RTNYlj5",
+	"tsrfTAg9X5b5q4jgel99eYLtU60LbRpIXrJXeL3JXjy7fLfc3u6UR8iG7nNo1W/yKh3bKh16kR8jHk9s",
+	"XcmbVj4upPQckvBx6e2XEB68NtP8Wdnaxo6aJ0vxmh02eW/Nq0i/9vxsrS45G3+eWZXke5MIrZmXtL/3",
+	"9qpTL0ynuv4bNnwszySgNc89nynd5hRsXfO4JeK1KuTq/edVQ1415Ht3kTV8j3lrN8BGNUxSnxrmHyh9",
+	"VcW1kf9dFPH5kxErP4v7V0mMFt/wXUNfm73Wdu3D1mcxXuuXf179cks7lZW0ZtJTlU45HLH7TJrKNxAt",
+	"aLoT0hhiou4f6iyvcwDez8s1X3kU0uCJ9xz171Ic3Pb0EQ/dvNIzyJcVseq4jC2//X5EGvLypz2FfllS",
+	"PweR2QUW+bjsh+X18v8HAIl2YMtWlAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -10,6 +10,15 @@ import (
 	"github.com/pkg/errors"
 )
 
+// Defines values for HandleTaskErrorRequestOp.
+const (
+	HandleTaskErrorRequestOpReplace HandleTaskErrorRequestOp = "replace"
+
+	HandleTaskErrorRequestOpRevert HandleTaskErrorRequestOp = "revert"
+
+	HandleTaskErrorRequestOpSkip HandleTaskErrorRequestOp = "skip"
+)
+
 // Defines values for TaskOnDuplicate.
 const (
 	TaskOnDuplicateError TaskOnDuplicate = "error"
@@ -71,6 +80,51 @@ type CreateTaskRequest struct {
 	Task Task `json:"task"`
 }
 
+// CreateTaskWithYamlRequest defines model for CreateTaskWithYamlRequest.
+type CreateTaskWithYamlRequest struct {
+	// only check the task config and do the precheck, the task will not be started
+	CheckOnly *bool `json:"check_only,omitempty"`
+
+	// whether to remove meta database in downstream database
+	RemoveMeta *bool `json:"remove_meta,omitempty"`
+
+	// source name list
+	SourceNameList *SourceNameList `json:"source_name_list,omitempty"`
+
+	// task config in YAML format, the same as the task config file used by dmctl
+	TaskYaml string `json:"task_yaml"`
+}
+
+// CreateTaskWithYamlResponse defines model for CreateTaskWithYamlResponse.
+type CreateTaskWithYamlResponse struct {
+	// name of the task in the task config
+	TaskName string `json:"task_name"`
+}
+
+// unresolved shard DDL lock
+type DDLLock struct {
+	// DDLs blocked by the lock
+	Ddls []string `json:"ddls"`
+
+	// ID of the lock
+	LockId string `json:"lock_id"`
+
+	// shard mode of the lock
+	Mode string `json:"mode"`
+
+	// owner source of the lock
+	Owner string `json:"owner"`
+
+	// sources (with the upstream tables) which have received the DDLs
+	SyncedSourceList []string `json:"synced_source_list"`
+
+	// task name
+	TaskName string `json:"task_name"`
+
+	// sources (with the upstream tables) which have not received the DDLs yet
+	UnsyncedSourceList []string `json:"unsynced_source_list"`
+}
+
 // DeleteSourceResponse defines model for DeleteSourceResponse.
 type DeleteSourceResponse struct {
 	// task name list
@@ -110,6 +164,12 @@ type GetSourceStatusResponse struct {
 	Total int            `json:"total"`
 }
 
+// GetTaskDDLLockListResponse defines model for GetTaskDDLLockListResponse.
+type GetTaskDDLLockListResponse struct {
+	Data  []DDLLock `json:"data"`
+	Total int       `json:"total"`
+}
+
 // GetTaskListResponse defines model for GetTaskListResponse.
 type GetTaskListResponse struct {
 	Data  []Task `json:"data"`
@@ -129,6 +189,24 @@ type GetTaskTableStructureResponse struct {
 	TableName       string  `json:"table_name"`
 }
 
+// action to handle the error binlog event of the task
+type HandleTaskErrorRequest struct {
+	// binlog position or GTID of the binlog event to handle, the current error binlog event is handled if not specified
+	BinlogPos *string `json:"binlog_pos,omitempty"`
+
+	// skip, replace or revert the error binlog event
+	Op HandleTaskErrorRequestOp `json:"op"`
+
+	// source name list
+	SourceNameList *SourceNameList `json:"source_name_list,omitempty"`
+
+	// sqls to replace the binlog event, only used for the replace operation
+	Sqls *[]string `json:"sqls,omitempty"`
+}
+
+// skip, replace or revert the error binlog event
+type HandleTaskErrorRequestOp string
+
 // status of load unit
 type LoadStatus struct {
 	FinishedBytes  int64  `json:"finished_bytes"`
@@ -474,6 +552,18 @@ type TaskTargetDataBase struct {
 	User string `json:"user"`
 }
 
+// action to force to unlock the shard DDL lock of the task
+type UnlockTaskDDLLockRequest struct {
+	// force to remove the lock even if the owner fails to execute the DDLs
+	ForceRemove *bool `json:"force_remove,omitempty"`
+
+	// ID of the lock to unlock
+	LockId string `json:"lock_id"`
+
+	// source to replace the owner of the lock to execute the DDLs, it must have received the DDLs
+	Owner *string `json:"owner,omitempty"`
+}
+
 // worker name list
 type WorkerNameList []string
 
@@ -510,12 +600,27 @@ type DMAPITransferSourceJSONBody WorkerNameRequest
 // DMAPIStartTaskJSONBody defines parameters for DMAPIStartTask.
 type DMAPIStartTaskJSONBody CreateTaskRequest
 
+// DMAPIStartTaskWithYamlJSONBody defines parameters for DMAPIStartTaskWithYaml.
+type DMAPIStartTaskWithYamlJSONBody CreateTaskWithYamlRequest
+
 // DMAPIDeleteTaskParams defines parameters for DMAPIDeleteTask.
 type DMAPIDeleteTaskParams struct {
 	// source name list
 	SourceNameList *[]string `json:"source_name_list,omitempty"`
 }
 
+// DMAPIGetTaskDDLLockListParams defines parameters for DMAPIGetTaskDDLLockList.
+type DMAPIGetTaskDDLLockListParams struct {
+	// source name list
+	SourceNameList *SourceNameList `json:"source_name_list,omitempty"`
+}
+
+// DMAPIUnlockTaskDDLLockJSONBody defines parameters for DMAPIUnlockTaskDDLLock.
+type DMAPIUnlockTaskDDLLockJSONBody UnlockTaskDDLLockRequest
+
+// DMAPIHandleTaskErrorJSONBody defines parameters for DMAPIHandleTaskError.
+type DMAPIHandleTaskErrorJSONBody HandleTaskErrorRequest
+
 // DMAPIPauseTaskJSONBody defines parameters for DMAPIPauseTask.
 type DMAPIPauseTaskJSONBody SourceNameList
 
@@ -546,6 +651,15 @@ type DMAPITransferSourceJSONRequestBody DMAPITransferSourceJSONBody
 // DMAPIStartTaskJSONRequestBody defines body for DMAPIStartTask for application/json ContentType.
 type DMAPIStartTaskJSONRequestBody DMAPIStartTaskJSONBody
 
+// DMAPIStartTaskWithYamlJSONRequestBody defines body for DMAPIStartTaskWithYaml for application/json ContentType.
+type DMAPIStartTaskWithYamlJSONRequestBody DMAPIStartTaskWithYamlJSONBody
+
+// DMAPIUnlockTaskDDLLockJSONRequestBody defines body for DMAPIUnlockTaskDDLLock for application/json ContentType.
+type DMAPIUnlockTaskDDLLockJSONRequestBody DMAPIUnlockTaskDDLLockJSONBody
+
+// DMAPIHandleTaskErrorJSONRequestBody defines body for DMAPIHandleTaskError for application/json ContentType.
+type DMAPIHandleTaskErrorJSONRequestBody DMAPIHandleTaskErrorJSONBody
+
 // DMAPIPauseTaskJSONRequestBody defines body for DMAPIPauseTask for application/json ContentType.
 type DMAPIPauseTaskJSONRequestBody DMAPIPauseTaskJSONBody
 
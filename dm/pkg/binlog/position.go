@@ -208,6 +208,17 @@ func VerifyBinlogPos(pos string) (*gmysql.Position, error) {
 	return &pos2, nil
 }
 
+// VerifyBinlogPosOrGTID verifies the binlog pos string of handle-error operation, which could also be a GTID
+// like `3ccc475b-2343-11e7-be21-6c0b84d59f30:15`. If it's a GTID, the GTID set is returned with a nil position.
+func VerifyBinlogPosOrGTID(flavor, pos string) (*gmysql.Position, gtid.Set, error) {
+	binlogPosStr := utils.TrimQuoteMark(pos)
+	if gs, err := gtid.ParserGTID(flavor, binlogPosStr); err == nil && len(binlogPosStr) > 0 {
+		return nil, gs, nil
+	}
+	pos2, err := VerifyBinlogPos(binlogPosStr)
+	return pos2, nil, err
+}
+
 // ComparePosition returns:
 //   1 if pos1 is bigger than pos2
 //   0 if pos1 is equal to pos2
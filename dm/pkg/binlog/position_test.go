@@ -723,6 +723,39 @@ func (t *testPositionSuite) TestVerifyBinlogPos(c *C) {
 	}
 }
 
+func (t *testPositionSuite) TestVerifyBinlogPosOrGTID(c *C) {
+	cases := []struct {
+		flavor string
+		input  string
+		hasErr bool
+		pos    *gmysql.Position
+		gtid   string
+	}{
+		{"", `"mysql-bin.000001:2345"`, false, &gmysql.Position{Name: "mysql-bin.000001", Pos: 2345}, ""},
+		{gmysql.MySQLFlavor, `mysql-bin|000001.000003:3270`, false, &gmysql.Position{Name: "mysql-bin|000001.000003", Pos: 3270}, ""},
+		{"", `"3ccc475b-2343-11e7-be21-6c0b84d59f30:15"`, false, nil, "3ccc475b-2343-11e7-be21-6c0b84d59f30:15"},
+		{gmysql.MySQLFlavor, `3ccc475b-2343-11e7-be21-6c0b84d59f30:15`, false, nil, "3ccc475b-2343-11e7-be21-6c0b84d59f30:15"},
+		{gmysql.MariaDBFlavor, `0-1-100`, false, nil, "0-1-100"},
+		{"", `mysql-bin.000001`, true, nil, ""},
+		{"", ``, true, nil, ""},
+	}
+
+	for _, ca := range cases {
+		pos, gs, err := VerifyBinlogPosOrGTID(ca.flavor, ca.input)
+		if ca.hasErr {
+			c.Assert(err, NotNil)
+			continue
+		}
+		c.Assert(err, IsNil)
+		c.Assert(pos, DeepEquals, ca.pos)
+		if len(ca.gtid) > 0 {
+			c.Assert(gs.String(), Equals, ca.gtid)
+		} else {
+			c.Assert(gs, IsNil)
+		}
+	}
+}
+
 func (t *testPositionSuite) TestSetGTID(c *C) {
 	GTIDSetStr := "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14"
 	GTIDSetStr2 := "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-15"
@@ -0,0 +1,129 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reader
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+// zstdMagic is the magic number at the beginning of a zstd frame.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// IsCompressedFile checks whether the binlog file is compressed with zstd.
+func IsCompressedFile(name string) (bool, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer f.Close()
+
+	b := make([]byte, len(zstdMagic))
+	if _, err = io.ReadFull(f, b); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return false, nil // too short to be compressed
+		}
+		return false, errors.Trace(err)
+	}
+	return bytes.Equal(b, zstdMagic), nil
+}
+
+// FileSize returns the size of the binlog file, for a compressed file the size before compressed is returned.
+func FileSize(name string) (int64, error) {
+	fi, err := os.Stat(name)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	compressed, err := IsCompressedFile(name)
+	if err != nil || !compressed {
+		return fi.Size(), err
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer f.Close()
+	d, err := zstd.NewReader(f, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer d.Close()
+	size, err := io.Copy(io.Discard, d)
+	return size, errors.Trace(err)
+}
+
+// ParseFile parses the binlog file from offset like `BinlogParser.ParseFile`, but the file can be compressed with zstd.
+func ParseFile(p *replication.BinlogParser, name string, offset int64, onEvent replication.OnEventFunc) error {
+	compressed, err := IsCompressedFile(name)
+	if err != nil {
+		return err
+	}
+	if !compressed {
+		return p.ParseFile(name, offset, onEvent)
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	d, err := zstd.NewReader(f, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer d.Close()
+
+	b := make([]byte, len(replication.BinLogFileHeader))
+	if _, err = io.ReadFull(d, b); err != nil {
+		return errors.Trace(err)
+	} else if !bytes.Equal(b, replication.BinLogFileHeader) {
+		return terror.ErrBinlogFileNotValid.Generate(name)
+	}
+
+	readOffset := int64(len(b))
+	if offset > readOffset {
+		// FORMAT_DESCRIPTION event should be read always (despite that fact passed offset may be higher than 4)
+		header := make([]byte, replication.EventHeaderSize)
+		if _, err = io.ReadFull(d, header); err != nil {
+			return errors.Annotatef(err, "read header of FormatDescriptionEvent")
+		}
+		ev := make([]byte, binary.LittleEndian.Uint32(header[9:13]))
+		copy(ev, header)
+		if _, err = io.ReadFull(d, ev[len(header):]); err != nil {
+			return errors.Annotatef(err, "read FormatDescriptionEvent")
+		}
+		if err = p.ParseReader(bytes.NewReader(ev), onEvent); err != nil {
+			return errors.Annotatef(err, "parse FormatDescriptionEvent")
+		}
+		readOffset += int64(len(ev))
+
+		// skip the data before offset, as a compressed file can't be seeked
+		if offset > readOffset {
+			if _, err = io.CopyN(io.Discard, d, offset-readOffset); err != nil {
+				return errors.Errorf("seek %s to %d error %v", name, offset, err)
+			}
+		}
+	}
+
+	return p.ParseReader(d, onEvent)
+}
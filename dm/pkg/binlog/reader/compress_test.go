@@ -0,0 +1,99 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/klauspost/compress/zstd"
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/ticdc/dm/pkg/binlog/event"
+)
+
+var _ = Suite(&testCompressSuite{})
+
+type testCompressSuite struct{}
+
+func (t *testCompressSuite) TestParseCompressedFile(c *C) {
+	dir := c.MkDir()
+	filename := filepath.Join(dir, "mysql-bin.000001")
+	compressedFilename := filepath.Join(dir, "mysql-bin.000002")
+
+	// write a binlog file with a FormatDescriptionEvent and two QueryEvents
+	header := &replication.EventHeader{
+		Timestamp: uint32(time.Now().Unix()),
+		ServerID:  uint32(101),
+	}
+	latestPos := uint32(len(replication.BinLogFileHeader))
+	formatDescEv, err := event.GenFormatDescriptionEvent(header, latestPos)
+	c.Assert(err, IsNil)
+	data := append([]byte{}, replication.BinLogFileHeader...)
+	data = append(data, formatDescEv.RawData...)
+	latestPos = formatDescEv.Header.LogPos
+	var queryEv *replication.BinlogEvent
+	for i := 0; i < 2; i++ {
+		queryEv, err = event.GenQueryEvent(
+			header, latestPos, 0, 0, 0, nil,
+			[]byte(fmt.Sprintf("schema-%d", i)), []byte(fmt.Sprintf("query-%d", i)))
+		c.Assert(err, IsNil)
+		data = append(data, queryEv.RawData...)
+		latestPos = queryEv.Header.LogPos
+	}
+	c.Assert(os.WriteFile(filename, data, 0o644), IsNil)
+
+	enc, err := zstd.NewWriter(nil)
+	c.Assert(err, IsNil)
+	c.Assert(os.WriteFile(compressedFilename, enc.EncodeAll(data, nil), 0o644), IsNil)
+	c.Assert(enc.Close(), IsNil)
+
+	compressed, err := IsCompressedFile(filename)
+	c.Assert(err, IsNil)
+	c.Assert(compressed, IsFalse)
+	compressed, err = IsCompressedFile(compressedFilename)
+	c.Assert(err, IsNil)
+	c.Assert(compressed, IsTrue)
+
+	// the size before compressed is returned
+	size, err := FileSize(compressedFilename)
+	c.Assert(err, IsNil)
+	c.Assert(size, Equals, int64(len(data)))
+
+	parse := func(name string, offset int64) [][]byte {
+		var events [][]byte
+		p := replication.NewBinlogParser()
+		p.SetVerifyChecksum(true)
+		err2 := ParseFile(p, name, offset, func(e *replication.BinlogEvent) error {
+			events = append(events, e.RawData)
+			return nil
+		})
+		c.Assert(err2, IsNil)
+		return events
+	}
+
+	// parse from the beginning
+	events := parse(compressedFilename, 4)
+	c.Assert(events, HasLen, 3)
+	c.Assert(events, DeepEquals, parse(filename, 4))
+
+	// parse from the middle, a FormatDescriptionEvent is always got first
+	offset := int64(latestPos - queryEv.Header.EventSize)
+	events = parse(compressedFilename, offset)
+	c.Assert(events, DeepEquals, [][]byte{formatDescEv.RawData, queryEv.RawData})
+	c.Assert(events, DeepEquals, parse(filename, offset))
+}
@@ -111,7 +111,7 @@ func (r *FileReader) StartSyncByPos(pos gmysql.Position) error {
 	r.wg.Add(1)
 	go func() {
 		defer r.wg.Done()
-		err := r.parser.ParseFile(pos.Name, int64(pos.Pos), r.onEvent)
+		err := ParseFile(r.parser, pos.Name, int64(pos.Pos), r.onEvent)
 		if err != nil {
 			if errors.Cause(err) != context.Canceled {
 				r.logger.Error("fail to parse binlog file", zap.Error(err))
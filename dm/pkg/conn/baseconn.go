@@ -218,6 +218,88 @@ func (conn *BaseConn) ExecuteSQL(tctx *tcontext.Context, hVec *metricsproxy.Hist
 	return conn.ExecuteSQLWithIgnoreError(tctx, hVec, task, nil, queries, args...)
 }
 
+// ExecuteStmtsWithIgnoreError executes the prepared statements on real DB in a transaction like ExecuteSQLWithIgnoreError,
+// the statements must be prepared on this connection, and the queries are only used in the logs and errors.
+// return
+// 1. failed: (the index of statements executed error, error)
+// 2. succeed: (len(stmts), nil).
+func (conn *BaseConn) ExecuteStmtsWithIgnoreError(tctx *tcontext.Context, hVec *metricsproxy.HistogramVecProxy, task string, ignoreErr func(error) bool, stmts []*sql.Stmt, queries []string, args ...[]interface{}) (int, error) {
+	if len(stmts) == 0 {
+		return 0, nil
+	}
+	if conn == nil || conn.DBConn == nil {
+		return 0, terror.ErrDBUnExpect.Generate("database connection not valid")
+	}
+
+	// sql.Tx prepares the statements of a sql.Conn again, so we begin the transaction on the connection explicitly.
+	startTime := time.Now()
+	_, err := conn.DBConn.ExecContext(tctx.Context(), "BEGIN")
+	if err != nil {
+		return 0, terror.ErrDBExecuteFailed.Delegate(err, "begin")
+	}
+	if hVec != nil {
+		hVec.WithLabelValues("begin", task).Observe(time.Since(startTime).Seconds())
+	}
+
+	l := len(stmts)
+
+	for i, stmt := range stmts {
+		var arg []interface{}
+		if len(args) > i {
+			arg = args[i]
+		}
+		query := queries[i]
+
+		// avoid use TruncateInterface for all log level which will slow the speed of DML
+		if tctx.L().Core().Enabled(zap.DebugLevel) {
+			tctx.L().Debug("execute prepared statement",
+				zap.String("query", utils.TruncateString(query, -1)),
+				zap.String("argument", utils.TruncateInterface(arg, -1)))
+		}
+
+		startTime = time.Now()
+		_, err = stmt.ExecContext(tctx.Context(), arg...)
+		if err == nil {
+			if hVec != nil {
+				hVec.WithLabelValues("stmt", task).Observe(time.Since(startTime).Seconds())
+			}
+			continue
+		}
+		if ignoreErr != nil && ignoreErr(err) {
+			tctx.L().Warn("execute prepared statement failed and will ignore this error",
+				zap.String("query", utils.TruncateString(query, -1)),
+				zap.String("argument", utils.TruncateInterface(arg, -1)),
+				log.ShortError(err))
+			continue
+		}
+
+		tctx.L().ErrorFilterContextCanceled("execute prepared statement failed",
+			zap.String("query", utils.TruncateString(query, -1)),
+			zap.String("argument", utils.TruncateInterface(arg, -1)), log.ShortError(err))
+
+		startTime = time.Now()
+		if _, rerr := conn.DBConn.ExecContext(tctx.Context(), "ROLLBACK"); rerr != nil {
+			tctx.L().Error("rollback failed",
+				zap.String("query", utils.TruncateString(query, -1)),
+				zap.String("argument", utils.TruncateInterface(arg, -1)),
+				log.ShortError(rerr))
+		} else if hVec != nil {
+			hVec.WithLabelValues("rollback", task).Observe(time.Since(startTime).Seconds())
+		}
+		// we should return the exec err, instead of the rollback rerr.
+		return i, terror.ErrDBExecuteFailed.Delegate(err, utils.TruncateString(query, -1))
+	}
+	startTime = time.Now()
+	_, err = conn.DBConn.ExecContext(tctx.Context(), "COMMIT")
+	if err != nil {
+		return l - 1, terror.ErrDBExecuteFailed.Delegate(err, "commit") // mark failed on the last one
+	}
+	if hVec != nil {
+		hVec.WithLabelValues("commit", task).Observe(time.Since(startTime).Seconds())
+	}
+	return l, nil
+}
+
 // ApplyRetryStrategy apply specify strategy for BaseConn.
 func (conn *BaseConn) ApplyRetryStrategy(tctx *tcontext.Context, params retry.Params,
 	operateFn func(*tcontext.Context) (interface{}, error)) (interface{}, int, error) {
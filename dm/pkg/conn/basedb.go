@@ -32,7 +32,6 @@ import (
 	"github.com/pingcap/ticdc/dm/pkg/utils"
 
 	"github.com/go-sql-driver/mysql"
-	toolutils "github.com/pingcap/tidb-tools/pkg/utils"
 )
 
 var customID int64
@@ -67,14 +66,13 @@ func (d *DefaultDBProviderImpl) Apply(config *config.DBConfig) (*BaseDB, error)
 		if loadErr := config.Security.LoadTLSContent(); loadErr != nil {
 			return nil, terror.ErrCtlLoadTLSCfg.Delegate(loadErr)
 		}
-		tlsConfig, err := toolutils.ToTLSConfigWithVerifyByRawbytes(config.Security.SSLCABytes,
-			config.Security.SSLCertBytes, config.Security.SSLKEYBytes, config.Security.CertAllowedCN)
+		tlsConfig, err := config.Security.ToTLSConfig(config.Host)
 		if err != nil {
 			return nil, terror.ErrConnInvalidTLSConfig.Delegate(err)
 		}
 		// NOTE for local test(use a self-signed or invalid certificate), we don't need to check CA file.
 		// see more here https://github.com/go-sql-driver/mysql#tls
-		if config.Host == "127.0.0.1" {
+		if config.Security.SSLVerifyMode == "" && config.Host == "127.0.0.1" {
 			tlsConfig.InsecureSkipVerify = true
 		}
 
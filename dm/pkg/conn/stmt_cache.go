@@ -0,0 +1,174 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"container/list"
+	"database/sql"
+	"sync"
+
+	"go.uber.org/zap"
+
+	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
+	"github.com/pingcap/ticdc/dm/pkg/log"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+	"github.com/pingcap/ticdc/dm/pkg/utils"
+)
+
+// StmtTable is the table of a statement with the version of its schema, the statements prepared
+// for an older version of the table are invalidated, like after a DDL is executed on the table.
+type StmtTable struct {
+	Name    string
+	Version uint64
+}
+
+type stmtKey struct {
+	table string
+	query string
+}
+
+type stmtEntry struct {
+	key     stmtKey
+	version uint64
+	stmt    *sql.Stmt
+}
+
+// StmtCache caches the prepared statements of a BaseConn in LRU order, so the same statements
+// are not prepared again for each transaction. it should be cleared when the connection is reset.
+type StmtCache struct {
+	mu       sync.Mutex
+	capacity int
+	lru      *list.List
+	entries  map[stmtKey]*list.Element
+	// table -> queries of the table, used to invalidate all statements of a table together.
+	tables map[string]map[string]struct{}
+}
+
+// NewStmtCache creates a StmtCache which caches `capacity` statements at most.
+func NewStmtCache(capacity int) *StmtCache {
+	return &StmtCache{
+		capacity: capacity,
+		lru:      list.New(),
+		entries:  make(map[stmtKey]*list.Element),
+		tables:   make(map[string]map[string]struct{}),
+	}
+}
+
+// Prepare returns the cached statements of the queries, and prepares the statements not cached on the connection.
+// the second return value is the count of the statements found in the cache.
+func (c *StmtCache) Prepare(tctx *tcontext.Context, conn *BaseConn, tables []StmtTable, queries []string) ([]*sql.Stmt, int, error) {
+	if conn == nil || conn.DBConn == nil {
+		return nil, 0, terror.ErrDBUnExpect.Generate("database connection not valid")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	hit := 0
+	stmts := make([]*sql.Stmt, 0, len(queries))
+	for i, query := range queries {
+		var table StmtTable
+		if i < len(tables) {
+			table = tables[i]
+		}
+		key := stmtKey{table: table.Name, query: query}
+		if elem, ok := c.entries[key]; ok {
+			entry := elem.Value.(*stmtEntry)
+			if entry.version == table.Version {
+				c.lru.MoveToFront(elem)
+				stmts = append(stmts, entry.stmt)
+				hit++
+				continue
+			}
+			// the schema of the table changed, so all statements of it are out of date.
+			c.invalidateTable(tctx, table.Name)
+		}
+
+		stmt, err := conn.DBConn.PrepareContext(tctx.Context(), query)
+		if err != nil {
+			return nil, hit, terror.ErrDBExecuteFailed.Delegate(err, utils.TruncateString(query, -1))
+		}
+		c.add(&stmtEntry{key: key, version: table.Version, stmt: stmt})
+		stmts = append(stmts, stmt)
+	}
+
+	// the statements used now are in the front, so they are not evicted even if there are more than the capacity.
+	for c.capacity > 0 && c.lru.Len() > c.capacity && c.lru.Len() > len(queries) {
+		c.remove(tctx, c.lru.Back())
+	}
+	return stmts, hit, nil
+}
+
+// Invalidate closes and removes the cached statements of the table.
+func (c *StmtCache) Invalidate(tctx *tcontext.Context, table string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidateTable(tctx, table)
+}
+
+// Clear closes and removes all cached statements.
+func (c *StmtCache) Clear(tctx *tcontext.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		closeStmt(tctx, elem.Value.(*stmtEntry))
+	}
+	c.lru.Init()
+	c.entries = make(map[stmtKey]*list.Element)
+	c.tables = make(map[string]map[string]struct{})
+}
+
+// Len returns the count of the cached statements.
+func (c *StmtCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *StmtCache) add(entry *stmtEntry) {
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	queries, ok := c.tables[entry.key.table]
+	if !ok {
+		queries = make(map[string]struct{})
+		c.tables[entry.key.table] = queries
+	}
+	queries[entry.key.query] = struct{}{}
+}
+
+func (c *StmtCache) invalidateTable(tctx *tcontext.Context, table string) {
+	for query := range c.tables[table] {
+		if elem, ok := c.entries[stmtKey{table: table, query: query}]; ok {
+			c.remove(tctx, elem)
+		}
+	}
+}
+
+func (c *StmtCache) remove(tctx *tcontext.Context, elem *list.Element) {
+	entry := c.lru.Remove(elem).(*stmtEntry)
+	delete(c.entries, entry.key)
+	if queries, ok := c.tables[entry.key.table]; ok {
+		delete(queries, entry.key.query)
+		if len(queries) == 0 {
+			delete(c.tables, entry.key.table)
+		}
+	}
+	closeStmt(tctx, entry)
+}
+
+func closeStmt(tctx *tcontext.Context, entry *stmtEntry) {
+	if err := entry.stmt.Close(); err != nil {
+		tctx.L().Warn("close prepared statement failed",
+			zap.String("query", utils.TruncateString(entry.key.query, -1)), log.ShortError(err))
+	}
+}
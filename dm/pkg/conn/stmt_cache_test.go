@@ -0,0 +1,117 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"errors"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+
+	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+var _ = Suite(&testStmtCacheSuite{})
+
+type testStmtCacheSuite struct{}
+
+func (t *testStmtCacheSuite) TestStmtCache(c *C) {
+	tctx := tcontext.Background()
+	cache := NewStmtCache(2)
+
+	_, _, err := cache.Prepare(tctx, NewBaseConn(nil, nil), nil, []string{"INSERT INTO t VALUES (?)"})
+	c.Assert(terror.ErrDBUnExpect.Equal(err), IsTrue)
+
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	dbConn, err := db.Conn(tctx.Context())
+	c.Assert(err, IsNil)
+	baseConn := &BaseConn{dbConn, nil}
+
+	var (
+		insertT1 = "INSERT INTO `test`.`t1` (`id`) VALUES (?)"
+		deleteT1 = "DELETE FROM `test`.`t1` WHERE `id` = ? LIMIT 1"
+		insertT2 = "INSERT INTO `test`.`t2` (`id`) VALUES (?)"
+		t1       = StmtTable{Name: "`test`.`t1`", Version: 1}
+		t2       = StmtTable{Name: "`test`.`t2`", Version: 1}
+	)
+
+	// prepare the statements not cached, and execute them in a transaction
+	mock.ExpectPrepare("INSERT INTO `test`.`t1`")
+	mock.ExpectPrepare("DELETE FROM `test`.`t1`")
+	stmts, hit, err := cache.Prepare(tctx, baseConn, []StmtTable{t1, t1, t1}, []string{insertT1, deleteT1, insertT1})
+	c.Assert(err, IsNil)
+	c.Assert(stmts, HasLen, 3)
+	c.Assert(hit, Equals, 1)
+	c.Assert(stmts[0], Equals, stmts[2])
+	c.Assert(cache.Len(), Equals, 2)
+
+	mock.ExpectExec("BEGIN").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO `test`.`t1`").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM `test`.`t1`").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO `test`.`t1`").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("COMMIT").WillReturnResult(sqlmock.NewResult(0, 0))
+	affected, err := baseConn.ExecuteStmtsWithIgnoreError(tctx, testStmtHistogram, "test", nil, stmts,
+		[]string{insertT1, deleteT1, insertT1}, []interface{}{1}, []interface{}{1}, []interface{}{2})
+	c.Assert(err, IsNil)
+	c.Assert(affected, Equals, 3)
+
+	// rollback if failed to execute
+	mock.ExpectExec("BEGIN").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO `test`.`t1`").WithArgs(3).WillReturnError(errors.New("ignore me"))
+	mock.ExpectExec("DELETE FROM `test`.`t1`").WithArgs(3).WillReturnError(errors.New("don't ignore me"))
+	mock.ExpectExec("ROLLBACK").WillReturnResult(sqlmock.NewResult(0, 0))
+	ignoreF := func(err error) bool {
+		return err.Error() == "ignore me"
+	}
+	affected, err = baseConn.ExecuteStmtsWithIgnoreError(tctx, testStmtHistogram, "test", ignoreF, stmts[:2],
+		[]string{insertT1, deleteT1}, []interface{}{3}, []interface{}{3})
+	c.Assert(terror.ErrDBExecuteFailed.Equal(err), IsTrue)
+	c.Assert(affected, Equals, 1)
+
+	// the cached statements are reused
+	stmts2, hit, err := cache.Prepare(tctx, baseConn, []StmtTable{t1}, []string{deleteT1})
+	c.Assert(err, IsNil)
+	c.Assert(hit, Equals, 1)
+	c.Assert(stmts2[0], Equals, stmts[1])
+
+	// the least recently used statement is evicted
+	mock.ExpectPrepare("INSERT INTO `test`.`t2`")
+	_, hit, err = cache.Prepare(tctx, baseConn, []StmtTable{t2}, []string{insertT2})
+	c.Assert(err, IsNil)
+	c.Assert(hit, Equals, 0)
+	c.Assert(cache.Len(), Equals, 2)
+
+	// the statements of a table are prepared again after its schema version changed
+	t1.Version = 2
+	mock.ExpectPrepare("DELETE FROM `test`.`t1`")
+	stmts2, hit, err = cache.Prepare(tctx, baseConn, []StmtTable{t1}, []string{deleteT1})
+	c.Assert(err, IsNil)
+	c.Assert(hit, Equals, 0)
+	c.Assert(stmts2[0], Not(Equals), stmts[1])
+	c.Assert(cache.Len(), Equals, 2)
+
+	cache.Invalidate(tctx, t1.Name)
+	c.Assert(cache.Len(), Equals, 1)
+	cache.Clear(tctx)
+	c.Assert(cache.Len(), Equals, 0)
+
+	mock.ExpectPrepare("INSERT INTO `test`.`t2`").WillReturnError(errors.New("prepare error"))
+	_, _, err = cache.Prepare(tctx, baseConn, []StmtTable{t2}, []string{insertT2})
+	c.Assert(terror.ErrDBExecuteFailed.Equal(err), IsTrue)
+	c.Assert(cache.Len(), Equals, 0)
+
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.etcd.io/etcd/clientv3"
+
+	"github.com/pingcap/ticdc/dm/dm/common"
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/dm/pb"
+	"github.com/pingcap/ticdc/dm/pkg/etcdutil"
+)
+
+// ErrorOperator represents a `handle-error` operator of the subtask, it's persisted to
+// apply the operator again after the subtask restarted before the binlog event is flushed.
+type ErrorOperator struct {
+	Task   string `json:"task"`
+	Source string `json:"source"`
+	// BinlogPos is the binlog position or the GTID to match the binlog event.
+	BinlogPos string     `json:"binlog-pos"`
+	Op        pb.ErrorOp `json:"op"`
+	Sqls      []string   `json:"sqls,omitempty"`
+}
+
+// NewErrorOperator creates a new ErrorOperator instance.
+func NewErrorOperator(task, source, binlogPos string, op pb.ErrorOp, sqls []string) ErrorOperator {
+	return ErrorOperator{
+		Task:      task,
+		Source:    source,
+		BinlogPos: binlogPos,
+		Op:        op,
+		Sqls:      sqls,
+	}
+}
+
+// String implements Stringer interface.
+func (o ErrorOperator) String() string {
+	s, _ := o.toJSON()
+	return s
+}
+
+// toJSON returns the string of JSON represent.
+func (o ErrorOperator) toJSON() (string, error) {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// errorOperatorFromJSON constructs ErrorOperator from its JSON represent.
+func errorOperatorFromJSON(s string) (o ErrorOperator, err error) {
+	err = json.Unmarshal([]byte(s), &o)
+	return
+}
+
+// PutErrorOperator puts the error operator of the subtask.
+// k/v: (task, sourceID, binlog-pos) -> error operator.
+// This function should often be called by DM-worker.
+func PutErrorOperator(cli *clientv3.Client, o ErrorOperator) (int64, error) {
+	value, err := o.toJSON()
+	if err != nil {
+		return 0, err
+	}
+	key := common.ErrorOperatorKeyAdapter.Encode(o.Task, o.Source, o.BinlogPos)
+
+	_, rev, err := etcdutil.DoOpsInOneTxnWithRetry(cli, clientv3.OpPut(key, value))
+	return rev, err
+}
+
+// GetErrorOperators gets all the error operators of the subtask.
+// k/v: (task, sourceID, binlog-pos) -> error operator.
+func GetErrorOperators(cli *clientv3.Client, task, sourceID string) (map[string]ErrorOperator, int64, error) {
+	ctx, cancel := context.WithTimeout(cli.Ctx(), etcdutil.DefaultRequestTimeout)
+	defer cancel()
+	resp, err := cli.Get(ctx, common.ErrorOperatorKeyAdapter.Encode(task, sourceID), clientv3.WithPrefix())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opm := make(map[string]ErrorOperator, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		o, err2 := errorOperatorFromJSON(string(kv.Value))
+		if err2 != nil {
+			return nil, 0, err2
+		}
+		opm[o.BinlogPos] = o
+	}
+	return opm, resp.Header.Revision, nil
+}
+
+// DeleteErrorOperators deletes the error operators of the subtask by the binlog positions.
+func DeleteErrorOperators(cli *clientv3.Client, task, sourceID string, binlogPos ...string) (int64, error) {
+	ops := make([]clientv3.Op, 0, len(binlogPos))
+	for _, pos := range binlogPos {
+		ops = append(ops, clientv3.OpDelete(common.ErrorOperatorKeyAdapter.Encode(task, sourceID, pos)))
+	}
+	_, rev, err := etcdutil.DoOpsInOneTxnWithRetry(cli, ops...)
+	return rev, err
+}
+
+// deleteErrorOperatorsOp returns a list of DELETE etcd operations for the error operators of the subtasks.
+func deleteErrorOperatorsOp(cfgs ...config.SubTaskConfig) []clientv3.Op {
+	ops := make([]clientv3.Op, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		ops = append(ops, clientv3.OpDelete(common.ErrorOperatorKeyAdapter.Encode(cfg.Name, cfg.SourceID), clientv3.WithPrefix()))
+	}
+	return ops
+}
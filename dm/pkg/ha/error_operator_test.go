@@ -0,0 +1,74 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ha
+
+import (
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/dm/pb"
+)
+
+func (t *testForEtcd) TestErrorOperatorEtcd(c *C) {
+	defer clearTestInfoOperation(c)
+
+	var (
+		task1  = "task1"
+		task2  = "task2"
+		source = "mysql-replica-1"
+		pos1   = "(mysql-bin.000001, 2345)"
+		gtid1  = "3ccc475b-2343-11e7-be21-6c0b84d59f30:15"
+		o1     = NewErrorOperator(task1, source, pos1, pb.ErrorOp_Replace, []string{"alter table db.tb add column a int"})
+		o2     = NewErrorOperator(task1, source, gtid1, pb.ErrorOp_Skip, nil)
+		o3     = NewErrorOperator(task2, source, pos1, pb.ErrorOp_Skip, nil)
+	)
+
+	// no operators exist.
+	opm, rev1, err := GetErrorOperators(etcdTestCli, task1, source)
+	c.Assert(err, IsNil)
+	c.Assert(opm, HasLen, 0)
+
+	// put the operators.
+	rev2, err := PutErrorOperator(etcdTestCli, o1)
+	c.Assert(err, IsNil)
+	c.Assert(rev2, Greater, rev1)
+	_, err = PutErrorOperator(etcdTestCli, o2)
+	c.Assert(err, IsNil)
+	_, err = PutErrorOperator(etcdTestCli, o3)
+	c.Assert(err, IsNil)
+
+	opm, _, err = GetErrorOperators(etcdTestCli, task1, source)
+	c.Assert(err, IsNil)
+	c.Assert(opm, DeepEquals, map[string]ErrorOperator{pos1: o1, gtid1: o2})
+	opm, _, err = GetErrorOperators(etcdTestCli, task2, source)
+	c.Assert(err, IsNil)
+	c.Assert(opm, DeepEquals, map[string]ErrorOperator{pos1: o3})
+
+	// delete the operator by the GTID.
+	_, err = DeleteErrorOperators(etcdTestCli, task1, source, gtid1)
+	c.Assert(err, IsNil)
+	opm, _, err = GetErrorOperators(etcdTestCli, task1, source)
+	c.Assert(err, IsNil)
+	c.Assert(opm, DeepEquals, map[string]ErrorOperator{pos1: o1})
+
+	// delete the operators with the subtask.
+	_, err = DeleteSubTaskCfgStage(etcdTestCli, []config.SubTaskConfig{{Name: task1, SourceID: source}}, nil)
+	c.Assert(err, IsNil)
+	opm, _, err = GetErrorOperators(etcdTestCli, task1, source)
+	c.Assert(err, IsNil)
+	c.Assert(opm, HasLen, 0)
+	opm, _, err = GetErrorOperators(etcdTestCli, task2, source)
+	c.Assert(err, IsNil)
+	c.Assert(opm, HasLen, 1)
+}
@@ -16,7 +16,6 @@ package ha
 import (
 	"context"
 	"encoding/json"
-	"sync/atomic"
 	"time"
 
 	"go.etcd.io/etcd/clientv3"
@@ -28,12 +27,8 @@ import (
 	"github.com/pingcap/ticdc/dm/pkg/log"
 )
 
-var (
-	// currentKeepAliveTTL may be assigned to KeepAliveTTL or RelayKeepAliveTTL.
-	currentKeepAliveTTL int64
-	// KeepAliveUpdateCh is used to notify keepalive TTL changing, in order to let watcher not see a DELETE of old key.
-	KeepAliveUpdateCh = make(chan int64, 10)
-)
+// KeepAliveUpdateCh is used to notify keepalive TTL changing, in order to let watcher not see a DELETE of old key.
+var KeepAliveUpdateCh = make(chan int64, 10)
 
 // WorkerEvent represents the PUT/DELETE keepalive event of DM-worker.
 type WorkerEvent struct {
@@ -80,12 +75,18 @@ func workerEventFromKey(key string) (WorkerEvent, error) {
 // this key will be kept in etcd until the worker is blocked or failed
 // k/v: workerName -> join time.
 func KeepAlive(ctx context.Context, cli *clientv3.Client, workerName string, keepAliveTTL int64) error {
-	// TTL in KeepAliveUpdateCh has higher priority
-	for len(KeepAliveUpdateCh) > 0 {
-		keepAliveTTL = <-KeepAliveUpdateCh
+	return KeepAliveWithUpdateCh(ctx, cli, workerName, keepAliveTTL, KeepAliveUpdateCh)
+}
+
+// KeepAliveWithUpdateCh is like KeepAlive, but the TTL changes are notified by updateCh, so that the workers
+// served by the same DM-worker process keep alive with their own TTLs.
+func KeepAliveWithUpdateCh(ctx context.Context, cli *clientv3.Client, workerName string, keepAliveTTL int64, updateCh chan int64) error {
+	// TTL in updateCh has higher priority
+	for len(updateCh) > 0 {
+		keepAliveTTL = <-updateCh
 	}
-	// a test concurrently call KeepAlive though in normal running we don't do that
-	atomic.StoreInt64(&currentKeepAliveTTL, keepAliveTTL)
+	// currentKeepAliveTTL may be assigned to KeepAliveTTL or RelayKeepAliveTTL.
+	currentKeepAliveTTL := keepAliveTTL
 
 	k := common.WorkerKeepAliveKeyAdapter.Encode(workerName)
 	workerEventJSON, err := WorkerEvent{
@@ -140,7 +141,7 @@ func KeepAlive(ctx context.Context, cli *clientv3.Client, workerName string, kee
 		case <-ctx.Done():
 			log.L().Info("ctx is canceled, keepalive will exit now")
 			return nil
-		case newTTL := <-KeepAliveUpdateCh:
+		case newTTL := <-updateCh:
 			if newTTL == currentKeepAliveTTL {
 				log.L().Info("ignore same keepalive TTL change", zap.Int64("TTL", newTTL))
 				continue
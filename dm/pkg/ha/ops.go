@@ -117,6 +117,8 @@ func opSubTaskCfgStage(cli *clientv3.Client, evType mvccpb.Event_EventType,
 		}
 	case mvccpb.DELETE:
 		ops1 = deleteSubTaskCfgOp(cfgs...)
+		// the error operators are useless after the subtask removed
+		ops1 = append(ops1, deleteErrorOperatorsOp(cfgs...)...)
 		ops2 = deleteSubTaskStageOp(stages...)
 	}
 
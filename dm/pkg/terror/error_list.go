@@ -244,6 +244,22 @@ const (
 	codeConfigInvalidChunkFileSize
 	codeConfigOnlineDDLInvalidRegex
 	codeConfigOnlineDDLMistakeRegex
+	codeConfigEnvNotSet
+	codeConfigSecretRefInvalid
+	codeConfigIncludeInvalid
+	codeConfigInvalidImportMode
+	codeConfigInvalidCheckpointStorage
+	codeConfigInvalidRelayCompression
+	codeConfigInvalidHeartbeat
+	codeConfigInvalidFilterRule
+	codeConfigInvalidColumnTransform
+	codeConfigColumnTransformNotFound
+	codeConfigInvalidResourceLimits
+	codeConfigInvalidSafeModeDuration
+	codeConfigInvalidDBTLS
+	codeConfigValidatorCfgConflict
+	codeConfigValidatorCfgNotFound
+	codeConfigInvalidValidator
 )
 
 // Binlog operation error code list.
@@ -329,6 +345,7 @@ const (
 	codeRelayPurgeArgsNotValid
 	codePreviousGTIDsNotValid
 	codeRotateEventWithDifferentServerID
+	codeRelayCompressFileFail
 )
 
 // Dump unit error code.
@@ -360,6 +377,8 @@ const (
 	codeLoadTaskWorkerNotMatch
 	codeLoadCheckPointNotMatch
 	codeLoadBackendNotMatch
+	codeLoadCheckPointInvalidOffset
+	codeLoadUnitGenColumnTransform
 )
 
 // Sync unit error code.
@@ -433,6 +452,8 @@ const (
 	codeSyncerParseDDL
 	codeSyncerUnsupportedStmt
 	codeSyncerGetEvent
+	codeSyncerGenExprFilter
+	codeSyncerGenColumnTransform
 )
 
 // DM-master error code.
@@ -492,6 +513,7 @@ const (
 	codeMasterFailToImportFromV10x
 	codeMasterInconsistentOptimistDDLsAndInfo
 	codeMasterOptimisticTableInfobeforeNotExist
+	codeMasterServerIDConflict
 )
 
 // DM-worker error code.
@@ -842,7 +864,7 @@ var (
 	ErrBinlogNotLogColumn = New(codeBinlogNotLogColumn, ClassBinlogOp, ScopeUpstream, LevelHigh, "upstream didn't log enough columns in binlog", "Please check if session `binlog_row_image` variable is not FULL, restart task to the location from where FULL binlog_row_image is used.")
 
 	// Config related error.
-	ErrConfigCheckItemNotSupport    = New(codeConfigCheckItemNotSupport, ClassConfig, ScopeInternal, LevelMedium, "checking item %s is not supported\n%s", "Please check `ignore-checking-items` config in task configuration file, which can be set including `all`/`dump_privilege`/`replication_privilege`/`version`/`binlog_enable`/`binlog_format`/`binlog_row_image`/`table_schema`/`schema_of_shard_tables`/`auto_increment_ID`.")
+	ErrConfigCheckItemNotSupport    = New(codeConfigCheckItemNotSupport, ClassConfig, ScopeInternal, LevelMedium, "checking item %s is not supported\n%s", "Please check `ignore-checking-items` config in task configuration file, which can be set including `all`/`dump_privilege`/`replication_privilege`/`version`/`binlog_enable`/`binlog_format`/`binlog_row_image`/`table_schema`/`schema_of_shard_tables`/`auto_increment_ID`/`table_compatibility`.")
 	ErrConfigTomlTransform          = New(codeConfigTomlTransform, ClassConfig, ScopeInternal, LevelMedium, "%s", "Please check the configuration file has correct TOML format.")
 	ErrConfigYamlTransform          = New(codeConfigYamlTransform, ClassConfig, ScopeInternal, LevelMedium, "%s", "Please check the configuration file has correct YAML format.")
 	ErrConfigTaskNameEmpty          = New(codeConfigTaskNameEmpty, ClassConfig, ScopeInternal, LevelMedium, "task name should not be empty", "Please check the `name` config in task configuration file.")
@@ -861,7 +883,7 @@ var (
 	ErrConfigSyncerCfgConflict      = New(codeConfigSyncerCfgConflict, ClassConfig, ScopeInternal, LevelMedium, "syncer-config-name and syncer should only specify one", "Please check the `syncer-config-name` and `syncer` config in task configuration file.")
 	ErrConfigReadCfgFromFile        = New(codeConfigReadCfgFromFile, ClassConfig, ScopeInternal, LevelMedium, "read config file %v", "")
 	ErrConfigNeedUniqueTaskName     = New(codeConfigNeedUniqueTaskName, ClassConfig, ScopeInternal, LevelMedium, "must specify a unique task name", "Please check the `name` config in task configuration file.")
-	ErrConfigInvalidTaskMode        = New(codeConfigInvalidTaskMode, ClassConfig, ScopeInternal, LevelMedium, "please specify right task-mode, support `full`, `incremental`, `all`", "Please check the `task-mode` config in task configuration file.")
+	ErrConfigInvalidTaskMode        = New(codeConfigInvalidTaskMode, ClassConfig, ScopeInternal, LevelMedium, "please specify right task-mode, support `full`, `incremental`, `all`, `dump`, `load`, `load-sync`", "Please check the `task-mode` config in task configuration file.")
 	ErrConfigNeedTargetDB           = New(codeConfigNeedTargetDB, ClassConfig, ScopeInternal, LevelMedium, "must specify target-database", "Please check the `target-database` config in task configuration file.")
 	ErrConfigMetadataNotSet         = New(codeConfigMetadataNotSet, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d) must set meta for task-mode %s", "Please check the `meta` config in task configuration file.")
 	ErrConfigRouteRuleNotFound      = New(codeConfigRouteRuleNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s route-rules %s not exist in routes", "Please check the `route-rules` config in task configuration file.")
@@ -893,6 +915,22 @@ var (
 		"config '%s' regex pattern '%s' invalid, reason: %s", "Please check if params is correctly in the configuration file.")
 	ErrConfigOnlineDDLMistakeRegex = New(codeConfigOnlineDDLMistakeRegex, ClassConfig, ScopeInternal, LevelHigh,
 		"online ddl sql '%s' invalid, table %s fail to match '%s' online ddl regex", "Please update your `shadow-table-rules` or `trash-table-rules` in the configuration file.")
+	ErrConfigEnvNotSet                = New(codeConfigEnvNotSet, ClassConfig, ScopeInternal, LevelMedium, "environment variable %s referenced in task config is not set", "Please set the environment variable for DM-master, or remove the placeholder from task configuration file.")
+	ErrConfigSecretRefInvalid         = New(codeConfigSecretRefInvalid, ClassConfig, ScopeInternal, LevelMedium, "fail to resolve secret reference %s", "Please check the `secret://` reference in task configuration file, and whether DM-master can access the secret.")
+	ErrConfigIncludeInvalid           = New(codeConfigIncludeInvalid, ClassConfig, ScopeInternal, LevelMedium, "invalid included task config %s", "Please check the `includes` config in task configuration file, the included files can only contain routes, filters, column-mappings, expression-filter, block-allow-list, mydumpers, loaders, syncers and validators.")
+	ErrConfigInvalidImportMode        = New(codeConfigInvalidImportMode, ClassConfig, ScopeInternal, LevelMedium, "invalid import-mode %s of the loader, support `sql`, `logical`, `physical`", "Please check the `import-mode` config in task configuration file.")
+	ErrConfigInvalidCheckpointStorage = New(codeConfigInvalidCheckpointStorage, ClassConfig, ScopeInternal, LevelMedium, "invalid checkpoint-storage %s of the syncer: %s", "Please check the `checkpoint-storage` and `checkpoint-db` config in task configuration file, the `checkpoint-storage` can be set to `downstream`, `mysql` or `etcd`.")
+	ErrConfigInvalidRelayCompression  = New(codeConfigInvalidRelayCompression, ClassConfig, ScopeInternal, LevelMedium, "invalid compression %s of the relay log", "Please check the `compression` config of `purge` in source configuration file, only `zstd` is supported now.")
+	ErrConfigInvalidHeartbeat         = New(codeConfigInvalidHeartbeat, ClassConfig, ScopeInternal, LevelMedium, "invalid heartbeat config of the syncer: %s", "Please check the `heartbeat-interval` and `heartbeat-table` config in task configuration file, DM can't write heartbeats into an existing `heartbeat-table`.")
+	ErrConfigInvalidFilterRule        = New(codeConfigInvalidFilterRule, ClassConfig, ScopeInternal, LevelMedium, "invalid filter rule %s: %v", "Please check the `filters` config in task configuration file, the `events` should be the supported event types like `truncate table`, the `sql-pattern` should be valid regular expressions and the `action` should be `Do` or `Ignore`.")
+	ErrConfigInvalidColumnTransform   = New(codeConfigInvalidColumnTransform, ClassConfig, ScopeInternal, LevelMedium, "invalid column transform %s: %s", "Please check the `column-transforms` config in task configuration file, the `schema`, `table`, `column` and `expr` should be set and the `expr` should be a valid SQL expression.")
+	ErrConfigColumnTransformNotFound  = New(codeConfigColumnTransformNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s column-transform-rules %s not exist in column-transforms", "Please check the `column-transform-rules` config in task configuration file.")
+	ErrConfigInvalidResourceLimits    = New(codeConfigInvalidResourceLimits, ClassConfig, ScopeInternal, LevelMedium, "invalid resource limits of the task: %s", "Please check the `resource-limits` config in task configuration file, the limits should not be negative and the `max-loader-memory` should be a size like `512MiB`.")
+	ErrConfigInvalidSafeModeDuration  = New(codeConfigInvalidSafeModeDuration, ClassConfig, ScopeInternal, LevelMedium, "invalid safe-mode-duration %s: %s", "Please check the `safe-mode-duration` config in task configuration file, it should be a non-negative duration like `60s`.")
+	ErrConfigInvalidDBTLS             = New(codeConfigInvalidDBTLS, ClassConfig, ScopeInternal, LevelMedium, "invalid TLS config of the database: %s", "Please check the `security` config of the database, `ssl-verify-mode` should be one of `required`, `verify-ca` and `verify-identity`, `ssl-ca` is required to verify the server, and `ssl-cert` and `ssl-key` should be set together.")
+	ErrConfigValidatorCfgConflict     = New(codeConfigValidatorCfgConflict, ClassConfig, ScopeInternal, LevelMedium, "validator-config-name and validator should only specify one", "Please check the `validator-config-name` and `validator` config in task configuration file.")
+	ErrConfigValidatorCfgNotFound     = New(codeConfigValidatorCfgNotFound, ClassConfig, ScopeInternal, LevelMedium, "mysql-instance(%d)'s validator config %s not exist in validators", "Please check the `validator-config-name` config in task configuration file.")
+	ErrConfigInvalidValidator         = New(codeConfigInvalidValidator, ClassConfig, ScopeInternal, LevelMedium, "invalid validator config: %s", "Please check the `validators` config in task configuration file, `mode` should be one of `none`, `full` and `sample`, `sample-rate` should be in (0, 100], and `check-interval` and `row-error-delay` should be positive durations like `5s`.")
 
 	// Binlog operation error.
 	ErrBinlogExtractPosition = New(codeBinlogExtractPosition, ClassBinlogOp, ScopeInternal, LevelHigh, "", "")
@@ -968,6 +1006,7 @@ var (
 	ErrRelayPurgeArgsNotValid            = New(codeRelayPurgeArgsNotValid, ClassRelayUnit, ScopeInternal, LevelHigh, "args (%T) %+v not valid", "")
 	ErrPreviousGTIDsNotValid             = New(codePreviousGTIDsNotValid, ClassRelayUnit, ScopeInternal, LevelHigh, "previousGTIDs %s not valid", "")
 	ErrRotateEventWithDifferentServerID  = New(codeRotateEventWithDifferentServerID, ClassRelayUnit, ScopeInternal, LevelHigh, "receive fake rotate event with different server_id", "Please use `resume-relay` command if upstream database has changed")
+	ErrRelayCompressFileFail             = New(codeRelayCompressFileFail, ClassRelayUnit, ScopeInternal, LevelHigh, "compress relay log %s", "Please check whether the relay log file is corrupted.")
 
 	// Dump unit error.
 	ErrDumpUnitRuntime        = New(codeDumpUnitRuntime, ClassDumpUnit, ScopeInternal, LevelHigh, "mydumper/dumpling runs with error, with output (may empty): %s", "")
@@ -995,6 +1034,8 @@ var (
 	ErrLoadTaskWorkerNotMatch      = New(codeLoadTaskWorkerNotMatch, ClassFunctional, ScopeInternal, LevelHigh, "different worker in load stage, previous worker: %s, current worker: %s", "Please check if the previous worker is online.")
 	ErrLoadTaskCheckPointNotMatch  = New(codeLoadCheckPointNotMatch, ClassFunctional, ScopeInternal, LevelHigh, "inconsistent checkpoints between loader and target database", "If you want to redo the whole task, please check that you have not forgotten to add -remove-meta flag for start-task command.")
 	ErrLoadBackendNotSupport       = New(codeLoadBackendNotMatch, ClassFunctional, ScopeInternal, LevelHigh, "DM do not support backend %s ", "If you do not understand the configure `tidb.backend` you can just delete it.")
+	ErrLoadCheckPointInvalidOffset = New(codeLoadCheckPointInvalidOffset, ClassFunctional, ScopeInternal, LevelHigh, "invalid checkpoint offset %d of data file %s: %s", "Please check whether the dumped files are changed. If you want to redo the whole task, please add -remove-meta flag for start-task command.")
+	ErrLoadUnitGenColumnTransform  = New(codeLoadUnitGenColumnTransform, ClassLoadUnit, ScopeInternal, LevelHigh, "generate column transform `%s` of column %s for table %s: %s", "Please check the `column-transforms` config in task configuration file.")
 
 	// Sync unit error.
 	ErrSyncerUnitPanic                   = New(codeSyncerUnitPanic, ClassSyncUnit, ScopeInternal, LevelHigh, "panic error: %v", "")
@@ -1067,6 +1108,8 @@ var (
 	ErrSyncerParseDDL                       = New(codeSyncerParseDDL, ClassSyncUnit, ScopeInternal, LevelHigh, "parse DDL: %s", "Please confirm your DDL statement is correct and needed. For TiDB compatible DDL, see https://docs.pingcap.com/tidb/stable/mysql-compatibility#ddl. You can use `handle-error` command to skip or replace the DDL or add a binlog filter rule to ignore it if the DDL is not needed.")
 	ErrSyncerUnsupportedStmt                = New(codeSyncerUnsupportedStmt, ClassSyncUnit, ScopeInternal, LevelHigh, "`%s` statement not supported in %s mode", "")
 	ErrSyncerGetEvent                       = New(codeSyncerGetEvent, ClassSyncUnit, ScopeUpstream, LevelHigh, "get binlog event error: %v", "Please check if the binlog file could be parsed by `mysqlbinlog`.")
+	ErrSyncerGenExprFilter                  = New(codeSyncerGenExprFilter, ClassSyncUnit, ScopeInternal, LevelHigh, "generate expression filter `%s` for table %s", "Please check the `expression-filter` config in task configuration file.")
+	ErrSyncerGenColumnTransform             = New(codeSyncerGenColumnTransform, ClassSyncUnit, ScopeInternal, LevelHigh, "generate column transform `%s` of column %s for table %s: %s", "Please check the `column-transforms` config in task configuration file.")
 
 	// DM-master error.
 	ErrMasterSQLOpNilRequest        = New(codeMasterSQLOpNilRequest, ClassDMMaster, ScopeInternal, LevelMedium, "nil request not valid", "")
@@ -1129,6 +1172,7 @@ var (
 
 	ErrMasterInconsistentOptimisticDDLsAndInfo = New(codeMasterInconsistentOptimistDDLsAndInfo, ClassDMMaster, ScopeInternal, LevelHigh, "inconsistent count of optimistic ddls and table infos, ddls: %d, table info: %d", "")
 	ErrMasterOptimisticTableInfoBeforeNotExist = New(codeMasterOptimisticTableInfobeforeNotExist, ClassDMMaster, ScopeInternal, LevelHigh, "table-info-before not exist in optimistic ddls: %v", "")
+	ErrMasterServerIDConflict                  = New(codeMasterServerIDConflict, ClassDMMaster, ScopeInternal, LevelHigh, "server-id %d of source %s conflicts with %s", "Please update the `server-id` in the source config to a value not used by the other sources, the upstream and its replicas.")
 
 	// DM-worker error.
 	ErrWorkerParseFlagSet            = New(codeWorkerParseFlagSet, ClassDMWorker, ScopeInternal, LevelMedium, "parse dm-worker config flag set", "")
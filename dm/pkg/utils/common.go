@@ -326,6 +326,8 @@ func (se *session) GetBuiltinFunctionUsage() map[string]uint32 {
 // NewSessionCtx return a session context with specified session variables.
 func NewSessionCtx(vars map[string]string) sessionctx.Context {
 	variables := variable.NewSessionVars()
+	// builtin functions like CONCAT read max_allowed_packet from the session, use the default value.
+	_ = variables.SetSystemVar(variable.MaxAllowedPacket, variable.GetSysVar(variable.MaxAllowedPacket).Value)
 	for k, v := range vars {
 		_ = variables.SetSystemVar(k, v)
 		if strings.EqualFold(k, "time_zone") {
@@ -0,0 +1,33 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	toolutils "github.com/pingcap/tidb-tools/pkg/utils"
+
+	"github.com/pingcap/ticdc/pkg/security"
+)
+
+// NewTLS creates the TLS of the DM components like toolutils.NewTLS, and the certificates are reloaded
+// when the files are modified, so that the rotated certificates are used without restarting the components.
+func NewTLS(caPath, certPath, keyPath, host string, verifyCN []string) (*toolutils.TLS, error) {
+	tls, err := toolutils.NewTLS(caPath, certPath, keyPath, host, verifyCN)
+	if err != nil {
+		return nil, err
+	}
+	if err = security.ReloadCertificates(tls.TLSConfig(), caPath, certPath, keyPath); err != nil {
+		return nil, err
+	}
+	return tls, nil
+}
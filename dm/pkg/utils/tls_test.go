@@ -0,0 +1,40 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testTLSSuite{})
+
+type testTLSSuite struct{}
+
+func (t *testTLSSuite) TestNewTLS(c *C) {
+	tls, err := NewTLS("", "", "", "127.0.0.1:8261", nil)
+	c.Assert(err, IsNil)
+	c.Assert(tls.TLSConfig(), IsNil)
+
+	certDir := "../../../tests/_certificates/"
+	tls, err = NewTLS(certDir+"ca.pem", certDir+"server.pem", certDir+"server-key.pem", "127.0.0.1:8261", []string{"client"})
+	c.Assert(err, IsNil)
+	// the certificate is got by the callbacks to reload it
+	c.Assert(tls.TLSConfig().Certificates, HasLen, 0)
+	cert, err := tls.TLSConfig().GetCertificate(nil)
+	c.Assert(err, IsNil)
+	c.Assert(cert.Certificate, Not(HasLen), 0)
+
+	_, err = NewTLS(certDir+"ca.pem", certDir+"not-exist.pem", certDir+"server-key.pem", "127.0.0.1:8261", nil)
+	c.Assert(err, NotNil)
+}
@@ -25,6 +25,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/pingcap/ticdc/dm/pkg/binlog"
+	"github.com/pingcap/ticdc/dm/pkg/binlog/reader"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
 	"github.com/pingcap/ticdc/dm/pkg/utils"
@@ -188,11 +189,10 @@ func readSortedBinlogFromDir(dirpath string) ([]string, error) {
 //  -1: update to smaller, only happens in special case, for example we change
 //      relay.meta manually and start task before relay log catches up.
 func fileSizeUpdated(path string, latestSize int64) (int, error) {
-	fi, err := os.Stat(path)
+	curSize, err := reader.FileSize(path)
 	if err != nil {
 		return 0, terror.ErrGetRelayLogStat.Delegate(err, path)
 	}
-	curSize := fi.Size()
 	switch {
 	case curSize == latestSize:
 		return 0, nil
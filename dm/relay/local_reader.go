@@ -16,7 +16,6 @@ package relay
 import (
 	"context"
 	"fmt"
-	"os"
 	"path"
 	"path/filepath"
 	"strings"
@@ -111,11 +110,11 @@ func (r *BinlogReader) checkRelayPos(pos mysql.Position) error {
 	pos = realPos
 	relayFilepath := path.Join(r.cfg.RelayDir, currentUUID, pos.Name)
 	r.tctx.L().Info("start to check relay log file", zap.String("path", relayFilepath), zap.Stringer("position", pos))
-	fi, err := os.Stat(relayFilepath)
+	size, err := reader.FileSize(relayFilepath)
 	if err != nil {
 		return terror.ErrGetRelayLogStat.Delegate(err, relayFilepath)
 	}
-	if fi.Size() < int64(pos.Pos) {
+	if size < int64(pos.Pos) {
 		return terror.ErrRelayLogGivenPosTooBig.Generate(pos)
 	}
 	return nil
@@ -552,8 +551,8 @@ func (r *BinlogReader) parseFile(
 		r.tctx.L().Debug("start parse relay log file", zap.String("file", fullPath), zap.Int64("offset", offset))
 	}
 
-	// use parser.ParseFile directly now, if needed we can change to use FileReader.
-	err = r.parser.ParseFile(fullPath, offset, onEventFunc)
+	// use reader.ParseFile directly now, if needed we can change to use FileReader.
+	err = reader.ParseFile(r.parser, fullPath, offset, onEventFunc)
 	if err != nil {
 		if possibleLast && isIgnorableParseError(err) {
 			r.tctx.L().Warn("fail to parse relay log file, meet some ignorable error", zap.String("file", fullPath), zap.Int64("offset", offset), zap.Error(err))
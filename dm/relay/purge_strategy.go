@@ -64,6 +64,9 @@ type PurgeStrategy interface {
 	// Do does the purge process one time
 	Do(args interface{}) error
 
+	// Files returns the relay log files which will be purged by Do, without purging them
+	Files(args interface{}) ([]*subRelayFiles, error)
+
 	// Purging indicates whether is doing purge
 	Purging() bool
 
@@ -161,6 +164,15 @@ func (s *filenameStrategy) Do(args interface{}) error {
 	return purgeRelayFilesBeforeFile(s.logger, fa.relayBaseDir, fa.uuids, fa.safeRelayLog)
 }
 
+func (s *filenameStrategy) Files(args interface{}) ([]*subRelayFiles, error) {
+	fa, ok := args.(*filenameArgs)
+	if !ok {
+		return nil, terror.ErrRelayPurgeArgsNotValid.Generate(args, args)
+	}
+
+	return getRelayFilesBeforeFile(s.logger, fa.relayBaseDir, fa.uuids, fa.safeRelayLog)
+}
+
 func (s *filenameStrategy) Purging() bool {
 	return s.purging.Load()
 }
@@ -221,6 +233,15 @@ func (s *inactiveStrategy) Do(args interface{}) error {
 	return purgeRelayFilesBeforeFile(s.logger, ia.relayBaseDir, ia.uuids, ia.activeRelayLog)
 }
 
+func (s *inactiveStrategy) Files(args interface{}) ([]*subRelayFiles, error) {
+	ia, ok := args.(*inactiveArgs)
+	if !ok {
+		return nil, terror.ErrRelayPurgeArgsNotValid.Generate(args, args)
+	}
+
+	return getRelayFilesBeforeFile(s.logger, ia.relayBaseDir, ia.uuids, ia.activeRelayLog)
+}
+
 func (s *inactiveStrategy) Purging() bool {
 	return s.purging.Load()
 }
@@ -290,6 +311,15 @@ func (s *spaceStrategy) Do(args interface{}) error {
 	return purgeRelayFilesBeforeFile(s.logger, sa.relayBaseDir, sa.uuids, sa.activeRelayLog)
 }
 
+func (s *spaceStrategy) Files(args interface{}) ([]*subRelayFiles, error) {
+	sa, ok := args.(*spaceArgs)
+	if !ok {
+		return nil, terror.ErrRelayPurgeArgsNotValid.Generate(args, args)
+	}
+
+	return getRelayFilesBeforeFile(s.logger, sa.relayBaseDir, sa.uuids, sa.activeRelayLog)
+}
+
 func (s *spaceStrategy) Purging() bool {
 	return s.purging.Load()
 }
@@ -351,6 +381,15 @@ func (s *timeStrategy) Do(args interface{}) error {
 	return purgeRelayFilesBeforeFileAndTime(s.logger, ta.relayBaseDir, ta.uuids, ta.activeRelayLog, ta.safeTime)
 }
 
+func (s *timeStrategy) Files(args interface{}) ([]*subRelayFiles, error) {
+	ta, ok := args.(*timeArgs)
+	if !ok {
+		return nil, terror.ErrRelayPurgeArgsNotValid.Generate(args, args)
+	}
+
+	return getRelayFilesBeforeFileAndTime(s.logger, ta.relayBaseDir, ta.uuids, ta.activeRelayLog, ta.safeTime)
+}
+
 func (s *timeStrategy) Purging() bool {
 	return s.purging.Load()
 }
@@ -58,6 +58,8 @@ type Purger interface {
 	Purging() bool
 	// Do does the purge process one time
 	Do(ctx context.Context, req *pb.PurgeRelayRequest) error
+	// DryRun returns the relay log files which would be purged by the request, without purging them
+	DryRun(req *pb.PurgeRelayRequest) ([]string, error)
 }
 
 // NewPurger creates a new purger.
@@ -70,6 +72,7 @@ type relayPurger struct {
 	cancel          context.CancelFunc
 	running         atomic.Int32
 	purgingStrategy atomic.Uint32
+	fileMu          sync.Mutex // ensures relay log files are not purged and compressed at the same time
 
 	cfg          config.PurgeConfig
 	baseRelayDir string
@@ -108,7 +111,7 @@ func (p *relayPurger) Start() {
 		return
 	}
 
-	if p.cfg.Interval <= 0 || (p.cfg.Expires <= 0 && p.cfg.RemainSpace <= 0) {
+	if p.cfg.Interval <= 0 || (p.cfg.Expires <= 0 && p.cfg.RemainSpace <= 0 && p.cfg.Compression == "") {
 		return // no need do purge in the background
 	}
 
@@ -138,6 +141,7 @@ func (p *relayPurger) run() {
 			return
 		case <-ticker.C:
 			p.tryPurge()
+			p.tryCompress()
 		}
 	}
 }
@@ -165,38 +169,81 @@ func (p *relayPurger) Purging() bool {
 
 // Do does the purge process one time.
 func (p *relayPurger) Do(ctx context.Context, req *pb.PurgeRelayRequest) error {
+	ps, args, err := p.strategyForRequest(req)
+	if err != nil {
+		return err
+	}
+	return p.doPurge(ps, args)
+}
+
+// DryRun returns the relay log files which would be purged by the request, without purging them.
+// if no purge condition specified in the request, the strategies used in the background are checked.
+func (p *relayPurger) DryRun(req *pb.PurgeRelayRequest) ([]string, error) {
+	var (
+		ps   PurgeStrategy
+		args StrategyArgs
+		err  error
+	)
+	if req.Inactive || req.Time > 0 || len(req.Filename) > 0 {
+		ps, args, err = p.strategyForRequest(req)
+	} else {
+		ps, args, err = p.check()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if ps == nil {
+		return nil, nil // no need to purge
+	}
+
+	earliest := p.earliestActiveRelayLog()
+	if earliest == nil {
+		return nil, terror.ErrRelayNoActiveRelayLog.Generate()
+	}
+	args.SetActiveRelayLog(earliest)
+
+	subFiles, err := ps.Files(args)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(subFiles))
+	for _, subRelay := range subFiles {
+		files = append(files, subRelay.files...)
+	}
+	return files, nil
+}
+
+// strategyForRequest returns the strategy and its args for a manual purge request.
+func (p *relayPurger) strategyForRequest(req *pb.PurgeRelayRequest) (PurgeStrategy, StrategyArgs, error) {
 	uuids, err := utils.ParseUUIDIndex(p.indexPath)
 	if err != nil {
-		return terror.Annotatef(err, "parse UUID index file %s", p.indexPath)
+		return nil, nil, terror.Annotatef(err, "parse UUID index file %s", p.indexPath)
 	}
 
 	switch {
 	case req.Inactive:
-		ps := p.strategies[strategyInactive]
 		args := &inactiveArgs{
 			relayBaseDir: p.baseRelayDir,
 			uuids:        uuids,
 		}
-		return p.doPurge(ps, args)
+		return p.strategies[strategyInactive], args, nil
 	case req.Time > 0:
-		ps := p.strategies[strategyTime]
 		args := &timeArgs{
 			relayBaseDir: p.baseRelayDir,
 			safeTime:     time.Unix(req.Time, 0),
 			uuids:        uuids,
 		}
-		return p.doPurge(ps, args)
+		return p.strategies[strategyTime], args, nil
 	case len(req.Filename) > 0:
-		ps := p.strategies[strategyFilename]
 		args := &filenameArgs{
 			relayBaseDir: p.baseRelayDir,
 			filename:     req.Filename,
 			subDir:       req.SubDir,
 			uuids:        uuids,
 		}
-		return p.doPurge(ps, args)
+		return p.strategies[strategyFilename], args, nil
 	default:
-		return terror.ErrRelayPurgeRequestNotValid.Generate(req)
+		return nil, nil, terror.ErrRelayPurgeRequestNotValid.Generate(req)
 	}
 }
 
@@ -238,9 +285,50 @@ func (p *relayPurger) doPurge(ps PurgeStrategy, args StrategyArgs) error {
 	args.SetActiveRelayLog(earliest)
 
 	p.logger.Info("start purging relay log files", zap.Stringer("type", ps.Type()), zap.Any("args", args))
+	p.fileMu.Lock()
+	defer p.fileMu.Unlock()
 	return ps.Do(args)
 }
 
+// tryCompress tries to compress the inactive relay log files if compression enabled.
+func (p *relayPurger) tryCompress() {
+	if p.cfg.Compression == "" {
+		return
+	}
+	err := p.doCompress()
+	if err != nil {
+		p.logger.Error("compress relay log files", zap.String("compression", p.cfg.Compression), zap.Error(err))
+	}
+}
+
+// doCompress compresses all inactive relay log files which are not compressed yet.
+func (p *relayPurger) doCompress() error {
+	// the active relay log may not be accurate when purging is forbidden, so compressing is forbidden too
+	for _, inter := range p.interceptors {
+		forbidden, msg := inter.ForbidPurge()
+		if forbidden {
+			return terror.ErrRelayPurgeIsForbidden.Generate(msg)
+		}
+	}
+
+	uuids, err := utils.ParseUUIDIndex(p.indexPath)
+	if err != nil {
+		return terror.Annotatef(err, "parse UUID index file %s", p.indexPath)
+	}
+	earliest := p.earliestActiveRelayLog()
+	if earliest == nil {
+		return terror.ErrRelayNoActiveRelayLog.Generate()
+	}
+
+	p.fileMu.Lock()
+	defer p.fileMu.Unlock()
+	files, err := getRelayFilesBeforeFile(p.logger, p.baseRelayDir, uuids, earliest)
+	if err != nil {
+		return terror.Annotatef(err, "get relay files from directory %s before file %+v with UUIDs %v", p.baseRelayDir, earliest, uuids)
+	}
+	return compressRelayFiles(p.logger, files)
+}
+
 func (p *relayPurger) check() (PurgeStrategy, StrategyArgs, error) {
 	p.logger.Info("checking whether needing to purge relay log files")
 
@@ -328,3 +416,8 @@ func (d *dummyPurger) Purging() bool {
 func (d *dummyPurger) Do(ctx context.Context, req *pb.PurgeRelayRequest) error {
 	return nil
 }
+
+// DryRun implements interface of Purger.
+func (d *dummyPurger) DryRun(req *pb.PurgeRelayRequest) ([]string, error) {
+	return nil, nil
+}
@@ -14,12 +14,16 @@
 package relay
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/klauspost/compress/zstd"
 	"go.uber.org/zap"
 
+	"github.com/pingcap/ticdc/dm/pkg/binlog/reader"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/streamer"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
@@ -184,3 +188,84 @@ func purgeRelayFiles(logger log.Logger, files []*subRelayFiles) error {
 	}
 	return nil
 }
+
+// compressRelayFiles compresses relay log files which are not compressed yet.
+func compressRelayFiles(logger log.Logger, files []*subRelayFiles) error {
+	for _, subRelay := range files {
+		for _, f := range subRelay.files {
+			compressed, err := reader.IsCompressedFile(f)
+			if err != nil {
+				return terror.ErrRelayCompressFileFail.Delegate(err, f)
+			}
+			if compressed {
+				continue
+			}
+			if err = compressRelayFile(logger, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// compressRelayFile compresses a relay log file with zstd and replaces it with the compressed one.
+// the checksums of all events are verified before compressing, so a corrupted file can be found early.
+func compressRelayFile(logger log.Logger, filename string) (err error) {
+	parser := replication.NewBinlogParser()
+	parser.SetVerifyChecksum(true)
+	parser.SetRawMode(true)
+	err = parser.ParseFile(filename, 4, func(*replication.BinlogEvent) error { return nil })
+	if err != nil {
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+
+	src, err := os.Open(filename)
+	if err != nil {
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+	defer src.Close()
+	fi, err := src.Stat()
+	if err != nil {
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+
+	// write to a temporary file which is not a valid relay log filename, then rename it atomically
+	tmpFilename := filename + ".zst.tmp"
+	dst, err := os.OpenFile(tmpFilename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+	defer func() {
+		if err != nil {
+			dst.Close()
+			os.Remove(tmpFilename)
+		}
+	}()
+	enc, err := zstd.NewWriter(dst, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+	if _, err = io.Copy(enc, src); err != nil {
+		enc.Close()
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+	if err = enc.Close(); err != nil {
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+	if err = dst.Sync(); err != nil {
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+	if err = dst.Close(); err != nil {
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+	// keep the modified time, it's used by the time strategy to purge
+	if err = os.Chtimes(tmpFilename, fi.ModTime(), fi.ModTime()); err != nil {
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+	if err = os.Rename(tmpFilename, filename); err != nil {
+		return terror.ErrRelayCompressFileFail.Delegate(err, filename)
+	}
+
+	logger.Info("compressed relay log file", zap.String("file", filename), zap.Int64("size", fi.Size()))
+	return nil
+}
@@ -21,11 +21,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-mysql-org/go-mysql/replication"
 	. "github.com/pingcap/check"
 
 	"github.com/pingcap/ticdc/dm/dm/config"
 	"github.com/pingcap/ticdc/dm/dm/pb"
+	"github.com/pingcap/ticdc/dm/pkg/binlog/event"
+	"github.com/pingcap/ticdc/dm/pkg/binlog/reader"
 	"github.com/pingcap/ticdc/dm/pkg/streamer"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 	"github.com/pingcap/ticdc/dm/pkg/utils"
 )
 
@@ -179,6 +183,58 @@ func (t *testPurgerSuite) TestPurgeManuallyFilename(c *C) {
 	}
 }
 
+func (t *testPurgerSuite) TestPurgeDryRun(c *C) {
+	// create relay log dir
+	baseDir, err := os.MkdirTemp("", "test_purge_dry_run")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(baseDir)
+
+	// prepare files and directories
+	relayDirsPath, relayFilesPath, safeTime := t.genRelayLogFiles(c, baseDir, 1, 0)
+	c.Assert(len(relayDirsPath), Equals, 3)
+	c.Assert(len(relayFilesPath), Equals, 3)
+
+	err = t.genUUIDIndexFile(baseDir)
+	c.Assert(err, IsNil)
+
+	cfg := config.PurgeConfig{
+		Interval: 0, // disable automatically
+	}
+
+	purger := NewPurger(cfg, baseDir, []Operator{t}, nil)
+
+	// inactive files
+	files, err := purger.DryRun(&pb.PurgeRelayRequest{Inactive: true})
+	c.Assert(err, IsNil)
+	expected := append(append([]string{}, relayFilesPath[0]...), relayFilesPath[1][:2]...)
+	c.Assert(files, DeepEquals, expected)
+
+	// files older than the time
+	files, err = purger.DryRun(&pb.PurgeRelayRequest{Time: safeTime.Unix()})
+	c.Assert(err, IsNil)
+	expected = append(append([]string{}, relayFilesPath[0]...), relayFilesPath[1][0])
+	c.Assert(files, DeepEquals, expected)
+
+	// no strategy enabled in the background
+	files, err = purger.DryRun(&pb.PurgeRelayRequest{})
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 0)
+
+	// check with the strategies in the background
+	cfg.Expires = 1
+	purger = NewPurger(cfg, baseDir, []Operator{t}, nil)
+	files, err = purger.DryRun(&pb.PurgeRelayRequest{})
+	c.Assert(err, IsNil)
+	c.Assert(files, HasLen, 0) // all files are modified just now
+
+	// nothing purged
+	for _, fps := range relayFilesPath {
+		for _, fp := range fps {
+			c.Assert(utils.IsFileExists(fp), IsTrue)
+		}
+	}
+}
+
 func (t *testPurgerSuite) TestPurgeAutomaticallyTime(c *C) {
 	// create relay log dir
 	baseDir, err := os.MkdirTemp("", "test_purge_automatically_time")
@@ -266,6 +322,83 @@ func (t *testPurgerSuite) TestPurgeAutomaticallySpace(c *C) {
 	}
 }
 
+func (t *testPurgerSuite) TestCompressInactive(c *C) {
+	// create relay log dir
+	baseDir, err := os.MkdirTemp("", "test_compress_inactive")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(baseDir)
+
+	// prepare files and directories with valid binlog events
+	relayDirsPath, relayFilesPath, _ := t.genRelayLogFiles(c, baseDir, -1, -1)
+	c.Assert(len(relayDirsPath), Equals, 3)
+	header := &replication.EventHeader{
+		Timestamp: uint32(time.Now().Unix()),
+		ServerID:  uint32(101),
+	}
+	formatDescEv, err := event.GenFormatDescriptionEvent(header, 4)
+	c.Assert(err, IsNil)
+	queryEv, err := event.GenQueryEvent(header, formatDescEv.Header.LogPos, 0, 0, 0, nil, []byte("db"), []byte("CREATE DATABASE db"))
+	c.Assert(err, IsNil)
+	content := append([]byte{}, replication.BinLogFileHeader...)
+	content = append(content, formatDescEv.RawData...)
+	content = append(content, queryEv.RawData...)
+	mTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, fps := range relayFilesPath {
+		for _, fp := range fps {
+			c.Assert(os.WriteFile(fp, content, 0o644), IsNil)
+			c.Assert(os.Chtimes(fp, mTime, mTime), IsNil)
+		}
+	}
+
+	err = t.genUUIDIndexFile(baseDir)
+	c.Assert(err, IsNil)
+
+	cfg := config.PurgeConfig{
+		Interval:    0, // disable automatically
+		Compression: config.RelayCompressionZstd,
+	}
+	purger := NewPurger(cfg, baseDir, []Operator{t}, nil).(*relayPurger)
+
+	// corrupt an inactive file, it can't be compressed
+	corrupted := append([]byte{}, content...)
+	corrupted[len(corrupted)-5]++
+	c.Assert(os.WriteFile(relayFilesPath[0][1], corrupted, 0o644), IsNil)
+	c.Assert(terror.ErrRelayCompressFileFail.Equal(purger.doCompress()), IsTrue)
+	c.Assert(os.WriteFile(relayFilesPath[0][1], content, 0o644), IsNil)
+	c.Assert(os.Chtimes(relayFilesPath[0][1], mTime, mTime), IsNil)
+
+	c.Assert(purger.doCompress(), IsNil)
+	parseEvents := func(fp string) [][]byte {
+		var events [][]byte
+		parser := replication.NewBinlogParser()
+		err2 := reader.ParseFile(parser, fp, 4, func(e *replication.BinlogEvent) error {
+			events = append(events, e.RawData)
+			return nil
+		})
+		c.Assert(err2, IsNil)
+		return events
+	}
+	for i, fps := range relayFilesPath {
+		for j, fp := range fps {
+			compressed, err2 := reader.IsCompressedFile(fp)
+			c.Assert(err2, IsNil)
+			// files before the active relay log are compressed
+			c.Assert(compressed, Equals, i == 0 || (i == 1 && j < 2))
+			fi, err2 := os.Stat(fp)
+			c.Assert(err2, IsNil)
+			c.Assert(fi.ModTime().Equal(mTime), IsTrue)
+			c.Assert(parseEvents(fp), DeepEquals, [][]byte{formatDescEv.RawData, queryEv.RawData})
+		}
+	}
+
+	// compressed files can still be purged
+	err = purger.Do(context.Background(), &pb.PurgeRelayRequest{Inactive: true})
+	c.Assert(err, IsNil)
+	c.Assert(utils.IsDirExists(relayDirsPath[0]), IsFalse)
+	c.Assert(utils.IsFileExists(relayFilesPath[1][0]), IsFalse)
+	c.Assert(utils.IsFileExists(relayFilesPath[1][2]), IsTrue)
+}
+
 func (t *testPurgerSuite) genRelayLogFiles(c *C, baseDir string, safeTimeIdxI, safeTimeIdxJ int) ([]string, [][]string, time.Time) {
 	var (
 		relayDirsPath  = make([]string, 0, 3)
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/syncer/metrics"
+	"github.com/pingcap/ticdc/pkg/hotkey"
 )
 
 // causality provides a simple mechanism to improve the concurrency of SQLs execution under the premise of ensuring correctness.
@@ -35,6 +36,10 @@ type causality struct {
 	// for metrics
 	task   string
 	source string
+
+	// hotKeys records the keys causing conflicts
+	hotKeys           *hotkey.Tracker
+	unregisterHotKeys func()
 }
 
 // causalityWrap creates and runs a causality instance.
@@ -46,7 +51,10 @@ func causalityWrap(inCh chan *job, syncer *Syncer) chan *job {
 		logger:    syncer.tctx.Logger.WithFields(zap.String("component", "causality")),
 		inCh:      inCh,
 		outCh:     make(chan *job, syncer.cfg.QueueSize),
+		hotKeys:   hotkey.NewTracker(hotkey.DefaultCapacity),
 	}
+	causality.unregisterHotKeys = hotkey.Register(
+		"dm/"+causality.task+"/"+causality.source+"/causality", causality.hotKeys)
 
 	go func() {
 		causality.run()
@@ -70,6 +78,7 @@ func (c *causality) run() {
 			// detectConflict before add
 			if c.detectConflict(keys) {
 				c.logger.Debug("meet causality key, will generate a conflict job to flush all sqls", zap.Strings("keys", keys))
+				c.observeConflictKeys(keys)
 				c.outCh <- newConflictJob()
 				c.reset()
 			}
@@ -84,9 +93,21 @@ func (c *causality) run() {
 
 // close closes outer channel.
 func (c *causality) close() {
+	if c.unregisterHotKeys != nil {
+		c.unregisterHotKeys()
+	}
 	close(c.outCh)
 }
 
+// observeConflictKeys records the keys which are related to the existing relations as hot keys.
+func (c *causality) observeConflictKeys(keys []string) {
+	for _, key := range keys {
+		if _, ok := c.relations[key]; ok {
+			c.hotKeys.Observe(key)
+		}
+	}
+}
+
 // add adds keys relation and return the relation. The keys must `detectConflict` first to ensure correctness.
 func (c *causality) add(keys []string) string {
 	if len(keys) == 0 {
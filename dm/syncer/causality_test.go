@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/schema"
 	"github.com/pingcap/ticdc/dm/pkg/utils"
+	"github.com/pingcap/ticdc/pkg/hotkey"
 )
 
 func (s *testSyncerSuite) TestDetectConflict(c *C) {
@@ -48,8 +49,11 @@ func (s *testSyncerSuite) TestDetectConflict(c *C) {
 	ca.add([]string{"test_4"})
 	excepted["test_4"] = "test_4"
 	c.Assert(ca.relations, DeepEquals, excepted)
-	conflictData := []string{"test_4", "test_3"}
+	conflictData := []string{"test_4", "test_3", "test_5"}
 	c.Assert(ca.detectConflict(conflictData), IsTrue)
+	ca.hotKeys = hotkey.NewTracker(0)
+	ca.observeConflictKeys(conflictData)
+	c.Assert(ca.hotKeys.Top(0), DeepEquals, []hotkey.Key{{Key: "test_3", Count: 1}, {Key: "test_4", Count: 1}})
 	ca.reset()
 	c.Assert(ca.relations, HasLen, 0)
 }
@@ -207,3 +211,107 @@ func (s *testSyncerSuite) TestCasualityWithPrefixIndex(c *C) {
 		c.Assert(job.tp, Equals, op)
 	}
 }
+
+func (s *testSyncerSuite) TestCasualityWithCompactor(c *C) {
+	p := parser.New()
+	se := mock.NewContext()
+	schemaStr := "create table tb(a int primary key, b int unique);"
+	ti, err := createTableInfo(p, se, int64(0), schemaStr)
+	c.Assert(err, IsNil)
+	tiIndex := &model.IndexInfo{
+		Table:   ti.Name,
+		Unique:  true,
+		Primary: true,
+		State:   model.StatePublic,
+		Tp:      model.IndexTypeBtree,
+		Columns: []*model.IndexColumn{{
+			Name:   ti.Columns[0].Name,
+			Offset: ti.Columns[0].Offset,
+			Length: types.UnspecifiedLength,
+		}},
+	}
+	downTi := schema.GetDownStreamTi(ti, ti)
+	c.Assert(downTi, NotNil)
+
+	jobCh := make(chan *job, 10)
+	syncer := &Syncer{
+		cfg: &config.SubTaskConfig{
+			SyncerConfig: config.SyncerConfig{
+				QueueSize: 1024,
+			},
+			Name:     "task",
+			SourceID: "source",
+		},
+		tctx: tcontext.Background().WithLogger(log.L()),
+	}
+	// the compactor flushes to the causality directly
+	compactor := &compactor{
+		outCh:        jobCh,
+		bufferSize:   10,
+		logger:       log.L(),
+		keyMap:       make(map[string]map[string]int),
+		buffer:       make([]*job, 0, 10),
+		addCountFunc: func(b bool, s string, ot opType, i int64, t *filter.Table) {},
+	}
+	causalityCh := causalityWrap(jobCh, syncer)
+	testCases := []struct {
+		op      opType
+		oldVals []interface{}
+		vals    []interface{}
+	}{
+		{
+			op:   insert,
+			vals: []interface{}{1, 2},
+		},
+		{
+			op:      update,
+			oldVals: []interface{}{1, 2},
+			vals:    []interface{}{1, 3},
+		},
+		{
+			op:      update,
+			oldVals: []interface{}{2, 4},
+			vals:    []interface{}{2, 5},
+		},
+		{
+			op:   del,
+			vals: []interface{}{2, 5},
+		},
+		{
+			op:   insert,
+			vals: []interface{}{3, 5},
+		},
+	}
+	// INSERT + UPDATE => INSERT, UPDATE + DELETE => DELETE
+	results := []struct {
+		op   opType
+		vals []interface{}
+	}{
+		{insert, []interface{}{1, 3}},
+		{del, []interface{}{2, 5}},
+		{insert, []interface{}{3, 5}},
+	}
+	table := &filter.Table{Schema: "test", Name: "t1"}
+	location := binlog.NewLocation("")
+	ec := &eventContext{startLocation: &location, currentLocation: &location, lastLocation: &location}
+
+	for _, tc := range testCases {
+		compactor.compactJob(newDMLJob(tc.op, table, table, newDML(tc.op, false, "", table, tc.oldVals, tc.vals, tc.oldVals, tc.vals, ti.Columns, ti, tiIndex, downTi), ec))
+	}
+	compactor.flushBuffer()
+
+	c.Assert(utils.WaitSomething(30, 100*time.Millisecond, func() bool {
+		return len(causalityCh) == len(results)
+	}), IsTrue)
+
+	jobs := make([]*job, 0, len(results))
+	for _, res := range results {
+		job := <-causalityCh
+		c.Assert(job.tp, Equals, res.op)
+		c.Assert(job.dml.values, DeepEquals, res.vals)
+		jobs = append(jobs, job)
+	}
+	// the compacted DELETE and the INSERT of the same unique key are dispatched by the same causality key
+	c.Assert(jobs[2].dml.key, Equals, jobs[1].dml.key)
+	c.Assert(jobs[0].dml.key, Not(Equals), jobs[1].dml.key)
+}
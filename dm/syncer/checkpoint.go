@@ -16,7 +16,6 @@ package syncer
 import (
 	"bytes"
 	"context"
-	"database/sql"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -26,23 +25,18 @@ import (
 
 	"github.com/pingcap/ticdc/dm/dm/config"
 	"github.com/pingcap/ticdc/dm/pkg/binlog"
-	"github.com/pingcap/ticdc/dm/pkg/conn"
 	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
-	"github.com/pingcap/ticdc/dm/pkg/cputil"
 	"github.com/pingcap/ticdc/dm/pkg/dumpling"
 	"github.com/pingcap/ticdc/dm/pkg/gtid"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/schema"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
 	"github.com/pingcap/ticdc/dm/pkg/utils"
-	"github.com/pingcap/ticdc/dm/syncer/dbconn"
 
 	"github.com/go-mysql-org/go-mysql/mysql"
-	"github.com/pingcap/failpoint"
-	"github.com/pingcap/tidb-tools/pkg/dbutil"
 	"github.com/pingcap/tidb-tools/pkg/filter"
 	"github.com/pingcap/tidb/parser/model"
-	tmysql "github.com/pingcap/tidb/parser/mysql"
+	"go.etcd.io/etcd/clientv3"
 	"go.uber.org/zap"
 )
 
@@ -257,7 +251,7 @@ type CheckPoint interface {
 }
 
 // RemoteCheckPoint implements CheckPoint
-// which using target database to store info
+// which using the checkpoint storage (the target database by default) to store info
 // NOTE: now we sync from relay log, so not add GTID support yet
 // it's not thread-safe.
 type RemoteCheckPoint struct {
@@ -265,10 +259,8 @@ type RemoteCheckPoint struct {
 
 	cfg *config.SubTaskConfig
 
-	db        *conn.BaseDB
-	dbConn    *dbconn.DBConn
-	tableName string // qualified table name: schema is set through task config, table is task name
-	id        string // checkpoint ID, now it is `source-id`
+	storage checkpointStorage
+	id      string // checkpoint ID, now it is `source-id`
 
 	// source-schema -> source-table -> checkpoint
 	// used to filter the synced binlog when re-syncing for sharding group
@@ -296,14 +288,16 @@ type RemoteCheckPoint struct {
 }
 
 // NewRemoteCheckPoint creates a new RemoteCheckPoint.
-func NewRemoteCheckPoint(tctx *tcontext.Context, cfg *config.SubTaskConfig, id string) CheckPoint {
+// cli is used only when the checkpoint storage is etcd.
+func NewRemoteCheckPoint(tctx *tcontext.Context, cfg *config.SubTaskConfig, cli *clientv3.Client, id string) CheckPoint {
+	logCtx := tcontext.Background().WithLogger(tctx.L().WithFields(zap.String("component", "remote checkpoint")))
 	cp := &RemoteCheckPoint{
 		cfg:         cfg,
-		tableName:   dbutil.TableName(cfg.MetaSchema, cputil.SyncerCheckpoint(cfg.Name)),
+		storage:     newCheckpointStorage(logCtx, cfg, cli, id),
 		id:          id,
 		points:      make(map[string]map[string]*binlogPoint),
 		globalPoint: newBinlogPoint(binlog.NewLocation(cfg.Flavor), binlog.NewLocation(cfg.Flavor), nil, nil, cfg.EnableGTID),
-		logCtx:      tcontext.Background().WithLogger(tctx.L().WithFields(zap.String("component", "remote checkpoint"))),
+		logCtx:      logCtx,
 	}
 
 	return cp
@@ -311,26 +305,17 @@ func NewRemoteCheckPoint(tctx *tcontext.Context, cfg *config.SubTaskConfig, id s
 
 // Init implements CheckPoint.Init.
 func (cp *RemoteCheckPoint) Init(tctx *tcontext.Context) error {
-	checkPointDB := cp.cfg.To
-	checkPointDB.RawDBCfg = config.DefaultRawDBConfig().SetReadTimeout(maxCheckPointTimeout)
-	db, dbConns, err := dbconn.CreateConns(tctx, cp.cfg, &checkPointDB, 1)
-	if err != nil {
-		return err
-	}
-	cp.db = db
-	cp.dbConn = dbConns[0]
-
-	return cp.prepare(tctx)
+	return cp.storage.Init(tctx)
 }
 
 // Close implements CheckPoint.Close.
 func (cp *RemoteCheckPoint) Close() {
-	dbconn.CloseBaseDB(cp.logCtx, cp.db)
+	cp.storage.Close()
 }
 
 // ResetConn implements CheckPoint.ResetConn.
 func (cp *RemoteCheckPoint) ResetConn(tctx *tcontext.Context) error {
-	return cp.dbConn.ResetConn(tctx)
+	return cp.storage.ResetConn(tctx)
 }
 
 // Clear implements CheckPoint.Clear.
@@ -342,11 +327,7 @@ func (cp *RemoteCheckPoint) Clear(tctx *tcontext.Context) error {
 	// use a new context apart from syncer, to make sure when syncer call `cancel` checkpoint could update
 	tctx2, cancel := tctx.WithContext(context.Background()).WithTimeout(maxDMLConnectionDuration)
 	defer cancel()
-	_, err := cp.dbConn.ExecuteSQL(
-		tctx2,
-		[]string{`DELETE FROM ` + cp.tableName + ` WHERE id = ?`},
-		[]interface{}{cp.id},
-	)
+	err := cp.storage.Delete(tctx2, "", "")
 	if err != nil {
 		return err
 	}
@@ -420,11 +401,7 @@ func (cp *RemoteCheckPoint) DeleteTablePoint(tctx *tcontext.Context, table *filt
 	tctx2, cancel := tctx.WithContext(context.Background()).WithTimeout(maxDMLConnectionDuration)
 	defer cancel()
 	cp.logCtx.L().Info("delete table checkpoint", zap.String("schema", sourceSchema), zap.String("table", sourceTable))
-	_, err := cp.dbConn.ExecuteSQL(
-		tctx2,
-		[]string{`DELETE FROM ` + cp.tableName + ` WHERE id = ? AND cp_schema = ? AND cp_table = ?`},
-		[]interface{}{cp.id, sourceSchema, sourceTable},
-	)
+	err := cp.storage.Delete(tctx2, sourceSchema, sourceTable)
 	if err != nil {
 		return err
 	}
@@ -445,11 +422,7 @@ func (cp *RemoteCheckPoint) DeleteSchemaPoint(tctx *tcontext.Context, sourceSche
 	tctx2, cancel := tctx.WithContext(context.Background()).WithTimeout(maxDMLConnectionDuration)
 	defer cancel()
 	cp.logCtx.L().Info("delete schema checkpoint", zap.String("schema", sourceSchema))
-	_, err := cp.dbConn.ExecuteSQL(
-		tctx2,
-		[]string{`DELETE FROM ` + cp.tableName + ` WHERE id = ? AND cp_schema = ?`},
-		[]interface{}{cp.id, sourceSchema},
-	)
+	err := cp.storage.Delete(tctx2, sourceSchema, "")
 	if err != nil {
 		return err
 	}
@@ -514,14 +487,11 @@ func (cp *RemoteCheckPoint) FlushPointsExcept(tctx *tcontext.Context, exceptTabl
 		m[table] = struct{}{}
 	}
 
-	sqls := make([]string, 0, 100)
-	args := make([][]interface{}, 0, 100)
+	rows := make([]*checkpointRow, 0, 100)
 
 	if cp.globalPoint.outOfDate() || cp.globalPointSaveTime.IsZero() || cp.needFlushSafeModeExitPoint {
 		locationG := cp.GlobalPoint()
-		sqlG, argG := cp.genUpdateSQL(globalCpSchema, globalCpTable, locationG, cp.safeModeExitPoint, nil, true)
-		sqls = append(sqls, sqlG)
-		args = append(args, argG)
+		rows = append(rows, cp.genCheckpointRow(globalCpSchema, globalCpTable, locationG, cp.safeModeExitPoint, nil, true))
 	}
 
 	points := make([]*binlogPoint, 0, 100)
@@ -540,23 +510,17 @@ func (cp *RemoteCheckPoint) FlushPointsExcept(tctx *tcontext.Context, exceptTabl
 				}
 
 				location := point.MySQLLocation()
-				sql2, arg := cp.genUpdateSQL(schema, table, location, nil, tiBytes, false)
-				sqls = append(sqls, sql2)
-				args = append(args, arg)
+				rows = append(rows, cp.genCheckpointRow(schema, table, location, nil, tiBytes, false))
 
 				points = append(points, point)
 			}
 		}
 	}
-	for i := range extraSQLs {
-		sqls = append(sqls, extraSQLs[i])
-		args = append(args, extraArgs[i])
-	}
 
 	// use a new context apart from syncer, to make sure when syncer call `cancel` checkpoint could update
 	tctx2, cancel := tctx.WithContext(context.Background()).WithTimeout(maxDMLConnectionDuration)
 	defer cancel()
-	_, err := cp.dbConn.ExecuteSQL(tctx2, sqls, args...)
+	err := cp.storage.Flush(tctx2, rows, extraSQLs, extraArgs)
 	if err != nil {
 		return err
 	}
@@ -576,8 +540,6 @@ func (cp *RemoteCheckPoint) FlushPointWithTableInfo(tctx *tcontext.Context, tabl
 	cp.Lock()
 	defer cp.Unlock()
 	sourceSchema, sourceTable := table.Schema, table.Name
-	sqls := make([]string, 0, 1)
-	args := make([][]interface{}, 0, 10)
 	point := newBinlogPoint(binlog.NewLocation(cp.cfg.Flavor), binlog.NewLocation(cp.cfg.Flavor), nil, nil, cp.cfg.EnableGTID)
 
 	if tablePoints, ok := cp.points[sourceSchema]; ok {
@@ -592,14 +554,12 @@ func (cp *RemoteCheckPoint) FlushPointWithTableInfo(tctx *tcontext.Context, tabl
 	}
 
 	location := point.MySQLLocation()
-	sql2, arg := cp.genUpdateSQL(sourceSchema, sourceTable, location, nil, tiBytes, false)
-	sqls = append(sqls, sql2)
-	args = append(args, arg)
+	row := cp.genCheckpointRow(sourceSchema, sourceTable, location, nil, tiBytes, false)
 
 	// use a new context apart from syncer, to make sure when syncer call `cancel` checkpoint could update
 	tctx2, cancel := tctx.WithContext(context.Background()).WithTimeout(utils.DefaultDBTimeout)
 	defer cancel()
-	_, err = cp.dbConn.ExecuteSQL(tctx2, sqls, args...)
+	err = cp.storage.Flush(tctx2, []*checkpointRow{row}, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -618,17 +578,14 @@ func (cp *RemoteCheckPoint) FlushSafeModeExitPoint(tctx *tcontext.Context) error
 	cp.RLock()
 	defer cp.RUnlock()
 
-	sqls := make([]string, 1)
-	args := make([][]interface{}, 1)
-
 	// use FlushedGlobalPoint here to avoid update global checkpoint
 	locationG := cp.FlushedGlobalPoint()
-	sqls[0], args[0] = cp.genUpdateSQL(globalCpSchema, globalCpTable, locationG, cp.safeModeExitPoint, nil, true)
+	row := cp.genCheckpointRow(globalCpSchema, globalCpTable, locationG, cp.safeModeExitPoint, nil, true)
 
 	// use a new context apart from syncer, to make sure when syncer call `cancel` checkpoint could update
 	tctx2, cancel := tctx.WithContext(context.Background()).WithTimeout(maxDMLConnectionDuration)
 	defer cancel()
-	_, err := cp.dbConn.ExecuteSQL(tctx2, sqls, args...)
+	err := cp.storage.Flush(tctx2, []*checkpointRow{row}, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -732,102 +689,32 @@ func (cp *RemoteCheckPoint) Rollback(schemaTracker *schema.Tracker) {
 	}
 }
 
-func (cp *RemoteCheckPoint) prepare(tctx *tcontext.Context) error {
-	if err := cp.createSchema(tctx); err != nil {
-		return err
-	}
-
-	return cp.createTable(tctx)
-}
-
-func (cp *RemoteCheckPoint) createSchema(tctx *tcontext.Context) error {
-	// TODO(lance6716): change ColumnName to IdentName or something
-	sql2 := fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", dbutil.ColumnName(cp.cfg.MetaSchema))
-	args := make([]interface{}, 0)
-	_, err := cp.dbConn.ExecuteSQL(tctx, []string{sql2}, [][]interface{}{args}...)
-	cp.logCtx.L().Info("create checkpoint schema", zap.String("statement", sql2))
-	return err
-}
-
-func (cp *RemoteCheckPoint) createTable(tctx *tcontext.Context) error {
-	sqls := []string{
-		`CREATE TABLE IF NOT EXISTS ` + cp.tableName + ` (
-			id VARCHAR(32) NOT NULL,
-			cp_schema VARCHAR(128) NOT NULL,
-			cp_table VARCHAR(128) NOT NULL,
-			binlog_name VARCHAR(128),
-			binlog_pos INT UNSIGNED,
-			binlog_gtid TEXT,
-			exit_safe_binlog_name VARCHAR(128) DEFAULT '',
-			exit_safe_binlog_pos INT UNSIGNED DEFAULT 0,
-			exit_safe_binlog_gtid TEXT,
-			table_info JSON NOT NULL,
-			is_global BOOLEAN,
-			create_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
-			update_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
-			UNIQUE KEY uk_id_schema_table (id, cp_schema, cp_table)
-		)`,
-	}
-	_, err := cp.dbConn.ExecuteSQL(tctx, sqls)
-	cp.logCtx.L().Info("create checkpoint table", zap.Strings("statements", sqls))
-	return err
-}
-
 // Load implements CheckPoint.Load.
 func (cp *RemoteCheckPoint) Load(tctx *tcontext.Context) error {
 	cp.Lock()
 	defer cp.Unlock()
 
-	query := `SELECT cp_schema, cp_table, binlog_name, binlog_pos, binlog_gtid, exit_safe_binlog_name, exit_safe_binlog_pos, exit_safe_binlog_gtid, table_info, is_global FROM ` + cp.tableName + ` WHERE id = ?`
-	rows, err := cp.dbConn.QuerySQL(tctx, query, cp.id)
-	defer func() {
-		if rows != nil {
-			rows.Close()
-		}
-	}()
-
-	failpoint.Inject("LoadCheckpointFailed", func(val failpoint.Value) {
-		err = tmysql.NewErr(uint16(val.(int)))
-		log.L().Warn("Load failed", zap.String("failpoint", "LoadCheckpointFailed"), zap.Error(err))
-	})
-
+	rows, err := cp.storage.Load(tctx)
 	if err != nil {
-		return terror.WithScope(err, terror.ScopeDownstream)
+		return err
 	}
 
 	// checkpoints in DB have higher priority
 	// if don't want to use checkpoint in DB, set `remove-meta` to `true`
-	var (
-		cpSchema              string
-		cpTable               string
-		binlogName            string
-		binlogPos             uint32
-		binlogGTIDSet         sql.NullString
-		exitSafeBinlogName    string
-		exitSafeBinlogPos     uint32
-		exitSafeBinlogGTIDSet sql.NullString
-		tiBytes               []byte
-		isGlobal              bool
-	)
-	for rows.Next() {
-		err := rows.Scan(&cpSchema, &cpTable, &binlogName, &binlogPos, &binlogGTIDSet, &exitSafeBinlogName, &exitSafeBinlogPos, &exitSafeBinlogGTIDSet, &tiBytes, &isGlobal)
-		if err != nil {
-			return terror.WithScope(terror.DBErrorAdapt(err, terror.ErrDBDriverError), terror.ScopeDownstream)
-		}
-
-		gset, err := gtid.ParserGTID(cp.cfg.Flavor, binlogGTIDSet.String) // default to "".
+	for _, row := range rows {
+		gset, err := gtid.ParserGTID(cp.cfg.Flavor, row.BinlogGTID) // default to "".
 		if err != nil {
 			return err
 		}
 
 		location := binlog.InitLocation(
 			mysql.Position{
-				Name: binlogName,
-				Pos:  binlogPos,
+				Name: row.BinlogName,
+				Pos:  row.BinlogPos,
 			},
 			gset,
 		)
-		if isGlobal {
+		if row.IsGlobal {
 			// Use IsFreshPosition here to make sure checkpoint can be updated if gset is empty
 			if !binlog.IsFreshPosition(location, cp.cfg.Flavor, cp.cfg.EnableGTID) {
 				cp.globalPoint = newBinlogPoint(location, location, nil, nil, cp.cfg.EnableGTID)
@@ -836,26 +723,26 @@ func (cp *RemoteCheckPoint) Load(tctx *tcontext.Context) error {
 
 			if cp.cfg.EnableGTID {
 				// gtid set default is "", but upgrade may cause NULL value
-				if exitSafeBinlogGTIDSet.Valid && exitSafeBinlogGTIDSet.String != "" {
-					gset2, err2 := gtid.ParserGTID(cp.cfg.Flavor, exitSafeBinlogGTIDSet.String)
+				if row.ExitSafeBinlogGTID != "" {
+					gset2, err2 := gtid.ParserGTID(cp.cfg.Flavor, row.ExitSafeBinlogGTID)
 					if err2 != nil {
 						return err2
 					}
 					exitSafeModeLoc := binlog.InitLocation(
 						mysql.Position{
-							Name: exitSafeBinlogName,
-							Pos:  exitSafeBinlogPos,
+							Name: row.ExitSafeBinlogName,
+							Pos:  row.ExitSafeBinlogPos,
 						},
 						gset2,
 					)
 					cp.SaveSafeModeExitPoint(&exitSafeModeLoc)
 				}
 			} else {
-				if exitSafeBinlogName != "" {
+				if row.ExitSafeBinlogName != "" {
 					exitSafeModeLoc := binlog.Location{
 						Position: mysql.Position{
-							Name: exitSafeBinlogName,
-							Pos:  exitSafeBinlogPos,
+							Name: row.ExitSafeBinlogName,
+							Pos:  row.ExitSafeBinlogPos,
 						},
 					}
 					cp.SaveSafeModeExitPoint(&exitSafeModeLoc)
@@ -865,22 +752,22 @@ func (cp *RemoteCheckPoint) Load(tctx *tcontext.Context) error {
 		}
 
 		var ti *model.TableInfo
-		if !bytes.Equal(tiBytes, []byte("null")) {
+		if !bytes.Equal(row.TableInfo, []byte("null")) {
 			// only create table if `table_info` is not `null`.
-			if err = json.Unmarshal(tiBytes, &ti); err != nil {
-				return terror.ErrSchemaTrackerInvalidJSON.Delegate(err, cpSchema, cpTable)
+			if err = json.Unmarshal(row.TableInfo, &ti); err != nil {
+				return terror.ErrSchemaTrackerInvalidJSON.Delegate(err, row.CPSchema, row.CPTable)
 			}
 		}
 
-		mSchema, ok := cp.points[cpSchema]
+		mSchema, ok := cp.points[row.CPSchema]
 		if !ok {
 			mSchema = make(map[string]*binlogPoint)
-			cp.points[cpSchema] = mSchema
+			cp.points[row.CPSchema] = mSchema
 		}
-		mSchema[cpTable] = newBinlogPoint(location, location, ti, ti, cp.cfg.EnableGTID)
+		mSchema[row.CPTable] = newBinlogPoint(location, location, ti, ti, cp.cfg.EnableGTID)
 	}
 
-	return terror.WithScope(terror.DBErrorAdapt(rows.Err(), terror.ErrDBDriverError), terror.ScopeDownstream)
+	return nil
 }
 
 // CheckAndUpdate check the checkpoint data consistency and try to fix them if possible.
@@ -929,7 +816,7 @@ func (cp *RemoteCheckPoint) LoadMeta() error {
 		err             error
 	)
 	switch cp.cfg.Mode {
-	case config.ModeAll:
+	case config.ModeAll, config.ModeLoadSync:
 		// NOTE: syncer must continue the syncing follow loader's tail, so we parse mydumper's output
 		// refine when master / slave switching added and checkpoint mechanism refactored
 		location, safeModeExitLoc, err = cp.parseMetaData()
@@ -943,9 +830,13 @@ func (cp *RemoteCheckPoint) LoadMeta() error {
 			cp.globalPoint = newBinlogPoint(binlog.NewLocation(cp.cfg.Flavor), binlog.NewLocation(cp.cfg.Flavor), nil, nil, cp.cfg.EnableGTID)
 			return nil
 		}
+		// `binlog-name` is required without GTID enabled, while `binlog-gtid` can be adjusted from `binlog-name` with GTID enabled.
+		if !cp.cfg.EnableGTID && len(cp.cfg.Meta.BinLogName) == 0 {
+			return terror.ErrConfigMetaInvalid.Generate()
+		}
 		gset, err := gtid.ParserGTID(cp.cfg.Flavor, cp.cfg.Meta.BinLogGTID)
 		if err != nil {
-			return err
+			return terror.ErrConfigMetaInvalid.Delegate(err)
 		}
 
 		loc := binlog.InitLocation(
@@ -957,7 +848,7 @@ func (cp *RemoteCheckPoint) LoadMeta() error {
 		)
 		location = &loc
 	default:
-		// should not go here (syncer is only used in `all` or `incremental` mode)
+		// should not go here (syncer is only used in `all`, `load-sync` or `incremental` mode)
 		return terror.ErrCheckpointInvalidTaskMode.Generate(cp.cfg.Mode)
 	}
 
@@ -974,24 +865,8 @@ func (cp *RemoteCheckPoint) LoadMeta() error {
 	return nil
 }
 
-// genUpdateSQL generates SQL and arguments for update checkpoint.
-func (cp *RemoteCheckPoint) genUpdateSQL(cpSchema, cpTable string, location binlog.Location, safeModeExitLoc *binlog.Location, tiBytes []byte, isGlobal bool) (string, []interface{}) {
-	// use `INSERT INTO ... ON DUPLICATE KEY UPDATE` rather than `REPLACE INTO`
-	// to keep `create_time`, `update_time` correctly
-	sql2 := `INSERT INTO ` + cp.tableName + `
-		(id, cp_schema, cp_table, binlog_name, binlog_pos, binlog_gtid, exit_safe_binlog_name, exit_safe_binlog_pos, exit_safe_binlog_gtid, table_info, is_global) VALUES
-		(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON DUPLICATE KEY UPDATE
-			binlog_name = VALUES(binlog_name),
-			binlog_pos = VALUES(binlog_pos),
-			binlog_gtid = VALUES(binlog_gtid),
-			exit_safe_binlog_name = VALUES(exit_safe_binlog_name),
-			exit_safe_binlog_pos = VALUES(exit_safe_binlog_pos),
-			exit_safe_binlog_gtid = VALUES(exit_safe_binlog_gtid),
-			table_info = VALUES(table_info),
-			is_global = VALUES(is_global);
-	`
-
+// genCheckpointRow generates the row of checkpoint to flush.
+func (cp *RemoteCheckPoint) genCheckpointRow(cpSchema, cpTable string, location binlog.Location, safeModeExitLoc *binlog.Location, tiBytes []byte, isGlobal bool) *checkpointRow {
 	if isGlobal {
 		cpSchema = globalCpSchema
 		cpTable = globalCpTable
@@ -1012,12 +887,18 @@ func (cp *RemoteCheckPoint) genUpdateSQL(cpSchema, cpTable string, location binl
 		exitSafeGTIDStr = safeModeExitLoc.GTIDSetStr()
 	}
 
-	// convert tiBytes to string to get a readable log
-	args := []interface{}{
-		cp.id, cpSchema, cpTable, location.Position.Name, location.Position.Pos, location.GTIDSetStr(),
-		exitSafeName, exitSafePos, exitSafeGTIDStr, string(tiBytes), isGlobal,
+	return &checkpointRow{
+		CPSchema:           cpSchema,
+		CPTable:            cpTable,
+		BinlogName:         location.Position.Name,
+		BinlogPos:          location.Position.Pos,
+		BinlogGTID:         location.GTIDSetStr(),
+		ExitSafeBinlogName: exitSafeName,
+		ExitSafeBinlogPos:  exitSafePos,
+		ExitSafeBinlogGTID: exitSafeGTIDStr,
+		TableInfo:          tiBytes,
+		IsGlobal:           isGlobal,
 	}
-	return sql2, args
 }
 
 func (cp *RemoteCheckPoint) parseMetaData() (*binlog.Location, *binlog.Location, error) {
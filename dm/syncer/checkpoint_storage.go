@@ -0,0 +1,402 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	tmysql "github.com/pingcap/tidb/parser/mysql"
+	"go.etcd.io/etcd/clientv3"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/ticdc/dm/dm/common"
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/pkg/conn"
+	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
+	"github.com/pingcap/ticdc/dm/pkg/cputil"
+	"github.com/pingcap/ticdc/dm/pkg/etcdutil"
+	"github.com/pingcap/ticdc/dm/pkg/log"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+	"github.com/pingcap/ticdc/dm/syncer/dbconn"
+)
+
+// maxEtcdCheckpointOps is the max number of checkpoints put in one etcd txn,
+// which should be less than the `max-txn-ops` of the DM-master.
+const maxEtcdCheckpointOps = 1024
+
+// checkpointRow is a row of the checkpoints persisted by checkpointStorage,
+// the global checkpoint has an empty cp_schema and cp_table.
+type checkpointRow struct {
+	CPSchema           string          `json:"cp-schema"`
+	CPTable            string          `json:"cp-table"`
+	BinlogName         string          `json:"binlog-name"`
+	BinlogPos          uint32          `json:"binlog-pos"`
+	BinlogGTID         string          `json:"binlog-gtid"`
+	ExitSafeBinlogName string          `json:"exit-safe-binlog-name"`
+	ExitSafeBinlogPos  uint32          `json:"exit-safe-binlog-pos"`
+	ExitSafeBinlogGTID string          `json:"exit-safe-binlog-gtid"`
+	TableInfo          json.RawMessage `json:"table-info"`
+	IsGlobal           bool            `json:"is-global"`
+}
+
+// checkpointStorage persists the checkpoints of a syncer, which is selected by the
+// `checkpoint-storage` of the subtask config.
+type checkpointStorage interface {
+	// Init initializes the storage, e.g. creates the connections and the checkpoint table.
+	Init(tctx *tcontext.Context) error
+
+	// Close closes the storage.
+	Close()
+
+	// ResetConn resets the connection of the storage.
+	ResetConn(tctx *tcontext.Context) error
+
+	// Load loads all the checkpoints of the syncer.
+	Load(tctx *tcontext.Context) ([]*checkpointRow, error)
+
+	// Flush saves the checkpoints of the syncer, the extraSQLs are executed in the same transaction
+	// and can only be executed by the storage in the downstream.
+	Flush(tctx *tcontext.Context, rows []*checkpointRow, extraSQLs []string, extraArgs [][]interface{}) error
+
+	// Delete deletes the checkpoint of the table, all the checkpoints of the schema if cpTable is empty,
+	// or all the checkpoints of the syncer if cpSchema is empty too.
+	Delete(tctx *tcontext.Context, cpSchema, cpTable string) error
+}
+
+// newCheckpointStorage creates the checkpointStorage of the subtask.
+func newCheckpointStorage(tctx *tcontext.Context, cfg *config.SubTaskConfig, cli *clientv3.Client, id string) checkpointStorage {
+	switch cfg.CheckpointStorage {
+	case config.CheckpointStorageEtcd:
+		return &etcdCheckpointStorage{
+			cli:  cli,
+			task: cfg.Name,
+			id:   id,
+		}
+	case config.CheckpointStorageMySQL:
+		return newDBCheckpointStorage(tctx, cfg, cfg.CheckpointDB, id, false)
+	default:
+		return newDBCheckpointStorage(tctx, cfg, &cfg.To, id, true)
+	}
+}
+
+// dbCheckpointStorage stores the checkpoints in a table of the downstream or an external MySQL.
+type dbCheckpointStorage struct {
+	cfg        *config.SubTaskConfig
+	dbCfg      *config.DBConfig
+	downstream bool
+
+	db        *conn.BaseDB
+	dbConn    *dbconn.DBConn
+	tableName string // qualified table name: schema is set through task config, table is task name
+	id        string // checkpoint ID, now it is `source-id`
+
+	logCtx *tcontext.Context
+}
+
+func newDBCheckpointStorage(tctx *tcontext.Context, cfg *config.SubTaskConfig, dbCfg *config.DBConfig, id string, downstream bool) *dbCheckpointStorage {
+	return &dbCheckpointStorage{
+		cfg:        cfg,
+		dbCfg:      dbCfg,
+		downstream: downstream,
+		tableName:  dbutil.TableName(cfg.MetaSchema, cputil.SyncerCheckpoint(cfg.Name)),
+		id:         id,
+		logCtx:     tctx,
+	}
+}
+
+// Init implements checkpointStorage.Init.
+func (s *dbCheckpointStorage) Init(tctx *tcontext.Context) error {
+	checkPointDB := *s.dbCfg
+	checkPointDB.RawDBCfg = config.DefaultRawDBConfig().SetReadTimeout(maxCheckPointTimeout)
+	db, dbConns, err := dbconn.CreateConns(tctx, s.cfg, &checkPointDB, 1)
+	if err != nil {
+		return err
+	}
+	s.db = db
+	s.dbConn = dbConns[0]
+
+	return s.prepare(tctx)
+}
+
+// Close implements checkpointStorage.Close.
+func (s *dbCheckpointStorage) Close() {
+	dbconn.CloseBaseDB(s.logCtx, s.db)
+}
+
+// ResetConn implements checkpointStorage.ResetConn.
+func (s *dbCheckpointStorage) ResetConn(tctx *tcontext.Context) error {
+	return s.dbConn.ResetConn(tctx)
+}
+
+func (s *dbCheckpointStorage) prepare(tctx *tcontext.Context) error {
+	if err := s.createSchema(tctx); err != nil {
+		return err
+	}
+
+	return s.createTable(tctx)
+}
+
+func (s *dbCheckpointStorage) createSchema(tctx *tcontext.Context) error {
+	// TODO(lance6716): change ColumnName to IdentName or something
+	sql2 := fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", dbutil.ColumnName(s.cfg.MetaSchema))
+	args := make([]interface{}, 0)
+	_, err := s.dbConn.ExecuteSQL(tctx, []string{sql2}, [][]interface{}{args}...)
+	s.logCtx.L().Info("create checkpoint schema", zap.String("statement", sql2))
+	return err
+}
+
+func (s *dbCheckpointStorage) createTable(tctx *tcontext.Context) error {
+	sqls := []string{
+		`CREATE TABLE IF NOT EXISTS ` + s.tableName + ` (
+			id VARCHAR(32) NOT NULL,
+			cp_schema VARCHAR(128) NOT NULL,
+			cp_table VARCHAR(128) NOT NULL,
+			binlog_name VARCHAR(128),
+			binlog_pos INT UNSIGNED,
+			binlog_gtid TEXT,
+			exit_safe_binlog_name VARCHAR(128) DEFAULT '',
+			exit_safe_binlog_pos INT UNSIGNED DEFAULT 0,
+			exit_safe_binlog_gtid TEXT,
+			table_info JSON NOT NULL,
+			is_global BOOLEAN,
+			create_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			update_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+			UNIQUE KEY uk_id_schema_table (id, cp_schema, cp_table)
+		)`,
+	}
+	_, err := s.dbConn.ExecuteSQL(tctx, sqls)
+	s.logCtx.L().Info("create checkpoint table", zap.Strings("statements", sqls))
+	return err
+}
+
+// Load implements checkpointStorage.Load.
+func (s *dbCheckpointStorage) Load(tctx *tcontext.Context) ([]*checkpointRow, error) {
+	query := `SELECT cp_schema, cp_table, binlog_name, binlog_pos, binlog_gtid, exit_safe_binlog_name, exit_safe_binlog_pos, exit_safe_binlog_gtid, table_info, is_global FROM ` + s.tableName + ` WHERE id = ?`
+	rows, err := s.dbConn.QuerySQL(tctx, query, s.id)
+	defer func() {
+		if rows != nil {
+			rows.Close()
+		}
+	}()
+
+	failpoint.Inject("LoadCheckpointFailed", func(val failpoint.Value) {
+		err = tmysql.NewErr(uint16(val.(int)))
+		log.L().Warn("Load failed", zap.String("failpoint", "LoadCheckpointFailed"), zap.Error(err))
+	})
+
+	if err != nil {
+		return nil, terror.WithScope(err, terror.ScopeDownstream)
+	}
+
+	var (
+		cpRows                []*checkpointRow
+		binlogGTIDSet         sql.NullString
+		exitSafeBinlogGTIDSet sql.NullString
+		tiBytes               []byte
+	)
+	for rows.Next() {
+		row := &checkpointRow{}
+		err := rows.Scan(&row.CPSchema, &row.CPTable, &row.BinlogName, &row.BinlogPos, &binlogGTIDSet, &row.ExitSafeBinlogName, &row.ExitSafeBinlogPos, &exitSafeBinlogGTIDSet, &tiBytes, &row.IsGlobal)
+		if err != nil {
+			return nil, terror.WithScope(terror.DBErrorAdapt(err, terror.ErrDBDriverError), terror.ScopeDownstream)
+		}
+		// gtid set default is "", but upgrade may cause NULL value
+		row.BinlogGTID = binlogGTIDSet.String
+		row.ExitSafeBinlogGTID = exitSafeBinlogGTIDSet.String
+		row.TableInfo = append(json.RawMessage(nil), tiBytes...)
+		cpRows = append(cpRows, row)
+	}
+
+	return cpRows, terror.WithScope(terror.DBErrorAdapt(rows.Err(), terror.ErrDBDriverError), terror.ScopeDownstream)
+}
+
+// Flush implements checkpointStorage.Flush.
+func (s *dbCheckpointStorage) Flush(tctx *tcontext.Context, rows []*checkpointRow, extraSQLs []string, extraArgs [][]interface{}) error {
+	if len(extraSQLs) > 0 && !s.downstream {
+		return terror.ErrConfigInvalidCheckpointStorage.Generate(s.cfg.CheckpointStorage, "the SQLs to the downstream can't be flushed with the checkpoints")
+	}
+
+	sqls := make([]string, 0, len(rows)+len(extraSQLs))
+	args := make([][]interface{}, 0, len(rows)+len(extraSQLs))
+	for _, row := range rows {
+		sql2, arg := s.genUpdateSQL(row)
+		sqls = append(sqls, sql2)
+		args = append(args, arg)
+	}
+	for i := range extraSQLs {
+		sqls = append(sqls, extraSQLs[i])
+		args = append(args, extraArgs[i])
+	}
+
+	_, err := s.dbConn.ExecuteSQL(tctx, sqls, args...)
+	return err
+}
+
+// Delete implements checkpointStorage.Delete.
+func (s *dbCheckpointStorage) Delete(tctx *tcontext.Context, cpSchema, cpTable string) error {
+	var (
+		sql2 string
+		args []interface{}
+	)
+	switch {
+	case cpSchema == "":
+		sql2 = `DELETE FROM ` + s.tableName + ` WHERE id = ?`
+		args = []interface{}{s.id}
+	case cpTable == "":
+		sql2 = `DELETE FROM ` + s.tableName + ` WHERE id = ? AND cp_schema = ?`
+		args = []interface{}{s.id, cpSchema}
+	default:
+		sql2 = `DELETE FROM ` + s.tableName + ` WHERE id = ? AND cp_schema = ? AND cp_table = ?`
+		args = []interface{}{s.id, cpSchema, cpTable}
+	}
+	_, err := s.dbConn.ExecuteSQL(tctx, []string{sql2}, args)
+	return err
+}
+
+// genUpdateSQL generates SQL and arguments for update checkpoint.
+func (s *dbCheckpointStorage) genUpdateSQL(row *checkpointRow) (string, []interface{}) {
+	// use `INSERT INTO ... ON DUPLICATE KEY UPDATE` rather than `REPLACE INTO`
+	// to keep `create_time`, `update_time` correctly
+	sql2 := `INSERT INTO ` + s.tableName + `
+		(id, cp_schema, cp_table, binlog_name, binlog_pos, binlog_gtid, exit_safe_binlog_name, exit_safe_binlog_pos, exit_safe_binlog_gtid, table_info, is_global) VALUES
+		(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			binlog_name = VALUES(binlog_name),
+			binlog_pos = VALUES(binlog_pos),
+			binlog_gtid = VALUES(binlog_gtid),
+			exit_safe_binlog_name = VALUES(exit_safe_binlog_name),
+			exit_safe_binlog_pos = VALUES(exit_safe_binlog_pos),
+			exit_safe_binlog_gtid = VALUES(exit_safe_binlog_gtid),
+			table_info = VALUES(table_info),
+			is_global = VALUES(is_global);
+	`
+
+	// convert tiBytes to string to get a readable log
+	args := []interface{}{
+		s.id, row.CPSchema, row.CPTable, row.BinlogName, row.BinlogPos, row.BinlogGTID,
+		row.ExitSafeBinlogName, row.ExitSafeBinlogPos, row.ExitSafeBinlogGTID, string(row.TableInfo), row.IsGlobal,
+	}
+	return sql2, args
+}
+
+// etcdCheckpointStorage stores the checkpoints in the etcd of the DM cluster.
+type etcdCheckpointStorage struct {
+	cli  *clientv3.Client
+	task string
+	id   string
+}
+
+// Init implements checkpointStorage.Init.
+func (s *etcdCheckpointStorage) Init(tctx *tcontext.Context) error {
+	if s.cli == nil {
+		return terror.ErrConfigInvalidCheckpointStorage.Generate(config.CheckpointStorageEtcd, "the etcd client is not available")
+	}
+	return nil
+}
+
+// Close implements checkpointStorage.Close.
+func (s *etcdCheckpointStorage) Close() {}
+
+// ResetConn implements checkpointStorage.ResetConn.
+func (s *etcdCheckpointStorage) ResetConn(tctx *tcontext.Context) error {
+	return nil
+}
+
+// key returns the etcd key of the checkpoint. NOTE: the global checkpoint is not under the prefix of
+// the table checkpoints, because the empty keys are skipped when encoding.
+func (s *etcdCheckpointStorage) key(cpSchema, cpTable string) string {
+	return common.SyncerCheckpointKeyAdapter.Encode(s.task, s.id, cpSchema, cpTable)
+}
+
+// Load implements checkpointStorage.Load.
+func (s *etcdCheckpointStorage) Load(tctx *tcontext.Context) ([]*checkpointRow, error) {
+	resp, _, err := etcdutil.DoOpsInOneTxnWithRetry(s.cli,
+		clientv3.OpGet(s.key(globalCpSchema, globalCpTable)),
+		clientv3.OpGet(common.SyncerCheckpointKeyAdapter.Encode(s.task, s.id), clientv3.WithPrefix()))
+	if err != nil {
+		return nil, err
+	}
+
+	var cpRows []*checkpointRow
+	for _, opResp := range resp.Responses {
+		for _, kv := range opResp.GetResponseRange().Kvs {
+			row := &checkpointRow{}
+			if err = json.Unmarshal(kv.Value, row); err != nil {
+				return nil, terror.ErrSchemaTrackerInvalidJSON.Delegate(err, row.CPSchema, row.CPTable)
+			}
+			cpRows = append(cpRows, row)
+		}
+	}
+	return cpRows, nil
+}
+
+// Flush implements checkpointStorage.Flush.
+// the checkpoints are put in batches and the global checkpoint is put in the last batch.
+func (s *etcdCheckpointStorage) Flush(tctx *tcontext.Context, rows []*checkpointRow, extraSQLs []string, extraArgs [][]interface{}) error {
+	if len(extraSQLs) > 0 {
+		return terror.ErrConfigInvalidCheckpointStorage.Generate(config.CheckpointStorageEtcd, "the SQLs to the downstream can't be flushed with the checkpoints")
+	}
+
+	var globalOp *clientv3.Op
+	ops := make([]clientv3.Op, 0, len(rows))
+	for _, row := range rows {
+		value, err := json.Marshal(row)
+		if err != nil {
+			return terror.ErrSchemaTrackerCannotSerialize.Delegate(err, row.CPSchema, row.CPTable)
+		}
+		op := clientv3.OpPut(s.key(row.CPSchema, row.CPTable), string(value))
+		if row.IsGlobal {
+			globalOp = &op
+			continue
+		}
+		ops = append(ops, op)
+	}
+	if globalOp != nil {
+		ops = append(ops, *globalOp)
+	}
+
+	for len(ops) > 0 {
+		batch := ops
+		if len(batch) > maxEtcdCheckpointOps {
+			batch = ops[:maxEtcdCheckpointOps]
+		}
+		if _, _, err := etcdutil.DoOpsInOneTxnWithRetry(s.cli, batch...); err != nil {
+			return err
+		}
+		ops = ops[len(batch):]
+	}
+	return nil
+}
+
+// Delete implements checkpointStorage.Delete.
+func (s *etcdCheckpointStorage) Delete(tctx *tcontext.Context, cpSchema, cpTable string) error {
+	var ops []clientv3.Op
+	switch {
+	case cpSchema == "":
+		ops = []clientv3.Op{
+			clientv3.OpDelete(s.key(globalCpSchema, globalCpTable)),
+			clientv3.OpDelete(common.SyncerCheckpointKeyAdapter.Encode(s.task, s.id), clientv3.WithPrefix()),
+		}
+	case cpTable == "":
+		ops = []clientv3.Op{clientv3.OpDelete(common.SyncerCheckpointKeyAdapter.Encode(s.task, s.id, cpSchema), clientv3.WithPrefix())}
+	default:
+		ops = []clientv3.Op{clientv3.OpDelete(s.key(cpSchema, cpTable))}
+	}
+	_, _, err := etcdutil.DoOpsInOneTxnWithRetry(s.cli, ops...)
+	return err
+}
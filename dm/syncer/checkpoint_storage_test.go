@@ -0,0 +1,91 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"github.com/go-mysql-org/go-mysql/mysql"
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"go.etcd.io/etcd/integration"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/pkg/binlog"
+	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+)
+
+var _ = Suite(&testCheckpointStorageSuite{})
+
+type testCheckpointStorageSuite struct{}
+
+func (s *testCheckpointStorageSuite) TestEtcdCheckpointStorage(c *C) {
+	cluster := integration.NewClusterV3(tt, &integration.ClusterConfig{Size: 1})
+	defer cluster.Terminate(tt)
+
+	var (
+		tctx = tcontext.Background()
+		cli  = cluster.RandClient()
+		cfg  = &config.SubTaskConfig{
+			Name:   "test-etcd-checkpoint",
+			Flavor: mysql.MySQLFlavor,
+			SyncerConfig: config.SyncerConfig{
+				CheckpointStorage: config.CheckpointStorageEtcd,
+			},
+		}
+		pos1  = mysql.Position{Name: "mysql-bin.000001", Pos: 1234}
+		pos2  = mysql.Position{Name: "mysql-bin.000002", Pos: 4}
+		table = &filter.Table{Schema: "db", Name: "tbl"}
+	)
+
+	// the etcd client is required
+	cp := NewRemoteCheckPoint(tctx, cfg, nil, cpid)
+	c.Assert(terror.ErrConfigInvalidCheckpointStorage.Equal(cp.Init(tctx)), IsTrue)
+
+	cp = NewRemoteCheckPoint(tctx, cfg, cli, cpid)
+	c.Assert(cp.Init(tctx), IsNil)
+	defer cp.Close()
+	_, ok := cp.(*RemoteCheckPoint).storage.(*etcdCheckpointStorage)
+	c.Assert(ok, IsTrue)
+
+	cp.SaveGlobalPoint(binlog.Location{Position: pos1})
+	cp.SaveTablePoint(table, binlog.Location{Position: pos2}, nil)
+	c.Assert(cp.FlushPointsExcept(tctx, nil, nil, nil), IsNil)
+	// the SQLs of the downstream can't be flushed with the checkpoints in etcd
+	c.Assert(terror.ErrConfigInvalidCheckpointStorage.Equal(cp.FlushPointsExcept(tctx, nil, []string{"DELETE FROM t"}, [][]interface{}{nil})), IsTrue)
+
+	// another checkpoint ID of the same task is not affected
+	cp2 := NewRemoteCheckPoint(tctx, cfg, cli, cpid+"2")
+	c.Assert(cp2.Init(tctx), IsNil)
+	c.Assert(cp2.Load(tctx), IsNil)
+	c.Assert(cp2.GlobalPoint().Position, Equals, binlog.MinPosition)
+
+	// load the checkpoints flushed
+	cp2 = NewRemoteCheckPoint(tctx, cfg, cli, cpid)
+	c.Assert(cp2.Load(tctx), IsNil)
+	c.Assert(cp2.GlobalPoint().Position, Equals, pos1)
+	c.Assert(cp2.IsOlderThanTablePoint(table, binlog.Location{Position: pos2}, true), IsTrue)
+
+	// delete the table checkpoint
+	c.Assert(cp.DeleteTablePoint(tctx, table), IsNil)
+	cp2 = NewRemoteCheckPoint(tctx, cfg, cli, cpid)
+	c.Assert(cp2.Load(tctx), IsNil)
+	c.Assert(cp2.GlobalPoint().Position, Equals, pos1)
+	c.Assert(cp2.IsOlderThanTablePoint(table, binlog.Location{Position: pos2}, true), IsFalse)
+
+	// clear all the checkpoints
+	c.Assert(cp.Clear(tctx), IsNil)
+	cp2 = NewRemoteCheckPoint(tctx, cfg, cli, cpid)
+	c.Assert(cp2.Load(tctx), IsNil)
+	c.Assert(cp2.GlobalPoint().Position, Equals, binlog.MinPosition)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/ticdc/dm/pkg/gtid"
 	"github.com/pingcap/ticdc/dm/pkg/retry"
 	"github.com/pingcap/ticdc/dm/pkg/schema"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 	"github.com/pingcap/ticdc/dm/syncer/dbconn"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -100,7 +101,7 @@ func (s *testCheckpointSuite) prepareCheckPointSQL() {
 func (s *testCheckpointSuite) TestCheckPoint(c *C) {
 	tctx := tcontext.Background()
 
-	cp := NewRemoteCheckPoint(tctx, s.cfg, cpid)
+	cp := NewRemoteCheckPoint(tctx, s.cfg, nil, cpid)
 	defer func() {
 		s.mock.ExpectClose()
 		cp.Close()
@@ -127,8 +128,8 @@ func (s *testCheckpointSuite) TestCheckPoint(c *C) {
 	c.Assert(err, IsNil)
 	conn := &dbconn.DBConn{Cfg: s.cfg, BaseConn: conn.NewBaseConn(dbConn, &retry.FiniteRetryStrategy{})}
 
-	cp.(*RemoteCheckPoint).dbConn = conn
-	err = cp.(*RemoteCheckPoint).prepare(tctx)
+	cp.(*RemoteCheckPoint).storage.(*dbCheckpointStorage).dbConn = conn
+	err = cp.(*RemoteCheckPoint).storage.(*dbCheckpointStorage).prepare(tctx)
 	c.Assert(err, IsNil)
 	c.Assert(cp.Clear(tctx), IsNil)
 
@@ -188,6 +189,20 @@ func (s *testCheckpointSuite) testGlobalCheckPoint(c *C, cp CheckPoint) {
 	c.Assert(cp.GlobalPoint().Position, Equals, pos1)
 	c.Assert(cp.FlushedGlobalPoint().Position, Equals, pos1)
 
+	// only `binlog-gtid` requires GTID enabled for the source
+	gtidStr := "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14"
+	s.cfg.Meta = &config.Meta{BinLogGTID: gtidStr}
+	c.Assert(terror.ErrConfigMetaInvalid.Equal(cp.LoadMeta()), IsTrue)
+	s.cfg.EnableGTID = true
+	c.Assert(cp.LoadMeta(), IsNil)
+	c.Assert(cp.GlobalPoint().GTIDSetStr(), Equals, gtidStr)
+	// a MariaDB GTID set is invalid for a MySQL source
+	s.cfg.Meta = &config.Meta{BinLogGTID: "1-1-12,4-4-4"}
+	c.Assert(terror.ErrConfigMetaInvalid.Equal(cp.LoadMeta()), IsTrue)
+	s.cfg.EnableGTID = false
+	s.cfg.Meta = &config.Meta{BinLogName: pos1.Name, BinLogPos: pos1.Pos}
+	c.Assert(cp.LoadMeta(), IsNil)
+
 	s.cfg.Mode = oldMode
 	s.cfg.Meta = nil
 
@@ -308,6 +323,8 @@ SHOW MASTER STATUS: /* AFTER CONNECTION POOL ESTABLISHED */
 	GTID:
 `, pos1.Name, pos1.Pos, "slave_host", pos1.Name, pos1.Pos+1000, pos2.Name, pos2.Pos)), 0o644)
 	c.Assert(err, IsNil)
+	// the existing dump of the load-sync mode is loaded in the same way
+	s.cfg.Mode = config.ModeLoadSync
 	c.Assert(cp.LoadMeta(), IsNil)
 
 	// should flush because globalPointSaveTime is zero
@@ -0,0 +1,143 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+	"github.com/pingcap/ticdc/dm/pkg/utils"
+)
+
+// columnTransform is a column transform whose expression is generated on the table structure.
+type columnTransform struct {
+	offset int // offset of the column in the row
+	expr   expression.Expression
+}
+
+// ColumnTransformGroup groups the column transforms of the tables.
+type ColumnTransformGroup struct {
+	configs    map[string][]*config.ColumnTransform // tableName -> raw config
+	transforms map[string][]columnTransform         // tableName -> transforms
+
+	ctx sessionctx.Context
+}
+
+// NewColumnTransformGroup creates a ColumnTransformGroup.
+func NewColumnTransformGroup(ctx sessionctx.Context, transformConfig []*config.ColumnTransform) *ColumnTransformGroup {
+	ret := &ColumnTransformGroup{
+		configs:    map[string][]*config.ColumnTransform{},
+		transforms: map[string][]columnTransform{},
+		ctx:        ctx,
+	}
+	for _, c := range transformConfig {
+		tableName := dbutil.TableName(c.Schema, c.Table)
+		ret.configs[tableName] = append(ret.configs[tableName], c)
+	}
+	return ret
+}
+
+// getTransforms returns the column transforms for given table.
+// This function will lazy calculate expressions if not initialized.
+func (g *ColumnTransformGroup) getTransforms(table *filter.Table, ti *model.TableInfo) ([]columnTransform, error) {
+	tableID := utils.GenTableID(table)
+
+	if ret, ok := g.transforms[tableID]; ok {
+		return ret, nil
+	}
+	configs, ok := g.configs[tableID]
+	if !ok {
+		return nil, nil
+	}
+
+	ret := make([]columnTransform, 0, len(configs))
+	for _, c := range configs {
+		col := model.FindColumnInfo(ti.Columns, c.Column)
+		if col == nil {
+			return nil, terror.ErrSyncerGenColumnTransform.Generate(c.Expr, c.Column, tableID, "column not found")
+		}
+		expr, err := expression.ParseSimpleExprWithTableInfo(g.ctx, c.Expr, ti)
+		if err != nil {
+			return nil, terror.ErrSyncerGenColumnTransform.Generate(c.Expr, c.Column, tableID, err.Error())
+		}
+		ret = append(ret, columnTransform{offset: col.Offset, expr: expr})
+	}
+	g.transforms[tableID] = ret
+	return ret, nil
+}
+
+// TransformRows replaces the values of the columns of the rows by the results of the column transforms.
+// the expressions are evaluated on the original row, so the transforms of a row don't affect each other.
+func (g *ColumnTransformGroup) TransformRows(table *filter.Table, ti *model.TableInfo, rows [][]interface{}) ([][]interface{}, error) {
+	transforms, err := g.getTransforms(table, ti)
+	if err != nil || len(transforms) == 0 {
+		return rows, err
+	}
+
+	ret := make([][]interface{}, 0, len(rows))
+	for _, row := range rows {
+		data, err := utils.AdjustBinaryProtocolForDatum(g.ctx, row, ti.Columns)
+		if err != nil {
+			return nil, terror.ErrSyncerUnitDoColumnMapping.Delegate(err, row, table)
+		}
+		r := chunk.MutRowFromDatums(data).ToRow()
+
+		newRow := append([]interface{}(nil), row...)
+		for _, t := range transforms {
+			if t.offset >= len(newRow) {
+				continue
+			}
+			d, err := t.expr.Eval(r)
+			if err != nil {
+				return nil, terror.ErrSyncerUnitDoColumnMapping.Delegate(err, row, table)
+			}
+			newRow[t.offset], err = datumToValue(d)
+			if err != nil {
+				return nil, terror.ErrSyncerUnitDoColumnMapping.Delegate(err, row, table)
+			}
+		}
+		ret = append(ret, newRow)
+	}
+	return ret, nil
+}
+
+// ResetTransforms deletes the expressions generated before. This should be called after table structure changed.
+func (g *ColumnTransformGroup) ResetTransforms(table *filter.Table) {
+	delete(g.transforms, utils.GenTableID(table))
+}
+
+// datumToValue converts the result of an expression to a value used as the argument of the DML.
+func datumToValue(d types.Datum) (interface{}, error) {
+	switch d.Kind() {
+	case types.KindNull:
+		return nil, nil
+	case types.KindInt64:
+		return d.GetInt64(), nil
+	case types.KindUint64:
+		return d.GetUint64(), nil
+	case types.KindFloat32, types.KindFloat64:
+		return d.GetFloat64(), nil
+	case types.KindBytes:
+		return d.GetBytes(), nil
+	default:
+		return d.ToString()
+	}
+}
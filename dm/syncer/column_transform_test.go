@@ -0,0 +1,90 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/pkg/schema"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
+	"github.com/pingcap/ticdc/dm/pkg/utils"
+	"github.com/pingcap/ticdc/dm/syncer/dbconn"
+)
+
+func (s *testFilterSuite) TestColumnTransform(c *C) {
+	var (
+		ctx     = context.Background()
+		dbName  = "test"
+		tblName = "t"
+		table   = &filter.Table{Schema: dbName, Name: tblName}
+		other   = &filter.Table{Schema: dbName, Name: "t2"}
+	)
+	dbConn := &dbconn.DBConn{Cfg: &config.SubTaskConfig{}, BaseConn: s.baseConn}
+	schemaTracker, err := schema.NewTracker(ctx, "unit-test", defaultTestSessionCfg, dbConn)
+	c.Assert(err, IsNil)
+	defer func() {
+		c.Assert(schemaTracker.Close(), IsNil)
+	}()
+	c.Assert(schemaTracker.CreateSchemaIfNotExists(dbName), IsNil)
+	c.Assert(schemaTracker.Exec(ctx, dbName, `
+create table t (
+	id bigint NOT NULL,
+	first_name varchar(20),
+	last_name varchar(20),
+	code varchar(20),
+	created_at datetime,
+	PRIMARY KEY (id)
+);`), IsNil)
+	ti, err := schemaTracker.GetTableInfo(table)
+	c.Assert(err, IsNil)
+
+	transforms := []*config.ColumnTransform{
+		{Schema: dbName, Table: tblName, Column: "id", Expr: "id | (3 << 48)"},
+		{Schema: dbName, Table: tblName, Column: "first_name", Expr: "CONCAT(first_name, ' ', last_name)"},
+		{Schema: dbName, Table: tblName, Column: "code", Expr: "SUBSTRING(code, 1, 4)"},
+		{Schema: dbName, Table: tblName, Column: "created_at", Expr: "CONVERT_TZ(created_at, '+08:00', '+00:00')"},
+	}
+	sessCtx := utils.NewSessionCtx(map[string]string{"time_zone": "UTC"})
+	g := NewColumnTransformGroup(sessCtx, transforms)
+
+	rows := [][]interface{}{
+		{int64(1), "John", "Smith", "ABCD-1234", "2021-11-18 08:00:00"},
+		{int64(2), nil, "Doe", nil, nil},
+	}
+	transformed, err := g.TransformRows(table, ti, rows)
+	c.Assert(err, IsNil)
+	c.Assert(transformed, DeepEquals, [][]interface{}{
+		{uint64(3<<48 | 1), "John Smith", "Smith", "ABCD", "2021-11-18 00:00:00.000000"},
+		{uint64(3<<48 | 2), nil, "Doe", nil, nil},
+	})
+	// the rows are not modified
+	c.Assert(rows[0][1], Equals, "John")
+
+	// rows of other tables are not transformed
+	transformed, err = g.TransformRows(other, ti, rows)
+	c.Assert(err, IsNil)
+	c.Assert(transformed, DeepEquals, rows)
+
+	// the transforms are regenerated after the table structure changed
+	c.Assert(schemaTracker.Exec(ctx, dbName, "alter table t drop column code"), IsNil)
+	ti, err = schemaTracker.GetTableInfo(table)
+	c.Assert(err, IsNil)
+	g.ResetTransforms(table)
+	_, err = g.TransformRows(table, ti, rows)
+	c.Assert(terror.ErrSyncerGenColumnTransform.Equal(err), IsTrue)
+}
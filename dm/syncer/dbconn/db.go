@@ -58,6 +58,8 @@ func CloseBaseDB(logCtx *tcontext.Context, baseDB *conn.BaseDB) {
 type DBConn struct {
 	Cfg      *config.SubTaskConfig
 	BaseConn *conn.BaseConn
+	// the prepared statements of BaseConn used by ExecuteStmts, nil if not enabled.
+	StmtCache *conn.StmtCache
 
 	// generate new BaseConn and close old one
 	ResetBaseConnFn func(*tcontext.Context, *conn.BaseConn) (*conn.BaseConn, error)
@@ -65,6 +67,10 @@ type DBConn struct {
 
 // ResetConn reset one worker connection from specify *BaseDB.
 func (conn *DBConn) ResetConn(tctx *tcontext.Context) error {
+	if conn.StmtCache != nil {
+		// the statements are prepared on the old connection
+		conn.StmtCache.Clear(tctx)
+	}
 	baseConn, err := conn.ResetBaseConnFn(tctx, conn.BaseConn)
 	if err != nil {
 		return err
@@ -160,6 +166,43 @@ func (conn *DBConn) ExecuteSQLWithIgnore(tctx *tcontext.Context, ignoreError fun
 		return 0, terror.ErrDBUnExpect.Generate("database base connection not valid")
 	}
 
+	return conn.executeWithRetry(tctx, queries, args, func(ctx *tcontext.Context) (int, error) {
+		return conn.BaseConn.ExecuteSQLWithIgnoreError(ctx, metrics.StmtHistogram, conn.Cfg.Name, ignoreError, queries, args...)
+	})
+}
+
+// ExecuteSQL does some SQL executions.
+func (conn *DBConn) ExecuteSQL(tctx *tcontext.Context, queries []string, args ...[]interface{}) (int, error) {
+	return conn.ExecuteSQLWithIgnore(tctx, nil, queries, args...)
+}
+
+// ExecuteStmts does some SQL executions by the prepared statements cached in StmtCache,
+// tables are the tables of the queries with their schema versions.
+// it's same as ExecuteSQL if StmtCache is not enabled.
+func (conn *DBConn) ExecuteStmts(tctx *tcontext.Context, tables []conn.StmtTable, queries []string, args ...[]interface{}) (int, error) {
+	if conn == nil || conn.StmtCache == nil {
+		return conn.ExecuteSQL(tctx, queries, args...)
+	}
+	if len(queries) == 0 {
+		return 0, nil
+	}
+	if conn.BaseConn == nil {
+		return 0, terror.ErrDBUnExpect.Generate("database base connection not valid")
+	}
+
+	return conn.executeWithRetry(tctx, queries, args, func(ctx *tcontext.Context) (int, error) {
+		stmts, hit, err := conn.StmtCache.Prepare(ctx, conn.BaseConn, tables, queries)
+		metrics.StmtCacheCounter.WithLabelValues("hit", conn.Cfg.Name, conn.Cfg.SourceID).Add(float64(hit))
+		metrics.StmtCacheCounter.WithLabelValues("prepare", conn.Cfg.Name, conn.Cfg.SourceID).Add(float64(len(stmts) - hit))
+		if err != nil {
+			return 0, err
+		}
+		return conn.BaseConn.ExecuteStmtsWithIgnoreError(ctx, metrics.StmtHistogram, conn.Cfg.Name, nil, stmts, queries, args...)
+	})
+}
+
+// executeWithRetry executes the queries by executeFn, and retries on the connection errors and retryable errors.
+func (conn *DBConn) executeWithRetry(tctx *tcontext.Context, queries []string, args [][]interface{}, executeFn func(*tcontext.Context) (int, error)) (int, error) {
 	// nolint:dupl
 	params := retry.Params{
 		RetryCount:         100,
@@ -199,7 +242,7 @@ func (conn *DBConn) ExecuteSQLWithIgnore(tctx *tcontext.Context, ignoreError fun
 		params,
 		func(ctx *tcontext.Context) (interface{}, error) {
 			startTime := time.Now()
-			ret, err := conn.BaseConn.ExecuteSQLWithIgnoreError(ctx, metrics.StmtHistogram, conn.Cfg.Name, ignoreError, queries, args...)
+			ret, err := executeFn(ctx)
 			if err == nil {
 				cost := time.Since(startTime)
 				// duration seconds
@@ -227,11 +270,6 @@ func (conn *DBConn) ExecuteSQLWithIgnore(tctx *tcontext.Context, ignoreError fun
 	return ret.(int), nil
 }
 
-// ExecuteSQL does some SQL executions.
-func (conn *DBConn) ExecuteSQL(tctx *tcontext.Context, queries []string, args ...[]interface{}) (int, error) {
-	return conn.ExecuteSQLWithIgnore(tctx, nil, queries, args...)
-}
-
 // CreateConns returns a opened DB from dbCfg and number of `count` connections of that DB.
 func CreateConns(tctx *tcontext.Context, cfg *config.SubTaskConfig, dbCfg *config.DBConfig, count int) (*conn.BaseDB, []*DBConn, error) {
 	conns := make([]*DBConn, 0, count)
@@ -30,6 +30,7 @@ import (
 	ttypes "github.com/pingcap/tidb/types"
 	"go.uber.org/zap"
 
+	"github.com/pingcap/ticdc/dm/pkg/conn"
 	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/schema"
@@ -746,6 +747,19 @@ func (dml *DML) genWhere(buf *strings.Builder) []interface{} {
 	return whereValues
 }
 
+// stmtTable returns the source table of the DML with the version of its schema in the schema tracker,
+// which is changed by the DDLs of the table.
+func (dml *DML) stmtTable() conn.StmtTable {
+	table := conn.StmtTable{Name: dml.targetTableID}
+	if dml.sourceTable != nil {
+		table.Name = dml.sourceTable.String()
+	}
+	if dml.sourceTableInfo != nil {
+		table.Version = dml.sourceTableInfo.UpdateTS
+	}
+	return table
+}
+
 // genSQL generates SQL for a DML.
 func (dml *DML) genSQL() (sql []string, arg [][]interface{}) {
 	switch dml.op {
@@ -1022,7 +1036,8 @@ func genDMLsWithSameCols(op dmlOpType, dmls []*DML) ([]string, [][]interface{})
 
 // genDMLsWithSameTable groups and generates dmls with same table.
 // all the dmls should have same dmlOpType.
-func genDMLsWithSameTable(op dmlOpType, dmls []*DML) ([]string, [][]interface{}) {
+// if batchUpdate is true, the updates of identify values are generated as multiple rows if possible.
+func genDMLsWithSameTable(op dmlOpType, dmls []*DML, batchUpdate bool) ([]string, [][]interface{}) {
 	queries := make([]string, 0, len(dmls))
 	args := make([][]interface{}, 0, len(dmls))
 	var lastTable string
@@ -1030,6 +1045,9 @@ func genDMLsWithSameTable(op dmlOpType, dmls []*DML) ([]string, [][]interface{})
 
 	// for updateDML, generate SQLs one by one
 	if op == updateDML {
+		if batchUpdate && len(dmls) > 1 && !updateIdentifyChained(dmls) {
+			return genUpdateIdentifySQLMultipleRows(dmls)
+		}
 		for _, dml := range dmls {
 			query, arg := dml.genUpdateSQL()
 			queries = append(queries, query...)
@@ -1061,9 +1079,47 @@ func genDMLsWithSameTable(op dmlOpType, dmls []*DML) ([]string, [][]interface{})
 	return queries, args
 }
 
+// updateIdentifyChained checks whether the identify values updated by some dmls are the
+// old or new identify values of other dmls, e.g. updating a=1 to a=2 and then a=2 to a=3.
+func updateIdentifyChained(dmls []*DML) bool {
+	keys := make(map[string]struct{}, len(dmls)*2)
+	for _, dml := range dmls {
+		oldKey := dml.targetTableID + "." + genKey(dml.oldIdentifyValues())
+		newKey := dml.targetTableID + "." + dml.identifyKey()
+		for _, key := range []string{oldKey, newKey} {
+			if _, ok := keys[key]; ok {
+				return true
+			}
+			keys[key] = struct{}{}
+		}
+	}
+	return false
+}
+
+// genUpdateIdentifySQLMultipleRows generates the update dmls which update their identify values
+// as a multiple rows `DELETE` of the old values and a multiple rows `INSERT` of the new values,
+// the dmls should not be chained, which is checked by updateIdentifyChained.
+func genUpdateIdentifySQLMultipleRows(dmls []*DML) ([]string, [][]interface{}) {
+	delDMLs := make([]*DML, 0, len(dmls))
+	insertDMLs := make([]*DML, 0, len(dmls))
+	insertOp := insertDML
+	for _, dml := range dmls {
+		delRow, insertRow := updateToDelAndInsert(dml)
+		delDMLs = append(delDMLs, delRow)
+		insertDMLs = append(insertDMLs, insertRow)
+		if dml.safeMode {
+			insertOp = insertOnDuplicateDML
+		}
+	}
+
+	queries, args := genDMLsWithSameTable(deleteDML, delDMLs, false)
+	insertQueries, insertArgs := genDMLsWithSameTable(insertOp, insertDMLs, false)
+	return append(queries, insertQueries...), append(args, insertArgs...)
+}
+
 // genDMLsWithSameOp groups and generates dmls by dmlOpType.
 // TODO: implement a volcano iterator interface for genDMLsWithSameXXX.
-func genDMLsWithSameOp(dmls []*DML) ([]string, [][]interface{}) {
+func genDMLsWithSameOp(dmls []*DML, batchUpdate bool) ([]string, [][]interface{}) {
 	queries := make([]string, 0, len(dmls))
 	args := make([][]interface{}, 0, len(dmls))
 	var lastOp dmlOpType
@@ -1084,7 +1140,7 @@ func genDMLsWithSameOp(dmls []*DML) ([]string, [][]interface{}) {
 
 		// now there are 4 situations: [insert, insert on duplicate(insert with safemode/update without identify keys), update(update identify keys), delete]
 		if lastOp != curOp {
-			query, arg := genDMLsWithSameTable(lastOp, groupDMLs)
+			query, arg := genDMLsWithSameTable(lastOp, groupDMLs, batchUpdate)
 			queries = append(queries, query...)
 			args = append(args, arg...)
 
@@ -1094,7 +1150,7 @@ func genDMLsWithSameOp(dmls []*DML) ([]string, [][]interface{}) {
 		groupDMLs = append(groupDMLs, dml)
 	}
 	if len(groupDMLs) > 0 {
-		query, arg := genDMLsWithSameTable(lastOp, groupDMLs)
+		query, arg := genDMLsWithSameTable(lastOp, groupDMLs, batchUpdate)
 		queries = append(queries, query...)
 		args = append(args, arg...)
 	}
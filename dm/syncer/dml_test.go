@@ -487,12 +487,8 @@ func (s *testSyncerSuite) TestGenDMLWithSameOp(c *C) {
 	expectQueries := []string{
 		// table1
 		"INSERT INTO `db1`.`tb1` (`id`,`col1`,`name`) VALUES (?,?,?),(?,?,?),(?,?,?),(?,?,?),(?,?,?),(?,?,?),(?,?,?),(?,?,?),(?,?,?) ON DUPLICATE KEY UPDATE `id`=VALUES(`id`),`col1`=VALUES(`col1`),`name`=VALUES(`name`)",
-		"DELETE FROM `db1`.`tb1` WHERE `id` = ? LIMIT 1",
-		"INSERT INTO `db1`.`tb1` (`id`,`col1`,`name`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `id`=VALUES(`id`),`col1`=VALUES(`col1`),`name`=VALUES(`name`)",
-		"DELETE FROM `db1`.`tb1` WHERE `id` = ? LIMIT 1",
-		"INSERT INTO `db1`.`tb1` (`id`,`col1`,`name`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `id`=VALUES(`id`),`col1`=VALUES(`col1`),`name`=VALUES(`name`)",
-		"DELETE FROM `db1`.`tb1` WHERE `id` = ? LIMIT 1",
-		"INSERT INTO `db1`.`tb1` (`id`,`col1`,`name`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `id`=VALUES(`id`),`col1`=VALUES(`col1`),`name`=VALUES(`name`)",
+		"DELETE FROM `db1`.`tb1` WHERE (`id`) IN ((?),(?),(?))",
+		"INSERT INTO `db1`.`tb1` (`id`,`col1`,`name`) VALUES (?,?,?),(?,?,?),(?,?,?) ON DUPLICATE KEY UPDATE `id`=VALUES(`id`),`col1`=VALUES(`col1`),`name`=VALUES(`name`)",
 		"DELETE FROM `db1`.`tb1` WHERE (`id`) IN ((?),(?),(?))",
 
 		// table2
@@ -503,9 +499,9 @@ func (s *testSyncerSuite) TestGenDMLWithSameOp(c *C) {
 		"INSERT INTO `db2`.`tb2` (`id`,`col3`,`name`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `id`=VALUES(`id`),`col3`=VALUES(`col3`),`name`=VALUES(`name`)",
 		"INSERT INTO `db2`.`tb2` (`id`,`col2`,`name`) VALUES (?,?,?),(?,?,?) ON DUPLICATE KEY UPDATE `id`=VALUES(`id`),`col2`=VALUES(`col2`),`name`=VALUES(`name`)",
 		"INSERT INTO `db2`.`tb2` (`id`,`col3`,`name`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `id`=VALUES(`id`),`col3`=VALUES(`col3`),`name`=VALUES(`name`)",
-		"UPDATE `db2`.`tb2` SET `id` = ?, `col2` = ?, `name` = ? WHERE `id` = ? LIMIT 1",
-		"UPDATE `db2`.`tb2` SET `id` = ?, `col2` = ?, `name` = ? WHERE `id` = ? LIMIT 1",
-		"UPDATE `db2`.`tb2` SET `id` = ?, `col3` = ?, `name` = ? WHERE `id` = ? LIMIT 1",
+		"DELETE FROM `db2`.`tb2` WHERE (`id`) IN ((?),(?),(?))",
+		"INSERT INTO `db2`.`tb2` (`id`,`col2`,`name`) VALUES (?,?,?),(?,?,?)",
+		"INSERT INTO `db2`.`tb2` (`id`,`col3`,`name`) VALUES (?,?,?)",
 		"DELETE FROM `db2`.`tb2` WHERE (`id`) IN ((?),(?),(?))",
 
 		// table1
@@ -515,12 +511,8 @@ func (s *testSyncerSuite) TestGenDMLWithSameOp(c *C) {
 	expectArgs := [][]interface{}{
 		// table1
 		{1, 1, "a", 2, 2, "b", 3, 3, "c", 1, 1, "aa", 2, 2, "bb", 3, 3, "cc", 1, 4, "aa", 2, 5, "bb", 3, 6, "cc"},
-		{1},
-		{4, 4, "aa"},
-		{2},
-		{5, 5, "bb"},
-		{3},
-		{6, 6, "cc"},
+		{1, 2, 3},
+		{4, 4, "aa", 5, 5, "bb", 6, 6, "cc"},
 		{4, 5, 6},
 
 		// table2
@@ -531,18 +523,48 @@ func (s *testSyncerSuite) TestGenDMLWithSameOp(c *C) {
 		{3, 3, "cc"},
 		{1, 4, "aa", 2, 5, "bb"},
 		{3, 6, "cc"},
-		{4, 4, "aa", 1},
-		{5, 5, "bb", 2},
-		{6, 6, "cc", 3},
+		{1, 2, 3},
+		{4, 4, "aa", 5, 5, "bb"},
+		{6, 6, "cc"},
 		{4, 5, 6},
 
 		// table1
 		{44, 55, 66},
 	}
 
-	queries, args := genDMLsWithSameOp(dmls)
+	queries, args := genDMLsWithSameOp(dmls, true)
 	c.Assert(queries, DeepEquals, expectQueries)
 	c.Assert(args, DeepEquals, expectArgs)
+
+	// the chained updates of identify values are generated one by one
+	dmls = []*DML{
+		newDML(update, false, targetTableID1, sourceTable11, []interface{}{1, 1, "a"}, []interface{}{2, 1, "a"}, []interface{}{1, 1, "a"}, []interface{}{2, 1, "a"}, ti11.Columns, ti11, ti11Index, downTi11),
+		newDML(update, false, targetTableID1, sourceTable11, []interface{}{2, 1, "a"}, []interface{}{3, 1, "a"}, []interface{}{2, 1, "a"}, []interface{}{3, 1, "a"}, ti11.Columns, ti11, ti11Index, downTi11),
+	}
+	queries, args = genDMLsWithSameOp(dmls, true)
+	c.Assert(queries, DeepEquals, []string{
+		"UPDATE `db1`.`tb1` SET `id` = ?, `col1` = ?, `name` = ? WHERE `id` = ? LIMIT 1",
+		"UPDATE `db1`.`tb1` SET `id` = ?, `col1` = ?, `name` = ? WHERE `id` = ? LIMIT 1",
+	})
+	c.Assert(args, DeepEquals, [][]interface{}{{2, 1, "a", 1}, {3, 1, "a", 2}})
+
+	// the updates of identify values are generated one by one without batch-dml
+	dmls = []*DML{
+		newDML(update, false, targetTableID1, sourceTable11, []interface{}{1, 1, "a"}, []interface{}{2, 1, "a"}, []interface{}{1, 1, "a"}, []interface{}{2, 1, "a"}, ti11.Columns, ti11, ti11Index, downTi11),
+		newDML(update, false, targetTableID1, sourceTable11, []interface{}{3, 1, "a"}, []interface{}{4, 1, "a"}, []interface{}{3, 1, "a"}, []interface{}{4, 1, "a"}, ti11.Columns, ti11, ti11Index, downTi11),
+	}
+	queries, args = genDMLsWithSameOp(dmls, false)
+	c.Assert(queries, DeepEquals, []string{
+		"UPDATE `db1`.`tb1` SET `id` = ?, `col1` = ?, `name` = ? WHERE `id` = ? LIMIT 1",
+		"UPDATE `db1`.`tb1` SET `id` = ?, `col1` = ?, `name` = ? WHERE `id` = ? LIMIT 1",
+	})
+	c.Assert(args, DeepEquals, [][]interface{}{{2, 1, "a", 1}, {4, 1, "a", 3}})
+	queries, args = genDMLsWithSameOp(dmls, true)
+	c.Assert(queries, DeepEquals, []string{
+		"DELETE FROM `db1`.`tb1` WHERE (`id`) IN ((?),(?))",
+		"INSERT INTO `db1`.`tb1` (`id`,`col1`,`name`) VALUES (?,?,?),(?,?,?)",
+	})
+	c.Assert(args, DeepEquals, [][]interface{}{{1, 3}, {2, 1, "a", 4, 1, "a"}})
 }
 
 func (s *testSyncerSuite) TestTruncateIndexValues(c *C) {
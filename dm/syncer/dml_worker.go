@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb-tools/pkg/filter"
 	"go.uber.org/zap"
 
+	"github.com/pingcap/ticdc/dm/pkg/conn"
 	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
@@ -36,6 +37,7 @@ type DMLWorker struct {
 	workerCount  int
 	chanSize     int
 	multipleRows bool
+	batchDML     bool
 	toDBConns    []*dbconn.DBConn
 	tctx         *tcontext.Context
 	wg           sync.WaitGroup // counts conflict/flush jobs in all DML job channels.
@@ -68,7 +70,8 @@ func dmlWorkerWrap(inCh chan *job, syncer *Syncer) chan *job {
 		batch:        syncer.cfg.Batch,
 		workerCount:  syncer.cfg.WorkerCount,
 		chanSize:     chanSize,
-		multipleRows: syncer.cfg.MultipleRows,
+		multipleRows: syncer.cfg.MultipleRows || syncer.cfg.BatchDML,
+		batchDML:     syncer.cfg.BatchDML,
 		task:         syncer.cfg.Name,
 		source:       syncer.cfg.SourceID,
 		worker:       syncer.cfg.WorkerName,
@@ -224,7 +227,7 @@ func (w *DMLWorker) executeBatchJobs(queueID int, jobs []*job) {
 	for _, j := range jobs {
 		dmls = append(dmls, j.dml)
 	}
-	queries, args := w.genSQLs(dmls)
+	queries, args, tables := w.genSQLs(dmls)
 	failpoint.Inject("WaitUserCancel", func(v failpoint.Value) {
 		t := v.(int)
 		time.Sleep(time.Duration(t) * time.Second)
@@ -232,7 +235,11 @@ func (w *DMLWorker) executeBatchJobs(queueID int, jobs []*job) {
 	// use background context to execute sqls as much as possible
 	ctx, cancel := w.tctx.WithTimeout(maxDMLExecutionDuration)
 	defer cancel()
-	affect, err = db.ExecuteSQL(ctx, queries, args...)
+	if w.multipleRows {
+		affect, err = db.ExecuteSQL(ctx, queries, args...)
+	} else {
+		affect, err = db.ExecuteStmts(ctx, tables, queries, args...)
+	}
 	failpoint.Inject("SafeModeExit", func(val failpoint.Value) {
 		if intVal, ok := val.(int); ok && intVal == 4 && len(jobs) > 0 {
 			w.logger.Warn("fail to exec DML", zap.String("failpoint", "SafeModeExit"))
@@ -242,17 +249,24 @@ func (w *DMLWorker) executeBatchJobs(queueID int, jobs []*job) {
 }
 
 // genSQLs generate SQLs in single row mode or multiple rows mode.
-func (w *DMLWorker) genSQLs(dmls []*DML) ([]string, [][]interface{}) {
+// in single row mode, the source tables of the SQLs with their schema versions are also returned to prepare the statements.
+func (w *DMLWorker) genSQLs(dmls []*DML) ([]string, [][]interface{}, []conn.StmtTable) {
 	if w.multipleRows {
-		return genDMLsWithSameOp(dmls)
+		queries, args := genDMLsWithSameOp(dmls, w.batchDML)
+		return queries, args, nil
 	}
 
 	queries := make([]string, 0, len(dmls))
 	args := make([][]interface{}, 0, len(dmls))
+	tables := make([]conn.StmtTable, 0, len(dmls))
 	for _, dml := range dmls {
 		query, arg := dml.genSQL()
 		queries = append(queries, query...)
 		args = append(args, arg...)
+		table := dml.stmtTable()
+		for range query {
+			tables = append(tables, table)
+		}
 	}
-	return queries, args
+	return queries, args, tables
 }
@@ -25,6 +25,7 @@ import (
 
 	"github.com/pingcap/ticdc/dm/dm/pb"
 	"github.com/pingcap/ticdc/dm/pkg/binlog"
+	"github.com/pingcap/ticdc/dm/pkg/gtid"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
 )
@@ -35,6 +36,7 @@ type Operator struct {
 	uuid   string // add a UUID, make it more friendly to be traced in log
 	op     pb.ErrorOp
 	events []*replication.BinlogEvent // startLocation -> events
+	gtid   gtid.Set                   // not nil if the operator is specified by GTID
 }
 
 // newOperator creates a new operator with a random UUID.
@@ -59,15 +61,18 @@ func (o *Operator) String() string {
 // Holder holds error operator.
 type Holder struct {
 	mu        sync.Mutex
-	operators map[string]*Operator
-	logger    log.Logger
+	operators map[string]*Operator // binlog position -> operator
+	// GTID -> operator, it's moved to operators by the binlog position after matched.
+	gtidOperators map[string]*Operator
+	logger        log.Logger
 }
 
 // NewHolder creates a new Holder.
 func NewHolder(pLogger *log.Logger) *Holder {
 	return &Holder{
-		operators: make(map[string]*Operator),
-		logger:    pLogger.WithFields(zap.String("component", "error operator holder")),
+		operators:     make(map[string]*Operator),
+		gtidOperators: make(map[string]*Operator),
+		logger:        pLogger.WithFields(zap.String("component", "error operator holder")),
 	}
 }
 
@@ -75,22 +80,33 @@ func NewHolder(pLogger *log.Logger) *Holder {
 func (h *Holder) Set(pos string, op pb.ErrorOp, events []*replication.BinlogEvent) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	return h.set(h.operators, pos, op, newOperator(op, events))
+}
 
+// SetByGTID sets an Operator for the binlog event of the specified GTID.
+func (h *Holder) SetByGTID(gs gtid.Set, op pb.ErrorOp, events []*replication.BinlogEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	oper := newOperator(op, events)
+	oper.gtid = gs
+	return h.set(h.gtidOperators, gs.String(), op, oper)
+}
+
+func (h *Holder) set(operators map[string]*Operator, key string, op pb.ErrorOp, oper *Operator) error {
 	if op == pb.ErrorOp_Revert {
-		if _, ok := h.operators[pos]; !ok {
-			return terror.ErrSyncerOperatorNotExist.Generate(pos)
+		if _, ok := operators[key]; !ok {
+			return terror.ErrSyncerOperatorNotExist.Generate(key)
 		}
-		delete(h.operators, pos)
+		delete(operators, key)
 		return nil
 	}
 
-	oper := newOperator(op, events)
-	pre, ok := h.operators[pos]
+	pre, ok := operators[key]
 	if ok {
-		h.logger.Warn("overwrite operator", zap.String("position", pos), zap.Stringer("old operator", pre))
+		h.logger.Warn("overwrite operator", zap.String("position", key), zap.Stringer("old operator", pre))
 	}
-	h.operators[pos] = oper
-	h.logger.Info("set a new operator", zap.String("position", pos), zap.Stringer("new operator", oper))
+	operators[key] = oper
+	h.logger.Info("set a new operator", zap.String("position", key), zap.Stringer("new operator", oper))
 	return nil
 }
 
@@ -132,6 +148,9 @@ func (h *Holder) MatchAndApply(startLocation, endLocation binlog.Location, realE
 
 	key := startLocation.Position.String()
 	operator, ok := h.operators[key]
+	if !ok {
+		operator, ok = h.matchGTID(key, startLocation, endLocation)
+	}
 	if !ok {
 		return false, pb.ErrorOp_InvalidErrorOp
 	}
@@ -169,20 +188,49 @@ func (h *Holder) MatchAndApply(startLocation, endLocation binlog.Location, realE
 	return true, operator.op
 }
 
-// RemoveOutdated remove outdated operator.
-func (h *Holder) RemoveOutdated(flushLocation binlog.Location) error {
+// matchGTID finds the operator specified by the GTID of the event, and moves it to the binlog
+// position of the event to get the replace events by the position later.
+func (h *Holder) matchGTID(key string, startLocation, endLocation binlog.Location) (*Operator, bool) {
+	endGTID := endLocation.GetGTID()
+	if endGTID == nil {
+		return nil, false
+	}
+	startGTID := startLocation.GetGTID()
+	for gs, operator := range h.gtidOperators {
+		if endGTID.Contain(operator.gtid) && (startGTID == nil || !startGTID.Contain(operator.gtid)) {
+			delete(h.gtidOperators, gs)
+			h.operators[key] = operator
+			return operator, true
+		}
+	}
+	return nil, false
+}
+
+// RemoveOutdated removes the outdated operators, and returns the binlog positions or GTIDs of them.
+func (h *Holder) RemoveOutdated(flushLocation binlog.Location) ([]string, error) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	removed := make([]string, 0)
 	for pos := range h.operators {
 		position, err := binlog.PositionFromPosStr(pos)
 		if err != nil {
 			// should not happen
-			return err
+			return removed, err
 		}
 		if binlog.ComparePosition(position, flushLocation.Position) == -1 {
 			h.logger.Info("remove a outdated operator", zap.Stringer("position", position), zap.Stringer("flush position", flushLocation.Position), zap.Stringer("operator", h.operators[pos]))
 			delete(h.operators, pos)
+			removed = append(removed, pos)
 		}
 	}
-	return nil
+	if flushGTID := flushLocation.GetGTID(); flushGTID != nil {
+		for gs, operator := range h.gtidOperators {
+			if flushGTID.Contain(operator.gtid) {
+				h.logger.Info("remove a outdated operator", zap.String("GTID", gs), zap.Stringer("flush GTID", flushGTID), zap.Stringer("operator", operator))
+				delete(h.gtidOperators, gs)
+				removed = append(removed, gs)
+			}
+		}
+	}
+	return removed, nil
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pingcap/ticdc/dm/dm/pb"
 	"github.com/pingcap/ticdc/dm/pkg/binlog"
+	"github.com/pingcap/ticdc/dm/pkg/gtid"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
 )
@@ -139,13 +140,17 @@ func (o *testOperatorSuite) TestOperator(c *C) {
 
 	// test removeOutdated
 	flushLocation := startLocation
-	c.Assert(h.RemoveOutdated(flushLocation), IsNil)
+	removed, err := h.RemoveOutdated(flushLocation)
+	c.Assert(err, IsNil)
+	c.Assert(removed, HasLen, 0)
 	apply, op = h.MatchAndApply(startLocation, endLocation, event1.Header.Timestamp)
 	c.Assert(apply, IsTrue)
 	c.Assert(op, Equals, pb.ErrorOp_Replace)
 
 	flushLocation = endLocation
-	c.Assert(h.RemoveOutdated(flushLocation), IsNil)
+	removed, err = h.RemoveOutdated(flushLocation)
+	c.Assert(err, IsNil)
+	c.Assert(removed, DeepEquals, []string{startLocation.Position.String()})
 	apply, op = h.MatchAndApply(startLocation, endLocation, event1.Header.Timestamp)
 	c.Assert(apply, IsFalse)
 	c.Assert(op, Equals, pb.ErrorOp_InvalidErrorOp)
@@ -154,3 +159,60 @@ func (o *testOperatorSuite) TestOperator(c *C) {
 	c.Assert(apply, IsTrue)
 	c.Assert(op, Equals, pb.ErrorOp_Replace)
 }
+
+func (o *testOperatorSuite) TestOperatorByGTID(c *C) {
+	logger := log.L()
+	h := NewHolder(&logger)
+
+	location := func(pos uint32, gs string) binlog.Location {
+		gset, err := gtid.ParserGTID(mysql.MySQLFlavor, gs)
+		c.Assert(err, IsNil)
+		return binlog.InitLocation(mysql.Position{Name: "mysql-bin.000001", Pos: pos}, gset)
+	}
+	startLocation := location(233, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-14")
+	endLocation := location(250, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-15")
+	nextLocation := location(300, "3ccc475b-2343-11e7-be21-6c0b84d59f30:1-16")
+
+	event := &replication.BinlogEvent{
+		Header: &replication.EventHeader{
+			EventType: replication.QUERY_EVENT,
+			Timestamp: uint32(1623313992),
+		},
+		Event: &replication.QueryEvent{
+			Schema: []byte("db"),
+			Query:  []byte("alter table tb add column a int"),
+		},
+	}
+
+	gs15, err := gtid.ParserGTID(mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:15")
+	c.Assert(err, IsNil)
+	gs16, err := gtid.ParserGTID(mysql.MySQLFlavor, "3ccc475b-2343-11e7-be21-6c0b84d59f30:16")
+	c.Assert(err, IsNil)
+
+	// revert not exist operator
+	err = h.SetByGTID(gs15, pb.ErrorOp_Revert, nil)
+	c.Assert(terror.ErrSyncerOperatorNotExist.Equal(err), IsTrue)
+
+	c.Assert(h.SetByGTID(gs15, pb.ErrorOp_Replace, []*replication.BinlogEvent{event}), IsNil)
+	c.Assert(h.SetByGTID(gs16, pb.ErrorOp_Skip, nil), IsNil)
+
+	// the operator of GTID 16 doesn't match the event of GTID 15
+	apply, op := h.MatchAndApply(startLocation, endLocation, event.Header.Timestamp)
+	c.Assert(apply, IsTrue)
+	c.Assert(op, Equals, pb.ErrorOp_Replace)
+	// the matched operator can be got by the binlog position
+	e, err := h.GetEvent(startLocation)
+	c.Assert(err, IsNil)
+	c.Assert(e.Event, Equals, event.Event)
+	c.Assert(e.Header.LogPos, Equals, endLocation.Position.Pos)
+
+	// the GTID of the operator is flushed
+	removed, err := h.RemoveOutdated(nextLocation)
+	c.Assert(err, IsNil)
+	c.Assert(removed, HasLen, 2)
+	c.Assert(removed[0], Equals, startLocation.Position.String())
+	c.Assert(removed[1], Equals, gs16.String())
+	apply, op = h.MatchAndApply(endLocation, nextLocation, event.Header.Timestamp)
+	c.Assert(apply, IsFalse)
+	c.Assert(op, Equals, pb.ErrorOp_InvalidErrorOp)
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/pingcap/ticdc/dm/dm/config"
 	"github.com/pingcap/ticdc/dm/pkg/log"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 	"github.com/pingcap/ticdc/dm/pkg/utils"
 )
 
@@ -94,8 +95,7 @@ func (g *ExprFilterGroup) GetInsertExprs(table *filter.Table, ti *model.TableInf
 		if c.InsertValueExpr != "" {
 			expr, err2 := getSimpleExprOfTable(g.ctx, c.InsertValueExpr, ti)
 			if err2 != nil {
-				// TODO: terror
-				return nil, err2
+				return nil, terror.ErrSyncerGenExprFilter.Delegate(err2, c.InsertValueExpr, tableID)
 			}
 			g.insertExprs[tableID] = append(g.insertExprs[tableID], expr)
 		}
@@ -120,8 +120,7 @@ func (g *ExprFilterGroup) GetUpdateExprs(table *filter.Table, ti *model.TableInf
 			if c.UpdateOldValueExpr != "" {
 				expr, err := getSimpleExprOfTable(g.ctx, c.UpdateOldValueExpr, ti)
 				if err != nil {
-					// TODO: terror
-					return nil, nil, err
+					return nil, nil, terror.ErrSyncerGenExprFilter.Delegate(err, c.UpdateOldValueExpr, tableID)
 				}
 				g.updateOldExprs[tableID] = append(g.updateOldExprs[tableID], expr)
 			} else {
@@ -135,8 +134,7 @@ func (g *ExprFilterGroup) GetUpdateExprs(table *filter.Table, ti *model.TableInf
 			if c.UpdateNewValueExpr != "" {
 				expr, err := getSimpleExprOfTable(g.ctx, c.UpdateNewValueExpr, ti)
 				if err != nil {
-					// TODO: terror
-					return nil, nil, err
+					return nil, nil, terror.ErrSyncerGenExprFilter.Delegate(err, c.UpdateNewValueExpr, tableID)
 				}
 				g.updateNewExprs[tableID] = append(g.updateNewExprs[tableID], expr)
 			} else {
@@ -164,8 +162,7 @@ func (g *ExprFilterGroup) GetDeleteExprs(table *filter.Table, ti *model.TableInf
 		if c.DeleteValueExpr != "" {
 			expr, err2 := getSimpleExprOfTable(g.ctx, c.DeleteValueExpr, ti)
 			if err2 != nil {
-				// TODO: terror
-				return nil, err2
+				return nil, terror.ErrSyncerGenExprFilter.Delegate(err2, c.DeleteValueExpr, tableID)
 			}
 			g.deleteExprs[tableID] = append(g.deleteExprs[tableID], expr)
 		}
@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/ticdc/dm/dm/config"
 	"github.com/pingcap/ticdc/dm/pkg/log"
 	"github.com/pingcap/ticdc/dm/pkg/schema"
+	"github.com/pingcap/ticdc/dm/pkg/terror"
 	"github.com/pingcap/ticdc/dm/pkg/utils"
 	"github.com/pingcap/ticdc/dm/syncer/dbconn"
 )
@@ -436,4 +437,10 @@ create table t (
 	skip, err = SkipDMLByExpression(sessCtx, []interface{}{2}, expr, ti.Columns)
 	c.Assert(err, IsNil)
 	c.Assert(skip, Equals, false)
+	// other errors are reported with the expression and the table
+	exprConfig[0].InsertValueExpr = "no_such_func(c) > 1"
+	g = NewExprFilterGroup(sessCtx, exprConfig)
+	_, err = g.GetInsertExprs(table, ti)
+	c.Assert(terror.ErrSyncerGenExprFilter.Equal(err), IsTrue)
+	c.Assert(err, ErrorMatches, ".*no_such_func\\(c\\) > 1.*`test`.`t`.*")
 }
@@ -21,16 +21,22 @@ import (
 
 	"github.com/go-mysql-org/go-mysql/replication"
 	"github.com/pingcap/tidb/parser/ast"
+	"go.uber.org/zap"
 
 	"github.com/pingcap/ticdc/dm/dm/pb"
 	"github.com/pingcap/ticdc/dm/pkg/binlog"
+	"github.com/pingcap/ticdc/dm/pkg/gtid"
+	"github.com/pingcap/ticdc/dm/pkg/ha"
 	parserpkg "github.com/pingcap/ticdc/dm/pkg/parser"
 	"github.com/pingcap/ticdc/dm/pkg/terror"
 )
 
 // HandleError handle error for syncer.
 func (s *Syncer) HandleError(ctx context.Context, req *pb.HandleWorkerErrorRequest) error {
-	pos := req.BinlogPos
+	var (
+		pos = req.BinlogPos
+		gs  gtid.Set
+	)
 
 	if len(pos) == 0 {
 		startLocation, isQueryEvent := s.getErrLocation()
@@ -42,34 +48,91 @@ func (s *Syncer) HandleError(ctx context.Context, req *pb.HandleWorkerErrorReque
 		}
 		pos = startLocation.Position.String()
 	} else {
-		startLocation, err := binlog.VerifyBinlogPos(pos)
+		startLocation, gtidSet, err := binlog.VerifyBinlogPosOrGTID(s.cfg.Flavor, pos)
 		if err != nil {
 			return err
 		}
-		pos = startLocation.String()
+		if gtidSet != nil {
+			gs = gtidSet
+			pos = gs.String()
+		} else {
+			pos = startLocation.String()
+		}
 	}
 
+	// remove outdated operators when add operator
+	if err := s.removeOutdatedErrorOperators(); err != nil {
+		return err
+	}
+
+	err := s.setErrorOperator(ctx, pos, gs, req.Op, req.Sqls)
+	if err != nil {
+		return err
+	}
+
+	// persist the operator to apply it again after the subtask restarted
+	if s.cli != nil {
+		if req.Op == pb.ErrorOp_Revert {
+			_, err = ha.DeleteErrorOperators(s.cli, s.cfg.Name, s.cfg.SourceID, pos)
+		} else {
+			_, err = ha.PutErrorOperator(s.cli, ha.NewErrorOperator(s.cfg.Name, s.cfg.SourceID, pos, req.Op, req.Sqls))
+		}
+	}
+	return err
+}
+
+// setErrorOperator sets the operator for the binlog position, or for the GTID if gs is not nil.
+func (s *Syncer) setErrorOperator(ctx context.Context, pos string, gs gtid.Set, op pb.ErrorOp, sqls []string) error {
 	events := make([]*replication.BinlogEvent, 0)
 	var err error
-	if req.Op == pb.ErrorOp_Replace {
-		events, err = s.genEvents(ctx, req.Sqls)
+	if op == pb.ErrorOp_Replace {
+		events, err = s.genEvents(ctx, sqls)
 		if err != nil {
 			return err
 		}
 	}
 
-	// remove outdated operators when add operator
-	err = s.errOperatorHolder.RemoveOutdated(s.checkpoint.FlushedGlobalPoint())
+	if gs != nil {
+		return s.errOperatorHolder.SetByGTID(gs, op, events)
+	}
+	return s.errOperatorHolder.Set(pos, op, events)
+}
+
+// removeOutdatedErrorOperators removes the operators before the flushed checkpoint, also from etcd.
+func (s *Syncer) removeOutdatedErrorOperators() error {
+	removed, err := s.errOperatorHolder.RemoveOutdated(s.checkpoint.FlushedGlobalPoint())
 	if err != nil {
 		return err
 	}
+	if s.cli != nil && len(removed) > 0 {
+		_, err = ha.DeleteErrorOperators(s.cli, s.cfg.Name, s.cfg.SourceID, removed...)
+	}
+	return err
+}
 
-	err = s.errOperatorHolder.Set(pos, req.Op, events)
+// loadErrorOperators loads the persisted operators of the subtask.
+func (s *Syncer) loadErrorOperators(ctx context.Context) error {
+	if s.cli == nil {
+		return nil
+	}
+	operators, _, err := ha.GetErrorOperators(s.cli, s.cfg.Name, s.cfg.SourceID)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	for _, o := range operators {
+		var gs gtid.Set
+		// the operators are persisted with the binlog positions like `(mysql-bin.000001, 1234)` or the GTIDs
+		if _, err = binlog.PositionFromPosStr(o.BinlogPos); err != nil {
+			if gs, err = gtid.ParserGTID(s.cfg.Flavor, o.BinlogPos); err != nil {
+				return err
+			}
+		}
+		if err = s.setErrorOperator(ctx, o.BinlogPos, gs, o.Op, o.Sqls); err != nil {
+			return err
+		}
+		s.tctx.L().Info("load error operator", zap.Stringer("operator", o))
+	}
+	return s.removeOutdatedErrorOperators()
 }
 
 func (s *Syncer) genEvents(ctx context.Context, sqls []string) ([]*replication.BinlogEvent, error) {
@@ -61,6 +61,18 @@ func (s *testSyncerSuite) TestHandleError(c *C) {
 				req:    pb.HandleWorkerErrorRequest{Op: pb.ErrorOp_Skip, Task: task, BinlogPos: "mysql-bin.000001:2345", Sqls: []string{}},
 				errMsg: "",
 			},
+			{
+				req:    pb.HandleWorkerErrorRequest{Op: pb.ErrorOp_Skip, Task: task, BinlogPos: "3ccc475b-2343-11e7-be21-6c0b84d59f30:15", Sqls: []string{}},
+				errMsg: "",
+			},
+			{
+				req:    pb.HandleWorkerErrorRequest{Op: pb.ErrorOp_Revert, Task: task, BinlogPos: "3ccc475b-2343-11e7-be21-6c0b84d59f30:15", Sqls: []string{}},
+				errMsg: "",
+			},
+			{
+				req:    pb.HandleWorkerErrorRequest{Op: pb.ErrorOp_Revert, Task: task, BinlogPos: "3ccc475b-2343-11e7-be21-6c0b84d59f30:16", Sqls: []string{}},
+				errMsg: ".*error operator not exist.*",
+			},
 		}
 	)
 	mockDB := conn.InitMockDB(c)
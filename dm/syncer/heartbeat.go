@@ -0,0 +1,167 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/pkg/log"
+)
+
+const (
+	// the table of the source which DM writes heartbeats into.
+	heartbeatSchema = "dm_heartbeat"
+	heartbeatTable  = "heartbeat"
+
+	heartbeatTimeLayout = "2006-01-02 15:04:05.000000"
+)
+
+// heartbeat measures the replication lag by the heartbeats of the source, which are timestamps in UTC
+// written into a table periodically, either by DM or by an external tool like pt-heartbeat.
+// the lag is the duration between now and the latest heartbeat replicated from the binlog.
+type heartbeat struct {
+	task     string
+	table    *filter.Table
+	interval time.Duration // 0 if DM doesn't write heartbeats
+
+	latest atomic.Time
+}
+
+// newHeartbeat creates a heartbeat from the syncer config, nil is returned if the heartbeat is disabled.
+func newHeartbeat(cfg *config.SubTaskConfig) *heartbeat {
+	if schema, table, ok := cfg.HeartbeatSourceTable(); ok {
+		return &heartbeat{task: cfg.Name, table: &filter.Table{Schema: schema, Name: table}}
+	}
+	if cfg.HeartbeatInterval > 0 {
+		return &heartbeat{
+			task:     cfg.Name,
+			table:    &filter.Table{Schema: heartbeatSchema, Name: heartbeatTable},
+			interval: time.Duration(cfg.HeartbeatInterval) * time.Second,
+		}
+	}
+	return nil
+}
+
+// run writes heartbeats into the source periodically until ctx is done, if DM should write heartbeats.
+func (h *heartbeat) run(ctx context.Context, db *sql.DB, logger log.Logger) {
+	if h == nil || h.interval == 0 {
+		return
+	}
+
+	initialized := false
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		if !initialized {
+			if err := h.createTable(ctx, db); err != nil {
+				logger.Warn("fail to create heartbeat table", zap.Stringer("table", h.table), log.ShortError(err))
+			} else {
+				initialized = true
+			}
+		}
+		if initialized {
+			if err := h.write(ctx, db, time.Now()); err != nil {
+				logger.Warn("fail to write heartbeat", zap.Stringer("table", h.table), log.ShortError(err))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *heartbeat) createTable(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", dbutil.ColumnName(h.table.Schema))); err != nil {
+		return err
+	}
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		task VARCHAR(255) NOT NULL,
+		ts DATETIME(6) NOT NULL,
+		PRIMARY KEY (task)
+	)`, dbutil.TableName(h.table.Schema, h.table.Name)))
+	return err
+}
+
+func (h *heartbeat) write(ctx context.Context, db *sql.DB, now time.Time) error {
+	_, err := db.ExecContext(ctx, fmt.Sprintf("REPLACE INTO %s (task, ts) VALUES (?, ?)", dbutil.TableName(h.table.Schema, h.table.Name)),
+		h.task, now.UTC().Format(heartbeatTimeLayout))
+	return err
+}
+
+// handleRows records the latest heartbeat in the rows if they are of the heartbeat table.
+// the first column holding a timestamp in a row is taken as the heartbeat.
+func (h *heartbeat) handleRows(table *filter.Table, rows [][]interface{}) {
+	if h == nil || !strings.EqualFold(table.Schema, h.table.Schema) || !strings.EqualFold(table.Name, h.table.Name) {
+		return
+	}
+	for _, row := range rows {
+		for _, value := range row {
+			ts, ok := parseHeartbeat(value)
+			if !ok {
+				continue
+			}
+			if ts.After(h.latest.Load()) {
+				h.latest.Store(ts)
+			}
+			break
+		}
+	}
+}
+
+// lag returns the replication lag in seconds, false is returned if no heartbeat is replicated yet.
+func (h *heartbeat) lag(now time.Time) (float64, bool) {
+	if h == nil {
+		return 0, false
+	}
+	latest := h.latest.Load()
+	if latest.IsZero() {
+		return 0, false
+	}
+	lag := now.Sub(latest).Seconds()
+	if lag < 0 {
+		lag = 0
+	}
+	return lag, true
+}
+
+// parseHeartbeat parses a column value of DATETIME or a string like `2006-01-02T15:04:05.000000`
+// used by pt-heartbeat as a timestamp in UTC.
+func parseHeartbeat(value interface{}) (time.Time, bool) {
+	var s string
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return time.Time{}, false
+	}
+	ts, err := time.ParseInLocation("2006-01-02 15:04:05.999999", strings.Replace(s, "T", " ", 1), time.UTC)
+	return ts, err == nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/pkg/log"
+	"github.com/pingcap/ticdc/dm/pkg/utils"
+)
+
+var _ = Suite(&testHeartbeatSuite{})
+
+type testHeartbeatSuite struct{}
+
+func (t *testHeartbeatSuite) TestNewHeartbeat(c *C) {
+	cfg := &config.SubTaskConfig{Name: "task"}
+	c.Assert(newHeartbeat(cfg), IsNil)
+
+	cfg.HeartbeatInterval = 2
+	h := newHeartbeat(cfg)
+	c.Assert(h.table, DeepEquals, &filter.Table{Schema: heartbeatSchema, Name: heartbeatTable})
+	c.Assert(h.interval, Equals, 2*time.Second)
+
+	cfg.HeartbeatInterval = 0
+	cfg.HeartbeatTable = "`percona`.`heartbeat`"
+	h = newHeartbeat(cfg)
+	c.Assert(h.table, DeepEquals, &filter.Table{Schema: "percona", Name: "heartbeat"})
+	c.Assert(h.interval, Equals, time.Duration(0))
+}
+
+func (t *testHeartbeatSuite) TestHeartbeatLag(c *C) {
+	var h *heartbeat
+	h.handleRows(&filter.Table{Schema: "percona", Name: "heartbeat"}, nil)
+	_, ok := h.lag(time.Now())
+	c.Assert(ok, IsFalse)
+
+	h = newHeartbeat(&config.SubTaskConfig{SyncerConfig: config.SyncerConfig{HeartbeatTable: "percona.heartbeat"}})
+	now := time.Date(2021, 11, 17, 10, 0, 10, 0, time.UTC)
+	_, ok = h.lag(now)
+	c.Assert(ok, IsFalse)
+
+	// rows of other tables are ignored
+	h.handleRows(&filter.Table{Schema: "db", Name: "heartbeat"}, [][]interface{}{{"2021-11-17 10:00:09.000000"}})
+	_, ok = h.lag(now)
+	c.Assert(ok, IsFalse)
+
+	// the format of pt-heartbeat, the first timestamp of each row is used
+	h.handleRows(&filter.Table{Schema: "Percona", Name: "heartbeat"}, [][]interface{}{
+		{"2021-11-17T10:00:05.500000", int32(1), "2021-11-17T10:00:09.000000"},
+		{"2021-11-17T10:00:07.500000", int32(2)},
+	})
+	lag, ok := h.lag(now)
+	c.Assert(ok, IsTrue)
+	c.Assert(lag, Equals, 2.5)
+
+	// the heartbeat written by DM, an older heartbeat doesn't decrease the latest one
+	h.handleRows(&filter.Table{Schema: "percona", Name: "heartbeat"}, [][]interface{}{
+		{"task", "2021-11-17 10:00:09.000000"},
+		{"task", "2021-11-17 10:00:01.000000"},
+	})
+	lag, ok = h.lag(now)
+	c.Assert(ok, IsTrue)
+	c.Assert(lag, Equals, 1.0)
+}
+
+func (t *testHeartbeatSuite) TestHeartbeatRun(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	h := newHeartbeat(&config.SubTaskConfig{Name: "task", SyncerConfig: config.SyncerConfig{HeartbeatInterval: 1}})
+
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS `dm_heartbeat`").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS `dm_heartbeat`.`heartbeat`").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("REPLACE INTO `dm_heartbeat`.`heartbeat` \\(task, ts\\) VALUES \\(\\?, \\?\\)").
+		WithArgs("task", sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		h.run(ctx, db, log.L())
+		close(done)
+	}()
+	c.Assert(utils.WaitSomething(30, 10*time.Millisecond, func() bool {
+		return mock.ExpectationsWereMet() == nil
+	}), IsTrue)
+	cancel()
+	<-done
+
+	// DM doesn't write into an existing heartbeat table
+	h = newHeartbeat(&config.SubTaskConfig{SyncerConfig: config.SyncerConfig{HeartbeatTable: "percona.heartbeat"}})
+	h.run(context.Background(), db, log.L())
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
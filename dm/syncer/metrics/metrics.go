@@ -206,6 +206,14 @@ var (
 			Help:      "replication lag gauge in second between mysql and syncer",
 		}, []string{"task", "source_id", "worker"})
 
+	HeartbeatLagGauge = metricsproxy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "heartbeat_lag",
+			Help:      "replication lag in second measured by the heartbeats of mysql",
+		}, []string{"task", "source_id", "worker"})
+
 	ReplicationLagHistogram = metricsproxy.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "dm",
@@ -223,6 +231,46 @@ var (
 			Help:      "the remaining time in second to catch up master",
 		}, []string{"task", "source_id", "worker"})
 
+	RemainingBinlogSizeGauge = metricsproxy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "remaining_binlog_size",
+			Help:      "the size in bytes of the binlog not replicated yet",
+		}, []string{"task", "source_id", "worker"})
+
+	SafeModeWindowGauge = metricsproxy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "safe_mode_window",
+			Help:      "the remaining time in second of the automatically engaged safe-mode",
+		}, []string{"task", "source_id", "worker"})
+
+	StmtCacheCounter = metricsproxy.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "prepared_stmt_cache_total",
+			Help:      "total number of the prepared statements found in the cache or prepared for the DMLs",
+		}, []string{"type", "task", "source_id"})
+
+	ValidatorRowsCounter = metricsproxy.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "validator_rows_total",
+			Help:      "total number of the rows validated by the validator, by the result `matched`, `error` or `skipped`",
+		}, []string{"result", "task", "source_id"})
+
+	ValidatorPendingRowsGauge = metricsproxy.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "dm",
+			Subsystem: "syncer",
+			Name:      "validator_pending_rows",
+			Help:      "the rows waiting to be validated by the validator",
+		}, []string{"task", "source_id", "worker"})
+
 	UnsyncedTableGauge = metricsproxy.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "dm",
@@ -288,7 +336,13 @@ func RegisterMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(SyncerExitWithErrorCounter)
 	registry.MustRegister(ReplicationLagGauge)
 	registry.MustRegister(ReplicationLagHistogram)
+	registry.MustRegister(HeartbeatLagGauge)
 	registry.MustRegister(RemainingTimeGauge)
+	registry.MustRegister(RemainingBinlogSizeGauge)
+	registry.MustRegister(SafeModeWindowGauge)
+	registry.MustRegister(StmtCacheCounter)
+	registry.MustRegister(ValidatorRowsCounter)
+	registry.MustRegister(ValidatorPendingRowsGauge)
 	registry.MustRegister(UnsyncedTableGauge)
 	registry.MustRegister(ShardLockResolving)
 
@@ -320,7 +374,13 @@ func RemoveLabelValuesWithTaskInMetrics(task string) {
 	SyncerExitWithErrorCounter.DeleteAllAboutLabels(prometheus.Labels{"task": task})
 	ReplicationLagGauge.DeleteAllAboutLabels(prometheus.Labels{"task": task})
 	ReplicationLagHistogram.DeleteAllAboutLabels(prometheus.Labels{"task": task})
+	HeartbeatLagGauge.DeleteAllAboutLabels(prometheus.Labels{"task": task})
 	RemainingTimeGauge.DeleteAllAboutLabels(prometheus.Labels{"task": task})
+	RemainingBinlogSizeGauge.DeleteAllAboutLabels(prometheus.Labels{"task": task})
+	SafeModeWindowGauge.DeleteAllAboutLabels(prometheus.Labels{"task": task})
+	StmtCacheCounter.DeleteAllAboutLabels(prometheus.Labels{"task": task})
+	ValidatorRowsCounter.DeleteAllAboutLabels(prometheus.Labels{"task": task})
+	ValidatorPendingRowsGauge.DeleteAllAboutLabels(prometheus.Labels{"task": task})
 	UnsyncedTableGauge.DeleteAllAboutLabels(prometheus.Labels{"task": task})
 	ShardLockResolving.DeleteAllAboutLabels(prometheus.Labels{"task": task})
 
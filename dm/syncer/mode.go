@@ -14,6 +14,7 @@
 package syncer
 
 import (
+	"sync"
 	"time"
 
 	"github.com/pingcap/failpoint"
@@ -21,10 +22,12 @@ import (
 
 	"github.com/pingcap/ticdc/dm/dm/unit"
 	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
+	sm "github.com/pingcap/ticdc/dm/syncer/safe-mode"
 )
 
 func (s *Syncer) enableSafeModeInitializationPhase(tctx *tcontext.Context) {
 	s.safeMode.Reset(tctx) // in initialization phase, reset first
+	s.safeModeWindow.reset(s.safeMode)
 
 	if s.cfg.SafeMode {
 		//nolint:errcheck
@@ -36,32 +39,137 @@ func (s *Syncer) enableSafeModeInitializationPhase(tctx *tcontext.Context) {
 		s.safeMode.Add(tctx, 1) // enable and will revert after pass SafeModeExitLoc
 		s.tctx.L().Info("enable safe-mode for safe mode exit point, will exit at", zap.Stringer("location", *s.checkpoint.SafeModeExitPoint()))
 	} else {
-		//nolint:errcheck
-		s.safeMode.Add(tctx, 1) // enable and will revert after 2 * CheckpointFlushInterval
-		go func() {
-			defer func() {
-				err := s.safeMode.Add(tctx, -1)
-				if err != nil {
-					// send error to the fatal chan to interrupt the process
-					s.runFatalChan <- unit.NewProcessError(err)
-				}
-				if !s.safeMode.Enable() {
-					s.tctx.L().Info("disable safe-mode after task initialization finished")
-				}
-			}()
-
-			initPhaseSeconds := s.cfg.CheckpointFlushInterval * 2
-
-			failpoint.Inject("SafeModeInitPhaseSeconds", func(val failpoint.Value) {
-				seconds, _ := val.(int)
-				initPhaseSeconds = seconds
-				s.tctx.L().Info("set initPhaseSeconds", zap.String("failpoint", "SafeModeInitPhaseSeconds"), zap.Int("value", seconds))
-			})
-			s.tctx.L().Info("enable safe-mode because of task initialization", zap.Int("duration in seconds", initPhaseSeconds))
+		s.engageSafeModeWindow(tctx, "task initialization")
+	}
+}
+
+// safeModeDuration returns the duration to engage safe-mode automatically, twice of CheckpointFlushInterval by default.
+func (s *Syncer) safeModeDuration() time.Duration {
+	duration := time.Duration(s.cfg.CheckpointFlushInterval*2) * time.Second
+	if s.cfg.SafeModeDuration != "" {
+		// already checked in the config.
+		if d, err := time.ParseDuration(s.cfg.SafeModeDuration); err == nil {
+			duration = d
+		}
+	}
+
+	failpoint.Inject("SafeModeInitPhaseSeconds", func(val failpoint.Value) {
+		seconds, _ := val.(int)
+		duration = time.Duration(seconds) * time.Second
+		s.tctx.L().Info("set initPhaseSeconds", zap.String("failpoint", "SafeModeInitPhaseSeconds"), zap.Int("value", seconds))
+	})
+	return duration
+}
+
+// engageSafeModeWindow enables safe-mode for the duration of safeModeDuration, which is extended if
+// safe-mode is already engaged by the window, and will revert after the window ends.
+func (s *Syncer) engageSafeModeWindow(tctx *tcontext.Context, reason string) {
+	duration := s.safeModeDuration()
+	if duration <= 0 {
+		return
+	}
+	w := &s.safeModeWindow
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	end := time.Now().Add(duration)
+	if end.After(w.end) {
+		w.end = end
+	}
+	if w.active {
+		s.tctx.L().Info("extend safe-mode because of "+reason, zap.Duration("remaining", time.Until(w.end)))
+		return
+	}
+	w.active = true
+	safeMode, generation := w.safeMode, w.generation
+	//nolint:errcheck
+	safeMode.Add(tctx, 1) // enable and will revert after the window ends
+	s.tctx.L().Info("enable safe-mode because of "+reason, zap.Int("duration in seconds", int(duration/time.Second)))
+
+	go func() {
+		defer func() {
+			err := safeMode.Add(tctx, -1)
+			if err != nil {
+				// send error to the fatal chan to interrupt the process
+				s.runFatalChan <- unit.NewProcessError(err)
+			}
+			if !safeMode.Enable() {
+				s.tctx.L().Info("disable safe-mode after " + reason + " finished")
+			}
+		}()
+
+		for {
+			wait := w.wait(generation)
+			if wait <= 0 {
+				return
+			}
 			select {
 			case <-tctx.Context().Done():
-			case <-time.After(time.Duration(initPhaseSeconds) * time.Second):
+				w.close(generation)
+				return
+			case <-time.After(wait):
 			}
-		}()
+		}
+	}()
+}
+
+// safeModeWindow is a period of time in which safe-mode is engaged automatically, like after the syncer
+// restarts or its checkpoint regresses. the zero value is ready to use.
+type safeModeWindow struct {
+	mu       sync.Mutex
+	safeMode *sm.SafeMode
+	end      time.Time
+	active   bool
+	// generation is increased when the window is reset, so the goroutines of the previous run don't touch it.
+	generation int
+}
+
+// reset resets the window to the state of not-engaged for a new run of the syncer.
+func (w *safeModeWindow) reset(safeMode *sm.SafeMode) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.safeMode = safeMode
+	w.end = time.Time{}
+	w.active = false
+	w.generation++
+}
+
+// wait returns the remaining duration of the window, and closes the window if it ends.
+func (w *safeModeWindow) wait(generation int) time.Duration {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if generation != w.generation {
+		return 0
+	}
+	remaining := time.Until(w.end)
+	if remaining <= 0 {
+		w.active = false
+	}
+	return remaining
+}
+
+// close closes the window before it ends.
+func (w *safeModeWindow) close(generation int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if generation == w.generation {
+		w.active = false
+	}
+}
+
+// status returns whether safe-mode is enabled and the remaining duration of the window.
+func (w *safeModeWindow) status() (bool, time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.safeMode == nil {
+		return false, 0
+	}
+	var remaining time.Duration
+	if w.active {
+		remaining = time.Until(w.end)
+		if remaining < 0 {
+			remaining = 0
+		}
 	}
+	return w.safeMode.Enable(), remaining
 }
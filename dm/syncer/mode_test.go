@@ -0,0 +1,103 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"time"
+
+	. "github.com/pingcap/check"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/dm/pb"
+	"github.com/pingcap/ticdc/dm/pkg/binlog"
+	tcontext "github.com/pingcap/ticdc/dm/pkg/context"
+	"github.com/pingcap/ticdc/dm/pkg/utils"
+	sm "github.com/pingcap/ticdc/dm/syncer/safe-mode"
+)
+
+var _ = Suite(&modeSuite{})
+
+type modeSuite struct{}
+
+type mockSafeModeCheckpoint struct {
+	CheckPoint
+}
+
+func (*mockSafeModeCheckpoint) SafeModeExitPoint() *binlog.Location {
+	return nil
+}
+
+func (t *modeSuite) TestSafeModeWindow(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tctx := tcontext.NewContext(ctx, tcontext.Background().L())
+
+	s := &Syncer{
+		cfg:          &config.SubTaskConfig{SyncerConfig: config.SyncerConfig{CheckpointFlushInterval: 1, SafeModeDuration: "0s"}},
+		tctx:         tctx,
+		checkpoint:   &mockSafeModeCheckpoint{},
+		runFatalChan: make(chan *pb.ProcessError, 1),
+	}
+	enabled, remaining := s.safeModeWindow.status()
+	c.Assert(enabled, IsFalse)
+	c.Assert(remaining, Equals, time.Duration(0))
+
+	// `0s` disables the automatically engaged safe-mode
+	s.safeMode = sm.NewSafeMode()
+	s.enableSafeModeInitializationPhase(tctx)
+	enabled, remaining = s.safeModeWindow.status()
+	c.Assert(enabled, IsFalse)
+	c.Assert(remaining, Equals, time.Duration(0))
+
+	// engaged after the syncer restarts
+	s.cfg.SafeModeDuration = "300ms"
+	s.safeMode = sm.NewSafeMode()
+	s.enableSafeModeInitializationPhase(tctx)
+	enabled, remaining = s.safeModeWindow.status()
+	c.Assert(enabled, IsTrue)
+	c.Assert(remaining, Greater, time.Duration(0))
+	c.Assert(remaining <= 300*time.Millisecond, IsTrue)
+
+	// extended after the checkpoint regresses
+	s.cfg.SafeModeDuration = "1s"
+	s.engageSafeModeWindow(tctx, "checkpoint regression")
+	enabled, remaining = s.safeModeWindow.status()
+	c.Assert(enabled, IsTrue)
+	c.Assert(remaining > 300*time.Millisecond, IsTrue)
+	time.Sleep(500 * time.Millisecond)
+	enabled, _ = s.safeModeWindow.status()
+	c.Assert(enabled, IsTrue)
+
+	c.Assert(utils.WaitSomething(30, 100*time.Millisecond, func() bool {
+		enabled, _ = s.safeModeWindow.status()
+		return !enabled
+	}), IsTrue)
+	_, remaining = s.safeModeWindow.status()
+	c.Assert(remaining, Equals, time.Duration(0))
+
+	// the window of the previous run doesn't affect the new run
+	s.engageSafeModeWindow(tctx, "checkpoint regression")
+	prevSafeMode := s.safeMode
+	s.safeMode = sm.NewSafeMode()
+	s.cfg.SafeModeDuration = "0s"
+	s.enableSafeModeInitializationPhase(tctx)
+	enabled, _ = s.safeModeWindow.status()
+	c.Assert(enabled, IsFalse)
+	c.Assert(prevSafeMode.Enable(), IsTrue)
+	cancel()
+	c.Assert(utils.WaitSomething(30, 100*time.Millisecond, func() bool {
+		return !prevSafeMode.Enable()
+	}), IsTrue)
+}
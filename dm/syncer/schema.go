@@ -107,6 +107,7 @@ func (s *Syncer) OperateSchema(ctx context.Context, req *pb.OperateWorkerSchemaR
 		}
 
 		s.exprFilterGroup.ResetExprs(sourceTable)
+		s.columnTransform.ResetTransforms(sourceTable)
 
 		if !req.Flush && !req.Sync {
 			break
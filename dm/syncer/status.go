@@ -36,6 +36,7 @@ func (s *Syncer) Status(sourceStatus *binlog.SourceStatus) interface{} {
 		RecentTps:           s.tps.Load(),
 		SyncerBinlog:        syncerLocation.Position.String(),
 		SecondsBehindMaster: s.secondsBehindMaster.Load(),
+		Validation:          s.validator.status(),
 	}
 
 	if syncerLocation.GetGTID() != nil {
@@ -112,6 +113,7 @@ func (s *Syncer) printStatus(sourceStatus *binlog.SourceStatus) {
 		s.currentLocationMu.RUnlock()
 
 		remainingSize := sourceStatus.Binlogs.After(currentLocation.Position)
+		metrics.RemainingBinlogSizeGauge.WithLabelValues(s.cfg.Name, s.cfg.SourceID, s.cfg.WorkerName).Set(float64(remainingSize))
 		bytesPerSec := (totalBinlogSize - lastBinlogSize) / seconds
 		if bytesPerSec > 0 {
 			remainingSeconds := remainingSize / bytesPerSec
@@ -126,6 +128,9 @@ func (s *Syncer) printStatus(sourceStatus *binlog.SourceStatus) {
 		}
 	}
 
+	safeMode, safeModeWindow := s.safeModeWindow.status()
+	metrics.SafeModeWindowGauge.WithLabelValues(s.cfg.Name, s.cfg.SourceID, s.cfg.WorkerName).Set(safeModeWindow.Seconds())
+
 	latestMasterPos := sourceStatus.Location.Position
 	latestMasterGTIDSet := sourceStatus.Location.GetGTID()
 	metrics.BinlogPosGauge.WithLabelValues("master", s.cfg.Name, s.cfg.SourceID).Set(float64(latestMasterPos.Pos))
@@ -142,6 +147,8 @@ func (s *Syncer) printStatus(sourceStatus *binlog.SourceStatus) {
 		zap.Int64("tps", tps),
 		zap.Stringer("master_position", latestMasterPos),
 		log.WrapStringerField("master_gtid", latestMasterGTIDSet),
+		zap.Bool("safe_mode", safeMode),
+		zap.Duration("safe_mode_window", safeModeWindow),
 		zap.Stringer("checkpoint", s.checkpoint))
 
 	s.lastCount.Store(total)
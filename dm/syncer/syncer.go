@@ -35,7 +35,6 @@ import (
 	"github.com/pingcap/tidb-tools/pkg/dbutil"
 	"github.com/pingcap/tidb-tools/pkg/filter"
 	router "github.com/pingcap/tidb-tools/pkg/table-router"
-	toolutils "github.com/pingcap/tidb-tools/pkg/utils"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/format"
@@ -161,6 +160,7 @@ type Syncer struct {
 	columnMapping   *cm.Mapping
 	baList          *filter.Filter
 	exprFilterGroup *ExprFilterGroup
+	columnTransform *ColumnTransformGroup
 	sessCtx         sessionctx.Context
 
 	closed atomic.Bool
@@ -172,6 +172,8 @@ type Syncer struct {
 	// For each binlog event, we will set the current value into eventContext because
 	// the status of this track may change over time.
 	safeMode *sm.SafeMode
+	// safeModeWindow engages safe-mode for a period of time after the syncer restarts or its checkpoint regresses.
+	safeModeWindow safeModeWindow
 
 	timezone *time.Location
 
@@ -223,6 +225,8 @@ type Syncer struct {
 
 	tsOffset                  atomic.Int64    // time offset between upstream and syncer, DM's timestamp - MySQL's timestamp
 	secondsBehindMaster       atomic.Int64    // current task delay second behind upstream
+	heartbeat                 *heartbeat      // measures the lag by the heartbeats of upstream if enabled
+	validator                 *validator      // validates the replicated rows with the downstream if enabled
 	workerJobTSArray          []*atomic.Int64 // worker's sync job TS array, note that idx=0 is skip idx and idx=1 is ddl idx,sql worker job idx=(queue id + 2)
 	lastCheckpointFlushedTime time.Time
 
@@ -250,7 +254,7 @@ func NewSyncer(cfg *config.SubTaskConfig, etcdClient *clientv3.Client, relay rel
 	syncer.addJobFunc = syncer.addJob
 	syncer.cli = etcdClient
 
-	syncer.checkpoint = NewRemoteCheckPoint(syncer.tctx, cfg, syncer.checkpointID())
+	syncer.checkpoint = NewRemoteCheckPoint(syncer.tctx, cfg, etcdClient, syncer.checkpointID())
 
 	syncer.binlogType = toBinlogType(relay)
 	syncer.errOperatorHolder = operator.NewHolder(&logger)
@@ -314,6 +318,8 @@ func (s *Syncer) Init(ctx context.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	s.heartbeat = newHeartbeat(s.cfg)
+	s.validator = newValidator(s.cfg)
 
 	err = s.createDBs(ctx)
 	if err != nil {
@@ -343,6 +349,7 @@ func (s *Syncer) Init(ctx context.Context) (err error) {
 	}
 	s.sessCtx = utils.NewSessionCtx(vars)
 	s.exprFilterGroup = NewExprFilterGroup(s.sessCtx, s.cfg.ExprFilter)
+	s.columnTransform = NewColumnTransformGroup(s.sessCtx, s.cfg.ColumnTransforms)
 
 	if len(s.cfg.ColumnMappingRules) > 0 {
 		s.columnMapping, err = cm.NewMapping(s.cfg.CaseSensitive, s.cfg.ColumnMappingRules)
@@ -421,6 +428,10 @@ func (s *Syncer) Init(ctx context.Context) (err error) {
 	}
 	rollbackHolder.Add(fr.FuncRollback{Name: "remove-active-realylog", Fn: s.removeActiveRelayLog})
 
+	if err = s.loadErrorOperators(ctx); err != nil {
+		return err
+	}
+
 	s.reset()
 	return nil
 }
@@ -835,6 +846,11 @@ func (s *Syncer) updateReplicationLagMetric() {
 	}
 	metrics.ReplicationLagHistogram.WithLabelValues(s.cfg.Name, s.cfg.SourceID, s.cfg.WorkerName).Observe(float64(lag))
 	metrics.ReplicationLagGauge.WithLabelValues(s.cfg.Name, s.cfg.SourceID, s.cfg.WorkerName).Set(float64(lag))
+	// the lag measured by the heartbeats is more accurate if enabled
+	if heartbeatLag, ok := s.heartbeat.lag(time.Now()); ok {
+		metrics.HeartbeatLagGauge.WithLabelValues(s.cfg.Name, s.cfg.SourceID, s.cfg.WorkerName).Set(heartbeatLag)
+		lag = int64(heartbeatLag)
+	}
 	s.secondsBehindMaster.Store(lag)
 
 	failpoint.Inject("ShowLagInLog", func(v failpoint.Value) {
@@ -1345,13 +1361,14 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 	var (
 		flushCheckpoint bool
 		delLoadTask     bool
-		cleanDumpFile   = s.cfg.CleanDumpFile
+		// the dump given by the user is never cleaned, such as a nightly dump
+		cleanDumpFile = s.cfg.CleanDumpFile && !s.cfg.IsDumpGivenByUser()
 	)
 	flushCheckpoint, err = s.adjustGlobalPointGTID(tctx)
 	if err != nil {
 		return err
 	}
-	if s.cfg.Mode == config.ModeAll && fresh {
+	if (s.cfg.Mode == config.ModeAll || s.cfg.Mode == config.ModeLoadSync) && fresh {
 		delLoadTask = true
 		flushCheckpoint = true
 		err = s.loadTableStructureFromDump(ctx)
@@ -1445,6 +1462,22 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 	s.wg.Add(1)
 	go s.syncDDL(tctx, adminQueueName, s.ddlDBConn, s.ddlJobCh)
 
+	if s.heartbeat != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.heartbeat.run(runCtx, s.fromDB.BaseDB.DB, tctx.L())
+		}()
+	}
+
+	if s.validator != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.validator.run(runCtx, s.toDB.DB, tctx.L())
+		}()
+	}
+
 	s.wg.Add(1)
 	go func() {
 		defer s.wg.Done()
@@ -1665,6 +1698,10 @@ func (s *Syncer) Run(ctx context.Context) (err error) {
 				if err != nil {
 					return err
 				}
+				// the events after the global checkpoint are replicated again
+				if binlog.CompareLocation(s.checkpoint.GlobalPoint(), lastLocation, s.cfg.EnableGTID) < 0 {
+					s.engageSafeModeWindow(tctx, "checkpoint regression")
+				}
 				log.L().Info("reset replication binlog puller", zap.Any("pos", s.checkpoint.GlobalPoint()))
 				if err = maybeSkipNRowsEvent(eventIndex); err != nil {
 					return err
@@ -1974,6 +2011,7 @@ func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) err
 		Name:   string(ev.Table.Table),
 	}
 	targetTable := s.route(sourceTable)
+	s.heartbeat.handleRows(sourceTable, ev.Rows)
 
 	*ec.currentLocation = binlog.InitLocation(
 		mysql.Position{
@@ -2044,6 +2082,10 @@ func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) err
 	if err != nil {
 		return err
 	}
+	rows, err = s.columnTransform.TransformRows(sourceTable, tableInfo, rows)
+	if err != nil {
+		return err
+	}
 	if err2 := checkLogColumns(ev.SkippedColumns); err2 != nil {
 		return err2
 	}
@@ -2114,6 +2156,8 @@ func (s *Syncer) handleRowsEvent(ev *replication.RowsEvent, ec eventContext) err
 		return nil
 	}
 
+	s.validator.handleDMLs(dmls, time.Now())
+
 	startTime := time.Now()
 	for i := range dmls {
 		job := newDMLJob(jobType, sourceTable, targetTable, dmls[i], &ec)
@@ -2791,6 +2835,7 @@ func (s *Syncer) trackDDL(usedSchema string, trackInfo *ddlInfo, ec *eventContex
 			return terror.ErrSchemaTrackerCannotExecDDL.Delegate(err, trackInfo.originDDL)
 		}
 		s.exprFilterGroup.ResetExprs(srcTable)
+		s.columnTransform.ResetTransforms(srcTable)
 	}
 
 	return nil
@@ -2883,11 +2928,11 @@ func (s *Syncer) createDBs(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	conn, err := s.fromDB.BaseDB.GetBaseConn(ctx)
+	baseConn, err := s.fromDB.BaseDB.GetBaseConn(ctx)
 	if err != nil {
 		return err
 	}
-	lcFlavor, err := utils.FetchLowerCaseTableNamesSetting(ctx, conn.DBConn)
+	lcFlavor, err := utils.FetchLowerCaseTableNamesSetting(ctx, baseConn.DBConn)
 	if err != nil {
 		return err
 	}
@@ -2927,6 +2972,11 @@ func (s *Syncer) createDBs(ctx context.Context) error {
 		dbconn.CloseUpstreamConn(s.tctx, s.fromDB) // release resources acquired before return with error
 		return err
 	}
+	if s.cfg.PreparedStmtCacheSize > 0 {
+		for _, toDBConn := range s.toDBConns {
+			toDBConn.StmtCache = conn.NewStmtCache(s.cfg.PreparedStmtCacheSize)
+		}
+	}
 	// baseConn for ddl
 	dbCfg = s.cfg.To
 	dbCfg.RawDBCfg = config.DefaultRawDBConfig().SetReadTimeout(maxDDLConnectionTimeout)
@@ -2948,6 +2998,11 @@ func (s *Syncer) createDBs(ctx context.Context) error {
 
 // closeBaseDB closes all opened DBs, rollback for createConns.
 func (s *Syncer) closeDBs() {
+	for _, toDBConn := range s.toDBConns {
+		if toDBConn.StmtCache != nil {
+			toDBConn.StmtCache.Clear(s.tctx)
+		}
+	}
 	dbconn.CloseUpstreamConn(s.tctx, s.fromDB)
 	dbconn.CloseBaseDB(s.tctx, s.toDB)
 	dbconn.CloseBaseDB(s.tctx, s.ddlDB)
@@ -3251,12 +3306,11 @@ func (s *Syncer) setSyncCfg() error {
 		if loadErr := s.cfg.From.Security.LoadTLSContent(); loadErr != nil {
 			return terror.ErrCtlLoadTLSCfg.Delegate(loadErr)
 		}
-		tlsConfig, err = toolutils.ToTLSConfigWithVerifyByRawbytes(s.cfg.From.Security.SSLCABytes,
-			s.cfg.From.Security.SSLCertBytes, s.cfg.From.Security.SSLKEYBytes, s.cfg.From.Security.CertAllowedCN)
+		tlsConfig, err = s.cfg.From.Security.ToTLSConfig(s.cfg.From.Host)
 		if err != nil {
 			return terror.ErrConnInvalidTLSConfig.Delegate(err)
 		}
-		if tlsConfig != nil {
+		if tlsConfig != nil && s.cfg.From.Security.SSLVerifyMode == "" {
 			tlsConfig.InsecureSkipVerify = true
 		}
 	}
@@ -0,0 +1,431 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb-tools/pkg/dbutil"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/dm/pb"
+	"github.com/pingcap/ticdc/dm/pkg/log"
+	"github.com/pingcap/ticdc/dm/syncer/metrics"
+)
+
+const (
+	validationResultMatched = "matched"
+	validationResultError   = "error"
+	validationResultSkipped = "skipped"
+
+	// the types of the row errors.
+	validationErrMissing    = "missing"    // the row is not found in the downstream
+	validationErrRedundant  = "redundant"  // the deleted row is still found in the downstream
+	validationErrMismatched = "mismatched" // the columns of the row are different in the downstream
+
+	// the max count of the row errors kept for the status, the older ones are dropped.
+	maxValidationErrors = 100
+
+	validationTimeLayout = "2006-01-02 15:04:05"
+)
+
+// pendingRow is a row changed by the binlog which is waiting to be validated with the downstream.
+type pendingRow struct {
+	table   string // the quoted target table
+	keyCols []*model.ColumnInfo
+	keyVals []interface{}
+	key     string
+	// the columns and values of the row, values is nil if the row should be absent in the downstream.
+	columns []*model.ColumnInfo
+	values  []interface{}
+	changed time.Time
+}
+
+// validator validates the rows changed by the replicated binlog with the rows in the downstream continuously.
+// a changed row is validated after the check interval, and a mismatched row is validated again until
+// the row error delay passes, to not report the rows which are not replicated yet as errors.
+type validator struct {
+	mode          string
+	sampleRate    int
+	batchSize     int
+	checkInterval time.Duration
+	rowErrorDelay time.Duration
+
+	task     string
+	sourceID string
+	worker   string
+
+	mu      sync.Mutex
+	pending map[string]*pendingRow // table and key -> row
+	errors  []*pb.ValidationError
+
+	validated atomic.Int64
+	errorRows atomic.Int64
+	skipped   atomic.Int64
+}
+
+// newValidator creates a validator from the syncer config, nil is returned if the validation is disabled.
+func newValidator(cfg *config.SubTaskConfig) *validator {
+	vcfg := cfg.ValidatorCfg
+	if vcfg.Mode == "" || vcfg.Mode == config.ValidationNone {
+		return nil
+	}
+	checkInterval, rowErrorDelay := vcfg.Durations()
+	return &validator{
+		mode:          vcfg.Mode,
+		sampleRate:    vcfg.SampleRate,
+		batchSize:     vcfg.BatchSize,
+		checkInterval: checkInterval,
+		rowErrorDelay: rowErrorDelay,
+		task:          cfg.Name,
+		sourceID:      cfg.SourceID,
+		worker:        cfg.WorkerName,
+		pending:       make(map[string]*pendingRow),
+	}
+}
+
+// handleDMLs records the rows changed by the DMLs as pending rows.
+// the rows of the tables without a primary key or a not null unique key in the downstream are skipped.
+func (v *validator) handleDMLs(dmls []*DML, now time.Time) {
+	if v == nil {
+		return
+	}
+	for _, dml := range dmls {
+		if dml.downstreamTableInfo == nil || dml.downstreamTableInfo.AbsoluteUKIndexInfo == nil {
+			v.skipped.Inc()
+			metrics.ValidatorRowsCounter.WithLabelValues(validationResultSkipped, v.task, v.sourceID).Inc()
+			continue
+		}
+		keyCols := make([]*model.ColumnInfo, 0, len(dml.downstreamTableInfo.AbsoluteUKIndexInfo.Columns))
+		for _, col := range dml.downstreamTableInfo.AbsoluteUKIndexInfo.Columns {
+			keyCols = append(keyCols, dml.columns[col.Offset])
+		}
+
+		switch dml.op {
+		case insert:
+			v.addRow(dml.targetTableID, keyCols, dml.identifyValues(), dml.columns, dml.values, now)
+		case update:
+			if dml.updateIdentify() {
+				v.addRow(dml.targetTableID, keyCols, dml.oldIdentifyValues(), keyCols, nil, now)
+			}
+			v.addRow(dml.targetTableID, keyCols, dml.identifyValues(), dml.columns, dml.values, now)
+		case del:
+			v.addRow(dml.targetTableID, keyCols, dml.identifyValues(), keyCols, nil, now)
+		}
+	}
+}
+
+func (v *validator) addRow(table string, keyCols []*model.ColumnInfo, keyVals []interface{}, columns []*model.ColumnInfo, values []interface{}, now time.Time) {
+	keyStrs := make([]string, 0, len(keyVals))
+	for i, val := range keyVals {
+		keyStrs = append(keyStrs, columnValue(val, &keyCols[i].FieldType))
+	}
+	key := strings.Join(keyStrs, ",")
+	if v.mode == config.ValidationSample && int(crc32.ChecksumIEEE([]byte(table+key))%100) >= v.sampleRate {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	// a later change of the row replaces the earlier one, which is validated with the latest values.
+	v.pending[table+"."+key] = &pendingRow{
+		table:   table,
+		keyCols: keyCols,
+		keyVals: keyVals,
+		key:     key,
+		columns: columns,
+		values:  values,
+		changed: now,
+	}
+}
+
+// run validates the pending rows with the downstream periodically until ctx is done.
+func (v *validator) run(ctx context.Context, db *sql.DB, logger log.Logger) {
+	if v == nil {
+		return
+	}
+	ticker := time.NewTicker(v.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		v.validate(ctx, db, time.Now(), logger)
+	}
+}
+
+// validate validates the pending rows changed at least the check interval before now.
+func (v *validator) validate(ctx context.Context, db *sql.DB, now time.Time, logger log.Logger) {
+	// the rows of a table are selected together if they have the same columns.
+	groups := make(map[string][]*pendingRow)
+	v.mu.Lock()
+	for _, row := range v.pending {
+		if now.Sub(row.changed) < v.checkInterval {
+			continue
+		}
+		names := make([]string, 0, len(row.columns))
+		for _, col := range row.columns {
+			names = append(names, col.Name.O)
+		}
+		group := row.table + "(" + strings.Join(names, ",") + ")"
+		groups[group] = append(groups[group], row)
+	}
+	v.mu.Unlock()
+
+	for _, rows := range groups {
+		for start := 0; start < len(rows); start += v.batchSize {
+			end := start + v.batchSize
+			if end > len(rows) {
+				end = len(rows)
+			}
+			downstreamRows, err := v.selectRows(ctx, db, rows[start:end])
+			if err != nil {
+				// keep the rows pending to validate them again.
+				logger.Warn("fail to select the rows from the downstream to validate", zap.String("table", rows[start].table), log.ShortError(err))
+				continue
+			}
+			for _, row := range rows[start:end] {
+				v.checkRow(row, findDownstreamRow(row, downstreamRows), now)
+			}
+		}
+	}
+
+	v.mu.Lock()
+	pendingRows := len(v.pending)
+	v.mu.Unlock()
+	metrics.ValidatorPendingRowsGauge.WithLabelValues(v.task, v.sourceID, v.worker).Set(float64(pendingRows))
+}
+
+// selectRows selects the rows with the keys of the pending rows, which have the same table and columns.
+func (v *validator) selectRows(ctx context.Context, db *sql.DB, rows []*pendingRow) ([][]sql.NullString, error) {
+	columns := make([]string, 0, len(rows[0].columns))
+	for _, col := range rows[0].columns {
+		columns = append(columns, dbutil.ColumnName(col.Name.O))
+	}
+	keyCols := make([]string, 0, len(rows[0].keyCols))
+	for _, col := range rows[0].keyCols {
+		keyCols = append(keyCols, dbutil.ColumnName(col.Name.O))
+	}
+	holders := make([]string, 0, len(rows))
+	args := make([]interface{}, 0, len(rows)*len(keyCols))
+	for _, row := range rows {
+		holders = append(holders, valuesHolder(len(keyCols)))
+		args = append(args, row.keyVals...)
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE (%s) IN (%s)",
+		strings.Join(columns, ","), rows[0].table, strings.Join(keyCols, ","), strings.Join(holders, ","))
+
+	sqlRows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlRows.Close()
+
+	var result [][]sql.NullString
+	for sqlRows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err = sqlRows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		result = append(result, values)
+	}
+	return result, sqlRows.Err()
+}
+
+// findDownstreamRow returns the downstream row with the key of the pending row, or nil if not found.
+func findDownstreamRow(row *pendingRow, downstreamRows [][]sql.NullString) []sql.NullString {
+	keyIdx := make([]int, 0, len(row.keyCols))
+	for _, keyCol := range row.keyCols {
+		for i, col := range row.columns {
+			if col.Name.L == keyCol.Name.L {
+				keyIdx = append(keyIdx, i)
+				break
+			}
+		}
+	}
+	for _, downstreamRow := range downstreamRows {
+		matched := true
+		for i, idx := range keyIdx {
+			if !validationValueEqual(row.keyVals[i], row.keyCols[i], downstreamRow[idx]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return downstreamRow
+		}
+	}
+	return nil
+}
+
+// checkRow compares the pending row with the downstream row, the row is removed from the pending rows if
+// it's matched, or it's still mismatched after the row error delay.
+func (v *validator) checkRow(row *pendingRow, downstreamRow []sql.NullString, now time.Time) {
+	errType := ""
+	switch {
+	case row.values == nil && downstreamRow != nil:
+		errType = validationErrRedundant
+	case row.values != nil && downstreamRow == nil:
+		errType = validationErrMissing
+	case row.values != nil:
+		for i, col := range row.columns {
+			if !validationValueEqual(row.values[i], col, downstreamRow[i]) {
+				errType = validationErrMismatched
+				break
+			}
+		}
+	}
+	if errType != "" && now.Sub(row.changed) < v.rowErrorDelay {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	// the row may be changed again during the validation, which is validated later.
+	pendingKey := row.table + "." + row.key
+	if v.pending[pendingKey] != row {
+		return
+	}
+	delete(v.pending, pendingKey)
+
+	if errType == "" {
+		v.validated.Inc()
+		metrics.ValidatorRowsCounter.WithLabelValues(validationResultMatched, v.task, v.sourceID).Inc()
+		return
+	}
+	v.errorRows.Inc()
+	metrics.ValidatorRowsCounter.WithLabelValues(validationResultError, v.task, v.sourceID).Inc()
+	v.errors = append(v.errors, &pb.ValidationError{
+		Table:      row.table,
+		Key:        row.key,
+		ErrType:    errType,
+		Upstream:   formatUpstreamRow(row),
+		Downstream: formatDownstreamRow(downstreamRow),
+		Time:       now.Format(validationTimeLayout),
+	})
+	if len(v.errors) > maxValidationErrors {
+		v.errors = v.errors[len(v.errors)-maxValidationErrors:]
+	}
+}
+
+// status returns the status of the validation, nil is returned if the validation is disabled.
+func (v *validator) status() *pb.ValidationStatus {
+	if v == nil {
+		return nil
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return &pb.ValidationStatus{
+		Mode:          v.mode,
+		ValidatedRows: v.validated.Load(),
+		PendingRows:   int64(len(v.pending)),
+		ErrorRows:     v.errorRows.Load(),
+		SkippedRows:   v.skipped.Load(),
+		Errors:        append([]*pb.ValidationError(nil), v.errors...),
+	}
+}
+
+func formatUpstreamRow(row *pendingRow) string {
+	if row.values == nil {
+		return ""
+	}
+	values := make([]string, 0, len(row.values))
+	for i, col := range row.columns {
+		values = append(values, columnValue(row.values[i], &col.FieldType))
+	}
+	return "(" + strings.Join(values, ",") + ")"
+}
+
+func formatDownstreamRow(row []sql.NullString) string {
+	if row == nil {
+		return ""
+	}
+	values := make([]string, 0, len(row))
+	for _, value := range row {
+		if value.Valid {
+			values = append(values, value.String)
+		} else {
+			values = append(values, "null")
+		}
+	}
+	return "(" + strings.Join(values, ",") + ")"
+}
+
+// validationValueEqual compares the value from the binlog with the value selected from the downstream.
+func validationValueEqual(value interface{}, col *model.ColumnInfo, downstream sql.NullString) bool {
+	if value == nil || !downstream.Valid {
+		return value == nil && !downstream.Valid
+	}
+
+	switch col.Tp {
+	case mysql.TypeBit:
+		// the bits are not comparable with the strings selected.
+		return true
+	case mysql.TypeFloat, mysql.TypeDouble, mysql.TypeNewDecimal:
+		expected, err1 := strconv.ParseFloat(columnValue(value, &col.FieldType), 64)
+		actual, err2 := strconv.ParseFloat(downstream.String, 64)
+		if err1 != nil || err2 != nil {
+			break
+		}
+		// the float values may lose precision in the binlog.
+		return math.Abs(expected-actual) <= 1e-6*math.Max(1, math.Max(math.Abs(expected), math.Abs(actual)))
+	case mysql.TypeJSON:
+		var expected, actual interface{}
+		if json.Unmarshal([]byte(columnValue(value, &col.FieldType)), &expected) != nil ||
+			json.Unmarshal([]byte(downstream.String), &actual) != nil {
+			break
+		}
+		return reflect.DeepEqual(expected, actual)
+	case mysql.TypeEnum:
+		// the binlog holds the index of the element, starting from 1.
+		if idx, ok := value.(int64); ok {
+			if idx > 0 && int(idx) <= len(col.Elems) {
+				return col.Elems[idx-1] == downstream.String
+			}
+			return downstream.String == ""
+		}
+	case mysql.TypeSet:
+		// the binlog holds the bitmask of the elements.
+		if mask, ok := value.(int64); ok {
+			elems := make([]string, 0, len(col.Elems))
+			for i, elem := range col.Elems {
+				if mask&(1<<uint(i)) != 0 {
+					elems = append(elems, elem)
+				}
+			}
+			return strings.Join(elems, ",") == downstream.String
+		}
+	}
+	return columnValue(value, &col.FieldType) == downstream.String
+}
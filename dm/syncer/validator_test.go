@@ -0,0 +1,163 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncer
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb-tools/pkg/filter"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/mock"
+
+	"github.com/pingcap/ticdc/dm/dm/config"
+	"github.com/pingcap/ticdc/dm/pkg/log"
+	"github.com/pingcap/ticdc/dm/pkg/schema"
+)
+
+var _ = Suite(&testValidatorSuite{})
+
+type testValidatorSuite struct{}
+
+func newTestValidatorConfig(mode string) *config.SubTaskConfig {
+	cfg := &config.SubTaskConfig{Name: "task", SourceID: "source"}
+	cfg.ValidatorCfg = config.ValidatorConfig{Mode: mode}
+	_ = cfg.ValidatorCfg.Adjust()
+	return cfg
+}
+
+func (t *testValidatorSuite) TestNewValidator(c *C) {
+	c.Assert(newValidator(&config.SubTaskConfig{}), IsNil)
+	c.Assert(newValidator(newTestValidatorConfig(config.ValidationNone)), IsNil)
+
+	v := newValidator(newTestValidatorConfig(config.ValidationFull))
+	c.Assert(v.checkInterval, Equals, 5*time.Second)
+	c.Assert(v.rowErrorDelay, Equals, 30*time.Second)
+	c.Assert(v.batchSize, Equals, 100)
+
+	// the methods of a disabled validator do nothing
+	v = nil
+	v.handleDMLs([]*DML{{}}, time.Now())
+	v.run(context.Background(), nil, log.L())
+	c.Assert(v.status(), IsNil)
+}
+
+func (t *testValidatorSuite) TestValidatorValidate(c *C) {
+	ti, err := createTableInfo(parser.New(), mock.NewContext(), 0, "create table test.tb(id int primary key, col1 int, name varchar(24))")
+	c.Assert(err, IsNil)
+	downTi := schema.GetDownStreamTi(ti, ti)
+	targetTableID := "`test`.`tb`"
+	sourceTable := &filter.Table{Schema: "test", Name: "tb1"}
+	newTestDML := func(op opType, oldValues, values []interface{}, downTi *schema.DownstreamTableInfo) *DML {
+		return newDML(op, false, targetTableID, sourceTable, oldValues, values, oldValues, values, ti.Columns, ti, nil, downTi)
+	}
+
+	v := newValidator(newTestValidatorConfig(config.ValidationFull))
+	start := time.Date(2021, 12, 1, 10, 0, 0, 0, time.UTC)
+	v.handleDMLs([]*DML{
+		newTestDML(insert, nil, []interface{}{1, 1, "a"}, downTi),
+		newTestDML(insert, nil, []interface{}{2, 2, "b"}, downTi),
+		newTestDML(update, []interface{}{3, 3, "c"}, []interface{}{4, 3, "c"}, downTi),
+		newTestDML(del, nil, []interface{}{5, 5, "e"}, downTi),
+		// the rows of the tables without a primary key or a not null unique key are skipped
+		newTestDML(insert, nil, []interface{}{6, 6, "f"}, &schema.DownstreamTableInfo{TableInfo: ti}),
+	}, start)
+	st := v.status()
+	c.Assert(st.PendingRows, Equals, int64(5))
+	c.Assert(st.SkippedRows, Equals, int64(1))
+
+	db, dbMock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+	dbMock.MatchExpectationsInOrder(false)
+
+	// the rows are not validated before the check interval
+	v.validate(context.Background(), db, start.Add(time.Second), log.L())
+	c.Assert(dbMock.ExpectationsWereMet(), IsNil)
+
+	expectSelect := func() {
+		dbMock.ExpectQuery("SELECT `id`,`col1`,`name` FROM `test`.`tb` WHERE \\(`id`\\) IN ").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "col1", "name"}).AddRow("1", "1", "a").AddRow("2", "3", "b"))
+		dbMock.ExpectQuery("SELECT `id` FROM `test`.`tb` WHERE \\(`id`\\) IN ").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("3"))
+	}
+
+	// the matched rows are validated, and the mismatched rows are still pending before the row error delay
+	expectSelect()
+	v.validate(context.Background(), db, start.Add(5*time.Second), log.L())
+	c.Assert(dbMock.ExpectationsWereMet(), IsNil)
+	st = v.status()
+	c.Assert(st.ValidatedRows, Equals, int64(2))
+	c.Assert(st.PendingRows, Equals, int64(3))
+	c.Assert(st.ErrorRows, Equals, int64(0))
+
+	// the rows still mismatched after the row error delay are reported
+	expectSelect()
+	v.validate(context.Background(), db, start.Add(30*time.Second), log.L())
+	c.Assert(dbMock.ExpectationsWereMet(), IsNil)
+	st = v.status()
+	c.Assert(st.ValidatedRows, Equals, int64(2))
+	c.Assert(st.PendingRows, Equals, int64(0))
+	c.Assert(st.ErrorRows, Equals, int64(3))
+	errTypes := make(map[string]string)
+	for _, rowErr := range st.Errors {
+		c.Assert(rowErr.Table, Equals, targetTableID)
+		c.Assert(rowErr.Time, Equals, "2021-12-01 10:00:30")
+		errTypes[rowErr.Key] = rowErr.ErrType
+		if rowErr.Key == "2" {
+			c.Assert(rowErr.Upstream, Equals, "(2,2,b)")
+			c.Assert(rowErr.Downstream, Equals, "(2,3,b)")
+		}
+	}
+	c.Assert(errTypes, DeepEquals, map[string]string{"2": validationErrMismatched, "3": validationErrRedundant, "4": validationErrMissing})
+
+	// a failed query keeps the rows pending
+	v.handleDMLs([]*DML{newTestDML(insert, nil, []interface{}{1, 1, "a"}, downTi)}, start)
+	dbMock.ExpectQuery("SELECT").WillReturnError(sql.ErrConnDone)
+	v.validate(context.Background(), db, start.Add(time.Minute), log.L())
+	c.Assert(dbMock.ExpectationsWereMet(), IsNil)
+	c.Assert(v.status().PendingRows, Equals, int64(1))
+
+	// no row is sampled with the sample rate 0
+	cfg := newTestValidatorConfig(config.ValidationSample)
+	cfg.ValidatorCfg.SampleRate = 0
+	v = newValidator(cfg)
+	v.handleDMLs([]*DML{newTestDML(insert, nil, []interface{}{1, 1, "a"}, downTi)}, start)
+	c.Assert(v.status().PendingRows, Equals, int64(0))
+}
+
+func (t *testValidatorSuite) TestValidationValueEqual(c *C) {
+	ti, err := createTableInfo(parser.New(), mock.NewContext(), 0,
+		"create table tb(a int unsigned, b double, c json, d enum('x','y'), e set('x','y','z'), f bit(8), g datetime)")
+	c.Assert(err, IsNil)
+	col := func(i int) *model.ColumnInfo { return ti.Columns[i] }
+	str := func(s string) sql.NullString { return sql.NullString{String: s, Valid: true} }
+
+	c.Assert(validationValueEqual(nil, col(0), sql.NullString{}), IsTrue)
+	c.Assert(validationValueEqual(nil, col(0), str("0")), IsFalse)
+	c.Assert(validationValueEqual(int32(-1), col(0), sql.NullString{}), IsFalse)
+	c.Assert(validationValueEqual(int32(-1), col(0), str("4294967295")), IsTrue)
+	c.Assert(validationValueEqual(0.1, col(1), str("0.1000000001")), IsTrue)
+	c.Assert(validationValueEqual(0.1, col(1), str("0.2")), IsFalse)
+	c.Assert(validationValueEqual([]byte(`{"a": [1, 2]}`), col(2), str(`{"a":[1,2]}`)), IsTrue)
+	c.Assert(validationValueEqual([]byte(`{"a": 1}`), col(2), str(`{"a":2}`)), IsFalse)
+	c.Assert(validationValueEqual(int64(2), col(3), str("y")), IsTrue)
+	c.Assert(validationValueEqual(int64(1), col(3), str("y")), IsFalse)
+	c.Assert(validationValueEqual(int64(5), col(4), str("x,z")), IsTrue)
+	c.Assert(validationValueEqual(int64(5), col(5), str("\x05")), IsTrue)
+	c.Assert(validationValueEqual("2021-12-01 10:00:00", col(6), str("2021-12-01 10:00:00")), IsTrue)
+}
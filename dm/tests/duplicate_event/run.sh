@@ -78,8 +78,8 @@ function run() {
 	binlog_file=$(grep "File" $TEST_DIR/sql_res.$TEST_NAME.txt | awk -F: '{print $2}' | xargs)
 	binlog_pos=$(grep "Position" $TEST_DIR/sql_res.$TEST_NAME.txt | awk -F: '{print $2}' | xargs)
 
-	server_uuid=$(tail -n 1 $WORK_DIR/worker2/relay-dir/server-uuid.index)
-	relay_log_size=$(ls -al $WORK_DIR/worker2/relay-dir/$server_uuid/$binlog_file | awk '{print $5}')
+	server_uuid=$(tail -n 1 $WORK_DIR/worker2/relay-dir/$SOURCE_ID2/server-uuid.index)
+	relay_log_size=$(ls -al $WORK_DIR/worker2/relay-dir/$SOURCE_ID2/$server_uuid/$binlog_file | awk '{print $5}')
 	[ "$binlog_pos" -eq "$relay_log_size" ]
 }
 
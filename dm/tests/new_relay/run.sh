@@ -281,17 +281,17 @@ function run() {
 	# test purge-relay for all relay workers
 	run_sql_source1 "show binary logs\G"
 	max_binlog_name=$(grep Log_name "$SQL_RESULT_FILE" | tail -n 1 | awk -F":" '{print $NF}')
-	server_uuid_1=$(tail -n 1 $WORK_DIR/worker1/relay-dir/server-uuid.index)
-	relay_log_count_1=$(($(ls $WORK_DIR/worker1/relay-dir/$server_uuid_1 | wc -l) - 1))
-	server_uuid_2=$(tail -n 1 $WORK_DIR/worker2/relay-dir/server-uuid.index)
-	relay_log_count_2=$(($(ls $WORK_DIR/worker2/relay-dir/$server_uuid_2 | wc -l) - 1))
+	server_uuid_1=$(tail -n 1 $WORK_DIR/worker1/relay-dir/$SOURCE_ID1/server-uuid.index)
+	relay_log_count_1=$(($(ls $WORK_DIR/worker1/relay-dir/$SOURCE_ID1/$server_uuid_1 | wc -l) - 1))
+	server_uuid_2=$(tail -n 1 $WORK_DIR/worker2/relay-dir/$SOURCE_ID1/server-uuid.index)
+	relay_log_count_2=$(($(ls $WORK_DIR/worker2/relay-dir/$SOURCE_ID1/$server_uuid_2 | wc -l) - 1))
 	[ "$relay_log_count_1" -ne 1 ]
 	[ "$relay_log_count_2" -ne 1 ]
 	run_dm_ctl $WORK_DIR "127.0.0.1:$MASTER_PORT" \
 		"purge-relay --filename $max_binlog_name -s $SOURCE_ID1" \
 		"\"result\": true" 3
-	new_relay_log_count_1=$(($(ls $WORK_DIR/worker1/relay-dir/$server_uuid_1 | wc -l) - 1))
-	new_relay_log_count_2=$(($(ls $WORK_DIR/worker2/relay-dir/$server_uuid_2 | wc -l) - 1))
+	new_relay_log_count_1=$(($(ls $WORK_DIR/worker1/relay-dir/$SOURCE_ID1/$server_uuid_1 | wc -l) - 1))
+	new_relay_log_count_2=$(($(ls $WORK_DIR/worker2/relay-dir/$SOURCE_ID1/$server_uuid_2 | wc -l) - 1))
 	[ "$new_relay_log_count_1" -eq 1 ]
 	[ "$new_relay_log_count_2" -eq 1 ]
 
@@ -43,6 +43,9 @@ type RedoApplierConfig struct {
 	SinkURI string
 	Storage string
 	Dir     string
+	// TargetTs is the ts which the downstream is rolled back to after the redo
+	// logs are applied, 0 means the resolved ts of the redo logs.
+	TargetTs uint64
 }
 
 // RedoApplier implements a redo log applier
@@ -95,6 +98,22 @@ func (ra *RedoApplier) consumeLogs(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	targetTs := resolvedTs
+	if ra.cfg.TargetTs != 0 {
+		targetTs = ra.cfg.TargetTs
+	}
+	if targetTs < checkpointTs || targetTs > resolvedTs {
+		return cerror.ErrRedoConfigInvalid.GenWithStack(
+			"target-ts %d should be in the range of the redo logs [%d, %d]", targetTs, checkpointTs, resolvedTs)
+	}
+	if targetTs < resolvedTs {
+		// check before applying anything, so that the downstream is untouched
+		// if it can't be rolled back.
+		err = ra.checkRollback(ctx, targetTs, resolvedTs)
+		if err != nil {
+			return err
+		}
+	}
 	err = ra.rd.ResetReader(ctx, checkpointTs, resolvedTs)
 	if err != nil {
 		return err
@@ -168,9 +187,123 @@ func (ra *RedoApplier) consumeLogs(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if targetTs < resolvedTs {
+		err = ra.rollback(ctx, s, targetTs, resolvedTs)
+		if err != nil {
+			return err
+		}
+	}
 	return errApplyFinished
 }
 
+// checkRollback checks whether the downstream can be rolled back to targetTs,
+// the DDLs in (targetTs, resolvedTs] can't be rolled back, and neither can the
+// updates and deletes whose old values are not recorded in the redo logs.
+func (ra *RedoApplier) checkRollback(ctx context.Context, targetTs, resolvedTs uint64) error {
+	err := ra.rd.ResetReader(ctx, targetTs, resolvedTs)
+	if err != nil {
+		return err
+	}
+	ddls, err := ra.rd.ReadNextDDL(ctx, 1)
+	if err != nil {
+		return err
+	}
+	if len(ddls) != 0 {
+		return cerror.ErrRedoConfigInvalid.GenWithStack(
+			"can not roll back to target-ts %d across the DDL committed at %d", targetTs, ddls[0].DDL.CommitTs)
+	}
+	for {
+		redoLogs, err := ra.rd.ReadNextLog(ctx, readBatch)
+		if err != nil {
+			return err
+		}
+		if len(redoLogs) == 0 {
+			return nil
+		}
+		for _, redoLog := range redoLogs {
+			if !hasOldValue(redoLog) {
+				return cerror.ErrRedoConfigInvalid.GenWithStack(
+					"can not roll back to target-ts %d, the old value of the row committed at %d "+
+						"is not recorded, enable-old-value is required", targetTs, redoLog.Row.CommitTs)
+			}
+		}
+	}
+}
+
+// hasOldValue returns whether all the old columns of the given row are
+// recorded. Inserts have no old columns at all, while the deletes logged with
+// enable-old-value off keep only the handle key columns.
+func hasOldValue(redoLog *model.RedoRowChangedEvent) bool {
+	for _, column := range redoLog.PreColumns {
+		if column == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// rollback reverts the row changed events in (targetTs, resolvedTs] in the
+// reverse order, so that the downstream, which is consistent at resolvedTs
+// after applying the redo logs, is rolled back to be consistent at targetTs.
+// Note the old values of the rows are required to revert updates and deletes,
+// and the reverted rows are cached in memory.
+func (ra *RedoApplier) rollback(ctx context.Context, s sink.Sink, targetTs, resolvedTs uint64) error {
+	err := ra.rd.ResetReader(ctx, targetTs, resolvedTs)
+	if err != nil {
+		return err
+	}
+	var rows []*model.RowChangedEvent
+	for {
+		redoLogs, err := ra.rd.ReadNextLog(ctx, readBatch)
+		if err != nil {
+			return err
+		}
+		if len(redoLogs) == 0 {
+			break
+		}
+		for _, redoLog := range redoLogs {
+			rows = append(rows, redo.LogToRow(redoLog))
+		}
+	}
+	log.Info("roll back redo log starts", zap.Uint64("target-ts", targetTs),
+		zap.Uint64("resolved-ts", resolvedTs), zap.Int("rows", len(rows)))
+
+	lastCommitTs := resolvedTs
+	cachedRows := make([]*model.RowChangedEvent, 0, emitBatch)
+	for i := len(rows) - 1; i >= 0; i-- {
+		if len(cachedRows) >= emitBatch {
+			err := s.EmitRowChangedEvents(ctx, cachedRows...)
+			if err != nil {
+				return err
+			}
+			cachedRows = make([]*model.RowChangedEvent, 0, emitBatch)
+		}
+		row := revertRow(rows[i], resolvedTs)
+		cachedRows = append(cachedRows, row)
+		lastCommitTs = row.CommitTs
+	}
+	err = s.EmitRowChangedEvents(ctx, cachedRows...)
+	if err != nil {
+		return err
+	}
+	_, err = s.FlushRowChangedEvents(ctx, lastCommitTs)
+	if err != nil {
+		return err
+	}
+	return s.Barrier(ctx)
+}
+
+// revertRow returns the row changed event which reverts the given row. The sink
+// requires the commit ts of the rows to be increasing, so the commit ts of the
+// reverting row is mirrored by resolvedTs, which is larger than resolvedTs and
+// decreases as the commit ts of the given row increases.
+func revertRow(row *model.RowChangedEvent, resolvedTs uint64) *model.RowChangedEvent {
+	reverted := *row
+	reverted.Columns, reverted.PreColumns = row.PreColumns, row.Columns
+	reverted.CommitTs = 2*resolvedTs - row.CommitTs + 1
+	return &reverted
+}
+
 var createRedoReader = createRedoReaderImpl
 
 func createRedoReaderImpl(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
@@ -238,3 +238,208 @@ func TestApplyMeetSinkError(t *testing.T) {
 	err = ap.Apply(ctx)
 	require.Regexp(t, "CDC:ErrMySQLConnectionError", err)
 }
+
+// mockRewindableReader is a mock redo log reader which supports rewinding by
+// ResetReader.
+type mockRewindableReader struct {
+	MockReader
+	rows []*model.RowChangedEvent
+	ddls []*model.DDLEvent
+}
+
+// ResetReader implements LogReader.ResetReader
+func (br *mockRewindableReader) ResetReader(ctx context.Context, startTs, endTs uint64) error {
+	br.redoLogCh = make(chan *model.RedoRowChangedEvent, len(br.rows))
+	for _, row := range br.rows {
+		if row.CommitTs > startTs && row.CommitTs <= endTs {
+			br.redoLogCh <- redo.RowToRedo(row)
+		}
+	}
+	close(br.redoLogCh)
+	br.ddlEventCh = make(chan *model.RedoDDLEvent, len(br.ddls))
+	for _, ddl := range br.ddls {
+		if ddl.CommitTs > startTs && ddl.CommitTs <= endTs {
+			br.ddlEventCh <- redo.DDLToRedo(ddl)
+		}
+	}
+	close(br.ddlEventCh)
+	return nil
+}
+
+func TestApplyDMLsAndRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rows := []*model.RowChangedEvent{
+		{
+			StartTs:  1100,
+			CommitTs: 1200,
+			Table:    &model.TableName{Schema: "test", Table: "t1"},
+			Columns: []*model.Column{
+				{Name: "a", Value: 1, Flag: model.HandleKeyFlag},
+				{Name: "b", Value: "2", Flag: 0},
+			},
+		},
+		{
+			StartTs:  1200,
+			CommitTs: 1300,
+			Table:    &model.TableName{Schema: "test", Table: "t1"},
+			PreColumns: []*model.Column{
+				{Name: "a", Value: 1, Flag: model.HandleKeyFlag},
+				{Name: "b", Value: "2", Flag: 0},
+			},
+			Columns: []*model.Column{
+				{Name: "a", Value: 2, Flag: model.HandleKeyFlag},
+				{Name: "b", Value: "3", Flag: 0},
+			},
+		},
+		{
+			StartTs:  1300,
+			CommitTs: 1400,
+			Table:    &model.TableName{Schema: "test", Table: "t1"},
+			Columns: []*model.Column{
+				{Name: "a", Value: 3, Flag: model.HandleKeyFlag},
+				{Name: "b", Value: "4", Flag: 0},
+			},
+		},
+	}
+	createMockReader := func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return &mockRewindableReader{
+			MockReader: MockReader{checkpointTs: 1000, resolvedTs: 2000},
+			rows:       rows,
+			ddls:       []*model.DDLEvent{{CommitTs: 1150}},
+		}, nil
+	}
+
+	dbIndex := 0
+	mockGetDBConn := func(ctx context.Context, dsnStr string) (*sql.DB, error) {
+		defer func() {
+			dbIndex++
+		}()
+		if dbIndex == 0 {
+			// mock for test db, which is used querying TiDB session variable
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				return nil, err
+			}
+			columns := []string{"Variable_name", "Value"}
+			mock.ExpectQuery("show session variables like 'allow_auto_random_explicit_insert';").WillReturnRows(
+				sqlmock.NewRows(columns).AddRow("allow_auto_random_explicit_insert", "0"),
+			)
+			mock.ExpectQuery("show session variables like 'tidb_txn_mode';").WillReturnRows(
+				sqlmock.NewRows(columns).AddRow("tidb_txn_mode", "pessimistic"),
+			)
+			mock.ExpectClose()
+			return db, nil
+		}
+		// normal db
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		require.Nil(t, err)
+		// roll forward to the resolved ts
+		mock.ExpectBegin()
+		mock.ExpectExec("REPLACE INTO `test`.`t1`(`a`,`b`) VALUES (?,?)").
+			WithArgs(1, "2").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM `test`.`t1` WHERE `a` = ? LIMIT 1;").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("REPLACE INTO `test`.`t1`(`a`,`b`) VALUES (?,?)").
+			WithArgs(2, "3").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec("REPLACE INTO `test`.`t1`(`a`,`b`) VALUES (?,?)").
+			WithArgs(3, "4").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		// roll back to the target ts in the reverse order
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM `test`.`t1` WHERE `a` = ? LIMIT 1;").
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM `test`.`t1` WHERE `a` = ? LIMIT 1;").
+			WithArgs(2).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("REPLACE INTO `test`.`t1`(`a`,`b`) VALUES (?,?)").
+			WithArgs(1, "2").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectClose()
+		return db, nil
+	}
+
+	getDBConnBak := sink.GetDBConnImpl
+	sink.GetDBConnImpl = mockGetDBConn
+	createRedoReaderBak := createRedoReader
+	createRedoReader = createMockReader
+	defer func() {
+		createRedoReader = createRedoReaderBak
+		sink.GetDBConnImpl = getDBConnBak
+	}()
+
+	// the target ts is out of the range of the redo logs
+	cfg := &RedoApplierConfig{
+		SinkURI:  "mysql://127.0.0.1:4000/?worker-count=1&max-txn-row=1",
+		TargetTs: 900,
+	}
+	err := NewRedoApplier(cfg).Apply(ctx)
+	require.Regexp(t, ".*should be in the range of the redo logs.*", err)
+
+	// the DDLs can't be rolled back
+	cfg.TargetTs = 1100
+	err = NewRedoApplier(cfg).Apply(ctx)
+	require.Regexp(t, ".*across the DDL committed at 1150.*", err)
+
+	cfg.TargetTs = 1200
+	err = NewRedoApplier(cfg).Apply(ctx)
+	require.Nil(t, err)
+}
+
+func TestRollbackWithoutOldValue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rows := []*model.RowChangedEvent{
+		{
+			StartTs:  1100,
+			CommitTs: 1200,
+			Table:    &model.TableName{Schema: "test", Table: "t1"},
+			Columns: []*model.Column{
+				{Name: "a", Value: 1, Flag: model.HandleKeyFlag},
+				{Name: "b", Value: "2", Flag: 0},
+			},
+		},
+		{
+			// the delete logged with enable-old-value off
+			StartTs:  1200,
+			CommitTs: 1300,
+			Table:    &model.TableName{Schema: "test", Table: "t1"},
+			PreColumns: []*model.Column{
+				{Name: "a", Value: 1, Flag: model.HandleKeyFlag},
+				nil,
+			},
+		},
+	}
+	createMockReader := func(ctx context.Context, cfg *RedoApplierConfig) (reader.RedoLogReader, error) {
+		return &mockRewindableReader{
+			MockReader: MockReader{checkpointTs: 1000, resolvedTs: 2000},
+			rows:       rows,
+		}, nil
+	}
+	createRedoReaderBak := createRedoReader
+	createRedoReader = createMockReader
+	defer func() {
+		createRedoReader = createRedoReaderBak
+	}()
+
+	cfg := &RedoApplierConfig{
+		SinkURI:  "mysql://127.0.0.1:4000/?worker-count=1&max-txn-row=1",
+		TargetTs: 1100,
+	}
+	err := NewRedoApplier(cfg).Apply(ctx)
+	require.Regexp(t, ".*the old value of the row committed at 1300 is not recorded.*", err)
+}
@@ -0,0 +1,126 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/model"
+	cmdcontext "github.com/pingcap/ticdc/pkg/cmd/context"
+	"github.com/pingcap/ticdc/pkg/cmd/factory"
+	"github.com/pingcap/ticdc/pkg/cmd/util"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/ticdc/pkg/security"
+	"github.com/spf13/cobra"
+)
+
+// drainCapturePollInterval is the interval of checking whether the capture is drained.
+const drainCapturePollInterval = time.Second
+
+// drainCaptureOptions defines flags for the `cli capture drain` command.
+type drainCaptureOptions struct {
+	etcdClient *etcd.CDCEtcdClient
+
+	credential *security.Credential
+
+	captureID string
+	wait      bool
+	timeout   time.Duration
+}
+
+// newDrainCaptureOptions creates new drainCaptureOptions for the `cli capture drain` command.
+func newDrainCaptureOptions() *drainCaptureOptions {
+	return &drainCaptureOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *drainCaptureOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.captureID, "capture-id", "", "ID of the capture to be drained")
+	cmd.PersistentFlags().BoolVar(&o.wait, "wait", true, "Wait until all the tables are moved off the capture")
+	cmd.PersistentFlags().DurationVar(&o.timeout, "timeout", 10*time.Minute, "The maximum time to wait for the capture to be drained")
+	_ = cmd.MarkPersistentFlagRequired("capture-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *drainCaptureOptions) complete(f factory.Factory) error {
+	etcdClient, err := f.EtcdClient()
+	if err != nil {
+		return err
+	}
+
+	o.etcdClient = etcdClient
+
+	o.credential = f.GetCredential()
+
+	return nil
+}
+
+// run runs the `cli capture drain` command.
+func (o *drainCaptureOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	path := fmt.Sprintf("/api/v1/captures/%s/drain", o.captureID)
+	status := &model.DrainCaptureStatus{}
+	err := requestOwnerOpenAPI(ctx, o.etcdClient, http.MethodPost, path, nil, status, o.credential)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(o.timeout)
+	for o.wait && status.TableCount > 0 {
+		if time.Now().After(deadline) {
+			return errors.Errorf("capture %s is not drained in %s, %d tables left",
+				o.captureID, o.timeout, status.TableCount)
+		}
+		cmd.Printf("waiting for %d tables to be moved off capture %s\n", status.TableCount, o.captureID)
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-time.After(drainCapturePollInterval):
+		}
+		err := requestOwnerOpenAPI(ctx, o.etcdClient, http.MethodGet, path, nil, status, o.credential)
+		if err != nil {
+			return err
+		}
+	}
+
+	return util.JSONPrint(cmd, status)
+}
+
+// newCmdDrainCapture creates the `cli capture drain` command.
+func newCmdDrainCapture(f factory.Factory) *cobra.Command {
+	o := newDrainCaptureOptions()
+
+	command := &cobra.Command{
+		Use:   "drain",
+		Short: "Move all the tables off a capture before it is stopped, which is useful for rolling upgrades",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := o.complete(f)
+			if err != nil {
+				return err
+			}
+
+			return o.run(cmd)
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -73,14 +73,18 @@ func newCmdChangefeed(f factory.Factory) *cobra.Command {
 	}
 
 	cmds.AddCommand(newCmdCreateChangefeed(f))
+	cmds.AddCommand(newCmdCloneChangefeed(f))
 	cmds.AddCommand(newCmdUpdateChangefeed(f))
 	cmds.AddCommand(newCmdStatisticsChangefeed(f))
 	cmds.AddCommand(newCmdCyclicChangefeed(f))
 	cmds.AddCommand(newCmdListChangefeed(f))
 	cmds.AddCommand(newCmdPauseChangefeed(f))
+	cmds.AddCommand(newCmdPauseTablesChangefeed(f))
 	cmds.AddCommand(newCmdQueryChangefeed(f))
 	cmds.AddCommand(newCmdRemoveChangefeed(f))
 	cmds.AddCommand(newCmdResumeChangefeed(f))
+	cmds.AddCommand(newCmdResumeTablesChangefeed(f))
+	cmds.AddCommand(newCmdReportChangefeed(f))
 
 	o.addFlags(cmds)
 
@@ -0,0 +1,125 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	cmdcontext "github.com/pingcap/ticdc/pkg/cmd/context"
+	"github.com/pingcap/ticdc/pkg/cmd/factory"
+	"github.com/spf13/cobra"
+)
+
+// cloneChangefeedOptions defines flags for the `cli changefeed clone` command.
+type cloneChangefeedOptions struct {
+	createChangefeedOptions *createChangefeedOptions
+
+	sourceChangefeedID string
+}
+
+// newCloneChangefeedOptions creates new options for the `cli changefeed clone` command.
+func newCloneChangefeedOptions(createChangefeedOptions *createChangefeedOptions) *cloneChangefeedOptions {
+	return &cloneChangefeedOptions{
+		createChangefeedOptions: createChangefeedOptions,
+	}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *cloneChangefeedOptions) addFlags(cmd *cobra.Command) {
+	if o == nil {
+		return
+	}
+
+	o.createChangefeedOptions.addFlags(cmd)
+	cmd.PersistentFlags().StringVar(&o.sourceChangefeedID, "id", "", "ID of the replication task (changefeed) to clone")
+	_ = cmd.MarkPersistentFlagRequired("id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *cloneChangefeedOptions) complete(ctx context.Context, f factory.Factory, cmd *cobra.Command) error {
+	etcdClient, err := f.EtcdClient()
+	if err != nil {
+		return err
+	}
+
+	info, err := etcdClient.GetChangeFeedInfo(ctx, o.sourceChangefeedID)
+	if err != nil {
+		return err
+	}
+	o.applySourceChangefeed(info, cmd)
+
+	return o.createChangefeedOptions.complete(ctx, f, cmd)
+}
+
+// applySourceChangefeed fills the options with the settings of the source changefeed,
+// the flags specified explicitly in the command line take precedence over them.
+// The start ts and target ts are not cloned, the new changefeed starts from the
+// current ts by default.
+func (o *cloneChangefeedOptions) applySourceChangefeed(info *model.ChangeFeedInfo, cmd *cobra.Command) {
+	flags := cmd.Flags()
+	commonOptions := o.createChangefeedOptions.commonChangefeedOptions
+	if !flags.Changed("sink-uri") {
+		commonOptions.sinkURI = info.SinkURI
+	}
+	if info.Engine != "" && !flags.Changed("sort-engine") {
+		commonOptions.sortEngine = info.Engine
+	}
+	if len(info.Opts) > 0 && !flags.Changed("opts") {
+		commonOptions.opts = formatOpts(info.Opts)
+	}
+	if !flags.Changed("sync-point") {
+		commonOptions.syncPointEnabled = info.SyncPointEnabled
+	}
+	if info.SyncPointInterval != 0 && !flags.Changed("sync-interval") {
+		commonOptions.syncPointInterval = info.SyncPointInterval
+	}
+	if !flags.Changed("sync-point-retention") {
+		commonOptions.syncPointRetention = info.SyncPointRetention
+	}
+	o.createChangefeedOptions.baseCfg = info.Config
+}
+
+// newCmdCloneChangefeed creates the `cli changefeed clone` command.
+func newCmdCloneChangefeed(f factory.Factory) *cobra.Command {
+	commonChangefeedOptions := newChangefeedCommonOptions()
+
+	o := newCloneChangefeedOptions(newCreateChangefeedOptions(commonChangefeedOptions))
+
+	command := &cobra.Command{
+		Use:   "clone",
+		Short: "Create a new replication task (changefeed) with the settings of an existing one",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdcontext.GetDefaultContext()
+
+			err := o.complete(ctx, f, cmd)
+			if err != nil {
+				return err
+			}
+
+			err = o.createChangefeedOptions.validate(ctx, cmd)
+			if err != nil {
+				return err
+			}
+
+			return o.createChangefeedOptions.run(ctx, cmd)
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -24,7 +24,9 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/redo"
 	"github.com/pingcap/ticdc/cdc/sink"
+	"github.com/pingcap/ticdc/cdc/upstream"
 	cmdcontext "github.com/pingcap/ticdc/pkg/cmd/context"
 	"github.com/pingcap/ticdc/pkg/cmd/factory"
 	"github.com/pingcap/ticdc/pkg/cmd/util"
@@ -54,6 +56,7 @@ type changefeedCommonOptions struct {
 	noConfirm              bool
 	targetTs               uint64
 	sinkURI                string
+	standbySinkURI         string
 	configFile             string
 	opts                   []string
 	sortEngine             string
@@ -63,6 +66,10 @@ type changefeedCommonOptions struct {
 	cyclicSyncDDL          bool
 	syncPointEnabled       bool
 	syncPointInterval      time.Duration
+	syncPointRetention     time.Duration
+	// configContent is the replica config in TOML format, it is used
+	// when the config file is not specified, e.g. from a template.
+	configContent string
 }
 
 // newChangefeedCommonOptions creates new changefeed common options.
@@ -80,6 +87,7 @@ func (o *changefeedCommonOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolVar(&o.noConfirm, "no-confirm", false, "Don't ask user whether to ignore ineligible table")
 	cmd.PersistentFlags().Uint64Var(&o.targetTs, "target-ts", 0, "Target ts of changefeed")
 	cmd.PersistentFlags().StringVar(&o.sinkURI, "sink-uri", "", "sink uri")
+	cmd.PersistentFlags().StringVar(&o.standbySinkURI, "standby-sink-uri", "", "Standby sink uri which the changefeed fails over to when the sink is unhealthy")
 	cmd.PersistentFlags().StringVar(&o.configFile, "config", "", "Path of the configuration file")
 	cmd.PersistentFlags().StringSliceVar(&o.opts, "opts", nil, "Extra options, in the `key=value` format")
 	cmd.PersistentFlags().StringVar(&o.sortEngine, "sort-engine", model.SortUnified, "sort engine used for data sort")
@@ -89,19 +97,40 @@ func (o *changefeedCommonOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolVar(&o.cyclicSyncDDL, "cyclic-sync-ddl", true, "(Experimental) Cyclic replication sync DDL of changefeed")
 	cmd.PersistentFlags().BoolVar(&o.syncPointEnabled, "sync-point", false, "(Experimental) Set and Record syncpoint in replication(default off)")
 	cmd.PersistentFlags().DurationVar(&o.syncPointInterval, "sync-interval", 10*time.Minute, "(Experimental) Set the interval for syncpoint in replication(default 10min)")
+	cmd.PersistentFlags().DurationVar(&o.syncPointRetention, "sync-point-retention", 0, "(Experimental) Set how long the syncpoints are kept in the downstream, 0 means they are never cleaned up")
 	_ = cmd.PersistentFlags().MarkHidden("sort-dir")
 }
 
-// strictDecodeConfig do strictDecodeFile check and only verify the rules for now.
+// strictDecodeConfig do strictDecodeFile check and verify the rules and replica config.
 func (o *changefeedCommonOptions) strictDecodeConfig(component string, cfg *config.ReplicaConfig) error {
-	err := util.StrictDecodeFile(o.configFile, component, cfg)
+	var err error
+	if len(o.configFile) == 0 && len(o.configContent) > 0 {
+		err = util.StrictDecodeContent(o.configContent, component, cfg)
+	} else {
+		err = util.StrictDecodeFile(o.configFile, component, cfg)
+	}
 	if err != nil {
 		return err
 	}
 
 	_, err = filter.VerifyRules(cfg)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := filter.VerifyRowFilters(cfg); err != nil {
+		return err
+	}
+
+	if err := filter.VerifyEventFilters(cfg); err != nil {
+		return err
+	}
+
+	if err := redo.ValidateConsistentConfig(cfg.Consistent); err != nil {
+		return err
+	}
+
+	return cfg.Validate()
 }
 
 // createChangefeedOptions defines common flags for the `cli changefeed crate` command.
@@ -117,9 +146,24 @@ type createChangefeedOptions struct {
 	changefeedID            string
 	disableGCSafePointCheck bool
 	startTs                 uint64
+	startTime               string
+	backupMeta              string
 	timezone                string
 
-	cfg *config.ReplicaConfig
+	// the upstream cluster of the changefeed, the cluster of the cdc server
+	// is used if upstreamPD is empty.
+	upstreamPD       string
+	upstreamCAPath   string
+	upstreamCertPath string
+	upstreamKeyPath  string
+	upstream         *model.UpstreamInfo
+
+	// baseCfg is the replica config that the config file and flags are applied to,
+	// the default replica config is used if it is nil.
+	baseCfg *config.ReplicaConfig
+	cfg     *config.ReplicaConfig
+
+	templateOptions *changefeedTemplateOptions
 }
 
 // newCreateChangefeedOptions creates new options for the `cli changefeed create` command.
@@ -137,14 +181,29 @@ func (o *createChangefeedOptions) addFlags(cmd *cobra.Command) {
 	}
 
 	o.commonChangefeedOptions.addFlags(cmd)
+	o.templateOptions.addFlags(cmd)
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
 	cmd.PersistentFlags().BoolVarP(&o.disableGCSafePointCheck, "disable-gc-check", "", false, "Disable GC safe point check")
 	cmd.PersistentFlags().Uint64Var(&o.startTs, "start-ts", 0, "Start ts of changefeed")
+	cmd.PersistentFlags().StringVar(&o.startTime, "start-time", "", "Start time of changefeed in the format of \"2006-01-02 15:04:05\" in the timezone of --tz, which is resolved to the start ts")
+	cmd.PersistentFlags().StringVar(&o.backupMeta, "backup-meta", "", "Path of a BR backupmeta file or the backup directory, the end ts of the backup is used as the start ts")
 	cmd.PersistentFlags().StringVar(&o.timezone, "tz", "SYSTEM", "timezone used when checking sink uri (changefeed timezone is determined by cdc server)")
+	cmd.PersistentFlags().StringVar(&o.upstreamPD, "upstream-pd", "", "PD address of the upstream cluster, use commas to separate multiple PDs, the cluster of the cdc server is used if it is empty")
+	cmd.PersistentFlags().StringVar(&o.upstreamCAPath, "upstream-ca", "", "CA certificate path for TLS connection to the upstream cluster")
+	cmd.PersistentFlags().StringVar(&o.upstreamCertPath, "upstream-cert", "", "Certificate path for TLS connection to the upstream cluster")
+	cmd.PersistentFlags().StringVar(&o.upstreamKeyPath, "upstream-key", "", "Private key path for TLS connection to the upstream cluster")
 }
 
 // complete adapts from the command line args to the data and client required.
 func (o *createChangefeedOptions) complete(ctx context.Context, f factory.Factory, cmd *cobra.Command) error {
+	if o.templateOptions != nil && o.templateOptions.templateFile != "" {
+		template, err := o.templateOptions.load()
+		if err != nil {
+			return err
+		}
+		template.apply(o, cmd)
+	}
+
 	etcdClient, err := f.EtcdClient()
 	if err != nil {
 		return err
@@ -152,16 +211,25 @@ func (o *createChangefeedOptions) complete(ctx context.Context, f factory.Factor
 
 	o.etcdClient = etcdClient
 
-	pdClient, err := f.PdClient()
-	if err != nil {
-		return err
-	}
+	if o.upstreamPD != "" {
+		if err := o.completeUpstream(ctx); err != nil {
+			return err
+		}
+	} else {
+		pdClient, err := f.PdClient()
+		if err != nil {
+			return err
+		}
 
-	o.pdClient = pdClient
+		o.pdClient = pdClient
 
-	o.pdAddr = f.GetPdAddr()
-	o.credential = f.GetCredential()
+		o.pdAddr = f.GetPdAddr()
+		o.credential = f.GetCredential()
+	}
 
+	if err := o.resolveStartTs(); err != nil {
+		return err
+	}
 	if o.startTs == 0 {
 		ts, logical, err := o.pdClient.GetTS(ctx)
 		if err != nil {
@@ -173,6 +241,54 @@ func (o *createChangefeedOptions) complete(ctx context.Context, f factory.Factor
 	return o.completeCfg(ctx, cmd)
 }
 
+// resolveStartTs resolves the start ts from the start time or the BR backupmeta
+// if any of them is specified.
+func (o *createChangefeedOptions) resolveStartTs() error {
+	if o.startTime == "" && o.backupMeta == "" {
+		return nil
+	}
+	tz, err := ticdcutil.GetTimezone(o.timezone)
+	if err != nil {
+		return errors.Annotate(err, "can not load timezone, Please specify the time zone through environment variable `TZ` or command line parameters `--tz`")
+	}
+	o.startTs, err = ticdcutil.ResolveStartTs(o.startTs, o.startTime, o.backupMeta, tz)
+	if err != nil {
+		return err
+	}
+	log.Info("resolve the start ts of the changefeed", zap.Uint64("startTs", o.startTs),
+		zap.String("startTime", o.startTime), zap.String("backupMeta", o.backupMeta))
+	return nil
+}
+
+// completeUpstream creates the PD client of the upstream cluster, which is used
+// to get the start ts and check the tables instead of the cluster of the server.
+func (o *createChangefeedOptions) completeUpstream(ctx context.Context) error {
+	o.upstream = &model.UpstreamInfo{
+		PDAddrs:  strings.Split(o.upstreamPD, ","),
+		CAPath:   o.upstreamCAPath,
+		CertPath: o.upstreamCertPath,
+		KeyPath:  o.upstreamKeyPath,
+	}
+	if err := o.upstream.Validate(); err != nil {
+		return err
+	}
+	o.pdAddr = o.upstreamPD
+	o.credential = &security.Credential{
+		CAPath:   o.upstreamCAPath,
+		CertPath: o.upstreamCertPath,
+		KeyPath:  o.upstreamKeyPath,
+	}
+	pdClient, err := upstream.NewPDClient(ctx, o.upstream.PDAddrs, o.credential)
+	if err != nil {
+		return err
+	}
+	if err := version.CheckClusterVersion(ctx, pdClient, o.upstream.PDAddrs, o.credential, true); err != nil {
+		return err
+	}
+	o.pdClient = pdClient
+	return nil
+}
+
 // completeCfg complete the replica config from file and cmd flags.
 func (o *createChangefeedOptions) completeCfg(ctx context.Context, cmd *cobra.Command) error {
 	_, captureInfos, err := o.etcdClient.GetCaptures(ctx)
@@ -186,7 +302,10 @@ func (o *createChangefeedOptions) completeCfg(ctx context.Context, cmd *cobra.Co
 	}
 
 	cfg := config.GetDefaultReplicaConfig()
-	if len(o.commonChangefeedOptions.configFile) > 0 {
+	if o.baseCfg != nil {
+		cfg = o.baseCfg.Clone()
+	}
+	if len(o.commonChangefeedOptions.configFile) > 0 || len(o.commonChangefeedOptions.configContent) > 0 {
 		if err := o.commonChangefeedOptions.strictDecodeConfig("TiCDC changefeed", cfg); err != nil {
 			return err
 		}
@@ -213,8 +332,8 @@ func (o *createChangefeedOptions) completeCfg(ctx context.Context, cmd *cobra.Co
 			}
 		}
 
-		if cfg.ForceReplicate {
-			log.Error("if use force replicate, old value feature must be enabled")
+		if cfg.GetNoKeyTableStrategy() == config.NoKeyTableStrategyAllColumns {
+			log.Error("if use force replicate or the all-columns strategy of the tables without keys, old value feature must be enabled")
 			return cerror.ErrOldValueNotEnabled.GenWithStackByArgs()
 		}
 	}
@@ -280,6 +399,15 @@ func (o *createChangefeedOptions) validate(ctx context.Context, cmd *cobra.Comma
 		return err
 	}
 
+	// the consistency check compares the upstream and downstream at syncpoints
+	if o.cfg.Checksum.IsEnabled() && !o.commonChangefeedOptions.syncPointEnabled {
+		return errors.New("Creating changefeed with the consistency check requires `--sync-point`")
+	}
+
+	if o.commonChangefeedOptions.syncPointRetention < 0 {
+		return errors.New("Creating changefeed with a negative `--sync-point-retention`")
+	}
+
 	// user is not allowed to set sort-dir at changefeed level
 	if o.commonChangefeedOptions.sortDir != "" {
 		cmd.Printf(color.HiYellowString("[WARN] --sort-dir is deprecated in changefeed settings. " +
@@ -304,17 +432,20 @@ func (o *createChangefeedOptions) validate(ctx context.Context, cmd *cobra.Comma
 // getInfo constructs the information for the changefeed.
 func (o *createChangefeedOptions) getInfo(cmd *cobra.Command) *model.ChangeFeedInfo {
 	info := &model.ChangeFeedInfo{
-		SinkURI:           o.commonChangefeedOptions.sinkURI,
-		Opts:              make(map[string]string),
-		CreateTime:        time.Now(),
-		StartTs:           o.startTs,
-		TargetTs:          o.commonChangefeedOptions.targetTs,
-		Config:            o.cfg,
-		Engine:            o.commonChangefeedOptions.sortEngine,
-		State:             model.StateNormal,
-		SyncPointEnabled:  o.commonChangefeedOptions.syncPointEnabled,
-		SyncPointInterval: o.commonChangefeedOptions.syncPointInterval,
-		CreatorVersion:    version.ReleaseVersion,
+		SinkURI:            o.commonChangefeedOptions.sinkURI,
+		StandbySinkURI:     o.commonChangefeedOptions.standbySinkURI,
+		Upstream:           o.upstream,
+		Opts:               make(map[string]string),
+		CreateTime:         time.Now(),
+		StartTs:            o.startTs,
+		TargetTs:           o.commonChangefeedOptions.targetTs,
+		Config:             o.cfg,
+		Engine:             o.commonChangefeedOptions.sortEngine,
+		State:              model.StateNormal,
+		SyncPointEnabled:   o.commonChangefeedOptions.syncPointEnabled,
+		SyncPointInterval:  o.commonChangefeedOptions.syncPointInterval,
+		SyncPointRetention: o.commonChangefeedOptions.syncPointRetention,
+		CreatorVersion:     version.ReleaseVersion,
 	}
 
 	if info.Engine == model.SortInFile {
@@ -366,7 +497,13 @@ func (o *createChangefeedOptions) validateTargetTs() error {
 func (o *createChangefeedOptions) validateSink(
 	ctx context.Context, cfg *config.ReplicaConfig, opts map[string]string,
 ) error {
-	return sink.Validate(ctx, o.commonChangefeedOptions.sinkURI, cfg, opts)
+	if err := sink.Validate(ctx, o.commonChangefeedOptions.sinkURI, cfg, opts); err != nil {
+		return err
+	}
+	if o.commonChangefeedOptions.standbySinkURI == "" {
+		return nil
+	}
+	return sink.Validate(ctx, o.commonChangefeedOptions.standbySinkURI, cfg, opts)
 }
 
 // run the `cli changefeed create` command.
@@ -396,7 +533,7 @@ func (o *createChangefeedOptions) run(ctx context.Context, cmd *cobra.Command) e
 	}
 
 	if len(ineligibleTables) != 0 {
-		if o.cfg.ForceReplicate {
+		if o.cfg.ReplicateNoKeyTables() {
 			cmd.Printf("[WARN] force to replicate some ineligible tables, %#v\n", ineligibleTables)
 		} else {
 			cmd.Printf("[WARN] some tables are not eligible to replicate, %#v\n", ineligibleTables)
@@ -414,6 +551,9 @@ func (o *createChangefeedOptions) run(ctx context.Context, cmd *cobra.Command) e
 	}
 
 	info := o.getInfo(cmd)
+	if err := info.ValidateStandbySinkURI(); err != nil {
+		return err
+	}
 
 	tz, err := ticdcutil.GetTimezone(o.timezone)
 	if err != nil {
@@ -446,6 +586,7 @@ func newCmdCreateChangefeed(f factory.Factory) *cobra.Command {
 	commonChangefeedOptions := newChangefeedCommonOptions()
 
 	o := newCreateChangefeedOptions(commonChangefeedOptions)
+	o.templateOptions = newChangefeedTemplateOptions()
 
 	command := &cobra.Command{
 		Use:   "create",
@@ -14,9 +14,12 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
 	"time"
 
@@ -177,11 +180,15 @@ func sendOwnerAdminChangeQuery(ctx context.Context, etcdClient *etcd.CDCEtcdClie
 		forceRemoveOpt = "true"
 	}
 
-	resp, err := httpClient.PostForm(url, map[string][]string{
+	form := map[string][]string{
 		cdc.APIOpVarAdminJob:           {fmt.Sprint(int(job.Type))},
 		cdc.APIOpVarChangefeedID:       {job.CfID},
 		cdc.APIOpForceRemoveChangefeed: {forceRemoveOpt},
-	})
+	}
+	if job.Opts != nil && job.Opts.OverwriteCheckpointTs != 0 {
+		form[cdc.APIOpOverwriteCheckpointTs] = []string{fmt.Sprint(job.Opts.OverwriteCheckpointTs)}
+	}
+	resp, err := httpClient.PostForm(url, form)
 	if err != nil {
 		return err
 	}
@@ -196,3 +203,111 @@ func sendOwnerAdminChangeQuery(ctx context.Context, etcdClient *etcd.CDCEtcdClie
 
 	return nil
 }
+
+// sendOwnerChangefeedUpdate sends the updated changefeed info to the owner,
+// which restarts the changefeed with it if the changefeed is running.
+func sendOwnerChangefeedUpdate(ctx context.Context, etcdClient *etcd.CDCEtcdClient,
+	id model.ChangeFeedID, info *model.ChangeFeedInfo, credential *security.Credential,
+) error {
+	owner, err := getOwnerCapture(ctx, etcdClient)
+	if err != nil {
+		return err
+	}
+
+	scheme := util.HTTP
+	if credential.IsTLSEnabled() {
+		scheme = util.HTTPS
+	}
+
+	url := fmt.Sprintf("%s://%s/capture/owner/changefeed/update", scheme, owner.AdvertiseAddr)
+	httpClient, err := httputil.NewClient(credential)
+	if err != nil {
+		return err
+	}
+
+	infoStr, err := info.Marshal()
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.PostForm(url, map[string][]string{
+		cdc.APIOpVarChangefeedID:   {id},
+		cdc.APIOpVarChangefeedInfo: {infoStr},
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return errors.BadRequestf("update changefeed failed")
+		}
+		return errors.BadRequestf("%s", string(body))
+	}
+
+	return nil
+}
+
+// sendOwnerOpenAPIRequest sends a POST request with a JSON body to the OpenAPI of the owner.
+func sendOwnerOpenAPIRequest(ctx context.Context, etcdClient *etcd.CDCEtcdClient,
+	path string, data interface{}, credential *security.Credential,
+) error {
+	return requestOwnerOpenAPI(ctx, etcdClient, http.MethodPost, path, data, nil, credential)
+}
+
+// requestOwnerOpenAPI sends a request with a JSON body to the OpenAPI of the owner,
+// and decodes the JSON response into result if the result is not nil.
+func requestOwnerOpenAPI(ctx context.Context, etcdClient *etcd.CDCEtcdClient,
+	method string, path string, data interface{}, result interface{}, credential *security.Credential,
+) error {
+	owner, err := getOwnerCapture(ctx, etcdClient)
+	if err != nil {
+		return err
+	}
+
+	scheme := util.HTTP
+	if credential.IsTLSEnabled() {
+		scheme = util.HTTPS
+	}
+
+	var body io.Reader
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	url := fmt.Sprintf("%s://%s%s", scheme, owner.AdvertiseAddr, path)
+	httpClient, err := httputil.NewClient(credential)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return errors.BadRequestf("request owner failed")
+		}
+		return errors.BadRequestf("%s", string(body))
+	}
+
+	if result != nil {
+		return errors.Trace(json.NewDecoder(resp.Body).Decode(result))
+	}
+	return nil
+}
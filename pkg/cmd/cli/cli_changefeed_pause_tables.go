@@ -0,0 +1,125 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/cmd/context"
+	"github.com/pingcap/ticdc/pkg/cmd/factory"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/ticdc/pkg/security"
+	"github.com/spf13/cobra"
+)
+
+// pauseTablesChangefeedOptions defines flags for the `cli changefeed pause-tables`
+// and `cli changefeed resume-tables` commands.
+type pauseTablesChangefeedOptions struct {
+	etcdClient *etcd.CDCEtcdClient
+
+	credential *security.Credential
+
+	changefeedID string
+	tableIDs     []int64
+	resume       bool
+}
+
+// newPauseTablesChangefeedOptions creates new options for the `cli changefeed pause-tables`
+// and `cli changefeed resume-tables` commands.
+func newPauseTablesChangefeedOptions(resume bool) *pauseTablesChangefeedOptions {
+	return &pauseTablesChangefeedOptions{resume: resume}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *pauseTablesChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().Int64SliceVarP(&o.tableIDs, "table-ids", "t", nil, "IDs of the tables, separated by comma")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+	_ = cmd.MarkPersistentFlagRequired("table-ids")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *pauseTablesChangefeedOptions) complete(f factory.Factory) error {
+	etcdClient, err := f.EtcdClient()
+	if err != nil {
+		return err
+	}
+
+	o.etcdClient = etcdClient
+
+	o.credential = f.GetCredential()
+
+	return nil
+}
+
+// run the `cli changefeed pause-tables` or `cli changefeed resume-tables` command.
+func (o *pauseTablesChangefeedOptions) run() error {
+	ctx := context.GetDefaultContext()
+
+	action := "pause_tables"
+	if o.resume {
+		action = "resume_tables"
+	}
+	path := fmt.Sprintf("/api/v1/changefeeds/%s/tables/%s", o.changefeedID, action)
+
+	return sendOwnerOpenAPIRequest(ctx, o.etcdClient, path, &model.PauseTablesConfig{TableIDs: o.tableIDs}, o.credential)
+}
+
+// newCmdPauseTablesChangefeed creates the `cli changefeed pause-tables` command.
+func newCmdPauseTablesChangefeed(f factory.Factory) *cobra.Command {
+	o := newPauseTablesChangefeedOptions(false)
+
+	command := &cobra.Command{
+		Use:   "pause-tables",
+		Short: "Pause the replication of some tables of a replication task (changefeed), the tables are replicated from the paused ts once resumed",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := o.complete(f)
+			if err != nil {
+				return err
+			}
+
+			return o.run()
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
+
+// newCmdResumeTablesChangefeed creates the `cli changefeed resume-tables` command.
+func newCmdResumeTablesChangefeed(f factory.Factory) *cobra.Command {
+	o := newPauseTablesChangefeedOptions(true)
+
+	command := &cobra.Command{
+		Use:   "resume-tables",
+		Short: "Resume the replication of the paused tables of a replication task (changefeed), the checkpoint ts of the changefeed goes back to the paused ts until they catch up",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := o.complete(f)
+			if err != nil {
+				return err
+			}
+
+			return o.run()
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -0,0 +1,503 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/model"
+	cmdcontext "github.com/pingcap/ticdc/pkg/cmd/context"
+	"github.com/pingcap/ticdc/pkg/cmd/factory"
+	"github.com/pingcap/ticdc/pkg/cmd/util"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/etcd"
+	"github.com/pingcap/ticdc/pkg/httputil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	reportFormatJSON     = "json"
+	reportFormatMarkdown = "markdown"
+
+	// reportTimeLayout is the layout of the `--from` and `--to` flags, in local time.
+	reportTimeLayout = "2006-01-02 15:04:05"
+	// reportMaxSamples limits the number of samples of each query,
+	// Prometheus rejects a range query with more than 11000 points.
+	reportMaxSamples = 10000
+	// reportMinStep is the minimum resolution of the samples,
+	// it is the default scrape interval of the Prometheus deployed by TiUP.
+	reportMinStep = 15 * time.Second
+)
+
+// reportWindow is a time window in which a changefeed stays in the same state.
+type reportWindow struct {
+	State    string         `json:"state"`
+	Start    model.JSONTime `json:"start"`
+	End      model.JSONTime `json:"end"`
+	Duration float64        `json:"duration"`
+}
+
+// lagReport is the statistics of the checkpoint lag in seconds.
+type lagReport struct {
+	Avg float64 `json:"avg"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+// sloReport is the statistics of the replication lag SLO.
+type sloReport struct {
+	MaxCheckpointLag int64 `json:"max_checkpoint_lag"`
+	// the duration in seconds when the checkpoint lag exceeds the maximum lag
+	ViolationDuration float64 `json:"violation_duration"`
+	// the percentage of time when the SLO is met
+	Availability float64 `json:"availability"`
+}
+
+// throughputReport is the statistics of the rows written by the sink.
+type throughputReport struct {
+	AvgRowsPerSecond float64 `json:"avg_rows_per_second"`
+	MaxRowsPerSecond float64 `json:"max_rows_per_second"`
+	TotalRows        float64 `json:"total_rows"`
+}
+
+// changefeedReport is the SLA report of a changefeed in a time range.
+type changefeedReport struct {
+	ID   string         `json:"id"`
+	From model.JSONTime `json:"from"`
+	To   model.JSONTime `json:"to"`
+	// the duration in seconds covered by the samples of the checkpoint lag
+	SampledDuration float64          `json:"sampled_duration"`
+	Lag             lagReport        `json:"lag"`
+	SLO             *sloReport       `json:"slo,omitempty"`
+	Throughput      throughputReport `json:"throughput"`
+	PauseWindows    []reportWindow   `json:"pause_windows"`
+	ErrorWindows    []reportWindow   `json:"error_windows"`
+	// the error of the changefeed in the metadata, if any
+	LastError *model.RunningError `json:"last_error,omitempty"`
+}
+
+// reportSample is a sample of a Prometheus range query.
+type reportSample struct {
+	ts    time.Time
+	value float64
+}
+
+// reportChangefeedOptions defines flags for the `cli changefeed report` command.
+type reportChangefeedOptions struct {
+	etcdClient *etcd.CDCEtcdClient
+	httpClient *httputil.Client
+
+	changefeedID     string
+	prometheusAddr   string
+	from             string
+	to               string
+	format           string
+	maxCheckpointLag int64
+}
+
+// newReportChangefeedOptions creates new options for the `cli changefeed report` command.
+func newReportChangefeedOptions() *reportChangefeedOptions {
+	return &reportChangefeedOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *reportChangefeedOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
+	cmd.PersistentFlags().StringVar(&o.prometheusAddr, "prometheus", "", "Address of the Prometheus which stores the metrics of TiCDC, such as http://127.0.0.1:9090")
+	cmd.PersistentFlags().StringVar(&o.from, "from", "", "Start time of the report, in the format of \"2006-01-02 15:04:05\" or RFC3339")
+	cmd.PersistentFlags().StringVar(&o.to, "to", "", "End time of the report, in the format of \"2006-01-02 15:04:05\" or RFC3339, defaults to now")
+	cmd.PersistentFlags().StringVar(&o.format, "format", reportFormatJSON, "Output format of the report, json or markdown")
+	cmd.PersistentFlags().Int64Var(&o.maxCheckpointLag, "max-checkpoint-lag", 0, "Maximum checkpoint lag in seconds used to evaluate the SLO, defaults to slo.max-checkpoint-lag of the changefeed")
+	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
+	_ = cmd.MarkPersistentFlagRequired("prometheus")
+	_ = cmd.MarkPersistentFlagRequired("from")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *reportChangefeedOptions) complete(f factory.Factory) error {
+	etcdClient, err := f.EtcdClient()
+	if err != nil {
+		return err
+	}
+
+	o.etcdClient = etcdClient
+
+	httpClient, err := httputil.NewClient(nil)
+	if err != nil {
+		return err
+	}
+
+	o.httpClient = httpClient
+
+	return nil
+}
+
+// validate checks that the provided report options are specified.
+func (o *reportChangefeedOptions) validate() (from, to time.Time, err error) {
+	if o.format != reportFormatJSON && o.format != reportFormatMarkdown {
+		return from, to, errors.Errorf("invalid format %s, only json and markdown are supported", o.format)
+	}
+	if o.maxCheckpointLag < 0 {
+		return from, to, errors.New("max-checkpoint-lag should not be negative")
+	}
+	from, err = parseReportTime(o.from)
+	if err != nil {
+		return from, to, err
+	}
+	to = time.Now()
+	if o.to != "" {
+		to, err = parseReportTime(o.to)
+		if err != nil {
+			return from, to, err
+		}
+	}
+	if !from.Before(to) {
+		return from, to, errors.New("the start time of the report should be earlier than the end time")
+	}
+	return from, to, nil
+}
+
+// run the `cli changefeed report` command.
+func (o *reportChangefeedOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	from, to, err := o.validate()
+	if err != nil {
+		return err
+	}
+
+	// The metadata of the changefeed may have been removed, the report is
+	// still generated from the metrics in this case.
+	info, err := o.etcdClient.GetChangeFeedInfo(ctx, o.changefeedID)
+	if err != nil && cerror.ErrChangeFeedNotExists.NotEqual(err) {
+		return err
+	}
+
+	maxCheckpointLag := o.maxCheckpointLag
+	if maxCheckpointLag == 0 && info != nil && info.Config.SLO.IsEnabled() {
+		maxCheckpointLag = info.Config.SLO.MaxCheckpointLag
+	}
+
+	step := reportStep(from, to)
+	queryRange := func(query string) ([]reportSample, error) {
+		return queryPrometheusRange(ctx, o.httpClient, o.prometheusAddr, query, from, to, step)
+	}
+
+	lagSamples, err := queryRange(fmt.Sprintf(`max(ticdc_owner_checkpoint_ts_lag{changefeed="%s"})`, o.changefeedID))
+	if err != nil {
+		return err
+	}
+	statusSamples, err := queryRange(fmt.Sprintf(`max(ticdc_owner_status{changefeed="%s"})`, o.changefeedID))
+	if err != nil {
+		return err
+	}
+	rowsSamples, err := queryRange(fmt.Sprintf(`sum(rate(ticdc_sink_table_sink_total_rows_count{changefeed="%s"}[1m]))`, o.changefeedID))
+	if err != nil {
+		return err
+	}
+
+	report := newChangefeedReport(o.changefeedID, from, to, step, maxCheckpointLag, lagSamples, statusSamples, rowsSamples)
+	if info != nil {
+		report.LastError = info.Error
+	}
+
+	if o.format == reportFormatMarkdown {
+		cmd.Print(report.markdown())
+		return nil
+	}
+	return util.JSONPrint(cmd, report)
+}
+
+// newCmdReportChangefeed creates the `cli changefeed report` command.
+func newCmdReportChangefeed(f factory.Factory) *cobra.Command {
+	o := newReportChangefeedOptions()
+
+	command := &cobra.Command{
+		Use:   "report",
+		Short: "Generate the SLA report of a replication task (changefeed) in a time range",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := o.complete(f)
+			if err != nil {
+				return err
+			}
+
+			return o.run(cmd)
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
+
+// parseReportTime parses a time in the report time layout or RFC3339.
+func parseReportTime(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation(reportTimeLayout, s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, errors.Errorf("invalid time %s, it should be in the format of \"%s\" or RFC3339", s, reportTimeLayout)
+	}
+	return t, nil
+}
+
+// reportStep returns the resolution of the samples in the time range.
+func reportStep(from, to time.Time) time.Duration {
+	step := (to.Sub(from) + reportMaxSamples - 1) / reportMaxSamples
+	step = step.Round(time.Second)
+	if step < reportMinStep {
+		step = reportMinStep
+	}
+	return step
+}
+
+// queryPrometheusRange evaluates a query which returns at most one series
+// over a range of time by the Prometheus HTTP API.
+func queryPrometheusRange(
+	ctx context.Context, client *httputil.Client, addr, query string,
+	from, to time.Time, step time.Duration,
+) ([]reportSample, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(from.Unix(), 10))
+	params.Set("end", strconv.FormatInt(to.Unix(), 10))
+	params.Set("step", strconv.FormatInt(int64(step/time.Second), 10))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(addr, "/")+"/api/v1/query_range?"+params.Encode(), nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var result struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			Result []struct {
+				Values [][2]interface{} `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, errors.Annotatef(err, "query prometheus failed, status code %d", resp.StatusCode)
+	}
+	if result.Status != "success" {
+		return nil, errors.Errorf("query prometheus failed: %s", result.Error)
+	}
+	if len(result.Data.Result) == 0 {
+		return nil, nil
+	}
+
+	values := result.Data.Result[0].Values
+	samples := make([]reportSample, 0, len(values))
+	for _, v := range values {
+		ts, ok := v[0].(float64)
+		if !ok {
+			return nil, errors.Errorf("invalid sample timestamp %v", v[0])
+		}
+		s, ok := v[1].(string)
+		if !ok {
+			return nil, errors.Errorf("invalid sample value %v", v[1])
+		}
+		value, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if math.IsNaN(value) {
+			continue
+		}
+		sec, frac := math.Modf(ts)
+		samples = append(samples, reportSample{
+			ts:    time.Unix(int64(sec), int64(frac*1e9)),
+			value: value,
+		})
+	}
+	return samples, nil
+}
+
+// newChangefeedReport aggregates the samples into a changefeed report,
+// the samples are evenly spaced by step.
+func newChangefeedReport(
+	id string, from, to time.Time, step time.Duration, maxCheckpointLag int64,
+	lagSamples, statusSamples, rowsSamples []reportSample,
+) *changefeedReport {
+	report := &changefeedReport{
+		ID:              id,
+		From:            model.JSONTime(from),
+		To:              model.JSONTime(to),
+		SampledDuration: float64(len(lagSamples)) * step.Seconds(),
+		PauseWindows:    stateWindows(statusSamples, step, model.StateStopped),
+		ErrorWindows:    stateWindows(statusSamples, step, model.StateError, model.StateFailed),
+	}
+
+	if len(lagSamples) > 0 {
+		lags := make([]float64, 0, len(lagSamples))
+		var sum float64
+		for _, s := range lagSamples {
+			lags = append(lags, s.value)
+			sum += s.value
+		}
+		sort.Float64s(lags)
+		report.Lag = lagReport{
+			Avg: sum / float64(len(lags)),
+			P99: lags[int(math.Ceil(float64(len(lags))*0.99))-1],
+			Max: lags[len(lags)-1],
+		}
+	}
+
+	if maxCheckpointLag > 0 {
+		report.SLO = &sloReport{MaxCheckpointLag: maxCheckpointLag, Availability: 100}
+		var violated int
+		for _, s := range lagSamples {
+			if s.value > float64(maxCheckpointLag) {
+				violated++
+			}
+		}
+		report.SLO.ViolationDuration = float64(violated) * step.Seconds()
+		if len(lagSamples) > 0 {
+			report.SLO.Availability = 100 * float64(len(lagSamples)-violated) / float64(len(lagSamples))
+		}
+	}
+
+	if len(rowsSamples) > 0 {
+		var sum float64
+		for _, s := range rowsSamples {
+			sum += s.value
+			if s.value > report.Throughput.MaxRowsPerSecond {
+				report.Throughput.MaxRowsPerSecond = s.value
+			}
+		}
+		report.Throughput.AvgRowsPerSecond = sum / float64(len(rowsSamples))
+		report.Throughput.TotalRows = math.Round(sum * step.Seconds())
+	}
+
+	return report
+}
+
+// stateWindows returns the time windows in which the changefeed is in one of
+// the given states, according to the samples of `ticdc_owner_status`.
+func stateWindows(samples []reportSample, step time.Duration, states ...model.FeedState) []reportWindow {
+	stateOf := func(value float64) (model.FeedState, bool) {
+		for _, state := range states {
+			if float64(state.ToInt()) == value {
+				return state, true
+			}
+		}
+		return "", false
+	}
+
+	windows := make([]reportWindow, 0)
+	var current *reportWindow
+	var lastTs time.Time
+	for _, s := range samples {
+		state, ok := stateOf(s.value)
+		// a gap in the samples or a change of the state ends the current window
+		if current != nil && (!ok || string(state) != current.State || s.ts.Sub(lastTs) > step) {
+			windows = append(windows, *current)
+			current = nil
+		}
+		if ok {
+			if current == nil {
+				current = &reportWindow{State: string(state), Start: model.JSONTime(s.ts)}
+			}
+			current.End = model.JSONTime(s.ts.Add(step))
+			current.Duration = time.Time(current.End).Sub(time.Time(current.Start)).Seconds()
+		}
+		lastTs = s.ts
+	}
+	if current != nil {
+		windows = append(windows, *current)
+	}
+	return windows
+}
+
+// markdown renders the report in markdown.
+func (r *changefeedReport) markdown() string {
+	const timeLayout = "2006-01-02 15:04:05"
+	var b strings.Builder
+	fmt.Fprintf(&b, "# SLA Report of Changefeed %s\n\n", r.ID)
+	fmt.Fprintf(&b, "From %s to %s, metrics sampled for %s.\n\n",
+		time.Time(r.From).Format(timeLayout), time.Time(r.To).Format(timeLayout), formatReportDuration(r.SampledDuration))
+
+	b.WriteString("## Checkpoint Lag\n\n")
+	b.WriteString("| Avg | P99 | Max |\n| --- | --- | --- |\n")
+	fmt.Fprintf(&b, "| %.2fs | %.2fs | %.2fs |\n\n", r.Lag.Avg, r.Lag.P99, r.Lag.Max)
+
+	b.WriteString("## SLO\n\n")
+	if r.SLO == nil {
+		b.WriteString("The replication lag SLO is disabled.\n\n")
+	} else {
+		b.WriteString("| Max Checkpoint Lag | Violation Duration | Availability |\n| --- | --- | --- |\n")
+		fmt.Fprintf(&b, "| %ds | %s | %.3f%% |\n\n",
+			r.SLO.MaxCheckpointLag, formatReportDuration(r.SLO.ViolationDuration), r.SLO.Availability)
+	}
+
+	b.WriteString("## Throughput\n\n")
+	b.WriteString("| Avg Rows/s | Max Rows/s | Total Rows |\n| --- | --- | --- |\n")
+	fmt.Fprintf(&b, "| %.2f | %.2f | %.0f |\n\n",
+		r.Throughput.AvgRowsPerSecond, r.Throughput.MaxRowsPerSecond, r.Throughput.TotalRows)
+
+	for _, section := range []struct {
+		title   string
+		windows []reportWindow
+	}{
+		{title: "Pause Windows", windows: r.PauseWindows},
+		{title: "Error Windows", windows: r.ErrorWindows},
+	} {
+		fmt.Fprintf(&b, "## %s\n\n", section.title)
+		if len(section.windows) == 0 {
+			b.WriteString("None.\n\n")
+			continue
+		}
+		b.WriteString("| State | Start | End | Duration |\n| --- | --- | --- | --- |\n")
+		for _, w := range section.windows {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", w.State,
+				time.Time(w.Start).Format(timeLayout), time.Time(w.End).Format(timeLayout), formatReportDuration(w.Duration))
+		}
+		b.WriteString("\n")
+	}
+
+	if r.LastError != nil {
+		b.WriteString("## Last Error\n\n")
+		fmt.Fprintf(&b, "[%s] %s\n", r.LastError.Code, r.LastError.Message)
+	}
+	return b.String()
+}
+
+// formatReportDuration formats a duration in seconds.
+func formatReportDuration(seconds float64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
@@ -0,0 +1,139 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/httputil"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+type changefeedReportSuite struct{}
+
+var _ = check.Suite(&changefeedReportSuite{})
+
+func newReportSamples(from time.Time, step time.Duration, values ...float64) []reportSample {
+	samples := make([]reportSample, 0, len(values))
+	for i, v := range values {
+		samples = append(samples, reportSample{ts: from.Add(time.Duration(i) * step), value: v})
+	}
+	return samples
+}
+
+func (s *changefeedReportSuite) TestQueryPrometheusRange(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.URL.Path, check.Equals, "/api/v1/query_range")
+		c.Assert(r.URL.Query().Get("step"), check.Equals, "15")
+		if r.URL.Query().Get("query") == "invalid" {
+			fmt.Fprint(w, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"matrix","result":[`+
+			`{"metric":{},"values":[[1635724800,"1.5"],[1635724815.5,"NaN"],[1635724830,"3"]]}]}}`)
+	}))
+	defer server.Close()
+
+	client, err := httputil.NewClient(nil)
+	c.Assert(err, check.IsNil)
+	from := time.Unix(1635724800, 0)
+	to := from.Add(time.Minute)
+	samples, err := queryPrometheusRange(context.Background(), client, server.URL+"/", "up", from, to, reportMinStep)
+	c.Assert(err, check.IsNil)
+	c.Assert(samples, check.DeepEquals, []reportSample{
+		{ts: from, value: 1.5},
+		{ts: from.Add(30 * time.Second), value: 3},
+	})
+
+	_, err = queryPrometheusRange(context.Background(), client, server.URL, "invalid", from, to, reportMinStep)
+	c.Assert(err, check.ErrorMatches, ".*parse error.*")
+	client.CloseIdleConnections()
+}
+
+func (s *changefeedReportSuite) TestReportStep(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	from := time.Unix(1635724800, 0)
+	c.Assert(reportStep(from, from.Add(time.Hour)), check.Equals, reportMinStep)
+	c.Assert(reportStep(from, from.Add(30*24*time.Hour)), check.Equals, 259*time.Second)
+}
+
+func (s *changefeedReportSuite) TestNewChangefeedReport(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	from := time.Unix(1635724800, 0)
+	step := 10 * time.Second
+	to := from.Add(10 * step)
+	lag := newReportSamples(from, step, 1, 2, 3, 40, 50, 2, 1, 1, 1, 1)
+	status := newReportSamples(from, step, 0, 3, 3, 0, 1, 1, 2, 0, 3, 3)
+	// a gap in the samples splits the pause window
+	status = append(status[:9], reportSample{ts: status[9].ts.Add(step), value: 3})
+	rows := newReportSamples(from, step, 100, 200, 0, 300)
+
+	report := newChangefeedReport("test", from, to, step, 30, lag, status, rows)
+	c.Assert(report.SampledDuration, check.Equals, float64(100))
+	c.Assert(report.Lag, check.DeepEquals, lagReport{Avg: 10.2, P99: 50, Max: 50})
+	c.Assert(report.SLO, check.DeepEquals, &sloReport{
+		MaxCheckpointLag: 30, ViolationDuration: 20, Availability: 80,
+	})
+	c.Assert(report.Throughput, check.DeepEquals, throughputReport{
+		AvgRowsPerSecond: 150, MaxRowsPerSecond: 300, TotalRows: 6000,
+	})
+	c.Assert(report.PauseWindows, check.DeepEquals, []reportWindow{
+		{State: "stopped", Start: model.JSONTime(from.Add(step)), End: model.JSONTime(from.Add(3 * step)), Duration: 20},
+		{State: "stopped", Start: model.JSONTime(from.Add(8 * step)), End: model.JSONTime(from.Add(9 * step)), Duration: 10},
+		{State: "stopped", Start: model.JSONTime(from.Add(10 * step)), End: model.JSONTime(from.Add(11 * step)), Duration: 10},
+	})
+	c.Assert(report.ErrorWindows, check.DeepEquals, []reportWindow{
+		{State: "error", Start: model.JSONTime(from.Add(4 * step)), End: model.JSONTime(from.Add(6 * step)), Duration: 20},
+		{State: "failed", Start: model.JSONTime(from.Add(6 * step)), End: model.JSONTime(from.Add(7 * step)), Duration: 10},
+	})
+
+	// the SLO is disabled
+	report = newChangefeedReport("test", from, to, step, 0, nil, nil, nil)
+	c.Assert(report.SLO, check.IsNil)
+	c.Assert(report.Lag, check.DeepEquals, lagReport{})
+	c.Assert(report.PauseWindows, check.HasLen, 0)
+}
+
+func (s *changefeedReportSuite) TestReportMarkdown(c *check.C) {
+	defer testleak.AfterTest(c)()
+
+	from := time.Unix(1635724800, 0)
+	step := 10 * time.Second
+	lag := newReportSamples(from, step, 1, 40)
+	status := newReportSamples(from, step, 0, 3)
+	report := newChangefeedReport("test", from, from.Add(2*step), step, 30, lag, status, nil)
+	report.LastError = &model.RunningError{Code: "CDC:ErrSinkURIInvalid", Message: "sink uri invalid"}
+
+	md := report.markdown()
+	c.Assert(strings.HasPrefix(md, "# SLA Report of Changefeed test\n"), check.IsTrue)
+	c.Assert(md, check.Matches, "(?s).*\\| 20.50s \\| 40.00s \\| 40.00s \\|.*")
+	c.Assert(md, check.Matches, "(?s).*\\| 30s \\| 10s \\| 50.000% \\|.*")
+	c.Assert(md, check.Matches, "(?s).*## Pause Windows\n\n\\| State.*\\| stopped \\| .* \\| 10s \\|.*")
+	c.Assert(md, check.Matches, "(?s).*## Error Windows\n\nNone\\..*")
+	c.Assert(md, check.Matches, "(?s).*\\[CDC:ErrSinkURIInvalid\\] sink uri invalid\n")
+
+	report.SLO = nil
+	c.Assert(report.markdown(), check.Matches, "(?s).*The replication lag SLO is disabled\\..*")
+}
@@ -34,8 +34,9 @@ type resumeChangefeedOptions struct {
 
 	credential *security.Credential
 
-	changefeedID string
-	noConfirm    bool
+	changefeedID          string
+	noConfirm             bool
+	overwriteCheckpointTs uint64
 }
 
 // newResumeChangefeedOptions creates new options for the `cli changefeed pause` command.
@@ -48,6 +49,7 @@ func newResumeChangefeedOptions() *resumeChangefeedOptions {
 func (o *resumeChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
 	cmd.PersistentFlags().BoolVar(&o.noConfirm, "no-confirm", false, "Don't ask user whether to ignore ineligible table")
+	cmd.PersistentFlags().Uint64Var(&o.overwriteCheckpointTs, "overwrite-checkpoint-ts", 0, "The checkpoint ts a changefeed quarantined for blocking the GC is resumed from")
 	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
 }
 
@@ -74,24 +76,36 @@ func (o *resumeChangefeedOptions) complete(f factory.Factory) error {
 
 // confirmResumeChangefeedCheck prompts the user to confirm the use of a large data gap when noConfirm is turned off.
 func (o *resumeChangefeedOptions) confirmResumeChangefeedCheck(ctx context.Context, cmd *cobra.Command) error {
-	resp, err := sendOwnerChangefeedQuery(ctx, o.etcdClient, o.changefeedID, o.credential)
+	changefeedInfo, err := o.etcdClient.GetChangeFeedInfo(ctx, o.changefeedID)
 	if err != nil {
 		return err
 	}
-
-	info := &cdc.ChangefeedResp{}
-	err = json.Unmarshal([]byte(resp), info)
-	if err != nil {
+	if err := changefeedInfo.VerifyResume(o.changefeedID, o.overwriteCheckpointTs); err != nil {
 		return err
 	}
 
+	checkpointTs := o.overwriteCheckpointTs
+	if checkpointTs == 0 {
+		resp, err := sendOwnerChangefeedQuery(ctx, o.etcdClient, o.changefeedID, o.credential)
+		if err != nil {
+			return err
+		}
+
+		info := &cdc.ChangefeedResp{}
+		err = json.Unmarshal([]byte(resp), info)
+		if err != nil {
+			return err
+		}
+		checkpointTs = info.TSO
+	}
+
 	currentPhysical, _, err := o.pdClient.GetTS(ctx)
 	if err != nil {
 		return err
 	}
 
 	if !o.noConfirm {
-		return confirmLargeDataGap(cmd, currentPhysical, info.TSO)
+		return confirmLargeDataGap(cmd, currentPhysical, checkpointTs)
 	}
 
 	return nil
@@ -108,6 +122,7 @@ func (o *resumeChangefeedOptions) run(cmd *cobra.Command) error {
 	job := model.AdminJob{
 		CfID: o.changefeedID,
 		Type: model.AdminResume,
+		Opts: &model.AdminJobOption{OverwriteCheckpointTs: o.overwriteCheckpointTs},
 	}
 
 	return sendOwnerAdminChangeQuery(ctx, o.etcdClient, job, o.credential)
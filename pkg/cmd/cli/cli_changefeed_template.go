@@ -0,0 +1,152 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// changefeedTemplate is the template used to create similar changefeeds.
+// It is in YAML format, and the variables in the form of `${name}` are
+// substituted before the template is decoded, `$$` stands for a literal `$`.
+// For example:
+//
+//	changefeed-id: "${tenant}-kafka"
+//	sink-uri: "kafka://127.0.0.1:9092/cdc-${tenant}?protocol=canal-json"
+//	config: |
+//	  [filter]
+//	  rules = ['${tenant}.*']
+type changefeedTemplate struct {
+	ChangefeedID string            `yaml:"changefeed-id"`
+	SinkURI      string            `yaml:"sink-uri"`
+	StartTs      uint64            `yaml:"start-ts"`
+	TargetTs     uint64            `yaml:"target-ts"`
+	SortEngine   string            `yaml:"sort-engine"`
+	Opts         map[string]string `yaml:"opts"`
+	// Config is the replica config in TOML format.
+	Config string `yaml:"config"`
+}
+
+// changefeedTemplateOptions defines flags for creating a changefeed from a template.
+type changefeedTemplateOptions struct {
+	templateFile string
+	vars         []string
+}
+
+// newChangefeedTemplateOptions creates new changefeed template options.
+func newChangefeedTemplateOptions() *changefeedTemplateOptions {
+	return &changefeedTemplateOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *changefeedTemplateOptions) addFlags(cmd *cobra.Command) {
+	if o == nil {
+		return
+	}
+
+	cmd.PersistentFlags().StringVar(&o.templateFile, "template", "", "Path of the changefeed template file")
+	cmd.PersistentFlags().StringArrayVar(&o.vars, "var", nil, "Variables of the changefeed template, in the `key=value` format")
+}
+
+// load reads the template file and substitutes the variables in it.
+func (o *changefeedTemplateOptions) load() (*changefeedTemplate, error) {
+	content, err := os.ReadFile(o.templateFile)
+	if err != nil {
+		return nil, errors.Annotatef(err, "fail to read the changefeed template %s", o.templateFile)
+	}
+	return parseChangefeedTemplate(string(content), o.vars)
+}
+
+// parseChangefeedTemplate substitutes the variables in the template content
+// and decodes it. All the variables in the template must be specified.
+func parseChangefeedTemplate(content string, vars []string) (*changefeedTemplate, error) {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		s := strings.SplitN(v, "=", 2)
+		if len(s) != 2 || s[0] == "" {
+			return nil, errors.Errorf("invalid template variable %s, it should be in the `key=value` format", v)
+		}
+		values[s[0]] = s[1]
+	}
+
+	var missing []string
+	content = os.Expand(content, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		value, ok := values[name]
+		if !ok {
+			for _, m := range missing {
+				if m == name {
+					return ""
+				}
+			}
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return nil, errors.Errorf("template variables %s are not specified", strings.Join(missing, ", "))
+	}
+
+	t := &changefeedTemplate{}
+	if err := yaml.UnmarshalStrict([]byte(content), t); err != nil {
+		return nil, errors.Annotate(err, "fail to decode the changefeed template")
+	}
+	return t, nil
+}
+
+// apply fills the options of the `cli changefeed create` command with the template,
+// the flags specified explicitly in the command line take precedence over the template.
+func (t *changefeedTemplate) apply(o *createChangefeedOptions, cmd *cobra.Command) {
+	flags := cmd.Flags()
+	if t.ChangefeedID != "" && !flags.Changed("changefeed-id") {
+		o.changefeedID = t.ChangefeedID
+	}
+	if t.SinkURI != "" && !flags.Changed("sink-uri") {
+		o.commonChangefeedOptions.sinkURI = t.SinkURI
+	}
+	if t.StartTs != 0 && !flags.Changed("start-ts") {
+		o.startTs = t.StartTs
+	}
+	if t.TargetTs != 0 && !flags.Changed("target-ts") {
+		o.commonChangefeedOptions.targetTs = t.TargetTs
+	}
+	if t.SortEngine != "" && !flags.Changed("sort-engine") {
+		o.commonChangefeedOptions.sortEngine = t.SortEngine
+	}
+	if len(t.Opts) > 0 && !flags.Changed("opts") {
+		o.commonChangefeedOptions.opts = formatOpts(t.Opts)
+	}
+	if t.Config != "" && !flags.Changed("config") {
+		o.commonChangefeedOptions.configContent = t.Config
+	}
+}
+
+// formatOpts converts the opts into the `key=value` format, sorted by key.
+func formatOpts(opts map[string]string) []string {
+	result := make([]string, 0, len(opts))
+	for key, value := range opts {
+		result = append(result, key+"="+value)
+	}
+	sort.Strings(result)
+	return result
+}
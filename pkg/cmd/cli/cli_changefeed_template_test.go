@@ -0,0 +1,122 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+	"github.com/spf13/cobra"
+)
+
+type changefeedTemplateSuite struct{}
+
+var _ = check.Suite(&changefeedTemplateSuite{})
+
+const testChangefeedTemplate = `
+changefeed-id: "${tenant}-kafka"
+sink-uri: "kafka://127.0.0.1:9092/cdc-${tenant}?protocol=canal-json&password=$$ecret"
+sort-engine: memory
+opts:
+  b: "2"
+  a: "1"
+config: |
+  [filter]
+  rules = ['${tenant}.*']
+`
+
+func (s *changefeedTemplateSuite) TestParseChangefeedTemplate(c *check.C) {
+	defer testleak.AfterTest(c)()
+	t, err := parseChangefeedTemplate(testChangefeedTemplate, []string{"tenant=foo"})
+	c.Assert(err, check.IsNil)
+	c.Assert(t, check.DeepEquals, &changefeedTemplate{
+		ChangefeedID: "foo-kafka",
+		SinkURI:      "kafka://127.0.0.1:9092/cdc-foo?protocol=canal-json&password=$ecret",
+		SortEngine:   "memory",
+		Opts:         map[string]string{"a": "1", "b": "2"},
+		Config:       "[filter]\nrules = ['foo.*']\n",
+	})
+
+	_, err = parseChangefeedTemplate(testChangefeedTemplate, nil)
+	c.Assert(err, check.ErrorMatches, "template variables tenant are not specified")
+	_, err = parseChangefeedTemplate(testChangefeedTemplate, []string{"tenant"})
+	c.Assert(err, check.ErrorMatches, "invalid template variable tenant.*")
+	_, err = parseChangefeedTemplate("unknown: 1", nil)
+	c.Assert(err, check.ErrorMatches, "(?s)fail to decode the changefeed template.*field unknown not found.*")
+}
+
+func (s *changefeedTemplateSuite) TestCreateChangefeedFromTemplate(c *check.C) {
+	defer testleak.AfterTest(c)()
+	path := filepath.Join(c.MkDir(), "template.yaml")
+	err := os.WriteFile(path, []byte(testChangefeedTemplate), 0o644)
+	c.Assert(err, check.IsNil)
+
+	cmd := new(cobra.Command)
+	o := newCreateChangefeedOptions(newChangefeedCommonOptions())
+	o.templateOptions = newChangefeedTemplateOptions()
+	o.addFlags(cmd)
+	c.Assert(cmd.ParseFlags([]string{
+		"--template=" + path, "--var=tenant=bar", "--sort-engine=unified",
+	}), check.IsNil)
+
+	template, err := o.templateOptions.load()
+	c.Assert(err, check.IsNil)
+	template.apply(o, cmd)
+	c.Assert(o.changefeedID, check.Equals, "bar-kafka")
+	c.Assert(o.commonChangefeedOptions.sinkURI, check.Equals,
+		"kafka://127.0.0.1:9092/cdc-bar?protocol=canal-json&password=$ecret")
+	// the flags specified explicitly take precedence over the template
+	c.Assert(o.commonChangefeedOptions.sortEngine, check.Equals, model.SortUnified)
+	c.Assert(o.commonChangefeedOptions.opts, check.DeepEquals, []string{"a=1", "b=2"})
+
+	cfg := config.GetDefaultReplicaConfig()
+	err = o.commonChangefeedOptions.strictDecodeConfig("cdc", cfg)
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.Filter.Rules, check.DeepEquals, []string{"bar.*"})
+}
+
+func (s *changefeedTemplateSuite) TestCloneChangefeed(c *check.C) {
+	defer testleak.AfterTest(c)()
+	cmd := new(cobra.Command)
+	o := newCloneChangefeedOptions(newCreateChangefeedOptions(newChangefeedCommonOptions()))
+	o.addFlags(cmd)
+	c.Assert(cmd.ParseFlags([]string{
+		"--id=source", "--sink-uri=blackhole://", "--sync-interval=1m",
+	}), check.IsNil)
+
+	info := &model.ChangeFeedInfo{
+		SinkURI:            "mysql://127.0.0.1:3306/",
+		Engine:             model.SortInMemory,
+		Opts:               map[string]string{"k": "v"},
+		SyncPointEnabled:   true,
+		SyncPointInterval:  5 * time.Minute,
+		SyncPointRetention: 24 * time.Hour,
+		Config:             config.GetDefaultReplicaConfig(),
+	}
+	o.applySourceChangefeed(info, cmd)
+	commonOptions := o.createChangefeedOptions.commonChangefeedOptions
+	c.Assert(o.sourceChangefeedID, check.Equals, "source")
+	c.Assert(commonOptions.sinkURI, check.Equals, "blackhole://")
+	c.Assert(commonOptions.sortEngine, check.Equals, model.SortInMemory)
+	c.Assert(commonOptions.opts, check.DeepEquals, []string{"k=v"})
+	c.Assert(commonOptions.syncPointEnabled, check.IsTrue)
+	c.Assert(commonOptions.syncPointInterval, check.Equals, time.Minute)
+	c.Assert(commonOptions.syncPointRetention, check.Equals, 24*time.Hour)
+	c.Assert(o.createChangefeedOptions.baseCfg, check.Equals, info.Config)
+}
@@ -21,10 +21,13 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/entry"
+	"github.com/pingcap/ticdc/cdc/kv"
 	"github.com/pingcap/ticdc/cdc/model"
 	cmdcontext "github.com/pingcap/ticdc/pkg/cmd/context"
 	"github.com/pingcap/ticdc/pkg/cmd/factory"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/security"
 	"github.com/r3labs/diff"
 	"github.com/spf13/cobra"
@@ -37,6 +40,7 @@ type updateChangefeedOptions struct {
 	etcdClient *etcd.CDCEtcdClient
 
 	credential *security.Credential
+	pdAddr     string
 
 	commonChangefeedOptions *changefeedCommonOptions
 	changefeedID            string
@@ -71,29 +75,41 @@ func (o *updateChangefeedOptions) complete(f factory.Factory) error {
 	o.etcdClient = etcdClient
 
 	o.credential = f.GetCredential()
+	o.pdAddr = f.GetPdAddr()
 
 	return nil
 }
 
-// run the `cli changefeed update` command.
+// run the `cli changefeed update` command. The changefeed is updated by the
+// owner like the OpenAPI does if the owner exists, a running changefeed is
+// restarted from the checkpoint with the new config.
 func (o *updateChangefeedOptions) run(cmd *cobra.Command) error {
 	ctx := cmdcontext.GetDefaultContext()
 
-	resp, err := sendOwnerChangefeedQuery(ctx, o.etcdClient, o.changefeedID, o.credential)
+	_, err := sendOwnerChangefeedQuery(ctx, o.etcdClient, o.changefeedID, o.credential)
 	// if no cdc owner exists, allow user to update changefeed config
 	if err != nil && errors.Cause(err) != cerror.ErrOwnerNotFound {
 		return err
 	}
-	// Note that the correctness of the logic here depends on the return value of `/capture/owner/changefeed/query` interface.
-	// TODO: Using error codes instead of string containing judgments
-	if err == nil && !strings.Contains(resp, `"state": "stopped"`) {
-		return errors.Errorf("can only update changefeed config when it is stopped\nstatus: %s", resp)
-	}
+	ownerExists := err == nil
 
 	old, err := o.etcdClient.GetChangeFeedInfo(ctx, o.changefeedID)
 	if err != nil {
 		return err
 	}
+	var running bool
+	switch old.State {
+	case model.StateNormal, model.StateError:
+		running = ownerExists
+	case model.StateStopped, model.StateFailed:
+	default:
+		return errors.Errorf("can not update changefeed config when it is %s", old.State)
+	}
+	status, _, err := o.etcdClient.GetChangeFeedStatus(ctx, o.changefeedID)
+	if err != nil && cerror.ErrChangeFeedNotExists.NotEqual(err) {
+		return err
+	}
+	checkpointTs := old.GetCheckpointTs(status)
 
 	newInfo, err := o.applyChanges(old, cmd)
 	if err != nil {
@@ -113,6 +129,17 @@ func (o *updateChangefeedOptions) run(cmd *cobra.Command) error {
 		cmd.Printf("%+v\n", change)
 	}
 
+	warnings, err := o.verifyFilterChange(old, newInfo, checkpointTs)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		cmd.Printf("[WARN] %s\n", warning)
+	}
+	if running {
+		cmd.Printf("The changefeed is running, it will be restarted from the checkpoint with the new config\n")
+	}
+
 	if !o.commonChangefeedOptions.noConfirm {
 		cmd.Printf("Could you agree to apply changes above to changefeed [Y/N]\n")
 		var yOrN string
@@ -126,7 +153,15 @@ func (o *updateChangefeedOptions) run(cmd *cobra.Command) error {
 		}
 	}
 
-	err = o.etcdClient.SaveChangeFeedInfo(ctx, newInfo, o.changefeedID)
+	if _, err = newInfo.RecordUpdate(old, model.ChangefeedUpdateSourceCLI, checkpointTs, warnings); err != nil {
+		return err
+	}
+	if ownerExists {
+		// the owner keeps the states maintained by itself in the new info
+		err = sendOwnerChangefeedUpdate(ctx, o.etcdClient, o.changefeedID, newInfo, o.credential)
+	} else {
+		err = o.etcdClient.SaveChangeFeedInfo(ctx, newInfo, o.changefeedID)
+	}
 	if err != nil {
 		return err
 	}
@@ -140,6 +175,29 @@ func (o *updateChangefeedOptions) run(cmd *cobra.Command) error {
 	return nil
 }
 
+// verifyFilterChange returns the warnings about the data skipped if the filter
+// config of the changefeed is changed at the checkpoint ts.
+func (o *updateChangefeedOptions) verifyFilterChange(oldInfo, newInfo *model.ChangeFeedInfo, checkpointTs uint64) ([]string, error) {
+	if !filter.IsChanged(oldInfo.Config, newInfo.Config) {
+		return nil, nil
+	}
+	pdAddr, credential := o.pdAddr, o.credential
+	if oldInfo.Upstream != nil {
+		pdAddr = strings.Join(oldInfo.Upstream.PDAddrs, ",")
+		credential = &security.Credential{
+			CAPath:   oldInfo.Upstream.CAPath,
+			CertPath: oldInfo.Upstream.CertPath,
+			KeyPath:  oldInfo.Upstream.KeyPath,
+		}
+	}
+	kvStore, err := kv.CreateTiStore(pdAddr, credential)
+	if err != nil {
+		return nil, err
+	}
+	defer kvStore.Close() //nolint:errcheck
+	return entry.VerifyFilterChange(oldInfo, newInfo, kvStore, checkpointTs)
+}
+
 // applyChanges applies the new changes to the old changefeed.
 func (o *updateChangefeedOptions) applyChanges(oldInfo *model.ChangeFeedInfo, cmd *cobra.Command) (*model.ChangeFeedInfo, error) {
 	newInfo, err := oldInfo.Clone()
@@ -153,6 +211,8 @@ func (o *updateChangefeedOptions) applyChanges(oldInfo *model.ChangeFeedInfo, cm
 			newInfo.TargetTs = o.commonChangefeedOptions.targetTs
 		case "sink-uri":
 			newInfo.SinkURI = o.commonChangefeedOptions.sinkURI
+		case "standby-sink-uri":
+			newInfo.StandbySinkURI = o.commonChangefeedOptions.standbySinkURI
 		case "config":
 			cfg := newInfo.Config
 			if err = o.commonChangefeedOptions.strictDecodeConfig("TiCDC changefeed", cfg); err != nil {
@@ -189,6 +249,8 @@ func (o *updateChangefeedOptions) applyChanges(oldInfo *model.ChangeFeedInfo, cm
 			newInfo.SyncPointEnabled = o.commonChangefeedOptions.syncPointEnabled
 		case "sync-interval":
 			newInfo.SyncPointInterval = o.commonChangefeedOptions.syncPointInterval
+		case "sync-point-retention":
+			newInfo.SyncPointRetention = o.commonChangefeedOptions.syncPointRetention
 		case "sort-dir":
 			log.Warn("this flag cannot be updated and will be ignored", zap.String("flagName", flag.Name))
 		case "changefeed-id", "no-confirm", "cyclic-filter-replica-ids":
@@ -208,6 +270,9 @@ func (o *updateChangefeedOptions) applyChanges(oldInfo *model.ChangeFeedInfo, cm
 	if err != nil {
 		return nil, err
 	}
+	if err = newInfo.ValidateStandbySinkURI(); err != nil {
+		return nil, err
+	}
 
 	return newInfo, nil
 }
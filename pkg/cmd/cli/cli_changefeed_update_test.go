@@ -69,6 +69,18 @@ func (s *changefeedUpdateSuite) TestApplyChanges(c *check.C) {
 	file, err := os.ReadFile(filename)
 	c.Assert(err, check.IsNil)
 	c.Assert(strings.Contains(string(file), "this flag cannot be updated and will be ignored"), check.IsTrue)
+
+	// Test for the standby sink.
+	oldInfo = &model.ChangeFeedInfo{SinkURI: "blackhole://"}
+	c.Assert(cmd.ParseFlags([]string{"--standby-sink-uri=mysql://root@standby-tidb:4000"}), check.IsNil)
+	newInfo, err = o.applyChanges(oldInfo, cmd)
+	c.Assert(err, check.IsNil)
+	c.Assert(newInfo.StandbySinkURI, check.Equals, "mysql://root@standby-tidb:4000")
+
+	oldInfo = &model.ChangeFeedInfo{SinkURI: "blackhole://"}
+	c.Assert(cmd.ParseFlags([]string{"--standby-sink-uri=kafka://standby-kafka:9092/topic"}), check.IsNil)
+	_, err = o.applyChanges(oldInfo, cmd)
+	c.Assert(err, check.ErrorMatches, ".*the scheme of standby-sink-uri.*")
 }
 
 func initTestLogger(filename string) (func(), error) {
@@ -17,8 +17,10 @@ import (
 	"os"
 
 	"github.com/pingcap/ticdc/pkg/cmd/cli"
+	"github.com/pingcap/ticdc/pkg/cmd/consumer"
 	"github.com/pingcap/ticdc/pkg/cmd/redo"
 	"github.com/pingcap/ticdc/pkg/cmd/server"
+	"github.com/pingcap/ticdc/pkg/cmd/sorterbench"
 	"github.com/pingcap/ticdc/pkg/cmd/version"
 	"github.com/spf13/cobra"
 )
@@ -43,6 +45,8 @@ func Run() {
 	cmd.AddCommand(cli.NewCmdCli())
 	cmd.AddCommand(version.NewCmdVersion())
 	cmd.AddCommand(redo.NewCmdRedo())
+	cmd.AddCommand(consumer.NewCmdVerifyConsumer())
+	cmd.AddCommand(sorterbench.NewCmdSorterBench())
 
 	if err := cmd.Execute(); err != nil {
 		cmd.Println(err)
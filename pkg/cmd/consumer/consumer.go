@@ -0,0 +1,115 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	cmdcontext "github.com/pingcap/ticdc/pkg/cmd/context"
+	"github.com/pingcap/ticdc/pkg/cmd/util"
+	"github.com/pingcap/ticdc/pkg/consumer"
+	"github.com/pingcap/ticdc/pkg/logutil"
+	"github.com/pingcap/ticdc/pkg/security"
+	"github.com/spf13/cobra"
+)
+
+// options defines flags for the `verify-consumer` command.
+type options struct {
+	upstreamURI   string
+	downstreamURI string
+	timezone      string
+	idleTimeout   time.Duration
+	logFile       string
+	logLevel      string
+	ca, cert, key string
+}
+
+// newOptions creates new options for the `verify-consumer` command.
+func newOptions() *options {
+	return &options{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *options) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.upstreamURI, "upstream-uri", "", "kafka uri of the topic to consume, "+
+		"eg, \"kafka://127.0.0.1:9092/topic?protocol=canal-json&enable-tidb-extension=true\"")
+	cmd.Flags().StringVar(&o.downstreamURI, "downstream-uri", "", "sink uri which the events are applied to, "+
+		"the events are only checked if it is not specified")
+	cmd.Flags().StringVar(&o.timezone, "tz", "System", "time zone of the downstream")
+	cmd.Flags().DurationVar(&o.idleTimeout, "idle-timeout", 0, "stop consuming after no message is received "+
+		"for the duration, 0 means consuming until interrupted")
+	cmd.Flags().StringVar(&o.logFile, "log-file", "", "log file path")
+	cmd.Flags().StringVar(&o.logLevel, "log-level", "info", "log level (etc: debug|info|warn|error)")
+	cmd.Flags().StringVar(&o.ca, "ca", "", "CA certificate path for Kafka SSL connection")
+	cmd.Flags().StringVar(&o.cert, "cert", "", "Certificate path for Kafka SSL connection")
+	cmd.Flags().StringVar(&o.key, "key", "", "Private key path for Kafka SSL connection")
+	// the possible error returned from MarkFlagRequired is `no such flag`
+	cmd.MarkFlagRequired("upstream-uri") //nolint:errcheck
+}
+
+// run runs the `verify-consumer` command.
+func (o *options) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	cfg := consumer.NewConfig()
+	if err := cfg.ParseUpstreamURI(o.upstreamURI); err != nil {
+		return err
+	}
+	cfg.DownstreamURI = o.downstreamURI
+	cfg.Timezone = o.timezone
+	cfg.IdleTimeout = o.idleTimeout
+	cfg.Credential = &security.Credential{
+		CAPath:   o.ca,
+		CertPath: o.cert,
+		KeyPath:  o.key,
+	}
+	c, err := consumer.New(cfg)
+	if err != nil {
+		return err
+	}
+	err = c.Run(ctx)
+	report := c.Report()
+	cmd.Println(report)
+	if err != nil {
+		return err
+	}
+	if report.ViolationCount != 0 {
+		return errors.Errorf("%d violations are found", report.ViolationCount)
+	}
+	return nil
+}
+
+// NewCmdVerifyConsumer creates the `verify-consumer` command.
+func NewCmdVerifyConsumer() *cobra.Command {
+	o := newOptions()
+
+	command := &cobra.Command{
+		Use:   "verify-consumer",
+		Short: "Consume a topic replicated by TiCDC and verify the ordering and the at-least-once delivery of the events",
+		Long: `Consume a Kafka topic replicated by TiCDC with the codec protocol specified in the upstream-uri,
+check that the rows and the DDLs are delivered in order and at least once, and apply the events
+to the downstream if the downstream-uri is specified.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cancel := util.InitCmd(cmd, &logutil.Config{File: o.logFile, Level: o.logLevel})
+			defer cancel()
+
+			return o.run(cmd)
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
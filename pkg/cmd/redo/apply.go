@@ -22,7 +22,8 @@ import (
 // applyRedoOptions defines flags for the `redo apply` command.
 type applyRedoOptions struct {
 	options
-	sinkURI string
+	sinkURI  string
+	targetTs uint64
 }
 
 // newapplyRedoOptions creates new applyRedoOptions for the `redo apply` command.
@@ -34,6 +35,8 @@ func newapplyRedoOptions() *applyRedoOptions {
 // flags related to template printing to it.
 func (o *applyRedoOptions) addFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&o.sinkURI, "sink-uri", "", "target database sink-uri")
+	cmd.Flags().Uint64Var(&o.targetTs, "target-ts", 0, "roll the target database back to the consistent state at target-ts "+
+		"after applying redo logs, requires old value enabled, default to the resolved-ts of redo logs")
 	// the possible error returned from MarkFlagRequired is `no such flag`
 	cmd.MarkFlagRequired("sink-uri") //nolint:errcheck
 }
@@ -43,9 +46,10 @@ func (o *applyRedoOptions) run(cmd *cobra.Command) error {
 	ctx := cmdcontext.GetDefaultContext()
 
 	cfg := &applier.RedoApplierConfig{
-		Storage: o.storage,
-		SinkURI: o.sinkURI,
-		Dir:     o.dir,
+		Storage:  o.storage,
+		SinkURI:  o.sinkURI,
+		Dir:      o.dir,
+		TargetTs: o.targetTs,
 	}
 	ap := applier.NewRedoApplier(cfg)
 	err := ap.Apply(ctx)
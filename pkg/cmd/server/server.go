@@ -41,6 +41,7 @@ import (
 // options defines flags for the `server` command.
 type options struct {
 	serverConfig         *config.ServerConfig
+	flagServerConfig     *config.ServerConfig // holds the values of the flags
 	serverPdAddr         string
 	serverConfigFilePath string
 
@@ -67,6 +68,7 @@ func (o *options) addFlags(cmd *cobra.Command) {
 	cmd.Flags().Int64Var(&o.serverConfig.GcTTL, "gc-ttl", o.serverConfig.GcTTL, "CDC GC safepoint TTL duration, specified in seconds")
 	cmd.Flags().StringVar(&o.serverConfig.LogFile, "log-file", o.serverConfig.LogFile, "log file path")
 	cmd.Flags().StringVar(&o.serverConfig.LogLevel, "log-level", o.serverConfig.LogLevel, "log level (etc: debug|info|warn|error)")
+	cmd.Flags().StringToStringVar(&o.serverConfig.Labels, "labels", o.serverConfig.Labels, "the labels of the capture, such as zone=z1,ssd=true")
 	cmd.Flags().StringVar(&o.serverConfig.DataDir, "data-dir", o.serverConfig.DataDir, "the path to the directory used to store TiCDC-generated data")
 	cmd.Flags().DurationVar((*time.Duration)(&o.serverConfig.OwnerFlushInterval), "owner-flush-interval", time.Duration(o.serverConfig.OwnerFlushInterval), "owner flushes changefeed status interval")
 	cmd.Flags().DurationVar((*time.Duration)(&o.serverConfig.ProcessorFlushInterval), "processor-flush-interval", time.Duration(o.serverConfig.ProcessorFlushInterval), "processor flushes task status interval")
@@ -90,12 +92,18 @@ func (o *options) addFlags(cmd *cobra.Command) {
 
 // run runs the server cmd.
 func (o *options) run(cmd *cobra.Command) error {
-	cancel := util.InitCmd(cmd, &logutil.Config{
+	cancel := util.InitCmdWithReload(cmd, &logutil.Config{
 		File:           o.serverConfig.LogFile,
 		Level:          o.serverConfig.LogLevel,
 		FileMaxSize:    o.serverConfig.Log.File.MaxSize,
 		FileMaxDays:    o.serverConfig.Log.File.MaxDays,
 		FileMaxBackups: o.serverConfig.Log.File.MaxBackups,
+		Format:         o.serverConfig.Log.Format,
+		ChangefeedDir:  o.serverConfig.Log.ChangefeedDir,
+	}, func() {
+		if _, err := config.ReloadGlobalServerConfig("SIGHUP"); err != nil {
+			log.Warn("fail to reload server config", zap.Error(err))
+		}
 	})
 	defer cancel()
 
@@ -105,6 +113,10 @@ func (o *options) run(cmd *cobra.Command) error {
 	}
 
 	config.StoreGlobalServerConfig(o.serverConfig)
+	config.SetServerConfigLoader(func() (*config.ServerConfig, error) {
+		// the warnings are printed when the server starts.
+		return o.loadServerConfig(cmd, func(string, ...interface{}) {})
+	})
 	ctx := ticdcutil.PutTimezoneInCtx(cmdcontext.GetDefaultContext(), tz)
 	ctx = ticdcutil.PutCaptureAddrInCtx(ctx, o.serverConfig.AdvertiseAddr)
 
@@ -140,19 +152,39 @@ func (o *options) run(cmd *cobra.Command) error {
 // complete adapts from the command line args and config file to the data required.
 func (o *options) complete(cmd *cobra.Command) error {
 	o.serverConfig.Security = o.getCredential()
+	o.flagServerConfig = o.serverConfig
+
+	cfg, err := o.loadServerConfig(cmd, cmd.Printf)
+	if err != nil {
+		return err
+	}
+
+	if cfg.DataDir == "" {
+		cmd.Printf(color.HiYellowString("[WARN] TiCDC server data-dir is not set. " +
+			"Please use `cdc server --data-dir` to start the cdc server if possible.\n"))
+	}
+
+	o.serverConfig = cfg
 
+	return nil
+}
+
+// loadServerConfig loads the server config from the config file, and overrides it by the flags specified.
+// It's called again to reload the server config, warnf is used to print the warnings.
+func (o *options) loadServerConfig(cmd *cobra.Command, warnf func(format string, i ...interface{})) (*config.ServerConfig, error) {
+	flagCfg := o.flagServerConfig
 	cfg := config.GetDefaultServerConfig()
 
 	if len(o.serverConfigFilePath) > 0 {
 		// strict decode config file, but ignore debug item
 		if err := util.StrictDecodeFile(o.serverConfigFilePath, "TiCDC server", cfg, config.DebugConfigurationItem); err != nil {
-			return err
+			return nil, err
 		}
 
 		// User specified sort-dir should not take effect, it's always `/tmp/sorter`
 		// if user try to set sort-dir by config file, warn it.
 		if cfg.Sorter.SortDir != config.DefaultSortDir {
-			cmd.Printf(color.HiYellowString("[WARN] --sort-dir is deprecated in server settings. " +
+			warnf(color.HiYellowString("[WARN] --sort-dir is deprecated in server settings. " +
 				"sort-dir will be set to `{data-dir}/tmp/sorter`. The sort-dir here will be no-op\n"))
 
 			cfg.Sorter.SortDir = config.DefaultSortDir
@@ -162,46 +194,48 @@ func (o *options) complete(cmd *cobra.Command) error {
 	cmd.Flags().Visit(func(flag *pflag.Flag) {
 		switch flag.Name {
 		case "addr":
-			cfg.Addr = o.serverConfig.Addr
+			cfg.Addr = flagCfg.Addr
 		case "advertise-addr":
-			cfg.AdvertiseAddr = o.serverConfig.AdvertiseAddr
+			cfg.AdvertiseAddr = flagCfg.AdvertiseAddr
 		case "tz":
-			cfg.TZ = o.serverConfig.TZ
+			cfg.TZ = flagCfg.TZ
 		case "gc-ttl":
-			cfg.GcTTL = o.serverConfig.GcTTL
+			cfg.GcTTL = flagCfg.GcTTL
 		case "log-file":
-			cfg.LogFile = o.serverConfig.LogFile
+			cfg.LogFile = flagCfg.LogFile
 		case "log-level":
-			cfg.LogLevel = o.serverConfig.LogLevel
+			cfg.LogLevel = flagCfg.LogLevel
 		case "data-dir":
-			cfg.DataDir = o.serverConfig.DataDir
+			cfg.DataDir = flagCfg.DataDir
+		case "labels":
+			cfg.Labels = flagCfg.Labels
 		case "owner-flush-interval":
-			cfg.OwnerFlushInterval = o.serverConfig.OwnerFlushInterval
+			cfg.OwnerFlushInterval = flagCfg.OwnerFlushInterval
 		case "processor-flush-interval":
-			cfg.ProcessorFlushInterval = o.serverConfig.ProcessorFlushInterval
+			cfg.ProcessorFlushInterval = flagCfg.ProcessorFlushInterval
 		case "sorter-num-workerpool-goroutine":
-			cfg.Sorter.NumWorkerPoolGoroutine = o.serverConfig.Sorter.NumWorkerPoolGoroutine
+			cfg.Sorter.NumWorkerPoolGoroutine = flagCfg.Sorter.NumWorkerPoolGoroutine
 		case "sorter-num-concurrent-worker":
-			cfg.Sorter.NumConcurrentWorker = o.serverConfig.Sorter.NumConcurrentWorker
+			cfg.Sorter.NumConcurrentWorker = flagCfg.Sorter.NumConcurrentWorker
 		case "sorter-chunk-size-limit":
-			cfg.Sorter.ChunkSizeLimit = o.serverConfig.Sorter.ChunkSizeLimit
+			cfg.Sorter.ChunkSizeLimit = flagCfg.Sorter.ChunkSizeLimit
 		case "sorter-max-memory-percentage":
-			cfg.Sorter.MaxMemoryPressure = o.serverConfig.Sorter.MaxMemoryPressure
+			cfg.Sorter.MaxMemoryPressure = flagCfg.Sorter.MaxMemoryPressure
 		case "sorter-max-memory-consumption":
-			cfg.Sorter.MaxMemoryConsumption = o.serverConfig.Sorter.MaxMemoryConsumption
+			cfg.Sorter.MaxMemoryConsumption = flagCfg.Sorter.MaxMemoryConsumption
 		case "ca":
-			cfg.Security.CAPath = o.serverConfig.Security.CAPath
+			cfg.Security.CAPath = flagCfg.Security.CAPath
 		case "cert":
-			cfg.Security.CertPath = o.serverConfig.Security.CertPath
+			cfg.Security.CertPath = flagCfg.Security.CertPath
 		case "key":
-			cfg.Security.KeyPath = o.serverConfig.Security.KeyPath
+			cfg.Security.KeyPath = flagCfg.Security.KeyPath
 		case "cert-allowed-cn":
-			cfg.Security.CertAllowedCN = o.serverConfig.Security.CertAllowedCN
+			cfg.Security.CertAllowedCN = flagCfg.Security.CertAllowedCN
 		case "sort-dir":
 			// user specified sorter dir should not take effect, it's always `/tmp/sorter`
 			// if user try to set sort-dir by flag, warn it.
-			if o.serverConfig.Sorter.SortDir != config.DefaultSortDir {
-				cmd.Printf(color.HiYellowString("[WARN] --sort-dir is deprecated in server settings. " +
+			if flagCfg.Sorter.SortDir != config.DefaultSortDir {
+				warnf(color.HiYellowString("[WARN] --sort-dir is deprecated in server settings. " +
 					"sort-dir will be set to `{data-dir}/tmp/sorter`. The sort-dir here will be no-op\n"))
 			}
 			cfg.Sorter.SortDir = config.DefaultSortDir
@@ -213,17 +247,9 @@ func (o *options) complete(cmd *cobra.Command) error {
 	})
 
 	if err := cfg.ValidateAndAdjust(); err != nil {
-		return errors.Trace(err)
-	}
-
-	if cfg.DataDir == "" {
-		cmd.Printf(color.HiYellowString("[WARN] TiCDC server data-dir is not set. " +
-			"Please use `cdc server --data-dir` to start the cdc server if possible.\n"))
+		return nil, errors.Trace(err)
 	}
-
-	o.serverConfig = cfg
-
-	return nil
+	return cfg, nil
 }
 
 // validate checks that the provided attach options are specified.
@@ -161,6 +161,7 @@ func (s *serverSuite) TestParseCfg(c *check.C) {
 				MaxDays:    0,
 				MaxBackups: 0,
 			},
+			Format: "text",
 		},
 		DataDir:                dataDir,
 		GcTTL:                  10,
@@ -175,6 +176,10 @@ func (s *serverSuite) TestParseCfg(c *check.C) {
 			MaxMemoryConsumption:   60000,
 			NumWorkerPoolGoroutine: 90,
 			SortDir:                config.DefaultSortDir,
+			FileCompression:        "none",
+			SegmentSize:            64 * 1024 * 1024,
+			DiskFullTimeout:        config.TomlDuration(10 * time.Minute),
+			DiskFullMemoryQuota:    256 * 1024 * 1024,
 			EnableLevelDB:          false,
 			LevelDB: config.LevelDBConfig{
 				Count:                  16,
@@ -205,6 +210,12 @@ func (s *serverSuite) TestParseCfg(c *check.C) {
 		Debug: &config.DebugConfig{
 			EnableTableActor: true,
 		},
+		Tracing: &config.TracingConfig{
+			SampleRatio: 0.01,
+		},
+		Reconcile: &config.ReconcileConfig{
+			Interval: config.TomlDuration(30 * time.Second),
+		},
 	})
 }
 
@@ -228,6 +239,10 @@ capture-session-ttl = 10
 owner-flush-interval = "600ms"
 processor-flush-interval = "600ms"
 
+[log]
+format = "json"
+changefeed-dir = "/root/changefeed-logs"
+
 [log.file]
 max-size = 200
 max-days = 1
@@ -279,6 +294,8 @@ cleanup-speed-limit = 14
 				MaxDays:    1,
 				MaxBackups: 1,
 			},
+			Format:        "json",
+			ChangefeedDir: "/root/changefeed-logs",
 		},
 		DataDir:                dataDir,
 		GcTTL:                  500,
@@ -293,6 +310,10 @@ cleanup-speed-limit = 14
 			MaxMemoryConsumption:   2000000,
 			NumWorkerPoolGoroutine: 5,
 			SortDir:                config.DefaultSortDir,
+			FileCompression:        "none",
+			SegmentSize:            64 * 1024 * 1024,
+			DiskFullTimeout:        config.TomlDuration(10 * time.Minute),
+			DiskFullMemoryQuota:    256 * 1024 * 1024,
 			EnableLevelDB:          false,
 			LevelDB: config.LevelDBConfig{
 				Count:                  5,
@@ -319,6 +340,12 @@ cleanup-speed-limit = 14
 		Debug: &config.DebugConfig{
 			EnableTableActor: true,
 		},
+		Tracing: &config.TracingConfig{
+			SampleRatio: 0.01,
+		},
+		Reconcile: &config.ReconcileConfig{
+			Interval: config.TomlDuration(30 * time.Second),
+		},
 	})
 }
 
@@ -400,6 +427,7 @@ cert-allowed-cn = ["dd","ee"]
 				MaxDays:    1,
 				MaxBackups: 1,
 			},
+			Format: "text",
 		},
 		DataDir:                dataDir,
 		GcTTL:                  10,
@@ -414,6 +442,10 @@ cert-allowed-cn = ["dd","ee"]
 			MaxMemoryConsumption:   60000000,
 			NumWorkerPoolGoroutine: 5,
 			SortDir:                config.DefaultSortDir,
+			FileCompression:        "none",
+			SegmentSize:            64 * 1024 * 1024,
+			DiskFullTimeout:        config.TomlDuration(10 * time.Minute),
+			DiskFullMemoryQuota:    256 * 1024 * 1024,
 			EnableLevelDB:          false,
 			LevelDB: config.LevelDBConfig{
 				Count:                  16,
@@ -444,5 +476,11 @@ cert-allowed-cn = ["dd","ee"]
 		Debug: &config.DebugConfig{
 			EnableTableActor: true,
 		},
+		Tracing: &config.TracingConfig{
+			SampleRatio: 0.01,
+		},
+		Reconcile: &config.ReconcileConfig{
+			Interval: config.TomlDuration(30 * time.Second),
+		},
 	})
 }
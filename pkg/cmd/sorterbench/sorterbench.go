@@ -0,0 +1,96 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sorterbench
+
+import (
+	cmdcontext "github.com/pingcap/ticdc/pkg/cmd/context"
+	"github.com/pingcap/ticdc/pkg/cmd/util"
+	"github.com/pingcap/ticdc/pkg/logutil"
+	"github.com/pingcap/ticdc/pkg/sorterbench"
+	"github.com/spf13/cobra"
+)
+
+// options defines flags for the `sorter-bench` command.
+type options struct {
+	cfg      *sorterbench.Config
+	logFile  string
+	logLevel string
+}
+
+// newOptions creates new options for the `sorter-bench` command.
+func newOptions() *options {
+	return &options{cfg: sorterbench.NewConfig()}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *options) addFlags(cmd *cobra.Command) {
+	cfg := o.cfg
+	cmd.Flags().StringVar(&cfg.Engine, "sort-engine", cfg.Engine, "sort engine under test (etc: memory|unified|low-latency)")
+	cmd.Flags().StringVar(&cfg.SortDir, "sort-dir", cfg.SortDir, "directory used by the unified sorter, "+
+		"it should be on the disk to be sized")
+	cmd.Flags().IntVar(&cfg.MaxMemoryPressure, "max-memory-pressure", cfg.MaxMemoryPressure,
+		"memory pressure in percentage above which the unified sorter spills the data to the disk, "+
+			"0 means always using the disk")
+	cmd.Flags().IntVar(&cfg.NumTables, "tables", cfg.NumTables, "number of the tables, each table has its own sorter")
+	cmd.Flags().IntVar(&cfg.NumEvents, "events", cfg.NumEvents, "number of the row events generated")
+	cmd.Flags().IntVar(&cfg.Rate, "rate", cfg.Rate, "number of the row events generated per second, "+
+		"0 means as fast as possible")
+	cmd.Flags().IntVar(&cfg.TxnSize, "txn-size", cfg.TxnSize, "number of the row events in a transaction")
+	cmd.Flags().StringVar(&cfg.KeyDistribution, "key-distribution", cfg.KeyDistribution,
+		"distribution of the keys in a table (etc: uniform|zipf|sequential)")
+	cmd.Flags().IntVar(&cfg.NumKeys, "keys", cfg.NumKeys, "number of the distinct keys in a table")
+	cmd.Flags().IntVar(&cfg.ValueSize, "value-size", cfg.ValueSize, "size of the value of a row event in bytes")
+	cmd.Flags().DurationVar(&cfg.ResolvedInterval, "resolved-interval", cfg.ResolvedInterval,
+		"interval of the resolved events sent to the sorters")
+	cmd.Flags().StringVar(&o.logFile, "log-file", "", "log file path")
+	cmd.Flags().StringVar(&o.logLevel, "log-level", "info", "log level (etc: debug|info|warn|error)")
+}
+
+// run runs the `sorter-bench` command.
+func (o *options) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	bench, err := sorterbench.New(o.cfg)
+	if err != nil {
+		return err
+	}
+	report, err := bench.Run(ctx)
+	if report != nil {
+		cmd.Println(report)
+	}
+	return err
+}
+
+// NewCmdSorterBench creates the `sorter-bench` command.
+func NewCmdSorterBench() *cobra.Command {
+	o := newOptions()
+
+	command := &cobra.Command{
+		Use:   "sorter-bench",
+		Short: "Benchmark a sort engine with synthetic events",
+		Long: `Generate synthetic events with the configured rate, key distribution and transaction size
+against the sort engine, and report the throughput, the resolved latency and the peak disk usage
+of the sort dir, which helps to size the hardware of the sort dir.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cancel := util.InitCmd(cmd, &logutil.Config{File: o.logFile, Level: o.logLevel})
+			defer cancel()
+
+			return o.run(cmd)
+		},
+	}
+	o.addFlags(command)
+
+	return command
+}
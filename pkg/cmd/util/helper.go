@@ -42,6 +42,12 @@ const (
 
 // InitCmd initializes the logger, the default context and returns its cancel function.
 func InitCmd(cmd *cobra.Command, logCfg *logutil.Config) context.CancelFunc {
+	return InitCmdWithReload(cmd, logCfg, nil)
+}
+
+// InitCmdWithReload initializes the command like InitCmd, and calls reload instead of exiting
+// when SIGHUP is received if reload is not nil.
+func InitCmdWithReload(cmd *cobra.Command, logCfg *logutil.Config, reload func()) context.CancelFunc {
 	// Init log.
 	err := logutil.InitLogger(logCfg)
 	if err != nil {
@@ -59,9 +65,16 @@ func InitCmd(cmd *cobra.Command, logCfg *logutil.Config) context.CancelFunc {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
-		sig := <-sc
-		log.Info("got signal to exit", zap.Stringer("signal", sig))
-		cancel()
+		for sig := range sc {
+			if sig == syscall.SIGHUP && reload != nil {
+				log.Info("got signal to reload", zap.Stringer("signal", sig))
+				reload()
+				continue
+			}
+			log.Info("got signal to exit", zap.Stringer("signal", sig))
+			cancel()
+			return
+		}
 	}()
 
 	cmdconetxt.SetDefaultContext(ctx)
@@ -99,7 +112,23 @@ func StrictDecodeFile(path, component string, cfg interface{}, ignoreCheckItems
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return checkUndecodedItems(metaData, component, "config file "+path, ignoreCheckItems...)
+}
+
+// StrictDecodeContent decodes the toml content strictly, it is the same as
+// StrictDecodeFile except that the config is not read from a file.
+func StrictDecodeContent(content, component string, cfg interface{}, ignoreCheckItems ...string) error {
+	metaData, err := toml.Decode(content, cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return checkUndecodedItems(metaData, component, "config", ignoreCheckItems...)
+}
 
+// checkUndecodedItems returns an error if any item in the decoded toml is not
+// mapped into the Config struct.
+func checkUndecodedItems(metaData toml.MetaData, component, source string, ignoreCheckItems ...string) error {
+	var err error
 	// check if item is a ignoreCheckItem
 	hasIgnoreItem := func(item []string) bool {
 		for _, ignoreCheckItem := range ignoreCheckItems {
@@ -125,8 +154,8 @@ func StrictDecodeFile(path, component string, cfg interface{}, ignoreCheckItems
 			hasUnknownConfigSize++
 		}
 		if hasUnknownConfigSize > 0 {
-			err = errors.Errorf("component %s's config file %s contained unknown configuration options: %s",
-				component, path, b.String())
+			err = errors.Errorf("component %s's %s contained unknown configuration options: %s",
+				component, source, b.String())
 		}
 	}
 	return errors.Trace(err)
@@ -170,6 +170,26 @@ max-backups = 1
 	c.Assert(err, check.ErrorMatches, ".*contained unknown configuration options.*")
 }
 
+func (s *utilsSuite) TestStrictDecodeContent(c *check.C) {
+	defer testleak.AfterTest(c)()
+	cfg := config.GetDefaultReplicaConfig()
+	err := StrictDecodeContent(`
+case-sensitive = false
+[filter]
+rules = ['test.*']
+`, "cdc", cfg)
+	c.Assert(err, check.IsNil)
+	c.Assert(cfg.CaseSensitive, check.IsFalse)
+	c.Assert(cfg.Filter.Rules, check.DeepEquals, []string{"test.*"})
+
+	cfg = config.GetDefaultReplicaConfig()
+	err = StrictDecodeContent(`
+[filter]
+unknown = true
+`, "cdc", cfg)
+	c.Assert(err, check.ErrorMatches, ".*component cdc's config contained unknown configuration options: filter.unknown.*")
+}
+
 func (s *utilsSuite) TestAndWriteExampleReplicaTOML(c *check.C) {
 	defer testleak.AfterTest(c)()
 	cfg := config.GetDefaultReplicaConfig()
@@ -180,6 +200,12 @@ func (s *utilsSuite) TestAndWriteExampleReplicaTOML(c *check.C) {
 	c.Assert(cfg.Filter, check.DeepEquals, &config.FilterConfig{
 		IgnoreTxnStartTs: []uint64{1, 2},
 		Rules:            []string{"*.*", "!test.*"},
+		RowFilters: []*config.RowFilterRule{
+			{Matcher: []string{"test1.*"}, Expression: "region = 'us-west'"},
+		},
+		EventFilters: []*config.EventFilterRule{
+			{Matcher: []string{"audit.*"}, IgnoreEvent: []string{"delete"}},
+		},
 	})
 	c.Assert(cfg.Mounter, check.DeepEquals, &config.MounterConfig{
 		WorkerNum: 16,
@@ -189,7 +215,22 @@ func (s *utilsSuite) TestAndWriteExampleReplicaTOML(c *check.C) {
 			{Dispatcher: "ts", Matcher: []string{"test1.*", "test2.*"}},
 			{Dispatcher: "rowid", Matcher: []string{"test3.*", "test4.*"}},
 		},
-		Protocol: "default",
+		Protocol:  "default",
+		AddColumn: &config.AddColumnConfig{},
+		Failover:  &config.FailoverConfig{Threshold: 300, ProbeInterval: 10},
+		CSV:       &config.CSVConfig{Delimiter: ",", Quote: "\"", NullString: "\\N"},
+	})
+	c.Assert(cfg.SortEngine, check.DeepEquals, &config.SortEngineConfig{
+		Rules: []*config.SortEngineRule{
+			{Matcher: []string{"test1.*"}, Engine: "memory"},
+		},
+	})
+	c.Assert(cfg.SLO, check.DeepEquals, &config.SLOConfig{})
+	c.Assert(cfg.Placement, check.DeepEquals, &config.PlacementConfig{})
+	c.Assert(cfg.DDL, check.DeepEquals, &config.DDLConfig{
+		CreateTableLike:     config.DDLPolicyRewrite,
+		TemporaryTable:      config.DDLPolicySkip,
+		CreateTableAsSelect: config.DDLPolicyRewrite,
 	})
 	c.Assert(cfg.Cyclic, check.DeepEquals, &config.CyclicConfig{
 		Enable:          false,
@@ -0,0 +1,270 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4"
+	"github.com/pingcap/errors"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+func init() {
+	Register(None, newNoneCodec)
+	Register(Gzip, newGzipCodec)
+	Register(Snappy, newSnappyCodec)
+	Register(LZ4, newLZ4Codec)
+	Register(ZSTD, newZSTDCodec)
+}
+
+// grow makes sure dst has room for n more bytes.
+func grow(dst []byte, n int) []byte {
+	if cap(dst)-len(dst) >= n {
+		return dst
+	}
+	buf := make([]byte, len(dst), len(dst)+n)
+	copy(buf, dst)
+	return buf
+}
+
+func errLevelNotSupported(level int) error {
+	return errors.Errorf("the level %d is not supported", level)
+}
+
+type noneCodec struct{}
+
+func newNoneCodec(level int) (Codec, error) {
+	if level != DefaultLevel {
+		return nil, errLevelNotSupported(level)
+	}
+	return noneCodec{}, nil
+}
+
+func (noneCodec) Name() string { return None }
+
+func (noneCodec) Level() int { return DefaultLevel }
+
+func (noneCodec) Compress(dst, src []byte) ([]byte, error) {
+	return append(dst, src...), nil
+}
+
+func (noneCodec) Decompress(dst, src []byte) ([]byte, error) {
+	return append(dst, src...), nil
+}
+
+type gzipCodec struct {
+	level int
+}
+
+func newGzipCodec(level int) (Codec, error) {
+	if level != DefaultLevel && (level < gzip.BestSpeed || level > gzip.BestCompression) {
+		return nil, errLevelNotSupported(level)
+	}
+	return &gzipCodec{level: level}, nil
+}
+
+func (c *gzipCodec) Name() string { return Gzip }
+
+func (c *gzipCodec) Level() int { return c.level }
+
+func (c *gzipCodec) Compress(dst, src []byte) ([]byte, error) {
+	level := c.level
+	if level == DefaultLevel {
+		level = gzip.DefaultCompression
+	}
+	buf := bytes.NewBuffer(dst)
+	w, err := gzip.NewWriterLevel(buf, level)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := w.Write(src); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *gzipCodec) Decompress(dst, src []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, cerror.ErrDecompressFailed.Wrap(err).GenWithStackByArgs(Gzip)
+	}
+	buf := bytes.NewBuffer(dst)
+	if _, err := io.Copy(buf, r); err != nil {
+		return nil, cerror.ErrDecompressFailed.Wrap(err).GenWithStackByArgs(Gzip)
+	}
+	return buf.Bytes(), nil
+}
+
+type snappyCodec struct{}
+
+func newSnappyCodec(level int) (Codec, error) {
+	if level != DefaultLevel {
+		return nil, errLevelNotSupported(level)
+	}
+	return snappyCodec{}, nil
+}
+
+func (snappyCodec) Name() string { return Snappy }
+
+func (snappyCodec) Level() int { return DefaultLevel }
+
+func (snappyCodec) Compress(dst, src []byte) ([]byte, error) {
+	dst = grow(dst, snappy.MaxEncodedLen(len(src)))
+	encoded := snappy.Encode(dst[len(dst):cap(dst)], src)
+	return dst[:len(dst)+len(encoded)], nil
+}
+
+func (snappyCodec) Decompress(dst, src []byte) ([]byte, error) {
+	n, err := snappy.DecodedLen(src)
+	if err != nil {
+		return nil, cerror.ErrDecompressFailed.Wrap(err).GenWithStackByArgs(Snappy)
+	}
+	dst = grow(dst, n)
+	decoded, err := snappy.Decode(dst[len(dst):len(dst)+n], src)
+	if err != nil {
+		return nil, cerror.ErrDecompressFailed.Wrap(err).GenWithStackByArgs(Snappy)
+	}
+	return dst[:len(dst)+len(decoded)], nil
+}
+
+// lz4Codec uses the lz4 block format, which doesn't record the size of the
+// original data, so a compressed block is prefixed by the size of the original
+// data as an uvarint. An incompressible block is stored as is, it is detected
+// by its length being equal to the size of the original data.
+type lz4Codec struct {
+	// level is used as the search depth of the high compression mode,
+	// DefaultLevel means the fast mode.
+	level int
+}
+
+func newLZ4Codec(level int) (Codec, error) {
+	if level < DefaultLevel {
+		return nil, errLevelNotSupported(level)
+	}
+	return &lz4Codec{level: level}, nil
+}
+
+func (c *lz4Codec) Name() string { return LZ4 }
+
+func (c *lz4Codec) Level() int { return c.level }
+
+func (c *lz4Codec) Compress(dst, src []byte) ([]byte, error) {
+	dst = grow(dst, binary.MaxVarintLen64+lz4.CompressBlockBound(len(src)))
+	dst = dst[:len(dst)+binary.PutUvarint(dst[len(dst):cap(dst)], uint64(len(src)))]
+	var (
+		n   int
+		err error
+	)
+	if c.level == DefaultLevel {
+		n, err = lz4.CompressBlock(src, dst[len(dst):cap(dst)], nil)
+	} else {
+		n, err = lz4.CompressBlockHC(src, dst[len(dst):cap(dst)], c.level)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n == 0 || n >= len(src) {
+		return append(dst, src...), nil
+	}
+	return dst[:len(dst)+n], nil
+}
+
+func (c *lz4Codec) Decompress(dst, src []byte) ([]byte, error) {
+	size, n := binary.Uvarint(src)
+	if n <= 0 {
+		return nil, cerror.ErrDecompressFailed.GenWithStackByArgs(LZ4)
+	}
+	src = src[n:]
+	if uint64(len(src)) == size {
+		return append(dst, src...), nil
+	}
+	// the compression ratio of lz4 is at most 255
+	if size > uint64(len(src))*255 {
+		return nil, cerror.ErrDecompressFailed.GenWithStackByArgs(LZ4)
+	}
+	dst = grow(dst, int(size))
+	decoded, err := lz4.UncompressBlock(src, dst[len(dst):len(dst)+int(size)])
+	if err != nil {
+		return nil, cerror.ErrDecompressFailed.Wrap(err).GenWithStackByArgs(LZ4)
+	}
+	if uint64(decoded) != size {
+		return nil, cerror.ErrDecompressFailed.GenWithStackByArgs(LZ4)
+	}
+	return dst[:len(dst)+decoded], nil
+}
+
+var (
+	// zstdDecoder is shared by all the zstd codecs, because the decoding
+	// doesn't depend on the level and every decoder holds some goroutines.
+	zstdDecoder     *zstd.Decoder
+	zstdDecoderErr  error
+	zstdDecoderOnce sync.Once
+)
+
+func getZSTDDecoder() (*zstd.Decoder, error) {
+	zstdDecoderOnce.Do(func() {
+		zstdDecoder, zstdDecoderErr = zstd.NewReader(nil)
+	})
+	return zstdDecoder, zstdDecoderErr
+}
+
+type zstdCodec struct {
+	level   int
+	encoder *zstd.Encoder
+}
+
+func newZSTDCodec(level int) (Codec, error) {
+	if level < DefaultLevel || level > 22 {
+		return nil, errLevelNotSupported(level)
+	}
+	var opts []zstd.EOption
+	if level != DefaultLevel {
+		opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+	}
+	encoder, err := zstd.NewWriter(nil, opts...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &zstdCodec{level: level, encoder: encoder}, nil
+}
+
+func (c *zstdCodec) Name() string { return ZSTD }
+
+func (c *zstdCodec) Level() int { return c.level }
+
+func (c *zstdCodec) Compress(dst, src []byte) ([]byte, error) {
+	return c.encoder.EncodeAll(src, dst), nil
+}
+
+func (c *zstdCodec) Decompress(dst, src []byte) ([]byte, error) {
+	decoder, err := getZSTDDecoder()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dst, err = decoder.DecodeAll(src, dst)
+	if err != nil {
+		return nil, cerror.ErrDecompressFailed.Wrap(err).GenWithStackByArgs(ZSTD)
+	}
+	return dst, nil
+}
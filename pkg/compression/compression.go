@@ -0,0 +1,140 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// Names of the built-in compression algorithms
+const (
+	None   = "none"
+	Gzip   = "gzip"
+	Snappy = "snappy"
+	LZ4    = "lz4"
+	ZSTD   = "zstd"
+)
+
+// DefaultLevel means the default compression level of an algorithm.
+const DefaultLevel = 0
+
+// Codec compresses and decompresses blocks of data. A block compressed by a
+// codec can be decompressed by any codec of the same algorithm regardless of
+// the level. Codecs are safe for concurrent use.
+type Codec interface {
+	// Name returns the name of the compression algorithm.
+	Name() string
+	// Level returns the compression level, DefaultLevel means the default
+	// level of the algorithm.
+	Level() int
+	// Compress appends the compressed src to dst and returns the updated slice.
+	Compress(dst, src []byte) ([]byte, error)
+	// Decompress appends the decompressed src to dst and returns the updated slice.
+	Decompress(dst, src []byte) ([]byte, error)
+}
+
+// Factory creates a codec with the given level, it returns an error if the
+// level is not supported by the algorithm.
+type Factory func(level int) (Codec, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+
+	// codecs caches the codecs created by New, keyed by the normalized spec.
+	codecs sync.Map
+)
+
+// Register registers a compression algorithm. It panics if the name is
+// registered twice.
+func Register(name string, factory Factory) {
+	name = strings.ToLower(name)
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic("compression algorithm " + name + " is registered twice")
+	}
+	registry[name] = factory
+}
+
+// Names returns the names of all the registered compression algorithms.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseSpec parses a compression spec in the format of "name[:level]", such as
+// "zstd" or "zstd:3". An empty spec means no compression.
+func ParseSpec(spec string) (name string, level int, err error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if spec == "" {
+		return None, DefaultLevel, nil
+	}
+	name = spec
+	if i := strings.IndexByte(spec, ':'); i >= 0 {
+		name = strings.TrimSpace(spec[:i])
+		level, err = strconv.Atoi(strings.TrimSpace(spec[i+1:]))
+		if err != nil {
+			return "", 0, cerror.ErrInvalidCompression.GenWithStackByArgs(spec, "the level should be an integer")
+		}
+	}
+	registryMu.RLock()
+	_, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return "", 0, cerror.ErrInvalidCompression.GenWithStackByArgs(
+			spec, "the algorithm should be one of "+strings.Join(Names(), ", "))
+	}
+	return name, level, nil
+}
+
+// New returns the codec of a compression spec in the format of "name[:level]".
+// Codecs are cached, so calling New repeatedly with the same spec is cheap.
+func New(spec string) (Codec, error) {
+	name, level, err := ParseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	key := name + ":" + strconv.Itoa(level)
+	if codec, ok := codecs.Load(key); ok {
+		return codec.(Codec), nil
+	}
+
+	registryMu.RLock()
+	factory := registry[name]
+	registryMu.RUnlock()
+	codec, err := factory(level)
+	if err != nil {
+		return nil, cerror.ErrInvalidCompression.GenWithStackByArgs(spec, err.Error())
+	}
+	actual, _ := codecs.LoadOrStore(key, codec)
+	return actual.(Codec), nil
+}
+
+// Validate checks whether a compression spec is valid.
+func Validate(spec string) error {
+	_, err := New(spec)
+	return err
+}
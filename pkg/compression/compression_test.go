@@ -0,0 +1,123 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpec(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		spec  string
+		name  string
+		level int
+		err   string
+	}{
+		{spec: "", name: None},
+		{spec: "none", name: None},
+		{spec: " ZSTD ", name: ZSTD},
+		{spec: "zstd:3", name: ZSTD, level: 3},
+		{spec: "lz4: 8", name: LZ4, level: 8},
+		{spec: "zstd:high", err: ".*the level should be an integer.*"},
+		{spec: "brotli", err: ".*the algorithm should be one of gzip, lz4, none, snappy, zstd.*"},
+	}
+	for _, tc := range testCases {
+		name, level, err := ParseSpec(tc.spec)
+		if tc.err != "" {
+			require.Regexp(t, tc.err, err)
+			continue
+		}
+		require.Nil(t, err)
+		require.Equal(t, tc.name, name)
+		require.Equal(t, tc.level, level)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	codec, err := New("zstd:3")
+	require.Nil(t, err)
+	require.Equal(t, ZSTD, codec.Name())
+	require.Equal(t, 3, codec.Level())
+	cached, err := New("ZSTD:3")
+	require.Nil(t, err)
+	require.Same(t, codec, cached)
+
+	for _, spec := range []string{"none:1", "snappy:1", "gzip:10", "zstd:23", "lz4:-1"} {
+		_, err = New(spec)
+		require.Regexp(t, ".*the level .* is not supported.*", err, spec)
+	}
+	require.Nil(t, Validate("gzip:9"))
+	require.Regexp(t, ".*invalid compression.*", Validate("xz"))
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+	require.Panics(t, func() { Register(None, newNoneCodec) })
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+	compressible := bytes.Repeat([]byte("ticdc compression "), 1024)
+	incompressible := make([]byte, 4096)
+	rand.New(rand.NewSource(0)).Read(incompressible)
+	inputs := [][]byte{nil, []byte("a"), compressible, incompressible}
+
+	specs := []string{"none", "gzip", "gzip:1", "snappy", "lz4", "lz4:9", "zstd", "zstd:1", "zstd:19"}
+	for _, spec := range specs {
+		codec, err := New(spec)
+		require.Nil(t, err)
+		for _, input := range inputs {
+			prefix := []byte("prefix")
+			compressed, err := codec.Compress(append([]byte{}, prefix...), input)
+			require.Nil(t, err, spec)
+			require.Equal(t, prefix, compressed[:len(prefix)], spec)
+			if len(input) == len(compressible) && codec.Name() != None {
+				require.Less(t, len(compressed), len(input)/4, spec)
+			}
+
+			decompressed, err := codec.Decompress(append([]byte{}, prefix...), compressed[len(prefix):])
+			require.Nil(t, err, spec)
+			require.Equal(t, prefix, decompressed[:len(prefix)], spec)
+			require.Equal(t, len(input), len(decompressed)-len(prefix), spec)
+			require.True(t, bytes.Equal(input, decompressed[len(prefix):]), spec)
+		}
+	}
+
+	// blocks can be decompressed regardless of the level
+	fast, err := New("zstd:1")
+	require.Nil(t, err)
+	best, err := New("zstd:19")
+	require.Nil(t, err)
+	compressed, err := best.Compress(nil, compressible)
+	require.Nil(t, err)
+	decompressed, err := fast.Decompress(nil, compressed)
+	require.Nil(t, err)
+	require.Equal(t, compressible, decompressed)
+}
+
+func TestDecompressCorruptedData(t *testing.T) {
+	t.Parallel()
+	for _, spec := range []string{"gzip", "snappy", "lz4", "zstd"} {
+		codec, err := New(spec)
+		require.Nil(t, err)
+		_, err = codec.Decompress(nil, []byte{0xff, 0xff, 0xff, 0xff, 0x0f, 0x01})
+		require.Regexp(t, ".*decompress data by "+spec+" failed.*", err)
+	}
+}
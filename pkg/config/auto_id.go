@@ -0,0 +1,105 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// AutoIDMode decides how the values of a kind of automatically generated
+// columns are replicated.
+type AutoIDMode string
+
+// The modes of replicating automatically generated columns
+const (
+	// AutoIDModeReplicate writes the upstream values to the downstream as is.
+	AutoIDModeReplicate AutoIDMode = "replicate"
+	// AutoIDModeRegenerate omits the columns from the inserted and updated
+	// values, so that the downstream generates the values by itself and
+	// never collides with the values it generates after a switchover. The
+	// rows are still identified by the upstream values of the handle key,
+	// so the updates and deletes match no downstream rows if the column is
+	// in the handle key, or all the columns are compared by the all-columns
+	// strategy. The updates are always replicated as UPDATE statements, even
+	// in the safe mode, and the old value must be enabled, otherwise the
+	// updates would be replicated as REPLACE statements which insert the rows
+	// again with new ids. It suits the append-only tables, or the tables
+	// identified by another unique key.
+	AutoIDModeRegenerate AutoIDMode = "regenerate"
+)
+
+// AutoIDConfig represents how the automatically generated IDs are replicated,
+// the modes of the columns take effect on the MySQL sink only.
+type AutoIDConfig struct {
+	// AutoIncrement is the mode of the AUTO_INCREMENT columns.
+	AutoIncrement AutoIDMode `toml:"auto-increment" json:"auto-increment"`
+	// AutoRandom is the mode of the AUTO_RANDOM columns.
+	AutoRandom AutoIDMode `toml:"auto-random" json:"auto-random"`
+	// Sequence replicates the DDLs of sequences, i.e. CREATE, ALTER and DROP
+	// SEQUENCE. The values allocated by the upstream sequences are not
+	// replicated, so the downstream sequences should be set by SETVAL before
+	// a switchover.
+	Sequence bool `toml:"sequence" json:"sequence"`
+}
+
+// AutoIncrementMode returns the mode of the AUTO_INCREMENT columns.
+func (c *AutoIDConfig) AutoIncrementMode() AutoIDMode {
+	if c == nil || c.AutoIncrement == "" {
+		return AutoIDModeReplicate
+	}
+	return c.AutoIncrement
+}
+
+// AutoRandomMode returns the mode of the AUTO_RANDOM columns.
+func (c *AutoIDConfig) AutoRandomMode() AutoIDMode {
+	if c == nil || c.AutoRandom == "" {
+		return AutoIDModeReplicate
+	}
+	return c.AutoRandom
+}
+
+// IsRegenerated returns true if any kind of the auto id columns is regenerated
+// by the downstream.
+func (c *AutoIDConfig) IsRegenerated() bool {
+	return c.AutoIncrementMode() == AutoIDModeRegenerate || c.AutoRandomMode() == AutoIDModeRegenerate
+}
+
+// ReplicateSequence returns whether the DDLs of sequences are replicated.
+func (c *AutoIDConfig) ReplicateSequence() bool {
+	return c != nil && c.Sequence
+}
+
+// Validate validates the auto id config.
+func (c *AutoIDConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := validateAutoIDMode("auto-increment", c.AutoIncrement); err != nil {
+		return err
+	}
+	return validateAutoIDMode("auto-random", c.AutoRandom)
+}
+
+func validateAutoIDMode(name string, mode AutoIDMode) error {
+	switch mode {
+	case "", AutoIDModeReplicate, AutoIDModeRegenerate:
+		return nil
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("auto-id.%s should be %s or %s, got %s",
+				name, AutoIDModeReplicate, AutoIDModeRegenerate, mode))
+	}
+}
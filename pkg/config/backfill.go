@@ -0,0 +1,77 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// DefaultBackfillBatchSize is the default number of rows read and written in
+// a batch by the backfill.
+const DefaultBackfillBatchSize = 1000
+
+// BackfillConfig represents the backfill of a new changefeed, which writes the
+// existing rows of the replicated tables at the start ts to the sink as
+// inserts before the incremental changes are replicated. The rows are read by
+// a TiDB server of the upstream cluster.
+type BackfillConfig struct {
+	// UpstreamURI is the URI of a TiDB server of the upstream cluster in the
+	// format of the MySQL sink URI, the existing rows are not backfilled if
+	// it is empty.
+	UpstreamURI string `toml:"upstream-uri" json:"upstream-uri"`
+	// BatchSize is the number of rows read and written in a batch, 0 means
+	// DefaultBackfillBatchSize.
+	BatchSize int `toml:"batch-size" json:"batch-size"`
+}
+
+// IsEnabled returns true if the existing rows are backfilled.
+func (c *BackfillConfig) IsEnabled() bool {
+	return c != nil && c.UpstreamURI != ""
+}
+
+// GetBatchSize returns the number of rows read and written in a batch.
+func (c *BackfillConfig) GetBatchSize() int {
+	if c == nil || c.BatchSize == 0 {
+		return DefaultBackfillBatchSize
+	}
+	return c.BatchSize
+}
+
+// Validate validates the backfill config.
+func (c *BackfillConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.BatchSize < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("backfill.batch-size should not be negative, got %d", c.BatchSize))
+	}
+	if c.UpstreamURI == "" {
+		return nil
+	}
+	u, err := url.Parse(c.UpstreamURI)
+	if err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("backfill.upstream-uri is invalid")
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "mysql", "tidb", "mysql+ssl", "tidb+ssl":
+		return nil
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("backfill.upstream-uri should be a mysql or tidb URI")
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// CharsetInvalidPolicy decides how the string values containing invalid
+// UTF-8 bytes are handled when they are converted to utf8mb4.
+type CharsetInvalidPolicy string
+
+// The policies of handling the invalid string values
+const (
+	// CharsetInvalidPolicyError stops the changefeed with an error.
+	CharsetInvalidPolicyError CharsetInvalidPolicy = "error"
+	// CharsetInvalidPolicyReplace replaces the invalid bytes with U+FFFD.
+	CharsetInvalidPolicyReplace CharsetInvalidPolicy = "replace"
+)
+
+// CharsetConfig represents how the values of the string columns are encoded
+// before they are written to the sinks.
+type CharsetConfig struct {
+	// ForceUTF8MB4 converts the values of all the non-binary string columns to
+	// utf8mb4, and marks the columns as utf8mb4 columns in the events.
+	ForceUTF8MB4 bool `toml:"force-utf8mb4" json:"force-utf8mb4"`
+	// InvalidPolicy is the policy of the values which are not valid UTF-8,
+	// it takes effect only if ForceUTF8MB4 is true.
+	InvalidPolicy CharsetInvalidPolicy `toml:"invalid-policy" json:"invalid-policy"`
+}
+
+// IsForceUTF8MB4 returns true if the string values are converted to utf8mb4.
+func (c *CharsetConfig) IsForceUTF8MB4() bool {
+	return c != nil && c.ForceUTF8MB4
+}
+
+// GetInvalidPolicy returns the policy of the invalid string values.
+func (c *CharsetConfig) GetInvalidPolicy() CharsetInvalidPolicy {
+	if c == nil || c.InvalidPolicy == "" {
+		return CharsetInvalidPolicyError
+	}
+	return c.InvalidPolicy
+}
+
+// Validate validates the charset config.
+func (c *CharsetConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	switch c.InvalidPolicy {
+	case "", CharsetInvalidPolicyError, CharsetInvalidPolicyReplace:
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("charset.invalid-policy should be %s or %s, got %s",
+				CharsetInvalidPolicyError, CharsetInvalidPolicyReplace, c.InvalidPolicy))
+	}
+	return nil
+}
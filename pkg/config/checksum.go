@@ -0,0 +1,95 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+	"strings"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	filter "github.com/pingcap/tidb-tools/pkg/table-filter"
+)
+
+// DefaultChecksumChunkSize is the default number of rows in a checksum chunk.
+const DefaultChecksumChunkSize = 10000
+
+// ChecksumConfig represents the config of the consistency checker of a
+// changefeed, which compares the checksums of the upstream and downstream
+// tables at the syncpoints.
+type ChecksumConfig struct {
+	// UpstreamURI is the URI of a TiDB server of the upstream cluster in the
+	// format of the MySQL sink URI, the checker is disabled if it is empty.
+	UpstreamURI string `toml:"upstream-uri" json:"upstream-uri"`
+	// Tables are the filter rules of the tables to be checked, all the
+	// replicated tables are checked if it is empty.
+	Tables []string `toml:"tables" json:"tables"`
+	// Rules specify the columns to be checked, the tables not matched by any
+	// rule are checked over all their columns.
+	Rules []*ChecksumRule `toml:"rules" json:"rules"`
+	// ChunkSize is the number of rows in a chunk, the checksums are computed
+	// and compared by chunks. 0 means the default value.
+	ChunkSize int `toml:"chunk-size" json:"chunk-size"`
+}
+
+// ChecksumRule specifies the columns to be checked of the tables matched
+type ChecksumRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	Columns []string `toml:"columns" json:"columns"`
+}
+
+// IsEnabled returns whether the consistency checker is enabled.
+func (c *ChecksumConfig) IsEnabled() bool {
+	return c != nil && c.UpstreamURI != ""
+}
+
+// GetChunkSize returns the number of rows in a chunk.
+func (c *ChecksumConfig) GetChunkSize() int {
+	if c == nil || c.ChunkSize == 0 {
+		return DefaultChecksumChunkSize
+	}
+	return c.ChunkSize
+}
+
+// Validate validates the checksum config.
+func (c *ChecksumConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.UpstreamURI != "" {
+		u, err := url.Parse(c.UpstreamURI)
+		if err != nil {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("checksum.upstream-uri is invalid")
+		}
+		switch strings.ToLower(u.Scheme) {
+		case "mysql", "tidb", "mysql+ssl", "tidb+ssl":
+		default:
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("checksum.upstream-uri should be a mysql or tidb URI")
+		}
+	}
+	if c.ChunkSize < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("checksum.chunk-size should not be negative")
+	}
+	if _, err := filter.Parse(c.Tables); err != nil {
+		return cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+	}
+	for _, rule := range c.Rules {
+		if len(rule.Columns) == 0 {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("checksum.rules.columns should not be empty")
+		}
+		if _, err := filter.Parse(rule.Matcher); err != nil {
+			return cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+		}
+	}
+	return nil
+}
@@ -62,7 +62,7 @@ var defaultReplicaConfig = &ReplicaConfig{
 		Enable: false,
 	},
 	Scheduler: &SchedulerConfig{
-		Tp:          "table-number",
+		Tp:          SchedulerTypeTableNumber,
 		PollingTime: -1,
 	},
 	Consistent: &ConsistentConfig{
@@ -70,6 +70,10 @@ var defaultReplicaConfig = &ReplicaConfig{
 		MaxLogSize:        64,
 		FlushIntervalInMs: 1000,
 		Storage:           "",
+		Compression:       "none",
+	},
+	EventTrace: &EventTraceConfig{
+		SampleRate: 0,
 	},
 }
 
@@ -87,6 +91,45 @@ type replicaConfig struct {
 	Cyclic           *CyclicConfig     `toml:"cyclic-replication" json:"cyclic-replication"`
 	Scheduler        *SchedulerConfig  `toml:"scheduler" json:"scheduler"`
 	Consistent       *ConsistentConfig `toml:"consistent" json:"consistent"`
+	EventTrace       *EventTraceConfig `toml:"event-trace" json:"event-trace"`
+	SortEngine       *SortEngineConfig `toml:"sort-engine" json:"sort-engine,omitempty"`
+	SLO              *SLOConfig        `toml:"slo" json:"slo,omitempty"`
+	DDL              *DDLConfig        `toml:"ddl" json:"ddl,omitempty"`
+	Placement        *PlacementConfig  `toml:"placement" json:"placement,omitempty"`
+	Checksum         *ChecksumConfig   `toml:"checksum" json:"checksum,omitempty"`
+	// Notification is the config of the notifications about the state
+	// transitions of the changefeed.
+	Notification *NotificationConfig `toml:"notification" json:"notification,omitempty"`
+	// GC is the config of how the changefeed holds the GC safepoint of the
+	// upstream.
+	GC *GCConfig `toml:"gc" json:"gc,omitempty"`
+	// Features are the feature flags of the changefeed, the features not
+	// specified use their default values.
+	Features map[string]bool `toml:"features" json:"features,omitempty"`
+	// NoKeyTableStrategy replicates the tables without a primary key or a
+	// not null unique key with the strategy if it's not empty, it takes
+	// precedence over ForceReplicate.
+	NoKeyTableStrategy NoKeyTableStrategy `toml:"no-key-table-strategy" json:"no-key-table-strategy,omitempty"`
+	// TimeZone is the time zone rendering the TIMESTAMP values in the events,
+	// and the time zone of the sessions of the MySQL sink. The time zone of
+	// the server is used if it's empty.
+	TimeZone string `toml:"time-zone" json:"time-zone,omitempty"`
+	// Partition is the config of how the partitioned tables are replicated.
+	Partition *PartitionConfig `toml:"partition" json:"partition,omitempty"`
+	// AutoID is the config of how the automatically generated IDs are
+	// replicated.
+	AutoID *AutoIDConfig `toml:"auto-id" json:"auto-id,omitempty"`
+	// Charset is the config of how the values of the string columns are
+	// encoded.
+	Charset *CharsetConfig `toml:"charset" json:"charset,omitempty"`
+	// RowSize is the config of how the oversized rows are handled.
+	RowSize *RowSizeConfig `toml:"row-size" json:"row-size,omitempty"`
+	// Offload is the config of how the large column values are offloaded
+	// to the external storage.
+	Offload *OffloadConfig `toml:"offload" json:"offload,omitempty"`
+	// Backfill is the config of how the existing rows are backfilled when
+	// the changefeed is created.
+	Backfill *BackfillConfig `toml:"backfill" json:"backfill,omitempty"`
 }
 
 // Marshal returns the json marshal format of a ReplicationConfig
@@ -137,6 +180,91 @@ func (c *ReplicaConfig) Clone() *ReplicaConfig {
 	return clone
 }
 
+// Validate verifies that each item in the replica config is valid.
+func (c *ReplicaConfig) Validate() error {
+	if c.Sink != nil && c.Sink.AddColumn != nil && c.Sink.AddColumn.BackfillWindow < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("sink.add-column.backfill-window should not be negative")
+	}
+	if c.Sink != nil {
+		if err := c.Sink.Failover.Validate(); err != nil {
+			return err
+		}
+		if err := c.Sink.CSV.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.EventTrace != nil {
+		if err := c.EventTrace.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := c.SortEngine.Validate(); err != nil {
+		return err
+	}
+	if c.Consistent != nil {
+		if err := c.Consistent.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Scheduler != nil {
+		if err := c.Scheduler.Validate(); err != nil {
+			return err
+		}
+	}
+	if err := c.SLO.Validate(); err != nil {
+		return err
+	}
+	if err := c.DDL.Validate(); err != nil {
+		return err
+	}
+	if err := validateNoKeyTableStrategy(c.NoKeyTableStrategy); err != nil {
+		return err
+	}
+	if err := validateTimeZone(c.TimeZone); err != nil {
+		return err
+	}
+	if err := validateFeatures(c.Features); err != nil {
+		return err
+	}
+	if err := c.Checksum.Validate(); err != nil {
+		return err
+	}
+	if err := c.Notification.Validate(); err != nil {
+		return err
+	}
+	if err := c.GC.Validate(); err != nil {
+		return err
+	}
+	if err := c.Partition.Validate(); err != nil {
+		return err
+	}
+	if err := c.AutoID.Validate(); err != nil {
+		return err
+	}
+	if c.AutoID.IsRegenerated() && !c.EnableOldValue {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("auto-id.* = %s requires enable-old-value", AutoIDModeRegenerate))
+	}
+	if c.Filter != nil && len(c.Filter.EventFilters) > 0 && !c.EnableOldValue {
+		// the updates have no pre columns without the old value, which can't be
+		// distinguished from the inserts
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("filter.event-filters requires enable-old-value")
+	}
+	if err := c.Charset.Validate(); err != nil {
+		return err
+	}
+	if err := c.RowSize.Validate(); err != nil {
+		return err
+	}
+	if err := c.Offload.Validate(); err != nil {
+		return err
+	}
+	if err := c.Backfill.Validate(); err != nil {
+		return err
+	}
+	return c.Placement.Validate()
+}
+
 func (c *replicaConfig) fillFromV1(v1 *outdated.ReplicaConfigV1) {
 	if v1 == nil || v1.Sink == nil {
 		return
@@ -167,6 +295,10 @@ type LogFileConfig struct {
 // LogConfig represents log config for server
 type LogConfig struct {
 	File *LogFileConfig `toml:"file" json:"file"`
+	// Format is the format of the logs, one of text and json.
+	Format string `toml:"format" json:"format"`
+	// ChangefeedDir is the directory to write the logs of each changefeed to a separate file.
+	ChangefeedDir string `toml:"changefeed-dir" json:"changefeed-dir"`
 }
 
 var defaultServerConfig = &ServerConfig{
@@ -180,6 +312,7 @@ var defaultServerConfig = &ServerConfig{
 			MaxDays:    0,
 			MaxBackups: 0,
 		},
+		Format: "text",
 	},
 	DataDir: "",
 	GcTTL:   24 * 60 * 60, // 24H
@@ -198,6 +331,10 @@ var defaultServerConfig = &ServerConfig{
 		MaxMemoryConsumption:   16 * 1024 * 1024 * 1024, // 16GB
 		NumWorkerPoolGoroutine: 16,
 		SortDir:                DefaultSortDir,
+		FileCompression:        "none",
+		SegmentSize:            64 * 1024 * 1024, // 64MB
+		DiskFullTimeout:        TomlDuration(10 * time.Minute),
+		DiskFullMemoryQuota:    256 * 1024 * 1024, // 256MB
 
 		// Default leveldb sorter config
 		EnableLevelDB: false,
@@ -220,6 +357,7 @@ var defaultServerConfig = &ServerConfig{
 	},
 	Security:            &SecurityConfig{},
 	PerTableMemoryQuota: 10 * 1024 * 1024, // 10MB
+	MemoryLimit:         0,                // 0 means unlimited
 	KVClient: &KVClientConfig{
 		WorkerConcurrent: 8,
 		WorkerPoolSize:   0, // 0 will use NumCPU() * 2
@@ -228,6 +366,12 @@ var defaultServerConfig = &ServerConfig{
 	Debug: &DebugConfig{
 		EnableTableActor: true,
 	},
+	Tracing: &TracingConfig{
+		SampleRatio: 0.01,
+	},
+	Reconcile: &ReconcileConfig{
+		Interval: TomlDuration(30 * time.Second),
+	},
 }
 
 // ServerConfig represents a config for server
@@ -244,16 +388,23 @@ type ServerConfig struct {
 	GcTTL int64  `toml:"gc-ttl" json:"gc-ttl"`
 	TZ    string `toml:"tz" json:"tz"`
 
+	// Labels are registered with the capture, changefeeds can be placed on
+	// the captures with specific labels by the placement config.
+	Labels map[string]string `toml:"labels" json:"labels,omitempty"`
+
 	CaptureSessionTTL int `toml:"capture-session-ttl" json:"capture-session-ttl"`
 
 	OwnerFlushInterval     TomlDuration `toml:"owner-flush-interval" json:"owner-flush-interval"`
 	ProcessorFlushInterval TomlDuration `toml:"processor-flush-interval" json:"processor-flush-interval"`
 
-	Sorter              *SorterConfig   `toml:"sorter" json:"sorter"`
-	Security            *SecurityConfig `toml:"security" json:"security"`
-	PerTableMemoryQuota uint64          `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
-	KVClient            *KVClientConfig `toml:"kv-client" json:"kv-client"`
-	Debug               *DebugConfig    `toml:"debug" json:"debug"`
+	Sorter              *SorterConfig    `toml:"sorter" json:"sorter"`
+	Security            *SecurityConfig  `toml:"security" json:"security"`
+	PerTableMemoryQuota uint64           `toml:"per-table-memory-quota" json:"per-table-memory-quota"`
+	MemoryLimit         uint64           `toml:"memory-limit" json:"memory-limit"`
+	KVClient            *KVClientConfig  `toml:"kv-client" json:"kv-client"`
+	Debug               *DebugConfig     `toml:"debug" json:"debug"`
+	Tracing             *TracingConfig   `toml:"tracing" json:"tracing"`
+	Reconcile           *ReconcileConfig `toml:"reconcile" json:"reconcile"`
 }
 
 // Marshal returns the json marshal format of a ServerConfig
@@ -317,6 +468,9 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	if c.GcTTL == 0 {
 		return cerror.ErrInvalidServerOption.GenWithStack("empty GC TTL is not allowed")
 	}
+	if err := ValidateLabels(c.Labels); err != nil {
+		return err
+	}
 	// 5s is minimum lease ttl in etcd(PD)
 	if c.CaptureSessionTTL < 5 {
 		log.Warn("capture session ttl too small, set to default value 10s")
@@ -336,6 +490,20 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 	}
 
 	defaultCfg := GetDefaultServerConfig()
+	if c.Log == nil {
+		c.Log = defaultCfg.Log
+	}
+	if c.Log.File == nil {
+		c.Log.File = defaultCfg.Log.File
+	}
+	switch c.Log.Format {
+	case "":
+		c.Log.Format = defaultCfg.Log.Format
+	case "text", "json":
+	default:
+		return cerror.ErrInvalidServerOption.GenWithStack("log format must be one of text and json, but got %s", c.Log.Format)
+	}
+
 	if c.Sorter == nil {
 		c.Sorter = defaultCfg.Sorter
 	}
@@ -359,6 +527,20 @@ func (c *ServerConfig) ValidateAndAdjust() error {
 		return cerror.ErrInvalidServerOption.GenWithStackByArgs("region-scan-limit should be at least 1")
 	}
 
+	if c.Tracing == nil {
+		c.Tracing = defaultCfg.Tracing
+	}
+	if err := c.Tracing.ValidateAndAdjust(); err != nil {
+		return err
+	}
+
+	if c.Reconcile == nil {
+		c.Reconcile = defaultCfg.Reconcile
+	}
+	if err := c.Reconcile.ValidateAndAdjust(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -64,7 +64,11 @@ const (
     "level": "none",
     "max-log-size": 64,
     "flush-interval": 1000,
-    "storage": ""
+    "storage": "",
+    "compression": "none"
+  },
+  "event-trace": {
+    "sample-rate": 0
   }
 }`
 
@@ -78,7 +82,9 @@ const (
       "max-size": 300,
       "max-days": 0,
       "max-backups": 0
-    }
+    },
+    "format": "text",
+    "changefeed-dir": ""
   },
   "data-dir": "",
   "gc-ttl": 86400,
@@ -93,6 +99,10 @@ const (
     "max-memory-consumption": 17179869184,
     "num-workerpool-goroutine": 16,
     "sort-dir": "/tmp/sorter",
+    "file-compression": "none",
+    "segment-size": 67108864,
+    "disk-full-timeout": 600000000000,
+    "disk-full-memory-quota": 268435456,
     "enable-leveldb-sorter": false,
     "leveldb": {
       "count": 16,
@@ -116,6 +126,7 @@ const (
     "cert-allowed-cn": null
   },
   "per-table-memory-quota": 10485760,
+  "memory-limit": 0,
   "kv-client": {
     "worker-concurrent": 8,
     "worker-pool-size": 0,
@@ -123,6 +134,17 @@ const (
   },
   "debug": {
     "enable-table-actor": true
+  },
+  "tracing": {
+    "exporter": "",
+    "endpoint": "",
+    "sample-ratio": 0.01
+  },
+  "reconcile": {
+    "spec-dir": "",
+    "etcd-prefix": "",
+    "interval": 30000000000,
+    "prune": false
   }
 }`
 
@@ -159,7 +181,11 @@ const (
     "level": "none",
     "max-log-size": 64,
     "flush-interval": 1000,
-    "storage": ""
+    "storage": "",
+    "compression": "none"
+  },
+  "event-trace": {
+    "sample-rate": 0
   }
 }`
 
@@ -196,7 +222,11 @@ const (
     "level": "none",
     "max-log-size": 64,
     "flush-interval": 1000,
-    "storage": ""
+    "storage": "",
+    "compression": "none"
+  },
+  "event-trace": {
+    "sample-rate": 0
   }
 }`
 )
@@ -17,7 +17,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 
+	timodel "github.com/pingcap/tidb/parser/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,6 +59,24 @@ func TestReplicaConfigClone(t *testing.T) {
 	require.Equal(t, 3, conf.Mounter.WorkerNum)
 }
 
+func TestNoKeyTableStrategy(t *testing.T) {
+	t.Parallel()
+	conf := GetDefaultReplicaConfig()
+	require.False(t, conf.ReplicateNoKeyTables())
+	require.Equal(t, NoKeyTableStrategyNone, conf.GetNoKeyTableStrategy())
+	// force-replicate is the same as the all-columns strategy
+	conf.ForceReplicate = true
+	require.True(t, conf.ReplicateNoKeyTables())
+	require.Equal(t, NoKeyTableStrategyAllColumns, conf.GetNoKeyTableStrategy())
+	conf.NoKeyTableStrategy = NoKeyTableStrategyRowID
+	require.Nil(t, conf.Validate())
+	require.Equal(t, NoKeyTableStrategyRowID, conf.GetNoKeyTableStrategy())
+	conf.ForceReplicate = false
+	require.True(t, conf.ReplicateNoKeyTables())
+	conf.NoKeyTableStrategy = "rowid"
+	require.Regexp(t, ".*no-key-table-strategy should be one of all-columns and row-id, got rowid.*", conf.Validate())
+}
+
 func TestReplicaConfigOutDated(t *testing.T) {
 	t.Parallel()
 	conf2 := new(ReplicaConfig)
@@ -131,6 +151,32 @@ func TestServerConfigValidateAndAdjust(t *testing.T) {
 	conf.PerTableMemoryQuota = 0
 	require.Nil(t, conf.ValidateAndAdjust())
 	require.EqualValues(t, GetDefaultServerConfig().PerTableMemoryQuota, conf.PerTableMemoryQuota)
+	require.False(t, conf.Tracing.IsEnabled())
+	conf.Tracing = &TracingConfig{Exporter: "zipkin"}
+	require.Regexp(t, ".*unknown tracing exporter zipkin", conf.ValidateAndAdjust())
+	conf.Tracing.Exporter = TracingExporterJaeger
+	require.Regexp(t, ".*tracing endpoint should not be empty", conf.ValidateAndAdjust())
+	conf.Tracing.Endpoint = "http://127.0.0.1:14268/api/traces"
+	conf.Tracing.SampleRatio = 2
+	require.Regexp(t, ".*sample-ratio should be in range.*", conf.ValidateAndAdjust())
+	conf.Tracing.SampleRatio = 1
+	require.Nil(t, conf.ValidateAndAdjust())
+	require.True(t, conf.Tracing.IsEnabled())
+	conf.Tracing.Exporter = TracingExporterOTLP
+	conf.Tracing.Endpoint = "http://127.0.0.1:4318/v1/traces"
+	require.Nil(t, conf.ValidateAndAdjust())
+	require.False(t, conf.Reconcile.IsEnabled())
+	conf.Reconcile = &ReconcileConfig{SpecDir: "/tmp/specs", EtcdPrefix: "/specs"}
+	require.Regexp(t, ".*can not be set at the same time", conf.ValidateAndAdjust())
+	conf.Reconcile.EtcdPrefix = ""
+	require.Regexp(t, ".*reconcile interval should be at least.*", conf.ValidateAndAdjust())
+	conf.Reconcile.Interval = TomlDuration(time.Minute)
+	require.Nil(t, conf.ValidateAndAdjust())
+	require.True(t, conf.Reconcile.IsEnabled())
+	conf.Labels = map[string]string{"ssd": "true"}
+	require.Nil(t, conf.ValidateAndAdjust())
+	conf.Labels["ssd"] = "true,zone=z1"
+	require.Regexp(t, ".*invalid value.*", conf.ValidateAndAdjust())
 }
 
 func TestSorterConfigValidateAndAdjust(t *testing.T) {
@@ -144,6 +190,377 @@ func TestSorterConfigValidateAndAdjust(t *testing.T) {
 	require.Nil(t, conf.ValidateAndAdjust())
 	conf.LevelDB.Compression = "invalid"
 	require.Error(t, conf.ValidateAndAdjust())
+	conf.LevelDB.Compression = "snappy"
+	conf.FileCompression = "zstd:3"
+	require.Nil(t, conf.ValidateAndAdjust())
+	conf.FileCompression = "zstd:high"
+	require.Regexp(t, ".*file-compression is invalid.*", conf.ValidateAndAdjust())
+	conf.FileCompression = "none"
+	conf.SegmentSize = 1024
+	require.Regexp(t, ".*segment-size should be 0 or at least 1MB.*", conf.ValidateAndAdjust())
+	conf.SegmentSize = 0
+	require.Nil(t, conf.ValidateAndAdjust())
+	conf.DiskFullTimeout = TomlDuration(-time.Second)
+	require.Regexp(t, ".*disk-full-timeout should not be negative.*", conf.ValidateAndAdjust())
+	conf.DiskFullTimeout = 0
+	require.Nil(t, conf.ValidateAndAdjust())
 	conf.LevelDB.CleanupSpeedLimit = 0
 	require.Error(t, conf.ValidateAndAdjust())
 }
+
+func TestReplicaConfigValidate(t *testing.T) {
+	t.Parallel()
+	conf := GetDefaultReplicaConfig()
+	require.Nil(t, conf.Validate())
+	require.False(t, conf.EventTrace.IsEnabled())
+
+	conf.EventTrace.SampleRate = 0.01
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.EventTrace.IsEnabled())
+	conf.EventTrace.SampleRate = 1.5
+	require.Regexp(t, ".*sample-rate should be in range.*", conf.Validate())
+	conf.EventTrace.SampleRate = -1
+	require.Regexp(t, ".*sample-rate should be in range.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	conf.Sink.AddColumn = &AddColumnConfig{BackfillWindow: 100}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.Sink.AddColumn.IsBackfillEnabled())
+	require.False(t, conf.Sink.AddColumn.IsEmitDefaultValueEnabled())
+	conf.Sink.AddColumn.BackfillWindow = -1
+	require.Regexp(t, ".*backfill-window.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.Equal(t, 5*time.Minute, conf.Sink.Failover.GetThreshold())
+	require.Equal(t, 10*time.Second, conf.Sink.Failover.GetProbeInterval())
+	conf.Sink.Failover = &FailoverConfig{Threshold: 60}
+	require.Nil(t, conf.Validate())
+	require.Equal(t, time.Minute, conf.Sink.Failover.GetThreshold())
+	conf.Sink.Failover.ProbeInterval = -1
+	require.Regexp(t, ".*sink.failover.probe-interval should not be negative.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.Equal(t, ",", conf.Sink.CSV.GetDelimiter())
+	require.Equal(t, `\N`, conf.Sink.CSV.GetNullString())
+	conf.Sink.CSV = &CSVConfig{Delimiter: "|", Quote: "'", NullString: "NULL"}
+	require.Nil(t, conf.Validate())
+	conf.Sink.CSV.Delimiter = "||"
+	require.Regexp(t, ".*sink.csv.delimiter should be a single character.*", conf.Validate())
+	conf.Sink.CSV.Delimiter = "\n"
+	require.Regexp(t, ".*sink.csv.delimiter should be a single character.*", conf.Validate())
+	conf.Sink.CSV.Delimiter = "'"
+	require.Regexp(t, ".*sink.csv.delimiter and sink.csv.quote should be different.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	conf.Consistent.Compression = "lz4"
+	require.Nil(t, conf.Validate())
+	conf.Consistent.Compression = "lz5"
+	require.Regexp(t, ".*consistent.compression is invalid.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	conf.Scheduler.Tp = SchedulerTypeLoad
+	require.Nil(t, conf.Validate())
+	conf.Scheduler.Tp = "table-size"
+	require.Regexp(t, ".*scheduler.type should be.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	conf.SortEngine = &SortEngineConfig{Rules: []*SortEngineRule{{Matcher: []string{"test.*"}, Engine: "memory"}}}
+	require.Nil(t, conf.Validate())
+	conf.SortEngine.Rules[0].Engine = "leveldb"
+	require.Regexp(t, ".*unknown sort engine leveldb.*", conf.Validate())
+	conf.SortEngine.Rules[0].Engine = "low-latency"
+	require.Nil(t, conf.Validate())
+	require.Equal(t, uint64(DefaultLowLatencyMemoryQuota), conf.SortEngine.GetLowLatencyMemoryQuota())
+	conf.SortEngine.LowLatencyMemoryQuota = 1024
+	require.Equal(t, uint64(1024), conf.SortEngine.GetLowLatencyMemoryQuota())
+	conf.SortEngine.Rules[0].Engine = "unified"
+	conf.SortEngine.Rules[0].Matcher = []string{"test.t["}
+	require.Regexp(t, ".*ErrFilterRuleInvalid.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	conf.SLO = &SLOConfig{MaxCheckpointLag: 30, WebhookURL: "http://127.0.0.1:8080/alert"}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.SLO.IsEnabled())
+	conf.SLO.WebhookURL = "127.0.0.1:8080"
+	require.Regexp(t, ".*webhook-url should be a http or https URL.*", conf.Validate())
+	conf.SLO.WebhookURL = ""
+	conf.SLO.MaxCheckpointLag = -1
+	require.Regexp(t, ".*max-checkpoint-lag should not be negative.*", conf.Validate())
+	require.False(t, conf.SLO.IsEnabled())
+
+	conf = GetDefaultReplicaConfig()
+	require.Equal(t, DDLPolicyRewrite, conf.DDL.CreateTableLikePolicy())
+	require.Equal(t, DDLPolicySkip, conf.DDL.TemporaryTablePolicy())
+	conf.DDL = &DDLConfig{CreateTableLike: DDLPolicyReplicate, TemporaryTable: DDLPolicyReplicate}
+	require.Nil(t, conf.Validate())
+	require.Equal(t, DDLPolicyReplicate, conf.DDL.CreateTableLikePolicy())
+	require.Equal(t, DDLPolicyRewrite, conf.DDL.CreateTableAsSelectPolicy())
+	conf.DDL.TemporaryTable = DDLPolicyRewrite
+	require.Regexp(t, ".*ddl.temporary-table should be one of.*", conf.Validate())
+	conf.DDL.TemporaryTable = ""
+	conf.DDL.CreateTableAsSelect = DDLPolicyReplicate
+	require.Regexp(t, ".*ddl.create-table-as-select should be one of.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.False(t, conf.DDL.IsDenied(timodel.ActionDropTable))
+	conf.DDL = &DDLConfig{DenyList: []string{"drop table", "Truncate Table"}}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.DDL.IsDenied(timodel.ActionDropTable))
+	require.True(t, conf.DDL.IsDenied(timodel.ActionTruncateTable))
+	require.False(t, conf.DDL.IsDenied(timodel.ActionAddColumn))
+	conf.DDL.AllowList = []string{"create table", "drop table"}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.DDL.IsDenied(timodel.ActionDropTable))
+	require.True(t, conf.DDL.IsDenied(timodel.ActionAddColumn))
+	require.False(t, conf.DDL.IsDenied(timodel.ActionCreateTable))
+	conf.DDL.DenyList = []string{"drop tables"}
+	require.Regexp(t, ".*ddl.deny-list contains an unknown DDL type drop tables.*", conf.Validate())
+	conf.DDL.DenyList = []string{"none"}
+	require.Regexp(t, ".*ddl.deny-list contains an unknown DDL type none.*", conf.Validate())
+	conf.DDL.DenyList = nil
+	conf.DDL.Rewrites = []*DDLRewriteRule{{Matcher: []string{"test.*"}, TargetSchema: "test_bak", IfNotExists: true}}
+	require.Nil(t, conf.Validate())
+	conf.DDL.Rewrites[0].Matcher = []string{"[test.*"}
+	require.Regexp(t, ".*ErrFilterRuleInvalid.*", conf.Validate())
+	conf.DDL.Rewrites = []*DDLRewriteRule{{Matcher: []string{"test.*"}}}
+	require.Regexp(t, ".*rewrites nothing.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.False(t, conf.DDL.IsAsync(timodel.ActionAddIndex))
+	conf.DDL = &DDLConfig{AsyncDDL: AsyncDDLPolicyIndex}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.DDL.IsAsync(timodel.ActionAddIndex))
+	require.True(t, conf.DDL.IsAsync(timodel.ActionDropIndex))
+	require.False(t, conf.DDL.IsAsync(timodel.ActionAddColumn))
+	conf.DDL.AsyncDDL = AsyncDDLPolicyNone
+	require.Nil(t, conf.Validate())
+	require.False(t, conf.DDL.IsAsync(timodel.ActionAddIndex))
+	conf.DDL.AsyncDDL = "all"
+	require.Regexp(t, ".*ddl.async-ddl should be one of.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	conf.Placement = &PlacementConfig{Constraints: []string{"ssd=true", "zone != z1"}}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.Placement.IsEnabled())
+	conf.Placement.Constraints = []string{"ssd"}
+	require.Regexp(t, ".*should be in the format of key=value or key!=value.*", conf.Validate())
+	conf.Placement.Constraints = []string{"ssd=tr ue"}
+	require.Regexp(t, ".*invalid label key or value.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	conf.Features = map[string]bool{FeatureLowLatencySortEngine: true}
+	require.Nil(t, conf.Validate())
+	conf.Features["actor-pipeline"] = true
+	require.Regexp(t, ".*unknown feature actor-pipeline.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.False(t, conf.Checksum.IsEnabled())
+	require.Equal(t, DefaultChecksumChunkSize, conf.Checksum.GetChunkSize())
+	conf.Checksum = &ChecksumConfig{
+		UpstreamURI: "mysql://root@127.0.0.1:4000/",
+		Tables:      []string{"test.*"},
+		Rules:       []*ChecksumRule{{Matcher: []string{"test.t1"}, Columns: []string{"id"}}},
+		ChunkSize:   100,
+	}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.Checksum.IsEnabled())
+	require.Equal(t, 100, conf.Checksum.GetChunkSize())
+	conf.Checksum.UpstreamURI = "kafka://127.0.0.1:9092/"
+	require.Regexp(t, ".*checksum.upstream-uri should be a mysql or tidb URI.*", conf.Validate())
+	conf.Checksum.UpstreamURI = "tidb://127.0.0.1:4000/"
+	conf.Checksum.Rules[0].Columns = nil
+	require.Regexp(t, ".*checksum.rules.columns should not be empty.*", conf.Validate())
+	conf.Checksum.Rules = nil
+	conf.Checksum.Tables = []string{"test.t["}
+	require.Regexp(t, ".*ErrFilterRuleInvalid.*", conf.Validate())
+	conf.Checksum.Tables = nil
+	conf.Checksum.ChunkSize = -1
+	require.Regexp(t, ".*checksum.chunk-size should not be negative.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.False(t, conf.Notification.IsEnabled())
+	require.False(t, conf.Notification.IsEventEnabled(NotificationEventError))
+	conf.Notification = &NotificationConfig{
+		Targets: []*NotificationTarget{
+			{URI: "http://127.0.0.1:8080/notify", Template: `{"text": "{{.ID}} is {{.Event}}"}`},
+			{URI: "kafka://127.0.0.1:9092,127.0.0.1:9093/cdc-notifications"},
+		},
+	}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.Notification.IsEventEnabled(NotificationEventLagExceeded))
+	require.Equal(t, 3, conf.Notification.GetMaxRetries())
+	conf.Notification.Events = []string{NotificationEventError, NotificationEventFinished}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.Notification.IsEventEnabled(NotificationEventError))
+	require.False(t, conf.Notification.IsEventEnabled(NotificationEventPaused))
+	conf.Notification.Events = []string{"stopped"}
+	require.Regexp(t, ".*notification.events stopped is invalid.*", conf.Validate())
+	conf.Notification.Events = nil
+	conf.Notification.LagThreshold = -1
+	require.Regexp(t, ".*notification.lag-threshold should not be negative.*", conf.Validate())
+	conf.Notification.LagThreshold = 0
+	conf.Notification.MaxRetries = -1
+	require.Regexp(t, ".*notification.max-retries should not be negative.*", conf.Validate())
+	conf.Notification.MaxRetries = 0
+	conf.Notification.Targets[1].URI = "kafka://127.0.0.1:9092/"
+	require.Regexp(t, ".*kafka://addr1,addr2/topic.*", conf.Validate())
+	conf.Notification.Targets[1].URI = "mysql://127.0.0.1:4000/"
+	require.Regexp(t, ".*should be a http, https or kafka URI.*", conf.Validate())
+	conf.Notification.Targets[1].URI = "https://127.0.0.1:8080/notify"
+	conf.Notification.Targets[0].Template = "{{.ID"
+	require.Regexp(t, ".*notification.targets.template is invalid.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.Equal(t, int64(0), conf.GC.GetTTL())
+	require.Equal(t, GCPolicyFail, conf.GC.GetPolicy())
+	conf.GC = &GCConfig{TTL: 3600, Policy: GCPolicyQuarantine}
+	require.Nil(t, conf.Validate())
+	require.Equal(t, int64(3600), conf.GC.GetTTL())
+	require.Equal(t, GCPolicyQuarantine, conf.GC.GetPolicy())
+	conf.GC.TTL = -1
+	require.Regexp(t, ".*gc.ttl should not be negative.*", conf.Validate())
+	conf.GC.TTL = 0
+	conf.GC.Policy = "pause"
+	require.Regexp(t, ".*gc.policy should be fail or quarantine.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.False(t, conf.Partition.IsMergeMode())
+	conf.Partition = &PartitionConfig{
+		Mode:   PartitionModeMerge,
+		Routes: []*PartitionRouteRule{{Matcher: []string{"test.t*"}, TargetTable: "{table}_{partition}"}},
+	}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.Partition.IsMergeMode())
+	require.Equal(t, "t1_p0", conf.Partition.Routes[0].Target("test", "t1", "p0"))
+	conf.Partition.Routes[0].TargetTable = "{schema}_{table}"
+	require.Regexp(t, ".*should contain {partition}.*", conf.Validate())
+	conf.Partition.Routes[0].TargetTable = "{table}_{partition}"
+	conf.Partition.Mode = "split"
+	require.Regexp(t, ".*partition.mode should be empty or merge.*", conf.Validate())
+	conf.Partition.Mode = PartitionModeNone
+	require.Regexp(t, ".*partition.routes only take effect in the merge mode.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.Equal(t, AutoIDModeReplicate, conf.AutoID.AutoIncrementMode())
+	require.Equal(t, AutoIDModeReplicate, conf.AutoID.AutoRandomMode())
+	require.False(t, conf.AutoID.ReplicateSequence())
+	conf.AutoID = &AutoIDConfig{AutoRandom: AutoIDModeRegenerate, Sequence: true}
+	require.Nil(t, conf.Validate())
+	require.Equal(t, AutoIDModeReplicate, conf.AutoID.AutoIncrementMode())
+	require.Equal(t, AutoIDModeRegenerate, conf.AutoID.AutoRandomMode())
+	require.True(t, conf.AutoID.ReplicateSequence())
+	conf.EnableOldValue = false
+	require.Regexp(t, ".*requires enable-old-value.*", conf.Validate())
+	conf.EnableOldValue = true
+	conf.AutoID.AutoIncrement = "verbatim"
+	require.Regexp(t, ".*auto-id.auto-increment should be replicate or regenerate.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	conf.Filter.EventFilters = []*EventFilterRule{{Matcher: []string{"test.*"}, IgnoreEvent: []string{EventTypeInsert}}}
+	require.Nil(t, conf.Validate())
+	conf.EnableOldValue = false
+	require.Regexp(t, ".*filter.event-filters requires enable-old-value.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.False(t, conf.Charset.IsForceUTF8MB4())
+	require.Equal(t, CharsetInvalidPolicyError, conf.Charset.GetInvalidPolicy())
+	conf.Charset = &CharsetConfig{ForceUTF8MB4: true, InvalidPolicy: CharsetInvalidPolicyReplace}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.Charset.IsForceUTF8MB4())
+	require.Equal(t, CharsetInvalidPolicyReplace, conf.Charset.GetInvalidPolicy())
+	conf.Charset.InvalidPolicy = "ignore"
+	require.Regexp(t, ".*charset.invalid-policy should be error or replace.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.False(t, conf.RowSize.IsLimited())
+	require.Equal(t, OversizedRowPolicyFail, conf.RowSize.GetPolicy())
+	conf.RowSize = &RowSizeConfig{MaxRowSize: 1024, Policy: OversizedRowPolicyTruncate}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.RowSize.IsLimited())
+	conf.RowSize.Policy = OversizedRowPolicyDeadLetter
+	require.Regexp(t, ".*row-size.dead-letter-uri is required by the dead-letter policy.*", conf.Validate())
+	conf.RowSize.DeadLetterURI = "/tmp/dead-letter"
+	require.Regexp(t, ".*row-size.dead-letter-uri /tmp/dead-letter is not a valid storage URI.*", conf.Validate())
+	conf.RowSize.DeadLetterURI = "local:///tmp/dead-letter"
+	require.Nil(t, conf.Validate())
+	conf.RowSize.Policy = "drop"
+	require.Regexp(t, ".*row-size.policy should be fail, truncate or dead-letter.*", conf.Validate())
+	conf.RowSize = &RowSizeConfig{MaxRowSize: -1}
+	require.Regexp(t, ".*row-size.max-row-size should not be negative.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.False(t, conf.Offload.IsEnabled())
+	require.Equal(t, int64(DefaultOffloadGCTTL), conf.Offload.GetGCTTL())
+	conf.Offload = &OffloadConfig{Threshold: 1 << 20, Storage: "s3://bucket/prefix", GCTTL: 3600}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.Offload.IsEnabled())
+	require.Equal(t, int64(3600), conf.Offload.GetGCTTL())
+	conf.Offload.Storage = ""
+	require.Regexp(t, ".*offload.storage  is not a valid storage URI.*", conf.Validate())
+	conf.Offload = &OffloadConfig{GCTTL: -1}
+	require.Regexp(t, ".*offload.gc-ttl should not be negative.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	require.False(t, conf.Backfill.IsEnabled())
+	require.Equal(t, DefaultBackfillBatchSize, conf.Backfill.GetBatchSize())
+	conf.Backfill = &BackfillConfig{UpstreamURI: "mysql://root@127.0.0.1:4000/", BatchSize: 100}
+	require.Nil(t, conf.Validate())
+	require.True(t, conf.Backfill.IsEnabled())
+	require.Equal(t, 100, conf.Backfill.GetBatchSize())
+	conf.Backfill.UpstreamURI = "kafka://127.0.0.1:9092/topic"
+	require.Regexp(t, ".*backfill.upstream-uri should be a mysql or tidb URI.*", conf.Validate())
+	conf.Backfill = &BackfillConfig{BatchSize: -1}
+	require.Regexp(t, ".*backfill.batch-size should not be negative.*", conf.Validate())
+
+	conf = GetDefaultReplicaConfig()
+	tz, err := conf.LoadTimeZone()
+	require.Nil(t, err)
+	require.Nil(t, tz)
+	conf.TimeZone = "Asia/Shanghai"
+	require.Nil(t, conf.Validate())
+	tz, err = conf.LoadTimeZone()
+	require.Nil(t, err)
+	require.Equal(t, "Asia/Shanghai", tz.String())
+	conf.TimeZone = "Local"
+	require.Regexp(t, ".*time-zone should be the name of a time zone.*", conf.Validate())
+	conf.TimeZone = "Mars/Olympus"
+	require.Regexp(t, ".*time-zone Mars/Olympus is not a valid time zone.*", conf.Validate())
+}
+
+func TestReplicaConfigFeatures(t *testing.T) {
+	t.Parallel()
+	conf := GetDefaultReplicaConfig()
+	require.False(t, conf.IsFeatureEnabled(FeatureLowLatencySortEngine))
+	require.Empty(t, conf.EnabledFeatures())
+
+	conf.Features = map[string]bool{FeatureLowLatencySortEngine: true}
+	require.True(t, conf.IsFeatureEnabled(FeatureLowLatencySortEngine))
+	require.Equal(t, []string{FeatureLowLatencySortEngine}, conf.EnabledFeatures())
+	require.True(t, conf.Clone().IsFeatureEnabled(FeatureLowLatencySortEngine))
+
+	conf.Features[FeatureLowLatencySortEngine] = false
+	require.False(t, conf.IsFeatureEnabled(FeatureLowLatencySortEngine))
+	require.Empty(t, conf.EnabledFeatures())
+
+	for _, f := range Features() {
+		require.NotEmpty(t, f.Description)
+	}
+}
+
+func TestPlacementMatch(t *testing.T) {
+	t.Parallel()
+	var placement *PlacementConfig
+	require.False(t, placement.IsEnabled())
+	require.True(t, placement.Match(nil))
+
+	placement = &PlacementConfig{Constraints: []string{"ssd=true", "zone!=z1"}}
+	require.True(t, placement.Match(map[string]string{"ssd": "true"}))
+	require.True(t, placement.Match(map[string]string{"ssd": "true", "zone": "z2"}))
+	require.False(t, placement.Match(map[string]string{"ssd": "true", "zone": "z1"}))
+	require.False(t, placement.Match(map[string]string{"ssd": "false"}))
+	require.False(t, placement.Match(nil))
+
+	require.Nil(t, ValidateLabels(map[string]string{"zone": "us-west-1a", "disk.type": "ssd"}))
+	require.Regexp(t, ".*invalid label key.*", ValidateLabels(map[string]string{"zone=": "z1"}))
+	require.Regexp(t, ".*invalid value.*", ValidateLabels(map[string]string{"zone": ""}))
+}
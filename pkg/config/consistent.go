@@ -13,10 +13,27 @@
 
 package config
 
+import (
+	"github.com/pingcap/ticdc/pkg/compression"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
 // ConsistentConfig represents replication consistency config for a changefeed
 type ConsistentConfig struct {
 	Level             string `toml:"level" json:"level"`
 	MaxLogSize        int64  `toml:"max-log-size" json:"max-log-size"`
 	FlushIntervalInMs int64  `toml:"flush-interval" json:"flush-interval"`
 	Storage           string `toml:"storage" json:"storage"`
+	// Compression is the compression of the redo log records, in the format
+	// of "name[:level]", such as "lz4" or "zstd:3".
+	Compression string `toml:"compression" json:"compression"`
+}
+
+// Validate validates the consistent configuration.
+func (c *ConsistentConfig) Validate() error {
+	if err := compression.Validate(c.Compression); err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"consistent.compression is invalid: " + err.Error())
+	}
+	return nil
 }
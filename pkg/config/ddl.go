@@ -0,0 +1,241 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	filter "github.com/pingcap/tidb-tools/pkg/table-filter"
+	"github.com/pingcap/tidb/parser/model"
+)
+
+// DDLPolicy decides how a kind of DDL is replicated to the downstream.
+type DDLPolicy string
+
+// The policies of DDLs
+const (
+	// DDLPolicyReplicate replicates the DDL as is.
+	DDLPolicyReplicate DDLPolicy = "replicate"
+	// DDLPolicyRewrite replicates the DDL as an equivalent plain CREATE TABLE
+	// statement constructed from the table info.
+	DDLPolicyRewrite DDLPolicy = "rewrite"
+	// DDLPolicySkip skips the DDL with a warning.
+	DDLPolicySkip DDLPolicy = "skip"
+)
+
+// The default policies of DDLs
+const (
+	DefaultCreateTableLikePolicy     = DDLPolicyRewrite
+	DefaultTemporaryTablePolicy      = DDLPolicySkip
+	DefaultCreateTableAsSelectPolicy = DDLPolicyRewrite
+)
+
+// AsyncDDLPolicy decides which DDLs are executed asynchronously downstream.
+type AsyncDDLPolicy string
+
+// The policies of executing DDLs asynchronously
+const (
+	// AsyncDDLPolicyNone executes all DDLs synchronously, the rows after a DDL
+	// are replicated after the DDL is executed.
+	AsyncDDLPolicyNone AsyncDDLPolicy = "none"
+	// AsyncDDLPolicyIndex executes the DDLs of indexes asynchronously, which
+	// don't change the encoding of the rows, so the rows after them are
+	// replicated while they are executed.
+	AsyncDDLPolicyIndex AsyncDDLPolicy = "index"
+)
+
+// DDLConfig represents the config of how some special DDLs are replicated
+type DDLConfig struct {
+	// CreateTableLike is the policy of `CREATE TABLE ... LIKE`, rewriting it
+	// makes the DDL independent of the referred table, which may be filtered
+	// out or absent in the downstream.
+	CreateTableLike DDLPolicy `toml:"create-table-like" json:"create-table-like"`
+	// TemporaryTable is the policy of the DDLs of global temporary tables,
+	// whose data is never replicated. Local temporary tables don't produce
+	// any DDL job. The rewrite policy is not supported.
+	TemporaryTable DDLPolicy `toml:"temporary-table" json:"temporary-table"`
+	// CreateTableAsSelect is the policy of `CREATE TABLE ... SELECT`, whose
+	// rows are replicated as row changes, so replicating it as is would
+	// insert the rows twice. The replicate policy is not supported.
+	CreateTableAsSelect DDLPolicy `toml:"create-table-as-select" json:"create-table-as-select"`
+	// AllowList is the types of the DDLs which are replicated, all types are
+	// allowed if it's empty. The types are named as the DDL jobs of TiDB,
+	// such as "create table" and "add column".
+	AllowList []string `toml:"allow-list" json:"allow-list,omitempty"`
+	// DenyList is the types of the DDLs which are not replicated, such as
+	// "drop table" and "truncate table", it takes precedence over AllowList.
+	// The schema of the changefeed is still changed by the denied DDLs.
+	DenyList []string `toml:"deny-list" json:"deny-list,omitempty"`
+	// Rewrites are the rules rewriting the DDL statements before they are
+	// executed by the sink.
+	Rewrites []*DDLRewriteRule `toml:"rewrites" json:"rewrites,omitempty"`
+	// AsyncDDL is the policy of the DDLs executed asynchronously downstream,
+	// the checkpoint of the changefeed is held at the DDL being executed
+	// asynchronously until it's finished, so that it's executed again after
+	// the changefeed is restarted.
+	AsyncDDL AsyncDDLPolicy `toml:"async-ddl" json:"async-ddl,omitempty"`
+}
+
+// DDLRewriteRule rewrites the DDL statements of the tables matched by the
+// matcher, the first matched rule is used if a table is matched by several
+// rules.
+type DDLRewriteRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	// TargetSchema renames the schema in the DDL statements, and the rows of
+	// the matched tables are replicated to the target schema as well.
+	TargetSchema string `toml:"target-schema" json:"target-schema,omitempty"`
+	// IfNotExists adds IF NOT EXISTS to the CREATE statements and IF EXISTS
+	// to the DROP statements, so that the DDLs can be executed repeatedly.
+	IfNotExists bool `toml:"if-not-exists" json:"if-not-exists,omitempty"`
+}
+
+// CreateTableLikePolicy returns the policy of `CREATE TABLE ... LIKE`.
+func (c *DDLConfig) CreateTableLikePolicy() DDLPolicy {
+	if c == nil || c.CreateTableLike == "" {
+		return DefaultCreateTableLikePolicy
+	}
+	return c.CreateTableLike
+}
+
+// TemporaryTablePolicy returns the policy of the DDLs of temporary tables.
+func (c *DDLConfig) TemporaryTablePolicy() DDLPolicy {
+	if c == nil || c.TemporaryTable == "" {
+		return DefaultTemporaryTablePolicy
+	}
+	return c.TemporaryTable
+}
+
+// CreateTableAsSelectPolicy returns the policy of `CREATE TABLE ... SELECT`.
+func (c *DDLConfig) CreateTableAsSelectPolicy() DDLPolicy {
+	if c == nil || c.CreateTableAsSelect == "" {
+		return DefaultCreateTableAsSelectPolicy
+	}
+	return c.CreateTableAsSelect
+}
+
+// IsAsync returns true if the DDLs of the type are executed asynchronously
+// downstream according to the async-ddl policy.
+func (c *DDLConfig) IsAsync(tp model.ActionType) bool {
+	if c == nil || c.AsyncDDL != AsyncDDLPolicyIndex {
+		return false
+	}
+	switch tp {
+	case model.ActionAddIndex, model.ActionDropIndex, model.ActionRenameIndex,
+		model.ActionAlterIndexVisibility:
+		return true
+	}
+	return false
+}
+
+// IsDenied returns true if the DDLs of the type are not replicated according
+// to the allow list and the deny list.
+func (c *DDLConfig) IsDenied(tp model.ActionType) bool {
+	if c == nil {
+		return false
+	}
+	if containsDDLType(c.DenyList, tp) {
+		return true
+	}
+	return len(c.AllowList) != 0 && !containsDDLType(c.AllowList, tp)
+}
+
+func containsDDLType(names []string, tp model.ActionType) bool {
+	for _, name := range names {
+		if strings.EqualFold(name, tp.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// ddlActionTypeByName returns the DDL type of the name, which is the string
+// of the type, such as "drop table".
+func ddlActionTypeByName(name string) (model.ActionType, bool) {
+	for i := int(model.ActionNone) + 1; i <= math.MaxUint8; i++ {
+		tp := model.ActionType(i)
+		// the unknown types are named as "none"
+		if tp.String() != model.ActionNone.String() && strings.EqualFold(name, tp.String()) {
+			return tp, true
+		}
+	}
+	return model.ActionNone, false
+}
+
+// Validate validates the DDL config.
+func (c *DDLConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := validateDDLTypes("allow-list", c.AllowList); err != nil {
+		return err
+	}
+	if err := validateDDLTypes("deny-list", c.DenyList); err != nil {
+		return err
+	}
+	for _, rule := range c.Rewrites {
+		if _, err := filter.Parse(rule.Matcher); err != nil {
+			return cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+		}
+		if rule.TargetSchema == "" && !rule.IfNotExists {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("the ddl rewrite rule with matcher %v rewrites nothing", rule.Matcher))
+		}
+	}
+	if err := validateDDLPolicy("create-table-like", c.CreateTableLike,
+		DDLPolicyReplicate, DDLPolicyRewrite, DDLPolicySkip); err != nil {
+		return err
+	}
+	if err := validateDDLPolicy("temporary-table", c.TemporaryTable,
+		DDLPolicyReplicate, DDLPolicySkip); err != nil {
+		return err
+	}
+	if err := validateDDLPolicy("create-table-as-select", c.CreateTableAsSelect,
+		DDLPolicyRewrite, DDLPolicySkip); err != nil {
+		return err
+	}
+	switch c.AsyncDDL {
+	case "", AsyncDDLPolicyNone, AsyncDDLPolicyIndex:
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("ddl.async-ddl should be one of [%s %s], got %s",
+				AsyncDDLPolicyNone, AsyncDDLPolicyIndex, c.AsyncDDL))
+	}
+	return nil
+}
+
+func validateDDLTypes(name string, types []string) error {
+	for _, tp := range types {
+		if _, ok := ddlActionTypeByName(tp); !ok {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("ddl.%s contains an unknown DDL type %s", name, tp))
+		}
+	}
+	return nil
+}
+
+func validateDDLPolicy(name string, policy DDLPolicy, supported ...DDLPolicy) error {
+	if policy == "" {
+		return nil
+	}
+	for _, p := range supported {
+		if policy == p {
+			return nil
+		}
+	}
+	return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+		fmt.Sprintf("ddl.%s should be one of %v, got %s", name, supported, policy))
+}
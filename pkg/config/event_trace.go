@@ -0,0 +1,37 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import cerror "github.com/pingcap/ticdc/pkg/errors"
+
+// EventTraceConfig represents the per-event latency tracking config for a changefeed
+type EventTraceConfig struct {
+	// SampleRate is the fraction of row changed events which carry stage
+	// timestamps through the table pipeline. 0 disables the tracking and
+	// 1 traces every event.
+	SampleRate float64 `toml:"sample-rate" json:"sample-rate"`
+}
+
+// IsEnabled returns whether the event tracing is enabled or not.
+func (c *EventTraceConfig) IsEnabled() bool {
+	return c != nil && c.SampleRate > 0
+}
+
+// Validate validates the event trace configuration
+func (c *EventTraceConfig) Validate() error {
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("event-trace.sample-rate should be in range [0, 1]")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// Feature is a behavior which is enabled per changefeed by the feature flags,
+// so that an experimental behavior can be rolled out gradually across the
+// changefeeds instead of being toggled for the whole cluster.
+type Feature struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Default is whether the feature is enabled for the changefeeds which
+	// don't specify its flag.
+	Default bool `json:"default"`
+}
+
+// The features
+const (
+	// FeatureLowLatencySortEngine enables the low-latency sort engine, the
+	// tables whose sort engine rule is low-latency use the unified sort engine
+	// if it is disabled.
+	FeatureLowLatencySortEngine = "low-latency-sort-engine"
+	// FeatureTableSinkMetrics enables the sink metrics labelled by table, it
+	// is disabled by default since the cardinality of the metrics grows with
+	// the number of the replicated tables.
+	FeatureTableSinkMetrics = "table-sink-metrics"
+)
+
+var features = map[string]Feature{
+	FeatureLowLatencySortEngine: {
+		Name:        FeatureLowLatencySortEngine,
+		Description: "sort the events of the tables matched by the low-latency sort engine rules in memory",
+	},
+	FeatureTableSinkMetrics: {
+		Name:        FeatureTableSinkMetrics,
+		Description: "report the flush duration, retries, conflicts and pending rows of the sink per table",
+	},
+}
+
+// Features returns all the features sorted by name.
+func Features() []Feature {
+	ret := make([]Feature, 0, len(features))
+	for _, f := range features {
+		ret = append(ret, f)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+// IsFeatureEnabled returns whether the feature is enabled for the changefeed.
+func (c *ReplicaConfig) IsFeatureEnabled(name string) bool {
+	if enabled, ok := c.Features[name]; ok {
+		return enabled
+	}
+	return features[name].Default
+}
+
+// EnabledFeatures returns the names of the features enabled for the
+// changefeed, sorted by name.
+func (c *ReplicaConfig) EnabledFeatures() []string {
+	var ret []string
+	for _, f := range Features() {
+		if c.IsFeatureEnabled(f.Name) {
+			ret = append(ret, f.Name)
+		}
+	}
+	return ret
+}
+
+func validateFeatures(flags map[string]bool) error {
+	for name := range flags {
+		if _, ok := features[name]; !ok {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(fmt.Sprintf("unknown feature %s", name))
+		}
+	}
+	return nil
+}
@@ -24,4 +24,34 @@ type FilterConfig struct {
 	*filter.MySQLReplicationRules
 	IgnoreTxnStartTs []uint64           `toml:"ignore-txn-start-ts" json:"ignore-txn-start-ts"`
 	DDLAllowlist     []model.ActionType `toml:"ddl-allow-list" json:"ddl-allow-list,omitempty"`
+	RowFilters       []*RowFilterRule   `toml:"row-filters" json:"row-filters,omitempty"`
+	EventFilters     []*EventFilterRule `toml:"event-filters" json:"event-filters,omitempty"`
+}
+
+// The DML event types which can be ignored by the event filters
+const (
+	EventTypeInsert = "insert"
+	EventTypeUpdate = "update"
+	EventTypeDelete = "delete"
+)
+
+// EventFilterRule drops the DML events of the given types of the tables
+// matched by the matcher, the first matched rule is used if a table is
+// matched by several rules. The rules require the old value, otherwise the
+// updates are not distinguished from the inserts.
+type EventFilterRule struct {
+	Matcher     []string `toml:"matcher" json:"matcher"`
+	IgnoreEvent []string `toml:"ignore-event" json:"ignore-event"`
+}
+
+// RowFilterRule replicates only the rows satisfying the expression of the
+// tables matched by the matcher, the first matched rule is used if a table is
+// matched by several rules.
+type RowFilterRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	// Expression is a SQL expression on the columns of the tables, such as
+	// `region = 'us-west'`. An insert or delete event is replicated if the
+	// expression is true for its row, and an update event is replicated if the
+	// expression is true for either its old or new row.
+	Expression string `toml:"expression" json:"expression"`
 }
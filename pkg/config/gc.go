@@ -0,0 +1,75 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// Policies of the changefeeds blocking the GC of the upstream longer than the gc ttl
+const (
+	// GCPolicyFail fails the changefeed, it can't be resumed because the
+	// data to replicate is going to be garbage collected.
+	GCPolicyFail = "fail"
+	// GCPolicyQuarantine pauses the changefeed and releases the GC safepoint
+	// held by it, so that the GC of the upstream is not blocked any more.
+	GCPolicyQuarantine = "quarantine"
+)
+
+// GCConfig represents the config of how the changefeed holds the GC safepoint
+// of the upstream.
+type GCConfig struct {
+	// TTL is the longest time in seconds that the checkpoint of the
+	// changefeed can lag behind while it blocks the GC of the upstream.
+	// 0 means the gc-ttl of the server.
+	TTL int64 `toml:"ttl" json:"ttl"`
+	// Policy is applied to the changefeed once it blocks the GC longer than
+	// TTL, it is "fail" if empty.
+	Policy string `toml:"policy" json:"policy"`
+}
+
+// GetTTL returns the gc ttl of the changefeed in seconds, 0 means the gc-ttl
+// of the server.
+func (c *GCConfig) GetTTL() int64 {
+	if c == nil {
+		return 0
+	}
+	return c.TTL
+}
+
+// GetPolicy returns the policy applied to the changefeed blocking the GC
+// longer than the gc ttl.
+func (c *GCConfig) GetPolicy() string {
+	if c == nil || c.Policy == "" {
+		return GCPolicyFail
+	}
+	return c.Policy
+}
+
+// Validate validates the gc config.
+func (c *GCConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.TTL < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("gc.ttl should not be negative")
+	}
+	switch c.Policy {
+	case "", GCPolicyFail, GCPolicyQuarantine:
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"gc.policy should be fail or quarantine")
+	}
+	return nil
+}
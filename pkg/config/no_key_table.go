@@ -0,0 +1,68 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// NoKeyTableStrategy decides how the tables without a primary key or a not
+// null unique key are replicated.
+type NoKeyTableStrategy string
+
+// The strategies of the tables without keys
+const (
+	// NoKeyTableStrategyNone doesn't replicate the tables without keys unless
+	// force-replicate is enabled, which is the same as the all-columns
+	// strategy.
+	NoKeyTableStrategyNone NoKeyTableStrategy = ""
+	// NoKeyTableStrategyAllColumns identifies the rows by all the columns.
+	// The rows are not identified correctly if there are duplicate rows, and
+	// the columns which can't be compared exactly, such as the floating
+	// point columns, may make the updates and deletes affect nothing.
+	NoKeyTableStrategyAllColumns NoKeyTableStrategy = "all-columns"
+	// NoKeyTableStrategyRowID identifies the rows by the implicit
+	// _tidb_rowid of the upstream. The downstream must be TiDB, and the
+	// existing rows of the downstream tables must have the same _tidb_rowid
+	// as the upstream ones, e.g. they are restored by BR or replicated from
+	// an empty table.
+	NoKeyTableStrategyRowID NoKeyTableStrategy = "row-id"
+)
+
+// ReplicateNoKeyTables returns whether the tables without a primary key or a
+// not null unique key are replicated.
+func (c *ReplicaConfig) ReplicateNoKeyTables() bool {
+	return c.ForceReplicate || c.NoKeyTableStrategy != NoKeyTableStrategyNone
+}
+
+// GetNoKeyTableStrategy returns the strategy of the tables without keys, it
+// returns NoKeyTableStrategyNone if the tables are not replicated.
+func (c *ReplicaConfig) GetNoKeyTableStrategy() NoKeyTableStrategy {
+	if c.NoKeyTableStrategy == NoKeyTableStrategyNone && c.ForceReplicate {
+		return NoKeyTableStrategyAllColumns
+	}
+	return c.NoKeyTableStrategy
+}
+
+func validateNoKeyTableStrategy(strategy NoKeyTableStrategy) error {
+	switch strategy {
+	case NoKeyTableStrategyNone, NoKeyTableStrategyAllColumns, NoKeyTableStrategyRowID:
+		return nil
+	}
+	return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+		fmt.Sprintf("no-key-table-strategy should be one of %s and %s, got %s",
+			NoKeyTableStrategyAllColumns, NoKeyTableStrategyRowID, strategy))
+}
@@ -0,0 +1,179 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// Events of changefeed notifications
+const (
+	// NotificationEventError is sent when the changefeed runs into the error
+	// or failed state.
+	NotificationEventError = "error"
+	// NotificationEventPaused is sent when the changefeed is paused.
+	NotificationEventPaused = "paused"
+	// NotificationEventResumed is sent when the changefeed is back to normal
+	// from the paused, error or failed state.
+	NotificationEventResumed = "resumed"
+	// NotificationEventFinished is sent when the changefeed reaches its target ts.
+	NotificationEventFinished = "finished"
+	// NotificationEventLagExceeded is sent when the checkpoint lag exceeds
+	// the lag threshold.
+	NotificationEventLagExceeded = "lag-exceeded"
+	// NotificationEventLagRecovered is sent when the checkpoint lag falls
+	// back below the lag threshold.
+	NotificationEventLagRecovered = "lag-recovered"
+)
+
+var notificationEvents = []string{
+	NotificationEventError, NotificationEventPaused, NotificationEventResumed,
+	NotificationEventFinished, NotificationEventLagExceeded, NotificationEventLagRecovered,
+}
+
+const defaultNotificationMaxRetries = 3
+
+// NotificationConfig represents the config of the notifications about the
+// state transitions of a changefeed.
+type NotificationConfig struct {
+	// Events are the events to be notified, all the events are notified if
+	// it is empty.
+	Events []string `toml:"events" json:"events"`
+	// LagThreshold is the checkpoint lag in seconds, the lag events are sent
+	// when the lag crosses it. 0 means the lag events are disabled.
+	LagThreshold int64 `toml:"lag-threshold" json:"lag-threshold"`
+	// MaxRetries is the maximum number of retries of sending a notification
+	// to a target. 0 means the default value.
+	MaxRetries int `toml:"max-retries" json:"max-retries"`
+	// Targets are where the notifications are sent, no notification is sent
+	// if it is empty.
+	Targets []*NotificationTarget `toml:"targets" json:"targets"`
+}
+
+// NotificationTarget is a webhook or a Kafka topic the notifications are sent to.
+type NotificationTarget struct {
+	// URI is a http or https URL which is notified by POST requests, or a
+	// Kafka URI in the format of kafka://addr1,addr2/topic.
+	URI string `toml:"uri" json:"uri"`
+	// Template is the Go text/template of the payload, which is executed with
+	// the notification. The notification is encoded in JSON if it is empty.
+	Template string `toml:"template" json:"template,omitempty"`
+}
+
+// IsEnabled returns whether the notifications are enabled.
+func (c *NotificationConfig) IsEnabled() bool {
+	return c != nil && len(c.Targets) != 0
+}
+
+// IsEventEnabled returns whether the event should be notified.
+func (c *NotificationConfig) IsEventEnabled(event string) bool {
+	if !c.IsEnabled() {
+		return false
+	}
+	if len(c.Events) == 0 {
+		return true
+	}
+	for _, e := range c.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMaxRetries returns the maximum number of retries of sending a notification.
+func (c *NotificationConfig) GetMaxRetries() int {
+	if c == nil || c.MaxRetries == 0 {
+		return defaultNotificationMaxRetries
+	}
+	return c.MaxRetries
+}
+
+// Validate validates the notification config.
+func (c *NotificationConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, event := range c.Events {
+		valid := false
+		for _, e := range notificationEvents {
+			if e == event {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("notification.events %s is invalid, the valid events are %s",
+					event, strings.Join(notificationEvents, ", ")))
+		}
+	}
+	if c.LagThreshold < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("notification.lag-threshold should not be negative")
+	}
+	if c.MaxRetries < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("notification.max-retries should not be negative")
+	}
+	for _, target := range c.Targets {
+		if err := target.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the notification target.
+func (t *NotificationTarget) Validate() error {
+	u, err := url.Parse(t.URI)
+	if err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("notification.targets.uri is invalid")
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+	case "kafka":
+		if u.Host == "" || strings.Trim(u.Path, "/") == "" {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				"notification.targets.uri should be in the format of kafka://addr1,addr2/topic")
+		}
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"notification.targets.uri should be a http, https or kafka URI")
+	}
+	if _, err := t.ParseTemplate(); err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("notification.targets.template is invalid: %s", err))
+	}
+	return nil
+}
+
+// ParseTemplate parses the template of the payload, it returns nil if the
+// template is empty. The function json can be used in the template to
+// encode a value in JSON.
+func (t *NotificationTarget) ParseTemplate() (*template.Template, error) {
+	if t.Template == "" {
+		return nil, nil
+	}
+	return template.New("notification").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Parse(t.Template)
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// DefaultOffloadGCTTL is the default time in seconds the offloaded values are
+// kept after the checkpoint of the changefeed passes them.
+const DefaultOffloadGCTTL = 24 * 60 * 60
+
+// OffloadConfig represents how the large column values are offloaded to the
+// external storage. The offloaded values are replaced by the URIs of the
+// objects in the events, and the columns are marked by the offloaded flag. It
+// is not supported by the MySQL sink.
+type OffloadConfig struct {
+	// Threshold is the min size in bytes of the values of the BLOB, TEXT and
+	// JSON columns to be offloaded, 0 means the values are never offloaded.
+	Threshold int64 `toml:"threshold" json:"threshold"`
+	// Storage is the URI of the external storage, such as "s3://bucket/prefix",
+	// the values of a changefeed are written under the "{changefeed-id}/" prefix.
+	Storage string `toml:"storage" json:"storage"`
+	// GCTTL is the time in seconds the offloaded values are kept after the
+	// checkpoint of the changefeed passes them, 0 means DefaultOffloadGCTTL.
+	GCTTL int64 `toml:"gc-ttl" json:"gc-ttl"`
+}
+
+// IsEnabled returns true if the large values are offloaded.
+func (c *OffloadConfig) IsEnabled() bool {
+	return c != nil && c.Threshold > 0
+}
+
+// GetGCTTL returns the time in seconds the offloaded values are kept.
+func (c *OffloadConfig) GetGCTTL() int64 {
+	if c == nil || c.GCTTL == 0 {
+		return DefaultOffloadGCTTL
+	}
+	return c.GCTTL
+}
+
+// Validate validates the offload config.
+func (c *OffloadConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.Threshold < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("offload.threshold should not be negative, got %d", c.Threshold))
+	}
+	if c.GCTTL < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("offload.gc-ttl should not be negative, got %d", c.GCTTL))
+	}
+	if !c.IsEnabled() {
+		return nil
+	}
+	if u, err := url.Parse(c.Storage); err != nil || u.Scheme == "" {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("offload.storage %s is not a valid storage URI", c.Storage))
+	}
+	return nil
+}
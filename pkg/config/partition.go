@@ -0,0 +1,110 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	filter "github.com/pingcap/tidb-tools/pkg/table-filter"
+)
+
+// PartitionMode decides how the partitions of the upstream partitioned tables
+// are written to the downstream.
+type PartitionMode string
+
+// The modes of replicating partitioned tables
+const (
+	// PartitionModeNone writes the partitions to the downstream table with
+	// the same name, and replicates the DDLs of partitions as is.
+	PartitionModeNone PartitionMode = ""
+	// PartitionModeMerge writes all the partitions to a single non-partitioned
+	// downstream table with the same name, or to the table of each partition
+	// if the table is matched by a route rule. The DDLs of partitions are
+	// replicated as the equivalent statements of the downstream tables, e.g.
+	// TRUNCATE PARTITION deletes the rows of the partition.
+	PartitionModeMerge PartitionMode = "merge"
+)
+
+// The placeholders of the target table of partition route rules
+const (
+	PartitionRouteSchemaPlaceholder    = "{schema}"
+	PartitionRouteTablePlaceholder     = "{table}"
+	PartitionRoutePartitionPlaceholder = "{partition}"
+)
+
+// PartitionConfig represents how the partitioned tables are replicated, it
+// takes effect on the MySQL sink only.
+type PartitionConfig struct {
+	Mode PartitionMode `toml:"mode" json:"mode"`
+	// Routes are the rules routing the partitions of the matched tables to a
+	// downstream table of each partition in the merge mode, the first matched
+	// rule is used if a table is matched by several rules.
+	Routes []*PartitionRouteRule `toml:"routes" json:"routes,omitempty"`
+}
+
+// PartitionRouteRule routes each partition of the tables matched by the
+// matcher to the downstream table named by the target table.
+type PartitionRouteRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	// TargetTable is the name of the downstream table of a partition, in
+	// which {schema}, {table} and {partition} are replaced by the names of
+	// the upstream schema, table and partition, e.g. "{table}_{partition}".
+	TargetTable string `toml:"target-table" json:"target-table"`
+}
+
+// IsMergeMode returns true if the partitions are written to the downstream
+// non-partitioned tables.
+func (c *PartitionConfig) IsMergeMode() bool {
+	return c != nil && c.Mode == PartitionModeMerge
+}
+
+// Target returns the name of the downstream table of the partition.
+func (r *PartitionRouteRule) Target(schema, table, partition string) string {
+	return strings.NewReplacer(
+		PartitionRouteSchemaPlaceholder, schema,
+		PartitionRouteTablePlaceholder, table,
+		PartitionRoutePartitionPlaceholder, partition,
+	).Replace(r.TargetTable)
+}
+
+// Validate validates the partition config.
+func (c *PartitionConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	switch c.Mode {
+	case PartitionModeNone, PartitionModeMerge:
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("partition.mode should be empty or %s, got %s", PartitionModeMerge, c.Mode))
+	}
+	if len(c.Routes) > 0 && c.Mode != PartitionModeMerge {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("partition.routes only take effect in the %s mode", PartitionModeMerge))
+	}
+	for _, rule := range c.Routes {
+		if _, err := filter.Parse(rule.Matcher); err != nil {
+			return cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+		}
+		// the partitions must be routed to different tables.
+		if !strings.Contains(rule.TargetTable, PartitionRoutePartitionPlaceholder) {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("the target table of the partition route rule with matcher %v should contain %s",
+					rule.Matcher, PartitionRoutePartitionPlaceholder))
+		}
+	}
+	return nil
+}
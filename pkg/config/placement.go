@@ -0,0 +1,142 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// labelRegexp matches the valid keys and values of the capture labels.
+var labelRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.\-]*[A-Za-z0-9])?$`)
+
+// ValidateLabels checks whether the keys and values of the capture labels are valid.
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if !labelRegexp.MatchString(key) {
+			return cerror.ErrInvalidServerOption.GenWithStack("invalid label key %q", key)
+		}
+		if !labelRegexp.MatchString(value) {
+			return cerror.ErrInvalidServerOption.GenWithStack("invalid value %q of label %s", value, key)
+		}
+	}
+	return nil
+}
+
+// The operators of the label constraints
+const (
+	LabelOpEqual    = "="
+	LabelOpNotEqual = "!="
+)
+
+// LabelConstraint is a constraint on a capture label in the format of
+// "key=value" or "key!=value". A capture without the label satisfies the
+// "!=" constraints but not the "=" constraints.
+type LabelConstraint struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+// ParseLabelConstraint parses a label constraint.
+func ParseLabelConstraint(s string) (LabelConstraint, error) {
+	op := LabelOpEqual
+	idx := strings.Index(s, LabelOpNotEqual)
+	if idx >= 0 {
+		op = LabelOpNotEqual
+	} else {
+		idx = strings.Index(s, LabelOpEqual)
+	}
+	if idx < 0 {
+		return LabelConstraint{}, cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("placement constraint %q should be in the format of key=value or key!=value", s))
+	}
+	c := LabelConstraint{
+		Key:   strings.TrimSpace(s[:idx]),
+		Op:    op,
+		Value: strings.TrimSpace(s[idx+len(op):]),
+	}
+	if !labelRegexp.MatchString(c.Key) || !labelRegexp.MatchString(c.Value) {
+		return LabelConstraint{}, cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("placement constraint %q contains an invalid label key or value", s))
+	}
+	return c, nil
+}
+
+// Match returns whether the labels satisfy the constraint.
+func (c LabelConstraint) Match(labels map[string]string) bool {
+	value, ok := labels[c.Key]
+	if c.Op == LabelOpNotEqual {
+		return !ok || value != c.Value
+	}
+	return ok && value == c.Value
+}
+
+// String implements fmt.Stringer.
+func (c LabelConstraint) String() string {
+	return c.Key + c.Op + c.Value
+}
+
+// PlacementConfig represents the placement rules of a changefeed, which
+// restrict the captures its tables can be dispatched to.
+type PlacementConfig struct {
+	// Constraints are the label constraints in the format of "key=value" or
+	// "key!=value", a capture is eligible only if it satisfies all of them.
+	Constraints []string `toml:"constraints" json:"constraints"`
+}
+
+// IsEnabled returns whether the placement rules are enabled.
+func (c *PlacementConfig) IsEnabled() bool {
+	return c != nil && len(c.Constraints) > 0
+}
+
+// LabelConstraints returns the parsed label constraints.
+func (c *PlacementConfig) LabelConstraints() ([]LabelConstraint, error) {
+	if !c.IsEnabled() {
+		return nil, nil
+	}
+	constraints := make([]LabelConstraint, 0, len(c.Constraints))
+	for _, s := range c.Constraints {
+		constraint, err := ParseLabelConstraint(s)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
+
+// Match returns whether the capture labels satisfy all the constraints. The
+// config is assumed to be validated, invalid constraints are never satisfied.
+func (c *PlacementConfig) Match(labels map[string]string) bool {
+	constraints, err := c.LabelConstraints()
+	if err != nil {
+		return false
+	}
+	for _, constraint := range constraints {
+		if !constraint.Match(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate validates the placement config.
+func (c *PlacementConfig) Validate() error {
+	_, err := c.LabelConstraints()
+	return err
+}
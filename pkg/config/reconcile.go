@@ -0,0 +1,62 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"time"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// minReconcileInterval is the minimum interval of the changefeed reconciliation.
+const minReconcileInterval = time.Second
+
+// ReconcileConfig represents config for the declarative changefeed
+// reconciliation. The owner reads the changefeed specs from a directory or an
+// etcd prefix periodically, and creates, updates or removes changefeeds to
+// make them match the specs.
+type ReconcileConfig struct {
+	// SpecDir is the directory of the changefeed spec files, every "*.json"
+	// file in it is a spec in the format of the create changefeed API.
+	SpecDir string `toml:"spec-dir" json:"spec-dir"`
+	// EtcdPrefix is the etcd key prefix of the changefeed specs, every value
+	// under the prefix is a spec in the format of the create changefeed API.
+	EtcdPrefix string `toml:"etcd-prefix" json:"etcd-prefix"`
+	// Interval is the interval of the reconciliation.
+	Interval TomlDuration `toml:"interval" json:"interval"`
+	// Prune removes the changefeeds created by the reconciliation if their
+	// specs are removed.
+	Prune bool `toml:"prune" json:"prune"`
+}
+
+// IsEnabled returns whether the reconciliation is enabled.
+func (c *ReconcileConfig) IsEnabled() bool {
+	return c != nil && (c.SpecDir != "" || c.EtcdPrefix != "")
+}
+
+// ValidateAndAdjust validates the reconciliation configuration.
+func (c *ReconcileConfig) ValidateAndAdjust() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	if c.SpecDir != "" && c.EtcdPrefix != "" {
+		return cerror.ErrInvalidServerOption.GenWithStack(
+			"reconcile spec-dir and etcd-prefix can not be set at the same time")
+	}
+	if time.Duration(c.Interval) < minReconcileInterval {
+		return cerror.ErrInvalidServerOption.GenWithStack(
+			"reconcile interval should be at least %s", minReconcileInterval)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// OversizedRowPolicy decides how the rows larger than the max row size are
+// handled.
+type OversizedRowPolicy string
+
+// The policies of handling the oversized rows
+const (
+	// OversizedRowPolicyFail stops the changefeed with an error.
+	OversizedRowPolicyFail OversizedRowPolicy = "fail"
+	// OversizedRowPolicyTruncate truncates the new values of the BLOB and
+	// TEXT columns, and replaces the new values of the JSON columns with JSON
+	// null, from the largest one until the row is small enough. The truncated
+	// columns are marked by the truncated flag. The old values are kept to
+	// locate the rows, so the changefeed fails if the row is still too large.
+	OversizedRowPolicyTruncate OversizedRowPolicy = "truncate"
+	// OversizedRowPolicyDeadLetter writes the rows to the dead letter storage
+	// in JSON, and skips them.
+	OversizedRowPolicyDeadLetter OversizedRowPolicy = "dead-letter"
+)
+
+// RowSizeConfig represents how the oversized rows are handled, the size of a
+// row is the size of its raw key-value, including the old value. The rows are
+// handled after they are sorted, only the oversized rows in the fail policy
+// are rejected before they are sorted.
+type RowSizeConfig struct {
+	// MaxRowSize is the max size of a row in bytes, 0 means unlimited.
+	MaxRowSize int64              `toml:"max-row-size" json:"max-row-size"`
+	Policy     OversizedRowPolicy `toml:"policy" json:"policy"`
+	// DeadLetterURI is the URI of the external storage the oversized rows are
+	// written to in the dead-letter policy, such as "s3://bucket/prefix" or
+	// "local:///data/dead-letter".
+	DeadLetterURI string `toml:"dead-letter-uri" json:"dead-letter-uri"`
+}
+
+// IsLimited returns true if the size of rows is limited.
+func (c *RowSizeConfig) IsLimited() bool {
+	return c != nil && c.MaxRowSize > 0
+}
+
+// GetPolicy returns the policy of the oversized rows.
+func (c *RowSizeConfig) GetPolicy() OversizedRowPolicy {
+	if c == nil || c.Policy == "" {
+		return OversizedRowPolicyFail
+	}
+	return c.Policy
+}
+
+// Validate validates the row size config.
+func (c *RowSizeConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxRowSize < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("row-size.max-row-size should not be negative, got %d", c.MaxRowSize))
+	}
+	switch c.Policy {
+	case "", OversizedRowPolicyFail, OversizedRowPolicyTruncate:
+	case OversizedRowPolicyDeadLetter:
+		if c.DeadLetterURI == "" {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("row-size.dead-letter-uri is required by the %s policy", OversizedRowPolicyDeadLetter))
+		}
+		if u, err := url.Parse(c.DeadLetterURI); err != nil || u.Scheme == "" {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+				fmt.Sprintf("row-size.dead-letter-uri %s is not a valid storage URI", c.DeadLetterURI))
+		}
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("row-size.policy should be %s, %s or %s, got %s", OversizedRowPolicyFail,
+				OversizedRowPolicyTruncate, OversizedRowPolicyDeadLetter, c.Policy))
+	}
+	return nil
+}
@@ -13,9 +13,37 @@
 
 package config
 
+import (
+	"fmt"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// Types of the scheduling policies of changefeeds
+const (
+	// SchedulerTypeTableNumber balances the number of tables among captures
+	SchedulerTypeTableNumber = "table-number"
+	// SchedulerTypeLoad balances the load of tables among captures, the load
+	// of a table is calculated by its event rate, sorter backlog and sink
+	// flush latency
+	SchedulerTypeLoad = "load"
+)
+
 // SchedulerConfig represents scheduler config for a changefeed
 type SchedulerConfig struct {
 	Tp string `toml:"type" json:"type"`
 	// PollingTime represents the polling cycle of checking the skewness of workload and try to do schedule if needed
 	PollingTime int `toml:"polling-time" json:"polling-time"`
 }
+
+// Validate validates the scheduler configuration.
+func (c *SchedulerConfig) Validate() error {
+	switch c.Tp {
+	case SchedulerTypeTableNumber, SchedulerTypeLoad:
+		return nil
+	default:
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("scheduler.type should be %s or %s, but got %s",
+				SchedulerTypeTableNumber, SchedulerTypeLoad, c.Tp))
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/logutil"
+	"go.uber.org/zap"
+)
+
+// reloadableServerConfigItems are the items of the server config which are safe to be changed by reloading
+// the server config. The sorter and the memory quota items take effect for the tables added after reloading,
+// except that the memory limits of the sorter take effect at once.
+var reloadableServerConfigItems = map[string]struct{}{
+	"log-level":                     {},
+	"gc-ttl":                        {},
+	"per-table-memory-quota":        {},
+	"sorter.num-concurrent-worker":  {},
+	"sorter.chunk-size-limit":       {},
+	"sorter.max-memory-percentage":  {},
+	"sorter.max-memory-consumption": {},
+	"sorter.disk-full-timeout":      {},
+	"sorter.disk-full-memory-quota": {},
+}
+
+var (
+	serverConfigReloadMu sync.Mutex
+	serverConfigLoader   func() (*ServerConfig, error)
+)
+
+// ServerConfigChange is a change of an item of the server config by reloading it.
+type ServerConfigChange struct {
+	Item string      `json:"item"`
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}
+
+// SetServerConfigLoader sets the function to load the server config when reloading it,
+// which loads the server config in the same way as the server starts.
+func SetServerConfigLoader(loader func() (*ServerConfig, error)) {
+	serverConfigReloadMu.Lock()
+	defer serverConfigReloadMu.Unlock()
+	serverConfigLoader = loader
+}
+
+// ReloadGlobalServerConfig loads the server config again and stores it as the global server config,
+// source is the trigger of the reloading recorded in the log, such as a signal or an API.
+// An error is returned and nothing is changed if any item which isn't reloadable is changed.
+func ReloadGlobalServerConfig(source string) ([]ServerConfigChange, error) {
+	serverConfigReloadMu.Lock()
+	defer serverConfigReloadMu.Unlock()
+
+	if serverConfigLoader == nil {
+		return nil, cerror.ErrServerConfigReloadNotSupport.GenWithStackByArgs()
+	}
+	newCfg, err := serverConfigLoader()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	oldCfg := GetGlobalServerConfig()
+	// the data-dir not specified and the sort-dir are decided by the server when it starts.
+	if newCfg.DataDir == "" {
+		newCfg.DataDir = oldCfg.DataDir
+	}
+	newCfg.Sorter.SortDir = oldCfg.Sorter.SortDir
+	changes, err := diffServerConfig(oldCfg, newCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var immutable []string
+	for _, change := range changes {
+		if _, ok := reloadableServerConfigItems[change.Item]; !ok {
+			immutable = append(immutable, change.Item)
+		}
+	}
+	if len(immutable) > 0 {
+		log.Warn("reject to reload server config", zap.String("source", source), zap.Strings("immutable-items", immutable))
+		return nil, cerror.ErrServerConfigImmutable.GenWithStackByArgs(strings.Join(immutable, ", "))
+	}
+	if len(changes) == 0 {
+		log.Info("server config reloaded without changes", zap.String("source", source))
+		return changes, nil
+	}
+
+	if newCfg.LogLevel != oldCfg.LogLevel {
+		if err = logutil.SetLogLevel(newCfg.LogLevel); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	StoreGlobalServerConfig(newCfg)
+	for _, change := range changes {
+		log.Warn("server config changed by reloading", zap.String("source", source),
+			zap.String("item", change.Item), zap.Any("old", change.Old), zap.Any("new", change.New))
+	}
+	return changes, nil
+}
+
+// diffServerConfig returns the changed items from the old config to the new config, sorted by the items.
+// the items are named by their keys in the config file, such as `sorter.chunk-size-limit`.
+func diffServerConfig(oldCfg, newCfg *ServerConfig) ([]ServerConfigChange, error) {
+	oldItems, err := flattenServerConfig(oldCfg)
+	if err != nil {
+		return nil, err
+	}
+	newItems, err := flattenServerConfig(newCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []ServerConfigChange
+	for item, oldValue := range oldItems {
+		if newValue := newItems[item]; !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, ServerConfigChange{Item: item, Old: oldValue, New: newValue})
+		}
+	}
+	for item, newValue := range newItems {
+		if _, ok := oldItems[item]; !ok {
+			changes = append(changes, ServerConfigChange{Item: item, New: newValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Item < changes[j].Item })
+	return changes, nil
+}
+
+func flattenServerConfig(cfg *ServerConfig) (map[string]interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrEncodeFailed, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	// keep the numbers like the memory quotas exact.
+	decoder.UseNumber()
+	var m map[string]interface{}
+	if err = decoder.Decode(&m); err != nil {
+		return nil, cerror.WrapError(cerror.ErrDecodeFailed, err)
+	}
+
+	items := make(map[string]interface{})
+	var flatten func(prefix string, m map[string]interface{})
+	flatten = func(prefix string, m map[string]interface{}) {
+		for key, value := range m {
+			if sub, ok := value.(map[string]interface{}); ok && key != "labels" {
+				flatten(prefix+key+".", sub)
+				continue
+			}
+			items[prefix+key] = value
+		}
+	}
+	flatten("", m)
+	return items, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/pingcap/ticdc/pkg/logutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffServerConfig(t *testing.T) {
+	t.Parallel()
+	oldCfg := GetDefaultServerConfig()
+	newCfg := oldCfg.Clone()
+	changes, err := diffServerConfig(oldCfg, newCfg)
+	require.Nil(t, err)
+	require.Len(t, changes, 0)
+
+	newCfg.GcTTL = 3600
+	newCfg.Sorter.ChunkSizeLimit = 1 << 30
+	newCfg.Labels = map[string]string{"zone": "z1"}
+	changes, err = diffServerConfig(oldCfg, newCfg)
+	require.Nil(t, err)
+	require.Equal(t, []ServerConfigChange{
+		{Item: "gc-ttl", Old: json.Number("86400"), New: json.Number("3600")},
+		{Item: "labels", Old: nil, New: map[string]interface{}{"zone": "z1"}},
+		{Item: "sorter.chunk-size-limit", Old: json.Number("134217728"), New: json.Number("1073741824")},
+	}, changes)
+}
+
+func TestReloadGlobalServerConfig(t *testing.T) {
+	oldCfg := GetGlobalServerConfig()
+	defer func() {
+		StoreGlobalServerConfig(oldCfg)
+		SetServerConfigLoader(nil)
+		_ = logutil.SetLogLevel(oldCfg.LogLevel)
+	}()
+
+	SetServerConfigLoader(nil)
+	_, err := ReloadGlobalServerConfig("test")
+	require.True(t, cerror.ErrServerConfigReloadNotSupport.Equal(err))
+
+	cfg := GetDefaultServerConfig()
+	cfg.DataDir = "/tmp/cdc_data"
+	cfg.Sorter.SortDir = "/tmp/cdc_data/tmp/sorter"
+	StoreGlobalServerConfig(cfg)
+
+	var loaded *ServerConfig
+	SetServerConfigLoader(func() (*ServerConfig, error) {
+		return loaded.Clone(), nil
+	})
+
+	// the data-dir and the sort-dir decided by the server are not changed.
+	loaded = GetDefaultServerConfig()
+	changes, err := ReloadGlobalServerConfig("test")
+	require.Nil(t, err)
+	require.Len(t, changes, 0)
+
+	loaded.GcTTL = 3600
+	loaded.LogLevel = "warn"
+	changes, err = ReloadGlobalServerConfig("test")
+	require.Nil(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, "gc-ttl", changes[0].Item)
+	require.Equal(t, "log-level", changes[1].Item)
+	require.Equal(t, int64(3600), GetGlobalServerConfig().GcTTL)
+	require.Equal(t, "/tmp/cdc_data/tmp/sorter", GetGlobalServerConfig().Sorter.SortDir)
+
+	// nothing is changed if any item isn't reloadable.
+	loaded.GcTTL = 7200
+	loaded.Addr = "127.0.0.1:8301"
+	loaded.DataDir = "/tmp/another_data"
+	_, err = ReloadGlobalServerConfig("test")
+	require.True(t, cerror.ErrServerConfigImmutable.Equal(err))
+	require.Regexp(t, "addr, data-dir of the server config", err.Error())
+	require.Equal(t, int64(3600), GetGlobalServerConfig().GcTTL)
+}
@@ -13,10 +13,30 @@
 
 package config
 
+import (
+	"time"
+	"unicode/utf8"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
 // SinkConfig represents sink config for a changefeed
 type SinkConfig struct {
-	DispatchRules []*DispatchRule `toml:"dispatchers" json:"dispatchers"`
-	Protocol      string          `toml:"protocol" json:"protocol"`
+	DispatchRules []*DispatchRule  `toml:"dispatchers" json:"dispatchers"`
+	Protocol      string           `toml:"protocol" json:"protocol"`
+	AddColumn     *AddColumnConfig `toml:"add-column" json:"add-column,omitempty"`
+	// EmitIdentity attaches the upstream cluster ID, the changefeed ID and
+	// the capture address to the messages of the MQ sinks.
+	EmitIdentity bool `toml:"emit-identity" json:"emit-identity,omitempty"`
+	// EmitTableStarted emits a message to all the partitions of the MQ sinks when
+	// a table is added to the changefeed, all the events of the table with a
+	// commit ts greater than the ts in the message are sent after it. It is not
+	// emitted again when the table is moved or restarted.
+	EmitTableStarted bool `toml:"emit-table-started" json:"emit-table-started,omitempty"`
+	// Failover is the config of failing over to the standby sink.
+	Failover *FailoverConfig `toml:"failover" json:"failover,omitempty"`
+	// CSV is the config of the csv protocol.
+	CSV *CSVConfig `toml:"csv" json:"csv,omitempty"`
 }
 
 // DispatchRule represents partition rule for a table
@@ -24,3 +44,138 @@ type DispatchRule struct {
 	Matcher    []string `toml:"matcher" json:"matcher"`
 	Dispatcher string   `toml:"dispatcher" json:"dispatcher"`
 }
+
+// AddColumnConfig represents how the MQ sinks inform consumers that materialize
+// full rows about columns added upstream.
+type AddColumnConfig struct {
+	// EmitDefaultValue attaches the added columns and their default values
+	// to the schema change message of an ADD COLUMN DDL.
+	EmitDefaultValue bool `toml:"emit-default-value" json:"emit-default-value"`
+	// BackfillWindow is the number of recently emitted rows kept for each table.
+	// When a column is added, these rows are emitted again as update events
+	// carrying the default value of the added column. 0 disables the backfill.
+	BackfillWindow int `toml:"backfill-window" json:"backfill-window"`
+}
+
+// IsBackfillEnabled returns whether the default value backfill is enabled.
+func (c *AddColumnConfig) IsBackfillEnabled() bool {
+	return c != nil && c.BackfillWindow > 0
+}
+
+// IsEmitDefaultValueEnabled returns whether the default values of added
+// columns are attached to schema change messages.
+func (c *AddColumnConfig) IsEmitDefaultValueEnabled() bool {
+	return c != nil && c.EmitDefaultValue
+}
+
+// The default values of the failover config
+const (
+	DefaultFailoverThreshold     = 300
+	DefaultFailoverProbeInterval = 10
+)
+
+// FailoverConfig represents how a changefeed fails over to the standby sink.
+type FailoverConfig struct {
+	// Threshold is how long in seconds the sink keeps unhealthy before the
+	// changefeed fails over to the standby sink, 0 means the default value.
+	Threshold int64 `toml:"threshold" json:"threshold"`
+	// ProbeInterval is the interval in seconds of probing the health of the
+	// sink, 0 means the default value.
+	ProbeInterval int64 `toml:"probe-interval" json:"probe-interval"`
+}
+
+// GetThreshold returns the failover threshold.
+func (c *FailoverConfig) GetThreshold() time.Duration {
+	if c == nil || c.Threshold == 0 {
+		return DefaultFailoverThreshold * time.Second
+	}
+	return time.Duration(c.Threshold) * time.Second
+}
+
+// GetProbeInterval returns the interval of probing the sink.
+func (c *FailoverConfig) GetProbeInterval() time.Duration {
+	if c == nil || c.ProbeInterval == 0 {
+		return DefaultFailoverProbeInterval * time.Second
+	}
+	return time.Duration(c.ProbeInterval) * time.Second
+}
+
+// Validate validates the failover config.
+func (c *FailoverConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.Threshold < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("sink.failover.threshold should not be negative")
+	}
+	if c.ProbeInterval < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("sink.failover.probe-interval should not be negative")
+	}
+	return nil
+}
+
+// The default values of the csv config
+const (
+	DefaultCSVDelimiter  = ","
+	DefaultCSVQuote      = "\""
+	DefaultCSVNullString = "\\N"
+)
+
+// CSVConfig represents how the rows are encoded by the csv protocol.
+type CSVConfig struct {
+	// Delimiter is the character separating the fields, empty means the
+	// default value.
+	Delimiter string `toml:"delimiter" json:"delimiter"`
+	// Quote is the character quoting the string fields, empty means the
+	// default value.
+	Quote string `toml:"quote" json:"quote"`
+	// NullString is how the NULL values are represented, empty means the
+	// default value.
+	NullString string `toml:"null" json:"null"`
+	// IncludeCommitTs adds the commit ts of the rows as a field.
+	IncludeCommitTs bool `toml:"include-commit-ts" json:"include-commit-ts"`
+}
+
+// GetDelimiter returns the delimiter of the fields.
+func (c *CSVConfig) GetDelimiter() string {
+	if c == nil || c.Delimiter == "" {
+		return DefaultCSVDelimiter
+	}
+	return c.Delimiter
+}
+
+// GetQuote returns the quote of the string fields.
+func (c *CSVConfig) GetQuote() string {
+	if c == nil || c.Quote == "" {
+		return DefaultCSVQuote
+	}
+	return c.Quote
+}
+
+// GetNullString returns the representation of the NULL values.
+func (c *CSVConfig) GetNullString() string {
+	if c == nil || c.NullString == "" {
+		return DefaultCSVNullString
+	}
+	return c.NullString
+}
+
+// Validate validates the csv config.
+func (c *CSVConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	delimiter, quote := c.GetDelimiter(), c.GetQuote()
+	if utf8.RuneCountInString(delimiter) != 1 || delimiter == "\r" || delimiter == "\n" {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"sink.csv.delimiter should be a single character other than the line breaks")
+	}
+	if utf8.RuneCountInString(quote) != 1 || quote == "\r" || quote == "\n" {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"sink.csv.quote should be a single character other than the line breaks")
+	}
+	if delimiter == quote {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("sink.csv.delimiter and sink.csv.quote should be different")
+	}
+	return nil
+}
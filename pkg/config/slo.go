@@ -0,0 +1,52 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"net/url"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// SLOConfig represents the replication lag SLO config of a changefeed
+type SLOConfig struct {
+	// MaxCheckpointLag is the maximum checkpoint lag in seconds, the SLO is
+	// violated if the checkpoint lag exceeds it. 0 means the SLO is disabled.
+	MaxCheckpointLag int64 `toml:"max-checkpoint-lag" json:"max-checkpoint-lag"`
+	// WebhookURL is the URL notified by POST requests when the SLO is
+	// violated or recovered, no notification is sent if it is empty.
+	WebhookURL string `toml:"webhook-url" json:"webhook-url"`
+}
+
+// IsEnabled returns whether the SLO is enabled.
+func (c *SLOConfig) IsEnabled() bool {
+	return c != nil && c.MaxCheckpointLag > 0
+}
+
+// Validate validates the SLO config.
+func (c *SLOConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.MaxCheckpointLag < 0 {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("slo.max-checkpoint-lag should not be negative")
+	}
+	if c.WebhookURL != "" {
+		u, err := url.Parse(c.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("slo.webhook-url should be a http or https URL")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	filter "github.com/pingcap/tidb-tools/pkg/table-filter"
+)
+
+// DefaultLowLatencyMemoryQuota is the default memory quota of the tables using
+// the low-latency sort engine.
+const DefaultLowLatencyMemoryQuota = 16 * 1024 * 1024 // 16MB
+
+// SortEngineConfig represents the per-table sort engine config of a changefeed.
+// Tables not matched by any rule use the sort engine of the changefeed.
+type SortEngineConfig struct {
+	Rules []*SortEngineRule `toml:"rules" json:"rules"`
+	// LowLatencyMemoryQuota is the maximum size in bytes of the unsorted events
+	// of each table using the low-latency sort engine, the table falls back to
+	// the unified sorter once it is exceeded. 0 means the default value.
+	LowLatencyMemoryQuota uint64 `toml:"low-latency-memory-quota" json:"low-latency-memory-quota"`
+}
+
+// GetLowLatencyMemoryQuota returns the memory quota of the tables using the
+// low-latency sort engine.
+func (c *SortEngineConfig) GetLowLatencyMemoryQuota() uint64 {
+	if c == nil || c.LowLatencyMemoryQuota == 0 {
+		return DefaultLowLatencyMemoryQuota
+	}
+	return c.LowLatencyMemoryQuota
+}
+
+// SortEngineRule specifies the sort engine of the tables matched
+type SortEngineRule struct {
+	Matcher []string `toml:"matcher" json:"matcher"`
+	Engine  string   `toml:"engine" json:"engine"`
+}
+
+// Validate validates the sort engine rules.
+func (c *SortEngineConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, rule := range c.Rules {
+		// Keep the engines in sync with model.SortEngine.
+		switch rule.Engine {
+		case "memory", "unified", "file", "low-latency":
+		default:
+			return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs("unknown sort engine " + rule.Engine)
+		}
+		if _, err := filter.Parse(rule.Matcher); err != nil {
+			return cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+		}
+	}
+	return nil
+}
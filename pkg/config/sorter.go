@@ -13,7 +13,10 @@
 
 package config
 
-import cerror "github.com/pingcap/ticdc/pkg/errors"
+import (
+	"github.com/pingcap/ticdc/pkg/compression"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
 
 // SorterConfig represents sorter config for a changefeed
 type SorterConfig struct {
@@ -29,6 +32,18 @@ type SorterConfig struct {
 	NumWorkerPoolGoroutine int `toml:"num-workerpool-goroutine" json:"num-workerpool-goroutine"`
 	// the directory used to store the temporary files generated by the sorter
 	SortDir string `toml:"sort-dir" json:"sort-dir"`
+	// the compression of the temporary files generated by the unified sorter,
+	// in the format of "name[:level]", such as "lz4" or "zstd:3"
+	FileCompression string `toml:"file-compression" json:"file-compression"`
+	// the size of the segment files shared by the tables to store the sorted
+	// events, 0 means using a temporary file per flush of every table
+	SegmentSize uint64 `toml:"segment-size" json:"segment-size"`
+	// the duration the sorter waits for the disk space of sort-dir to be freed
+	// when the disk is full, before an error is reported
+	DiskFullTimeout TomlDuration `toml:"disk-full-timeout" json:"disk-full-timeout"`
+	// the memory the sorter can use to keep the small flushes in memory when
+	// the disk of sort-dir is full, 0 disables the fallback
+	DiskFullMemoryQuota uint64 `toml:"disk-full-memory-quota" json:"disk-full-memory-quota"`
 
 	// EnableLevelDB enables leveldb sorter.
 	//
@@ -114,6 +129,15 @@ func (c *SorterConfig) ValidateAndAdjust() error {
 	if c.MaxMemoryPressure < 0 || c.MaxMemoryPressure > 100 {
 		return cerror.ErrIllegalSorterParameter.GenWithStackByArgs("max-memory-percentage should be a percentage")
 	}
+	if c.SegmentSize != 0 && c.SegmentSize < 1*1024*1024 {
+		return cerror.ErrIllegalSorterParameter.GenWithStackByArgs("segment-size should be 0 or at least 1MB")
+	}
+	if c.DiskFullTimeout < 0 {
+		return cerror.ErrIllegalSorterParameter.GenWithStackByArgs("disk-full-timeout should not be negative")
+	}
+	if err := compression.Validate(c.FileCompression); err != nil {
+		return cerror.ErrIllegalSorterParameter.GenWithStackByArgs("file-compression is invalid: " + err.Error())
+	}
 	if c.LevelDB.Compression != "none" && c.LevelDB.Compression != "snappy" {
 		return cerror.ErrIllegalSorterParameter.GenWithStackByArgs("sorter.leveldb.compression must be \"none\" or \"snappy\"")
 	}
@@ -0,0 +1,53 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// LoadTimeZone returns the time zone of the changefeed, which renders the
+// TIMESTAMP values. It returns nil if the time zone is not specified, and the
+// time zone of the server is used.
+func (c *ReplicaConfig) LoadTimeZone() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return nil, nil
+	}
+	tz, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrLoadTimezone, err)
+	}
+	return tz, nil
+}
+
+func validateTimeZone(name string) error {
+	if name == "" {
+		return nil
+	}
+	// the local time zone is not the same on all the servers, and it can't be
+	// set as the time zone of the downstream sessions by the name.
+	if strings.EqualFold(name, "local") {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			"time-zone should be the name of a time zone, such as Asia/Shanghai or UTC, got Local")
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return cerror.ErrInvalidReplicaConfig.GenWithStackByArgs(
+			fmt.Sprintf("time-zone %s is not a valid time zone", name))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+)
+
+// The exporters of spans
+const (
+	// TracingExporterJaeger sends spans to the jaeger collector.
+	TracingExporterJaeger = "jaeger"
+	// TracingExporterOTLP sends spans to the OTLP/HTTP endpoint in the JSON
+	// encoding.
+	TracingExporterOTLP = "otlp"
+)
+
+// TracingConfig represents config for OpenTelemetry tracing
+type TracingConfig struct {
+	// Exporter is the exporter of spans, "jaeger" or "otlp", tracing is
+	// disabled if it is empty.
+	Exporter string `toml:"exporter" json:"exporter"`
+	// Endpoint is the endpoint of the collector, e.g. "http://127.0.0.1:14268/api/traces"
+	// for jaeger and "http://127.0.0.1:4318/v1/traces" for OTLP.
+	Endpoint string `toml:"endpoint" json:"endpoint"`
+	// SampleRatio is the ratio of traces to be sampled.
+	SampleRatio float64 `toml:"sample-ratio" json:"sample-ratio"`
+}
+
+// IsEnabled returns whether tracing is enabled.
+func (c *TracingConfig) IsEnabled() bool {
+	return c != nil && c.Exporter != ""
+}
+
+// ValidateAndAdjust validates the tracing configuration.
+func (c *TracingConfig) ValidateAndAdjust() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	if c.Exporter != TracingExporterJaeger && c.Exporter != TracingExporterOTLP {
+		return cerror.ErrInvalidServerOption.GenWithStack("unknown tracing exporter %s", c.Exporter)
+	}
+	if c.Endpoint == "" {
+		return cerror.ErrInvalidServerOption.GenWithStack("tracing endpoint should not be empty")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return cerror.ErrInvalidServerOption.GenWithStack("tracing sample-ratio should be in range [0, 1]")
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"go.uber.org/zap"
+)
+
+// maxReportedViolations is the maximum number of the violations kept in the
+// report, the others are only counted.
+const maxReportedViolations = 100
+
+// Report summarizes the events checked by the Checker.
+type Report struct {
+	Rows           uint64
+	DDLs           uint64
+	ResolvedEvents uint64
+	// Duplicates is the number of the events received more than once, which
+	// is allowed by the at-least-once delivery.
+	Duplicates uint64
+	// ViolationCount is the number of the violations of the invariants, and
+	// Violations describes the first ones.
+	ViolationCount uint64
+	Violations     []string
+}
+
+// String implements fmt.Stringer
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rows: %d, ddls: %d, resolved events: %d, duplicates: %d, violations: %d",
+		r.Rows, r.DDLs, r.ResolvedEvents, r.Duplicates, r.ViolationCount)
+	for _, v := range r.Violations {
+		fmt.Fprintf(&b, "\n  %s", v)
+	}
+	if r.ViolationCount > uint64(len(r.Violations)) {
+		fmt.Fprintf(&b, "\n  ... and %d more", r.ViolationCount-uint64(len(r.Violations)))
+	}
+	return b.String()
+}
+
+// Checker checks the invariants of the events consumed from the partitions:
+//  1. a row never arrives after a resolved event covering it in the same
+//     partition, unless it is a duplicate of a received row;
+//  2. the rows of the same handle key arrive in the order of the commit ts,
+//     unless it is a duplicate of a received row;
+//  3. the DDLs arrive in the order of the commit ts, unless it is a duplicate
+//     of a received DDL.
+//
+// A duplicate is allowed by the at-least-once delivery, for example the
+// events are sent again after the changefeed is restarted. The Checker keeps
+// a fingerprint of every event to detect the duplicates, so it is meant for
+// validation runs rather than long running consumers.
+type Checker struct {
+	mu           sync.Mutex
+	resolvedTs   map[int32]uint64
+	keyCommitTs  map[string]uint64
+	maxDDLTs     uint64
+	fingerprints map[uint64]struct{}
+	report       Report
+}
+
+// NewChecker creates a new Checker.
+func NewChecker() *Checker {
+	return &Checker{
+		resolvedTs:   make(map[int32]uint64),
+		keyCommitTs:  make(map[string]uint64),
+		fingerprints: make(map[uint64]struct{}),
+	}
+}
+
+// CheckRow checks the row received from the partition, it returns whether
+// the row is a duplicate.
+func (c *Checker) CheckRow(partition int32, row *model.RowChangedEvent) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.isDuplicate(rowFingerprint(row)) {
+		c.report.Duplicates++
+		return true
+	}
+	c.report.Rows++
+	handleKey := handleKeyOf(row)
+	if resolvedTs := c.resolvedTs[partition]; row.CommitTs <= resolvedTs {
+		c.violateLocked("row %s of %s with commit ts %d arrives after resolved ts %d in partition %d",
+			handleKey, row.Table, row.CommitTs, resolvedTs, partition)
+		return false
+	}
+	if handleKey == "" {
+		return false
+	}
+	key := row.Table.String() + handleKey
+	if commitTs, ok := c.keyCommitTs[key]; ok && row.CommitTs < commitTs {
+		c.violateLocked("row %s of %s with commit ts %d arrives after the row with commit ts %d",
+			handleKey, row.Table, row.CommitTs, commitTs)
+		return false
+	}
+	c.keyCommitTs[key] = row.CommitTs
+	return false
+}
+
+// CheckDDL checks the DDL, it returns whether the DDL is a duplicate. A DDL
+// is sent to all the partitions, so its copies are not counted as duplicates.
+func (c *Checker) CheckDDL(ddl *model.DDLEvent) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.isDuplicate(ddlFingerprint(ddl)) {
+		return true
+	}
+	c.report.DDLs++
+	if ddl.CommitTs < c.maxDDLTs {
+		c.violateLocked("ddl %s with commit ts %d arrives after the ddl with commit ts %d",
+			ddl.Query, ddl.CommitTs, c.maxDDLTs)
+		return false
+	}
+	c.maxDDLTs = ddl.CommitTs
+	return false
+}
+
+// CheckResolved checks the resolved event received from the partition. The
+// resolved ts could regress after the changefeed is restarted, which is not a
+// violation, and the largest one is kept.
+func (c *Checker) CheckResolved(partition int32, ts uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.report.ResolvedEvents++
+	if ts > c.resolvedTs[partition] {
+		c.resolvedTs[partition] = ts
+	}
+}
+
+// Violate records a violation detected outside the Checker.
+func (c *Checker) Violate(format string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.violateLocked(format, args...)
+}
+
+// Report returns the summary of the checked events.
+func (c *Checker) Report() *Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	report := c.report
+	report.Violations = append([]string(nil), c.report.Violations...)
+	return &report
+}
+
+func (c *Checker) violateLocked(format string, args ...interface{}) {
+	violation := fmt.Sprintf(format, args...)
+	log.Warn("consumer invariant violated", zap.String("violation", violation))
+	c.report.ViolationCount++
+	if len(c.report.Violations) < maxReportedViolations {
+		c.report.Violations = append(c.report.Violations, violation)
+	}
+}
+
+func (c *Checker) isDuplicate(fingerprint uint64) bool {
+	if _, ok := c.fingerprints[fingerprint]; ok {
+		return true
+	}
+	c.fingerprints[fingerprint] = struct{}{}
+	return false
+}
+
+// handleKeyOf returns the values of the handle key columns of the row, or an
+// empty string if the row has no handle key.
+func handleKeyOf(row *model.RowChangedEvent) string {
+	columns := row.Columns
+	if row.IsDelete() {
+		columns = row.PreColumns
+	}
+	var b strings.Builder
+	for _, col := range columns {
+		if col != nil && col.Flag.IsHandleKey() {
+			fmt.Fprintf(&b, "[%s=%v]", col.Name, col.Value)
+		}
+	}
+	return b.String()
+}
+
+func rowFingerprint(row *model.RowChangedEvent) uint64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "row|%s|%d", row.Table, row.CommitTs)
+	for _, columns := range [][]*model.Column{row.PreColumns, row.Columns} {
+		fmt.Fprint(h, "|")
+		for _, col := range columns {
+			if col != nil {
+				fmt.Fprintf(h, "[%s=%v]", col.Name, col.Value)
+			}
+		}
+	}
+	return h.Sum64()
+}
+
+func ddlFingerprint(ddl *model.DDLEvent) uint64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "ddl|%d|%s", ddl.CommitTs, ddl.Query)
+	return h.Sum64()
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newRow(commitTs uint64, id int64, name string) *model.RowChangedEvent {
+	return &model.RowChangedEvent{
+		CommitTs: commitTs,
+		Table:    &model.TableName{Schema: "test", Table: "t"},
+		Columns: []*model.Column{
+			{Name: "id", Flag: model.HandleKeyFlag, Value: id},
+			{Name: "name", Value: name},
+		},
+	}
+}
+
+func TestCheckerRows(t *testing.T) {
+	c := NewChecker()
+	require.False(t, c.CheckRow(0, newRow(100, 1, "a")))
+	require.False(t, c.CheckRow(0, newRow(110, 1, "b")))
+	require.False(t, c.CheckRow(1, newRow(105, 2, "a")))
+	c.CheckResolved(0, 110)
+	c.CheckResolved(1, 120)
+	// the resolved ts regresses after the changefeed is restarted
+	c.CheckResolved(0, 90)
+
+	// the duplicates are allowed
+	require.True(t, c.CheckRow(0, newRow(100, 1, "a")))
+	require.True(t, c.CheckRow(0, newRow(110, 1, "b")))
+	report := c.Report()
+	require.Equal(t, uint64(3), report.Rows)
+	require.Equal(t, uint64(2), report.Duplicates)
+	require.Equal(t, uint64(3), report.ResolvedEvents)
+	require.Zero(t, report.ViolationCount)
+
+	// a new row arrives after the resolved event covering it
+	require.False(t, c.CheckRow(0, newRow(108, 3, "a")))
+	// a row arrives after the newer row of the same key
+	require.False(t, c.CheckRow(2, newRow(105, 1, "c")))
+	report = c.Report()
+	require.Equal(t, uint64(2), report.ViolationCount)
+	require.Len(t, report.Violations, 2)
+	require.Contains(t, report.Violations[0], "arrives after resolved ts 110 in partition 0")
+	require.Contains(t, report.Violations[1], "arrives after the row with commit ts 110")
+	require.Contains(t, report.String(), "violations: 2")
+}
+
+func TestCheckerDDLs(t *testing.T) {
+	c := NewChecker()
+	ddl1 := &model.DDLEvent{CommitTs: 100, Query: "create table t1(id int primary key)"}
+	ddl2 := &model.DDLEvent{CommitTs: 200, Query: "create table t2(id int primary key)"}
+	// the ddls are sent to all the partitions
+	for i := 0; i < 3; i++ {
+		c.CheckDDL(ddl1)
+		c.CheckDDL(ddl2)
+	}
+	report := c.Report()
+	require.Equal(t, uint64(2), report.DDLs)
+	require.Zero(t, report.Duplicates)
+	require.Zero(t, report.ViolationCount)
+
+	require.False(t, c.CheckDDL(&model.DDLEvent{CommitTs: 150, Query: "create table t3(id int primary key)"}))
+	require.Equal(t, uint64(1), c.Report().ViolationCount)
+}
+
+func TestCheckerReportLimit(t *testing.T) {
+	c := NewChecker()
+	for i := 0; i < maxReportedViolations+10; i++ {
+		c.Violate("violation %d", i)
+	}
+	report := c.Report()
+	require.Equal(t, uint64(maxReportedViolations+10), report.ViolationCount)
+	require.Len(t, report.Violations, maxReportedViolations)
+	require.Contains(t, report.String(), "... and 10 more")
+}
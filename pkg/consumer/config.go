@@ -0,0 +1,177 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/sink/codec"
+	"github.com/pingcap/ticdc/pkg/security"
+)
+
+// Config is the configuration of the Consumer.
+type Config struct {
+	KafkaAddrs           []string
+	KafkaTopic           string
+	KafkaGroupID         string
+	KafkaVersion         string
+	KafkaPartitionNum    int32
+	KafkaMaxMessageBytes int
+	KafkaMaxBatchSize    int
+	Credential           *security.Credential
+
+	Protocol            codec.Protocol
+	EnableTiDBExtension bool
+
+	// DownstreamURI is the sink uri which the events are applied to, the
+	// events are only checked if it is empty.
+	DownstreamURI string
+	Timezone      string
+	// IdleTimeout stops the consumer after no message is received for the
+	// duration, 0 means the consumer runs until it is canceled.
+	IdleTimeout time.Duration
+}
+
+// NewConfig creates a Config with the default values.
+func NewConfig() *Config {
+	return &Config{
+		KafkaGroupID:         fmt.Sprintf("ticdc_kafka_consumer_%s", uuid.New().String()),
+		KafkaVersion:         "2.4.0",
+		KafkaMaxMessageBytes: math.MaxInt64,
+		KafkaMaxBatchSize:    math.MaxInt64,
+		Protocol:             codec.ProtocolDefault,
+		Timezone:             "System",
+	}
+}
+
+// ParseUpstreamURI parses the kafka uri of the topic to consume, such as
+// kafka://127.0.0.1:9092/topic?protocol=canal-json&enable-tidb-extension=true
+func (c *Config) ParseUpstreamURI(uri string) error {
+	upstreamURI, err := url.Parse(uri)
+	if err != nil {
+		return errors.Annotate(err, "invalid upstream-uri")
+	}
+	if strings.ToLower(upstreamURI.Scheme) != "kafka" {
+		return errors.Errorf("invalid upstream-uri scheme, the scheme of upstream-uri must be `kafka`, upstream-uri: %s", uri)
+	}
+	c.KafkaTopic = strings.TrimFunc(upstreamURI.Path, func(r rune) bool {
+		return r == '/'
+	})
+	c.KafkaAddrs = strings.Split(upstreamURI.Host, ",")
+
+	query := upstreamURI.Query()
+	if s := query.Get("version"); s != "" {
+		c.KafkaVersion = s
+	}
+	if s := query.Get("consumer-group-id"); s != "" {
+		c.KafkaGroupID = s
+	}
+	if s := query.Get("partition-num"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return errors.Errorf("invalid partition-num of upstream-uri: %s", s)
+		}
+		c.KafkaPartitionNum = int32(n)
+	}
+	if s := query.Get("max-message-bytes"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return errors.Errorf("invalid max-message-bytes of upstream-uri: %s", s)
+		}
+		c.KafkaMaxMessageBytes = n
+	}
+	if s := query.Get("max-batch-size"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return errors.Errorf("invalid max-batch-size of upstream-uri: %s", s)
+		}
+		c.KafkaMaxBatchSize = n
+	}
+	if s := query.Get("protocol"); s != "" {
+		switch strings.ToLower(s) {
+		case "default", "craft", "canal-json", "maxwell":
+			c.Protocol.FromString(s)
+		default:
+			return errors.Errorf("protocol %s is not supported by the consumer", s)
+		}
+	}
+	if s := query.Get("enable-tidb-extension"); s != "" {
+		c.EnableTiDBExtension, err = strconv.ParseBool(s)
+		if err != nil {
+			return errors.Errorf("invalid enable-tidb-extension of upstream-uri: %s", s)
+		}
+	}
+	return nil
+}
+
+// carriesResolvedEvents returns whether the messages of the protocol carry
+// the resolved events, which are required to apply the events to the
+// downstream transactionally.
+func (c *Config) carriesResolvedEvents() bool {
+	switch c.Protocol {
+	case codec.ProtocolMaxwell:
+		return false
+	case codec.ProtocolCanalJSON:
+		return c.EnableTiDBExtension
+	default:
+		return true
+	}
+}
+
+// Validate validates the config.
+func (c *Config) Validate() error {
+	if c.KafkaTopic == "" {
+		return errors.New("the topic of upstream-uri is not specified")
+	}
+	if c.DownstreamURI != "" && !c.carriesResolvedEvents() {
+		return errors.New("the messages of the protocol do not carry resolved events, " +
+			"so the events can only be checked without the downstream-uri")
+	}
+	return nil
+}
+
+func (c *Config) newSaramaConfig() (*sarama.Config, error) {
+	config := sarama.NewConfig()
+
+	version, err := sarama.ParseKafkaVersion(c.KafkaVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	config.ClientID = "ticdc_kafka_sarama_consumer"
+	config.Version = version
+
+	config.Metadata.Retry.Max = 10000
+	config.Metadata.Retry.Backoff = 500 * time.Millisecond
+	config.Consumer.Retry.Backoff = 500 * time.Millisecond
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	if c.Credential != nil && len(c.Credential.CAPath) != 0 {
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config, err = c.Credential.ToTLSConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	return config, nil
+}
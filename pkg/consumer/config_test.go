@@ -0,0 +1,47 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/sink/codec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigParseUpstreamURI(t *testing.T) {
+	cfg := NewConfig()
+	require.Nil(t, cfg.ParseUpstreamURI("kafka://127.0.0.1:9092,127.0.0.1:9093/topic?"+
+		"protocol=canal-json&enable-tidb-extension=true&partition-num=4&max-message-bytes=1024&version=2.6.0"))
+	require.Equal(t, []string{"127.0.0.1:9092", "127.0.0.1:9093"}, cfg.KafkaAddrs)
+	require.Equal(t, "topic", cfg.KafkaTopic)
+	require.Equal(t, codec.ProtocolCanalJSON, cfg.Protocol)
+	require.True(t, cfg.EnableTiDBExtension)
+	require.Equal(t, int32(4), cfg.KafkaPartitionNum)
+	require.Equal(t, 1024, cfg.KafkaMaxMessageBytes)
+	require.Equal(t, "2.6.0", cfg.KafkaVersion)
+	cfg.DownstreamURI = "mysql://root@127.0.0.1:3306/"
+	require.Nil(t, cfg.Validate())
+
+	// the events of maxwell can only be checked
+	cfg = NewConfig()
+	require.Nil(t, cfg.ParseUpstreamURI("kafka://127.0.0.1:9092/topic?protocol=maxwell"))
+	require.Nil(t, cfg.Validate())
+	cfg.DownstreamURI = "mysql://root@127.0.0.1:3306/"
+	require.Regexp(t, ".*do not carry resolved events.*", cfg.Validate())
+
+	require.Regexp(t, ".*protocol avro is not supported.*", NewConfig().ParseUpstreamURI("kafka://127.0.0.1:9092/topic?protocol=avro"))
+	require.Regexp(t, ".*scheme of upstream-uri must be `kafka`.*", NewConfig().ParseUpstreamURI("pulsar://127.0.0.1:6650/topic"))
+	require.Regexp(t, ".*invalid partition-num.*", NewConfig().ParseUpstreamURI("kafka://127.0.0.1:9092/topic?partition-num=x"))
+}
@@ -0,0 +1,527 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sink"
+	"github.com/pingcap/ticdc/cdc/sink/codec"
+	"github.com/pingcap/ticdc/pkg/config"
+	cdcfilter "github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/quotes"
+	"github.com/pingcap/ticdc/pkg/util"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+type partitionSink struct {
+	sink.Sink
+	resolvedTs uint64
+}
+
+// Consumer consumes the messages sent by TiCDC to a Kafka topic, checks the
+// invariants of the events by the Checker, and applies the events to the
+// downstream if the downstream uri is specified.
+type Consumer struct {
+	cfg     *Config
+	checker *Checker
+
+	ddlList          []*model.DDLEvent
+	maxDDLReceivedTs uint64
+	ddlListMu        sync.Mutex
+
+	// sinks are nil if the events are not applied to the downstream
+	sinks   []*partitionSink
+	sinksMu sync.Mutex
+
+	ddlSink              sink.Sink
+	fakeTableIDGenerator *fakeTableIDGenerator
+
+	globalResolvedTs uint64
+	// lastMessageTime is the unix nano time of the last received message
+	lastMessageTime int64
+}
+
+// New creates a new Consumer.
+func New(cfg *Config) (*Consumer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Consumer{
+		cfg:     cfg,
+		checker: NewChecker(),
+		fakeTableIDGenerator: &fakeTableIDGenerator{
+			tableIDs: make(map[string]int64),
+		},
+	}, nil
+}
+
+// Report returns the summary of the consumed events.
+func (c *Consumer) Report() *Report {
+	return c.checker.Report()
+}
+
+// Run consumes the topic until the context is done or no message is received
+// within the idle timeout.
+func (c *Consumer) Run(ctx context.Context) error {
+	saramaConfig, err := c.cfg.newSaramaConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := waitTopicCreated(ctx, c.cfg.KafkaAddrs, c.cfg.KafkaTopic, saramaConfig); err != nil {
+		return errors.Trace(err)
+	}
+	if c.cfg.KafkaPartitionNum == 0 {
+		c.cfg.KafkaPartitionNum, err = getPartitionNum(c.cfg.KafkaAddrs, c.cfg.KafkaTopic, saramaConfig)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if c.cfg.DownstreamURI != "" {
+		if err := c.initSinks(ctx, cancel); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	client, err := sarama.NewConsumerGroup(c.cfg.KafkaAddrs, c.cfg.KafkaGroupID, saramaConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	atomic.StoreInt64(&c.lastMessageTime, time.Now().UnixNano())
+	errg, ctx := errgroup.WithContext(ctx)
+	errg.Go(func() error {
+		for {
+			// `Consume` should be called inside an infinite loop, when a
+			// server-side rebalance happens, the consumer session will need to be
+			// recreated to get the new claims
+			if err := client.Consume(ctx, []string{c.cfg.KafkaTopic}, c); err != nil {
+				return errors.Trace(err)
+			}
+			if ctx.Err() != nil {
+				return nil
+			}
+		}
+	})
+	if c.sinks != nil {
+		errg.Go(func() error {
+			return c.apply(ctx)
+		})
+	}
+	if c.cfg.IdleTimeout > 0 {
+		errg.Go(func() error {
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case now := <-ticker.C:
+					last := time.Unix(0, atomic.LoadInt64(&c.lastMessageTime))
+					if now.Sub(last) >= c.cfg.IdleTimeout {
+						log.Info("no message is received within the idle timeout, stop consuming",
+							zap.Duration("idleTimeout", c.cfg.IdleTimeout))
+						cancel()
+						return nil
+					}
+				}
+			}
+		})
+	}
+	err = errg.Wait()
+	if errors.Cause(err) == context.Canceled {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (c *Consumer) initSinks(ctx context.Context, cancel context.CancelFunc) error {
+	// TODO support filter in downstream sink
+	tz, err := util.GetTimezone(c.cfg.Timezone)
+	if err != nil {
+		return errors.Annotate(err, "can not load timezone")
+	}
+	ctx = util.PutTimezoneInCtx(ctx, tz)
+	filter, err := cdcfilter.NewFilter(config.GetDefaultReplicaConfig())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	errCh := make(chan error, 1)
+	opts := map[string]string{}
+	c.sinks = make([]*partitionSink, c.cfg.KafkaPartitionNum)
+	for i := range c.sinks {
+		s, err := sink.New(ctx, "kafka-consumer", c.cfg.DownstreamURI, filter, config.GetDefaultReplicaConfig(), opts, errCh)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.sinks[i] = &partitionSink{Sink: s}
+	}
+	c.ddlSink, err = sink.New(ctx, "kafka-consumer", c.cfg.DownstreamURI, filter, config.GetDefaultReplicaConfig(), opts, errCh)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	go func() {
+		err := <-errCh
+		if errors.Cause(err) != context.Canceled {
+			log.Error("error on running consumer", zap.Error(err))
+		} else {
+			log.Info("consumer exited")
+		}
+		cancel()
+	}()
+	return nil
+}
+
+func (c *Consumer) newDecoder(key, value []byte) (codec.EventBatchDecoder, error) {
+	switch c.cfg.Protocol {
+	case codec.ProtocolCraft:
+		return codec.NewCraftEventBatchDecoder(value)
+	case codec.ProtocolCanalJSON:
+		return codec.NewCanalFlatEventBatchDecoder(value, c.cfg.EnableTiDBExtension), nil
+	case codec.ProtocolMaxwell:
+		return codec.NewMaxwellEventBatchDecoder(key, value)
+	default:
+		return codec.NewJSONEventBatchDecoder(key, value)
+	}
+}
+
+// Setup is run at the beginning of a new session, before ConsumeClaim
+func (c *Consumer) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// Cleanup is run at the end of a session, once all ConsumeClaim goroutines have exited
+func (c *Consumer) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim must start a consumer loop of ConsumerGroupClaim's Messages().
+func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	ctx := session.Context()
+	partition := claim.Partition()
+	var sink *partitionSink
+	if c.sinks != nil {
+		c.sinksMu.Lock()
+		sink = c.sinks[partition]
+		c.sinksMu.Unlock()
+	}
+	for message := range claim.Messages() {
+		atomic.StoreInt64(&c.lastMessageTime, time.Now().UnixNano())
+		log.Debug("Message claimed", zap.Int32("partition", message.Partition), zap.ByteString("key", message.Key), zap.ByteString("value", message.Value))
+		if err := c.consumeMessage(ctx, partition, sink, message); err != nil {
+			return errors.Trace(err)
+		}
+		session.MarkMessage(message, "")
+	}
+	return nil
+}
+
+func (c *Consumer) consumeMessage(ctx context.Context, partition int32, sink *partitionSink, message *sarama.ConsumerMessage) error {
+	batchDecoder, err := c.newDecoder(message.Key, message.Value)
+	if err != nil {
+		return errors.Annotatef(err, "decode message failed, partition: %d, offset: %d", partition, message.Offset)
+	}
+
+	counter := 0
+	for {
+		tp, hasNext, err := batchDecoder.HasNext()
+		if err != nil {
+			return errors.Annotatef(err, "decode message failed, partition: %d, offset: %d", partition, message.Offset)
+		}
+		if !hasNext {
+			break
+		}
+
+		counter++
+		// If the message containing only one event exceeds the length limit, CDC will allow it and issue a warning.
+		if len(message.Key)+len(message.Value) > c.cfg.KafkaMaxMessageBytes && counter == 2 {
+			c.checker.Violate("max-message-bytes %d is exceeded by %d bytes in partition %d at offset %d",
+				c.cfg.KafkaMaxMessageBytes, len(message.Key)+len(message.Value), partition, message.Offset)
+		}
+
+		switch tp {
+		case model.MqMessageTypeDDL:
+			ddl, err := batchDecoder.NextDDLEvent()
+			if err != nil {
+				return errors.Annotatef(err, "decode message value failed, partition: %d, offset: %d", partition, message.Offset)
+			}
+			if !c.checker.CheckDDL(ddl) && sink != nil {
+				c.appendDDL(ddl)
+			}
+		case model.MqMessageTypeRow:
+			row, err := batchDecoder.NextRowChangedEvent()
+			if err != nil {
+				return errors.Annotatef(err, "decode message value failed, partition: %d, offset: %d", partition, message.Offset)
+			}
+			c.checker.CheckRow(partition, row)
+			if sink == nil {
+				break
+			}
+			globalResolvedTs := atomic.LoadUint64(&c.globalResolvedTs)
+			if row.CommitTs <= globalResolvedTs || row.CommitTs <= atomic.LoadUint64(&sink.resolvedTs) {
+				log.Debug("filter fallback row", zap.ByteString("row", message.Key),
+					zap.Uint64("globalResolvedTs", globalResolvedTs),
+					zap.Uint64("sinkResolvedTs", atomic.LoadUint64(&sink.resolvedTs)),
+					zap.Int32("partition", partition))
+				break
+			}
+			// FIXME: hack to set start-ts in row changed event, as start-ts
+			// is not contained in TiCDC open protocol
+			if row.StartTs == 0 {
+				row.StartTs = row.CommitTs
+			}
+			var partitionID int64
+			if row.Table.IsPartition {
+				partitionID = row.Table.TableID
+			}
+			row.Table.TableID =
+				c.fakeTableIDGenerator.generateFakeTableID(row.Table.Schema, row.Table.Table, partitionID)
+			if err := sink.EmitRowChangedEvents(ctx, row); err != nil {
+				return errors.Annotate(err, "emit row changed event failed")
+			}
+		case model.MqMessageTypeResolved:
+			ts, err := batchDecoder.NextResolvedEvent()
+			if err != nil {
+				return errors.Annotatef(err, "decode message value failed, partition: %d, offset: %d", partition, message.Offset)
+			}
+			c.checker.CheckResolved(partition, ts)
+			if sink != nil && atomic.LoadUint64(&sink.resolvedTs) < ts {
+				log.Debug("update sink resolved ts",
+					zap.Uint64("ts", ts),
+					zap.Int32("partition", partition))
+				atomic.StoreUint64(&sink.resolvedTs, ts)
+			}
+		case model.MqMessageTypeTableStarted:
+			table, ts, err := batchDecoder.NextTableStartedEvent()
+			if err != nil {
+				return errors.Annotatef(err, "decode message value failed, partition: %d, offset: %d", partition, message.Offset)
+			}
+			log.Info("table started",
+				zap.Stringer("table", table),
+				zap.Uint64("ts", ts),
+				zap.Int32("partition", partition))
+		}
+	}
+
+	if counter > c.cfg.KafkaMaxBatchSize {
+		c.checker.Violate("max-batch-size %d is exceeded by %d events in partition %d at offset %d",
+			c.cfg.KafkaMaxBatchSize, counter, partition, message.Offset)
+	}
+	return nil
+}
+
+func (c *Consumer) appendDDL(ddl *model.DDLEvent) {
+	c.ddlListMu.Lock()
+	defer c.ddlListMu.Unlock()
+	if ddl.CommitTs <= c.maxDDLReceivedTs {
+		return
+	}
+	globalResolvedTs := atomic.LoadUint64(&c.globalResolvedTs)
+	if ddl.CommitTs <= globalResolvedTs {
+		log.Error("unexpected ddl job", zap.Uint64("ddlts", ddl.CommitTs), zap.Uint64("globalResolvedTs", globalResolvedTs))
+		return
+	}
+	c.ddlList = append(c.ddlList, ddl)
+	c.maxDDLReceivedTs = ddl.CommitTs
+}
+
+func (c *Consumer) getFrontDDL() *model.DDLEvent {
+	c.ddlListMu.Lock()
+	defer c.ddlListMu.Unlock()
+	if len(c.ddlList) > 0 {
+		return c.ddlList[0]
+	}
+	return nil
+}
+
+func (c *Consumer) popDDL() *model.DDLEvent {
+	c.ddlListMu.Lock()
+	defer c.ddlListMu.Unlock()
+	if len(c.ddlList) > 0 {
+		ddl := c.ddlList[0]
+		c.ddlList = c.ddlList[1:]
+		return ddl
+	}
+	return nil
+}
+
+func (c *Consumer) forEachSink(fn func(sink *partitionSink) error) error {
+	c.sinksMu.Lock()
+	defer c.sinksMu.Unlock()
+	for _, sink := range c.sinks {
+		if err := fn(sink); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// apply flushes the events to the downstream by the global resolved ts, and
+// executes the DDLs once the events before them are flushed.
+func (c *Consumer) apply(ctx context.Context) error {
+	var lastGlobalResolvedTs uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		time.Sleep(100 * time.Millisecond)
+		// handle ddl
+		globalResolvedTs := uint64(math.MaxUint64)
+		err := c.forEachSink(func(sink *partitionSink) error {
+			resolvedTs := atomic.LoadUint64(&sink.resolvedTs)
+			if resolvedTs < globalResolvedTs {
+				globalResolvedTs = resolvedTs
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		todoDDL := c.getFrontDDL()
+		if todoDDL != nil && globalResolvedTs >= todoDDL.CommitTs {
+			// flush DMLs
+			err := c.forEachSink(func(sink *partitionSink) error {
+				return syncFlushRowChangedEvents(ctx, sink, todoDDL.CommitTs)
+			})
+			if err != nil {
+				return errors.Trace(err)
+			}
+
+			// execute ddl
+			err = c.ddlSink.EmitDDLEvent(ctx, todoDDL)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			c.popDDL()
+			continue
+		}
+
+		if todoDDL != nil && todoDDL.CommitTs < globalResolvedTs {
+			globalResolvedTs = todoDDL.CommitTs
+		}
+		if lastGlobalResolvedTs == globalResolvedTs {
+			continue
+		}
+		lastGlobalResolvedTs = globalResolvedTs
+		atomic.StoreUint64(&c.globalResolvedTs, globalResolvedTs)
+		log.Info("update globalResolvedTs", zap.Uint64("ts", globalResolvedTs))
+
+		err = c.forEachSink(func(sink *partitionSink) error {
+			return syncFlushRowChangedEvents(ctx, sink, globalResolvedTs)
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func syncFlushRowChangedEvents(ctx context.Context, sink sink.Sink, resolvedTs uint64) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		checkpointTs, err := sink.FlushRowChangedEvents(ctx, resolvedTs)
+		if err != nil {
+			return err
+		}
+		if checkpointTs >= resolvedTs {
+			return nil
+		}
+	}
+}
+
+func getPartitionNum(address []string, topic string, cfg *sarama.Config) (int32, error) {
+	// get partition number or create topic automatically
+	admin, err := sarama.NewClusterAdmin(address, cfg)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	topics, err := admin.ListTopics()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	err = admin.Close()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	topicDetail, exist := topics[topic]
+	if !exist {
+		return 0, errors.Errorf("can not find topic %s", topic)
+	}
+	log.Info("get partition number of topic", zap.String("topic", topic), zap.Int32("partition_num", topicDetail.NumPartitions))
+	return topicDetail.NumPartitions, nil
+}
+
+func waitTopicCreated(ctx context.Context, address []string, topic string, cfg *sarama.Config) error {
+	admin, err := sarama.NewClusterAdmin(address, cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer admin.Close()
+	for i := 0; i <= 30; i++ {
+		topics, err := admin.ListTopics()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := topics[topic]; ok {
+			return nil
+		}
+		log.Info("wait the topic created", zap.String("topic", topic))
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-time.After(time.Second):
+		}
+	}
+	return errors.Errorf("wait the topic(%s) created timeout", topic)
+}
+
+type fakeTableIDGenerator struct {
+	tableIDs       map[string]int64
+	currentTableID int64
+	mu             sync.Mutex
+}
+
+func (g *fakeTableIDGenerator) generateFakeTableID(schema, table string, partition int64) int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	key := quotes.QuoteSchema(schema, table)
+	if partition != 0 {
+		key = fmt.Sprintf("%s.`%d`", key, partition)
+	}
+	if tableID, ok := g.tableIDs[key]; ok {
+		return tableID
+	}
+	g.currentTableID++
+	g.tableIDs[key] = g.currentTableID
+	return g.currentTableID
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/ticdc/cdc/kv"
 	"github.com/pingcap/ticdc/cdc/model"
 	"github.com/pingcap/ticdc/cdc/processor/pipeline/system"
+	"github.com/pingcap/ticdc/cdc/upstream"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/etcd"
 	"github.com/pingcap/ticdc/pkg/version"
@@ -40,6 +41,7 @@ type GlobalVars struct {
 	EtcdClient       *etcd.CDCEtcdClient
 	GrpcPool         kv.GrpcPool
 	TableActorSystem *system.System
+	UpstreamManager  *upstream.Manager
 }
 
 // ChangefeedVars contains some vars which can be used anywhere in a pipeline
@@ -48,6 +50,9 @@ type GlobalVars struct {
 type ChangefeedVars struct {
 	ID   model.ChangeFeedID
 	Info *model.ChangeFeedInfo
+	// Upstream is the upstream which the changefeed replicates from, it is
+	// always ready.
+	Upstream *upstream.Upstream
 }
 
 // Context contains Vars(), Done(), Throw(error) and StdContext() context.Context
@@ -199,6 +204,22 @@ func NewBackendContext4Test(withChangefeedVars bool) Context {
 	return ctx
 }
 
+// GetUpstream returns the upstream which the changefeed in the context
+// replicates from, it falls back to the clients in the GlobalVars if the
+// ChangefeedVars don't carry an upstream.
+func GetUpstream(ctx Context) *upstream.Upstream {
+	if vars := ctx.ChangefeedVars(); vars != nil && vars.Upstream != nil {
+		return vars.Upstream
+	}
+	globalVars := ctx.GlobalVars()
+	return &upstream.Upstream{
+		ID:        model.DefaultUpstreamID,
+		PDClient:  globalVars.PDClient,
+		KVStorage: globalVars.KVStorage,
+		GrpcPool:  globalVars.GrpcPool,
+	}
+}
+
 // ZapFieldCapture returns a zap field containing capture address
 func ZapFieldCapture(ctx Context) zap.Field {
 	return zap.String("capture", ctx.GlobalVars().CaptureInfo.AdvertiseAddr)
@@ -0,0 +1,92 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"github.com/pingcap/errors"
+)
+
+// ErrorClass is the class of a changefeed error, which decides how the owner
+// handles the error.
+type ErrorClass string
+
+const (
+	// ErrorClassRetryable means the changefeed is restarted automatically
+	// after the error occurs, with a backoff if the error keeps occurring.
+	ErrorClassRetryable ErrorClass = "retryable"
+	// ErrorClassConfig means the error is caused by an invalid changefeed
+	// config, the changefeed is paused until the config is fixed.
+	ErrorClassConfig ErrorClass = "config"
+	// ErrorClassDownstreamSchema means the schema of the downstream doesn't
+	// match the replicated data, the changefeed is paused until the schema
+	// is fixed.
+	ErrorClassDownstreamSchema ErrorClass = "downstream-schema"
+	// ErrorClassFatal means the changefeed is failed immediately after the
+	// error occurs, it can't be resumed unless the cause is fixed manually.
+	ErrorClassFatal ErrorClass = "fatal"
+)
+
+// configErrors are the errors caused by invalid changefeed configs, retrying
+// them makes no sense until the config is updated.
+var configErrors = []*errors.Error{
+	ErrSinkURIInvalid, ErrSinkInvalidConfig, ErrMySQLInvalidConfig,
+	ErrKafkaInvalidConfig, ErrKafkaInvalidPartitionNum, ErrKafkaInvalidVersion,
+	ErrFilterRuleInvalid, ErrOldValueNotEnabled, ErrTableIneligible, ErrInvalidReplicaConfig,
+}
+
+// ErrorClassOf returns the class of the error code. The downstream schema
+// errors can't be recognized by the code, see errorutil.IsDownstreamSchemaError.
+func ErrorClassOf(code errors.RFCErrorCode) ErrorClass {
+	if ChangefeedFastFailErrorCode(code) {
+		return ErrorClassFatal
+	}
+	for _, e := range configErrors {
+		if e.RFCCode() == code {
+			return ErrorClassConfig
+		}
+	}
+	return ErrorClassRetryable
+}
+
+// errorHints are the remediation hints of the errors which users are able to
+// fix by themselves.
+var errorHints = map[errors.RFCErrorCode]string{
+	ErrGCTTLExceeded.RFCCode(): "the checkpoint of the changefeed lags behind too long, " +
+		"remove the changefeed and create it again with a start-ts newer than the GC safepoint",
+	ErrSnapshotLostByGC.RFCCode(): "the data to replicate has been garbage collected, " +
+		"remove the changefeed and create it again with a start-ts newer than the GC safepoint",
+	ErrStartTsBeforeGC.RFCCode(): "use a start-ts newer than the GC safepoint",
+	ErrSinkURIInvalid.RFCCode():  "check the format and the parameters of the sink-uri",
+	ErrMySQLConnectionError.RFCCode(): "check the network to the downstream database, " +
+		"and the user and password in the sink-uri",
+	ErrMySQLInvalidConfig.RFCCode():     "check the parameters of the sink-uri",
+	ErrKafkaNewSaramaProducer.RFCCode(): "check the network to the kafka brokers and the kafka parameters of the sink-uri",
+	ErrKafkaInvalidConfig.RFCCode():     "check the kafka parameters of the sink-uri",
+	ErrKafkaInvalidPartitionNum.RFCCode(): "the partition-num in the sink-uri should not be larger than " +
+		"the partition number of the topic",
+	ErrPulsarNewProducer.RFCCode():     "check the network to the pulsar brokers and the pulsar parameters of the sink-uri",
+	ErrFilterRuleInvalid.RFCCode():     "check the filter rules in the changefeed configuration",
+	ErrTableIneligible.RFCCode():       "tables without a primary key or a not null unique key can only be replicated with force-replicate",
+	ErrOldValueNotEnabled.RFCCode():    "set enable-old-value to true in the changefeed configuration",
+	ErrProcessorSortDir.RFCCode():      "check the permission and the free space of the sort-dir",
+	ErrCheckDataDirSatisfied.RFCCode(): "make sure there is enough free space in the data-dir",
+	ErrUnifiedSorterDiskFull.RFCCode(): "free the disk space of the sort-dir, or increase disk-full-memory-quota and disk-full-timeout of the sorter",
+	ErrJSONCodecRowTooLarge.RFCCode():  "increase max-message-bytes in the sink-uri or the message.max.bytes of the kafka topic",
+}
+
+// ErrorHint returns the remediation hint of the error code, empty string is
+// returned if there is no hint for the code.
+func ErrorHint(code errors.RFCErrorCode) string {
+	return errorHints[code]
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorClassAndHint(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, ErrorClassFatal, ErrorClassOf(ErrGCTTLExceeded.RFCCode()))
+	require.Equal(t, ErrorClassRetryable, ErrorClassOf(ErrKafkaSendMessage.RFCCode()))
+	require.Equal(t, ErrorClassRetryable, ErrorClassOf(ErrProcessorUnknown.RFCCode()))
+	require.Equal(t, ErrorClassConfig, ErrorClassOf(ErrSinkURIInvalid.RFCCode()))
+	require.Equal(t, ErrorClassConfig, ErrorClassOf(ErrFilterRuleInvalid.RFCCode()))
+
+	require.NotEmpty(t, ErrorHint(ErrGCTTLExceeded.RFCCode()))
+	require.NotEmpty(t, ErrorHint(ErrSinkURIInvalid.RFCCode()))
+	require.Empty(t, ErrorHint(ErrProcessorUnknown.RFCCode()))
+}
@@ -59,6 +59,7 @@ var (
 	ErrEncodeFailed      = errors.Normalize("encode failed: %s", errors.RFCCodeText("CDC:ErrEncodeFailed"))
 	ErrDecodeFailed      = errors.Normalize("decode failed: %s", errors.RFCCodeText("CDC:ErrDecodeFailed"))
 	ErrFilterRuleInvalid = errors.Normalize("filter rule is invalid", errors.RFCCodeText("CDC:ErrFilterRuleInvalid"))
+	ErrEvalRowFilter     = errors.Normalize("evaluate the row filter `%s` of table %s failed", errors.RFCCodeText("CDC:ErrEvalRowFilter"))
 
 	// internal errors
 	ErrAdminStopProcessor = errors.Normalize("stop processor by admin command", errors.RFCCodeText("CDC:ErrAdminStopProcessor"))
@@ -104,6 +105,8 @@ var (
 	ErrMySQLConnectionError      = errors.Normalize("MySQL connection error", errors.RFCCodeText("CDC:ErrMySQLConnectionError"))
 	ErrMySQLInvalidConfig        = errors.Normalize("MySQL config invalid", errors.RFCCodeText("CDC:ErrMySQLInvalidConfig"))
 	ErrMySQLWorkerPanic          = errors.Normalize("MySQL worker panic", errors.RFCCodeText("CDC:ErrMySQLWorkerPanic"))
+	ErrMySQLTimeZoneMismatch     = errors.Normalize("the time zone %s of the downstream sessions is %d seconds from UTC, but the time zone %s of the changefeed is %d seconds from UTC, the TIMESTAMP values would be shifted", errors.RFCCodeText("CDC:ErrMySQLTimeZoneMismatch"))
+	ErrPartitionDDLUnsupported   = errors.Normalize("the DDL `%s` of partitioned table can't be replicated in the merge partition mode: %s", errors.RFCCodeText("CDC:ErrPartitionDDLUnsupported"))
 	ErrAvroToEnvelopeError       = errors.Normalize("to envelope failed", errors.RFCCodeText("CDC:ErrAvroToEnvelopeError"))
 	ErrAvroUnknownType           = errors.Normalize("unknown type for Avro: %v", errors.RFCCodeText("CDC:ErrAvroUnknownType"))
 	ErrAvroMarshalFailed         = errors.Normalize("json marshal failed", errors.RFCCodeText("CDC:ErrAvroMarshalFailed"))
@@ -148,6 +151,12 @@ var (
 	ErrUnmarshalFailed       = errors.Normalize("unmarshal failed", errors.RFCCodeText("CDC:ErrUnmarshalFailed"))
 	ErrInvalidChangefeedID   = errors.Normalize(`bad changefeed id, please match the pattern "^[a-zA-Z0-9]+(\-[a-zA-Z0-9]+)*$, the length should no more than %d", eg, "simple-changefeed-task"`, errors.RFCCodeText("CDC:ErrInvalidChangefeedID"))
 	ErrInvalidEtcdKey        = errors.Normalize("invalid key: %s", errors.RFCCodeText("CDC:ErrInvalidEtcdKey"))
+	ErrInvalidCompression    = errors.Normalize("invalid compression %s: %s", errors.RFCCodeText("CDC:ErrInvalidCompression"))
+	ErrDecompressFailed      = errors.Normalize("decompress data by %s failed", errors.RFCCodeText("CDC:ErrDecompressFailed"))
+	ErrInvalidCharsetValue   = errors.Normalize("the value of column %s of table %s is not valid %s", errors.RFCCodeText("CDC:ErrInvalidCharsetValue"))
+	ErrRowTooLarge           = errors.Normalize("the row of table %s committed at %d is %d bytes, which exceeds the max row size %d", errors.RFCCodeText("CDC:ErrRowTooLarge"))
+	ErrDeadLetterStorage     = errors.Normalize("dead letter storage error", errors.RFCCodeText("CDC:ErrDeadLetterStorage"))
+	ErrOffloadStorage        = errors.Normalize("offload storage error", errors.RFCCodeText("CDC:ErrOffloadStorage"))
 
 	// schema storage errors
 	ErrSchemaStorageUnresolved = errors.Normalize("can not found schema snapshot, the specified ts(%d) is more than resolvedTs(%d)", errors.RFCCodeText("CDC:ErrSchemaStorageUnresolved"))
@@ -170,11 +179,17 @@ var (
 	ErrProcessorUnknown             = errors.Normalize("processor running unknown error", errors.RFCCodeText("CDC:ErrProcessorUnknown"))
 	ErrOwnerUnknown                 = errors.Normalize("owner running unknown error", errors.RFCCodeText("CDC:ErrOwnerUnknown"))
 	ErrProcessorTableNotFound       = errors.Normalize("table not found in processor cache", errors.RFCCodeText("CDC:ErrProcessorTableNotFound"))
+	ErrProcessorQueryTimeout        = errors.Normalize("query processor timeout", errors.RFCCodeText("CDC:ErrProcessorQueryTimeout"))
 	ErrProcessorEtcdWatch           = errors.Normalize("etcd watch returns error", errors.RFCCodeText("CDC:ErrProcessorEtcdWatch"))
 	ErrProcessorSortDir             = errors.Normalize("sort dir error", errors.RFCCodeText("CDC:ErrProcessorSortDir"))
 	ErrUnknownSortEngine            = errors.Normalize("unknown sort engine %s", errors.RFCCodeText("CDC:ErrUnknownSortEngine"))
 	ErrInvalidTaskKey               = errors.Normalize("invalid task key: %s", errors.RFCCodeText("CDC:ErrInvalidTaskKey"))
 	ErrInvalidServerOption          = errors.Normalize("invalid server option", errors.RFCCodeText("CDC:ErrInvalidServerOption"))
+	ErrServerConfigImmutable        = errors.Normalize("%s of the server config can't be changed without restarting the server", errors.RFCCodeText("CDC:ErrServerConfigImmutable"))
+	ErrServerConfigReloadNotSupport = errors.Normalize("reloading the server config is not supported by the server", errors.RFCCodeText("CDC:ErrServerConfigReloadNotSupport"))
+	ErrInvalidReplicaConfig         = errors.Normalize("invalid replica config: %s", errors.RFCCodeText("CDC:ErrInvalidReplicaConfig"))
+	ErrInvalidChangefeedSpec        = errors.Normalize("invalid changefeed spec %s: %s", errors.RFCCodeText("CDC:ErrInvalidChangefeedSpec"))
+	ErrInvalidUpstream              = errors.Normalize("invalid upstream: %s", errors.RFCCodeText("CDC:ErrInvalidUpstream"))
 	ErrServerNewPDClient            = errors.Normalize("server creates pd client failed", errors.RFCCodeText("CDC:ErrServerNewPDClient"))
 	ErrServeHTTP                    = errors.Normalize("serve http error", errors.RFCCodeText("CDC:ErrServeHTTP"))
 	ErrCaptureCampaignOwner         = errors.Normalize("campaign owner failed", errors.RFCCodeText("CDC:ErrCaptureCampaignOwner"))
@@ -194,10 +209,12 @@ var (
 	ErrOwnerCampaignKeyDeleted      = errors.Normalize("owner campaign key deleted", errors.RFCCodeText("CDC:ErrOwnerCampaignKeyDeleted"))
 	ErrServiceSafepointLost         = errors.Normalize("service safepoint lost. current safepoint is %d, please remove all changefeed(s) whose checkpoints are behind the current safepoint", errors.RFCCodeText("CDC:ErrServiceSafepointLost"))
 	ErrUpdateServiceSafepointFailed = errors.Normalize("updating service safepoint failed", errors.RFCCodeText("CDC:ErrUpdateServiceSafepointFailed"))
-	ErrStartTsBeforeGC              = errors.Normalize("fail to create changefeed because start-ts %d is earlier than GC safepoint at %d", errors.RFCCodeText("CDC:ErrStartTsBeforeGC"))
+	ErrStartTsBeforeGC              = errors.Normalize("fail to create changefeed because start-ts %d (%s) is earlier than GC safepoint at %d (%s)", errors.RFCCodeText("CDC:ErrStartTsBeforeGC"))
+	ErrInvalidStartTs               = errors.Normalize("invalid start ts: %s", errors.RFCCodeText("CDC:ErrInvalidStartTs"))
 	ErrTargetTsBeforeStartTs        = errors.Normalize("fail to create changefeed because target-ts %d is earlier than start-ts %d", errors.RFCCodeText("CDC:ErrTargetTsBeforeStartTs"))
 	ErrSnapshotLostByGC             = errors.Normalize("fail to create or maintain changefeed due to snapshot loss caused by GC. checkpoint-ts %d is earlier than or equal to GC safepoint at %d", errors.RFCCodeText("CDC:ErrSnapshotLostByGC"))
 	ErrGCTTLExceeded                = errors.Normalize("the checkpoint-ts(%d) lag of the changefeed(%s) has exceeded the GC TTL", errors.RFCCodeText("CDC:ErrGCTTLExceeded"))
+	ErrChangefeedQuarantined        = errors.Normalize("changefeed %s is quarantined for blocking the GC at checkpoint-ts %d, it can only be resumed with an overwrite checkpoint-ts greater than that", errors.RFCCodeText("CDC:ErrChangefeedQuarantined"))
 	ErrNotOwner                     = errors.Normalize("this capture is not a owner", errors.RFCCodeText("CDC:ErrNotOwner"))
 	ErrOwnerNotFound                = errors.Normalize("owner not found", errors.RFCCodeText("CDC:ErrOwnerNotFound"))
 	ErrTableListenReplicated        = errors.Normalize("A table(%d) is being replicated by at least two processors(%s, %s), please report a bug", errors.RFCCodeText("CDC:ErrTableListenReplicated"))
@@ -243,6 +260,7 @@ var (
 	// sorter errors
 	ErrUnifiedSorterBackendTerminating = errors.Normalize("unified sorter backend is terminating", errors.RFCCodeText("CDC:ErrUnifiedSorterBackendTerminating"))
 	ErrUnifiedSorterIOError            = errors.Normalize("unified sorter IO error. Make sure your sort-dir is configured correctly by passing a valid argument or toml file to `cdc server`, or if you use TiUP, review the settings in `tiup cluster edit-config`. Details: %s", errors.RFCCodeText("CDC:ErrUnifiedSorterIOError"))
+	ErrUnifiedSorterDiskFull           = errors.Normalize("unified sorter disk full, no space is freed in the sort-dir after waiting for %s. Details: %s", errors.RFCCodeText("CDC:ErrUnifiedSorterDiskFull"))
 	ErrIllegalSorterParameter          = errors.Normalize("illegal parameter for sorter: %s", errors.RFCCodeText("CDC:ErrIllegalSorterParameter"))
 	ErrAsyncIOCancelled                = errors.Normalize("asynchronous IO operation is cancelled. Internal use only, report a bug if seen in log", errors.RFCCodeText("CDC:ErrAsyncIOCancelled"))
 	ErrConflictingFileLocks            = errors.Normalize("file lock conflict: %s", errors.RFCCodeText("ErrConflictingFileLocks"))
@@ -0,0 +1,39 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorutil
+
+import (
+	dmysql "github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+// IsDownstreamSchemaError checks whether the error is returned by the
+// downstream database because its schema doesn't match the replicated data,
+// such as a missing table or column, or a column too narrow for the data.
+// Retrying such errors makes no sense until the schema is fixed.
+func IsDownstreamSchemaError(err error) bool {
+	mysqlErr, ok := errors.Cause(err).(*dmysql.MySQLError)
+	if !ok {
+		return false
+	}
+	switch mysqlErr.Number {
+	case mysql.ErrBadDB, mysql.ErrNoSuchTable, mysql.ErrBadField,
+		mysql.ErrWrongValueCountOnRow, mysql.ErrBadNull, mysql.ErrNoDefaultForField,
+		mysql.ErrDataTooLong, mysql.ErrTruncatedWrongValueForField, mysql.ErrWarnDataOutOfRange:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorutil
+
+import (
+	"errors"
+	"testing"
+
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	tmysql "github.com/pingcap/tidb/parser/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDownstreamSchemaError(t *testing.T) {
+	cases := []struct {
+		err error
+		ret bool
+	}{
+		{errors.New("raw error"), false},
+		{newMysqlErr(tmysql.ErrNoSuchTable, "Table 'test.t' doesn't exist"), true},
+		{newMysqlErr(tmysql.ErrDataTooLong, "Data too long for column 'a' at row 1"), true},
+		{newMysqlErr(tmysql.ErrDupEntry, "Duplicate entry '1' for key 'PRIMARY'"), false},
+		{cerror.WrapError(cerror.ErrMySQLTxnError, newMysqlErr(tmysql.ErrBadField, "Unknown column 'b' in 'field list'")), true},
+	}
+
+	for _, item := range cases {
+		assert.Equal(t, item.ret, IsDownstreamSchemaError(item.err))
+	}
+}
@@ -25,6 +25,7 @@ const (
 	EtcdKeyBase = "/tidb/cdc"
 	ownerKey    = "/owner"
 	captureKey  = "/capture"
+	drainKey    = "/drain"
 
 	taskKey         = "/task"
 	taskWorkloadKey = taskKey + "/workload"
@@ -48,6 +49,7 @@ const (
 	CDCKeyTypeTaskPosition
 	CDCKeyTypeTaskStatus
 	CDCKeyTypeTaskWorkload
+	CDCKeyTypeDrainCapture
 )
 
 // CDCKey represents a etcd key which is defined by TiCDC
@@ -102,6 +104,11 @@ func (k *CDCKey) Parse(key string) error {
 		k.CaptureID = key[len(captureKey)+1:]
 		k.ChangefeedID = ""
 		k.OwnerLeaseID = ""
+	case strings.HasPrefix(key, drainKey):
+		k.Tp = CDCKeyTypeDrainCapture
+		k.CaptureID = key[len(drainKey)+1:]
+		k.ChangefeedID = ""
+		k.OwnerLeaseID = ""
 	case strings.HasPrefix(key, changefeedInfoKey):
 		k.Tp = CDCKeyTypeChangefeedInfo
 		k.CaptureID = ""
@@ -154,6 +161,8 @@ func (k *CDCKey) String() string {
 		return EtcdKeyBase + ownerKey + "/" + k.OwnerLeaseID
 	case CDCKeyTypeCapture:
 		return EtcdKeyBase + captureKey + "/" + k.CaptureID
+	case CDCKeyTypeDrainCapture:
+		return EtcdKeyBase + drainKey + "/" + k.CaptureID
 	case CDCKeyTypeChangefeedInfo:
 		return EtcdKeyBase + changefeedInfoKey + "/" + k.ChangefeedID
 	case CDCKeyTypeChangeFeedStatus:
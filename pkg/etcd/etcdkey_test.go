@@ -45,6 +45,12 @@ func (s *etcdkeySuite) TestEtcdKey(c *check.C) {
 			Tp:        CDCKeyTypeCapture,
 			CaptureID: "6bbc01c8-0605-4f86-a0f9-b3119109b225",
 		},
+	}, {
+		key: "/tidb/cdc/drain/6bbc01c8-0605-4f86-a0f9-b3119109b225",
+		expected: &CDCKey{
+			Tp:        CDCKeyTypeDrainCapture,
+			CaptureID: "6bbc01c8-0605-4f86-a0f9-b3119109b225",
+		},
 	}, {
 		key: "/tidb/cdc/changefeed/info/test-_@#$%changefeed",
 		expected: &CDCKey{
@@ -0,0 +1,113 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	filterV2 "github.com/pingcap/tidb-tools/pkg/table-filter"
+)
+
+type eventFilterRule struct {
+	filter filterV2.Filter
+	ignore map[string]struct{}
+}
+
+// EventTypeFilter drops the DML events of the types ignored by the event
+// filter rules. It caches the ignored types by table, so it's not safe to be
+// used by multiple goroutines.
+type EventTypeFilter struct {
+	rules []*eventFilterRule
+	// ignoredTypes caches the event types ignored by the tables, the value is
+	// nil if the table matches no rule.
+	ignoredTypes map[model.TableName]map[string]struct{}
+}
+
+// VerifyEventFilters checks the event filter rules in the configuration and
+// returns an invalid rule error if the verification fails.
+func VerifyEventFilters(cfg *config.ReplicaConfig) error {
+	_, err := NewEventTypeFilter(cfg)
+	return err
+}
+
+// NewEventTypeFilter creates an EventTypeFilter, it returns nil if there is no
+// event filter rule in the configuration.
+func NewEventTypeFilter(cfg *config.ReplicaConfig) (*EventTypeFilter, error) {
+	if len(cfg.Filter.EventFilters) == 0 {
+		return nil, nil
+	}
+	rules := make([]*eventFilterRule, 0, len(cfg.Filter.EventFilters))
+	for _, ruleCfg := range cfg.Filter.EventFilters {
+		f, err := filterV2.Parse(ruleCfg.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+		}
+		if !cfg.CaseSensitive {
+			f = filterV2.CaseInsensitive(f)
+		}
+		ignore := make(map[string]struct{}, len(ruleCfg.IgnoreEvent))
+		for _, tp := range ruleCfg.IgnoreEvent {
+			switch tp {
+			case config.EventTypeInsert, config.EventTypeUpdate, config.EventTypeDelete:
+				ignore[tp] = struct{}{}
+			default:
+				return nil, cerror.ErrFilterRuleInvalid.GenWithStack(
+					"unknown event type %q of the event filter with matcher %v, the valid types are %s, %s and %s",
+					tp, ruleCfg.Matcher, config.EventTypeInsert, config.EventTypeUpdate, config.EventTypeDelete)
+			}
+		}
+		rules = append(rules, &eventFilterRule{filter: f, ignore: ignore})
+	}
+	return &EventTypeFilter{
+		rules:        rules,
+		ignoredTypes: make(map[model.TableName]map[string]struct{}),
+	}, nil
+}
+
+// EventTypeOf returns the DML event type of the row changed event.
+func EventTypeOf(row *model.RowChangedEvent) string {
+	switch {
+	case len(row.PreColumns) == 0:
+		return config.EventTypeInsert
+	case len(row.Columns) == 0:
+		return config.EventTypeDelete
+	default:
+		return config.EventTypeUpdate
+	}
+}
+
+// ShouldIgnoreEvent returns true if the type of the row changed event is
+// ignored by the rule of its table.
+func (f *EventTypeFilter) ShouldIgnoreEvent(row *model.RowChangedEvent) bool {
+	if f == nil || row.Table == nil {
+		return false
+	}
+	name := model.TableName{Schema: row.Table.Schema, Table: row.Table.Table}
+	ignore, ok := f.ignoredTypes[name]
+	if !ok {
+		for _, rule := range f.rules {
+			if rule.filter.MatchTable(name.Schema, name.Table) {
+				ignore = rule.ignore
+				break
+			}
+		}
+		f.ignoredTypes[name] = ignore
+	}
+	if len(ignore) == 0 {
+		return false
+	}
+	_, ok = ignore[EventTypeOf(row)]
+	return ok
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyEventFilters(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	require.Nil(t, VerifyEventFilters(cfg))
+	cfg.Filter.EventFilters = []*config.EventFilterRule{
+		{Matcher: []string{"audit.*"}, IgnoreEvent: []string{"delete", "update"}},
+	}
+	require.Nil(t, VerifyEventFilters(cfg))
+
+	cfg.Filter.EventFilters = []*config.EventFilterRule{
+		{Matcher: []string{"audit.*"}, IgnoreEvent: []string{"truncate"}},
+	}
+	require.Regexp(t, ".*unknown event type \"truncate\".*", VerifyEventFilters(cfg))
+	cfg.Filter.EventFilters = []*config.EventFilterRule{
+		{Matcher: []string{"[audit.*"}, IgnoreEvent: []string{"delete"}},
+	}
+	require.Regexp(t, ".*ErrFilterRuleInvalid.*", VerifyEventFilters(cfg))
+}
+
+func TestEventTypeFilter(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	f, err := NewEventTypeFilter(cfg)
+	require.Nil(t, err)
+	require.Nil(t, f)
+	cols := []*model.Column{{Name: "id", Value: 1}}
+	insert := func(schema, table string) *model.RowChangedEvent {
+		return &model.RowChangedEvent{Table: &model.TableName{Schema: schema, Table: table}, Columns: cols}
+	}
+	update := func(schema, table string) *model.RowChangedEvent {
+		return &model.RowChangedEvent{Table: &model.TableName{Schema: schema, Table: table}, Columns: cols, PreColumns: cols}
+	}
+	del := func(schema, table string) *model.RowChangedEvent {
+		return &model.RowChangedEvent{Table: &model.TableName{Schema: schema, Table: table}, PreColumns: cols}
+	}
+	// a nil filter ignores nothing
+	require.False(t, f.ShouldIgnoreEvent(del("audit", "log")))
+
+	require.Equal(t, config.EventTypeInsert, EventTypeOf(insert("test", "t")))
+	require.Equal(t, config.EventTypeUpdate, EventTypeOf(update("test", "t")))
+	require.Equal(t, config.EventTypeDelete, EventTypeOf(del("test", "t")))
+
+	cfg.Filter.EventFilters = []*config.EventFilterRule{
+		{Matcher: []string{"audit.log"}, IgnoreEvent: []string{"delete", "update"}},
+		{Matcher: []string{"audit.*"}, IgnoreEvent: []string{"delete"}},
+	}
+	f, err = NewEventTypeFilter(cfg)
+	require.Nil(t, err)
+	cases := []struct {
+		row    *model.RowChangedEvent
+		ignore bool
+	}{
+		{row: insert("audit", "log"), ignore: false},
+		{row: update("audit", "log"), ignore: true},
+		{row: del("audit", "log"), ignore: true},
+		// the matcher is case sensitive by default
+		{row: del("Audit", "Log"), ignore: false},
+		{row: insert("audit", "t"), ignore: false},
+		{row: update("audit", "t"), ignore: false},
+		{row: del("audit", "t"), ignore: true},
+		{row: del("test", "t"), ignore: false},
+	}
+	for i, cs := range cases {
+		require.Equal(t, cs.ignore, f.ShouldIgnoreEvent(cs.row), "case %d", i)
+	}
+}
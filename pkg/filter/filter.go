@@ -14,6 +14,9 @@
 package filter
 
 import (
+	"strings"
+	"sync"
+
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/cyclic/mark"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
@@ -24,7 +27,10 @@ import (
 
 // Filter is an event filter implementation.
 type Filter struct {
+	// mu protects filter and rules, which can be updated by UpdateRules
+	mu               sync.RWMutex
 	filter           filterV2.Filter
+	rules            []string
 	ignoreTxnStartTs []uint64
 	ddlAllowlist     []model.ActionType
 	isCyclicEnabled  bool
@@ -53,22 +59,106 @@ func VerifyRules(cfg *config.ReplicaConfig) (filterV2.Filter, error) {
 
 // NewFilter creates a filter.
 func NewFilter(cfg *config.ReplicaConfig) (*Filter, error) {
-	f, err := VerifyRules(cfg)
+	f, err := newTableFilter(cfg)
 	if err != nil {
-		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+		return nil, err
 	}
-
-	if !cfg.CaseSensitive {
-		f = filterV2.CaseInsensitive(f)
+	ddlAllowlist := cfg.Filter.DDLAllowlist
+	if cfg.AutoID.ReplicateSequence() {
+		ddlAllowlist = append(ddlAllowlist[:len(ddlAllowlist):len(ddlAllowlist)],
+			model.ActionCreateSequence, model.ActionAlterSequence, model.ActionDropSequence)
 	}
 	return &Filter{
 		filter:           f,
+		rules:            cfg.Filter.Rules,
 		ignoreTxnStartTs: cfg.Filter.IgnoreTxnStartTs,
-		ddlAllowlist:     cfg.Filter.DDLAllowlist,
+		ddlAllowlist:     ddlAllowlist,
 		isCyclicEnabled:  cfg.Cyclic.IsEnabled(),
 	}, nil
 }
 
+func newTableFilter(cfg *config.ReplicaConfig) (filterV2.Filter, error) {
+	f, err := VerifyRules(cfg)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+	}
+	if !cfg.CaseSensitive {
+		f = filterV2.CaseInsensitive(f)
+	}
+	return f, nil
+}
+
+// IsRulesChanged returns true if the filter rules in the config are different from the rules of the filter.
+func (f *Filter) IsRulesChanged(cfg *config.ReplicaConfig) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if len(f.rules) != len(cfg.Filter.Rules) {
+		return true
+	}
+	for i, rule := range f.rules {
+		if rule != cfg.Filter.Rules[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateRules replaces the table filter rules with the rules in the config,
+// the filter is shared by the components of a changefeed, so it's safe to be
+// called while the filter is in use.
+func (f *Filter) UpdateRules(cfg *config.ReplicaConfig) error {
+	tableFilter, err := newTableFilter(cfg)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.filter = tableFilter
+	f.rules = cfg.Filter.Rules
+	return nil
+}
+
+// AddTablesToRules returns the filter rules which match the tables matched by
+// the matcher in addition to the tables matched by the given filter config.
+func AddTablesToRules(cfg *config.FilterConfig, matcher []string) ([]string, error) {
+	return appendTableRules(cfg, matcher, "")
+}
+
+// RemoveTablesFromRules returns the filter rules which don't match the tables
+// matched by the matcher any longer.
+func RemoveTablesFromRules(cfg *config.FilterConfig, matcher []string) ([]string, error) {
+	return appendTableRules(cfg, matcher, "!")
+}
+
+// appendTableRules appends the matcher to the filter rules, a later rule
+// takes precedence over the earlier ones.
+func appendTableRules(cfg *config.FilterConfig, matcher []string, prefix string) ([]string, error) {
+	if len(cfg.Rules) == 0 && cfg.MySQLReplicationRules != nil {
+		return nil, cerror.ErrFilterRuleInvalid.GenWithStack("can not add or remove tables with mysql replication rules")
+	}
+	if len(matcher) == 0 {
+		return nil, cerror.ErrFilterRuleInvalid.GenWithStack("the table matcher is empty")
+	}
+	for _, m := range matcher {
+		if m == "" || strings.HasPrefix(m, "!") || strings.HasPrefix(m, "@") {
+			return nil, cerror.ErrFilterRuleInvalid.GenWithStack("invalid table matcher %q", m)
+		}
+	}
+	rules := make([]string, 0, len(cfg.Rules)+len(matcher)+1)
+	if len(cfg.Rules) == 0 {
+		// keep consistent with the default rules in VerifyRules
+		rules = append(rules, "*.*")
+	}
+	rules = append(rules, cfg.Rules...)
+	for _, m := range matcher {
+		rules = append(rules, prefix+m)
+	}
+	if _, err := filterV2.Parse(rules); err != nil {
+		return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+	}
+	return rules, nil
+}
+
 func (f *Filter) shouldIgnoreStartTs(ts uint64) bool {
 	for _, ignoreTs := range f.ignoreTxnStartTs {
 		if ignoreTs == ts {
@@ -88,6 +178,8 @@ func (f *Filter) ShouldIgnoreTable(db, tbl string) bool {
 		// Always replicate mark tables.
 		return false
 	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
 	return !f.filter.MatchTable(db, tbl)
 }
 
@@ -104,7 +196,9 @@ func (f *Filter) ShouldIgnoreDDLEvent(ts uint64, ddlType model.ActionType, schem
 	switch ddlType {
 	case model.ActionCreateSchema, model.ActionDropSchema,
 		model.ActionModifySchemaCharsetAndCollate:
+		f.mu.RLock()
 		shouldIgnoreTableOrSchema = !f.filter.MatchSchema(schema)
+		f.mu.RUnlock()
 	default:
 		shouldIgnoreTableOrSchema = f.ShouldIgnoreTable(schema, table)
 	}
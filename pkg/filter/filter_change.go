@@ -0,0 +1,85 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/util"
+	"github.com/r3labs/diff"
+)
+
+// IsChanged returns whether the filter config is changed from oldCfg to newCfg.
+func IsChanged(oldCfg, newCfg *config.ReplicaConfig) bool {
+	return diff.Changed(oldCfg.Filter, newCfg.Filter)
+}
+
+// ChangedTables returns the tables which are replicated with the new config
+// but not with the old config, and the tables which are replicated with the
+// old config but not with the new config.
+func ChangedTables(oldCfg, newCfg *config.ReplicaConfig, tables []model.TableName) (added, removed []model.TableName, err error) {
+	oldFilter, err := NewFilter(oldCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	newFilter, err := NewFilter(newCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, table := range tables {
+		oldIgnored := oldFilter.ShouldIgnoreTable(table.Schema, table.Table)
+		newIgnored := newFilter.ShouldIgnoreTable(table.Schema, table.Table)
+		switch {
+		case oldIgnored && !newIgnored:
+			added = append(added, table)
+		case !oldIgnored && newIgnored:
+			removed = append(removed, table)
+		}
+	}
+	return added, removed, nil
+}
+
+// ChangeWarnings returns the warnings about the data which is skipped if the
+// filter config of a changefeed is changed at the checkpoint ts. The changes
+// of the newly replicated tables before the checkpoint ts are not replicated,
+// and the changes of the tables which are not replicated any more after the
+// checkpoint ts are skipped.
+func ChangeWarnings(oldCfg, newCfg *config.ReplicaConfig, tables []model.TableName, checkpointTs uint64) ([]string, error) {
+	added, removed, err := ChangedTables(oldCfg, newCfg, tables)
+	if err != nil {
+		return nil, err
+	}
+	sortTables := func(tables []model.TableName) {
+		sort.Slice(tables, func(i, j int) bool {
+			return tables[i].String() < tables[j].String()
+		})
+	}
+	sortTables(added)
+	sortTables(removed)
+	var warnings []string
+	if len(added) != 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"tables %v are replicated from the checkpoint ts %d (%s), the changes before it are not replicated",
+			added, checkpointTs, util.FormatTs(checkpointTs)))
+	}
+	if len(removed) != 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"tables %v are not replicated any more, the changes after the checkpoint ts %d (%s) are skipped",
+			removed, checkpointTs, util.FormatTs(checkpointTs)))
+	}
+	return warnings, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeWarnings(t *testing.T) {
+	t.Parallel()
+
+	tables := []model.TableName{
+		{Schema: "test", Table: "t1"},
+		{Schema: "test", Table: "t2"},
+		{Schema: "other", Table: "t1"},
+	}
+	oldCfg := config.GetDefaultReplicaConfig()
+	oldCfg.Filter.Rules = []string{"test.*"}
+	newCfg := oldCfg.Clone()
+	require.False(t, IsChanged(oldCfg, newCfg))
+	warnings, err := ChangeWarnings(oldCfg, newCfg, tables, 429000000000000000)
+	require.Nil(t, err)
+	require.Len(t, warnings, 0)
+
+	newCfg.Filter.Rules = []string{"test.*", "!test.t2", "other.*"}
+	require.True(t, IsChanged(oldCfg, newCfg))
+	added, removed, err := ChangedTables(oldCfg, newCfg, tables)
+	require.Nil(t, err)
+	require.Equal(t, []model.TableName{{Schema: "other", Table: "t1"}}, added)
+	require.Equal(t, []model.TableName{{Schema: "test", Table: "t2"}}, removed)
+	warnings, err = ChangeWarnings(oldCfg, newCfg, tables, 429000000000000000)
+	require.Nil(t, err)
+	require.Len(t, warnings, 2)
+	require.Regexp(t, `tables \[other.t1\] are replicated from the checkpoint ts 429000000000000000 .*`, warnings[0])
+	require.Regexp(t, `tables \[test.t2\] are not replicated any more, .*`, warnings[1])
+
+	newCfg.Filter.Rules = []string{"test.*("}
+	_, err = ChangeWarnings(oldCfg, newCfg, tables, 429000000000000000)
+	require.Regexp(t, ".*ErrFilterRuleInvalid.*", err)
+}
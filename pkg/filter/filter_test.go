@@ -134,6 +134,19 @@ func TestShouldDiscardDDL(t *testing.T) {
 	require.True(t, filter.ShouldDiscardDDL(model.ActionCreateSequence))
 }
 
+func TestShouldDiscardSequenceDDL(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.AutoID = &config.AutoIDConfig{Sequence: true}
+	filter, err := NewFilter(cfg)
+	require.Nil(t, err)
+	require.False(t, filter.ShouldDiscardDDL(model.ActionCreateSequence))
+	require.False(t, filter.ShouldDiscardDDL(model.ActionAlterSequence))
+	require.False(t, filter.ShouldDiscardDDL(model.ActionDropSequence))
+	require.True(t, filter.ShouldDiscardDDL(model.ActionRebaseAutoID))
+}
+
 func TestShouldIgnoreDDL(t *testing.T) {
 	t.Parallel()
 
@@ -190,3 +203,44 @@ func TestShouldIgnoreDDL(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateRules(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	cfg.Filter.Rules = []string{"sns.*"}
+	filter, err := NewFilter(cfg)
+	require.Nil(t, err)
+	require.False(t, filter.IsRulesChanged(cfg))
+	require.True(t, filter.ShouldIgnoreTable("ecom", "order"))
+
+	rules, err := AddTablesToRules(cfg.Filter, []string{"ecom.order"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"sns.*", "ecom.order"}, rules)
+	cfg.Filter.Rules = rules
+	require.True(t, filter.IsRulesChanged(cfg))
+	require.Nil(t, filter.UpdateRules(cfg))
+	require.False(t, filter.IsRulesChanged(cfg))
+	require.False(t, filter.ShouldIgnoreTable("ecom", "order"))
+	require.False(t, filter.ShouldIgnoreTable("sns", "log"))
+
+	rules, err = RemoveTablesFromRules(cfg.Filter, []string{"sns.log"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"sns.*", "ecom.order", "!sns.log"}, rules)
+	cfg.Filter.Rules = rules
+	require.Nil(t, filter.UpdateRules(cfg))
+	require.True(t, filter.ShouldIgnoreTable("sns", "log"))
+	require.False(t, filter.ShouldIgnoreTable("sns", "user"))
+
+	// The empty rules match all tables.
+	rules, err = RemoveTablesFromRules(&config.FilterConfig{}, []string{"sns.log"})
+	require.Nil(t, err)
+	require.Equal(t, []string{"*.*", "!sns.log"}, rules)
+
+	_, err = AddTablesToRules(cfg.Filter, nil)
+	require.Regexp(t, "the table matcher is empty", err)
+	_, err = RemoveTablesFromRules(cfg.Filter, []string{"!sns.user"})
+	require.Regexp(t, "invalid table matcher", err)
+	_, err = AddTablesToRules(cfg.Filter, []string{"sns.[user"})
+	require.Regexp(t, ".*ErrFilterRuleInvalid.*", err)
+}
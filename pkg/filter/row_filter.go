@@ -0,0 +1,226 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	filterV2 "github.com/pingcap/tidb-tools/pkg/table-filter"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	// register the rewriter of the simple expressions
+	_ "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/mock"
+)
+
+type rowFilterRule struct {
+	filter     filterV2.Filter
+	expression string
+}
+
+// rowFilterExpr is the expression of a table built with a version of the
+// table info, its rule is nil if the table matches no rule.
+type rowFilterExpr struct {
+	tableInfoVersion uint64
+	rule             *rowFilterRule
+	expr             expression.Expression
+}
+
+// RowFilter filters the row changed events by the SQL expressions of the row
+// filter rules. It's not safe to be used by multiple goroutines, since the
+// builtin functions of the expressions keep the evaluation state, use Clone to
+// create a RowFilter for each goroutine.
+type RowFilter struct {
+	rules []*rowFilterRule
+	tz    *time.Location
+	sctx  sessionctx.Context
+
+	// exprs caches the expressions by table ID, an expression is rebuilt when
+	// the table info changes.
+	exprs map[model.TableID]*rowFilterExpr
+}
+
+// VerifyRowFilters checks the row filter rules in the configuration and
+// returns an invalid rule error if the verification fails. The columns of an
+// expression can only be checked when the expression is evaluated.
+func VerifyRowFilters(cfg *config.ReplicaConfig) error {
+	_, err := newRowFilterRules(cfg)
+	return err
+}
+
+func newRowFilterRules(cfg *config.ReplicaConfig) ([]*rowFilterRule, error) {
+	rules := make([]*rowFilterRule, 0, len(cfg.Filter.RowFilters))
+	for _, ruleCfg := range cfg.Filter.RowFilters {
+		f, err := filterV2.Parse(ruleCfg.Matcher)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrFilterRuleInvalid, err)
+		}
+		if !cfg.CaseSensitive {
+			f = filterV2.CaseInsensitive(f)
+		}
+		if strings.TrimSpace(ruleCfg.Expression) == "" {
+			return nil, cerror.ErrFilterRuleInvalid.GenWithStack(
+				"the expression of the row filter with matcher %v is empty", ruleCfg.Matcher)
+		}
+		if err := verifyRowFilterExpression(ruleCfg.Expression); err != nil {
+			return nil, err
+		}
+		rules = append(rules, &rowFilterRule{filter: f, expression: ruleCfg.Expression})
+	}
+	return rules, nil
+}
+
+// verifyRowFilterExpression checks that the expression is a single valid SQL
+// expression.
+func verifyRowFilterExpression(expr string) error {
+	stmts, _, err := parser.New().ParseSQL("SELECT " + expr)
+	if err != nil {
+		return cerror.ErrFilterRuleInvalid.Wrap(err).GenWithStack("invalid row filter expression `%s`", expr)
+	}
+	if len(stmts) != 1 {
+		return cerror.ErrFilterRuleInvalid.GenWithStack("invalid row filter expression `%s`", expr)
+	}
+	sel, ok := stmts[0].(*ast.SelectStmt)
+	if !ok || len(sel.Fields.Fields) != 1 || sel.Fields.Fields[0].WildCard != nil || sel.From != nil {
+		return cerror.ErrFilterRuleInvalid.GenWithStack("invalid row filter expression `%s`", expr)
+	}
+	return nil
+}
+
+// NewRowFilter creates a RowFilter, it returns nil if there is no row filter
+// rule in the configuration.
+func NewRowFilter(cfg *config.ReplicaConfig, tz *time.Location) (*RowFilter, error) {
+	rules, err := newRowFilterRules(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return newRowFilter(rules, tz), nil
+}
+
+func newRowFilter(rules []*rowFilterRule, tz *time.Location) *RowFilter {
+	sctx := mock.NewContext()
+	if tz != nil {
+		sctx.GetSessionVars().TimeZone = tz
+		sctx.GetSessionVars().StmtCtx.TimeZone = tz
+	}
+	return &RowFilter{
+		rules: rules,
+		tz:    tz,
+		sctx:  sctx,
+		exprs: make(map[model.TableID]*rowFilterExpr),
+	}
+}
+
+// Clone creates a RowFilter with the same rules, which has its own session
+// context and expressions, it returns nil if the filter is nil.
+func (f *RowFilter) Clone() *RowFilter {
+	if f == nil {
+		return nil
+	}
+	return newRowFilter(f.rules, f.tz)
+}
+
+// ShouldIgnoreRow returns true if the row changed event should not be
+// replicated. The row and preRow are the decoded datums of the new and old
+// values by column ID, and nil means the value does not exist.
+func (f *RowFilter) ShouldIgnoreRow(
+	tableInfo *model.TableInfo, row, preRow map[int64]types.Datum,
+) (bool, error) {
+	if f == nil {
+		return false, nil
+	}
+	e, err := f.getExpr(tableInfo)
+	if err != nil {
+		return false, err
+	}
+	if e.rule == nil {
+		return false, nil
+	}
+	for _, datums := range []map[int64]types.Datum{row, preRow} {
+		if datums == nil {
+			continue
+		}
+		matched, err := f.eval(e, tableInfo, datums)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (f *RowFilter) getExpr(tableInfo *model.TableInfo) (*rowFilterExpr, error) {
+	e, ok := f.exprs[tableInfo.ID]
+	if ok && e.tableInfoVersion == tableInfo.TableInfoVersion {
+		return e, nil
+	}
+
+	e = &rowFilterExpr{tableInfoVersion: tableInfo.TableInfoVersion}
+	schema, table := tableInfo.TableName.Schema, tableInfo.TableName.Table
+	for _, rule := range f.rules {
+		if rule.filter.MatchTable(schema, table) {
+			e.rule = rule
+			break
+		}
+	}
+	if e.rule != nil {
+		var err error
+		e.expr, err = expression.ParseSimpleExprWithTableInfo(f.sctx, e.rule.expression, tableInfo.TableInfo)
+		if err != nil {
+			return nil, cerror.ErrEvalRowFilter.Wrap(err).GenWithStackByArgs(e.rule.expression, tableInfo.TableName.String())
+		}
+	}
+	f.exprs[tableInfo.ID] = e
+	return e, nil
+}
+
+// eval evaluates the expression with the datums, a null result means the row
+// doesn't satisfy the expression.
+func (f *RowFilter) eval(e *rowFilterExpr, tableInfo *model.TableInfo, datums map[int64]types.Datum) (bool, error) {
+	// the columns of the expression are the public columns of the table
+	cols := tableInfo.Cols()
+	values := make([]types.Datum, len(cols))
+	for i, col := range cols {
+		if d, ok := datums[col.ID]; ok {
+			values[i] = d
+		} else {
+			values[i] = types.NewDatum(col.GetOriginDefaultValue())
+		}
+	}
+	d, err := e.expr.Eval(chunk.MutRowFromDatums(values).ToRow())
+	if err != nil {
+		return false, cerror.ErrEvalRowFilter.Wrap(err).GenWithStackByArgs(e.rule.expression, tableInfo.TableName.String())
+	}
+	if d.IsNull() {
+		return false, nil
+	}
+	matched, err := d.ToBool(f.sctx.GetSessionVars().StmtCtx)
+	if err != nil {
+		return false, cerror.ErrEvalRowFilter.Wrap(err).GenWithStackByArgs(e.rule.expression, tableInfo.TableName.String())
+	}
+	return matched != 0, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	timodel "github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newRowFilterTestTable(id int64, schema, table string, version uint64) *model.TableInfo {
+	newCol := func(id int64, name string, tp byte) *timodel.ColumnInfo {
+		return &timodel.ColumnInfo{
+			ID:        id,
+			Name:      timodel.NewCIStr(name),
+			Offset:    int(id - 1),
+			State:     timodel.StatePublic,
+			FieldType: *types.NewFieldType(tp),
+		}
+	}
+	return model.WrapTableInfo(1, schema, version, &timodel.TableInfo{
+		ID:   id,
+		Name: timodel.NewCIStr(table),
+		Columns: []*timodel.ColumnInfo{
+			newCol(1, "id", mysql.TypeLonglong),
+			newCol(2, "region", mysql.TypeVarchar),
+		},
+	})
+}
+
+func TestVerifyRowFilters(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	require.Nil(t, VerifyRowFilters(cfg))
+	cfg.Filter.RowFilters = []*config.RowFilterRule{
+		{Matcher: []string{"test.*"}, Expression: "region = 'us-west' AND id > 10"},
+	}
+	require.Nil(t, VerifyRowFilters(cfg))
+
+	for _, rule := range []*config.RowFilterRule{
+		{Matcher: []string{"test.*"}, Expression: " "},
+		{Matcher: []string{"test.*"}, Expression: "region = "},
+		{Matcher: []string{"test.*"}, Expression: "id, region"},
+		{Matcher: []string{"test.*"}, Expression: "id FROM t"},
+		{Matcher: []string{"test.*"}, Expression: "id; DROP TABLE t"},
+		{Matcher: []string{"[test.*"}, Expression: "id > 1"},
+	} {
+		cfg.Filter.RowFilters = []*config.RowFilterRule{rule}
+		require.Regexp(t, ".*ErrFilterRuleInvalid.*", VerifyRowFilters(cfg), rule.Expression)
+	}
+}
+
+func TestRowFilter(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GetDefaultReplicaConfig()
+	f, err := NewRowFilter(cfg, nil)
+	require.Nil(t, err)
+	require.Nil(t, f)
+	require.Nil(t, f.Clone())
+	// a nil filter ignores nothing
+	ignore, err := f.ShouldIgnoreRow(newRowFilterTestTable(100, "test", "t1", 1), nil, nil)
+	require.Nil(t, err)
+	require.False(t, ignore)
+
+	cfg.Filter.RowFilters = []*config.RowFilterRule{
+		{Matcher: []string{"test.t1"}, Expression: "region = 'us-west'"},
+		{Matcher: []string{"test.*"}, Expression: "id > 10"},
+	}
+	f, err = NewRowFilter(cfg, nil)
+	require.Nil(t, err)
+
+	row := func(id int64, region interface{}) map[int64]types.Datum {
+		return map[int64]types.Datum{1: types.NewIntDatum(id), 2: types.NewDatum(region)}
+	}
+	t1 := newRowFilterTestTable(100, "test", "t1", 1)
+	cases := []struct {
+		table       *model.TableInfo
+		row, preRow map[int64]types.Datum
+		ignore      bool
+	}{
+		// insert
+		{table: t1, row: row(1, "us-west"), ignore: false},
+		{table: t1, row: row(100, "us-east"), ignore: true},
+		// a null result doesn't satisfy the expression
+		{table: t1, row: row(1, nil), ignore: true},
+		// update
+		{table: t1, row: row(1, "us-east"), preRow: row(1, "us-west"), ignore: false},
+		{table: t1, row: row(1, "us-east"), preRow: row(1, "eu"), ignore: true},
+		// delete
+		{table: t1, preRow: row(1, "us-west"), ignore: false},
+		// the second rule is used for the other tables
+		{table: newRowFilterTestTable(101, "test", "t3", 1), row: row(11, "us-east"), ignore: false},
+		{table: newRowFilterTestTable(102, "test", "t2", 1), row: row(1, "us-west"), ignore: true},
+		// the tables matched by no rule are not filtered
+		{table: newRowFilterTestTable(103, "other", "t1", 1), row: row(1, "us-east"), ignore: false},
+	}
+	// the cloned filter builds its own expressions
+	clone := f.Clone()
+	require.NotSame(t, f.sctx, clone.sctx)
+	for i, cs := range cases {
+		for _, f := range []*RowFilter{f, clone} {
+			ignore, err := f.ShouldIgnoreRow(cs.table, cs.row, cs.preRow)
+			require.Nil(t, err)
+			require.Equal(t, cs.ignore, ignore, "case %d", i)
+		}
+	}
+	require.NotSame(t, f.exprs[t1.ID].expr, clone.exprs[t1.ID].expr)
+
+	// the expression is rebuilt after the table info changes
+	t1 = newRowFilterTestTable(100, "test", "t1", 2)
+	t1.Columns[1].Name = timodel.NewCIStr("zone")
+	_, err = f.ShouldIgnoreRow(t1, row(1, "us-west"), nil)
+	require.Regexp(t, ".*ErrEvalRowFilter.*", err)
+}
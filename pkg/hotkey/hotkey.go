@@ -0,0 +1,210 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hotkey
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+)
+
+// DefaultCapacity is the default number of keys counted by a tracker.
+const DefaultCapacity = 128
+
+// Key is a hot key and the number of times it is observed.
+type Key struct {
+	Key   string `json:"key"`
+	Count uint64 `json:"count"`
+	// Error is the upper bound of the overestimation of Count, which is not
+	// zero only if the key replaced a less frequent key in the tracker.
+	Error uint64 `json:"error,omitempty"`
+}
+
+type counter struct {
+	Key
+	index int
+}
+
+type counterHeap []*counter
+
+func (h counterHeap) Len() int           { return len(h) }
+func (h counterHeap) Less(i, j int) bool { return h[i].Count < h[j].Count }
+func (h counterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *counterHeap) Push(x interface{}) {
+	c := x.(*counter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *counterHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// Tracker finds the most frequent keys of a stream approximately by the
+// space-saving algorithm, which counts a fixed number of keys. When a new key
+// is observed and the tracker is full, the least frequent key is replaced.
+// Tracker is safe for concurrent use, and a nil Tracker ignores all keys.
+type Tracker struct {
+	mu       sync.Mutex
+	capacity int
+	counters map[string]*counter
+	heap     counterHeap
+}
+
+// NewTracker creates a tracker counting at most capacity keys.
+func NewTracker(capacity int) *Tracker {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Tracker{
+		capacity: capacity,
+		counters: make(map[string]*counter, capacity),
+		heap:     make(counterHeap, 0, capacity),
+	}
+}
+
+// Observe records an occurrence of the key.
+func (t *Tracker) Observe(key string) {
+	t.Add(key, 1)
+}
+
+// Add records n occurrences of the key.
+func (t *Tracker) Add(key string, n uint64) {
+	if t == nil || n == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if c, ok := t.counters[key]; ok {
+		c.Count += n
+		heap.Fix(&t.heap, c.index)
+		return
+	}
+	if len(t.heap) < t.capacity {
+		c := &counter{Key: Key{Key: key, Count: n}}
+		heap.Push(&t.heap, c)
+		t.counters[key] = c
+		return
+	}
+	// replace the least frequent key, whose count is inherited as the error
+	c := t.heap[0]
+	delete(t.counters, c.Key.Key)
+	c.Key = Key{Key: key, Count: c.Count + n, Error: c.Count}
+	t.counters[key] = c
+	heap.Fix(&t.heap, 0)
+}
+
+// Top returns at most n most frequent keys in descending order of the count,
+// n <= 0 means all the counted keys.
+func (t *Tracker) Top(n int) []Key {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	keys := make([]Key, 0, len(t.heap))
+	for _, c := range t.heap {
+		keys = append(keys, c.Key)
+	}
+	t.mu.Unlock()
+	return topKeys(keys, n)
+}
+
+// Reset clears all the counted keys.
+func (t *Tracker) Reset() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.counters = make(map[string]*counter, t.capacity)
+	t.heap = t.heap[:0]
+}
+
+func topKeys(keys []Key, n int) []Key {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Count != keys[j].Count {
+			return keys[i].Count > keys[j].Count
+		}
+		return keys[i].Key < keys[j].Key
+	})
+	if n > 0 && len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+// Report is the hot keys of a source, such as the causality of a sink.
+type Report struct {
+	Source string `json:"source"`
+	Keys   []Key  `json:"keys"`
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[*Tracker]string)
+)
+
+// Register registers a tracker of the source so that its hot keys are
+// included in Reports. Trackers of the same source are merged in reports.
+// The returned function unregisters the tracker.
+func Register(source string, t *Tracker) (unregister func()) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[t] = source
+	return func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		delete(registry, t)
+	}
+}
+
+// Reports returns the top n hot keys of all the registered sources in the
+// order of the source name, n <= 0 means all the counted keys.
+func Reports(n int) []Report {
+	registryMu.Lock()
+	sources := make(map[string][]*Tracker)
+	for t, source := range registry {
+		sources[source] = append(sources[source], t)
+	}
+	registryMu.Unlock()
+
+	reports := make([]Report, 0, len(sources))
+	for source, trackers := range sources {
+		merged := make(map[string]Key)
+		for _, t := range trackers {
+			for _, key := range t.Top(0) {
+				m := merged[key.Key]
+				m.Key = key.Key
+				m.Count += key.Count
+				m.Error += key.Error
+				merged[key.Key] = m
+			}
+		}
+		keys := make([]Key, 0, len(merged))
+		for _, key := range merged {
+			keys = append(keys, key)
+		}
+		reports = append(reports, Report{Source: source, Keys: topKeys(keys, n)})
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Source < reports[j].Source })
+	return reports
+}
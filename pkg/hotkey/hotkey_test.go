@@ -0,0 +1,89 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hotkey
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	t.Parallel()
+	tracker := NewTracker(3)
+	for i := 0; i < 10; i++ {
+		tracker.Observe("a")
+	}
+	tracker.Add("b", 5)
+	tracker.Observe("c")
+	require.Equal(t, []Key{{Key: "a", Count: 10}, {Key: "b", Count: 5}}, tracker.Top(2))
+
+	// d replaces c, which is the least frequent key
+	tracker.Add("d", 2)
+	require.Equal(t, []Key{
+		{Key: "a", Count: 10},
+		{Key: "b", Count: 5},
+		{Key: "d", Count: 3, Error: 1},
+	}, tracker.Top(0))
+
+	tracker.Reset()
+	require.Empty(t, tracker.Top(0))
+
+	// the hot keys survive a stream of distinct cold keys
+	tracker = NewTracker(10)
+	tracker.Add("a", 50)
+	tracker.Add("b", 30)
+	for i := 0; i < 100; i++ {
+		tracker.Observe(fmt.Sprintf("cold-%d", i))
+	}
+	top := tracker.Top(2)
+	require.Equal(t, Key{Key: "a", Count: 50}, top[0])
+	require.Equal(t, Key{Key: "b", Count: 30}, top[1])
+
+	var nilTracker *Tracker
+	nilTracker.Observe("a")
+	require.Nil(t, nilTracker.Top(0))
+}
+
+func TestReports(t *testing.T) {
+	t.Parallel()
+	t1, t2, t3 := NewTracker(0), NewTracker(0), NewTracker(0)
+	unregister1 := Register("test-reports/source-b", t1)
+	unregister2 := Register("test-reports/source-b", t2)
+	unregister3 := Register("test-reports/source-a", t3)
+	t1.Add("k1", 3)
+	t1.Add("k2", 1)
+	t2.Add("k2", 5)
+	t3.Add("k3", 1)
+
+	filter := func(reports []Report) []Report {
+		var result []Report
+		for _, report := range reports {
+			if len(report.Source) > 13 && report.Source[:13] == "test-reports/" {
+				result = append(result, report)
+			}
+		}
+		return result
+	}
+	require.Equal(t, []Report{
+		{Source: "test-reports/source-a", Keys: []Key{{Key: "k3", Count: 1}}},
+		{Source: "test-reports/source-b", Keys: []Key{{Key: "k2", Count: 6}}},
+	}, filter(Reports(1)))
+
+	unregister1()
+	unregister2()
+	unregister3()
+	require.Empty(t, filter(Reports(0)))
+}
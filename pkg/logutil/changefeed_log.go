@@ -0,0 +1,147 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// changefeedFieldKeys are the keys of the fields which carry the changefeed ID in the logs of the modules.
+var changefeedFieldKeys = map[string]struct{}{
+	"changefeed":    {},
+	"changefeedID":  {},
+	"changefeed-id": {},
+}
+
+// changefeedFromFields returns the changefeed ID carried by the fields.
+func changefeedFromFields(fields []zapcore.Field) (string, bool) {
+	for _, field := range fields {
+		if _, ok := changefeedFieldKeys[field.Key]; ok && field.Type == zapcore.StringType && field.String != "" {
+			return field.String, true
+		}
+	}
+	return "", false
+}
+
+// changefeedLogRouter holds the cores to write the logs of each changefeed to `{dir}/{changefeed-id}.log`.
+type changefeedLogRouter struct {
+	cfg *Config
+
+	mu    sync.Mutex
+	cores map[string]zapcore.Core
+}
+
+func newChangefeedLogRouter(cfg *Config) (*changefeedLogRouter, error) {
+	if err := os.MkdirAll(cfg.ChangefeedDir, 0o755); err != nil {
+		return nil, errors.Annotate(err, "can't create the directory of changefeed logs")
+	}
+	return &changefeedLogRouter{cfg: cfg, cores: make(map[string]zapcore.Core)}, nil
+}
+
+// core returns the core to write the logs of the changefeed, which is created at the first time.
+func (r *changefeedLogRouter) core(changefeed string) (zapcore.Core, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if core, ok := r.cores[changefeed]; ok {
+		return core, nil
+	}
+
+	// the changefeed ID is validated when the changefeed is created, make sure that it's a file name here.
+	name := strings.ReplaceAll(changefeed, string(filepath.Separator), "_")
+	// the level is checked by the core of the main log before writing.
+	_, props, err := log.InitLogger(&log.Config{
+		Level:  "debug",
+		Format: r.cfg.Format,
+		File: log.FileLogConfig{
+			Filename:   filepath.Join(r.cfg.ChangefeedDir, name+".log"),
+			MaxSize:    r.cfg.FileMaxSize,
+			MaxDays:    r.cfg.FileMaxDays,
+			MaxBackups: r.cfg.FileMaxBackups,
+		},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	r.cores[changefeed] = props.Core
+	return props.Core, nil
+}
+
+func (r *changefeedLogRouter) sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var err error
+	for _, core := range r.cores {
+		err = multierr.Append(err, core.Sync())
+	}
+	return err
+}
+
+// changefeedCore writes the logs to the main log, and the ones carrying a changefeed ID
+// to the log of the changefeed too.
+type changefeedCore struct {
+	zapcore.Core
+	router *changefeedLogRouter
+
+	// the changefeed and the fields added by With
+	changefeed string
+	fields     []zapcore.Field
+}
+
+func (c *changefeedCore) With(fields []zapcore.Field) zapcore.Core {
+	changefeed := c.changefeed
+	if id, ok := changefeedFromFields(fields); ok {
+		changefeed = id
+	}
+	return &changefeedCore{
+		Core:       c.Core.With(fields),
+		router:     c.router,
+		changefeed: changefeed,
+		fields:     append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *changefeedCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *changefeedCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	err := c.Core.Write(ent, fields)
+	changefeed := c.changefeed
+	if id, ok := changefeedFromFields(fields); ok {
+		changefeed = id
+	}
+	if changefeed == "" {
+		return err
+	}
+	core, cfErr := c.router.core(changefeed)
+	if cfErr == nil {
+		cfErr = core.Write(ent, append(c.fields[:len(c.fields):len(c.fields)], fields...))
+	}
+	return multierr.Append(err, cfErr)
+}
+
+func (c *changefeedCore) Sync() error {
+	return multierr.Append(c.Core.Sync(), c.router.sync())
+}
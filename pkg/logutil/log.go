@@ -32,6 +32,7 @@ var _globalP *log.ZapProperties
 
 const (
 	defaultLogLevel   = "info"
+	defaultLogFormat  = "text"
 	defaultLogMaxDays = 7
 	defaultLogMaxSize = 512 // MB
 )
@@ -48,6 +49,11 @@ type Config struct {
 	FileMaxDays int `toml:"max-days" json:"max-days"`
 	// Maximum number of old log files to retain.
 	FileMaxBackups int `toml:"max-backups" json:"max-backups"`
+	// Log format, one of text and json, default is text.
+	Format string `toml:"format" json:"format"`
+	// The directory to write the logs of each changefeed to a separate file,
+	// leave empty to write them to the log file only.
+	ChangefeedDir string `toml:"changefeed-dir" json:"changefeed-dir"`
 }
 
 // Adjust adjusts config
@@ -58,6 +64,9 @@ func (cfg *Config) Adjust() {
 	if cfg.Level == "warning" {
 		cfg.Level = "warn"
 	}
+	if len(cfg.Format) == 0 {
+		cfg.Format = defaultLogFormat
+	}
 	if cfg.FileMaxSize == 0 {
 		cfg.FileMaxSize = defaultLogMaxSize
 	}
@@ -83,8 +92,14 @@ func SetLogLevel(level string) error {
 
 // InitLogger initializes logger
 func InitLogger(cfg *Config) error {
+	switch cfg.Format {
+	case "", "text", "json":
+	default:
+		return errors.Errorf("unsupported log format: %s", cfg.Format)
+	}
 	pclogConfig := &log.Config{
-		Level: cfg.Level,
+		Level:  cfg.Level,
+		Format: cfg.Format,
 		File: log.FileLogConfig{
 			Filename:   cfg.File,
 			MaxSize:    cfg.FileMaxSize,
@@ -104,6 +119,16 @@ func InitLogger(cfg *Config) error {
 	// error itself.
 	lg = lg.WithOptions(zap.AddStacktrace(zap.DPanicLevel))
 
+	if len(cfg.ChangefeedDir) > 0 {
+		router, err := newChangefeedLogRouter(cfg)
+		if err != nil {
+			return err
+		}
+		lg = lg.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return &changefeedCore{Core: core, router: router}
+		}))
+	}
+
 	log.ReplaceGlobals(lg, _globalP)
 
 	var level zapcore.Level
@@ -15,7 +15,10 @@ package logutil
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/check"
@@ -61,6 +64,51 @@ func (s *logSuite) TestInitLoggerAndSetLogLevel(c *check.C) {
 	c.Assert(err, check.NotNil)
 }
 
+func (s *logSuite) TestChangefeedLog(c *check.C) {
+	// lumberjack starts a goroutine to clean up the old files, so the leak isn't checked here.
+	dir := c.MkDir()
+	cfg := &Config{
+		Level:         "info",
+		File:          filepath.Join(dir, "cdc.log"),
+		Format:        "json",
+		ChangefeedDir: filepath.Join(dir, "changefeeds"),
+	}
+	cfg.Adjust()
+	err := InitLogger(cfg)
+	c.Assert(err, check.IsNil)
+
+	log.Info("changefeed created", zap.String("changefeed", "cf1"))
+	logger := log.L().With(zap.String("changefeed-id", "cf2"))
+	logger.Warn("table added", zap.Int64("tableID", 1))
+	logger.Debug("debug log is filtered by the level")
+	log.Info("log without changefeed")
+	c.Assert(log.L().Sync(), check.IsNil)
+
+	readLines := func(path string) []map[string]interface{} {
+		data, err := os.ReadFile(path)
+		c.Assert(err, check.IsNil)
+		var lines []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			m := make(map[string]interface{})
+			c.Assert(json.Unmarshal([]byte(line), &m), check.IsNil)
+			lines = append(lines, m)
+		}
+		return lines
+	}
+	c.Assert(readLines(cfg.File), check.HasLen, 3)
+	lines := readLines(filepath.Join(cfg.ChangefeedDir, "cf1.log"))
+	c.Assert(lines, check.HasLen, 1)
+	c.Assert(lines[0]["message"], check.Equals, "changefeed created")
+	lines = readLines(filepath.Join(cfg.ChangefeedDir, "cf2.log"))
+	c.Assert(lines, check.HasLen, 1)
+	c.Assert(lines[0]["message"], check.Equals, "table added")
+	c.Assert(lines[0]["changefeed-id"], check.Equals, "cf2")
+	c.Assert(lines[0]["tableID"], check.Equals, float64(1))
+
+	cfg.Format = "yaml"
+	c.Assert(InitLogger(cfg), check.ErrorMatches, "unsupported log format: yaml")
+}
+
 func (s *logSuite) TestZapErrorFilter(c *check.C) {
 	defer testleak.AfterTest(c)()
 	var (
@@ -0,0 +1,241 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"go.uber.org/zap"
+)
+
+// Component is a component whose memory consumption is accounted by the Governor.
+type Component string
+
+// Components sharing the memory limit
+const (
+	// ComponentSorter accounts the events buffered in memory by the sorter.
+	ComponentSorter Component = "sorter"
+	// ComponentSink accounts the events on their way from the sorter to the
+	// sink, including the mounter queue and the pending batches in the sink.
+	ComponentSink Component = "sink"
+)
+
+// componentWeights are the weights of the shares of the memory limit. A
+// component is always allowed to consume memory within its share, and it can
+// borrow the memory unused by the others as long as the total consumption is
+// below the limit. Once the limit is reached, only the components consuming
+// less than their shares can make progress, so the backpressure is applied to
+// the components in proportion to their overuse, and the downstream
+// components are always able to drain the upstream ones.
+var componentWeights = map[Component]uint64{
+	ComponentSorter: 6,
+	ComponentSink:   4,
+}
+
+var totalWeight = func() uint64 {
+	var total uint64
+	for _, w := range componentWeights {
+		total += w
+	}
+	return total
+}()
+
+// Governor tracks the memory consumed by the components of a server against
+// a server-level limit.
+type Governor struct {
+	mu    sync.Mutex
+	limit uint64
+	total uint64
+	used  map[Component]uint64
+	// notify is closed and replaced when the memory is released or the limit
+	// is changed, to wake up the blocked consumers.
+	notify chan struct{}
+}
+
+var globalGovernor = NewGovernor(0)
+
+// GlobalGovernor returns the Governor shared by all the components of the server.
+func GlobalGovernor() *Governor {
+	return globalGovernor
+}
+
+// NewGovernor creates a new Governor, limit is the memory limit in bytes and
+// 0 means unlimited.
+func NewGovernor(limit uint64) *Governor {
+	g := &Governor{
+		used:   make(map[Component]uint64, len(componentWeights)),
+		notify: make(chan struct{}),
+	}
+	g.SetLimit(limit)
+	return g
+}
+
+// SetLimit changes the memory limit, 0 means unlimited.
+func (g *Governor) SetLimit(limit uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.limit = limit
+	memoryLimitGauge.Set(float64(limit))
+	g.wakeUpLocked()
+}
+
+// Limit returns the memory limit.
+func (g *Governor) Limit() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.limit
+}
+
+// Used returns the memory consumed by the component.
+func (g *Governor) Used(c Component) uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.used[c]
+}
+
+// Total returns the memory consumed by all the components.
+func (g *Governor) Total() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.total
+}
+
+// Available returns whether the component can consume more memory without
+// being blocked.
+func (g *Governor) Available(c Component) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.limit == 0 || g.total < g.limit || g.used[c] < g.shareLocked(c)
+}
+
+// Acquire consumes nBytes of memory for the component, it blocks until the
+// memory is available or the context is done. blockCallBack is called before
+// blocking, which could be used to flush the downstream to free some memory.
+func (g *Governor) Acquire(ctx context.Context, c Component, nBytes uint64, blockCallBack func() error) error {
+	g.mu.Lock()
+	if g.allowLocked(c, nBytes) {
+		g.consumeLocked(c, nBytes)
+		g.mu.Unlock()
+		return nil
+	}
+	g.mu.Unlock()
+
+	blockedCounter.WithLabelValues(string(c)).Inc()
+	if blockCallBack != nil {
+		if err := blockCallBack(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for {
+		g.mu.Lock()
+		if g.allowLocked(c, nBytes) {
+			g.consumeLocked(c, nBytes)
+			g.mu.Unlock()
+			return nil
+		}
+		notify := g.notify
+		g.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-notify:
+		}
+	}
+}
+
+// ForceAcquire consumes nBytes of memory for the component without blocking,
+// it should be used only if blocking could cause a deadlock.
+func (g *Governor) ForceAcquire(c Component, nBytes uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.consumeLocked(c, nBytes)
+}
+
+// Release frees nBytes of memory consumed by the component.
+func (g *Governor) Release(c Component, nBytes uint64) {
+	if nBytes == 0 {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	used := g.used[c]
+	if used < nBytes {
+		log.Warn("memory governor: releasing more than consumed, report a bug",
+			zap.String("component", string(c)),
+			zap.Uint64("consumed", used),
+			zap.Uint64("released", nBytes))
+		nBytes = used
+	}
+	g.used[c] = used - nBytes
+	g.total -= nBytes
+	memoryUsageGauge.WithLabelValues(string(c)).Set(float64(g.used[c]))
+	g.wakeUpLocked()
+}
+
+// WaitAvailable blocks until the total memory consumption is below the
+// limit, it is used by the ingress of the event streams, which does not
+// hold memory itself but feeds the other components.
+func (g *Governor) WaitAvailable(ctx context.Context) error {
+	for {
+		g.mu.Lock()
+		if g.limit == 0 || g.total < g.limit {
+			g.mu.Unlock()
+			return nil
+		}
+		notify := g.notify
+		g.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-notify:
+		}
+	}
+}
+
+// Share returns the share of the memory limit guaranteed to the component.
+func (g *Governor) Share(c Component) uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.shareLocked(c)
+}
+
+func (g *Governor) shareLocked(c Component) uint64 {
+	return g.limit / totalWeight * componentWeights[c]
+}
+
+func (g *Governor) allowLocked(c Component, nBytes uint64) bool {
+	if g.limit == 0 || g.total+nBytes <= g.limit {
+		return true
+	}
+	used := g.used[c]
+	// A component consuming nothing is always allowed to make progress, even
+	// if nBytes is larger than its share.
+	return used == 0 || used+nBytes <= g.shareLocked(c)
+}
+
+func (g *Governor) consumeLocked(c Component, nBytes uint64) {
+	g.used[c] += nBytes
+	g.total += nBytes
+	memoryUsageGauge.WithLabelValues(string(c)).Set(float64(g.used[c]))
+}
+
+func (g *Governor) wakeUpLocked() {
+	close(g.notify)
+	g.notify = make(chan struct{})
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGovernorUnlimited(t *testing.T) {
+	t.Parallel()
+	g := NewGovernor(0)
+	ctx := context.Background()
+	require.Nil(t, g.Acquire(ctx, ComponentSorter, 1<<40, nil))
+	require.Nil(t, g.WaitAvailable(ctx))
+	require.True(t, g.Available(ComponentSink))
+	require.Equal(t, uint64(1<<40), g.Used(ComponentSorter))
+	g.Release(ComponentSorter, 1<<40)
+	require.Equal(t, uint64(0), g.Total())
+}
+
+func TestGovernorShares(t *testing.T) {
+	t.Parallel()
+	g := NewGovernor(1000)
+	ctx := context.Background()
+	require.Equal(t, uint64(600), g.Share(ComponentSorter))
+	require.Equal(t, uint64(400), g.Share(ComponentSink))
+
+	// the sorter borrows the memory unused by the sink
+	require.Nil(t, g.Acquire(ctx, ComponentSorter, 900, nil))
+	require.True(t, g.Available(ComponentSorter))
+	require.Nil(t, g.Acquire(ctx, ComponentSorter, 100, nil))
+	require.False(t, g.Available(ComponentSorter))
+	// the sink is still able to make progress within its share
+	require.True(t, g.Available(ComponentSink))
+	require.Nil(t, g.Acquire(ctx, ComponentSink, 300, nil))
+	require.Equal(t, uint64(1300), g.Total())
+
+	ctx1, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	require.Equal(t, context.DeadlineExceeded, errors.Cause(g.WaitAvailable(ctx1)))
+
+	// releasing more than consumed is tolerated
+	g.Release(ComponentSink, 400)
+	require.Equal(t, uint64(0), g.Used(ComponentSink))
+	require.Equal(t, uint64(1000), g.Total())
+}
+
+func TestGovernorBlocking(t *testing.T) {
+	t.Parallel()
+	g := NewGovernor(1000)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.Nil(t, g.Acquire(ctx, ComponentSorter, 1000, nil))
+
+	blocked := make(chan struct{})
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- g.Acquire(ctx, ComponentSorter, 100, func() error {
+			close(blocked)
+			return nil
+		})
+	}()
+	<-blocked
+	select {
+	case <-acquired:
+		t.Fatal("the acquirement should be blocked")
+	case <-time.After(50 * time.Millisecond):
+	}
+	g.Release(ComponentSorter, 200)
+	require.Nil(t, <-acquired)
+	require.Equal(t, uint64(900), g.Used(ComponentSorter))
+
+	// the force acquirement is never blocked
+	g.ForceAcquire(ComponentSorter, 500)
+	require.Equal(t, uint64(1400), g.Total())
+
+	// raising the limit wakes up the blocked consumers
+	go func() {
+		acquired <- g.Acquire(ctx, ComponentSorter, 100, nil)
+	}()
+	g.SetLimit(2000)
+	require.Nil(t, <-acquired)
+
+	// the blocked acquirement is interrupted by the context
+	ctx1, cancel1 := context.WithCancel(ctx)
+	cancel1()
+	require.Equal(t, context.Canceled, errors.Cause(g.Acquire(ctx1, ComponentSorter, 1000, nil)))
+}
@@ -0,0 +1,47 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memquota
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	memoryUsageGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "memory_quota",
+			Name:      "usage_bytes",
+			Help:      "memory consumed by the components accounted by the memory governor",
+		}, []string{"component"})
+	memoryLimitGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "memory_quota",
+			Name:      "limit_bytes",
+			Help:      "memory limit of the memory governor, 0 means unlimited",
+		})
+	blockedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "memory_quota",
+			Name:      "blocked_count",
+			Help:      "number of times that a component is blocked by the memory governor",
+		}, []string{"component"})
+)
+
+// InitMetrics registers all metrics in this file
+func InitMetrics(registry *prometheus.Registry) {
+	registry.MustRegister(memoryUsageGauge)
+	registry.MustRegister(memoryLimitGauge)
+	registry.MustRegister(blockedCounter)
+}
@@ -28,18 +28,23 @@ import (
 
 // GlobalReactorState represents a global state which stores all key-value pairs in ETCD
 type GlobalReactorState struct {
-	Owner          map[string]struct{}
-	Captures       map[model.CaptureID]*model.CaptureInfo
-	Changefeeds    map[model.ChangeFeedID]*ChangefeedReactorState
-	pendingPatches [][]DataPatch
+	Owner       map[string]struct{}
+	Captures    map[model.CaptureID]*model.CaptureInfo
+	Changefeeds map[model.ChangeFeedID]*ChangefeedReactorState
+	// DrainingCaptures are the captures being drained, the flags are stored
+	// apart from the capture info because the capture info is written with
+	// the lease of the capture.
+	DrainingCaptures map[model.CaptureID]struct{}
+	pendingPatches   [][]DataPatch
 }
 
 // NewGlobalState creates a new global state
 func NewGlobalState() ReactorState {
 	return &GlobalReactorState{
-		Owner:       map[string]struct{}{},
-		Captures:    make(map[model.CaptureID]*model.CaptureInfo),
-		Changefeeds: make(map[model.ChangeFeedID]*ChangefeedReactorState),
+		Owner:            map[string]struct{}{},
+		Captures:         make(map[model.CaptureID]*model.CaptureInfo),
+		Changefeeds:      make(map[model.ChangeFeedID]*ChangefeedReactorState),
+		DrainingCaptures: make(map[model.CaptureID]struct{}),
 	}
 }
 
@@ -73,6 +78,12 @@ func (s *GlobalReactorState) Update(key util.EtcdKey, value []byte, _ bool) erro
 
 		log.Info("remote capture online", zap.String("capture-id", k.CaptureID), zap.Any("info", newCaptureInfo))
 		s.Captures[k.CaptureID] = &newCaptureInfo
+	case etcd.CDCKeyTypeDrainCapture:
+		if value != nil {
+			s.DrainingCaptures[k.CaptureID] = struct{}{}
+		} else {
+			delete(s.DrainingCaptures, k.CaptureID)
+		}
 	case etcd.CDCKeyTypeChangefeedInfo,
 		etcd.CDCKeyTypeChangeFeedStatus,
 		etcd.CDCKeyTypeTaskPosition,
@@ -110,6 +121,28 @@ func (s *GlobalReactorState) GetPatches() [][]DataPatch {
 	return pendingPatches
 }
 
+// PatchDrainingCapture appends a DataPatch which marks the capture as draining
+// or not.
+func (s *GlobalReactorState) PatchDrainingCapture(captureID model.CaptureID, draining bool) {
+	k := etcd.CDCKey{
+		Tp:        etcd.CDCKeyTypeDrainCapture,
+		CaptureID: captureID,
+	}
+	patch := &SingleDataPatch{
+		Key: util.NewEtcdKey(k.String()),
+		Func: func(v []byte) ([]byte, bool, error) {
+			if draining {
+				if v != nil {
+					return v, false, nil
+				}
+				return []byte(captureID), true, nil
+			}
+			return nil, v != nil, nil
+		},
+	}
+	s.pendingPatches = append(s.pendingPatches, []DataPatch{patch})
+}
+
 // ChangefeedReactorState represents a changefeed state which stores all key-value pairs of a changefeed in ETCD
 type ChangefeedReactorState struct {
 	ID            model.ChangeFeedID
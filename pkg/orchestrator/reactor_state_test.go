@@ -88,6 +88,7 @@ func (s *stateSuite) TestChangefeedStateUpdate(c *check.C) {
 						Cyclic:           &config.CyclicConfig{},
 						Scheduler:        &config.SchedulerConfig{Tp: "table-number", PollingTime: -1},
 						Consistent:       &config.ConsistentConfig{Level: "normal", Storage: "local"},
+						EventTrace:       &config.EventTraceConfig{},
 					},
 				},
 				Status: &model.ChangeFeedStatus{CheckpointTs: 421980719742451713, ResolvedTs: 421980720003809281},
@@ -149,6 +150,7 @@ func (s *stateSuite) TestChangefeedStateUpdate(c *check.C) {
 						Cyclic:           &config.CyclicConfig{},
 						Scheduler:        &config.SchedulerConfig{Tp: "table-number", PollingTime: -1},
 						Consistent:       &config.ConsistentConfig{Level: "normal", Storage: "local"},
+						EventTrace:       &config.EventTraceConfig{},
 					},
 				},
 				Status: &model.ChangeFeedStatus{CheckpointTs: 421980719742451713, ResolvedTs: 421980720003809281},
@@ -217,6 +219,7 @@ func (s *stateSuite) TestChangefeedStateUpdate(c *check.C) {
 						Cyclic:           &config.CyclicConfig{},
 						Scheduler:        &config.SchedulerConfig{Tp: "table-number", PollingTime: -1},
 						Consistent:       &config.ConsistentConfig{Level: "normal", Storage: "local"},
+						EventTrace:       &config.EventTraceConfig{},
 					},
 				},
 				Status: &model.ChangeFeedStatus{CheckpointTs: 421980719742451713, ResolvedTs: 421980720003809281},
@@ -345,6 +348,7 @@ func (s *stateSuite) TestPatchInfo(c *check.C) {
 			Cyclic:     defaultConfig.Cyclic,
 			Scheduler:  defaultConfig.Scheduler,
 			Consistent: defaultConfig.Consistent,
+			EventTrace: defaultConfig.EventTrace,
 		},
 	})
 	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
@@ -363,6 +367,7 @@ func (s *stateSuite) TestPatchInfo(c *check.C) {
 			Cyclic:     defaultConfig.Cyclic,
 			Scheduler:  defaultConfig.Scheduler,
 			Consistent: defaultConfig.Consistent,
+			EventTrace: defaultConfig.EventTrace,
 		},
 	})
 	state.PatchInfo(func(info *model.ChangeFeedInfo) (*model.ChangeFeedInfo, bool, error) {
@@ -557,6 +562,7 @@ func (s *stateSuite) TestGlobalStateUpdate(c *check.C) {
 				"/tidb/cdc/owner/22317526c4fc9a37",
 				"/tidb/cdc/owner/22317526c4fc9a38",
 				"/tidb/cdc/capture/6bbc01c8-0605-4f86-a0f9-b3119109b225",
+				"/tidb/cdc/drain/6bbc01c8-0605-4f86-a0f9-b3119109b225",
 				"/tidb/cdc/task/position/6bbc01c8-0605-4f86-a0f9-b3119109b225/test1",
 				"/tidb/cdc/task/workload/6bbc01c8-0605-4f86-a0f9-b3119109b225/test2",
 				"/tidb/cdc/task/workload/55551111/test2",
@@ -565,12 +571,16 @@ func (s *stateSuite) TestGlobalStateUpdate(c *check.C) {
 				`6bbc01c8-0605-4f86-a0f9-b3119109b225`,
 				`55551111`,
 				`{"id":"6bbc01c8-0605-4f86-a0f9-b3119109b225","address":"127.0.0.1:8300"}`,
+				`6bbc01c8-0605-4f86-a0f9-b3119109b225`,
 				`{"resolved-ts":421980720003809281,"checkpoint-ts":421980719742451713,"admin-job-type":0}`,
 				`{"45":{"workload":1}}`,
 				`{"46":{"workload":1}}`,
 			},
 			expected: GlobalReactorState{
 				Owner: map[string]struct{}{"22317526c4fc9a37": {}, "22317526c4fc9a38": {}},
+				DrainingCaptures: map[model.CaptureID]struct{}{
+					"6bbc01c8-0605-4f86-a0f9-b3119109b225": {},
+				},
 				Captures: map[model.CaptureID]*model.CaptureInfo{"6bbc01c8-0605-4f86-a0f9-b3119109b225": {
 					ID:            "6bbc01c8-0605-4f86-a0f9-b3119109b225",
 					AdvertiseAddr: "127.0.0.1:8300",
@@ -622,8 +632,9 @@ func (s *stateSuite) TestGlobalStateUpdate(c *check.C) {
 				``,
 			},
 			expected: GlobalReactorState{
-				Owner:    map[string]struct{}{"22317526c4fc9a38": {}},
-				Captures: map[model.CaptureID]*model.CaptureInfo{},
+				Owner:            map[string]struct{}{"22317526c4fc9a38": {}},
+				Captures:         map[model.CaptureID]*model.CaptureInfo{},
+				DrainingCaptures: map[model.CaptureID]struct{}{},
 				Changefeeds: map[model.ChangeFeedID]*ChangefeedReactorState{
 					"test2": {
 						ID:            "test2",
@@ -135,6 +135,16 @@ func (p *Pipeline) close() {
 	}
 }
 
+// OutputChannelLength returns the number of messages in the output channel of
+// each node, the key is the name of the node.
+func (p *Pipeline) OutputChannelLength() map[string]int {
+	ret := make(map[string]int, len(p.runners))
+	for _, r := range p.runners {
+		ret[r.getName()] = len(r.getOutputCh())
+	}
+	return ret
+}
+
 // Wait all the nodes exited
 func (p *Pipeline) Wait() {
 	p.runnersWg.Wait()
@@ -366,6 +366,8 @@ func TestPipelineAppendNode(t *testing.T) {
 	p.AppendNode(ctx, "echo node", echoNode{})
 	// wait the echo node sent all messages to next node
 	time.Sleep(1 * time.Second)
+	// the messages sent by the echo node are not consumed yet
+	require.Equal(t, map[string]int{"header": 0, "echo node": 5}, p.OutputChannelLength())
 
 	p.AppendNode(ctx, "check node", &checkNode{
 		t: t,
@@ -57,17 +57,24 @@ func (s *Credential) ToGRPCDialOption() (grpc.DialOption, error) {
 	return grpc.WithTransportCredentials(credentials.NewTLS(tlsCfg)), nil
 }
 
-// ToTLSConfig generates tls's config from *Security
+// ToTLSConfig generates tls's config from *Security,
+// the certificates are reloaded when the files are modified.
 func (s *Credential) ToTLSConfig() (*tls.Config, error) {
 	cfg, err := utils.ToTLSConfig(s.CAPath, s.CertPath, s.KeyPath)
-	return cfg, cerror.WrapError(cerror.ErrToTLSConfigFailed, err)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrToTLSConfigFailed, err)
+	}
+	return cfg, ReloadCertificates(cfg, s.CAPath, s.CertPath, s.KeyPath)
 }
 
 // ToTLSConfigWithVerify generates tls's config from *Security and requires
-// the remote common name to be verified.
+// the remote common name to be verified, the certificates are reloaded when the files are modified.
 func (s *Credential) ToTLSConfigWithVerify() (*tls.Config, error) {
 	cfg, err := utils.ToTLSConfigWithVerify(s.CAPath, s.CertPath, s.KeyPath, s.CertAllowedCN)
-	return cfg, cerror.WrapError(cerror.ErrToTLSConfigFailed, err)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrToTLSConfigFailed, err)
+	}
+	return cfg, ReloadCertificates(cfg, s.CAPath, s.CertPath, s.KeyPath)
 }
 
 func (s *Credential) getSelfCommonName() (string, error) {
@@ -0,0 +1,187 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// certReloader holds the certificate, the key and the CA loaded from the files,
+// and reloads them when any of the files is modified.
+type certReloader struct {
+	caPath   string
+	certPath string
+	keyPath  string
+
+	mu       sync.Mutex
+	modTimes [3]time.Time // of the CA, the certificate and the key
+	cert     *tls.Certificate
+	caPool   *x509.CertPool
+}
+
+func newCertReloader(caPath, certPath, keyPath string) (*certReloader, error) {
+	r := &certReloader{caPath: caPath, certPath: certPath, keyPath: keyPath}
+	modTimes, err := r.stat()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.reload(modTimes); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) stat() ([3]time.Time, error) {
+	var modTimes [3]time.Time
+	for i, path := range []string{r.caPath, r.certPath, r.keyPath} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return modTimes, errors.Trace(err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (r *certReloader) reload(modTimes [3]time.Time) error {
+	caPool := x509.NewCertPool()
+	ca, err := os.ReadFile(r.caPath)
+	if err != nil {
+		return errors.Annotate(err, "could not read ca certificate")
+	}
+	if !caPool.AppendCertsFromPEM(ca) {
+		return errors.New("failed to append ca certs")
+	}
+
+	var cert *tls.Certificate
+	if r.certPath != "" && r.keyPath != "" {
+		keyPair, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+		if err != nil {
+			return errors.Annotate(err, "could not load key pair")
+		}
+		cert = &keyPair
+	}
+
+	r.caPool, r.cert, r.modTimes = caPool, cert, modTimes
+	return nil
+}
+
+// load returns the certificate and the CA, which are reloaded first if any of the files is modified.
+// the ones loaded before are returned if fail to reload, for example the certificate is replaced
+// but the key is not yet, and they are reloaded again in the next call.
+func (r *certReloader) load() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modTimes, err := r.stat()
+	if err == nil && modTimes != r.modTimes {
+		err = r.reload(modTimes)
+		if err == nil {
+			log.Info("certificates reloaded", zap.String("ca", r.caPath), zap.String("cert", r.certPath))
+		}
+	}
+	if err != nil {
+		log.Warn("fail to reload certificates, use the ones loaded before",
+			zap.String("ca", r.caPath), zap.String("cert", r.certPath), zap.Error(err))
+	}
+	return r.cert, r.caPool
+}
+
+// ReloadCertificates makes the TLS config built from the files reload the certificate and the key when
+// the files are modified, so that the rotated certificates are used by the new connections without restarting
+// the process. The CA to verify the certificates of the clients is reloaded too, but the CA to verify the
+// certificates of the servers is not, as the server name to verify is unknown in the callbacks of tls.Config.
+// The config is modified in place, so it should be called before the config is cloned or used.
+func ReloadCertificates(tlsCfg *tls.Config, caPath, certPath, keyPath string) error {
+	if tlsCfg == nil {
+		return nil
+	}
+	r, err := newCertReloader(caPath, certPath, keyPath)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrToTLSConfigFailed, err)
+	}
+
+	if r.cert != nil {
+		tlsCfg.Certificates = nil
+		tlsCfg.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _ := r.load()
+			return cert, nil
+		}
+		tlsCfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := r.load()
+			return cert, nil
+		}
+	}
+	// the certificates of the clients are verified by the CA reloaded instead of ClientCAs.
+	if tlsCfg.ClientAuth == tls.RequireAndVerifyClientCert {
+		tlsCfg.ClientAuth = tls.RequireAnyClientCert
+		verifyChains := tlsCfg.VerifyPeerCertificate
+		tlsCfg.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			// the chains are verified already when the config is used by a client.
+			if verifiedChains == nil {
+				_, caPool := r.load()
+				chains, err := verifyClientCertificate(rawCerts, caPool)
+				if err != nil {
+					return err
+				}
+				verifiedChains = chains
+			}
+			if verifyChains != nil {
+				return verifyChains(rawCerts, verifiedChains)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// verifyClientCertificate verifies the certificate of a client like crypto/tls does with ClientCAs.
+func verifyClientCertificate(rawCerts [][]byte, caPool *x509.CertPool) ([][]*x509.Certificate, error) {
+	if len(rawCerts) == 0 {
+		return nil, errors.New("client didn't provide a certificate")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to parse client certificate")
+		}
+		certs = append(certs, cert)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         caPool,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	chains, err := certs[0].Verify(opts)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to verify client certificate")
+	}
+	return chains, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCertDir = "../../tests/_certificates"
+
+// copyCertificate copies the certificate file in the test directory to path, and sets its modification time.
+func copyCertificate(t *testing.T, name, path string, modTime time.Time) {
+	data, err := os.ReadFile(filepath.Join(testCertDir, name))
+	require.Nil(t, err)
+	require.Nil(t, os.WriteFile(path, data, 0o600))
+	require.Nil(t, os.Chtimes(path, modTime, modTime))
+}
+
+func getCommonName(t *testing.T, cert *tls.Certificate) string {
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.Nil(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestReloadCertificates(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	cd := &Credential{
+		CAPath:   filepath.Join(dir, "ca.pem"),
+		CertPath: filepath.Join(dir, "cert.pem"),
+		KeyPath:  filepath.Join(dir, "key.pem"),
+	}
+	copyCertificate(t, "ca.pem", cd.CAPath, now)
+	copyCertificate(t, "server.pem", cd.CertPath, now)
+	copyCertificate(t, "server-key.pem", cd.KeyPath, now)
+
+	tlsCfg, err := cd.ToTLSConfig()
+	require.Nil(t, err)
+	require.Len(t, tlsCfg.Certificates, 0)
+	cert, err := tlsCfg.GetCertificate(nil)
+	require.Nil(t, err)
+	require.Equal(t, "tidb-server", getCommonName(t, cert))
+
+	// the certificate is reloaded after both the certificate and the key are rotated
+	copyCertificate(t, "client.pem", cd.CertPath, now.Add(time.Minute))
+	cert, err = tlsCfg.GetClientCertificate(nil)
+	require.Nil(t, err)
+	require.Equal(t, "tidb-server", getCommonName(t, cert))
+	copyCertificate(t, "client-key.pem", cd.KeyPath, now.Add(time.Minute))
+	cert, err = tlsCfg.GetClientCertificate(nil)
+	require.Nil(t, err)
+	require.Equal(t, "client", getCommonName(t, cert))
+
+	// the certificate loaded before is used if the files are removed
+	require.Nil(t, os.Remove(cd.CertPath))
+	cert, err = tlsCfg.GetCertificate(nil)
+	require.Nil(t, err)
+	require.Equal(t, "client", getCommonName(t, cert))
+
+	// the config without TLS is not changed
+	require.Nil(t, ReloadCertificates(nil, "", "", ""))
+	cd.CAPath = filepath.Join(dir, "not-exist.pem")
+	_, err = cd.ToTLSConfig()
+	require.NotNil(t, err)
+}
+
+func TestReloadCertificatesHandshake(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	serverCd := &Credential{
+		CAPath:        filepath.Join(dir, "ca.pem"),
+		CertPath:      filepath.Join(dir, "server.pem"),
+		KeyPath:       filepath.Join(dir, "server-key.pem"),
+		CertAllowedCN: []string{"client"},
+	}
+	clientCd := &Credential{
+		CAPath:   filepath.Join(testCertDir, "ca.pem"),
+		CertPath: filepath.Join(testCertDir, "client.pem"),
+		KeyPath:  filepath.Join(testCertDir, "client-key.pem"),
+	}
+	copyCertificate(t, "ca.pem", serverCd.CAPath, now)
+	copyCertificate(t, "server.pem", serverCd.CertPath, now)
+	copyCertificate(t, "server-key.pem", serverCd.KeyPath, now)
+
+	serverCfg, err := serverCd.ToTLSConfigWithVerify()
+	require.Nil(t, err)
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", serverCfg)
+	require.Nil(t, err)
+	defer lis.Close()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			conn.Close()
+		}
+	}()
+
+	handshake := func(cd *Credential) error {
+		clientCfg, err := cd.ToTLSConfig()
+		require.Nil(t, err)
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", lis.Addr().String(), clientCfg)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		// the server verifies the certificate of the client after the client finishes the handshake.
+		_, err = conn.Read(make([]byte, 1))
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	require.Nil(t, handshake(clientCd))
+
+	// the client without a certificate is rejected
+	require.NotNil(t, handshake(&Credential{CAPath: clientCd.CAPath}))
+
+	// the client is rejected if the CA is rotated to another one which doesn't sign its certificate
+	copyCertificate(t, "server.pem", serverCd.CAPath, now.Add(time.Minute))
+	require.NotNil(t, handshake(clientCd))
+	copyCertificate(t, "ca.pem", serverCd.CAPath, now.Add(2*time.Minute))
+	require.Nil(t, handshake(clientCd))
+}
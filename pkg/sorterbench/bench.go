@@ -0,0 +1,393 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sorterbench
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/cdc/sorter"
+	"github.com/pingcap/ticdc/cdc/sorter/memory"
+	"github.com/pingcap/ticdc/cdc/sorter/unified"
+	"github.com/pingcap/ticdc/pkg/config"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+)
+
+const (
+	benchChangefeedID = "sorter-bench"
+	// diskUsageSampleInterval is the interval of sampling the disk usage of
+	// the sort dir.
+	diskUsageSampleInterval = 100 * time.Millisecond
+)
+
+// Report summarizes a run of the Bench.
+type Report struct {
+	Engine       model.SortEngine
+	Events       uint64
+	Transactions uint64
+	// Elapsed is the duration from the first event is generated to the last
+	// event is output by the sorters.
+	Elapsed time.Duration
+	// The latencies are the durations from a resolved event is sent to a
+	// sorter to the resolved ts is output, that is, all the events before the
+	// resolved ts are sorted.
+	LatencyP50 time.Duration
+	LatencyP99 time.Duration
+	LatencyMax time.Duration
+	// PeakDiskUsage is the peak size of the files in the sort dir in bytes.
+	PeakDiskUsage int64
+}
+
+// Throughput returns the number of the events sorted per second.
+func (r *Report) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Events) / r.Elapsed.Seconds()
+}
+
+// String implements fmt.Stringer
+func (r *Report) String() string {
+	return fmt.Sprintf("engine: %s, events: %d, transactions: %d, elapsed: %s, throughput: %.0f events/s, "+
+		"resolved latency p50: %s, p99: %s, max: %s, peak disk usage: %s",
+		r.Engine, r.Events, r.Transactions, r.Elapsed, r.Throughput(),
+		r.LatencyP50, r.LatencyP99, r.LatencyMax, units.BytesSize(float64(r.PeakDiskUsage)))
+}
+
+// Bench generates synthetic events against a sort engine, and measures the
+// throughput, the latency and the disk usage of the sort engine.
+type Bench struct {
+	cfg *Config
+}
+
+// New creates a new Bench.
+func New(cfg *Config) (*Bench, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Bench{cfg: cfg}, nil
+}
+
+// resolvedMark records when a resolved ts is sent to the sorter.
+type resolvedMark struct {
+	ts     uint64
+	sentAt time.Time
+}
+
+// table is a table under test, which has its own sorter like the tables in
+// the processor.
+type table struct {
+	id     model.TableID
+	sorter sorter.EventSorter
+	keys   func() uint64
+
+	mu      sync.Mutex
+	pending []resolvedMark
+
+	// the fields below are only accessed by the consumer of the table
+	events    uint64
+	latencies []time.Duration
+}
+
+// sendResolved sends the resolved ts to the sorter, and records it to
+// measure the latency.
+func (t *table) sendResolved(ctx context.Context, ts uint64) {
+	t.mu.Lock()
+	t.pending = append(t.pending, resolvedMark{ts: ts, sentAt: time.Now()})
+	t.mu.Unlock()
+	t.sorter.AddEntry(ctx, model.NewResolvedPolymorphicEvent(0, ts))
+}
+
+// consume reads the sorted events of the table until the resolved ts reaches
+// the finalTs.
+func (t *table) consume(ctx context.Context, finalTs uint64) error {
+	var lastTs uint64
+	for {
+		var event *model.PolymorphicEvent
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case event = <-t.sorter.Output():
+		}
+		if event.CRTs < lastTs {
+			return errors.Errorf("table %d outputs the event with commit ts %d after %d", t.id, event.CRTs, lastTs)
+		}
+		lastTs = event.CRTs
+		if event.RawKV.OpType != model.OpTypeResolved {
+			t.events++
+			continue
+		}
+		now := time.Now()
+		t.mu.Lock()
+		i := 0
+		for ; i < len(t.pending) && t.pending[i].ts <= event.CRTs; i++ {
+			t.latencies = append(t.latencies, now.Sub(t.pending[i].sentAt))
+		}
+		t.pending = t.pending[i:]
+		t.mu.Unlock()
+		if event.CRTs >= finalTs {
+			return nil
+		}
+	}
+}
+
+// Run runs the benchmark, and returns the report even if it is interrupted.
+// The unified sorter is configured through the global server config, so only
+// one Bench should be run at a time.
+func (b *Bench) Run(ctx context.Context) (*Report, error) {
+	if b.cfg.Engine != model.SortInMemory {
+		if err := b.prepareSortDir(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer unified.CleanUp()
+	}
+	tables := make([]*table, b.cfg.NumTables)
+	for i := range tables {
+		t, err := b.newTable(model.TableID(i + 1))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tables[i] = t
+	}
+	numTxns := (b.cfg.NumEvents + b.cfg.TxnSize - 1) / b.cfg.TxnSize
+	finalTs := commitTsOf(numTxns)
+
+	var peakDiskUsage int64
+	start := time.Now()
+	errg, ctx := errgroup.WithContext(ctx)
+	sorterCtx, cancelSorters := context.WithCancel(ctx)
+	defer cancelSorters()
+	for _, t := range tables {
+		t := t
+		errg.Go(func() error {
+			err := t.sorter.Run(sorterCtx)
+			if errors.Cause(err) == context.Canceled {
+				return nil
+			}
+			return errors.Trace(err)
+		})
+	}
+	if b.cfg.Engine != model.SortInMemory {
+		errg.Go(func() error {
+			err := unified.RunWorkerPool(sorterCtx)
+			if errors.Cause(err) == context.Canceled {
+				return nil
+			}
+			return errors.Trace(err)
+		})
+		errg.Go(func() error {
+			peakDiskUsage = b.sampleDiskUsage(sorterCtx)
+			return nil
+		})
+	}
+	var elapsed time.Duration
+	errg.Go(func() error {
+		// the sorters are stopped after all the events are output
+		defer cancelSorters()
+		workers, ctx := errgroup.WithContext(sorterCtx)
+		for _, t := range tables {
+			t := t
+			workers.Go(func() error {
+				return t.consume(ctx, finalTs)
+			})
+		}
+		workers.Go(func() error {
+			return b.generate(ctx, tables, numTxns)
+		})
+		err := workers.Wait()
+		elapsed = time.Since(start)
+		return err
+	})
+	err := errg.Wait()
+
+	report := &Report{
+		Engine:        b.cfg.Engine,
+		Elapsed:       elapsed,
+		PeakDiskUsage: peakDiskUsage,
+	}
+	var latencies []time.Duration
+	for _, t := range tables {
+		report.Events += t.events
+		latencies = append(latencies, t.latencies...)
+	}
+	report.Transactions = (report.Events + uint64(b.cfg.TxnSize) - 1) / uint64(b.cfg.TxnSize)
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		report.LatencyP50 = latencies[len(latencies)*50/100]
+		report.LatencyP99 = latencies[len(latencies)*99/100]
+		report.LatencyMax = latencies[len(latencies)-1]
+	}
+	return report, errors.Trace(err)
+}
+
+// prepareSortDir creates the sort dir and configures the unified sorter. The
+// sort dir is also used as the data dir, whose disk is checked before the
+// unified sorter starts.
+func (b *Bench) prepareSortDir() error {
+	conf := config.GetGlobalServerConfig().Clone()
+	conf.DataDir = b.cfg.SortDir
+	conf.Sorter.SortDir = b.cfg.SortDir
+	conf.Sorter.MaxMemoryPressure = b.cfg.MaxMemoryPressure
+	config.StoreGlobalServerConfig(conf)
+	return errors.Trace(unified.CheckDir(b.cfg.SortDir))
+}
+
+func (b *Bench) newTable(tableID model.TableID) (*table, error) {
+	tableName := fmt.Sprintf("`bench`.`t%d`", tableID)
+	newUnifiedSorter := func() (sorter.EventSorter, error) {
+		return unified.NewUnifiedSorter(b.cfg.SortDir, benchChangefeedID, tableName, tableID, "")
+	}
+	t := &table{id: tableID, keys: b.newKeyGenerator(tableID)}
+	switch b.cfg.Engine {
+	case model.SortInMemory:
+		t.sorter = memory.NewEntrySorter()
+	case model.SortLowLatency:
+		t.sorter = memory.NewFallbackSorter(config.DefaultLowLatencyMemoryQuota, newUnifiedSorter)
+	default:
+		s, err := newUnifiedSorter()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		t.sorter = s
+	}
+	return t, nil
+}
+
+func (b *Bench) newKeyGenerator(tableID model.TableID) func() uint64 {
+	r := rand.New(rand.NewSource(tableID))
+	switch b.cfg.KeyDistribution {
+	case KeyDistributionZipf:
+		zipf := rand.NewZipf(r, 1.1, 1, uint64(b.cfg.NumKeys-1))
+		return zipf.Uint64
+	case KeyDistributionSequential:
+		var next uint64
+		return func() uint64 {
+			key := next
+			next = (next + 1) % uint64(b.cfg.NumKeys)
+			return key
+		}
+	default:
+		return func() uint64 {
+			return uint64(r.Int63n(int64(b.cfg.NumKeys)))
+		}
+	}
+}
+
+// commitTsOf returns the commit ts of the i-th transaction, which leaves
+// room for the start ts before it.
+func commitTsOf(i int) uint64 {
+	return uint64(i+1) * 2
+}
+
+// generate sends the transactions to the tables in turn, and the resolved
+// events to all the tables every resolved interval. The final resolved event
+// covers all the transactions.
+func (b *Bench) generate(ctx context.Context, tables []*table, numTxns int) error {
+	var limiter *rate.Limiter
+	if b.cfg.Rate > 0 {
+		limiter = rate.NewLimiter(rate.Limit(b.cfg.Rate), b.cfg.TxnSize)
+	}
+	value := make([]byte, b.cfg.ValueSize)
+	rand.Read(value) //nolint:gosec
+	ticker := time.NewTicker(b.cfg.ResolvedInterval)
+	defer ticker.Stop()
+
+	var resolvedTs uint64
+	remaining := b.cfg.NumEvents
+	for i := 0; i < numTxns; i++ {
+		select {
+		case <-ticker.C:
+			for _, t := range tables {
+				t.sendResolved(ctx, resolvedTs)
+			}
+		default:
+		}
+		txnSize := b.cfg.TxnSize
+		if remaining < txnSize {
+			txnSize = remaining
+		}
+		if limiter != nil {
+			if err := limiter.WaitN(ctx, txnSize); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		t := tables[i%len(tables)]
+		commitTs := commitTsOf(i)
+		for j := 0; j < txnSize; j++ {
+			t.sorter.AddEntry(ctx, model.NewPolymorphicEvent(&model.RawKVEntry{
+				OpType:  model.OpTypePut,
+				Key:     []byte(fmt.Sprintf("t%d_r%d", t.id, t.keys())),
+				Value:   value,
+				StartTs: commitTs - 1,
+				CRTs:    commitTs,
+			}))
+		}
+		if err := ctx.Err(); err != nil {
+			return errors.Trace(err)
+		}
+		remaining -= txnSize
+		resolvedTs = commitTs
+	}
+	for _, t := range tables {
+		t.sendResolved(ctx, commitTsOf(numTxns))
+	}
+	log.Info("all the events are generated", zap.Int("events", b.cfg.NumEvents), zap.Int("transactions", numTxns))
+	return errors.Trace(ctx.Err())
+}
+
+// sampleDiskUsage samples the size of the files in the sort dir until the
+// context is done, and returns the peak size. The last sample is taken after
+// the context is done, before the files are cleaned up.
+func (b *Bench) sampleDiskUsage(ctx context.Context) int64 {
+	ticker := time.NewTicker(diskUsageSampleInterval)
+	defer ticker.Stop()
+	var peak int64
+	for {
+		if usage := dirSize(b.cfg.SortDir); usage > peak {
+			peak = usage
+		}
+		if ctx.Err() != nil {
+			return peak
+		}
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+}
+
+// dirSize returns the total size of the files in the dir, the files removed
+// while walking the dir are ignored.
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sorterbench
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(t *testing.T, engine model.SortEngine) *Config {
+	cfg := NewConfig()
+	cfg.Engine = engine
+	cfg.SortDir = t.TempDir()
+	cfg.NumTables = 4
+	cfg.NumEvents = 10000
+	cfg.NumKeys = 100
+	cfg.TxnSize = 7
+	cfg.ResolvedInterval = 10 * time.Millisecond
+	return cfg
+}
+
+func TestBench(t *testing.T) {
+	defer config.StoreGlobalServerConfig(config.GetGlobalServerConfig())
+
+	for _, engine := range []model.SortEngine{model.SortInMemory, model.SortUnified, model.SortLowLatency} {
+		cfg := newTestConfig(t, engine)
+		if engine == model.SortUnified {
+			// force the unified sorter to spill the data to the disk
+			cfg.MaxMemoryPressure = 0
+			cfg.KeyDistribution = KeyDistributionZipf
+		}
+		b, err := New(cfg)
+		require.Nil(t, err)
+		report, err := b.Run(context.Background())
+		require.Nil(t, err, engine)
+		require.Equal(t, uint64(10000), report.Events, engine)
+		require.Equal(t, uint64(1429), report.Transactions, engine)
+		require.Greater(t, report.Throughput(), float64(0))
+		require.Greater(t, report.LatencyMax, time.Duration(0))
+		require.LessOrEqual(t, report.LatencyP50, report.LatencyP99)
+		if engine == model.SortUnified {
+			require.Greater(t, report.PeakDiskUsage, int64(0))
+		}
+		require.Contains(t, report.String(), "engine: "+engine)
+	}
+}
+
+func TestBenchRate(t *testing.T) {
+	cfg := newTestConfig(t, model.SortInMemory)
+	cfg.NumEvents = 1000
+	cfg.Rate = 5000
+	cfg.TxnSize = 10
+	cfg.KeyDistribution = KeyDistributionSequential
+	b, err := New(cfg)
+	require.Nil(t, err)
+	report, err := b.Run(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, uint64(1000), report.Events)
+	// the first burst is sent without waiting
+	require.GreaterOrEqual(t, report.Elapsed, 190*time.Millisecond)
+}
+
+func TestBenchCanceled(t *testing.T) {
+	cfg := newTestConfig(t, model.SortInMemory)
+	cfg.Rate = 100
+	b, err := New(cfg)
+	require.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	report, err := b.Run(ctx)
+	require.Regexp(t, "context canceled", err)
+	require.Less(t, report.Events, uint64(10000))
+}
+
+func TestValidate(t *testing.T) {
+	cfg := NewConfig()
+	require.Nil(t, cfg.Validate())
+	cfg.Engine = "leveldb"
+	require.Regexp(t, "unsupported sort engine leveldb", cfg.Validate())
+
+	cfg = NewConfig()
+	cfg.KeyDistribution = "normal"
+	require.Regexp(t, "unknown key distribution normal", cfg.Validate())
+
+	cfg = NewConfig()
+	cfg.SortDir = ""
+	require.Regexp(t, "sort-dir must be specified", cfg.Validate())
+	cfg.Engine = model.SortInMemory
+	require.Nil(t, cfg.Validate())
+
+	cfg = NewConfig()
+	cfg.TxnSize = 0
+	require.Regexp(t, "txn-size should be positive", cfg.Validate())
+
+	cfg = NewConfig()
+	cfg.ResolvedInterval = 0
+	require.Regexp(t, "resolved-interval should be positive", cfg.Validate())
+}
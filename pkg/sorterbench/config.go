@@ -0,0 +1,123 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sorterbench
+
+import (
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/cdc/model"
+	"github.com/pingcap/ticdc/pkg/config"
+)
+
+// The distributions of the keys generated in a table.
+const (
+	// KeyDistributionUniform picks the keys uniformly at random.
+	KeyDistributionUniform = "uniform"
+	// KeyDistributionZipf picks the keys following a zipf distribution, so
+	// that a few keys are updated frequently.
+	KeyDistributionZipf = "zipf"
+	// KeyDistributionSequential generates the keys in ascending order, like
+	// the inserts to an auto increment primary key.
+	KeyDistributionSequential = "sequential"
+)
+
+// Config is the configuration of the Bench.
+type Config struct {
+	// Engine is the sort engine under test.
+	Engine model.SortEngine
+	// SortDir is the directory used by the unified sorter.
+	SortDir string
+	// MaxMemoryPressure is the memory pressure in percentage above which the
+	// unified sorter spills the data to the disk.
+	MaxMemoryPressure int
+
+	// NumTables is the number of the tables, each table has its own sorter.
+	NumTables int
+	// NumEvents is the number of the row events generated.
+	NumEvents int
+	// Rate is the number of the row events generated per second in total, 0
+	// means as fast as possible.
+	Rate int
+	// TxnSize is the number of the row events in a transaction, the events of
+	// a transaction share the same commit ts.
+	TxnSize int
+	// KeyDistribution is the distribution of the keys generated in a table.
+	KeyDistribution string
+	// NumKeys is the number of the distinct keys in a table.
+	NumKeys int
+	// ValueSize is the size of the value of a row event in bytes.
+	ValueSize int
+	// ResolvedInterval is the interval of the resolved events sent to the
+	// sorters.
+	ResolvedInterval time.Duration
+}
+
+// NewConfig creates a Config with the default values.
+func NewConfig() *Config {
+	return &Config{
+		Engine:            model.SortUnified,
+		SortDir:           config.DefaultSortDir,
+		MaxMemoryPressure: config.GetDefaultServerConfig().Sorter.MaxMemoryPressure,
+		NumTables:         16,
+		NumEvents:         1000000,
+		TxnSize:           10,
+		KeyDistribution:   KeyDistributionUniform,
+		NumKeys:           100000,
+		ValueSize:         256,
+		ResolvedInterval:  100 * time.Millisecond,
+	}
+}
+
+// Validate checks the configuration.
+func (c *Config) Validate() error {
+	switch c.Engine {
+	case model.SortInMemory, model.SortUnified, model.SortLowLatency:
+	default:
+		return errors.Errorf("unsupported sort engine %s", c.Engine)
+	}
+	switch c.KeyDistribution {
+	case KeyDistributionUniform, KeyDistributionZipf, KeyDistributionSequential:
+	default:
+		return errors.Errorf("unknown key distribution %s", c.KeyDistribution)
+	}
+	if c.Engine != model.SortInMemory && c.SortDir == "" {
+		return errors.New("sort-dir must be specified")
+	}
+	if c.MaxMemoryPressure < 0 || c.MaxMemoryPressure > 100 {
+		return errors.New("max-memory-pressure should be in [0, 100]")
+	}
+	if c.NumTables <= 0 {
+		return errors.New("tables should be positive")
+	}
+	if c.NumEvents <= 0 {
+		return errors.New("events should be positive")
+	}
+	if c.Rate < 0 {
+		return errors.New("rate should not be negative")
+	}
+	if c.TxnSize <= 0 {
+		return errors.New("txn-size should be positive")
+	}
+	if c.NumKeys <= 0 {
+		return errors.New("keys should be positive")
+	}
+	if c.ValueSize < 0 {
+		return errors.New("value-size should not be negative")
+	}
+	if c.ResolvedInterval <= 0 {
+		return errors.New("resolved-interval should be positive")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	ctx := context.Background()
+	requests := make(chan *otlpRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		data, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		req := &otlpRequest{}
+		require.Nil(t, json.Unmarshal(data, req))
+		requests <- req
+	}))
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer(instrumentationName)
+	spanCtx, parent := tracer.Start(ctx, EventSpanName)
+	parent.SetAttributes(ChangefeedKey.String("test"), TableIDKey.Int64(42))
+	_, child := tracer.Start(spanCtx, "sort")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.End()
+
+	exporter := newOTLPExporter(server.URL)
+	require.Nil(t, exporter.ExportSpans(ctx, recorder.Ended()))
+	req := <-requests
+	require.Len(t, req.ResourceSpans, 1)
+	require.Len(t, req.ResourceSpans[0].ScopeSpans, 1)
+	scopeSpans := req.ResourceSpans[0].ScopeSpans[0]
+	require.Equal(t, instrumentationName, scopeSpans.Scope.Name)
+	require.Len(t, scopeSpans.Spans, 2)
+
+	sortSpan, eventSpan := scopeSpans.Spans[0], scopeSpans.Spans[1]
+	require.Equal(t, "sort", sortSpan.Name)
+	require.Equal(t, eventSpan.TraceID, sortSpan.TraceID)
+	require.Equal(t, eventSpan.SpanID, sortSpan.ParentSpanID)
+	require.Equal(t, 2, sortSpan.Status.Code)
+	require.Equal(t, "failed", sortSpan.Status.Message)
+	require.Equal(t, EventSpanName, eventSpan.Name)
+	require.Empty(t, eventSpan.ParentSpanID)
+	require.Len(t, eventSpan.Attributes, 2)
+	require.Equal(t, "test", *eventSpan.Attributes[0].Value.StringValue)
+	require.Equal(t, "42", *eventSpan.Attributes[1].Value.IntValue)
+	require.Nil(t, exporter.Shutdown(ctx))
+
+	// the failed exports are reported.
+	failed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failed.Close()
+	exporter = newOTLPExporter(failed.URL)
+	require.Regexp(t, ".*failed to export spans.*400.*", exporter.ExportSpans(ctx, recorder.Ended()))
+	require.Nil(t, exporter.Shutdown(ctx))
+}
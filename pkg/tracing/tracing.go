@@ -0,0 +1,115 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/config"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	serviceName         = "ticdc"
+	instrumentationName = "github.com/pingcap/ticdc"
+)
+
+// Attribute keys of spans
+const (
+	CaptureKey    = attribute.Key("ticdc.capture")
+	ChangefeedKey = attribute.Key("ticdc.changefeed")
+	TableIDKey    = attribute.Key("ticdc.table.id")
+	TableNameKey  = attribute.Key("ticdc.table.name")
+	CommitTsKey   = attribute.Key("ticdc.commit-ts")
+	ResolvedTsKey = attribute.Key("ticdc.resolved-ts")
+)
+
+// EventSpanName is the name of the root spans of the row changed events, the
+// events are sampled by the table pipelines.
+const EventSpanName = "event"
+
+// Tracer returns the tracer of TiCDC. Spans created by the tracer are dropped
+// if tracing is not enabled.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup registers the global tracer provider which exports spans by the
+// exporter configured. The returned function flushes the pending spans and
+// shuts down the tracer provider.
+func Setup(cfg *config.TracingConfig, captureAddr string) (func(context.Context) error, error) {
+	if !cfg.IsEnabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch cfg.Exporter {
+	case config.TracingExporterJaeger:
+		exporter, err = jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(cfg.Endpoint)))
+	case config.TracingExporterOTLP:
+		exporter = newOTLPExporter(cfg.Endpoint)
+	default:
+		return nil, cerror.ErrInvalidServerOption.GenWithStack("unknown tracing exporter %s", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler{ratio: sdktrace.TraceIDRatioBased(cfg.SampleRatio)})),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(serviceName),
+			CaptureKey.String(captureAddr),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	log.Info("tracing is enabled",
+		zap.String("exporter", cfg.Exporter),
+		zap.String("endpoint", cfg.Endpoint),
+		zap.Float64("sampleRatio", cfg.SampleRatio))
+	return provider.Shutdown, nil
+}
+
+// sampler samples the root spans by the ratio, except the spans of the row
+// changed events, which have been sampled by the table pipelines by the same
+// ratio already.
+type sampler struct {
+	ratio sdktrace.Sampler
+}
+
+// ShouldSample implements sdktrace.Sampler.
+func (s sampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if p.Name == EventSpanName {
+		return sdktrace.AlwaysSample().ShouldSample(p)
+	}
+	return s.ratio.ShouldSample(p)
+}
+
+// Description implements sdktrace.Sampler.
+func (s sampler) Description() string {
+	return "TiCDCSampler{" + s.ratio.Description() + "}"
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+	shutdown, err := Setup(&config.TracingConfig{}, "127.0.0.1:8300")
+	require.Nil(t, err)
+	require.Nil(t, shutdown(ctx))
+	_, span := Tracer().Start(ctx, "test")
+	require.False(t, span.IsRecording())
+	span.End()
+
+	_, err = Setup(&config.TracingConfig{Exporter: "zipkin"}, "127.0.0.1:8300")
+	require.Regexp(t, ".*unknown tracing exporter zipkin", err)
+
+	shutdown, err = Setup(&config.TracingConfig{
+		Exporter:    config.TracingExporterJaeger,
+		Endpoint:    "http://127.0.0.1:14268/api/traces",
+		SampleRatio: 1,
+	}, "127.0.0.1:8300")
+	require.Nil(t, err)
+	_, span = Tracer().Start(ctx, "test")
+	require.True(t, span.IsRecording())
+	span.End()
+	// Exporting to the collector fails as there is no collector, the spans
+	// are dropped.
+	_ = shutdown(ctx)
+
+	shutdown, err = Setup(&config.TracingConfig{
+		Exporter:    config.TracingExporterOTLP,
+		Endpoint:    "http://127.0.0.1:4318/v1/traces",
+		SampleRatio: 0,
+	}, "127.0.0.1:8300")
+	require.Nil(t, err)
+	// the event spans are sampled by the table pipelines, they are always
+	// recorded while the other spans are sampled by the ratio.
+	_, span = Tracer().Start(ctx, "test")
+	require.False(t, span.IsRecording())
+	span.End()
+	spanCtx, span := Tracer().Start(ctx, EventSpanName)
+	require.True(t, span.IsRecording())
+	_, stageSpan := Tracer().Start(spanCtx, "sort")
+	require.True(t, stageSpan.IsRecording())
+	stageSpan.End()
+	span.End()
+	_ = shutdown(ctx)
+}
@@ -44,7 +44,10 @@ type Manager interface {
 	// Set `forceUpdate` to force Manager update.
 	TryUpdateGCSafePoint(ctx context.Context, checkpointTs model.Ts, forceUpdate bool) error
 	CurrentTimeFromPDCached(ctx context.Context) (time.Time, error)
-	CheckStaleCheckpointTs(ctx context.Context, changefeedID model.ChangeFeedID, checkpointTs model.Ts) error
+	// CheckStaleCheckpointTs checks whether the checkpoint of the changefeed
+	// blocks the GC longer than gcTTL seconds, 0 means the gc-ttl of the
+	// server, or the snapshot at the checkpoint has been garbage collected.
+	CheckStaleCheckpointTs(ctx context.Context, changefeedID model.ChangeFeedID, checkpointTs model.Ts, gcTTL int64) error
 }
 
 type gcManager struct {
@@ -81,6 +84,8 @@ func (m *gcManager) TryUpdateGCSafePoint(
 		return nil
 	}
 	m.lastUpdatedTime = time.Now()
+	// gc-ttl may be changed by reloading the server config.
+	m.gcTTL = config.GetGlobalServerConfig().GcTTL
 
 	actual, err := setServiceGCSafepoint(
 		ctx, m.pdClient, CDCServiceSafePointID, m.gcTTL, checkpointTs)
@@ -125,15 +130,18 @@ func (m *gcManager) CurrentTimeFromPDCached(ctx context.Context) (time.Time, err
 }
 
 func (m *gcManager) CheckStaleCheckpointTs(
-	ctx context.Context, changefeedID model.ChangeFeedID, checkpointTs model.Ts,
+	ctx context.Context, changefeedID model.ChangeFeedID, checkpointTs model.Ts, gcTTL int64,
 ) error {
+	if gcTTL == 0 {
+		gcTTL = m.gcTTL
+	}
 	gcSafepointUpperBound := checkpointTs - 1
 	if m.isTiCDCBlockGC {
 		pdTime, err := m.CurrentTimeFromPDCached(ctx)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		if pdTime.Sub(oracle.GetTimeFromTS(gcSafepointUpperBound)) > time.Duration(m.gcTTL)*time.Second {
+		if pdTime.Sub(oracle.GetTimeFromTS(gcSafepointUpperBound)) > time.Duration(gcTTL)*time.Second {
 			return cerror.ErrGCTTLExceeded.GenWithStackByArgs(checkpointTs, changefeedID)
 		}
 	} else {
@@ -120,16 +120,23 @@ func (s *gcManagerSuite) TestCheckStaleCheckpointTs(c *check.C) {
 	gcManager := NewManager(mockPDClient).(*gcManager)
 	gcManager.isTiCDCBlockGC = true
 	ctx := context.Background()
-	err := gcManager.CheckStaleCheckpointTs(ctx, "cfID", 10)
+	err := gcManager.CheckStaleCheckpointTs(ctx, "cfID", 10, 0)
 	c.Assert(cerror.ErrGCTTLExceeded.Equal(errors.Cause(err)), check.IsTrue)
 	c.Assert(cerror.ChangefeedFastFailError(err), check.IsTrue)
 
-	err = gcManager.CheckStaleCheckpointTs(ctx, "cfID", oracle.GoTimeToTS(time.Now()))
+	err = gcManager.CheckStaleCheckpointTs(ctx, "cfID", oracle.GoTimeToTS(time.Now()), 0)
 	c.Assert(err, check.IsNil)
 
+	// the gc ttl of the changefeed takes precedence over the server's
+	checkpointTs := oracle.GoTimeToTS(time.Now().Add(-time.Hour))
+	err = gcManager.CheckStaleCheckpointTs(ctx, "cfID", checkpointTs, 0)
+	c.Assert(err, check.IsNil)
+	err = gcManager.CheckStaleCheckpointTs(ctx, "cfID", checkpointTs, 60)
+	c.Assert(cerror.ErrGCTTLExceeded.Equal(errors.Cause(err)), check.IsTrue)
+
 	gcManager.isTiCDCBlockGC = false
 	gcManager.lastSafePointTs = 20
-	err = gcManager.CheckStaleCheckpointTs(ctx, "cfID", 10)
+	err = gcManager.CheckStaleCheckpointTs(ctx, "cfID", 10, 0)
 	c.Assert(cerror.ErrSnapshotLostByGC.Equal(errors.Cause(err)), check.IsTrue)
 	c.Assert(cerror.ChangefeedFastFailError(err), check.IsTrue)
 }
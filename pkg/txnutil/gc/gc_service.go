@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/log"
 	cerrors "github.com/pingcap/ticdc/pkg/errors"
 	"github.com/pingcap/ticdc/pkg/retry"
+	"github.com/pingcap/ticdc/pkg/util"
 	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
 )
@@ -28,6 +29,9 @@ import (
 const (
 	// cdcChangefeedCreatingServiceGCSafePointID is service GC safe point ID
 	cdcChangefeedCreatingServiceGCSafePointID = "ticdc-creating-"
+	// cdcPrecheckServiceGCSafePointID is the service GC safe point ID used to
+	// query the minimum service GC safe point, it is never set actually.
+	cdcPrecheckServiceGCSafePointID = "ticdc-precheck"
 )
 
 // EnsureChangefeedStartTsSafety checks if the startTs less than the minimum of
@@ -41,11 +45,24 @@ func EnsureChangefeedStartTsSafety(
 		return errors.Trace(err)
 	}
 	if startTs < minServiceGCTs {
-		return cerrors.ErrStartTsBeforeGC.GenWithStackByArgs(startTs, minServiceGCTs)
+		return cerrors.ErrStartTsBeforeGC.GenWithStackByArgs(
+			startTs, util.FormatTs(startTs), minServiceGCTs, util.FormatTs(minServiceGCTs))
 	}
 	return nil
 }
 
+// GetMinServiceGCSafepoint returns the minimum service GC safepoint without
+// blocking GC, which is the safepoint that the start ts must not be less than.
+func GetMinServiceGCSafepoint(ctx context.Context, pdCli pd.Client) (uint64, error) {
+	// Set TTL to 0 second to make sure no service safe point is left.
+	minServiceGCTs, err := setServiceGCSafepoint(
+		ctx, pdCli, cdcPrecheckServiceGCSafePointID, 0, math.MaxUint64)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return minServiceGCTs, nil
+}
+
 // PD leader switch may happen, so just gcServiceMaxRetries it.
 // The default PD election timeout is 3 seconds. Triple the timeout as
 // retry time to make sure PD leader can be elected during retry.
@@ -39,7 +39,7 @@ func (s *gcServiceSuite) TestCheckSafetyOfStartTs(c *check.C) {
 	// assume no pd leader switch
 	s.pdCli.UpdateServiceGCSafePoint(ctx, "service1", 10, 60) //nolint:errcheck
 	err := EnsureChangefeedStartTsSafety(ctx, s.pdCli, "changefeed1", TTL, 50)
-	c.Assert(err.Error(), check.Equals, "[CDC:ErrStartTsBeforeGC]fail to create changefeed because start-ts 50 is earlier than GC safepoint at 60")
+	c.Assert(err.Error(), check.Equals, "[CDC:ErrStartTsBeforeGC]fail to create changefeed because start-ts 50 (1970-01-01 00:00:00.000 UTC) is earlier than GC safepoint at 60 (1970-01-01 00:00:00.000 UTC)")
 	s.pdCli.UpdateServiceGCSafePoint(ctx, "service2", 10, 80) //nolint:errcheck
 	s.pdCli.UpdateServiceGCSafePoint(ctx, "service3", 10, 70) //nolint:errcheck
 	err = EnsureChangefeedStartTsSafety(ctx, s.pdCli, "changefeed2", TTL, 65)
@@ -67,7 +67,23 @@ func (s *gcServiceSuite) TestCheckSafetyOfStartTs(c *check.C) {
 	s.pdCli.retryThreshold = 3
 	s.pdCli.retryCount = 0
 	err = EnsureChangefeedStartTsSafety(ctx, s.pdCli, "changefeed1", TTL, 50)
-	c.Assert(err.Error(), check.Equals, "[CDC:ErrStartTsBeforeGC]fail to create changefeed because start-ts 50 is earlier than GC safepoint at 60")
+	c.Assert(err.Error(), check.Equals, "[CDC:ErrStartTsBeforeGC]fail to create changefeed because start-ts 50 (1970-01-01 00:00:00.000 UTC) is earlier than GC safepoint at 60 (1970-01-01 00:00:00.000 UTC)")
+}
+
+func (s *gcServiceSuite) TestGetMinServiceGCSafepoint(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := context.Background()
+
+	pdCli := &mockPdClientForServiceGCSafePoint{serviceSafePoint: make(map[string]uint64)}
+	pdCli.UpdateServiceGCSafePoint(ctx, "service1", 10, 60) //nolint:errcheck
+	pdCli.UpdateServiceGCSafePoint(ctx, "service2", 10, 70) //nolint:errcheck
+	safePoint, err := GetMinServiceGCSafepoint(ctx, pdCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(safePoint, check.Equals, uint64(60))
+	// the minimum service GC safepoint is not changed by the query
+	safePoint, err = GetMinServiceGCSafepoint(ctx, pdCli)
+	c.Assert(err, check.IsNil)
+	c.Assert(safePoint, check.Equals, uint64(60))
 }
 
 type mockPdClientForServiceGCSafePoint struct {
@@ -0,0 +1,123 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+// SocketOptions holds the socket-level options of the TCP connections
+// created by sinks, the zero value means the default options of Go and the kernel.
+type SocketOptions struct {
+	// SendBufferSize is the size of the socket send buffer (SO_SNDBUF) in bytes.
+	SendBufferSize int
+	// ReceiveBufferSize is the size of the socket receive buffer (SO_RCVBUF) in bytes.
+	ReceiveBufferSize int
+	// DisableNoDelay enables the Nagle's algorithm, Go sets TCP_NODELAY on
+	// TCP connections by default.
+	DisableNoDelay bool
+	// KeepAlive is the interval between keep-alive probes, 0 means the
+	// default interval of Go, a negative value disables keep-alive probes.
+	KeepAlive time.Duration
+}
+
+// ParseSocketOptions parses the socket options from the query of a sink URI,
+// the supported options are `send-buffer-size`, `receive-buffer-size`,
+// `tcp-nodelay` and `keepalive-interval`.
+func ParseSocketOptions(query url.Values) (*SocketOptions, error) {
+	opts := &SocketOptions{}
+	parseSize := func(key string) (int, error) {
+		s := query.Get(key)
+		if s == "" {
+			return 0, nil
+		}
+		size, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, errors.Annotatef(err, "invalid %s", key)
+		}
+		if size < 0 {
+			return 0, errors.Errorf("%s should not be negative", key)
+		}
+		return size, nil
+	}
+	var err error
+	if opts.SendBufferSize, err = parseSize("send-buffer-size"); err != nil {
+		return nil, err
+	}
+	if opts.ReceiveBufferSize, err = parseSize("receive-buffer-size"); err != nil {
+		return nil, err
+	}
+	if s := query.Get("tcp-nodelay"); s != "" {
+		noDelay, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid tcp-nodelay")
+		}
+		opts.DisableNoDelay = !noDelay
+	}
+	if s := query.Get("keepalive-interval"); s != "" {
+		opts.KeepAlive, err = time.ParseDuration(s)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid keepalive-interval")
+		}
+	}
+	return opts, nil
+}
+
+// IsDefault returns whether all the options are the default options.
+func (o *SocketOptions) IsDefault() bool {
+	return o == nil || *o == SocketOptions{}
+}
+
+// DialContext connects to the address with the socket options applied.
+func (o *SocketOptions) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{KeepAlive: o.KeepAlive}
+	conn, err := dialer.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := o.apply(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (o *SocketOptions) apply(conn net.Conn) error {
+	tcpConn, ok := conn.(*net.TCPConn)
+	if !ok {
+		return nil
+	}
+	if o.SendBufferSize > 0 {
+		if err := tcpConn.SetWriteBuffer(o.SendBufferSize); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if o.ReceiveBufferSize > 0 {
+		if err := tcpConn.SetReadBuffer(o.ReceiveBufferSize); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if o.DisableNoDelay {
+		if err := tcpConn.SetNoDelay(false); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
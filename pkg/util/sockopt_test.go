@@ -0,0 +1,89 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+)
+
+type sockoptSuite struct{}
+
+var _ = check.Suite(&sockoptSuite{})
+
+func (s *sockoptSuite) TestParseSocketOptions(c *check.C) {
+	defer testleak.AfterTest(c)()
+	testCases := []struct {
+		query    string
+		expected *SocketOptions
+		err      string
+	}{
+		{"", &SocketOptions{}, ""},
+		{"worker-count=16", &SocketOptions{}, ""},
+		{"tcp-nodelay=true", &SocketOptions{}, ""},
+		{
+			"send-buffer-size=4194304&receive-buffer-size=1048576&tcp-nodelay=false&keepalive-interval=10s",
+			&SocketOptions{
+				SendBufferSize:    4194304,
+				ReceiveBufferSize: 1048576,
+				DisableNoDelay:    true,
+				KeepAlive:         10 * time.Second,
+			},
+			"",
+		},
+		{"keepalive-interval=-1s", &SocketOptions{KeepAlive: -time.Second}, ""},
+		{"send-buffer-size=4M", nil, ".*invalid send-buffer-size.*"},
+		{"receive-buffer-size=-1", nil, ".*receive-buffer-size should not be negative.*"},
+		{"tcp-nodelay=no", nil, ".*invalid tcp-nodelay.*"},
+		{"keepalive-interval=10", nil, ".*invalid keepalive-interval.*"},
+	}
+	for _, tc := range testCases {
+		query, err := url.ParseQuery(tc.query)
+		c.Assert(err, check.IsNil)
+		opts, err := ParseSocketOptions(query)
+		if tc.err != "" {
+			c.Assert(err, check.ErrorMatches, tc.err)
+			continue
+		}
+		c.Assert(err, check.IsNil)
+		c.Assert(opts, check.DeepEquals, tc.expected)
+		c.Assert(opts.IsDefault(), check.Equals, *tc.expected == SocketOptions{})
+	}
+}
+
+func (s *sockoptSuite) TestSocketOptionsDialContext(c *check.C) {
+	defer testleak.AfterTest(c)()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, check.IsNil)
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+
+	opts := &SocketOptions{SendBufferSize: 1 << 20, ReceiveBufferSize: 1 << 20, DisableNoDelay: true, KeepAlive: time.Second}
+	conn, err := opts.DialContext(context.Background(), "tcp", l.Addr().String())
+	c.Assert(err, check.IsNil)
+	c.Assert(conn.Close(), check.IsNil)
+
+	_, err = opts.DialContext(context.Background(), "tcp", "127.0.0.1:0")
+	c.Assert(err, check.NotNil)
+}
@@ -0,0 +1,110 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	cerror "github.com/pingcap/ticdc/pkg/errors"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+const (
+	// StartTimeFormat is the format of the human-readable start time
+	StartTimeFormat = "2006-01-02 15:04:05"
+	// backupMetaFile is the name of the metadata file in a BR backup
+	backupMetaFile = "backupmeta"
+)
+
+// ResolveStartTs resolves the start ts of a changefeed from one of the start
+// ts, the human-readable start time in the location, and the path of a BR
+// backupmeta file or the backup directory containing it. It returns 0 if none
+// of them is specified, which means the current ts should be used.
+func ResolveStartTs(startTs uint64, startTime, backupMetaPath string, loc *time.Location) (uint64, error) {
+	specified := 0
+	for _, ok := range []bool{startTs != 0, startTime != "", backupMetaPath != ""} {
+		if ok {
+			specified++
+		}
+	}
+	if specified > 1 {
+		return 0, cerror.ErrInvalidStartTs.GenWithStackByArgs(
+			"only one of start-ts, start-time and backup-meta can be specified")
+	}
+	switch {
+	case startTime != "":
+		return ParseStartTime(startTime, loc)
+	case backupMetaPath != "":
+		return ReadBackupEndTs(backupMetaPath)
+	}
+	return startTs, nil
+}
+
+// ParseStartTime parses a start time in the format of StartTimeFormat in the
+// location to a TSO.
+func ParseStartTime(startTime string, loc *time.Location) (uint64, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	t, err := time.ParseInLocation(StartTimeFormat, startTime, loc)
+	if err != nil {
+		return 0, cerror.ErrInvalidStartTs.Wrap(err).GenWithStackByArgs(
+			fmt.Sprintf("start-time %s should be in the format of %s", startTime, StartTimeFormat))
+	}
+	if t.Unix() <= 0 {
+		return 0, cerror.ErrInvalidStartTs.GenWithStackByArgs(
+			fmt.Sprintf("start-time %s should be later than 1970-01-01", startTime))
+	}
+	return oracle.GoTimeToTS(t), nil
+}
+
+// ReadBackupEndTs reads the end ts of a BR backup from its backupmeta file,
+// the path can be the backupmeta file or the backup directory containing it.
+// The data of the backup is consistent at the end ts, so replicating from it
+// doesn't lose or duplicate any change.
+func ReadBackupEndTs(path string) (uint64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, cerror.ErrInvalidStartTs.Wrap(err).GenWithStackByArgs(
+			fmt.Sprintf("failed to read the backupmeta %s", path))
+	}
+	if info.IsDir() {
+		path = filepath.Join(path, backupMetaFile)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, cerror.ErrInvalidStartTs.Wrap(err).GenWithStackByArgs(
+			fmt.Sprintf("failed to read the backupmeta %s", path))
+	}
+	meta := &backuppb.BackupMeta{}
+	if err := meta.Unmarshal(data); err != nil {
+		return 0, cerror.ErrInvalidStartTs.Wrap(err).GenWithStackByArgs(
+			fmt.Sprintf("failed to decode the backupmeta %s, the encrypted backupmeta is not supported", path))
+	}
+	if meta.GetEndVersion() == 0 {
+		return 0, cerror.ErrInvalidStartTs.GenWithStackByArgs(
+			fmt.Sprintf("the backupmeta %s has no end version", path))
+	}
+	return meta.GetEndVersion(), nil
+}
+
+// FormatTs formats the physical time of a TSO in UTC, which makes the error
+// messages about the TSOs readable.
+func FormatTs(ts uint64) string {
+	return oracle.GetTimeFromTS(ts).UTC().Format("2006-01-02 15:04:05.000 UTC")
+}
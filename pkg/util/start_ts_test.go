@@ -0,0 +1,89 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pingcap/check"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	"github.com/pingcap/ticdc/pkg/util/testleak"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+type startTsSuite struct{}
+
+var _ = check.Suite(&startTsSuite{})
+
+func (s *startTsSuite) TestParseStartTime(c *check.C) {
+	defer testleak.AfterTest(c)()
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	c.Assert(err, check.IsNil)
+	ts, err := ParseStartTime("2021-11-01 08:00:00", shanghai)
+	c.Assert(err, check.IsNil)
+	c.Assert(ts, check.Equals, oracle.GoTimeToTS(time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)))
+	c.Assert(FormatTs(ts), check.Equals, "2021-11-01 00:00:00.000 UTC")
+
+	_, err = ParseStartTime("2021-11-01T08:00:00Z", shanghai)
+	c.Assert(err, check.ErrorMatches, ".*should be in the format of 2006-01-02 15:04:05.*")
+	_, err = ParseStartTime("1970-01-01 00:00:00", time.UTC)
+	c.Assert(err, check.ErrorMatches, ".*should be later than 1970-01-01.*")
+}
+
+func (s *startTsSuite) TestReadBackupEndTs(c *check.C) {
+	defer testleak.AfterTest(c)()
+	dir := c.MkDir()
+	meta := &backuppb.BackupMeta{StartVersion: 100, EndVersion: 429000000000000000}
+	data, err := meta.Marshal()
+	c.Assert(err, check.IsNil)
+	c.Assert(os.WriteFile(filepath.Join(dir, "backupmeta"), data, 0o644), check.IsNil)
+
+	// both the file and the directory are accepted
+	ts, err := ReadBackupEndTs(filepath.Join(dir, "backupmeta"))
+	c.Assert(err, check.IsNil)
+	c.Assert(ts, check.Equals, uint64(429000000000000000))
+	ts, err = ReadBackupEndTs(dir)
+	c.Assert(err, check.IsNil)
+	c.Assert(ts, check.Equals, uint64(429000000000000000))
+
+	_, err = ReadBackupEndTs(filepath.Join(dir, "not-exist"))
+	c.Assert(err, check.ErrorMatches, ".*failed to read the backupmeta.*")
+	c.Assert(os.WriteFile(filepath.Join(dir, "invalid"), []byte("invalid"), 0o644), check.IsNil)
+	_, err = ReadBackupEndTs(filepath.Join(dir, "invalid"))
+	c.Assert(err, check.ErrorMatches, ".*failed to decode the backupmeta.*")
+	data, err = (&backuppb.BackupMeta{}).Marshal()
+	c.Assert(err, check.IsNil)
+	c.Assert(os.WriteFile(filepath.Join(dir, "empty"), data, 0o644), check.IsNil)
+	_, err = ReadBackupEndTs(filepath.Join(dir, "empty"))
+	c.Assert(err, check.ErrorMatches, ".*has no end version.*")
+}
+
+func (s *startTsSuite) TestResolveStartTs(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ts, err := ResolveStartTs(0, "", "", nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(ts, check.Equals, uint64(0))
+	ts, err = ResolveStartTs(100, "", "", nil)
+	c.Assert(err, check.IsNil)
+	c.Assert(ts, check.Equals, uint64(100))
+	ts, err = ResolveStartTs(0, "2021-11-01 00:00:00", "", time.UTC)
+	c.Assert(err, check.IsNil)
+	c.Assert(ts, check.Equals, oracle.GoTimeToTS(time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)))
+	_, err = ResolveStartTs(100, "2021-11-01 00:00:00", "", time.UTC)
+	c.Assert(err, check.ErrorMatches, ".*only one of start-ts, start-time and backup-meta can be specified.*")
+	_, err = ResolveStartTs(0, "2021-11-01 00:00:00", "/backup", time.UTC)
+	c.Assert(err, check.ErrorMatches, ".*only one of start-ts, start-time and backup-meta can be specified.*")
+}
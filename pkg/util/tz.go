@@ -14,11 +14,13 @@
 package util
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/pingcap/ticdc/pkg/config"
 	cerror "github.com/pingcap/ticdc/pkg/errors"
 )
 
@@ -63,3 +65,14 @@ func GetLocalTimezone() (*time.Location, error) {
 	}
 	return getTimezoneFromZonefile(str)
 }
+
+// PutChangefeedTimezoneInCtx returns a new child context with the timezone of
+// the changefeed if it's specified by the config, otherwise the timezone of
+// the server in the context is kept.
+func PutChangefeedTimezoneInCtx(ctx context.Context, cfg *config.ReplicaConfig) (context.Context, error) {
+	tz, err := cfg.LoadTimeZone()
+	if err != nil || tz == nil {
+		return ctx, err
+	}
+	return PutTimezoneInCtx(ctx, tz), nil
+}
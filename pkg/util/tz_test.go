@@ -14,7 +14,11 @@
 package util
 
 import (
+	"context"
+	"time"
+
 	"github.com/pingcap/check"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/util/testleak"
 )
 
@@ -45,3 +49,21 @@ func (s *tzSuite) TestGetTimezoneFromZonefile(c *check.C) {
 		}
 	}
 }
+
+func (s *tzSuite) TestPutChangefeedTimezoneInCtx(c *check.C) {
+	defer testleak.AfterTest(c)()
+	ctx := PutTimezoneInCtx(context.Background(), time.UTC)
+	cfg := config.GetDefaultReplicaConfig()
+	cfCtx, err := PutChangefeedTimezoneInCtx(ctx, cfg)
+	c.Assert(err, check.IsNil)
+	c.Assert(TimezoneFromCtx(cfCtx), check.Equals, time.UTC)
+
+	cfg.TimeZone = "Asia/Shanghai"
+	cfCtx, err = PutChangefeedTimezoneInCtx(ctx, cfg)
+	c.Assert(err, check.IsNil)
+	c.Assert(TimezoneFromCtx(cfCtx).String(), check.Equals, "Asia/Shanghai")
+
+	cfg.TimeZone = "Mars/Olympus"
+	_, err = PutChangefeedTimezoneInCtx(ctx, cfg)
+	c.Assert(err, check.ErrorMatches, ".*unknown time zone Mars/Olympus.*")
+}
@@ -1,12 +1,14 @@
 #!/usr/bin/env bash
 
-echo "generate canal & craft benchmark protocol code..."
+echo "generate canal & craft benchmark & admin protocol code..."
 
 [ ! -d ./canal ] && mkdir ./canal
 [ ! -d ./cdclog ] && mkdir ./cdclog
 [ ! -d ./benchmark ] && mkdir ./benchmark
+[ ! -d ./adminpb ] && mkdir ./adminpb
 
 protoc --gofast_out=./canal EntryProtocol.proto
 protoc --gofast_out=./canal CanalProtocol.proto
 protoc --gofast_out=./benchmark CraftBenchmark.proto
 protoc --gofast_out=plugins=grpc:./p2p CDCPeerToPeer.proto
+protoc --gofast_out=plugins=grpc:./adminpb CDCAdmin.proto